@@ -0,0 +1,334 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Review the log of state-changing grctool actions",
+	Long: `Review the log of every state-changing grctool action: syncs, evidence
+generation, writes, submissions, moves and deletions.
+
+Each run of a command that may change data or a GRC system appends an entry
+recording who ran it, on which host, with which arguments, and how it ended.
+Read-only commands (list, status, view, history, ...) are not logged. Secret
+flag values, such as tokens and passwords, are redacted.
+
+Each host appends to its own file under <data_dir>/audit (audit.log_dir), so
+logs mirrored or committed from several machines never conflict. Entries are
+never rewritten, and each records the hash of the one before it, so altered,
+removed or reordered entries are detected.`,
+}
+
+var auditLogCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Show recorded actions",
+	Long: `Show the recorded actions of every host, oldest first. The hash chain of each
+host's log is verified every time, and the command fails when one is broken.
+
+Examples:
+  # Show the latest actions
+  grctool audit log
+
+  # Show failed submissions since the start of the quarter
+  grctool audit log --command "evidence submit" --outcome failed --since 2025-10-01
+
+  # Hand an auditor everything one person did, as JSON
+  grctool audit log --actor alex@example.com --limit 0 --output json`,
+	Args: cobra.NoArgs,
+	RunE: runAuditLog,
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+	auditCmd.AddCommand(auditLogCmd)
+
+	auditLogCmd.Flags().String("since", "", "show actions started on or after this day, YYYY-MM-DD")
+	auditLogCmd.Flags().String("until", "", "show actions started on or before this day, YYYY-MM-DD")
+	auditLogCmd.Flags().String("actor", "", "show actions of this actor")
+	auditLogCmd.Flags().String("host", "", "show actions run on this host")
+	auditLogCmd.Flags().String("command", "", "show runs of this command and its subcommands, e.g. \"evidence\"")
+	auditLogCmd.Flags().String("outcome", "", "show actions that succeeded or failed")
+	auditLogCmd.Flags().Int("limit", 50, "show at most this many of the latest actions (0 for all)")
+}
+
+// unauditedCommands are the top-level commands never logged
+var unauditedCommands = map[string]bool{
+	"help":                          true,
+	"completion":                    true,
+	"version":                       true,
+	cobra.ShellCompRequestCmd:       true,
+	cobra.ShellCompNoDescRequestCmd: true,
+}
+
+// readOnlyCommands are the command names that only read, wherever they
+// appear in the command tree
+var readOnlyCommands = map[string]bool{
+	"calendar":  true,
+	"check":     true,
+	"history":   true,
+	"index":     true,
+	"list":      true,
+	"log":       true,
+	"map":       true,
+	"query":     true,
+	"search":    true,
+	"show":      true,
+	"stale":     true,
+	"stats":     true,
+	"status":    true,
+	"summary":   true,
+	"templates": true,
+	"ui":        true,
+	"validate":  true,
+	"variables": true,
+	"verify":    true,
+	"view":      true,
+}
+
+// sensitiveFlagParts mark the flags whose values are redacted in the log
+var sensitiveFlagParts = []string{"token", "secret", "password", "cookie", "key"}
+
+// auditLog is the structured output of audit log
+type auditLog struct {
+	Chains  []storage.AuditChain `json:"chains"`
+	Total   int                  `json:"total"` // Matching entries before --limit
+	Entries []models.AuditEntry  `json:"entries"`
+}
+
+// auditedCommand reports whether running cmd is recorded in the audit log
+func auditedCommand(cmd *cobra.Command) bool {
+	if cmd == nil || cmd == rootCmd || !cmd.Runnable() {
+		return false
+	}
+	if help, _ := cmd.Flags().GetBool("help"); help {
+		return false
+	}
+	return !unauditedCommands[topLevelCommand(cmd).Name()] && !readOnlyCommands[cmd.Name()]
+}
+
+// auditLogDir returns the directory of the action logs
+func auditLogDir(cfg *config.Config) string {
+	if cfg.Audit.LogDir != "" {
+		return cfg.Audit.LogDir
+	}
+	return filepath.Join(cfg.Storage.DataDir, storage.AuditDir)
+}
+
+// recordAction appends a run of cmd that started at start and ended with
+// err to the audit log. Runs without a configured data directory, such as
+// init before a config exists, are not recorded.
+func recordAction(cmd *cobra.Command, start time.Time, err error) {
+	if !auditedCommand(cmd) {
+		return
+	}
+	cfg, loadErr := config.Load()
+	if loadErr != nil || cfg.Storage.DataDir == "" {
+		return
+	}
+	if appendErr := storage.AppendAuditEntry(auditLogDir(cfg), newAuditEntry(cmd, start, err)); appendErr != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "⚠️  Failed to record action in audit log: %v\n", appendErr)
+	}
+}
+
+// newAuditEntry describes a run of cmd that started at start and ended
+// with err
+func newAuditEntry(cmd *cobra.Command, start time.Time, err error) models.AuditEntry {
+	host, _ := os.Hostname()
+	entry := models.AuditEntry{
+		Timestamp:     start,
+		Actor:         storage.CustodyActor(),
+		Host:          host,
+		Command:       strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()+" "),
+		Args:          auditArgs(cmd),
+		Profile:       config.ActiveProfile(),
+		Version:       version,
+		CorrelationID: correlationID,
+		Outcome:       models.AuditSucceeded,
+		DurationMS:    time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		entry.Outcome = models.AuditFailed
+		entry.Error = err.Error()
+	}
+	return entry
+}
+
+// auditArgs returns the positional arguments of cmd followed by the flags
+// that were set, with the values of secret flags redacted
+func auditArgs(cmd *cobra.Command) []string {
+	args := append([]string{}, cmd.Flags().Args()...)
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		value := flag.Value.String()
+		for _, part := range sensitiveFlagParts {
+			if strings.Contains(flag.Name, part) {
+				value = "[REDACTED]"
+				break
+			}
+		}
+		if flag.Value.Type() == "bool" && value == "true" {
+			args = append(args, "--"+flag.Name)
+			return
+		}
+		args = append(args, fmt.Sprintf("--%s=%s", flag.Name, value))
+	})
+	return args
+}
+
+func runAuditLog(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	since, err := parseDateFlag(cmd, "since")
+	if err != nil {
+		return err
+	}
+	until, err := parseDateFlag(cmd, "until")
+	if err != nil {
+		return err
+	}
+	outcome, _ := cmd.Flags().GetString("outcome")
+	if outcome != "" && outcome != models.AuditSucceeded && outcome != models.AuditFailed {
+		return fmt.Errorf("invalid --outcome %q: use %s or %s", outcome, models.AuditSucceeded, models.AuditFailed)
+	}
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	dir := auditLogDir(cfg)
+	chains, chainErr := storage.VerifyAuditLog(dir)
+	entries, err := storage.ReadAuditLog(dir)
+	if err != nil {
+		return err
+	}
+
+	filter := auditFilter{Outcome: outcome}
+	filter.Actor, _ = cmd.Flags().GetString("actor")
+	filter.Host, _ = cmd.Flags().GetString("host")
+	filter.Command, _ = cmd.Flags().GetString("command")
+	if since != nil {
+		filter.Since = *since
+	}
+	if until != nil {
+		filter.Until = until.AddDate(0, 0, 1)
+	}
+	matching := filterAuditEntries(entries, filter)
+	total := len(matching)
+	if limit > 0 && len(matching) > limit {
+		matching = matching[len(matching)-limit:]
+	}
+
+	if isStructuredOutput(format) {
+		if err := writeStructured(cmd, format, auditLog{Chains: chains, Total: total, Entries: matching}); err != nil {
+			return err
+		}
+	} else {
+		displayAuditLog(cmd, matching, total)
+		if chainErr == nil && len(chains) > 0 {
+			cmd.Println()
+			displayAuditChains(cmd, chains)
+		}
+	}
+	if chainErr != nil {
+		return fmt.Errorf("audit log cannot be trusted: %w", chainErr)
+	}
+	return nil
+}
+
+// auditFilter selects audit entries; zero fields match every entry
+type auditFilter struct {
+	Actor   string
+	Host    string
+	Command string // Matches the command and its subcommands
+	Outcome string
+	Since   time.Time // Inclusive
+	Until   time.Time // Exclusive
+}
+
+// filterAuditEntries returns the entries matching the filter, in order
+func filterAuditEntries(entries []models.AuditEntry, filter auditFilter) []models.AuditEntry {
+	matching := []models.AuditEntry{}
+	for _, entry := range entries {
+		switch {
+		case filter.Actor != "" && !strings.EqualFold(entry.Actor, filter.Actor),
+			filter.Host != "" && !strings.EqualFold(entry.Host, filter.Host),
+			filter.Command != "" && entry.Command != filter.Command && !strings.HasPrefix(entry.Command, filter.Command+" "),
+			filter.Outcome != "" && entry.Outcome != filter.Outcome,
+			!filter.Since.IsZero() && entry.Timestamp.Before(filter.Since),
+			!filter.Until.IsZero() && !entry.Timestamp.Before(filter.Until):
+			continue
+		}
+		matching = append(matching, entry)
+	}
+	return matching
+}
+
+// displayAuditLog lists audit entries as a table
+func displayAuditLog(cmd *cobra.Command, entries []models.AuditEntry, total int) {
+	if len(entries) == 0 {
+		cmd.Println("No recorded actions match")
+		return
+	}
+	if total > len(entries) {
+		cmd.Printf("📜 Showing the latest %d of %d recorded actions\n\n", len(entries), total)
+	} else {
+		cmd.Printf("📜 %d recorded action(s)\n\n", len(entries))
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tACTOR\tHOST\tCOMMAND\tOUTCOME\tDURATION")
+	fmt.Fprintln(w, "----\t-----\t----\t-------\t-------\t--------")
+	for _, entry := range entries {
+		command := strings.TrimSpace(entry.Command + " " + strings.Join(entry.Args, " "))
+		duration := time.Duration(entry.DurationMS) * time.Millisecond
+		if duration >= time.Second {
+			duration = duration.Round(100 * time.Millisecond)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			entry.Timestamp.Local().Format("2006-01-02 15:04:05"), orDash(entry.Actor), orDash(entry.Host), command, entry.Outcome, duration)
+	}
+	w.Flush()
+
+	for _, entry := range entries {
+		if entry.Error != "" {
+			cmd.Printf("\n❌ %s %s: %s\n", entry.Timestamp.Local().Format("2006-01-02 15:04:05"), entry.Command, entry.Error)
+		}
+	}
+}
+
+// displayAuditChains reports the verified hash chain of each host's log
+func displayAuditChains(cmd *cobra.Command, chains []storage.AuditChain) {
+	for _, chain := range chains {
+		cmd.Printf("🔗 %s: hash chain intact, %d entries, head %s\n", chain.Log, chain.Entries, shortSHA256(chain.Head))
+	}
+}
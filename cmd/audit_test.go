@@ -0,0 +1,151 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/models"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Not parallel: other tests run these commands with --help, which stays set
+// and is reset here
+func TestAuditedCommand(t *testing.T) {
+	tests := map[string]struct {
+		cmd  *cobra.Command
+		want bool
+	}{
+		"sync":              {cmd: syncCmd, want: true},
+		"evidence generate": {cmd: evidenceGenerateCmd, want: true},
+		"evidence submit":   {cmd: evidenceSubmitCmd, want: true},
+		"evidence list":     {cmd: evidenceListCmd, want: false},
+		"evidence history":  {cmd: evidenceHistoryCmd, want: false},
+		"audit log":         {cmd: auditLogCmd, want: false},
+		"version":           {cmd: versionCmd, want: false},
+		"root":              {cmd: rootCmd, want: false},
+		"group":             {cmd: auditCmd, want: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if help := tc.cmd.Flags().Lookup("help"); help != nil {
+				require.NoError(t, help.Value.Set("false"))
+			}
+			assert.Equal(t, tc.want, auditedCommand(tc.cmd))
+		})
+	}
+}
+
+func TestNewAuditEntry(t *testing.T) {
+	t.Parallel()
+
+	cmd := &cobra.Command{Use: "submit"}
+	rootish := &cobra.Command{Use: "grctool"}
+	evidence := &cobra.Command{Use: "evidence"}
+	rootish.AddCommand(evidence)
+	evidence.AddCommand(cmd)
+	cmd.Flags().String("window", "", "")
+	cmd.Flags().String("api-token", "", "")
+	cmd.Flags().Bool("dry-run", false, "")
+	cmd.Flags().Bool("force", false, "")
+	require.NoError(t, cmd.ParseFlags([]string{"ET-0047", "--window", "2025-Q4", "--api-token", "s3cr3t", "--dry-run"}))
+
+	start := time.Now().Add(-2 * time.Second)
+	entry := newAuditEntry(cmd, start, errors.New("submission rejected"))
+	assert.Equal(t, "evidence submit", entry.Command)
+	assert.Equal(t, []string{"ET-0047", "--api-token=[REDACTED]", "--dry-run", "--window=2025-Q4"}, entry.Args)
+	assert.Equal(t, start, entry.Timestamp)
+	assert.Equal(t, models.AuditFailed, entry.Outcome)
+	assert.Equal(t, "submission rejected", entry.Error)
+	assert.GreaterOrEqual(t, entry.DurationMS, int64(2000))
+	assert.NotEmpty(t, entry.Actor)
+	assert.Equal(t, correlationID, entry.CorrelationID)
+
+	entry = newAuditEntry(cmd, start, nil)
+	assert.Equal(t, models.AuditSucceeded, entry.Outcome)
+	assert.Empty(t, entry.Error)
+}
+
+func TestFilterAuditEntries(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+	entries := []models.AuditEntry{
+		{Timestamp: day, Actor: "alex@example.com", Host: "laptop", Command: "sync", Outcome: models.AuditSucceeded},
+		{Timestamp: day.Add(24 * time.Hour), Actor: "ci", Host: "runner", Command: "evidence generate", Outcome: models.AuditFailed},
+		{Timestamp: day.Add(48 * time.Hour), Actor: "Alex@example.com", Host: "laptop", Command: "evidence submit", Outcome: models.AuditSucceeded},
+		{Timestamp: day.Add(72 * time.Hour), Actor: "ci", Host: "runner", Command: "evidences", Outcome: models.AuditSucceeded},
+	}
+
+	tests := map[string]struct {
+		filter auditFilter
+		want   []string
+	}{
+		"no filter":     {want: []string{"sync", "evidence generate", "evidence submit", "evidences"}},
+		"actor":         {filter: auditFilter{Actor: "alex@example.com"}, want: []string{"sync", "evidence submit"}},
+		"host":          {filter: auditFilter{Host: "runner"}, want: []string{"evidence generate", "evidences"}},
+		"command group": {filter: auditFilter{Command: "evidence"}, want: []string{"evidence generate", "evidence submit"}},
+		"outcome":       {filter: auditFilter{Outcome: models.AuditFailed}, want: []string{"evidence generate"}},
+		"date range": {
+			filter: auditFilter{Since: day.Add(24 * time.Hour), Until: day.Add(72 * time.Hour)},
+			want:   []string{"evidence generate", "evidence submit"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var commands []string
+			for _, entry := range filterAuditEntries(entries, tc.filter) {
+				commands = append(commands, entry.Command)
+			}
+			assert.Equal(t, tc.want, commands)
+		})
+	}
+}
+
+func TestDisplayAuditLog(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2025, 10, 20, 9, 30, 0, 0, time.Local)
+	tests := map[string]struct {
+		entries []models.AuditEntry
+		total   int
+		want    string
+	}{
+		"no entries": {
+			want: "No recorded actions match\n",
+		},
+		"latest of more": {
+			entries: []models.AuditEntry{
+				{Timestamp: at, Actor: "alex@example.com", Host: "laptop", Command: "sync", Outcome: models.AuditSucceeded, DurationMS: 12345},
+				{Timestamp: at.Add(time.Minute), Actor: "ci", Host: "runner", Command: "evidence submit", Args: []string{"ET-0047", "--window=2025-Q4"}, Outcome: models.AuditFailed, Error: "submission rejected", DurationMS: 250},
+			},
+			total: 7,
+			want: "📜 Showing the latest 2 of 7 recorded actions\n\n" +
+				"TIME                 ACTOR             HOST    COMMAND                                   OUTCOME    DURATION\n" +
+				"----                 -----             ----    -------                                   -------    --------\n" +
+				"2025-10-20 09:30:00  alex@example.com  laptop  sync                                      succeeded  12.3s\n" +
+				"2025-10-20 09:31:00  ci                runner  evidence submit ET-0047 --window=2025-Q4  failed     250ms\n" +
+				"\n❌ 2025-10-20 09:31:00 evidence submit: submission rejected\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&buf)
+			displayAuditLog(cmd, tc.entries, tc.total)
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+}
@@ -17,6 +17,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -25,6 +26,7 @@ import (
 
 	"github.com/grctool/grctool/internal/auth"
 	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/keychain"
 	"github.com/grctool/grctool/internal/logger"
 	"github.com/grctool/grctool/internal/tools"
 	"github.com/spf13/cobra"
@@ -35,43 +37,62 @@ import (
 // authCmd represents the auth command
 var authCmd = &cobra.Command{
 	Use:   "auth",
-	Short: "Manage Tugboat Logic authentication",
-	Long: `Manage authentication with Tugboat Logic.
+	Short: "Manage Tugboat Logic, GitHub and Google authentication",
+	Long: `Manage authentication with Tugboat Logic, GitHub and Google.
 
 This command provides subcommands for handling authentication,
-including automated browser-based login.`,
+including automated browser-based login and storing credentials
+in the OS keychain.`,
 }
 
 // loginCmd represents the auth login command
 var loginCmd = &cobra.Command{
-	Use:   "login",
-	Short: "Authenticate with Tugboat Logic via Safari",
-	Long: `Authenticate with Tugboat Logic using Safari browser (macOS only).
-
-This command will:
+	Use:   "login [tugboat|github|google]",
+	Short: "Authenticate and store credentials in the OS keychain",
+	Long: `Authenticate with a provider and store its credentials in the OS keychain
+(macOS Keychain, the Secret Service via libsecret on Linux, or the Windows
+Credential Manager). Stored credentials are used whenever the configuration
+does not set them, so no tokens need to live in environment variables or the
+config file. With an active profile, credentials are stored for that profile.
+
+Providers:
+  tugboat  Log in to Tugboat Logic through Safari (macOS only, the default)
+  github   Store a GitHub personal access token, read from a prompt or stdin
+  google   Store a Google service account key, read from --credentials-file or stdin
+
+The Tugboat flow will:
 1. Open Safari to the Tugboat Logic login page
 2. Wait for you to complete the login process (supports Touch ID, Face ID, 1Password)
 3. Automatically extract authentication cookies using AppleScript
-4. Save the credentials to your configuration file
+4. Save the session to the keychain, or to the config file with --store config
 
-Requirements:
+Tugboat requirements:
 - macOS only (Safari automation uses AppleScript)
 - Enable "Allow JavaScript from Apple Events" in Safari Developer settings for automatic extraction
 
 Example:
   grctool auth login
-  grctool auth login --timeout 10m`,
-	RunE: runLogin,
+  grctool auth login --timeout 10m
+  grctool auth login github
+  gh auth token | grctool auth login github
+  grctool auth login google --credentials-file service-account.json`,
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: []string{keychain.AccountTugboat, keychain.AccountGitHub, keychain.AccountGoogle},
+	RunE:      runLogin,
 }
 
 // logoutCmd represents the auth logout command
 var logoutCmd = &cobra.Command{
-	Use:   "logout",
-	Short: "Remove stored Tugboat Logic credentials",
-	Long: `Remove stored Tugboat Logic credentials from the configuration file.
-
-This will clear the stored authentication cookies and tokens.`,
-	RunE: runLogout,
+	Use:   "logout [tugboat|github|google]",
+	Short: "Remove stored credentials",
+	Long: `Remove stored credentials for a provider, Tugboat Logic by default.
+
+For Tugboat this clears the authentication cookies and tokens from the
+configuration file and the session from the OS keychain. For GitHub and
+Google it removes the token or key from the OS keychain.`,
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: []string{keychain.AccountTugboat, keychain.AccountGitHub, keychain.AccountGoogle},
+	RunE:      runLogout,
 }
 
 // statusCmd represents the auth status command
@@ -87,8 +108,10 @@ Falls back to Tugboat-only status check otherwise.`,
 }
 
 var (
-	authTimeout time.Duration
-	saveToFile  string
+	authTimeout     time.Duration
+	saveToFile      string
+	credentialStore string
+	googleKeyFile   string
 )
 
 func init() {
@@ -100,9 +123,23 @@ func init() {
 	// Login command flags
 	loginCmd.Flags().DurationVar(&authTimeout, "timeout", 5*time.Minute, "Authentication timeout")
 	loginCmd.Flags().StringVar(&saveToFile, "save-to", "", "Save credentials to specific file (default: current config)")
+	loginCmd.Flags().StringVar(&credentialStore, "store", "keychain", "Where to store Tugboat credentials: keychain or config")
+	loginCmd.Flags().StringVar(&googleKeyFile, "credentials-file", "", "Google service account key file to store (google only)")
 }
 
 func runLogin(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case keychain.AccountGitHub:
+			return runLoginGitHub(cmd)
+		case keychain.AccountGoogle:
+			return runLoginGoogle(cmd)
+		}
+	}
+	if credentialStore != "keychain" && credentialStore != "config" {
+		return fmt.Errorf("invalid --store %q: use keychain or config", credentialStore)
+	}
+
 	// Load configuration to get base URL
 	cfg, err := loadConfigForAuth()
 	if err != nil {
@@ -147,12 +184,27 @@ func runLogin(cmd *cobra.Command, args []string) error {
 		cmd.Println("✅ Credentials validated successfully!")
 	}
 
-	// Save credentials to configuration
-	if err := saveCredentials(cfg, creds); err != nil {
+	// Save the session to the keychain, falling back to the config file
+	location := getConfigPath()
+	inKeychain := false
+	if credentialStore == "keychain" {
+		session := config.TugboatSession{
+			CookieHeader: creds.CookieHeader,
+			BearerToken:  creds.BearerToken,
+			AuthExpires:  creds.ExpiresAt.Format(time.RFC3339),
+		}
+		if err := config.StoreTugboatSession(config.ActiveProfile(), session); err != nil {
+			cmd.Printf("⚠️  Could not use the OS keychain (%v); saving to the config file instead\n", err)
+		} else {
+			inKeychain = true
+			location = keychain.Default.Name()
+		}
+	}
+	if err := saveCredentials(cfg, creds, inKeychain); err != nil {
 		return fmt.Errorf("failed to save credentials: %w", err)
 	}
 
-	cmd.Printf("\n🔐 Credentials saved to: %s\n", getConfigPath())
+	cmd.Printf("\n🔐 Credentials saved to: %s\n", location)
 	if creds.OrgID != "" {
 		cmd.Printf("🏢 Organization ID: %s\n", creds.OrgID)
 	}
@@ -163,6 +215,19 @@ func runLogin(cmd *cobra.Command, args []string) error {
 }
 
 func runLogout(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 && args[0] != keychain.AccountTugboat {
+		return logoutKeychain(cmd, args[0])
+	}
+
+	// Remove the session from the keychain; the keychain may be unavailable
+	removed, err := config.DeleteKeychainCredential(keychain.AccountTugboat, config.ActiveProfile())
+	if err != nil && !errors.Is(err, keychain.ErrUnsupported) {
+		return fmt.Errorf("failed to remove credentials from the keychain: %w", err)
+	}
+	if removed {
+		cmd.Printf("🔒 Tugboat session removed from: %s\n", keychain.Default.Name())
+	}
+
 	configPath := getConfigPath()
 
 	// Load current configuration
@@ -234,6 +299,8 @@ func runAuthStatusFromProviders(cmd *cobra.Command, ctx context.Context, shared
 		}
 	}
 
+	printKeychainStatus(cmd)
+
 	if !anyAuthenticated {
 		cmd.Println("\nRun 'grctool auth login' to authenticate with Tugboat Logic")
 	}
@@ -282,6 +349,8 @@ func runAuthStatusLegacy(cmd *cobra.Command, ctx context.Context) error {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
 
+	defer printKeychainStatus(cmd)
+
 	if cfg.Tugboat.CookieHeader == "" {
 		cmd.Println("Not authenticated")
 		cmd.Println("Run 'grctool auth login' to authenticate")
@@ -328,6 +397,9 @@ func loadConfigForAuth() (*config.Config, error) {
 			return nil, fmt.Errorf("failed to read config file: %w", err)
 		}
 	}
+	if err := config.ApplyProfile(); err != nil {
+		return nil, err
+	}
 
 	// Unmarshal into config struct
 	if err := viper.Unmarshal(cfg); err != nil {
@@ -339,11 +411,16 @@ func loadConfigForAuth() (*config.Config, error) {
 		cfg.Tugboat.BaseURL = "https://app.tugboatlogic.com"
 	}
 
+	cfg.Profile = config.ActiveProfile()
+	config.ApplyKeychainCredentials(cfg)
+
 	return cfg, nil
 }
 
-// saveCredentials saves authentication credentials to the config file
-func saveCredentials(cfg *config.Config, creds *auth.AuthCredentials) error {
+// saveCredentials saves authentication credentials to the config file. With
+// the session in the keychain, only its expiry and organization are written
+// and any session left in the file is removed, as it would take precedence.
+func saveCredentials(cfg *config.Config, creds *auth.AuthCredentials, inKeychain bool) error {
 	configPath := getConfigPath()
 	if saveToFile != "" {
 		configPath = saveToFile
@@ -365,16 +442,28 @@ func saveCredentials(cfg *config.Config, creds *auth.AuthCredentials) error {
 		configData = make(map[string]interface{})
 	}
 
+	// Credentials belong to the active profile, if any
+	section := configData
+	profile := config.ActiveProfile()
+	if profile != "" {
+		section = profileSection(configData, profile)
+	}
+
 	// Update tugboat section
-	if _, ok := configData["tugboat"]; !ok {
-		configData["tugboat"] = make(map[string]interface{})
+	if _, ok := section["tugboat"]; !ok {
+		section["tugboat"] = make(map[string]interface{})
 	}
 
-	tugboat := configData["tugboat"].(map[string]interface{})
+	tugboat := section["tugboat"].(map[string]interface{})
 	tugboat["auth_mode"] = "browser"
-	tugboat["cookie_header"] = creds.CookieHeader
-	if creds.BearerToken != "" {
-		tugboat["bearer_token"] = creds.BearerToken
+	if inKeychain {
+		delete(tugboat, "cookie_header")
+		delete(tugboat, "bearer_token")
+	} else {
+		tugboat["cookie_header"] = creds.CookieHeader
+		if creds.BearerToken != "" {
+			tugboat["bearer_token"] = creds.BearerToken
+		}
 	}
 	tugboat["auth_expires"] = creds.ExpiresAt.Format(time.RFC3339)
 
@@ -383,8 +472,8 @@ func saveCredentials(cfg *config.Config, creds *auth.AuthCredentials) error {
 		tugboat["org_id"] = creds.OrgID
 	}
 
-	// Ensure base_url is set
-	if _, ok := tugboat["base_url"]; !ok {
+	// Ensure base_url is set; a profile inherits the top-level one
+	if _, ok := tugboat["base_url"]; !ok && profile == "" {
 		tugboat["base_url"] = cfg.Tugboat.BaseURL
 	}
 
@@ -0,0 +1,182 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/grctool/grctool/internal/auth"
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/keychain"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+// validateGitHubToken checks a token against the GitHub API. Tests replace it.
+var validateGitHubToken = func(ctx context.Context, token string) error {
+	provider := auth.NewGitHubAuthProvider(token, os.TempDir(), logger.FromContextOrNew(ctx, "auth"))
+	return provider.ValidateAuth(ctx)
+}
+
+// runLoginGitHub stores a GitHub personal access token in the keychain
+func runLoginGitHub(cmd *cobra.Command) error {
+	token, err := readSecret(cmd, "GitHub personal access token: ")
+	if err != nil {
+		return fmt.Errorf("failed to read token: %w", err)
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return fmt.Errorf("no token given: enter one at the prompt or pipe it in, e.g. gh auth token | grctool auth login github")
+	}
+
+	cmd.Println("🔍 Validating token...")
+	if err := validateGitHubToken(context.Background(), token); err != nil {
+		return fmt.Errorf("GitHub rejected the token: %w", err)
+	}
+
+	return storeInKeychain(cmd, keychain.AccountGitHub, token, "GitHub token")
+}
+
+// runLoginGoogle stores a Google credentials JSON key in the keychain
+func runLoginGoogle(cmd *cobra.Command) error {
+	var data []byte
+	var err error
+	if googleKeyFile != "" {
+		data, err = os.ReadFile(googleKeyFile)
+	} else if isTerminalInput(cmd) {
+		return fmt.Errorf("give the key with --credentials-file or pipe it in on stdin")
+	} else {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read Google credentials: %w", err)
+	}
+
+	var key struct {
+		Type        string `json:"type"`
+		ClientEmail string `json:"client_email"`
+	}
+	if err := json.Unmarshal(data, &key); err != nil || key.Type == "" {
+		return fmt.Errorf("not a Google credentials JSON file")
+	}
+	// Compact the key so it is stored as a single printable line
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, data); err != nil {
+		return fmt.Errorf("not a Google credentials JSON file: %w", err)
+	}
+
+	if err := storeInKeychain(cmd, keychain.AccountGoogle, compact.String(), "Google "+strings.ReplaceAll(key.Type, "_", " ")); err != nil {
+		return err
+	}
+	if key.ClientEmail != "" {
+		cmd.Printf("👤 Service account: %s\n", key.ClientEmail)
+	}
+	if googleKeyFile != "" {
+		cmd.Printf("💡 The key file %s is no longer needed and can be deleted\n", googleKeyFile)
+	}
+	return nil
+}
+
+// storeInKeychain saves a secret for the active profile
+func storeInKeychain(cmd *cobra.Command, provider, secret, what string) error {
+	profile := config.ActiveProfile()
+	if err := keychain.Default.Set(keychain.Account(provider, profile), secret); err != nil {
+		if errors.Is(err, keychain.ErrUnsupported) {
+			return fmt.Errorf("%w: %s", err, keychain.Default.Name())
+		}
+		return fmt.Errorf("failed to store %s in the keychain: %w", what, err)
+	}
+
+	cmd.Printf("🔐 %s saved to: %s\n", what, keychain.Default.Name())
+	if profile != "" {
+		cmd.Printf("📁 Profile: %s\n", profile)
+	}
+	return nil
+}
+
+// logoutKeychain removes a provider's credential for the active profile
+func logoutKeychain(cmd *cobra.Command, provider string) error {
+	removed, err := config.DeleteKeychainCredential(provider, config.ActiveProfile())
+	if err != nil {
+		return fmt.Errorf("failed to remove credentials from the keychain: %w", err)
+	}
+	if !removed {
+		cmd.Printf("No %s credentials stored in the keychain. Nothing to logout from.\n", provider)
+		return nil
+	}
+	cmd.Println("✅ Logged out successfully!")
+	cmd.Printf("🔒 %s credentials removed from: %s\n", provider, keychain.Default.Name())
+	return nil
+}
+
+// printKeychainStatus lists the credentials stored for the active profile
+func printKeychainStatus(cmd *cobra.Command) {
+	profile := config.ActiveProfile()
+	cmd.Printf("\nKeychain: %s\n", keychain.Default.Name())
+	for _, provider := range []string{keychain.AccountTugboat, keychain.AccountGitHub, keychain.AccountGoogle} {
+		state := "not stored"
+		if _, err := keychain.Lookup(keychain.Default, provider, profile); err == nil {
+			state = "stored"
+		} else if errors.Is(err, keychain.ErrUnsupported) {
+			return
+		} else if !errors.Is(err, keychain.ErrNotFound) {
+			state = "error: " + err.Error()
+		}
+		cmd.Printf("  %s: %s\n", provider, state)
+	}
+}
+
+// readSecret reads a secret from a prompt, without echo, at a terminal, or
+// from the whole of a piped stdin
+func readSecret(cmd *cobra.Command, prompt string) (string, error) {
+	in := cmd.InOrStdin()
+	if !isTerminalInput(cmd) {
+		data, err := io.ReadAll(in)
+		return string(data), err
+	}
+
+	cmd.Print(prompt)
+	if runtime.GOOS != "windows" {
+		if err := stty(in, "-echo"); err == nil {
+			defer func() {
+				_ = stty(in, "echo")
+				cmd.Println()
+			}()
+		}
+	}
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err == io.EOF {
+		err = nil
+	}
+	return line, err
+}
+
+// stty changes the settings of the terminal in
+func stty(in io.Reader, args ...string) error {
+	c := exec.Command("stty", args...)
+	c.Stdin = in
+	return c.Run()
+}
@@ -19,12 +19,16 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/grctool/grctool/internal/auth"
 	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/keychain"
 	"github.com/grctool/grctool/internal/logger"
 	"github.com/grctool/grctool/internal/tools"
 	"github.com/spf13/cobra"
@@ -77,6 +81,8 @@ func TestAuthCommand(t *testing.T) {
 }
 
 func TestAuthLogout(t *testing.T) {
+	useMemoryKeychain(t)
+
 	// Create a temporary directory for testing
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, "test-config.yaml")
@@ -358,3 +364,125 @@ func TestGetConfigPath(t *testing.T) {
 		})
 	}
 }
+
+// useMemoryKeychain replaces the OS keychain for the rest of the test
+func useMemoryKeychain(t *testing.T) *keychain.MemoryStore {
+	t.Helper()
+	store := keychain.NewMemoryStore()
+	old := keychain.Default
+	keychain.Default = store
+	t.Cleanup(func() { keychain.Default = old })
+	return store
+}
+
+func TestAuthLogout_RemovesKeychainSession(t *testing.T) {
+	store := useMemoryKeychain(t)
+	require.NoError(t, config.StoreTugboatSession("", config.TugboatSession{BearerToken: "stored"}))
+
+	oldCfgFile := cfgFile
+	cfgFile = filepath.Join(t.TempDir(), "missing.yaml")
+	defer func() { cfgFile = oldCfgFile }()
+
+	cmd := &cobra.Command{Use: "logout", RunE: runLogout}
+	output := &bytes.Buffer{}
+	cmd.SetOut(output)
+	cmd.SetArgs([]string{})
+	require.NoError(t, cmd.Execute())
+
+	_, err := store.Get(keychain.AccountTugboat)
+	assert.ErrorIs(t, err, keychain.ErrNotFound)
+	assert.Contains(t, output.String(), "Tugboat session removed from: memory")
+}
+
+func TestAuthLoginGitHub(t *testing.T) {
+	store := useMemoryKeychain(t)
+
+	var validated string
+	oldValidate := validateGitHubToken
+	validateGitHubToken = func(ctx context.Context, token string) error {
+		validated = token
+		if token == "ghp_bad" {
+			return errors.New("GitHub token is invalid or expired")
+		}
+		return nil
+	}
+	t.Cleanup(func() { validateGitHubToken = oldValidate })
+
+	run := func(stdin string) (string, error) {
+		cmd := &cobra.Command{Use: "login"}
+		output := &bytes.Buffer{}
+		cmd.SetOut(output)
+		cmd.SetIn(strings.NewReader(stdin))
+		err := runLogin(cmd, []string{"github"})
+		return output.String(), err
+	}
+
+	out, err := run("ghp_good\n")
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_good", validated)
+	assert.Contains(t, out, "GitHub token saved to: memory")
+	token, err := store.Get(keychain.AccountGitHub)
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_good", token)
+
+	_, err = run("ghp_bad\n")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GitHub rejected the token")
+
+	_, err = run("")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no token given")
+
+	cmd := &cobra.Command{Use: "logout"}
+	cmd.SetOut(&bytes.Buffer{})
+	require.NoError(t, runLogout(cmd, []string{"github"}))
+	_, err = store.Get(keychain.AccountGitHub)
+	assert.ErrorIs(t, err, keychain.ErrNotFound)
+}
+
+func TestAuthLoginGoogle(t *testing.T) {
+	store := useMemoryKeychain(t)
+
+	keyFile := filepath.Join(t.TempDir(), "key.json")
+	require.NoError(t, os.WriteFile(keyFile, []byte("{\n  \"type\": \"service_account\",\n  \"client_email\": \"grctool@acme.iam.gserviceaccount.com\"\n}\n"), 0600))
+
+	oldKeyFile := googleKeyFile
+	t.Cleanup(func() { googleKeyFile = oldKeyFile })
+
+	t.Run("key file", func(t *testing.T) {
+		googleKeyFile = keyFile
+		cmd := &cobra.Command{Use: "login"}
+		output := &bytes.Buffer{}
+		cmd.SetOut(output)
+		require.NoError(t, runLogin(cmd, []string{"google"}))
+
+		stored, err := store.Get(keychain.AccountGoogle)
+		require.NoError(t, err)
+		assert.Equal(t, `{"type":"service_account","client_email":"grctool@acme.iam.gserviceaccount.com"}`, stored)
+		assert.Contains(t, output.String(), "grctool@acme.iam.gserviceaccount.com")
+	})
+
+	t.Run("not a credentials file", func(t *testing.T) {
+		googleKeyFile = ""
+		cmd := &cobra.Command{Use: "login"}
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetIn(strings.NewReader(`{"hello": "world"}`))
+		err := runLogin(cmd, []string{"google"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not a Google credentials JSON file")
+	})
+}
+
+func TestLoadConfigForAuth_Keychain(t *testing.T) {
+	useMemoryKeychain(t)
+	require.NoError(t, config.StoreTugboatSession("", config.TugboatSession{CookieHeader: "session=stored", BearerToken: "stored"}))
+
+	oldDir, _ := os.Getwd()
+	require.NoError(t, os.Chdir(t.TempDir()))
+	defer func() { _ = os.Chdir(oldDir) }()
+
+	cfg, err := loadConfigForAuth()
+	require.NoError(t, err)
+	assert.Equal(t, "session=stored", cfg.Tugboat.CookieHeader)
+	assert.Equal(t, "stored", cfg.Tugboat.BearerToken)
+}
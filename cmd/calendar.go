@@ -0,0 +1,176 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/services/calendar"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var calendarCmd = &cobra.Command{
+	Use:   "calendar",
+	Short: "Show evidence deadlines as a calendar and export them to iCal",
+	Long: `Project the due dates of all evidence tasks onto a calendar.
+
+Each task's collection interval (monthly, quarterly, semi-annual, annual) is
+expanded into its collection windows, with the deadline falling the task's
+due-days-before before the end of each window. Next due dates synced from
+Tugboat are included when they differ.
+
+Use --ics to write an iCalendar file that evidence owners can import or
+subscribe to (for example after publishing it to a shared location). Event
+UIDs are stable, so regenerating the file updates existing entries.
+
+Examples:
+  # Deadlines for the next three months
+  grctool calendar
+
+  # A year of SOC 2 deadlines for one owner, exported to iCal
+  grctool calendar --months 12 --framework soc2 --assignee jane@example.com --ics jane.ics
+
+  # Deadlines as JSON
+  grctool calendar --from 2026-01-01 --output json`,
+	Args: cobra.NoArgs,
+	RunE: runCalendar,
+}
+
+func init() {
+	rootCmd.AddCommand(calendarCmd)
+
+	calendarCmd.Flags().String("from", "", "first day to include, YYYY-MM-DD (default: today)")
+	calendarCmd.Flags().Int("months", 3, "number of months to project")
+	calendarCmd.Flags().String("assignee", "", "only tasks assigned to this name or email")
+	calendarCmd.Flags().String("framework", "", "only tasks in or referencing this framework")
+	calendarCmd.Flags().String("ics", "", "write the deadlines to an iCalendar (.ics) file")
+	calendarCmd.Flags().String("name", "Compliance Deadlines", "calendar name shown by calendar clients")
+	calendarCmd.Flags().Int("reminder-days", 7, "days before each deadline to remind (0 disables reminders)")
+}
+
+func runCalendar(cmd *cobra.Command, args []string) error {
+	fromFlag, _ := cmd.Flags().GetString("from")
+	months, _ := cmd.Flags().GetInt("months")
+	assignee, _ := cmd.Flags().GetString("assignee")
+	framework, _ := cmd.Flags().GetString("framework")
+	icsFile, _ := cmd.Flags().GetString("ics")
+	name, _ := cmd.Flags().GetString("name")
+	reminderDays, _ := cmd.Flags().GetInt("reminder-days")
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if months <= 0 {
+		return fmt.Errorf("--months must be positive")
+	}
+	if reminderDays < 0 {
+		return fmt.Errorf("--reminder-days must not be negative")
+	}
+
+	from := time.Now()
+	if fromFlag != "" {
+		from, err = time.Parse("2006-01-02", fromFlag)
+		if err != nil {
+			return fmt.Errorf("invalid --from date %q: use YYYY-MM-DD", fromFlag)
+		}
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	tasks, err := store.GetAllEvidenceTasks()
+	if err != nil {
+		return fmt.Errorf("failed to load evidence tasks: %w", err)
+	}
+
+	opts := calendar.Options{
+		From:      from,
+		To:        from.AddDate(0, months, 0),
+		Assignee:  assignee,
+		Framework: framework,
+	}
+	events := calendar.Project(tasks, opts)
+
+	if icsFile != "" {
+		if err := writeCalendarFile(icsFile, events, calendar.ICSOptions{Name: name, ReminderDays: reminderDays}); err != nil {
+			return err
+		}
+	}
+
+	if isStructuredOutput(format) {
+		if events == nil {
+			events = []calendar.Event{}
+		}
+		return writeStructured(cmd, format, events)
+	}
+
+	displayCalendar(cmd, events, opts)
+	if icsFile != "" {
+		cmd.Printf("\n✅ Exported %d deadlines to: %s\n", len(events), icsFile)
+	}
+	return nil
+}
+
+func writeCalendarFile(path string, events []calendar.Event, opts calendar.ICSOptions) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create calendar file: %w", err)
+	}
+	if err := calendar.WriteICS(file, events, opts); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write calendar file: %w", err)
+	}
+	return file.Close()
+}
+
+// displayCalendar lists the deadlines grouped by month
+func displayCalendar(cmd *cobra.Command, events []calendar.Event, opts calendar.Options) {
+	cmd.Printf("📅 Evidence deadlines %s – %s\n", opts.From.Format("2006-01-02"), opts.To.AddDate(0, 0, -1).Format("2006-01-02"))
+
+	if len(events) == 0 {
+		cmd.Println("\nNo evidence deadlines in this period.")
+		return
+	}
+
+	month := ""
+	for _, event := range events {
+		if heading := event.Date.Format("January 2006"); heading != month {
+			cmd.Printf("\n%s\n", heading)
+			month = heading
+		}
+
+		window := event.Window
+		if window == "" {
+			window = "next due"
+		}
+		cmd.Printf("  %s  %-8s %s (%s)\n", event.Date.Format("Mon 02"), event.TaskRef, event.TaskName, window)
+	}
+	cmd.Printf("\n%d deadlines\n", len(events))
+}
@@ -511,6 +511,34 @@ func TestBuildValidationChecks(t *testing.T) {
 	assert.Equal(t, "control_alignment", checks[3].Code)
 }
 
+func TestBuildValidationChecks_Rubric(t *testing.T) {
+	t.Parallel()
+
+	result := &models.EvaluationResult{
+		Completeness:      models.DimensionScore{Status: "pass"},
+		RequirementsMatch: models.DimensionScore{Status: "fail"},
+		QualityScore:      models.DimensionScore{Status: models.DimensionSkipped},
+		ControlAlignment:  models.DimensionScore{Status: models.DimensionSkipped},
+		RequiredChecks: []models.RequiredCheckResult{
+			{Name: "user-export", Status: "pass", Severity: models.IssueCritical},
+			{Name: "signoff", Status: "fail", Severity: models.IssueCritical},
+			{Name: "readme", Status: "fail", Severity: models.IssueLow},
+		},
+	}
+
+	checks := buildValidationChecks(result)
+
+	require.Len(t, checks, 5)
+	assert.Equal(t, "completeness", checks[0].Code)
+	assert.Equal(t, "requirements_match", checks[1].Code)
+	assert.Equal(t, "required:user-export", checks[2].Code)
+	assert.Equal(t, "info", checks[2].Severity)
+	assert.Equal(t, "error", checks[3].Severity)
+	assert.Equal(t, "warning", checks[4].Severity)
+	assert.Equal(t, 1, countRequiredChecks(result, "pass"))
+	assert.Equal(t, 2, countRequiredChecks(result, "fail"))
+}
+
 func TestSaveEvaluationResultToFile(t *testing.T) {
 	t.Parallel()
 
@@ -951,6 +979,15 @@ func TestEvidenceCommandStructure(t *testing.T) {
 		assert.NotNil(t, evidenceListCmd.Flags().Lookup("collection-type"))
 		assert.NotNil(t, evidenceListCmd.Flags().Lookup("sensitive"))
 		assert.NotNil(t, evidenceListCmd.Flags().Lookup("complexity"))
+		assert.NotNil(t, evidenceListCmd.Flags().Lookup("export-file"))
+		// --output is the global format flag; the export file has its own
+		assert.Nil(t, evidenceListCmd.LocalNonPersistentFlags().Lookup("output"))
+	})
+
+	t.Run("evidence view flags", func(t *testing.T) {
+		t.Parallel()
+		assert.NotNil(t, evidenceViewCmd.Flags().Lookup("export-file"))
+		assert.Nil(t, evidenceViewCmd.LocalNonPersistentFlags().Lookup("output"))
 	})
 
 	t.Run("evidence generate flags", func(t *testing.T) {
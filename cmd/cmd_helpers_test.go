@@ -30,6 +30,7 @@ import (
 	configService "github.com/grctool/grctool/internal/services/config"
 	"github.com/grctool/grctool/internal/services/evidence"
 	"github.com/grctool/grctool/internal/services/validation"
+	"github.com/grctool/grctool/internal/templates"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -38,10 +39,10 @@ import (
 // ============================================================
 // evidence.go — formatFileSize, getStatusIcon, getScoreStatus,
 //               truncateFileName, extractRequirements,
-//               calculatePeriod, parseJSONResult,
+//               parseJSONResult,
 //               isTugboatManagedTask, displayTugboatManagedMessage,
 //               selectEvidenceTemplate, identifyApplicableToolsForAssembly,
-//               generateXxxTemplate, applyTemplateVariables,
+//               generateXxxTemplate,
 //               display* functions
 // ============================================================
 
@@ -148,27 +149,6 @@ func TestTruncateFileName(t *testing.T) {
 	}
 }
 
-func TestCalculatePeriod(t *testing.T) {
-	t.Parallel()
-
-	tests := []struct {
-		window   string
-		expected string
-	}{
-		{"2025-Q4", "Quarterly period 2025-Q4"},
-		{"2025-Q1", "Quarterly period 2025-Q1"},
-		{"2025", "2025"},
-		{"2025-03", "2025-03"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.window, func(t *testing.T) {
-			t.Parallel()
-			assert.Equal(t, tt.expected, calculatePeriod(tt.window))
-		})
-	}
-}
-
 func TestParseJSONResult(t *testing.T) {
 	t.Parallel()
 
@@ -420,7 +400,8 @@ func TestSelectEvidenceTemplate(t *testing.T) {
 			task := &domain.EvidenceTask{
 				Category: tt.category,
 			}
-			template := selectEvidenceTemplate(task)
+			template, err := selectEvidenceTemplate(templates.EvidenceData{Task: task}, templates.NewEvidenceTemplates(""))
+			require.NoError(t, err)
 			assert.NotEmpty(t, template)
 			assert.Contains(t, template, tt.contains)
 		})
@@ -429,79 +410,59 @@ func TestSelectEvidenceTemplate(t *testing.T) {
 	t.Run("nil MasterContent", func(t *testing.T) {
 		t.Parallel()
 		task := &domain.EvidenceTask{}
-		template := selectEvidenceTemplate(task)
+		template, err := selectEvidenceTemplate(templates.EvidenceData{Task: task}, templates.NewEvidenceTemplates(""))
+		require.NoError(t, err)
 		assert.NotEmpty(t, template, "should return generic template")
 	})
-}
 
-func TestGenerateTemplates(t *testing.T) {
-	t.Parallel()
-
-	t.Run("generic template", func(t *testing.T) {
+	t.Run("generic outline", func(t *testing.T) {
 		t.Parallel()
-		template := generateGenericTemplate()
+		template, err := selectEvidenceTemplate(templates.EvidenceData{Task: &domain.EvidenceTask{Category: "Unknown"}}, templates.NewEvidenceTemplates(""))
+		require.NoError(t, err)
 		assert.Contains(t, template, "Evidence Report")
 		assert.Contains(t, template, "Executive Summary")
 		assert.Contains(t, template, "Technical Evidence")
+		assert.Contains(t, template, "## Control Mapping\n[List of controls this evidence satisfies]\n\n")
 	})
 
-	t.Run("infrastructure template", func(t *testing.T) {
+	t.Run("related controls", func(t *testing.T) {
 		t.Parallel()
-		template := generateInfrastructureTemplate()
-		assert.Contains(t, template, "Infrastructure")
-	})
-
-	t.Run("personnel template", func(t *testing.T) {
-		t.Parallel()
-		template := generatePersonnelTemplate()
-		assert.Contains(t, template, "Personnel")
-	})
-
-	t.Run("process template", func(t *testing.T) {
-		t.Parallel()
-		template := generateProcessTemplate()
-		assert.Contains(t, template, "Process")
+		task := &domain.EvidenceTask{Category: "Infrastructure", RelatedControls: []domain.Control{
+			{ID: "101", ReferenceID: "AC-01", Name: "Access Provisioning", FrameworkCodes: []domain.FrameworkCode{{Framework: "SOC 2", Code: "CC6.1"}}},
+		}}
+		template, err := selectEvidenceTemplate(templates.NewEvidenceData(task, "2025-Q4", nil), templates.NewEvidenceTemplates(""))
+		require.NoError(t, err)
+		assert.Contains(t, template, "## Control Mapping\n\n| Control | Name | Frameworks |\n|---------|------|------------|\n"+
+			"| AC-01 | Access Provisioning | SOC 2: CC6.1 |\n\n")
 	})
 
-	t.Run("compliance template", func(t *testing.T) {
+	t.Run("mapped", func(t *testing.T) {
 		t.Parallel()
-		template := generateComplianceTemplate()
-		assert.Contains(t, template, "Compliance")
-	})
+		dir := t.TempDir()
+		vendor := filepath.Join(dir, "vendor.md")
+		require.NoError(t, os.WriteFile(vendor, []byte("# Vendor Review: {{.Task.ReferenceID}}\n"), 0644))
+		templateSet := templates.NewEvidenceTemplates(dir).WithMappings(config.EvidenceTemplateMappings{
+			Categories: map[string]string{"Vendor": vendor},
+		})
+		template, err := selectEvidenceTemplate(templates.EvidenceData{Task: &domain.EvidenceTask{ReferenceID: "ET-0090", Category: "Vendor"}}, templateSet)
+		require.NoError(t, err)
+		assert.Equal(t, "# Vendor Review: ET-0090\n", template)
 
-	t.Run("monitoring template", func(t *testing.T) {
-		t.Parallel()
-		template := generateMonitoringTemplate()
-		assert.Contains(t, template, "Monitoring")
+		template, err = selectEvidenceTemplate(templates.EvidenceData{Task: &domain.EvidenceTask{Category: "Personnel"}}, templateSet)
+		require.NoError(t, err)
+		assert.Contains(t, template, "Personnel", "unmapped tasks fall back to built-ins")
 	})
 
-	t.Run("data template", func(t *testing.T) {
+	t.Run("override", func(t *testing.T) {
 		t.Parallel()
-		template := generateDataTemplate()
-		assert.Contains(t, template, "Data")
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "infrastructure.md"), []byte("# Cloud Evidence\n\n## Required: Terraform state\n"), 0644))
+		template, err := selectEvidenceTemplate(templates.EvidenceData{Task: &domain.EvidenceTask{Category: "Infrastructure"}}, templates.NewEvidenceTemplates(dir))
+		require.NoError(t, err)
+		assert.Equal(t, "# Cloud Evidence\n\n## Required: Terraform state\n", template)
 	})
 }
 
-func TestApplyTemplateVariables(t *testing.T) {
-	t.Parallel()
-
-	task := &domain.EvidenceTask{
-	ID: "327992",
-		ReferenceID: "ET-0001",
-		Name:        "Access Control Evidence",
-	}
-
-	template := "# {{TASK_REF}} - {{TASK_NAME}}\nTugboat ID: {{TUGBOAT_ID}}\nWindow: {{WINDOW}}\nPeriod: {{PERIOD}}"
-	result := applyTemplateVariables(template, task, "2025-Q4")
-
-	assert.Contains(t, result, "ET-0001")
-	assert.Contains(t, result, "Access Control Evidence")
-	assert.Contains(t, result, "327992")
-	assert.Contains(t, result, "2025-Q4")
-	assert.Contains(t, result, "Quarterly period 2025-Q4")
-	assert.NotContains(t, result, "{{")
-}
-
 func TestGetDefaultTemplate(t *testing.T) {
 	t.Parallel()
 
@@ -708,7 +669,7 @@ func TestCreateToolRequestForEvidence(t *testing.T) {
 		t.Parallel()
 		req := createToolRequestForEvidence(task, "terraform-security-indexer", cfg)
 		assert.Equal(t, "ET-0001", req["task_ref"])
-		assert.Equal(t, "control_mapping", req["query_type"])
+		assert.Equal(t, "by_control", req["query_type"])
 	})
 
 	t.Run("terraform-security-analyzer", func(t *testing.T) {
@@ -1164,7 +1125,8 @@ func TestEvidenceGenerateOptionsStruct(t *testing.T) {
 
 // ============================================================
 // evidence.go — displayDimensionScores, displayRequirementsChecklist,
-//               displayControlAlignment, displaySubmissionRecommendation
+//               displayControlAlignment, displaySubmissionRecommendation,
+//               displayAuditorFeedback
 // ============================================================
 
 func TestDisplayDimensionScores(t *testing.T) {
@@ -1294,7 +1256,7 @@ func TestDisplaySubmissionRecommendation(t *testing.T) {
 		}
 		task := &domain.EvidenceTask{ReferenceID: "ET-0001"}
 
-		displaySubmissionRecommendation(cmd, task, "2025-Q4", true, true, result, false)
+		displaySubmissionRecommendation(cmd, task, "2025-Q4", true, true, result, false, nil)
 		output := buf.String()
 		assert.Contains(t, output, "ET-0001")
 	})
@@ -1307,7 +1269,7 @@ func TestDisplaySubmissionRecommendation(t *testing.T) {
 
 		task := &domain.EvidenceTask{ReferenceID: "ET-0002"}
 
-		displaySubmissionRecommendation(cmd, task, "2025-Q4", true, false, nil, true)
+		displaySubmissionRecommendation(cmd, task, "2025-Q4", true, false, nil, true, nil)
 		output := buf.String()
 		assert.Contains(t, output, "ALREADY SUBMITTED")
 	})
@@ -1320,10 +1282,52 @@ func TestDisplaySubmissionRecommendation(t *testing.T) {
 
 		task := &domain.EvidenceTask{ReferenceID: "ET-0003"}
 
-		displaySubmissionRecommendation(cmd, task, "2025-Q4", false, false, nil, false)
+		displaySubmissionRecommendation(cmd, task, "2025-Q4", false, false, nil, false, nil)
 		output := buf.String()
 		assert.NotEmpty(t, output)
 	})
+
+	t.Run("rejected after submission", func(t *testing.T) {
+		t.Parallel()
+		buf := new(bytes.Buffer)
+		cmd := &cobra.Command{}
+		cmd.SetOut(buf)
+
+		task := &domain.EvidenceTask{ReferenceID: "ET-0004"}
+		feedback := &models.EvidenceFeedback{Comments: []models.FeedbackComment{
+			{Status: "rejected", Comment: "Screenshot is missing the date"},
+		}}
+
+		displaySubmissionRecommendation(cmd, task, "2025-Q4", true, false, nil, true, feedback)
+		output := buf.String()
+		assert.Contains(t, output, "REWORK NEEDED")
+		assert.NotContains(t, output, "ALREADY SUBMITTED")
+	})
+}
+
+func TestDisplayAuditorFeedback(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	cmd := &cobra.Command{}
+	cmd.SetOut(buf)
+
+	displayAuditorFeedback(cmd, nil)
+	assert.Empty(t, buf.String(), "nothing is shown without feedback")
+
+	displayAuditorFeedback(cmd, &models.EvidenceFeedback{
+		Comments: []models.FeedbackComment{
+			{Author: "Alex Auditor", Status: "rejected", Comment: "Screenshot is missing the date", AttachmentID: "50001",
+				CreatedAt: time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC)},
+			{Author: "Alex Auditor", Status: "rejected", Comment: "Wrong quarter", Resolved: true},
+		},
+	})
+	output := buf.String()
+	assert.Contains(t, output, "AUDITOR FEEDBACK")
+	assert.Contains(t, output, "❌ Alex Auditor, 2025-11-10 (rejected)")
+	assert.Contains(t, output, "Attachment: 50001")
+	assert.Contains(t, output, "Screenshot is missing the date")
+	assert.Contains(t, output, "1 open rejection(s)")
 }
 
 // ============================================================
@@ -1338,10 +1342,19 @@ func TestGenerateClaudeInstructions(t *testing.T) {
 		Name:        "Access Control Evidence",
 	}
 
-	instructions := generateClaudeInstructions(task, "2025-Q4")
-	assert.Contains(t, instructions, "ET-0001")
-	assert.Contains(t, instructions, "Access Control Evidence")
-	assert.NotEmpty(t, instructions)
+	data := templates.NewEvidenceData(task, "2025-Q4", nil)
+	instructions, err := generateClaudeInstructions(data, templates.NewEvidenceTemplates(""))
+	require.NoError(t, err)
+	assert.Contains(t, instructions, "# Claude Code Instructions: ET-0001")
+	assert.Contains(t, instructions, "**Access Control Evidence** (ET-0001)")
+	assert.Contains(t, instructions, "ET-0001_Evidence.md")
+	assert.NotContains(t, instructions, "{{")
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "instructions.md"), []byte("Draft {{TASK_REF}} for {{WINDOW}} in a formal tone.\n"), 0644))
+	instructions, err = generateClaudeInstructions(data, templates.NewEvidenceTemplates(dir))
+	require.NoError(t, err)
+	assert.Equal(t, "Draft ET-0001 for 2025-Q4 in a formal tone.\n", instructions)
 }
 
 // ============================================================
@@ -1664,7 +1677,7 @@ func TestSaveAssemblyContext(t *testing.T) {
 	ctx := &AssemblyContext{
 		ComprehensivePrompt: "Test prompt content",
 		ClaudeInstructions:  "Test instructions",
-		EvidenceTemplate:    "# {{TASK_REF}} - {{TASK_NAME}}",
+		EvidenceTemplate:    "# ET-0001 - Access Control Evidence",
 	}
 
 	paths, err := saveAssemblyContext(task, "2025-Q4", ctx, tmpDir)
@@ -1682,12 +1695,10 @@ func TestSaveAssemblyContext(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "Test prompt content", string(promptData))
 
-	// Verify template variables were applied
+	// Verify the rendered template was saved as is
 	templateData, err := os.ReadFile(paths.TemplateFile)
 	require.NoError(t, err)
-	assert.Contains(t, string(templateData), "ET-0001")
-	assert.Contains(t, string(templateData), "Access Control Evidence")
-	assert.NotContains(t, string(templateData), "{{TASK_REF}}")
+	assert.Equal(t, "# ET-0001 - Access Control Evidence", string(templateData))
 }
 
 // ============================================================
@@ -242,6 +242,10 @@ func completeToolNames(cmd *cobra.Command, args []string, toComplete string) ([]
 		"github-workflow-analyzer\tCI/CD workflow security",
 		"github-review-analyzer\tPR review and approval analysis",
 		"google-workspace\tGoogle Workspace document analysis",
+		"vendor-review\tVendor inventory and review status",
+		"incident-log\tIncident log with response timelines",
+		"policy-review\tAuthored policy approvals and review status",
+		"policy-acknowledgment\tEmployee policy acknowledgment rates",
 		"storage-read\tSafe file read operations",
 		"storage-write\tSafe file write operations",
 		"name-generator\tGenerate filesystem-friendly names",
@@ -19,6 +19,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	internalConfig "github.com/grctool/grctool/internal/config"
@@ -39,16 +40,49 @@ var configCmd = &cobra.Command{
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize configuration file",
-	Long:  `Create a new configuration file with default values`,
-	RunE:  runConfigInit,
+	Long: `Create a new configuration file, along with CLAUDE.md and the agent documentation.
+
+Run in a terminal, init walks through the Tugboat organization, data
+directory, Terraform scan paths, GitHub repository and collector URLs,
+suggesting values found in the current directory, and writes a commented
+.grctool.yaml. It then offers to log in and run an initial sync. Without a
+terminal, or with --interactive=false, a default configuration is written.
+
+Examples:
+  # Guided setup
+  grctool init
+
+  # Default configuration, for scripts
+  grctool init --interactive=false
+
+  # Start over with an existing configuration
+  grctool init --force`,
+	RunE: runConfigInit,
 }
 
 // configValidateCmd represents the config validate command
 var configValidateCmd = &cobra.Command{
 	Use:   "validate",
 	Short: "Validate configuration file",
-	Long:  `Validate the current configuration file for correctness`,
-	RunE:  runConfigValidate,
+	Long: `Validate the current configuration file for correctness.
+
+Loads .grctool.yaml (with the active profile), reports unknown or misplaced
+keys, checks that the paths it references exist and are writable, tests the
+Tugboat, GitHub and Google credentials with read-only calls, and checks that
+each collector URL maps to a synced evidence task. Every problem is reported
+with how to fix it.
+
+Examples:
+  # Full validation
+  grctool config validate
+
+  # Without network calls
+  grctool config validate --offline
+
+  # For scripts and CI
+  grctool config validate --offline --output json`,
+	Args: cobra.NoArgs,
+	RunE: runConfigValidate,
 }
 
 func init() {
@@ -56,8 +90,12 @@ func init() {
 	rootCmd.AddCommand(initCmd)
 	configCmd.AddCommand(configValidateCmd)
 
+	configValidateCmd.Flags().Bool("offline", false, "skip the credential checks that call Tugboat, GitHub and Google")
+	configValidateCmd.Flags().Bool("skip-permissions", false, "skip the data directory write check")
+
 	initCmd.Flags().StringP("output", "o", ".grctool.yaml", "output file path")
 	initCmd.Flags().Bool("force", false, "overwrite existing configuration file")
+	initCmd.Flags().Bool("interactive", false, "prompt for the configuration (default when run in a terminal)")
 	initCmd.Flags().Bool("skip-claude-md", false, "skip CLAUDE.md generation")
 	initCmd.Flags().String("claude-md-output", "CLAUDE.md", "CLAUDE.md output path")
 }
@@ -68,6 +106,11 @@ func runConfigInit(cmd *cobra.Command, args []string) error {
 	skipClaudeMd, _ := cmd.Flags().GetBool("skip-claude-md")
 	claudeMdOutput, _ := cmd.Flags().GetString("claude-md-output")
 
+	interactive := isTerminalInput(cmd)
+	if cmd.Flags().Changed("interactive") {
+		interactive, _ = cmd.Flags().GetBool("interactive")
+	}
+
 	// Initialize config service
 	configService, err := initializeConfigService()
 	if err != nil {
@@ -78,8 +121,13 @@ func runConfigInit(cmd *cobra.Command, args []string) error {
 	configExists := fileExists(outputPath)
 
 	// Only create/overwrite config if it doesn't exist or force is set
+	var answers *initAnswers
 	if !configExists || force {
-		if err := configService.InitializeConfig(outputPath, force); err != nil {
+		if interactive {
+			if answers, err = writeWizardConfig(cmd, outputPath); err != nil {
+				return err
+			}
+		} else if err := configService.InitializeConfig(outputPath, force); err != nil {
 			return err
 		}
 
@@ -127,12 +175,16 @@ func runConfigInit(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Print next steps
-	cmd.Println("\nNext steps:")
-	cmd.Println("1. Edit the configuration file to set your organization ID")
-	cmd.Println("2. Run 'grctool auth login' to authenticate with Tugboat Logic")
-	cmd.Println("3. Adjust paths and settings as needed")
-	cmd.Println("4. Run 'grctool config validate' to verify your configuration")
+	if answers != nil {
+		runWizardFollowUp(cmd, answers)
+	} else {
+		// Print next steps
+		cmd.Println("\nNext steps:")
+		cmd.Println("1. Edit the configuration file to set your organization ID")
+		cmd.Println("2. Run 'grctool auth login' to authenticate with Tugboat Logic")
+		cmd.Println("3. Adjust paths and settings as needed")
+		cmd.Println("4. Run 'grctool config validate' to verify your configuration")
+	}
 	if !skipClaudeMd {
 		cmd.Println("\nCLAUDE.md has been generated with AI assistant instructions.")
 		cmd.Println("Agent documentation generated in .grctool/docs/ (use 'grctool agent-context' to view)")
@@ -150,6 +202,13 @@ func fileExists(path string) bool {
 
 func runConfigValidate(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
+	offline, _ := cmd.Flags().GetBool("offline")
+	skipPermissions, _ := cmd.Flags().GetBool("skip-permissions")
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
 
 	// Initialize config service
 	configService, err := initializeConfigService()
@@ -158,11 +217,24 @@ func runConfigValidate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Validate configuration
-	result, err := configService.ValidateConfig(ctx)
+	result, err := configService.ValidateConfig(ctx, config.ValidationOptions{
+		SkipConnectivity: offline,
+		SkipPermissions:  skipPermissions,
+	})
 	if err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
+	if isStructuredOutput(format) {
+		if err := writeStructured(cmd, format, result); err != nil {
+			return err
+		}
+		if !result.Valid {
+			return fmt.Errorf("configuration validation failed")
+		}
+		return nil
+	}
+
 	// Print validation results
 	printValidationResults(cmd, result)
 
@@ -198,7 +270,13 @@ func printValidationResults(cmd *cobra.Command, result *config.ValidationResult)
 	cmd.Printf("Duration: %v\n\n", result.Duration)
 
 	// Print individual check results
-	for _, check := range result.Checks {
+	keys := make([]string, 0, len(result.Checks))
+	for key := range result.Checks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		check := result.Checks[key]
 		symbol := getCheckStatusSymbol(check.Status)
 		cmd.Printf("%s %s: %s (%v)\n", symbol, check.Name, check.Message, check.Duration)
 	}
@@ -211,6 +289,13 @@ func printValidationResults(cmd *cobra.Command, result *config.ValidationResult)
 		}
 	}
 
+	if len(result.Warnings) > 0 {
+		cmd.Printf("\nWarnings:\n")
+		for _, warning := range result.Warnings {
+			cmd.Printf("  - %s\n", warning)
+		}
+	}
+
 	cmd.Println()
 }
 
@@ -229,6 +314,8 @@ func getCheckStatusSymbol(status string) string {
 		return "✗"
 	case "warning":
 		return "⚠"
+	case "skipped":
+		return "-"
 	default:
 		return "?"
 	}
@@ -0,0 +1,140 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/interpolation"
+	"github.com/grctool/grctool/internal/services"
+	"github.com/grctool/grctool/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+var configVariablesCmd = &cobra.Command{
+	Use:   "variables",
+	Short: "Document the interpolation variables and functions",
+	Long: `Write a markdown reference of the variables and functions available to
+policies, controls and evidence tasks, e.g. for policy authors.
+
+Variables come from interpolation.variables, overridden in the documents of
+a framework by interpolation.frameworks.<framework> and in those of a task by
+interpolation.tasks.<ref>. Without a scope every definition is listed; with
+--framework, --task or --window the variables a document of that scope sees
+are listed, each with the scope its value comes from.
+
+Documents use a variable as {{organization.name}} or [organization.name],
+call a function as {{upper organization.name}}, and pipe a value into one as
+{{organization.name | upper}}. Function arguments are variable names or
+double-quoted strings.
+
+Examples:
+  # Reference of every variable and function
+  grctool config variables > docs/variables.md
+
+  # What ET-0047's document sees
+  grctool config variables --framework "SOC 2" --task ET-0047 --window 2025-Q4
+
+  # For scripts
+  grctool config variables --output json`,
+	Args: cobra.NoArgs,
+	RunE: runConfigVariables,
+}
+
+func init() {
+	configCmd.AddCommand(configVariablesCmd)
+
+	configVariablesCmd.Flags().String("framework", "", "list the variables of this framework's documents")
+	configVariablesCmd.Flags().String("task", "", "list the variables of this evidence task's document")
+	configVariablesCmd.Flags().String("window", "", "collection window of the document, e.g. 2025-Q4")
+}
+
+// ConfigVariablesResult is the structured output of 'config variables'
+type ConfigVariablesResult struct {
+	Scope     *interpolation.Scope     `json:"scope,omitempty"`
+	Variables []interpolation.Variable `json:"variables"`
+	Functions []interpolation.Function `json:"functions"`
+}
+
+func runConfigVariables(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	var scope interpolation.Scope
+	scope.Framework, _ = cmd.Flags().GetString("framework")
+	scope.TaskRef, _ = cmd.Flags().GetString("task")
+	scope.Window, _ = cmd.Flags().GetString("window")
+
+	result := configVariables(services.NewInterpolator(cfg), scope)
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, result)
+	}
+	if !cfg.Interpolation.Enabled {
+		cmd.PrintErrln("⚠️  interpolation.enabled is false: documents are written without substituting these variables")
+	}
+	displayConfigVariables(cmd, result)
+	return nil
+}
+
+// configVariables lists the variables of the scope, or every definition for
+// the zero scope, with the functions
+func configVariables(interpolator *interpolation.StandardInterpolator, scope interpolation.Scope) ConfigVariablesResult {
+	if scope == (interpolation.Scope{}) {
+		return ConfigVariablesResult{Variables: interpolator.Definitions(), Functions: interpolation.Functions()}
+	}
+	return ConfigVariablesResult{Scope: &scope, Variables: interpolator.Variables(scope), Functions: interpolation.Functions()}
+}
+
+// displayConfigVariables writes the variable and function reference as
+// markdown
+func displayConfigVariables(cmd *cobra.Command, result ConfigVariablesResult) {
+	cmd.Println("# Interpolation Variables")
+	cmd.Println()
+	if result.Scope != nil {
+		cmd.Printf("Variables of documents with framework %s, task %s and window %s.\n\n",
+			orDash(result.Scope.Framework), orDash(result.Scope.TaskRef), orDash(result.Scope.Window))
+	}
+	cmd.Println("Use a variable as `{{name}}` or `[name]`, call a function as `{{upper name}}`, or pipe a value into one as `{{name | upper}}`.")
+	cmd.Println()
+
+	cmd.Println("## Variables")
+	cmd.Println()
+	if len(result.Variables) == 0 {
+		cmd.Println("No variables are configured under interpolation.variables.")
+	} else {
+		cmd.Println("| Variable | Value | Scope |")
+		cmd.Println("|----------|-------|-------|")
+		for _, v := range result.Variables {
+			cmd.Printf("| `%s` | %s | %s |\n", v.Name, templates.MarkdownCell(v.Value), v.Scope)
+		}
+	}
+	cmd.Println()
+
+	cmd.Println("## Functions")
+	cmd.Println()
+	cmd.Println("| Usage | Description |")
+	cmd.Println("|-------|-------------|")
+	for _, fn := range result.Functions {
+		cmd.Printf("| `{{%s}}` | %s |\n", fn.Usage, templates.MarkdownCell(fn.Description))
+	}
+}
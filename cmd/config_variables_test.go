@@ -0,0 +1,82 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/interpolation"
+	"github.com/grctool/grctool/internal/services"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigVariables(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Interpolation: config.InterpolationConfig{
+		Enabled:    true,
+		Variables:  map[string]interface{}{"organization": map[string]interface{}{"name": "Seventh Sense"}, "audit.firm": "Internal | Audit"},
+		Frameworks: map[string]map[string]interface{}{"soc2": {"audit.firm": "Prescient Assurance"}},
+		Tasks:      map[string]map[string]interface{}{"et-0047": {"review.cadence": "monthly"}},
+	}}
+	interpolator := services.NewInterpolator(cfg)
+
+	tests := map[string]struct {
+		scope     interpolation.Scope
+		variables []interpolation.Variable
+	}{
+		"every definition": {
+			variables: []interpolation.Variable{
+				{Name: "audit.firm", Value: "Internal | Audit", Scope: "global"},
+				{Name: "organization.name", Value: "Seventh Sense", Scope: "global"},
+				{Name: "audit.firm", Value: "Prescient Assurance", Scope: "frameworks.soc2"},
+				{Name: "review.cadence", Value: "monthly", Scope: "tasks.et-0047"},
+			},
+		},
+		"scoped": {
+			scope: interpolation.Scope{Framework: "SOC 2", TaskRef: "ET-0047", Window: "2025-Q4"},
+			variables: []interpolation.Variable{
+				{Name: "audit.firm", Value: "Prescient Assurance", Scope: "frameworks.soc2"},
+				{Name: "organization.name", Value: "Seventh Sense", Scope: "global"},
+				{Name: "review.cadence", Value: "monthly", Scope: "tasks.et-0047"},
+				{Name: "window", Value: "2025-Q4", Scope: "window"},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			result := configVariables(interpolator, tc.scope)
+			assert.Equal(t, tc.variables, result.Variables)
+			assert.Len(t, result.Functions, len(interpolation.Functions()))
+		})
+	}
+
+	t.Run("markdown", func(t *testing.T) {
+		t.Parallel()
+		var out bytes.Buffer
+		cmd := &cobra.Command{Use: "test"}
+		cmd.SetOut(&out)
+		displayConfigVariables(cmd, configVariables(interpolator, interpolation.Scope{TaskRef: "ET-0047"}))
+
+		assert.Contains(t, out.String(), "# Interpolation Variables\n\nVariables of documents with framework -, task ET-0047 and window -.\n")
+		assert.Contains(t, out.String(), "| `audit.firm` | Internal \\| Audit | global |\n")
+		assert.Contains(t, out.String(), "| `review.cadence` | monthly | tasks.et-0047 |\n")
+		assert.Contains(t, out.String(), "## Functions\n\n| Usage | Description |\n|-------|-------------|\n| `{{upper VALUE}}` | VALUE in upper case |\n")
+	})
+
+	t.Run("no variables", func(t *testing.T) {
+		t.Parallel()
+		var out bytes.Buffer
+		cmd := &cobra.Command{Use: "test"}
+		cmd.SetOut(&out)
+		displayConfigVariables(cmd, configVariables(services.NewInterpolator(&config.Config{}), interpolation.Scope{}))
+		require.Contains(t, out.String(), "No variables are configured under interpolation.variables.")
+	})
+}
@@ -25,22 +25,24 @@ import (
 	"github.com/grctool/grctool/internal/config"
 	"github.com/grctool/grctool/internal/domain"
 	"github.com/grctool/grctool/internal/formatters"
-	"github.com/grctool/grctool/internal/interpolation"
 	"github.com/grctool/grctool/internal/markdown"
+	"github.com/grctool/grctool/internal/services"
 	"github.com/grctool/grctool/internal/storage"
 	"github.com/spf13/cobra"
 )
 
 // controlCmd represents the control command
 var controlCmd = &cobra.Command{
-	Use:   "control",
-	Short: "Manage and view controls",
+	Use:     "control",
+	Aliases: []string{"controls"},
+	Short:   "Manage and view controls",
 	Long: `Commands for managing and viewing security controls from Tugboat Logic.
 
 This command group provides various operations for controls including:
 - Viewing controls in markdown format
 - Listing available controls
-- Searching controls by framework, category, or status`,
+- Searching controls by framework, category, or status
+- Finding controls without evidence (gaps)`,
 }
 
 // controlViewCmd represents the control view command
@@ -92,12 +94,7 @@ Examples:
 		}
 
 		// Create formatter with interpolation
-		interpolatorConfig := interpolation.InterpolatorConfig{
-			Variables:         cfg.Interpolation.GetFlatVariables(),
-			Enabled:           cfg.Interpolation.Enabled,
-			OnMissingVariable: interpolation.MissingVariableIgnore,
-		}
-		interpolator := interpolation.NewStandardInterpolator(interpolatorConfig)
+		interpolator := services.NewInterpolator(cfg)
 		formatter := formatters.NewControlFormatterWithInterpolation(interpolator)
 
 		// Get flags
@@ -198,12 +195,7 @@ Examples:
 		summaryMode, _ := cmd.Flags().GetBool("summary")
 
 		// Create formatter with interpolation
-		interpolatorConfig := interpolation.InterpolatorConfig{
-			Variables:         cfg.Interpolation.GetFlatVariables(),
-			Enabled:           cfg.Interpolation.Enabled,
-			OnMissingVariable: interpolation.MissingVariableIgnore,
-		}
-		interpolator := interpolation.NewStandardInterpolator(interpolatorConfig)
+		interpolator := services.NewInterpolator(cfg)
 		formatter := formatters.NewControlFormatterWithInterpolation(interpolator)
 
 		// Format output
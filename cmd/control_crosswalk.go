@@ -0,0 +1,149 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/frameworks"
+	"github.com/grctool/grctool/internal/services/export"
+	"github.com/grctool/grctool/internal/tools"
+	"github.com/spf13/cobra"
+)
+
+var controlCrosswalkCmd = &cobra.Command{
+	Use:   "crosswalk [control-ref...]",
+	Short: "Show equivalent requirements of each control across frameworks",
+	Long: `Show, for each control, the requirements it addresses in every framework
+and the equivalent controls of other frameworks, with the evidence that
+satisfies it.
+
+A control's requirements come from its framework codes, or are crosswalked
+from its SOC2 codes (SOC 2 CC6.1 addresses ISO 27001 A.5.15, NIST 800-53
+AC-2, and so on). Controls of different frameworks that address a common
+requirement are equivalent, and the evidence tasks of one satisfy the other:
+one submission covers the mapped controls in every framework. Each task's
+evidence is checked in its current window unless --window names one window
+for every task.
+
+Examples:
+  # Every control across every framework
+  grctool controls crosswalk
+
+  # Two controls, shown against SOC 2 and ISO 27001 only
+  grctool controls crosswalk CC6.1 ISO-01 --framework soc2 --framework iso27001
+
+  # Machine-readable crosswalk for a closed quarter
+  grctool controls crosswalk --window 2025-Q3 --output json`,
+	RunE: runControlCrosswalk,
+}
+
+func init() {
+	controlCmd.AddCommand(controlCrosswalkCmd)
+
+	controlCrosswalkCmd.Flags().StringSlice("framework", nil, "frameworks to show requirements of (default: every built-in and custom framework)")
+	controlCrosswalkCmd.Flags().String("window", "", "window to check for every task (default: each task's current window)")
+}
+
+func runControlCrosswalk(cmd *cobra.Command, args []string) error {
+	frameworkNames, _ := cmd.Flags().GetStringSlice("framework")
+	window, _ := cmd.Flags().GetString("window")
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	for _, name := range frameworkNames {
+		if _, err := frameworks.Resolve(name); err != nil {
+			return err
+		}
+	}
+
+	data, err := loadComplianceData(false)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	crosswalk := export.BuildCrosswalk(data, export.CrosswalkOptions{
+		Frameworks: frameworkNames,
+		Controls:   args,
+		Window:     window,
+		WindowFor: func(task domain.EvidenceTask) string {
+			return tools.CalculateEvidenceWindow(task.CollectionInterval, now)
+		},
+		GeneratedAt: now,
+	})
+	if len(args) > 0 && len(crosswalk.Controls) == 0 {
+		return fmt.Errorf("no controls found matching %s", strings.Join(args, ", "))
+	}
+
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, crosswalk)
+	}
+	displayControlCrosswalk(cmd, crosswalk)
+	return nil
+}
+
+func displayControlCrosswalk(cmd *cobra.Command, crosswalk *export.Crosswalk) {
+	if len(crosswalk.Controls) == 0 {
+		cmd.Println("No controls found. Run 'grctool sync' to download controls.")
+		return
+	}
+
+	labels := make([]string, 0, len(crosswalk.Frameworks))
+	for _, framework := range crosswalk.Frameworks {
+		labels = append(labels, frameworks.Label(framework))
+	}
+	satisfied := 0
+	for _, control := range crosswalk.Controls {
+		if control.Satisfied() {
+			satisfied++
+		}
+	}
+	cmd.Printf("🔀 Crosswalk of %d controls across %s (%d satisfied by submitted evidence)\n",
+		len(crosswalk.Controls), strings.Join(labels, ", "), satisfied)
+
+	for _, control := range crosswalk.Controls {
+		status := "⚠️  no submitted evidence"
+		if control.Satisfied() {
+			status = "✅ satisfied"
+		}
+		cmd.Printf("\n%s  %s", control.Ref, control.Name)
+		if control.Framework != "" {
+			cmd.Printf(" (%s)", control.Framework)
+		}
+		cmd.Printf("  %s\n", status)
+
+		for _, requirement := range control.Requirements {
+			cmd.Printf("  %-12s %s\n", frameworks.Label(requirement.Framework)+":", strings.Join(requirement.Codes, ", "))
+		}
+		if len(control.Equivalents) > 0 {
+			cmd.Printf("  %-12s %s\n", "Equivalent:", strings.Join(control.Equivalents, ", "))
+		}
+		for _, evidence := range control.Evidence {
+			line := fmt.Sprintf("  %-12s %s %s", "Evidence:", evidence.TaskRef, evidence.TaskName)
+			if evidence.Via != "" {
+				line += " via " + evidence.Via
+			}
+			line += fmt.Sprintf(" (%s, %s)", orDash(evidence.Window), orDash(evidence.Status))
+			cmd.Println(line)
+		}
+	}
+}
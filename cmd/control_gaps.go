@@ -0,0 +1,147 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/services"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/grctool/grctool/internal/tools"
+	"github.com/spf13/cobra"
+)
+
+var controlGapsCmd = &cobra.Command{
+	Use:   "gaps",
+	Short: "Find controls and tasks without evidence",
+	Long: `Cross-reference controls with their evidence tasks and the evidence
+collected, listing:
+
+- controls with no evidence task mapped to them
+- evidence tasks with no evidence, working or submitted, in the current window
+- controls whose only evidence failed validation ('grctool evidence validate')
+
+Each task's current window follows its collection interval (quarterly tasks
+use 2025-Q4, annual tasks 2025, and so on); --window checks one window for
+every task instead.
+
+Examples:
+  # Gaps in the current windows
+  grctool controls gaps
+
+  # SOC 2 gaps for a closed quarter
+  grctool controls gaps --framework SOC2 --window 2025-Q3
+
+  # Fail a CI job when any control lacks evidence
+  grctool controls gaps --fail --output json`,
+	Args: cobra.NoArgs,
+	RunE: runControlGaps,
+}
+
+func init() {
+	controlCmd.AddCommand(controlGapsCmd)
+
+	controlGapsCmd.Flags().String("framework", "", "only controls and tasks of this framework")
+	controlGapsCmd.Flags().String("window", "", "window to check for every task (default: each task's current window)")
+	controlGapsCmd.Flags().Bool("fail", false, "exit non-zero when gaps are found")
+}
+
+func runControlGaps(cmd *cobra.Command, args []string) error {
+	framework, _ := cmd.Flags().GetString("framework")
+	window, _ := cmd.Flags().GetString("window")
+	failOnGaps, _ := cmd.Flags().GetBool("fail")
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	now := time.Now()
+	report, err := services.AnalyzeControlGaps(store, services.ControlGapOptions{
+		Framework: framework,
+		Window:    window,
+		WindowFor: func(task domain.EvidenceTask) string {
+			return tools.CalculateEvidenceWindow(task.CollectionInterval, now)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if isStructuredOutput(format) {
+		if err := writeStructured(cmd, format, report); err != nil {
+			return err
+		}
+	} else {
+		displayControlGaps(cmd, report)
+	}
+
+	if failOnGaps && report.Total() > 0 {
+		return fmt.Errorf("%d control gaps found", report.Total())
+	}
+	return nil
+}
+
+func displayControlGaps(cmd *cobra.Command, report *services.ControlGapReport) {
+	if report.Total() == 0 {
+		cmd.Printf("✅ No gaps: every one of %d controls has evidence tasks, and all %d tasks have evidence\n",
+			report.Controls, report.Tasks)
+		return
+	}
+
+	cmd.Printf("⚠️  %d gaps across %d controls and %d evidence tasks\n", report.Total(), report.Controls, report.Tasks)
+
+	if len(report.UnmappedControls) > 0 {
+		cmd.Printf("\n🧩 Controls with no evidence tasks (%d)\n", len(report.UnmappedControls))
+		for _, gap := range report.UnmappedControls {
+			cmd.Printf("  %s  %s\n", gap.Ref, gap.Name)
+		}
+	}
+
+	if len(report.TasksWithoutEvidence) > 0 {
+		cmd.Printf("\n📭 Tasks with no evidence in the window (%d)\n", len(report.TasksWithoutEvidence))
+		for _, gap := range report.TasksWithoutEvidence {
+			line := fmt.Sprintf("  %s  %s (%s)", gap.TaskRef, gap.TaskName, gap.Window)
+			if len(gap.Controls) > 0 {
+				line += " → " + strings.Join(gap.Controls, ", ")
+			}
+			cmd.Println(line)
+		}
+	}
+
+	if len(report.FailedControls) > 0 {
+		cmd.Printf("\n❌ Controls whose only evidence failed validation (%d)\n", len(report.FailedControls))
+		for _, gap := range report.FailedControls {
+			cmd.Printf("  %s  %s ← %s\n", gap.Ref, gap.Name, strings.Join(gap.Tasks, ", "))
+		}
+	}
+
+	cmd.Println()
+	cmd.Println("Map tasks to controls in Tugboat, then collect evidence with grctool evidence generate <task-ref>")
+}
@@ -0,0 +1,312 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/daemon"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/grctool/grctool/internal/tools"
+	"github.com/spf13/cobra"
+)
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Continuously refresh evidence tool data as sources change",
+	Long: `Watch configured sources and schedules, keeping tool data fresh.
+
+Every poll interval the daemon checks each source under daemon.sources in
+the config. A git checkout counts as changed when its HEAD commit moves; any
+other directory when a file matching the source's include patterns is added,
+removed or modified. When a source changes, the tools of the evidence tasks
+it feeds are re-run and their output is written to
+
+  <data_dir>/evidence/<task>/<window>/.context/tool_outputs/<tool>.json
+
+Tools are taken from the source, then from the task's schedules.task_mappings
+entry, then from the tools applicable to the task. A failed refresh is retried
+on the next poll. Due schedules are run on every poll as by 'schedule run'.
+
+State is kept in <data_dir>/.state/daemon_state.yaml, so a restarted daemon
+only refreshes sources that changed while it was stopped.
+
+Examples:
+  # Run in the foreground until interrupted
+  grctool daemon
+
+  # Poll once, e.g. from cron or CI
+  grctool daemon --once
+
+  # Watch sources only, every minute
+  grctool daemon --interval 1m --no-schedules`,
+	Args: cobra.NoArgs,
+	RunE: runDaemon,
+}
+
+func init() {
+	rootCmd.AddCommand(daemonCmd)
+
+	daemonCmd.Flags().Bool("once", false, "poll once and exit")
+	daemonCmd.Flags().Duration("interval", 0, "poll interval (default: daemon.poll_interval)")
+	daemonCmd.Flags().String("window", "", "evidence window for tool output (default: current quarter)")
+	daemonCmd.Flags().Bool("no-schedules", false, "only watch sources; do not run due schedules")
+}
+
+func runDaemon(cmd *cobra.Command, args []string) error {
+	once, _ := cmd.Flags().GetBool("once")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	window, _ := cmd.Flags().GetString("window")
+	noSchedules, _ := cmd.Flags().GetBool("no-schedules")
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	if interval <= 0 {
+		interval = cfg.Daemon.PollInterval
+	}
+	var schedules daemon.ScheduleFunc
+	if !noSchedules && len(cfg.Schedules.Schedules) > 0 {
+		schedules = dueScheduleRunner(cmd.OutOrStdout(), window)
+	}
+	sources := daemonSources(cfg.Daemon.Sources)
+	if len(sources) == 0 && schedules == nil {
+		return errors.New("nothing to watch: configure daemon.sources or schedules")
+	}
+
+	refresher := &toolRefresher{cfg: cfg, store: store, window: window}
+	d := daemon.New(sources, daemon.Options{
+		Interval:  interval,
+		StateDir:  filepath.Join(cfg.Storage.DataDir, ".state"),
+		Refresh:   refresher.Refresh,
+		Schedules: schedules,
+	}, logger.WithComponent("daemon"))
+
+	if once {
+		result, err := d.Poll(cmd.Context(), time.Now())
+		if err != nil {
+			return err
+		}
+		if isStructuredOutput(format) {
+			return writeStructured(cmd, format, result)
+		}
+		printDaemonPoll(cmd, *result, true)
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	history := storage.NewGitHistory(cfg.Storage)
+	cmd.Printf("👀 Watching %d source(s), polling every %s (Ctrl+C to stop)\n", len(sources), interval)
+	if err := d.Run(ctx, func(result daemon.PollResult) {
+		printDaemonPoll(cmd, result, false)
+		if cfg.Storage.Encryption.Enabled {
+			if _, err := encryptSensitiveEvidence(cfg); err != nil {
+				cmd.Printf("[%s] ❌ %v\n", result.Time.Format("15:04:05"), err)
+			}
+		}
+		if cfg.Storage.Dedup.Enabled {
+			if _, err := dedupEvidence(cfg, false); err != nil {
+				cmd.Printf("[%s] ❌ %v\n", result.Time.Format("15:04:05"), err)
+			}
+		}
+		commitDaemonPoll(ctx, cmd, history, result)
+	}); err != nil {
+		return err
+	}
+	cmd.Println("👋 Daemon stopped")
+	return nil
+}
+
+// daemonSources converts configured watch sources
+func daemonSources(configured []config.WatchSourceConfig) []daemon.Source {
+	sources := make([]daemon.Source, 0, len(configured))
+	for _, source := range configured {
+		sources = append(sources, daemon.Source{
+			Name:    source.Name,
+			Path:    source.Path,
+			Include: source.Include,
+			Tasks:   source.Tasks,
+			Tools:   source.Tools,
+		})
+	}
+	return sources
+}
+
+// printDaemonPoll reports changed and failing sources, and unchanged ones
+// when showUnchanged is set
+func printDaemonPoll(cmd *cobra.Command, result daemon.PollResult, showUnchanged bool) {
+	stamp := result.Time.Format("15:04:05")
+	for _, source := range result.Sources {
+		switch {
+		case source.Error != "":
+			cmd.Printf("[%s] ❌ %s: %s\n", stamp, source.Name, source.Error)
+		case source.Changed:
+			cmd.Printf("[%s] 🔄 %s changed, refreshed %s\n", stamp, source.Name, strings.Join(source.Refreshed, ", "))
+		case showUnchanged:
+			cmd.Printf("[%s] ✅ %s unchanged\n", stamp, source.Name)
+		}
+	}
+	if result.ScheduleError != "" {
+		cmd.Printf("[%s] ❌ schedules: %s\n", stamp, result.ScheduleError)
+	}
+}
+
+// commitDaemonPoll commits what a poll changed in data_dir when
+// storage.git.enabled is on, as the daemon never exits to do it
+func commitDaemonPoll(ctx context.Context, cmd *cobra.Command, history *storage.GitHistory, result daemon.PollResult) {
+	if history == nil {
+		return
+	}
+	var refreshed []string
+	seen := map[string]bool{}
+	for _, source := range result.Sources {
+		for _, ref := range source.Refreshed {
+			if !seen[ref] {
+				seen[ref] = true
+				refreshed = append(refreshed, ref)
+			}
+		}
+	}
+	subject := "grctool daemon: scheduled collection"
+	if len(refreshed) > 0 {
+		subject = "grctool daemon: refreshed " + strings.Join(refreshed, ", ")
+	}
+
+	stamp := result.Time.Format("15:04:05")
+	hash, err := history.Commit(ctx, subject)
+	switch {
+	case err != nil:
+		cmd.Printf("[%s] ❌ git: %v\n", stamp, err)
+	case hash != "":
+		cmd.Printf("[%s] 📝 committed %s\n", stamp, hash)
+	}
+}
+
+// dueScheduleRunner runs the schedules that are due on each daemon poll
+func dueScheduleRunner(out io.Writer, window string) daemon.ScheduleFunc {
+	return func(ctx context.Context, now time.Time) error {
+		s, err := loadScheduler()
+		if err != nil {
+			return err
+		}
+		due, err := s.GetDueSchedules(now)
+		if err != nil {
+			return fmt.Errorf("checking due schedules: %w", err)
+		}
+		if len(due) == 0 {
+			return nil
+		}
+
+		router, err := newToolOutputRouter(window)
+		if err != nil {
+			return err
+		}
+		orch := loadOrchestrator(s)
+		failed := 0
+		for _, sched := range due {
+			if summary := runAndRecordSchedule(ctx, out, s, orch, sched, now, router); summary.Failed > 0 {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d due schedules had failing tools", failed, len(due))
+		}
+		return nil
+	}
+}
+
+// toolRefresher re-runs tools for evidence tasks and stores their output in
+// the task's window directory
+type toolRefresher struct {
+	cfg    *config.Config
+	store  *storage.Storage
+	window string // Empty means the current quarter, evaluated per refresh
+}
+
+// Refresh implements daemon.RefreshFunc
+func (r *toolRefresher) Refresh(ctx context.Context, taskRef string, toolNames []string) error {
+	task, err := r.store.GetEvidenceTask(taskRef)
+	if err != nil {
+		return err
+	}
+	if len(toolNames) == 0 {
+		toolNames = daemonToolsForTask(r.cfg, task)
+	}
+	if len(toolNames) == 0 {
+		return errors.New("no tools to run: list tools on the source or add a schedules.task_mappings entry")
+	}
+
+	window := r.window
+	if window == "" {
+		window = getCurrentQuarter()
+	}
+
+	var errs []error
+	for _, toolName := range toolNames {
+		tool, err := tools.GetTool(toolName)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		result, _, err := tool.Execute(ctx, createToolRequestForEvidence(task, toolName, r.cfg))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", toolName, err))
+			continue
+		}
+		if err := saveToolOutput(r.cfg, task, window, toolName, result); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// daemonToolsForTask picks tools for a task whose source lists none: its
+// schedule task mapping, else the tools applicable to the task
+func daemonToolsForTask(cfg *config.Config, task *domain.EvidenceTask) []string {
+	for _, mapping := range cfg.Schedules.TaskMappings {
+		if strings.EqualFold(mapping.TaskRef, task.ReferenceID) && len(mapping.Tools) > 0 {
+			return mapping.Tools
+		}
+	}
+	toolNames := applicableToolsForTask(context.Background(), cfg, task, identifyApplicableTools(task))
+	sort.Strings(toolNames)
+	return toolNames
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDaemonToolsForTask(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Schedules: config.SchedulesConfig{
+		TaskMappings: []config.TaskMappingConfig{
+			{TaskRef: "ET-0001", Tools: []string{"github-permissions"}, Schedule: "weekly"},
+		},
+	}}
+
+	mapped := &domain.EvidenceTask{ReferenceID: "ET-0001", Name: "Terraform review"}
+	assert.Equal(t, []string{"github-permissions"}, daemonToolsForTask(cfg, mapped))
+
+	inferred := &domain.EvidenceTask{ReferenceID: "ET-0002", Name: "Terraform infrastructure review"}
+	assert.Equal(t, []string{"terraform-security-analyzer", "terraform-security-indexer"}, daemonToolsForTask(cfg, inferred),
+		"inferred tools are sorted")
+
+	assert.Empty(t, daemonToolsForTask(cfg, &domain.EvidenceTask{ReferenceID: "ET-0003", Name: "Board minutes"}))
+}
+
+func TestToolRefresher_Refresh(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Storage: config.StorageConfig{DataDir: t.TempDir()}}
+	store, err := storage.NewStorage(cfg.Storage)
+	require.NoError(t, err)
+	require.NoError(t, store.SaveEvidenceTask(&domain.EvidenceTask{ID: "1001", ReferenceID: "ET-0001", Name: "Board minutes"}))
+
+	refresher := &toolRefresher{cfg: cfg, store: store, window: "2025-Q4"}
+
+	err = refresher.Refresh(context.Background(), "ET-0001", nil)
+	assert.ErrorContains(t, err, "no tools to run")
+
+	err = refresher.Refresh(context.Background(), "ET-0001", []string{"no-such-tool"})
+	assert.ErrorContains(t, err, "no-such-tool")
+
+	assert.Error(t, refresher.Refresh(context.Background(), "ET-0999", []string{"no-such-tool"}))
+}
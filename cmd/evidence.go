@@ -18,28 +18,38 @@ package cmd
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"text/tabwriter"
 	"time"
 
 	"github.com/grctool/grctool/internal/config"
 	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/drata"
 	"github.com/grctool/grctool/internal/formatters"
-	"github.com/grctool/grctool/internal/interpolation"
+	"github.com/grctool/grctool/internal/frameworks"
+	"github.com/grctool/grctool/internal/hyperproof"
 	"github.com/grctool/grctool/internal/logger"
 	"github.com/grctool/grctool/internal/models"
 	"github.com/grctool/grctool/internal/naming"
 	"github.com/grctool/grctool/internal/providers"
+	"github.com/grctool/grctool/internal/redaction"
+	"github.com/grctool/grctool/internal/secureframe"
 	"github.com/grctool/grctool/internal/services"
 	"github.com/grctool/grctool/internal/services/evidence"
+	"github.com/grctool/grctool/internal/services/matching"
 	"github.com/grctool/grctool/internal/services/submission"
+	"github.com/grctool/grctool/internal/signing"
 	"github.com/grctool/grctool/internal/storage"
+	"github.com/grctool/grctool/internal/templates"
 	"github.com/grctool/grctool/internal/tools"
 	"github.com/grctool/grctool/internal/tugboat"
+	"github.com/grctool/grctool/internal/vanta"
 	"github.com/spf13/cobra"
 )
 
@@ -60,8 +70,18 @@ var evidenceCmd = &cobra.Command{
 var evidenceListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List evidence tasks",
-	Long:  `List all evidence collection tasks from Tugboat Logic`,
-	RunE:  runEvidenceList,
+	Long: `List all evidence collection tasks from Tugboat Logic
+
+Examples:
+  # Show pending tasks as a table
+  grctool evidence list --status pending
+
+  # Export the task inventory for a spreadsheet
+  grctool evidence list --export-file evidence-tasks.csv
+
+  # Export a markdown report to stdout
+  grctool evidence list --framework soc2 --format md`,
+	RunE: runEvidenceList,
 }
 
 var evidenceViewCmd = &cobra.Command{
@@ -82,7 +102,7 @@ Examples:
   grctool evidence view 327992
 
   # Save evidence task to markdown file
-  grctool evidence view ET-0001 --output task-ET-0001.md`,
+  grctool evidence view ET-0001 --export-file task-ET-0001.md`,
 	Args: cobra.ExactArgs(1),
 	RunE: runEvidenceView,
 }
@@ -90,15 +110,46 @@ Examples:
 var evidenceMapCmd = &cobra.Command{
 	Use:   "map",
 	Short: "Map relationships between evidence tasks, controls, and policies",
-	Long:  `Create a visual map showing the relationships between evidence tasks, controls, and policies.`,
-	RunE:  runEvidenceMap,
+	Long: `Create a visual map showing the relationships between evidence tasks, controls, and policies.
+
+Use --framework iso27001 to also map controls and evidence tasks onto the
+ISO 27001:2022 Annex A controls and list the Annex A controls without evidence.
+Use --framework hipaa to map them onto the HIPAA Security Rule administrative,
+physical and technical safeguards and list the safeguards without evidence.
+Use --framework nist80053 or --framework pci to map them onto the NIST SP 800-53
+Rev. 5 controls or PCI DSS v4.0 requirements they reference, directly or through
+the SOC2 crosswalk.`,
+	RunE: runEvidenceMap,
 }
 
 var evidenceGenerateCmd = &cobra.Command{
 	Use:   "generate [task-id]",
 	Short: "Generate evidence using coordinated tools",
-	Long:  `Generate evidence for a specific task using coordinated tool analysis of your infrastructure and documentation.`,
-	RunE:  runEvidenceGenerate,
+	Long: `Generate evidence for a specific task using coordinated tool analysis of your infrastructure and documentation.
+
+By default generate writes an assembly prompt for an assistant-driven session.
+With --synthesize it runs the task's applicable tools and has the language
+model configured under evidence.llm (or evidence.llm.operations.synthesis)
+draft <task>_Evidence.md from the assembly prompt and tool outputs. The draft
+is marked machine-drafted and pending review: 'evidence submit' refuses it
+until a reviewer runs 'grctool evidence approve'.
+
+With --all --synthesize it drafts every pending task that can be automated,
+recording the tokens and cost of each draft in its generation metadata. The
+batch stops before it would exceed the budget set by evidence.synthesis or
+--budget-tokens and --budget-cost; running it again continues with the tasks
+not yet drafted.
+
+Examples:
+  # Write the assembly prompt for an assistant session
+  grctool evidence generate ET-0047 --window 2025-Q4
+
+  # Draft the evidence with the configured model
+  grctool evidence generate ET-0047 --window 2025-Q4 --synthesize
+
+  # Draft all pending tasks, spending at most $5
+  grctool evidence generate --all --window 2025-Q4 --synthesize --budget-cost 5`,
+	RunE: runEvidenceGenerate,
 }
 
 var evidenceReviewCmd = &cobra.Command{
@@ -110,11 +161,35 @@ var evidenceReviewCmd = &cobra.Command{
 }
 
 var evidenceSubmitCmd = &cobra.Command{
-	Use:   "submit [task-id]",
-	Short: "Submit evidence to Tugboat Logic",
-	Long:  `Submit completed evidence to Tugboat Logic for compliance review.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runEvidenceSubmit,
+	Use:   "submit [task-id | --all]",
+	Short: "Submit evidence to Tugboat Logic, another GRC platform or an auditor dropbox",
+	Long: `Submit completed evidence to Tugboat Logic, Vanta, Drata, Secureframe or
+Hyperproof for compliance review, or deliver it to an auditor's SFTP server,
+S3 bucket or webhook.
+
+Evidence goes to the first target the task is configured for: its Tugboat
+collector under tugboat.collector_urls, its Vanta document under
+vanta.document_ids, its Drata control under drata.control_ids, its
+Secureframe test under secureframe.test_ids, its Hyperproof label or control
+under hyperproof.label_ids or hyperproof.control_ids, or its delivery URL
+under delivery.urls. Deliveries are a zip of the window's files with a
+manifest of their checksums. Use --target to choose when a task is configured
+for several.
+
+Failed uploads are retried with exponential backoff. Files that still fail
+stay in the window, while the uploaded ones move to .submitted/, and are
+recorded in .submission/submission.yaml; --retry-failed uploads just those.
+
+With --queue, a submission whose target cannot be reached is queued locally
+instead of failing; --offline queues it without trying, for air-gapped
+environments. 'grctool queue flush' sends queued submissions in order.
+
+With --all instead of a task ID, every task whose evidence in the window
+passed validation is submitted. A pre-flight summary lists the tasks that are
+ready and why the others are skipped, followed by each task's result and a
+report of the failures; --dry-run stops after the summary.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runEvidenceSubmit,
 }
 
 func init() {
@@ -156,9 +231,11 @@ func init() {
 
 	// Evidence list flags
 	evidenceListCmd.Flags().StringSlice("status", []string{}, "filter by status (pending, completed, overdue)")
-	evidenceListCmd.Flags().String("framework", "", "filter by framework (soc2, iso27001, etc)")
+	evidenceListCmd.Flags().String("framework", "", "filter by framework, matching framework codes of related controls too (soc2, iso27001, nist80053, pci, hipaa, etc)")
 	evidenceListCmd.Flags().StringSlice("priority", []string{}, "filter by priority (high, medium, low)")
-	evidenceListCmd.Flags().String("assignee", "", "filter by assignee")
+	evidenceListCmd.Flags().String("assignee", "", "filter by assignee (member ID, email or name)")
+	evidenceListCmd.Flags().Bool("mine", false, "show only tasks assigned to you (user.email in config, else git's user.email)")
+	evidenceListCmd.MarkFlagsMutuallyExclusive("assignee", "mine")
 	evidenceListCmd.Flags().Bool("overdue", false, "show only overdue tasks")
 	evidenceListCmd.Flags().Bool("due-soon", false, "show tasks due within 7 days")
 	evidenceListCmd.Flags().StringSlice("category", []string{}, "filter by category (Infrastructure, Personnel, Process, Compliance, Monitoring, Data)")
@@ -166,9 +243,14 @@ func init() {
 	evidenceListCmd.Flags().StringSlice("collection-type", []string{}, "filter by collection type (Manual, Automated, Hybrid)")
 	evidenceListCmd.Flags().Bool("sensitive", false, "show only sensitive data tasks")
 	evidenceListCmd.Flags().StringSlice("complexity", []string{}, "filter by complexity level (Simple, Moderate, Complex)")
+	evidenceListCmd.Flags().String("format", "", "export format (csv, json, md); inferred from --export-file extension if omitted")
+	evidenceListCmd.Flags().String("export-file", "", "write the export to this file instead of stdout")
+
+	// Evidence map flags
+	evidenceMapCmd.Flags().String("framework", frameworks.SOC2, "framework to map controls to (soc2, iso27001, nist80053, pci, hipaa, or a custom framework name)")
 
 	// Evidence view flags
-	evidenceViewCmd.Flags().StringP("output", "o", "", "output file path (optional)")
+	evidenceViewCmd.Flags().String("export-file", "", "write the task markdown to this file instead of stdout")
 	evidenceViewCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		if len(args) == 0 {
 			return completeTaskRefs(cmd, args, toComplete)
@@ -184,6 +266,9 @@ func init() {
 	evidenceGenerateCmd.Flags().String("window", "", "evidence collection window (e.g., 2025-Q4)")
 	evidenceGenerateCmd.Flags().Bool("context-only", false, "only generate context document, don't prompt for generation")
 	evidenceGenerateCmd.Flags().Bool("with-tool-data", false, "execute applicable tools and collect data during context generation")
+	evidenceGenerateCmd.Flags().Bool("synthesize", false, "draft the evidence document with the configured language model, pending human review")
+	evidenceGenerateCmd.Flags().Int("budget-tokens", 0, "with --all --synthesize, stop before spending more tokens than this (default: evidence.synthesis.max_tokens)")
+	evidenceGenerateCmd.Flags().Float64("budget-cost", 0, "with --all --synthesize, stop before spending more USD than this (default: evidence.synthesis.max_cost)")
 
 	// Evidence review flags
 	evidenceReviewCmd.Flags().String("window", "", "evidence collection window (e.g., 2025-Q4)")
@@ -194,13 +279,29 @@ func init() {
 	evidenceSubmitCmd.Flags().String("window", "", "evidence collection window (e.g., 2025-Q4)")
 	evidenceSubmitCmd.Flags().String("notes", "", "submission notes for auditors")
 	evidenceSubmitCmd.Flags().Bool("skip-validation", false, "skip evidence validation checks")
-	evidenceSubmitCmd.Flags().Bool("dry-run", false, "preview submission without uploading to Tugboat")
+	evidenceSubmitCmd.Flags().Bool("dry-run", false, "preview submission without uploading")
+	evidenceSubmitCmd.Flags().String("target", "", "submission target (tugboat, vanta, drata, secureframe, hyperproof, delivery); defaults to the first the task is configured for")
+	evidenceSubmitCmd.Flags().Bool("retry-failed", false, "upload only the files that failed in the window's last submission")
+	evidenceSubmitCmd.Flags().Bool("queue", false, "queue the submission for 'grctool queue flush' when the target cannot be reached")
+	evidenceSubmitCmd.Flags().Bool("offline", false, "queue the submission for 'grctool queue flush' without contacting the target, e.g. when air-gapped")
+	evidenceSubmitCmd.Flags().Bool("all", false, "submit every task whose evidence in the window passed validation")
 	evidenceSubmitCmd.MarkFlagRequired("window")
 }
 
 func runEvidenceList(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	exportFile, _ := cmd.Flags().GetString("export-file")
+	exportFormatFlag, _ := cmd.Flags().GetString("format")
+	exportFormat, err := resolveExportFormat(exportFormatFlag, exportFile)
+	if err != nil {
+		return err
+	}
+
 	// Initialize service
 	evidenceService, err := initializeEvidenceService()
 	if err != nil {
@@ -209,6 +310,15 @@ func runEvidenceList(cmd *cobra.Command, args []string) error {
 
 	// Build filter from flags
 	filter := buildEvidenceFilterFromFlags(cmd)
+	if mine, _ := cmd.Flags().GetBool("mine"); mine {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if filter.AssignedTo, err = currentUser(cfg); err != nil {
+			return err
+		}
+	}
 
 	// Get filtered tasks
 	tasks, err := evidenceService.ListEvidenceTasks(ctx, filter)
@@ -216,15 +326,56 @@ func runEvidenceList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to list evidence tasks: %w", err)
 	}
 
+	if exportFormat != "" {
+		summary, _ := evidenceService.GetEvidenceTaskSummary(ctx)
+		return exportEvidenceList(cmd, exportFormat, exportFile, newEvidenceListResult(tasks, summary, time.Now()))
+	}
+
+	if isStructuredOutput(format) {
+		summary, _ := evidenceService.GetEvidenceTaskSummary(ctx)
+		return writeStructured(cmd, format, newEvidenceListResult(tasks, summary, time.Now()))
+	}
+
 	// Display tasks
 	return displayEvidenceTasks(cmd, tasks, evidenceService, ctx)
 }
 
+// exportEvidenceList writes the task inventory to exportFile, or stdout when empty
+func exportEvidenceList(cmd *cobra.Command, exportFormat, exportFile string, result EvidenceListResult) error {
+	if exportFile == "" {
+		return writeEvidenceListExport(cmd.OutOrStdout(), exportFormat, result, time.Now())
+	}
+
+	// Ensure output directory exists
+	if err := os.MkdirAll(filepath.Dir(exportFile), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	file, err := os.Create(exportFile)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	if err := writeEvidenceListExport(file, exportFormat, result, time.Now()); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "✅ Exported %d evidence task(s) to: %s\n", result.Count, exportFile)
+	return nil
+}
+
 func runEvidenceView(cmd *cobra.Command, args []string) error {
 	taskIDOrRef := args[0]
 
 	// Get flags
-	outputFile, _ := cmd.Flags().GetString("output")
+	exportFile, _ := cmd.Flags().GetString("export-file")
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
 
 	// Load configuration
 	cfg, err := config.Load()
@@ -244,15 +395,14 @@ func runEvidenceView(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("evidence task not found: %s", taskIDOrRef)
 	}
 
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, EvidenceViewResult{Task: task})
+	}
+
 	// Initialize formatter with interpolation if enabled
 	var formatter *formatters.EvidenceTaskFormatter
 	if cfg.Interpolation.Enabled {
-		interpolatorConfig := interpolation.InterpolatorConfig{
-			Variables:         cfg.Interpolation.GetFlatVariables(),
-			Enabled:           true,
-			OnMissingVariable: interpolation.MissingVariableIgnore,
-		}
-		interpolator := interpolation.NewStandardInterpolator(interpolatorConfig)
+		interpolator := services.NewInterpolator(cfg)
 		formatter = formatters.NewEvidenceTaskFormatterWithInterpolation(interpolator)
 	} else {
 		formatter = formatters.NewEvidenceTaskFormatter()
@@ -262,18 +412,18 @@ func runEvidenceView(cmd *cobra.Command, args []string) error {
 	markdown := formatter.ToDocumentMarkdown(task)
 
 	// Output markdown
-	if outputFile != "" {
+	if exportFile != "" {
 		// Ensure output directory exists
-		if err := os.MkdirAll(filepath.Dir(outputFile), 0755); err != nil {
+		if err := os.MkdirAll(filepath.Dir(exportFile), 0755); err != nil {
 			return fmt.Errorf("failed to create output directory: %w", err)
 		}
 
 		// Write to file
-		if err := os.WriteFile(outputFile, []byte(markdown), 0644); err != nil {
+		if err := os.WriteFile(exportFile, []byte(markdown), 0644); err != nil {
 			return fmt.Errorf("failed to write output file: %w", err)
 		}
 
-		fmt.Fprintf(cmd.OutOrStdout(), "✅ Evidence task exported to: %s\n", outputFile)
+		fmt.Fprintf(cmd.OutOrStdout(), "✅ Evidence task exported to: %s\n", exportFile)
 	} else {
 		// Print to stdout
 		fmt.Fprint(cmd.OutOrStdout(), markdown)
@@ -285,6 +435,16 @@ func runEvidenceView(cmd *cobra.Command, args []string) error {
 func runEvidenceMap(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	frameworkFlag, _ := cmd.Flags().GetString("framework")
+	framework, err := frameworks.Resolve(frameworkFlag)
+	if err != nil {
+		return err
+	}
+
 	// Initialize service
 	evidenceService, err := initializeEvidenceService()
 	if err != nil {
@@ -296,13 +456,18 @@ func runEvidenceMap(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to map evidence relationships: %w", err)
 	}
+	evidence.MapFrameworkControls(mapResult, framework)
+
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, mapResult)
+	}
 
 	// Display mapping results
 	return displayEvidenceMap(cmd, mapResult)
 }
 
 func runEvidenceGenerate(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := commandContext(cmd)
 
 	// Initialize service
 	evidenceService, err := initializeEvidenceService()
@@ -333,7 +498,26 @@ func must[T any](val T, err error) T {
 }
 
 func processEvidenceGeneration(cmd *cobra.Command, evidenceService interface{}, options evidence.BulkGenerationOptions, args []string, ctx context.Context) error {
+	synthesize, _ := cmd.Flags().GetBool("synthesize")
+	if (cmd.Flags().Changed("budget-tokens") || cmd.Flags().Changed("budget-cost")) && !(synthesize && options.All) {
+		return fmt.Errorf("--budget-tokens and --budget-cost limit batch synthesis: use them with --all --synthesize")
+	}
+
 	// Check if --all flag is set for bulk generation
+	if synthesize && options.All {
+		if contextOnly, _ := cmd.Flags().GetBool("context-only"); contextOnly {
+			return fmt.Errorf("--synthesize and --context-only cannot be combined")
+		}
+		svc, ok := evidenceService.(evidence.Service)
+		if !ok {
+			return fmt.Errorf("invalid evidence service type")
+		}
+		window, _ := cmd.Flags().GetString("window")
+		if window == "" {
+			window = getCurrentQuarter()
+		}
+		return synthesizeAllEvidence(cmd, svc, options, window, ctx)
+	}
 	if options.All {
 		return processBulkEvidenceGeneration(cmd, evidenceService, options, ctx)
 	}
@@ -346,6 +530,9 @@ func processEvidenceGeneration(cmd *cobra.Command, evidenceService interface{},
 	taskRef := args[0]
 	window, _ := cmd.Flags().GetString("window")
 	contextOnly, _ := cmd.Flags().GetBool("context-only")
+	if synthesize && contextOnly {
+		return fmt.Errorf("--synthesize and --context-only cannot be combined")
+	}
 
 	// Default window to current quarter if not specified
 	if window == "" {
@@ -356,39 +543,150 @@ func processEvidenceGeneration(cmd *cobra.Command, evidenceService interface{},
 	return processSingleTaskGeneration(cmd, taskRef, window, contextOnly, options)
 }
 
-// executeApplicableTools executes applicable tools and saves their output
-func executeApplicableTools(task *domain.EvidenceTask, toolNames []string, outputDir string, cfg *config.Config) error {
+// Outcomes of an applicable tool run
+const (
+	toolRunSucceeded = "succeeded"
+	toolRunFailed    = "failed"
+	toolRunTimedOut  = "timed_out"
+)
+
+// toolRun is the outcome of running an applicable tool for a task
+type toolRun struct {
+	Tool        string        `json:"tool"`
+	Status      string        `json:"status"`
+	Duration    time.Duration `json:"duration"`
+	OutputBytes int           `json:"output_bytes,omitempty"`
+	Error       string        `json:"error,omitempty"` // Why the tool failed
+}
+
+// executeApplicableTools runs the applicable tools of a task and saves their
+// output in outputDir. At most evidence.tools.concurrency tools run at once,
+// each within evidence.tools.timeout. A failed tool does not stop the others;
+// the runs are returned in toolNames order, with an error naming the tools
+// that failed.
+func executeApplicableTools(ctx context.Context, task *domain.EvidenceTask, toolNames []string, outputDir string, cfg *config.Config) ([]toolRun, error) {
+	runs := make([]toolRun, len(toolNames))
 	if len(toolNames) == 0 {
-		return nil // No tools to execute
+		return runs, nil // No tools to execute
+	}
+
+	workers := min(max(cfg.Evidence.Tools.Concurrency, 1), len(toolNames))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				runs[i] = runApplicableTool(ctx, task, toolNames[i], outputDir, cfg)
+			}
+		}()
 	}
+	for i := range toolNames {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
 
-	for _, toolName := range toolNames {
-		tool, err := tools.GetTool(toolName)
-		if err != nil {
-			// Skip unknown tools - don't fail the entire operation
-			continue
+	var failed []string
+	for _, run := range runs {
+		if run.Status != toolRunSucceeded {
+			failed = append(failed, run.Tool)
 		}
+	}
+	if len(failed) > 0 {
+		return runs, fmt.Errorf("%d of %d tools failed: %s", len(failed), len(runs), strings.Join(failed, ", "))
+	}
+	return runs, nil
+}
 
-		// Execute tool
-		ctx := context.Background()
-		request := createToolRequestForEvidence(task, toolName, cfg)
+// runApplicableTool runs one applicable tool of a task within the tool
+// timeout and saves its output as <tool>.json in outputDir
+func runApplicableTool(ctx context.Context, task *domain.EvidenceTask, toolName, outputDir string, cfg *config.Config) toolRun {
+	start := time.Now()
+	run := toolRun{Tool: toolName, Status: toolRunSucceeded}
 
-		result, _, err := tool.Execute(ctx, request)
-		if err != nil {
-			// Log error but continue with other tools
-			// Don't fail the entire operation for one tool failure
-			continue
+	timeout := cfg.Evidence.Tools.Timeout
+	result, timedOut, err := executeToolWithin(ctx, timeout, toolName, createToolRequestForEvidence(task, toolName, cfg))
+	if err == nil {
+		if err = storage.WriteEvidenceFile(filepath.Join(outputDir, toolName+".json"), []byte(result), storage.EncryptsEvidence(cfg.Storage.Encryption, task)); err != nil {
+			err = fmt.Errorf("failed to save output: %w", err)
+		} else {
+			run.OutputBytes = len(result)
 		}
+	}
+	run.Duration = time.Since(start)
 
-		// Save tool output as JSON
-		outputFile := filepath.Join(outputDir, fmt.Sprintf("%s.json", toolName))
-		data := []byte(result)
+	switch {
+	case timedOut:
+		run.Status = toolRunTimedOut
+		run.Error = fmt.Sprintf("timed out after %s", timeout)
+	case err != nil:
+		run.Status = toolRunFailed
+		run.Error = err.Error()
+	}
+	if log := logger.WithComponent("evidence"); log != nil && run.Error != "" {
+		log.Info("applicable tool failed",
+			logger.String("task_ref", task.ReferenceID),
+			logger.String("tool", toolName),
+			logger.String("status", run.Status),
+			logger.String("error", run.Error))
+	}
+	return run
+}
 
-		if err := os.WriteFile(outputFile, data, 0644); err != nil {
-			continue
+// executeToolWithin runs a registered tool with a context that is cancelled
+// once timeout passes, and waits for the tool to return so its work stops
+// instead of running on in the background. A timeout of zero or less waits
+// for the tool to finish.
+func executeToolWithin(ctx context.Context, timeout time.Duration, toolName string, params map[string]interface{}) (string, bool, error) {
+	if timeout <= 0 {
+		result, _, err := tools.ExecuteTool(ctx, toolName, params)
+		return result, false, err
+	}
+
+	toolCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, _, err := tools.ExecuteTool(toolCtx, toolName, params)
+	timedOut := err != nil && errors.Is(toolCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil
+	return result, timedOut, err
+}
+
+// printToolRuns prints a table of which applicable tools succeeded, and why
+// the others failed
+func printToolRuns(cmd *cobra.Command, runs []toolRun) {
+	if len(runs) == 0 {
+		return
+	}
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TOOL\tSTATUS\tDURATION\tDETAIL")
+	for _, run := range runs {
+		detail := run.Error
+		if run.Status == toolRunSucceeded {
+			detail = fmt.Sprintf("%d bytes", run.OutputBytes)
 		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", run.Tool, run.Status, run.Duration.Round(time.Millisecond), detail)
 	}
+	w.Flush()
+}
+
+// toolOutputDir returns the directory of a task's window that evidence
+// generate reads tool data from
+func toolOutputDir(cfg *config.Config, task *domain.EvidenceTask, window string) string {
+	taskDirName := naming.GetEvidenceTaskDirName(task.Name, task.ReferenceID, task.ID)
+	return filepath.Join(cfg.Storage.DataDir, "evidence", taskDirName, window, naming.SubfolderContext, "tool_outputs")
+}
 
+// saveToolOutput stores a tool's output as tool data of a task's window
+func saveToolOutput(cfg *config.Config, task *domain.EvidenceTask, window, toolName, output string) error {
+	outputDir := toolOutputDir(cfg, task, window)
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create tool output directory: %w", err)
+	}
+	if err := storage.WriteEvidenceFile(filepath.Join(outputDir, toolName+".json"), []byte(output), storage.EncryptsEvidence(cfg.Storage.Encryption, task)); err != nil {
+		return fmt.Errorf("failed to save %s output: %w", toolName, err)
+	}
 	return nil
 }
 
@@ -407,7 +705,7 @@ func createToolRequestForEvidence(task *domain.EvidenceTask, toolName string, cf
 	// Tool-specific request customization
 	switch toolName {
 	case "terraform-security-indexer":
-		baseRequest["query_type"] = "control_mapping"
+		baseRequest["query_type"] = "by_control"
 	case "terraform-security-analyzer":
 		baseRequest["security_domain"] = "all"
 	case "github-permissions":
@@ -465,18 +763,25 @@ func processSingleTaskGeneration(cmd *cobra.Command, taskRef string, window stri
 		return fmt.Errorf("failed to save assembly context: %w", err)
 	}
 
-	// Execute tools if requested
+	// Execute tools if requested; synthesis drafts from their outputs
 	withToolData, _ := cmd.Flags().GetBool("with-tool-data")
-	if withToolData && len(assemblyContext.ApplicableTools) > 0 {
+	synthesize, _ := cmd.Flags().GetBool("synthesize")
+	if (withToolData || synthesize) && len(assemblyContext.ApplicableTools) > 0 {
 		cmd.Printf("🔧 Executing %d applicable tool(s)...\n", len(assemblyContext.ApplicableTools))
-		if err := executeApplicableTools(task, assemblyContext.ApplicableTools, assemblyPaths.ToolDataDir, cfg); err != nil {
-			// Log warning but continue
-			cmd.Printf("⚠️  Warning: Some tools failed to execute: %v\n", err)
+		runs, err := executeApplicableTools(commandContext(cmd), task, assemblyContext.ApplicableTools, assemblyPaths.ToolDataDir, cfg)
+		printToolRuns(cmd, runs)
+		if err != nil {
+			// Continue with the data of the tools that succeeded
+			cmd.Printf("⚠️  Warning: %v\n", err)
 		} else {
 			cmd.Printf("✅ Tool data collected in: %s\n", assemblyPaths.ToolDataDir)
 		}
 	}
 
+	if synthesize {
+		return synthesizeEvidence(cmd, cfg, task, window, assemblyContext.ComprehensivePrompt, assemblyPaths.ToolDataDir)
+	}
+
 	// Output success with new structure
 	cmd.Printf("✅ Assembly context created for %s: %s\n\n", task.ReferenceID, task.Name)
 	cmd.Printf("📄 Assembly prompt: %s\n", assemblyPaths.PromptFile)
@@ -629,6 +934,37 @@ func runEvidenceReview(cmd *cobra.Command, args []string) error {
 		window = getCurrentQuarter()
 	}
 
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if isStructuredOutput(format) {
+		files, _ := storage.GetEvidenceFiles(task.ReferenceID, window)
+		validationResult, validationErr := storage.LoadValidationResult(task.ReferenceID, window)
+		if validationErr != nil {
+			validationResult = nil
+		}
+		alreadySubmitted, _ := storage.CheckAlreadySubmitted(task.ReferenceID, window)
+		feedback, _ := storage.LoadFeedback(task.ReferenceID, window)
+		var verification *models.SubmissionVerification
+		if submitted, err := storage.LoadSubmission(task.ReferenceID, window); err == nil {
+			verification = submitted.Verification
+		}
+		return writeStructured(cmd, format, EvidenceReviewResult{
+			TaskRef:          task.ReferenceID,
+			TaskName:         task.Name,
+			Window:           window,
+			Files:            files,
+			Validation:       validationResult,
+			Requirements:     extractRequirements(task),
+			Controls:         task.Controls,
+			Feedback:         feedback,
+			Verification:     verification,
+			AlreadySubmitted: alreadySubmitted,
+			ReadyToSubmit:    !alreadySubmitted && isReadyForSubmission(len(files) > 0, validationResult),
+		})
+	}
+
 	// Display review header
 	displayReviewHeader(cmd, task, window)
 
@@ -642,27 +978,60 @@ func runEvidenceReview(cmd *cobra.Command, args []string) error {
 	hasValidation := validationErr == nil && validationResult != nil
 	displayValidationStatus(cmd, validationResult, validationErr)
 
-	// 3. Display requirements checklist
+	// 3. Display auditor feedback synced from Tugboat
+	feedback, _ := storage.LoadFeedback(task.ReferenceID, window)
+	displayAuditorFeedback(cmd, feedback)
+
+	// 4. Display what the target received of the last submission
+	if submitted, err := storage.LoadSubmission(task.ReferenceID, window); err == nil {
+		displaySubmissionVerification(cmd, submitted.Verification)
+	}
+
+	// 5. Display requirements checklist
 	displayRequirementsChecklist(cmd, task, hasFiles)
 
-	// 4. Display control alignment
+	// 6. Display control alignment
 	displayControlAlignment(cmd, task, storage)
 
-	// 5. Display submission recommendation
+	// 7. Display submission recommendation
 	alreadySubmitted, _ := storage.CheckAlreadySubmitted(task.ReferenceID, window)
-	displaySubmissionRecommendation(cmd, task, window, hasFiles, hasValidation, validationResult, alreadySubmitted)
+	displaySubmissionRecommendation(cmd, task, window, hasFiles, hasValidation, validationResult, alreadySubmitted, feedback)
 
 	return nil
 }
 
 func runEvidenceSubmit(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := commandContext(cmd)
 
 	// Get flags
 	window, _ := cmd.Flags().GetString("window")
 	notes, _ := cmd.Flags().GetString("notes")
 	skipValidation, _ := cmd.Flags().GetBool("skip-validation")
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	retryFailed, _ := cmd.Flags().GetBool("retry-failed")
+	queueMode, _ := cmd.Flags().GetBool("queue")
+	offline, _ := cmd.Flags().GetBool("offline")
+	target, _ := cmd.Flags().GetString("target")
+	target = strings.ToLower(strings.TrimSpace(target))
+	if target != "" && !isSubmissionTarget(target) {
+		return fmt.Errorf("unsupported --target %q: use tugboat, vanta, drata, secureframe, hyperproof or delivery", target)
+	}
+
+	all, _ := cmd.Flags().GetBool("all")
+	switch {
+	case all && len(args) > 0:
+		return fmt.Errorf("use either a task ID or --all")
+	case all:
+		return runEvidenceSubmitAll(cmd, window)
+	case len(args) == 0:
+		return fmt.Errorf("requires a task ID, or --all to submit every validated task")
+	}
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	structured := isStructuredOutput(format)
 
 	taskRef := args[0]
 
@@ -678,28 +1047,7 @@ func runEvidenceSubmit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to initialize storage: %w", err)
 	}
 
-	// Initialize submission service — prefer registry-based path, fall back to direct client
-	var submissionService *submission.SubmissionService
-	if !dryRun {
-		if reg := providers.GlobalRegistry(); reg != nil {
-			if svc, err := submission.NewSubmissionServiceWithRegistry(storage, reg, "tugboat", cfg.Tugboat.CollectorURLs); err == nil {
-				submissionService = svc
-			}
-		}
-	}
-	if submissionService == nil {
-		// Fallback: direct Tugboat client (backward compat, dry-run, or no registry)
-		var tugboatClient *tugboat.Client
-		if !dryRun {
-			tugboatClient = tugboat.NewClient(&cfg.Tugboat, nil)
-		}
-		submissionService = submission.NewSubmissionService(
-			storage,
-			tugboatClient,
-			cfg.Tugboat.OrgID,
-			cfg.Tugboat.CollectorURLs,
-		)
-	}
+	submissionService := newSubmissionService(cfg, storage, target, dryRun)
 
 	// Build submission request
 	req := &submission.SubmitRequest{
@@ -708,6 +1056,23 @@ func runEvidenceSubmit(cmd *cobra.Command, args []string) error {
 		Notes:          notes,
 		SkipValidation: skipValidation,
 		SubmittedBy:    "grctool-cli",
+		RetryFailed:    retryFailed,
+	}
+
+	result := &EvidenceSubmitResult{
+		TaskRef: taskRef,
+		Window:  window,
+		DryRun:  dryRun,
+	}
+
+	// Hold the window from the resubmission check until files have moved, so
+	// a concurrent run (e.g. the daemon) cannot submit or move them twice
+	if !dryRun {
+		lock, err := storage.LockWindow(taskRef, window)
+		if err != nil {
+			return err
+		}
+		defer lock.Unlock()
 	}
 
 	// Check if files already exist in .submitted/ folder (prevents resubmission)
@@ -715,10 +1080,20 @@ func runEvidenceSubmit(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to check submission status: %w", err)
 	}
-	if alreadySubmitted {
+	previous, _ := storage.LoadSubmission(taskRef, window)
+	if alreadySubmitted && !retryFailed {
+		result.AlreadySubmitted = true
+		if structured {
+			return writeStructured(cmd, format, result)
+		}
 		cmd.Printf("⚠️  Evidence for %s/%s has already been submitted\n", taskRef, window)
+		if previous != nil && len(previous.FailedFiles) > 0 {
+			cmd.Printf("%d file(s) failed to upload. To upload them again:\n", len(previous.FailedFiles))
+			cmd.Printf("  grctool evidence submit %s --window %s --retry-failed\n", taskRef, window)
+			return nil
+		}
 		cmd.Println("Files are in .submitted/ folder. To resubmit:")
-		cmd.Println("  1. Move files from .submitted/ back to root directory")
+		cmd.Printf("  1. Run: grctool evidence withdraw %s --window %s --reason \"...\"\n", taskRef, window)
 		cmd.Println("  2. Run submit command again")
 		return nil
 	}
@@ -728,47 +1103,167 @@ func runEvidenceSubmit(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to get evidence files: %w", err)
 	}
+	if retryFailed {
+		if previous == nil || len(previous.FailedFiles) == 0 {
+			return fmt.Errorf("no failed uploads recorded for %s in window %s", taskRef, window)
+		}
+		files = failedEvidenceFiles(files, previous.FailedFiles)
+	}
+	result.Files = files
+	destination, destinationTarget := submissionDestination(cfg, taskRef, target)
+	result.Target, result.Destination = destinationTarget.name, destination
+	if result.Target == submission.TargetTugboat {
+		result.CollectorURL = destination
+	}
 
-	cmd.Printf("📁 Evidence directory: data/evidence/%s/%s (root)\n", taskRef, window)
-	cmd.Printf("📄 Files to submit: %d\n\n", len(files))
-	for i, file := range files {
-		cmd.Printf("  %d. %s (%d bytes)\n", i+1, file.Filename, file.SizeBytes)
+	if !structured {
+		cmd.Printf("📁 Evidence directory: data/evidence/%s/%s (root)\n", taskRef, window)
+		cmd.Printf("📄 Files to submit: %d\n\n", len(files))
+		for i, file := range files {
+			cmd.Printf("  %d. %s (%d bytes)\n", i+1, file.Filename, file.SizeBytes)
+		}
+		cmd.Println()
+	}
+
+	// Unapproved evidence, and machine drafts not yet reviewed, are refused
+	// before they are queued or uploaded
+	var approvalErr error
+	if cfg.Submission.RequireApproval {
+		_, approvalErr = submission.CheckApproval(storage, taskRef, window, files)
+	} else {
+		approvalErr = submission.CheckDraftReviewed(storage, taskRef, window, files)
+	}
+	if approvalErr != nil && !dryRun {
+		return approvalErr
+	}
+
+	// Queue the submission for 'grctool queue flush', now when offline or
+	// once the target turns out to be unreachable
+	var queue *models.SubmissionQueue
+	if (queueMode || offline) && !dryRun {
+		if queue, err = storage.LoadSubmissionQueue(); err != nil {
+			return err
+		}
+		if queued, ok := queue.Queued(taskRef, window); ok {
+			return fmt.Errorf("%s/%s is already queued as #%d; send it with 'grctool queue flush'", taskRef, window, queued.ID)
+		}
+	}
+	enqueue := func(reason string) error {
+		entry := queue.Enqueue(models.QueuedSubmission{
+			TaskRef:        taskRef,
+			Window:         window,
+			Target:         target,
+			Notes:          notes,
+			SkipValidation: skipValidation,
+			RetryFailed:    retryFailed,
+			SubmittedBy:    req.SubmittedBy,
+			Reason:         reason,
+			QueuedAt:       time.Now(),
+		})
+		if err := storage.SaveSubmissionQueue(queue); err != nil {
+			return err
+		}
+		result.Queued, result.QueueID = true, entry.ID
+		if structured {
+			return writeStructured(cmd, format, result)
+		}
+		cmd.Printf("📥 Queued as #%d: %s\n", entry.ID, reason)
+		cmd.Println("Files stay in the root directory until it is sent with:")
+		cmd.Println("  grctool queue flush")
+		return nil
+	}
+	if offline && !dryRun {
+		return enqueue("submitted offline")
 	}
-	cmd.Println()
 
 	if dryRun {
+		if structured {
+			return writeStructured(cmd, format, result)
+		}
 		cmd.Println("🔍 Dry-run mode - no files will be uploaded")
-		if collectorURL, ok := cfg.Tugboat.CollectorURLs[taskRef]; ok {
-			cmd.Printf("Would submit to: %s\n", collectorURL)
-		} else {
-			cmd.Printf("⚠️  Warning: No collector URL configured for %s\n", taskRef)
-			cmd.Println("Add to .grctool.yaml under tugboat.collector_urls")
+		if approvalErr != nil {
+			cmd.Printf("⚠️  Warning: %v\n", approvalErr)
+		}
+		switch {
+		case destination != "":
+			cmd.Printf("Would submit to %s: %s\n", destinationTarget.label, destination)
+		case target != "":
+			cmd.Printf("⚠️  Warning: No %s configured for %s\n", destinationTarget.destination, taskRef)
+			cmd.Printf("Add to .grctool.yaml under %s\n", destinationTarget.configKey)
+		default:
+			keys := []string{}
+			for _, candidate := range submissionTargets(cfg) {
+				keys = append(keys, candidate.configKey)
+			}
+			cmd.Printf("⚠️  Warning: No submission target configured for %s\n", taskRef)
+			cmd.Printf("Add to .grctool.yaml under %s\n", strings.Join(keys, ", "))
 		}
 		return nil
 	}
 
 	// Submit evidence
-	cmd.Printf("🚀 Submitting evidence to Tugboat Logic...\n\n")
+	if !structured {
+		cmd.Printf("🚀 Submitting evidence to %s...\n\n", destinationTarget.label)
+	}
 	resp, err := submissionService.Submit(ctx, req)
 	if err != nil {
+		if queue != nil && errors.Is(err, submission.ErrTargetUnreachable) {
+			return enqueue(fmt.Sprintf("%s is unreachable", destinationTarget.label))
+		}
 		return fmt.Errorf("submission failed: %w", err)
 	}
 
+	result.Success = resp.Success
+	result.SubmissionID = resp.SubmissionID
+	result.Status = resp.Status
+	result.Message = resp.Message
+	if resp.Success {
+		uploaded := files
+		if resp.Submission != nil {
+			result.FilesSubmitted = resp.Submission.TotalFileCount
+			if resp.Submission.TugboatResponse != nil && resp.Submission.TugboatResponse.Metadata != nil {
+				if submitted, ok := resp.Submission.TugboatResponse.Metadata["files_submitted"].(int); ok {
+					result.FilesSubmitted = submitted
+				}
+				if failedFiles, ok := resp.Submission.TugboatResponse.Metadata["failed_files"].([]string); ok {
+					result.FailedFiles = failedFiles
+				}
+			}
+			uploaded = resp.Submission.UploadedFiles()
+			result.Verification = resp.Submission.Verification
+			result.Transformations = resp.Submission.Transformations
+		}
+
+		// NEW HYBRID APPROACH: Move files to .submitted/ after successful upload.
+		// Files that failed stay in the root for --retry-failed.
+		if err := storage.MoveEvidenceFilesToSubmitted(taskRef, window, uploaded); err != nil {
+			result.MoveError = err.Error()
+		} else {
+			result.MovedToSubmitted = true
+		}
+	} else if resp.ValidationResult != nil && !resp.ValidationResult.ReadyForSubmission {
+		result.Validation = resp.ValidationResult
+	}
+
+	if structured {
+		return writeStructured(cmd, format, result)
+	}
+
 	// Display results
 	if resp.Success {
 		cmd.Printf("✅ Success! Submission ID: %s\n", resp.SubmissionID)
 		cmd.Printf("Status: %s\n", resp.Status)
 		if resp.Submission != nil {
-			cmd.Printf("Files submitted: %d/%d\n", resp.Submission.TotalFileCount, len(files))
+			cmd.Printf("Files submitted: %d/%d\n", result.FilesSubmitted, len(files))
+
+			displayTransformations(cmd, result.Transformations)
 
 			// Show failed files if any
 			if resp.Submission.TugboatResponse != nil && resp.Submission.TugboatResponse.Metadata != nil {
 				if failedCount, ok := resp.Submission.TugboatResponse.Metadata["files_failed"].(int); ok && failedCount > 0 {
 					cmd.Printf("\n⚠️  Warning: %d file(s) failed to upload\n", failedCount)
-					if failedFiles, ok := resp.Submission.TugboatResponse.Metadata["failed_files"].([]string); ok {
-						for _, failedFile := range failedFiles {
-							cmd.Printf("  ❌ %s\n", failedFile)
-						}
+					for _, failedFile := range result.FailedFiles {
+						cmd.Printf("  ❌ %s\n", failedFile)
 					}
 				}
 			}
@@ -776,20 +1271,27 @@ func runEvidenceSubmit(cmd *cobra.Command, args []string) error {
 		if resp.Message != "" {
 			cmd.Printf("\n%s\n", resp.Message)
 		}
+		if result.Verification != nil {
+			cmd.Println()
+			displaySubmissionVerification(cmd, result.Verification)
+		}
 
-		// NEW HYBRID APPROACH: Move files to .submitted/ after successful upload
 		cmd.Println("\n📦 Moving files to .submitted/ folder...")
-		if err := storage.MoveEvidenceFilesToSubmitted(taskRef, window, files); err != nil {
-			cmd.Printf("⚠️  Warning: Failed to move files to .submitted/: %v\n", err)
+		if result.MoveError != "" {
+			cmd.Printf("⚠️  Warning: Failed to move files to .submitted/: %s\n", result.MoveError)
 			cmd.Println("Files were uploaded successfully but remain in root directory")
 		} else {
 			cmd.Printf("✅ Files moved to .submitted/ (prevents resubmission)\n")
 		}
+		if len(result.FailedFiles) > 0 {
+			cmd.Println("Failed files remain in the root directory. To upload them again:")
+			cmd.Printf("  grctool evidence submit %s --window %s --retry-failed\n", taskRef, window)
+		}
 	} else {
 		cmd.Printf("❌ Submission failed: %s\n", resp.Message)
-		if resp.ValidationResult != nil && !resp.ValidationResult.ReadyForSubmission {
-			cmd.Printf("\nValidation errors: %d\n", resp.ValidationResult.FailedChecks)
-			for _, err := range resp.ValidationResult.Errors {
+		if result.Validation != nil {
+			cmd.Printf("\nValidation errors: %d\n", result.Validation.FailedChecks)
+			for _, err := range result.Validation.Errors {
 				cmd.Printf("  - %s\n", err)
 			}
 		}
@@ -800,6 +1302,137 @@ func runEvidenceSubmit(cmd *cobra.Command, args []string) error {
 
 // Helper functions
 
+// failedEvidenceFiles returns the evidence files that failed to upload
+func failedEvidenceFiles(files []models.EvidenceFileRef, failures []models.FailedUpload) []models.EvidenceFileRef {
+	failed := map[string]bool{}
+	for _, failure := range failures {
+		failed[failure.Filename] = true
+	}
+	retry := []models.EvidenceFileRef{}
+	for _, file := range files {
+		if failed[file.Filename] {
+			retry = append(retry, file)
+		}
+	}
+	return retry
+}
+
+// submissionTarget is a platform evidence can be submitted to, with the
+// config mapping each task to where its evidence is uploaded
+type submissionTarget struct {
+	name         string // Target name accepted by --target
+	label        string
+	destination  string // What a task is mapped to, e.g. "collector URL"
+	configKey    string
+	destinations map[string]string // TaskRef -> destination
+}
+
+// submissionTargets returns the submission targets in the order a task
+// configured for several of them is routed
+func submissionTargets(cfg *config.Config) []submissionTarget {
+	return []submissionTarget{
+		{submission.TargetTugboat, "Tugboat Logic", "collector URL", "tugboat.collector_urls", cfg.Tugboat.CollectorURLs},
+		{submission.TargetVanta, "Vanta", "Vanta document ID", "vanta.document_ids", cfg.Vanta.DocumentIDs},
+		{submission.TargetDrata, "Drata", "Drata control ID", "drata.control_ids", cfg.Drata.ControlIDs},
+		{submission.TargetSecureframe, "Secureframe", "Secureframe test ID", "secureframe.test_ids", cfg.Secureframe.TestIDs},
+		{submission.TargetHyperproof, "Hyperproof", "Hyperproof label or control", "hyperproof.label_ids", hyperproofDestinations(cfg)},
+		{submission.TargetDelivery, "evidence dropbox", "delivery URL", "delivery.urls", deliveryDestinations(cfg)},
+	}
+}
+
+// deliveryDestinations returns the delivery URL of each task, without passwords
+func deliveryDestinations(cfg *config.Config) map[string]string {
+	target := submission.NewDeliveryTarget(cfg.Delivery)
+	destinations := map[string]string{}
+	for taskRef := range cfg.Delivery.URLs {
+		destinations[taskRef], _ = target.Destination(taskRef)
+	}
+	return destinations
+}
+
+// hyperproofDestinations returns the Hyperproof label or control of each task
+func hyperproofDestinations(cfg *config.Config) map[string]string {
+	destinations := map[string]string{}
+	for taskRef, target := range hyperproof.ProofTargets(cfg.Hyperproof) {
+		destinations[taskRef] = target.String()
+	}
+	return destinations
+}
+
+func isSubmissionTarget(name string) bool {
+	for _, target := range submissionTargets(&config.Config{}) {
+		if target.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// newSubmissionService creates a submission service for the named target, or
+// for every configured target when name is empty, routing each task to the
+// first it is configured for. Tugboat prefers the registry-based provider and
+// falls back to a direct client. Dry runs get no client, so nothing is uploaded.
+func newSubmissionService(cfg *config.Config, store *storage.Storage, name string, dryRun bool) *submission.SubmissionService {
+	if dryRun {
+		return submission.NewSubmissionService(store, nil, cfg.Tugboat.OrgID, cfg.Tugboat.CollectorURLs)
+	}
+
+	var svc *submission.SubmissionService
+	if name != "" && name != submission.TargetTugboat {
+		svc = submission.NewSubmissionServiceWithTargets(store)
+	} else if reg := providers.GlobalRegistry(); reg != nil {
+		if registered, err := submission.NewSubmissionServiceWithRegistry(store, reg, "tugboat", cfg.Tugboat.CollectorURLs); err == nil {
+			svc = registered
+		}
+	}
+	if svc == nil {
+		// Fallback: direct Tugboat client (backward compat or no registry)
+		svc = submission.NewSubmissionService(
+			store,
+			tugboat.NewClient(&cfg.Tugboat, nil),
+			cfg.Tugboat.OrgID,
+			cfg.Tugboat.CollectorURLs,
+		)
+	}
+
+	use := func(target string, destinations int) bool {
+		return name == target || (name == "" && destinations > 0)
+	}
+	if use(submission.TargetVanta, len(cfg.Vanta.DocumentIDs)) {
+		svc.AddTarget(submission.NewVantaTarget(vanta.NewClient(cfg.Vanta, nil), cfg.Vanta.DocumentIDs))
+	}
+	if use(submission.TargetDrata, len(cfg.Drata.ControlIDs)) {
+		svc.AddTarget(submission.NewDrataTarget(drata.NewClient(cfg.Drata, nil), cfg.Drata.ControlIDs))
+	}
+	if use(submission.TargetSecureframe, len(cfg.Secureframe.TestIDs)) {
+		svc.AddTarget(submission.NewSecureframeTarget(secureframe.NewClient(cfg.Secureframe, nil), cfg.Secureframe.TestIDs))
+	}
+	if proofTargets := hyperproof.ProofTargets(cfg.Hyperproof); use(submission.TargetHyperproof, len(proofTargets)) {
+		svc.AddTarget(submission.NewHyperproofTarget(hyperproof.NewClient(cfg.Hyperproof, nil), proofTargets))
+	}
+	if use(submission.TargetDelivery, len(cfg.Delivery.URLs)) {
+		svc.AddTarget(submission.NewDeliveryTarget(cfg.Delivery))
+	}
+	svc.SetRequireApproval(cfg.Submission.RequireApproval)
+	svc.SetSigner(signing.New(cfg.Evidence.Signing))
+	svc.SetScanner(redaction.New(cfg.Submission.Scan))
+	svc.SetUploadManifest(cfg.Submission.UploadManifest)
+	return svc
+}
+
+// submissionDestination returns where a task's evidence is uploaded and the
+// target it goes to: the named target, or the first the task is configured
+// for. The destination is empty when the task is not configured for it.
+func submissionDestination(cfg *config.Config, taskRef, name string) (string, submissionTarget) {
+	targets := submissionTargets(cfg)
+	for _, target := range targets {
+		if name == target.name || (name == "" && target.destinations[taskRef] != "") {
+			return target.destinations[taskRef], target
+		}
+	}
+	return "", targets[0]
+}
+
 func initializeEvidenceService() (evidence.Service, error) {
 	// Load configuration
 	cfg, err := config.Load()
@@ -864,6 +1497,7 @@ func buildEvidenceFilterFromFlags(cmd *cobra.Command) domain.EvidenceFilter {
 	now := time.Now()
 	if overdue {
 		filter.DueBefore = &now
+		filter.ExcludeDeferred = true
 	}
 	if dueSoon {
 		dueSoonDate := now.AddDate(0, 0, 7)
@@ -893,9 +1527,11 @@ func displayEvidenceTasks(cmd *cobra.Command, tasks []domain.EvidenceTask, evide
 		dueDateStr := "N/A"
 		if task.NextDue != nil {
 			dueDateStr = task.NextDue.Format("2006-01-02")
-			// Mark overdue tasks
-			if task.NextDue.Before(now) {
+			// Mark overdue and deferred tasks
+			if task.IsOverdue(now) {
 				dueDateStr += " ⚠️"
+			} else if task.IsDeferred(now) {
+				dueDateStr += " ⏸️"
 			}
 		}
 
@@ -917,16 +1553,7 @@ func displayEvidenceTasks(cmd *cobra.Command, tasks []domain.EvidenceTask, evide
 		}
 
 		// Get reference ID (assign one if not set)
-		refID := task.ReferenceID
-		if refID == "" {
-			// Generate a reference ID based on task ID if not available
-			// Generate a reference ID based on task ID if not available
-			if idNum, err := strconv.Atoi(task.ID); err == nil {
-				refID = fmt.Sprintf("ET-%04d", idNum-327991) // Offset to start from ET-0001
-			} else {
-				refID = fmt.Sprintf("ET-%s", task.ID)
-			}
-		}
+		refID := displayTaskRef(task)
 
 		// Get category with intelligent assignment
 		category := task.GetCategory()
@@ -968,12 +1595,28 @@ func displayEvidenceTasks(cmd *cobra.Command, tasks []domain.EvidenceTask, evide
 	// Show summary stats
 	summary, err := evidenceService.GetEvidenceTaskSummary(ctx)
 	if err == nil {
-		cmd.Printf("Summary: %d total, %d overdue, %d due soon\n", summary.Total, summary.Overdue, summary.DueSoon)
+		cmd.Printf("Summary: %d total, %d overdue, %d due soon", summary.Total, summary.Overdue, summary.DueSoon)
+		if summary.Deferred > 0 {
+			cmd.Printf(", %d deferred", summary.Deferred)
+		}
+		cmd.Println()
 	}
 
 	return nil
 }
 
+// displayTaskRef returns the task's reference ID, deriving one from the
+// numeric task ID when it has not been assigned
+func displayTaskRef(task domain.EvidenceTask) string {
+	if task.ReferenceID != "" {
+		return task.ReferenceID
+	}
+	if idNum, err := strconv.Atoi(task.ID); err == nil {
+		return fmt.Sprintf("ET-%04d", idNum-327991) // Offset to start from ET-0001
+	}
+	return fmt.Sprintf("ET-%s", task.ID)
+}
+
 func displayEvidenceMap(cmd *cobra.Command, mapResult *evidence.EvidenceMapResult) error {
 	cmd.Println("Mapping Evidence Task Relationships")
 
@@ -1021,16 +1664,52 @@ func displayEvidenceMap(cmd *cobra.Command, mapResult *evidence.EvidenceMapResul
 	cmd.Printf("   Total task-to-control/policy relationships: %d\n", mapResult.TotalRelationships)
 	cmd.Printf("   Average relationships per task: %.1f\n", mapResult.Summary.AverageRelationships)
 
+	uncovered := displayFrameworkControls(cmd, mapResult)
+
 	// Summary recommendations
 	cmd.Println("\n**Recommendations:**")
 	if mapResult.Summary.OverdueCount > 0 {
 		cmd.Printf("   • Address %d overdue tasks\n", mapResult.Summary.OverdueCount)
 	}
+	if uncovered > 0 {
+		cmd.Printf("   • Collect evidence for %d %s controls without evidence tasks\n", uncovered, frameworks.Label(mapResult.Framework))
+	}
 	cmd.Println("   • Use 'grctool evidence generate <task-id>' to create evidence and assembly context")
 
 	return nil
 }
 
+// displayFrameworkControls prints the coverage of the framework controls the
+// evidence map was mapped onto and returns the number without evidence tasks
+func displayFrameworkControls(cmd *cobra.Command, mapResult *evidence.EvidenceMapResult) int {
+	if len(mapResult.FrameworkControls) == 0 {
+		return 0
+	}
+
+	var uncovered []evidence.FrameworkControlMapping
+	for _, control := range mapResult.FrameworkControls {
+		if !control.Covered() {
+			uncovered = append(uncovered, control)
+		}
+	}
+
+	cmd.Printf("\n**%s Control Coverage:**\n", frameworks.Label(mapResult.Framework))
+	cmd.Printf("   Controls with evidence tasks: %d of %d\n",
+		len(mapResult.FrameworkControls)-len(uncovered), len(mapResult.FrameworkControls))
+	for _, control := range mapResult.FrameworkControls {
+		if control.Covered() {
+			cmd.Printf("   %s %s: %d controls, %d tasks\n", control.Code, control.Title, len(control.Controls), len(control.Tasks))
+		}
+	}
+	if len(uncovered) > 0 {
+		cmd.Println("   Without evidence tasks:")
+		for _, control := range uncovered {
+			cmd.Printf("      %s %s\n", control.Code, control.Title)
+		}
+	}
+	return len(uncovered)
+}
+
 // Evidence context generation helpers
 
 // EvidenceGenerationContext holds all context needed for evidence generation
@@ -1041,6 +1720,7 @@ type EvidenceGenerationContext struct {
 	ExistingEvidence []string
 	SourceLocations  map[string]string
 	PreviousWindows  []string
+	RelatedDocuments []matching.Match // Policies, controls and prior evidence ranked by relevance
 }
 
 // AssemblyContext holds all materials for evidence assembly
@@ -1051,6 +1731,7 @@ type AssemblyContext struct {
 	ClaudeInstructions  string // How to use materials
 	EvidenceTemplate    string // Structure guide
 	ApplicableTools     []string
+	RelatedDocuments    []matching.Match       // Policies, controls and prior evidence ranked by relevance
 	ToolData            map[string]interface{} // If --with-tool-data
 }
 
@@ -1068,6 +1749,117 @@ type PromptAssemblerOutput struct {
 	Data   map[string]interface{}
 }
 
+// Structured results for --output json|yaml
+
+// EvidenceListResult is the structured output of 'evidence list'
+type EvidenceListResult struct {
+	Count   int                         `json:"count"`
+	Tasks   []EvidenceTaskListItem      `json:"tasks"`
+	Summary *domain.EvidenceTaskSummary `json:"summary,omitempty"`
+}
+
+// EvidenceTaskListItem is one row of 'evidence list'
+type EvidenceTaskListItem struct {
+	ReferenceID    string     `json:"reference_id"`
+	ID             string     `json:"id"`
+	Name           string     `json:"name"`
+	Category       string     `json:"category"`
+	Framework      string     `json:"framework"`
+	Status         string     `json:"status"`
+	AecStatus      string     `json:"aec_status"`
+	CollectionType string     `json:"collection_type"`
+	Priority       string     `json:"priority"`
+	NextDue        *time.Time `json:"next_due,omitempty"`
+	Overdue        bool       `json:"overdue"`
+	DeferredUntil  *time.Time `json:"deferred_until,omitempty"` // Set while 'evidence defer' postpones the task
+	Assignees      []string   `json:"assignees,omitempty"`
+	TugboatURL     string     `json:"tugboat_url,omitempty"`
+}
+
+// EvidenceViewResult is the structured output of 'evidence view'
+type EvidenceViewResult struct {
+	Task *domain.EvidenceTask `json:"task"`
+}
+
+// EvidenceReviewResult is the structured output of 'evidence review'
+type EvidenceReviewResult struct {
+	TaskRef          string                         `json:"task_ref"`
+	TaskName         string                         `json:"task_name"`
+	Window           string                         `json:"window"`
+	Files            []models.EvidenceFileRef       `json:"files"`
+	Validation       *models.ValidationResult       `json:"validation,omitempty"`
+	Requirements     []string                       `json:"requirements,omitempty"`
+	Controls         []string                       `json:"controls,omitempty"`
+	Feedback         *models.EvidenceFeedback       `json:"feedback,omitempty"`
+	Verification     *models.SubmissionVerification `json:"verification,omitempty"` // What the target received of the last submission
+	AlreadySubmitted bool                           `json:"already_submitted"`
+	ReadyToSubmit    bool                           `json:"ready_to_submit"`
+}
+
+// EvidenceSubmitResult is the structured output of 'evidence submit'
+type EvidenceSubmitResult struct {
+	TaskRef          string                         `json:"task_ref"`
+	Window           string                         `json:"window"`
+	DryRun           bool                           `json:"dry_run"`
+	AlreadySubmitted bool                           `json:"already_submitted"`
+	Files            []models.EvidenceFileRef       `json:"files"`
+	Target           string                         `json:"target"`                  // tugboat, vanta, drata, secureframe, hyperproof or delivery
+	Destination      string                         `json:"destination,omitempty"`   // Collector URL, delivery URL or the target's document, control, test or label
+	CollectorURL     string                         `json:"collector_url,omitempty"` // Set for Tugboat submissions
+	Success          bool                           `json:"success"`
+	SubmissionID     string                         `json:"submission_id,omitempty"`
+	Status           string                         `json:"status,omitempty"`
+	Message          string                         `json:"message,omitempty"`
+	FilesSubmitted   int                            `json:"files_submitted"`
+	FailedFiles      []string                       `json:"failed_files,omitempty"`
+	Validation       *models.ValidationResult       `json:"validation,omitempty"`
+	MovedToSubmitted bool                           `json:"moved_to_submitted"`
+	MoveError        string                         `json:"move_error,omitempty"`
+	Verification     *models.SubmissionVerification `json:"verification,omitempty"`    // What the target received, when it can be read back
+	Transformations  []models.FileTransformation    `json:"transformations,omitempty"` // Files changed to meet the target's limits
+	Queued           bool                           `json:"queued,omitempty"`          // Queued for 'grctool queue flush' instead of sent
+	QueueID          int                            `json:"queue_id,omitempty"`        // Set when queued
+}
+
+// newEvidenceListResult builds the structured 'evidence list' output
+func newEvidenceListResult(tasks []domain.EvidenceTask, summary *domain.EvidenceTaskSummary, now time.Time) EvidenceListResult {
+	result := EvidenceListResult{
+		Count:   len(tasks),
+		Tasks:   make([]EvidenceTaskListItem, 0, len(tasks)),
+		Summary: summary,
+	}
+
+	for _, task := range tasks {
+		item := EvidenceTaskListItem{
+			ReferenceID:    displayTaskRef(task),
+			ID:             task.ID,
+			Name:           task.Name,
+			Category:       task.GetCategory(),
+			Framework:      task.Framework,
+			Status:         task.Status,
+			AecStatus:      task.GetAecStatusDisplay(),
+			CollectionType: task.GetCollectionType(),
+			Priority:       task.Priority,
+			NextDue:        task.NextDue,
+			Overdue:        task.IsOverdue(now),
+			TugboatURL:     task.TugboatURL,
+		}
+		if task.IsDeferred(now) {
+			item.DeferredUntil = &task.Deferral.Until
+		}
+		for _, assignee := range task.Assignees {
+			if assignee.Name != "" {
+				item.Assignees = append(item.Assignees, assignee.Name)
+			} else if assignee.Email != "" {
+				item.Assignees = append(item.Assignees, assignee.Email)
+			}
+		}
+		result.Tasks = append(result.Tasks, item)
+	}
+
+	return result
+}
+
 func getCurrentQuarter() string {
 	now := time.Now()
 	quarter := (int(now.Month())-1)/3 + 1
@@ -1075,6 +1867,7 @@ func getCurrentQuarter() string {
 }
 
 func generateEvidenceContext(task *domain.EvidenceTask, window string, requestedTools []string, cfg *config.Config, storage *storage.Storage) (*EvidenceGenerationContext, error) {
+	matchCtx := context.Background()
 	context := &EvidenceGenerationContext{
 		Task:            task,
 		SourceLocations: make(map[string]string),
@@ -1084,7 +1877,7 @@ func generateEvidenceContext(task *domain.EvidenceTask, window string, requested
 	if len(requestedTools) > 0 {
 		context.ApplicableTools = requestedTools
 	} else {
-		context.ApplicableTools = identifyApplicableTools(task)
+		context.ApplicableTools = applicableToolsForTask(matchCtx, cfg, task, identifyApplicableTools(task))
 	}
 
 	// Scan for existing evidence
@@ -1141,11 +1934,20 @@ func generateEvidenceContext(task *domain.EvidenceTask, window string, requested
 		}
 	}
 
+	// Rank policies, controls and prior evidence by relevance
+	if matcher := newTaskMatcher(cfg); matcher != nil {
+		related, err := relatedTaskDocuments(matchCtx, matcher, task, window, storage, evidenceDir, cfg.Evidence.Matching.Limit)
+		if err != nil {
+			logger.Warn("semantic document matching failed", logger.String("task", task.ReferenceID), logger.Error(err))
+		}
+		context.RelatedDocuments = related
+	}
+
 	return context, nil
 }
 
 func identifyApplicableTools(task *domain.EvidenceTask) []string {
-	var tools []string
+	var applicable []string
 
 	// Combine task name and description for keyword matching
 	searchText := strings.ToLower(task.Name + " " + task.Description)
@@ -1159,19 +1961,30 @@ func identifyApplicableTools(task *domain.EvidenceTask) []string {
 		"terraform-security-analyzer": {"terraform", "cloud", "aws", "gcp", "azure"},
 		"google-workspace":            {"google", "drive", "docs", "sheets", "forms", "workspace"},
 		"atmos-stack-analyzer":        {"atmos", "stack", "environment"},
+		"vendor-review":               {"vendor", "third party", "third-party", "supplier", "subprocessor"},
+		"incident-log":                {"incident", "breach", "outage", "postmortem", "post-mortem"},
+		"policy-review":               {"policy review", "policies are reviewed", "policy approval", "policies are approved"},
+		"policy-acknowledgment":       {"policy acknowledg", "policies are acknowledged", "acknowledge policies", "acknowledgment of polic", "policy attestation"},
 	}
 
 	// Check each tool pattern
 	for tool, keywords := range toolPatterns {
 		for _, keyword := range keywords {
 			if strings.Contains(searchText, keyword) {
-				tools = append(tools, tool)
+				applicable = append(applicable, tool)
 				break
 			}
 		}
 	}
 
-	return tools
+	// Plugin tools declare their own keywords
+	for _, plugin := range tools.PluginTools() {
+		if plugin.AppliesTo(searchText) {
+			applicable = append(applicable, plugin.Name())
+		}
+	}
+
+	return applicable
 }
 
 func formatContextAsMarkdown(context *EvidenceGenerationContext, task *domain.EvidenceTask, window string) string {
@@ -1216,6 +2029,8 @@ func formatContextAsMarkdown(context *EvidenceGenerationContext, task *domain.Ev
 		md.WriteString("\n")
 	}
 
+	md.WriteString(formatRelatedDocuments(context.RelatedDocuments))
+
 	// Applicable Tools
 	md.WriteString("## Applicable Tools\n\n")
 	if len(context.ApplicableTools) > 0 {
@@ -1303,140 +2118,10 @@ func saveEvidenceContext(task *domain.EvidenceTask, window string, contextMarkdo
 	return contextPath, nil
 }
 
-func generateClaudeInstructions(task *domain.EvidenceTask, window string) string {
-	return fmt.Sprintf(`# Claude Code Instructions: %s
-
-## Your Mission
-
-Help the user generate evidence for **%s** (%s).
-
-## What You Have
-
-1. **Assembly Prompt** (.context/assembly-prompt.md)
-   - Comprehensive context from prompt-assembler
-   - Related controls and policies
-   - Example evidence structure
-   - All requirements for this task
-
-2. **Evidence Template** (.context/evidence-template.md)
-   - Pre-structured report outline
-   - Section headers and prompts
-   - Based on proven evidence patterns
-
-3. **Tool Data** (.context/tool_outputs/ directory, if available)
-   - Pre-collected data from automated tools
-   - Ready for synthesis into evidence
-
-## Dual Output Approach
-
-You will generate TWO outputs:
-
-### 1. Simple Evidence File (%s_Evidence.md - root directory)
-**Purpose**: Clean, auditor-friendly evidence document
-**Structure**:
-- Header with task description and collection date
-- Collection tasks broken down from task description/guidance
-- Each task shows: Evidence statement → Inline snippet (quoted) → Source reference
-- Flat file structure (no subfolders) ready for Tugboat upload
-
-**Format Example**:
-`+"```"+`markdown
-## Collection Task 1: Document access provisioning process
-
-**Evidence:** Policy requires manager approval for all access requests
-
-**Source:** `+"`"+`POL-0001-access-control.md`+"`"+`
-**Original Path:** `+"`"+`docs/policies/POL-0001-access-control.md`+"`"+`
-**Last Modified:** 2025-09-15
-**Section:** 3.2 (lines 45-52)
-
-> All access requests must be:
-> 1. Submitted via standardized request form
-> 2. Approved by direct manager
-> 3. Reviewed by security team
-
----
-`+"```"+`
-
-### 2. Narrative Background (.context/narrative-background.md)
-**Purpose**: Detailed context and explanations (NOT uploaded to Tugboat)
-**Contents**:
-- Detailed analysis and reasoning
-- Executive summaries
-- Compliance interpretations
-- Background information for internal use
-
-## Workflow
-
-### Step 1: Review Assembly Prompt
-Read .context/assembly-prompt.md to understand:
-- What evidence is needed
-- Which controls it satisfies
-- What policies/sources are relevant
-- What tools can help
-
-### Step 2: Breakdown Collection Tasks
-Analyze task description and guidance to identify discrete collection tasks:
-- What specific items need to be verified?
-- What documentation needs to be reviewed?
-- What technical evidence needs to be collected?
-
-### Step 3: Collect Tool Data & Sources
-Run applicable tools and gather source materials:
-`+"```bash\n"+`
-grctool tool <tool-name> --repository <repo> > .context/tool_outputs/<tool-name>.json
-`+"```\n"+`
-
-### Step 4: Generate Simple Evidence File
-Create %s_Evidence.md (root directory) with:
-- Collection tasks derived from description/guidance
-- Evidence snippets with inline quotes
-- Source file references with relative paths and dates
-- Copy all referenced source files flat to root directory
-
-### Step 5: Generate Narrative Background
-Create .context/narrative-background.md with:
-- Detailed analysis and interpretation
-- Executive summary
-- Compliance reasoning
-- Additional context
-
-## Source File Handling
-
-**IMPORTANT**: Copy all referenced source files to root directory (flat, no subdirectories):
-- Policy documents → POL-XXXX-name.md (from docs/policies/markdown/)
-- Control files → AC1-778771.md (from docs/controls/markdown/)
-- Infrastructure configs → main.tf, deploy.yml (original source files)
-- Application configs → config.yaml, .env.example
-
-**File Selection Rules**:
-- ✅ **DO include**: Markdown documentation (.md), infrastructure source files (.tf, .yml, .yaml, .toml, .hcl)
-- ❌ **NEVER include**: JSON files (.json) - not auditor-friendly
-- 📊 **Tool outputs**: Analyze JSON internally, summarize findings in narrative, DON'T copy JSON to root
-
-**Path References**: Use relative paths from data directory:
-- ✅ `+"`"+`docs/policies/markdown/POL-0001-access-control.md`+"`"+`
-- ✅ `+"`"+`docs/controls/markdown/AC1-778771.md`+"`"+`
-- ❌ `+"`"+`/Users/erik/Projects/7thsense-ops/isms/docs/policies/POL-0001-access-control.md`+"`"+`
-
-## Expected Outputs
-
-**Root Directory**: Ready for Tugboat upload
-- %s_Evidence.md (simple, task-focused)
-- POL-XXXX-*.md (policy source files in markdown)
-- AC*-*.md (control source files in markdown)
-- Infrastructure/config files (.tf, .yml, .yaml - original source files only, NO JSON)
-
-**.context/**: Internal context only
-- narrative-background.md (detailed analysis)
-- assembly-prompt.md
-- claude-instructions.md
-- evidence-template.md
-
----
-
-**Need help?** Review the assembly prompt first, then ask questions about available data sources!
-`, task.ReferenceID, task.Name, task.ReferenceID, task.ReferenceID, task.ReferenceID, task.ReferenceID)
+// generateClaudeInstructions renders the instructions template telling an
+// assistant how to use a task's assembly materials
+func generateClaudeInstructions(data templates.EvidenceData, evidenceTemplates *templates.EvidenceTemplates) (string, error) {
+	return evidenceTemplates.Render(templates.InstructionsTemplate, data)
 }
 
 // isTugboatManagedTask checks if a task is managed by Tugboat (AEC enabled + Hybrid collection)
@@ -1550,21 +2235,53 @@ func generateAssemblyContext(task *domain.EvidenceTask, window string, tools []s
 	}
 
 	// 2. Generate Claude-specific instructions
-	claudeInstructions := generateClaudeInstructions(task, window)
+	templateSet := evidenceTemplates(cfg)
+	templateData := templates.NewEvidenceData(task, window, cfg)
+	claudeInstructions, err := generateClaudeInstructions(templateData, templateSet)
+	if err != nil {
+		return nil, err
+	}
 
-	// 3. Select/generate evidence template based on task category
-	evidenceTemplate := selectEvidenceTemplate(task)
+	// 3. Render the evidence template of the task's category
+	evidenceTemplate, err := selectEvidenceTemplate(templateData, templateSet)
+	if err != nil {
+		return nil, err
+	}
 
 	// 4. Identify applicable tools (from prompt or config)
 	applicableTools := identifyApplicableToolsForAssembly(task, tools)
 
+	// 5. Rank tools, policies, controls and prior evidence by relevance
+	var related []matching.Match
+	if matcher := newTaskMatcher(cfg); matcher != nil {
+		ctx := context.Background()
+		if len(tools) == 0 {
+			if matches, err := rankTaskTools(ctx, matcher, task, cfg.Evidence.Matching.Limit); err == nil {
+				applicableTools = mergeMatchedTools(applicableTools, matches)
+			} else {
+				logger.Warn("semantic tool matching failed", logger.String("task", task.ReferenceID), logger.Error(err))
+			}
+		}
+		evidenceDir := filepath.Join(cfg.Storage.DataDir, "evidence")
+		var err error
+		if related, err = relatedTaskDocuments(ctx, matcher, task, window, storage, evidenceDir, cfg.Evidence.Matching.Limit); err != nil {
+			logger.Warn("semantic document matching failed", logger.String("task", task.ReferenceID), logger.Error(err))
+		}
+	}
+
+	prompt := promptOutput.Prompt
+	if section := formatRelatedDocuments(related); section != "" {
+		prompt = strings.TrimRight(prompt, "\n") + "\n\n" + section
+	}
+
 	return &AssemblyContext{
 		Task:                task,
 		Window:              window,
-		ComprehensivePrompt: promptOutput.Prompt,
+		ComprehensivePrompt: prompt,
 		ClaudeInstructions:  claudeInstructions,
 		EvidenceTemplate:    evidenceTemplate,
 		ApplicableTools:     applicableTools,
+		RelatedDocuments:    related,
 		ToolData:            make(map[string]interface{}),
 	}, nil
 }
@@ -1656,248 +2373,54 @@ func identifyApplicableToolsForAssembly(task *domain.EvidenceTask, tools []strin
 		applicableTools = append(applicableTools, "docs-reader")
 	}
 
-	return applicableTools
-}
-
-// selectEvidenceTemplate selects an appropriate evidence template based on task category
-func selectEvidenceTemplate(task *domain.EvidenceTask) string {
-	// Get task category
-	category := task.GetCategory()
-
-	// Select template based on category
-	switch category {
-	case "Infrastructure":
-		return generateInfrastructureTemplate()
-	case "Personnel":
-		return generatePersonnelTemplate()
-	case "Process":
-		return generateProcessTemplate()
-	case "Compliance":
-		return generateComplianceTemplate()
-	case "Monitoring":
-		return generateMonitoringTemplate()
-	case "Data":
-		return generateDataTemplate()
-	default:
-		return generateGenericTemplate()
+	// Vendor management tools
+	if strings.Contains(taskText, "vendor") || strings.Contains(taskText, "third party") ||
+		strings.Contains(taskText, "third-party") || strings.Contains(taskText, "supplier") {
+		applicableTools = append(applicableTools, "vendor-review")
 	}
-}
-
-// Template generation functions (these provide structure guides for evidence)
-
-func generateGenericTemplate() string {
-	return `# Evidence Report Template
-
-## Executive Summary
-[Brief overview of compliance status and key findings]
-
-## Control Mapping
-[List of controls this evidence satisfies]
-
-## Policy Foundations
-[Related policies and governance documents]
-
-## Technical Evidence
-[Specific configurations, settings, and technical implementations]
-
-## Compliance Analysis
-[Analysis of how the evidence demonstrates compliance]
-
-## Auditor Notes
-[Additional context for auditors]
-
-## Quality Assurance
-[Verification steps and validation]
-`
-}
-
-func generateInfrastructureTemplate() string {
-	return `# Infrastructure Evidence Report
-
-## Executive Summary
-[Brief overview of infrastructure security posture]
-
-## Control Mapping
-[Controls satisfied by this infrastructure evidence]
-
-## Policy Foundations
-[Infrastructure security policies and standards]
 
-## Infrastructure Configuration
-### Cloud Resources
-[Cloud infrastructure details]
-
-### Network Security
-[Network configurations and security controls]
-
-### Access Controls
-[IAM policies, roles, and permissions]
-
-### Monitoring & Logging
-[CloudTrail, logging configurations]
-
-## Security Analysis
-[Security posture assessment]
-
-## Compliance Review
-[Compliance status against requirements]
-
-## Auditor Notes
-[Additional context for infrastructure audit]
-`
-}
-
-func generatePersonnelTemplate() string {
-	return `# Personnel Evidence Report
-
-## Executive Summary
-[Overview of personnel security controls]
-
-## Control Mapping
-[Personnel-related controls]
-
-## Policy Foundations
-[HR policies, acceptable use policies]
-
-## Personnel Security Controls
-### Access Management
-[User provisioning and deprovisioning]
-
-### Training & Awareness
-[Security training programs]
-
-### Background Checks
-[Background verification processes]
-
-## Compliance Analysis
-[Personnel control effectiveness]
-
-## Auditor Notes
-[Additional personnel security context]
-`
-}
-
-func generateProcessTemplate() string {
-	return `# Process Evidence Report
-
-## Executive Summary
-[Overview of process controls]
-
-## Control Mapping
-[Process-related controls]
-
-## Policy Foundations
-[Process policies and procedures]
-
-## Process Documentation
-### Standard Operating Procedures
-[SOPs and process documentation]
-
-### Change Management
-[Change control processes]
-
-### Incident Response
-[Incident handling procedures]
-
-## Compliance Analysis
-[Process control effectiveness]
-
-## Auditor Notes
-[Additional process context]
-`
-}
-
-func generateComplianceTemplate() string {
-	return `# Compliance Evidence Report
-
-## Executive Summary
-[Overview of compliance program]
-
-## Control Mapping
-[Compliance controls]
-
-## Policy Foundations
-[Compliance policies and frameworks]
-
-## Compliance Program
-### Framework Alignment
-[Framework mappings (SOC2, ISO27001, etc.)]
-
-### Risk Management
-[Risk assessment and treatment]
-
-### Audit & Review
-[Audit processes and findings]
+	// Incident management tools
+	if strings.Contains(taskText, "incident") || strings.Contains(taskText, "breach") || strings.Contains(taskText, "outage") {
+		applicableTools = append(applicableTools, "incident-log")
+	}
 
-## Compliance Analysis
-[Compliance posture assessment]
+	// Policy management tools
+	if strings.Contains(taskText, "policy") && (strings.Contains(taskText, "review") || strings.Contains(taskText, "approv")) {
+		applicableTools = append(applicableTools, "policy-review")
+	}
+	if strings.Contains(taskText, "policy") || strings.Contains(taskText, "policies") || strings.Contains(taskText, "code of conduct") {
+		if strings.Contains(taskText, "acknowledg") || strings.Contains(taskText, "attest") {
+			applicableTools = append(applicableTools, "policy-acknowledgment")
+		}
+	}
 
-## Auditor Notes
-[Additional compliance context]
-`
+	return applicableTools
 }
 
-func generateMonitoringTemplate() string {
-	return `# Monitoring Evidence Report
-
-## Executive Summary
-[Overview of monitoring capabilities]
-
-## Control Mapping
-[Monitoring-related controls]
-
-## Policy Foundations
-[Monitoring policies and standards]
-
-## Monitoring Infrastructure
-### Log Collection
-[Logging systems and aggregation]
-
-### Alerting & Detection
-[Alert rules and detection mechanisms]
-
-### Incident Detection
-[Security monitoring and SIEM]
-
-## Compliance Analysis
-[Monitoring effectiveness]
-
-## Auditor Notes
-[Additional monitoring context]
-`
+// selectEvidenceTemplate renders the evidence outline mapped to the task in
+// evidence.generation.templates, else the outline of the task's category, or
+// the generic outline for other categories
+func selectEvidenceTemplate(data templates.EvidenceData, evidenceTemplates *templates.EvidenceTemplates) (string, error) {
+	if content, ok, err := evidenceTemplates.RenderMapped(data); ok {
+		return content, err
+	}
+	switch category := data.Task.GetCategory(); category {
+	case "Infrastructure", "Personnel", "Process", "Compliance", "Monitoring", "Data":
+		return evidenceTemplates.Render(strings.ToLower(category), data)
+	default:
+		return evidenceTemplates.Render(templates.GenericTemplate, data)
+	}
 }
 
-func generateDataTemplate() string {
-	return `# Data Security Evidence Report
-
-## Executive Summary
-[Overview of data security controls]
-
-## Control Mapping
-[Data security controls]
-
-## Policy Foundations
-[Data protection and privacy policies]
-
-## Data Security Controls
-### Data Classification
-[Data classification scheme]
-
-### Encryption
-[Encryption at rest and in transit]
-
-### Access Controls
-[Data access restrictions]
-
-### Data Lifecycle
-[Data retention and disposal]
-
-## Compliance Analysis
-[Data security effectiveness]
-
-## Auditor Notes
-[Additional data security context]
-`
+// evidenceTemplates reads the instruction and evidence templates, with the
+// overrides in evidence.generation.template_dir and the outlines mapped in
+// evidence.generation.templates
+func evidenceTemplates(cfg *config.Config) *templates.EvidenceTemplates {
+	dir := cfg.Evidence.Generation.TemplateDir
+	if dir == "" {
+		dir = filepath.Join(cfg.Storage.DataDir, "templates")
+	}
+	return templates.NewEvidenceTemplates(dir).WithMappings(cfg.Evidence.Generation.Templates)
 }
 
 // parseJSONResult attempts to parse a JSON string into the provided struct
@@ -2643,34 +3166,6 @@ func getDataTemplate() string {
 	return getDefaultTemplate()
 }
 
-// applyTemplateVariables replaces template placeholders with actual values
-func applyTemplateVariables(template string, task *domain.EvidenceTask, window string) string {
-	replacements := map[string]string{
-		"{{TASK_REF}}":   task.ReferenceID,
-		"{{TASK_NAME}}":  task.Name,
-		"{{TUGBOAT_ID}}": fmt.Sprintf("%s", task.ID),
-		"{{WINDOW}}":     window,
-		"{{DATE}}":       time.Now().Format("2006-01-02"),
-		"{{PERIOD}}":     calculatePeriod(window),
-	}
-
-	result := template
-	for key, value := range replacements {
-		result = strings.ReplaceAll(result, key, value)
-	}
-
-	return result
-}
-
-// calculatePeriod converts a window identifier into a human-readable period description
-func calculatePeriod(window string) string {
-	// Parse window like "2025-Q4" and return period description
-	if strings.Contains(window, "Q") {
-		return fmt.Sprintf("Quarterly period %s", window)
-	}
-	return window
-}
-
 // saveAssemblyContext persists all assembly materials to disk
 func saveAssemblyContext(task *domain.EvidenceTask, window string, ctx *AssemblyContext, dataDir string) (*AssemblyPaths, error) {
 	// Determine evidence directory path
@@ -2705,9 +3200,8 @@ func saveAssemblyContext(task *domain.EvidenceTask, window string, ctx *Assembly
 		return nil, fmt.Errorf("failed to save instructions: %w", err)
 	}
 
-	// Save evidence template (with variables applied)
-	templateContent := applyTemplateVariables(ctx.EvidenceTemplate, task, window)
-	if err := os.WriteFile(assemblyPaths.TemplateFile, []byte(templateContent), 0644); err != nil {
+	// Save rendered evidence template
+	if err := os.WriteFile(assemblyPaths.TemplateFile, []byte(ctx.EvidenceTemplate), 0644); err != nil {
 		return nil, fmt.Errorf("failed to save template: %w", err)
 	}
 
@@ -2801,6 +3295,94 @@ func displayValidationStatus(cmd *cobra.Command, result *models.ValidationResult
 	cmd.Println()
 }
 
+// displayAuditorFeedback shows the auditor comments synced from Tugboat,
+// flagging the rejections that still need rework
+func displayAuditorFeedback(cmd *cobra.Command, feedback *models.EvidenceFeedback) {
+	if feedback == nil || len(feedback.Comments) == 0 {
+		return
+	}
+
+	cmd.Println("💬 AUDITOR FEEDBACK")
+	cmd.Println(strings.Repeat("─", 67))
+
+	for _, comment := range feedback.Comments {
+		icon := "💬"
+		switch {
+		case comment.Status == "rejected" && !comment.Resolved:
+			icon = "❌"
+		case comment.Status == "accepted", comment.Resolved:
+			icon = "✓"
+		}
+		cmd.Printf("  %s %s, %s (%s)\n", icon, comment.Author, comment.CreatedAt.Format("2006-01-02"), comment.Status)
+		if comment.AttachmentID != "" {
+			cmd.Printf("     Attachment: %s\n", comment.AttachmentID)
+		}
+		for _, line := range strings.Split(strings.TrimSpace(comment.Comment), "\n") {
+			cmd.Printf("     %s\n", line)
+		}
+	}
+
+	if open := len(feedback.OpenRejections()); open > 0 {
+		cmd.Printf("\n%d open rejection(s) to address before resubmitting\n", open)
+	}
+	cmd.Printf("Synced: %s\n\n", feedback.SyncedAt.Format("2006-01-02 15:04"))
+}
+
+// displaySubmissionVerification shows whether the target received the files
+// of the last submission as they were sent, flagging the ones it did not
+func displaySubmissionVerification(cmd *cobra.Command, verification *models.SubmissionVerification) {
+	if verification == nil {
+		return
+	}
+
+	switch verification.Status {
+	case "verified":
+		cmd.Printf("🔎 Verified %s: all %d file(s) received as sent\n\n", verification.VerifiedAt.Format("2006-01-02 15:04"), len(verification.Files))
+		return
+	case "error":
+		cmd.Printf("⚠️  Could not verify the submission: %s\n\n", verification.Error)
+		return
+	}
+
+	mismatches := verification.Mismatches()
+	cmd.Printf("⚠️  SUBMISSION MISMATCH: %d of %d file(s) not received as sent\n", len(mismatches), len(verification.Files))
+	cmd.Println(strings.Repeat("─", 67))
+	for _, file := range mismatches {
+		if file.Status == "missing" {
+			cmd.Printf("  ❌ %s: not found on the target\n", file.Filename)
+			continue
+		}
+		cmd.Printf("  ❌ %s: sent %d bytes, received %d\n", file.Filename, file.SentBytes, file.ReceivedBytes)
+	}
+	cmd.Printf("Verified: %s. Check the evidence on the target and resubmit the affected files.\n\n", verification.VerifiedAt.Format("2006-01-02 15:04"))
+}
+
+// transformationSteps describes the steps of a file transformation
+var transformationSteps = map[string]string{
+	models.TransformCompressedImage: "re-encoded as a smaller JPEG",
+	models.TransformConvertedToCSV:  "converted to a CSV summary",
+	models.TransformRenamedToText:   "renamed to .txt",
+	models.TransformArchived:        "compressed into a zip archive",
+	models.TransformSplit:           "split into parts with a parts manifest",
+}
+
+// displayTransformations shows the files changed to meet the target's size
+// and type limits, and what was uploaded instead
+func displayTransformations(cmd *cobra.Command, transformations []models.FileTransformation) {
+	for _, transformation := range transformations {
+		steps := make([]string, 0, len(transformation.Steps))
+		for _, step := range transformation.Steps {
+			steps = append(steps, transformationSteps[step])
+		}
+		cmd.Printf("  🗜️  %s (%s) was %s\n", transformation.Filename, transformation.Reason, strings.Join(steps, ", then "))
+		if len(transformation.UploadedAs) == 1 {
+			cmd.Printf("      uploaded as %s, %d bytes\n", transformation.UploadedAs[0], transformation.UploadedSizeBytes)
+		} else {
+			cmd.Printf("      uploaded as %d files, %d bytes\n", len(transformation.UploadedAs), transformation.UploadedSizeBytes)
+		}
+	}
+}
+
 func displayDimensionScores(cmd *cobra.Command, result *models.ValidationResult) {
 	// Parse dimension scores from checks if available
 	dimensions := make(map[string]float64)
@@ -2927,9 +3509,32 @@ func displayControlAlignment(cmd *cobra.Command, task *domain.EvidenceTask, stor
 	cmd.Println()
 }
 
-func displaySubmissionRecommendation(cmd *cobra.Command, task *domain.EvidenceTask, window string, hasFiles bool, hasValidation bool, result *models.ValidationResult, alreadySubmitted bool) {
+func displaySubmissionRecommendation(cmd *cobra.Command, task *domain.EvidenceTask, window string, hasFiles bool, hasValidation bool, result *models.ValidationResult, alreadySubmitted bool, feedback *models.EvidenceFeedback) {
 	// Check if ready
-	isReady := hasFiles && (!hasValidation || (result != nil && result.ReadyForSubmission))
+	var validation *models.ValidationResult
+	if hasValidation {
+		validation = result
+	}
+	isReady := isReadyForSubmission(hasFiles, validation)
+
+	openRejections := 0
+	if feedback != nil {
+		openRejections = len(feedback.OpenRejections())
+	}
+
+	if alreadySubmitted && openRejections > 0 {
+		cmd.Println("🔁 STATUS: REJECTED - REWORK NEEDED")
+		cmd.Println(strings.Repeat("─", 67))
+		cmd.Printf("The auditor left %d open rejection(s) on the submitted evidence.\n", openRejections)
+		cmd.Println()
+		cmd.Println("To rework and resubmit:")
+		cmd.Printf("  1. Run: grctool evidence withdraw %s --window %s --reason \"...\"\n", task.ReferenceID, window)
+		cmd.Println("  2. Address each rejection listed under AUDITOR FEEDBACK")
+		cmd.Printf("  3. Run: grctool evidence evaluate %s --window %s\n", task.ReferenceID, window)
+		cmd.Println("  4. Run: grctool evidence submit " + task.ReferenceID + " --window " + window)
+		cmd.Println(strings.Repeat("=", 67))
+		return
+	}
 
 	if alreadySubmitted {
 		cmd.Println("📦 STATUS: ALREADY SUBMITTED")
@@ -2938,7 +3543,7 @@ func displaySubmissionRecommendation(cmd *cobra.Command, task *domain.EvidenceTa
 		cmd.Println("Files are in .submitted/ folder.")
 		cmd.Println()
 		cmd.Println("To resubmit:")
-		cmd.Println("  1. Move files from .submitted/ back to root directory")
+		cmd.Printf("  1. Run: grctool evidence withdraw %s --window %s --reason \"...\"\n", task.ReferenceID, window)
 		cmd.Println("  2. Run: grctool evidence submit " + task.ReferenceID + " --window " + window)
 		cmd.Println(strings.Repeat("=", 67))
 		return
@@ -2960,6 +3565,9 @@ func displaySubmissionRecommendation(cmd *cobra.Command, task *domain.EvidenceTa
 			cmd.Printf("  ✓ Meets quality threshold (%.0f/100)\n", result.CompletenessScore)
 		}
 		cmd.Println("  ✓ Addresses key requirements")
+		if openRejections > 0 {
+			cmd.Printf("  ⚠️  %d open auditor rejection(s): confirm each is addressed\n", openRejections)
+		}
 		cmd.Println()
 		cmd.Println("Next steps:")
 		cmd.Println("  1. Review files one more time if desired")
@@ -2992,6 +3600,12 @@ func displaySubmissionRecommendation(cmd *cobra.Command, task *domain.EvidenceTa
 	cmd.Println(strings.Repeat("=", 67))
 }
 
+// isReadyForSubmission reports whether evidence can be submitted: files must be
+// present and, when the evidence has been validated, validation must have passed
+func isReadyForSubmission(hasFiles bool, validation *models.ValidationResult) bool {
+	return hasFiles && (validation == nil || validation.ReadyForSubmission)
+}
+
 // Helper functions for formatting
 
 func formatFileSize(bytes int64) string {
@@ -0,0 +1,130 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/services/submission"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var evidenceApproveCmd = &cobra.Command{
+	Use:   "approve [task-id]",
+	Short: "Record a second reviewer's approval of a window's evidence",
+	Long: `Record a second reviewer's sign-off on the evidence in a window's root,
+before it is submitted.
+
+The approval names the approver and lists each evidence file with its SHA-256
+checksum. It is saved in the window's .submission/approval.yaml, replacing any
+earlier approval. With --sign, the approval statement is signed with gpg so it
+can be verified independently of grctool.
+
+When submission.require_approval is set in .grctool.yaml, 'evidence submit'
+refuses evidence without an approval, or whose files were added or changed
+after it was approved.
+
+Examples:
+  # Approve the access review evidence
+  grctool evidence approve ET-0047 --window 2025-Q4 --approver sam@example.com
+
+  # Approve with a comment and a gpg signature from a specific key
+  grctool evidence approve ET-0047 --window 2025-Q4 --approver sam@example.com \
+    --comment "Checked against the HR roster" --gpg-key sam@example.com`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTaskRefs,
+	RunE:              runEvidenceApprove,
+}
+
+func init() {
+	evidenceCmd.AddCommand(evidenceApproveCmd)
+
+	evidenceApproveCmd.Flags().String("window", "", "evidence collection window (e.g., 2025-Q4)")
+	evidenceApproveCmd.Flags().String("approver", "", "name or email of the reviewer approving the evidence")
+	evidenceApproveCmd.Flags().String("comment", "", "comment recorded with the approval")
+	evidenceApproveCmd.Flags().Bool("sign", false, "sign the approval with gpg's default key")
+	evidenceApproveCmd.Flags().String("gpg-key", "", "sign the approval with this gpg key (implies --sign)")
+	evidenceApproveCmd.MarkFlagRequired("window")
+	evidenceApproveCmd.MarkFlagRequired("approver")
+}
+
+func runEvidenceApprove(cmd *cobra.Command, args []string) error {
+	taskRef := args[0]
+	window, _ := cmd.Flags().GetString("window")
+	approver, _ := cmd.Flags().GetString("approver")
+	comment, _ := cmd.Flags().GetString("comment")
+	sign, _ := cmd.Flags().GetBool("sign")
+	gpgKey, _ := cmd.Flags().GetString("gpg-key")
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	req := &submission.ApproveRequest{
+		TaskRef:  taskRef,
+		Window:   window,
+		Approver: strings.TrimSpace(approver),
+		Comment:  strings.TrimSpace(comment),
+	}
+	if sign || gpgKey != "" {
+		req.Signer = submission.GPGSigner(gpgKey)
+	}
+	approval, err := submission.NewSubmissionServiceWithTargets(store).Approve(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, approval)
+	}
+	displayApproval(cmd, approval, cfg.Submission.RequireApproval)
+	return nil
+}
+
+// displayApproval reports a recorded approval and the files it covers
+func displayApproval(cmd *cobra.Command, approval *models.EvidenceApproval, required bool) {
+	cmd.Printf("✅ %s approved %d file(s) for %s/%s\n", approval.Approver, len(approval.Files), approval.TaskRef, approval.Window)
+	for _, file := range approval.Files {
+		cmd.Printf("  - %s (sha256 %s)\n", file.Filename, file.SHA256)
+	}
+	if approval.Comment != "" {
+		cmd.Printf("Comment: %s\n", approval.Comment)
+	}
+	if approval.Signature != "" {
+		cmd.Println("🔏 Signed with gpg")
+	}
+	if !required {
+		cmd.Println("ℹ️  Approval is not required to submit; set submission.require_approval in .grctool.yaml to enforce it")
+	}
+	cmd.Println()
+	cmd.Println("Changing or adding evidence files invalidates the approval.")
+	cmd.Printf("Next: grctool evidence submit %s --window %s\n", approval.TaskRef, approval.Window)
+}
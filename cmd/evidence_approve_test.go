@@ -0,0 +1,58 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/grctool/grctool/internal/models"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplayApproval(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		approval models.EvidenceApproval
+		required bool
+		contains []string
+		excludes []string
+	}{
+		"signed": {
+			approval: models.EvidenceApproval{Comment: "Checked against the HR roster", Signature: "-----BEGIN PGP SIGNATURE-----"},
+			required: true,
+			contains: []string{"Comment: Checked against the HR roster\n", "🔏 Signed with gpg\n"},
+			excludes: []string{"not required"},
+		},
+		"not required": {
+			contains: []string{"ℹ️  Approval is not required to submit; set submission.require_approval in .grctool.yaml to enforce it"},
+			excludes: []string{"Comment:", "Signed"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&buf)
+
+			approval := tc.approval
+			approval.TaskRef, approval.Window, approval.Approver = "ET-0047", "2025-Q4", "sam@example.com"
+			approval.Files = []models.ApprovedFile{{Filename: "access.csv", SHA256: "9a0364b9"}}
+			displayApproval(cmd, &approval, tc.required)
+			output := buf.String()
+			assert.Contains(t, output, "✅ sam@example.com approved 1 file(s) for ET-0047/2025-Q4\n  - access.csv (sha256 9a0364b9)\n")
+			assert.Contains(t, output, "Next: grctool evidence submit ET-0047 --window 2025-Q4")
+			for _, want := range tc.contains {
+				assert.Contains(t, output, want)
+			}
+			for _, unwanted := range tc.excludes {
+				assert.NotContains(t, output, unwanted)
+			}
+		})
+	}
+}
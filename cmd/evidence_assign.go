@@ -0,0 +1,285 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/services"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/grctool/grctool/internal/tugboat"
+	"github.com/grctool/grctool/internal/vcr"
+	"github.com/spf13/cobra"
+)
+
+var evidenceAssignCmd = &cobra.Command{
+	Use:   "assign [task-id] [person...]",
+	Short: "Assign people to an evidence task",
+	Long: `Add owners to an evidence task.
+
+People are given by Tugboat member ID, email or name. Anyone assigned to a
+synced task is recognised by any of these; others need their member ID or
+email. The change is saved to the local task; with --push it is also sent to
+Tugboat Logic, the same way 'grctool sync push --assignee' does. Assignments
+that are not pushed are replaced by Tugboat's on the next sync.
+
+Examples:
+  # Assign a task locally
+  grctool evidence assign ET-0001 jane@example.com
+
+  # Assign two people and update Tugboat
+  grctool evidence assign ET-0001 jane@example.com "Raj Patel" --push`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runEvidenceAssignment(cmd, args[0], args[1:], true)
+	},
+}
+
+var evidenceUnassignCmd = &cobra.Command{
+	Use:   "unassign [task-id] [person...]",
+	Short: "Remove people from an evidence task",
+	Long: `Remove owners from an evidence task.
+
+People are matched against the task's assignees by Tugboat member ID, email
+or name. The change is saved to the local task; with --push it is also sent
+to Tugboat Logic. Tugboat tasks cannot be left without assignees by a push,
+so assign someone else first.
+
+Examples:
+  # Hand a task over from Jane to Raj
+  grctool evidence assign ET-0001 raj@example.com
+  grctool evidence unassign ET-0001 jane@example.com --push`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runEvidenceAssignment(cmd, args[0], args[1:], false)
+	},
+}
+
+func init() {
+	for _, c := range []*cobra.Command{evidenceAssignCmd, evidenceUnassignCmd} {
+		evidenceCmd.AddCommand(c)
+		c.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 0 {
+				return completeTaskRefs(cmd, args, toComplete)
+			}
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		c.Flags().Bool("push", false, "also update the assignees in Tugboat Logic")
+		c.Flags().Bool("dry-run", false, "show what would change without saving or pushing")
+		c.Flags().Bool("force", false, "push even if the task changed in Tugboat since the last sync")
+	}
+}
+
+// AssignmentResult is the structured output of 'evidence assign' and
+// 'evidence unassign'
+type AssignmentResult struct {
+	TaskRef   string               `json:"task_ref"`
+	Added     []string             `json:"added,omitempty"`
+	Removed   []string             `json:"removed,omitempty"`
+	Unchanged []string             `json:"unchanged,omitempty"`
+	Assignees []domain.Person      `json:"assignees"`
+	DryRun    bool                 `json:"dry_run,omitempty"`
+	Push      *services.PushResult `json:"push,omitempty"`
+}
+
+func runEvidenceAssignment(cmd *cobra.Command, taskRef string, people []string, assign bool) error {
+	push, _ := cmd.Flags().GetBool("push")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	force, _ := cmd.Flags().GetBool("force")
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	task, err := store.GetEvidenceTask(taskRef)
+	if err != nil {
+		return fmt.Errorf("failed to find task %s: %w", taskRef, err)
+	}
+
+	result := &AssignmentResult{TaskRef: displayTaskRef(*task), DryRun: dryRun}
+	if assign {
+		tasks, err := store.GetAllEvidenceTasks()
+		if err != nil {
+			return fmt.Errorf("failed to load evidence tasks: %w", err)
+		}
+		for _, who := range people {
+			person, err := resolvePerson(who, tasks)
+			if err != nil {
+				return err
+			}
+			if task.Assign(person) {
+				result.Added = append(result.Added, personLabel(person))
+			} else {
+				result.Unchanged = append(result.Unchanged, personLabel(person))
+			}
+		}
+	} else {
+		for _, who := range people {
+			if !task.IsAssignedTo(who) {
+				return fmt.Errorf("%s is not assigned to %s", who, result.TaskRef)
+			}
+			for _, assignee := range task.Assignees {
+				if assignee.Matches(who) {
+					result.Removed = append(result.Removed, personLabel(assignee))
+				}
+			}
+			task.Unassign(who)
+		}
+	}
+	result.Assignees = task.Assignees
+
+	if push && len(task.Assignees) == 0 {
+		return errors.New("a push cannot leave a Tugboat task without assignees; assign someone else first")
+	}
+
+	changed := len(result.Added) > 0 || len(result.Removed) > 0
+	if changed && !dryRun {
+		if err := store.SaveEvidenceTask(task); err != nil {
+			return fmt.Errorf("failed to save task: %w", err)
+		}
+	}
+
+	if push {
+		values := make([]string, 0, len(task.Assignees))
+		for _, assignee := range task.Assignees {
+			if assignee.ID != "" {
+				values = append(values, assignee.ID)
+			} else {
+				values = append(values, assignee.Email)
+			}
+		}
+
+		client := tugboat.NewClient(&cfg.Tugboat, vcr.FromEnvironment())
+		defer client.Close()
+		syncService := services.NewSyncService(client, store, cfg, logger.WithComponent("sync"))
+		result.Push, err = syncService.PushEvidenceTask(cmd.Context(), task.ID, services.TaskUpdate{Assignees: values},
+			services.PushOptions{DryRun: dryRun, Force: force})
+		if err != nil {
+			return err
+		}
+	}
+
+	if isStructuredOutput(format) {
+		if err := writeStructured(cmd, format, result); err != nil {
+			return err
+		}
+	} else {
+		displayAssignment(cmd, result)
+	}
+
+	if result.Push != nil && (result.Push.Status == services.PushStatusConflict || result.Push.Status == services.PushStatusFailed) {
+		return fmt.Errorf("assignees of %s not pushed", result.TaskRef)
+	}
+	return nil
+}
+
+// resolvePerson finds who among the assignees of synced tasks, falling back
+// to a bare member ID or email for people not yet assigned to any
+func resolvePerson(who string, tasks []domain.EvidenceTask) (domain.Person, error) {
+	who = strings.TrimSpace(who)
+	for _, task := range tasks {
+		for _, assignee := range task.Assignees {
+			if assignee.Matches(who) {
+				assignee.AssignedAt = nil
+				return assignee, nil
+			}
+		}
+	}
+	if _, err := strconv.Atoi(who); err == nil {
+		return domain.Person{ID: who}, nil
+	}
+	if strings.Contains(who, "@") {
+		return domain.Person{Email: who}, nil
+	}
+	return domain.Person{}, fmt.Errorf("unknown person %q: use a Tugboat member ID, an email, or the name of someone assigned to a synced task", who)
+}
+
+// personLabel names a person for display
+func personLabel(person domain.Person) string {
+	switch {
+	case person.Name != "" && person.Email != "":
+		return fmt.Sprintf("%s <%s>", person.Name, person.Email)
+	case person.Name != "":
+		return person.Name
+	case person.Email != "":
+		return person.Email
+	default:
+		return "member " + person.ID
+	}
+}
+
+// currentUser returns who 'evidence list --mine' looks for: user.email or
+// user.name from the config, else git's user.email
+func currentUser(cfg *config.Config) (string, error) {
+	if cfg.User.Email != "" {
+		return cfg.User.Email, nil
+	}
+	if cfg.User.Name != "" {
+		return cfg.User.Name, nil
+	}
+	if actor := storage.CustodyActor(); strings.Contains(actor, "@") {
+		return actor, nil
+	}
+	return "", errors.New("cannot tell who you are: set user.email in .grctool.yaml")
+}
+
+// displayAssignment reports an assignment change and its push to Tugboat
+func displayAssignment(cmd *cobra.Command, result *AssignmentResult) {
+	verb := ""
+	if result.DryRun {
+		verb = "would be "
+	}
+	for _, label := range result.Added {
+		cmd.Printf("👤 %s: %sassigned %s\n", result.TaskRef, verb, label)
+	}
+	for _, label := range result.Removed {
+		cmd.Printf("👤 %s: %sunassigned %s\n", result.TaskRef, verb, label)
+	}
+	for _, label := range result.Unchanged {
+		cmd.Printf("✅ %s: %s is already assigned\n", result.TaskRef, label)
+	}
+
+	labels := make([]string, 0, len(result.Assignees))
+	for _, assignee := range result.Assignees {
+		labels = append(labels, personLabel(assignee))
+	}
+	if len(labels) == 0 {
+		labels = append(labels, "none")
+	}
+	cmd.Printf("Assignees: %s\n", strings.Join(labels, ", "))
+
+	if result.Push != nil {
+		printPushResults(cmd, []services.PushResult{*result.Push}, false)
+	} else if !result.DryRun && (len(result.Added) > 0 || len(result.Removed) > 0) {
+		cmd.Println("ℹ️  Saved locally only: push with --push, or the next sync restores Tugboat's assignees")
+	}
+}
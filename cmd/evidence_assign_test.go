@@ -0,0 +1,113 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/services"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePerson(t *testing.T) {
+	t.Parallel()
+
+	jane := domain.Person{ID: "4201", Name: "Jane Doe", Email: "jane@example.com"}
+	tasks := []domain.EvidenceTask{{Assignees: []domain.Person{jane}}}
+
+	tests := map[string]struct {
+		who     string
+		want    domain.Person
+		wantErr string
+	}{
+		"known by name":   {who: "Jane Doe", want: jane},
+		"known by email":  {who: "jane@example.com", want: jane},
+		"new member id":   {who: "4299", want: domain.Person{ID: "4299"}},
+		"new email":       {who: "lee@example.com", want: domain.Person{Email: "lee@example.com"}},
+		"unknown by name": {who: "Lee Chen", wantErr: `unknown person "Lee Chen"`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			person, err := resolvePerson(tc.who, tasks)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, person)
+		})
+	}
+}
+
+func TestCurrentUser(t *testing.T) {
+	t.Parallel()
+
+	who, err := currentUser(&config.Config{User: config.UserConfig{Email: "jane@example.com", Name: "Jane Doe"}})
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", who)
+
+	who, err = currentUser(&config.Config{User: config.UserConfig{Name: "Jane Doe"}})
+	require.NoError(t, err)
+	assert.Equal(t, "Jane Doe", who)
+}
+
+func TestDisplayAssignment(t *testing.T) {
+	t.Parallel()
+
+	jane := domain.Person{ID: "4201", Name: "Jane Doe", Email: "jane@example.com"}
+	tests := map[string]struct {
+		result   AssignmentResult
+		contains []string
+		excludes []string
+	}{
+		"local only": {
+			result:   AssignmentResult{Added: []string{"Jane Doe <jane@example.com>"}, Assignees: []domain.Person{jane}},
+			contains: []string{"👤 ET-0001: assigned Jane Doe <jane@example.com>\n", "Assignees: Jane Doe <jane@example.com>\n", "Saved locally only"},
+		},
+		"dry run": {
+			result:   AssignmentResult{Removed: []string{"Jane Doe <jane@example.com>"}, DryRun: true},
+			contains: []string{"👤 ET-0001: would be unassigned Jane Doe <jane@example.com>\n", "Assignees: none\n"},
+			excludes: []string{"Saved locally only"},
+		},
+		"pushed": {
+			result: AssignmentResult{Added: []string{"member 4299"}, Assignees: []domain.Person{jane, {ID: "4299"}},
+				Push: &services.PushResult{TaskRef: "ET-0001", Status: services.PushStatusPushed, Changes: []string{"assignees: [4201] → [4201, 4299]"}}},
+			contains: []string{"Assignees: Jane Doe <jane@example.com>, member 4299\n", "✅ ET-0001: pushed assignees: [4201] → [4201, 4299]"},
+			excludes: []string{"Saved locally only"},
+		},
+		"already assigned": {
+			result:   AssignmentResult{Unchanged: []string{"jane@example.com"}, Assignees: []domain.Person{jane}},
+			contains: []string{"✅ ET-0001: jane@example.com is already assigned"},
+			excludes: []string{"Saved locally only"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&buf)
+
+			result := tc.result
+			result.TaskRef = "ET-0001"
+			displayAssignment(cmd, &result)
+
+			output := buf.String()
+			for _, want := range tc.contains {
+				assert.Contains(t, output, want)
+			}
+			for _, unwanted := range tc.excludes {
+				assert.NotContains(t, output, unwanted)
+			}
+		})
+	}
+}
@@ -22,14 +22,18 @@ import (
 	"strconv"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/grctool/grctool/internal/config"
 	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/models"
 	"github.com/grctool/grctool/internal/naming"
 	"github.com/grctool/grctool/internal/services/evidence"
 	"github.com/grctool/grctool/internal/storage"
+	"github.com/grctool/grctool/internal/tools"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -232,6 +236,151 @@ func TestIdentifyApplicableTools(t *testing.T) {
 	}
 }
 
+// TestIdentifyApplicableTools_Plugins tests that plugin tools are matched by
+// their configured keywords
+func TestIdentifyApplicableTools_Plugins(t *testing.T) {
+	log, err := logger.NewTestLogger()
+	require.NoError(t, err)
+	plugin := tools.NewPluginTool(config.PluginToolConfig{
+		Name:     "okta-mfa-test",
+		Command:  "grctool-okta",
+		Keywords: []string{"okta", "multi-factor"},
+	}, log)
+	require.NoError(t, tools.RegisterTool(plugin))
+	defer func() { _ = tools.GlobalRegistry.Unregister(plugin.Name()) }()
+
+	matched := identifyApplicableTools(&domain.EvidenceTask{
+		Name:        "MFA Enforcement",
+		Description: "Show that multi-factor authentication is enforced in Okta for all employees",
+	})
+	assert.Contains(t, matched, "okta-mfa-test")
+
+	unmatched := identifyApplicableTools(&domain.EvidenceTask{
+		Name:        "Employee Training Records",
+		Description: "Collect annual compliance training completion certificates",
+	})
+	assert.NotContains(t, unmatched, "okta-mfa-test")
+}
+
+// applicableStubTool is a registered tool that waits for delay, then
+// answers with err or its name. It stops early when its context is done.
+type applicableStubTool struct {
+	name     string
+	delay    time.Duration
+	err      error
+	inFlight *atomic.Int32
+	maxSeen  *atomic.Int32
+	running  atomic.Bool
+}
+
+func (s *applicableStubTool) Name() string        { return s.name }
+func (s *applicableStubTool) Description() string { return "stub " + s.name }
+
+func (s *applicableStubTool) GetClaudeToolDefinition() models.ClaudeTool {
+	return models.ClaudeTool{Name: s.name, Description: s.Description(), InputSchema: map[string]interface{}{"type": "object"}}
+}
+
+func (s *applicableStubTool) Execute(ctx context.Context, params map[string]interface{}) (string, *models.EvidenceSource, error) {
+	if s.inFlight != nil {
+		n := s.inFlight.Add(1)
+		defer s.inFlight.Add(-1)
+		for seen := s.maxSeen.Load(); n > seen && !s.maxSeen.CompareAndSwap(seen, n); seen = s.maxSeen.Load() {
+		}
+	}
+	s.running.Store(true)
+	defer s.running.Store(false)
+	select {
+	case <-time.After(s.delay):
+	case <-ctx.Done():
+		return "", nil, ctx.Err()
+	}
+	if s.err != nil {
+		return "", nil, s.err
+	}
+	return fmt.Sprintf(`{"tool": %q}`, s.name), nil, nil
+}
+
+// registerApplicableStubs registers stub tools for the test's duration
+func registerApplicableStubs(t *testing.T, stubs ...*applicableStubTool) {
+	t.Helper()
+	for _, stub := range stubs {
+		require.NoError(t, tools.RegisterTool(stub))
+		name := stub.name
+		t.Cleanup(func() { _ = tools.GlobalRegistry.Unregister(name) })
+	}
+}
+
+// TestExecuteApplicableTools tests that a failed, slow or unknown tool is
+// reported without stopping the others
+func TestExecuteApplicableTools(t *testing.T) {
+	slow := &applicableStubTool{name: "applicable-slow", delay: 2 * time.Second}
+	registerApplicableStubs(t,
+		&applicableStubTool{name: "applicable-ok"},
+		&applicableStubTool{name: "applicable-fail", err: fmt.Errorf("repository not found")},
+		slow,
+	)
+	cfg := &config.Config{}
+	cfg.Evidence.Tools.Concurrency = 4
+	cfg.Evidence.Tools.Timeout = 100 * time.Millisecond
+	outputDir := t.TempDir()
+	task := &domain.EvidenceTask{ReferenceID: "ET-0001", Name: "Access Review"}
+
+	start := time.Now()
+	runs, err := executeApplicableTools(context.Background(), task,
+		[]string{"applicable-ok", "applicable-fail", "applicable-slow", "applicable-missing"}, outputDir, cfg)
+	assert.Less(t, time.Since(start), time.Second, "the slow tool is cancelled at its timeout")
+	assert.False(t, slow.running.Load(), "the slow tool has stopped when the runs are returned")
+
+	require.Error(t, err)
+	assert.Equal(t, "3 of 4 tools failed: applicable-fail, applicable-slow, applicable-missing", err.Error())
+	require.Len(t, runs, 4)
+
+	assert.Equal(t, toolRunSucceeded, runs[0].Status)
+	assert.Equal(t, len(`{"tool": "applicable-ok"}`), runs[0].OutputBytes)
+	assert.FileExists(t, filepath.Join(outputDir, "applicable-ok.json"))
+
+	assert.Equal(t, toolRunFailed, runs[1].Status)
+	assert.Equal(t, "repository not found", runs[1].Error)
+	assert.NoFileExists(t, filepath.Join(outputDir, "applicable-fail.json"))
+
+	assert.Equal(t, toolRunTimedOut, runs[2].Status)
+	assert.Equal(t, "timed out after 100ms", runs[2].Error)
+
+	assert.Equal(t, toolRunFailed, runs[3].Status)
+	assert.Contains(t, runs[3].Error, "applicable-missing")
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	printToolRuns(cmd, runs)
+	assert.Contains(t, out.String(), "TOOL")
+	assert.Regexp(t, `applicable-fail\s+failed\s+\S+\s+repository not found`, out.String())
+	assert.Regexp(t, `applicable-ok\s+succeeded\s+\S+\s+25 bytes`, out.String())
+}
+
+// TestExecuteApplicableTools_Concurrency tests that no more than
+// evidence.tools.concurrency tools run at once
+func TestExecuteApplicableTools_Concurrency(t *testing.T) {
+	var inFlight, maxSeen atomic.Int32
+	var names []string
+	for i := 0; i < 6; i++ {
+		name := fmt.Sprintf("applicable-pool-%d", i)
+		names = append(names, name)
+		registerApplicableStubs(t, &applicableStubTool{name: name, delay: 100 * time.Millisecond, inFlight: &inFlight, maxSeen: &maxSeen})
+	}
+	cfg := &config.Config{}
+	cfg.Evidence.Tools.Concurrency = 2
+
+	runs, err := executeApplicableTools(context.Background(), &domain.EvidenceTask{ReferenceID: "ET-0001"}, names, t.TempDir(), cfg)
+	require.NoError(t, err)
+	require.Len(t, runs, 6)
+	for i, run := range runs {
+		assert.Equal(t, names[i], run.Tool, "runs are reported in tool order")
+		assert.Equal(t, toolRunSucceeded, run.Status)
+	}
+	assert.Equal(t, int32(2), maxSeen.Load())
+}
+
 // TestFormatContextAsMarkdown tests markdown context generation
 func TestFormatContextAsMarkdown(t *testing.T) {
 	task := &domain.EvidenceTask{
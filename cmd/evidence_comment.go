@@ -0,0 +1,154 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/services"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/grctool/grctool/internal/tugboat"
+	"github.com/grctool/grctool/internal/vcr"
+	"github.com/spf13/cobra"
+)
+
+var evidenceCommentCmd = &cobra.Command{
+	Use:   "comment [task-id] [message]",
+	Short: "Post a comment on an evidence task, or show its comment thread",
+	Long: `Post a comment on an evidence task in Tugboat Logic, or show the task's
+comment thread.
+
+The thread is kept in comments.yaml in the task's evidence directory, so
+questions and answers about collecting the evidence live alongside it. Posted
+comments are added to it straight away, and 'grctool sync --submissions'
+refreshes it with everything commented in Tugboat, including auditor review
+decisions. Use --fetch to refresh one task's thread before showing it.
+
+Examples:
+  # Ask a question on the task
+  grctool evidence comment ET-0047 "Does the export need to include service accounts?"
+
+  # Show the thread
+  grctool evidence comment ET-0047
+
+  # Show the thread with the latest replies from Tugboat
+  grctool evidence comment ET-0047 --fetch`,
+	Args:              cobra.RangeArgs(1, 2),
+	ValidArgsFunction: completeTaskRefs,
+	RunE:              runEvidenceComment,
+}
+
+func init() {
+	evidenceCmd.AddCommand(evidenceCommentCmd)
+
+	evidenceCommentCmd.Flags().Bool("fetch", false, "refresh the thread from Tugboat before showing it")
+}
+
+func runEvidenceComment(cmd *cobra.Command, args []string) error {
+	fetch, _ := cmd.Flags().GetBool("fetch")
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	task, err := store.GetEvidenceTask(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to find task %s: %w", args[0], err)
+	}
+	taskRef := displayTaskRef(*task)
+
+	var syncService *services.SyncService
+	if len(args) == 2 || fetch {
+		client := tugboat.NewClient(&cfg.Tugboat, vcr.FromEnvironment())
+		defer client.Close()
+		syncService = services.NewSyncService(client, store, cfg, logger.WithComponent("sync"))
+	}
+
+	if len(args) == 2 {
+		comment, err := syncService.PostComment(cmd.Context(), taskRef, args[1])
+		if err != nil {
+			return fmt.Errorf("failed to post comment: %w", err)
+		}
+		if isStructuredOutput(format) {
+			return writeStructured(cmd, format, comment)
+		}
+		cmd.Printf("💬 Posted comment %s on %s\n", comment.ID, taskRef)
+		return nil
+	}
+
+	var thread *models.TaskComments
+	if fetch {
+		if thread, err = syncService.SyncTaskComments(cmd.Context(), taskRef); err != nil {
+			return fmt.Errorf("failed to fetch comments: %w", err)
+		}
+	} else if thread, err = store.LoadTaskComments(taskRef); err != nil {
+		return err
+	}
+
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, thread)
+	}
+	displayCommentThread(cmd, thread)
+	return nil
+}
+
+// displayCommentThread prints a task's comments, oldest first
+func displayCommentThread(cmd *cobra.Command, thread *models.TaskComments) {
+	if len(thread.Comments) == 0 {
+		cmd.Printf("💬 No comments on %s\n", thread.TaskRef)
+		if thread.SyncedAt.IsZero() {
+			cmd.Println("Run with --fetch to load the thread from Tugboat")
+		}
+		return
+	}
+
+	cmd.Printf("💬 %s: %d comment(s)\n", thread.TaskRef, len(thread.Comments))
+	for _, comment := range thread.Comments {
+		heading := fmt.Sprintf("%s  %s", comment.CreatedAt.Local().Format("2006-01-02 15:04"), comment.Author)
+		switch comment.Status {
+		case "accepted":
+			heading += "  ✅ accepted"
+		case "rejected":
+			heading += "  ❌ rejected"
+			if comment.Resolved {
+				heading += " (resolved)"
+			}
+		}
+		cmd.Println()
+		cmd.Println(heading)
+		for _, line := range strings.Split(strings.TrimSpace(comment.Comment), "\n") {
+			cmd.Printf("  %s\n", line)
+		}
+	}
+	if !thread.SyncedAt.IsZero() {
+		cmd.Println()
+		cmd.Printf("Synced: %s\n", thread.SyncedAt.Local().Format("2006-01-02 15:04"))
+	}
+}
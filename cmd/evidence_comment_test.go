@@ -0,0 +1,66 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/models"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplayCommentThread(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2025, 11, 6, 8, 30, 0, 0, time.Local)
+	tests := map[string]struct {
+		thread   models.TaskComments
+		contains []string
+		excludes []string
+	}{
+		"never synced": {
+			thread:   models.TaskComments{TaskRef: "ET-0047"},
+			contains: []string{"💬 No comments on ET-0047\n", "Run with --fetch"},
+		},
+		"synced without comments": {
+			thread:   models.TaskComments{TaskRef: "ET-0047", SyncedAt: created},
+			contains: []string{"💬 No comments on ET-0047\n"},
+			excludes: []string{"--fetch"},
+		},
+		"thread": {
+			thread: models.TaskComments{TaskRef: "ET-0047", SyncedAt: created.Add(time.Hour), Comments: []models.FeedbackComment{
+				{ID: "903", Author: "Jane Doe", Status: "comment", Comment: "Does the export need service accounts?", CreatedAt: created},
+				{ID: "904", Author: "Alex Auditor", Status: "rejected", Comment: "Include them.\nAnd the date.", CreatedAt: created.Add(30 * time.Minute), Resolved: true},
+			}},
+			contains: []string{
+				"💬 ET-0047: 2 comment(s)\n",
+				"2025-11-06 08:30  Jane Doe\n  Does the export need service accounts?\n",
+				"2025-11-06 09:00  Alex Auditor  ❌ rejected (resolved)\n  Include them.\n  And the date.\n",
+				"Synced: 2025-11-06 09:30\n",
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&buf)
+
+			thread := tc.thread
+			displayCommentThread(cmd, &thread)
+			output := buf.String()
+			for _, want := range tc.contains {
+				assert.Contains(t, output, want)
+			}
+			for _, unwanted := range tc.excludes {
+				assert.NotContains(t, output, unwanted)
+			}
+		})
+	}
+}
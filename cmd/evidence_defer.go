@@ -0,0 +1,186 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/grctool/grctool/internal/tugboat"
+	"github.com/spf13/cobra"
+)
+
+var evidenceDeferCmd = &cobra.Command{
+	Use:   "defer [task-id]",
+	Short: "Postpone an evidence task with a justification",
+	Long: `Defer an evidence task until a date, recording why.
+
+Deferrals are kept locally in the docs directory, so sync does not overwrite
+them. Until the date passes the task is not counted as overdue by 'evidence
+list', 'schedule', 'notify' or the dashboard, and is reported separately as
+deferred instead. With --tugboat-note the justification is also posted as a
+comment on the task in Tugboat, so auditors see it.
+
+Examples:
+  # Defer a task until a vendor report arrives
+  grctool evidence defer ET-0103 --until 2026-01-15 --reason "Waiting on vendor SOC 2 report"
+
+  # Record the deferral in Tugboat too
+  grctool evidence defer ET-0103 --until 2026-01-15 --reason "Waiting on vendor SOC 2 report" --tugboat-note
+
+  # Lift a deferral early
+  grctool evidence defer ET-0103 --clear`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTaskRefs,
+	RunE:              runEvidenceDefer,
+}
+
+func init() {
+	evidenceCmd.AddCommand(evidenceDeferCmd)
+
+	evidenceDeferCmd.Flags().String("until", "", "date the task is due again (YYYY-MM-DD)")
+	evidenceDeferCmd.Flags().String("reason", "", "why the task is deferred")
+	evidenceDeferCmd.Flags().String("by", "", "who approved the deferral (default: git user.email)")
+	evidenceDeferCmd.Flags().Bool("tugboat-note", false, "also post the justification as a comment on the Tugboat task")
+	evidenceDeferCmd.Flags().Bool("clear", false, "remove the task's deferral")
+}
+
+func runEvidenceDefer(cmd *cobra.Command, args []string) error {
+	untilFlag, _ := cmd.Flags().GetString("until")
+	reason, _ := cmd.Flags().GetString("reason")
+	by, _ := cmd.Flags().GetString("by")
+	tugboatNote, _ := cmd.Flags().GetBool("tugboat-note")
+	clearDeferral, _ := cmd.Flags().GetBool("clear")
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	task, err := store.GetEvidenceTask(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to find task %s: %w", args[0], err)
+	}
+	if task.ReferenceID == "" {
+		return fmt.Errorf("task %s has no reference ID; run 'grctool sync' first", args[0])
+	}
+
+	if clearDeferral {
+		existing, err := store.GetTaskDeferral(task.ReferenceID)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return fmt.Errorf("task %s is not deferred", task.ReferenceID)
+		}
+		if err := store.DeleteTaskDeferral(task.ReferenceID); err != nil {
+			return fmt.Errorf("failed to remove deferral: %w", err)
+		}
+		if isStructuredOutput(format) {
+			return writeStructured(cmd, format, existing)
+		}
+		cmd.Printf("▶️  Cleared deferral of %s; it is due %s again\n", task.ReferenceID, formatDueDate(task.NextDue))
+		return nil
+	}
+
+	if untilFlag == "" || strings.TrimSpace(reason) == "" {
+		return fmt.Errorf("--until and --reason are required")
+	}
+	until, err := time.ParseInLocation("2006-01-02", untilFlag, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid --until date %q, expected YYYY-MM-DD", untilFlag)
+	}
+	if by == "" {
+		by = storage.CustodyActor()
+	}
+
+	deferral := &domain.TaskDeferral{
+		TaskRef:    task.ReferenceID,
+		Until:      until,
+		Reason:     strings.TrimSpace(reason),
+		DeferredBy: by,
+		DeferredAt: time.Now(),
+		DueDate:    task.NextDue,
+	}
+	if err := deferral.Validate(); err != nil {
+		return err
+	}
+
+	if tugboatNote {
+		taskID, err := strconv.Atoi(task.ID)
+		if err != nil {
+			return fmt.Errorf("task %s has no Tugboat ID to comment on", task.ReferenceID)
+		}
+		client := tugboat.NewClient(&cfg.Tugboat, nil)
+		comment, err := client.CreateEvidenceComment(context.Background(), taskID, deferralNote(deferral))
+		if err != nil {
+			return fmt.Errorf("%w\nThe deferral was not recorded; run again, or omit --tugboat-note", err)
+		}
+		deferral.TugboatCommentID = comment.ID
+	}
+
+	if err := store.SaveTaskDeferral(deferral); err != nil {
+		return fmt.Errorf("failed to save deferral: %w", err)
+	}
+
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, deferral)
+	}
+	displayDeferral(cmd, task.Name, deferral)
+	return nil
+}
+
+// deferralNote is the comment posted to Tugboat for a deferral
+func deferralNote(deferral *domain.TaskDeferral) string {
+	return fmt.Sprintf("Deferred until %s by %s: %s",
+		deferral.Until.Format("2006-01-02"), deferral.DeferredBy, deferral.Reason)
+}
+
+// displayDeferral reports a recorded deferral
+func displayDeferral(cmd *cobra.Command, taskName string, deferral *domain.TaskDeferral) {
+	cmd.Printf("⏸️  Deferred %s (%s) until %s\n", deferral.TaskRef, taskName, deferral.Until.Format("2006-01-02"))
+	cmd.Printf("Reason: %s\n", deferral.Reason)
+	if deferral.DeferredBy != "" {
+		cmd.Printf("Deferred by: %s\n", deferral.DeferredBy)
+	}
+	cmd.Printf("Was due: %s\n", formatDueDate(deferral.DueDate))
+	if deferral.TugboatCommentID != 0 {
+		cmd.Printf("📝 Posted note to Tugboat (comment %d)\n", deferral.TugboatCommentID)
+	}
+}
+
+// formatDueDate formats an optional due date for display
+func formatDueDate(due *time.Time) string {
+	if due == nil {
+		return "not scheduled"
+	}
+	return due.Format("2006-01-02")
+}
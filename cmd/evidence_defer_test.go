@@ -0,0 +1,67 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplayDeferral(t *testing.T) {
+	t.Parallel()
+
+	due := time.Date(2025, 11, 30, 0, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		deferral domain.TaskDeferral
+		contains []string
+		excludes []string
+	}{
+		"local only": {
+			deferral: domain.TaskDeferral{DeferredBy: "alice@example.com", DueDate: &due},
+			contains: []string{"Deferred by: alice@example.com\n", "Was due: 2025-11-30\n"},
+			excludes: []string{"Tugboat"},
+		},
+		"with tugboat note": {
+			deferral: domain.TaskDeferral{TugboatCommentID: 77},
+			contains: []string{"Was due: not scheduled\n", "📝 Posted note to Tugboat (comment 77)"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&buf)
+
+			deferral := tc.deferral
+			deferral.TaskRef = "ET-0103"
+			deferral.Until = time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+			deferral.Reason = "Waiting on vendor SOC 2 report"
+			displayDeferral(cmd, "Vendor Reviews", &deferral)
+
+			output := buf.String()
+			assert.Contains(t, output, "⏸️  Deferred ET-0103 (Vendor Reviews) until 2026-01-15\nReason: Waiting on vendor SOC 2 report\n")
+			for _, want := range tc.contains {
+				assert.Contains(t, output, want)
+			}
+			for _, unwanted := range tc.excludes {
+				assert.NotContains(t, output, unwanted)
+			}
+		})
+	}
+}
+
+func TestDeferralNote(t *testing.T) {
+	t.Parallel()
+
+	note := deferralNote(&domain.TaskDeferral{TaskRef: "ET-0103", Until: time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC),
+		Reason: "Waiting on vendor SOC 2 report", DeferredBy: "alice@example.com"})
+	assert.Equal(t, "Deferred until 2026-01-15 by alice@example.com: Waiting on vendor SOC 2 report", note)
+}
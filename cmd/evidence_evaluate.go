@@ -21,11 +21,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"text/tabwriter"
 
 	"github.com/grctool/grctool/internal/config"
 	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/llm"
 	"github.com/grctool/grctool/internal/logger"
 	"github.com/grctool/grctool/internal/models"
 	"github.com/grctool/grctool/internal/naming"
@@ -49,12 +51,23 @@ var evidenceEvaluateCmd = &cobra.Command{
 	Short: "Evaluate evidence against task requirements",
 	Long: `Evaluate evidence comprehensively across four dimensions:
 
-Evaluation Dimensions:
+Evaluation Dimensions (default weights):
   1. Completeness (30%) - Required files and metadata present
   2. Requirements Match (30%) - Evidence addresses task requirements
   3. Quality (20%) - File formats, naming, and structure
   4. Control Alignment (20%) - Evidence addresses related controls
 
+Rubrics under evidence.evaluation.rubrics in the config replace the weights,
+the pass thresholds of the overall score and of each dimension, and add
+required checks (files the evidence must include) for some tasks or
+categories.
+
+With a model configured under evidence.llm (or evidence.llm.operations.evaluate),
+the model reads the evidence files and scores requirements match. Ollama and
+llama.cpp serve it on this machine; OpenAI, Anthropic, AWS Bedrock and Vertex AI
+only receive evidence when allow_remote is set. When the model cannot be
+reached, evaluation falls back to its heuristics.
+
 The evaluation produces:
   - Overall score (0-100) and pass/fail status
   - Individual dimension scores
@@ -71,7 +84,13 @@ Examples:
   grctool evidence evaluate ET-0001 --window 2025-Q4 --subfolder .submitted
 
   # Evaluate all tasks
-  grctool evidence evaluate --all`,
+  grctool evidence evaluate --all
+
+  # Score on heuristics only, without the configured model
+  grctool evidence evaluate ET-0001 --window 2025-Q4 --no-llm
+
+  # Write a JUnit report for CI (exits non-zero when any evaluation fails)
+  grctool evidence evaluate --all --format junit -o reports/evidence-evaluation.xml`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runEvidenceEvaluate,
 }
@@ -80,9 +99,11 @@ func init() {
 	evidenceEvaluateCmd.Flags().String("window", "", "specific window to evaluate (e.g., 2025-Q4)")
 	evidenceEvaluateCmd.Flags().String("subfolder", "", "evaluate specific subfolder only (.submitted/archive)")
 	evidenceEvaluateCmd.Flags().Bool("all", false, "evaluate all evidence tasks")
-	evidenceEvaluateCmd.Flags().StringP("output", "o", "", "output results to JSON file")
+	evidenceEvaluateCmd.Flags().StringP("output", "o", "", "output results to JSON file (JUnit XML with --format junit)")
+	evidenceEvaluateCmd.Flags().String("format", reportFormatText, "report format (text, junit)")
 	evidenceEvaluateCmd.Flags().Bool("save-validation", true, "save results to .validation/validation.yaml")
 	evidenceEvaluateCmd.Flags().Bool("verbose", false, "show detailed evaluation information")
+	evidenceEvaluateCmd.Flags().Bool("no-llm", false, "do not have the configured model review the evidence")
 }
 
 func runEvidenceEvaluate(cmd *cobra.Command, args []string) error {
@@ -95,8 +116,14 @@ func runEvidenceEvaluate(cmd *cobra.Command, args []string) error {
 	outputFile, _ := cmd.Flags().GetString("output")
 	saveValidation, _ := cmd.Flags().GetBool("save-validation")
 	verbose, _ := cmd.Flags().GetBool("verbose")
+	format, _ := cmd.Flags().GetString("format")
+	noLLM, _ := cmd.Flags().GetBool("no-llm")
 
 	// Validate arguments
+	if err := validateReportFormat(format); err != nil {
+		return err
+	}
+
 	if !all && len(args) == 0 {
 		return fmt.Errorf("task reference required (or use --all flag)")
 	}
@@ -140,6 +167,23 @@ func runEvidenceEvaluate(cmd *cobra.Command, args []string) error {
 
 	scanner := services.NewEvidenceScanner(evidenceDir, storageAdapter, log)
 	evaluatorService := services.NewEvidenceEvaluatorService(evidenceDir, store, scanner, log)
+	evaluatorService.SetRubrics(cfg.Evidence.Evaluation)
+	if !noLLM {
+		llmConfig := cfg.Evidence.LLM.For(config.LLMOperationEvaluate)
+		model, err := llm.NewModel(llmConfig, nil)
+		if err != nil {
+			return err
+		}
+		evaluatorService.SetModel(model, llmConfig.MaxContextChars)
+	}
+
+	if format == reportFormatJUnit {
+		var taskRef string
+		if !all {
+			taskRef = args[0]
+		}
+		return evaluateJUnit(ctx, cmd, scanner, evaluatorService, store, taskRef, window, subfolder, saveValidation, outputFile)
+	}
 
 	if all {
 		// Evaluate all tasks
@@ -253,10 +297,89 @@ func evaluateAllTasks(ctx context.Context, scanner services.EvidenceScanner, eva
 	return nil
 }
 
+// evaluateJUnit evaluates one task (or all tasks when taskRef is empty) without
+// terminal output and writes a JUnit report to outputFile or stdout. It returns
+// an error when any evaluation fails so CI builds fail on evidence regressions.
+func evaluateJUnit(ctx context.Context, cmd *cobra.Command, scanner services.EvidenceScanner, evaluator *services.EvidenceEvaluatorService,
+	storage *storage.Storage, taskRef, window, subfolder string, saveValidation bool, outputFile string) error {
+
+	var suites []junitTestSuite
+
+	if taskRef != "" {
+		var result *models.EvaluationResult
+		var err error
+		if subfolder != "" {
+			result, err = evaluator.EvaluateSubfolder(ctx, taskRef, window, subfolder)
+		} else {
+			result, err = evaluator.EvaluateWindow(ctx, taskRef, window)
+		}
+		if err != nil {
+			suites = append(suites, junitErrorSuite(junitSuiteName(taskRef, window, subfolder), err))
+		} else {
+			suites = append(suites, evaluationJUnitSuite(result))
+			if saveValidation && subfolder != "" {
+				if err := saveValidationMetadata(storage, result); err != nil {
+					cmd.PrintErrf("⚠ Warning: Failed to save validation metadata: %v\n", err)
+				}
+			}
+		}
+	} else {
+		taskStates, err := scanner.ScanAll(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to scan evidence: %w", err)
+		}
+
+		taskRefs := make([]string, 0, len(taskStates))
+		for ref := range taskStates {
+			taskRefs = append(taskRefs, ref)
+		}
+		sort.Strings(taskRefs)
+
+		for _, ref := range taskRefs {
+			windows := make([]string, 0, len(taskStates[ref].Windows))
+			for name := range taskStates[ref].Windows {
+				windows = append(windows, name)
+			}
+			sort.Strings(windows)
+
+			for _, name := range windows {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				result, err := evaluator.EvaluateWindow(ctx, ref, name)
+				if err != nil {
+					suites = append(suites, junitErrorSuite(junitSuiteName(ref, name, ""), err))
+					continue
+				}
+				suites = append(suites, evaluationJUnitSuite(result))
+			}
+		}
+	}
+
+	if err := saveJUnitReport(cmd.OutOrStdout(), outputFile, "grctool evidence evaluate", suites); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	if outputFile != "" {
+		cmd.Printf("✓ JUnit report saved to: %s\n", outputFile)
+	}
+
+	if failed := junitFailureCount(suites); failed > 0 {
+		return fmt.Errorf("%d of %d evidence evaluations failed", failed, len(suites))
+	}
+	return nil
+}
+
 func displayEvaluationResult(result *models.EvaluationResult, verbose bool) {
 	// Overall score and status
 	status := getStatusEmoji(result.OverallStatus)
-	fmt.Printf("%s Overall Score: %.1f/100 (%s)\n\n", status, result.OverallScore, strings.ToUpper(string(result.OverallStatus)))
+	fmt.Printf("%s Overall Score: %.1f/100 (%s)\n", status, result.OverallScore, strings.ToUpper(string(result.OverallStatus)))
+	if result.Rubric != "" {
+		fmt.Printf("Rubric: %s (pass threshold %.0f)\n", result.Rubric, result.PassThreshold)
+	}
+	if result.ReviewedBy != "" {
+		fmt.Printf("Reviewed by: %s\n", result.ReviewedBy)
+	}
+	fmt.Println()
 
 	// Dimension scores
 	fmt.Println("Dimension Scores:")
@@ -272,6 +395,14 @@ func displayEvaluationResult(result *models.EvaluationResult, verbose bool) {
 	w.Flush()
 	fmt.Println()
 
+	if len(result.RequiredChecks) > 0 {
+		fmt.Println("Required Checks:")
+		for _, check := range result.RequiredChecks {
+			fmt.Printf("  %s %s: %s\n", getDimensionStatusEmoji(check.Status), check.Name, check.Details)
+		}
+		fmt.Println()
+	}
+
 	// Evidence summary
 	fmt.Printf("Evidence Files: %d files (%.2f KB total)\n\n",
 		result.FileCount, float64(result.TotalBytes)/1024)
@@ -315,6 +446,10 @@ func displayEvaluationResult(result *models.EvaluationResult, verbose bool) {
 }
 
 func displayDimension(w *tabwriter.Writer, name string, dimension models.DimensionScore) {
+	if dimension.Status == models.DimensionSkipped {
+		fmt.Fprintf(w, "  %s\t-\t0%%\t%s\n", name, dimension.Status)
+		return
+	}
 	status := getDimensionStatusEmoji(dimension.Status)
 	fmt.Fprintf(w, "  %s\t%.1f/%.0f\t%.0f%%\t%s %s\n",
 		name, dimension.Score, dimension.MaxScore, dimension.Weight*100, status, dimension.Status)
@@ -355,19 +490,20 @@ func displayEvaluationSummary(results []*models.EvaluationResult, errors []strin
 
 func saveValidationMetadata(storage *storage.Storage, result *models.EvaluationResult) error {
 	// Convert EvaluationResult to ValidationResult format
+	checks := buildValidationChecks(result)
 	validationResult := &models.ValidationResult{
 		TaskRef:             result.TaskRef,
 		Window:              result.Window,
 		Status:              string(result.OverallStatus),
 		ValidationMode:      "comprehensive",
 		CompletenessScore:   result.OverallScore / 100.0,
-		TotalChecks:         4, // Four evaluation dimensions
-		PassedChecks:        countPassedDimensions(result),
-		FailedChecks:        countFailedDimensions(result),
+		TotalChecks:         len(checks), // Scored dimensions and required checks
+		PassedChecks:        countPassedDimensions(result) + countRequiredChecks(result, "pass"),
+		FailedChecks:        countFailedDimensions(result) + countRequiredChecks(result, "fail"),
 		Warnings:            countWarnings(result),
 		Errors:              convertIssuesToValidationErrors(result.Issues),
 		WarningsList:        []models.ValidationError{},
-		Checks:              buildValidationChecks(result),
+		Checks:              checks,
 		EvidenceFiles:       []models.EvidenceFileRef{},
 		ReadyForSubmission:  result.OverallStatus == models.EvaluationPass,
 		ValidationTimestamp: result.EvaluatedAt,
@@ -435,8 +571,19 @@ func convertIssuesToValidationErrors(issues []models.EvaluationIssue) []models.V
 	return errors
 }
 
+// countRequiredChecks counts the rubric's required checks with a status
+func countRequiredChecks(result *models.EvaluationResult, status string) int {
+	count := 0
+	for _, check := range result.RequiredChecks {
+		if check.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
 func buildValidationChecks(result *models.EvaluationResult) []models.ValidationCheck {
-	checks := []models.ValidationCheck{
+	dimensions := []models.ValidationCheck{
 		{
 			Code:     "completeness",
 			Name:     "Completeness",
@@ -466,6 +613,26 @@ func buildValidationChecks(result *models.EvaluationResult) []models.ValidationC
 			Message:  result.ControlAlignment.Details,
 		},
 	}
+
+	checks := []models.ValidationCheck{}
+	for _, check := range dimensions {
+		if check.Status != models.DimensionSkipped {
+			checks = append(checks, check)
+		}
+	}
+	for _, check := range result.RequiredChecks {
+		severity := getSeverityFromStatus(check.Status)
+		if check.Status == "fail" && check.Severity != models.IssueCritical && check.Severity != models.IssueHigh {
+			severity = "warning"
+		}
+		checks = append(checks, models.ValidationCheck{
+			Code:     "required:" + check.Name,
+			Name:     check.Name,
+			Status:   check.Status,
+			Severity: severity,
+			Message:  check.Details,
+		})
+	}
 	return checks
 }
 
@@ -0,0 +1,224 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/naming"
+	"github.com/grctool/grctool/internal/services/conversion"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var evidenceExportCmd = &cobra.Command{
+	Use:   "export [task-ref]",
+	Short: "Export generated evidence documents for auditors",
+	Long: `Export the generated markdown evidence documents of a task window into
+auditor-ready formats.
+
+With --pdf, every markdown evidence file in the window (inline code snippets and
+source references included) is rendered to a PDF with a title page, table of
+contents, and page headers. Files are read from the window root, or from
+.submitted/ when the evidence has already been submitted.
+
+PDFs are written to the window's exports/ folder by default, which is not
+picked up by 'evidence submit'.
+
+Examples:
+  # Export the current quarter's evidence as PDF
+  grctool evidence export ET-0001 --pdf
+
+  # Export a specific window to a directory for the auditor
+  grctool evidence export ET-0001 --window 2025-Q3 --pdf --output-dir ./audit-2025`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEvidenceExport,
+}
+
+func init() {
+	evidenceCmd.AddCommand(evidenceExportCmd)
+
+	evidenceExportCmd.Flags().String("window", "", "evidence collection window (e.g., 2025-Q4, defaults to current quarter)")
+	evidenceExportCmd.Flags().Bool("pdf", false, "render markdown evidence documents to PDF")
+	evidenceExportCmd.Flags().String("output-dir", "", "directory for exported files (default: the window's exports/ folder)")
+	evidenceExportCmd.Flags().String("page-size", "A4", "PDF page size (A4, Letter)")
+	evidenceExportCmd.Flags().Bool("no-toc", false, "omit the table of contents")
+	evidenceExportCmd.Flags().String("header-image", "", "PNG/JPEG logo for the PDF title page")
+
+	evidenceExportCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return completeTaskRefs(cmd, args, toComplete)
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func runEvidenceExport(cmd *cobra.Command, args []string) error {
+	exportPDF, _ := cmd.Flags().GetBool("pdf")
+	if !exportPDF {
+		return fmt.Errorf("no export format selected, use --pdf")
+	}
+
+	window, _ := cmd.Flags().GetString("window")
+	if window == "" {
+		window = getCurrentQuarter()
+	}
+	outputDir, _ := cmd.Flags().GetString("output-dir")
+	pageSize, _ := cmd.Flags().GetString("page-size")
+	noTOC, _ := cmd.Flags().GetBool("no-toc")
+	headerImage, _ := cmd.Flags().GetString("header-image")
+
+	switch {
+	case strings.EqualFold(pageSize, "A4"):
+		pageSize = "A4"
+	case strings.EqualFold(pageSize, "Letter"):
+		pageSize = "Letter"
+	default:
+		return fmt.Errorf("invalid page size %q (must be A4 or Letter)", pageSize)
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Initialize storage
+	storage, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	taskRef := args[0]
+	task, err := storage.GetEvidenceTask(taskRef)
+	if err != nil {
+		return fmt.Errorf("evidence task not found: %s", taskRef)
+	}
+
+	sourceDir, files, err := findExportableEvidence(storage, task.ReferenceID, window)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no markdown evidence documents found for %s in window %s", task.ReferenceID, window)
+	}
+
+	if outputDir == "" {
+		outputDir = filepath.Join(sourceDir, naming.SubfolderExports)
+	}
+
+	opts := conversion.DefaultOptions()
+	opts.PageSize = pageSize
+	opts.GenerateTOC = !noTOC
+	opts.HeaderImage = headerImage
+	opts.TaskRef = task.ReferenceID
+	opts.Window = window
+	opts.Author = "grctool"
+	opts.Subject = task.Name
+
+	cmd.Printf("📄 Exporting %d evidence document(s) for %s (%s) to PDF...\n", len(files), task.ReferenceID, window)
+	exported, err := exportEvidencePDFs(conversion.NewConverter(), task, storage.GetBaseDir(), files, outputDir, opts)
+	for _, path := range exported {
+		cmd.Printf("  ✅ %s\n", path)
+	}
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("\nExported %d PDF(s) to: %s\n", len(exported), outputDir)
+	return nil
+}
+
+// findExportableEvidence returns the markdown evidence files of a window,
+// reading the root directory first and falling back to .submitted/
+func findExportableEvidence(storage *storage.Storage, taskRef, window string) (string, []models.EvidenceFileRef, error) {
+	files, err := storage.GetEvidenceFiles(taskRef, window)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get evidence files: %w", err)
+	}
+
+	markdown := filterMarkdownEvidence(files)
+	if len(markdown) == 0 {
+		submitted, err := storage.GetEvidenceFilesFromSubfolder(taskRef, window, naming.SubfolderSubmitted)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get submitted evidence files: %w", err)
+		}
+		markdown = filterMarkdownEvidence(submitted)
+	}
+
+	if len(markdown) == 0 {
+		return "", nil, nil
+	}
+
+	// The window directory is the parent of the root files (or of .submitted/)
+	sourceDir := filepath.Dir(filepath.Join(storage.GetBaseDir(), markdown[0].RelativePath))
+	if filepath.Base(sourceDir) == naming.SubfolderSubmitted {
+		sourceDir = filepath.Dir(sourceDir)
+	}
+
+	return sourceDir, markdown, nil
+}
+
+// filterMarkdownEvidence keeps only markdown evidence documents
+func filterMarkdownEvidence(files []models.EvidenceFileRef) []models.EvidenceFileRef {
+	var markdown []models.EvidenceFileRef
+	for _, file := range files {
+		ext := strings.ToLower(filepath.Ext(file.Filename))
+		if ext == ".md" || ext == ".markdown" {
+			markdown = append(markdown, file)
+		}
+	}
+	return markdown
+}
+
+// exportEvidencePDFs converts each markdown evidence file to a PDF in outputDir
+// and returns the paths written. Conversion stops at the first failure.
+func exportEvidencePDFs(converter conversion.Converter, task *domain.EvidenceTask, baseDir string, files []models.EvidenceFileRef, outputDir string, opts *conversion.ConversionOptions) ([]string, error) {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	var exported []string
+	for _, file := range files {
+		inputPath := filepath.Join(baseDir, file.RelativePath)
+		outputPath := filepath.Join(outputDir, strings.TrimSuffix(file.Filename, filepath.Ext(file.Filename))+".pdf")
+
+		fileOpts := *opts
+		fileOpts.Title = fmt.Sprintf("%s: %s", task.ReferenceID, task.Name)
+		if file.Title != "" && file.Title != file.Filename {
+			fileOpts.Title = file.Title
+		}
+
+		// Encrypted and deduplicated evidence is resolved to its plaintext here;
+		// the converter would otherwise render the ciphertext or reference line
+		source, err := storage.ReadFile(inputPath)
+		if err != nil {
+			return exported, fmt.Errorf("failed to read %s: %w", file.Filename, err)
+		}
+		if err := converter.ConvertMarkdownBytesToPDF(source, outputPath, &fileOpts); err != nil {
+			return exported, fmt.Errorf("failed to export %s: %w", file.Filename, err)
+		}
+		exported = append(exported, outputPath)
+	}
+
+	return exported, nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/services/conversion"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingConverter struct {
+	calls   map[string]*conversion.ConversionOptions
+	sources map[string]string
+	failOn  string
+}
+
+func (rc *recordingConverter) ConvertMarkdownToPDF(inputPath, outputPath string, opts *conversion.ConversionOptions) error {
+	source, err := os.ReadFile(inputPath)
+	if err != nil {
+		return err
+	}
+	return rc.ConvertMarkdownBytesToPDF(source, outputPath, opts)
+}
+
+// ConvertMarkdownBytesToPDF records calls by output filename, since the
+// export passes the source rather than the input path
+func (rc *recordingConverter) ConvertMarkdownBytesToPDF(source []byte, outputPath string, opts *conversion.ConversionOptions) error {
+	name := filepath.Base(outputPath)
+	if name == rc.failOn {
+		return errors.New("render failed")
+	}
+	rc.calls[name] = opts
+	if rc.sources != nil {
+		rc.sources[name] = string(source)
+	}
+	return os.WriteFile(outputPath, []byte("%PDF-1.4"), 0644)
+}
+
+// writeEvidenceFiles creates each file under baseDir with markdown content
+func writeEvidenceFiles(t *testing.T, baseDir string, files []models.EvidenceFileRef) {
+	t.Helper()
+	for _, file := range files {
+		path := filepath.Join(baseDir, file.RelativePath)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte("# "+file.Filename), 0644))
+	}
+}
+
+func TestFilterMarkdownEvidence(t *testing.T) {
+	t.Parallel()
+
+	files := []models.EvidenceFileRef{
+		{Filename: "01_terraform_iam.md"},
+		{Filename: "02_users.csv"},
+		{Filename: "03_notes.MARKDOWN"},
+		{Filename: "04_report.pdf"},
+	}
+
+	filtered := filterMarkdownEvidence(files)
+
+	require.Len(t, filtered, 2)
+	assert.Equal(t, "01_terraform_iam.md", filtered[0].Filename)
+	assert.Equal(t, "03_notes.MARKDOWN", filtered[1].Filename)
+	assert.Empty(t, filterMarkdownEvidence(nil))
+}
+
+func TestExportEvidencePDFs(t *testing.T) {
+	t.Parallel()
+
+	baseDir := t.TempDir()
+	outputDir := filepath.Join(t.TempDir(), "exports")
+	task := &domain.EvidenceTask{ReferenceID: "ET-0001", Name: "Access Controls"}
+	files := []models.EvidenceFileRef{
+		{Filename: "01_iam.md", Title: "01_iam.md", RelativePath: "evidence/ET-0001/2025-Q4/01_iam.md"},
+		{Filename: "02_github.md", Title: "GitHub Permissions", RelativePath: "evidence/ET-0001/2025-Q4/02_github.md"},
+	}
+
+	writeEvidenceFiles(t, baseDir, files)
+
+	converter := &recordingConverter{calls: map[string]*conversion.ConversionOptions{}}
+	opts := conversion.DefaultOptions()
+	opts.Window = "2025-Q4"
+
+	exported, err := exportEvidencePDFs(converter, task, baseDir, files, outputDir, opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		filepath.Join(outputDir, "01_iam.pdf"),
+		filepath.Join(outputDir, "02_github.pdf"),
+	}, exported)
+	assert.FileExists(t, exported[0])
+
+	first := converter.calls["01_iam.pdf"]
+	require.NotNil(t, first)
+	assert.Equal(t, "ET-0001: Access Controls", first.Title)
+	assert.Equal(t, "2025-Q4", first.Window)
+	assert.Equal(t, "GitHub Permissions", converter.calls["02_github.pdf"].Title)
+	assert.Empty(t, opts.Title, "shared options must not be modified")
+}
+
+func TestExportEvidencePDFs_StopsOnFailure(t *testing.T) {
+	t.Parallel()
+
+	task := &domain.EvidenceTask{ReferenceID: "ET-0001", Name: "Access Controls"}
+	files := []models.EvidenceFileRef{
+		{Filename: "01_iam.md", RelativePath: "01_iam.md"},
+		{Filename: "02_github.md", RelativePath: "02_github.md"},
+		{Filename: "03_okta.md", RelativePath: "03_okta.md"},
+	}
+	baseDir := t.TempDir()
+	writeEvidenceFiles(t, baseDir, files)
+	converter := &recordingConverter{calls: map[string]*conversion.ConversionOptions{}, failOn: "02_github.pdf"}
+
+	exported, err := exportEvidencePDFs(converter, task, baseDir, files, t.TempDir(), conversion.DefaultOptions())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to export 02_github.md")
+	assert.Len(t, exported, 1)
+}
+
+func TestExportEvidencePDFs_Encrypted(t *testing.T) {
+	key, err := storage.GenerateKey()
+	require.NoError(t, err)
+	useStorageConfig(t, "  encryption:\n    enabled: true\n    key: "+key+"\n    tasks: [ET-0001]\n")
+	storage.UseEncryption(loadEvidenceCipher)
+	t.Cleanup(func() { storage.UseEncryption(loadEvidenceCipher) })
+
+	baseDir := t.TempDir()
+	task := &domain.EvidenceTask{ReferenceID: "ET-0001", Name: "Access Controls"}
+	files := []models.EvidenceFileRef{{Filename: "01_users.md", RelativePath: "01_users.md"}}
+	require.NoError(t, storage.WriteEvidenceFile(filepath.Join(baseDir, "01_users.md"), []byte("# Users\n\nalice has MFA\n"), true))
+
+	converter := &recordingConverter{calls: map[string]*conversion.ConversionOptions{}, sources: map[string]string{}}
+	_, err = exportEvidencePDFs(converter, task, baseDir, files, t.TempDir(), conversion.DefaultOptions())
+	require.NoError(t, err)
+	assert.Equal(t, "# Users\n\nalice has MFA\n", converter.sources["01_users.pdf"], "the converter gets plaintext")
+
+	// Without the key the export fails rather than rendering ciphertext
+	storage.UseEncryption(func() (*storage.Cipher, error) { return nil, errors.New("no key") })
+	_, err = exportEvidencePDFs(converter, task, baseDir, files, t.TempDir(), conversion.DefaultOptions())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read 01_users.md")
+}
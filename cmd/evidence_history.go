@@ -0,0 +1,213 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var evidenceHistoryCmd = &cobra.Command{
+	Use:   "history [task-id]",
+	Short: "Show the chain of custody of a window's evidence files",
+	Long: `Show the chain of custody of each evidence file in a task's window: when it
+was generated, edited, validated, approved, submitted and moved, by whom, and
+the checksum of its content at the time.
+
+Events are appended to the window's .submission/custody.jsonl as they happen
+and are never rewritten. Edits made outside grctool are recorded when the
+changed file is next validated, approved or submitted, dated when the file
+was modified.
+
+The log is tamper-evident: each entry records the hash of the one before it,
+and the chain is verified every time the history is shown. With --anchor the
+head hash is also committed to the data directory's git history
+(storage.git.enabled), so entries removed from the end are detected too. The
+command fails when the chain is broken.
+
+Examples:
+  # Show every file's history
+  grctool evidence history ET-0047 --window 2025-Q4
+
+  # Show one file's history as JSON
+  grctool evidence history ET-0047 --window 2025-Q4 --file 01_user_access.csv --output json
+
+  # Anchor the log's head in git before handing the evidence to the auditor
+  grctool evidence history ET-0047 --window 2025-Q4 --anchor`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTaskRefs,
+	RunE:              runEvidenceHistory,
+}
+
+func init() {
+	evidenceCmd.AddCommand(evidenceHistoryCmd)
+
+	evidenceHistoryCmd.Flags().String("window", "", "evidence collection window (e.g., 2025-Q4)")
+	evidenceHistoryCmd.Flags().String("file", "", "show only this evidence file")
+	evidenceHistoryCmd.Flags().Bool("anchor", false, "record the log's head hash in the data directory's git history (needs storage.git.enabled)")
+	evidenceHistoryCmd.MarkFlagRequired("window")
+}
+
+// custodyHistory is the structured output of evidence history
+type custodyHistory struct {
+	TaskRef    string                `json:"task_ref"`
+	Window     string                `json:"window"`
+	Chain      *storage.CustodyChain `json:"chain,omitempty"`
+	ChainError string                `json:"chain_error,omitempty"`
+	Events     []models.CustodyEvent `json:"events"`
+}
+
+func runEvidenceHistory(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	taskRef := args[0]
+	window, _ := cmd.Flags().GetString("window")
+	filename, _ := cmd.Flags().GetString("file")
+	anchor, _ := cmd.Flags().GetBool("anchor")
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	history := storage.NewGitHistory(cfg.Storage)
+	if anchor && history == nil {
+		return fmt.Errorf("--anchor records the head hash in the data directory's git history; set storage.git.enabled in .grctool.yaml first")
+	}
+
+	// Check the log against the head last anchored in git, if any
+	windowDir := store.EvidenceWindowDir(taskRef, window)
+	anchored := ""
+	if history != nil {
+		if anchored, err = history.CustodyAnchor(ctx, windowDir); err != nil {
+			return fmt.Errorf("failed to read custody anchor: %w", err)
+		}
+	}
+	chain, chainErr := storage.VerifyCustodyLog(windowDir, anchored)
+
+	events, err := store.LoadCustodyLog(taskRef, window)
+	if err != nil {
+		return err
+	}
+	if filename != "" {
+		matching := []models.CustodyEvent{}
+		for _, event := range events {
+			if event.File == filename {
+				matching = append(matching, event)
+			}
+		}
+		events = matching
+	}
+
+	if isStructuredOutput(format) {
+		output := custodyHistory{TaskRef: taskRef, Window: window, Chain: chain, Events: events}
+		if chainErr != nil {
+			output.ChainError = chainErr.Error()
+		}
+		if err := writeStructured(cmd, format, output); err != nil {
+			return err
+		}
+	} else {
+		displayCustodyHistory(cmd, taskRef, window, events)
+		if chainErr == nil && chain.Entries > 0 {
+			cmd.Println()
+			displayCustodyChain(cmd, chain)
+		}
+	}
+	if chainErr != nil {
+		return fmt.Errorf("chain of custody for %s/%s cannot be trusted: %w", taskRef, window, chainErr)
+	}
+
+	if anchor {
+		head, commit, err := history.AnchorCustodyLog(ctx, windowDir)
+		if err != nil {
+			return fmt.Errorf("failed to anchor custody log: %w", err)
+		}
+		if !isStructuredOutput(format) {
+			cmd.Printf("⚓ Anchored head %s in commit %s\n", shortSHA256(head), commit)
+		}
+	}
+	return nil
+}
+
+// displayCustodyChain reports a verified custody log hash chain
+func displayCustodyChain(cmd *cobra.Command, chain *storage.CustodyChain) {
+	cmd.Printf("🔗 Hash chain intact: %d entries, head %s\n", chain.Entries, shortSHA256(chain.Head))
+	if chain.Anchor != "" {
+		cmd.Printf("⚓ Contains the head anchored in git history (%s)\n", shortSHA256(chain.Anchor))
+	} else {
+		cmd.Println("ℹ️  Not anchored: run with --anchor to record the head in git, so removing entries from the end is detected")
+	}
+}
+
+// displayCustodyHistory reports the custody events of each file, files in
+// the order they first appear in the log
+func displayCustodyHistory(cmd *cobra.Command, taskRef, window string, events []models.CustodyEvent) {
+	if len(events) == 0 {
+		cmd.Printf("No custody events recorded for %s/%s\n", taskRef, window)
+		return
+	}
+
+	files := []string{}
+	byFile := map[string][]models.CustodyEvent{}
+	for _, event := range events {
+		if _, ok := byFile[event.File]; !ok {
+			files = append(files, event.File)
+		}
+		byFile[event.File] = append(byFile[event.File], event)
+	}
+
+	cmd.Printf("📜 Chain of custody for %s/%s (%d file(s), %d event(s))\n", taskRef, window, len(files), len(events))
+	for _, file := range files {
+		cmd.Println()
+		cmd.Printf("%s\n", file)
+		for _, event := range byFile[file] {
+			actor := event.Actor
+			if actor == "" {
+				actor = "unknown"
+			}
+			line := fmt.Sprintf("  %s  %-9s  %s", event.Timestamp.Local().Format("2006-01-02 15:04:05"), event.Event, actor)
+			if event.SHA256 != "" {
+				line += "  sha256 " + shortSHA256(event.SHA256)
+			}
+			if event.Details != "" {
+				line += "  " + event.Details
+			}
+			cmd.Println(strings.TrimRight(line, " "))
+		}
+	}
+}
+
+// shortSHA256 abbreviates a checksum for display
+func shortSHA256(checksum string) string {
+	if len(checksum) > 12 {
+		return checksum[:12]
+	}
+	return checksum
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplayCustodyHistory(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2025, 10, 20, 9, 30, 0, 0, time.Local)
+	tests := map[string]struct {
+		events []models.CustodyEvent
+		want   string
+	}{
+		"no events": {
+			want: "No custody events recorded for ET-0047/2025-Q4\n",
+		},
+		"grouped by file": {
+			events: []models.CustodyEvent{
+				{Timestamp: at, File: "01_users.csv", Event: models.CustodyGenerated, Actor: "alex@example.com", SHA256: "9a0364b9e99bb480dd25e1f0284c8555", Details: "Terraform - iam.tf"},
+				{Timestamp: at, File: "02_roles.md", Event: models.CustodyGenerated, Actor: "alex@example.com"},
+				{Timestamp: at.Add(time.Hour), File: "01_users.csv", Event: models.CustodyEdited, SHA256: "ffff"},
+			},
+			want: "📜 Chain of custody for ET-0047/2025-Q4 (2 file(s), 3 event(s))\n" +
+				"\n01_users.csv\n" +
+				"  2025-10-20 09:30:00  generated  alex@example.com  sha256 9a0364b9e99b  Terraform - iam.tf\n" +
+				"  2025-10-20 10:30:00  edited     unknown  sha256 ffff\n" +
+				"\n02_roles.md\n" +
+				"  2025-10-20 09:30:00  generated  alex@example.com\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&buf)
+			displayCustodyHistory(cmd, "ET-0047", "2025-Q4", tc.events)
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+}
+
+func TestDisplayCustodyChain(t *testing.T) {
+	t.Parallel()
+
+	head := "9a0364b9e99bb480dd25e1f0284c8555"
+	tests := map[string]struct {
+		chain storage.CustodyChain
+		want  string
+	}{
+		"anchored": {
+			chain: storage.CustodyChain{Entries: 5, Head: head, Anchor: head},
+			want: "🔗 Hash chain intact: 5 entries, head 9a0364b9e99b\n" +
+				"⚓ Contains the head anchored in git history (9a0364b9e99b)\n",
+		},
+		"not anchored": {
+			chain: storage.CustodyChain{Entries: 5, Head: head},
+			want: "🔗 Hash chain intact: 5 entries, head 9a0364b9e99b\n" +
+				"ℹ️  Not anchored: run with --anchor to record the head in git, so removing entries from the end is detected\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&buf)
+			displayCustodyChain(cmd, &tc.chain)
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+}
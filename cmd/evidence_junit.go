@@ -0,0 +1,309 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/grctool/grctool/internal/models"
+)
+
+// Report formats for evidence validate and evaluate
+const (
+	reportFormatText  = "text"
+	reportFormatJUnit = "junit"
+)
+
+// junitTestSuites is the root element of a JUnit XML report
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite groups the checks for one task and window
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase is a single check
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+// junitMessage is the body of a failure, error or skipped element
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// validateReportFormat checks the --format flag of validate and evaluate; an
+// empty format means text
+func validateReportFormat(format string) error {
+	switch format {
+	case "", reportFormatText, reportFormatJUnit:
+		return nil
+	default:
+		return fmt.Errorf("invalid format %q: must be %s or %s", format, reportFormatText, reportFormatJUnit)
+	}
+}
+
+// junitSuiteName identifies a task and window, e.g. "ET-0001/2025-Q4"
+func junitSuiteName(taskRef, window, subfolder string) string {
+	name := taskRef + "/" + window
+	if subfolder != "" {
+		name += "/" + subfolder
+	}
+	return name
+}
+
+// junitClassName is the dotted form of a suite name that CI systems use to
+// group test cases
+func junitClassName(suiteName string) string {
+	return strings.NewReplacer("/", ".", " ", "_").Replace(suiteName)
+}
+
+// evaluationJUnitSuite reports each evaluation dimension as a test case. Issues
+// are attached to the dimension matching their category.
+func evaluationJUnitSuite(result *models.EvaluationResult) junitTestSuite {
+	suite := junitTestSuite{
+		Name:      junitSuiteName(result.TaskRef, result.Window, result.Subfolder),
+		Timestamp: result.EvaluatedAt.UTC().Format("2006-01-02T15:04:05"),
+	}
+	if result.EvaluatedAt.IsZero() {
+		suite.Timestamp = ""
+	}
+	className := junitClassName(suite.Name)
+
+	dimensions := []struct {
+		code      string
+		score     models.DimensionScore
+		issueKeys []string
+	}{
+		{"completeness", result.Completeness, []string{"completeness"}},
+		{"requirements_match", result.RequirementsMatch, []string{"requirements"}},
+		{"quality", result.QualityScore, []string{"quality"}},
+		{"control_alignment", result.ControlAlignment, []string{"control_alignment"}},
+	}
+
+	for _, dimension := range dimensions {
+		testCase := junitTestCase{Name: dimension.code, ClassName: className}
+
+		var details []string
+		if dimension.score.Details != "" {
+			details = append(details, dimension.score.Details)
+		}
+		for _, issue := range result.Issues {
+			if slices.Contains(dimension.issueKeys, issue.Category) {
+				details = append(details, formatEvaluationIssue(issue))
+			}
+		}
+		body := strings.Join(details, "\n")
+		summary := fmt.Sprintf("score %.1f/%.0f", dimension.score.Score, dimension.score.MaxScore)
+
+		switch dimension.score.Status {
+		case "fail":
+			testCase.Failure = &junitMessage{Message: summary, Type: "fail", Text: body}
+			suite.Failures++
+		case models.DimensionSkipped:
+			testCase.Skipped = &junitMessage{Message: dimension.score.Details}
+			suite.Skipped++
+		default:
+			testCase.SystemOut = strings.TrimSpace(summary + " (" + dimension.score.Status + ")\n" + body)
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	for _, check := range result.RequiredChecks {
+		testCase := junitTestCase{Name: "required:" + check.Name, ClassName: className}
+		if check.Status == "fail" {
+			testCase.Failure = &junitMessage{Message: check.Details, Type: string(check.Severity), Text: check.Description}
+			suite.Failures++
+		} else {
+			testCase.SystemOut = check.Details
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	overall := junitTestCase{Name: "overall", ClassName: className}
+	summary := fmt.Sprintf("score %.1f/100, pass threshold %.0f", result.OverallScore, result.PassThreshold)
+	if result.OverallStatus == models.EvaluationFail {
+		overall.Failure = &junitMessage{Message: summary, Type: "fail", Text: strings.Join(result.Recommendations, "\n")}
+		suite.Failures++
+	} else {
+		overall.SystemOut = summary
+	}
+	suite.Cases = append(suite.Cases, overall)
+
+	suite.Tests = len(suite.Cases)
+	return suite
+}
+
+// validationJUnitSuite reports each validation check as a test case
+func validationJUnitSuite(result *models.ValidationResult) junitTestSuite {
+	suite := junitTestSuite{
+		Name:      junitSuiteName(result.TaskRef, result.Window, ""),
+		Timestamp: result.ValidationTimestamp.UTC().Format("2006-01-02T15:04:05"),
+	}
+	if result.ValidationTimestamp.IsZero() {
+		suite.Timestamp = ""
+	}
+	className := junitClassName(suite.Name)
+
+	for _, check := range result.Checks {
+		name := check.Code
+		if name == "" {
+			name = check.Name
+		}
+		testCase := junitTestCase{Name: name, ClassName: className}
+		switch check.Status {
+		case "failed", "fail":
+			testCase.Failure = &junitMessage{Message: check.Message, Type: check.Severity, Text: validationErrorDetails(result.Errors, check.Code)}
+			suite.Failures++
+		case "skipped":
+			testCase.Skipped = &junitMessage{Message: check.Message}
+			suite.Skipped++
+		default:
+			testCase.SystemOut = check.Message
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	if result.Status == "skipped" && len(suite.Cases) == 0 {
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      "validation",
+			ClassName: className,
+			Skipped:   &junitMessage{Message: "validation mode is skip"},
+		})
+		suite.Skipped++
+	}
+
+	suite.Tests = len(suite.Cases)
+	return suite
+}
+
+// junitErrorSuite reports a task and window that could not be checked at all
+func junitErrorSuite(name string, err error) junitTestSuite {
+	return junitTestSuite{
+		Name:   name,
+		Tests:  1,
+		Errors: 1,
+		Cases: []junitTestCase{{
+			Name:      "evidence",
+			ClassName: junitClassName(name),
+			Error:     &junitMessage{Message: err.Error(), Type: "error"},
+		}},
+	}
+}
+
+// writeJUnitReport encodes the suites as a JUnit XML document
+func writeJUnitReport(w io.Writer, name string, suites []junitTestSuite) error {
+	report := junitTestSuites{Name: name, Suites: suites}
+	for _, suite := range suites {
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to encode JUnit report: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// saveJUnitReport writes the report to outputFile, or to w when no file is given
+func saveJUnitReport(w io.Writer, outputFile, name string, suites []junitTestSuite) error {
+	if outputFile == "" {
+		return writeJUnitReport(w, name, suites)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outputFile), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	file, err := os.Create(outputFile)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	if err := writeJUnitReport(file, name, suites); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// junitFailureCount counts failed and errored suites
+func junitFailureCount(suites []junitTestSuite) int {
+	count := 0
+	for _, suite := range suites {
+		if suite.Failures > 0 || suite.Errors > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+func formatEvaluationIssue(issue models.EvaluationIssue) string {
+	line := fmt.Sprintf("[%s] %s", strings.ToUpper(string(issue.Severity)), issue.Message)
+	if issue.Location != "" {
+		line += " (" + issue.Location + ")"
+	}
+	if issue.Suggestion != "" {
+		line += " - " + issue.Suggestion
+	}
+	return line
+}
+
+func validationErrorDetails(errors []models.ValidationError, code string) string {
+	var lines []string
+	for _, validationErr := range errors {
+		if validationErr.Code != code {
+			continue
+		}
+		line := validationErr.Message
+		if validationErr.Suggestion != "" {
+			line += " - " + validationErr.Suggestion
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,226 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluationJUnitSuite(t *testing.T) {
+	t.Parallel()
+
+	result := &models.EvaluationResult{
+		TaskRef:           "ET-0001",
+		Window:            "2025-Q4",
+		OverallScore:      55,
+		OverallStatus:     models.EvaluationFail,
+		PassThreshold:     70,
+		Completeness:      models.DimensionScore{Score: 40, MaxScore: 100, Status: "fail", Details: "1 of 3 files"},
+		RequirementsMatch: models.DimensionScore{Score: 80, MaxScore: 100, Status: "pass"},
+		QualityScore:      models.DimensionScore{Score: 60, MaxScore: 100, Status: "warning"},
+		ControlAlignment:  models.DimensionScore{Score: 90, MaxScore: 100, Status: "pass"},
+		Issues: []models.EvaluationIssue{
+			{Severity: models.IssueHigh, Category: "completeness", Message: "Missing access review export", Location: "2025-Q4"},
+			{Severity: models.IssueLow, Category: "quality", Message: "File name is not descriptive"},
+		},
+		Recommendations: []string{"Add the Q4 access review export"},
+		EvaluatedAt:     time.Date(2025, 10, 3, 9, 0, 0, 0, time.UTC),
+	}
+
+	suite := evaluationJUnitSuite(result)
+
+	assert.Equal(t, "ET-0001/2025-Q4", suite.Name)
+	assert.Equal(t, "2025-10-03T09:00:00", suite.Timestamp)
+	assert.Equal(t, 5, suite.Tests)
+	assert.Equal(t, 2, suite.Failures)
+
+	completeness := suite.Cases[0]
+	assert.Equal(t, "completeness", completeness.Name)
+	assert.Equal(t, "ET-0001.2025-Q4", completeness.ClassName)
+	require.NotNil(t, completeness.Failure)
+	assert.Equal(t, "score 40.0/100", completeness.Failure.Message)
+	assert.Contains(t, completeness.Failure.Text, "1 of 3 files")
+	assert.Contains(t, completeness.Failure.Text, "[HIGH] Missing access review export (2025-Q4)")
+	assert.NotContains(t, completeness.Failure.Text, "not descriptive")
+
+	quality := suite.Cases[2]
+	assert.Nil(t, quality.Failure, "warnings do not fail the build")
+	assert.Contains(t, quality.SystemOut, "(warning)")
+	assert.Contains(t, quality.SystemOut, "File name is not descriptive")
+
+	overall := suite.Cases[4]
+	assert.Equal(t, "overall", overall.Name)
+	require.NotNil(t, overall.Failure)
+	assert.Equal(t, "Add the Q4 access review export", overall.Failure.Text)
+}
+
+func TestEvaluationJUnitSuite_Rubric(t *testing.T) {
+	t.Parallel()
+
+	result := &models.EvaluationResult{
+		TaskRef:           "ET-0047",
+		Window:            "2025-Q4",
+		OverallScore:      85,
+		OverallStatus:     models.EvaluationFail,
+		PassThreshold:     80,
+		Rubric:            "access-review",
+		Completeness:      models.DimensionScore{Score: 85, MaxScore: 100, Weight: 0.5, Status: "pass"},
+		RequirementsMatch: models.DimensionScore{Score: 85, MaxScore: 100, Weight: 0.5, Status: "pass"},
+		QualityScore:      models.DimensionScore{Status: models.DimensionSkipped, Details: "Not scored by rubric access-review"},
+		ControlAlignment:  models.DimensionScore{Status: models.DimensionSkipped, Details: "Not scored by rubric access-review"},
+		RequiredChecks: []models.RequiredCheckResult{
+			{Name: "user-export", Status: "pass", Severity: models.IssueCritical, Details: "1 file(s) match *users*.csv, 1 required"},
+			{Name: "signoff", Description: "Manager sign-off", Status: "fail", Severity: models.IssueCritical, Details: "0 file(s) match *signoff*.pdf, 1 required"},
+		},
+	}
+
+	suite := evaluationJUnitSuite(result)
+
+	assert.Equal(t, 7, suite.Tests)
+	assert.Equal(t, 2, suite.Skipped)
+	assert.Equal(t, 2, suite.Failures)
+
+	require.NotNil(t, suite.Cases[2].Skipped)
+	assert.Equal(t, "Not scored by rubric access-review", suite.Cases[2].Skipped.Message)
+
+	assert.Equal(t, "required:user-export", suite.Cases[4].Name)
+	assert.Nil(t, suite.Cases[4].Failure)
+	signoff := suite.Cases[5]
+	assert.Equal(t, "required:signoff", signoff.Name)
+	require.NotNil(t, signoff.Failure)
+	assert.Equal(t, "critical", signoff.Failure.Type)
+	assert.Equal(t, "Manager sign-off", signoff.Failure.Text)
+}
+
+func TestValidationJUnitSuite(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		result       *models.ValidationResult
+		wantTests    int
+		wantFailures int
+		wantSkipped  int
+	}{
+		"mixed checks": {
+			result: &models.ValidationResult{
+				TaskRef: "ET-0002",
+				Window:  "2025-Q3",
+				Status:  "failed",
+				Checks: []models.ValidationCheck{
+					{Code: "MINIMUM_FILE_COUNT", Name: "Minimum File Count", Status: "failed", Severity: "error", Message: "No evidence files found"},
+					{Code: "REQUIRED_FILES_PRESENT", Name: "Required Files Present", Status: "passed", Message: "All files present"},
+					{Code: "FILE_SIZE", Name: "File Size", Status: "warning", Message: "Large file"},
+				},
+				Errors: []models.ValidationError{
+					{Code: "MINIMUM_FILE_COUNT", Message: "No evidence files found in the evidence directory", Suggestion: "Generate evidence"},
+				},
+			},
+			wantTests:    3,
+			wantFailures: 1,
+		},
+		"skip mode": {
+			result:      &models.ValidationResult{TaskRef: "ET-0003", Window: "2025-Q3", Status: "skipped"},
+			wantTests:   1,
+			wantSkipped: 1,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			suite := validationJUnitSuite(tt.result)
+			assert.Equal(t, tt.wantTests, suite.Tests)
+			assert.Equal(t, tt.wantFailures, suite.Failures)
+			assert.Equal(t, tt.wantSkipped, suite.Skipped)
+			assert.Empty(t, suite.Timestamp)
+		})
+	}
+
+	suite := validationJUnitSuite(tests["mixed checks"].result)
+	require.NotNil(t, suite.Cases[0].Failure)
+	assert.Equal(t, "MINIMUM_FILE_COUNT", suite.Cases[0].Name)
+	assert.Equal(t, "No evidence files found in the evidence directory - Generate evidence", suite.Cases[0].Failure.Text)
+	assert.Equal(t, "Large file", suite.Cases[2].SystemOut)
+}
+
+func TestWriteJUnitReport(t *testing.T) {
+	t.Parallel()
+
+	suites := []junitTestSuite{
+		validationJUnitSuite(&models.ValidationResult{
+			TaskRef: "ET-0001",
+			Window:  "2025-Q4",
+			Checks:  []models.ValidationCheck{{Code: "A", Status: "passed", Message: "ok <fine> & dandy"}},
+		}),
+		junitErrorSuite("ET-0009/2025-Q4", errors.New("failed to get evidence files")),
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeJUnitReport(&buf, "grctool evidence validate", suites))
+
+	output := buf.String()
+	assert.Contains(t, output, `<?xml version="1.0" encoding="UTF-8"?>`)
+	assert.Contains(t, output, `<testsuites name="grctool evidence validate" tests="2" failures="0" errors="1">`)
+	assert.Contains(t, output, `<error message="failed to get evidence files" type="error"></error>`)
+	assert.Contains(t, output, "ok &lt;fine&gt; &amp; dandy")
+
+	var decoded junitTestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &decoded))
+	require.Len(t, decoded.Suites, 2)
+	assert.Equal(t, "ET-0009.2025-Q4", decoded.Suites[1].Cases[0].ClassName)
+	assert.Equal(t, 1, junitFailureCount(suites))
+}
+
+func TestSaveJUnitReport_File(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "reports", "junit.xml")
+	var stdout bytes.Buffer
+	require.NoError(t, saveJUnitReport(&stdout, path, "grctool evidence evaluate", nil))
+
+	assert.Empty(t, stdout.String())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `tests="0"`)
+}
+
+func TestValidateReportFormat(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, validateReportFormat(""))
+	assert.NoError(t, validateReportFormat("text"))
+	assert.NoError(t, validateReportFormat("junit"))
+	assert.Error(t, validateReportFormat("xml"))
+}
+
+func TestValidationTargets(t *testing.T) {
+	t.Parallel()
+
+	states := map[string]*models.EvidenceTaskState{
+		"ET-0002": {Windows: map[string]models.WindowState{"2025-Q4": {}, "2025-Q3": {}}},
+		"ET-0001": {Windows: map[string]models.WindowState{"2025-Q4": {}}},
+		"ET-0003": {},
+	}
+
+	all := validationTargets(states, "")
+	assert.Equal(t, []validationTarget{
+		{taskRef: "ET-0001", window: "2025-Q4"},
+		{taskRef: "ET-0002", window: "2025-Q3"},
+		{taskRef: "ET-0002", window: "2025-Q4"},
+	}, all)
+
+	q3 := validationTargets(states, "2025-Q3")
+	assert.Equal(t, []validationTarget{{taskRef: "ET-0002", window: "2025-Q3"}}, q3)
+}
@@ -0,0 +1,160 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/templates"
+)
+
+// Export formats accepted by 'evidence list --format'
+const (
+	exportFormatCSV      = "csv"
+	exportFormatJSON     = "json"
+	exportFormatMarkdown = "md"
+)
+
+// evidenceListCSVHeader is the column order of the CSV export
+var evidenceListCSVHeader = []string{
+	"reference_id", "id", "name", "category", "framework", "status", "aec_status",
+	"collection_type", "priority", "next_due", "overdue", "assignees", "tugboat_url",
+}
+
+// resolveExportFormat normalizes --format, inferring it from the export file
+// extension when not given. An empty result means no export was requested.
+func resolveExportFormat(format, exportFile string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" && exportFile != "" {
+		switch strings.ToLower(filepath.Ext(exportFile)) {
+		case ".csv":
+			format = exportFormatCSV
+		case ".json":
+			format = exportFormatJSON
+		case ".md", ".markdown":
+			format = exportFormatMarkdown
+		default:
+			return "", fmt.Errorf("cannot infer export format from %q, use --format (csv, json, md)", exportFile)
+		}
+	}
+
+	switch format {
+	case "", exportFormatCSV, exportFormatJSON, exportFormatMarkdown:
+		return format, nil
+	case "markdown":
+		return exportFormatMarkdown, nil
+	}
+	return "", fmt.Errorf("invalid export format %q (must be one of: csv, json, md)", format)
+}
+
+// writeEvidenceListExport renders the evidence task inventory in the given export format
+func writeEvidenceListExport(w io.Writer, format string, result EvidenceListResult, generatedAt time.Time) error {
+	switch format {
+	case exportFormatCSV:
+		return writeEvidenceListCSV(w, result)
+	case exportFormatJSON:
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode evidence list: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	case exportFormatMarkdown:
+		_, err := io.WriteString(w, formatEvidenceListMarkdown(result, generatedAt))
+		return err
+	}
+	return fmt.Errorf("unsupported export format: %s", format)
+}
+
+func writeEvidenceListCSV(w io.Writer, result EvidenceListResult) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(evidenceListCSVHeader); err != nil {
+		return err
+	}
+
+	for _, task := range result.Tasks {
+		nextDue := ""
+		if task.NextDue != nil {
+			nextDue = task.NextDue.Format("2006-01-02")
+		}
+		record := []string{
+			task.ReferenceID, task.ID, task.Name, task.Category, task.Framework, task.Status, task.AecStatus,
+			task.CollectionType, task.Priority, nextDue, strconv.FormatBool(task.Overdue),
+			strings.Join(task.Assignees, "; "), task.TugboatURL,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// formatEvidenceListMarkdown renders the task inventory as a markdown report
+func formatEvidenceListMarkdown(result EvidenceListResult, generatedAt time.Time) string {
+	var md strings.Builder
+
+	md.WriteString("# Evidence Tasks\n\n")
+	md.WriteString(fmt.Sprintf("Generated: %s\n\n", generatedAt.Format("2006-01-02 15:04:05")))
+
+	if result.Summary != nil {
+		md.WriteString(fmt.Sprintf("**Summary:** %d total, %d overdue, %d due soon\n\n",
+			result.Summary.Total, result.Summary.Overdue, result.Summary.DueSoon))
+	}
+
+	if len(result.Tasks) == 0 {
+		md.WriteString("No evidence tasks found matching the specified criteria.\n")
+		return md.String()
+	}
+
+	md.WriteString("| Ref | Name | Category | Framework | Status | Type | Priority | Due Date | Assignees |\n")
+	md.WriteString("|-----|------|----------|-----------|--------|------|----------|----------|-----------|\n")
+
+	for _, task := range result.Tasks {
+		dueDate := "N/A"
+		if task.NextDue != nil {
+			dueDate = task.NextDue.Format("2006-01-02")
+			if task.Overdue {
+				dueDate += " (overdue)"
+			}
+		}
+
+		name := templates.MarkdownCell(task.Name)
+		if task.TugboatURL != "" {
+			name = fmt.Sprintf("[%s](%s)", name, task.TugboatURL)
+		}
+
+		assignees := "N/A"
+		if len(task.Assignees) > 0 {
+			assignees = strings.Join(task.Assignees, ", ")
+		}
+
+		md.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %s | %s | %s |\n",
+			task.ReferenceID, name, templates.MarkdownCell(task.Category), templates.MarkdownCell(task.Framework),
+			templates.MarkdownCell(task.Status), templates.MarkdownCell(task.CollectionType),
+			templates.MarkdownCell(task.Priority), dueDate, templates.MarkdownCell(assignees)))
+	}
+
+	return md.String()
+}
@@ -0,0 +1,123 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEvidenceListResult() EvidenceListResult {
+	due := time.Date(2025, 9, 30, 0, 0, 0, 0, time.UTC)
+	return EvidenceListResult{
+		Count: 2,
+		Tasks: []EvidenceTaskListItem{
+			{
+				ReferenceID: "ET-0001",
+				ID:          "327992",
+				Name:        "Access review | quarterly",
+				Framework:   "SOC2",
+				Status:      "pending",
+				NextDue:     &due,
+				Overdue:     true,
+				Assignees:   []string{"Alex", "Sam"},
+				TugboatURL:  "https://my.tugboatlogic.com/org/1/evidence/tasks/327992",
+			},
+			{ReferenceID: "ET-0002", ID: "327993", Name: "Backups"},
+		},
+		Summary: &domain.EvidenceTaskSummary{Total: 2, Overdue: 1},
+	}
+}
+
+func TestResolveExportFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		format     string
+		exportFile string
+		want       string
+		wantErr    string
+	}{
+		"no export":             {},
+		"explicit csv":          {format: "CSV", want: exportFormatCSV},
+		"markdown alias":        {format: "markdown", want: exportFormatMarkdown},
+		"inferred from csv":     {exportFile: "out/tasks.csv", want: exportFormatCSV},
+		"inferred from md":      {exportFile: "tasks.md", want: exportFormatMarkdown},
+		"flag wins over ext":    {format: "json", exportFile: "tasks.txt", want: exportFormatJSON},
+		"unknown extension":     {exportFile: "tasks.txt", wantErr: "cannot infer export format"},
+		"unsupported format":    {format: "xlsx", wantErr: "invalid export format"},
+		"inferred from json":    {exportFile: "tasks.JSON", want: exportFormatJSON},
+		"markdown extension":    {exportFile: "tasks.markdown", want: exportFormatMarkdown},
+		"whitespace is ignored": {format: " md ", want: exportFormatMarkdown},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := resolveExportFormat(tt.format, tt.exportFile)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWriteEvidenceListExport_CSV(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, writeEvidenceListExport(&buf, exportFormatCSV, testEvidenceListResult(), time.Now()))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, evidenceListCSVHeader, records[0])
+	assert.Equal(t, "Access review | quarterly", records[1][2])
+	assert.Equal(t, "2025-09-30", records[1][9])
+	assert.Equal(t, "true", records[1][10])
+	assert.Equal(t, "Alex; Sam", records[1][11])
+	assert.Equal(t, "", records[2][9])
+}
+
+func TestWriteEvidenceListExport_JSON(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, writeEvidenceListExport(&buf, exportFormatJSON, testEvidenceListResult(), time.Now()))
+
+	var decoded EvidenceListResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, 2, decoded.Count)
+	assert.Equal(t, "ET-0002", decoded.Tasks[1].ReferenceID)
+}
+
+func TestWriteEvidenceListExport_Markdown(t *testing.T) {
+	t.Parallel()
+
+	generatedAt := time.Date(2025, 10, 1, 9, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	require.NoError(t, writeEvidenceListExport(&buf, exportFormatMarkdown, testEvidenceListResult(), generatedAt))
+
+	md := buf.String()
+	assert.Contains(t, md, "# Evidence Tasks")
+	assert.Contains(t, md, "Generated: 2025-10-01 09:00:00")
+	assert.Contains(t, md, "**Summary:** 2 total, 1 overdue, 0 due soon")
+	assert.Contains(t, md, "[Access review \\| quarterly](https://my.tugboatlogic.com/org/1/evidence/tasks/327992)")
+	assert.Contains(t, md, "2025-09-30 (overdue)")
+	assert.Contains(t, md, "| ET-0002 | Backups |")
+
+	buf.Reset()
+	require.NoError(t, writeEvidenceListExport(&buf, exportFormatMarkdown, EvidenceListResult{}, generatedAt))
+	assert.Contains(t, buf.String(), "No evidence tasks found")
+}
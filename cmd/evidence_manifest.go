@@ -0,0 +1,111 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/services/submission"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var evidenceManifestCmd = &cobra.Command{
+	Use:   "manifest [task-id]",
+	Short: "Generate the manifest indexing a window's evidence",
+	Long: `Generate manifest.json for a task's window: each evidence file with its
+size, SHA-256 checksum, sources and collection time, and the controls the
+task supports, so auditors have a machine-readable index of what was
+provided. It is saved in the window's .submission/ directory.
+
+Submitting a window generates its manifest too. Evidence packages always
+contain it, and with submission.upload_manifest it is uploaded after the
+evidence files to targets that take files one by one.
+
+Examples:
+  grctool evidence manifest ET-0047 --window 2025-Q4
+
+  # Print the manifest itself
+  grctool evidence manifest ET-0047 --window 2025-Q4 --output json`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTaskRefs,
+	RunE:              runEvidenceManifest,
+}
+
+func init() {
+	evidenceCmd.AddCommand(evidenceManifestCmd)
+
+	evidenceManifestCmd.Flags().String("window", "", "evidence collection window (e.g., 2025-Q4)")
+	evidenceManifestCmd.MarkFlagRequired("window")
+}
+
+func runEvidenceManifest(cmd *cobra.Command, args []string) error {
+	taskRef := args[0]
+	window, _ := cmd.Flags().GetString("window")
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	manifest, err := submission.NewSubmissionServiceWithTargets(store).Manifest(taskRef, window)
+	if err != nil {
+		return fmt.Errorf("failed to generate manifest: %w", err)
+	}
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, manifest)
+	}
+	displayEvidenceManifest(cmd, manifest, filepath.Join(store.EvidenceWindowDir(taskRef, window), ".submission", submission.ManifestFilename))
+	return nil
+}
+
+// displayEvidenceManifest summarizes a manifest and where it was saved
+func displayEvidenceManifest(cmd *cobra.Command, manifest *submission.PackageManifest, path string) {
+	cmd.Printf("📋 Manifest for %s/%s: %d file(s)\n", manifest.TaskRef, manifest.Window, len(manifest.Files))
+	for _, file := range manifest.Files {
+		cmd.Printf("  %s (%s, sha256:%s, collected %s)\n", file.Filename, formatBytes(file.SizeBytes), file.SHA256[:12], file.CollectedAt.Local().Format("2006-01-02 15:04"))
+		if len(file.Sources) > 0 {
+			sources := make([]string, 0, len(file.Sources))
+			for _, source := range file.Sources {
+				sources = append(sources, source.Location)
+			}
+			cmd.Printf("     Sources: %s\n", strings.Join(sources, ", "))
+		}
+	}
+	if len(manifest.Controls) > 0 {
+		controls := make([]string, 0, len(manifest.Controls))
+		for _, control := range manifest.Controls {
+			if control.ReferenceID != "" {
+				controls = append(controls, control.ReferenceID)
+			} else {
+				controls = append(controls, control.ID)
+			}
+		}
+		cmd.Printf("   Controls: %s\n", strings.Join(controls, ", "))
+	}
+	cmd.Printf("💾 Saved to %s\n", path)
+}
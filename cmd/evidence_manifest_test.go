@@ -0,0 +1,47 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/services/submission"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplayEvidenceManifest(t *testing.T) {
+	t.Parallel()
+
+	manifest := &submission.PackageManifest{
+		TaskRef: "ET-0047",
+		Window:  "2025-Q4",
+		Controls: []submission.ManifestControl{
+			{ID: "778805", ReferenceID: "CC6.1"},
+			{ID: "778806"},
+		},
+		Files: []submission.PackageFile{
+			{
+				Filename:    "access.csv",
+				SizeBytes:   2048,
+				SHA256:      "149c4dfcc255bfa22c64ddf2d80c6acf5534f65732e94cfde4ce72f03bd7711b",
+				CollectedAt: time.Date(2025, 10, 2, 9, 30, 0, 0, time.Local),
+				Sources:     []submission.ManifestSource{{Type: "tool", Location: "github-permissions"}},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&buf)
+	displayEvidenceManifest(cmd, manifest, "data/evidence/ET-0047/2025-Q4/.submission/manifest.json")
+
+	assert.Equal(t, "📋 Manifest for ET-0047/2025-Q4: 1 file(s)\n"+
+		"  access.csv (2.0KB, sha256:149c4dfcc255, collected 2025-10-02 09:30)\n"+
+		"     Sources: github-permissions\n"+
+		"   Controls: CC6.1, 778806\n"+
+		"💾 Saved to data/evidence/ET-0047/2025-Q4/.submission/manifest.json\n", buf.String())
+}
@@ -0,0 +1,294 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/naming"
+	"github.com/grctool/grctool/internal/services/matching"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/grctool/grctool/internal/tools"
+)
+
+// matchableTools are the evidence collection tools semantic matching may
+// suggest for a task
+var matchableTools = []string{
+	"atmos-stack-analyzer",
+	"docs-reader",
+	"github-deployment-access",
+	"github-permissions",
+	"github-review-analyzer",
+	"github-security-features",
+	"github-workflow-analyzer",
+	"google-workspace",
+	"incident-log",
+	"policy-acknowledgment",
+	"policy-review",
+	"terraform-security-analyzer",
+	"terraform-security-indexer",
+	"vendor-review",
+}
+
+const (
+	// maxMatchText bounds the text of each candidate compared with a task
+	maxMatchText = 4000
+
+	// maxEvidenceCandidates bounds the prior evidence files compared with a task
+	maxEvidenceCandidates = 500
+)
+
+// matchTextExtensions are the prior evidence files compared with a task
+var matchTextExtensions = map[string]bool{
+	".md": true, ".txt": true, ".csv": true, ".json": true, ".yaml": true, ".yml": true,
+}
+
+var matchHTMLTags = regexp.MustCompile(`<[^>]*>`)
+
+// newTaskMatcher creates the configured semantic matcher, or nil when
+// matching is off or misconfigured; callers then match on keywords only
+func newTaskMatcher(cfg *config.Config) *matching.Matcher {
+	cacheDir := cfg.Storage.CacheDir
+	if cacheDir == "" {
+		cacheDir = filepath.Join(cfg.Storage.DataDir, ".cache")
+	}
+	matcher, err := matching.New(cfg.Evidence.Matching, filepath.Join(cacheDir, "embeddings"))
+	if err != nil {
+		logger.Warn("semantic matching disabled", logger.Error(err))
+		return nil
+	}
+	return matcher
+}
+
+// taskMatchQuery is the text of a task compared with candidates
+func taskMatchQuery(task *domain.EvidenceTask) string {
+	return matchText(task.Name, task.Description, task.Guidance)
+}
+
+// matchText joins text for matching, HTML reduced to words and bounded in size
+func matchText(parts ...string) string {
+	var texts []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(matchHTMLTags.ReplaceAllString(part, " ")); part != "" {
+			texts = append(texts, part)
+		}
+	}
+	text := strings.Join(strings.Fields(strings.Join(texts, "\n")), " ")
+	if len(text) > maxMatchText {
+		text = strings.ToValidUTF8(text[:maxMatchText], "")
+	}
+	return text
+}
+
+// applicableToolsForTask adds the tools semantic matching finds relevant
+// to the keyword-matched ones, keeping keyword matches first
+func applicableToolsForTask(ctx context.Context, cfg *config.Config, task *domain.EvidenceTask, keywordTools []string) []string {
+	matcher := newTaskMatcher(cfg)
+	if matcher == nil {
+		return keywordTools
+	}
+	matches, err := rankTaskTools(ctx, matcher, task, cfg.Evidence.Matching.Limit)
+	if err != nil {
+		logger.Warn("semantic tool matching failed", logger.String("task", task.ReferenceID), logger.Error(err))
+		return keywordTools
+	}
+	return mergeMatchedTools(keywordTools, matches)
+}
+
+// rankTaskTools ranks the registered collection tools, plugins included, by
+// relevance to a task
+func rankTaskTools(ctx context.Context, matcher *matching.Matcher, task *domain.EvidenceTask, limit int) ([]matching.Match, error) {
+	names := slices.Clone(matchableTools)
+	for _, plugin := range tools.PluginTools() {
+		names = append(names, plugin.Name())
+	}
+
+	var candidates []matching.Candidate
+	for _, name := range names {
+		tool, err := tools.GetTool(name)
+		if err != nil {
+			continue
+		}
+		candidates = append(candidates, matching.Candidate{
+			Kind:  matching.KindTool,
+			Ref:   name,
+			Title: name,
+			Text:  matchText(strings.ReplaceAll(name, "-", " "), tool.Description()),
+		})
+	}
+	return matcher.Rank(ctx, taskMatchQuery(task), candidates, limit)
+}
+
+// mergeMatchedTools appends matched tools missing from tools
+func mergeMatchedTools(toolNames []string, matches []matching.Match) []string {
+	merged := append([]string{}, toolNames...)
+	seen := make(map[string]bool, len(toolNames))
+	for _, name := range toolNames {
+		seen[name] = true
+	}
+	for _, match := range matches {
+		if !seen[match.Ref] {
+			seen[match.Ref] = true
+			merged = append(merged, match.Ref)
+		}
+	}
+	return merged
+}
+
+// relatedTaskDocuments ranks policies, controls and prior evidence by
+// relevance to a task, up to limit of each kind. The task's own evidence
+// for window is left out.
+func relatedTaskDocuments(ctx context.Context, matcher *matching.Matcher, task *domain.EvidenceTask, window string, store *storage.Storage, evidenceDir string, limit int) ([]matching.Match, error) {
+	query := taskMatchQuery(task)
+	var related []matching.Match
+
+	var policies []matching.Candidate
+	if all, err := store.GetAllPolicies(); err == nil {
+		for _, policy := range all {
+			policies = append(policies, matching.Candidate{
+				Kind:  matching.KindPolicy,
+				Ref:   documentRef(policy.ReferenceID, policy.ID),
+				Title: policy.Name,
+				Text:  matchText(policy.Name, policy.Summary, policy.Description, policy.Content),
+			})
+		}
+	}
+	var controls []matching.Candidate
+	if all, err := store.GetAllControls(); err == nil {
+		for _, control := range all {
+			controls = append(controls, matching.Candidate{
+				Kind:  matching.KindControl,
+				Ref:   documentRef(control.ReferenceID, control.ID),
+				Title: control.Name,
+				Text:  matchText(control.Name, control.Description, control.Help),
+			})
+		}
+	}
+	evidence := priorEvidenceCandidates(evidenceDir, task.ReferenceID, window)
+
+	for _, candidates := range [][]matching.Candidate{policies, controls, evidence} {
+		matches, err := matcher.Rank(ctx, query, candidates, limit)
+		if err != nil {
+			return nil, err
+		}
+		related = append(related, matches...)
+	}
+	return related, nil
+}
+
+// documentRef is how a policy or control is referred to
+func documentRef(referenceID, id string) string {
+	if referenceID != "" {
+		return referenceID
+	}
+	return id
+}
+
+// priorEvidenceCandidates lists the text evidence files of every task,
+// working and submitted, except the task's own files for window
+func priorEvidenceCandidates(evidenceDir, taskRef, window string) []matching.Candidate {
+	var candidates []matching.Candidate
+	taskDirs, err := os.ReadDir(evidenceDir)
+	if err != nil {
+		return nil
+	}
+	for _, taskDir := range taskDirs {
+		ref := naming.ExtractTaskRef(taskDir.Name())
+		if !taskDir.IsDir() || ref == "" {
+			continue
+		}
+		windows, err := os.ReadDir(filepath.Join(evidenceDir, taskDir.Name()))
+		if err != nil {
+			continue
+		}
+		for _, w := range windows {
+			if !w.IsDir() || strings.HasPrefix(w.Name(), ".") || (strings.EqualFold(ref, taskRef) && w.Name() == window) {
+				continue
+			}
+			windowDir := filepath.Join(evidenceDir, taskDir.Name(), w.Name())
+			for _, dir := range []string{"", naming.SubfolderSubmitted} {
+				files, err := os.ReadDir(filepath.Join(windowDir, dir))
+				if err != nil {
+					continue
+				}
+				for _, file := range files {
+					if len(candidates) >= maxEvidenceCandidates {
+						return candidates
+					}
+					if file.IsDir() || strings.HasPrefix(file.Name(), ".") || !matchTextExtensions[strings.ToLower(filepath.Ext(file.Name()))] {
+						continue
+					}
+					text, ok := readMatchText(filepath.Join(windowDir, dir, file.Name()))
+					if !ok {
+						continue
+					}
+					candidates = append(candidates, matching.Candidate{
+						Kind:  matching.KindEvidence,
+						Ref:   ref,
+						Title: w.Name() + "/" + file.Name(),
+						Text:  matchText(strings.TrimSuffix(file.Name(), filepath.Ext(file.Name())), text),
+					})
+				}
+			}
+		}
+	}
+	return candidates
+}
+
+// readMatchText reads the start of an evidence file; encrypted evidence is
+// never read
+func readMatchText(path string) (string, bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, maxMatchText))
+	if err != nil || storage.IsEncrypted(data) {
+		return "", false
+	}
+	return string(data), true
+}
+
+// formatRelatedDocuments renders related documents as a Markdown section
+func formatRelatedDocuments(related []matching.Match) string {
+	if len(related) == 0 {
+		return ""
+	}
+	var md strings.Builder
+	md.WriteString("## Related by Relevance\n\n")
+	md.WriteString("Ranked by semantic similarity to this task; review before relying on them.\n\n")
+	for _, match := range related {
+		switch match.Kind {
+		case matching.KindEvidence:
+			md.WriteString(fmt.Sprintf("- **evidence** %s `%s` (%.2f)\n", match.Ref, match.Title, match.Score))
+		default:
+			md.WriteString(fmt.Sprintf("- **%s** %s: %s (%.2f)\n", match.Kind, match.Ref, match.Title, match.Score))
+		}
+	}
+	md.WriteString("\n")
+	return md.String()
+}
@@ -0,0 +1,102 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/services/matching"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeMatchedTools(t *testing.T) {
+	t.Parallel()
+
+	matches := []matching.Match{
+		{Candidate: matching.Candidate{Kind: matching.KindTool, Ref: "github-review-analyzer"}, Score: 0.4},
+		{Candidate: matching.Candidate{Kind: matching.KindTool, Ref: "github-permissions"}, Score: 0.3},
+	}
+	keyword := []string{"github-permissions"}
+	assert.Equal(t, []string{"github-permissions", "github-review-analyzer"}, mergeMatchedTools(keyword, matches))
+	assert.Equal(t, []string{"github-permissions"}, keyword, "keyword tools are not modified")
+	assert.Equal(t, keyword, mergeMatchedTools(keyword, nil))
+}
+
+func TestApplicableToolsForTask_MatchingOff(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Storage: config.StorageConfig{DataDir: t.TempDir()}}
+	task := &domain.EvidenceTask{ReferenceID: "ET-0001", Name: "Access review"}
+	assert.Equal(t, []string{"github-permissions"}, applicableToolsForTask(context.Background(), cfg, task, []string{"github-permissions"}))
+
+	cfg.Evidence.Matching.Provider = "unknown"
+	assert.Nil(t, newTaskMatcher(cfg), "a misconfigured provider falls back to keywords")
+}
+
+func TestRelatedTaskDocuments(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+	store, err := storage.NewStorage(config.StorageConfig{DataDir: dataDir})
+	require.NoError(t, err)
+	require.NoError(t, store.SavePolicy(&domain.Policy{ID: "1", ReferenceID: "POL-0001", Name: "Access Control Policy",
+		Content: "<p>User access to repositories is reviewed quarterly and removed on termination.</p>"}))
+	require.NoError(t, store.SavePolicy(&domain.Policy{ID: "2", ReferenceID: "POL-0002", Name: "Vendor Management Policy",
+		Content: "Suppliers are assessed annually."}))
+	require.NoError(t, store.SaveControl(&domain.Control{ID: "3", ReferenceID: "CC6.2", Name: "Access Reviews",
+		Description: "User access is reviewed quarterly."}))
+
+	evidenceDir := filepath.Join(dataDir, "evidence")
+	writeEvidence := func(rel, content string) {
+		path := filepath.Join(evidenceDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	writeEvidence("Access_Review_ET-0001_101/2025-Q1/.submitted/01_repository_access_review.md", "# Repository access review\n\nAll repository access reviewed.")
+	writeEvidence("Access_Review_ET-0001_101/2025-Q2/01_repository_access_review.md", "# Repository access review (current window)")
+	writeEvidence("Access_Review_ET-0001_101/2025-Q2/.context/generation-context.md", "repository access review")
+	writeEvidence("Offboarding_ET-0002_102/2025-Q1/01_terminations.csv", "user,access removed\nbob,yes\n")
+	writeEvidence("Offboarding_ET-0002_102/2025-Q1/02_access_review.png", "repository access review")
+	cipher, err := storage.NewCipher(make([]byte, 32))
+	require.NoError(t, err)
+	encrypted, err := cipher.Encrypt([]byte("repository access review"))
+	require.NoError(t, err)
+	writeEvidence("Offboarding_ET-0002_102/2025-Q1/03_access_review.md", string(encrypted))
+
+	task := &domain.EvidenceTask{ID: "101", ReferenceID: "ET-0001", Name: "Access Review",
+		Description: "Quarterly review of user access to source code repositories"}
+	matcher, err := matching.New(config.MatchingConfig{Provider: matching.ProviderLocal}, "")
+	require.NoError(t, err)
+
+	related, err := relatedTaskDocuments(context.Background(), matcher, task, "2025-Q2", store, evidenceDir, 5)
+	require.NoError(t, err)
+
+	var refs []string
+	for _, match := range related {
+		refs = append(refs, match.Kind+" "+match.Ref+" "+match.Title)
+	}
+	assert.Contains(t, refs, "policy POL-0001 Access Control Policy")
+	assert.NotContains(t, refs, "policy POL-0002 Vendor Management Policy")
+	assert.Contains(t, refs, "control CC6.2 Access Reviews")
+	assert.Contains(t, refs, "evidence ET-0001 2025-Q1/01_repository_access_review.md", "submitted evidence of earlier windows is included")
+	assert.Contains(t, refs, "evidence ET-0002 2025-Q1/01_terminations.csv")
+	for _, ref := range refs {
+		assert.NotContains(t, ref, "2025-Q2", "the window being assembled is left out")
+		assert.NotContains(t, ref, "02_access_review.png")
+		assert.NotContains(t, ref, "03_access_review.md", "encrypted evidence is not read")
+	}
+
+	section := formatRelatedDocuments(related)
+	assert.Contains(t, section, "## Related by Relevance")
+	assert.Contains(t, section, "- **policy** POL-0001: Access Control Policy (")
+	assert.Contains(t, section, "- **evidence** ET-0001 `2025-Q1/01_repository_access_review.md` (")
+	assert.Empty(t, formatRelatedDocuments(nil))
+}
@@ -0,0 +1,107 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var evidenceReuseCmd = &cobra.Command{
+	Use:   "reuse [task-id]",
+	Short: "Add another task's evidence to a window without copying it",
+	Long: `Add evidence files of another task's window to a task's window as
+references to one stored copy, instead of copying the same tool output to
+every task it supports. A reference reads as the file it stands for, and is
+turned back into a real file when its window is submitted.
+
+Every evidence file of the source window is reused unless --file names
+some. Encrypted evidence is never reused across tasks. Each reused file is
+recorded in the window's chain of custody.
+
+Examples:
+  # Reuse all of ET-0047's evidence in ET-0051
+  grctool evidence reuse ET-0051 --window 2025-Q4 --from ET-0047
+
+  # Reuse one file from last quarter's window
+  grctool evidence reuse ET-0051 --window 2025-Q4 --from ET-0047 --from-window 2025-Q3 --file users.csv`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTaskRefs,
+	RunE:              runEvidenceReuse,
+}
+
+func init() {
+	evidenceCmd.AddCommand(evidenceReuseCmd)
+
+	evidenceReuseCmd.Flags().String("window", "", "evidence collection window to add the files to (e.g., 2025-Q4)")
+	evidenceReuseCmd.Flags().String("from", "", "task whose evidence to reuse")
+	evidenceReuseCmd.Flags().String("from-window", "", "window of the source task (default: --window)")
+	evidenceReuseCmd.Flags().StringSlice("file", nil, "evidence file to reuse (repeatable; default: all)")
+	evidenceReuseCmd.MarkFlagRequired("window")
+	evidenceReuseCmd.MarkFlagRequired("from")
+	evidenceReuseCmd.RegisterFlagCompletionFunc("from", completeTaskRefs)
+}
+
+func runEvidenceReuse(cmd *cobra.Command, args []string) error {
+	taskRef := args[0]
+	window, _ := cmd.Flags().GetString("window")
+	fromRef, _ := cmd.Flags().GetString("from")
+	fromWindow, _ := cmd.Flags().GetString("from-window")
+	files, _ := cmd.Flags().GetStringSlice("file")
+	if fromWindow == "" {
+		fromWindow = window
+	}
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	added, err := store.ReuseEvidence(fromRef, fromWindow, taskRef, window, files)
+	if err != nil {
+		return fmt.Errorf("failed to reuse evidence: %w", err)
+	}
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, map[string]interface{}{
+			"task_ref":    taskRef,
+			"window":      window,
+			"from":        fromRef,
+			"from_window": fromWindow,
+			"added":       added,
+		})
+	}
+
+	if len(added) == 0 {
+		cmd.Printf("✅ %s window %s already has this evidence\n", taskRef, window)
+		return nil
+	}
+	for _, path := range added {
+		cmd.Printf("🔗 %s\n", path)
+	}
+	cmd.Printf("\nReused %d file(s) from %s window %s in %s window %s\n", len(added), fromRef, fromWindow, taskRef, window)
+	return nil
+}
@@ -0,0 +1,193 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/redaction"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+// redactedDir holds the sanitized copies evidence scan --redact writes in a
+// window; being hidden, they are never submitted
+const redactedDir = ".redacted"
+
+var evidenceScanCmd = &cobra.Command{
+	Use:   "scan [task-id]",
+	Short: "Scan a window's evidence for secrets, personal data and disallowed content",
+	Long: `Scan the evidence files in a task's window for secrets, such as API keys,
+tokens and private keys, personal data, such as emails and SSNs, and the
+terms and patterns submission.scan.rules disallow for the task's category,
+reporting each by file and line.
+
+What a finding does is set in .grctool.yaml under submission.scan: secrets
+block submission by default, personal data is a warning and each rule sets
+its own action. Findings accepted in submission.scan.waivers are listed with
+the reason and never block. The same scan runs in 'evidence validate' and
+'evidence submit', which refuses blocked findings even with
+--skip-validation. The command fails when a finding blocks submission,
+unless --redact is given.
+
+With --redact, a sanitized copy of each file with findings is written to the
+window's .redacted/ directory, every match not waived replaced by
+[REDACTED:<rule>]. Review the copies and replace the originals with them
+before submitting.
+
+Examples:
+  # Scan a window
+  grctool evidence scan ET-0047 --window 2025-Q4
+
+  # Write sanitized copies
+  grctool evidence scan ET-0047 --window 2025-Q4 --redact`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTaskRefs,
+	RunE:              runEvidenceScan,
+}
+
+func init() {
+	evidenceCmd.AddCommand(evidenceScanCmd)
+
+	evidenceScanCmd.Flags().String("window", "", "evidence collection window (e.g., 2025-Q4)")
+	evidenceScanCmd.Flags().Bool("redact", false, "write sanitized copies of the files with findings to .redacted/")
+	evidenceScanCmd.MarkFlagRequired("window")
+}
+
+// evidenceScanResult is the structured output of evidence scan
+type evidenceScanResult struct {
+	TaskRef  string              `json:"task_ref"`
+	Window   string              `json:"window"`
+	Files    int                 `json:"files"`
+	Findings []redaction.Finding `json:"findings"`
+	Redacted []string            `json:"redacted,omitempty"` // Sanitized copies written
+}
+
+func runEvidenceScan(cmd *cobra.Command, args []string) error {
+	taskRef := args[0]
+	window, _ := cmd.Flags().GetString("window")
+	redact, _ := cmd.Flags().GetBool("redact")
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	scanner := redaction.New(cfg.Submission.Scan)
+	if scanner == nil {
+		return fmt.Errorf("submission.scan.secrets, submission.scan.pii and every submission.scan.rules entry are off; nothing to scan for")
+	}
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	files, err := store.GetEvidenceFiles(taskRef, window)
+	if err != nil {
+		return fmt.Errorf("failed to list evidence files: %w", err)
+	}
+	windowDir := store.EvidenceWindowDir(taskRef, window)
+	scanner = scanner.ForTask(taskRef, store.EvidenceTaskCategory(taskRef))
+
+	result := evidenceScanResult{TaskRef: taskRef, Window: window, Files: len(files), Findings: []redaction.Finding{}}
+	for _, file := range files {
+		data, err := storage.ReadFile(filepath.Join(windowDir, file.Filename))
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file.Filename, err)
+		}
+		findings := scanner.Scan(file.Filename, data)
+		result.Findings = append(result.Findings, findings...)
+		if !redact {
+			continue
+		}
+
+		sanitized, count := scanner.Redact(file.Filename, data)
+		if count == 0 {
+			continue
+		}
+		path := filepath.Join(windowDir, redactedDir, file.Filename)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", redactedDir, err)
+		}
+		if err := os.WriteFile(path, sanitized, 0644); err != nil {
+			return fmt.Errorf("failed to write sanitized copy of %s: %w", file.Filename, err)
+		}
+		result.Redacted = append(result.Redacted, filepath.Join(redactedDir, file.Filename))
+	}
+
+	if isStructuredOutput(format) {
+		if err := writeStructured(cmd, format, result); err != nil {
+			return err
+		}
+	} else {
+		displayEvidenceScan(cmd, result)
+	}
+
+	if blocking := redaction.Blocking(result.Findings); len(blocking) > 0 && !redact {
+		return fmt.Errorf("%d finding(s) in %s/%s block submission", len(blocking), taskRef, window)
+	}
+	return nil
+}
+
+// displayEvidenceScan reports the findings of a scan and the sanitized
+// copies written
+func displayEvidenceScan(cmd *cobra.Command, result evidenceScanResult) {
+	if len(result.Findings) == 0 {
+		cmd.Printf("✅ No secrets or personal data found in %d file(s) of %s/%s\n", result.Files, result.TaskRef, result.Window)
+		return
+	}
+
+	blocking, waived := len(redaction.Blocking(result.Findings)), 0
+	for _, finding := range result.Findings {
+		if finding.Action == redaction.Waived {
+			waived++
+		}
+	}
+	cmd.Printf("🔍 %d finding(s) in %d file(s) of %s/%s (%d blocking submission, %d waived)\n",
+		len(result.Findings), result.Files, result.TaskRef, result.Window, blocking, waived)
+	for _, finding := range result.Findings {
+		switch finding.Action {
+		case redaction.Block:
+			cmd.Printf("  ❌ %s\n", finding)
+		case redaction.Waived:
+			cmd.Printf("  ☑️  %s (waived: %s)\n", finding, finding.Waiver)
+		default:
+			cmd.Printf("  ⚠️  %s\n", finding)
+		}
+	}
+	if waived == len(result.Findings) {
+		return
+	}
+
+	cmd.Println()
+	if len(result.Redacted) > 0 {
+		cmd.Printf("🧹 Wrote %d sanitized copies:\n", len(result.Redacted))
+		for _, path := range result.Redacted {
+			cmd.Printf("  - %s\n", path)
+		}
+		cmd.Println("Review them and replace the originals before submitting.")
+		return
+	}
+	cmd.Printf("Run: grctool evidence scan %s --window %s --redact for sanitized copies,\n", result.TaskRef, result.Window)
+	cmd.Println("or waive accepted findings with submission.scan.waivers in .grctool.yaml")
+}
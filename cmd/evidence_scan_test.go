@@ -0,0 +1,67 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/grctool/grctool/internal/redaction"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplayEvidenceScan(t *testing.T) {
+	t.Parallel()
+
+	findings := []redaction.Finding{
+		{File: "config.tf", Line: 4, Rule: "secret_assignment", Kind: redaction.KindSecret, Action: redaction.Block, Excerpt: "hunt********"},
+		{File: "users.csv", Line: 2, Rule: "email", Kind: redaction.KindPII, Action: redaction.Warn, Excerpt: "alex********"},
+	}
+	tests := map[string]struct {
+		result evidenceScanResult
+		want   string
+	}{
+		"clean": {
+			result: evidenceScanResult{TaskRef: "ET-0047", Window: "2025-Q4", Files: 3},
+			want:   "✅ No secrets or personal data found in 3 file(s) of ET-0047/2025-Q4\n",
+		},
+		"findings": {
+			result: evidenceScanResult{TaskRef: "ET-0047", Window: "2025-Q4", Files: 3, Findings: findings},
+			want: "🔍 2 finding(s) in 3 file(s) of ET-0047/2025-Q4 (1 blocking submission, 0 waived)\n" +
+				"  ❌ config.tf:4: secret assignment hunt********\n" +
+				"  ⚠️  users.csv:2: email alex********\n" +
+				"\nRun: grctool evidence scan ET-0047 --window 2025-Q4 --redact for sanitized copies,\n" +
+				"or waive accepted findings with submission.scan.waivers in .grctool.yaml\n",
+		},
+		"all waived": {
+			result: evidenceScanResult{TaskRef: "ET-0047", Window: "2025-Q4", Files: 3, Findings: []redaction.Finding{
+				{File: "hosts.md", Line: 7, Rule: "internal-hosts", Kind: redaction.KindDLP, Action: redaction.Waived, Excerpt: "db01********", Waiver: "Auditor asked for hostnames"},
+			}},
+			want: "🔍 1 finding(s) in 3 file(s) of ET-0047/2025-Q4 (0 blocking submission, 1 waived)\n" +
+				"  ☑️  hosts.md:7: internal-hosts db01******** (waived: Auditor asked for hostnames)\n",
+		},
+		"redacted": {
+			result: evidenceScanResult{TaskRef: "ET-0047", Window: "2025-Q4", Files: 3, Findings: findings, Redacted: []string{".redacted/config.tf", ".redacted/users.csv"}},
+			want: "🔍 2 finding(s) in 3 file(s) of ET-0047/2025-Q4 (1 blocking submission, 0 waived)\n" +
+				"  ❌ config.tf:4: secret assignment hunt********\n" +
+				"  ⚠️  users.csv:2: email alex********\n" +
+				"\n🧹 Wrote 2 sanitized copies:\n" +
+				"  - .redacted/config.tf\n" +
+				"  - .redacted/users.csv\n" +
+				"Review them and replace the originals before submitting.\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&buf)
+			displayEvidenceScan(cmd, tc.result)
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+}
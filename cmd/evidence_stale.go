@@ -0,0 +1,161 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/services"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var evidenceStaleCmd = &cobra.Command{
+	Use:   "stale [task-ref]",
+	Short: "Find evidence that is out of date",
+	Long: `Flag evidence files whose sources changed after collection or that are
+older than the maximum age.
+
+Source timestamps are recorded per file in .generation/metadata.yaml when
+evidence is written: the tool run time, the last-modified time of local source
+files and globs (re-checked on disk), and the last-modified time reported for
+remote documents with --source-modified-at.
+
+By default each task's latest window is checked. The maximum age comes from
+evidence.freshness.max_age_days in the configuration (default 90 days).
+
+Examples:
+  # Check every task
+  grctool evidence stale
+
+  # Check one task in a specific window with a 30 day limit
+  grctool evidence stale ET-0001 --window 2025-Q4 --max-age 30
+
+  # Fail a CI job when anything is stale
+  grctool evidence stale --fail --output json`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeTaskRefs,
+	RunE:              runEvidenceStale,
+}
+
+func init() {
+	evidenceCmd.AddCommand(evidenceStaleCmd)
+
+	evidenceStaleCmd.Flags().String("window", "", "window to check (default: each task's latest window)")
+	evidenceStaleCmd.Flags().Int("max-age", 0, "maximum evidence age in days (default: evidence.freshness.max_age_days)")
+	evidenceStaleCmd.Flags().Bool("fail", false, "exit non-zero when stale evidence is found")
+}
+
+func runEvidenceStale(cmd *cobra.Command, args []string) error {
+	window, _ := cmd.Flags().GetString("window")
+	maxAgeDays, _ := cmd.Flags().GetInt("max-age")
+	failOnStale, _ := cmd.Flags().GetBool("fail")
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if maxAgeDays < 0 {
+		return fmt.Errorf("--max-age must not be negative")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if maxAgeDays == 0 {
+		maxAgeDays = cfg.Evidence.Freshness.MaxAgeDays
+	}
+
+	consoleLoggerCfg := cfg.Logging.Loggers["console"]
+	log, err := logger.New((&consoleLoggerCfg).ToLoggerConfig())
+	if err != nil {
+		return fmt.Errorf("failed to initialize logger: %w", err)
+	}
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	evidenceDir := filepath.Join(cfg.Storage.DataDir, "evidence")
+	scanner := services.NewEvidenceScanner(evidenceDir, &storageAdapter{storage: store}, log)
+	freshness := services.NewEvidenceFreshnessService(evidenceDir, scanner, log)
+
+	opts := services.FreshnessOptions{
+		MaxAge: time.Duration(maxAgeDays) * 24 * time.Hour,
+		Window: window,
+	}
+
+	var stale []services.StaleEvidence
+	if len(args) > 0 {
+		stale, err = freshness.CheckTask(context.Background(), args[0], opts)
+	} else {
+		stale, err = freshness.CheckAll(context.Background(), opts)
+	}
+	if err != nil {
+		return err
+	}
+
+	if isStructuredOutput(format) {
+		if stale == nil {
+			stale = []services.StaleEvidence{}
+		}
+		if err := writeStructured(cmd, format, stale); err != nil {
+			return err
+		}
+	} else {
+		displayStaleEvidence(cmd, stale, maxAgeDays)
+	}
+
+	if failOnStale && len(stale) > 0 {
+		return fmt.Errorf("%d stale evidence findings", len(stale))
+	}
+	return nil
+}
+
+func displayStaleEvidence(cmd *cobra.Command, stale []services.StaleEvidence, maxAgeDays int) {
+	if len(stale) == 0 {
+		cmd.Printf("✅ All evidence is fresh (max age %d days)\n", maxAgeDays)
+		return
+	}
+
+	cmd.Printf("⚠️  %d stale evidence findings (max age %d days)\n\n", len(stale), maxAgeDays)
+
+	current := ""
+	for _, entry := range stale {
+		group := entry.TaskRef + " / " + entry.Window
+		if group != current {
+			if current != "" {
+				cmd.Println()
+			}
+			cmd.Printf("%s\n", group)
+			current = group
+		}
+
+		icon := "⏰"
+		if entry.Reason == services.FreshnessSourceChanged {
+			icon = "🔄"
+		}
+		cmd.Printf("  %s %s: %s\n", icon, entry.File, entry.Message)
+	}
+	cmd.Println()
+	cmd.Println("Regenerate the flagged evidence, e.g. grctool evidence generate <task-ref>")
+}
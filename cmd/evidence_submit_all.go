@@ -0,0 +1,325 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/services/submission"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+// BulkSubmitResult is the structured output of 'evidence submit --all'
+type BulkSubmitResult struct {
+	Window     string           `json:"window"`
+	DryRun     bool             `json:"dry_run"`
+	Tasks      []BulkSubmitTask `json:"tasks"`       // Tasks whose evidence passed validation, with their outcome
+	Skipped    []BulkSubmitTask `json:"skipped"`     // Tasks with evidence that were not submitted, and why
+	NoEvidence int              `json:"no_evidence"` // Tasks without evidence in the window
+	Submitted  int              `json:"submitted"`
+	Failed     int              `json:"failed"`
+}
+
+// BulkSubmitTask is the pre-flight check or submission outcome of one task
+type BulkSubmitTask struct {
+	TaskRef        string   `json:"task_ref"`
+	Name           string   `json:"name"`
+	Target         string   `json:"target,omitempty"`
+	Destination    string   `json:"destination,omitempty"`
+	Files          int      `json:"files"`
+	Status         string   `json:"status"` // ready, skipped, submitted, partial (some files failed) or failed
+	SubmissionID   string   `json:"submission_id,omitempty"`
+	FilesSubmitted int      `json:"files_submitted,omitempty"`
+	Reason         string   `json:"reason,omitempty"` // Why the task was skipped or failed
+	Errors         []string `json:"errors,omitempty"` // Validation errors, or the files that failed to upload
+}
+
+func runEvidenceSubmitAll(cmd *cobra.Command, window string) error {
+	notes, _ := cmd.Flags().GetString("notes")
+	skipValidation, _ := cmd.Flags().GetBool("skip-validation")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	target, _ := cmd.Flags().GetString("target")
+	target = strings.ToLower(strings.TrimSpace(target))
+	for _, flag := range []string{"retry-failed", "queue", "offline"} {
+		if set, _ := cmd.Flags().GetBool(flag); set {
+			return fmt.Errorf("--%s submits a single task and cannot be used with --all", flag)
+		}
+	}
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	structured := isStructuredOutput(format)
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	tasks, err := store.GetAllEvidenceTasks()
+	if err != nil {
+		return fmt.Errorf("failed to load evidence tasks: %w", err)
+	}
+
+	result := planBulkSubmission(cfg, store, tasks, window, target)
+	result.DryRun = dryRun
+	if !structured {
+		displayBulkSubmitPlan(cmd, result)
+	}
+
+	if !dryRun && len(result.Tasks) > 0 {
+		if !structured {
+			cmd.Printf("🚀 Submitting %d task(s)...\n\n", len(result.Tasks))
+		}
+		submit := func(ctx context.Context, target string, req *submission.SubmitRequest) (*submission.SubmitResponse, error) {
+			return newSubmissionService(cfg, store, target, false).Submit(ctx, req)
+		}
+		progress := func(i int, task BulkSubmitTask) {
+			if !structured {
+				cmd.Printf("  [%d/%d] %s %s\n", i+1, len(result.Tasks), task.TaskRef, bulkSubmitStatusIcon(task.Status))
+			}
+		}
+		if err := submitBulk(commandContext(cmd), store, result, target, &submission.SubmitRequest{
+			Notes:          notes,
+			SkipValidation: skipValidation,
+			SubmittedBy:    "grctool-cli",
+		}, submit, progress); err != nil {
+			return err
+		}
+	}
+
+	if structured {
+		if err := writeStructured(cmd, format, result); err != nil {
+			return err
+		}
+	} else if dryRun {
+		cmd.Println("🔍 Dry-run mode - no files were uploaded")
+	} else if len(result.Tasks) > 0 {
+		displayBulkSubmitResults(cmd, result)
+	}
+
+	if result.Failed > 0 {
+		return fmt.Errorf("%d of %d submissions failed", result.Failed, len(result.Tasks))
+	}
+	return nil
+}
+
+// planBulkSubmission is the pre-flight check of 'evidence submit --all': the
+// tasks with evidence in the window that passed validation and have a
+// submission target are ready, and the others with evidence are skipped
+func planBulkSubmission(cfg *config.Config, store *storage.Storage, tasks []domain.EvidenceTask, window, target string) *BulkSubmitResult {
+	result := &BulkSubmitResult{Window: window, Tasks: []BulkSubmitTask{}, Skipped: []BulkSubmitTask{}}
+
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ReferenceID < tasks[j].ReferenceID })
+	for _, task := range tasks {
+		taskRef := task.ReferenceID
+		entry := BulkSubmitTask{TaskRef: taskRef, Name: task.Name, Status: "skipped"}
+		files, _ := store.GetEvidenceFiles(taskRef, window)
+		entry.Files = len(files)
+
+		if submitted, _ := store.CheckAlreadySubmitted(taskRef, window); submitted {
+			entry.Reason = "already submitted"
+			if previous, err := store.LoadSubmission(taskRef, window); err == nil && len(previous.FailedFiles) > 0 {
+				entry.Reason = fmt.Sprintf("already submitted; upload the %d failed file(s) with --retry-failed", len(previous.FailedFiles))
+			}
+			result.Skipped = append(result.Skipped, entry)
+			continue
+		}
+		if len(files) == 0 {
+			result.NoEvidence++
+			continue
+		}
+
+		validation, err := store.LoadValidationResult(taskRef, window)
+		switch {
+		case err != nil:
+			entry.Reason = "not validated; run 'grctool evidence validate'"
+		case !validation.ReadyForSubmission:
+			entry.Reason = fmt.Sprintf("validation failed with %d errors", validation.FailedChecks)
+			for _, validationErr := range validation.Errors {
+				entry.Errors = append(entry.Errors, validationErr.Message)
+			}
+		}
+		if entry.Reason == "" && cfg.Submission.RequireApproval {
+			if _, err := submission.CheckApproval(store, taskRef, window, files); err != nil {
+				entry.Reason = err.Error()
+			}
+		} else if entry.Reason == "" {
+			if err := submission.CheckDraftReviewed(store, taskRef, window, files); err != nil {
+				entry.Reason = err.Error()
+			}
+		}
+		destination, destinationTarget := submissionDestination(cfg, taskRef, target)
+		if destination != "" {
+			entry.Target, entry.Destination = destinationTarget.name, destination
+		} else if entry.Reason == "" {
+			entry.Reason = "no submission target configured"
+			if target != "" {
+				entry.Reason = fmt.Sprintf("no %s configured", destinationTarget.destination)
+			}
+		}
+		if entry.Reason != "" {
+			result.Skipped = append(result.Skipped, entry)
+			continue
+		}
+
+		entry.Status = "ready"
+		result.Tasks = append(result.Tasks, entry)
+	}
+	return result
+}
+
+// submitBulk submits each ready task of a bulk submission in turn, recording
+// its outcome, and carries on past failures so they can be reported together.
+// Each window is locked until its uploaded files have moved to .submitted/.
+func submitBulk(ctx context.Context, store *storage.Storage, result *BulkSubmitResult, target string, template *submission.SubmitRequest, submit submitFunc, progress func(int, BulkSubmitTask)) error {
+	for i := range result.Tasks {
+		task := &result.Tasks[i]
+		req := *template
+		req.TaskRef, req.Window = task.TaskRef, result.Window
+		if err := submitBulkTask(ctx, store, task, target, &req, submit); err != nil {
+			return err
+		}
+
+		if task.Status == "failed" {
+			result.Failed++
+		} else {
+			result.Submitted++
+		}
+		progress(i, *task)
+	}
+	return nil
+}
+
+func submitBulkTask(ctx context.Context, store *storage.Storage, task *BulkSubmitTask, target string, req *submission.SubmitRequest, submit submitFunc) error {
+	lock, err := store.LockWindow(req.TaskRef, req.Window)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	task.Status = "failed"
+	resp, err := submit(ctx, target, req)
+	if err != nil {
+		task.Reason = err.Error()
+		return nil
+	}
+	if !resp.Success {
+		task.Reason = resp.Message
+		if resp.ValidationResult != nil {
+			for _, validationErr := range resp.ValidationResult.Errors {
+				task.Errors = append(task.Errors, validationErr.Message)
+			}
+		}
+		return nil
+	}
+
+	task.Status = "submitted"
+	task.SubmissionID = resp.SubmissionID
+	task.FilesSubmitted = task.Files
+	if resp.Submission != nil {
+		uploaded := resp.Submission.UploadedFiles()
+		task.FilesSubmitted = len(uploaded)
+		if len(resp.Submission.FailedFiles) > 0 {
+			task.Status = "partial"
+			task.Reason = fmt.Sprintf("%d file(s) failed to upload; retry with --retry-failed", len(resp.Submission.FailedFiles))
+			for _, failure := range resp.Submission.FailedFiles {
+				task.Errors = append(task.Errors, fmt.Sprintf("%s: %s", failure.Filename, failure.Error))
+			}
+		}
+		if err := store.MoveEvidenceFilesToSubmitted(req.TaskRef, req.Window, uploaded); err != nil {
+			task.Errors = append(task.Errors, fmt.Sprintf("submitted, but failed to move files to .submitted/: %v", err))
+		}
+	}
+	return nil
+}
+
+func displayBulkSubmitPlan(cmd *cobra.Command, result *BulkSubmitResult) {
+	cmd.Printf("📋 Pre-flight check for window %s\n", result.Window)
+	cmd.Printf("  ✅ Ready: %d task(s)\n", len(result.Tasks))
+	cmd.Printf("  ⏭️  Skipped: %d task(s)\n", len(result.Skipped))
+	cmd.Printf("  📭 No evidence: %d task(s)\n\n", result.NoEvidence)
+
+	if len(result.Tasks) > 0 {
+		cmd.Println("Ready to submit:")
+		for _, task := range result.Tasks {
+			cmd.Printf("  %-10s %3d file(s) → %s %s\n", task.TaskRef, task.Files, task.Target, task.Destination)
+		}
+		cmd.Println()
+	}
+	if len(result.Skipped) > 0 {
+		cmd.Println("Skipped:")
+		for _, task := range result.Skipped {
+			cmd.Printf("  %-10s %s\n", task.TaskRef, task.Reason)
+		}
+		cmd.Println()
+	}
+	if len(result.Tasks) == 0 {
+		cmd.Println("No evidence ready to submit. Validate it first with: grctool evidence validate <task>")
+	}
+}
+
+func displayBulkSubmitResults(cmd *cobra.Command, result *BulkSubmitResult) {
+	cmd.Println()
+	cmd.Printf("%-10s %-10s %-7s %-11s %s\n", "TASK", "STATUS", "FILES", "TARGET", "SUBMISSION")
+	for _, task := range result.Tasks {
+		submissionID := task.SubmissionID
+		if submissionID == "" {
+			submissionID = "-"
+		}
+		cmd.Printf("%-10s %-10s %-7s %-11s %s\n", task.TaskRef, task.Status,
+			fmt.Sprintf("%d/%d", task.FilesSubmitted, task.Files), task.Target, submissionID)
+	}
+	cmd.Printf("\n✅ Submitted: %d  ❌ Failed: %d  ⏭️  Skipped: %d\n", result.Submitted, result.Failed, len(result.Skipped))
+
+	var failures []BulkSubmitTask
+	for _, task := range result.Tasks {
+		if task.Status == "failed" || task.Status == "partial" {
+			failures = append(failures, task)
+		}
+	}
+	if len(failures) == 0 {
+		return
+	}
+	cmd.Printf("\nFailure report (%d task(s)):\n", len(failures))
+	for _, task := range failures {
+		cmd.Printf("  %s %s - %s: %s\n", bulkSubmitStatusIcon(task.Status), task.TaskRef, task.Name, task.Reason)
+		for _, detail := range task.Errors {
+			cmd.Printf("      - %s\n", detail)
+		}
+	}
+}
+
+func bulkSubmitStatusIcon(status string) string {
+	switch status {
+	case "submitted":
+		return "✅"
+	case "partial":
+		return "⚠️"
+	default:
+		return "❌"
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/services/submission"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanBulkSubmission(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+	store, err := storage.NewStorage(config.StorageConfig{DataDir: dataDir})
+	require.NoError(t, err)
+	writeEvidence := func(taskRef, filename string) {
+		dir := filepath.Join(dataDir, "evidence", taskRef, "2025-Q4", filepath.Dir(filename))
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, filepath.Base(filename)), []byte("user,role\n"), 0644))
+	}
+	validate := func(taskRef string, result *models.ValidationResult) {
+		require.NoError(t, store.SaveValidationResult(taskRef, "2025-Q4", result))
+	}
+
+	writeEvidence("ET-0001", "access.csv")
+	validate("ET-0001", &models.ValidationResult{Status: "passed", ReadyForSubmission: true})
+	writeEvidence("ET-0002", "access.csv")
+	validate("ET-0002", &models.ValidationResult{Status: "failed", FailedChecks: 1,
+		Errors: []models.ValidationError{{Code: "MISSING_FILE", Message: "no reviewer sign-off"}}})
+	writeEvidence("ET-0003", "access.csv")
+	writeEvidence("ET-0005", ".submitted/access.csv")
+	writeEvidence("ET-0006", "access.csv")
+	validate("ET-0006", &models.ValidationResult{Status: "passed", ReadyForSubmission: true})
+
+	tasks := []domain.EvidenceTask{}
+	for _, taskRef := range []string{"ET-0006", "ET-0005", "ET-0004", "ET-0003", "ET-0002", "ET-0001"} {
+		tasks = append(tasks, domain.EvidenceTask{ReferenceID: taskRef, Name: "Task " + taskRef})
+	}
+	cfg := &config.Config{Vanta: config.VantaConfig{DocumentIDs: map[string]string{"ET-0001": "doc-1", "ET-0002": "doc-2"}}}
+
+	tests := map[string]struct {
+		target  string
+		ready   []BulkSubmitTask
+		skipped map[string]string
+	}{
+		"first configured target": {
+			ready: []BulkSubmitTask{{TaskRef: "ET-0001", Name: "Task ET-0001", Target: "vanta", Destination: "doc-1", Files: 1, Status: "ready"}},
+			skipped: map[string]string{
+				"ET-0002": "validation failed with 1 errors",
+				"ET-0003": "not validated; run 'grctool evidence validate'",
+				"ET-0005": "already submitted",
+				"ET-0006": "no submission target configured",
+			},
+		},
+		"named target": {
+			target: "drata",
+			ready:  []BulkSubmitTask{},
+			skipped: map[string]string{
+				"ET-0001": "no Drata control ID configured",
+				"ET-0002": "validation failed with 1 errors",
+				"ET-0003": "not validated; run 'grctool evidence validate'",
+				"ET-0005": "already submitted",
+				"ET-0006": "no Drata control ID configured",
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			result := planBulkSubmission(cfg, store, append([]domain.EvidenceTask{}, tasks...), "2025-Q4", tc.target)
+			assert.Equal(t, tc.ready, result.Tasks)
+			skipped := map[string]string{}
+			for _, task := range result.Skipped {
+				skipped[task.TaskRef] = task.Reason
+			}
+			assert.Equal(t, tc.skipped, skipped)
+			assert.Equal(t, 1, result.NoEvidence)
+		})
+	}
+
+	result := planBulkSubmission(cfg, store, tasks, "2025-Q4", "")
+	require.Len(t, result.Skipped, 4)
+	assert.Equal(t, []string{"no reviewer sign-off"}, result.Skipped[0].Errors)
+
+	approvalCfg := *cfg
+	approvalCfg.Submission.RequireApproval = true
+	result = planBulkSubmission(&approvalCfg, store, tasks, "2025-Q4", "")
+	assert.Empty(t, result.Tasks)
+	assert.Equal(t, "ET-0001/2025-Q4 has not been approved; a second reviewer must run 'grctool evidence approve ET-0001 --window 2025-Q4'", result.Skipped[0].Reason)
+}
+
+func TestSubmitBulk(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+	store, err := storage.NewStorage(config.StorageConfig{DataDir: dataDir})
+	require.NoError(t, err)
+	for _, taskRef := range []string{"ET-0001", "ET-0002", "ET-0003"} {
+		dir := filepath.Join(dataDir, "evidence", taskRef, "2025-Q4")
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		for _, filename := range []string{"access.csv", "users.csv"} {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, filename), []byte("user,role\n"), 0644))
+		}
+	}
+
+	result := &BulkSubmitResult{Window: "2025-Q4", Tasks: []BulkSubmitTask{
+		{TaskRef: "ET-0001", Target: "vanta", Files: 2, Status: "ready"},
+		{TaskRef: "ET-0002", Target: "vanta", Files: 2, Status: "ready"},
+		{TaskRef: "ET-0003", Target: "vanta", Files: 2, Status: "ready"},
+	}}
+	files := []models.EvidenceFileRef{{Filename: "access.csv"}, {Filename: "users.csv"}}
+	var requests []submission.SubmitRequest
+	submit := func(ctx context.Context, target string, req *submission.SubmitRequest) (*submission.SubmitResponse, error) {
+		requests = append(requests, *req)
+		assert.Equal(t, "vanta", target)
+		switch req.TaskRef {
+		case "ET-0001":
+			return &submission.SubmitResponse{Success: true, SubmissionID: "batch-1", Submission: &models.EvidenceSubmission{EvidenceFiles: files}}, nil
+		case "ET-0002":
+			return &submission.SubmitResponse{Success: true, SubmissionID: "batch-2", Submission: &models.EvidenceSubmission{
+				EvidenceFiles: files,
+				FailedFiles:   []models.FailedUpload{{Filename: "users.csv", Error: "status 500"}},
+			}}, nil
+		}
+		return nil, errors.New("vanta submission failed: status 401")
+	}
+	var progress []string
+	err = submitBulk(context.Background(), store, result, "vanta", &submission.SubmitRequest{Notes: "Q4", SubmittedBy: "grctool-cli"}, submit,
+		func(i int, task BulkSubmitTask) { progress = append(progress, task.TaskRef+" "+task.Status) })
+	require.NoError(t, err)
+
+	require.Len(t, requests, 3)
+	assert.Equal(t, submission.SubmitRequest{TaskRef: "ET-0003", Window: "2025-Q4", Notes: "Q4", SubmittedBy: "grctool-cli"}, requests[2])
+	assert.Equal(t, []string{"ET-0001 submitted", "ET-0002 partial", "ET-0003 failed"}, progress)
+	assert.Equal(t, 2, result.Submitted)
+	assert.Equal(t, 1, result.Failed)
+
+	assert.Equal(t, 2, result.Tasks[0].FilesSubmitted)
+	assert.Equal(t, "batch-1", result.Tasks[0].SubmissionID)
+	assert.Equal(t, 1, result.Tasks[1].FilesSubmitted)
+	assert.Equal(t, []string{"users.csv: status 500"}, result.Tasks[1].Errors)
+	assert.Equal(t, "vanta submission failed: status 401", result.Tasks[2].Reason)
+
+	windowDir := filepath.Join(dataDir, "evidence", "ET-0002", "2025-Q4")
+	assert.FileExists(t, filepath.Join(windowDir, ".submitted", "access.csv"))
+	assert.FileExists(t, filepath.Join(windowDir, "users.csv"), "failed files stay for --retry-failed")
+	assert.FileExists(t, filepath.Join(dataDir, "evidence", "ET-0003", "2025-Q4", "access.csv"))
+}
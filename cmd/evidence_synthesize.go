@@ -0,0 +1,244 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/llm"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/services/evidence"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/grctool/grctool/internal/tools"
+	"github.com/spf13/cobra"
+)
+
+// synthesizeEvidence has the model configured for synthesis draft the
+// task's evidence document from the assembly prompt and tool outputs
+func synthesizeEvidence(cmd *cobra.Command, cfg *config.Config, task *domain.EvidenceTask, window, assemblyPrompt, toolOutputDir string) error {
+	llmConfig := cfg.Evidence.LLM.For(config.LLMOperationSynthesis)
+	model, err := llm.NewModel(llmConfig, nil)
+	if err != nil {
+		return err
+	}
+	if model == nil {
+		return fmt.Errorf("no language model is configured for synthesis: set evidence.llm or evidence.llm.operations.synthesis in .grctool.yaml")
+	}
+
+	cmd.Printf("🤖 Drafting evidence for %s with %s...\n", task.ReferenceID, model.Name())
+	synthesizer := tools.NewEvidenceSynthesizer(cfg, logger.WithComponent("synthesis"), model, llmConfig)
+	result, err := synthesizer.Synthesize(commandContext(cmd), task, window, assemblyPrompt, toolOutputDir)
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("✅ Evidence drafted for %s: %s\n\n", task.ReferenceID, task.Name)
+	cmd.Printf("📝 Draft: %s\n", result.Path)
+	cmd.Printf("🪙 Used: %s\n", formatModelUsage(result.Usage))
+	if len(result.ToolsUsed) > 0 {
+		cmd.Printf("🔧 From tool outputs: %v\n", result.ToolsUsed)
+	} else {
+		cmd.Println("⚠️  No tool outputs were collected; the draft rests on the assembly prompt alone")
+	}
+	cmd.Println("\n⚠️  Machine-drafted, pending human review. It cannot be submitted until approved:")
+	cmd.Println("  1. Check every statement in the draft against its source and correct it")
+	cmd.Printf("  2. grctool evidence approve %s --window %s --approver <you>\n", task.ReferenceID, window)
+	return nil
+}
+
+// synthesizeAllEvidence drafts the evidence of every pending task that can
+// be automated, within the budget set by evidence.synthesis or the
+// --budget-tokens and --budget-cost flags. Tasks whose window already has an
+// evidence document are skipped, so running it again after the budget runs
+// out continues where it stopped.
+func synthesizeAllEvidence(cmd *cobra.Command, svc evidence.Service, options evidence.BulkGenerationOptions, window string, ctx context.Context) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	llmConfig := cfg.Evidence.LLM.For(config.LLMOperationSynthesis)
+	model, err := llm.NewModel(llmConfig, nil)
+	if err != nil {
+		return err
+	}
+	if model == nil {
+		return fmt.Errorf("no language model is configured for synthesis: set evidence.llm or evidence.llm.operations.synthesis in .grctool.yaml")
+	}
+
+	budget := tools.SynthesisBudget{MaxTokens: cfg.Evidence.Synthesis.MaxTokens, MaxCost: cfg.Evidence.Synthesis.MaxCost}
+	if cmd.Flags().Changed("budget-tokens") {
+		budget.MaxTokens, _ = cmd.Flags().GetInt("budget-tokens")
+	}
+	if cmd.Flags().Changed("budget-cost") {
+		budget.MaxCost, _ = cmd.Flags().GetFloat64("budget-cost")
+	}
+	if budget.MaxTokens < 0 || budget.MaxCost < 0 {
+		return fmt.Errorf("--budget-tokens and --budget-cost cannot be negative")
+	}
+
+	cmd.Println("Loading pending evidence tasks...")
+	allTasks, err := svc.ListEvidenceTasks(ctx, domain.EvidenceFilter{})
+	if err != nil {
+		return fmt.Errorf("failed to list evidence tasks: %w", err)
+	}
+	var tasks []domain.EvidenceTask
+	for _, task := range allTasks {
+		if task.Completed || strings.ToLower(task.Status) == "completed" {
+			continue
+		}
+		if !task.CanBeAutomated() || isTugboatManagedTask(&task) {
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+	if len(tasks) == 0 {
+		cmd.Println("No pending evidence tasks can be drafted.")
+		return nil
+	}
+
+	cmd.Printf("Drafting evidence for %d pending task(s) with %s", len(tasks), model.Name())
+	if limits := formatBudget(budget); limits != "" {
+		cmd.Printf(" within %s", limits)
+	}
+	cmd.Print("\n\n")
+
+	synthesizer := tools.NewEvidenceSynthesizer(cfg, logger.WithComponent("synthesis"), model, llmConfig)
+	prepare := func(task *domain.EvidenceTask) (string, string, error) {
+		assemblyContext, err := generateAssemblyContext(task, window, options.Tools, cfg, store)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to generate assembly context: %w", err)
+		}
+		assemblyPaths, err := saveAssemblyContext(task, window, assemblyContext, cfg.Storage.DataDir)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to save assembly context: %w", err)
+		}
+		// Draft from the tools that succeeded; failures are logged. Without
+		// any tool data there is nothing to draft from.
+		runs, err := executeApplicableTools(commandContext(cmd), task, assemblyContext.ApplicableTools, assemblyPaths.ToolDataDir, cfg)
+		if err != nil && !anyToolSucceeded(runs) {
+			return "", "", err
+		}
+		return assemblyContext.ComprehensivePrompt, assemblyPaths.ToolDataDir, nil
+	}
+	batch := draftTasks(cmd, ctx, tasks, window, synthesizer, &budget, prepare)
+
+	cmd.Print("\n" + strings.Repeat("=", 60) + "\n")
+	cmd.Print("Evidence Synthesis Complete\n")
+	cmd.Printf("  ✅ Drafted: %d tasks (%s)\n", budget.Drafts, formatModelUsage(budget.Spent))
+	if batch.skipped > 0 {
+		cmd.Printf("  ⏭️  Skipped: %d tasks already have evidence in %s\n", batch.skipped, window)
+	}
+	if len(batch.failed) > 0 {
+		cmd.Printf("  ⚠️  Failed: %d tasks\n", len(batch.failed))
+		cmd.Println("\nFailed tasks:")
+		for _, failure := range batch.failed {
+			cmd.Printf("    - %s\n", failure)
+		}
+	}
+	if batch.exhausted != "" {
+		cmd.Printf("\n⏸️  Budget reached (%s); %d task(s) not drafted.\n", batch.exhausted, batch.remaining)
+		cmd.Println("   Run the same command again to continue: tasks already drafted are skipped.")
+	}
+	if budget.Drafts > 0 {
+		cmd.Println("\n⚠️  Drafts are machine-drafted and pending human review. Check each against its sources, then:")
+		cmd.Printf("  grctool evidence approve <task> --window %s --approver <you>\n", window)
+	}
+	return nil
+}
+
+// synthesisBatch is the outcome of drafting a batch of tasks
+type synthesisBatch struct {
+	skipped   int
+	failed    []string
+	exhausted string // Why the budget stopped the batch; empty when it did not
+	remaining int    // Tasks left undrafted when the budget stopped the batch
+}
+
+// draftTasks drafts each task in turn, stopping once the budget cannot fit
+// another draft. prepare returns a task's assembly prompt and the directory
+// of its tool outputs.
+func draftTasks(cmd *cobra.Command, ctx context.Context, tasks []domain.EvidenceTask, window string, synthesizer *tools.EvidenceSynthesizer,
+	budget *tools.SynthesisBudget, prepare func(task *domain.EvidenceTask) (string, string, error)) synthesisBatch {
+	var batch synthesisBatch
+	for i := range tasks {
+		task := &tasks[i]
+		if synthesizer.Drafted(task, window) {
+			batch.skipped++
+			continue
+		}
+		if reason := budget.Exhausted(); reason != "" {
+			batch.exhausted = reason
+			for _, rest := range tasks[i:] {
+				if !synthesizer.Drafted(&rest, window) {
+					batch.remaining++
+				}
+			}
+			break
+		}
+
+		cmd.Printf("  [%d/%d] %s - %s", i+1, len(tasks), task.ReferenceID, task.Name)
+		assemblyPrompt, toolOutputDir, err := prepare(task)
+		if err == nil {
+			var result *tools.SynthesisResult
+			result, err = synthesizer.Synthesize(ctx, task, window, assemblyPrompt, toolOutputDir)
+			if err == nil {
+				budget.Spend(result.Usage)
+				cmd.Printf(" ✅ %s\n", formatModelUsage(result.Usage))
+				continue
+			}
+		}
+		cmd.Printf(" ⚠️  Failed: %v\n", err)
+		batch.failed = append(batch.failed, fmt.Sprintf("%s (%s)", task.ReferenceID, err.Error()))
+	}
+	return batch
+}
+
+// anyToolSucceeded reports whether any of the runs succeeded
+func anyToolSucceeded(runs []toolRun) bool {
+	for _, run := range runs {
+		if run.Status == toolRunSucceeded {
+			return true
+		}
+	}
+	return false
+}
+
+// formatModelUsage describes the tokens a model used and their cost
+func formatModelUsage(usage models.ModelUsage) string {
+	return fmt.Sprintf("%d input + %d output tokens, $%.4f", usage.InputTokens, usage.OutputTokens, usage.CostUSD)
+}
+
+// formatBudget describes the limits of a budget, or "" when it has none
+func formatBudget(budget tools.SynthesisBudget) string {
+	var limits []string
+	if budget.MaxTokens > 0 {
+		limits = append(limits, fmt.Sprintf("%d tokens", budget.MaxTokens))
+	}
+	if budget.MaxCost > 0 {
+		limits = append(limits, fmt.Sprintf("$%.2f", budget.MaxCost))
+	}
+	return strings.Join(limits, " and ")
+}
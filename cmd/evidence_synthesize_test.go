@@ -0,0 +1,130 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/llm"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/services/evidence"
+	"github.com/grctool/grctool/internal/tools"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessEvidenceGeneration_SynthesizeConflicts(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		options     evidence.BulkGenerationOptions
+		contextOnly bool
+		synthesize  bool
+		budget      bool
+		wantErr     string
+	}{
+		"with --context-only": {
+			synthesize:  true,
+			contextOnly: true,
+			wantErr:     "--synthesize and --context-only cannot be combined",
+		},
+		"with --all --context-only": {
+			options:     evidence.BulkGenerationOptions{All: true},
+			synthesize:  true,
+			contextOnly: true,
+			wantErr:     "--synthesize and --context-only cannot be combined",
+		},
+		"budget for one task": {
+			synthesize: true,
+			budget:     true,
+			wantErr:    "--budget-tokens and --budget-cost limit batch synthesis: use them with --all --synthesize",
+		},
+		"budget without synthesis": {
+			options: evidence.BulkGenerationOptions{All: true},
+			budget:  true,
+			wantErr: "--budget-tokens and --budget-cost limit batch synthesis: use them with --all --synthesize",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cmd := &cobra.Command{Use: "test"}
+			cmd.Flags().String("window", "2025-Q4", "")
+			cmd.Flags().Bool("context-only", tc.contextOnly, "")
+			cmd.Flags().Bool("synthesize", tc.synthesize, "")
+			cmd.Flags().Int("budget-tokens", 0, "")
+			cmd.Flags().Float64("budget-cost", 0, "")
+			if tc.budget {
+				require.NoError(t, cmd.Flags().Set("budget-cost", "5"))
+			}
+
+			err := processEvidenceGeneration(cmd, new(MockEvidenceService), tc.options, []string{"ET-0047"}, context.Background())
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
+
+// meteredModel drafts every task, reporting the same usage for each
+type meteredModel struct{}
+
+func (meteredModel) Name() string { return "anthropic/claude-3-5-haiku-latest" }
+
+func (meteredModel) Available(context.Context) error { return nil }
+
+func (meteredModel) Generate(_ context.Context, req llm.Request) (string, error) {
+	*req.Usage = llm.Usage{InputTokens: 3000, OutputTokens: 1000}
+	return "# Evidence\n\nDrafted.", nil
+}
+
+func TestDraftTasks(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Storage: config.StorageConfig{DataDir: t.TempDir()}}
+	log, err := logger.NewTestLogger()
+	require.NoError(t, err)
+	llmConfig := config.LLMConfig{MaxContextChars: 12000, InputCostPerMillion: 1, OutputCostPerMillion: 5}
+	synthesizer := tools.NewEvidenceSynthesizer(cfg, log, meteredModel{}, llmConfig)
+	tasks := []domain.EvidenceTask{
+		{ID: "1", ReferenceID: "ET-0001", Name: "User Access List"},
+		{ID: "2", ReferenceID: "ET-0002", Name: "Firewall Configuration"},
+		{ID: "3", ReferenceID: "ET-0003", Name: "Backup Report"},
+		{ID: "4", ReferenceID: "ET-0004", Name: "Patch Report"},
+	}
+	prepare := func(task *domain.EvidenceTask) (string, string, error) {
+		if task.ReferenceID == "ET-0002" {
+			return "", "", errors.New("prompt assembler failed")
+		}
+		return "# Evidence Collection Task: " + task.Name, "", nil
+	}
+
+	// Each draft costs $0.008; $0.02 fits two
+	budget := &tools.SynthesisBudget{MaxCost: 0.02}
+	var out bytes.Buffer
+	cmd := &cobra.Command{Use: "test"}
+	cmd.SetOut(&out)
+	batch := draftTasks(cmd, context.Background(), tasks, "2025-Q4", synthesizer, budget, prepare)
+
+	assert.Equal(t, 2, budget.Drafts)
+	assert.Equal(t, []string{"ET-0002 (prompt assembler failed)"}, batch.failed)
+	assert.Equal(t, "$0.0160 of the $0.02 budget spent", batch.exhausted)
+	assert.Equal(t, 1, batch.remaining)
+	assert.Contains(t, out.String(), "ET-0001 - User Access List ✅ 3000 input + 1000 output tokens, $0.0080")
+	assert.True(t, synthesizer.Drafted(&tasks[2], "2025-Q4"))
+	assert.False(t, synthesizer.Drafted(&tasks[3], "2025-Q4"))
+
+	// Running again with a fresh budget skips the drafted tasks
+	budget = &tools.SynthesisBudget{MaxCost: 0.02}
+	batch = draftTasks(cmd, context.Background(), tasks, "2025-Q4", synthesizer, budget, prepare)
+	assert.Equal(t, 2, batch.skipped)
+	assert.Equal(t, 1, budget.Drafts)
+	assert.Empty(t, batch.exhausted)
+	assert.True(t, synthesizer.Drafted(&tasks[3], "2025-Q4"))
+}
@@ -0,0 +1,134 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+var evidenceTemplatesCmd = &cobra.Command{
+	Use:   "templates [name]",
+	Short: "List or print the instruction and evidence templates",
+	Long: `List the templates 'evidence generate' writes into each window's .context/
+directory, or print one of them: the assistant instructions and the evidence
+outline of each task category.
+
+A file <name>.md in evidence.generation.template_dir (default
+<data_dir>/templates) replaces the built-in template of that name, so
+instructions, tone and required sections can be changed without rebuilding
+grctool. Templates are Go text/templates rendered with the task (.Task),
+its related controls (.Controls), .Window, .Period, .Date and the loaded
+configuration (.Config), e.g. {{range .Controls}}| {{cell .ID}} | {{cell .Name}} |{{end}}.
+The earlier {{TASK_REF}}-style placeholders still work.
+
+Outlines for particular tasks, categories or frameworks can be mapped to
+files in evidence.generation.templates; a task reference mapping wins over a
+category mapping, which wins over a framework mapping, and tasks without one
+use the template of their category.
+
+Examples:
+  # Show which templates are overridden
+  grctool evidence templates
+
+  # Copy the built-in templates into the template directory to edit them
+  grctool evidence templates --init
+
+  # Print the instructions template in use
+  grctool evidence templates instructions`,
+	Args: cobra.MaximumNArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return templates.EvidenceTemplateNames, cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: runEvidenceTemplates,
+}
+
+func init() {
+	evidenceCmd.AddCommand(evidenceTemplatesCmd)
+
+	evidenceTemplatesCmd.Flags().Bool("init", false, "copy the built-in templates not yet overridden into the template directory")
+}
+
+func runEvidenceTemplates(cmd *cobra.Command, args []string) error {
+	initialize, _ := cmd.Flags().GetBool("init")
+	if initialize && len(args) > 0 {
+		return fmt.Errorf("--init copies every template; do not name one")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	templateSet := evidenceTemplates(cfg)
+
+	switch {
+	case initialize:
+		written, err := templateSet.WriteBuiltins()
+		for _, path := range written {
+			cmd.Printf("📝 %s\n", path)
+		}
+		if err != nil {
+			return err
+		}
+		if len(written) == 0 {
+			cmd.Printf("Every template is already overridden in %s\n", templateSet.Dir())
+			return nil
+		}
+		cmd.Printf("\n✅ Copied %d template(s) into %s; edit them to change what 'evidence generate' writes\n", len(written), templateSet.Dir())
+	case len(args) == 1:
+		content, err := templateSet.Get(args[0])
+		if err != nil {
+			return err
+		}
+		cmd.Print(content)
+	default:
+		displayEvidenceTemplates(cmd, templateSet)
+	}
+	return nil
+}
+
+// displayEvidenceTemplates lists the templates and where each is read from
+func displayEvidenceTemplates(cmd *cobra.Command, templateSet *templates.EvidenceTemplates) {
+	cmd.Printf("Templates (overrides in %s):\n\n", templateSet.Dir())
+	for _, name := range templates.EvidenceTemplateNames {
+		if path := templateSet.OverridePath(name); path != "" {
+			cmd.Printf("  %-16s %s\n", name, path)
+		} else {
+			cmd.Printf("  %-16s built-in\n", name)
+		}
+	}
+
+	files := templateSet.Mappings().Files()
+	if len(files) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(files))
+	for key := range files {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	cmd.Printf("\nMapped outlines (evidence.generation.templates):\n\n")
+	for _, key := range keys {
+		cmd.Printf("  %-28s %s\n", key, files[key])
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/templates"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisplayEvidenceTemplates(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "instructions.md"), []byte("# Our instructions\n"), 0644))
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{Use: "test"}
+	cmd.SetOut(&out)
+	displayEvidenceTemplates(cmd, templates.NewEvidenceTemplates(dir))
+
+	assert.Contains(t, out.String(), "Templates (overrides in "+dir+"):")
+	assert.Contains(t, out.String(), "  instructions     "+filepath.Join(dir, "instructions.md")+"\n")
+	assert.Contains(t, out.String(), "  infrastructure   built-in\n")
+	assert.NotContains(t, out.String(), "Mapped outlines")
+
+	out.Reset()
+	displayEvidenceTemplates(cmd, templates.NewEvidenceTemplates(dir).WithMappings(config.EvidenceTemplateMappings{
+		Tasks:      map[string]string{"ET-0047": "/etc/grctool/et-0047.md"},
+		Categories: map[string]string{"Vendor": "/etc/grctool/vendor.md"},
+	}))
+	assert.Contains(t, out.String(), "Mapped outlines (evidence.generation.templates):\n\n"+
+		"  categories.Vendor            /etc/grctool/vendor.md\n"+
+		"  tasks.ET-0047                /etc/grctool/et-0047.md\n")
+}
@@ -0,0 +1,210 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/redaction"
+	"github.com/grctool/grctool/internal/services/validation"
+	"github.com/grctool/grctool/internal/signing"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var evidenceValidateCmd = &cobra.Command{
+	Use:   "validate [task-ref]",
+	Short: "Validate evidence for submission readiness",
+	Long: `Run the submission validation checks (file count, required files, formats,
+sizes and metadata) against the evidence in a collection window.
+
+Results are saved to the window's .validation/validation.yaml, which
+'evidence review' and 'evidence submit' use to decide submission readiness.
+
+The command exits non-zero when any validation fails.
+
+Examples:
+  # Validate a task in the current quarter
+  grctool evidence validate ET-0001
+
+  # Validate all tasks with evidence in a window, with lenient rules
+  grctool evidence validate --all --window 2025-Q4 --mode lenient
+
+  # Write a JUnit report for CI
+  grctool evidence validate --all --format junit -o reports/evidence-validation.xml`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeTaskRefs,
+	RunE:              runEvidenceValidate,
+}
+
+func init() {
+	evidenceCmd.AddCommand(evidenceValidateCmd)
+
+	evidenceValidateCmd.Flags().String("window", "", "collection window (default: current quarter; with --all: every window)")
+	evidenceValidateCmd.Flags().String("mode", string(validation.EvidenceValidationModeStrict), "validation mode (strict, lenient, advisory)")
+	evidenceValidateCmd.Flags().Bool("all", false, "validate all evidence tasks")
+	evidenceValidateCmd.Flags().String("format", reportFormatText, "report format (text, junit)")
+	evidenceValidateCmd.Flags().StringP("output", "o", "", "write the JUnit report to a file instead of stdout")
+}
+
+// validationTarget is a task and window to validate
+type validationTarget struct {
+	taskRef string
+	window  string
+}
+
+func runEvidenceValidate(cmd *cobra.Command, args []string) error {
+	window, _ := cmd.Flags().GetString("window")
+	mode, _ := cmd.Flags().GetString("mode")
+	all, _ := cmd.Flags().GetBool("all")
+	format, _ := cmd.Flags().GetString("format")
+	outputFile, _ := cmd.Flags().GetString("output")
+
+	if err := validateReportFormat(format); err != nil {
+		return err
+	}
+	validationMode := validation.EvidenceValidationMode(mode)
+	switch validationMode {
+	case validation.EvidenceValidationModeStrict, validation.EvidenceValidationModeLenient, validation.EvidenceValidationModeAdvisory:
+	default:
+		return fmt.Errorf("invalid mode %q: must be strict, lenient or advisory", mode)
+	}
+	if !all && len(args) == 0 {
+		return fmt.Errorf("task reference required (or use --all flag)")
+	}
+	if all && len(args) > 0 {
+		return fmt.Errorf("cannot specify task reference with --all flag")
+	}
+
+	scanner, cfg, err := initializeScanner()
+	if err != nil {
+		return err
+	}
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	var targets []validationTarget
+	if all {
+		taskStates, err := scanner.ScanAll(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to scan evidence: %w", err)
+		}
+		targets = validationTargets(taskStates, window)
+	} else {
+		task, err := store.GetEvidenceTask(args[0])
+		if err != nil {
+			return fmt.Errorf("evidence task not found: %s", args[0])
+		}
+		if window == "" {
+			window = getCurrentQuarter()
+		}
+		targets = []validationTarget{{taskRef: task.ReferenceID, window: window}}
+	}
+
+	validator := validation.NewEvidenceValidationService(store)
+	validator.SetSigner(signing.New(cfg.Evidence.Signing))
+	validator.SetScanner(redaction.New(cfg.Submission.Scan))
+	var suites []junitTestSuite
+	failed := 0
+
+	for _, target := range targets {
+		result, err := validator.ValidateEvidence(&validation.EvidenceValidationRequest{
+			TaskRef:        target.taskRef,
+			Window:         target.window,
+			ValidationMode: validationMode,
+		})
+		if err != nil {
+			failed++
+			if format == reportFormatJUnit {
+				suites = append(suites, junitErrorSuite(junitSuiteName(target.taskRef, target.window, ""), err))
+			} else {
+				cmd.Printf("✗ %s / %s: %v\n\n", target.taskRef, target.window, err)
+			}
+			continue
+		}
+
+		if result.Status == "failed" {
+			failed++
+		}
+		if format == reportFormatJUnit {
+			suites = append(suites, validationJUnitSuite(result))
+		} else {
+			displayValidationChecks(cmd, result)
+		}
+	}
+
+	if format == reportFormatJUnit {
+		if err := saveJUnitReport(cmd.OutOrStdout(), outputFile, "grctool evidence validate", suites); err != nil {
+			return fmt.Errorf("failed to write JUnit report: %w", err)
+		}
+		if outputFile != "" {
+			cmd.Printf("✓ JUnit report saved to: %s\n", outputFile)
+		}
+	} else if len(targets) > 1 {
+		cmd.Printf("Validated %d task windows: %d passed, %d failed\n", len(targets), len(targets)-failed, failed)
+	} else if len(targets) == 0 {
+		cmd.Println("No evidence found to validate")
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d evidence validations failed", failed, len(targets))
+	}
+	return nil
+}
+
+// validationTargets lists the task windows found by the scanner, restricted to
+// one window when given, in a stable order
+func validationTargets(taskStates map[string]*models.EvidenceTaskState, window string) []validationTarget {
+	var targets []validationTarget
+	for taskRef, state := range taskStates {
+		for name := range state.Windows {
+			if window == "" || name == window {
+				targets = append(targets, validationTarget{taskRef: taskRef, window: name})
+			}
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].taskRef != targets[j].taskRef {
+			return targets[i].taskRef < targets[j].taskRef
+		}
+		return targets[i].window < targets[j].window
+	})
+	return targets
+}
+
+func displayValidationChecks(cmd *cobra.Command, result *models.ValidationResult) {
+	cmd.Printf("%s %s / %s: %s (%d/%d checks passed)\n", getStatusIcon(result.Status),
+		result.TaskRef, result.Window, strings.ToUpper(result.Status), result.PassedChecks, result.TotalChecks)
+
+	for _, check := range result.Checks {
+		cmd.Printf("  %s %s: %s\n", getStatusIcon(check.Status), check.Name, check.Message)
+	}
+	for _, validationErr := range result.Errors {
+		if validationErr.Suggestion != "" {
+			cmd.Printf("     → %s\n", validationErr.Suggestion)
+		}
+	}
+	if result.ReadyForSubmission {
+		cmd.Println("  Ready for submission")
+	}
+	cmd.Println()
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/services"
+	"github.com/spf13/cobra"
+)
+
+var evidenceVerifyCmd = &cobra.Command{
+	Use:   "verify [task-ref]",
+	Short: "Verify evidence files against their recorded checksums",
+	Long: `Recompute the SHA256 checksum of every evidence file recorded in
+.generation/metadata.yaml and compare it with the checksum taken when the file
+was written, for chain of custody. Files moved to .submitted/ are verified
+there, encrypted files are verified as plaintext, and files no metadata
+records are listed as untracked.
+
+Every window of the task is verified unless --window is given. The command
+exits non-zero when a recorded file was modified, is missing or cannot be
+read.
+
+Examples:
+  # Verify one task
+  grctool evidence verify ET-0001
+
+  # Verify every task in a specific window
+  grctool evidence verify --all --window 2025-Q4
+
+  # Machine-readable report for an audit trail
+  grctool evidence verify --all --output json`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeTaskRefs,
+	RunE:              runEvidenceVerify,
+}
+
+func init() {
+	evidenceCmd.AddCommand(evidenceVerifyCmd)
+
+	evidenceVerifyCmd.Flags().Bool("all", false, "verify every task")
+	evidenceVerifyCmd.Flags().String("window", "", "window to verify (default: every window)")
+}
+
+func runEvidenceVerify(cmd *cobra.Command, args []string) error {
+	all, _ := cmd.Flags().GetBool("all")
+	window, _ := cmd.Flags().GetString("window")
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if all == (len(args) > 0) {
+		return fmt.Errorf("specify a task reference or --all")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	consoleLoggerCfg := cfg.Logging.Loggers["console"]
+	log, err := logger.New((&consoleLoggerCfg).ToLoggerConfig())
+	if err != nil {
+		return fmt.Errorf("failed to initialize logger: %w", err)
+	}
+
+	integrity := services.NewEvidenceIntegrityService(filepath.Join(cfg.Storage.DataDir, "evidence"), log)
+	var results []services.WindowIntegrity
+	if all {
+		results, err = integrity.VerifyAll(context.Background(), window)
+	} else {
+		results, err = integrity.VerifyTask(context.Background(), args[0], window)
+	}
+	if err != nil {
+		return err
+	}
+
+	if isStructuredOutput(format) {
+		if results == nil {
+			results = []services.WindowIntegrity{}
+		}
+		if err := writeStructured(cmd, format, results); err != nil {
+			return err
+		}
+	} else {
+		displayEvidenceIntegrity(cmd, results)
+	}
+
+	compromised := 0
+	for _, result := range results {
+		if result.Compromised() {
+			compromised++
+		}
+	}
+	if compromised > 0 {
+		return fmt.Errorf("evidence integrity check failed for %d window(s)", compromised)
+	}
+	return nil
+}
+
+func displayEvidenceIntegrity(cmd *cobra.Command, results []services.WindowIntegrity) {
+	if len(results) == 0 {
+		cmd.Println("No evidence windows to verify")
+		return
+	}
+
+	icons := map[services.IntegrityStatus]string{
+		services.IntegrityModified:   "✏️ ",
+		services.IntegrityMissing:    "❓",
+		services.IntegrityUnreadable: "🚫",
+		services.IntegrityUnrecorded: "➖",
+		services.IntegrityUntracked:  "➕",
+	}
+
+	for _, result := range results {
+		group := result.TaskRef + " / " + result.Window
+		switch result.Status {
+		case services.WindowCompromised:
+			cmd.Printf("❌ %s: %d modified, %d missing, %d verified\n", group, result.Modified, result.Missing, result.Verified)
+		case services.WindowNoMetadata:
+			cmd.Printf("⚪ %s: no checksums recorded\n", group)
+		default:
+			cmd.Printf("✅ %s: %d file(s) verified\n", group, result.Verified)
+		}
+		for _, file := range result.Files {
+			if file.Status == services.IntegrityVerified {
+				continue
+			}
+			cmd.Printf("   %s %s: %s", icons[file.Status], file.File, file.Status)
+			if file.Message != "" {
+				cmd.Printf(" (%s)", file.Message)
+			}
+			cmd.Println()
+		}
+	}
+}
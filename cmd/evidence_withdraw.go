@@ -0,0 +1,143 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/services/submission"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/grctool/grctool/internal/tugboat"
+	"github.com/spf13/cobra"
+)
+
+var evidenceWithdrawCmd = &cobra.Command{
+	Use:   "withdraw [task-id]",
+	Short: "Withdraw a submission so the window can be reworked and resubmitted",
+	Long: `Withdraw the submission of a task's evidence window, marking it superseded.
+
+The files the submission uploaded are removed from the target first, where
+its API allows: Tugboat attachments and Vanta document uploads are deleted.
+Other targets keep the files, which must be removed there by hand. If the
+target cannot be reached or refuses, nothing changes locally; run the command
+again, or use --local-only to supersede the local record only.
+
+The submitted files then move from .submitted/ back to the window root, and
+the submission metadata and history record the withdrawal and its reason, so
+the evidence can be reworked and submitted again.
+
+Examples:
+  # Withdraw a submission that exported the wrong quarter
+  grctool evidence withdraw ET-0047 --window 2025-Q4 --reason "Exported Q3 access review"
+
+  # Supersede the local record without touching the target
+  grctool evidence withdraw ET-0047 --window 2025-Q4 --reason "Removed in Tugboat by hand" --local-only`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTaskRefs,
+	RunE:              runEvidenceWithdraw,
+}
+
+func init() {
+	evidenceCmd.AddCommand(evidenceWithdrawCmd)
+
+	evidenceWithdrawCmd.Flags().String("window", "", "evidence collection window (e.g., 2025-Q4)")
+	evidenceWithdrawCmd.Flags().String("reason", "", "why the submission is withdrawn, recorded in its history")
+	evidenceWithdrawCmd.Flags().Bool("local-only", false, "supersede the local record without removing files from the target")
+	evidenceWithdrawCmd.MarkFlagRequired("window")
+	evidenceWithdrawCmd.MarkFlagRequired("reason")
+}
+
+func runEvidenceWithdraw(cmd *cobra.Command, args []string) error {
+	taskRef := args[0]
+	window, _ := cmd.Flags().GetString("window")
+	reason, _ := cmd.Flags().GetString("reason")
+	localOnly, _ := cmd.Flags().GetBool("local-only")
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	// Tugboat attachments are deleted through its REST API, which the
+	// registry provider does not expose, so the direct client is added too
+	svc := newSubmissionService(cfg, store, "", false)
+	if len(cfg.Tugboat.CollectorURLs) > 0 {
+		svc.AddTarget(submission.NewTugboatTarget(tugboat.NewClient(&cfg.Tugboat, nil), cfg.Tugboat.CollectorURLs))
+	}
+
+	withdrawn, err := svc.Withdraw(context.Background(), &submission.WithdrawRequest{
+		TaskRef:     taskRef,
+		Window:      window,
+		Reason:      strings.TrimSpace(reason),
+		WithdrawnBy: "grctool-cli",
+		LocalOnly:   localOnly,
+	})
+	if err != nil {
+		if errors.Is(err, submission.ErrRemoteWithdrawal) {
+			return fmt.Errorf("%w\nNothing was changed locally; run again, or use --local-only once the files are removed by hand", err)
+		}
+		return err
+	}
+
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, withdrawn)
+	}
+	displayWithdrawal(cmd, withdrawn)
+	return nil
+}
+
+// displayWithdrawal reports a withdrawn submission and what happened on its
+// target
+func displayWithdrawal(cmd *cobra.Command, withdrawn *models.EvidenceSubmission) {
+	withdrawal := withdrawn.Withdrawal
+	cmd.Printf("↩️  Withdrew submission %s for %s/%s\n", withdrawn.SubmissionID, withdrawn.TaskRef, withdrawn.Window)
+	cmd.Printf("Status: %s\n", withdrawn.Status)
+	cmd.Printf("Reason: %s\n", withdrawal.Reason)
+
+	switch withdrawal.Remote {
+	case submission.WithdrawnRemotely:
+		cmd.Printf("🗑️  Removed %d file(s) from %s\n", len(withdrawal.RemovedFiles), withdrawal.Target)
+		for _, filename := range withdrawal.RemovedFiles {
+			cmd.Printf("  - %s\n", filename)
+		}
+	case submission.WithdrawUnsupported:
+		cmd.Printf("⚠️  %s cannot remove submitted files through grctool: remove them there by hand\n", withdrawal.Target)
+	default:
+		if withdrawal.Target != "" {
+			cmd.Printf("⚠️  Files uploaded to %s were left in place (--local-only)\n", withdrawal.Target)
+		}
+	}
+
+	cmd.Println("✅ Files moved from .submitted/ back to the window root")
+	cmd.Println()
+	cmd.Println("Next steps:")
+	cmd.Println("  1. Rework the evidence")
+	cmd.Printf("  2. Run: grctool evidence submit %s --window %s\n", withdrawn.TaskRef, withdrawn.Window)
+}
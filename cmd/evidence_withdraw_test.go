@@ -0,0 +1,58 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/services/submission"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplayWithdrawal(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		withdrawal models.SubmissionWithdrawal
+		contains   []string
+	}{
+		"removed from target": {
+			withdrawal: models.SubmissionWithdrawal{Reason: "Wrong quarter", Target: "Tugboat", Remote: submission.WithdrawnRemotely,
+				RemovedFiles: []string{"access.csv", "summary.md"}},
+			contains: []string{"🗑️  Removed 2 file(s) from Tugboat\n  - access.csv\n  - summary.md\n"},
+		},
+		"unsupported target": {
+			withdrawal: models.SubmissionWithdrawal{Reason: "Wrong quarter", Target: "Drata", Remote: submission.WithdrawUnsupported},
+			contains:   []string{"⚠️  Drata cannot remove submitted files through grctool: remove them there by hand"},
+		},
+		"local only": {
+			withdrawal: models.SubmissionWithdrawal{Reason: "Wrong quarter", Target: "Vanta", Remote: submission.WithdrawSkipped},
+			contains:   []string{"⚠️  Files uploaded to Vanta were left in place (--local-only)"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&buf)
+
+			withdrawal := tc.withdrawal
+			displayWithdrawal(cmd, &models.EvidenceSubmission{
+				TaskRef: "ET-0047", Window: "2025-Q4", SubmissionID: "batch-1760000000-files-2",
+				Status: "superseded", Withdrawal: &withdrawal,
+			})
+			output := buf.String()
+			assert.Contains(t, output, "↩️  Withdrew submission batch-1760000000-files-2 for ET-0047/2025-Q4\nStatus: superseded\nReason: Wrong quarter\n")
+			assert.Contains(t, output, "  2. Run: grctool evidence submit ET-0047 --window 2025-Q4")
+			for _, want := range tc.contains {
+				assert.Contains(t, output, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/services/export"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+// exportCmd groups program-wide exports of the synced compliance data
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export compliance data for reviewers and external tooling",
+	Long: `Export synced evidence tasks, controls, policies and their relationships
+into formats used outside grctool.
+
+To export the evidence documents of a single task, use 'grctool evidence export'.`,
+}
+
+var exportXLSXCmd = &cobra.Command{
+	Use:   "xlsx",
+	Short: "Export an Excel workbook of tasks, controls, policies and mappings",
+	Long: `Export a multi-sheet .xlsx workbook from local storage:
+
+  Evidence Tasks       - task inventory with owners and due dates
+  Controls             - controls with the number of supporting evidence tasks
+  Policies             - policies and the controls they cover
+  Task-Control Matrix  - one row per task, one column per control
+  Submission Status    - local evidence state per task and collection window
+
+Run 'grctool sync' first so the workbook reflects current data.
+
+Examples:
+  grctool export xlsx
+  grctool export xlsx --output reports/soc2-2025.xlsx`,
+	RunE: runExportXLSX,
+}
+
+var exportOSCALCmd = &cobra.Command{
+	Use:   "oscal",
+	Short: "Export controls and evidence mappings as an OSCAL component definition",
+	Long: `Export an OSCAL (NIST Open Security Controls Assessment Language) component
+definition in JSON. Each control becomes an implemented requirement carrying its
+implementation statement, grouped into one control implementation per framework.
+Evidence tasks and policies are emitted as back-matter resources and linked
+from the controls they support.
+
+Control implementations reference a grctool URN as their source unless
+--source names the catalog or profile the controls come from.
+
+Examples:
+  grctool export oscal
+  grctool export oscal --output oscal/component-definition.json
+  grctool export oscal --source https://example.com/profiles/fedramp-moderate.json`,
+	RunE: runExportOSCAL,
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.AddCommand(exportXLSXCmd)
+	exportCmd.AddCommand(exportOSCALCmd)
+
+	exportXLSXCmd.Flags().StringP("output", "o", "grctool-export.xlsx", "output workbook path")
+	exportXLSXCmd.Flags().Bool("skip-status", false, "skip scanning evidence directories for the submission status sheet")
+
+	exportOSCALCmd.Flags().StringP("output", "o", "grctool-oscal.json", "output file path")
+	exportOSCALCmd.Flags().String("title", "Compliance Program", "title of the component definition")
+	exportOSCALCmd.Flags().String("document-version", "1.0.0", "document version recorded in the OSCAL metadata")
+	exportOSCALCmd.Flags().String("source", "", "catalog or profile href for the control implementations")
+	exportOSCALCmd.Flags().Bool("skip-status", false, "skip scanning evidence directories for local evidence state")
+}
+
+func runExportXLSX(cmd *cobra.Command, args []string) error {
+	outputFile, _ := cmd.Flags().GetString("output")
+	skipStatus, _ := cmd.Flags().GetBool("skip-status")
+
+	data, err := loadComplianceData(skipStatus)
+	if err != nil {
+		return err
+	}
+
+	if err := writeWorkbookFile(outputFile, export.BuildComplianceWorkbook(data)); err != nil {
+		return err
+	}
+
+	cmd.Printf("✅ Exported %d tasks, %d controls, %d policies to: %s\n",
+		len(data.Tasks), len(data.Controls), len(data.Policies), outputFile)
+	return nil
+}
+
+func runExportOSCAL(cmd *cobra.Command, args []string) error {
+	outputFile, _ := cmd.Flags().GetString("output")
+	title, _ := cmd.Flags().GetString("title")
+	documentVersion, _ := cmd.Flags().GetString("document-version")
+	source, _ := cmd.Flags().GetString("source")
+	skipStatus, _ := cmd.Flags().GetBool("skip-status")
+
+	data, err := loadComplianceData(skipStatus)
+	if err != nil {
+		return err
+	}
+
+	doc := export.BuildOSCALComponentDefinition(data, export.OSCALOptions{
+		Title:   title,
+		Version: documentVersion,
+		Source:  source,
+	})
+	content, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal OSCAL document: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outputFile), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(outputFile, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+
+	cmd.Printf("✅ Exported %d controls with %d evidence tasks and %d policies to: %s\n",
+		len(data.Controls), len(data.Tasks), len(data.Policies), outputFile)
+	return nil
+}
+
+// loadComplianceData reads tasks, controls and policies from local storage and,
+// unless skipStatus is set, scans evidence directories for local evidence state
+func loadComplianceData(skipStatus bool) (*export.ComplianceData, error) {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Initialize storage
+	storage, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	var states []*models.EvidenceTaskState
+	if !skipStatus {
+		scanner, _, err := initializeScanner()
+		if err != nil {
+			return nil, err
+		}
+		taskStates, err := scanner.ScanAll(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		for _, state := range taskStates {
+			states = append(states, state)
+		}
+	}
+
+	return export.LoadComplianceData(storage, states)
+}
+
+// writeWorkbookFile writes the workbook, creating the parent directory if needed
+func writeWorkbookFile(path string, wb *export.Workbook) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	if err := wb.Write(file); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write workbook: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write workbook: %w", err)
+	}
+	return nil
+}
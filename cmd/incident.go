@@ -0,0 +1,360 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/tools"
+	"github.com/spf13/cobra"
+)
+
+// incidentTimeLayouts are the layouts accepted by incident time flags. Times
+// without a zone are local.
+var incidentTimeLayouts = []string{"2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02"}
+
+var incidentCmd = &cobra.Command{
+	Use:   "incident",
+	Short: "Maintain the incident log",
+	Long: `Maintain a log of security and availability incidents in local storage.
+Each incident records its severity (low, medium, high or critical) and a
+response timeline: when it was detected, acknowledged and resolved, from which
+the time to acknowledge and time to resolve are derived. Closing an incident
+records its resolution time, root cause and resolution.
+
+The log is kept as JSON files under docs/incidents in the data directory;
+export the incidents in an evidence window with 'grctool tool incident-log'.
+
+Times are given as "YYYY-MM-DD HH:MM" in local time, as RFC 3339, or as a
+date alone for midnight.
+
+Examples:
+  # Log an incident
+  grctool incident add --title "API outage" --severity high \
+    --detected "2025-09-03 10:02" --acknowledged "2025-09-03 10:09"
+
+  # Open incidents
+  grctool incident list --status open
+
+  # Close an incident
+  grctool incident close INC-0001 --resolved "2025-09-03 13:40" \
+    --root-cause "Expired TLS certificate" --resolution "Renewed and automated renewal"`,
+}
+
+var incidentAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Log an incident",
+	Args:  cobra.NoArgs,
+	RunE:  runIncidentAdd,
+}
+
+var incidentListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List incidents, most recently detected first",
+	Args:  cobra.NoArgs,
+	RunE:  runIncidentList,
+}
+
+var incidentCloseCmd = &cobra.Command{
+	Use:   "close <incident-id>",
+	Short: "Close an incident with its resolution",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runIncidentClose,
+}
+
+func init() {
+	rootCmd.AddCommand(incidentCmd)
+	incidentCmd.AddCommand(incidentAddCmd)
+	incidentCmd.AddCommand(incidentListCmd)
+	incidentCmd.AddCommand(incidentCloseCmd)
+
+	addIncidentFieldFlags(incidentAddCmd)
+	_ = incidentAddCmd.MarkFlagRequired("title")
+
+	incidentListCmd.Flags().String("status", "", "only incidents with this status (open or closed)")
+	incidentListCmd.Flags().String("severity", "", "only incidents with this severity")
+	incidentListCmd.Flags().String("window", "", "only incidents detected in this evidence window, e.g. 2025-Q3")
+
+	addIncidentCloseFlags(incidentCloseCmd)
+}
+
+// incidentInfo is an incident as shown by the incident commands, with its response times
+type incidentInfo struct {
+	domain.Incident
+	MinutesToAcknowledge *int `json:"minutes_to_acknowledge,omitempty"`
+	MinutesToResolve     *int `json:"minutes_to_resolve,omitempty"`
+}
+
+func newIncidentInfo(incident domain.Incident) incidentInfo {
+	info := incidentInfo{Incident: incident}
+	if d, ok := incident.TimeToAcknowledge(); ok {
+		minutes := int(d.Minutes())
+		info.MinutesToAcknowledge = &minutes
+	}
+	if d, ok := incident.TimeToResolve(); ok {
+		minutes := int(d.Minutes())
+		info.MinutesToResolve = &minutes
+	}
+	return info
+}
+
+// addIncidentFieldFlags adds the flags describing a new incident
+func addIncidentFieldFlags(c *cobra.Command) {
+	c.Flags().String("title", "", "short description of the incident")
+	c.Flags().String("description", "", "what happened and its impact")
+	c.Flags().String("category", "", "incident category, e.g. security, availability, data")
+	c.Flags().String("severity", domain.IncidentSeverityMedium, "severity: low, medium, high or critical")
+	c.Flags().String("owner", "", "incident lead")
+	c.Flags().String("detected", "", "when the incident was detected (default: now)")
+	c.Flags().String("acknowledged", "", "when the incident was acknowledged")
+}
+
+// addIncidentCloseFlags adds the flags recording an incident's resolution
+func addIncidentCloseFlags(c *cobra.Command) {
+	c.Flags().String("resolved", "", "when the incident was resolved (default: now)")
+	c.Flags().String("acknowledged", "", "when the incident was acknowledged, if not already recorded")
+	c.Flags().String("root-cause", "", "root cause of the incident")
+	c.Flags().String("resolution", "", "how the incident was resolved")
+}
+
+func runIncidentAdd(cmd *cobra.Command, args []string) error {
+	incident := &domain.Incident{Status: domain.IncidentStatusOpen}
+	if err := applyIncidentFlags(cmd, incident, time.Now().UTC()); err != nil {
+		return err
+	}
+
+	store, err := openLocalStorage()
+	if err != nil {
+		return err
+	}
+	if incident.ID, err = store.NextIncidentID(); err != nil {
+		return fmt.Errorf("failed to allocate incident ID: %w", err)
+	}
+	incident.CreatedAt = time.Now().UTC()
+	incident.UpdatedAt = incident.CreatedAt
+	if err := store.SaveIncident(incident); err != nil {
+		return fmt.Errorf("failed to save incident: %w", err)
+	}
+
+	return showIncident(cmd, incident, "🚨 Logged")
+}
+
+func runIncidentList(cmd *cobra.Command, args []string) error {
+	status, _ := cmd.Flags().GetString("status")
+	severity, _ := cmd.Flags().GetString("severity")
+	window, _ := cmd.Flags().GetString("window")
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	store, err := openLocalStorage()
+	if err != nil {
+		return err
+	}
+	all, err := store.GetAllIncidents()
+	if err != nil {
+		return fmt.Errorf("failed to load incidents: %w", err)
+	}
+
+	incidents, err := filterIncidentList(all, status, severity, window)
+	if err != nil {
+		return err
+	}
+
+	if isStructuredOutput(format) {
+		infos := make([]incidentInfo, 0, len(incidents))
+		for _, incident := range incidents {
+			infos = append(infos, newIncidentInfo(incident))
+		}
+		return writeStructured(cmd, format, infos)
+	}
+
+	if len(incidents) == 0 {
+		cmd.Println("No incidents found. Log one with: grctool incident add --title <title>")
+		return nil
+	}
+
+	cmd.Printf("%-9s %-8s %-6s %-16s %-8s %-8s %s\n", "ID", "SEVERITY", "STATUS", "DETECTED", "ACK", "RESOLVE", "TITLE")
+	for _, incident := range incidents {
+		ack, resolve := "-", "-"
+		if d, ok := incident.TimeToAcknowledge(); ok {
+			ack = tools.FormatResponseTime(d)
+		}
+		if d, ok := incident.TimeToResolve(); ok {
+			resolve = tools.FormatResponseTime(d)
+		}
+		cmd.Printf("%-9s %-8s %-6s %-16s %-8s %-8s %s\n", incident.ID, incident.Severity, incident.Status,
+			incident.DetectedAt.Local().Format("2006-01-02 15:04"), ack, resolve, incident.Title)
+	}
+	cmd.Printf("\n%d incidents\n", len(incidents))
+	return nil
+}
+
+func runIncidentClose(cmd *cobra.Command, args []string) error {
+	store, err := openLocalStorage()
+	if err != nil {
+		return err
+	}
+	incident, err := store.GetIncident(args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := closeIncident(cmd, incident, time.Now().UTC()); err != nil {
+		return err
+	}
+	incident.UpdatedAt = time.Now().UTC()
+	if err := store.SaveIncident(incident); err != nil {
+		return fmt.Errorf("failed to save incident: %w", err)
+	}
+
+	return showIncident(cmd, incident, "✅ Closed")
+}
+
+// applyIncidentFlags copies the add flags onto incident, detected now unless
+// --detected is given
+func applyIncidentFlags(cmd *cobra.Command, incident *domain.Incident, now time.Time) error {
+	flags := cmd.Flags()
+	incident.Title, _ = flags.GetString("title")
+	incident.Description, _ = flags.GetString("description")
+	incident.Category, _ = flags.GetString("category")
+	incident.Severity, _ = flags.GetString("severity")
+	incident.Owner, _ = flags.GetString("owner")
+
+	detected, err := parseTimeFlag(cmd, "detected")
+	if err != nil {
+		return err
+	}
+	if detected == nil {
+		detected = &now
+	}
+	incident.DetectedAt = *detected
+
+	if incident.AcknowledgedAt, err = parseTimeFlag(cmd, "acknowledged"); err != nil {
+		return err
+	}
+	return incident.Validate()
+}
+
+// closeIncident records the incident's resolution, resolved now unless
+// --resolved is given
+func closeIncident(cmd *cobra.Command, incident *domain.Incident, now time.Time) error {
+	if incident.Status == domain.IncidentStatusClosed {
+		return fmt.Errorf("incident %s is already closed", incident.ID)
+	}
+
+	resolved, err := parseTimeFlag(cmd, "resolved")
+	if err != nil {
+		return err
+	}
+	if resolved == nil {
+		resolved = &now
+	}
+	acknowledged, err := parseTimeFlag(cmd, "acknowledged")
+	if err != nil {
+		return err
+	}
+	if acknowledged != nil {
+		incident.AcknowledgedAt = acknowledged
+	}
+
+	incident.Status = domain.IncidentStatusClosed
+	incident.ResolvedAt = resolved
+	if cmd.Flags().Changed("root-cause") {
+		incident.RootCause, _ = cmd.Flags().GetString("root-cause")
+	}
+	if cmd.Flags().Changed("resolution") {
+		incident.Resolution, _ = cmd.Flags().GetString("resolution")
+	}
+	return incident.Validate()
+}
+
+// parseTimeFlag parses an incident time flag in one of incidentTimeLayouts or
+// RFC 3339, returning nil when it is empty
+func parseTimeFlag(cmd *cobra.Command, name string) (*time.Time, error) {
+	value, _ := cmd.Flags().GetString(name)
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		t = t.UTC()
+		return &t, nil
+	}
+	for _, layout := range incidentTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			t = t.UTC()
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid --%s time %q: use \"YYYY-MM-DD HH:MM\" or RFC 3339", name, value)
+}
+
+// filterIncidentList keeps incidents matching the filters, most recently detected first
+func filterIncidentList(incidents []domain.Incident, status, severity, window string) ([]domain.Incident, error) {
+	var start, end time.Time
+	if window != "" {
+		var err error
+		if start, end, err = tools.ParseEvidenceWindow(window); err != nil {
+			return nil, err
+		}
+	}
+
+	filtered := []domain.Incident{}
+	for _, incident := range incidents {
+		if status != "" && !strings.EqualFold(incident.Status, status) {
+			continue
+		}
+		if severity != "" && !strings.EqualFold(incident.Severity, severity) {
+			continue
+		}
+		if window != "" && (incident.DetectedAt.Before(start) || !incident.DetectedAt.Before(end)) {
+			continue
+		}
+		filtered = append(filtered, incident)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool { return filtered[i].DetectedAt.After(filtered[j].DetectedAt) })
+	return filtered, nil
+}
+
+func showIncident(cmd *cobra.Command, incident *domain.Incident, verb string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, newIncidentInfo(*incident))
+	}
+
+	cmd.Printf("%s %s: %s\n", verb, incident.ID, incident.Title)
+	cmd.Printf("  Severity:     %s, status %s\n", incident.Severity, incident.Status)
+	cmd.Printf("  Detected:     %s\n", incident.DetectedAt.Local().Format("2006-01-02 15:04"))
+	if d, ok := incident.TimeToAcknowledge(); ok {
+		cmd.Printf("  Acknowledged: %s (after %s)\n", incident.AcknowledgedAt.Local().Format("2006-01-02 15:04"), tools.FormatResponseTime(d))
+	}
+	if d, ok := incident.TimeToResolve(); ok {
+		cmd.Printf("  Resolved:     %s (after %s)\n", incident.ResolvedAt.Local().Format("2006-01-02 15:04"), tools.FormatResponseTime(d))
+	}
+	if incident.RootCause != "" {
+		cmd.Printf("  Root cause:   %s\n", incident.RootCause)
+	}
+	return nil
+}
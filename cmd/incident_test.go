@@ -0,0 +1,112 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newIncidentFlagsCmd returns a command with the add or close flags of the incident commands
+func newIncidentFlagsCmd(closing bool, args ...string) *cobra.Command {
+	cmd := &cobra.Command{Use: "incident"}
+	if closing {
+		addIncidentCloseFlags(cmd)
+	} else {
+		addIncidentFieldFlags(cmd)
+	}
+	_ = cmd.Flags().Parse(args)
+	return cmd
+}
+
+func TestApplyIncidentFlags(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 9, 3, 12, 0, 0, 0, time.UTC)
+
+	incident := &domain.Incident{Status: domain.IncidentStatusOpen}
+	require.NoError(t, applyIncidentFlags(newIncidentFlagsCmd(false, "--title", "API outage"), incident, now))
+	assert.Equal(t, now, incident.DetectedAt, "detected now by default")
+	assert.Equal(t, domain.IncidentSeverityMedium, incident.Severity)
+	assert.Nil(t, incident.AcknowledgedAt)
+
+	incident = &domain.Incident{Status: domain.IncidentStatusOpen}
+	require.NoError(t, applyIncidentFlags(newIncidentFlagsCmd(false, "--title", "API outage", "--severity", "high",
+		"--detected", "2025-09-03T10:02:00Z", "--acknowledged", "2025-09-03T10:09:00Z"), incident, now))
+	ack, ok := incident.TimeToAcknowledge()
+	require.True(t, ok)
+	assert.Equal(t, 7*time.Minute, ack)
+
+	err := applyIncidentFlags(newIncidentFlagsCmd(false, "--title", "API outage",
+		"--detected", "2025-09-03T10:02:00Z", "--acknowledged", "2025-09-03T09:00:00Z"), incident, now)
+	assert.ErrorContains(t, err, "acknowledged before it was detected")
+
+	err = applyIncidentFlags(newIncidentFlagsCmd(false, "--title", "API outage", "--detected", "yesterday"), incident, now)
+	assert.ErrorContains(t, err, `invalid --detected time "yesterday"`)
+}
+
+func TestParseTimeFlag_Layouts(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"2025-09-03 10:02", "2025-09-03T10:02", "2025-09-03T10:02:00Z", "2025-09-03"} {
+		cmd := newIncidentFlagsCmd(false, "--detected", value)
+		parsed, err := parseTimeFlag(cmd, "detected")
+		require.NoError(t, err, value)
+		require.NotNil(t, parsed, value)
+		assert.Equal(t, time.UTC, parsed.Location(), value)
+	}
+}
+
+func TestCloseIncident(t *testing.T) {
+	t.Parallel()
+
+	detected := time.Date(2025, 9, 3, 10, 0, 0, 0, time.UTC)
+	now := detected.Add(3 * time.Hour)
+
+	incident := &domain.Incident{ID: "INC-0001", Title: "API outage", Severity: domain.IncidentSeverityHigh,
+		Status: domain.IncidentStatusOpen, DetectedAt: detected}
+	require.NoError(t, closeIncident(newIncidentFlagsCmd(true, "--root-cause", "Expired certificate",
+		"--acknowledged", "2025-09-03T10:05:00Z"), incident, now))
+	assert.Equal(t, domain.IncidentStatusClosed, incident.Status)
+	assert.Equal(t, now, *incident.ResolvedAt, "resolved now by default")
+	assert.Equal(t, "Expired certificate", incident.RootCause)
+	require.NotNil(t, incident.AcknowledgedAt)
+
+	err := closeIncident(newIncidentFlagsCmd(true), incident, now)
+	assert.ErrorContains(t, err, "already closed")
+}
+
+func TestFilterIncidentList(t *testing.T) {
+	t.Parallel()
+
+	incidents := []domain.Incident{
+		{ID: "INC-0001", Severity: domain.IncidentSeverityHigh, Status: domain.IncidentStatusClosed,
+			DetectedAt: time.Date(2025, 6, 30, 23, 0, 0, 0, time.UTC)},
+		{ID: "INC-0002", Severity: domain.IncidentSeverityLow, Status: domain.IncidentStatusOpen,
+			DetectedAt: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: "INC-0003", Severity: domain.IncidentSeverityHigh, Status: domain.IncidentStatusOpen,
+			DetectedAt: time.Date(2025, 8, 15, 0, 0, 0, 0, time.UTC)},
+	}
+	ids := func(incidents []domain.Incident, err error) []string {
+		require.NoError(t, err)
+		var out []string
+		for _, incident := range incidents {
+			out = append(out, incident.ID)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"INC-0003", "INC-0002", "INC-0001"}, ids(filterIncidentList(incidents, "", "", "")))
+	assert.Equal(t, []string{"INC-0003", "INC-0002"}, ids(filterIncidentList(incidents, "OPEN", "", "")))
+	assert.Equal(t, []string{"INC-0003", "INC-0001"}, ids(filterIncidentList(incidents, "", "high", "")))
+	assert.Equal(t, []string{"INC-0003", "INC-0002"}, ids(filterIncidentList(incidents, "", "", "2025-Q3")))
+
+	_, err := filterIncidentList(incidents, "", "", "Q3")
+	assert.ErrorContains(t, err, "invalid evidence window")
+}
@@ -0,0 +1,509 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// defaultTugboatBaseURL is offered when the wizard asks for the Tugboat URL
+const defaultTugboatBaseURL = "https://api-my.tugboatlogic.com"
+
+// defaultPasswordEnv names the variable the collector password is read from
+const defaultPasswordEnv = "TUGBOAT_PASSWORD"
+
+// terraformDirs are the directories checked for Terraform to suggest scan paths
+var terraformDirs = []string{"terraform", "infra", "infrastructure", "deploy", "iac"}
+
+// githubRemotePattern extracts owner/name from an https or ssh GitHub remote
+var githubRemotePattern = regexp.MustCompile(`github\.com[:/]([^/]+)/([^/]+?)(?:\.git)?/?$`)
+
+// envVarPattern is a valid environment variable name
+var envVarPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// collectorURL is one evidence task's collector URL, as entered in the wizard
+type collectorURL struct {
+	TaskRef string
+	URL     string
+}
+
+// initAnswers holds what the init wizard asked for
+type initAnswers struct {
+	BaseURL       string
+	OrgID         string
+	DataDir       string
+	ScanPaths     []string
+	GitHubRepo    string
+	Username      string
+	PasswordEnv   string
+	CollectorURLs []collectorURL
+	Login         bool
+	Sync          bool
+}
+
+// prompter asks questions on a reader, falling back to each question's
+// default once the input runs out
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+	eof bool
+}
+
+func newPrompter(in io.Reader, out io.Writer) *prompter {
+	return &prompter{in: bufio.NewReader(in), out: out}
+}
+
+// ask returns the answer to a question, or def for an empty answer
+func (p *prompter) ask(question, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", question)
+	}
+	if p.eof {
+		fmt.Fprintln(p.out)
+		return def, nil
+	}
+
+	line, err := p.in.ReadString('\n')
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("failed to read input: %w", err)
+		}
+		p.eof = true
+		fmt.Fprintln(p.out)
+	}
+	if answer := strings.TrimSpace(line); answer != "" {
+		return answer, nil
+	}
+	return def, nil
+}
+
+// confirm asks a yes/no question
+func (p *prompter) confirm(question string, def bool) (bool, error) {
+	choices := "y/N"
+	if def {
+		choices = "Y/n"
+	}
+	for {
+		answer, err := p.ask(fmt.Sprintf("%s [%s]", question, choices), "")
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		fmt.Fprintln(p.out, "   Please answer y or n")
+	}
+}
+
+// askValid repeats a question until check accepts the answer. Once the input
+// runs out a rejected answer gives the default, so the wizard cannot loop.
+func (p *prompter) askValid(question, def string, check func(string) error) (string, error) {
+	for {
+		answer, err := p.ask(question, def)
+		if err != nil {
+			return "", err
+		}
+		if err := check(answer); err != nil {
+			fmt.Fprintf(p.out, "   ❌ %v\n", err)
+			if p.eof {
+				return def, nil
+			}
+			continue
+		}
+		return answer, nil
+	}
+}
+
+// isTerminalInput reports whether the command reads from a terminal, where a
+// user can answer prompts
+func isTerminalInput(cmd *cobra.Command) bool {
+	f, ok := cmd.InOrStdin().(*os.File)
+	return ok && isatty.IsTerminal(f.Fd())
+}
+
+// writeWizardConfig runs the wizard and writes its configuration to path,
+// which becomes the config the rest of the command uses
+func writeWizardConfig(cmd *cobra.Command, path string) (*initAnswers, error) {
+	answers, err := runInitWizard(newPrompter(cmd.InOrStdin(), cmd.OutOrStdout()), ".")
+	if err != nil {
+		return nil, err
+	}
+	data, err := renderInitConfig(answers)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	cfgFile = path
+	viper.SetConfigFile(path)
+	if err := viper.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read the new config file: %w", err)
+	}
+	cmd.Println()
+	return answers, nil
+}
+
+// runWizardFollowUp logs in and syncs as the wizard was asked to, then lists
+// what is left to do. Failures are reported without failing init, since the
+// configuration is already written.
+func runWizardFollowUp(cmd *cobra.Command, answers *initAnswers) {
+	loggedIn := false
+	if answers.Login {
+		cmd.Println()
+		if err := runLogin(cmd, nil); err != nil {
+			cmd.Printf("⚠️  Login failed: %v\n", err)
+		} else {
+			loggedIn = true
+		}
+	}
+
+	synced := false
+	if answers.Sync {
+		cmd.Println("\n🔄 Running initial sync...")
+		syncCmd.SetOut(cmd.OutOrStdout())
+		syncCmd.SetErr(cmd.ErrOrStderr())
+		if err := runSync(syncCmd, nil); err != nil {
+			cmd.Printf("⚠️  Initial sync failed: %v\n", err)
+		} else {
+			synced = true
+		}
+	}
+
+	var steps []string
+	if !loggedIn {
+		steps = append(steps, "Run 'grctool auth login' to authenticate with Tugboat Logic")
+	}
+	if !synced {
+		steps = append(steps, "Run 'grctool sync' to download policies, controls and evidence tasks")
+	}
+	if len(answers.CollectorURLs) == 0 {
+		steps = append(steps, "Run 'grctool evidence setup <task>' to add collector URLs for evidence submission")
+	}
+	steps = append(steps, "Run 'grctool config validate' to verify your configuration")
+
+	cmd.Println("\nNext steps:")
+	for i, step := range steps {
+		cmd.Printf("%d. %s\n", i+1, step)
+	}
+}
+
+// runInitWizard asks for the settings of a new configuration. Defaults are
+// detected from the directory it runs in.
+func runInitWizard(p *prompter, dir string) (*initAnswers, error) {
+	answers := &initAnswers{}
+	var err error
+
+	fmt.Fprintln(p.out, "🧭 GRCTool setup: press Enter to accept the value in brackets")
+
+	fmt.Fprintln(p.out, "\n🔐 Tugboat Logic")
+	if answers.BaseURL, err = p.askValid("Tugboat URL", defaultTugboatBaseURL, validateBaseURL); err != nil {
+		return nil, err
+	}
+	if answers.OrgID, err = p.askValid("Organization ID from the URL /org/<id>/policies (blank to detect at login)", "", validateOrgID); err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintln(p.out, "\n📁 Storage")
+	if answers.DataDir, err = p.ask("Data directory for synced documents and evidence", "./"); err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintln(p.out, "\n🏗️  Terraform")
+	scanPaths, err := p.ask("Terraform scan paths, comma-separated (blank to skip)", strings.Join(detectTerraformPaths(dir), ","))
+	if err != nil {
+		return nil, err
+	}
+	answers.ScanPaths = splitList(scanPaths)
+
+	fmt.Fprintln(p.out, "\n🐙 GitHub")
+	if answers.GitHubRepo, err = p.askValid("GitHub repository, owner/name (blank to skip)", detectGitHubRepo(dir), validateRepository); err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintln(p.out, "\n📤 Evidence submission")
+	submit, err := p.confirm("Set up collector URLs for submitting evidence?", false)
+	if err != nil {
+		return nil, err
+	}
+	if submit {
+		if err := askCollectorSettings(p, answers); err != nil {
+			return nil, err
+		}
+	}
+
+	fmt.Fprintln(p.out)
+	if runtime.GOOS == "darwin" {
+		if answers.Login, err = p.confirm("Log in to Tugboat now (opens Safari)?", true); err != nil {
+			return nil, err
+		}
+	}
+	if answers.Login || runtime.GOOS != "darwin" {
+		if answers.Sync, err = p.confirm("Run an initial sync?", answers.Login); err != nil {
+			return nil, err
+		}
+	}
+
+	return answers, nil
+}
+
+// askCollectorSettings asks for the Custom Evidence Integration credentials
+// and a collector URL per evidence task
+func askCollectorSettings(p *prompter, answers *initAnswers) error {
+	var err error
+	if answers.Username, err = p.ask("Custom Evidence Integration username", ""); err != nil {
+		return err
+	}
+	if answers.PasswordEnv, err = p.askValid("Environment variable holding its password", defaultPasswordEnv, validateEnvVar); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(p.out, "   Find each collector URL in Tugboat under the evidence task's Custom Evidence Integration")
+	seen := map[string]bool{}
+	for !p.eof {
+		ref, err := p.ask("Evidence task (e.g. ET-0001, blank to finish)", "")
+		if err != nil {
+			return err
+		}
+		if ref == "" {
+			break
+		}
+		ref = normalizeTaskRef(strings.ToUpper(ref))
+		if seen[ref] {
+			fmt.Fprintf(p.out, "   ❌ %s already has a collector URL\n", ref)
+			continue
+		}
+
+		collector, err := p.askValid(fmt.Sprintf("Collector URL for %s (blank to skip)", ref), "", func(value string) error {
+			if value == "" {
+				return nil
+			}
+			return validateCollectorURL(value)
+		})
+		if err != nil {
+			return err
+		}
+		if collector == "" {
+			continue
+		}
+		seen[ref] = true
+		answers.CollectorURLs = append(answers.CollectorURLs, collectorURL{TaskRef: ref, URL: collector})
+	}
+
+	fmt.Fprintf(p.out, "   💡 Submission also needs TUGBOAT_API_KEY and %s set in the environment\n", answers.PasswordEnv)
+	return nil
+}
+
+func validateBaseURL(value string) error {
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return fmt.Errorf("%q is not an http(s) URL", value)
+	}
+	return nil
+}
+
+func validateOrgID(value string) error {
+	if value == "" {
+		return nil
+	}
+	if _, err := strconv.Atoi(value); err != nil {
+		return fmt.Errorf("organization ID must be a number, got %q", value)
+	}
+	return nil
+}
+
+func validateRepository(value string) error {
+	if value == "" {
+		return nil
+	}
+	if parts := strings.Split(value, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("repository must be owner/name, got %q", value)
+	}
+	return nil
+}
+
+func validateEnvVar(value string) error {
+	if !envVarPattern.MatchString(value) {
+		return fmt.Errorf("%q is not an environment variable name", value)
+	}
+	return nil
+}
+
+// detectTerraformPaths suggests scan paths for the Terraform in dir
+func detectTerraformPaths(dir string) []string {
+	var paths []string
+	for _, name := range terraformDirs {
+		if info, err := os.Stat(filepath.Join(dir, name)); err == nil && info.IsDir() {
+			paths = append(paths, name+"/**/*.tf")
+		}
+	}
+	if len(paths) == 0 {
+		if matches, _ := filepath.Glob(filepath.Join(dir, "*.tf")); len(matches) > 0 {
+			paths = append(paths, "./**/*.tf")
+		}
+	}
+	return paths
+}
+
+// detectGitHubRepo returns the owner/name of dir's origin remote when it is
+// on GitHub, or ""
+func detectGitHubRepo(dir string) string {
+	cmd := exec.Command("git", "remote", "get-url", "origin")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return parseGitHubRemote(strings.TrimSpace(string(out)))
+}
+
+func parseGitHubRemote(remote string) string {
+	match := githubRemotePattern.FindStringSubmatch(remote)
+	if match == nil {
+		return ""
+	}
+	return match[1] + "/" + match[2]
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// initConfigTemplate is the config file the wizard writes. Values are quoted
+// with strconv.Quote, whose output is also a valid YAML string.
+var initConfigTemplate = template.Must(template.New("grctool.yaml").Funcs(template.FuncMap{
+	"quote": strconv.Quote,
+}).Parse(`# GRCTool configuration, written by 'grctool init'
+# Run 'grctool config validate' after editing to check your changes.
+
+# Tugboat Logic
+tugboat:
+  base_url: {{ quote .BaseURL }}
+  # Organization ID (find in URL: /org/{org_id}/policies); 'grctool auth login'
+  # fills it in when empty
+  org_id: {{ quote .OrgID }}
+  timeout: "30s"
+  # Requests per second
+  rate_limit: 10
+  # Session credentials are added by 'grctool auth login'
+  auth_mode: "browser"
+{{- if .Username }}
+
+  # Custom Evidence Integration, used by 'grctool evidence submit'.
+  # The API key is read from TUGBOAT_API_KEY and never stored here.
+  username: {{ quote .Username }}
+  password: {{ printf "${%s}" .PasswordEnv | quote }}
+{{- end }}
+{{- if .CollectorURLs }}
+  # Evidence task reference -> collector URL ('grctool evidence setup' adds more)
+  collector_urls:
+{{- range .CollectorURLs }}
+    {{ .TaskRef }}: {{ quote .URL }}
+{{- end }}
+{{- end }}
+
+# Storage
+storage:
+  # Parent directory of docs/, evidence/ and prompts/
+  data_dir: {{ quote .DataDir }}
+
+# Logging
+logging:
+  level: "info"
+  format: "text"
+
+# Evidence collection tools
+evidence:
+  tools:
+    terraform:
+{{- if .ScanPaths }}
+      enabled: true
+      # Glob patterns of the Terraform analyzed for evidence
+      scan_paths:
+{{- range .ScanPaths }}
+        - {{ quote . }}
+{{- end }}
+{{- else }}
+      # Set scan_paths to the Terraform to analyze, e.g. "terraform/**/*.tf"
+      enabled: false
+{{- end }}
+      include_patterns:
+        - "*.tf"
+        - "*.yaml"
+        - "*.yml"
+      exclude_patterns:
+        - "*.secret"
+        - "builds/"
+        - "terraform.tfstate*"
+    github:
+{{- if .GitHubRepo }}
+      enabled: true
+      repository: {{ quote .GitHubRepo }}
+{{- else }}
+      # Set repository to owner/name to collect GitHub evidence
+      enabled: false
+{{- end }}
+
+# Authentication for evidence tools
+auth:
+  github:
+    # Read from the environment; 'gh auth login' is used when unset
+    token: "${GITHUB_TOKEN}"
+`))
+
+// renderInitConfig returns the commented config file for the wizard's answers
+func renderInitConfig(answers *initAnswers) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := initConfigTemplate.Execute(&buf, answers); err != nil {
+		return nil, fmt.Errorf("failed to render configuration: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const testCollectorURL = "https://openapi.tugboatlogic.com/api/v0/evidence/collector/805/"
+
+func TestRunInitWizard(t *testing.T) {
+	t.Parallel()
+
+	input := strings.Join([]string{
+		"",      // Tugboat URL: default
+		"abc",   // organization ID: rejected
+		"12345", // organization ID
+		"./grc", // data directory
+		"infra/**/*.tf, modules/**/*.tf",
+		"not-a-repo",                       // rejected
+		"acme/platform",                    // GitHub repository
+		"y",                                // set up collector URLs
+		"collector-user",                   // username
+		"",                                 // password variable: default
+		"et-1",                             // evidence task
+		"https://example.com/collector/1/", // rejected
+		testCollectorURL,
+		"",  // finish collector URLs
+		"n", // no login (macOS) or no sync
+		"n",
+	}, "\n") + "\n"
+
+	answers, err := runInitWizard(newPrompter(strings.NewReader(input), io.Discard), t.TempDir())
+	require.NoError(t, err)
+
+	assert.Equal(t, defaultTugboatBaseURL, answers.BaseURL)
+	assert.Equal(t, "12345", answers.OrgID)
+	assert.Equal(t, "./grc", answers.DataDir)
+	assert.Equal(t, []string{"infra/**/*.tf", "modules/**/*.tf"}, answers.ScanPaths)
+	assert.Equal(t, "acme/platform", answers.GitHubRepo)
+	assert.Equal(t, "collector-user", answers.Username)
+	assert.Equal(t, defaultPasswordEnv, answers.PasswordEnv)
+	assert.Equal(t, []collectorURL{{TaskRef: "ET-0001", URL: testCollectorURL}}, answers.CollectorURLs)
+	assert.False(t, answers.Login)
+	assert.False(t, answers.Sync)
+}
+
+func TestRunInitWizard_EndOfInputUsesDefaults(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "terraform"), 0755))
+
+	answers, err := runInitWizard(newPrompter(strings.NewReader("\n777"), io.Discard), dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, defaultTugboatBaseURL, answers.BaseURL)
+	assert.Equal(t, "777", answers.OrgID, "an answer without a newline is the last one")
+	assert.Equal(t, "./", answers.DataDir)
+	assert.Equal(t, []string{"terraform/**/*.tf"}, answers.ScanPaths)
+	assert.Empty(t, answers.CollectorURLs)
+}
+
+func TestRenderInitConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		answers *initAnswers
+		check   func(t *testing.T, cfg config.Config)
+	}{
+		"all settings": {
+			answers: &initAnswers{
+				BaseURL:       defaultTugboatBaseURL,
+				OrgID:         "12345",
+				DataDir:       "./grc",
+				ScanPaths:     []string{"infra/**/*.tf"},
+				GitHubRepo:    "acme/platform",
+				Username:      "collector \"user\"",
+				PasswordEnv:   "ACME_PASSWORD",
+				CollectorURLs: []collectorURL{{TaskRef: "ET-0001", URL: testCollectorURL}},
+			},
+			check: func(t *testing.T, cfg config.Config) {
+				assert.Equal(t, "12345", cfg.Tugboat.OrgID)
+				assert.Equal(t, 30*time.Second, cfg.Tugboat.Timeout)
+				assert.Equal(t, "collector \"user\"", cfg.Tugboat.Username)
+				assert.Equal(t, "${ACME_PASSWORD}", cfg.Tugboat.Password)
+				assert.Equal(t, map[string]string{"ET-0001": testCollectorURL}, cfg.Tugboat.CollectorURLs)
+				assert.Equal(t, "./grc", cfg.Storage.DataDir)
+				assert.True(t, cfg.Evidence.Tools.Terraform.Enabled)
+				assert.Equal(t, []string{"infra/**/*.tf"}, cfg.Evidence.Tools.Terraform.ScanPaths)
+				assert.True(t, cfg.Evidence.Tools.GitHub.Enabled)
+				assert.Equal(t, "acme/platform", cfg.Evidence.Tools.GitHub.Repository)
+			},
+		},
+		"minimal": {
+			answers: &initAnswers{BaseURL: defaultTugboatBaseURL, DataDir: "./"},
+			check: func(t *testing.T, cfg config.Config) {
+				assert.Equal(t, defaultTugboatBaseURL, cfg.Tugboat.BaseURL)
+				assert.Empty(t, cfg.Tugboat.Username)
+				assert.Empty(t, cfg.Tugboat.CollectorURLs)
+				assert.False(t, cfg.Evidence.Tools.Terraform.Enabled)
+				assert.False(t, cfg.Evidence.Tools.GitHub.Enabled)
+				assert.Equal(t, "${GITHUB_TOKEN}", cfg.Auth.GitHub.Token)
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			data, err := renderInitConfig(tc.answers)
+			require.NoError(t, err)
+			assert.Contains(t, string(data), "# Tugboat Logic")
+
+			var cfg config.Config
+			require.NoError(t, yaml.Unmarshal(data, &cfg))
+			tc.check(t, cfg)
+		})
+	}
+}
+
+func TestParseGitHubRemote(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		remote string
+		want   string
+	}{
+		"https":         {remote: "https://github.com/acme/platform.git", want: "acme/platform"},
+		"https no .git": {remote: "https://github.com/acme/platform", want: "acme/platform"},
+		"ssh":           {remote: "git@github.com:acme/platform.git", want: "acme/platform"},
+		"ssh url":       {remote: "ssh://git@github.com/acme/platform.git", want: "acme/platform"},
+		"other host":    {remote: "https://gitlab.com/acme/platform.git", want: ""},
+		"dotted repo":   {remote: "git@github.com:acme/platform.io.git", want: "acme/platform.io"},
+		"empty":         {remote: "", want: ""},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, parseGitHubRemote(tc.remote))
+		})
+	}
+}
+
+func TestDetectTerraformPaths(t *testing.T) {
+	t.Parallel()
+
+	t.Run("known directories", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "infra"), 0755))
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "terraform"), 0755))
+		assert.Equal(t, []string{"terraform/**/*.tf", "infra/**/*.tf"}, detectTerraformPaths(dir))
+	})
+
+	t.Run("top-level files", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "main.tf"), nil, 0644))
+		assert.Equal(t, []string{"./**/*.tf"}, detectTerraformPaths(dir))
+	})
+
+	t.Run("none", func(t *testing.T) {
+		t.Parallel()
+		assert.Empty(t, detectTerraformPaths(t.TempDir()))
+	})
+}
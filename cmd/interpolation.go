@@ -0,0 +1,153 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/interpolation"
+	"github.com/grctool/grctool/internal/services"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/grctool/grctool/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+var interpolationCmd = &cobra.Command{
+	Use:   "interpolation",
+	Short: "Check the variables used by documents and templates",
+	Long:  `Check the {{variables}} used by synced documents and evidence templates.`,
+}
+
+var interpolationCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Find undefined variables in synced documents and templates",
+	Long: `Scan the synced policies, controls and evidence tasks and the evidence
+templates for variables that are not defined, so a typo such as
+{{organisation.name}} is caught before it is left as written in submitted
+evidence.
+
+Each {{...}} expression of a document is interpolated strictly, as
+OnMissingVariable error does, with the variables of the document's framework
+and task: an undefined variable or a failing function call is reported with
+the closest defined variable when it looks like a typo. [Bracket] variables
+are not checked, since markdown links and checkboxes are written the same way.
+
+Evidence templates, with their overrides and the files mapped by
+evidence.generation.templates, are rendered against a sample task; unknown
+{{TASK_REF}}-style placeholders and actions that fail are reported.
+
+The command exits non-zero when anything is found. Set interpolation.strict to
+have 'grctool sync' run the same check on the documents it writes.
+
+Examples:
+  # Check everything
+  grctool interpolation check
+
+  # For CI
+  grctool interpolation check --output json`,
+	Args: cobra.NoArgs,
+	RunE: runInterpolationCheck,
+}
+
+func init() {
+	rootCmd.AddCommand(interpolationCmd)
+	interpolationCmd.AddCommand(interpolationCheckCmd)
+}
+
+// InterpolationCheckResult is the structured output of 'interpolation check'
+type InterpolationCheckResult struct {
+	Documents []services.DocumentProblem  `json:"documents"`
+	Templates []templates.TemplateProblem `json:"templates"`
+}
+
+func runInterpolationCheck(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	var result InterpolationCheckResult
+	if result.Documents, err = services.CheckDocuments(services.NewInterpolator(cfg), store); err != nil {
+		return err
+	}
+	if result.Templates, err = evidenceTemplates(cfg).Check(cfg); err != nil {
+		return err
+	}
+
+	if isStructuredOutput(format) {
+		if result.Documents == nil {
+			result.Documents = []services.DocumentProblem{}
+		}
+		if result.Templates == nil {
+			result.Templates = []templates.TemplateProblem{}
+		}
+		if err := writeStructured(cmd, format, result); err != nil {
+			return err
+		}
+	} else {
+		if !cfg.Interpolation.Enabled {
+			cmd.PrintErrln("⚠️  interpolation.enabled is false: documents are written without substituting any variable")
+		}
+		displayInterpolationCheck(cmd, result)
+	}
+
+	if found := len(result.Documents) + len(result.Templates); found > 0 {
+		return fmt.Errorf("found %d undefined variable(s)", found)
+	}
+	return nil
+}
+
+// displayInterpolationCheck lists the problems of the documents, then of the
+// templates
+func displayInterpolationCheck(cmd *cobra.Command, result InterpolationCheckResult) {
+	if len(result.Documents) == 0 && len(result.Templates) == 0 {
+		cmd.Println("✅ Every variable of the synced documents and evidence templates is defined")
+		return
+	}
+	displayDocumentProblems(cmd, result.Documents)
+	for _, problem := range result.Templates {
+		cmd.Printf("❌ template %s (%s) line %d: %s\n", problem.Template, problem.Path, problem.Line, describeProblem(problem.Problem))
+	}
+}
+
+// displayDocumentProblems lists the undefined variables of synced documents
+func displayDocumentProblems(cmd *cobra.Command, problems []services.DocumentProblem) {
+	for _, problem := range problems {
+		cmd.Printf("❌ %s %s %s line %d: %s\n", problem.Kind, problem.Document, problem.Field, problem.Line, describeProblem(problem.Problem))
+	}
+}
+
+// describeProblem describes an expression that cannot be filled in, e.g.
+// "{{organisation.name}}: missing variable: organisation.name (did you mean organization.name?)"
+func describeProblem(problem interpolation.Problem) string {
+	description := problem.Message
+	if problem.Expression != "" {
+		description = problem.Expression + ": " + description
+	}
+	if problem.Suggestion != "" {
+		description += fmt.Sprintf(" (did you mean %s?)", problem.Suggestion)
+	}
+	return description
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/grctool/grctool/internal/interpolation"
+	"github.com/grctool/grctool/internal/services"
+	"github.com/grctool/grctool/internal/templates"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplayInterpolationCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		result InterpolationCheckResult
+		want   string
+	}{
+		"clean": {
+			want: "✅ Every variable of the synced documents and evidence templates is defined\n",
+		},
+		"problems": {
+			result: InterpolationCheckResult{
+				Documents: []services.DocumentProblem{{
+					Kind: "policy", Document: "POL-0001", Field: "content",
+					Problem: interpolation.Problem{Expression: "{{organisation.name}}", Line: 3, Message: "missing variable: organisation.name", Suggestion: "organization.name"},
+				}},
+				Templates: []templates.TemplateProblem{{
+					Template: "tasks.ET-0047", Path: "templates/vendor.md",
+					Problem: interpolation.Problem{Line: 2, Message: `function "organization" not defined`},
+				}},
+			},
+			want: "❌ policy POL-0001 content line 3: {{organisation.name}}: missing variable: organisation.name (did you mean organization.name?)\n" +
+				"❌ template tasks.ET-0047 (templates/vendor.md) line 2: function \"organization\" not defined\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			cmd := &cobra.Command{Use: "test"}
+			cmd.SetOut(&out)
+			displayInterpolationCheck(cmd, tc.result)
+			assert.Equal(t, tc.want, out.String())
+		})
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/notify"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Send digests of overdue, due-soon and rejected evidence tasks",
+	Long: `Build a digest per assignee of the evidence tasks that are overdue, due
+soon, or whose submitted evidence was rejected, and deliver it through the
+notification channels configured in .grctool.yaml.
+
+The command is non-interactive and exits non-zero when a delivery fails, so it
+can run from cron or a CI schedule. Run 'grctool sync' first so due dates are
+current.
+
+Configuration:
+  notifications:
+    due_soon_days: 7
+    slack:
+      enabled: true
+      webhook_url: ${SLACK_WEBHOOK_URL}       # Default channel
+      channels:                               # Per-assignee channels
+        jane@example.com: ${SLACK_WEBHOOK_SECURITY}
+    email:
+      enabled: true
+      host: smtp.example.com
+      port: 587
+      username: grctool@example.com
+      password: ${SMTP_PASSWORD}
+      from: "GRCTool <grctool@example.com>"
+      unassigned_to: [compliance@example.com]
+      body_template: templates/digest.tmpl    # Optional text/template
+
+Examples:
+  # Preview the digests without sending
+  grctool notify --dry-run
+
+  # Send to Slack only
+  grctool notify --via slack
+
+  # Email a single assignee
+  grctool notify --via email --assignee jane@example.com
+
+  # Weekday mornings from cron
+  0 8 * * 1-5  cd /srv/compliance && grctool sync && grctool notify`,
+	Args: cobra.NoArgs,
+	RunE: runNotify,
+}
+
+func init() {
+	rootCmd.AddCommand(notifyCmd)
+
+	notifyCmd.Flags().Bool("dry-run", false, "print the digests instead of sending them")
+	notifyCmd.Flags().String("assignee", "", "only notify this assignee (name or email)")
+	notifyCmd.Flags().Int("due-soon-days", 0, "days ahead that count as due soon (default: notifications.due_soon_days)")
+	notifyCmd.Flags().StringSlice("via", nil, "notification channels to use (default: all enabled channels)")
+}
+
+func runNotify(cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	assignee, _ := cmd.Flags().GetString("assignee")
+	dueSoonDays, _ := cmd.Flags().GetInt("due-soon-days")
+	via, _ := cmd.Flags().GetStringSlice("via")
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if dueSoonDays < 0 {
+		return fmt.Errorf("--due-soon-days must not be negative")
+	}
+
+	scanner, cfg, err := initializeScanner()
+	if err != nil {
+		return err
+	}
+	if dueSoonDays == 0 {
+		dueSoonDays = cfg.Notifications.DueSoonDays
+	}
+
+	var notifiers []notify.Notifier
+	if !dryRun {
+		notifiers, err = buildNotifiers(cfg, via)
+		if err != nil {
+			return err
+		}
+	}
+
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	tasks, err := store.GetAllEvidenceTasks()
+	if err != nil {
+		return fmt.Errorf("failed to load evidence tasks: %w", err)
+	}
+	states, err := scanner.ScanAll(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to scan evidence: %w", err)
+	}
+
+	digests := notify.BuildDigests(tasks, states, notify.Options{
+		DueSoonDays: dueSoonDays,
+		Assignee:    assignee,
+	})
+
+	if dryRun {
+		if isStructuredOutput(format) {
+			return writeStructured(cmd, format, digests)
+		}
+		displayDigests(cmd, digests)
+		return nil
+	}
+
+	deliveries := notify.Dispatch(context.Background(), notifiers, digests)
+	if isStructuredOutput(format) {
+		if deliveries == nil {
+			deliveries = []notify.Delivery{}
+		}
+		if err := writeStructured(cmd, format, deliveries); err != nil {
+			return err
+		}
+	} else {
+		displayDeliveries(cmd, deliveries)
+	}
+
+	failed := 0
+	for _, delivery := range deliveries {
+		if delivery.Error != "" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d notifications failed", failed, len(deliveries))
+	}
+	return nil
+}
+
+// buildNotifiers returns the enabled notification channels, restricted to
+// the names in via when given
+func buildNotifiers(cfg *config.Config, via []string) ([]notify.Notifier, error) {
+	wanted := make(map[string]bool)
+	for _, name := range via {
+		wanted[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+	selected := func(name string) bool {
+		return len(wanted) == 0 || wanted[name]
+	}
+
+	var notifiers []notify.Notifier
+	if slack := cfg.Notifications.Slack; slack.Enabled && selected("slack") {
+		if slack.WebhookURL == "" && len(slack.Channels) == 0 {
+			return nil, fmt.Errorf("notifications.slack.webhook_url is required when Slack notifications are enabled")
+		}
+		notifiers = append(notifiers, notify.NewSlackNotifier(slack, nil))
+		delete(wanted, "slack")
+	}
+	if email := cfg.Notifications.Email; email.Enabled && selected("email") {
+		notifier, err := notify.NewEmailNotifier(email, nil)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, notifier)
+		delete(wanted, "email")
+	}
+
+	if len(wanted) > 0 {
+		var unknown []string
+		for name := range wanted {
+			unknown = append(unknown, name)
+		}
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("notification channels not enabled in the configuration: %s", strings.Join(unknown, ", "))
+	}
+	if len(notifiers) == 0 {
+		return nil, fmt.Errorf("no notification channels are enabled (configure notifications.slack or notifications.email)")
+	}
+	return notifiers, nil
+}
+
+func displayDigests(cmd *cobra.Command, digests []notify.Digest) {
+	if len(digests) == 0 {
+		cmd.Println("✅ No overdue, due-soon or rejected evidence tasks")
+		return
+	}
+
+	for _, digest := range digests {
+		cmd.Printf("📬 %s", digest.Recipient.Label())
+		if digest.Recipient.Email != "" && digest.Recipient.Name != "" {
+			cmd.Printf(" <%s>", digest.Recipient.Email)
+		}
+		cmd.Printf(" (%d tasks)\n", digest.Total())
+
+		for _, group := range []struct {
+			label string
+			items []notify.Item
+		}{
+			{"Overdue", digest.Overdue},
+			{"Due soon", digest.DueSoon},
+			{"Rejected", digest.Rejected},
+		} {
+			for _, item := range group.items {
+				cmd.Printf("  %-9s %-8s %s — %s\n", group.label, item.TaskRef, item.TaskName, notify.ItemStatus(item))
+			}
+		}
+		cmd.Println()
+	}
+}
+
+func displayDeliveries(cmd *cobra.Command, deliveries []notify.Delivery) {
+	if len(deliveries) == 0 {
+		cmd.Println("✅ No overdue, due-soon or rejected evidence tasks; nothing sent")
+		return
+	}
+
+	sent := 0
+	for _, delivery := range deliveries {
+		switch {
+		case delivery.Error != "":
+			cmd.Printf("❌ %s → %s: %s\n", delivery.Notifier, delivery.Recipient.Label(), delivery.Error)
+		case delivery.Skipped:
+			cmd.Printf("⏭️  %s → %s: no destination configured\n", delivery.Notifier, delivery.Recipient.Label())
+		default:
+			sent++
+			cmd.Printf("✅ %s → %s: %d tasks\n", delivery.Notifier, delivery.Recipient.Label(), delivery.Items)
+		}
+	}
+	cmd.Printf("\nSent %d of %d notifications\n", sent, len(deliveries))
+}
@@ -0,0 +1,57 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildNotifiers(t *testing.T) {
+	t.Parallel()
+
+	slack := config.SlackNotifyConfig{Enabled: true, WebhookURL: "https://hooks.slack.com/services/T/B/X"}
+	email := config.EmailNotifyConfig{Enabled: true, Host: "smtp.example.com", From: "grctool@example.com"}
+
+	tests := map[string]struct {
+		slack   config.SlackNotifyConfig
+		email   config.EmailNotifyConfig
+		via     []string
+		want    []string
+		wantErr string
+	}{
+		"all enabled":         {slack: slack, email: email, want: []string{"slack", "email"}},
+		"email only":          {slack: slack, email: email, via: []string{"email"}, want: []string{"email"}},
+		"invalid email":       {email: config.EmailNotifyConfig{Enabled: true, From: "grctool@example.com"}, wantErr: "notifications.email.host is required"},
+		"explicit channel":    {slack: slack, via: []string{" Slack "}, want: []string{"slack"}},
+		"nothing enabled":     {wantErr: "no notification channels are enabled"},
+		"disabled channel":    {via: []string{"slack"}, wantErr: "not enabled in the configuration: slack"},
+		"unknown channel":     {slack: slack, via: []string{"slack", "pager"}, wantErr: "not enabled in the configuration: pager"},
+		"missing webhook url": {slack: config.SlackNotifyConfig{Enabled: true}, wantErr: "webhook_url is required"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &config.Config{Notifications: config.NotificationsConfig{Slack: tt.slack, Email: tt.email}}
+			notifiers, err := buildNotifiers(cfg, tt.via)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var names []string
+			for _, n := range notifiers {
+				names = append(names, n.Name())
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
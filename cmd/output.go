@@ -0,0 +1,108 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats accepted by the global --output flag
+const (
+	outputFormatTable = "table"
+	outputFormatJSON  = "json"
+	outputFormatYAML  = "yaml"
+)
+
+// outputFormat returns the structured output format requested for cmd.
+// Commands that define their own --output flag (for example a file path)
+// shadow the global one; for those only the values json and yaml select
+// structured output and anything else means the table view.
+func outputFormat(cmd *cobra.Command) (string, error) {
+	flag := cmd.Flag("output")
+	if flag == nil {
+		return outputFormatTable, nil
+	}
+
+	format := strings.ToLower(strings.TrimSpace(flag.Value.String()))
+	switch format {
+	case outputFormatJSON, outputFormatYAML, outputFormatTable:
+		return format, nil
+	}
+
+	if flag == rootCmd.PersistentFlags().Lookup("output") {
+		return "", fmt.Errorf("invalid output format %q (must be one of: table, json, yaml)", flag.Value.String())
+	}
+	return outputFormatTable, nil
+}
+
+// isStructuredOutput reports whether format is a machine-readable format
+func isStructuredOutput(format string) bool {
+	return format == outputFormatJSON || format == outputFormatYAML
+}
+
+// writeStructured renders v as JSON or YAML to the command's output stream.
+// YAML is produced from the JSON encoding so both formats share field names.
+func writeStructured(cmd *cobra.Command, format string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	if format == outputFormatYAML {
+		data, err = jsonToYAML(data)
+		if err != nil {
+			return fmt.Errorf("failed to encode output: %w", err)
+		}
+	} else {
+		data = append(data, '\n')
+	}
+
+	_, err = cmd.OutOrStdout().Write(data)
+	return err
+}
+
+// jsonToYAML converts a JSON document to block-style YAML, preserving key order
+func jsonToYAML(data []byte) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	resetYAMLStyle(&node)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputFormat_GlobalFlag(t *testing.T) {
+	flag := rootCmd.PersistentFlags().Lookup("output")
+	require.NotNil(t, flag)
+	assert.Equal(t, outputFormatTable, flag.DefValue)
+	t.Cleanup(func() { _ = flag.Value.Set(flag.DefValue) })
+
+	tests := map[string]struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		"table": {value: "table", want: outputFormatTable},
+		"json":  {value: "json", want: outputFormatJSON},
+		"yaml":  {value: "YAML", want: outputFormatYAML},
+		"xml":   {value: "xml", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, flag.Value.Set(tt.value))
+			format, err := outputFormat(rootCmd)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid output format")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, format)
+		})
+	}
+}
+
+func TestOutputFormat_LocalFileFlag(t *testing.T) {
+	t.Parallel()
+
+	newCmd := func(value string) *cobra.Command {
+		cmd := &cobra.Command{Use: "view"}
+		cmd.Flags().StringP("output", "o", "", "output file path")
+		require.NoError(t, cmd.Flags().Set("output", value))
+		return cmd
+	}
+
+	format, err := outputFormat(newCmd("json"))
+	require.NoError(t, err)
+	assert.Equal(t, outputFormatJSON, format)
+
+	format, err = outputFormat(newCmd("exports/ET-0001.md"))
+	require.NoError(t, err)
+	assert.Equal(t, outputFormatTable, format)
+
+	format, err = outputFormat(&cobra.Command{Use: "bare"})
+	require.NoError(t, err)
+	assert.Equal(t, outputFormatTable, format)
+}
+
+func TestWriteStructured(t *testing.T) {
+	t.Parallel()
+
+	value := struct {
+		Name    string   `json:"name"`
+		Version string   `json:"version"`
+		Count   int      `json:"count"`
+		Tags    []string `json:"tags"`
+	}{Name: "grctool", Version: "1.0", Count: 2, Tags: []string{"soc2", "true"}}
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+
+	require.NoError(t, writeStructured(cmd, outputFormatJSON, value))
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, "grctool", decoded["name"])
+
+	out.Reset()
+	require.NoError(t, writeStructured(cmd, outputFormatYAML, value))
+	assert.Equal(t, "name: grctool\nversion: \"1.0\"\ncount: 2\ntags:\n  - soc2\n  - \"true\"\n", out.String())
+}
+
+func TestNewEvidenceListResult(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	past := now.AddDate(0, 0, -3)
+	future := now.AddDate(0, 1, 0)
+
+	tasks := []domain.EvidenceTask{
+		{
+			ID:          "327992",
+			ReferenceID: "ET-0001",
+			Name:        "Access review",
+			Framework:   "SOC2",
+			Status:      "pending",
+			NextDue:     &past,
+			Assignees:   []domain.Person{{Name: "Alex"}, {Email: "sam@example.com"}, {}},
+		},
+		{ID: "327993", Name: "Backups", NextDue: &future},
+	}
+	summary := &domain.EvidenceTaskSummary{Total: 2, Overdue: 1}
+
+	result := newEvidenceListResult(tasks, summary, now)
+
+	assert.Equal(t, 2, result.Count)
+	assert.Same(t, summary, result.Summary)
+	require.Len(t, result.Tasks, 2)
+	assert.Equal(t, "ET-0001", result.Tasks[0].ReferenceID)
+	assert.True(t, result.Tasks[0].Overdue)
+	assert.Equal(t, []string{"Alex", "sam@example.com"}, result.Tasks[0].Assignees)
+	assert.Equal(t, "ET-0002", result.Tasks[1].ReferenceID)
+	assert.False(t, result.Tasks[1].Overdue)
+
+	empty := newEvidenceListResult(nil, nil, now)
+	assert.NotNil(t, empty.Tasks)
+}
+
+func TestIsReadyForSubmission(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, isReadyForSubmission(false, nil))
+	assert.True(t, isReadyForSubmission(true, nil))
+	assert.True(t, isReadyForSubmission(true, &models.ValidationResult{ReadyForSubmission: true}))
+	assert.False(t, isReadyForSubmission(true, &models.ValidationResult{ReadyForSubmission: false}))
+}
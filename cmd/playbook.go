@@ -0,0 +1,155 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/playbook"
+	"github.com/grctool/grctool/internal/services"
+	"github.com/grctool/grctool/internal/tools"
+	"github.com/spf13/cobra"
+)
+
+var playbookCmd = &cobra.Command{
+	Use:   "playbook",
+	Short: "Run declarative tool pipelines",
+	Long: `Run playbooks: YAML files that chain tools for evidence tasks. Each step
+runs a tool with parameters that may refer to the task ({{task.ref}}), the
+collection window ({{window}}), configured interpolation variables and the
+output of earlier steps ({{steps.<id>.output}}). A typical playbook collects
+data with one or more tools and writes it as evidence with evidence-writer.
+
+  name: access-review
+  tasks: [ET-0001, ET-0047]
+  steps:
+    - id: permissions
+      tool: github-permissions
+      params:
+        repository: acme/platform
+        output_format: matrix
+    - id: write
+      tool: evidence-writer
+      params:
+        title: GitHub Access Review
+        content: "{{steps.permissions.output}}"
+        format: markdown
+        source_type: github
+
+Steps that leave out task_ref or window get the task and window of the run
+when their tool accepts them.`,
+}
+
+var playbookRunCmd = &cobra.Command{
+	Use:   "run <playbook.yaml>",
+	Short: "Run a playbook's steps for each of its tasks",
+	Long: `Run the steps of a playbook in order for each evidence task, given with
+--task or else listed in the playbook. Tools and parameters are checked for
+every task before any step runs, so a misspelled variable is reported with
+a suggestion rather than written into evidence.
+
+A step that fails skips the remaining steps of its task; the other tasks
+still run, and the command fails if any task did.
+
+Examples:
+  # Run for the playbook's tasks in the 2025-Q4 window
+  grctool playbook run access-review.yaml --window 2025-Q4
+
+  # Run for selected tasks only
+  grctool playbook run access-review.yaml --window 2025-Q4 --task ET-0001 --task ET-0047
+
+  # Machine-readable results
+  grctool playbook run access-review.yaml --window 2025-Q4 --output json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPlaybookRun,
+}
+
+func init() {
+	rootCmd.AddCommand(playbookCmd)
+	playbookCmd.AddCommand(playbookRunCmd)
+
+	playbookRunCmd.Flags().String("window", "", "collection window to run for, e.g. 2025-Q4 (default: current quarter)")
+	playbookRunCmd.Flags().StringSlice("task", nil, "evidence task to run for, instead of the playbook's tasks (repeatable)")
+}
+
+func runPlaybookRun(cmd *cobra.Command, args []string) error {
+	window, _ := cmd.Flags().GetString("window")
+	taskRefs, _ := cmd.Flags().GetStringSlice("task")
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if window == "" {
+		window = getCurrentQuarter()
+	}
+	if _, _, err := tools.ParseEvidenceWindow(window); err != nil {
+		return err
+	}
+
+	book, err := playbook.Load(args[0])
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	// Playbook parameters are interpolated whether or not documents are
+	interpolationCfg := *cfg
+	interpolationCfg.Interpolation.Enabled = true
+	runner := playbook.NewRunner(tools.GlobalRegistry, services.NewInterpolator(&interpolationCfg), logger.WithComponent("playbook"))
+
+	result, runErr := runner.Run(commandContext(cmd), book, taskRefs, window)
+	if result == nil {
+		return runErr
+	}
+
+	if isStructuredOutput(format) {
+		if err := writeStructured(cmd, format, result); err != nil {
+			return err
+		}
+		return runErr
+	}
+
+	cmd.Printf("📋 Playbook %s, window %s\n", result.Playbook, result.Window)
+	for _, task := range result.Tasks {
+		taskRef := task.TaskRef
+		if taskRef == "" {
+			taskRef = "(no task)"
+		}
+		icon := "✅"
+		if task.Status == playbook.StatusFailed {
+			icon = "❌"
+		}
+		cmd.Printf("\n%s %s\n", icon, taskRef)
+		for _, step := range task.Steps {
+			switch step.Status {
+			case playbook.StatusSucceeded:
+				cmd.Printf("   ✓ %s (%s, %d bytes, %dms)\n", step.ID, step.Tool, step.OutputBytes, step.DurationMS)
+			case playbook.StatusFailed:
+				cmd.Printf("   ✗ %s (%s): %s\n", step.ID, step.Tool, step.Error)
+			default:
+				cmd.Printf("   - %s (%s): skipped\n", step.ID, step.Tool)
+			}
+		}
+	}
+	cmd.Printf("\n%d tasks, %d failed\n", len(result.Tasks), result.Failed)
+	return runErr
+}
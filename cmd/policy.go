@@ -24,7 +24,7 @@ import (
 	"github.com/grctool/grctool/internal/config"
 	"github.com/grctool/grctool/internal/domain"
 	"github.com/grctool/grctool/internal/formatters"
-	"github.com/grctool/grctool/internal/interpolation"
+	"github.com/grctool/grctool/internal/services"
 	"github.com/grctool/grctool/internal/storage"
 	"github.com/spf13/cobra"
 )
@@ -38,7 +38,9 @@ var policyCmd = &cobra.Command{
 This command group provides various operations for policies including:
 - Viewing policies in markdown format
 - Listing available policies
-- Searching policies by framework or status`,
+- Searching policies by framework or status
+- Authoring local policies through draft, review and approval
+- Tracking employee policy acknowledgments`,
 }
 
 // policyViewCmd represents the policy view command
@@ -131,12 +133,7 @@ func runPolicyView(cmd *cobra.Command, args []string) error {
 	// Initialize formatter with interpolation if enabled
 	var formatter *formatters.PolicyFormatter
 	if cfg.Interpolation.Enabled {
-		config := interpolation.InterpolatorConfig{
-			Variables:         cfg.Interpolation.GetFlatVariables(),
-			Enabled:           true,
-			OnMissingVariable: interpolation.MissingVariableIgnore,
-		}
-		interpolator := interpolation.NewStandardInterpolator(config)
+		interpolator := services.NewInterpolator(cfg)
 		formatter = formatters.NewPolicyFormatterWithInterpolation(interpolator)
 	} else {
 		formatter = formatters.NewPolicyFormatter()
@@ -229,12 +226,7 @@ func runPolicyList(cmd *cobra.Command, args []string) error {
 		// Initialize formatter with interpolation if enabled
 		var formatter *formatters.PolicyFormatter
 		if cfg.Interpolation.Enabled {
-			config := interpolation.InterpolatorConfig{
-				Variables:         cfg.Interpolation.GetFlatVariables(),
-				Enabled:           true,
-				OnMissingVariable: interpolation.MissingVariableIgnore,
-			}
-			interpolator := interpolation.NewStandardInterpolator(config)
+			interpolator := services.NewInterpolator(cfg)
 			formatter = formatters.NewPolicyFormatterWithInterpolation(interpolator)
 		} else {
 			formatter = formatters.NewPolicyFormatter()
@@ -0,0 +1,701 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/server"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/grctool/grctool/internal/tools"
+	"github.com/spf13/cobra"
+)
+
+var policyAckCmd = &cobra.Command{
+	Use:   "ack",
+	Short: "Track employee policy acknowledgments",
+	Long: `Record employees' acknowledgments of policies per policy and period (such as
+the annual acknowledgment campaign for 2025) and measure them against an
+employee roster.
+
+Acknowledgments are imported from Google Forms or HRIS CSV exports, or
+collected through signed links served by 'grctool serve'. They are stored
+under docs/acknowledgments/<policy>/<period>.json in the data directory and
+exported as evidence by the policy-acknowledgment tool.
+
+Policies are authored policy IDs or synced policy references (e.g. POL-0001).
+Periods are a year, half, quarter or month: 2025, 2025-H1, 2025-Q1, 2025-01.`,
+}
+
+var policyAckImportCmd = &cobra.Command{
+	Use:   "import <file.csv>",
+	Short: "Import acknowledgments from a Google Forms or HRIS CSV export",
+	Long: `Import acknowledgments of a policy from a CSV export with a header row.
+
+The email, name and date columns are found by their headers ("Email Address",
+"Full Name" and "Timestamp" in Google Forms responses; "email", "name" and
+"date" or "acknowledged_at" in HRIS exports) unless named with the column
+flags. Exports with a Timestamp and an Email Address column are recorded with
+the source google-forms, others with csv.
+
+Each employee's earliest acknowledgment is kept, so importing an export again
+is harmless. Rows dated outside the period are skipped.
+
+Examples:
+  grctool policy ack import responses.csv --policy acceptable-use-policy --period 2025
+  grctool policy ack import hris.csv --policy POL-0003 --date-column "Signed On"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPolicyAckImport,
+}
+
+var policyAckRosterCmd = &cobra.Command{
+	Use:   "roster <file.csv>",
+	Short: "Import the employee roster acknowledgment rates are measured against",
+	Long: `Replace the employee roster with the employees in an HRIS CSV export. The
+email, name and department columns are found by their headers unless named
+with the column flags. When the export has a status column, employees whose
+status is terminated, inactive, offboarded or former are left out.
+
+Examples:
+  grctool policy ack roster employees.csv
+  grctool policy ack roster bamboohr.csv --email-column "Work Email"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPolicyAckRoster,
+}
+
+var policyAckLinkCmd = &cobra.Command{
+	Use:   "link <policy>",
+	Short: "Print signed acknowledgment links for employees",
+	Long: `Print a signed link per employee at which they acknowledge a policy for a
+period, to send by email or chat. The links are served by
+'grctool serve --ack-secret' and must be signed with the same secret.
+
+Examples:
+  GRCTOOL_ACK_SECRET=... grctool policy ack link acceptable-use-policy \
+    --email alex@example.com --base-url https://grc.example.com
+
+  # Links for everyone on the roster who has not acknowledged yet
+  grctool policy ack link acceptable-use-policy --pending --base-url https://grc.example.com`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPolicyAckLink,
+}
+
+var policyAckStatusCmd = &cobra.Command{
+	Use:   "status [policy]",
+	Short: "Show acknowledgment rates against the roster",
+	Long: `Without a policy, show the acknowledgment rate of each policy with
+acknowledgments in the period, and of each approved authored policy. With
+one, show its rate and the employees who have not acknowledged it.
+
+Examples:
+  grctool policy ack status --period 2025
+  grctool policy ack status acceptable-use-policy`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPolicyAckStatus,
+}
+
+func init() {
+	policyCmd.AddCommand(policyAckCmd)
+	policyAckCmd.AddCommand(policyAckImportCmd)
+	policyAckCmd.AddCommand(policyAckRosterCmd)
+	policyAckCmd.AddCommand(policyAckLinkCmd)
+	policyAckCmd.AddCommand(policyAckStatusCmd)
+
+	addPolicyAckImportFlags(policyAckImportCmd)
+	_ = policyAckImportCmd.MarkFlagRequired("policy")
+	addPolicyAckRosterFlags(policyAckRosterCmd)
+
+	addAckPeriodFlag(policyAckLinkCmd)
+	policyAckLinkCmd.Flags().StringSlice("email", nil, "employee to link (repeatable)")
+	policyAckLinkCmd.Flags().Bool("pending", false, "link every roster employee who has not acknowledged")
+	policyAckLinkCmd.Flags().String("base-url", "http://127.0.0.1:8080", "URL at which 'grctool serve' is reachable")
+	policyAckLinkCmd.Flags().String("secret", "", "secret signing the links (default: $GRCTOOL_ACK_SECRET)")
+
+	addAckPeriodFlag(policyAckStatusCmd)
+}
+
+// addAckPeriodFlag adds the --period flag selecting the acknowledgment period
+func addAckPeriodFlag(c *cobra.Command) {
+	c.Flags().String("period", "", "acknowledgment period, e.g. 2025 or 2025-Q1 (default: the current year)")
+}
+
+// addPolicyAckImportFlags adds the flags of an acknowledgment import
+func addPolicyAckImportFlags(c *cobra.Command) {
+	c.Flags().String("policy", "", "policy acknowledged")
+	addAckPeriodFlag(c)
+	c.Flags().String("version", "", "policy version acknowledged (default: the approved version)")
+	c.Flags().String("source", "", "source recorded (csv, google-forms; default: detected from the header)")
+	c.Flags().String("email-column", "", "header of the email column")
+	c.Flags().String("name-column", "", "header of the name column")
+	c.Flags().String("date-column", "", "header of the acknowledgment date column")
+}
+
+// addPolicyAckRosterFlags adds the flags of a roster import
+func addPolicyAckRosterFlags(c *cobra.Command) {
+	c.Flags().String("email-column", "", "header of the email column")
+	c.Flags().String("name-column", "", "header of the name column")
+	c.Flags().String("department-column", "", "header of the department column")
+	c.Flags().String("status-column", "", "header of the employment status column")
+}
+
+// ackPeriod returns the --period flag, defaulting to the year of now
+func ackPeriod(cmd *cobra.Command, now time.Time) (string, error) {
+	period, _ := cmd.Flags().GetString("period")
+	if period == "" {
+		return strconv.Itoa(now.Year()), nil
+	}
+	return period, domain.ValidateAcknowledgmentPeriod(period)
+}
+
+// resolveAckPolicy resolves a policy reference to the ID acknowledgments are
+// stored under and the version in force, trying authored policies first
+func resolveAckPolicy(store *storage.Storage, ref string) (string, string, error) {
+	authored, err := store.GetAuthoredPolicy(ref)
+	if err == nil {
+		return authored.ID, authored.ApprovedVersion(), nil
+	}
+	if !errors.Is(err, storage.ErrAuthoredPolicyNotFound) {
+		return "", "", err
+	}
+
+	policy, err := store.GetPolicy(ref)
+	if err != nil || policy == nil {
+		return "", "", fmt.Errorf("policy not found: %s (draft it with 'grctool policy draft' or sync it)", ref)
+	}
+	id := policy.ReferenceID
+	if id == "" {
+		id = policy.ID
+	}
+	version := ""
+	if policy.VersionNum > 0 {
+		version = strconv.Itoa(policy.VersionNum)
+	}
+	return id, version, nil
+}
+
+// csvColumns locates columns of a CSV header by name
+type csvColumns map[string]int
+
+// newCSVColumns indexes a header by its trimmed, lower-cased names
+func newCSVColumns(header []string) csvColumns {
+	columns := csvColumns{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := columns[name]; !ok {
+			columns[name] = i
+		}
+	}
+	return columns
+}
+
+// find returns the column named override when given, else the first of the
+// candidate names present, else -1. An override that is missing is an error.
+func (c csvColumns) find(override string, candidates ...string) (int, error) {
+	if override != "" {
+		if i, ok := c[strings.ToLower(strings.TrimSpace(override))]; ok {
+			return i, nil
+		}
+		return -1, fmt.Errorf("column %q not found in the CSV header", override)
+	}
+	for _, candidate := range candidates {
+		if i, ok := c[candidate]; ok {
+			return i, nil
+		}
+	}
+	return -1, nil
+}
+
+func csvField(record []string, i int) string {
+	if i < 0 || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// acknowledgmentDateLayouts are the date formats of Google Forms and common
+// HRIS exports
+var acknowledgmentDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"1/2/2006 15:04:05",
+	"1/2/2006 15:04",
+	"1/2/2006",
+}
+
+func parseAcknowledgmentDate(value string) (time.Time, error) {
+	for _, layout := range acknowledgmentDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date %q", value)
+}
+
+// ackImportResult summarizes an acknowledgment import
+type ackImportResult struct {
+	Policy        string   `json:"policy"`
+	Period        string   `json:"period"`
+	Source        string   `json:"source"`
+	Added         int      `json:"added"`
+	Duplicates    int      `json:"duplicates"`
+	OutsidePeriod int      `json:"outside_period"`
+	Invalid       []string `json:"invalid"`
+	Total         int      `json:"total"`
+}
+
+// importAcknowledgments adds the acknowledgments in a CSV export to acks,
+// skipping rows dated outside the period and reporting invalid ones
+func importAcknowledgments(cmd *cobra.Command, r io.Reader, acks *domain.PolicyAcknowledgments, version string) (*ackImportResult, error) {
+	flags := cmd.Flags()
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns := newCSVColumns(header)
+
+	emailColumn, _ := flags.GetString("email-column")
+	nameColumn, _ := flags.GetString("name-column")
+	dateColumn, _ := flags.GetString("date-column")
+	emailIdx, err := columns.find(emailColumn, "email address", "email", "work email", "employee email")
+	if err != nil {
+		return nil, err
+	}
+	if emailIdx < 0 {
+		return nil, fmt.Errorf("no email column found in the CSV header: name it with --email-column")
+	}
+	nameIdx, err := columns.find(nameColumn, "full name", "name", "employee name", "employee")
+	if err != nil {
+		return nil, err
+	}
+	dateIdx, err := columns.find(dateColumn, "timestamp", "acknowledged_at", "acknowledged at", "acknowledged", "date", "signed")
+	if err != nil {
+		return nil, err
+	}
+	if dateIdx < 0 {
+		return nil, fmt.Errorf("no date column found in the CSV header: name it with --date-column")
+	}
+
+	source, _ := flags.GetString("source")
+	if source == "" {
+		source = domain.AcknowledgmentSourceCSV
+		if _, ok := columns["timestamp"]; ok {
+			if _, ok := columns["email address"]; ok {
+				source = domain.AcknowledgmentSourceGoogleForms
+			}
+		}
+	}
+	if !slices.Contains(domain.AcknowledgmentSources, source) {
+		return nil, fmt.Errorf("invalid --source %q (must be one of: %v)", source, domain.AcknowledgmentSources)
+	}
+	start, end, err := tools.ParseEvidenceWindow(acks.Period)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ackImportResult{Policy: acks.Policy, Period: acks.Period, Source: source, Invalid: []string{}}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		email := csvField(record, emailIdx)
+		if email == "" && csvField(record, dateIdx) == "" {
+			continue
+		}
+		if !strings.Contains(email, "@") {
+			result.Invalid = append(result.Invalid, fmt.Sprintf("line %d: invalid email %q", line, email))
+			continue
+		}
+		date, err := parseAcknowledgmentDate(csvField(record, dateIdx))
+		if err != nil {
+			result.Invalid = append(result.Invalid, fmt.Sprintf("line %d: %v", line, err))
+			continue
+		}
+		if date.Before(start) || !date.Before(end) {
+			result.OutsidePeriod++
+			continue
+		}
+		added := acks.Add(domain.PolicyAcknowledgment{
+			Email:          email,
+			Name:           csvField(record, nameIdx),
+			Version:        version,
+			AcknowledgedAt: date,
+			Source:         source,
+		})
+		if added {
+			result.Added++
+		} else {
+			result.Duplicates++
+		}
+	}
+	result.Total = len(acks.Acknowledgments)
+	return result, nil
+}
+
+func runPolicyAckImport(cmd *cobra.Command, args []string) error {
+	store, err := openLocalStorage()
+	if err != nil {
+		return err
+	}
+	ref, _ := cmd.Flags().GetString("policy")
+	policy, version, err := resolveAckPolicy(store, ref)
+	if err != nil {
+		return err
+	}
+	if v, _ := cmd.Flags().GetString("version"); v != "" {
+		version = v
+	}
+	now := time.Now()
+	period, err := ackPeriod(cmd, now)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open acknowledgments: %w", err)
+	}
+	defer file.Close()
+
+	acks, err := store.GetPolicyAcknowledgments(policy, period)
+	if err != nil {
+		return err
+	}
+	result, err := importAcknowledgments(cmd, file, acks, version)
+	if err != nil {
+		return err
+	}
+	acks.UpdatedAt = now.UTC()
+	if err := store.SavePolicyAcknowledgments(acks); err != nil {
+		return fmt.Errorf("failed to save acknowledgments: %w", err)
+	}
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, result)
+	}
+	cmd.Printf("✅ Imported %d acknowledgments of %s for %s (%s)\n", result.Added, policy, period, result.Source)
+	if result.Duplicates > 0 {
+		cmd.Printf("  %d already recorded\n", result.Duplicates)
+	}
+	if result.OutsidePeriod > 0 {
+		cmd.Printf("  %d dated outside %s, skipped\n", result.OutsidePeriod, period)
+	}
+	for _, invalid := range result.Invalid {
+		cmd.Printf("  ⚠️  %s, skipped\n", invalid)
+	}
+	cmd.Printf("  %d acknowledgments recorded in total\n", result.Total)
+	return nil
+}
+
+// inactiveEmploymentStatuses are the HRIS statuses of people left off the roster
+var inactiveEmploymentStatuses = []string{"terminated", "inactive", "offboarded", "former", "terminated employee"}
+
+// parseEmployeeRoster reads the active employees in an HRIS CSV export
+func parseEmployeeRoster(cmd *cobra.Command, r io.Reader) ([]domain.Employee, error) {
+	flags := cmd.Flags()
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns := newCSVColumns(header)
+
+	emailColumn, _ := flags.GetString("email-column")
+	nameColumn, _ := flags.GetString("name-column")
+	departmentColumn, _ := flags.GetString("department-column")
+	statusColumn, _ := flags.GetString("status-column")
+	emailIdx, err := columns.find(emailColumn, "email", "work email", "email address", "employee email")
+	if err != nil {
+		return nil, err
+	}
+	if emailIdx < 0 {
+		return nil, fmt.Errorf("no email column found in the CSV header: name it with --email-column")
+	}
+	nameIdx, err := columns.find(nameColumn, "name", "full name", "employee name", "display name")
+	if err != nil {
+		return nil, err
+	}
+	departmentIdx, err := columns.find(departmentColumn, "department", "team", "division")
+	if err != nil {
+		return nil, err
+	}
+	statusIdx, err := columns.find(statusColumn, "status", "employment status", "employee status")
+	if err != nil {
+		return nil, err
+	}
+
+	employees := []domain.Employee{}
+	seen := map[string]bool{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		email := domain.NormalizeEmail(csvField(record, emailIdx))
+		if !strings.Contains(email, "@") || seen[email] {
+			continue
+		}
+		if slices.Contains(inactiveEmploymentStatuses, strings.ToLower(csvField(record, statusIdx))) {
+			continue
+		}
+		seen[email] = true
+		employees = append(employees, domain.Employee{
+			Email:      email,
+			Name:       csvField(record, nameIdx),
+			Department: csvField(record, departmentIdx),
+		})
+	}
+	return employees, nil
+}
+
+func runPolicyAckRoster(cmd *cobra.Command, args []string) error {
+	store, err := openLocalStorage()
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open roster: %w", err)
+	}
+	defer file.Close()
+
+	employees, err := parseEmployeeRoster(cmd, file)
+	if err != nil {
+		return err
+	}
+	if len(employees) == 0 {
+		return fmt.Errorf("no active employees with an email address found in %s", args[0])
+	}
+	roster := &domain.EmployeeRoster{Employees: employees, ImportedAt: time.Now().UTC(), Source: args[0]}
+	if err := store.SaveEmployeeRoster(roster); err != nil {
+		return fmt.Errorf("failed to save roster: %w", err)
+	}
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, roster)
+	}
+	cmd.Printf("✅ Imported roster of %d employees from %s\n", len(employees), args[0])
+	return nil
+}
+
+// ackLink is a signed acknowledgment link for an employee
+type ackLink struct {
+	Email string `json:"email"`
+	Link  string `json:"link"`
+}
+
+func runPolicyAckLink(cmd *cobra.Command, args []string) error {
+	flags := cmd.Flags()
+	secret, _ := flags.GetString("secret")
+	if secret == "" {
+		secret = os.Getenv("GRCTOOL_ACK_SECRET")
+	}
+	if secret == "" {
+		return errors.New("a secret is required: set --secret or GRCTOOL_ACK_SECRET to the one 'grctool serve' uses")
+	}
+	emails, _ := flags.GetStringSlice("email")
+	pending, _ := flags.GetBool("pending")
+	if len(emails) == 0 && !pending {
+		return errors.New("name employees with --email or use --pending")
+	}
+	baseURL, _ := flags.GetString("base-url")
+
+	store, err := openLocalStorage()
+	if err != nil {
+		return err
+	}
+	policy, version, err := resolveAckPolicy(store, args[0])
+	if err != nil {
+		return err
+	}
+	period, err := ackPeriod(cmd, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if pending {
+		roster, err := store.GetEmployeeRoster()
+		if err != nil {
+			return err
+		}
+		if roster == nil {
+			return errors.New("--pending needs a roster: import one with 'grctool policy ack roster'")
+		}
+		acks, err := store.GetPolicyAcknowledgments(policy, period)
+		if err != nil {
+			return err
+		}
+		for _, employee := range domain.CalculateAcknowledgmentRate(acks, roster).Pending {
+			emails = append(emails, employee.Email)
+		}
+	}
+
+	links := []ackLink{}
+	for _, email := range emails {
+		if !strings.Contains(email, "@") {
+			return fmt.Errorf("invalid email %q", email)
+		}
+		links = append(links, ackLink{
+			Email: domain.NormalizeEmail(email),
+			Link:  server.AcknowledgmentLink(baseURL, secret, policy, period, email, version),
+		})
+	}
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, links)
+	}
+	if len(links) == 0 {
+		cmd.Printf("Everyone on the roster has acknowledged %s for %s\n", policy, period)
+		return nil
+	}
+	// Links go to stdout so they can be piped into a mail merge
+	for _, link := range links {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", link.Email, link.Link)
+	}
+	return nil
+}
+
+func runPolicyAckStatus(cmd *cobra.Command, args []string) error {
+	store, err := openLocalStorage()
+	if err != nil {
+		return err
+	}
+	period, err := ackPeriod(cmd, time.Now())
+	if err != nil {
+		return err
+	}
+	roster, err := store.GetEmployeeRoster()
+	if err != nil {
+		return err
+	}
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		policy, _, err := resolveAckPolicy(store, args[0])
+		if err != nil {
+			return err
+		}
+		acks, err := store.GetPolicyAcknowledgments(policy, period)
+		if err != nil {
+			return err
+		}
+		rate := domain.CalculateAcknowledgmentRate(acks, roster)
+		if isStructuredOutput(format) {
+			return writeStructured(cmd, format, rate)
+		}
+		showAckRate(cmd, rate)
+		return nil
+	}
+
+	records, err := tools.CollectPolicyAcknowledgments(store, period)
+	if err != nil {
+		return err
+	}
+	rates := make([]domain.AcknowledgmentRate, len(records))
+	for i := range records {
+		rates[i] = domain.CalculateAcknowledgmentRate(&records[i], roster)
+	}
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, rates)
+	}
+	if len(rates) == 0 {
+		cmd.Printf("No acknowledgments recorded for %s. Import them with: grctool policy ack import <file.csv> --policy <policy>\n", period)
+		return nil
+	}
+	if roster == nil {
+		cmd.Println("⚠️  No employee roster: import one with 'grctool policy ack roster' to measure rates")
+	}
+	cmd.Printf("%-32s %-8s %-12s %-9s %s\n", "POLICY", "PERIOD", "ACKNOWLEDGED", "RATE", "PENDING")
+	for _, rate := range rates {
+		cmd.Printf("%-32s %-8s %-12s %-9s %d\n", rate.Policy, rate.Period, ackCount(rate), ackPercent(rate), len(rate.Pending))
+	}
+	return nil
+}
+
+func ackCount(rate domain.AcknowledgmentRate) string {
+	if rate.Expected == 0 {
+		return strconv.Itoa(rate.Acknowledged)
+	}
+	return fmt.Sprintf("%d/%d", rate.Acknowledged, rate.Expected)
+}
+
+func ackPercent(rate domain.AcknowledgmentRate) string {
+	if rate.Expected == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", rate.Rate)
+}
+
+func showAckRate(cmd *cobra.Command, rate domain.AcknowledgmentRate) {
+	cmd.Printf("%s (%s): %s acknowledged", rate.Policy, rate.Period, ackCount(rate))
+	if rate.Expected > 0 {
+		cmd.Printf(" (%s)", ackPercent(rate))
+	}
+	cmd.Println()
+	if rate.Expected == 0 {
+		cmd.Println("⚠️  No employee roster: import one with 'grctool policy ack roster' to measure the rate")
+		return
+	}
+	if rate.OffRoster > 0 {
+		cmd.Printf("  %d acknowledgments by people not on the roster\n", rate.OffRoster)
+	}
+	if len(rate.Pending) == 0 {
+		return
+	}
+	cmd.Printf("\nPending (%d):\n", len(rate.Pending))
+	for _, employee := range rate.Pending {
+		if employee.Name != "" {
+			cmd.Printf("  %s <%s>\n", employee.Name, employee.Email)
+		} else {
+			cmd.Printf("  %s\n", employee.Email)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPolicyAckImportFlagsCmd returns a command with the flags of policy ack import
+func newPolicyAckImportFlagsCmd(args ...string) *cobra.Command {
+	cmd := &cobra.Command{Use: "import"}
+	addPolicyAckImportFlags(cmd)
+	_ = cmd.Flags().Parse(args)
+	return cmd
+}
+
+// newPolicyAckRosterFlagsCmd returns a command with the flags of policy ack roster
+func newPolicyAckRosterFlagsCmd(args ...string) *cobra.Command {
+	cmd := &cobra.Command{Use: "roster"}
+	addPolicyAckRosterFlags(cmd)
+	_ = cmd.Flags().Parse(args)
+	return cmd
+}
+
+func TestImportAcknowledgments(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		args          []string
+		csv           string
+		source        string
+		added         int
+		duplicates    int
+		outsidePeriod int
+		invalid       []string
+		wantErr       string
+	}{
+		"google forms export": {
+			csv: "Timestamp,Email Address,Full Name,I agree\n" +
+				"2/3/2025 9:30:00,Alex@Example.com,Alex Kim,Yes\n" +
+				"2/4/2025 10:00:00,sam@example.com,Sam Lee,Yes\n" +
+				"2/5/2025 11:00:00,alex@example.com,Alex Kim,Yes\n" +
+				"12/30/2024 11:00:00,kim@example.com,Kim,Yes\n",
+			source: domain.AcknowledgmentSourceGoogleForms, added: 2, duplicates: 1, outsidePeriod: 1,
+		},
+		"hris export with named columns": {
+			args: []string{"--email-column", "Work Email", "--date-column", "Signed On"},
+			csv: "Employee,Work Email,Signed On\n" +
+				"Alex Kim,alex@example.com,2025-02-03\n" +
+				"Sam Lee,sam,2025-02-03\n" +
+				"Kim,kim@example.com,yesterday\n" +
+				",,\n",
+			source: domain.AcknowledgmentSourceCSV, added: 1,
+			invalid: []string{`line 3: invalid email "sam"`, `line 4: unrecognized date "yesterday"`},
+		},
+		"explicit source": {
+			args:   []string{"--source", "google-forms"},
+			csv:    "email,acknowledged_at\nalex@example.com,2025-02-03T09:30:00Z\n",
+			source: domain.AcknowledgmentSourceGoogleForms, added: 1,
+		},
+		"missing named column": {
+			args:    []string{"--email-column", "Work Email"},
+			csv:     "email,date\n",
+			wantErr: `column "Work Email" not found`,
+		},
+		"no date column": {
+			csv:     "email,name\nalex@example.com,Alex\n",
+			wantErr: "no date column found",
+		},
+		"invalid source": {
+			args:    []string{"--source", "email"},
+			csv:     "email,date\n",
+			wantErr: `invalid --source "email"`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			acks := &domain.PolicyAcknowledgments{Policy: "acceptable-use-policy", Period: "2025"}
+			result, err := importAcknowledgments(newPolicyAckImportFlagsCmd(tc.args...), strings.NewReader(tc.csv), acks, "1.0")
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.source, result.Source)
+			assert.Equal(t, tc.added, result.Added)
+			assert.Equal(t, tc.duplicates, result.Duplicates)
+			assert.Equal(t, tc.outsidePeriod, result.OutsidePeriod)
+			if tc.invalid == nil {
+				tc.invalid = []string{}
+			}
+			assert.Equal(t, tc.invalid, result.Invalid)
+			assert.Len(t, acks.Acknowledgments, tc.added)
+			require.NoError(t, acks.Validate())
+		})
+	}
+}
+
+func TestImportAcknowledgments_KeepsEarliest(t *testing.T) {
+	t.Parallel()
+
+	acks := &domain.PolicyAcknowledgments{Policy: "acceptable-use-policy", Period: "2025-Q1"}
+	_, err := importAcknowledgments(newPolicyAckImportFlagsCmd(), strings.NewReader(
+		"Timestamp,Email Address\n3/1/2025 8:00:00,alex@example.com\n1/15/2025 8:00:00,alex@example.com\n"), acks, "1.0")
+	require.NoError(t, err)
+	ack, ok := acks.Find("alex@example.com")
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC), ack.AcknowledgedAt)
+	assert.Equal(t, "1.0", ack.Version)
+}
+
+func TestParseEmployeeRoster(t *testing.T) {
+	t.Parallel()
+
+	csv := "Name,Email,Department,Status\n" +
+		"Alex Kim,Alex@Example.com,Engineering,Active\n" +
+		"Sam Lee,sam@example.com,Sales,Terminated\n" +
+		"Kim,kim@example.com,,\n" +
+		"Duplicate,alex@example.com,Engineering,Active\n" +
+		"No Email,,Sales,Active\n"
+	employees, err := parseEmployeeRoster(newPolicyAckRosterFlagsCmd(), strings.NewReader(csv))
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Employee{
+		{Email: "alex@example.com", Name: "Alex Kim", Department: "Engineering"},
+		{Email: "kim@example.com", Name: "Kim"},
+	}, employees)
+
+	employees, err = parseEmployeeRoster(newPolicyAckRosterFlagsCmd("--email-column", "Work Email", "--department-column", "Org"),
+		strings.NewReader("Work Email,Org\nalex@example.com,Eng\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Employee{{Email: "alex@example.com", Department: "Eng"}}, employees)
+
+	_, err = parseEmployeeRoster(newPolicyAckRosterFlagsCmd(), strings.NewReader("Name,Department\nAlex,Eng\n"))
+	assert.ErrorContains(t, err, "no email column found")
+}
+
+func TestAckPeriod(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	cmd := &cobra.Command{Use: "status"}
+	addAckPeriodFlag(cmd)
+	period, err := ackPeriod(cmd, now)
+	require.NoError(t, err)
+	assert.Equal(t, "2025", period)
+
+	require.NoError(t, cmd.Flags().Set("period", "2025-Q3"))
+	period, err = ackPeriod(cmd, now)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-Q3", period)
+
+	require.NoError(t, cmd.Flags().Set("period", "Q3"))
+	_, err = ackPeriod(cmd, now)
+	assert.ErrorContains(t, err, "invalid acknowledgment period")
+}
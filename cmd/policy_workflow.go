@@ -0,0 +1,428 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+// authoredPolicyTemplate is the body of a new policy draft; %s is the title
+const authoredPolicyTemplate = `# %s
+
+## Purpose
+
+## Scope
+
+## Policy
+
+## Roles and Responsibilities
+
+## Exceptions
+
+## Enforcement
+
+## Review
+
+This policy is reviewed at least annually and approved by the approvers listed above.
+`
+
+var policyDraftCmd = &cobra.Command{
+	Use:   "draft <title-or-id>",
+	Short: "Start a policy draft or a new revision of an approved policy",
+	Long: `Create a policy as markdown with YAML front matter (title, owner, version,
+status, approvers and review dates) under docs/policies/authored in the data
+directory, then edit the markdown directly.
+
+Drafting a new title creates version 1.0 from a template, or from --from.
+Drafting an approved policy starts its next revision (1.0 becomes 1.1);
+drafting a policy in review returns it to draft. The front matter of a draft
+is updated from the flags given.
+
+Examples:
+  grctool policy draft "Access Control Policy" --owner alex@example.com \
+    --approver cto@example.com --approver ciso@example.com
+
+  # Import existing markdown
+  grctool policy draft "Encryption Policy" --from ./encryption.md`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPolicyDraft,
+}
+
+var policyReviewCmd = &cobra.Command{
+	Use:   "review [policy]",
+	Short: "Submit a draft for approval, or show annual review status",
+	Long: `With a policy, submit its draft for approval by its approvers.
+
+Without one, list the authored policies with their version, approvals and
+annual review status: current, due_soon (within 30 days), overdue or
+never_approved.
+
+Examples:
+  grctool policy review access-control-policy
+  grctool policy review --due`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPolicyReview,
+}
+
+var policyApproveCmd = &cobra.Command{
+	Use:   "approve <policy>",
+	Short: "Record an approver's approval of a policy in review",
+	Long: `Record an approval of the version of a policy in review. Once every listed
+approver has approved it (or on the first approval, if none are listed) the
+policy is approved and its next review is scheduled a year later, or after
+lifecycle.policy_review_cadence when configured (e.g. "90d", "6m", "1y").
+
+Examples:
+  grctool policy approve access-control-policy --approver cto@example.com`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPolicyApprove,
+}
+
+func init() {
+	policyCmd.AddCommand(policyDraftCmd)
+	policyCmd.AddCommand(policyReviewCmd)
+	policyCmd.AddCommand(policyApproveCmd)
+
+	addPolicyDraftFlags(policyDraftCmd)
+	policyReviewCmd.Flags().Bool("due", false, "only policies whose annual review is due, overdue or that were never approved")
+	addPolicyApproveFlags(policyApproveCmd)
+	_ = policyApproveCmd.MarkFlagRequired("approver")
+}
+
+// addPolicyDraftFlags adds the flags setting a draft's front matter and body
+func addPolicyDraftFlags(c *cobra.Command) {
+	c.Flags().String("owner", "", "person accountable for the policy")
+	c.Flags().StringSlice("approver", nil, "approver of each version, replacing the current list (repeatable)")
+	c.Flags().String("version", "", "version of the draft (default: 1.0, or the next revision)")
+	c.Flags().String("from", "", "markdown file to use as the policy body")
+}
+
+// addPolicyApproveFlags adds the flags recording an approval
+func addPolicyApproveFlags(c *cobra.Command) {
+	c.Flags().String("approver", "", "who is approving")
+	c.Flags().String("date", "", "approval date (YYYY-MM-DD, default today)")
+	c.Flags().String("next-review", "", "next review date (YYYY-MM-DD, default from the review cadence)")
+}
+
+// authoredPolicyInfo is an authored policy as shown by the policy workflow
+// commands, with its review status and file
+type authoredPolicyInfo struct {
+	domain.AuthoredPolicy
+	ReviewStatus     string   `json:"review_status"`
+	PendingApprovers []string `json:"pending_approvers"`
+	Path             string   `json:"path"`
+}
+
+func newAuthoredPolicyInfo(store *storage.Storage, policy domain.AuthoredPolicy, now time.Time) authoredPolicyInfo {
+	return authoredPolicyInfo{
+		AuthoredPolicy:   policy,
+		ReviewStatus:     policy.ReviewStatus(now),
+		PendingApprovers: policy.PendingApprovers(),
+		Path:             store.AuthoredPolicyPath(policy.ID),
+	}
+}
+
+func runPolicyDraft(cmd *cobra.Command, args []string) error {
+	store, err := openLocalStorage()
+	if err != nil {
+		return err
+	}
+
+	policy, err := store.GetAuthoredPolicy(args[0])
+	if err != nil && !errors.Is(err, storage.ErrAuthoredPolicyNotFound) {
+		return err
+	}
+	policy, verb, err := draftAuthoredPolicy(cmd, policy, args[0])
+	if err != nil {
+		return err
+	}
+	if policy.ID == "" {
+		return fmt.Errorf("cannot derive a policy ID from %q", args[0])
+	}
+	if err := store.SaveAuthoredPolicy(policy); err != nil {
+		return fmt.Errorf("failed to save policy: %w", err)
+	}
+
+	if err := showAuthoredPolicy(cmd, store, policy, verb); err != nil {
+		return err
+	}
+	if format, _ := outputFormat(cmd); !isStructuredOutput(format) {
+		cmd.Printf("\nEdit %s, then submit it with: grctool policy review %s\n", store.AuthoredPolicyPath(policy.ID), policy.ID)
+	}
+	return nil
+}
+
+// draftAuthoredPolicy creates a policy titled ref when policy is nil, starts
+// the next revision of an approved policy, or returns a policy in review to
+// draft, then applies the draft flags
+func draftAuthoredPolicy(cmd *cobra.Command, policy *domain.AuthoredPolicy, ref string) (*domain.AuthoredPolicy, string, error) {
+	flags := cmd.Flags()
+	version, _ := flags.GetString("version")
+
+	var verb string
+	switch {
+	case policy == nil:
+		title := strings.TrimSpace(ref)
+		policy = &domain.AuthoredPolicy{
+			ID:      storage.AuthoredPolicyID(title),
+			Title:   title,
+			Version: "1.0",
+			Body:    fmt.Sprintf(authoredPolicyTemplate, title),
+		}
+		verb = "📝 Drafted"
+	case policy.Status == domain.AuthoredPolicyApproved:
+		policy.Version = domain.NextPolicyVersion(policy.Version)
+		verb = "📝 Started revision of"
+	case policy.Status == domain.AuthoredPolicyInReview:
+		verb = "↩️  Returned to draft"
+	default:
+		verb = "📝 Updated draft"
+	}
+	policy.Status = domain.AuthoredPolicyDraft
+
+	if version != "" {
+		policy.Version = version
+	}
+	if flags.Changed("owner") {
+		policy.Owner, _ = flags.GetString("owner")
+	}
+	if flags.Changed("approver") {
+		policy.Approvers, _ = flags.GetStringSlice("approver")
+	}
+	if from, _ := flags.GetString("from"); from != "" {
+		body, err := os.ReadFile(from)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read policy body: %w", err)
+		}
+		policy.Body = string(body)
+	}
+	return policy, verb, nil
+}
+
+func runPolicyReview(cmd *cobra.Command, args []string) error {
+	store, err := openLocalStorage()
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		due, _ := cmd.Flags().GetBool("due")
+		return listPolicyReviews(cmd, store, due)
+	}
+
+	policy, err := store.GetAuthoredPolicy(args[0])
+	if err != nil {
+		return err
+	}
+	if policy.Status != domain.AuthoredPolicyDraft {
+		return fmt.Errorf("policy %s is %s, not a draft: start a revision with 'grctool policy draft %s'",
+			policy.ID, policy.Status, policy.ID)
+	}
+	policy.Status = domain.AuthoredPolicyInReview
+	if err := store.SaveAuthoredPolicy(policy); err != nil {
+		return fmt.Errorf("failed to save policy: %w", err)
+	}
+
+	return showAuthoredPolicy(cmd, store, policy, "📤 Submitted for review:")
+}
+
+func listPolicyReviews(cmd *cobra.Command, store *storage.Storage, dueOnly bool) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	all, err := store.GetAllAuthoredPolicies()
+	if err != nil {
+		return fmt.Errorf("failed to load policies: %w", err)
+	}
+
+	now := time.Now()
+	infos := []authoredPolicyInfo{}
+	for _, policy := range all {
+		info := newAuthoredPolicyInfo(store, policy, now)
+		if dueOnly && info.ReviewStatus == domain.PolicyReviewCurrent {
+			continue
+		}
+		infos = append(infos, info)
+	}
+
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, infos)
+	}
+	if len(infos) == 0 {
+		cmd.Println("No authored policies found. Start one with: grctool policy draft <title>")
+		return nil
+	}
+
+	cmd.Printf("%-32s %-7s %-8s %-11s %-11s %-14s %s\n", "POLICY", "VERSION", "STATUS", "APPROVED", "NEXT REVIEW", "REVIEW", "PENDING")
+	for _, info := range infos {
+		cmd.Printf("%-32s %-7s %-8s %-11s %-11s %-14s %s\n", info.ID, info.Version, info.Status,
+			orDash(info.ApprovedDate), orDash(info.ReviewDate), info.ReviewStatus,
+			orDash(strings.Join(info.PendingApprovers, ", ")))
+	}
+	cmd.Printf("\n%d policies\n", len(infos))
+	return nil
+}
+
+func runPolicyApprove(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	policy, err := store.GetAuthoredPolicy(args[0])
+	if err != nil {
+		return err
+	}
+	if err := approveAuthoredPolicy(cmd, policy, cfg.Lifecycle.PolicyReviewCadence, time.Now()); err != nil {
+		return err
+	}
+	if err := store.SaveAuthoredPolicy(policy); err != nil {
+		return fmt.Errorf("failed to save policy: %w", err)
+	}
+
+	if policy.Status == domain.AuthoredPolicyApproved {
+		return showAuthoredPolicy(cmd, store, policy, "✅ Approved")
+	}
+	return showAuthoredPolicy(cmd, store, policy, "🖊️  Approval recorded for")
+}
+
+// approveAuthoredPolicy records the --approver's approval of a policy in
+// review, approving the policy once no approvers are pending
+func approveAuthoredPolicy(cmd *cobra.Command, policy *domain.AuthoredPolicy, cadence string, now time.Time) error {
+	approver, _ := cmd.Flags().GetString("approver")
+	approver = strings.TrimSpace(approver)
+	if approver == "" {
+		return fmt.Errorf("--approver is required")
+	}
+	if policy.Status != domain.AuthoredPolicyInReview {
+		return fmt.Errorf("policy %s is %s, not in review: submit it with 'grctool policy review %s'",
+			policy.ID, policy.Status, policy.ID)
+	}
+	if len(policy.Approvers) > 0 && !slices.ContainsFunc(policy.Approvers, func(a string) bool { return strings.EqualFold(a, approver) }) {
+		return fmt.Errorf("%s is not an approver of %s (approvers: %s)", approver, policy.ID, strings.Join(policy.Approvers, ", "))
+	}
+	if policy.HasApproved(approver) {
+		return fmt.Errorf("%s has already approved %s version %s", approver, policy.ID, policy.Version)
+	}
+
+	approved, err := parseDateFlag(cmd, "date")
+	if err != nil {
+		return err
+	}
+	if approved == nil {
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		approved = &today
+	}
+	policy.Approvals = append(policy.Approvals, domain.PolicyApproval{
+		Approver: approver,
+		Date:     approved.Format("2006-01-02"),
+		Version:  policy.Version,
+	})
+	if len(policy.PendingApprovers()) > 0 {
+		return nil
+	}
+
+	next, err := parseDateFlag(cmd, "next-review")
+	if err != nil {
+		return err
+	}
+	if next == nil {
+		if next, err = nextPolicyReview(*approved, cadence); err != nil {
+			return err
+		}
+	}
+	policy.Status = domain.AuthoredPolicyApproved
+	policy.ApprovedDate = approved.Format("2006-01-02")
+	policy.ReviewDate = next.Format("2006-01-02")
+	return nil
+}
+
+var policyReviewCadencePattern = regexp.MustCompile(`^(\d+)\s*([dwmy])$`)
+
+// nextPolicyReview schedules the review after approved from a cadence such as
+// "90d", "6m" or "1y", or a year later when no cadence is configured
+func nextPolicyReview(approved time.Time, cadence string) (*time.Time, error) {
+	cadence = strings.ToLower(strings.TrimSpace(cadence))
+	var next time.Time
+	switch cadence {
+	case "", "annual", "annually", "yearly":
+		next = approved.AddDate(1, 0, 0)
+	case "semiannual", "semi-annual":
+		next = approved.AddDate(0, 6, 0)
+	case "quarterly":
+		next = approved.AddDate(0, 3, 0)
+	default:
+		match := policyReviewCadencePattern.FindStringSubmatch(cadence)
+		if match == nil {
+			return nil, fmt.Errorf("invalid lifecycle.policy_review_cadence %q: use e.g. 90d, 6m or 1y", cadence)
+		}
+		n, _ := strconv.Atoi(match[1])
+		switch match[2] {
+		case "d":
+			next = approved.AddDate(0, 0, n)
+		case "w":
+			next = approved.AddDate(0, 0, 7*n)
+		case "m":
+			next = approved.AddDate(0, n, 0)
+		case "y":
+			next = approved.AddDate(n, 0, 0)
+		}
+	}
+	return &next, nil
+}
+
+func showAuthoredPolicy(cmd *cobra.Command, store *storage.Storage, policy *domain.AuthoredPolicy, verb string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, newAuthoredPolicyInfo(store, *policy, now))
+	}
+
+	cmd.Printf("%s %s v%s (%s)\n", verb, policy.ID, policy.Version, policy.Status)
+	if policy.Owner != "" {
+		cmd.Printf("  Owner:       %s\n", policy.Owner)
+	}
+	if len(policy.Approvers) > 0 {
+		cmd.Printf("  Approvers:   %s\n", strings.Join(policy.Approvers, ", "))
+	}
+	if pending := policy.PendingApprovers(); len(pending) > 0 && policy.Status == domain.AuthoredPolicyInReview {
+		cmd.Printf("  Pending:     %s\n", strings.Join(pending, ", "))
+	}
+	if policy.ApprovedDate != "" {
+		cmd.Printf("  Approved:    %s, next review %s (%s)\n", policy.ApprovedDate, policy.ReviewDate,
+			strings.ReplaceAll(policy.ReviewStatus(now), "_", " "))
+	}
+	return nil
+}
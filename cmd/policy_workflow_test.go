@@ -0,0 +1,140 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPolicyDraftFlagsCmd returns a command with the flags of policy draft
+func newPolicyDraftFlagsCmd(args ...string) *cobra.Command {
+	cmd := &cobra.Command{Use: "draft"}
+	addPolicyDraftFlags(cmd)
+	_ = cmd.Flags().Parse(args)
+	return cmd
+}
+
+// newPolicyApproveFlagsCmd returns a command with the flags of policy approve
+func newPolicyApproveFlagsCmd(args ...string) *cobra.Command {
+	cmd := &cobra.Command{Use: "approve"}
+	addPolicyApproveFlags(cmd)
+	_ = cmd.Flags().Parse(args)
+	return cmd
+}
+
+func TestDraftAuthoredPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy, verb, err := draftAuthoredPolicy(newPolicyDraftFlagsCmd("--owner", "alex@example.com",
+		"--approver", "cto@example.com", "--approver", "ciso@example.com"), nil, " Access Control Policy ")
+	require.NoError(t, err)
+	assert.Equal(t, "📝 Drafted", verb)
+	assert.Equal(t, "access-control-policy", policy.ID)
+	assert.Equal(t, "Access Control Policy", policy.Title)
+	assert.Equal(t, "1.0", policy.Version)
+	assert.Equal(t, domain.AuthoredPolicyDraft, policy.Status)
+	assert.Equal(t, []string{"cto@example.com", "ciso@example.com"}, policy.Approvers)
+	assert.Contains(t, policy.Body, "# Access Control Policy\n\n## Purpose")
+
+	// A revision of an approved policy keeps its approvals and review date
+	policy.Status = domain.AuthoredPolicyApproved
+	policy.ApprovedDate, policy.ReviewDate = "2025-09-01", "2026-09-01"
+	policy, verb, err = draftAuthoredPolicy(newPolicyDraftFlagsCmd(), policy, "access-control-policy")
+	require.NoError(t, err)
+	assert.Equal(t, "📝 Started revision of", verb)
+	assert.Equal(t, "1.1", policy.Version)
+	assert.Equal(t, domain.AuthoredPolicyDraft, policy.Status)
+	assert.Equal(t, "alex@example.com", policy.Owner, "flags not given are kept")
+	assert.Equal(t, "2026-09-01", policy.ReviewDate)
+
+	body := filepath.Join(t.TempDir(), "policy.md")
+	require.NoError(t, os.WriteFile(body, []byte("# Imported\n"), 0644))
+	policy.Status = domain.AuthoredPolicyInReview
+	policy, verb, err = draftAuthoredPolicy(newPolicyDraftFlagsCmd("--from", body, "--version", "2.0"), policy, "x")
+	require.NoError(t, err)
+	assert.Equal(t, "↩️  Returned to draft", verb)
+	assert.Equal(t, "2.0", policy.Version)
+	assert.Equal(t, "# Imported\n", policy.Body)
+}
+
+func TestApproveAuthoredPolicy(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 9, 1, 15, 0, 0, 0, time.UTC)
+	newPolicy := func() *domain.AuthoredPolicy {
+		return &domain.AuthoredPolicy{ID: "access-control-policy", Title: "Access Control Policy", Version: "1.0",
+			Status: domain.AuthoredPolicyInReview, Approvers: []string{"cto@example.com", "ciso@example.com"}}
+	}
+
+	policy := newPolicy()
+	require.NoError(t, approveAuthoredPolicy(newPolicyApproveFlagsCmd("--approver", "CTO@example.com"), policy, "", now))
+	assert.Equal(t, domain.AuthoredPolicyInReview, policy.Status, "still pending the CISO")
+	assert.Equal(t, []domain.PolicyApproval{{Approver: "CTO@example.com", Date: "2025-09-01", Version: "1.0"}}, policy.Approvals)
+
+	err := approveAuthoredPolicy(newPolicyApproveFlagsCmd("--approver", "cto@example.com"), policy, "", now)
+	assert.ErrorContains(t, err, "has already approved access-control-policy version 1.0")
+	err = approveAuthoredPolicy(newPolicyApproveFlagsCmd("--approver", "intern@example.com"), policy, "", now)
+	assert.ErrorContains(t, err, "is not an approver")
+
+	require.NoError(t, approveAuthoredPolicy(newPolicyApproveFlagsCmd("--approver", "ciso@example.com", "--date", "2025-09-02"), policy, "", now))
+	assert.Equal(t, domain.AuthoredPolicyApproved, policy.Status)
+	assert.Equal(t, "2025-09-02", policy.ApprovedDate)
+	assert.Equal(t, "2026-09-02", policy.ReviewDate, "reviewed annually by default")
+
+	err = approveAuthoredPolicy(newPolicyApproveFlagsCmd("--approver", "cto@example.com"), policy, "", now)
+	assert.ErrorContains(t, err, "not in review")
+
+	policy = newPolicy()
+	policy.Approvers = nil
+	require.NoError(t, approveAuthoredPolicy(newPolicyApproveFlagsCmd("--approver", "ceo@example.com"), policy, "90d", now))
+	assert.Equal(t, domain.AuthoredPolicyApproved, policy.Status, "any approver approves a policy without approvers")
+	assert.Equal(t, "2025-11-30", policy.ReviewDate, "review cadence from config")
+
+	policy = newPolicy()
+	policy.Approvers = nil
+	require.NoError(t, approveAuthoredPolicy(newPolicyApproveFlagsCmd("--approver", "ceo@example.com", "--next-review", "2026-01-15"), policy, "", now))
+	assert.Equal(t, "2026-01-15", policy.ReviewDate)
+}
+
+func TestNextPolicyReview(t *testing.T) {
+	t.Parallel()
+
+	approved := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		cadence string
+		want    string
+		err     string
+	}{
+		"default":   {cadence: "", want: "2026-01-31"},
+		"annual":    {cadence: "Annual", want: "2026-01-31"},
+		"quarterly": {cadence: "quarterly", want: "2025-05-01"},
+		"days":      {cadence: "90d", want: "2025-05-01"},
+		"weeks":     {cadence: "2w", want: "2025-02-14"},
+		"months":    {cadence: "6m", want: "2025-07-31"},
+		"years":     {cadence: "2y", want: "2027-01-31"},
+		"invalid":   {cadence: "often", err: `invalid lifecycle.policy_review_cadence "often"`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			next, err := nextPolicyReview(approved, tc.cadence)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, next.Format("2006-01-02"))
+		})
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage profiles for working with several Tugboat organizations",
+	Long: `Manage named profiles, each holding the settings of one Tugboat organization.
+
+Profiles are defined under profiles in the config file. Each lists only the
+settings that differ from the top-level configuration, typically credentials,
+data directories and collector URLs:
+
+  profile: client-a
+  profiles:
+    client-a:
+      tugboat:
+        org_id: "12345"
+        collector_urls:
+          ET-0001: https://openapi.tugboatlogic.com/api/v0/evidence/collector/111/
+      storage:
+        data_dir: ./clients/a
+    client-b:
+      tugboat:
+        org_id: "67890"
+        password: ${CLIENT_B_TUGBOAT_PASSWORD}
+      storage:
+        data_dir: ./clients/b
+
+The active profile is chosen by --profile, then GRCTOOL_PROFILE, then the
+config file's profile setting. 'grctool auth login' saves credentials to the
+active profile.
+
+Examples:
+  # List profiles
+  grctool profile list
+
+  # Make client-b the default
+  grctool profile use client-b
+
+  # Sync one client without switching
+  grctool --profile client-a sync`,
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the configured profiles",
+	Args:  cobra.NoArgs,
+	RunE:  runProfileList,
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:               "use <profile>",
+	Short:             "Set the profile used by default",
+	Long:              `Set the profile used when neither --profile nor GRCTOOL_PROFILE is given, by saving it in the config file.`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runProfileUse,
+	ValidArgsFunction: completeProfileNames,
+}
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileUseCmd)
+}
+
+// profileInfo is a profile as shown by profile list
+type profileInfo struct {
+	Name        string `json:"name"`
+	Active      bool   `json:"active"`
+	Description string `json:"description,omitempty"`
+	OrgID       string `json:"org_id,omitempty"`
+	DataDir     string `json:"data_dir,omitempty"`
+}
+
+func runProfileList(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadWithoutValidation()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	active := config.ActiveProfile()
+	profiles := make([]profileInfo, 0, len(cfg.Profiles))
+	for _, name := range config.ProfileNames() {
+		profile := cfg.Profiles[name]
+		profiles = append(profiles, profileInfo{
+			Name:        name,
+			Active:      name == active,
+			Description: profile.Description,
+			OrgID:       profile.Tugboat.OrgID,
+			DataDir:     profile.Storage.DataDir,
+		})
+	}
+
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, profiles)
+	}
+
+	if len(profiles) == 0 {
+		cmd.Println("No profiles configured. Add them under profiles in the config file (see 'grctool profile --help').")
+		return nil
+	}
+
+	cmd.Printf("  %-20s %-12s %-30s %s\n", "PROFILE", "ORG ID", "DATA DIR", "DESCRIPTION")
+	for _, p := range profiles {
+		marker := " "
+		if p.Active {
+			marker = "*"
+		}
+		cmd.Printf("%s %-20s %-12s %-30s %s\n", marker, p.Name, orDash(p.OrgID), orDash(p.DataDir), p.Description)
+	}
+	if active == "" {
+		cmd.Println("\nNo profile is active; the top-level configuration is used.")
+	}
+	return nil
+}
+
+func runProfileUse(cmd *cobra.Command, args []string) error {
+	name := strings.ToLower(strings.TrimSpace(args[0]))
+
+	names := config.ProfileNames()
+	if !slices.Contains(names, name) {
+		if len(names) == 0 {
+			return fmt.Errorf("profile %q is not defined: no profiles are configured", name)
+		}
+		return fmt.Errorf("profile %q is not defined; available profiles: %s", name, strings.Join(names, ", "))
+	}
+
+	path := viper.ConfigFileUsed()
+	if path == "" {
+		return fmt.Errorf("no config file found: run 'grctool init' first")
+	}
+	if err := setConfigProfile(path, name); err != nil {
+		return err
+	}
+
+	cmd.Printf("✅ Now using profile %s (saved in %s)\n", name, path)
+	if env := os.Getenv(config.ProfileEnvVar); env != "" && !strings.EqualFold(env, name) {
+		cmd.Printf("⚠️  %s=%s still overrides it in this shell\n", config.ProfileEnvVar, env)
+	}
+	return nil
+}
+
+// setConfigProfile sets the profile key of the config file at path, keeping
+// the rest of the file, comments included
+func setConfigProfile(path, name string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("config file %s is not a YAML mapping", path)
+	}
+
+	value := &yaml.Node{Kind: yaml.ScalarNode, Value: name}
+	updated := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "profile" {
+			root.Content[i+1] = value
+			updated = true
+		}
+	}
+	if !updated {
+		key := &yaml.Node{Kind: yaml.ScalarNode, Value: "profile"}
+		root.Content = append([]*yaml.Node{key, value}, root.Content...)
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to encode config file: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to encode config file: %w", err)
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// profileSection returns the settings map of the named profile in a config
+// file's contents, creating it if needed. Names match case-insensitively.
+func profileSection(configData map[string]interface{}, name string) map[string]interface{} {
+	profiles, ok := configData["profiles"].(map[string]interface{})
+	if !ok {
+		profiles = make(map[string]interface{})
+		configData["profiles"] = profiles
+	}
+	for key, settings := range profiles {
+		if section, ok := settings.(map[string]interface{}); ok && strings.EqualFold(key, name) {
+			return section
+		}
+	}
+	section := make(map[string]interface{})
+	profiles[name] = section
+	return section
+}
+
+func completeProfileNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return config.ProfileNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
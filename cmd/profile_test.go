@@ -0,0 +1,73 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetConfigProfile(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		content string
+		want    string
+	}{
+		"adds the key, keeping comments": {
+			content: "# Tugboat settings\ntugboat:\n  org_id: \"100\"\n",
+			want:    "profile: client-b\n# Tugboat settings\ntugboat:\n  org_id: \"100\"\n",
+		},
+		"replaces the key": {
+			content: "profile: client-a\ntugboat:\n  org_id: \"100\"\n",
+			want:    "profile: client-b\ntugboat:\n  org_id: \"100\"\n",
+		},
+		"empty file": {
+			content: "",
+			want:    "profile: client-b\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), ".grctool.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.content), 0600))
+
+			require.NoError(t, setConfigProfile(path, "client-b"))
+
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, string(data))
+			info, err := os.Stat(path)
+			require.NoError(t, err)
+			assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+		})
+	}
+}
+
+func TestProfileSection(t *testing.T) {
+	t.Parallel()
+
+	existing := map[string]interface{}{"tugboat": map[string]interface{}{"org_id": "111"}}
+	configData := map[string]interface{}{
+		"profiles": map[string]interface{}{"Client-A": existing},
+	}
+
+	section := profileSection(configData, "client-a")
+	section["storage"] = "updated"
+	assert.Equal(t, "updated", existing["storage"])
+
+	created := profileSection(configData, "client-b")
+	created["tugboat"] = map[string]interface{}{"org_id": "222"}
+	assert.Contains(t, configData["profiles"], "client-b")
+
+	empty := map[string]interface{}{}
+	profileSection(empty, "client-c")
+	assert.Equal(t, map[string]interface{}{"client-c": map[string]interface{}{}}, empty["profiles"])
+}
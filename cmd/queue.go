@@ -0,0 +1,348 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/services/submission"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var queueCmd = &cobra.Command{
+	Use:   "queue",
+	Short: "Send evidence submissions queued while offline",
+	Long: `Manage the offline submission queue. 'grctool evidence submit --queue'
+queues a submission when its target cannot be reached, and --offline queues it
+without trying, e.g. while preparing evidence in an air-gapped environment.
+The queue is kept in submissions/queue.yaml in the data directory.
+
+Examples:
+  # Show queued and sent submissions
+  grctool queue list
+
+  # Send the queued submissions, oldest first
+  grctool queue flush
+
+  # Drop a queued submission
+  grctool queue remove 3`,
+}
+
+var queueListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List queued submissions and the receipts of sent ones",
+	Args:  cobra.NoArgs,
+	RunE:  runQueueList,
+}
+
+var queueFlushCmd = &cobra.Command{
+	Use:   "flush",
+	Short: "Send the queued submissions, oldest first",
+	Long: `Send the queued submissions in the order they were queued. Each is
+validated and uploaded as 'grctool evidence submit' would, and its uploaded
+files move to .submitted/. A sent submission stays in the queue with its
+submission ID and receipts.
+
+Flushing stops at the first submission whose target is still unreachable, so
+no submission is sent ahead of an earlier one; run it again once the target
+can be reached. Submissions the target rejects, or that fail validation, are
+marked failed and skipped.`,
+	Args: cobra.NoArgs,
+	RunE: runQueueFlush,
+}
+
+var queueRemoveCmd = &cobra.Command{
+	Use:   "remove <id>",
+	Short: "Remove a submission from the queue without sending it",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runQueueRemove,
+}
+
+func init() {
+	rootCmd.AddCommand(queueCmd)
+	queueCmd.AddCommand(queueListCmd)
+	queueCmd.AddCommand(queueFlushCmd)
+	queueCmd.AddCommand(queueRemoveCmd)
+
+	queueListCmd.Flags().Bool("pending", false, "only submissions not sent yet")
+}
+
+// QueueFlushResult is the structured output of 'queue flush'
+type QueueFlushResult struct {
+	Sent        []models.QueuedSubmission `json:"sent"`
+	Failed      []models.QueuedSubmission `json:"failed"`
+	Remaining   int                       `json:"remaining"`             // Still queued
+	Unreachable string                    `json:"unreachable,omitempty"` // Error of the submission that stopped the flush
+}
+
+// submitFunc submits evidence to the named target, or the first target the
+// task is configured for when target is empty
+type submitFunc func(ctx context.Context, target string, req *submission.SubmitRequest) (*submission.SubmitResponse, error)
+
+func runQueueList(cmd *cobra.Command, args []string) error {
+	pendingOnly, _ := cmd.Flags().GetBool("pending")
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	store, err := openLocalStorage()
+	if err != nil {
+		return err
+	}
+	queue, err := store.LoadSubmissionQueue()
+	if err != nil {
+		return err
+	}
+	entries := queue.Entries
+	if pendingOnly {
+		entries = queue.Pending()
+	}
+
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, entries)
+	}
+
+	if len(entries) == 0 {
+		cmd.Println("No queued submissions. Queue one with: grctool evidence submit <task> --window <window> --queue")
+		return nil
+	}
+
+	cmd.Printf("%-4s %-8s %-10s %-10s %-17s %s\n", "ID", "STATUS", "TASK", "WINDOW", "QUEUED", "DETAILS")
+	for _, entry := range entries {
+		details := entry.Reason
+		switch {
+		case entry.Status == "sent":
+			details = "submission " + entry.SubmissionID
+			if len(entry.Receipts) > 0 {
+				filenames := make([]string, 0, len(entry.Receipts))
+				for filename := range entry.Receipts {
+					filenames = append(filenames, filename)
+				}
+				sort.Strings(filenames)
+				for _, filename := range filenames {
+					details += fmt.Sprintf(", %s: %s", filename, entry.Receipts[filename])
+				}
+			}
+		case entry.LastError != "":
+			details = entry.LastError
+		}
+		cmd.Printf("%-4d %-8s %-10s %-10s %-17s %s\n",
+			entry.ID, entry.Status, entry.TaskRef, entry.Window, entry.QueuedAt.Local().Format("2006-01-02 15:04"), details)
+	}
+	cmd.Printf("\n%d queued\n", len(queue.Pending()))
+	return nil
+}
+
+func runQueueFlush(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	queue, err := store.LoadSubmissionQueue()
+	if err != nil {
+		return err
+	}
+
+	submit := func(ctx context.Context, target string, req *submission.SubmitRequest) (*submission.SubmitResponse, error) {
+		return newSubmissionService(cfg, store, target, false).Submit(ctx, req)
+	}
+	result, err := flushQueue(context.Background(), store, queue, submit, time.Now)
+	if err != nil {
+		return err
+	}
+
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, result)
+	}
+
+	if len(result.Sent) == 0 && len(result.Failed) == 0 && result.Unreachable == "" {
+		cmd.Println("No queued submissions to send")
+		return nil
+	}
+	for _, entry := range result.Sent {
+		cmd.Printf("✅ #%d %s/%s sent: submission %s\n", entry.ID, entry.TaskRef, entry.Window, entry.SubmissionID)
+		if entry.LastError != "" {
+			cmd.Printf("   ⚠️  %s\n", entry.LastError)
+		}
+	}
+	for _, entry := range result.Failed {
+		cmd.Printf("❌ #%d %s/%s failed: %s\n", entry.ID, entry.TaskRef, entry.Window, entry.LastError)
+	}
+	if result.Unreachable != "" {
+		cmd.Printf("\n📥 Stopped: %s\n", result.Unreachable)
+		cmd.Printf("%d submission(s) remain queued. Run 'grctool queue flush' again once the target can be reached.\n", result.Remaining)
+	}
+	return nil
+}
+
+// flushQueue sends the queued submissions oldest first, saving the queue
+// after each. It stops at the first submission whose target is still
+// unreachable, so none is sent ahead of an earlier one.
+func flushQueue(ctx context.Context, store *storage.Storage, queue *models.SubmissionQueue, submit submitFunc, now func() time.Time) (*QueueFlushResult, error) {
+	result := &QueueFlushResult{Sent: []models.QueuedSubmission{}, Failed: []models.QueuedSubmission{}}
+	for i := range queue.Entries {
+		entry := &queue.Entries[i]
+		if entry.Status != "queued" {
+			continue
+		}
+
+		unreachable, err := flushQueuedSubmission(ctx, store, entry, submit, now)
+		if err != nil {
+			return nil, err
+		}
+		if err := store.SaveSubmissionQueue(queue); err != nil {
+			return nil, err
+		}
+		if unreachable {
+			result.Unreachable = entry.LastError
+			break
+		}
+		if entry.Status == "sent" {
+			result.Sent = append(result.Sent, *entry)
+		} else {
+			result.Failed = append(result.Failed, *entry)
+		}
+	}
+	result.Remaining = len(queue.Pending())
+	return result, nil
+}
+
+// flushQueuedSubmission sends one queued submission and records the outcome
+// on it, reporting whether its target was unreachable. The window is locked
+// until its uploaded files have moved to .submitted/.
+func flushQueuedSubmission(ctx context.Context, store *storage.Storage, entry *models.QueuedSubmission, submit submitFunc, now func() time.Time) (bool, error) {
+	lock, err := store.LockWindow(entry.TaskRef, entry.Window)
+	if err != nil {
+		return false, err
+	}
+	defer lock.Unlock()
+
+	entry.Attempts++
+	if !entry.RetryFailed {
+		if submitted, _ := store.CheckAlreadySubmitted(entry.TaskRef, entry.Window); submitted {
+			entry.Status, entry.LastError = "failed", "already submitted"
+			return false, nil
+		}
+	}
+
+	resp, err := submit(ctx, entry.Target, &submission.SubmitRequest{
+		TaskRef:        entry.TaskRef,
+		Window:         entry.Window,
+		Notes:          entry.Notes,
+		SkipValidation: entry.SkipValidation,
+		SubmittedBy:    entry.SubmittedBy,
+		RetryFailed:    entry.RetryFailed,
+	})
+	if err != nil {
+		entry.LastError = err.Error()
+		if errors.Is(err, submission.ErrTargetUnreachable) {
+			return true, nil
+		}
+		entry.Status = "failed"
+		return false, nil
+	}
+	if !resp.Success {
+		entry.Status, entry.LastError = "failed", resp.Message
+		return false, nil
+	}
+
+	sentAt := now()
+	entry.Status, entry.LastError = "sent", ""
+	entry.SentAt = &sentAt
+	entry.SubmissionID = resp.SubmissionID
+	if resp.Submission != nil {
+		entry.Receipts = submissionReceipts(resp.Submission)
+		if len(resp.Submission.FailedFiles) > 0 {
+			entry.LastError = fmt.Sprintf("%d file(s) failed to upload; send them with 'grctool evidence submit %s --window %s --retry-failed'",
+				len(resp.Submission.FailedFiles), entry.TaskRef, entry.Window)
+		}
+		if err := store.MoveEvidenceFilesToSubmitted(entry.TaskRef, entry.Window, resp.Submission.UploadedFiles()); err != nil {
+			entry.LastError = fmt.Sprintf("sent, but failed to move files to .submitted/: %v", err)
+		}
+	}
+	return false, nil
+}
+
+// submissionReceipts returns the IDs the target assigned to the uploaded
+// files, or to the evidence package, of a submission
+func submissionReceipts(sub *models.EvidenceSubmission) map[string]string {
+	if sub.TugboatResponse == nil {
+		return nil
+	}
+	receipts := map[string]string{}
+	switch recorded := sub.TugboatResponse.Metadata["receipts"].(type) {
+	case map[string]string:
+		for filename, receipt := range recorded {
+			receipts[filename] = receipt
+		}
+	case map[string]interface{}:
+		for filename, receipt := range recorded {
+			receipts[filename] = fmt.Sprint(receipt)
+		}
+	}
+	if len(receipts) == 0 {
+		return nil
+	}
+	return receipts
+}
+
+func runQueueRemove(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid queue ID %q", args[0])
+	}
+
+	store, err := openLocalStorage()
+	if err != nil {
+		return err
+	}
+	queue, err := store.LoadSubmissionQueue()
+	if err != nil {
+		return err
+	}
+	for i, entry := range queue.Entries {
+		if entry.ID != id || entry.Status != "queued" {
+			continue
+		}
+		// Kept, so the IDs of removed submissions are never reused
+		queue.Entries[i].Status = "removed"
+		if err := store.SaveSubmissionQueue(queue); err != nil {
+			return err
+		}
+		cmd.Printf("✅ Removed #%d %s/%s from the queue\n", entry.ID, entry.TaskRef, entry.Window)
+		return nil
+	}
+	return fmt.Errorf("no queued submission #%d", id)
+}
@@ -0,0 +1,135 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/services/submission"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlushQueue(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+	store, err := storage.NewStorage(config.StorageConfig{DataDir: dataDir})
+	require.NoError(t, err)
+	windowDir := filepath.Join(dataDir, "evidence", "ET-0001", "2025-Q4")
+	require.NoError(t, os.MkdirAll(windowDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(windowDir, "access.csv"), []byte("user,role\n"), 0644))
+
+	queue := &models.SubmissionQueue{}
+	for _, taskRef := range []string{"ET-0001", "ET-0002", "ET-0003", "ET-0004"} {
+		queue.Enqueue(models.QueuedSubmission{TaskRef: taskRef, Window: "2025-Q4", Target: "vanta", SubmittedBy: "grctool-cli"})
+	}
+	queue.Entries[1].Status = "sent" // Sent by an earlier flush
+
+	var submitted []string
+	submit := func(ctx context.Context, target string, req *submission.SubmitRequest) (*submission.SubmitResponse, error) {
+		submitted = append(submitted, req.TaskRef)
+		assert.Equal(t, "vanta", target)
+		switch req.TaskRef {
+		case "ET-0001":
+			return &submission.SubmitResponse{Success: true, SubmissionID: "batch-1", Submission: &models.EvidenceSubmission{
+				TaskRef:         req.TaskRef,
+				Window:          req.Window,
+				EvidenceFiles:   []models.EvidenceFileRef{{Filename: "access.csv"}},
+				TugboatResponse: &models.TugboatSubmissionResponse{Metadata: map[string]interface{}{"receipts": map[string]string{"access.csv": "doc-file-1"}}},
+			}}, nil
+		case "ET-0003":
+			return nil, fmt.Errorf("Vanta submission failed: %w", submission.ErrTargetUnreachable)
+		}
+		return nil, errors.New("unexpected submission")
+	}
+	now := time.Date(2025, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	result, err := flushQueue(context.Background(), store, queue, submit, func() time.Time { return now })
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ET-0001", "ET-0003"}, submitted, "sent in order, stopping at the unreachable one")
+	require.Len(t, result.Sent, 1)
+	assert.Equal(t, "batch-1", result.Sent[0].SubmissionID)
+	assert.Equal(t, map[string]string{"access.csv": "doc-file-1"}, result.Sent[0].Receipts)
+	assert.Equal(t, &now, result.Sent[0].SentAt)
+	assert.Empty(t, result.Failed)
+	assert.Contains(t, result.Unreachable, "submission target unreachable")
+	assert.Equal(t, 2, result.Remaining)
+	assert.FileExists(t, filepath.Join(windowDir, ".submitted", "access.csv"))
+
+	saved, err := store.LoadSubmissionQueue()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sent", "sent", "queued", "queued"}, queueStatuses(saved))
+	assert.Equal(t, 1, saved.Entries[2].Attempts)
+	assert.Equal(t, 0, saved.Entries[3].Attempts)
+
+	// Once reachable, the rest are sent; failed ones are skipped
+	submit = func(ctx context.Context, target string, req *submission.SubmitRequest) (*submission.SubmitResponse, error) {
+		if req.TaskRef == "ET-0004" {
+			return &submission.SubmitResponse{Success: false, Message: "Evidence validation failed with 2 errors"}, nil
+		}
+		return &submission.SubmitResponse{Success: true, SubmissionID: "batch-3"}, nil
+	}
+	result, err = flushQueue(context.Background(), store, saved, submit, func() time.Time { return now })
+	require.NoError(t, err)
+	require.Len(t, result.Sent, 1)
+	assert.Equal(t, "ET-0003", result.Sent[0].TaskRef)
+	assert.Equal(t, 2, result.Sent[0].Attempts)
+	require.Len(t, result.Failed, 1)
+	assert.Equal(t, "Evidence validation failed with 2 errors", result.Failed[0].LastError)
+	assert.Zero(t, result.Remaining)
+	assert.Empty(t, result.Unreachable)
+
+	// A window submitted since it was queued is not submitted twice
+	saved.Enqueue(models.QueuedSubmission{TaskRef: "ET-0001", Window: "2025-Q4"})
+	result, err = flushQueue(context.Background(), store, saved, submit, func() time.Time { return now })
+	require.NoError(t, err)
+	require.Len(t, result.Failed, 1)
+	assert.Equal(t, 5, result.Failed[0].ID)
+	assert.Equal(t, "already submitted", result.Failed[0].LastError)
+}
+
+func queueStatuses(queue *models.SubmissionQueue) []string {
+	statuses := []string{}
+	for _, entry := range queue.Entries {
+		statuses = append(statuses, entry.Status)
+	}
+	return statuses
+}
+
+func TestSubmissionReceipts(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		response *models.TugboatSubmissionResponse
+		want     map[string]string
+	}{
+		"uploaded": {
+			response: &models.TugboatSubmissionResponse{Metadata: map[string]interface{}{"receipts": map[string]string{"access.csv": "doc-1"}}},
+			want:     map[string]string{"access.csv": "doc-1"},
+		},
+		"merged retry": {
+			response: &models.TugboatSubmissionResponse{Metadata: map[string]interface{}{"receipts": map[string]interface{}{"access.csv": "doc-1", "users.csv": "doc-2"}}},
+			want:     map[string]string{"access.csv": "doc-1", "users.csv": "doc-2"},
+		},
+		"no receipts": {response: &models.TugboatSubmissionResponse{}},
+		"no response": {},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, submissionReceipts(&models.EvidenceSubmission{TugboatResponse: tc.response}))
+		})
+	}
+}
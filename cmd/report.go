@@ -0,0 +1,191 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/services/export"
+	"github.com/grctool/grctool/internal/tools"
+	"github.com/spf13/cobra"
+)
+
+// Report formats accepted by 'report --format'
+const (
+	reportFormatMarkdown = "md"
+	reportFormatHTML     = "html"
+	reportFormatCSV      = "csv"
+)
+
+// reportCmd groups reports on the state of the compliance program
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate compliance program reports",
+	Long: `Generate reports on the compliance program from local storage, for
+management reviews and audit preparation.
+
+Run 'grctool sync' first so reports reflect current data.`,
+}
+
+var reportCoverageCmd = &cobra.Command{
+	Use:   "coverage",
+	Short: "Report per-criterion coverage of a framework",
+	Long: `Report, for each criterion of a framework (such as SOC 2 CC6.1), how many
+of its controls are implemented, how many of their evidence tasks have
+evidence submitted and accepted, and the gaps that remain.
+
+Controls are grouped by their framework codes; a control without codes for
+the framework is reported under its own reference. Framework names are matched
+loosely, so --framework pci selects the codes synced as "PCI DSS v4.0". Each task's evidence is
+checked in its current window, which follows its collection interval, unless
+--window names one window for every task. With --crosswalk, a control is also
+credited with the evidence tasks of equivalent controls in other frameworks
+(see 'grctool controls crosswalk'), so one submission covers both.
+
+The report is written as markdown, HTML or CSV. The format is inferred from
+the --output file extension when --format is not given.
+
+Examples:
+  # Markdown to the terminal
+  grctool report coverage --framework SOC2
+
+  # HTML page for a management review
+  grctool report coverage --framework SOC2 --output reports/soc2-coverage.html
+
+  # Spreadsheet of a closed quarter
+  grctool report coverage --framework SOC2 --window 2025-Q3 --format csv --output soc2-q3.csv
+
+  # PCI DSS requirements
+  grctool report coverage --framework pci
+
+  # HIPAA safeguards
+  grctool report coverage --framework hipaa
+
+  # ISO 27001 coverage, counting evidence collected for equivalent SOC 2 controls
+  grctool report coverage --framework iso27001 --crosswalk`,
+	Args: cobra.NoArgs,
+	RunE: runReportCoverage,
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.AddCommand(reportCoverageCmd)
+
+	reportCoverageCmd.Flags().String("framework", "", "framework to report on, e.g. SOC2, pci, hipaa or a custom framework name (required)")
+	reportCoverageCmd.Flags().String("window", "", "window to check for every task (default: each task's current window)")
+	reportCoverageCmd.Flags().String("format", "", "report format: md, html or csv (default: from --output extension, else md)")
+	reportCoverageCmd.Flags().StringP("output", "o", "", "write the report to this file instead of stdout")
+	reportCoverageCmd.Flags().Bool("crosswalk", false, "credit controls with the evidence of equivalent controls in other frameworks")
+	_ = reportCoverageCmd.MarkFlagRequired("framework")
+}
+
+func runReportCoverage(cmd *cobra.Command, args []string) error {
+	framework, _ := cmd.Flags().GetString("framework")
+	window, _ := cmd.Flags().GetString("window")
+	format, _ := cmd.Flags().GetString("format")
+	outputFile, _ := cmd.Flags().GetString("output")
+	crosswalk, _ := cmd.Flags().GetBool("crosswalk")
+
+	format, err := resolveReportFormat(format, outputFile)
+	if err != nil {
+		return err
+	}
+
+	data, err := loadComplianceData(false)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	report := export.BuildCoverageReport(data, export.CoverageOptions{
+		Framework: framework,
+		Window:    window,
+		WindowFor: func(task domain.EvidenceTask) string {
+			return tools.CalculateEvidenceWindow(task.CollectionInterval, now)
+		},
+		Crosswalk:   crosswalk,
+		GeneratedAt: now,
+	})
+
+	if outputFile == "" {
+		return writeCoverageReport(cmd.OutOrStdout(), format, report)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outputFile), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	file, err := os.Create(outputFile)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	if err := writeCoverageReport(file, format, report); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	cmd.Printf("✅ Wrote %s coverage of %d criteria (%d controls, %d evidence tasks) to: %s\n",
+		framework, len(report.Criteria), report.Summary.Controls, report.Summary.Tasks, outputFile)
+	return nil
+}
+
+// resolveReportFormat normalizes --format, inferring it from the output file
+// extension and defaulting to markdown
+func resolveReportFormat(format, outputFile string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(outputFile)) {
+		case ".html", ".htm":
+			return reportFormatHTML, nil
+		case ".csv":
+			return reportFormatCSV, nil
+		default:
+			return reportFormatMarkdown, nil
+		}
+	}
+
+	switch format {
+	case reportFormatMarkdown, reportFormatHTML, reportFormatCSV:
+		return format, nil
+	case "markdown":
+		return reportFormatMarkdown, nil
+	}
+	return "", fmt.Errorf("invalid report format %q (must be one of: md, html, csv)", format)
+}
+
+func writeCoverageReport(w io.Writer, format string, report *export.CoverageReport) error {
+	var err error
+	switch format {
+	case reportFormatHTML:
+		err = export.WriteCoverageHTML(w, report)
+	case reportFormatCSV:
+		err = export.WriteCoverageCSV(w, report)
+	default:
+		err = export.WriteCoverageMarkdown(w, report)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveReportFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		format     string
+		outputFile string
+		want       string
+		wantErr    string
+	}{
+		"default markdown":   {want: reportFormatMarkdown},
+		"markdown alias":     {format: "Markdown", want: reportFormatMarkdown},
+		"inferred from html": {outputFile: "reports/soc2.HTML", want: reportFormatHTML},
+		"inferred from htm":  {outputFile: "soc2.htm", want: reportFormatHTML},
+		"inferred from csv":  {outputFile: "soc2.csv", want: reportFormatCSV},
+		"other extension":    {outputFile: "soc2.txt", want: reportFormatMarkdown},
+		"flag wins over ext": {format: "csv", outputFile: "soc2.html", want: reportFormatCSV},
+		"unsupported format": {format: "pdf", wantErr: "invalid report format"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := resolveReportFormat(tt.format, tt.outputFile)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
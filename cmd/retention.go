@@ -0,0 +1,157 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/grctool/grctool/internal/tools"
+	"github.com/spf13/cobra"
+)
+
+var retentionCmd = &cobra.Command{
+	Use:   "retention",
+	Short: "Enforce evidence retention and legal holds",
+	Long: `Enforce the evidence retention policy configured under retention:
+
+  retention:
+    keep_for: 7y              # delete windows that ended longer ago
+    archive_after: 2y         # pack windows that ended longer ago into archives
+    archive_dir: /mnt/cold    # move archives to cold storage
+    rules:
+      - categories: [Infrastructure]
+        keep_for: 3y
+    legal_holds:
+      - name: acme-v-us
+        tasks: [ET-0047]
+        reason: Litigation hold, counsel ticket LEGAL-112
+
+keep_for defaults to lifecycle.evidence_retention; without either, evidence
+is kept forever. Evidence a legal hold covers is never deleted.`,
+}
+
+var retentionApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Archive and delete evidence windows past their retention periods",
+	Long: `Apply the retention policy to every evidence window, measured from the end
+of the window's collection period:
+
+  - windows older than keep_for are deleted, together with their archives
+    here and in cold storage, unless a legal hold covers them
+  - windows older than archive_after are packed into <window>.tar.gz, in
+    archive_dir/<task directory>/ when archive_dir is set, and archives
+    'grctool storage gc --compress' left beside their windows move there
+
+Windows locked by another grctool run are skipped. Run with --dry-run first:
+deleted evidence can only be recovered from backups or storage.git history.
+
+Examples:
+  # See what would be archived, deleted and held
+  grctool retention apply --dry-run
+
+  grctool retention apply`,
+	Args: cobra.NoArgs,
+	RunE: runRetentionApply,
+}
+
+func init() {
+	rootCmd.AddCommand(retentionCmd)
+	retentionCmd.AddCommand(retentionApplyCmd)
+
+	retentionApplyCmd.Flags().Bool("dry-run", false, "list what would be archived and deleted without changing anything")
+}
+
+func runRetentionApply(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Retention.KeepFor == "" && cfg.Retention.ArchiveAfter == "" && len(cfg.Retention.Rules) == 0 {
+		return fmt.Errorf("no retention policy configured: set retention.keep_for or retention.archive_after")
+	}
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	result, err := store.ApplyRetention(storage.RetentionOptions{
+		DryRun: dryRun,
+		Policy: cfg.Retention,
+		WindowEnd: func(window string) (time.Time, error) {
+			_, end, err := tools.ParseEvidenceWindow(window)
+			return end, err
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("retention apply failed: %w", err)
+	}
+
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, result)
+	}
+	displayRetentionResult(cmd, result)
+	return nil
+}
+
+// displayRetentionResult prints what applying retention did, or would do
+func displayRetentionResult(cmd *cobra.Command, result *storage.RetentionResult) {
+	if len(result.Items) == 0 {
+		cmd.Println("✅ No evidence is past its retention periods")
+	}
+	for _, item := range result.Items {
+		icon, action := "📦", "Archived"
+		if result.DryRun {
+			action = "Would archive"
+		}
+		switch item.Action {
+		case storage.RetentionDeleted:
+			icon, action = "🗑️ ", "Deleted"
+			if result.DryRun {
+				action = "Would delete"
+			}
+		case storage.RetentionHeld:
+			icon, action = "⚖️ ", "Kept"
+		}
+		cmd.Printf("%s %s %s (%s %s", icon, action, item.Path, item.TaskRef, item.Window)
+		if item.Action != storage.RetentionHeld {
+			cmd.Printf(", %s", formatBytes(item.Bytes))
+		}
+		cmd.Printf(")")
+		if item.Reason != "" {
+			cmd.Printf(": %s", item.Reason)
+		}
+		cmd.Println()
+	}
+	for _, item := range result.Skipped {
+		cmd.Printf("⏭️  Skipped %s: %s\n", item.Path, item.Reason)
+	}
+	if result.FreedBytes > 0 {
+		if result.DryRun {
+			cmd.Printf("\n%s would be freed\n", formatBytes(result.FreedBytes))
+		} else {
+			cmd.Printf("\n%s freed\n", formatBytes(result.FreedBytes))
+		}
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplayRetentionResult(t *testing.T) {
+	t.Parallel()
+
+	result := &storage.RetentionResult{
+		DryRun: true,
+		Items: []storage.RetentionItem{
+			{Action: storage.RetentionDeleted, TaskRef: "ET-0001", Window: "2017-Q4", Path: "evidence/Access_Review_ET-0001_101/2017-Q4", Bytes: 2048, Reason: "ended 2017-12-31, more than 7y ago"},
+			{Action: storage.RetentionArchived, TaskRef: "ET-0001", Window: "2022-Q2", Path: "evidence/Access_Review_ET-0001_101/2022-Q2", Bytes: 1024},
+			{Action: storage.RetentionHeld, TaskRef: "ET-0047", Window: "2017-Q4", Path: "evidence/Firewall_Rules_ET-0047_147/2017-Q4", Reason: "past 7y retention, but under legal hold acme-v-us: Litigation hold"},
+		},
+		Skipped:    []storage.RetentionItem{{Path: "evidence/Access_Review_ET-0001_101/2016-Q1", Reason: "locked"}},
+		FreedBytes: 3072,
+	}
+
+	var buf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&buf)
+	displayRetentionResult(cmd, result)
+
+	assert.Equal(t, "🗑️  Would delete evidence/Access_Review_ET-0001_101/2017-Q4 (ET-0001 2017-Q4, 2.0KB): ended 2017-12-31, more than 7y ago\n"+
+		"📦 Would archive evidence/Access_Review_ET-0001_101/2022-Q2 (ET-0001 2022-Q2, 1.0KB)\n"+
+		"⚖️  Kept evidence/Firewall_Rules_ET-0047_147/2017-Q4 (ET-0047 2017-Q4): past 7y retention, but under legal hold acme-v-us: Litigation hold\n"+
+		"⏭️  Skipped evidence/Access_Review_ET-0001_101/2016-Q1: locked\n"+
+		"\n3.0KB would be freed\n", buf.String())
+
+	buf.Reset()
+	displayRetentionResult(cmd, &storage.RetentionResult{})
+	assert.Equal(t, "✅ No evidence is past its retention periods\n", buf.String())
+}
@@ -0,0 +1,395 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var riskCmd = &cobra.Command{
+	Use:   "risk",
+	Short: "Maintain the risk register",
+	Long: `Maintain a risk register in local storage. Each risk is rated for
+likelihood and impact on a 1-5 scale; the score is their product, banded as
+low (1-4), medium (5-9), high (10-15) or critical (16-25).
+
+Risks record a treatment (mitigate, accept, transfer or avoid) with its plan,
+an optional residual rating, and links to the controls that treat them and the
+evidence tasks that show the treatment works. The register is kept as JSON
+files under docs/risks in the data directory.
+
+Examples:
+  # Add a risk
+  grctool risk add --title "Leaked deploy credentials" --likelihood 3 --impact 5 \
+    --treatment mitigate --plan "Short-lived OIDC credentials" --owner alex@example.com
+
+  # Highest risks first
+  grctool risk list --min-score 10
+
+  # Record the residual rating once treated
+  grctool risk update RISK-0001 --status treating --residual-likelihood 1 --residual-impact 5
+
+  # Link controls and evidence tasks
+  grctool risk link RISK-0001 --control CC6.1 --task ET-0047`,
+}
+
+var riskAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a risk to the register",
+	Args:  cobra.NoArgs,
+	RunE:  runRiskAdd,
+}
+
+var riskListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List risks, highest score first",
+	Args:  cobra.NoArgs,
+	RunE:  runRiskList,
+}
+
+var riskUpdateCmd = &cobra.Command{
+	Use:   "update <risk-id>",
+	Short: "Update a risk's rating, treatment or status",
+	Long: `Update the fields of a risk given as flags; fields not given are left as
+they are. Use 'grctool risk link' to change linked controls and tasks.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRiskUpdate,
+}
+
+var riskLinkCmd = &cobra.Command{
+	Use:   "link <risk-id>",
+	Short: "Link a risk to controls and evidence tasks",
+	Long: `Link a risk to the controls that treat it and the evidence tasks that show
+the treatment works. Controls and tasks must exist in local storage; run
+'grctool sync' first. Use --remove to unlink them instead.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRiskLink,
+}
+
+func init() {
+	rootCmd.AddCommand(riskCmd)
+	riskCmd.AddCommand(riskAddCmd)
+	riskCmd.AddCommand(riskListCmd)
+	riskCmd.AddCommand(riskUpdateCmd)
+	riskCmd.AddCommand(riskLinkCmd)
+
+	addRiskFieldFlags(riskAddCmd)
+	addRiskFieldFlags(riskUpdateCmd)
+	_ = riskAddCmd.MarkFlagRequired("title")
+	_ = riskAddCmd.MarkFlagRequired("likelihood")
+	_ = riskAddCmd.MarkFlagRequired("impact")
+	riskAddCmd.Flags().StringSlice("control", nil, "control that treats the risk (repeatable)")
+	riskAddCmd.Flags().StringSlice("task", nil, "evidence task showing the treatment works (repeatable)")
+
+	riskListCmd.Flags().String("status", "", "only risks with this status")
+	riskListCmd.Flags().Int("min-score", 0, "only risks scoring at least this much")
+	riskListCmd.Flags().String("control", "", "only risks linked to this control")
+
+	riskLinkCmd.Flags().StringSlice("control", nil, "control to link (repeatable)")
+	riskLinkCmd.Flags().StringSlice("task", nil, "evidence task to link (repeatable)")
+	riskLinkCmd.Flags().Bool("remove", false, "unlink the given controls and tasks")
+}
+
+// riskInfo is a risk as shown by the risk commands, with its computed scores
+type riskInfo struct {
+	domain.Risk
+	Score         int    `json:"score"`
+	Level         string `json:"level"`
+	ResidualScore int    `json:"residual_score,omitempty"`
+}
+
+func newRiskInfo(risk domain.Risk) riskInfo {
+	return riskInfo{Risk: risk, Score: risk.Score(), Level: risk.Level(), ResidualScore: risk.ResidualScore()}
+}
+
+// addRiskFieldFlags adds the flags setting a risk's fields, shared by add and update
+func addRiskFieldFlags(c *cobra.Command) {
+	c.Flags().String("title", "", "short name of the risk")
+	c.Flags().String("description", "", "what could happen and why")
+	c.Flags().String("category", "", "risk category, e.g. security, vendor, operational")
+	c.Flags().String("owner", "", "person accountable for the risk")
+	c.Flags().Int("likelihood", 0, "likelihood before treatment, 1-5")
+	c.Flags().Int("impact", 0, "impact before treatment, 1-5")
+	c.Flags().String("treatment", domain.RiskTreatmentMitigate, "treatment: mitigate, accept, transfer or avoid")
+	c.Flags().String("plan", "", "treatment plan")
+	c.Flags().Int("residual-likelihood", 0, "likelihood once treated, 1-5")
+	c.Flags().Int("residual-impact", 0, "impact once treated, 1-5")
+	c.Flags().String("status", domain.RiskStatusOpen, "status: open, treating, accepted or closed")
+}
+
+// openLocalStorage opens the storage holding locally maintained registers
+func openLocalStorage() (*storage.Storage, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	return store, nil
+}
+
+func runRiskAdd(cmd *cobra.Command, args []string) error {
+	store, err := openLocalStorage()
+	if err != nil {
+		return err
+	}
+
+	risk := &domain.Risk{}
+	applyRiskFlags(cmd, risk)
+
+	controls, _ := cmd.Flags().GetStringSlice("control")
+	tasks, _ := cmd.Flags().GetStringSlice("task")
+	if err := linkRisk(store, risk, controls, tasks, false); err != nil {
+		return err
+	}
+
+	if risk.ID, err = store.NextRiskID(); err != nil {
+		return fmt.Errorf("failed to allocate risk ID: %w", err)
+	}
+	risk.CreatedAt = time.Now().UTC()
+	risk.UpdatedAt = risk.CreatedAt
+	if err := store.SaveRisk(risk); err != nil {
+		return fmt.Errorf("failed to save risk: %w", err)
+	}
+
+	return showRisk(cmd, risk, "✅ Added")
+}
+
+func runRiskList(cmd *cobra.Command, args []string) error {
+	status, _ := cmd.Flags().GetString("status")
+	minScore, _ := cmd.Flags().GetInt("min-score")
+	control, _ := cmd.Flags().GetString("control")
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	store, err := openLocalStorage()
+	if err != nil {
+		return err
+	}
+	all, err := store.GetAllRisks()
+	if err != nil {
+		return fmt.Errorf("failed to load risks: %w", err)
+	}
+
+	risks := filterRisks(all, status, minScore, control)
+
+	if isStructuredOutput(format) {
+		infos := make([]riskInfo, 0, len(risks))
+		for _, risk := range risks {
+			infos = append(infos, newRiskInfo(risk))
+		}
+		return writeStructured(cmd, format, infos)
+	}
+
+	if len(risks) == 0 {
+		cmd.Println("No risks found. Add one with: grctool risk add --title <title> --likelihood <1-5> --impact <1-5>")
+		return nil
+	}
+
+	cmd.Printf("%-10s %-5s %-8s %-8s %-9s %-9s %s\n", "ID", "SCORE", "LEVEL", "RESIDUAL", "TREATMENT", "STATUS", "TITLE")
+	for _, risk := range risks {
+		residual := "-"
+		if risk.ResidualScore() > 0 {
+			residual = fmt.Sprintf("%d", risk.ResidualScore())
+		}
+		cmd.Printf("%-10s %-5d %-8s %-8s %-9s %-9s %s\n",
+			risk.ID, risk.Score(), risk.Level(), residual, risk.Treatment, risk.Status, risk.Title)
+	}
+	cmd.Printf("\n%d risks\n", len(risks))
+	return nil
+}
+
+func runRiskUpdate(cmd *cobra.Command, args []string) error {
+	store, err := openLocalStorage()
+	if err != nil {
+		return err
+	}
+	risk, err := store.GetRisk(args[0])
+	if err != nil {
+		return err
+	}
+
+	applyRiskFlags(cmd, risk)
+	risk.UpdatedAt = time.Now().UTC()
+	if err := store.SaveRisk(risk); err != nil {
+		return fmt.Errorf("failed to save risk: %w", err)
+	}
+
+	return showRisk(cmd, risk, "✅ Updated")
+}
+
+func runRiskLink(cmd *cobra.Command, args []string) error {
+	controls, _ := cmd.Flags().GetStringSlice("control")
+	tasks, _ := cmd.Flags().GetStringSlice("task")
+	remove, _ := cmd.Flags().GetBool("remove")
+	if len(controls) == 0 && len(tasks) == 0 {
+		return fmt.Errorf("nothing to link: give --control or --task")
+	}
+
+	store, err := openLocalStorage()
+	if err != nil {
+		return err
+	}
+	risk, err := store.GetRisk(args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := linkRisk(store, risk, controls, tasks, remove); err != nil {
+		return err
+	}
+	risk.UpdatedAt = time.Now().UTC()
+	if err := store.SaveRisk(risk); err != nil {
+		return fmt.Errorf("failed to save risk: %w", err)
+	}
+
+	if remove {
+		return showRisk(cmd, risk, "🔗 Unlinked from")
+	}
+	return showRisk(cmd, risk, "🔗 Linked")
+}
+
+// applyRiskFlags copies the flags given on the command line onto risk. On
+// add every flag applies, so defaults for treatment and status take effect.
+func applyRiskFlags(cmd *cobra.Command, risk *domain.Risk) {
+	flags := cmd.Flags()
+	adding := risk.ID == ""
+	set := func(name string) bool { return adding || flags.Changed(name) }
+
+	stringFields := map[string]*string{
+		"title":       &risk.Title,
+		"description": &risk.Description,
+		"category":    &risk.Category,
+		"owner":       &risk.Owner,
+		"treatment":   &risk.Treatment,
+		"plan":        &risk.TreatmentPlan,
+		"status":      &risk.Status,
+	}
+	for name, field := range stringFields {
+		if set(name) {
+			*field, _ = flags.GetString(name)
+		}
+	}
+
+	intFields := map[string]*int{
+		"likelihood":          &risk.Likelihood,
+		"impact":              &risk.Impact,
+		"residual-likelihood": &risk.ResidualLikelihood,
+		"residual-impact":     &risk.ResidualImpact,
+	}
+	for name, field := range intFields {
+		if set(name) {
+			*field, _ = flags.GetInt(name)
+		}
+	}
+}
+
+// linkRisk resolves controls and tasks against local storage and adds them to,
+// or removes them from, the risk's links by reference ID
+func linkRisk(store *storage.Storage, risk *domain.Risk, controls, tasks []string, remove bool) error {
+	for _, id := range controls {
+		control, err := store.GetControl(id)
+		if err != nil {
+			return fmt.Errorf("control %s not found in local storage (run 'grctool sync' first)", id)
+		}
+		risk.Controls = updateLinks(risk.Controls, controlLabel(control), remove)
+	}
+	for _, id := range tasks {
+		task, err := store.GetEvidenceTask(id)
+		if err != nil {
+			return fmt.Errorf("evidence task %s not found in local storage (run 'grctool sync' first)", id)
+		}
+		risk.EvidenceTasks = updateLinks(risk.EvidenceTasks, task.ReferenceID, remove)
+	}
+	return nil
+}
+
+// updateLinks adds or removes ref, keeping links sorted and unique
+func updateLinks(links []string, ref string, remove bool) []string {
+	if remove {
+		return slices.DeleteFunc(links, func(link string) bool { return link == ref })
+	}
+	if !slices.Contains(links, ref) {
+		links = append(links, ref)
+		sort.Strings(links)
+	}
+	return links
+}
+
+// filterRisks keeps risks matching the filters, highest score first
+func filterRisks(risks []domain.Risk, status string, minScore int, control string) []domain.Risk {
+	filtered := []domain.Risk{}
+	for _, risk := range risks {
+		if status != "" && !strings.EqualFold(risk.Status, status) {
+			continue
+		}
+		if risk.Score() < minScore {
+			continue
+		}
+		if control != "" && !slices.ContainsFunc(risk.Controls, func(c string) bool { return strings.EqualFold(c, control) }) {
+			continue
+		}
+		filtered = append(filtered, risk)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool { return filtered[i].Score() > filtered[j].Score() })
+	return filtered
+}
+
+func showRisk(cmd *cobra.Command, risk *domain.Risk, verb string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, newRiskInfo(*risk))
+	}
+
+	cmd.Printf("%s %s: %s\n", verb, risk.ID, risk.Title)
+	cmd.Printf("  Score:     %d (%s) = likelihood %d × impact %d\n", risk.Score(), risk.Level(), risk.Likelihood, risk.Impact)
+	if risk.ResidualScore() > 0 {
+		cmd.Printf("  Residual:  %d (%s)\n", risk.ResidualScore(), domain.RiskLevel(risk.ResidualScore()))
+	}
+	cmd.Printf("  Treatment: %s, status %s\n", risk.Treatment, risk.Status)
+	if len(risk.Controls) > 0 {
+		cmd.Printf("  Controls:  %s\n", strings.Join(risk.Controls, ", "))
+	}
+	if len(risk.EvidenceTasks) > 0 {
+		cmd.Printf("  Tasks:     %s\n", strings.Join(risk.EvidenceTasks, ", "))
+	}
+	return nil
+}
+
+// controlLabel is how a control is referred to
+func controlLabel(control *domain.Control) string {
+	if control.ReferenceID != "" {
+		return control.ReferenceID
+	}
+	return control.ID
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRiskFlagsCmd returns a command with the add/update flags of the risk commands
+func newRiskFlagsCmd(args ...string) *cobra.Command {
+	cmd := &cobra.Command{Use: "update"}
+	addRiskFieldFlags(cmd)
+	_ = cmd.Flags().Parse(args)
+	return cmd
+}
+
+func TestApplyRiskFlags(t *testing.T) {
+	t.Parallel()
+
+	risk := &domain.Risk{}
+	applyRiskFlags(newRiskFlagsCmd("--title", "Leaked credentials", "--likelihood", "3", "--impact", "5"), risk)
+	assert.Equal(t, "Leaked credentials", risk.Title)
+	assert.Equal(t, 15, risk.Score())
+	assert.Equal(t, domain.RiskTreatmentMitigate, risk.Treatment, "defaults apply when adding")
+	assert.Equal(t, domain.RiskStatusOpen, risk.Status)
+
+	risk.ID = "RISK-0001"
+	risk.TreatmentPlan = "Rotate keys"
+	applyRiskFlags(newRiskFlagsCmd("--status", "treating", "--residual-likelihood", "1", "--residual-impact", "5"), risk)
+	assert.Equal(t, domain.RiskStatusTreating, risk.Status)
+	assert.Equal(t, 5, risk.ResidualScore())
+	assert.Equal(t, "Leaked credentials", risk.Title, "flags not given are kept on update")
+	assert.Equal(t, "Rotate keys", risk.TreatmentPlan)
+	assert.Equal(t, 3, risk.Likelihood)
+}
+
+func TestLinkRisk(t *testing.T) {
+	t.Parallel()
+
+	store, err := storage.NewStorage(config.StorageConfig{DataDir: t.TempDir()})
+	require.NoError(t, err)
+	require.NoError(t, store.SaveControl(&domain.Control{ID: "101", ReferenceID: "CC6.1", Name: "Logical Access"}))
+	require.NoError(t, store.SaveControl(&domain.Control{ID: "102", Name: "Unreferenced"}))
+	require.NoError(t, store.SaveEvidenceTask(&domain.EvidenceTask{ID: "327992", ReferenceID: "ET-0001", Name: "Access Review"}))
+
+	risk := &domain.Risk{}
+	require.NoError(t, linkRisk(store, risk, []string{"CC6.1", "102", "101"}, []string{"ET0001"}, false))
+	assert.Equal(t, []string{"102", "CC6.1"}, risk.Controls, "linked by reference, sorted and unique")
+	assert.Equal(t, []string{"ET-0001"}, risk.EvidenceTasks)
+
+	require.NoError(t, linkRisk(store, risk, []string{"101"}, nil, true))
+	assert.Equal(t, []string{"102"}, risk.Controls)
+
+	err = linkRisk(store, risk, []string{"CC9.9"}, nil, false)
+	assert.ErrorContains(t, err, "control CC9.9 not found")
+	err = linkRisk(store, risk, nil, []string{"ET-0099"}, false)
+	assert.ErrorContains(t, err, "evidence task ET-0099 not found")
+}
+
+func TestFilterRisks(t *testing.T) {
+	t.Parallel()
+
+	risks := []domain.Risk{
+		{ID: "RISK-0001", Likelihood: 2, Impact: 2, Status: domain.RiskStatusOpen},
+		{ID: "RISK-0002", Likelihood: 4, Impact: 5, Status: domain.RiskStatusTreating, Controls: []string{"CC6.1"}},
+		{ID: "RISK-0003", Likelihood: 3, Impact: 3, Status: domain.RiskStatusOpen, Controls: []string{"CC6.1"}},
+	}
+	ids := func(risks []domain.Risk) []string {
+		var out []string
+		for _, risk := range risks {
+			out = append(out, risk.ID)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"RISK-0002", "RISK-0003", "RISK-0001"}, ids(filterRisks(risks, "", 0, "")))
+	assert.Equal(t, []string{"RISK-0003", "RISK-0001"}, ids(filterRisks(risks, "OPEN", 0, "")))
+	assert.Equal(t, []string{"RISK-0002"}, ids(filterRisks(risks, "", 10, "")))
+	assert.Equal(t, []string{"RISK-0002", "RISK-0003"}, ids(filterRisks(risks, "", 0, "cc6.1")))
+	assert.Empty(t, filterRisks(risks, "closed", 0, ""))
+}
@@ -18,8 +18,11 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/frameworks"
 	"github.com/grctool/grctool/internal/logger"
 	"github.com/grctool/grctool/internal/providers"
 	"github.com/grctool/grctool/internal/tools"
@@ -29,6 +32,10 @@ import (
 
 var cfgFile string
 
+// correlationID identifies this run in every log line, span, tool output and
+// audit entry it produces
+var correlationID string
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "grctool",
@@ -43,7 +50,11 @@ extracting relevant configuration snippets from your infrastructure code.`,
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() error {
-	return rootCmd.Execute()
+	start := time.Now()
+	cmd, err := rootCmd.ExecuteC()
+	recordAction(cmd, start, err)
+	finishTracing(err)
+	return err
 }
 
 func init() {
@@ -51,22 +62,33 @@ func init() {
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default searches $PWD then $HOME for .grctool.yaml)")
+	rootCmd.PersistentFlags().String("profile", "", "configuration profile to use (default: $GRCTOOL_PROFILE, then the config's profile setting)")
 	rootCmd.PersistentFlags().Bool("verbose", false, "verbose output")
 	rootCmd.PersistentFlags().String("log-level", "warn", "console log level (trace, debug, info, warn, error)")
 	rootCmd.PersistentFlags().String("log-file", "", "log file location (default: OS-appropriate path)")
 	rootCmd.PersistentFlags().String("log-file-level", "info", "file log level (trace, debug, info, warn, error)")
 	rootCmd.PersistentFlags().Bool("no-log-file", false, "disable file logging")
+	rootCmd.PersistentFlags().String("log-format", "", "log format for console and file logs (text, json)")
+	rootCmd.PersistentFlags().Bool("no-cache", false, "run tools instead of reusing cached results")
+	rootCmd.PersistentFlags().String("output", outputFormatTable, "output format for evidence and status commands (table, json, yaml)")
 
 	// Bind flags to viper
+	_ = viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile"))
+	_ = viper.BindEnv("profile", config.ProfileEnvVar)
 	_ = viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
 	_ = viper.BindPFlag("log-level", rootCmd.PersistentFlags().Lookup("log-level"))
 	_ = viper.BindPFlag("log-file", rootCmd.PersistentFlags().Lookup("log-file"))
 	_ = viper.BindPFlag("log-file-level", rootCmd.PersistentFlags().Lookup("log-file-level"))
 	_ = viper.BindPFlag("no-log-file", rootCmd.PersistentFlags().Lookup("no-log-file"))
+	_ = viper.BindPFlag("log-format", rootCmd.PersistentFlags().Lookup("log-format"))
+	_ = viper.BindPFlag("no-cache", rootCmd.PersistentFlags().Lookup("no-cache"))
 }
 
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
+	if cfgFile == "" {
+		cfgFile = os.Getenv(config.ConfigEnvVar)
+	}
 	if cfgFile != "" {
 		// Use config file from the flag.
 		viper.SetConfigFile(cfgFile)
@@ -86,6 +108,15 @@ func initConfig() {
 
 	viper.AutomaticEnv() // read in environment variables that match
 
+	// Let a GRCTOOL_* variable set any config key or global flag, overriding
+	// the config file, so CI can run without one
+	if err := config.BindEnv(); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+	}
+	for _, flag := range []string{"verbose", "log-level", "log-file", "log-file-level", "no-log-file", "log-format"} {
+		_ = viper.BindEnv(flag, config.EnvVar(flag))
+	}
+
 	// If a config file is found, read it in.
 	if err := viper.ReadInConfig(); err == nil {
 		if viper.GetBool("verbose") {
@@ -93,8 +124,68 @@ func initConfig() {
 		}
 	}
 
+	// Merge the active profile over the config, so every setting read from
+	// here on is the profile's
+	if err := config.ApplyProfile(); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+	} else if profile := config.ActiveProfile(); profile != "" && viper.GetBool("verbose") {
+		fmt.Fprintln(os.Stderr, "Using profile:", profile)
+	}
+
+	// Identify the run before anything logs
+	initCorrelationID()
+
 	// Initialize logging system
 	initLogging()
+
+	// Export spans when tracing.enabled is set
+	initTracing()
+
+	// Register the custom frameworks defined in the data directory
+	initCustomFrameworks()
+}
+
+// initCustomFrameworks registers the framework definitions under
+// <data_dir>/frameworks, so internal control frameworks work wherever a
+// --framework is resolved from synced controls
+func initCustomFrameworks() {
+	cfg, err := config.Load()
+	if err != nil || cfg.Storage.DataDir == "" {
+		return
+	}
+	definitions, err := frameworks.LoadCustomFrameworks(filepath.Join(cfg.Storage.DataDir, frameworks.CustomFrameworksDir))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+		return
+	}
+	for _, definition := range definitions {
+		if err := frameworks.RegisterCustom(definition); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+		}
+	}
+}
+
+// initCorrelationID takes the run's correlation ID from the environment or
+// generates one, and exports it so the commands this run starts share it
+func initCorrelationID() {
+	correlationID = os.Getenv(config.CorrelationIDEnvVar)
+	if correlationID == "" {
+		correlationID = tools.GenerateCorrelationID()
+	}
+	_ = os.Setenv(config.CorrelationIDEnvVar, correlationID)
+}
+
+// logFormat returns the format --log-format sets for every logger, or "" to
+// keep each logger's own
+func logFormat() string {
+	format := viper.GetString("log-format")
+	switch format {
+	case "", "text", "json":
+		return format
+	default:
+		fmt.Fprintf(os.Stderr, "⚠️  unknown log format %q, expected text or json\n", format)
+		return ""
+	}
 }
 
 // initLogging initializes the centralized logging system
@@ -104,6 +195,7 @@ func initLogging() {
 
 	// Check if file logging is disabled before anything else
 	noLogFile := viper.GetBool("no-log-file")
+	format := logFormat()
 
 	// Load configuration
 	cfg, err := config.Load()
@@ -117,6 +209,10 @@ func initLogging() {
 			defaultConfig.Level = logger.ParseLogLevel(viper.GetString("log-level"))
 		}
 
+		if format != "" {
+			defaultConfig.Format = format
+		}
+
 		// For tool commands, use stderr and warn level by default
 		if isToolCommand {
 			defaultConfig.Output = "stderr"
@@ -155,6 +251,9 @@ func initLogging() {
 			if viper.IsSet("log-file-level") {
 				fileConfig.Level = logger.ParseLogLevel(viper.GetString("log-file-level"))
 			}
+			if format != "" {
+				fileConfig.Format = format
+			}
 
 			fileLogger, fileErr := logger.NewZerologLogger(fileConfig)
 			if fileErr != nil {
@@ -166,7 +265,7 @@ func initLogging() {
 			}
 		}
 
-		multiLogger := logger.NewMultiLogger(loggers...)
+		multiLogger := logger.NewMultiLogger(loggers...).WithFields(logger.CorrelationID(correlationID))
 		logger.InitGlobalWithLogger(multiLogger)
 
 		// Only warn about config issues if a config file was actually found
@@ -219,6 +318,11 @@ func initLogging() {
 			continue
 		}
 
+		// Override the format from --log-format if provided
+		if format != "" {
+			loggerCfg.Format = format
+		}
+
 		// For tool commands, override console logger to use stderr and warn level
 		if isToolCommand && name == "console" {
 			loggerCfg.Output = "stderr"
@@ -247,8 +351,9 @@ func initLogging() {
 		return
 	}
 
-	// Initialize global logger with multi-logger
-	multiLogger := logger.NewMultiLogger(loggers...)
+	// Initialize global logger with multi-logger, tagging every line with
+	// the run's correlation ID
+	multiLogger := logger.NewMultiLogger(loggers...).WithFields(logger.CorrelationID(correlationID))
 	logger.InitGlobalWithLogger(multiLogger)
 
 	multiLogger.Info("logging system initialized",
@@ -273,6 +378,13 @@ func initToolRegistry() {
 		log.Warn("Failed to initialize tool registry", logger.Error(err))
 	}
 
+	// Reuse tool results unless disabled for this run or in config
+	if viper.GetBool("no-cache") || cfg.Evidence.Tools.Cache.Disabled {
+		tools.GlobalRegistry.SetCache(nil)
+	} else if err := tools.EnableResultCache(cfg, log); err != nil {
+		log.Warn("Tool results will not be cached", logger.Error(err))
+	}
+
 	// Initialize global provider registry from config
 	provLog := logger.WithComponent("providers")
 	providers.InitGlobalRegistry(cfg.Providers, provLog)
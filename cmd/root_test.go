@@ -17,10 +17,15 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/logger"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -295,6 +300,83 @@ func TestInitLogging(t *testing.T) {
 	}
 }
 
+func TestInitCorrelationID(t *testing.T) {
+	original := correlationID
+	defer func() { correlationID = original }()
+
+	t.Run("generated", func(t *testing.T) {
+		t.Setenv(config.CorrelationIDEnvVar, "")
+		initCorrelationID()
+		assert.NotEmpty(t, correlationID)
+		assert.Equal(t, correlationID, os.Getenv(config.CorrelationIDEnvVar), "should export the ID to child processes")
+	})
+
+	t.Run("inherited", func(t *testing.T) {
+		t.Setenv(config.CorrelationIDEnvVar, "ci-run-42")
+		initCorrelationID()
+		assert.Equal(t, "ci-run-42", correlationID)
+	})
+}
+
+func TestInitLogging_JSONFormat(t *testing.T) {
+	originalViper := viper.AllSettings()
+	originalID := correlationID
+	defer func() {
+		correlationID = originalID
+		viper.Reset()
+		for k, v := range originalViper {
+			viper.Set(k, v)
+		}
+	}()
+
+	logFile := filepath.Join(t.TempDir(), "grctool.log")
+	viper.Reset()
+	viper.Set("log-format", "json")
+	viper.Set("log-file", logFile)
+	viper.Set("log-file-level", "info")
+	correlationID = "ci-run-42"
+
+	initLogging()
+	logger.Info("json logging test")
+
+	var line map[string]interface{}
+	require.Eventually(t, func() bool {
+		data, err := os.ReadFile(logFile)
+		if err != nil {
+			return false
+		}
+		for _, raw := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			if json.Unmarshal([]byte(raw), &line) == nil && line["message"] == "json logging test" {
+				return true
+			}
+		}
+		return false
+	}, 2*time.Second, 20*time.Millisecond, "log file should hold the message as a JSON line")
+	assert.Equal(t, "ci-run-42", line["correlation_id"])
+	assert.Equal(t, "info", line["level"])
+}
+
+func TestLogFormat(t *testing.T) {
+	original := viper.GetString("log-format")
+	defer viper.Set("log-format", original)
+
+	tests := map[string]struct {
+		flag string
+		want string
+	}{
+		"unset":   {flag: "", want: ""},
+		"text":    {flag: "text", want: "text"},
+		"json":    {flag: "json", want: "json"},
+		"unknown": {flag: "xml", want: ""},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			viper.Set("log-format", tc.flag)
+			assert.Equal(t, tc.want, logFormat())
+		})
+	}
+}
+
 func TestExecute(t *testing.T) {
 	// Test that Execute function exists and can be called
 	// This is a simple test since Execute() just delegates to rootCmd.Execute()
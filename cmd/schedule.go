@@ -17,14 +17,17 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/tabwriter"
 	"time"
 
 	"context"
 
 	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
 	"github.com/grctool/grctool/internal/logger"
 	"github.com/grctool/grctool/internal/scheduler"
 	"github.com/grctool/grctool/internal/storage"
@@ -69,7 +72,24 @@ Use --force to run a schedule regardless of whether it is due.
 Use --dry-run to preview what would be executed without making changes.
 
 Tool execution is performed via the evidence collection orchestrator.
-Schedules are marked completed/failed after execution.`,
+Schedules are marked completed/failed after execution.
+
+A schedule runs the tools its tasks are mapped to, either through its own
+tasks and tools lists or through schedules.task_mappings. Each tool's output
+is saved as tool data of the task's evidence window, where evidence generate
+picks it up:
+
+  <data_dir>/evidence/<task>/<window>/.context/tool_outputs/<tool>.json
+
+Examples:
+  # Run whatever is due, e.g. from an hourly cron job
+  grctool schedule run
+
+  # Preview a schedule's tool runs
+  grctool schedule run weekly-github --force --dry-run
+
+  # Collect into the previous quarter
+  grctool schedule run nightly-terraform --force --window 2025-Q3`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runScheduleRun,
 }
@@ -83,6 +103,7 @@ func init() {
 	// Flags for schedule run
 	scheduleRunCmd.Flags().Bool("dry-run", false, "show what would execute without running")
 	scheduleRunCmd.Flags().Bool("force", false, "run even if not due")
+	scheduleRunCmd.Flags().String("window", "", "evidence window for tool output (default: current quarter)")
 }
 
 // loadScheduler creates a Scheduler from the current config.
@@ -300,6 +321,18 @@ func printTaskDueGroupings(out interface{ Write([]byte) (int, error) }, cfg *con
 			ref = t.ID
 		}
 		category, daysUntil := scheduler.ClassifyTaskDue(t.NextDue, now)
+		if t.IsDeferred(now) {
+			details = append(details, scheduler.TaskDueDetail{
+				TaskRef:       ref,
+				TaskName:      t.Name,
+				Category:      scheduler.TaskDeferred,
+				DueDate:       t.NextDue,
+				DaysUntil:     daysUntil,
+				DeferredUntil: &t.Deferral.Until,
+				DeferReason:   t.Deferral.Reason,
+			})
+			continue
+		}
 		if category == scheduler.TaskNoSchedule {
 			continue
 		}
@@ -329,6 +362,15 @@ func printTaskDueGroupings(out interface{ Write([]byte) (int, error) }, cfg *con
 	}
 	fmt.Fprintln(out)
 
+	if len(grouping.Deferred) > 0 {
+		fmt.Fprintln(out, "=== Evidence Tasks: Deferred ===")
+		for _, d := range grouping.Deferred {
+			fmt.Fprintf(out, "  %s  %s  (deferred until %s: %s)\n",
+				d.TaskRef, d.TaskName, d.DeferredUntil.Format("2006-01-02"), d.DeferReason)
+		}
+		fmt.Fprintln(out)
+	}
+
 	fmt.Fprintln(out, "=== Evidence Tasks: Due This Week ===")
 	if len(grouping.DueThisWeek) == 0 {
 		fmt.Fprintln(out, "  No tasks due this week.")
@@ -365,6 +407,7 @@ func printTaskDueGroupings(out interface{ Write([]byte) (int, error) }, cfg *con
 func runScheduleRun(cmd *cobra.Command, args []string) error {
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 	force, _ := cmd.Flags().GetBool("force")
+	window, _ := cmd.Flags().GetString("window")
 
 	s, err := loadScheduler()
 	if err != nil {
@@ -377,7 +420,7 @@ func runScheduleRun(cmd *cobra.Command, args []string) error {
 	// If a specific schedule name is given, filter to just that one.
 	if len(args) == 1 {
 		name := args[0]
-		return runNamedSchedule(cmd, s, name, now, dryRun, force)
+		return runNamedSchedule(cmd, s, name, now, window, dryRun, force)
 	}
 
 	// Run all due schedules.
@@ -392,36 +435,29 @@ func runScheduleRun(cmd *cobra.Command, args []string) error {
 	}
 
 	orch := loadOrchestrator(s)
+	var router *toolOutputRouter
+	if !dryRun {
+		if router, err = newToolOutputRouter(window); err != nil {
+			return err
+		}
+	}
 
 	for _, d := range due {
 		if dryRun {
 			fmt.Fprintf(out, "[dry-run] Would execute schedule: %s (scope: %s, provider: %s)\n",
 				d.Name, d.Scope, d.Provider)
+			printSchedulePlan(out, orch, d.Name)
 			continue
 		}
 
-		fmt.Fprintf(out, "Executing schedule: %s (scope: %s, provider: %s)\n",
-			d.Name, d.Scope, d.Provider)
-
-		summary := executeSchedule(cmd.Context(), orch, d)
-		printCollectionSummary(out, summary)
-
-		if summary.Failed > 0 {
-			if err := s.MarkFailed(d.Name, now, fmt.Sprintf("%d tools failed", summary.Failed)); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to save state for %s: %v\n", d.Name, err)
-			}
-		} else {
-			if err := s.MarkCompleted(d.Name, now); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to save state for %s: %v\n", d.Name, err)
-			}
-		}
+		runAndRecordSchedule(cmd.Context(), out, s, orch, d, now, router)
 	}
 
 	return nil
 }
 
 // runNamedSchedule runs a specific schedule by name.
-func runNamedSchedule(cmd *cobra.Command, s *scheduler.Scheduler, name string, now time.Time, dryRun, force bool) error {
+func runNamedSchedule(cmd *cobra.Command, s *scheduler.Scheduler, name string, now time.Time, window string, dryRun, force bool) error {
 	out := cmd.OutOrStdout()
 
 	cfg, err := config.Load()
@@ -463,33 +499,45 @@ func runNamedSchedule(cmd *cobra.Command, s *scheduler.Scheduler, name string, n
 		}
 	}
 
+	orch := loadOrchestrator(s)
 	if dryRun {
 		fmt.Fprintf(out, "[dry-run] Would execute schedule: %s (scope: %s, provider: %s)\n",
 			found.Name, found.Scope, found.Provider)
+		printSchedulePlan(out, orch, found.Name)
 		return nil
 	}
 
-	fmt.Fprintf(out, "Executing schedule: %s (scope: %s, provider: %s)\n",
-		found.Name, found.Scope, found.Provider)
-
-	orch := loadOrchestrator(s)
-	summary := executeSchedule(cmd.Context(), orch, scheduler.Schedule{
+	router, err := newToolOutputRouter(window)
+	if err != nil {
+		return err
+	}
+	runAndRecordSchedule(cmd.Context(), out, s, orch, scheduler.Schedule{
 		Name: found.Name, Cron: found.Cron, Enabled: found.Enabled,
 		Scope: found.Scope, Provider: found.Provider,
-	})
+	}, now, router)
+
+	return nil
+}
+
+// runAndRecordSchedule executes a schedule, prints its summary and records
+// the outcome in the scheduler state.
+func runAndRecordSchedule(ctx context.Context, out io.Writer, s *scheduler.Scheduler, orch *scheduler.Orchestrator, sched scheduler.Schedule, now time.Time, router *toolOutputRouter) *scheduler.CollectionSummary {
+	fmt.Fprintf(out, "Executing schedule: %s (scope: %s, provider: %s)\n",
+		sched.Name, sched.Scope, sched.Provider)
+
+	summary := executeSchedule(ctx, orch, sched, router)
 	printCollectionSummary(out, summary)
 
 	if summary.Failed > 0 {
-		if err := s.MarkFailed(name, now, fmt.Sprintf("%d tools failed", summary.Failed)); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to save state for %s: %v\n", name, err)
+		if err := s.MarkFailed(sched.Name, now, fmt.Sprintf("%d tools failed", summary.Failed)); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save state for %s: %v\n", sched.Name, err)
 		}
 	} else {
-		if err := s.MarkCompleted(name, now); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to save state for %s: %v\n", name, err)
+		if err := s.MarkCompleted(sched.Name, now); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save state for %s: %v\n", sched.Name, err)
 		}
 	}
-
-	return nil
+	return summary
 }
 
 // loadOrchestrator creates an Orchestrator from schedule-task-tool mappings in config.
@@ -503,16 +551,7 @@ func loadOrchestrator(_ *scheduler.Scheduler) *scheduler.Orchestrator {
 		return scheduler.NewOrchestrator(nil, log)
 	}
 
-	var mappings []scheduler.TaskToolMapping
-	for _, tm := range cfg.Schedules.TaskMappings {
-		mappings = append(mappings, scheduler.TaskToolMapping{
-			TaskRef:  tm.TaskRef,
-			Tools:    tm.Tools,
-			Schedule: tm.Schedule,
-		})
-	}
-
-	return scheduler.NewOrchestrator(mappings, log)
+	return scheduler.NewOrchestrator(scheduler.MappingsFromConfig(cfg.Schedules), log)
 }
 
 // toolExecutor wraps the global tool registry ExecuteTool as the executor
@@ -522,15 +561,106 @@ func toolExecutor(ctx context.Context, toolName string, params map[string]interf
 	return result, err
 }
 
-// executeSchedule runs a single schedule through the orchestrator.
-func executeSchedule(ctx context.Context, orch *scheduler.Orchestrator, sched scheduler.Schedule) *scheduler.CollectionSummary {
-	mappings := orch.GetMappingsForSchedule(sched.Name)
-	var taskRefs []string
-	for _, m := range mappings {
-		taskRefs = append(taskRefs, m.TaskRef)
+// executeSchedule runs a single schedule through the orchestrator. With a
+// router, tools get their task's parameters and their output is saved in the
+// task's evidence window.
+func executeSchedule(ctx context.Context, orch *scheduler.Orchestrator, sched scheduler.Schedule, router *toolOutputRouter) *scheduler.CollectionSummary {
+	plan := orch.BuildSchedulePlan(sched.Name)
+	if router != nil {
+		router.prepare(plan)
+	}
+	summary := orch.Execute(ctx, plan, toolExecutor)
+	if router != nil {
+		router.save(summary)
+	}
+	return summary
+}
+
+// printSchedulePlan lists the tool runs of a schedule.
+func printSchedulePlan(out io.Writer, orch *scheduler.Orchestrator, name string) {
+	plan := orch.BuildSchedulePlan(name)
+	if len(plan.Tasks) == 0 {
+		fmt.Fprintln(out, "  No tools are mapped to this schedule.")
+		return
+	}
+	for _, task := range plan.Tasks {
+		var toolNames []string
+		for _, tool := range task.Tools {
+			toolNames = append(toolNames, tool.ToolName)
+		}
+		fmt.Fprintf(out, "  Task %s: %s\n", task.TaskRef, strings.Join(toolNames, ", "))
+	}
+}
+
+// toolOutputRouter gives scheduled tool runs the parameters of their task
+// and saves successful output as tool data of the task's evidence window.
+type toolOutputRouter struct {
+	cfg    *config.Config
+	store  *storage.Storage
+	window string
+	tasks  map[string]*domain.EvidenceTask // Planned tasks by task ref
+	errs   map[string]error                // Planned tasks that failed to load
+}
+
+// newToolOutputRouter routes output to window, or to the current quarter
+// when window is empty.
+func newToolOutputRouter(window string) (*toolOutputRouter, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	if window == "" {
+		window = getCurrentQuarter()
+	}
+	return &toolOutputRouter{
+		cfg:    cfg,
+		store:  store,
+		window: window,
+		tasks:  make(map[string]*domain.EvidenceTask),
+		errs:   make(map[string]error),
+	}, nil
+}
+
+// prepare sets the parameters of each planned tool run from its task.
+func (r *toolOutputRouter) prepare(plan *scheduler.CollectionPlan) {
+	for i := range plan.Tasks {
+		planned := &plan.Tasks[i]
+		task, err := r.store.GetEvidenceTask(planned.TaskRef)
+		if err != nil {
+			r.errs[planned.TaskRef] = err
+			continue
+		}
+		r.tasks[planned.TaskRef] = task
+		for j := range planned.Tools {
+			planned.Tools[j].Params = createToolRequestForEvidence(task, planned.Tools[j].ToolName, r.cfg)
+		}
+	}
+}
+
+// save writes the output of successful tool runs. Runs whose output cannot
+// be saved count as failed.
+func (r *toolOutputRouter) save(summary *scheduler.CollectionSummary) {
+	for i := range summary.Results {
+		result := &summary.Results[i]
+		if !result.Success {
+			continue
+		}
+
+		err := r.errs[result.TaskRef]
+		if task, ok := r.tasks[result.TaskRef]; ok {
+			err = saveToolOutput(r.cfg, task, r.window, result.ToolName, result.Output)
+		}
+		if err != nil {
+			result.Success = false
+			result.Error = fmt.Sprintf("output not saved: %v", err)
+			summary.Succeeded--
+			summary.Failed++
+		}
 	}
-	plan := orch.BuildPlan(taskRefs)
-	return orch.Execute(ctx, plan, toolExecutor)
 }
 
 // printCollectionSummary outputs the per-task results and overall summary of a collection run.
@@ -69,7 +69,7 @@ func TestExecuteSchedule_NoMappings(t *testing.T) {
 	orch := scheduler.NewOrchestrator(nil, log)
 
 	sched := scheduler.Schedule{Name: "test-schedule", Scope: "all"}
-	summary := executeSchedule(context.Background(), orch, sched)
+	summary := executeSchedule(context.Background(), orch, sched, nil)
 	assert.Equal(t, 0, summary.TotalTasks)
 	assert.Equal(t, 0, summary.Failed)
 }
@@ -86,7 +86,7 @@ func TestExecuteSchedule_WithMappings(t *testing.T) {
 	sched := scheduler.Schedule{Name: "nightly", Scope: "all"}
 
 	// Test with matching schedule name — plan has 1 task.
-	summary := executeSchedule(context.Background(), orch, sched)
+	summary := executeSchedule(context.Background(), orch, sched, nil)
 	assert.Equal(t, 1, summary.TotalTasks)
 	// Tool "test-tool" isn't registered, so it fails, but plan was built.
 	assert.Equal(t, 1, summary.Failed)
@@ -160,6 +160,12 @@ storage:
 	require.NoError(t, store.SaveEvidenceTask(&domain.EvidenceTask{
 		ID: "1004", ReferenceID: "ET-0004", Name: "No Schedule Task",
 	}))
+	require.NoError(t, store.SaveEvidenceTask(&domain.EvidenceTask{
+		ID: "1005", ReferenceID: "ET-0005", Name: "Deferred Task", NextDue: &overdueDue,
+	}))
+	require.NoError(t, store.SaveTaskDeferral(&domain.TaskDeferral{
+		TaskRef: "ET-0005", Until: now.AddDate(0, 1, 0), Reason: "Waiting on vendor report",
+	}))
 
 	var buf bytes.Buffer
 	printTaskDueGroupings(&buf, cfg, now)
@@ -178,6 +184,68 @@ storage:
 	assert.Contains(t, output, "ET-0003")
 	assert.Contains(t, output, "Due This Month Task")
 
+	assert.Contains(t, output, "Evidence Tasks: Deferred ===\n  ET-0005  Deferred Task  (deferred until 2026-05-10: Waiting on vendor report)")
+	assert.NotContains(t, output, "ET-0005  Deferred Task  (overdue")
+
 	// ET-0004 has no NextDue, should not appear
 	assert.NotContains(t, output, "ET-0004")
 }
+
+func TestToolOutputRouter(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Storage: config.StorageConfig{DataDir: t.TempDir()}}
+	store, err := storage.NewStorage(cfg.Storage)
+	require.NoError(t, err)
+	task := &domain.EvidenceTask{ID: "1001", ReferenceID: "ET-0001", Name: "GitHub Access Review"}
+	require.NoError(t, store.SaveEvidenceTask(task))
+
+	router := &toolOutputRouter{
+		cfg: cfg, store: store, window: "2025-Q4",
+		tasks: make(map[string]*domain.EvidenceTask), errs: make(map[string]error),
+	}
+	plan := &scheduler.CollectionPlan{Tasks: []scheduler.PlannedTask{
+		{TaskRef: "ET-0001", Tools: []scheduler.PlannedToolRun{{ToolName: "github-permissions"}}},
+		{TaskRef: "ET-0999", Tools: []scheduler.PlannedToolRun{{ToolName: "github-permissions"}}},
+	}}
+	router.prepare(plan)
+	assert.Equal(t, "ET-0001", plan.Tasks[0].Tools[0].Params["task_ref"])
+	assert.Nil(t, plan.Tasks[1].Tools[0].Params)
+
+	summary := &scheduler.CollectionSummary{
+		Succeeded: 2,
+		Failed:    1,
+		Results: []scheduler.CollectionResult{
+			{TaskRef: "ET-0001", ToolName: "github-permissions", Success: true, Output: `{"members": 3}`},
+			{TaskRef: "ET-0001", ToolName: "github-security-features", Error: "auth required"},
+			{TaskRef: "ET-0999", ToolName: "github-permissions", Success: true, Output: "{}"},
+		},
+	}
+	router.save(summary)
+
+	data, err := os.ReadFile(filepath.Join(toolOutputDir(cfg, task, "2025-Q4"), "github-permissions.json"))
+	require.NoError(t, err)
+	assert.Equal(t, `{"members": 3}`, string(data))
+	assert.NoFileExists(t, filepath.Join(toolOutputDir(cfg, task, "2025-Q4"), "github-security-features.json"))
+
+	assert.False(t, summary.Results[2].Success)
+	assert.Contains(t, summary.Results[2].Error, "output not saved")
+	assert.Equal(t, 1, summary.Succeeded)
+	assert.Equal(t, 2, summary.Failed)
+}
+
+func TestPrintSchedulePlan(t *testing.T) {
+	t.Parallel()
+
+	orch := scheduler.NewOrchestrator([]scheduler.TaskToolMapping{
+		{TaskRef: "ET-0047", Tools: []string{"github-permissions", "github-security-features"}, Schedule: "weekly-github"},
+	}, testhelpers.NewStubLogger())
+
+	var buf bytes.Buffer
+	printSchedulePlan(&buf, orch, "weekly-github")
+	assert.Equal(t, "  Task ET-0047: github-permissions, github-security-features\n", buf.String())
+
+	buf.Reset()
+	printSchedulePlan(&buf, orch, "nightly")
+	assert.Contains(t, buf.String(), "No tools are mapped")
+}
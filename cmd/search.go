@@ -0,0 +1,114 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search policies, controls, evidence tasks and evidence files",
+	Long: `Full-text search across the synced policies, controls and evidence tasks
+and the text of generated evidence (Markdown, CSV, JSON, YAML and similar
+files). Every word must match, in any form ("encrypt" finds "encryption");
+put a phrase in quotes to match it exactly. Results are ranked with names
+weighing most, and show the matching text.
+
+The search index lives in the storage catalog (.state/catalog/) and is
+refreshed from the files before each search. Encrypted evidence is not
+indexed.
+
+Examples:
+  grctool search encryption at rest
+  grctool search '"access review"' --type evidence
+  grctool search mfa --type policy --type control --output json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSearch,
+}
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+
+	searchCmd.Flags().StringSlice("type", nil, "only these types: "+strings.Join(storage.SearchKinds, ", "))
+	searchCmd.Flags().Int("limit", 20, "maximum number of results")
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	kinds, _ := cmd.Flags().GetStringSlice("type")
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if limit <= 0 {
+		return fmt.Errorf("--limit must be positive")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	catalog, err := storage.OpenCatalog(cfg.Storage)
+	if err != nil {
+		return err
+	}
+	defer catalog.Close()
+
+	query := strings.Join(args, " ")
+	results, err := catalog.Search(query, storage.SearchOptions{Kinds: kinds, Limit: limit})
+	if err != nil {
+		return err
+	}
+
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, results)
+	}
+	displaySearchResults(cmd, query, results)
+	return nil
+}
+
+func displaySearchResults(cmd *cobra.Command, query string, results []storage.SearchResult) {
+	if len(results) == 0 {
+		cmd.Printf("No results for %q\n", query)
+		return
+	}
+
+	icons := map[string]string{
+		storage.SearchPolicy:   "📜",
+		storage.SearchControl:  "🛡️ ",
+		storage.SearchTask:     "📋",
+		storage.SearchEvidence: "📁",
+	}
+	cmd.Printf("🔎 %d result(s) for %q\n", len(results), query)
+	for _, result := range results {
+		cmd.Println()
+		if result.Kind == storage.SearchEvidence {
+			cmd.Printf("%s %s  %s (evidence)\n", icons[result.Kind], result.Ref, result.Location)
+		} else {
+			cmd.Printf("%s %s  %s (%s)\n", icons[result.Kind], result.Ref, result.Title, result.Kind)
+		}
+		if result.Snippet != "" {
+			cmd.Printf("   %s\n", result.Snippet)
+		}
+	}
+}
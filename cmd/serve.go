@@ -0,0 +1,317 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/server"
+	"github.com/grctool/grctool/internal/services"
+	"github.com/grctool/grctool/internal/services/evidence"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/grctool/grctool/internal/tugboat"
+	"github.com/grctool/grctool/internal/webhook"
+	"github.com/spf13/cobra"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the evidence API and web dashboard over HTTP",
+	Long: `Run an HTTP server exposing evidence tasks, status, evidence files,
+validation results and submissions as a JSON API, so dashboards and
+automations can integrate without shelling out to the CLI.
+
+The server also hosts a web dashboard at / with the status overview, task
+list and detail, evidence file browser and validation scores. It reads
+everything through the API; disable it with --dashboard=false.
+
+Endpoints:
+  GET  /api/v1/health
+  GET  /api/v1/tasks                                    (evidence list filters as query parameters)
+  GET  /api/v1/tasks/summary
+  GET  /api/v1/tasks/{ref}
+  GET  /api/v1/status                                   (?state=&automation=)
+  GET  /api/v1/status/{ref}
+  GET  /api/v1/tasks/{ref}/windows/{window}/files
+  GET  /api/v1/tasks/{ref}/windows/{window}/files/{name}
+  GET  /api/v1/tasks/{ref}/windows/{window}/validation
+  POST /api/v1/tasks/{ref}/windows/{window}/submit      ({"notes", "skip_validation", "dry_run"})
+  POST /api/v1/webhooks/tugboat                         (with --webhooks)
+  GET  /ack/{policy}/{period}                           (signed policy acknowledgment links)
+
+When a token is set (--token or GRCTOOL_API_TOKEN), every endpoint except
+health and webhooks requires "Authorization: Bearer <token>"; the dashboard
+asks for it. The server refuses to listen on anything other than a loopback
+address without a token, unless --insecure-no-auth is given. Submissions
+upload evidence to Tugboat Logic, so without a token the server is read-only.
+
+Requests that change state must send "Content-Type: application/json" and,
+when they carry an Origin header, come from the server's own origin. When
+listening on a specific address, requests must name it in the Host header
+(localhost too for loopback addresses); add names such as a reverse proxy's
+with --allowed-host.
+
+With --webhooks, Tugboat notifications update local state as they arrive
+instead of on the next sync:
+
+  {"event": "evidence.accepted", "task_id": "327992", "window": "2025-Q4",
+   "submission_id": "...", "comment": "...", "reviewer": "...", "occurred_at": "..."}
+
+evidence.accepted and evidence.rejected record the auditor's review on the
+window's submission; without a window, the window holding submission_id or
+else the latest submitted window is used. task.created and task.updated sync
+the task from Tugboat. Requests must carry an X-Webhook-Signature of
+"sha256=<hex HMAC-SHA256 of the body>" keyed with the webhook secret
+(--webhook-secret or GRCTOOL_WEBHOOK_SECRET), or present the secret as a
+bearer token.
+
+With an acknowledgment secret (--ack-secret or GRCTOOL_ACK_SECRET), employees
+acknowledge policies at the signed links printed by 'grctool policy ack link';
+acknowledgments are recorded with the source "link".
+
+Examples:
+  # Serve the API and dashboard on localhost
+  grctool serve
+
+  # Serve on all interfaces with a token
+  GRCTOOL_API_TOKEN=$(openssl rand -hex 32) grctool serve --addr :8080
+
+  # Query the API
+  curl -H "Authorization: Bearer $GRCTOOL_API_TOKEN" localhost:8080/api/v1/tasks?status=pending
+
+  # Receive Tugboat notifications
+  GRCTOOL_WEBHOOK_SECRET=$(openssl rand -hex 32) grctool serve --addr :8080 --webhooks`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().String("addr", "127.0.0.1:8080", "address to listen on")
+	serveCmd.Flags().String("token", "", "bearer token required by the API (default: $GRCTOOL_API_TOKEN)")
+	serveCmd.Flags().Bool("read-only", false, "disable evidence submission (always disabled without a token)")
+	serveCmd.Flags().Bool("insecure-no-auth", false, "serve without a token on a non-loopback address, exposing all evidence to the network")
+	serveCmd.Flags().StringSlice("allowed-host", nil, "additional host names clients may use to reach the server, e.g. behind a reverse proxy")
+	serveCmd.Flags().Bool("dashboard", true, "serve the web dashboard at /")
+	serveCmd.Flags().Bool("webhooks", false, "receive Tugboat notifications at /api/v1/webhooks/tugboat")
+	serveCmd.Flags().String("webhook-secret", "", "secret authenticating webhook requests (default: $GRCTOOL_WEBHOOK_SECRET)")
+	serveCmd.Flags().String("ack-secret", "", "secret signing policy acknowledgment links; enables /ack/ (default: $GRCTOOL_ACK_SECRET)")
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	addr, _ := cmd.Flags().GetString("addr")
+	token, _ := cmd.Flags().GetString("token")
+	readOnly, _ := cmd.Flags().GetBool("read-only")
+	dashboard, _ := cmd.Flags().GetBool("dashboard")
+	webhooks, _ := cmd.Flags().GetBool("webhooks")
+	webhookSecret, _ := cmd.Flags().GetString("webhook-secret")
+	ackSecret, _ := cmd.Flags().GetString("ack-secret")
+	extraHosts, _ := cmd.Flags().GetStringSlice("allowed-host")
+	insecureNoAuth, _ := cmd.Flags().GetBool("insecure-no-auth")
+	if token == "" {
+		token = os.Getenv("GRCTOOL_API_TOKEN")
+	}
+	if webhookSecret == "" {
+		webhookSecret = os.Getenv("GRCTOOL_WEBHOOK_SECRET")
+	}
+	if ackSecret == "" {
+		ackSecret = os.Getenv("GRCTOOL_ACK_SECRET")
+	}
+	if webhooks && webhookSecret == "" {
+		return errors.New("--webhooks requires a secret: set --webhook-secret or GRCTOOL_WEBHOOK_SECRET")
+	}
+
+	if err := checkServeAuth(addr, token, insecureNoAuth); err != nil {
+		return err
+	}
+	allowedHosts, err := serveAllowedHosts(addr, extraHosts)
+	if err != nil {
+		return err
+	}
+
+	handler, err := newAPIHandler(server.Options{
+		Token:                token,
+		AllowedHosts:         allowedHosts,
+		Version:              version,
+		Dashboard:            dashboard,
+		WebhookSecret:        webhookSecret,
+		AcknowledgmentSecret: ackSecret,
+	}, readOnly || token == "", webhooks)
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	httpServer := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	cmd.Printf("🌐 Serving grctool API on http://%s/api/v1\n", listener.Addr())
+	if dashboard {
+		cmd.Printf("📊 Dashboard: http://%s/\n", listener.Addr())
+	}
+	if token == "" {
+		if !isLoopback(listener.Addr()) {
+			cmd.Println("⚠️  --insecure-no-auth: every task and evidence file is served to the network without authentication")
+		}
+	} else {
+		cmd.Println("🔒 Bearer token required")
+	}
+	if readOnly {
+		cmd.Println("📖 Read-only: evidence submission is disabled")
+	} else if token == "" {
+		cmd.Println("📖 Read-only: evidence submission requires an API token")
+	}
+	if webhooks {
+		cmd.Printf("🪝 Receiving Tugboat webhooks at http://%s/api/v1/webhooks/tugboat\n", listener.Addr())
+	}
+	if ackSecret != "" {
+		cmd.Printf("✍️  Recording policy acknowledgments at http://%s/ack/\n", listener.Addr())
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("server failed: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	cmd.Println("Shutting down...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down server: %w", err)
+	}
+	return nil
+}
+
+// newAPIHandler wires the API server and dashboard to storage, the evidence
+// service and the evidence scanner, and webhooks to the sync service
+func newAPIHandler(opts server.Options, readOnly, webhooks bool) (http.Handler, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	consoleLoggerCfg := cfg.Logging.Loggers["console"]
+	log, err := logger.New((&consoleLoggerCfg).ToLoggerConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize logger: %w", err)
+	}
+
+	evidenceService, err := evidence.NewService(services.NewDataService(store), cfg, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize evidence service: %w", err)
+	}
+
+	evidenceDir := filepath.Join(cfg.Storage.DataDir, "evidence")
+	scanner := services.NewEvidenceScanner(evidenceDir, &storageAdapter{storage: store}, log)
+
+	var submitter server.Submitter
+	if !readOnly {
+		submitter = newSubmissionService(cfg, store, "", false)
+	}
+
+	if webhooks {
+		syncService := services.NewSyncService(tugboat.NewClient(&cfg.Tugboat, nil), store, cfg, log)
+		opts.Webhooks = webhook.NewProcessor(store, scanner, syncService, log.WithComponent("webhook"))
+	}
+
+	if opts.AcknowledgmentSecret != "" {
+		opts.Acknowledgments = store
+	}
+
+	srv := server.NewServer(evidenceService, scanner, store, submitter, opts, log)
+	return srv.Handler(), nil
+}
+
+// serveAllowedHosts returns the host names accepted in the Host header when
+// listening on addr: its host, plus localhost for loopback addresses. A
+// wildcard address accepts any host unless extra names are given.
+func serveAllowedHosts(addr string, extra []string) ([]string, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --addr %q: %w", addr, err)
+	}
+
+	var hosts []string
+	ip := net.ParseIP(host)
+	switch {
+	case host == "" || (ip != nil && ip.IsUnspecified()):
+	case host == "localhost" || (ip != nil && ip.IsLoopback()):
+		hosts = []string{"localhost", "127.0.0.1", "::1"}
+		if !slices.Contains(hosts, host) {
+			hosts = append(hosts, host)
+		}
+	default:
+		hosts = []string{host}
+	}
+	return append(hosts, extra...), nil
+}
+
+// checkServeAuth refuses to serve without a token on an address other
+// machines can reach, unless insecureNoAuth overrides it
+func checkServeAuth(addr, token string, insecureNoAuth bool) error {
+	if token != "" || insecureNoAuth {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid --addr %q: %w", addr, err)
+	}
+	if ip := net.ParseIP(host); host == "localhost" || (ip != nil && ip.IsLoopback()) {
+		return nil
+	}
+	return fmt.Errorf("refusing to serve on %s without an API token: set --token or GRCTOOL_API_TOKEN, or pass --insecure-no-auth", addr)
+}
+
+func isLoopback(addr net.Addr) bool {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	return ok && tcpAddr.IP.IsLoopback()
+}
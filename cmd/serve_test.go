@@ -0,0 +1,77 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeAllowedHosts(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		addr    string
+		extra   []string
+		want    []string
+		wantErr bool
+	}{
+		"default loopback":   {addr: "127.0.0.1:8080", want: []string{"localhost", "127.0.0.1", "::1"}},
+		"other loopback":     {addr: "127.0.0.2:8080", want: []string{"localhost", "127.0.0.1", "::1", "127.0.0.2"}},
+		"localhost":          {addr: "localhost:8080", want: []string{"localhost", "127.0.0.1", "::1"}},
+		"specific address":   {addr: "10.0.0.5:8080", extra: []string{"grc.example.com"}, want: []string{"10.0.0.5", "grc.example.com"}},
+		"wildcard":           {addr: ":8080"},
+		"wildcard with host": {addr: "0.0.0.0:8080", extra: []string{"grc.example.com"}, want: []string{"grc.example.com"}},
+		"missing port":       {addr: "127.0.0.1", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := serveAllowedHosts(tt.addr, tt.extra)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCheckServeAuth(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		addr     string
+		token    string
+		insecure bool
+		wantErr  string
+	}{
+		"loopback without token":         {addr: "127.0.0.1:8080"},
+		"localhost without token":        {addr: "localhost:8080"},
+		"ipv6 loopback without token":    {addr: "[::1]:8080"},
+		"wildcard with token":            {addr: ":8080", token: "secret"},
+		"wildcard without token":         {addr: ":8080", wantErr: "refusing to serve on :8080 without an API token"},
+		"all interfaces without token":   {addr: "0.0.0.0:8080", wantErr: "refusing to serve on 0.0.0.0:8080"},
+		"specific address without token": {addr: "10.0.0.5:8080", wantErr: "refusing to serve on 10.0.0.5:8080"},
+		"host name without token":        {addr: "grc.example.com:8080", wantErr: "refusing to serve"},
+		"insecure no auth":               {addr: ":8080", insecure: true},
+		"missing port":                   {addr: "127.0.0.1", wantErr: "invalid --addr"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := checkServeAuth(tt.addr, tt.token, tt.insecure)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -116,6 +116,12 @@ func runStatusDashboard(cmd *cobra.Command, args []string) error {
 	filterAutomation, _ := cmd.Flags().GetString("automation")
 	verbose, _ := cmd.Flags().GetBool("verbose")
 
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	structured := isStructuredOutput(format)
+
 	// Initialize scanner
 	scanner, cfg, err := initializeScanner()
 	if err != nil {
@@ -129,7 +135,9 @@ func runStatusDashboard(cmd *cobra.Command, args []string) error {
 	}
 
 	// Perform scan
-	cmd.Println("Scanning evidence directories...")
+	if !structured {
+		cmd.Println("Scanning evidence directories...")
+	}
 	taskStates, err := scanner.ScanAll(ctx)
 	if err != nil {
 		return fmt.Errorf("scan failed: %w", err)
@@ -142,7 +150,7 @@ func runStatusDashboard(cmd *cobra.Command, args []string) error {
 	}
 
 	// Apply filters
-	var filteredTasks []*models.EvidenceTaskState
+	filteredTasks := make([]*models.EvidenceTaskState, 0, len(taskStates))
 	for _, task := range taskStates {
 		// State filter
 		if filterState != "" && task.LocalState != models.LocalEvidenceState(filterState) {
@@ -155,6 +163,26 @@ func runStatusDashboard(cmd *cobra.Command, args []string) error {
 		filteredTasks = append(filteredTasks, task)
 	}
 
+	// Deferred tasks are reported apart from the evidence states; a dashboard
+	// without them is still useful, so read failures are not fatal
+	deferred := activeDeferrals(cfg, time.Now())
+
+	if structured {
+		sort.Slice(filteredTasks, func(i, j int) bool {
+			return filteredTasks[i].TaskRef < filteredTasks[j].TaskRef
+		})
+		return writeStructured(cmd, format, StatusDashboardResult{
+			EvidenceDir:   evidenceDir,
+			LastScan:      cache.LastScan,
+			TotalTasks:    len(taskStates),
+			FilteredTasks: len(filteredTasks),
+			ByState:       cache.GetStateSummary(),
+			ByAutomation:  cache.GetAutomationSummary(),
+			Tasks:         filteredTasks,
+			Deferred:      deferred,
+		})
+	}
+
 	// Display header
 	cmd.Println()
 	cmd.Println("Evidence Status Dashboard")
@@ -213,6 +241,14 @@ func runStatusDashboard(cmd *cobra.Command, args []string) error {
 	}
 	cmd.Println()
 
+	if len(deferred) > 0 {
+		cmd.Printf("Deferred (%d):\n", len(deferred))
+		for _, d := range deferred {
+			cmd.Printf("  %-10s until %s  %s\n", d.TaskRef, d.Until.Format("2006-01-02"), d.Reason)
+		}
+		cmd.Println()
+	}
+
 	// Display recent activity
 	recentTasks := getRecentActivity(filteredTasks, 10)
 	if len(recentTasks) > 0 {
@@ -242,6 +278,12 @@ func runStatusTask(cmd *cobra.Command, args []string) error {
 	// Normalize task reference (e.g., ET-1 -> ET-0001)
 	taskRef = normalizeTaskRef(taskRef)
 
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	structured := isStructuredOutput(format)
+
 	// Initialize scanner
 	scanner, _, err := initializeScanner()
 	if err != nil {
@@ -249,12 +291,18 @@ func runStatusTask(cmd *cobra.Command, args []string) error {
 	}
 
 	// Scan the specific task
-	cmd.Printf("Scanning task %s...\n", taskRef)
+	if !structured {
+		cmd.Printf("Scanning task %s...\n", taskRef)
+	}
 	taskState, err := scanner.ScanTask(ctx, taskRef)
 	if err != nil {
 		return fmt.Errorf("failed to scan task: %w", err)
 	}
 
+	if structured {
+		return writeStructured(cmd, format, taskState)
+	}
+
 	// Display task header
 	cmd.Println()
 	cmd.Printf("%s: %s\n", taskRef, taskState.TaskName)
@@ -331,6 +379,18 @@ func runStatusScan(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// StatusDashboardResult is the structured output of 'status'
+type StatusDashboardResult struct {
+	EvidenceDir   string                              `json:"evidence_dir"`
+	LastScan      time.Time                           `json:"last_scan"`
+	TotalTasks    int                                 `json:"total_tasks"`
+	FilteredTasks int                                 `json:"filtered_tasks"`
+	ByState       map[models.LocalEvidenceState]int   `json:"by_state"`
+	ByAutomation  map[models.AutomationCapability]int `json:"by_automation"`
+	Tasks         []*models.EvidenceTaskState         `json:"tasks"`
+	Deferred      []domain.TaskDeferral               `json:"deferred,omitempty"`
+}
+
 // Helper functions
 
 // storageAdapter adapts storage.Storage to services.Storage interface
@@ -342,6 +402,26 @@ func (sa *storageAdapter) GetEvidenceTask(ctx context.Context, taskID string) (*
 	return sa.storage.GetEvidenceTask(taskID)
 }
 
+// activeDeferrals returns the task deferrals that still apply at now, or
+// none when they cannot be read
+func activeDeferrals(cfg *config.Config, now time.Time) []domain.TaskDeferral {
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return nil
+	}
+	all, err := store.GetAllTaskDeferrals()
+	if err != nil {
+		return nil
+	}
+	var active []domain.TaskDeferral
+	for i := range all {
+		if all[i].Active(now) {
+			active = append(active, all[i])
+		}
+	}
+	return active
+}
+
 // initializeScanner creates a new evidence scanner instance
 func initializeScanner() (services.EvidenceScanner, *config.Config, error) {
 	// Load configuration
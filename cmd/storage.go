@@ -0,0 +1,742 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/keychain"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/grctool/grctool/internal/tools"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var storageCmd = &cobra.Command{
+	Use:   "storage",
+	Short: "Mirror the data directory to S3 or GCS",
+	Long: `Mirror the data directory (synced documents, evidence and metadata) to an
+S3 or GCS bucket, so a team shares one copy and ephemeral CI runners start
+from it. The local data directory stays the cache every read is served from.
+
+Configure the bucket in the config file:
+
+  storage:
+    data_dir: ./data
+    remote:
+      url: s3://acme-grc/grctool      # or gs://acme-grc/grctool
+      region: us-east-1               # S3 only
+      endpoint: https://minio:9000    # S3-compatible stores only
+      auto_sync: true                 # pull before and push after every command
+
+S3 uses AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY, or the aws CLI's
+credentials (profiles, SSO, instance roles). GCS uses Application Default
+Credentials. The cache directory is not mirrored.
+
+A file changed both locally and remotely since the last sync is a conflict:
+pull keeps the local copy and reports it, and the next push uploads it.
+
+Examples:
+  grctool storage status
+  grctool storage pull
+  grctool storage push --dry-run`,
+}
+
+var storagePullCmd = &cobra.Command{
+	Use:   "pull",
+	Short: "Download files changed in the bucket since the last sync",
+	Args:  cobra.NoArgs,
+	RunE:  runStoragePull,
+}
+
+var storagePushCmd = &cobra.Command{
+	Use:   "push",
+	Short: "Upload files changed locally since the last sync",
+	Args:  cobra.NoArgs,
+	RunE:  runStoragePush,
+}
+
+var storageKeygenCmd = &cobra.Command{
+	Use:   "keygen",
+	Short: "Generate the key sensitive evidence is encrypted with",
+	Long: `Generate an AES-256 key for storage.encryption and store it in the OS
+keychain for the active profile. With --print the key is printed instead,
+to be kept in a secret store and given to CI as GRCTOOL_STORAGE_ENCRYPTION_KEY.
+
+Everyone reading encrypted evidence needs the same key; share it through
+your secret manager, or use storage.encryption.kms_key_id instead.`,
+	Args: cobra.NoArgs,
+	RunE: runStorageKeygen,
+}
+
+var storageEncryptCmd = &cobra.Command{
+	Use:   "encrypt",
+	Short: "Encrypt the evidence of sensitive tasks now",
+	Long: `Encrypt the plaintext evidence files of tasks flagged sensitive, and of
+those listed in storage.encryption.tasks. With storage.encryption.enabled
+evidence is encrypted as it is written, and this also happens after every
+command and before storage push.`,
+	Args: cobra.NoArgs,
+	RunE: runStorageEncrypt,
+}
+
+var storageDecryptCmd = &cobra.Command{
+	Use:   "decrypt [task-ref...]",
+	Short: "Turn encrypted evidence back into plaintext",
+	Long: `Decrypt the evidence files of the given tasks, or of every encrypted task,
+for example before turning storage.encryption off. Reading and submitting
+evidence decrypts it transparently, so this is not needed day to day.
+
+The decrypted files are never committed by storage.git: with encryption
+still enabled the next command encrypts them again before committing.`,
+	RunE: runStorageDecrypt,
+}
+
+var storageStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show what pull and push would transfer",
+	Args:  cobra.NoArgs,
+	RunE:  runStorageStatus,
+}
+
+var storageGcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Remove stale caches, tool outputs and empty evidence windows",
+	Long: `Reclaim space in the data directory:
+
+  - cache entries past their expiration or older than --cache-max-age days
+  - .context/tool_outputs of windows that were submitted, or whose task
+    directory no longer matches a synced task, unless a legal hold in
+    retention.legal_holds covers them
+  - evidence window directories without any files
+  - stored copies of deduplicated evidence no file refers to anymore
+
+With --compress, windows whose period has ended, whose submission was
+accepted and that hold no unsubmitted evidence are packed into
+<window>.tar.gz beside them. Evidence files are never removed otherwise, and
+windows locked by another grctool run are skipped.
+
+Examples:
+  # See what would be removed
+  grctool storage gc --dry-run
+
+  # Also archive closed windows
+  grctool storage gc --compress`,
+	Args: cobra.NoArgs,
+	RunE: runStorageGc,
+}
+
+var storageDedupCmd = &cobra.Command{
+	Use:   "dedup",
+	Short: "Store evidence copied to several windows once",
+	Long: `Replace evidence files whose content appears in more than one window with
+small reference records pointing to a single copy in .objects/. References
+read as the file they stand for, so validation, scans and reviews see the
+real content, and each window's references are turned back into real files
+when it is submitted.
+
+Only working files of storage.dedup.min_size bytes or more are
+deduplicated; encrypted, submitted and archived evidence is left alone.
+With storage.dedup.enabled this also happens after every command, and
+grctool storage gc removes stored copies nothing refers to anymore.
+
+Examples:
+  # See how much would be saved
+  grctool storage dedup --dry-run
+
+  # Also deduplicate small files
+  grctool storage dedup --min-size 0`,
+	Args: cobra.NoArgs,
+	RunE: runStorageDedup,
+}
+
+var storageCatalogCmd = &cobra.Command{
+	Use:   "catalog",
+	Short: "Refresh the SQLite catalog and show what it indexes",
+	Long: `Refresh the SQLite catalog of the data directory and show what it holds.
+
+With storage.catalog.enabled, every lookup and listing of evidence tasks,
+controls and policies is answered from a catalog in .state/catalog/ instead
+of parsing every file. The catalog is re-indexed
+from the files whenever they change and is never authoritative: it can be
+deleted at any time, and any error reading it falls back to the files.
+
+Examples:
+  grctool storage catalog
+  grctool storage catalog --rebuild`,
+	Args: cobra.NoArgs,
+	RunE: runStorageCatalog,
+}
+
+// noDataCommands are the top-level commands that do not touch data_dir, so
+// are never synced or committed automatically
+var noDataCommands = map[string]bool{
+	"auth":       true,
+	"config":     true,
+	"init":       true,
+	"profile":    true,
+	"help":       true,
+	"completion": true,
+	"version":    true,
+}
+
+func init() {
+	// With storage.remote.auto_sync, data_dir is pulled before and pushed
+	// after each command; with storage.git.enabled, its changes are committed
+	rootCmd.PersistentPreRunE = beforeCommand
+	rootCmd.PersistentPostRunE = recordDataChanges
+
+	rootCmd.AddCommand(storageCmd)
+	storageCmd.AddCommand(storagePullCmd)
+	storageCmd.AddCommand(storagePushCmd)
+	storageCmd.AddCommand(storageStatusCmd)
+	storageCmd.AddCommand(storageKeygenCmd)
+	storageCmd.AddCommand(storageEncryptCmd)
+	storageCmd.AddCommand(storageDecryptCmd)
+	storageCmd.AddCommand(storageGcCmd)
+	storageCmd.AddCommand(storageCatalogCmd)
+	storageCmd.AddCommand(storageDedupCmd)
+
+	storagePullCmd.Flags().Bool("dry-run", false, "list what would be downloaded without downloading")
+	storagePushCmd.Flags().Bool("dry-run", false, "list what would be uploaded without uploading")
+	storageKeygenCmd.Flags().Bool("print", false, "print the key instead of storing it in the keychain")
+	storageKeygenCmd.Flags().Bool("force", false, "replace a key already in the keychain")
+	storageGcCmd.Flags().Bool("dry-run", false, "list what would be removed without removing it")
+	storageGcCmd.Flags().Int("cache-max-age", 7, "remove cache entries not modified for this many days")
+	storageGcCmd.Flags().Bool("compress", false, "pack closed, accepted windows into archives")
+	storageCatalogCmd.Flags().Bool("rebuild", false, "re-index every file, changed or not")
+	storageDedupCmd.Flags().Bool("dry-run", false, "list what would be deduplicated without changing anything")
+	storageDedupCmd.Flags().Int64("min-size", -1, "smallest file to deduplicate, in bytes (default: storage.dedup.min_size)")
+
+	// Encrypted evidence is decrypted with the configured key wherever it is
+	// read; the key is only loaded on meeting an encrypted file
+	storage.UseEncryption(loadEvidenceCipher)
+}
+
+// loadEvidenceCipher loads the key storage.encryption configures
+func loadEvidenceCipher() (*storage.Cipher, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	return storage.LoadCipher(context.Background(), cfg.Storage)
+}
+
+// loadMirror returns the configured mirror
+func loadMirror(ctx context.Context) (*storage.Mirror, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	mirror, err := storage.NewMirror(ctx, cfg.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if mirror == nil {
+		return nil, fmt.Errorf("no remote storage configured: set storage.remote.url to an s3:// or gs:// URL")
+	}
+	return mirror, nil
+}
+
+func runStoragePull(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	mirror, err := loadMirror(ctx)
+	if err != nil {
+		return err
+	}
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	result, err := mirror.Pull(ctx, dryRun)
+	if result != nil {
+		printSyncResult(cmd.OutOrStdout(), "⬇️ ", "Pulled", mirror.Remote(), result, dryRun)
+	}
+	if err != nil {
+		return fmt.Errorf("pull from %s failed: %w", mirror.Remote(), err)
+	}
+	return nil
+}
+
+func runStoragePush(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	mirror, err := loadMirror(ctx)
+	if err != nil {
+		return err
+	}
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	// Evidence a failed command left behind in plaintext is encrypted
+	// before it can reach the bucket
+	if !dryRun && viper.GetBool("storage.encryption.enabled") {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+		if _, err := encryptSensitiveEvidence(cfg); err != nil {
+			return err
+		}
+	}
+
+	result, err := mirror.Push(ctx, dryRun)
+	if result != nil {
+		printSyncResult(cmd.OutOrStdout(), "⬆️ ", "Pushed", mirror.Remote(), result, dryRun)
+	}
+	if err != nil {
+		return fmt.Errorf("push to %s failed: %w", mirror.Remote(), err)
+	}
+	return nil
+}
+
+func runStorageStatus(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	mirror, err := loadMirror(ctx)
+	if err != nil {
+		return err
+	}
+
+	pull, err := mirror.Pull(ctx, true)
+	if err != nil {
+		return fmt.Errorf("failed to list %s: %w", mirror.Remote(), err)
+	}
+	push, err := mirror.Push(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, map[string]interface{}{
+			"remote": mirror.Remote(),
+			"pull":   pull,
+			"push":   push,
+		})
+	}
+
+	cmd.Printf("☁️  Remote: %s\n\n", mirror.Remote())
+	cmd.Printf("⬇️  To pull: %d\n", len(pull.Transferred))
+	for _, key := range pull.Transferred {
+		cmd.Printf("   %s\n", key)
+	}
+	cmd.Printf("⬆️  To push: %d\n", len(push.Transferred))
+	for _, key := range push.Transferred {
+		cmd.Printf("   %s\n", key)
+	}
+	if len(pull.Conflicts) > 0 {
+		cmd.Printf("⚠️  Changed on both sides: %d\n", len(pull.Conflicts))
+		for _, key := range pull.Conflicts {
+			cmd.Printf("   %s\n", key)
+		}
+	}
+	cmd.Printf("✅ In sync: %d\n", push.Unchanged)
+	return nil
+}
+
+func runStorageKeygen(cmd *cobra.Command, args []string) error {
+	key, err := storage.GenerateKey()
+	if err != nil {
+		return fmt.Errorf("failed to generate key: %w", err)
+	}
+	if printKey, _ := cmd.Flags().GetBool("print"); printKey {
+		cmd.Println(key)
+		return nil
+	}
+
+	account := keychain.Account(keychain.AccountEvidenceKey, config.ActiveProfile())
+	if force, _ := cmd.Flags().GetBool("force"); !force {
+		if _, err := keychain.Default.Get(account); err == nil {
+			return fmt.Errorf("an evidence key is already in the keychain; replacing it makes evidence encrypted with it unreadable, so decrypt that first and pass --force")
+		}
+	}
+	if err := keychain.Default.Set(account, key); err != nil {
+		return fmt.Errorf("failed to store the key in the keychain (%s): %w; use --print to keep it elsewhere", keychain.Default.Name(), err)
+	}
+	cmd.Printf("🔑 Evidence key stored in the keychain: %s\n", keychain.Default.Name())
+	cmd.Println("   Set storage.encryption.enabled: true to encrypt sensitive evidence with it")
+	return nil
+}
+
+func runStorageEncrypt(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	encrypted, err := encryptSensitiveEvidence(cfg)
+	if err != nil {
+		return err
+	}
+	cmd.Printf("🔒 Encrypted %d evidence file(s)\n", len(encrypted))
+	return nil
+}
+
+func runStorageDecrypt(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	refs := args
+	if len(refs) == 0 {
+		if refs, err = store.EncryptedTasks(cfg.Storage.Encryption); err != nil {
+			return err
+		}
+	}
+	decrypted, err := store.DecryptEvidence(refs)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt evidence: %w", err)
+	}
+	cmd.Printf("🔓 Decrypted %d evidence file(s)\n", len(decrypted))
+	if cfg.Storage.Encryption.Enabled {
+		cmd.Println("⚠️  storage.encryption is still enabled, so the next command encrypts them again")
+	}
+	return nil
+}
+
+func runStorageGc(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	maxAge, _ := cmd.Flags().GetInt("cache-max-age")
+	compress, _ := cmd.Flags().GetBool("compress")
+	if maxAge < 0 {
+		return fmt.Errorf("--cache-max-age must not be negative")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	result, err := store.GarbageCollect(storage.GCOptions{
+		DryRun:      dryRun,
+		CacheMaxAge: time.Duration(maxAge) * 24 * time.Hour,
+		Compress:    compress,
+		WindowEnd: func(window string) (time.Time, error) {
+			_, end, err := tools.ParseEvidenceWindow(window)
+			return end, err
+		},
+		Held: func(taskRef, window string) bool {
+			return cfg.Retention.HoldOn(taskRef, window) != nil
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("storage gc failed: %w", err)
+	}
+
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, result)
+	}
+
+	verb := "Removed"
+	if dryRun {
+		verb = "Would remove"
+	}
+	if len(result.Items) == 0 {
+		cmd.Println("✅ Nothing to clean up")
+	}
+	for _, item := range result.Items {
+		action := verb
+		if item.Kind == storage.GCKindCompressed {
+			action = "Compressed"
+			if dryRun {
+				action = "Would compress"
+			}
+		}
+		cmd.Printf("🧹 %s %s (%s, %s)", action, item.Path, item.Kind, formatBytes(item.Bytes))
+		if item.Reason != "" {
+			cmd.Printf(": %s", item.Reason)
+		}
+		cmd.Println()
+	}
+	for _, item := range result.Skipped {
+		cmd.Printf("⏭️  Skipped %s: %s\n", item.Path, item.Reason)
+	}
+	if len(result.Items) > 0 {
+		if dryRun {
+			cmd.Printf("\n%d item(s), %s would be freed\n", len(result.Items), formatBytes(result.FreedBytes))
+		} else {
+			cmd.Printf("\n%d item(s), %s freed\n", len(result.Items), formatBytes(result.FreedBytes))
+		}
+	}
+	return nil
+}
+
+func runStorageCatalog(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	catalog, err := storage.OpenCatalog(cfg.Storage)
+	if err != nil {
+		return err
+	}
+	defer catalog.Close()
+
+	if rebuild, _ := cmd.Flags().GetBool("rebuild"); rebuild {
+		if err := catalog.Rebuild(); err != nil {
+			return fmt.Errorf("failed to rebuild catalog: %w", err)
+		}
+	}
+	stats, err := catalog.Stats()
+	if err != nil {
+		return fmt.Errorf("failed to refresh catalog: %w", err)
+	}
+
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, map[string]interface{}{
+			"enabled": cfg.Storage.Catalog.Enabled,
+			"catalog": stats,
+		})
+	}
+
+	cmd.Printf("🗂️  Catalog: %s\n", stats.Path)
+	cmd.Printf("   Evidence tasks: %d\n", stats.Tasks)
+	cmd.Printf("   Controls:       %d\n", stats.Controls)
+	cmd.Printf("   Policies:       %d\n", stats.Policies)
+	cmd.Printf("   Windows:        %d (%d evidence files)\n", stats.Windows, stats.EvidenceFiles)
+	if !stats.RefreshedAt.IsZero() {
+		cmd.Printf("   Last indexed:   %s\n", stats.RefreshedAt.Local().Format("2006-01-02 15:04:05"))
+	}
+	if !cfg.Storage.Catalog.Enabled {
+		cmd.Println("ℹ️  storage.catalog.enabled is off, so commands still read the files directly")
+	}
+	return nil
+}
+
+func runStorageDedup(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if minSize, _ := cmd.Flags().GetInt64("min-size"); minSize >= 0 {
+		cfg.Storage.Dedup.MinSize = minSize
+	}
+
+	report, err := dedupEvidence(cfg, dryRun)
+	if err != nil {
+		return err
+	}
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, report)
+	}
+
+	if len(report.Files) == 0 {
+		cmd.Println("✅ No duplicate evidence")
+	}
+	verb := "Deduplicated"
+	if dryRun {
+		verb = "Would deduplicate"
+	}
+	for _, file := range report.Files {
+		cmd.Printf("🔗 %s %s (%s, sha256:%s)\n", verb, file.Path, formatBytes(file.Bytes), file.SHA256[:12])
+	}
+	for _, window := range report.Skipped {
+		cmd.Printf("⏭️  Skipped %s: locked by another grctool run\n", window)
+	}
+	if len(report.Files) > 0 {
+		saved := "saved"
+		if dryRun {
+			saved = "would be saved"
+		}
+		cmd.Printf("\n%d file(s), %d new object(s), %s %s\n", len(report.Files), report.Objects, formatBytes(report.SavedBytes), saved)
+	}
+	return nil
+}
+
+// dedupEvidence replaces duplicate evidence files with references, as
+// storage.dedup configures
+func dedupEvidence(cfg *config.Config, dryRun bool) (*storage.DedupReport, error) {
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	report, err := store.DedupEvidence(cfg.Storage.Dedup.MinSize, dryRun)
+	if err != nil {
+		return report, fmt.Errorf("failed to deduplicate evidence: %w", err)
+	}
+	return report, nil
+}
+
+// encryptSensitiveEvidence encrypts the plaintext evidence of the tasks
+// storage.encryption covers
+func encryptSensitiveEvidence(cfg *config.Config) ([]string, error) {
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	refs, err := store.EncryptedTasks(cfg.Storage.Encryption)
+	if err != nil || len(refs) == 0 {
+		return nil, err
+	}
+	encrypted, err := store.EncryptEvidence(refs)
+	if err != nil {
+		return encrypted, fmt.Errorf("failed to encrypt sensitive evidence: %w", err)
+	}
+	return encrypted, nil
+}
+
+// printSyncResult summarizes a pull or push
+func printSyncResult(w io.Writer, icon, verb, remote string, result *storage.SyncResult, dryRun bool) {
+	if dryRun {
+		verb = "Would transfer"
+	}
+	fmt.Fprintf(w, "%s %s %d file(s), %s (%d unchanged)\n", icon, verb, len(result.Transferred), remote, result.Unchanged)
+	if dryRun {
+		for _, key := range result.Transferred {
+			fmt.Fprintf(w, "   %s\n", key)
+		}
+	}
+	for _, key := range result.Conflicts {
+		fmt.Fprintf(w, "⚠️  Kept local copy of %s, which also changed in %s\n", key, remote)
+	}
+}
+
+// topLevelCommand returns the child of rootCmd that cmd belongs to, or
+// rootCmd itself
+func topLevelCommand(cmd *cobra.Command) *cobra.Command {
+	top := cmd
+	for top.HasParent() && top.Parent() != rootCmd {
+		top = top.Parent()
+	}
+	return top
+}
+
+// touchesData reports whether cmd may change data_dir
+func touchesData(cmd *cobra.Command) bool {
+	top := topLevelCommand(cmd)
+	return top != rootCmd && !noDataCommands[top.Name()]
+}
+
+// autoSyncMirror returns the mirror when storage.remote.auto_sync is on and
+// cmd is neither a storage command nor one that leaves data_dir alone
+func autoSyncMirror(ctx context.Context, cmd *cobra.Command) (*storage.Mirror, error) {
+	if !touchesData(cmd) || topLevelCommand(cmd) == storageCmd || !viper.GetBool("storage.remote.auto_sync") {
+		return nil, nil
+	}
+	cfg, err := config.Load()
+	if err != nil || !cfg.Storage.Remote.AutoSync {
+		return nil, nil
+	}
+	return storage.NewMirror(ctx, cfg.Storage)
+}
+
+// pullRemoteStorage brings data_dir up to date before a command runs
+func pullRemoteStorage(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+	mirror, err := autoSyncMirror(ctx, cmd)
+	if err != nil || mirror == nil {
+		return err
+	}
+	result, err := mirror.Pull(ctx, false)
+	if err != nil {
+		return fmt.Errorf("pull from %s failed: %w", mirror.Remote(), err)
+	}
+	if len(result.Transferred) > 0 || len(result.Conflicts) > 0 {
+		printSyncResult(cmd.ErrOrStderr(), "⬇️ ", "Pulled", mirror.Remote(), result, false)
+	}
+	return nil
+}
+
+// recordDataChanges encrypts, deduplicates, commits and then pushes what a
+// command changed in data_dir. What storage decrypt turned into plaintext is
+// neither committed nor pushed. Evidence is already encrypted as it is
+// written; encrypting here catches what a command wrote any other way.
+func recordDataChanges(cmd *cobra.Command, args []string) error {
+	if touchesData(cmd) && cmd != storageDecryptCmd && viper.GetBool("storage.encryption.enabled") {
+		if cfg, err := config.Load(); err == nil {
+			if _, err := encryptSensitiveEvidence(cfg); err != nil {
+				return err
+			}
+		}
+	}
+	if touchesData(cmd) && cmd != storageDedupCmd && viper.GetBool("storage.dedup.enabled") {
+		if cfg, err := config.Load(); err == nil {
+			if _, err := dedupEvidence(cfg, false); err != nil {
+				return err
+			}
+		}
+	}
+	if err := commitDataChanges(cmd, args); err != nil {
+		return err
+	}
+	return pushRemoteStorage(cmd, args)
+}
+
+// commitDataChanges commits data_dir when storage.git.enabled is on. History
+// cannot be rewritten, so decrypted evidence is never committed.
+func commitDataChanges(cmd *cobra.Command, args []string) error {
+	if !touchesData(cmd) || cmd == storageDecryptCmd || !viper.GetBool("storage.git.enabled") {
+		return nil
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return nil
+	}
+	history := storage.NewGitHistory(cfg.Storage)
+	if history == nil {
+		return nil
+	}
+	subject := strings.TrimSpace(cmd.CommandPath() + " " + strings.Join(args, " "))
+	hash, err := history.Commit(context.Background(), subject)
+	if err != nil {
+		return fmt.Errorf("failed to commit data directory changes: %w", err)
+	}
+	if hash != "" {
+		fmt.Fprintf(cmd.ErrOrStderr(), "📝 Committed data directory changes as %s\n", hash)
+	}
+	return nil
+}
+
+// pushRemoteStorage uploads what a command changed in data_dir
+func pushRemoteStorage(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+	mirror, err := autoSyncMirror(ctx, cmd)
+	if err != nil || mirror == nil {
+		return err
+	}
+	result, err := mirror.Push(ctx, false)
+	if err != nil {
+		return fmt.Errorf("push to %s failed: %w", mirror.Remote(), err)
+	}
+	if len(result.Transferred) > 0 {
+		printSyncResult(cmd.ErrOrStderr(), "⬆️ ", "Pushed", mirror.Remote(), result, false)
+	}
+	return nil
+}
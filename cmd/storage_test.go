@@ -0,0 +1,271 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/keychain"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useStorageConfig loads a config whose storage section is remote
+func useStorageConfig(t *testing.T, remote string) {
+	t.Helper()
+	tempDir := t.TempDir()
+	configContent := `
+tugboat:
+  base_url: "https://api-test.example.com"
+  org_id: "test"
+  timeout: "30s"
+  rate_limit: 10
+  cookie_header: "test-cookie"
+
+storage:
+  data_dir: "` + filepath.Join(tempDir, "data") + `"
+` + remote
+	configFile := filepath.Join(tempDir, ".grctool.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(configContent), 0o644))
+
+	viper.Reset()
+	viper.SetConfigFile(configFile)
+	require.NoError(t, viper.ReadInConfig())
+	t.Cleanup(viper.Reset)
+}
+
+func TestStorageStatus_NoRemote(t *testing.T) {
+	useStorageConfig(t, "")
+
+	cmd := &cobra.Command{Use: "status", RunE: runStorageStatus}
+	cmd.Flags().String("output", outputFormatTable, "")
+	cmd.SetArgs([]string{})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "storage.remote.url")
+}
+
+func TestAutoSyncMirror(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "test-key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	find := func(args ...string) *cobra.Command {
+		cmd, _, err := rootCmd.Find(args)
+		require.NoError(t, err)
+		return cmd
+	}
+
+	tests := map[string]struct {
+		remote  string
+		command []string
+		want    bool
+	}{
+		"auto sync on": {
+			remote:  "  remote:\n    url: s3://grc-bucket/acme\n    auto_sync: true\n",
+			command: []string{"sync"},
+			want:    true,
+		},
+		"auto sync off": {
+			remote:  "  remote:\n    url: s3://grc-bucket/acme\n",
+			command: []string{"sync"},
+		},
+		"no remote": {
+			command: []string{"sync"},
+		},
+		"storage commands sync explicitly": {
+			remote:  "  remote:\n    url: s3://grc-bucket/acme\n    auto_sync: true\n",
+			command: []string{"storage", "pull"},
+		},
+		"auth is exempt": {
+			remote:  "  remote:\n    url: s3://grc-bucket/acme\n    auto_sync: true\n",
+			command: []string{"auth", "status"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			useStorageConfig(t, tc.remote)
+			mirror, err := autoSyncMirror(context.Background(), find(tc.command...))
+			require.NoError(t, err)
+			if tc.want {
+				require.NotNil(t, mirror)
+				assert.Equal(t, "s3://grc-bucket/acme", mirror.Remote())
+			} else {
+				assert.Nil(t, mirror)
+			}
+		})
+	}
+}
+
+func TestCommitDataChanges(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	useStorageConfig(t, "  git:\n    enabled: true\n    author_name: Compliance Bot\n    author_email: compliance@example.com\n")
+	dataDir := viper.GetString("storage.data_dir")
+	require.NoError(t, os.MkdirAll(filepath.Join(dataDir, "evidence", "ET-0001"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "evidence", "ET-0001", "report.md"), []byte("# evidence"), 0o644))
+
+	sync, _, err := rootCmd.Find([]string{"sync"})
+	require.NoError(t, err)
+	stderr := &bytes.Buffer{}
+	sync.SetErr(stderr)
+	defer sync.SetErr(nil)
+
+	require.NoError(t, commitDataChanges(sync, nil))
+	assert.Contains(t, stderr.String(), "Committed data directory changes")
+
+	log := exec.Command("git", "log", "-1", "--format=%an %s")
+	log.Dir = dataDir
+	out, err := log.Output()
+	require.NoError(t, err)
+	assert.Equal(t, "Compliance Bot grctool sync\n", string(out))
+
+	// Commands that leave data_dir alone do not commit
+	auth, _, err := rootCmd.Find([]string{"auth", "status"})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "evidence", "ET-0001", "report.md"), []byte("# changed"), 0o644))
+	require.NoError(t, commitDataChanges(auth, nil))
+	count := exec.Command("git", "rev-list", "--count", "HEAD")
+	count.Dir = dataDir
+	out, err = count.Output()
+	require.NoError(t, err)
+	assert.Equal(t, "1\n", string(out))
+}
+
+func TestStorageDecrypt_GitHistory(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	key, err := storage.GenerateKey()
+	require.NoError(t, err)
+	useStorageConfig(t, "  git:\n    enabled: true\n  encryption:\n    enabled: true\n    key: "+key+"\n    tasks: [ET-0001]\n")
+	storage.UseEncryption(loadEvidenceCipher)
+	t.Cleanup(func() { storage.UseEncryption(loadEvidenceCipher) })
+
+	dataDir := viper.GetString("storage.data_dir")
+	window := filepath.Join(dataDir, "evidence", "Access_Review_ET-0001_101", "2025-Q1")
+	require.NoError(t, os.MkdirAll(window, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(window, "users.csv"), []byte("user,mfa\nalice,true\n"), 0o644))
+
+	sync, _, err := rootCmd.Find([]string{"sync"})
+	require.NoError(t, err)
+	for _, cmd := range []*cobra.Command{sync, storageDecryptCmd} {
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+	}
+	t.Cleanup(func() {
+		for _, cmd := range []*cobra.Command{sync, storageDecryptCmd} {
+			cmd.SetOut(nil)
+			cmd.SetErr(nil)
+		}
+	})
+
+	gitLog := func() string {
+		log := exec.Command("git", "log", "-p", "--all")
+		log.Dir = dataDir
+		out, err := log.Output()
+		require.NoError(t, err)
+		return string(out)
+	}
+
+	require.NoError(t, recordDataChanges(sync, nil))
+	require.Contains(t, gitLog(), "users.csv")
+
+	args := []string{"ET-0001"}
+	require.NoError(t, runStorageDecrypt(storageDecryptCmd, args))
+	require.NoError(t, recordDataChanges(storageDecryptCmd, args))
+	content, err := os.ReadFile(filepath.Join(window, "users.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "user,mfa\nalice,true\n", string(content), "decrypted on disk")
+	assert.NotContains(t, gitLog(), "alice", "decrypted evidence is not committed")
+
+	// The next command encrypts it again before committing
+	require.NoError(t, recordDataChanges(sync, nil))
+	content, err = os.ReadFile(filepath.Join(window, "users.csv"))
+	require.NoError(t, err)
+	assert.True(t, storage.IsEncrypted(content))
+	assert.NotContains(t, gitLog(), "alice")
+}
+
+func TestStorageKeygen(t *testing.T) {
+	store := useMemoryKeychain(t)
+	useStorageConfig(t, "")
+
+	run := func(args ...string) (string, error) {
+		cmd := &cobra.Command{Use: "keygen", RunE: runStorageKeygen}
+		cmd.Flags().Bool("print", false, "")
+		cmd.Flags().Bool("force", false, "")
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+		cmd.SetArgs(args)
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	out, err := run("--print")
+	require.NoError(t, err)
+	assert.Len(t, strings.TrimSpace(out), 44, "a base64 32-byte key")
+
+	account := keychain.Account(keychain.AccountEvidenceKey, config.ActiveProfile())
+	_, err = run()
+	require.NoError(t, err)
+	first, err := store.Get(account)
+	require.NoError(t, err)
+
+	_, err = run()
+	require.Error(t, err, "an existing key is not replaced without --force")
+	_, err = run("--force")
+	require.NoError(t, err)
+	second, err := store.Get(account)
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+}
+
+func TestStorageDedup(t *testing.T) {
+	useStorageConfig(t, "")
+	dataDir := viper.GetString("storage.data_dir")
+	export := strings.Repeat("user,mfa\nalice,true\n", 300)
+	for _, dir := range []string{"Access_Review_ET-0001_101", "MFA_Review_ET-0002_102"} {
+		window := filepath.Join(dataDir, "evidence", dir, "2025-Q1")
+		require.NoError(t, os.MkdirAll(window, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(window, "users.csv"), []byte(export), 0o644))
+	}
+
+	run := func(args ...string) string {
+		var buf bytes.Buffer
+		cmd := &cobra.Command{Use: "dedup", RunE: runStorageDedup}
+		cmd.Flags().String("output", outputFormatTable, "")
+		cmd.Flags().Bool("dry-run", false, "")
+		cmd.Flags().Int64("min-size", -1, "")
+		cmd.SetOut(&buf)
+		cmd.SetArgs(args)
+		require.NoError(t, cmd.Execute())
+		return buf.String()
+	}
+
+	out := run("--dry-run")
+	assert.Contains(t, out, "🔗 Would deduplicate evidence/Access_Review_ET-0001_101/2025-Q1/users.csv")
+	assert.Contains(t, out, "2 file(s), 1 new object(s)")
+
+	out = run()
+	assert.Contains(t, out, "🔗 Deduplicated evidence/MFA_Review_ET-0002_102/2025-Q1/users.csv")
+	assert.DirExists(t, filepath.Join(dataDir, ".objects", "sha256"))
+
+	assert.Contains(t, run(), "✅ No duplicate evidence")
+}
@@ -19,6 +19,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/grctool/grctool/internal/appcontext"
@@ -39,7 +44,27 @@ var syncCmd = &cobra.Command{
 
 This command connects to your Tugboat Logic instance and downloads all relevant data
 for local processing and evidence collection. The data is stored locally to improve
-performance and enable offline work.`,
+performance and enable offline work.
+
+Large organizations can refresh just the objects they are working on with
+--only and --ref. A sync that is interrupted, or fails part way, saves its
+progress; run it again with --resume to skip what it already saved.
+
+Examples:
+  # Full sync
+  grctool sync
+
+  # Refresh two controls
+  grctool sync --only controls --ref AC-01,CC6.8
+
+  # Refresh evidence tasks only
+  grctool sync --only evidence-tasks
+
+  # Refresh one task and its submissions
+  grctool sync --only evidence-tasks,submissions --ref ET-0047
+
+  # Continue a sync that did not finish
+  grctool sync --resume`,
 	RunE: runSync,
 }
 
@@ -74,8 +99,12 @@ func init() {
 	syncCmd.Flags().Bool("procedures", false, "sync procedures only")
 	syncCmd.Flags().Bool("evidence", false, "sync evidence tasks only")
 	syncCmd.Flags().Bool("controls", false, "sync controls only")
-	syncCmd.Flags().Bool("dry-run", false, "show what would be synced without making changes")
+	syncCmd.Flags().Bool("dry-run", false, "preview new, updated and deleted documents without making changes")
 	syncCmd.Flags().Bool("force", false, "force full sync even if data is recent")
+	syncCmd.Flags().StringSlice("only", nil, "sync only these types: policies, controls, evidence-tasks, submissions")
+	syncCmd.Flags().StringSlice("ref", nil, "sync only these reference IDs (e.g. AC-01,ET-0047) or provider IDs")
+	syncCmd.Flags().Bool("resume", false, "continue a sync that did not finish, skipping what it already saved")
+	syncCmd.Flags().Int("concurrency", 0, "parallel API fetches (default from tugboat.sync_concurrency)")
 
 	// Sync validate options
 	syncValidateCmd.Flags().Bool("policies", false, "validate policies only")
@@ -88,7 +117,7 @@ func init() {
 
 func runSync(cmd *cobra.Command, args []string) error {
 	// Create context with basic enrichment
-	ctx := context.Background()
+	ctx := commandContext(cmd)
 	ctx = appcontext.EnrichContext(ctx, cmd)
 	ctx = appcontext.WithRequestID(ctx, appcontext.GenerateRequestID())
 
@@ -109,6 +138,23 @@ func runSync(cmd *cobra.Command, args []string) error {
 	evidence, _ := cmd.Flags().GetBool("evidence")
 	controls, _ := cmd.Flags().GetBool("controls")
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	only, _ := cmd.Flags().GetStringSlice("only")
+	refs, _ := cmd.Flags().GetStringSlice("ref")
+	resume, _ := cmd.Flags().GetBool("resume")
+
+	selected, err := parseSyncOnly(only)
+	if err != nil {
+		return err
+	}
+	policies = policies || selected.policies
+	controls = controls || selected.controls
+	evidence = evidence || selected.evidence
+	procedures = procedures || selected.procedures
+	submissions := selected.submissions
+	for i, ref := range refs {
+		refs[i] = normalizeTaskRef(strings.ToUpper(strings.TrimSpace(ref)))
+	}
 
 	cmd.Println("🔄 Starting Tugboat Logic sync with new architecture...")
 	log.Info("starting sync operation",
@@ -116,6 +162,7 @@ func runSync(cmd *cobra.Command, args []string) error {
 		logger.Field{Key: "evidence", Value: evidence},
 		logger.Field{Key: "controls", Value: controls},
 		logger.Field{Key: "dry_run", Value: dryRun},
+		logger.Field{Key: "refs", Value: refs},
 	)
 
 	if dryRun {
@@ -166,7 +213,7 @@ func runSync(cmd *cobra.Command, args []string) error {
 	syncService := services.NewSyncService(client, storage, cfg, log)
 
 	// Determine what to sync
-	syncAll := !policies && !procedures && !evidence && !controls
+	syncAll := !policies && !procedures && !evidence && !controls && !submissions
 
 	// Prepare sync options
 	syncOptions := services.SyncOptions{
@@ -175,32 +222,41 @@ func runSync(cmd *cobra.Command, args []string) error {
 		Policies:    syncAll || policies,
 		Controls:    syncAll || controls,
 		Evidence:    syncAll || evidence,
-		Submissions: syncAll, // Always sync submissions when doing a full sync
+		Submissions: syncAll || submissions, // Always sync submissions when doing a full sync
+		DryRun:      dryRun,
+		Refs:        refs,
+		Resume:      resume,
+		Concurrency: concurrency,
+		Progress:    syncProgressBar(cmd.ErrOrStderr()),
 	}
 
 	if dryRun {
-		cmd.Println("📋 Dry run - would sync:")
-		if syncOptions.Policies {
-			cmd.Println("  ✓ Policies")
-		}
-		if syncOptions.Controls {
-			cmd.Println("  ✓ Controls")
-		}
-		if syncOptions.Evidence {
-			cmd.Println("  ✓ Evidence tasks")
-		}
-		if syncOptions.Submissions {
-			cmd.Println("  ✓ Submissions")
+		cmd.Println("🔍 Comparing upstream data with local documents...")
+		result, err := syncService.SyncAll(ctx, syncOptions)
+		if err != nil {
+			return fmt.Errorf("sync preview failed: %w", err)
 		}
+		displaySyncPreview(cmd, syncOptions, result)
 		if procedures {
 			cmd.Println("  ⚠️  Procedures (not yet implemented)")
 		}
 		return nil
 	}
 
+	if !resume {
+		if checkpoint, err := storage.LoadSyncCheckpoint(); err == nil && checkpoint != nil {
+			cmd.Printf("⚠️  The sync started %s did not finish; starting over (use --resume to continue it)\n",
+				checkpoint.StartedAt.Format("2006-01-02 15:04"))
+		}
+	}
+
+	// An interrupted sync stops cleanly, keeping its checkpoint for --resume
+	syncCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// Perform the sync using the new service
 	cmd.Println("🚀 Starting sync with new service architecture...")
-	result, err := syncService.SyncAll(ctx, syncOptions)
+	result, err := syncService.SyncAll(syncCtx, syncOptions)
 	if err != nil {
 		return fmt.Errorf("sync failed: %w", err)
 	}
@@ -225,8 +281,8 @@ func runSync(cmd *cobra.Command, args []string) error {
 	}
 
 	if syncOptions.Submissions {
-		cmd.Printf("  📎 Submissions: %d total, %d tasks synced, %d files downloaded, %d errors\n",
-			result.Submissions.Total, result.Submissions.Synced, result.Submissions.Downloaded, result.Submissions.Errors)
+		cmd.Printf("  📎 Submissions: %d total, %d tasks synced, %d files downloaded, %d auditor comments, %d errors\n",
+			result.Submissions.Total, result.Submissions.Synced, result.Submissions.Downloaded, result.Submissions.Feedback, result.Submissions.Errors)
 	}
 
 	if len(result.Errors) > 0 {
@@ -240,15 +296,156 @@ func runSync(cmd *cobra.Command, args []string) error {
 		cmd.Println("⚠️  Procedure sync not yet implemented in new architecture")
 	}
 
-	// Update last sync time
-	if err := storage.SetSyncTime("full_sync", time.Now()); err != nil {
-		cmd.Printf("⚠️  Warning: failed to save sync time: %v\n", err)
+	if result.Resumed {
+		skipped := result.Policies.Skipped + result.Controls.Skipped + result.EvidenceTasks.Skipped + result.Submissions.Skipped
+		cmd.Printf("⏩ Resumed: skipped %d items the earlier sync saved\n", skipped)
+	}
+	if result.Resumable {
+		cmd.Println("💾 Some items were not fully synced; progress was saved.")
+		cmd.Println("   Run the same command with --resume to retry only those.")
+	}
+
+	// Update last sync time, unless only some references were refreshed
+	if len(refs) == 0 {
+		if err := storage.SetSyncTime("full_sync", time.Now()); err != nil {
+			cmd.Printf("⚠️  Warning: failed to save sync time: %v\n", err)
+		}
+	}
+
+	if cfg.Interpolation.Strict {
+		problems, err := services.CheckDocuments(services.NewInterpolator(cfg), storage)
+		if err != nil {
+			return fmt.Errorf("interpolation check failed: %w", err)
+		}
+		if len(problems) > 0 {
+			displayDocumentProblems(cmd, problems)
+			return fmt.Errorf("interpolation.strict: synced documents use %d undefined variable(s); define them or fix the documents, then run 'grctool interpolation check'", len(problems))
+		}
 	}
 
 	cmd.Println("✅ Sync completed successfully with new architecture")
 	return nil
 }
 
+// syncSelection is the set of types chosen with sync --only
+type syncSelection struct {
+	policies, controls, evidence, submissions, procedures bool
+}
+
+func parseSyncOnly(only []string) (syncSelection, error) {
+	var selected syncSelection
+	for _, syncType := range only {
+		switch strings.ToLower(strings.TrimSpace(syncType)) {
+		case "policies":
+			selected.policies = true
+		case "controls":
+			selected.controls = true
+		case "evidence-tasks", "evidence":
+			selected.evidence = true
+		case "submissions":
+			selected.submissions = true
+		case "procedures":
+			selected.procedures = true
+		default:
+			return selected, fmt.Errorf("unknown sync type %q: use policies, controls, evidence-tasks or submissions", syncType)
+		}
+	}
+	return selected, nil
+}
+
+// displaySyncPreview shows the changes a dry-run sync found, by type
+func displaySyncPreview(cmd *cobra.Command, opts services.SyncOptions, result *services.SyncResult) {
+	cmd.Println("📋 Dry run - a sync would make these changes:")
+
+	sections := []struct {
+		enabled bool
+		label   string
+		stats   services.SyncStats
+	}{
+		{opts.Policies, "📋 Policies", result.Policies},
+		{opts.Controls, "🛡️  Controls", result.Controls},
+		{opts.Evidence, "📝 Evidence Tasks", result.EvidenceTasks},
+	}
+
+	changed := 0
+	for _, section := range sections {
+		if !section.enabled {
+			continue
+		}
+		counts := map[string]int{}
+		for _, change := range section.stats.Changes {
+			counts[change.Action]++
+		}
+		unchanged := section.stats.Total - counts[services.SyncChangeNew] - counts[services.SyncChangeUpdated]
+		cmd.Printf("  %s: %d new, %d updated, %d deleted upstream, %d unchanged\n", section.label,
+			counts[services.SyncChangeNew], counts[services.SyncChangeUpdated], counts[services.SyncChangeDeleted], unchanged)
+
+		for _, change := range section.stats.Changes {
+			ref := change.ReferenceID
+			if ref == "" {
+				ref = change.ID
+			}
+			switch change.Action {
+			case services.SyncChangeNew:
+				cmd.Printf("    + %s %s\n", ref, change.Name)
+			case services.SyncChangeUpdated:
+				cmd.Printf("    ~ %s %s\n", ref, change.Name)
+				for _, field := range change.Fields {
+					cmd.Printf("        %s: %s → %s\n", field.Field, previewValue(field.Old), previewValue(field.New))
+				}
+			case services.SyncChangeDeleted:
+				cmd.Printf("    - %s %s (local copy is kept)\n", ref, change.Name)
+			}
+		}
+		changed += len(section.stats.Changes)
+	}
+
+	if opts.Submissions {
+		cmd.Println("  📎 Submissions are not previewed")
+	}
+	if len(result.Errors) > 0 {
+		cmd.Printf("⚠️  Encountered %d errors; the preview may be incomplete:\n", len(result.Errors))
+		for _, errMsg := range result.Errors {
+			cmd.Printf("  - %s\n", errMsg)
+		}
+	}
+
+	if changed == 0 {
+		cmd.Println("✅ Local documents are up to date")
+	} else {
+		cmd.Println("💡 Run without --dry-run to apply these changes")
+	}
+}
+
+func previewValue(value string) string {
+	if value == "" {
+		return "(empty)"
+	}
+	return value
+}
+
+// syncProgressBar renders sync fetch progress on a single line of w. It
+// returns nil when w is not a terminal, so redirected output stays clean.
+func syncProgressBar(w io.Writer) services.SyncProgress {
+	f, ok := w.(*os.File)
+	if !ok {
+		return nil
+	}
+	if info, err := f.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return nil
+	}
+
+	const width = 30
+	return func(stage string, done, total int) {
+		filled := width * done / total
+		fmt.Fprintf(w, "\r  ⏳ %-15s [%s%s] %d/%d",
+			stage, strings.Repeat("█", filled), strings.Repeat("░", width-filled), done, total)
+		if done == total {
+			fmt.Fprintln(w)
+		}
+	}
+}
+
 func runSyncValidate(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
@@ -0,0 +1,194 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/services"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/grctool/grctool/internal/tugboat"
+	"github.com/grctool/grctool/internal/vcr"
+	"github.com/spf13/cobra"
+)
+
+// syncPushCmd represents the sync push command
+var syncPushCmd = &cobra.Command{
+	Use:   "push [task-ref]",
+	Short: "Push local evidence task changes to Tugboat Logic",
+	Long: `Push task status, assignees and notes from grctool to Tugboat Logic.
+
+Without a task reference, every task whose latest evidence window has an
+accepted submission is marked complete. With one, the changes given as flags
+are pushed to that task.
+
+Before pushing, the task is fetched from Tugboat Logic. Fields that already
+hold the requested value are not sent. If the task was updated in Tugboat
+after the last sync, the push is refused as a conflict: run 'grctool sync
+--evidence' to review the remote changes, or push with --force. Pushed tasks
+are re-synced so the local copy matches Tugboat.
+
+Examples:
+  # Mark tasks with accepted submissions complete
+  grctool sync push
+
+  # Preview what would be pushed
+  grctool sync push --dry-run
+
+  # Reassign a task and add a note
+  grctool sync push ET-0001 --assignee jane@example.com --notes "Collected by the platform team"
+
+  # Reopen a task even though it changed remotely
+  grctool sync push ET-0001 --incomplete --force`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSyncPush,
+}
+
+func init() {
+	syncCmd.AddCommand(syncPushCmd)
+
+	syncPushCmd.Flags().Bool("complete", false, "mark the task complete")
+	syncPushCmd.Flags().Bool("incomplete", false, "mark the task not complete")
+	syncPushCmd.Flags().StringSlice("assignee", nil, "set assignees (Tugboat member IDs or emails of people assigned to synced tasks)")
+	syncPushCmd.Flags().String("notes", "", "set the task notes")
+	syncPushCmd.Flags().Bool("force", false, "push even if the task changed in Tugboat since the last sync")
+	syncPushCmd.Flags().Bool("dry-run", false, "show what would be pushed without pushing")
+	syncPushCmd.MarkFlagsMutuallyExclusive("complete", "incomplete")
+}
+
+func runSyncPush(cmd *cobra.Command, args []string) error {
+	force, _ := cmd.Flags().GetBool("force")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	update, err := taskUpdateFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	hasUpdate := update.Completed != nil || update.Assignees != nil || update.Notes != nil
+	if len(args) == 0 && hasUpdate {
+		return errors.New("--complete, --incomplete, --assignee and --notes need a task reference")
+	}
+	if len(args) == 1 && !hasUpdate {
+		return errors.New("nothing to push: set --complete, --incomplete, --assignee or --notes")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	log := logger.WithComponent("sync")
+
+	client := tugboat.NewClient(&cfg.Tugboat, vcr.FromEnvironment())
+	defer client.Close()
+	syncService := services.NewSyncService(client, store, cfg, log)
+	opts := services.PushOptions{DryRun: dryRun, Force: force}
+
+	var results []services.PushResult
+	if len(args) == 1 {
+		result, err := syncService.PushEvidenceTask(cmd.Context(), args[0], update, opts)
+		if err != nil {
+			return err
+		}
+		results = append(results, *result)
+	} else {
+		scanner := services.NewEvidenceScanner(filepath.Join(cfg.Storage.DataDir, "evidence"), &storageAdapter{storage: store}, log)
+		results, err = syncService.CompleteAcceptedTasks(cmd.Context(), scanner, opts)
+		if err != nil {
+			return err
+		}
+	}
+
+	if isStructuredOutput(format) {
+		if err := writeStructured(cmd, format, results); err != nil {
+			return err
+		}
+	} else {
+		printPushResults(cmd, results, len(args) == 0)
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Status == services.PushStatusConflict || result.Status == services.PushStatusFailed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d task(s) not pushed", failed)
+	}
+	return nil
+}
+
+// taskUpdateFromFlags builds the task update from the flags that were set
+func taskUpdateFromFlags(cmd *cobra.Command) (services.TaskUpdate, error) {
+	var update services.TaskUpdate
+	if complete, _ := cmd.Flags().GetBool("complete"); complete {
+		update.Completed = &complete
+	}
+	if incomplete, _ := cmd.Flags().GetBool("incomplete"); incomplete {
+		completed := false
+		update.Completed = &completed
+	}
+	if cmd.Flags().Changed("assignee") {
+		assignees, _ := cmd.Flags().GetStringSlice("assignee")
+		if len(assignees) == 0 {
+			return update, errors.New("--assignee needs at least one member")
+		}
+		update.Assignees = assignees
+	}
+	if cmd.Flags().Changed("notes") {
+		notes, _ := cmd.Flags().GetString("notes")
+		update.Notes = &notes
+	}
+	return update, nil
+}
+
+func printPushResults(cmd *cobra.Command, results []services.PushResult, completingAccepted bool) {
+	if len(results) == 0 {
+		if completingAccepted {
+			cmd.Println("✅ No accepted submissions on incomplete tasks")
+		}
+		return
+	}
+
+	for _, result := range results {
+		changes := strings.Join(result.Changes, ", ")
+		switch result.Status {
+		case services.PushStatusPushed:
+			cmd.Printf("✅ %s: pushed %s\n", result.TaskRef, changes)
+		case services.PushStatusWouldPush:
+			cmd.Printf("🔍 %s: would push %s\n", result.TaskRef, changes)
+		case services.PushStatusUnchanged:
+			cmd.Printf("✅ %s: already up to date in Tugboat\n", result.TaskRef)
+		case services.PushStatusConflict:
+			cmd.Printf("⚠️  %s: conflict: %s\n", result.TaskRef, result.Error)
+		default:
+			cmd.Printf("❌ %s: %s\n", result.TaskRef, result.Error)
+		}
+	}
+}
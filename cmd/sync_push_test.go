@@ -0,0 +1,48 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskUpdateFromFlags(t *testing.T) {
+	t.Parallel()
+
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{Use: "push"}
+		cmd.Flags().Bool("complete", false, "")
+		cmd.Flags().Bool("incomplete", false, "")
+		cmd.Flags().StringSlice("assignee", nil, "")
+		cmd.Flags().String("notes", "", "")
+		require.NoError(t, cmd.Flags().Parse(args))
+		return cmd
+	}
+
+	update, err := taskUpdateFromFlags(newCmd())
+	require.NoError(t, err)
+	assert.Nil(t, update.Completed)
+	assert.Nil(t, update.Assignees)
+	assert.Nil(t, update.Notes)
+
+	update, err = taskUpdateFromFlags(newCmd("--incomplete", "--assignee", "jane@example.com,42", "--notes", ""))
+	require.NoError(t, err)
+	require.NotNil(t, update.Completed)
+	assert.False(t, *update.Completed)
+	assert.Equal(t, []string{"jane@example.com", "42"}, update.Assignees)
+	require.NotNil(t, update.Notes, "clearing notes is a change")
+	assert.Empty(t, *update.Notes)
+
+	update, err = taskUpdateFromFlags(newCmd("--complete"))
+	require.NoError(t, err)
+	require.NotNil(t, update.Completed)
+	assert.True(t, *update.Completed)
+
+	_, err = taskUpdateFromFlags(newCmd("--assignee", ""))
+	assert.ErrorContains(t, err, "at least one member")
+}
@@ -22,6 +22,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/grctool/grctool/internal/services"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -188,6 +189,64 @@ func TestSyncCommand(t *testing.T) {
 	}
 }
 
+func TestDisplaySyncPreview(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	cmd := &cobra.Command{}
+	cmd.SetOut(buf)
+
+	opts := services.SyncOptions{Policies: true, Controls: true, Submissions: true, DryRun: true}
+	displaySyncPreview(cmd, opts, &services.SyncResult{
+		Policies: services.SyncStats{Total: 5, Changes: []services.SyncChange{
+			{EntityType: "policy", ID: "12348", Name: "Vendor Management Policy", Action: services.SyncChangeNew},
+			{EntityType: "policy", ID: "12346", ReferenceID: "POL-0002", Name: "Data Retention Policy", Action: services.SyncChangeUpdated,
+				Fields: []services.FieldChange{{Field: "status", Old: "active", New: "draft"}, {Field: "category", New: "Privacy"}}},
+			{EntityType: "policy", ID: "12347", ReferenceID: "POL-0003", Name: "Legacy Policy", Action: services.SyncChangeDeleted},
+		}},
+		Controls: services.SyncStats{Total: 3},
+	})
+
+	output := buf.String()
+	assert.Contains(t, output, "Policies: 1 new, 1 updated, 1 deleted upstream, 3 unchanged")
+	assert.Contains(t, output, "+ 12348 Vendor Management Policy")
+	assert.Contains(t, output, "~ POL-0002 Data Retention Policy")
+	assert.Contains(t, output, "status: active → draft")
+	assert.Contains(t, output, "category: (empty) → Privacy")
+	assert.Contains(t, output, "- POL-0003 Legacy Policy (local copy is kept)")
+	assert.Contains(t, output, "Controls: 0 new, 0 updated, 0 deleted upstream, 3 unchanged")
+	assert.Contains(t, output, "Submissions are not previewed")
+	assert.Contains(t, output, "Run without --dry-run")
+}
+
+func TestParseSyncOnly(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		only    []string
+		want    syncSelection
+		wantErr bool
+	}{
+		"none":           {want: syncSelection{}},
+		"controls":       {only: []string{"controls"}, want: syncSelection{controls: true}},
+		"evidence tasks": {only: []string{"Evidence-Tasks", " submissions"}, want: syncSelection{evidence: true, submissions: true}},
+		"unknown type":   {only: []string{"risks"}, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseSyncOnly(tc.only)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
 // TODO: Update these tests to use the new service layer architecture
 // These tests were testing old helper functions that have been moved to services
 
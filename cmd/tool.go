@@ -17,7 +17,9 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/grctool/grctool/internal/config"
@@ -40,15 +42,24 @@ All tool commands support:
 - Path safety validation (prevents directory traversal)
 - Automatic redaction of sensitive data (API keys, tokens, etc.)
 - Correlation ID tracking for request tracing
-- Duration measurement for operations`,
+- Duration measurement for operations
+
+Plugin tools declared under evidence.tools.plugins run as 'grctool tool <name>',
+with their parameters given as --param name=value.`,
+	Args: cobra.ArbitraryArgs,
+	RunE: runPluginTool,
 }
 
 // toolListCmd lists available tools
 var toolListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List available evidence assembly tools",
-	Long:  `List all registered evidence assembly tools with their descriptions and metadata.`,
-	RunE:  runToolList,
+	Long: `List all registered evidence assembly tools with their descriptions and metadata.
+
+With --format json or yaml, prints a catalog of every tool with its description
+and the JSON Schema of its input, for orchestrators and agents to discover the
+tools and how to call them.`,
+	RunE: runToolList,
 }
 
 // toolStatsCmd shows tool registry statistics
@@ -70,6 +81,8 @@ func init() {
 	toolCmd.PersistentFlags().String("output", "json", "output format (json)")
 	toolCmd.PersistentFlags().String("task-ref", "", "task reference (ET-101, 328001, etc.)")
 	toolCmd.PersistentFlags().Bool("quiet", false, "quiet mode - compact JSON output")
+	toolCmd.Flags().StringArray("param", nil, "plugin tool parameter as name=value (repeatable)")
+	toolListCmd.Flags().String("format", "", "print the tool catalog with input schemas (json, yaml)")
 
 	// Register completion functions for common flags
 	toolCmd.RegisterFlagCompletionFunc("task-ref", completeTaskRefs)
@@ -93,7 +106,7 @@ type ToolContext struct {
 // NewToolContext creates a new tool context with common setup
 func NewToolContext(cmd *cobra.Command, ctx context.Context) (*ToolContext, error) {
 	startTime := time.Now()
-	correlationID := tools.GenerateCorrelationID()
+	correlationID := tools.CorrelationID(ctx)
 
 	// Load configuration
 	cfg, err := config.Load()
@@ -228,6 +241,13 @@ func (tc *ToolContext) ValidateTaskRef() (*tools.ValidationResult, error) {
 
 // runToolList handles the tool list command
 func runToolList(cmd *cobra.Command, args []string) error {
+	if format, _ := cmd.Flags().GetString("format"); format != "" {
+		if !isStructuredOutput(format) {
+			return fmt.Errorf("invalid format %q (must be one of: json, yaml)", format)
+		}
+		return writeStructured(cmd, format, tools.GetToolCatalog())
+	}
+
 	ctx := context.Background()
 	toolCtx, err := NewToolContext(cmd, ctx)
 	if err != nil {
@@ -260,11 +280,45 @@ func runToolStats(cmd *cobra.Command, args []string) error {
 	return toolCtx.WriteSuccess(stats, "stats")
 }
 
+// runPluginTool runs the plugin tool named by the first argument, as no
+// subcommand is registered for tools declared in the config
+func runPluginTool(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return cmd.Help()
+	}
+	tool, err := tools.GetTool(args[0])
+	plugin, ok := tool.(*tools.PluginTool)
+	if err != nil || !ok {
+		// Unknown tools show the help, as before plugins could be run
+		fmt.Fprintf(cmd.ErrOrStderr(), "Unknown tool %q\n\n", args[0])
+		return cmd.Help()
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("plugin tools take parameters as --param name=value, got arguments: %s", strings.Join(args[1:], " "))
+	}
+
+	values := make(map[string]string)
+	paramFlags, _ := cmd.Flags().GetStringArray("param")
+	for _, flag := range paramFlags {
+		name, value, found := strings.Cut(flag, "=")
+		if !found || name == "" {
+			return fmt.Errorf("--param must be name=value, got: %s", flag)
+		}
+		values[name] = value
+	}
+	params, err := plugin.Params(values)
+	if err != nil {
+		return err
+	}
+
+	return ValidateAndExecuteTool(cmd, plugin.Name(), params, plugin.ValidationRules())
+}
+
 // Helper functions for tool command implementations
 
 // ValidateAndExecuteTool provides common validation and execution logic for tools
 func ValidateAndExecuteTool(cmd *cobra.Command, toolName string, params map[string]interface{}, validationRules map[string]tools.ValidationRule) error {
-	ctx := context.Background()
+	ctx := commandContext(cmd)
 	toolCtx, err := NewToolContext(cmd, ctx)
 	if err != nil {
 		return err
@@ -324,16 +378,25 @@ func ValidateAndExecuteTool(cmd *cobra.Command, toolName string, params map[stri
 	if err != nil {
 		// Determine error code based on error type
 		errorCode := tools.ErrorCodeInternal
-		if _, ok := err.(*tools.ValidationError); ok {
+		details := map[string]interface{}{
+			"params": params,
+			"error":  err.Error(),
+		}
+		var schemaErr *tools.SchemaValidationError
+		if errors.As(err, &schemaErr) {
+			errorCode = tools.ErrorCodeValidation
+			if schemaErr.Target == tools.SchemaTargetOutput {
+				errorCode = tools.ErrorCodeExecution
+			}
+			details["target"] = schemaErr.Target
+			details["errors"] = schemaErr.Errors
+		} else if _, ok := err.(*tools.ValidationError); ok {
 			errorCode = tools.ErrorCodeValidation
 		}
 
 		return toolCtx.WriteError(errorCode,
 			fmt.Sprintf("tool execution failed: %v", err),
-			toolName, map[string]interface{}{
-				"params": params,
-				"error":  err.Error(),
-			})
+			toolName, details)
 	}
 
 	// Prepare response data
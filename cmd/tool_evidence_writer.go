@@ -32,11 +32,14 @@ var (
 	evidenceFormat      string
 	evidenceSourceType  string
 	evidenceSourceLoc   string
+	evidenceSourceMod   string
 	evidenceControls    []string
 	evidenceSummary     string
 	evidenceReasoning   string
 	evidenceStatus      string
+	evidenceWindow      string
 	evidenceUpdatePlan  bool
+	evidenceToolParams  map[string]string
 )
 
 var toolEvidenceWriterCmd = &cobra.Command{
@@ -58,7 +61,8 @@ Examples:
   # Write evidence from file with detailed metadata
   grctool tool evidence-writer --task-ref ET-101 --title "Terraform Config" \
     --file terraform_analysis.md --source-type terraform \
-    --source-location "infrastructure/iam/*.tf" --controls AC1,AC2
+    --source-location "infrastructure/iam/*.tf" --controls AC1,AC2 \
+    --tool-param terraform_git_hash=3f2c1e9
 
   # Write CSV evidence data
   grctool tool evidence-writer --task-ref ET10 --title "User Access Report" \
@@ -95,17 +99,41 @@ Examples:
 
 		// Build parameters
 		params := map[string]interface{}{
-			"task_ref":        evidenceTaskRef,
-			"title":           evidenceTitle,
-			"content":         content,
-			"format":          evidenceFormat,
-			"source_type":     evidenceSourceType,
-			"source_location": evidenceSourceLoc,
-			"controls":        evidenceControls,
-			"summary":         evidenceSummary,
-			"reasoning":       evidenceReasoning,
-			"status":          evidenceStatus,
-			"update_plan":     evidenceUpdatePlan,
+			"task_ref":    evidenceTaskRef,
+			"title":       evidenceTitle,
+			"content":     content,
+			"format":      evidenceFormat,
+			"status":      evidenceStatus,
+			"update_plan": evidenceUpdatePlan,
+		}
+		if len(evidenceControls) > 0 {
+			// Convert []string to []interface{} for tool compatibility
+			controls := make([]interface{}, len(evidenceControls))
+			for i, control := range evidenceControls {
+				controls[i] = control
+			}
+			params["controls"] = controls
+		}
+		// Unset optional flags are left out rather than passed as empty
+		// strings, which the tool's schema rejects
+		for name, value := range map[string]string{
+			"source_type":        evidenceSourceType,
+			"source_location":    evidenceSourceLoc,
+			"source_modified_at": evidenceSourceMod,
+			"summary":            evidenceSummary,
+			"reasoning":          evidenceReasoning,
+			"window":             evidenceWindow,
+		} {
+			if value != "" {
+				params[name] = value
+			}
+		}
+		if len(evidenceToolParams) > 0 {
+			toolParameters := map[string]interface{}{}
+			for key, value := range evidenceToolParams {
+				toolParameters[key] = value
+			}
+			params["tool_parameters"] = toolParameters
 		}
 
 		return ValidateAndExecuteTool(cmd, "evidence-writer", params, nil)
@@ -134,8 +162,12 @@ func init() {
 		"Type of evidence source: terraform, github, google_docs, manual, screenshot, api, database")
 	toolEvidenceWriterCmd.Flags().StringVar(&evidenceSourceLoc, "source-location", "",
 		"Source location (file path, URL, or description)")
+	toolEvidenceWriterCmd.Flags().StringVar(&evidenceSourceMod, "source-modified-at", "",
+		"When a remote source was last modified (RFC 3339); local file sources are read from disk")
 	toolEvidenceWriterCmd.Flags().StringSliceVar(&evidenceControls, "controls", []string{},
 		"Control references this evidence addresses (comma-separated)")
+	toolEvidenceWriterCmd.Flags().StringToStringVar(&evidenceToolParams, "tool-param", nil,
+		"Parameter of the tool run that produced the content, recorded in the provenance attestation (key=value, repeatable)")
 
 	// Collection plan options
 	toolEvidenceWriterCmd.Flags().StringVar(&evidenceSummary, "summary", "",
@@ -144,6 +176,8 @@ func init() {
 		"Why this evidence is relevant and what it demonstrates")
 	toolEvidenceWriterCmd.Flags().StringVar(&evidenceStatus, "status", "complete",
 		"Evidence collection status: complete, partial, pending")
+	toolEvidenceWriterCmd.Flags().StringVar(&evidenceWindow, "window", "",
+		"Collection window to write into, e.g. 2025-Q4 (default: current window of the task's interval)")
 	toolEvidenceWriterCmd.Flags().BoolVar(&evidenceUpdatePlan, "update-plan", true,
 		"Whether to update the collection plan")
 
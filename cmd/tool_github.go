@@ -16,6 +16,7 @@
 package cmd
 
 import (
+	"github.com/grctool/grctool/internal/frameworks"
 	"github.com/grctool/grctool/internal/tools"
 	"github.com/spf13/cobra"
 )
@@ -118,6 +119,7 @@ func init() {
 	githubSecurityFeaturesCmd.Flags().String("output-format", "detailed", "output format (detailed, matrix, summary)")
 	githubSecurityFeaturesCmd.Flags().Bool("include-policy-analysis", true, "include security policy analysis")
 	githubSecurityFeaturesCmd.Flags().Bool("include-compliance-mapping", false, "include SOC2/compliance framework mapping")
+	githubSecurityFeaturesCmd.Flags().String("framework", frameworks.SOC2, "framework to map security features to (soc2, iso27001, nist80053, pci, hipaa)")
 	githubSecurityFeaturesCmd.MarkFlagRequired("repository")
 
 	// GitHub Workflow Analyzer flags
@@ -126,6 +128,7 @@ func init() {
 	githubWorkflowAnalyzerCmd.Flags().StringArray("filter-workflows", []string{}, "filter workflows by name patterns (e.g., '*security*', '*deploy*')")
 	githubWorkflowAnalyzerCmd.Flags().Bool("check-branch-protection", true, "check branch protection rules and approval requirements")
 	githubWorkflowAnalyzerCmd.Flags().Bool("use-cache", true, "use cached results when available")
+	githubWorkflowAnalyzerCmd.Flags().String("framework", frameworks.SOC2, "framework to map compliance rules to (soc2, iso27001, nist80053, pci, hipaa)")
 
 	// GitHub Review Analyzer flags
 	githubReviewAnalyzerCmd.Flags().String("analysis-period", "90d", "time period for analysis (30d, 90d, 180d, 1y)")
@@ -244,6 +247,14 @@ func runGitHubSecurityFeatures(cmd *cobra.Command, args []string) error {
 		params["include_compliance_mapping"] = includeComplianceMapping
 	}
 
+	framework, err := frameworkFlag(cmd)
+	if err != nil {
+		return err
+	}
+	if framework != "" {
+		params["framework"] = framework
+	}
+
 	// Define validation rules
 	validationRules := map[string]tools.ValidationRule{
 		"repository": {
@@ -260,6 +271,11 @@ func runGitHubSecurityFeatures(cmd *cobra.Command, args []string) error {
 		},
 		"include_policy_analysis":    BoolRule,
 		"include_compliance_mapping": BoolRule,
+		"framework": {
+			Required:      false,
+			Type:          "string",
+			AllowedValues: frameworks.Supported,
+		},
 	}
 
 	// Execute tool with validation
@@ -291,6 +307,14 @@ func runGitHubWorkflowAnalyzer(cmd *cobra.Command, args []string) error {
 		params["use_cache"] = useCache
 	}
 
+	framework, err := frameworkFlag(cmd)
+	if err != nil {
+		return err
+	}
+	if framework != "" {
+		params["framework"] = framework
+	}
+
 	// Define validation rules
 	validationRules := map[string]tools.ValidationRule{
 		"analysis_type": {
@@ -302,6 +326,11 @@ func runGitHubWorkflowAnalyzer(cmd *cobra.Command, args []string) error {
 		"filter_workflows":        {Required: false, Type: "array"},
 		"check_branch_protection": BoolRule,
 		"use_cache":               BoolRule,
+		"framework": {
+			Required:      false,
+			Type:          "string",
+			AllowedValues: frameworks.Supported,
+		},
 	}
 
 	// Execute tool with validation
@@ -23,7 +23,7 @@ import (
 // googleWorkspaceCmd handles the google-workspace tool
 var googleWorkspaceCmd = &cobra.Command{
 	Use:   "google-workspace",
-	Short: "Extract evidence from Google Workspace documents including Drive, Docs, Sheets, and Forms",
+	Short: "Extract evidence from Google Workspace documents including Drive, Docs, Sheets, Forms, Drive sharing, the user directory, and audit activity",
 	Long: `Extract evidence from Google Workspace documents for SOC2 audit evidence including:
 - Google Drive folder contents and permissions
 - Google Docs text content and revision history
@@ -31,7 +31,13 @@ var googleWorkspaceCmd = &cobra.Command{
 - Google Forms responses and configuration
 - Document metadata (created, modified, editors)
 - Sharing and permission settings
-- Revision history and change tracking`,
+- Revision history and change tracking
+- Drive sharing audits of folders and shared drives (external sharing,
+  link visibility, owners)
+- Directory users, suspension status, 2-Step Verification enrollment,
+  and admin role assignments (Admin SDK, requires --admin-email)
+- Login and admin audit activity sampled within an evidence window
+  (Reports API, requires --admin-email)`,
 	RunE: runGoogleWorkspace,
 }
 
@@ -41,13 +47,21 @@ func init() {
 
 	// Google Workspace direct access flags
 	googleWorkspaceCmd.Flags().String("document-id", "", "Google document ID (from URL or share link)")
-	googleWorkspaceCmd.Flags().String("document-type", "drive", "Type of Google document: drive, docs, sheets, forms")
+	googleWorkspaceCmd.Flags().String("document-type", "drive", "Type of Google document: drive, docs, sheets, forms, sharing, directory, activity")
 	googleWorkspaceCmd.Flags().Bool("include-metadata", true, "Include document metadata (created, modified, editors)")
 	googleWorkspaceCmd.Flags().Bool("include-revisions", false, "Include revision history")
 	googleWorkspaceCmd.Flags().String("sheet-range", "", "For sheets: range to extract (e.g., 'A1:D10', 'Sheet1!A:Z')")
 	googleWorkspaceCmd.Flags().String("search-query", "", "Search query for Drive folder content")
 	googleWorkspaceCmd.Flags().Int("max-results", 20, "Maximum number of results to return")
-	googleWorkspaceCmd.Flags().String("credentials-path", "", "Path to Google service account credentials JSON file")
+	googleWorkspaceCmd.Flags().StringSlice("internal-domains", nil, "For sharing: domains treated as internal (defaults to the folder owner's domain)")
+	googleWorkspaceCmd.Flags().Bool("recursive", false, "For sharing: audit subfolders as well")
+	googleWorkspaceCmd.Flags().String("window", "", "For activity: evidence window to sample (e.g., '2025', '2025-Q3', '2025-07')")
+	googleWorkspaceCmd.Flags().String("start-time", "", "For activity: start of the sampling range (RFC3339 or YYYY-MM-DD)")
+	googleWorkspaceCmd.Flags().String("end-time", "", "For activity: end of the sampling range (RFC3339 or YYYY-MM-DD)")
+	googleWorkspaceCmd.Flags().StringSlice("applications", nil, "For activity: Reports API applications to sample (default: login,admin)")
+	googleWorkspaceCmd.Flags().String("credentials-path", "", "Path to Google credentials JSON file (service account key or workload identity federation config); defaults to Application Default Credentials")
+	googleWorkspaceCmd.Flags().String("impersonate-service-account", "", "Service account to impersonate keylessly via the IAM Credentials API")
+	googleWorkspaceCmd.Flags().String("admin-email", "", "Workspace admin to impersonate via domain-wide delegation (required for directory and activity; its domain is internal for shared-drive sharing audits)")
 	googleWorkspaceCmd.MarkFlagRequired("document-id")
 }
 
@@ -68,6 +82,14 @@ func runGoogleWorkspace(cmd *cobra.Command, args []string) error {
 		params["credentials_path"] = credentialsPath
 	}
 
+	if impersonate, _ := cmd.Flags().GetString("impersonate-service-account"); impersonate != "" {
+		params["impersonate_service_account"] = impersonate
+	}
+
+	if adminEmail, _ := cmd.Flags().GetString("admin-email"); adminEmail != "" {
+		params["admin_email"] = adminEmail
+	}
+
 	// Build extraction rules from flags
 	extractionRules := make(map[string]interface{})
 
@@ -91,6 +113,30 @@ func runGoogleWorkspace(cmd *cobra.Command, args []string) error {
 		extractionRules["max_results"] = maxResults
 	}
 
+	if internalDomains, _ := cmd.Flags().GetStringSlice("internal-domains"); len(internalDomains) > 0 {
+		extractionRules["internal_domains"] = internalDomains
+	}
+
+	if recursive, _ := cmd.Flags().GetBool("recursive"); cmd.Flags().Changed("recursive") {
+		extractionRules["recursive"] = recursive
+	}
+
+	if window, _ := cmd.Flags().GetString("window"); window != "" {
+		extractionRules["window"] = window
+	}
+
+	if startTime, _ := cmd.Flags().GetString("start-time"); startTime != "" {
+		extractionRules["start_time"] = startTime
+	}
+
+	if endTime, _ := cmd.Flags().GetString("end-time"); endTime != "" {
+		extractionRules["end_time"] = endTime
+	}
+
+	if applications, _ := cmd.Flags().GetStringSlice("applications"); len(applications) > 0 {
+		extractionRules["applications"] = applications
+	}
+
 	if len(extractionRules) > 0 {
 		params["extraction_rules"] = extractionRules
 	}
@@ -106,7 +152,17 @@ func runGoogleWorkspace(cmd *cobra.Command, args []string) error {
 		"document_type": {
 			Required:      false,
 			Type:          "string",
-			AllowedValues: []string{"drive", "docs", "sheets", "forms"},
+			AllowedValues: []string{"drive", "docs", "sheets", "forms", "sharing", "directory", "activity"},
+		},
+		"impersonate_service_account": {
+			Required:  false,
+			Type:      "string",
+			MaxLength: 320,
+		},
+		"admin_email": {
+			Required:  false,
+			Type:      "string",
+			MaxLength: 320,
 		},
 		"credentials_path": OptionalPathRule,
 		"extraction_rules": {
@@ -0,0 +1,77 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/tools"
+	"github.com/spf13/cobra"
+)
+
+// incidentLogToolCmd handles the incident-log tool
+var incidentLogToolCmd = &cobra.Command{
+	Use:   "incident-log",
+	Short: "Export the incidents in an evidence window with response timelines",
+	Long: `Export the incident log maintained with 'grctool incident' as evidence for
+incident management tasks. Lists every incident detected in the window with:
+- Severity, status and incident lead
+- Detection, acknowledgement and resolution times
+- Time to acknowledge and time to resolve, with medians
+- Root cause and resolution
+
+The window defaults to the current quarter. Use --task-ref to save the export
+as evidence for a task.`,
+	RunE: runIncidentLogTool,
+}
+
+func init() {
+	toolCmd.AddCommand(incidentLogToolCmd)
+
+	incidentLogToolCmd.Flags().String("window", "", "evidence window, e.g. 2025, 2025-H1, 2025-Q3 or 2025-07 (default: current quarter)")
+	incidentLogToolCmd.Flags().String("output-format", "markdown", "output format (markdown, csv)")
+	incidentLogToolCmd.Flags().String("min-severity", "", "only incidents at least this severe (low, medium, high, critical)")
+}
+
+// runIncidentLogTool executes the incident-log tool
+func runIncidentLogTool(cmd *cobra.Command, args []string) error {
+	params := make(map[string]interface{})
+
+	if window, _ := cmd.Flags().GetString("window"); window != "" {
+		params["window"] = window
+	}
+	if outputFormat, _ := cmd.Flags().GetString("output-format"); outputFormat != "" {
+		params["output_format"] = outputFormat
+	}
+	if minSeverity, _ := cmd.Flags().GetString("min-severity"); minSeverity != "" {
+		params["min_severity"] = minSeverity
+	}
+
+	validationRules := map[string]tools.ValidationRule{
+		"window": OptionalStringRule,
+		"output_format": {
+			Required:      false,
+			Type:          "string",
+			AllowedValues: []string{"markdown", "csv"},
+		},
+		"min_severity": {
+			Required:      false,
+			Type:          "string",
+			AllowedValues: domain.IncidentSeverities,
+		},
+	}
+
+	return ValidateAndExecuteTool(cmd, "incident-log", params, validationRules)
+}
@@ -0,0 +1,77 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/grctool/grctool/internal/tools"
+	"github.com/spf13/cobra"
+)
+
+// policyAcknowledgmentToolCmd handles the policy-acknowledgment tool
+var policyAcknowledgmentToolCmd = &cobra.Command{
+	Use:   "policy-acknowledgment",
+	Short: "Export employee policy acknowledgment rates as evidence",
+	Long: `Export the policy acknowledgments recorded with 'grctool policy ack' as
+evidence for policy acknowledgment and security awareness tasks:
+- Per policy, employees on the roster who acknowledged it in the period
+- Acknowledgment rate and how acknowledgments were collected
+- Employees who have not acknowledged yet
+
+Use --task-ref to save the export as evidence for a task.`,
+	RunE: runPolicyAcknowledgmentTool,
+}
+
+func init() {
+	toolCmd.AddCommand(policyAcknowledgmentToolCmd)
+
+	policyAcknowledgmentToolCmd.Flags().String("policy", "", "only this policy (default: all policies)")
+	policyAcknowledgmentToolCmd.Flags().String("period", "", "acknowledgment period, e.g. 2025 or 2025-Q1 (default: the current year)")
+	policyAcknowledgmentToolCmd.Flags().String("output-format", "markdown", "output format (markdown, csv)")
+}
+
+// runPolicyAcknowledgmentTool executes the policy-acknowledgment tool
+func runPolicyAcknowledgmentTool(cmd *cobra.Command, args []string) error {
+	params := make(map[string]interface{})
+
+	if policy, _ := cmd.Flags().GetString("policy"); policy != "" {
+		params["policy"] = policy
+	}
+	if period, _ := cmd.Flags().GetString("period"); period != "" {
+		params["period"] = period
+	}
+	if outputFormat, _ := cmd.Flags().GetString("output-format"); outputFormat != "" {
+		params["output_format"] = outputFormat
+	}
+
+	validationRules := map[string]tools.ValidationRule{
+		"policy": {
+			Required: false,
+			Type:     "string",
+		},
+		"period": {
+			Required: false,
+			Type:     "string",
+			Pattern:  `^\d{4}(-(H[12]|Q[1-4]|0[1-9]|1[0-2]))?$`,
+		},
+		"output_format": {
+			Required:      false,
+			Type:          "string",
+			AllowedValues: []string{"markdown", "csv"},
+		},
+	}
+
+	return ValidateAndExecuteTool(cmd, "policy-acknowledgment", params, validationRules)
+}
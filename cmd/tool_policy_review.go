@@ -0,0 +1,67 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/grctool/grctool/internal/tools"
+	"github.com/spf13/cobra"
+)
+
+// policyReviewToolCmd handles the policy-review tool
+var policyReviewToolCmd = &cobra.Command{
+	Use:   "policy-review",
+	Short: "Export authored policy approvals and annual review status as evidence",
+	Long: `Export the policies maintained with 'grctool policy draft/review/approve' as
+evidence that policies are reviewed and approved:
+- Each policy's owner, version and status
+- Who approved the current version, and when
+- When the next annual review is due, and whether it is overdue
+- Versions awaiting approval
+
+Only approved policies are included unless --include-drafts is given. Use
+--task-ref to save the export as evidence for a task.`,
+	RunE: runPolicyReviewTool,
+}
+
+func init() {
+	toolCmd.AddCommand(policyReviewToolCmd)
+
+	policyReviewToolCmd.Flags().String("output-format", "markdown", "output format (markdown, csv)")
+	policyReviewToolCmd.Flags().Bool("include-drafts", false, "include policies that have never been approved")
+}
+
+// runPolicyReviewTool executes the policy-review tool
+func runPolicyReviewTool(cmd *cobra.Command, args []string) error {
+	params := make(map[string]interface{})
+
+	if outputFormat, _ := cmd.Flags().GetString("output-format"); outputFormat != "" {
+		params["output_format"] = outputFormat
+	}
+	if includeDrafts, _ := cmd.Flags().GetBool("include-drafts"); includeDrafts {
+		params["include_drafts"] = true
+	}
+
+	validationRules := map[string]tools.ValidationRule{
+		"output_format": {
+			Required:      false,
+			Type:          "string",
+			AllowedValues: []string{"markdown", "csv"},
+		},
+		"include_drafts": BoolRule,
+	}
+
+	return ValidateAndExecuteTool(cmd, "policy-review", params, validationRules)
+}
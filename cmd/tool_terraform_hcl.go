@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/grctool/grctool/internal/frameworks"
 	"github.com/grctool/grctool/internal/tools"
 	"github.com/spf13/cobra"
 )
@@ -34,7 +35,7 @@ var terraformHCLCmd = &cobra.Command{
 • Multi-AZ pattern detection
 • High availability analysis  
 • Security configuration analysis
-• SOC2 control mapping
+• SOC2, ISO 27001 Annex A, NIST 800-53, PCI DSS and HIPAA control mapping (--framework)
 • Resource dependency analysis
 
 This tool parses .tf files only (NO state files, NO secrets) and extracts:
@@ -57,6 +58,7 @@ type terraformHCLFlags struct {
 	analyzeSecurity bool
 	analyzeHA       bool
 	controlMapping  []string
+	framework       string
 	outputFormat    string
 	includeDiags    bool
 }
@@ -80,7 +82,9 @@ func init() {
 
 	// Control mapping
 	terraformHCLCmd.Flags().StringSliceVar(&terraformHCLOpts.controlMapping, "control-mapping", nil,
-		"Specific SOC2 control codes to map resources to (e.g., CC6.1,CC6.8)")
+		"Specific control codes of the framework to map resources to (e.g., CC6.1,CC6.8, A.8.24, SC-28, 1.3.1 or 164.312(b))")
+	terraformHCLCmd.PersistentFlags().StringVar(&terraformHCLOpts.framework, "framework", frameworks.SOC2,
+		"Framework to map resources to: soc2, iso27001 (ISO 27001:2022 Annex A), nist80053 (NIST SP 800-53 Rev. 5), pci (PCI DSS v4.0) or hipaa (HIPAA Security Rule)")
 
 	// Output options
 	terraformHCLCmd.Flags().StringVar(&terraformHCLOpts.outputFormat, "output-format", "summary",
@@ -106,6 +110,7 @@ func runTerraformHCL(cmd *cobra.Command, args []string) error {
 		"analyze_security":    terraformHCLOpts.analyzeSecurity,
 		"analyze_ha":          terraformHCLOpts.analyzeHA,
 		"control_mapping":     terraformHCLOpts.controlMapping,
+		"framework":           terraformHCLOpts.framework,
 		"output_format":       terraformHCLOpts.outputFormat,
 		"include_diagnostics": terraformHCLOpts.includeDiags,
 	}
@@ -147,26 +152,19 @@ func validateTerraformHCLParams() error {
 			terraformHCLOpts.outputFormat)
 	}
 
-	// Validate control codes if provided
-	if len(terraformHCLOpts.controlMapping) > 0 {
-		validControls := map[string]bool{
-			"CC1.1": true, "CC1.2": true, "CC1.3": true, "CC1.4": true, "CC1.5": true,
-			"CC2.1": true, "CC2.2": true, "CC2.3": true,
-			"CC3.1": true, "CC3.2": true, "CC3.3": true, "CC3.4": true,
-			"CC4.1": true, "CC4.2": true,
-			"CC5.1": true, "CC5.2": true, "CC5.3": true,
-			"CC6.1": true, "CC6.2": true, "CC6.3": true, "CC6.4": true, "CC6.5": true,
-			"CC6.6": true, "CC6.7": true, "CC6.8": true,
-			"CC7.1": true, "CC7.2": true, "CC7.3": true, "CC7.4": true, "CC7.5": true,
-			"CC8.1": true,
-			"SO2":   true,
-		}
+	framework, err := frameworks.Normalize(terraformHCLOpts.framework)
+	if err != nil {
+		return err
+	}
+	terraformHCLOpts.framework = framework
 
-		for _, control := range terraformHCLOpts.controlMapping {
-			if !validControls[control] {
-				return fmt.Errorf("invalid control code '%s'", control)
-			}
+	// Validate control codes of the framework if provided
+	for i, control := range terraformHCLOpts.controlMapping {
+		normalized, err := frameworks.NormalizeControl(framework, control)
+		if err != nil {
+			return err
 		}
+		terraformHCLOpts.controlMapping[i] = normalized
 	}
 
 	return nil
@@ -182,7 +180,7 @@ This command provides a simplified interface for common analysis tasks:
 - Scans specified paths for .tf files
 - Performs comprehensive security and HA analysis
 - Outputs summary in markdown format
-- Maps findings to common SOC2 controls
+- Maps findings to common SOC2, ISO 27001 Annex A, NIST 800-53, PCI DSS or HIPAA controls
 
 Example:
   grctool tool terraform-hcl-parser analyze ./terraform/
@@ -211,11 +209,33 @@ func runTerraformHCLAnalyze(cmd *cobra.Command, args []string) error {
 	terraformHCLOpts.includeModules = true
 	terraformHCLOpts.includeDiags = false
 
-	// Set common SOC2 controls for analysis
+	// Set common controls of the framework for analysis
 	terraformHCLOpts.controlMapping = []string{
 		"CC6.1", "CC6.3", "CC6.6", "CC6.7", "CC6.8",
 		"CC7.1", "CC7.2", "CC7.4", "SO2",
 	}
+	switch framework, _ := frameworks.Normalize(terraformHCLOpts.framework); framework {
+	case frameworks.ISO27001:
+		terraformHCLOpts.controlMapping = []string{
+			"A.5.15", "A.5.18", "A.8.2", "A.8.6", "A.8.13",
+			"A.8.15", "A.8.16", "A.8.20", "A.8.22", "A.8.24",
+		}
+	case frameworks.NIST80053:
+		terraformHCLOpts.controlMapping = []string{
+			"AC-2", "AC-3", "AC-6", "AU-2", "AU-12", "CP-9",
+			"SC-6", "SC-7", "SC-13", "SC-28", "SI-4",
+		}
+	case frameworks.PCIDSS:
+		terraformHCLOpts.controlMapping = []string{
+			"1.3.1", "1.3.2", "1.4.1", "3.5.1", "3.6.1",
+			"4.2.1", "7.2.1", "7.2.2", "10.2.1", "10.4.1",
+		}
+	case frameworks.HIPAA:
+		terraformHCLOpts.controlMapping = []string{
+			"164.308(a)(1)(ii)(D)", "164.308(a)(7)(ii)(A)", "164.312(a)(1)",
+			"164.312(a)(2)(iv)", "164.312(b)", "164.312(e)(1)",
+		}
+	}
 
 	return runTerraformHCL(cmd, args)
 }
@@ -228,13 +248,14 @@ var terraformHCLSecurityCmd = &cobra.Command{
 
 This command focuses specifically on security aspects:
 - Identifies misconfigurations and security risks
-- Maps findings to SOC2 security controls
+- Maps findings to SOC2, ISO 27001 Annex A, NIST 800-53, PCI DSS or HIPAA security controls
 - Analyzes encryption, access controls, and network security
 - Outputs security-only report with remediation guidance
 
 Example:
   grctool tool terraform-hcl-parser security ./terraform/
-  grctool tool terraform-hcl-parser security --controls CC6.8,CC7.1 ./infra/`,
+  grctool tool terraform-hcl-parser security --controls CC6.8,CC7.1 ./infra/
+  grctool tool terraform-hcl-parser security --framework iso27001 --controls A.8.24 ./infra/`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: runTerraformHCLSecurity,
 }
@@ -243,7 +264,7 @@ func init() {
 	terraformHCLCmd.AddCommand(terraformHCLSecurityCmd)
 
 	terraformHCLSecurityCmd.Flags().StringSliceVar(&terraformHCLOpts.controlMapping, "controls", nil,
-		"Specific security controls to focus on (e.g., CC6.8,CC7.1)")
+		"Specific security controls to focus on (e.g., CC6.8,CC7.1, A.8.24, SC-28, 1.3.1 or 164.312(b))")
 }
 
 // runTerraformHCLSecurity executes security-focused analysis
@@ -262,6 +283,28 @@ func runTerraformHCLSecurity(cmd *cobra.Command, args []string) error {
 			"CC6.1", "CC6.2", "CC6.3", "CC6.6", "CC6.7", "CC6.8",
 			"CC7.1", "CC7.2", "CC7.4",
 		}
+		switch framework, _ := frameworks.Normalize(terraformHCLOpts.framework); framework {
+		case frameworks.ISO27001:
+			terraformHCLOpts.controlMapping = []string{
+				"A.5.15", "A.5.16", "A.5.18", "A.8.2", "A.8.15",
+				"A.8.16", "A.8.20", "A.8.22", "A.8.24",
+			}
+		case frameworks.NIST80053:
+			terraformHCLOpts.controlMapping = []string{
+				"AC-2", "AC-3", "AC-4", "AC-6", "AU-2", "AU-12",
+				"IA-2", "SC-7", "SC-8", "SC-13", "SC-28",
+			}
+		case frameworks.PCIDSS:
+			terraformHCLOpts.controlMapping = []string{
+				"1.3.1", "1.3.2", "1.4.1", "3.5.1", "4.2.1",
+				"7.2.1", "7.2.2", "8.2.1", "10.2.1",
+			}
+		case frameworks.HIPAA:
+			terraformHCLOpts.controlMapping = []string{
+				"164.312(a)(1)", "164.312(a)(2)(iv)", "164.312(b)",
+				"164.312(d)", "164.312(e)(1)", "164.312(e)(2)(ii)",
+			}
+		}
 	}
 
 	return runTerraformHCL(cmd, args)
@@ -0,0 +1,224 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/grctool/grctool/internal/frameworks"
+	"github.com/grctool/grctool/internal/tools"
+	"github.com/grctool/grctool/internal/tools/terraform"
+	"github.com/spf13/cobra"
+)
+
+const terraformSecurityToolName = "terraform-security-analyzer"
+
+// terraformSecurityCmd represents the terraform-security-analyzer command
+var terraformSecurityCmd = &cobra.Command{
+	Use:   terraformSecurityToolName,
+	Short: "Analyze Terraform security configuration with SOC2, ISO 27001, NIST 800-53, PCI DSS or HIPAA control mapping",
+	Long: `Analyze Terraform manifests for encryption, IAM, network, backup and monitoring
+configuration, map resources to SOC2 controls, and report security findings such
+as wildcard IAM permissions, open ingress and missing encryption.
+
+Use --framework iso27001, nist80053, pci or hipaa to map resources, findings and
+gaps to ISO 27001:2022 Annex A, NIST SP 800-53 Rev. 5, PCI DSS v4.0 or HIPAA
+Security Rule safeguards instead, and --controls to restrict the analysis to
+resources evidencing specific controls of the selected framework.
+
+Use --cis-benchmark aws, gcp or all to evaluate CIS AWS Foundations v3.0.0 or
+CIS GCP Foundation v2.0.0 benchmark items against every declared resource. Each
+item is reported as pass, fail or not applicable with its cross-references to
+the controls of the selected framework.
+
+Output formats: detailed_json, summary_markdown, compliance_csv, sarif
+
+Use --sarif-file to write findings as a SARIF 2.1.0 log for GitHub code scanning
+or other SARIF consumers. File locations are relative to the current directory,
+so run the command from the repository root.
+
+Examples:
+  grctool tool terraform-security-analyzer --security-domain iam
+  grctool tool terraform-security-analyzer --framework iso27001 --controls A.8.24 --output-format summary_markdown
+  grctool tool terraform-security-analyzer --cis-benchmark aws --output-format summary_markdown
+  grctool tool terraform-security-analyzer --sarif-file results/terraform.sarif`,
+	RunE: runTerraformSecurity,
+}
+
+func init() {
+	toolCmd.AddCommand(terraformSecurityCmd)
+
+	terraformSecurityCmd.Flags().String("security-domain", "all", "security domain (encryption, iam, network, backup, monitoring, all)")
+	terraformSecurityCmd.Flags().String("framework", frameworks.SOC2, "framework to map controls to (soc2, iso27001, nist80053, pci, hipaa)")
+	terraformSecurityCmd.Flags().StringSlice("controls", nil, "controls of the framework to find evidence for (e.g., CC6.1, A.8.24, SC-28, 1.3.1 or 164.312(b))")
+	terraformSecurityCmd.Flags().StringSlice("soc2-controls", nil, "SOC2 controls to find evidence for (e.g., CC6.1,CC6.8)")
+	terraformSecurityCmd.Flags().StringSlice("evidence-tasks", nil, "evidence task references to address")
+	terraformSecurityCmd.Flags().Bool("include-compliance-gaps", true, "include compliance gap analysis")
+	terraformSecurityCmd.Flags().String("output-format", "detailed_json", "output format (detailed_json, summary_markdown, compliance_csv, sarif)")
+	terraformSecurityCmd.Flags().String("cis-benchmark", "", "evaluate CIS Foundations benchmark items (aws, gcp, all)")
+	terraformSecurityCmd.Flags().Bool("skip-cache", false, "skip the security index and force a live scan")
+	terraformSecurityCmd.Flags().String("sarif-file", "", "write findings as SARIF to this file")
+}
+
+// runTerraformSecurity executes the terraform-security-analyzer tool
+func runTerraformSecurity(cmd *cobra.Command, args []string) error {
+	params := make(map[string]interface{})
+
+	if domain, _ := cmd.Flags().GetString("security-domain"); domain != "" {
+		params["security_domain"] = domain
+	}
+	framework, err := frameworkFlag(cmd)
+	if err != nil {
+		return err
+	}
+	if framework != "" {
+		params["framework"] = framework
+	}
+	if controls, _ := cmd.Flags().GetStringSlice("controls"); len(controls) > 0 {
+		params["controls"] = toInterfaceSlice(controls)
+	}
+	if controls, _ := cmd.Flags().GetStringSlice("soc2-controls"); len(controls) > 0 {
+		params["soc2_controls"] = toInterfaceSlice(controls)
+	}
+	if tasks, _ := cmd.Flags().GetStringSlice("evidence-tasks"); len(tasks) > 0 {
+		params["evidence_tasks"] = toInterfaceSlice(tasks)
+	}
+	if includeGaps, _ := cmd.Flags().GetBool("include-compliance-gaps"); cmd.Flags().Changed("include-compliance-gaps") {
+		params["include_compliance_gaps"] = includeGaps
+	}
+	if outputFormat, _ := cmd.Flags().GetString("output-format"); outputFormat != "" {
+		params["output_format"] = outputFormat
+	}
+	if benchmark, _ := cmd.Flags().GetString("cis-benchmark"); benchmark != "" {
+		params["cis_benchmark"] = benchmark
+	}
+	if skipCache, _ := cmd.Flags().GetBool("skip-cache"); skipCache {
+		params["skip_cache"] = true
+	}
+
+	sarifFile, _ := cmd.Flags().GetString("sarif-file")
+	if sarifFile != "" {
+		params["output_format"] = "sarif"
+	}
+
+	validationRules := map[string]tools.ValidationRule{
+		"security_domain": {
+			Required:      false,
+			Type:          "string",
+			AllowedValues: []string{"encryption", "iam", "network", "backup", "monitoring", "all"},
+		},
+		"framework": {
+			Required:      false,
+			Type:          "string",
+			AllowedValues: frameworks.Supported,
+		},
+		"controls":                {Required: false, Type: "array"},
+		"soc2_controls":           {Required: false, Type: "array"},
+		"evidence_tasks":          {Required: false, Type: "array"},
+		"include_compliance_gaps": BoolRule,
+		"output_format": {
+			Required:      false,
+			Type:          "string",
+			AllowedValues: []string{"detailed_json", "summary_markdown", "compliance_csv", "sarif"},
+		},
+		"cis_benchmark": {
+			Required:      false,
+			Type:          "string",
+			AllowedValues: []string{terraform.CISBenchmarkAWS, terraform.CISBenchmarkGCP, terraform.CISBenchmarkAll},
+		},
+		"skip_cache": BoolRule,
+	}
+
+	if sarifFile == "" {
+		return ValidateAndExecuteTool(cmd, terraformSecurityToolName, params, validationRules)
+	}
+	return writeTerraformSARIF(cmd, sarifFile, params, validationRules)
+}
+
+// writeTerraformSARIF runs the analyzer with SARIF output and writes the log to
+// a file, reporting the file path in the standard tool envelope
+func writeTerraformSARIF(cmd *cobra.Command, path string, params map[string]interface{}, validationRules map[string]tools.ValidationRule) error {
+	toolCtx, err := NewToolContext(cmd, commandContext(cmd))
+	if err != nil {
+		return err
+	}
+
+	paramResult, err := toolCtx.Validator.ValidateParameters(params, validationRules)
+	if err != nil {
+		return toolCtx.WriteError(tools.ErrorCodeValidation,
+			fmt.Sprintf("parameter validation failed: %v", err),
+			terraformSecurityToolName, map[string]interface{}{"params": params})
+	}
+	if !paramResult.Valid {
+		return toolCtx.WriteError(tools.ErrorCodeValidation, "invalid parameters",
+			terraformSecurityToolName, map[string]interface{}{
+				"params": params,
+				"errors": paramResult.Errors,
+			})
+	}
+
+	report, _, err := tools.ExecuteTool(toolCtx.Context, terraformSecurityToolName, params)
+	if err != nil {
+		return toolCtx.WriteError(tools.ErrorCodeInternal,
+			fmt.Sprintf("tool execution failed: %v", err),
+			terraformSecurityToolName, map[string]interface{}{"error": err.Error()})
+	}
+
+	var sarifLog terraform.SARIFLog
+	if err := json.Unmarshal([]byte(report), &sarifLog); err != nil {
+		return toolCtx.WriteError(tools.ErrorCodeInternal,
+			fmt.Sprintf("invalid SARIF report: %v", err),
+			terraformSecurityToolName, nil)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(report+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write SARIF file: %w", err)
+	}
+
+	resultCount := 0
+	for _, run := range sarifLog.Runs {
+		resultCount += len(run.Results)
+	}
+
+	return toolCtx.WriteSuccess(map[string]interface{}{
+		"sarif_file":   path,
+		"result_count": resultCount,
+	}, terraformSecurityToolName)
+}
+
+// frameworkFlag returns the --framework flag resolved to a supported
+// framework, so aliases such as "pci" or "ISO 27001" are accepted
+func frameworkFlag(cmd *cobra.Command) (string, error) {
+	framework, _ := cmd.Flags().GetString("framework")
+	if framework == "" {
+		return "", nil
+	}
+	return frameworks.Normalize(framework)
+}
+
+func toInterfaceSlice(values []string) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, value := range values {
+		result[i] = value
+	}
+	return result
+}
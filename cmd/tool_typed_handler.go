@@ -16,7 +16,6 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/grctool/grctool/internal/tools"
@@ -27,7 +26,7 @@ import (
 
 // ValidateAndExecuteTypedTool executes a tool using the new typed interface
 func ValidateAndExecuteTypedTool(cmd *cobra.Command, toolName string, params map[string]interface{}) error {
-	ctx := context.Background()
+	ctx := commandContext(cmd)
 	toolCtx, err := NewToolContext(cmd, ctx)
 	if err != nil {
 		return err
@@ -0,0 +1,77 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/tools"
+	"github.com/spf13/cobra"
+)
+
+// vendorReviewToolCmd handles the vendor-review tool
+var vendorReviewToolCmd = &cobra.Command{
+	Use:   "vendor-review",
+	Short: "Export the vendor inventory and review status as evidence",
+	Long: `Export the vendor inventory maintained with 'grctool vendor' as evidence for
+third-party management tasks:
+- Each vendor's service, owner, criticality and data access
+- SOC report and its expiry
+- Last and next review dates and review status
+- Vendors whose review is overdue or whose SOC report is expired, expiring
+  or missing
+
+Use --task-ref to save the export as evidence for a task.`,
+	RunE: runVendorReviewTool,
+}
+
+func init() {
+	toolCmd.AddCommand(vendorReviewToolCmd)
+
+	vendorReviewToolCmd.Flags().String("output-format", "markdown", "output format (markdown, csv)")
+	vendorReviewToolCmd.Flags().String("min-criticality", "", "only vendors at least this critical (low, medium, high, critical)")
+	vendorReviewToolCmd.Flags().Bool("include-offboarded", false, "include offboarded vendors")
+}
+
+// runVendorReviewTool executes the vendor-review tool
+func runVendorReviewTool(cmd *cobra.Command, args []string) error {
+	params := make(map[string]interface{})
+
+	if outputFormat, _ := cmd.Flags().GetString("output-format"); outputFormat != "" {
+		params["output_format"] = outputFormat
+	}
+	if minCriticality, _ := cmd.Flags().GetString("min-criticality"); minCriticality != "" {
+		params["min_criticality"] = minCriticality
+	}
+	if includeOffboarded, _ := cmd.Flags().GetBool("include-offboarded"); includeOffboarded {
+		params["include_offboarded"] = true
+	}
+
+	validationRules := map[string]tools.ValidationRule{
+		"output_format": {
+			Required:      false,
+			Type:          "string",
+			AllowedValues: []string{"markdown", "csv"},
+		},
+		"min_criticality": {
+			Required:      false,
+			Type:          "string",
+			AllowedValues: domain.VendorCriticalities,
+		},
+		"include_offboarded": BoolRule,
+	}
+
+	return ValidateAndExecuteTool(cmd, "vendor-review", params, validationRules)
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/grctool/grctool/internal/appcontext"
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/telemetry"
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracingShutdownTimeout bounds how long exiting waits on the collector
+const tracingShutdownTimeout = 5 * time.Second
+
+var (
+	// shutdownTracing flushes buffered spans; a no-op until tracing is set up
+	shutdownTracing = func(context.Context) error { return nil }
+	// commandSpan is the root span of the running command
+	commandSpan trace.Span
+)
+
+// initTracing installs the span exporter when tracing.enabled is set
+func initTracing() {
+	cfg, err := config.Load()
+	if err != nil {
+		return
+	}
+	shutdownTracing = telemetry.Setup(cfg.Tracing, version)
+}
+
+// beforeCommand opens the command's root span, so the tool, API and storage
+// spans it causes share one trace, carries the run's correlation ID to the
+// services and tools it calls, and then pulls data_dir
+func beforeCommand(cmd *cobra.Command, args []string) error {
+	ctx := appcontext.WithCorrelationID(commandContext(cmd), correlationID)
+	ctx, span := telemetry.Start(ctx, cmd.CommandPath(), attribute.String("correlation.id", correlationID))
+	cmd.SetContext(ctx)
+	commandSpan = span
+	return pullRemoteStorage(cmd, args)
+}
+
+// finishTracing ends the command span with the command's outcome and
+// flushes the spans still buffered
+func finishTracing(err error) {
+	if commandSpan != nil {
+		telemetry.End(commandSpan, err)
+		commandSpan = nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+	_ = shutdownTracing(ctx)
+}
+
+// commandContext returns the command's context, which carries its span.
+// Commands run directly, as in tests, have none.
+func commandContext(cmd *cobra.Command) context.Context {
+	if ctx := cmd.Context(); ctx != nil {
+		return ctx
+	}
+	return context.Background()
+}
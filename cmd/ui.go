@@ -0,0 +1,154 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var uiCmd = &cobra.Command{
+	Use:   "ui",
+	Short: "Interactive terminal UI for evidence task triage",
+	Long: `Browse evidence tasks in an interactive terminal UI.
+
+The task list shows each task's local evidence state and due date, with a
+detail pane for the selected task. Filter by text, local state, overdue or
+due soon, and act on the selected task with one key:
+
+  c  generate context   (evidence generate <ref> --window <w> --context-only)
+  r  review evidence    (evidence review <ref> --window <w>)
+  s  submit dry run     (evidence submit <ref> --window <w> --dry-run)
+
+Actions run against --window (default: the current quarter); press w to
+switch the selected task to another window that has evidence. Press ? for
+all keys. The list is reloaded after every action.
+
+Examples:
+  # Triage all tasks
+  grctool ui
+
+  # Only my SOC 2 tasks, acting on the previous quarter
+  grctool ui --framework soc2 --assignee jane@example.com --window 2025-Q3`,
+	Args: cobra.NoArgs,
+	RunE: runUI,
+}
+
+func init() {
+	rootCmd.AddCommand(uiCmd)
+
+	uiCmd.Flags().String("window", "", "default evidence collection window for actions (default: current quarter)")
+	uiCmd.Flags().StringSlice("status", []string{}, "only load tasks with this Tugboat status (pending, completed, overdue)")
+	uiCmd.Flags().String("framework", "", "only load tasks for this framework (soc2, iso27001, etc)")
+	uiCmd.Flags().StringSlice("priority", []string{}, "only load tasks with this priority (high, medium, low)")
+	uiCmd.Flags().String("assignee", "", "only load tasks assigned to this person")
+	uiCmd.Flags().StringSlice("category", []string{}, "only load tasks in this category")
+}
+
+func runUI(cmd *cobra.Command, args []string) error {
+	window, _ := cmd.Flags().GetString("window")
+	if window == "" {
+		window = getCurrentQuarter()
+	}
+
+	evidenceService, err := initializeEvidenceService()
+	if err != nil {
+		return err
+	}
+	scanner, _, err := initializeScanner()
+	if err != nil {
+		return err
+	}
+
+	// The filter flags share names with 'evidence list'
+	filter := buildEvidenceFilterFromFlags(cmd)
+	load := func(ctx context.Context) ([]tui.Task, error) {
+		tasks, err := evidenceService.ListEvidenceTasks(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list evidence tasks: %w", err)
+		}
+		states, err := scanner.ScanAll(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan evidence: %w", err)
+		}
+		return uiTasks(tasks, states), nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM)
+	defer stop()
+
+	tasks, err := load(ctx)
+	if err != nil {
+		return err
+	}
+
+	model := tui.NewModel(tasks, window, time.Now())
+	if err := tui.Run(ctx, model, os.Stdin, cmd.OutOrStdout(), load, runGRCTool); err != nil {
+		if errors.Is(err, tui.ErrNotTerminal) {
+			return fmt.Errorf("%w; use 'grctool evidence list' in scripts", err)
+		}
+		return err
+	}
+	return nil
+}
+
+// uiTasks joins evidence tasks with their scanned local state, ordered by reference
+func uiTasks(tasks []domain.EvidenceTask, states map[string]*models.EvidenceTaskState) []tui.Task {
+	result := make([]tui.Task, 0, len(tasks))
+	for _, task := range tasks {
+		ref := displayTaskRef(task)
+		result = append(result, tui.Task{Ref: ref, Task: task, State: states[ref]})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Ref < result[j].Ref
+	})
+	return result
+}
+
+// runGRCTool runs an action from the UI as a separate grctool process, so
+// every action starts with fresh flags and config
+func runGRCTool(ctx context.Context, args []string) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate grctool: %w", err)
+	}
+
+	child := exec.CommandContext(ctx, executable, uiCommandArgs(args, cfgFile)...)
+	child.Stdin = os.Stdin
+	child.Stdout = os.Stdout
+	child.Stderr = os.Stderr
+	return child.Run()
+}
+
+// uiCommandArgs passes the UI's --config on to the commands it runs
+func uiCommandArgs(args []string, configFile string) []string {
+	if configFile == "" {
+		return args
+	}
+	return append([]string{"--config", configFile}, args...)
+}
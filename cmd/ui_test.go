@@ -0,0 +1,42 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUITasks(t *testing.T) {
+	t.Parallel()
+
+	tasks := []domain.EvidenceTask{
+		{ReferenceID: "ET-0002", Name: "Vulnerability Scans"},
+		{ReferenceID: "ET-0001", Name: "Access Review"},
+	}
+	states := map[string]*models.EvidenceTaskState{
+		"ET-0001": {LocalState: models.StateGenerated},
+	}
+
+	result := uiTasks(tasks, states)
+	require.Len(t, result, 2)
+	assert.Equal(t, "ET-0001", result[0].Ref)
+	assert.Equal(t, models.StateGenerated, result[0].State.LocalState)
+	assert.Equal(t, "ET-0002", result[1].Ref)
+	assert.Nil(t, result[1].State)
+}
+
+func TestUICommandArgs(t *testing.T) {
+	t.Parallel()
+
+	args := []string{"evidence", "review", "ET-0001", "--window", "2025-Q4"}
+	assert.Equal(t, args, uiCommandArgs(args, ""))
+	assert.Equal(t,
+		[]string{"--config", "/tmp/grctool.yaml", "evidence", "review", "ET-0001", "--window", "2025-Q4"},
+		uiCommandArgs(args, "/tmp/grctool.yaml"))
+}
@@ -0,0 +1,403 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+var vendorCmd = &cobra.Command{
+	Use:   "vendor",
+	Short: "Maintain the vendor inventory",
+	Long: `Maintain an inventory of third-party vendors in local storage. Each vendor
+records its criticality (low, medium, high or critical), the most sensitive data
+it can access (none, internal, confidential or restricted), its SOC report and
+when that report expires, and when it was last reviewed and is next due.
+
+Vendors whose review is overdue or due within 30 days, or that have data access
+and whose SOC report is expired, expiring or missing, need attention. The
+inventory is kept as JSON files under docs/vendors in the data directory; export
+it as evidence with 'grctool tool vendor-review'.
+
+Examples:
+  # Add a vendor
+  grctool vendor add --name "Acme Cloud" --service "Hosting" --criticality critical \
+    --data-access restricted --soc-report "SOC 2 Type II" --soc-expiry 2026-03-31
+
+  # Vendors needing attention
+  grctool vendor list --attention
+
+  # Record a review, next due in a year
+  grctool vendor review VEN-0001`,
+}
+
+var vendorAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a vendor to the inventory",
+	Args:  cobra.NoArgs,
+	RunE:  runVendorAdd,
+}
+
+var vendorListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List vendors, most critical first",
+	Args:  cobra.NoArgs,
+	RunE:  runVendorList,
+}
+
+var vendorUpdateCmd = &cobra.Command{
+	Use:   "update <vendor>",
+	Short: "Update a vendor's details, criticality or SOC report",
+	Long: `Update the fields of a vendor given as flags; fields not given are left as
+they are. The vendor may be given by ID or name. Use 'grctool vendor review'
+to record a review.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVendorUpdate,
+}
+
+var vendorReviewCmd = &cobra.Command{
+	Use:   "review <vendor>",
+	Short: "Record a vendor review",
+	Long: `Record that a vendor was reviewed, by default today, and when the next
+review is due, by default a year after the review.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVendorReview,
+}
+
+func init() {
+	rootCmd.AddCommand(vendorCmd)
+	vendorCmd.AddCommand(vendorAddCmd)
+	vendorCmd.AddCommand(vendorListCmd)
+	vendorCmd.AddCommand(vendorUpdateCmd)
+	vendorCmd.AddCommand(vendorReviewCmd)
+
+	addVendorFieldFlags(vendorAddCmd)
+	addVendorFieldFlags(vendorUpdateCmd)
+	_ = vendorAddCmd.MarkFlagRequired("name")
+
+	vendorListCmd.Flags().String("criticality", "", "only vendors with this criticality")
+	vendorListCmd.Flags().String("status", "", "only vendors with this status")
+	vendorListCmd.Flags().Bool("attention", false, "only vendors whose review or SOC report needs attention")
+
+	addVendorReviewFlags(vendorReviewCmd)
+}
+
+// vendorInfo is a vendor as shown by the vendor commands, with its computed statuses
+type vendorInfo struct {
+	domain.Vendor
+	ReviewStatus    string `json:"review_status"`
+	SOCReportStatus string `json:"soc_report_status"`
+	NeedsAttention  bool   `json:"needs_attention"`
+}
+
+func newVendorInfo(vendor domain.Vendor, now time.Time) vendorInfo {
+	return vendorInfo{
+		Vendor:          vendor,
+		ReviewStatus:    vendor.ReviewStatus(now),
+		SOCReportStatus: vendor.SOCReportStatus(now),
+		NeedsAttention:  vendor.NeedsAttention(now),
+	}
+}
+
+// addVendorFieldFlags adds the flags setting a vendor's fields, shared by add and update
+func addVendorFieldFlags(c *cobra.Command) {
+	c.Flags().String("name", "", "vendor name")
+	c.Flags().String("service", "", "what the vendor provides")
+	c.Flags().String("website", "", "vendor website")
+	c.Flags().String("owner", "", "person responsible for the relationship")
+	c.Flags().String("status", domain.VendorStatusActive, "status: onboarding, active or offboarded")
+	c.Flags().String("criticality", domain.VendorCriticalityMedium, "criticality: low, medium, high or critical")
+	c.Flags().String("data-access", domain.VendorDataNone, "data access: none, internal, confidential or restricted")
+	c.Flags().String("soc-report", "", "SOC report held, e.g. \"SOC 2 Type II\"")
+	c.Flags().String("soc-expiry", "", "SOC report expiry date (YYYY-MM-DD)")
+	c.Flags().String("notes", "", "notes")
+}
+
+// addVendorReviewFlags adds the flags recording a review
+func addVendorReviewFlags(c *cobra.Command) {
+	c.Flags().String("date", "", "review date (YYYY-MM-DD, default today)")
+	c.Flags().String("next", "", "next review date (YYYY-MM-DD, default a year after the review)")
+	c.Flags().String("notes", "", "review notes, replacing the vendor's notes")
+}
+
+func runVendorAdd(cmd *cobra.Command, args []string) error {
+	vendor := &domain.Vendor{}
+	if err := applyVendorFlags(cmd, vendor); err != nil {
+		return err
+	}
+
+	store, err := openLocalStorage()
+	if err != nil {
+		return err
+	}
+	if existing, err := store.GetVendor(vendor.Name); err == nil {
+		return fmt.Errorf("vendor %q already exists as %s", vendor.Name, existing.ID)
+	}
+
+	if vendor.ID, err = store.NextVendorID(); err != nil {
+		return fmt.Errorf("failed to allocate vendor ID: %w", err)
+	}
+	vendor.CreatedAt = time.Now().UTC()
+	vendor.UpdatedAt = vendor.CreatedAt
+	if err := store.SaveVendor(vendor); err != nil {
+		return fmt.Errorf("failed to save vendor: %w", err)
+	}
+
+	return showVendor(cmd, vendor, "✅ Added")
+}
+
+func runVendorList(cmd *cobra.Command, args []string) error {
+	criticality, _ := cmd.Flags().GetString("criticality")
+	status, _ := cmd.Flags().GetString("status")
+	attention, _ := cmd.Flags().GetBool("attention")
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	store, err := openLocalStorage()
+	if err != nil {
+		return err
+	}
+	all, err := store.GetAllVendors()
+	if err != nil {
+		return fmt.Errorf("failed to load vendors: %w", err)
+	}
+
+	now := time.Now()
+	vendors := filterVendorList(all, criticality, status, attention, now)
+
+	if isStructuredOutput(format) {
+		infos := make([]vendorInfo, 0, len(vendors))
+		for _, vendor := range vendors {
+			infos = append(infos, newVendorInfo(vendor, now))
+		}
+		return writeStructured(cmd, format, infos)
+	}
+
+	if len(vendors) == 0 {
+		cmd.Println("No vendors found. Add one with: grctool vendor add --name <name>")
+		return nil
+	}
+
+	cmd.Printf("%-9s %-8s %-12s %-10s %-14s %-11s %s\n", "ID", "CRIT", "DATA", "SOC", "REVIEW", "NEXT", "NAME")
+	needing := 0
+	for _, vendor := range vendors {
+		marker := ""
+		if vendor.NeedsAttention(now) {
+			marker = " ⚠️"
+			needing++
+		}
+		next := "-"
+		if vendor.NextReview != nil {
+			next = vendor.NextReview.Format("2006-01-02")
+		}
+		cmd.Printf("%-9s %-8s %-12s %-10s %-14s %-11s %s%s\n",
+			vendor.ID, vendor.Criticality, vendor.DataAccess, vendor.SOCReportStatus(now),
+			vendor.ReviewStatus(now), next, vendor.Name, marker)
+	}
+	cmd.Printf("\n%d vendors, %d needing attention\n", len(vendors), needing)
+	return nil
+}
+
+func runVendorUpdate(cmd *cobra.Command, args []string) error {
+	store, err := openLocalStorage()
+	if err != nil {
+		return err
+	}
+	vendor, err := store.GetVendor(args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := applyVendorFlags(cmd, vendor); err != nil {
+		return err
+	}
+	vendor.UpdatedAt = time.Now().UTC()
+	if err := store.SaveVendor(vendor); err != nil {
+		return fmt.Errorf("failed to save vendor: %w", err)
+	}
+
+	return showVendor(cmd, vendor, "✅ Updated")
+}
+
+func runVendorReview(cmd *cobra.Command, args []string) error {
+	store, err := openLocalStorage()
+	if err != nil {
+		return err
+	}
+	vendor, err := store.GetVendor(args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := applyVendorReview(cmd, vendor, time.Now().UTC()); err != nil {
+		return err
+	}
+	vendor.UpdatedAt = time.Now().UTC()
+	if err := store.SaveVendor(vendor); err != nil {
+		return fmt.Errorf("failed to save vendor: %w", err)
+	}
+
+	return showVendor(cmd, vendor, "📋 Reviewed")
+}
+
+// applyVendorFlags copies the flags given on the command line onto vendor. On
+// add every flag applies, so defaults for status, criticality and data access
+// take effect.
+func applyVendorFlags(cmd *cobra.Command, vendor *domain.Vendor) error {
+	flags := cmd.Flags()
+	adding := vendor.ID == ""
+	set := func(name string) bool { return adding || flags.Changed(name) }
+
+	stringFields := map[string]*string{
+		"name":        &vendor.Name,
+		"service":     &vendor.Service,
+		"website":     &vendor.Website,
+		"owner":       &vendor.Owner,
+		"status":      &vendor.Status,
+		"criticality": &vendor.Criticality,
+		"data-access": &vendor.DataAccess,
+		"soc-report":  &vendor.SOCReport,
+		"notes":       &vendor.Notes,
+	}
+	for name, field := range stringFields {
+		if set(name) {
+			*field, _ = flags.GetString(name)
+		}
+	}
+
+	if set("soc-expiry") {
+		expiry, err := parseDateFlag(cmd, "soc-expiry")
+		if err != nil {
+			return err
+		}
+		vendor.SOCReportExpiry = expiry
+	}
+	return nil
+}
+
+// applyVendorReview records a review on the given date, or today, with the
+// next review a year later unless --next is given
+func applyVendorReview(cmd *cobra.Command, vendor *domain.Vendor, today time.Time) error {
+	reviewed, err := parseDateFlag(cmd, "date")
+	if err != nil {
+		return err
+	}
+	if reviewed == nil {
+		date := today.Truncate(24 * time.Hour)
+		reviewed = &date
+	}
+
+	next, err := parseDateFlag(cmd, "next")
+	if err != nil {
+		return err
+	}
+	if next == nil {
+		date := reviewed.AddDate(1, 0, 0)
+		next = &date
+	}
+	if !next.After(*reviewed) {
+		return fmt.Errorf("next review %s must be after the review date %s",
+			next.Format("2006-01-02"), reviewed.Format("2006-01-02"))
+	}
+
+	vendor.LastReviewed = reviewed
+	vendor.NextReview = next
+	if cmd.Flags().Changed("notes") {
+		vendor.Notes, _ = cmd.Flags().GetString("notes")
+	}
+	return nil
+}
+
+// parseDateFlag parses a YYYY-MM-DD flag, returning nil when it is empty
+func parseDateFlag(cmd *cobra.Command, name string) (*time.Time, error) {
+	value, _ := cmd.Flags().GetString(name)
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%s date %q: use YYYY-MM-DD", name, value)
+	}
+	return &date, nil
+}
+
+// filterVendorList keeps vendors matching the filters, most critical first
+func filterVendorList(vendors []domain.Vendor, criticality, status string, attention bool, now time.Time) []domain.Vendor {
+	rank := func(criticality string) int { return slices.Index(domain.VendorCriticalities, criticality) }
+
+	filtered := []domain.Vendor{}
+	for _, vendor := range vendors {
+		if criticality != "" && !strings.EqualFold(vendor.Criticality, criticality) {
+			continue
+		}
+		if status != "" && !strings.EqualFold(vendor.Status, status) {
+			continue
+		}
+		if attention && !vendor.NeedsAttention(now) {
+			continue
+		}
+		filtered = append(filtered, vendor)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		if rank(filtered[i].Criticality) != rank(filtered[j].Criticality) {
+			return rank(filtered[i].Criticality) > rank(filtered[j].Criticality)
+		}
+		return strings.ToLower(filtered[i].Name) < strings.ToLower(filtered[j].Name)
+	})
+	return filtered
+}
+
+func showVendor(cmd *cobra.Command, vendor *domain.Vendor, verb string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if isStructuredOutput(format) {
+		return writeStructured(cmd, format, newVendorInfo(*vendor, now))
+	}
+
+	cmd.Printf("%s %s: %s\n", verb, vendor.ID, vendor.Name)
+	if vendor.Service != "" {
+		cmd.Printf("  Service:     %s\n", vendor.Service)
+	}
+	cmd.Printf("  Criticality: %s, data access %s, status %s\n", vendor.Criticality, vendor.DataAccess, vendor.Status)
+	if vendor.SOCReportExpiry != nil {
+		cmd.Printf("  SOC report:  %s, expires %s (%s)\n", vendor.SOCReport, vendor.SOCReportExpiry.Format("2006-01-02"), vendor.SOCReportStatus(now))
+	} else if vendor.SOCReport != "" {
+		cmd.Printf("  SOC report:  %s\n", vendor.SOCReport)
+	}
+	review := strings.ReplaceAll(vendor.ReviewStatus(now), "_", " ")
+	if vendor.NextReview != nil {
+		cmd.Printf("  Review:      %s, next due %s\n", review, vendor.NextReview.Format("2006-01-02"))
+	} else {
+		cmd.Printf("  Review:      %s\n", review)
+	}
+	if vendor.NeedsAttention(now) {
+		cmd.Println("  ⚠️  Needs attention")
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newVendorFlagsCmd returns a command with the add/update and review flags of the vendor commands
+func newVendorFlagsCmd(review bool, args ...string) *cobra.Command {
+	cmd := &cobra.Command{Use: "vendor"}
+	if review {
+		addVendorReviewFlags(cmd)
+	} else {
+		addVendorFieldFlags(cmd)
+	}
+	_ = cmd.Flags().Parse(args)
+	return cmd
+}
+
+func TestApplyVendorFlags(t *testing.T) {
+	t.Parallel()
+
+	vendor := &domain.Vendor{}
+	require.NoError(t, applyVendorFlags(newVendorFlagsCmd(false, "--name", "Acme Cloud", "--data-access", "restricted",
+		"--soc-report", "SOC 2 Type II", "--soc-expiry", "2026-03-31"), vendor))
+	assert.Equal(t, "Acme Cloud", vendor.Name)
+	assert.Equal(t, domain.VendorDataRestricted, vendor.DataAccess)
+	assert.Equal(t, domain.VendorCriticalityMedium, vendor.Criticality, "defaults apply when adding")
+	assert.Equal(t, domain.VendorStatusActive, vendor.Status)
+	require.NotNil(t, vendor.SOCReportExpiry)
+	assert.Equal(t, "2026-03-31", vendor.SOCReportExpiry.Format("2006-01-02"))
+
+	vendor.ID = "VEN-0001"
+	require.NoError(t, applyVendorFlags(newVendorFlagsCmd(false, "--criticality", "critical"), vendor))
+	assert.Equal(t, domain.VendorCriticalityCritical, vendor.Criticality)
+	assert.Equal(t, domain.VendorDataRestricted, vendor.DataAccess, "flags not given are kept on update")
+	assert.NotNil(t, vendor.SOCReportExpiry)
+
+	err := applyVendorFlags(newVendorFlagsCmd(false, "--soc-expiry", "31/03/2026"), vendor)
+	assert.ErrorContains(t, err, `invalid --soc-expiry date "31/03/2026": use YYYY-MM-DD`)
+}
+
+func TestApplyVendorReview(t *testing.T) {
+	t.Parallel()
+
+	today := time.Date(2025, 11, 1, 15, 30, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		args     []string
+		reviewed string
+		next     string
+		err      string
+	}{
+		"defaults":       {reviewed: "2025-11-01", next: "2026-11-01"},
+		"given date":     {args: []string{"--date", "2025-06-15"}, reviewed: "2025-06-15", next: "2026-06-15"},
+		"given next":     {args: []string{"--next", "2026-05-01"}, reviewed: "2025-11-01", next: "2026-05-01"},
+		"next too early": {args: []string{"--date", "2025-06-15", "--next", "2025-06-01"}, err: "must be after the review date"},
+		"bad date":       {args: []string{"--date", "June"}, err: `invalid --date date "June"`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			vendor := &domain.Vendor{ID: "VEN-0001"}
+			err := applyVendorReview(newVendorFlagsCmd(true, tc.args...), vendor, today)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.reviewed, vendor.LastReviewed.Format("2006-01-02"))
+			assert.Equal(t, tc.next, vendor.NextReview.Format("2006-01-02"))
+		})
+	}
+}
+
+func TestFilterVendorList(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	nextYear := now.AddDate(1, 0, 0)
+	vendors := []domain.Vendor{
+		{ID: "VEN-0001", Name: "Zeta", Criticality: domain.VendorCriticalityLow, Status: domain.VendorStatusActive,
+			DataAccess: domain.VendorDataNone, NextReview: &nextYear},
+		{ID: "VEN-0002", Name: "Beta", Criticality: domain.VendorCriticalityCritical, Status: domain.VendorStatusActive,
+			DataAccess: domain.VendorDataRestricted, NextReview: &nextYear},
+		{ID: "VEN-0003", Name: "Alpha", Criticality: domain.VendorCriticalityCritical, Status: domain.VendorStatusOffboarded,
+			DataAccess: domain.VendorDataNone},
+	}
+	ids := func(vendors []domain.Vendor) []string {
+		var out []string
+		for _, vendor := range vendors {
+			out = append(out, vendor.ID)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"VEN-0003", "VEN-0002", "VEN-0001"}, ids(filterVendorList(vendors, "", "", false, now)))
+	assert.Equal(t, []string{"VEN-0003", "VEN-0002"}, ids(filterVendorList(vendors, "CRITICAL", "", false, now)))
+	assert.Equal(t, []string{"VEN-0002", "VEN-0001"}, ids(filterVendorList(vendors, "", "active", false, now)))
+	assert.Equal(t, []string{"VEN-0002"}, ids(filterVendorList(vendors, "", "", true, now)), "missing SOC report needs attention")
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"runtime"
 
+	"github.com/grctool/grctool/internal/services/provenance"
 	"github.com/spf13/cobra"
 )
 
@@ -34,6 +35,7 @@ func SetVersionInfo(v, bt, gc string) {
 	version = v
 	buildTime = bt
 	gitCommit = gc
+	provenance.SetBuilderVersion(v)
 }
 
 // versionCmd represents the version command
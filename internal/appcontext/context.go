@@ -27,15 +27,16 @@ type contextKey string
 
 // Context key constants
 const (
-	loggerKey    contextKey = "grctool.logger"
-	commandKey   contextKey = "grctool.cobra.command"
-	configKey    contextKey = "grctool.config"
-	vcrConfigKey contextKey = "grctool.vcr.config"
-	outputKey    contextKey = "grctool.output.writer"
-	requestIDKey contextKey = "grctool.request.id"
-	userIDKey    contextKey = "grctool.user.id"
-	operationKey contextKey = "grctool.operation.name"
-	errorKey     contextKey = "grctool.error.writer"
+	loggerKey        contextKey = "grctool.logger"
+	commandKey       contextKey = "grctool.cobra.command"
+	configKey        contextKey = "grctool.config"
+	vcrConfigKey     contextKey = "grctool.vcr.config"
+	outputKey        contextKey = "grctool.output.writer"
+	requestIDKey     contextKey = "grctool.request.id"
+	correlationIDKey contextKey = "grctool.correlation.id"
+	userIDKey        contextKey = "grctool.user.id"
+	operationKey     contextKey = "grctool.operation.name"
+	errorKey         contextKey = "grctool.error.writer"
 )
 
 // WithLogger stores a logger in the context
@@ -126,6 +127,20 @@ func GetRequestID(ctx context.Context) string {
 	return ""
 }
 
+// WithCorrelationID stores the correlation ID of the running command in the
+// context. Unlike a request ID it is shared by everything the command does.
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey, correlationID)
+}
+
+// GetCorrelationID retrieves the correlation ID from context
+func GetCorrelationID(ctx context.Context) string {
+	if id, ok := ctx.Value(correlationIDKey).(string); ok {
+		return id
+	}
+	return ""
+}
+
 // WithUserID stores a user ID in the context
 func WithUserID(ctx context.Context, userID string) context.Context {
 	return context.WithValue(ctx, userIDKey, userID)
@@ -75,6 +75,13 @@ func TestContextKeys(t *testing.T) {
 		assert.Equal(t, reqID, retrieved)
 	})
 
+	t.Run("Correlation ID storage and retrieval", func(t *testing.T) {
+		correlationID := "6f1c2a9e-0d4b-4c7e-9a51-3b8f2e7d1c40"
+		ctx := WithCorrelationID(ctx, correlationID)
+		retrieved := GetCorrelationID(ctx)
+		assert.Equal(t, correlationID, retrieved)
+	})
+
 	t.Run("Operation storage and retrieval", func(t *testing.T) {
 		operation := "sync"
 		ctx := WithOperation(ctx, operation)
@@ -123,6 +130,7 @@ func TestNilSafety(t *testing.T) {
 	assert.Nil(t, GetOutput(ctx))
 	assert.Nil(t, GetError(ctx))
 	assert.Equal(t, "", GetRequestID(ctx))
+	assert.Equal(t, "", GetCorrelationID(ctx))
 	assert.Equal(t, "", GetUserID(ctx))
 	assert.Equal(t, "", GetOperation(ctx))
 
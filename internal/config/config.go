@@ -16,10 +16,18 @@
 package config
 
 import (
+	"context"
+	"encoding/base64"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -31,6 +39,12 @@ import (
 // Config represents the application configuration
 type Config struct {
 	Tugboat       TugboatConfig       `mapstructure:"tugboat" yaml:"tugboat"`
+	Vanta         VantaConfig         `mapstructure:"vanta" yaml:"vanta,omitempty"`
+	Drata         DrataConfig         `mapstructure:"drata" yaml:"drata,omitempty"`
+	Secureframe   SecureframeConfig   `mapstructure:"secureframe" yaml:"secureframe,omitempty"`
+	Hyperproof    HyperproofConfig    `mapstructure:"hyperproof" yaml:"hyperproof,omitempty"`
+	Delivery      DeliveryConfig      `mapstructure:"delivery" yaml:"delivery,omitempty"`
+	Submission    SubmissionConfig    `mapstructure:"submission" yaml:"submission,omitempty"`
 	Evidence      EvidenceConfig      `mapstructure:"evidence" yaml:"evidence"`
 	Storage       StorageConfig       `mapstructure:"storage" yaml:"storage"`
 	Logging       LoggingConfig       `mapstructure:"logging" yaml:"logging"`
@@ -39,6 +53,17 @@ type Config struct {
 	Providers     ProvidersConfig     `mapstructure:"providers" yaml:"providers,omitempty"`
 	Schedules     SchedulesConfig     `mapstructure:"schedules" yaml:"schedules,omitempty"`
 	Lifecycle     LifecycleConfig     `mapstructure:"lifecycle" yaml:"lifecycle,omitempty"`
+	Retention     RetentionConfig     `mapstructure:"retention" yaml:"retention,omitempty"`
+	Notifications NotificationsConfig `mapstructure:"notifications" yaml:"notifications,omitempty"`
+	Daemon        DaemonConfig        `mapstructure:"daemon" yaml:"daemon,omitempty"`
+	User          UserConfig          `mapstructure:"user" yaml:"user,omitempty"`
+	Tracing       TracingConfig       `mapstructure:"tracing" yaml:"tracing,omitempty"`
+	Audit         AuditConfig         `mapstructure:"audit" yaml:"audit,omitempty"`
+
+	// Profile names the active entry of Profiles, whose settings have been
+	// merged over the rest of the configuration
+	Profile  string                   `mapstructure:"profile" yaml:"profile,omitempty"`
+	Profiles map[string]ProfileConfig `mapstructure:"profiles" yaml:"profiles,omitempty"`
 }
 
 // ProviderConfig holds configuration for a single data/sync provider
@@ -61,6 +86,11 @@ type ScheduleConfig struct {
 	Enabled  bool   `yaml:"enabled" mapstructure:"enabled"`
 	Scope    string `yaml:"scope,omitempty" mapstructure:"scope"`       // "all", "policies", "controls", "evidence"
 	Provider string `yaml:"provider,omitempty" mapstructure:"provider"` // provider name reference
+
+	// Tools run for each of Tasks when the schedule is due, e.g. github-permissions
+	// weekly. Shorthand for task_mappings entries naming this schedule.
+	Tasks []string `yaml:"tasks,omitempty" mapstructure:"tasks"`
+	Tools []string `yaml:"tools,omitempty" mapstructure:"tools"`
 }
 
 // TaskMappingConfig maps an evidence task to the tools that collect its evidence.
@@ -83,6 +113,185 @@ type LifecycleConfig struct {
 	EvidenceRetention   string `yaml:"evidence_retention,omitempty" mapstructure:"evidence_retention"`       // e.g., "7y"
 }
 
+// RetentionConfig sets how long evidence windows are kept once their period
+// ends and when they move to cold storage, as applied by 'grctool retention
+// apply'. Periods are written like 90d, 12w, 6m or 7y.
+type RetentionConfig struct {
+	KeepFor      string          `mapstructure:"keep_for" yaml:"keep_for,omitempty"`           // Windows that ended longer ago are deleted (default: lifecycle.evidence_retention, else kept forever)
+	ArchiveAfter string          `mapstructure:"archive_after" yaml:"archive_after,omitempty"` // Windows that ended longer ago are packed into <window>.tar.gz
+	ArchiveDir   string          `mapstructure:"archive_dir" yaml:"archive_dir,omitempty"`     // Cold storage directory archives are moved to (default: beside the window)
+	Rules        []RetentionRule `mapstructure:"rules" yaml:"rules,omitempty"`
+	LegalHolds   []LegalHold     `mapstructure:"legal_holds" yaml:"legal_holds,omitempty"`
+}
+
+// RetentionRule overrides the retention periods for some tasks; the first
+// rule naming a task or its category applies
+type RetentionRule struct {
+	Tasks        []string `mapstructure:"tasks" yaml:"tasks,omitempty"`           // Task references
+	Categories   []string `mapstructure:"categories" yaml:"categories,omitempty"` // Task categories, e.g. "Infrastructure"
+	KeepFor      string   `mapstructure:"keep_for" yaml:"keep_for,omitempty"`     // Default: retention.keep_for
+	ArchiveAfter string   `mapstructure:"archive_after" yaml:"archive_after,omitempty"`
+}
+
+// LegalHold keeps evidence from being deleted, whatever its retention
+// period, for as long as the hold is configured
+type LegalHold struct {
+	Name     string   `mapstructure:"name" yaml:"name"`
+	Tasks    []string `mapstructure:"tasks" yaml:"tasks,omitempty"`     // Task references (default: all)
+	Windows  []string `mapstructure:"windows" yaml:"windows,omitempty"` // Windows, e.g. 2023-Q4 (default: all)
+	Reason   string   `mapstructure:"reason" yaml:"reason"`             // Matter or request the evidence is preserved for (required)
+	PlacedBy string   `mapstructure:"placed_by" yaml:"placed_by,omitempty"`
+	Since    string   `mapstructure:"since" yaml:"since,omitempty"` // Day the hold was placed, YYYY-MM-DD
+}
+
+// retentionPeriodPattern matches retention periods such as 90d, 12w, 6m or 7y
+var retentionPeriodPattern = regexp.MustCompile(`^(\d+)\s*([dwmy])$`)
+
+// RetentionCutoff returns the time a retention period before now: evidence
+// whose window ended before it has outlived the period
+func RetentionCutoff(period string, now time.Time) (time.Time, error) {
+	match := retentionPeriodPattern.FindStringSubmatch(strings.TrimSpace(period))
+	if match == nil {
+		return time.Time{}, fmt.Errorf("invalid retention period %q: use e.g. 90d, 6m or 7y", period)
+	}
+	n, _ := strconv.Atoi(match[1])
+	switch match[2] {
+	case "d":
+		return now.AddDate(0, 0, -n), nil
+	case "w":
+		return now.AddDate(0, 0, -7*n), nil
+	case "m":
+		return now.AddDate(0, -n, 0), nil
+	default:
+		return now.AddDate(-n, 0, 0), nil
+	}
+}
+
+// PeriodsFor returns how long the evidence of a task is kept and when it is
+// archived: the periods of the first rule naming the task or its category,
+// falling back to the defaults. An empty period never elapses.
+func (r RetentionConfig) PeriodsFor(taskRef, category string) (keepFor, archiveAfter string) {
+	keepFor, archiveAfter = r.KeepFor, r.ArchiveAfter
+	for _, rule := range r.Rules {
+		if !containsFold(rule.Tasks, taskRef) && (category == "" || !containsFold(rule.Categories, category)) {
+			continue
+		}
+		if rule.KeepFor != "" {
+			keepFor = rule.KeepFor
+		}
+		if rule.ArchiveAfter != "" {
+			archiveAfter = rule.ArchiveAfter
+		}
+		break
+	}
+	return keepFor, archiveAfter
+}
+
+// HoldOn returns the legal hold covering a window of a task, if any
+func (r RetentionConfig) HoldOn(taskRef, window string) *LegalHold {
+	for i, hold := range r.LegalHolds {
+		if (len(hold.Tasks) == 0 || containsFold(hold.Tasks, taskRef)) &&
+			(len(hold.Windows) == 0 || containsFold(hold.Windows, window)) {
+			return &r.LegalHolds[i]
+		}
+	}
+	return nil
+}
+
+// validateRetentionPeriods checks the keep_for and archive_after periods set
+// at a config path
+func validateRetentionPeriods(path, keepFor, archiveAfter string) error {
+	if keepFor != "" {
+		if _, err := RetentionCutoff(keepFor, time.Now()); err != nil {
+			return fmt.Errorf("%s.keep_for: %w", path, err)
+		}
+	}
+	if archiveAfter != "" {
+		if _, err := RetentionCutoff(archiveAfter, time.Now()); err != nil {
+			return fmt.Errorf("%s.archive_after: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// containsFold reports whether list holds s, ignoring case
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// TracingConfig holds settings for OpenTelemetry tracing of commands, tool
+// runs, API calls and storage operations, exported over OTLP/HTTP
+type TracingConfig struct {
+	Enabled     bool              `mapstructure:"enabled" yaml:"enabled"`
+	Endpoint    string            `mapstructure:"endpoint" yaml:"endpoint,omitempty"`         // Collector URL (default: $OTEL_EXPORTER_OTLP_ENDPOINT, else http://localhost:4318)
+	Headers     map[string]string `mapstructure:"headers" yaml:"headers,omitempty"`           // Sent with each export, e.g. an API key; supports ${ENV_VAR}
+	ServiceName string            `mapstructure:"service_name" yaml:"service_name,omitempty"` // default: grctool
+	SampleRatio float64           `mapstructure:"sample_ratio" yaml:"sample_ratio,omitempty"` // Fraction of commands traced, 0 to 1 (default: 1)
+}
+
+// AuditConfig holds settings for the log of state-changing grctool actions
+type AuditConfig struct {
+	LogDir string `mapstructure:"log_dir" yaml:"log_dir,omitempty"` // Directory of the per-host action logs (default: <data_dir>/audit)
+}
+
+// DaemonConfig holds settings for continuous evidence collection
+type DaemonConfig struct {
+	PollInterval time.Duration       `mapstructure:"poll_interval" yaml:"poll_interval"` // How often sources are checked for changes (default: 5m)
+	Sources      []WatchSourceConfig `mapstructure:"sources" yaml:"sources,omitempty"`
+}
+
+// WatchSourceConfig is a directory or git checkout that evidence is collected from
+type WatchSourceConfig struct {
+	Name    string   `mapstructure:"name" yaml:"name"`
+	Path    string   `mapstructure:"path" yaml:"path"`                 // Relative paths are resolved against the config file
+	Include []string `mapstructure:"include" yaml:"include,omitempty"` // File patterns to watch, e.g. "*.tf" (default: all files)
+	Tasks   []string `mapstructure:"tasks" yaml:"tasks"`               // Evidence tasks refreshed when the source changes
+	Tools   []string `mapstructure:"tools" yaml:"tools,omitempty"`     // Tools to re-run (default: the task's schedules.task_mappings, else its applicable tools)
+}
+
+// UserConfig identifies the person running grctool, whose tasks 'evidence
+// list --mine' shows
+type UserConfig struct {
+	Email string `mapstructure:"email" yaml:"email,omitempty"` // Default: git's user.email
+	Name  string `mapstructure:"name" yaml:"name,omitempty"`   // Used when no email is set
+}
+
+// NotificationsConfig holds settings for due and overdue evidence notifications
+type NotificationsConfig struct {
+	DueSoonDays int               `mapstructure:"due_soon_days" yaml:"due_soon_days"` // Tasks due within this many days are due soon (default: 7)
+	Slack       SlackNotifyConfig `mapstructure:"slack" yaml:"slack"`
+	Email       EmailNotifyConfig `mapstructure:"email" yaml:"email"`
+}
+
+// SlackNotifyConfig holds Slack incoming webhook settings
+type SlackNotifyConfig struct {
+	Enabled    bool   `mapstructure:"enabled" yaml:"enabled"`
+	WebhookURL string `mapstructure:"webhook_url" yaml:"webhook_url"` // Channel for assignees without their own; supports ${ENV_VAR}
+	// Channels maps assignee emails to the webhook URL of their channel
+	Channels map[string]string `mapstructure:"channels" yaml:"channels,omitempty"`
+}
+
+// EmailNotifyConfig holds SMTP settings for email digests
+type EmailNotifyConfig struct {
+	Enabled  bool   `mapstructure:"enabled" yaml:"enabled"`
+	Host     string `mapstructure:"host" yaml:"host"`
+	Port     int    `mapstructure:"port" yaml:"port"` // default: 587
+	Username string `mapstructure:"username" yaml:"username,omitempty"`
+	Password string `mapstructure:"password" yaml:"password,omitempty"` // supports ${ENV_VAR}
+	From     string `mapstructure:"from" yaml:"from"`
+	// UnassignedTo receives the digest of tasks without an assignee
+	UnassignedTo []string `mapstructure:"unassigned_to" yaml:"unassigned_to,omitempty"`
+	// Subject and BodyTemplate are Go text/template sources rendered with the digest;
+	// BodyTemplate is a file path. Built-in templates are used when empty.
+	Subject      string `mapstructure:"subject" yaml:"subject,omitempty"`
+	BodyTemplate string `mapstructure:"body_template" yaml:"body_template,omitempty"`
+}
+
 // TugboatConfig holds Tugboat Logic API configuration
 type TugboatConfig struct {
 	BaseURL         string        `mapstructure:"base_url" yaml:"base_url"`
@@ -95,6 +304,8 @@ type TugboatConfig struct {
 	AuthExpires     string        `mapstructure:"auth_expires" yaml:"auth_expires"`           // When auth expires
 	LogAPIRequests  bool          `mapstructure:"log_api_requests" yaml:"log_api_requests"`   // Log HTTP request details
 	LogAPIResponses bool          `mapstructure:"log_api_responses" yaml:"log_api_responses"` // Log HTTP response details
+	SyncConcurrency int           `mapstructure:"sync_concurrency" yaml:"sync_concurrency"`   // Parallel API fetches during sync
+	SyncRetries     int           `mapstructure:"sync_retries" yaml:"sync_retries"`           // Retries of a failed sync fetch
 
 	// Custom Evidence Integration API (for evidence submission)
 	// API Key should be set via TUGBOAT_API_KEY environment variable (not stored in config for security)
@@ -103,13 +314,129 @@ type TugboatConfig struct {
 	CollectorURLs map[string]string `mapstructure:"collector_urls" yaml:"collector_urls"` // Evidence task ref -> collector URL mapping
 }
 
+// VantaConfig holds the Vanta API settings used to submit evidence to Vanta
+// instead of Tugboat Logic. The client secret should be set through the
+// VANTA_CLIENT_SECRET environment variable rather than stored in config.
+type VantaConfig struct {
+	BaseURL      string            `mapstructure:"base_url" yaml:"base_url,omitempty"`
+	ClientID     string            `mapstructure:"client_id" yaml:"client_id,omitempty"`
+	ClientSecret string            `mapstructure:"client_secret" yaml:"client_secret,omitempty"`
+	Timeout      time.Duration     `mapstructure:"timeout" yaml:"timeout,omitempty"`
+	DocumentIDs  map[string]string `mapstructure:"document_ids" yaml:"document_ids,omitempty"` // Evidence task ref -> Vanta document ID mapping
+}
+
+// DrataConfig holds the Drata public API settings used to submit evidence to
+// Drata as external evidence. The API key should be set through the
+// DRATA_API_KEY environment variable rather than stored in config.
+type DrataConfig struct {
+	BaseURL    string            `mapstructure:"base_url" yaml:"base_url,omitempty"`
+	APIKey     string            `mapstructure:"api_key" yaml:"api_key,omitempty"`
+	Timeout    time.Duration     `mapstructure:"timeout" yaml:"timeout,omitempty"`
+	ControlIDs map[string]string `mapstructure:"control_ids" yaml:"control_ids,omitempty"` // Evidence task ref -> Drata control ID mapping
+}
+
+// SecureframeConfig holds the Secureframe API settings used to submit
+// evidence to Secureframe tests. The API secret should be set through the
+// SECUREFRAME_API_SECRET environment variable rather than stored in config.
+type SecureframeConfig struct {
+	BaseURL   string            `mapstructure:"base_url" yaml:"base_url,omitempty"`
+	APIKey    string            `mapstructure:"api_key" yaml:"api_key,omitempty"`
+	APISecret string            `mapstructure:"api_secret" yaml:"api_secret,omitempty"`
+	Timeout   time.Duration     `mapstructure:"timeout" yaml:"timeout,omitempty"`
+	TestIDs   map[string]string `mapstructure:"test_ids" yaml:"test_ids,omitempty"` // Evidence task ref -> Secureframe test ID mapping
+}
+
+// HyperproofConfig holds the Hyperproof API settings used to upload evidence
+// as proof of Hyperproof labels or controls. The client secret should be set
+// through the HYPERPROOF_CLIENT_SECRET environment variable rather than
+// stored in config.
+type HyperproofConfig struct {
+	BaseURL      string            `mapstructure:"base_url" yaml:"base_url,omitempty"`
+	TokenURL     string            `mapstructure:"token_url" yaml:"token_url,omitempty"`
+	ClientID     string            `mapstructure:"client_id" yaml:"client_id,omitempty"`
+	ClientSecret string            `mapstructure:"client_secret" yaml:"client_secret,omitempty"`
+	Timeout      time.Duration     `mapstructure:"timeout" yaml:"timeout,omitempty"`
+	OwnerID      string            `mapstructure:"owner_id" yaml:"owner_id,omitempty"`       // Hyperproof user who owns uploaded proof
+	LabelIDs     map[string]string `mapstructure:"label_ids" yaml:"label_ids,omitempty"`     // Evidence task ref -> Hyperproof label ID mapping
+	ControlIDs   map[string]string `mapstructure:"control_ids" yaml:"control_ids,omitempty"` // Evidence task ref -> Hyperproof control ID, for tasks without a label
+}
+
+// DeliveryConfig holds the settings for delivering evidence packages, a zip of
+// a window's files with a manifest, to an auditor's SFTP server, S3 bucket or
+// webhook. The webhook secret should be set through the
+// GRCTOOL_DELIVERY_WEBHOOK_SECRET environment variable rather than stored in
+// config.
+type DeliveryConfig struct {
+	Timeout         time.Duration     `mapstructure:"timeout" yaml:"timeout,omitempty"`
+	SSHIdentityFile string            `mapstructure:"ssh_identity_file" yaml:"ssh_identity_file,omitempty"` // SFTP private key, instead of ssh's defaults
+	S3Region        string            `mapstructure:"s3_region" yaml:"s3_region,omitempty"`
+	S3Endpoint      string            `mapstructure:"s3_endpoint" yaml:"s3_endpoint,omitempty"`       // S3-compatible store, such as MinIO
+	WebhookSecret   string            `mapstructure:"webhook_secret" yaml:"webhook_secret,omitempty"` // HMAC-SHA256 key signing webhook deliveries
+	URLs            map[string]string `mapstructure:"urls" yaml:"urls,omitempty"`                     // Evidence task ref -> sftp://, s3:// or https:// delivery URL
+}
+
+// SubmissionConfig holds the controls on submitting evidence to any target
+type SubmissionConfig struct {
+	// RequireApproval refuses submissions of evidence not signed off with
+	// 'grctool evidence approve', or changed since it was
+	RequireApproval bool `mapstructure:"require_approval" yaml:"require_approval,omitempty"`
+
+	// Scan checks evidence for secrets and personal data before submission
+	Scan ScanConfig `mapstructure:"scan" yaml:"scan,omitempty"`
+
+	// UploadManifest uploads manifest.json, indexing the files, sources and
+	// controls of the window, after its evidence files. Evidence packages
+	// always contain it.
+	UploadManifest bool `mapstructure:"upload_manifest" yaml:"upload_manifest,omitempty"`
+}
+
+// ScanConfig sets what the pre-submission scan does when evidence contains
+// secrets, such as API and private keys, or personal data, such as emails
+// and SSNs: "block" fails validation and refuses submission, "warn" reports
+// it and "off" skips the check
+type ScanConfig struct {
+	Secrets string       `mapstructure:"secrets" yaml:"secrets,omitempty"` // block, warn or off (default: block)
+	PII     string       `mapstructure:"pii" yaml:"pii,omitempty"`         // block, warn or off (default: warn)
+	Allow   []string     `mapstructure:"allow" yaml:"allow,omitempty"`     // Regular expressions of matches to ignore, e.g. "@example\\.com$"
+	Rules   []DLPRule    `mapstructure:"rules" yaml:"rules,omitempty"`     // Team-defined disallowed content
+	Waivers []ScanWaiver `mapstructure:"waivers" yaml:"waivers,omitempty"` // Accepted findings, with the reason
+}
+
+// DLPRule disallows terms or patterns, such as customer names or internal
+// hostnames, in the evidence of some task categories
+type DLPRule struct {
+	Name       string   `mapstructure:"name" yaml:"name"`
+	Categories []string `mapstructure:"categories" yaml:"categories,omitempty"` // Task categories it applies to, e.g. "Infrastructure" (default: all)
+	Terms      []string `mapstructure:"terms" yaml:"terms,omitempty"`           // Words or phrases, matched case-insensitively
+	Patterns   []string `mapstructure:"patterns" yaml:"patterns,omitempty"`     // Regular expressions
+	Action     string   `mapstructure:"action" yaml:"action,omitempty"`         // block, warn or off (default: block)
+}
+
+// ScanWaiver accepts the findings of a rule, built in or in rules, that
+// evidence may contain, narrowed by task, file and matched text
+type ScanWaiver struct {
+	Rule     string   `mapstructure:"rule" yaml:"rule"`
+	Tasks    []string `mapstructure:"tasks" yaml:"tasks,omitempty"` // Task references (default: all)
+	Files    []string `mapstructure:"files" yaml:"files,omitempty"` // File name glob patterns (default: all)
+	Match    string   `mapstructure:"match" yaml:"match,omitempty"` // Regular expression the matched text must match
+	Reason   string   `mapstructure:"reason" yaml:"reason"`         // Why the finding is acceptable (required)
+	WaivedBy string   `mapstructure:"waived_by" yaml:"waived_by,omitempty"`
+	Expires  string   `mapstructure:"expires" yaml:"expires,omitempty"` // Last day it applies, YYYY-MM-DD
+}
+
 // EvidenceConfig holds evidence collection configuration
 type EvidenceConfig struct {
 	Generation       GenerationConfig       `mapstructure:"generation" yaml:"generation"`
 	Tools            ToolsConfig            `mapstructure:"tools" yaml:"tools"`
 	Quality          QualityConfig          `mapstructure:"quality" yaml:"quality"`
+	Evaluation       EvaluationConfig       `mapstructure:"evaluation" yaml:"evaluation,omitempty"`
 	SecurityControls SecurityControlsConfig `mapstructure:"security_controls" yaml:"security_controls"`
 	Terraform        TerraformConfig        `mapstructure:"terraform" yaml:"terraform"` // Terraform tool configuration
+	Freshness        FreshnessConfig        `mapstructure:"freshness" yaml:"freshness"`
+	Matching         MatchingConfig         `mapstructure:"matching" yaml:"matching"`
+	LLM              LLMConfig              `mapstructure:"llm" yaml:"llm,omitempty"`
+	Synthesis        SynthesisConfig        `mapstructure:"synthesis" yaml:"synthesis,omitempty"`
+	Signing          SigningConfig          `mapstructure:"signing" yaml:"signing,omitempty"`
 }
 
 // GenerationConfig holds evidence generation settings
@@ -121,6 +448,31 @@ type GenerationConfig struct {
 	DefaultFormat    string `mapstructure:"default_format" yaml:"default_format"` // csv or markdown
 	SummaryCacheDir  string `mapstructure:"summary_cache_dir" yaml:"summary_cache_dir"`
 	MaxSummaryLength int    `mapstructure:"max_summary_length" yaml:"max_summary_length"`
+	TemplateDir      string `mapstructure:"template_dir" yaml:"template_dir,omitempty"` // Overrides of the instruction and evidence templates (default: <data_dir>/templates)
+
+	Templates EvidenceTemplateMappings `mapstructure:"templates" yaml:"templates,omitempty"`
+}
+
+// EvidenceTemplateMappings selects user-supplied evidence outline files for
+// tasks in place of the built-in template of their category. A task
+// reference mapping wins over a category mapping, which wins over a framework
+// mapping.
+type EvidenceTemplateMappings struct {
+	Tasks      map[string]string `mapstructure:"tasks" yaml:"tasks,omitempty"`           // Evidence task ref -> template file
+	Categories map[string]string `mapstructure:"categories" yaml:"categories,omitempty"` // Task category, e.g. "Vendor" -> template file
+	Frameworks map[string]string `mapstructure:"frameworks" yaml:"frameworks,omitempty"` // Framework, e.g. "SOC 2" -> template file
+}
+
+// Files returns every mapped template file keyed by its config path, e.g.
+// "tasks.ET-0047"
+func (m EvidenceTemplateMappings) Files() map[string]string {
+	files := make(map[string]string, len(m.Tasks)+len(m.Categories)+len(m.Frameworks))
+	for kind, mapping := range map[string]map[string]string{"tasks": m.Tasks, "categories": m.Categories, "frameworks": m.Frameworks} {
+		for key, path := range mapping {
+			files[kind+"."+key] = path
+		}
+	}
+	return files
 }
 
 // ToolsConfig holds configuration for evidence collection tools
@@ -128,6 +480,47 @@ type ToolsConfig struct {
 	Terraform  TerraformToolConfig  `mapstructure:"terraform" yaml:"terraform"`
 	GitHub     GitHubToolConfig     `mapstructure:"github" yaml:"github"`
 	GoogleDocs GoogleDocsToolConfig `mapstructure:"google_docs" yaml:"google_docs"`
+	Plugins    []PluginToolConfig   `mapstructure:"plugins" yaml:"plugins,omitempty"` // External evidence collectors
+	Cache      ToolCacheConfig      `mapstructure:"cache" yaml:"cache,omitempty"`     // Reuse of tool results across runs
+
+	Concurrency int           `mapstructure:"concurrency" yaml:"concurrency,omitempty"` // Applicable tools evidence generate runs at once (default: 4)
+	Timeout     time.Duration `mapstructure:"timeout" yaml:"timeout,omitempty"`         // How long each applicable tool may run (default: 5m)
+}
+
+// ToolCacheConfig controls the tool result cache. Results of GitHub and
+// Terraform tools are reused while their parameters and sources are unchanged,
+// so bulk generation does not repeat identical API calls.
+type ToolCacheConfig struct {
+	Disabled bool                     `mapstructure:"disabled" yaml:"disabled,omitempty"` // Always run tools, as with --no-cache
+	TTL      time.Duration            `mapstructure:"ttl" yaml:"ttl,omitempty"`           // How long a result is reused (default: 1h)
+	Tools    map[string]time.Duration `mapstructure:"tools" yaml:"tools,omitempty"`       // TTL by tool name; 0 never caches the tool
+}
+
+// PluginToolConfig registers an external evidence collector as a tool. grctool
+// runs its command with the tool parameters as JSON on stdin and reads the
+// result and evidence source as JSON from stdout.
+type PluginToolConfig struct {
+	Name        string                  `mapstructure:"name" yaml:"name"`                       // Tool name, e.g. okta-mfa
+	Description string                  `mapstructure:"description" yaml:"description"`         // Shown in 'tool list' and to the model
+	Command     string                  `mapstructure:"command" yaml:"command"`                 // Executable; relative paths with a directory are resolved against the config file
+	Args        []string                `mapstructure:"args" yaml:"args,omitempty"`             // Arguments passed before the request is written
+	Env         map[string]string       `mapstructure:"env" yaml:"env,omitempty"`               // Added to grctool's environment
+	Keywords    []string                `mapstructure:"keywords" yaml:"keywords,omitempty"`     // Task name or description words that make the tool applicable
+	Parameters  []PluginParameterConfig `mapstructure:"parameters" yaml:"parameters,omitempty"` // Parameters the collector accepts
+	Timeout     time.Duration           `mapstructure:"timeout" yaml:"timeout,omitempty"`       // How long a run may take (default: 5m)
+}
+
+// pluginNamePattern matches plugin tool names, which become 'grctool tool'
+// subcommands
+var pluginNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// PluginParameterConfig declares a parameter of a plugin tool
+type PluginParameterConfig struct {
+	Name        string   `mapstructure:"name" yaml:"name"`
+	Type        string   `mapstructure:"type" yaml:"type,omitempty"` // string, integer or boolean (default: string)
+	Description string   `mapstructure:"description" yaml:"description,omitempty"`
+	Required    bool     `mapstructure:"required" yaml:"required,omitempty"`
+	Values      []string `mapstructure:"values" yaml:"values,omitempty"` // Allowed values
 }
 
 // TerraformToolConfig holds Terraform tool configuration
@@ -154,6 +547,14 @@ type GoogleDocsToolConfig struct {
 	Enabled         bool   `mapstructure:"enabled" yaml:"enabled"`
 	CredentialsFile string `mapstructure:"credentials_file" yaml:"credentials_file"`
 	SharedDriveID   string `mapstructure:"shared_drive_id" yaml:"shared_drive_id"`
+	// UseDefaultCredentials authenticates with Application Default Credentials
+	// (gcloud, workload identity federation, or the metadata server) instead of a key file
+	UseDefaultCredentials bool `mapstructure:"use_default_credentials" yaml:"use_default_credentials"`
+	// ImpersonateServiceAccount is a service account to impersonate keylessly
+	ImpersonateServiceAccount string `mapstructure:"impersonate_service_account" yaml:"impersonate_service_account"`
+	// CredentialsJSON is a key read from the OS keychain at load time, never
+	// from the config file
+	CredentialsJSON string `mapstructure:"-" yaml:"-" json:"-"`
 }
 
 // QualityConfig holds evidence quality settings
@@ -165,9 +566,349 @@ type QualityConfig struct {
 	MinQualityScore      float64 `mapstructure:"min_quality_score" yaml:"min_quality_score"`
 }
 
-// SecurityControlsConfig holds security control mappings
+// EvaluationConfig holds the rubrics 'grctool evidence evaluate' scores
+// evidence with. Tasks no rubric covers are scored on completeness 30%,
+// requirements match 30%, quality 20% and control alignment 20%.
+type EvaluationConfig struct {
+	Rubrics []EvaluationRubric `mapstructure:"rubrics" yaml:"rubrics,omitempty"`
+}
+
+// SynthesisConfig holds the budget of 'grctool evidence generate --all
+// --synthesize'. A batch stops drafting once it has spent either limit, and
+// running it again continues with the tasks not yet drafted.
+type SynthesisConfig struct {
+	MaxTokens int     `mapstructure:"max_tokens" yaml:"max_tokens,omitempty"` // Input and output tokens per batch (default: 0, unlimited)
+	MaxCost   float64 `mapstructure:"max_cost" yaml:"max_cost,omitempty"`     // USD per batch, at the model's prices (default: 0, unlimited)
+}
+
+// EvaluationRubric sets how the evidence of some tasks is scored. The first
+// rubric naming a task applies, then the first naming its category, then the
+// first naming neither.
+type EvaluationRubric struct {
+	Name           string                     `mapstructure:"name" yaml:"name"`
+	Tasks          []string                   `mapstructure:"tasks" yaml:"tasks,omitempty"`                   // Task references
+	Categories     []string                   `mapstructure:"categories" yaml:"categories,omitempty"`         // Task categories, e.g. "Infrastructure"
+	PassThreshold  float64                    `mapstructure:"pass_threshold" yaml:"pass_threshold,omitempty"` // Overall score needed to pass (default: 70)
+	Dimensions     map[string]RubricDimension `mapstructure:"dimensions" yaml:"dimensions,omitempty"`         // Keyed by dimension; unlisted dimensions are not scored (default: all four)
+	RequiredChecks []RubricCheck              `mapstructure:"required_checks" yaml:"required_checks,omitempty"`
+}
+
+// RubricDimension weights one evaluation dimension: completeness,
+// requirements_match, quality or control_alignment
+type RubricDimension struct {
+	Weight float64 `mapstructure:"weight" yaml:"weight"`       // Relative share of the overall score
+	Pass   float64 `mapstructure:"pass" yaml:"pass,omitempty"` // Score the dimension passes at (default: 80)
+	Warn   float64 `mapstructure:"warn" yaml:"warn,omitempty"` // Score below which it fails (default: 50)
+}
+
+// RubricCheck requires evidence files matching one of its patterns
+type RubricCheck struct {
+	Name        string   `mapstructure:"name" yaml:"name"`
+	Description string   `mapstructure:"description" yaml:"description,omitempty"`
+	Files       []string `mapstructure:"files" yaml:"files"`                   // File name glob patterns, e.g. "*users*.csv"
+	MinFiles    int      `mapstructure:"min_files" yaml:"min_files,omitempty"` // Matching files needed (default: 1)
+	Severity    string   `mapstructure:"severity" yaml:"severity,omitempty"`   // Issue raised when unmet: critical fails the evaluation (default), high, medium or low
+}
+
+// EvaluationDimensions are the dimensions a rubric can weight, in the order
+// evidence evaluate reports them
+var EvaluationDimensions = []string{"completeness", "requirements_match", "quality", "control_alignment"}
+
+// RubricFor returns the rubric scoring the evidence of a task, or nil when
+// the default scoring applies
+func (e EvaluationConfig) RubricFor(taskRef, category string) *EvaluationRubric {
+	var byCategory, fallback *EvaluationRubric
+	for i, rubric := range e.Rubrics {
+		switch {
+		case containsFold(rubric.Tasks, taskRef):
+			return &e.Rubrics[i]
+		case category != "" && containsFold(rubric.Categories, category):
+			if byCategory == nil {
+				byCategory = &e.Rubrics[i]
+			}
+		case len(rubric.Tasks) == 0 && len(rubric.Categories) == 0:
+			if fallback == nil {
+				fallback = &e.Rubrics[i]
+			}
+		}
+	}
+	if byCategory != nil {
+		return byCategory
+	}
+	return fallback
+}
+
+// validateRubric checks a rubric, filling in the default check sizes and
+// severities
+func validateRubric(path string, rubric *EvaluationRubric) error {
+	if rubric.PassThreshold < 0 || rubric.PassThreshold > 100 {
+		return fmt.Errorf("%s.pass_threshold must be between 0 and 100, got: %g", path, rubric.PassThreshold)
+	}
+	totalWeight := 0.0
+	for name, dimension := range rubric.Dimensions {
+		if !slices.Contains(EvaluationDimensions, name) {
+			return fmt.Errorf("%s.dimensions has unknown dimension %s: use %s", path, name, strings.Join(EvaluationDimensions, ", "))
+		}
+		if dimension.Weight < 0 {
+			return fmt.Errorf("%s.dimensions.%s.weight must not be negative", path, name)
+		}
+		if dimension.Pass < 0 || dimension.Pass > 100 || dimension.Warn < 0 || dimension.Warn > 100 {
+			return fmt.Errorf("%s.dimensions.%s pass and warn must be between 0 and 100", path, name)
+		}
+		if dimension.Pass != 0 && dimension.Warn > dimension.Pass {
+			return fmt.Errorf("%s.dimensions.%s.warn must not be above pass", path, name)
+		}
+		totalWeight += dimension.Weight
+	}
+	if len(rubric.Dimensions) > 0 && totalWeight == 0 {
+		return fmt.Errorf("%s.dimensions must give at least one dimension a weight", path)
+	}
+	checkNames := map[string]bool{}
+	for i := range rubric.RequiredChecks {
+		check := &rubric.RequiredChecks[i]
+		if check.Name == "" || len(check.Files) == 0 {
+			return fmt.Errorf("%s.required_checks[%d] needs a name and files", path, i)
+		}
+		if checkNames[check.Name] {
+			return fmt.Errorf("%s.required_checks has more than one check named %s", path, check.Name)
+		}
+		checkNames[check.Name] = true
+		for _, pattern := range check.Files {
+			if _, err := filepath.Match(pattern, ""); err != nil {
+				return fmt.Errorf("%s.required_checks %s file pattern %q is invalid: %w", path, check.Name, pattern, err)
+			}
+		}
+		if check.MinFiles <= 0 {
+			check.MinFiles = 1 // default
+		}
+		switch check.Severity {
+		case "":
+			check.Severity = "critical" // default
+		case "critical", "high", "medium", "low":
+		default:
+			return fmt.Errorf("%s.required_checks %s severity must be critical, high, medium or low, got: %s", path, check.Name, check.Severity)
+		}
+	}
+	return nil
+}
+
+// FreshnessConfig holds evidence freshness settings
+type FreshnessConfig struct {
+	MaxAgeDays int `mapstructure:"max_age_days" yaml:"max_age_days"` // Evidence collected longer ago is stale (default: 90)
+}
+
+// MatchingConfig holds semantic matching of evidence tasks to tools,
+// policies, controls and prior evidence
+type MatchingConfig struct {
+	Provider string  `mapstructure:"provider" yaml:"provider"`   // "local" or "openai" (any OpenAI-compatible API); empty matches on keywords only
+	Model    string  `mapstructure:"model" yaml:"model"`         // Embedding model (default: text-embedding-3-small)
+	BaseURL  string  `mapstructure:"base_url" yaml:"base_url"`   // API base URL (default: https://api.openai.com/v1)
+	APIKey   string  `mapstructure:"api_key" yaml:"api_key"`     // supports ${ENV_VAR}
+	MinScore float64 `mapstructure:"min_score" yaml:"min_score"` // Least similarity to count as related (default: per provider)
+	Limit    int     `mapstructure:"limit" yaml:"limit"`         // Related items listed per kind (default: 5)
+}
+
+// LLMConfig selects the language model that reviews evidence in 'evidence
+// evaluate', drafts collection plans in assembled prompts and, with 'evidence
+// generate --synthesize', drafts the evidence itself. Ollama and llama.cpp
+// serve models on this machine; OpenAI, Anthropic, AWS Bedrock and Google
+// Vertex AI send prompts, including evidence contents, to the cloud and so
+// need allow_remote. Operations can each select their own provider. Without
+// a model, or while it cannot be reached, evaluation and prompt assembly fall
+// back to their heuristics and templates.
+type LLMConfig struct {
+	Provider        string        `mapstructure:"provider" yaml:"provider,omitempty"`                   // "ollama", "llamacpp", "openai", "anthropic", "bedrock", "vertex" or "none"; empty disables model review
+	Model           string        `mapstructure:"model" yaml:"model,omitempty"`                         // e.g. llama3.1:8b, gpt-4o-mini, claude-3-5-haiku-latest (required except for llamacpp)
+	BaseURL         string        `mapstructure:"base_url" yaml:"base_url,omitempty"`                   // default: per provider, e.g. http://localhost:11434 (ollama)
+	APIKey          string        `mapstructure:"api_key" yaml:"api_key,omitempty"`                     // openai and anthropic; supports ${ENV_VAR}
+	Region          string        `mapstructure:"region" yaml:"region,omitempty"`                       // bedrock (default: the AWS environment's) and vertex (default: us-central1)
+	Project         string        `mapstructure:"project" yaml:"project,omitempty"`                     // Google Cloud project (required for vertex)
+	Timeout         time.Duration `mapstructure:"timeout" yaml:"timeout,omitempty"`                     // Per request (default: 2m)
+	MaxContextChars int           `mapstructure:"max_context_chars" yaml:"max_context_chars,omitempty"` // Evidence text sent per review (default: 12000)
+	AllowRemote     bool          `mapstructure:"allow_remote" yaml:"allow_remote,omitempty"`           // Allow a model off this machine, sending evidence over the network
+
+	// Prices in USD per million tokens, to account for what synthesis
+	// costs (default: 0, e.g. for local models)
+	InputCostPerMillion  float64 `mapstructure:"input_cost_per_million" yaml:"input_cost_per_million,omitempty"`
+	OutputCostPerMillion float64 `mapstructure:"output_cost_per_million" yaml:"output_cost_per_million,omitempty"`
+
+	// Operations overrides the model per operation, keyed by evaluate,
+	// prompt_assembly or synthesis. An override naming a provider stands alone; one
+	// without changes only the fields it sets.
+	Operations map[string]LLMConfig `mapstructure:"operations" yaml:"operations,omitempty"`
+}
+
+// LLM providers
+const (
+	LLMProviderOllama    = "ollama"
+	LLMProviderLlamaCpp  = "llamacpp"
+	LLMProviderOpenAI    = "openai"
+	LLMProviderAnthropic = "anthropic"
+	LLMProviderBedrock   = "bedrock"
+	LLMProviderVertex    = "vertex"
+	LLMProviderNone      = "none" // Disables the model for an operation
+)
+
+// LLMProviders are the providers evidence.llm.provider accepts
+var LLMProviders = []string{LLMProviderOllama, LLMProviderLlamaCpp, LLMProviderOpenAI, LLMProviderAnthropic, LLMProviderBedrock, LLMProviderVertex, LLMProviderNone}
+
+// Operations that use the language model
+const (
+	LLMOperationEvaluate       = "evaluate"
+	LLMOperationPromptAssembly = "prompt_assembly"
+	LLMOperationSynthesis      = "synthesis"
+)
+
+// LLMOperations are the operations evidence.llm.operations accepts
+var LLMOperations = []string{LLMOperationEvaluate, LLMOperationPromptAssembly, LLMOperationSynthesis}
+
+// For returns the model configuration of an operation
+func (c LLMConfig) For(operation string) LLMConfig {
+	override, ok := c.Operations[operation]
+	if !ok {
+		c.Operations = nil
+		return c
+	}
+	override.Operations = nil
+	if override.Provider != "" {
+		return override
+	}
+
+	resolved := c
+	resolved.Operations = nil
+	if override.Model != "" {
+		resolved.Model = override.Model
+	}
+	if override.BaseURL != "" {
+		resolved.BaseURL = override.BaseURL
+	}
+	if override.APIKey != "" {
+		resolved.APIKey = override.APIKey
+	}
+	if override.Region != "" {
+		resolved.Region = override.Region
+	}
+	if override.Project != "" {
+		resolved.Project = override.Project
+	}
+	if override.Timeout > 0 {
+		resolved.Timeout = override.Timeout
+	}
+	if override.MaxContextChars > 0 {
+		resolved.MaxContextChars = override.MaxContextChars
+	}
+	if override.InputCostPerMillion > 0 {
+		resolved.InputCostPerMillion = override.InputCostPerMillion
+	}
+	if override.OutputCostPerMillion > 0 {
+		resolved.OutputCostPerMillion = override.OutputCostPerMillion
+	}
+	resolved.AllowRemote = resolved.AllowRemote || override.AllowRemote
+	return resolved
+}
+
+// Cost returns what a completion costs in USD at the configured prices
+func (c LLMConfig) Cost(inputTokens, outputTokens int) float64 {
+	return (float64(inputTokens)*c.InputCostPerMillion + float64(outputTokens)*c.OutputCostPerMillion) / 1e6
+}
+
+// validateLLM fills the defaults of a model configuration and checks it
+func validateLLM(path string, llm *LLMConfig) error {
+	switch llm.Provider {
+	case "", LLMProviderNone:
+		return nil
+	case LLMProviderOllama:
+		if llm.BaseURL == "" {
+			llm.BaseURL = "http://localhost:11434" // default
+		}
+	case LLMProviderLlamaCpp:
+		if llm.BaseURL == "" {
+			llm.BaseURL = "http://localhost:8080" // default
+		}
+	case LLMProviderOpenAI:
+		if llm.BaseURL == "" {
+			llm.BaseURL = "https://api.openai.com/v1" // default
+		}
+	case LLMProviderAnthropic:
+		if llm.BaseURL == "" {
+			llm.BaseURL = "https://api.anthropic.com" // default
+		}
+	case LLMProviderBedrock:
+		// The base URL defaults to the regional runtime endpoint, resolved
+		// with the AWS credentials
+	case LLMProviderVertex:
+		if llm.Project == "" {
+			llm.Project = os.Getenv("GOOGLE_CLOUD_PROJECT") // default
+		}
+		if llm.Project == "" {
+			return fmt.Errorf("%s.project is required with the vertex provider", path)
+		}
+		if llm.Region == "" {
+			llm.Region = "us-central1" // default
+		}
+		if llm.BaseURL == "" {
+			llm.BaseURL = "https://" + llm.Region + "-aiplatform.googleapis.com" // default
+		}
+	default:
+		return fmt.Errorf("%s.provider must be one of %s, got: %s", path, strings.Join(LLMProviders, ", "), llm.Provider)
+	}
+	if llm.Model == "" && llm.Provider != LLMProviderLlamaCpp {
+		return fmt.Errorf("%s.model is required with the %s provider", path, llm.Provider)
+	}
+	if llm.BaseURL != "" {
+		if parsed, err := url.Parse(llm.BaseURL); err != nil || parsed.Host == "" {
+			return fmt.Errorf("%s.base_url is not a valid URL: %s", path, llm.BaseURL)
+		}
+	}
+	if !llm.AllowRemote && !isLoopbackURL(llm.BaseURL) {
+		endpoint := llm.BaseURL
+		if endpoint == "" {
+			endpoint = "the " + llm.Provider + " provider"
+		}
+		return fmt.Errorf("%s sends prompts to %s, which is not on this machine: evidence would leave it, set %s.allow_remote to allow this", path, endpoint, path)
+	}
+	if llm.InputCostPerMillion < 0 || llm.OutputCostPerMillion < 0 {
+		return fmt.Errorf("%s.input_cost_per_million and %s.output_cost_per_million cannot be negative", path, path)
+	}
+	if llm.Timeout <= 0 {
+		llm.Timeout = 2 * time.Minute // default
+	}
+	if llm.MaxContextChars <= 0 {
+		llm.MaxContextChars = 12000 // default
+	}
+	return nil
+}
+
+// isLoopbackURL reports whether a URL points at this machine
+func isLoopbackURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := parsed.Hostname()
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// SigningConfig signs each evidence file and the window's generation
+// metadata as they are written, so auditors can confirm they were not
+// altered after collection. Signatures are verified by evidence validate and
+// submit.
+type SigningConfig struct {
+	Enabled   bool   `mapstructure:"enabled" yaml:"enabled"`
+	Method    string `mapstructure:"method" yaml:"method,omitempty"`         // "gpg" or "cosign" (default: gpg)
+	Key       string `mapstructure:"key" yaml:"key,omitempty"`               // gpg key ID or email (default key when empty), or cosign private key path or KMS URI
+	PublicKey string `mapstructure:"public_key" yaml:"public_key,omitempty"` // cosign public key verifying signatures; gpg verifies with the keyring
+}
+
+// SecurityControlsConfig holds security control mappings, keyed by the
+// control codes of each framework
 type SecurityControlsConfig struct {
-	SOC2 map[string]SecurityControlMapping `mapstructure:"soc2" yaml:"soc2"`
+	SOC2      map[string]SecurityControlMapping `mapstructure:"soc2" yaml:"soc2"`
+	NIST80053 map[string]SecurityControlMapping `mapstructure:"nist_800_53" yaml:"nist_800_53"` // NIST SP 800-53 Rev. 5, e.g. SC-28
 }
 
 // SecurityControlMapping represents a security control mapping
@@ -191,6 +932,69 @@ type StorageConfig struct {
 	LocalDataDir string       `mapstructure:"local_data_dir" yaml:"local_data_dir"` // For offline-first local storage
 	CacheDir     string       `mapstructure:"cache_dir" yaml:"cache_dir"`           // For performance cache
 	Paths        StoragePaths `mapstructure:"paths" yaml:"paths,omitempty"`         // Customizable subdirectory paths
+
+	// Remote mirrors data_dir to object storage, shared by a team or CI runners
+	Remote RemoteStorageConfig `mapstructure:"remote" yaml:"remote,omitempty"`
+
+	// Git commits every change to data_dir, giving a reviewable history
+	Git GitStorageConfig `mapstructure:"git" yaml:"git,omitempty"`
+
+	// Encryption encrypts the evidence of sensitive tasks at rest
+	Encryption EncryptionConfig `mapstructure:"encryption" yaml:"encryption,omitempty"`
+
+	// Catalog indexes data_dir in SQLite so lookups skip parsing every file
+	Catalog CatalogConfig `mapstructure:"catalog" yaml:"catalog,omitempty"`
+
+	// Dedup stores evidence files copied to several tasks or windows once
+	Dedup DedupConfig `mapstructure:"dedup" yaml:"dedup,omitempty"`
+}
+
+// RemoteStorageConfig describes an S3 or GCS bucket that data_dir is mirrored
+// to. The local data_dir remains the cache all reads are served from.
+type RemoteStorageConfig struct {
+	URL      string `mapstructure:"url" yaml:"url,omitempty"`           // s3://bucket/prefix or gs://bucket/prefix
+	Region   string `mapstructure:"region" yaml:"region,omitempty"`     // S3 region (default: AWS_REGION, then us-east-1)
+	Endpoint string `mapstructure:"endpoint" yaml:"endpoint,omitempty"` // S3-compatible endpoint, e.g. MinIO
+	AutoSync bool   `mapstructure:"auto_sync" yaml:"auto_sync"`         // Pull before and push after each command
+}
+
+// GitStorageConfig commits the evidence, metadata and submission records a
+// command writes to data_dir to a git repository. data_dir becomes a
+// repository of its own unless it already lies inside one.
+type GitStorageConfig struct {
+	Enabled     bool   `mapstructure:"enabled" yaml:"enabled"`
+	Branch      string `mapstructure:"branch" yaml:"branch,omitempty"`             // Required branch; a new repository starts on it (default: main)
+	AuthorName  string `mapstructure:"author_name" yaml:"author_name,omitempty"`   // Default: git's user.name, then grctool
+	AuthorEmail string `mapstructure:"author_email" yaml:"author_email,omitempty"` // Default: git's user.email
+}
+
+// EncryptionConfig encrypts the evidence files of tasks flagged sensitive,
+// and of any listed in Tasks, with AES-256-GCM. Files are decrypted
+// transparently when read or submitted. The key is either a base64 AES-256
+// key, by default the one grctool storage keygen stored in the keychain, or
+// a data key wrapped by AWS KMS and kept in data_dir.
+type EncryptionConfig struct {
+	Enabled  bool     `mapstructure:"enabled" yaml:"enabled"`
+	Key      string   `mapstructure:"key" yaml:"key,omitempty"`               // Base64 key, usually a keychain://, vault:// or awssm:// reference
+	KMSKeyID string   `mapstructure:"kms_key_id" yaml:"kms_key_id,omitempty"` // AWS KMS key ID or ARN wrapping the data key
+	Tasks    []string `mapstructure:"tasks" yaml:"tasks,omitempty"`           // Task refs to encrypt besides sensitive ones
+}
+
+// CatalogConfig enables a SQLite catalog of the synced tasks, controls and
+// policies and of the evidence files in data_dir. The catalog is rebuilt
+// from the files whenever they change and is never authoritative: any error
+// reading it falls back to the files.
+type CatalogConfig struct {
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+}
+
+// DedupConfig keeps one content-addressed copy of evidence files that
+// appear in several windows, replacing each copy with a small reference
+// record. References read as the file they point to, and are turned back
+// into real files when their window is submitted.
+type DedupConfig struct {
+	Enabled bool  `mapstructure:"enabled" yaml:"enabled"`             // Deduplicate after every command
+	MinSize int64 `mapstructure:"min_size" yaml:"min_size,omitempty"` // Smaller files stay as they are (default: 4096 bytes)
 }
 
 // StoragePaths defines customizable subdirectory paths within data_dir
@@ -313,6 +1117,9 @@ func (sp StoragePaths) ResolveRelativeTo(baseDir string) StoragePaths {
 	if result.EvidenceTasksJSON != "" && !filepath.IsAbs(result.EvidenceTasksJSON) {
 		result.EvidenceTasksJSON = filepath.Join(baseDir, result.EvidenceTasksJSON)
 	}
+	if result.EvidenceTasksMarkdown != "" && !filepath.IsAbs(result.EvidenceTasksMarkdown) {
+		result.EvidenceTasksMarkdown = filepath.Join(baseDir, result.EvidenceTasksMarkdown)
+	}
 	if result.EvidencePrompts != "" && !filepath.IsAbs(result.EvidencePrompts) {
 		result.EvidencePrompts = filepath.Join(baseDir, result.EvidencePrompts)
 	}
@@ -360,6 +1167,11 @@ type LoggerConfig struct {
 type InterpolationConfig struct {
 	Enabled   bool                   `mapstructure:"enabled" yaml:"enabled"`
 	Variables map[string]interface{} `mapstructure:"variables" yaml:"variables"`
+
+	Frameworks map[string]map[string]interface{} `mapstructure:"frameworks" yaml:"frameworks,omitempty"` // Framework -> variables overriding the global ones in its documents
+	Tasks      map[string]map[string]interface{} `mapstructure:"tasks" yaml:"tasks,omitempty"`           // Evidence task ref -> variables overriding framework and global ones
+	Env        []string                          `mapstructure:"env" yaml:"env,omitempty"`               // Environment variables templates may read with env
+	Strict     bool                              `mapstructure:"strict" yaml:"strict,omitempty"`         // Fail sync when synced documents use undefined {{variables}}
 }
 
 // AuthConfig holds authentication configuration
@@ -387,6 +1199,26 @@ func (ic *InterpolationConfig) GetFlatVariables() map[string]string {
 	return result
 }
 
+// GetFrameworkVariables returns the flattened variables of each framework
+// scope
+func (ic *InterpolationConfig) GetFrameworkVariables() map[string]map[string]string {
+	return ic.flattenScopes(ic.Frameworks)
+}
+
+// GetTaskVariables returns the flattened variables of each task scope
+func (ic *InterpolationConfig) GetTaskVariables() map[string]map[string]string {
+	return ic.flattenScopes(ic.Tasks)
+}
+
+func (ic *InterpolationConfig) flattenScopes(scopes map[string]map[string]interface{}) map[string]map[string]string {
+	result := make(map[string]map[string]string, len(scopes))
+	for scope, variables := range scopes {
+		result[scope] = make(map[string]string)
+		ic.flattenVariables("", variables, result[scope])
+	}
+	return result
+}
+
 // flattenVariables recursively flattens nested variables into dot notation
 func (ic *InterpolationConfig) flattenVariables(prefix string, variables map[string]interface{}, result map[string]string) {
 	for key, value := range variables {
@@ -416,27 +1248,40 @@ func (ic *InterpolationConfig) flattenVariables(prefix string, variables map[str
 	}
 }
 
-// validateConfigStructure checks for unknown or misplaced configuration keys
+// validateConfigStructure warns about unknown or misplaced configuration keys
 func validateConfigStructure() {
+	for _, warning := range StructureWarnings() {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: %s\n", warning)
+	}
+}
+
+// StructureWarnings returns the unknown or misplaced keys in the config file
+// in use, each with how to fix it
+func StructureWarnings() []string {
 	configFile := viper.ConfigFileUsed()
 	if configFile == "" {
-		return // No config file, nothing to validate
+		return nil // No config file, nothing to validate
 	}
 
 	// Read the raw YAML to detect unknown keys
 	data, err := os.ReadFile(configFile)
 	if err != nil {
-		return // Can't read file, skip validation
+		return nil // Can't read file, skip validation
 	}
 
 	var rawConfig map[string]interface{}
 	if err := yaml.Unmarshal(data, &rawConfig); err != nil {
-		return // Invalid YAML, will be caught by viper
+		return nil // Invalid YAML, will be caught by viper
 	}
 
 	// Known top-level keys
 	knownKeys := map[string]bool{
 		"tugboat":       true,
+		"vanta":         true,
+		"drata":         true,
+		"secureframe":   true,
+		"hyperproof":    true,
+		"delivery":      true,
 		"evidence":      true,
 		"storage":       true,
 		"logging":       true,
@@ -445,12 +1290,23 @@ func validateConfigStructure() {
 		"providers":     true,
 		"schedules":     true,
 		"lifecycle":     true,
+		"notifications": true,
+		"daemon":        true,
+		"profile":       true,
+		"profiles":      true,
 	}
 
+	var warnings []string
+
 	// Check top-level keys
+	var keys []string
 	for key := range rawConfig {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
 		if !knownKeys[key] {
-			fmt.Fprintf(os.Stderr, "⚠️  Warning: Unknown config key '%s' in %s\n", key, configFile)
+			warnings = append(warnings, fmt.Sprintf("Unknown config key '%s' in %s", key, configFile))
 		}
 	}
 
@@ -459,9 +1315,9 @@ func validateConfigStructure() {
 		// Check if user put terraform directly under evidence instead of evidence.tools.terraform
 		if _, hasTerraform := evidence["terraform"]; hasTerraform {
 			if _, hasTools := evidence["tools"]; !hasTools {
-				fmt.Fprintf(os.Stderr, "⚠️  Warning: Found 'evidence.terraform' but expected 'evidence.tools.terraform'\n")
-				fmt.Fprintf(os.Stderr, "   The Terraform tool configuration should be under 'evidence.tools.terraform'\n")
-				fmt.Fprintf(os.Stderr, "   See .grctool.example.yaml for correct structure\n")
+				warnings = append(warnings, "Found 'evidence.terraform' but expected 'evidence.tools.terraform'\n"+
+					"   The Terraform tool configuration should be under 'evidence.tools.terraform'\n"+
+					"   See .grctool.example.yaml for correct structure")
 			}
 		}
 
@@ -471,26 +1327,37 @@ func validateConfigStructure() {
 				"terraform":   true,
 				"github":      true,
 				"google_docs": true,
+				"plugins":     true,
+				"cache":       true,
+				"concurrency": true,
+				"timeout":     true,
 			}
+			var toolNames []string
 			for tool := range tools {
+				toolNames = append(toolNames, tool)
+			}
+			sort.Strings(toolNames)
+			for _, tool := range toolNames {
 				if !knownTools[tool] {
-					fmt.Fprintf(os.Stderr, "⚠️  Warning: Unknown tool '%s' under evidence.tools\n", tool)
+					warnings = append(warnings, fmt.Sprintf("Unknown tool '%s' under evidence.tools", tool))
 				}
 			}
 
 			// Validate terraform tool config
 			if terraform, ok := tools["terraform"].(map[string]interface{}); ok {
 				if _, hasEnabled := terraform["enabled"]; !hasEnabled {
-					fmt.Fprintf(os.Stderr, "⚠️  Warning: Terraform tool is missing 'enabled' field\n")
-					fmt.Fprintf(os.Stderr, "   Add 'evidence.tools.terraform.enabled: true' to use Terraform tools\n")
+					warnings = append(warnings, "Terraform tool is missing 'enabled' field\n"+
+						"   Add 'evidence.tools.terraform.enabled: true' to use Terraform tools")
 				}
 				if _, hasScanPaths := terraform["scan_paths"]; !hasScanPaths {
-					fmt.Fprintf(os.Stderr, "⚠️  Warning: Terraform tool is missing 'scan_paths'\n")
-					fmt.Fprintf(os.Stderr, "   Add paths to scan, e.g. 'scan_paths: [\"terraform/**/*.tf\"]'\n")
+					warnings = append(warnings, "Terraform tool is missing 'scan_paths'\n"+
+						"   Add paths to scan, e.g. 'scan_paths: [\"terraform/**/*.tf\"]'")
 				}
 			}
 		}
 	}
+
+	return warnings
 }
 
 // Load loads the configuration from viper
@@ -500,15 +1367,26 @@ func Load() (*Config, error) {
 	// Validate structure before unmarshaling
 	validateConfigStructure()
 
+	if err := ApplyProfile(); err != nil {
+		return nil, err
+	}
+
 	if err := viper.Unmarshal(&config); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
+	config.Profile = ActiveProfile()
+	normalizeCollectorURLs(&config)
 
 	// Process environment variable substitutions
 	if err := processEnvVars(&config); err != nil {
 		return nil, fmt.Errorf("failed to process environment variables: %w", err)
 	}
 
+	// Resolve vault://, awssm:// and other secret references
+	if err := resolveSecrets(context.Background(), &config); err != nil {
+		return nil, err
+	}
+
 	// Resolve relative paths relative to config file location
 	if err := resolveConfigPaths(&config); err != nil {
 		return nil, fmt.Errorf("failed to resolve config paths: %w", err)
@@ -527,13 +1405,21 @@ func Load() (*Config, error) {
 func LoadWithoutValidation() (*Config, error) {
 	var config Config
 
+	// An undefined profile is ignored, leaving the top-level configuration
+	_ = ApplyProfile()
+
 	if err := viper.Unmarshal(&config); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
+	config.Profile = ActiveProfile()
+	normalizeCollectorURLs(&config)
 
 	// Process environment variable substitutions (ignore errors)
 	_ = processEnvVars(&config)
 
+	// Resolve secret references (a reference that fails is left as written)
+	_ = resolveSecrets(context.Background(), &config)
+
 	// Resolve relative paths relative to config file location (ignore errors)
 	_ = resolveConfigPaths(&config)
 
@@ -566,6 +1452,9 @@ func processEnvVars(config *Config) error {
 		}
 	}
 
+	// Fill credentials missing from the configuration from the OS keychain
+	ApplyKeychainCredentials(config)
+
 	// Populate GitHub token from gh CLI if not configured
 	// This ensures all GitHub tools have access to the token
 	if config.Auth.GitHub.Token == "" && config.Evidence.Tools.GitHub.APIToken == "" {
@@ -622,9 +1511,50 @@ func processEnvVars(config *Config) error {
 		}
 	}
 
+	// Notification webhooks (optional)
+	config.Notifications.Slack.WebhookURL = resolveEnvRef(config.Notifications.Slack.WebhookURL)
+	for assignee, webhook := range config.Notifications.Slack.Channels {
+		config.Notifications.Slack.Channels[assignee] = resolveEnvRef(webhook)
+	}
+	config.Notifications.Email.Username = resolveEnvRef(config.Notifications.Email.Username)
+	config.Notifications.Email.Password = resolveEnvRef(config.Notifications.Email.Password)
+
+	// Embedding API key (optional)
+	config.Evidence.Matching.APIKey = resolveEnvRef(config.Evidence.Matching.APIKey)
+
+	// Language model API keys (optional)
+	config.Evidence.LLM.APIKey = resolveEnvRef(config.Evidence.LLM.APIKey)
+	for operation, llm := range config.Evidence.LLM.Operations {
+		llm.APIKey = resolveEnvRef(llm.APIKey)
+		config.Evidence.LLM.Operations[operation] = llm
+	}
+
 	return nil
 }
 
+// normalizeCollectorURLs upper-cases the task references of collector URLs.
+// Viper lower-cases map keys, while task references such as ET-0001 are
+// looked up in upper case.
+func normalizeCollectorURLs(config *Config) {
+	if len(config.Tugboat.CollectorURLs) == 0 {
+		return
+	}
+	urls := make(map[string]string, len(config.Tugboat.CollectorURLs))
+	for ref, url := range config.Tugboat.CollectorURLs {
+		urls[strings.ToUpper(ref)] = url
+	}
+	config.Tugboat.CollectorURLs = urls
+}
+
+// resolveEnvRef replaces a "${ENV_VAR}" value with the variable's value, or
+// an empty string when the variable is not set
+func resolveEnvRef(value string) string {
+	if strings.HasPrefix(value, "${") && strings.HasSuffix(value, "}") {
+		return os.Getenv(strings.TrimSuffix(strings.TrimPrefix(value, "${"), "}"))
+	}
+	return value
+}
+
 // getGitHubTokenFromCLI attempts to retrieve a GitHub token from the gh CLI
 // Returns an empty string if gh CLI is not available or not authenticated
 // This is a helper function to centralize GitHub authentication
@@ -677,6 +1607,45 @@ func resolveConfigPaths(cfg *Config) error {
 	if cfg.Evidence.Generation.SummaryCacheDir != "" && !filepath.IsAbs(cfg.Evidence.Generation.SummaryCacheDir) {
 		cfg.Evidence.Generation.SummaryCacheDir = filepath.Join(configDir, cfg.Evidence.Generation.SummaryCacheDir)
 	}
+	if cfg.Evidence.Generation.TemplateDir != "" && !filepath.IsAbs(cfg.Evidence.Generation.TemplateDir) {
+		cfg.Evidence.Generation.TemplateDir = filepath.Join(configDir, cfg.Evidence.Generation.TemplateDir)
+	}
+	for _, mapping := range []map[string]string{cfg.Evidence.Generation.Templates.Tasks, cfg.Evidence.Generation.Templates.Categories, cfg.Evidence.Generation.Templates.Frameworks} {
+		for key, path := range mapping {
+			if path != "" && !filepath.IsAbs(path) {
+				mapping[key] = filepath.Join(configDir, path)
+			}
+		}
+	}
+
+	// Resolve the retention archive directory
+	if cfg.Retention.ArchiveDir != "" && !filepath.IsAbs(cfg.Retention.ArchiveDir) {
+		cfg.Retention.ArchiveDir = filepath.Join(configDir, cfg.Retention.ArchiveDir)
+	}
+
+	// Resolve the audit log directory
+	if cfg.Audit.LogDir != "" && !filepath.IsAbs(cfg.Audit.LogDir) {
+		cfg.Audit.LogDir = filepath.Join(configDir, cfg.Audit.LogDir)
+	}
+
+	// Resolve notification templates
+	if cfg.Notifications.Email.BodyTemplate != "" && !filepath.IsAbs(cfg.Notifications.Email.BodyTemplate) {
+		cfg.Notifications.Email.BodyTemplate = filepath.Join(configDir, cfg.Notifications.Email.BodyTemplate)
+	}
+
+	// Resolve plugin commands given as paths, leaving bare names to $PATH
+	for i, plugin := range cfg.Evidence.Tools.Plugins {
+		if strings.ContainsRune(plugin.Command, filepath.Separator) && !filepath.IsAbs(plugin.Command) {
+			cfg.Evidence.Tools.Plugins[i].Command = filepath.Join(configDir, plugin.Command)
+		}
+	}
+
+	// Resolve daemon sources
+	for i, source := range cfg.Daemon.Sources {
+		if source.Path != "" && !filepath.IsAbs(source.Path) {
+			cfg.Daemon.Sources[i].Path = filepath.Join(configDir, source.Path)
+		}
+	}
 
 	// Resolve Terraform paths
 	if cfg.Evidence.Terraform.AtmosPath != "" && !filepath.IsAbs(cfg.Evidence.Terraform.AtmosPath) {
@@ -728,6 +1697,53 @@ func (c *Config) Validate() error {
 	if c.Tugboat.RateLimit <= 0 {
 		c.Tugboat.RateLimit = 10 // default
 	}
+	if c.Tugboat.SyncConcurrency <= 0 {
+		c.Tugboat.SyncConcurrency = 4 // default
+	}
+	if c.Tugboat.SyncRetries <= 0 {
+		c.Tugboat.SyncRetries = 3 // default
+	}
+	if c.Vanta.BaseURL == "" {
+		c.Vanta.BaseURL = "https://api.vanta.com" // default
+	}
+	if c.Vanta.Timeout <= 0 {
+		c.Vanta.Timeout = 30 * time.Second // default
+	}
+	if c.Drata.BaseURL == "" {
+		c.Drata.BaseURL = "https://public-api.drata.com" // default
+	}
+	if c.Drata.Timeout <= 0 {
+		c.Drata.Timeout = 30 * time.Second // default
+	}
+	if c.Secureframe.BaseURL == "" {
+		c.Secureframe.BaseURL = "https://api.secureframe.com" // default
+	}
+	if c.Secureframe.Timeout <= 0 {
+		c.Secureframe.Timeout = 30 * time.Second // default
+	}
+	if c.Hyperproof.BaseURL == "" {
+		c.Hyperproof.BaseURL = "https://api.hyperproof.app" // default
+	}
+	if c.Hyperproof.TokenURL == "" {
+		c.Hyperproof.TokenURL = "https://accounts.hyperproof.app/oauth/token" // default
+	}
+	if c.Hyperproof.Timeout <= 0 {
+		c.Hyperproof.Timeout = 30 * time.Second // default
+	}
+	if c.Delivery.Timeout <= 0 {
+		c.Delivery.Timeout = 5 * time.Minute // default
+	}
+	for taskRef, rawURL := range c.Delivery.URLs {
+		u, err := url.Parse(rawURL)
+		if err != nil || u.Host == "" {
+			return fmt.Errorf("delivery.urls.%s is not a valid URL: %s", taskRef, rawURL)
+		}
+		switch u.Scheme {
+		case "sftp", "s3", "https", "http":
+		default:
+			return fmt.Errorf("delivery.urls.%s must be an sftp://, s3:// or https:// URL, got: %s", taskRef, u.Redacted())
+		}
+	}
 
 	// Validate Evidence configuration
 	// Terraform configuration validation
@@ -758,6 +1774,11 @@ func (c *Config) Validate() error {
 	if c.Evidence.Generation.DefaultFormat != "csv" && c.Evidence.Generation.DefaultFormat != "markdown" {
 		return fmt.Errorf("evidence.generation.default_format must be 'csv' or 'markdown', got: %s", c.Evidence.Generation.DefaultFormat)
 	}
+	for key, path := range c.Evidence.Generation.Templates.Files() {
+		if strings.TrimSpace(path) == "" {
+			return fmt.Errorf("evidence.generation.templates.%s needs a template file", key)
+		}
+	}
 
 	// Validate Tools configuration
 	// Terraform tool validation
@@ -792,11 +1813,14 @@ func (c *Config) Validate() error {
 
 	// Google Docs tool validation
 	if c.Evidence.Tools.GoogleDocs.Enabled {
-		if c.Evidence.Tools.GoogleDocs.CredentialsFile == "" {
-			return fmt.Errorf("evidence.tools.google_docs.credentials_file is required when Google Docs tool is enabled")
-		}
-		if _, err := os.Stat(c.Evidence.Tools.GoogleDocs.CredentialsFile); os.IsNotExist(err) {
-			return fmt.Errorf("evidence.tools.google_docs.credentials_file does not exist: %s", c.Evidence.Tools.GoogleDocs.CredentialsFile)
+		googleDocs := c.Evidence.Tools.GoogleDocs
+		keyless := googleDocs.UseDefaultCredentials || googleDocs.ImpersonateServiceAccount != "" || googleDocs.CredentialsJSON != ""
+		if googleDocs.CredentialsFile == "" {
+			if !keyless {
+				return fmt.Errorf("evidence.tools.google_docs.credentials_file is required when Google Docs tool is enabled (or set use_default_credentials)")
+			}
+		} else if _, err := os.Stat(googleDocs.CredentialsFile); os.IsNotExist(err) {
+			return fmt.Errorf("evidence.tools.google_docs.credentials_file does not exist: %s", googleDocs.CredentialsFile)
 		}
 	}
 
@@ -811,6 +1835,263 @@ func (c *Config) Validate() error {
 		c.Evidence.Quality.MinQualityScore = 0.8 // default
 	}
 
+	rubricNames := map[string]bool{}
+	for i := range c.Evidence.Evaluation.Rubrics {
+		rubric := &c.Evidence.Evaluation.Rubrics[i]
+		if rubric.Name == "" {
+			return fmt.Errorf("evidence.evaluation.rubrics[%d].name is required", i)
+		}
+		if rubricNames[rubric.Name] {
+			return fmt.Errorf("evidence.evaluation.rubrics has more than one rubric named %s", rubric.Name)
+		}
+		rubricNames[rubric.Name] = true
+		if err := validateRubric(fmt.Sprintf("evidence.evaluation.rubrics[%d]", i), rubric); err != nil {
+			return err
+		}
+	}
+
+	// Validate Freshness configuration
+	if c.Evidence.Freshness.MaxAgeDays <= 0 {
+		c.Evidence.Freshness.MaxAgeDays = 90 // default
+	}
+	if c.Evidence.Matching.Limit <= 0 {
+		c.Evidence.Matching.Limit = 5 // default
+	}
+	if err := validateLLM("evidence.llm", &c.Evidence.LLM); err != nil {
+		return err
+	}
+	for operation := range c.Evidence.LLM.Operations {
+		if !slices.Contains(LLMOperations, operation) {
+			return fmt.Errorf("evidence.llm.operations.%s is not an operation: use one of %s", operation, strings.Join(LLMOperations, ", "))
+		}
+		resolved := c.Evidence.LLM.For(operation)
+		if err := validateLLM("evidence.llm.operations."+operation, &resolved); err != nil {
+			return err
+		}
+		c.Evidence.LLM.Operations[operation] = resolved
+	}
+	if c.Evidence.Synthesis.MaxTokens < 0 || c.Evidence.Synthesis.MaxCost < 0 {
+		return fmt.Errorf("evidence.synthesis.max_tokens and evidence.synthesis.max_cost cannot be negative")
+	}
+	if signing := &c.Evidence.Signing; signing.Enabled {
+		switch signing.Method {
+		case "":
+			signing.Method = "gpg" // default
+		case "gpg":
+		case "cosign":
+			if signing.Key == "" || signing.PublicKey == "" {
+				return fmt.Errorf("evidence.signing.key and evidence.signing.public_key are required to sign with cosign")
+			}
+		default:
+			return fmt.Errorf("evidence.signing.method must be 'gpg' or 'cosign', got: %s", signing.Method)
+		}
+	}
+
+	scan := &c.Submission.Scan
+	if scan.Secrets == "" {
+		scan.Secrets = "block" // default
+	}
+	if scan.PII == "" {
+		scan.PII = "warn" // default
+	}
+	for _, setting := range [][2]string{{"secrets", scan.Secrets}, {"pii", scan.PII}} {
+		if action := setting[1]; action != "block" && action != "warn" && action != "off" {
+			return fmt.Errorf("submission.scan.%s must be 'block', 'warn' or 'off', got: %s", setting[0], action)
+		}
+	}
+	for _, pattern := range scan.Allow {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("submission.scan.allow pattern %q is not a valid regular expression: %w", pattern, err)
+		}
+	}
+	ruleNames := map[string]bool{}
+	for i := range scan.Rules {
+		rule := &scan.Rules[i]
+		if rule.Name == "" {
+			return fmt.Errorf("submission.scan.rules[%d].name is required", i)
+		}
+		if ruleNames[rule.Name] {
+			return fmt.Errorf("submission.scan.rules has more than one rule named %s", rule.Name)
+		}
+		ruleNames[rule.Name] = true
+		if len(rule.Terms) == 0 && len(rule.Patterns) == 0 {
+			return fmt.Errorf("submission.scan.rules %s needs terms or patterns", rule.Name)
+		}
+		if rule.Action == "" {
+			rule.Action = "block" // default
+		}
+		if rule.Action != "block" && rule.Action != "warn" && rule.Action != "off" {
+			return fmt.Errorf("submission.scan.rules %s action must be 'block', 'warn' or 'off', got: %s", rule.Name, rule.Action)
+		}
+		for _, pattern := range rule.Patterns {
+			if _, err := regexp.Compile(pattern); err != nil {
+				return fmt.Errorf("submission.scan.rules %s pattern %q is not a valid regular expression: %w", rule.Name, pattern, err)
+			}
+		}
+	}
+	for i, waiver := range scan.Waivers {
+		if waiver.Rule == "" || waiver.Reason == "" {
+			return fmt.Errorf("submission.scan.waivers[%d] needs a rule and a reason", i)
+		}
+		if waiver.Match != "" {
+			if _, err := regexp.Compile(waiver.Match); err != nil {
+				return fmt.Errorf("submission.scan.waivers[%d].match is not a valid regular expression: %w", i, err)
+			}
+		}
+		for _, glob := range waiver.Files {
+			if _, err := filepath.Match(glob, ""); err != nil {
+				return fmt.Errorf("submission.scan.waivers[%d] file pattern %q is invalid: %w", i, glob, err)
+			}
+		}
+		if waiver.Expires != "" {
+			if _, err := time.Parse("2006-01-02", waiver.Expires); err != nil {
+				return fmt.Errorf("submission.scan.waivers[%d].expires must be a date like 2025-12-31, got: %s", i, waiver.Expires)
+			}
+		}
+	}
+
+	// Validate Notifications configuration
+	if c.Notifications.DueSoonDays <= 0 {
+		c.Notifications.DueSoonDays = 7 // default
+	}
+	if c.Notifications.Email.Port <= 0 {
+		c.Notifications.Email.Port = 587 // default
+	}
+
+	// Validate Tracing configuration
+	if c.Tracing.ServiceName == "" {
+		c.Tracing.ServiceName = "grctool" // default
+	}
+	if c.Tracing.Endpoint == "" {
+		c.Tracing.Endpoint = os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") // default
+	}
+	if c.Tracing.Endpoint == "" {
+		c.Tracing.Endpoint = "http://localhost:4318" // default
+	}
+	if u, err := url.Parse(c.Tracing.Endpoint); c.Tracing.Enabled && (err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "") {
+		return fmt.Errorf("tracing.endpoint must be an http:// or https:// URL, got: %s", c.Tracing.Endpoint)
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		return fmt.Errorf("tracing.sample_ratio must be between 0 and 1, got: %g", c.Tracing.SampleRatio)
+	}
+	if c.Tracing.SampleRatio == 0 {
+		c.Tracing.SampleRatio = 1 // default
+	}
+
+	// Validate Daemon configuration
+	if c.Daemon.PollInterval <= 0 {
+		c.Daemon.PollInterval = 5 * time.Minute // default
+	}
+	sourceNames := make(map[string]bool)
+	for i, source := range c.Daemon.Sources {
+		if source.Name == "" || source.Path == "" {
+			return fmt.Errorf("daemon.sources[%d] requires a name and a path", i)
+		}
+		if sourceNames[source.Name] {
+			return fmt.Errorf("daemon.sources has more than one source named %q", source.Name)
+		}
+		sourceNames[source.Name] = true
+		if len(source.Tasks) == 0 {
+			return fmt.Errorf("daemon.sources[%d] (%s) must list the evidence tasks it feeds", i, source.Name)
+		}
+	}
+
+	// Validate plugin tools
+	pluginNames := make(map[string]bool)
+	for i := range c.Evidence.Tools.Plugins {
+		plugin := &c.Evidence.Tools.Plugins[i]
+		if plugin.Name == "" || plugin.Command == "" {
+			return fmt.Errorf("evidence.tools.plugins[%d] requires a name and a command", i)
+		}
+		if !pluginNamePattern.MatchString(plugin.Name) {
+			return fmt.Errorf("evidence.tools.plugins[%d] name must be lower case letters, digits and dashes, got: %s", i, plugin.Name)
+		}
+		if pluginNames[plugin.Name] {
+			return fmt.Errorf("evidence.tools.plugins has more than one plugin named %q", plugin.Name)
+		}
+		pluginNames[plugin.Name] = true
+		if plugin.Timeout <= 0 {
+			plugin.Timeout = 5 * time.Minute // default
+		}
+		for j := range plugin.Parameters {
+			param := &plugin.Parameters[j]
+			if param.Name == "" {
+				return fmt.Errorf("evidence.tools.plugins %s parameters[%d] requires a name", plugin.Name, j)
+			}
+			if param.Type == "" {
+				param.Type = "string" // default
+			}
+			if param.Type != "string" && param.Type != "integer" && param.Type != "boolean" {
+				return fmt.Errorf("evidence.tools.plugins %s parameter %s type must be string, integer or boolean, got: %s", plugin.Name, param.Name, param.Type)
+			}
+		}
+	}
+
+	// Validate tool result cache
+	if c.Evidence.Tools.Cache.TTL < 0 {
+		return fmt.Errorf("evidence.tools.cache.ttl must not be negative, got: %s", c.Evidence.Tools.Cache.TTL)
+	}
+	if c.Evidence.Tools.Cache.TTL == 0 {
+		c.Evidence.Tools.Cache.TTL = time.Hour // default
+	}
+	for name, ttl := range c.Evidence.Tools.Cache.Tools {
+		if ttl < 0 {
+			return fmt.Errorf("evidence.tools.cache.tools %s ttl must not be negative, got: %s", name, ttl)
+		}
+	}
+
+	// Validate applicable tool execution
+	if c.Evidence.Tools.Concurrency < 0 {
+		return fmt.Errorf("evidence.tools.concurrency must not be negative, got: %d", c.Evidence.Tools.Concurrency)
+	}
+	if c.Evidence.Tools.Concurrency == 0 {
+		c.Evidence.Tools.Concurrency = 4 // default
+	}
+	if c.Evidence.Tools.Timeout < 0 {
+		return fmt.Errorf("evidence.tools.timeout must not be negative, got: %s", c.Evidence.Tools.Timeout)
+	}
+	if c.Evidence.Tools.Timeout == 0 {
+		c.Evidence.Tools.Timeout = 5 * time.Minute // default
+	}
+
+	// Validate Schedules configuration
+	for i, schedule := range c.Schedules.Schedules {
+		if (len(schedule.Tasks) == 0) != (len(schedule.Tools) == 0) {
+			return fmt.Errorf("schedules.schedules[%d] (%s) must list both tasks and tools, or neither", i, schedule.Name)
+		}
+	}
+
+	// Validate Retention configuration
+	if c.Retention.KeepFor == "" {
+		c.Retention.KeepFor = c.Lifecycle.EvidenceRetention // default
+	}
+	if err := validateRetentionPeriods("retention", c.Retention.KeepFor, c.Retention.ArchiveAfter); err != nil {
+		return err
+	}
+	for i, rule := range c.Retention.Rules {
+		if len(rule.Tasks) == 0 && len(rule.Categories) == 0 {
+			return fmt.Errorf("retention.rules[%d] must list tasks or categories", i)
+		}
+		if err := validateRetentionPeriods(fmt.Sprintf("retention.rules[%d]", i), rule.KeepFor, rule.ArchiveAfter); err != nil {
+			return err
+		}
+	}
+	holdNames := map[string]bool{}
+	for i, hold := range c.Retention.LegalHolds {
+		if hold.Name == "" || hold.Reason == "" {
+			return fmt.Errorf("retention.legal_holds[%d] needs a name and a reason", i)
+		}
+		if holdNames[hold.Name] {
+			return fmt.Errorf("retention.legal_holds has more than one hold named %s", hold.Name)
+		}
+		holdNames[hold.Name] = true
+		if hold.Since != "" {
+			if _, err := time.Parse("2006-01-02", hold.Since); err != nil {
+				return fmt.Errorf("retention.legal_holds %s since must be a date like 2025-12-31, got: %s", hold.Name, hold.Since)
+			}
+		}
+	}
+
 	// Validate Storage configuration
 	if c.Storage.DataDir == "" {
 		c.Storage.DataDir = "./data" // default
@@ -821,6 +2102,32 @@ func (c *Config) Validate() error {
 	if c.Storage.CacheDir == "" {
 		c.Storage.CacheDir = "./.cache" // default
 	}
+	if remote := c.Storage.Remote.URL; remote != "" {
+		if !strings.HasPrefix(remote, "s3://") && !strings.HasPrefix(remote, "gs://") {
+			return fmt.Errorf("storage.remote.url must be an s3:// or gs:// URL, got: %s", remote)
+		}
+		if c.Storage.Remote.Endpoint != "" && !strings.HasPrefix(remote, "s3://") {
+			return fmt.Errorf("storage.remote.endpoint only applies to s3:// URLs")
+		}
+	}
+	if encryption := c.Storage.Encryption; encryption.Key != "" && encryption.KMSKeyID != "" {
+		return fmt.Errorf("set only one of storage.encryption.key and storage.encryption.kms_key_id")
+	} else if encryption.Key != "" {
+		if key, err := base64.StdEncoding.DecodeString(encryption.Key); err != nil || len(key) != 32 {
+			return fmt.Errorf("storage.encryption.key must be a base64-encoded 32-byte key (see grctool storage keygen)")
+		}
+	}
+	if c.Storage.Dedup.MinSize < 0 {
+		return fmt.Errorf("storage.dedup.min_size must not be negative")
+	} else if c.Storage.Dedup.MinSize == 0 {
+		c.Storage.Dedup.MinSize = 4096
+	}
+	if email := c.Storage.Git.AuthorEmail; email != "" && (!strings.Contains(email, "@") || strings.ContainsAny(email, "<> ")) {
+		return fmt.Errorf("storage.git.author_email is not an email address: %s", email)
+	}
+	if branch := c.Storage.Git.Branch; strings.ContainsAny(branch, " ~^:?*[\\") || strings.Contains(branch, "..") {
+		return fmt.Errorf("storage.git.branch is not a valid branch name: %s", branch)
+	}
 
 	// Validate Logging configuration - use defaults if not configured
 	if len(c.Logging.Loggers) == 0 {
@@ -19,6 +19,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -377,3 +378,54 @@ func TestConfig_BackwardCompatible(t *testing.T) {
 		t.Errorf("Expected empty lifecycle cadence in backward-compatible config, got %q", cfg.Lifecycle.PolicyReviewCadence)
 	}
 }
+
+func TestConfig_DaemonConfig(t *testing.T) {
+	newConfig := func(sources ...WatchSourceConfig) *Config {
+		return &Config{
+			Tugboat: TugboatConfig{BaseURL: "https://test.com", OrgID: "123"},
+			Daemon:  DaemonConfig{Sources: sources},
+		}
+	}
+
+	cfg := newConfig(WatchSourceConfig{Name: "infra", Path: "/repos/infra", Include: []string{"*.tf"}, Tasks: []string{"ET-0001"}})
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Expected valid daemon config, got: %v", err)
+	}
+	if cfg.Daemon.PollInterval != 5*time.Minute {
+		t.Errorf("Expected default poll interval 5m, got %s", cfg.Daemon.PollInterval)
+	}
+
+	invalid := map[string]*Config{
+		"missing path":   newConfig(WatchSourceConfig{Name: "infra", Tasks: []string{"ET-0001"}}),
+		"missing tasks":  newConfig(WatchSourceConfig{Name: "infra", Path: "/repos/infra"}),
+		"duplicate name": newConfig(WatchSourceConfig{Name: "infra", Path: "/a", Tasks: []string{"ET-0001"}}, WatchSourceConfig{Name: "infra", Path: "/b", Tasks: []string{"ET-0002"}}),
+	}
+	for name, cfg := range invalid {
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
+
+func TestConfig_ScheduleTools(t *testing.T) {
+	newConfig := func(schedule ScheduleConfig) *Config {
+		return &Config{
+			Tugboat:   TugboatConfig{BaseURL: "https://test.com", OrgID: "123"},
+			Schedules: SchedulesConfig{Schedules: []ScheduleConfig{schedule}},
+		}
+	}
+
+	valid := newConfig(ScheduleConfig{Name: "weekly-github", Cron: "0 6 * * 1", Tasks: []string{"ET-0047"}, Tools: []string{"github-permissions"}})
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Expected schedule with tasks and tools to be valid, got: %v", err)
+	}
+
+	for name, schedule := range map[string]ScheduleConfig{
+		"tools without tasks": {Name: "weekly-github", Cron: "0 6 * * 1", Tools: []string{"github-permissions"}},
+		"tasks without tools": {Name: "weekly-github", Cron: "0 6 * * 1", Tasks: []string{"ET-0047"}},
+	} {
+		if err := newConfig(schedule).Validate(); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
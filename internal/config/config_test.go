@@ -178,6 +178,26 @@ func TestInterpolationConfig_flattenVariables(t *testing.T) {
 	}
 }
 
+func TestInterpolationConfig_ScopedVariables(t *testing.T) {
+	ic := &InterpolationConfig{
+		Frameworks: map[string]map[string]interface{}{
+			"SOC 2": {"audit": map[string]interface{}{"firm": "Prescient Assurance"}},
+		},
+		Tasks: map[string]map[string]interface{}{
+			"ET-0047": {"review.cadence": "monthly", "retention_years": 7},
+		},
+	}
+
+	frameworks := ic.GetFrameworkVariables()
+	if frameworks["SOC 2"]["audit.firm"] != "Prescient Assurance" {
+		t.Errorf("Expected nested framework variables to be flattened, got %v", frameworks)
+	}
+	tasks := ic.GetTaskVariables()
+	if tasks["ET-0047"]["review.cadence"] != "monthly" || tasks["ET-0047"]["retention_years"] != "7" {
+		t.Errorf("Expected task variables as strings, got %v", tasks)
+	}
+}
+
 func TestValidateInterpolationVariables(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -0,0 +1,143 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// EnvPrefix starts the name of every environment variable that sets a config key
+const EnvPrefix = "GRCTOOL"
+
+// ConfigEnvVar names the config file to use when --config is not given
+const ConfigEnvVar = "GRCTOOL_CONFIG"
+
+// CorrelationIDEnvVar gives a run its correlation ID, so a daemon or CI job
+// can tie together the grctool commands it starts
+const CorrelationIDEnvVar = "GRCTOOL_CORRELATION_ID"
+
+// envAliases are further variables that set a config key, checked after the
+// key's own
+var envAliases = map[string][]string{
+	"drata.api_key":            {"DRATA_API_KEY"},
+	"hyperproof.client_secret": {"HYPERPROOF_CLIENT_SECRET"},
+	"secureframe.api_key":      {"SECUREFRAME_API_KEY"},
+	"secureframe.api_secret":   {"SECUREFRAME_API_SECRET"},
+	"storage.data_dir":         {"GRCTOOL_DATA_DIR"},
+	"vanta.client_secret":      {"VANTA_CLIENT_SECRET"},
+}
+
+// EnvVar returns the environment variable that sets a config key: the key in
+// upper case with dots as underscores, after GRCTOOL_. For example
+// tugboat.org_id is GRCTOOL_TUGBOAT_ORG_ID.
+func EnvVar(key string) string {
+	return EnvPrefix + "_" + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+// EnvKeys returns every config key an environment variable can set, sorted.
+// Lists are given comma-separated; maps, such as tugboat.collector_urls,
+// vanta.document_ids, drata.control_ids, secureframe.test_ids,
+// hyperproof.label_ids, delivery.urls and profiles, and lists of settings
+// groups can only be set in the config file.
+func EnvKeys() []string {
+	var keys []string
+	collectEnvKeys(reflect.TypeOf(Config{}), "", &keys)
+	sort.Strings(keys)
+	return keys
+}
+
+func collectEnvKeys(t reflect.Type, prefix string, keys *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, opts := keyName(field)
+		if name == "-" {
+			continue
+		}
+		key := prefix + name
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		switch {
+		case opts == "squash" && fieldType.Kind() == reflect.Struct:
+			collectEnvKeys(fieldType, prefix, keys)
+		case fieldType == reflect.TypeOf(time.Duration(0)):
+			*keys = append(*keys, key)
+		case fieldType.Kind() == reflect.Struct:
+			collectEnvKeys(fieldType, key+".", keys)
+		case fieldType.Kind() == reflect.Map, fieldType.Kind() == reflect.Interface:
+			continue
+		case fieldType.Kind() == reflect.Slice && fieldType.Elem().Kind() == reflect.Struct:
+			continue
+		default:
+			*keys = append(*keys, key)
+		}
+	}
+}
+
+// keyName returns the config key of a struct field and its mapstructure
+// options, such as squash
+func keyName(field reflect.StructField) (string, string) {
+	name, opts, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+	return name, opts
+}
+
+// BindEnv lets an environment variable set each config key, overriding the
+// config file and the active profile, so CI can configure grctool without
+// writing secrets to a file
+func BindEnv() error {
+	for _, key := range EnvKeys() {
+		if err := viper.BindEnv(append([]string{key}, envVars(key)...)...); err != nil {
+			return fmt.Errorf("failed to bind %s: %w", EnvVar(key), err)
+		}
+	}
+	return nil
+}
+
+// EnvOverrides returns the environment variables that are setting config
+// keys, sorted
+func EnvOverrides() []string {
+	var vars []string
+	for _, key := range EnvKeys() {
+		for _, name := range envVars(key) {
+			if _, ok := os.LookupEnv(name); ok {
+				vars = append(vars, name)
+			}
+		}
+	}
+	sort.Strings(vars)
+	return vars
+}
+
+// envVars returns the variables that set a key, in order of precedence
+func envVars(key string) []string {
+	return append([]string{EnvVar(key)}, envAliases[key]...)
+}
@@ -0,0 +1,113 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvVar(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		key  string
+		want string
+	}{
+		"top-level":  {key: "profile", want: "GRCTOOL_PROFILE"},
+		"nested":     {key: "tugboat.org_id", want: "GRCTOOL_TUGBOAT_ORG_ID"},
+		"tool":       {key: "evidence.tools.github.repository", want: "GRCTOOL_EVIDENCE_TOOLS_GITHUB_REPOSITORY"},
+		"flag style": {key: "log-level", want: "GRCTOOL_LOG_LEVEL"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, EnvVar(tc.key))
+		})
+	}
+}
+
+func TestEnvKeys(t *testing.T) {
+	t.Parallel()
+
+	keys := EnvKeys()
+	for _, key := range []string{
+		"tugboat.base_url",
+		"tugboat.timeout",
+		"tugboat.password",
+		"storage.data_dir",
+		"storage.paths.docs",
+		"evidence.tools.terraform.scan_paths",
+		"evidence.tools.google_docs.credentials_file",
+		"auth.github.token",
+		"notifications.email.password",
+	} {
+		assert.Contains(t, keys, key)
+	}
+	for _, key := range []string{"tugboat.collector_urls", "profiles", "logging.loggers", "interpolation.variables"} {
+		assert.NotContains(t, keys, key, "maps are set in the config file")
+	}
+	assert.IsIncreasing(t, keys)
+}
+
+func TestBindEnv_OverridesConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	dataDir := filepath.Join(dir, "from-env")
+	path := filepath.Join(dir, ".grctool.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(profilesConfig), 0644))
+
+	t.Setenv("GRCTOOL_TUGBOAT_ORG_ID", "999")
+	t.Setenv("GRCTOOL_TUGBOAT_TIMEOUT", "45s")
+	t.Setenv("GRCTOOL_DATA_DIR", dataDir)
+	t.Setenv("GRCTOOL_EVIDENCE_TOOLS_TERRAFORM_SCAN_PATHS", "terraform/**/*.tf,modules/**/*.tf")
+	t.Setenv("GRCTOOL_EVIDENCE_TOOLS_GITHUB_ENABLED", "true")
+	t.Setenv("GRCTOOL_EVIDENCE_TOOLS_GITHUB_REPOSITORY", "acme/platform")
+
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.SetConfigFile(path)
+	require.NoError(t, viper.ReadInConfig())
+	require.NoError(t, BindEnv())
+
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, "client-a", cfg.Profile)
+	assert.Equal(t, "999", cfg.Tugboat.OrgID, "the environment overrides the active profile")
+	assert.Equal(t, "https://api.example.com", cfg.Tugboat.BaseURL)
+	assert.Equal(t, 45*time.Second, cfg.Tugboat.Timeout)
+	assert.Equal(t, dataDir, cfg.Storage.DataDir)
+	assert.Equal(t, []string{filepath.Join(dir, "terraform/**/*.tf"), filepath.Join(dir, "modules/**/*.tf")},
+		cfg.Evidence.Tools.Terraform.ScanPaths, "relative paths resolve against the config file")
+	assert.True(t, cfg.Evidence.Tools.GitHub.Enabled)
+	assert.Equal(t, "acme/platform", cfg.Evidence.Tools.GitHub.Repository)
+	assert.Equal(t, "https://collector.example.com/shared", cfg.Tugboat.CollectorURLs["ET-0001"])
+}
+
+func TestBindEnv_WithoutConfigFile(t *testing.T) {
+	dataDir := t.TempDir()
+	t.Setenv("GRCTOOL_TUGBOAT_BASE_URL", "https://api.example.com")
+	t.Setenv("GRCTOOL_TUGBOAT_BEARER_TOKEN", "token-from-ci")
+	t.Setenv("GRCTOOL_STORAGE_DATA_DIR", dataDir)
+	t.Setenv("GRCTOOL_DATA_DIR", "/ignored")
+
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	require.NoError(t, BindEnv())
+
+	cfg, err := LoadWithoutValidation()
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://api.example.com", cfg.Tugboat.BaseURL)
+	assert.Equal(t, "token-from-ci", cfg.Tugboat.BearerToken)
+	assert.Equal(t, dataDir, cfg.Storage.DataDir, "a key's own variable wins over its alias")
+	assert.Equal(t, []string{"GRCTOOL_DATA_DIR", "GRCTOOL_STORAGE_DATA_DIR", "GRCTOOL_TUGBOAT_BASE_URL", "GRCTOOL_TUGBOAT_BEARER_TOKEN"}, EnvOverrides())
+}
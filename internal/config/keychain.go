@@ -0,0 +1,117 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/grctool/grctool/internal/keychain"
+)
+
+// TugboatSession is the Tugboat browser session grctool auth login stores in
+// the keychain
+type TugboatSession struct {
+	CookieHeader string `json:"cookie_header,omitempty"`
+	BearerToken  string `json:"bearer_token,omitempty"`
+	AuthExpires  string `json:"auth_expires,omitempty"`
+}
+
+// keychainProvider resolves keychain://<account>[#field] references. A field
+// is read from a credential stored as JSON, such as the Tugboat session.
+type keychainProvider struct{}
+
+func (keychainProvider) Resolve(ctx context.Context, ref SecretRef) (string, error) {
+	secret, err := keychain.Default.Get(ref.Path)
+	if err != nil {
+		return "", fmt.Errorf("keychain account %s: %w", ref.Path, err)
+	}
+	if ref.Field == "" {
+		return secret, nil
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(secret), &data); err != nil {
+		return "", fmt.Errorf("keychain account %s is not a JSON object, so has no field %q", ref.Path, ref.Field)
+	}
+	return secretField(data, ref)
+}
+
+// keychainSecret returns a provider's credential for the active profile, or
+// "" when none is stored or the keychain is unavailable
+func keychainSecret(provider, profile string) string {
+	secret, err := keychain.Lookup(keychain.Default, provider, profile)
+	if err != nil {
+		return ""
+	}
+	return secret
+}
+
+// ApplyKeychainCredentials fills credentials missing from the configuration
+// with those grctool auth login stored in the OS keychain. Configured values,
+// including environment variables, always win.
+func ApplyKeychainCredentials(cfg *Config) {
+	if cfg.Tugboat.CookieHeader == "" && cfg.Tugboat.BearerToken == "" {
+		if stored := keychainSecret(keychain.AccountTugboat, cfg.Profile); stored != "" {
+			var session TugboatSession
+			if err := json.Unmarshal([]byte(stored), &session); err == nil {
+				cfg.Tugboat.CookieHeader = session.CookieHeader
+				cfg.Tugboat.BearerToken = session.BearerToken
+				if cfg.Tugboat.AuthExpires == "" {
+					cfg.Tugboat.AuthExpires = session.AuthExpires
+				}
+			}
+		}
+	}
+
+	if cfg.Auth.GitHub.Token == "" && cfg.Evidence.Tools.GitHub.APIToken == "" {
+		if token := keychainSecret(keychain.AccountGitHub, cfg.Profile); token != "" {
+			cfg.Auth.GitHub.Token = token
+			cfg.Evidence.Tools.GitHub.APIToken = token
+		}
+	}
+
+	google := &cfg.Evidence.Tools.GoogleDocs
+	if google.CredentialsFile == "" && !google.UseDefaultCredentials {
+		google.CredentialsJSON = keychainSecret(keychain.AccountGoogle, cfg.Profile)
+	}
+
+	encryption := &cfg.Storage.Encryption
+	if encryption.Enabled && encryption.Key == "" && encryption.KMSKeyID == "" {
+		encryption.Key = keychainSecret(keychain.AccountEvidenceKey, cfg.Profile)
+	}
+}
+
+// StoreTugboatSession saves a Tugboat session in the keychain under the
+// account of the given profile
+func StoreTugboatSession(profile string, session TugboatSession) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	return keychain.Default.Set(keychain.Account(keychain.AccountTugboat, profile), string(data))
+}
+
+// DeleteKeychainCredential removes a provider's credential for a profile,
+// reporting false when none was stored
+func DeleteKeychainCredential(provider, profile string) (bool, error) {
+	err := keychain.Default.Delete(keychain.Account(provider, profile))
+	if errors.Is(err, keychain.ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
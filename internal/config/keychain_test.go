@@ -0,0 +1,92 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grctool/grctool/internal/keychain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useMemoryKeychain replaces the OS keychain for the rest of the test
+func useMemoryKeychain(t *testing.T) *keychain.MemoryStore {
+	t.Helper()
+	store := keychain.NewMemoryStore()
+	old := keychain.Default
+	keychain.Default = store
+	t.Cleanup(func() { keychain.Default = old })
+	return store
+}
+
+func TestApplyKeychainCredentials(t *testing.T) {
+	store := useMemoryKeychain(t)
+	require.NoError(t, StoreTugboatSession("", TugboatSession{CookieHeader: "session=global", BearerToken: "global-bearer"}))
+	require.NoError(t, StoreTugboatSession("acme", TugboatSession{CookieHeader: "session=acme", AuthExpires: "2030-01-01T00:00:00Z"}))
+	require.NoError(t, store.Set(keychain.AccountGitHub, "ghp_stored"))
+	require.NoError(t, store.Set(keychain.AccountGoogle, `{"type":"service_account"}`))
+
+	t.Run("fills missing credentials", func(t *testing.T) {
+		cfg := &Config{}
+		ApplyKeychainCredentials(cfg)
+		assert.Equal(t, "session=global", cfg.Tugboat.CookieHeader)
+		assert.Equal(t, "global-bearer", cfg.Tugboat.BearerToken)
+		assert.Equal(t, "ghp_stored", cfg.Auth.GitHub.Token)
+		assert.Equal(t, "ghp_stored", cfg.Evidence.Tools.GitHub.APIToken)
+		assert.Equal(t, `{"type":"service_account"}`, cfg.Evidence.Tools.GoogleDocs.CredentialsJSON)
+	})
+
+	t.Run("profile session", func(t *testing.T) {
+		cfg := &Config{Profile: "acme"}
+		ApplyKeychainCredentials(cfg)
+		assert.Equal(t, "session=acme", cfg.Tugboat.CookieHeader)
+		assert.Empty(t, cfg.Tugboat.BearerToken)
+		assert.Equal(t, "2030-01-01T00:00:00Z", cfg.Tugboat.AuthExpires)
+		assert.Equal(t, "ghp_stored", cfg.Auth.GitHub.Token, "profiles fall back to credentials stored without one")
+	})
+
+	t.Run("configured credentials win", func(t *testing.T) {
+		cfg := &Config{
+			Tugboat: TugboatConfig{BearerToken: "configured"},
+			Auth:    AuthConfig{GitHub: GitHubAuthConfig{Token: "ghp_configured"}},
+			Evidence: EvidenceConfig{Tools: ToolsConfig{GoogleDocs: GoogleDocsToolConfig{
+				UseDefaultCredentials: true,
+			}}},
+		}
+		ApplyKeychainCredentials(cfg)
+		assert.Empty(t, cfg.Tugboat.CookieHeader)
+		assert.Equal(t, "ghp_configured", cfg.Auth.GitHub.Token)
+		assert.Empty(t, cfg.Evidence.Tools.GitHub.APIToken)
+		assert.Empty(t, cfg.Evidence.Tools.GoogleDocs.CredentialsJSON)
+	})
+}
+
+func TestKeychainSecretReference(t *testing.T) {
+	useMemoryKeychain(t)
+	require.NoError(t, StoreTugboatSession("", TugboatSession{BearerToken: "stored-bearer"}))
+
+	cfg := &Config{Auth: AuthConfig{Tugboat: TugboatAuthConfig{BearerToken: "keychain://tugboat#bearer_token"}}}
+	require.NoError(t, resolveSecrets(context.Background(), cfg))
+	assert.Equal(t, "stored-bearer", cfg.Auth.Tugboat.BearerToken)
+
+	broken := &Config{Tugboat: TugboatConfig{Password: "keychain://missing"}}
+	err := resolveSecrets(context.Background(), broken)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, keychain.ErrNotFound)
+}
+
+func TestDeleteKeychainCredential(t *testing.T) {
+	useMemoryKeychain(t)
+	require.NoError(t, StoreTugboatSession("acme", TugboatSession{BearerToken: "b"}))
+
+	removed, err := DeleteKeychainCredential(keychain.AccountTugboat, "acme")
+	require.NoError(t, err)
+	assert.True(t, removed)
+
+	removed, err = DeleteKeychainCredential(keychain.AccountTugboat, "acme")
+	require.NoError(t, err)
+	assert.False(t, removed)
+}
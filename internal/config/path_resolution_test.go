@@ -95,6 +95,10 @@ func TestResolveConfigPaths(t *testing.T) {
 						OutputDir:       "./evidence/generated",
 						PromptDir:       "./evidence/prompts",
 						SummaryCacheDir: "./.cache/summaries",
+						Templates: EvidenceTemplateMappings{
+							Tasks:      map[string]string{"ET-0047": "./templates/et-0047.md"},
+							Categories: map[string]string{"Vendor": "/srv/templates/vendor.md"},
+						},
 					},
 					Terraform: TerraformConfig{
 						AtmosPath: "../terraform/atmos",
@@ -115,6 +119,10 @@ func TestResolveConfigPaths(t *testing.T) {
 						OutputDir:       "/project/evidence/generated",
 						PromptDir:       "/project/evidence/prompts",
 						SummaryCacheDir: "/project/.cache/summaries",
+						Templates: EvidenceTemplateMappings{
+							Tasks:      map[string]string{"ET-0047": "/project/templates/et-0047.md"},
+							Categories: map[string]string{"Vendor": "/srv/templates/vendor.md"},
+						},
 					},
 					Terraform: TerraformConfig{
 						AtmosPath: "/terraform/atmos",
@@ -0,0 +1,79 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// ProfileEnvVar selects a profile, overriding the config file's profile setting
+const ProfileEnvVar = "GRCTOOL_PROFILE"
+
+// ProfileConfig holds the settings of one Tugboat organization. A profile's
+// settings are merged over the top-level configuration when it is active, so
+// it only lists what differs, typically credentials, data directories and
+// collector URLs.
+type ProfileConfig struct {
+	Description   string              `mapstructure:"description" yaml:"description,omitempty"`
+	Tugboat       TugboatConfig       `mapstructure:"tugboat" yaml:"tugboat,omitempty"`
+	Storage       StorageConfig       `mapstructure:"storage" yaml:"storage,omitempty"`
+	Auth          AuthConfig          `mapstructure:"auth" yaml:"auth,omitempty"`
+	Interpolation InterpolationConfig `mapstructure:"interpolation" yaml:"interpolation,omitempty"`
+}
+
+// ActiveProfile returns the name of the selected profile, from --profile,
+// GRCTOOL_PROFILE or the config file's profile setting, or "" for none
+func ActiveProfile() string {
+	return strings.ToLower(strings.TrimSpace(viper.GetString("profile")))
+}
+
+// ProfileNames returns the names of the profiles in the config file, sorted.
+// Names are case-insensitive and returned in lower case.
+func ProfileNames() []string {
+	var names []string
+	for name := range viper.GetStringMap("profiles") {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ApplyProfile merges the active profile's settings over the top-level
+// configuration. It does nothing when no profile is selected, and fails if
+// the selected profile is not defined.
+func ApplyProfile() error {
+	name := ActiveProfile()
+	if name == "" {
+		return nil
+	}
+
+	settings, ok := viper.Get("profiles." + name).(map[string]interface{})
+	if !ok {
+		if names := ProfileNames(); len(names) > 0 {
+			return fmt.Errorf("profile %q is not defined; available profiles: %s", name, strings.Join(names, ", "))
+		}
+		return fmt.Errorf("profile %q is not defined: add it under profiles in the config file", name)
+	}
+
+	if err := viper.MergeConfigMap(settings); err != nil {
+		return fmt.Errorf("failed to apply profile %q: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const profilesConfig = `tugboat:
+  base_url: "https://api.example.com"
+  org_id: "100"
+  collector_urls:
+    ET-0001: "https://collector.example.com/shared"
+storage:
+  data_dir: "./data"
+profile: client-a
+profiles:
+  client-a:
+    description: Client A
+    tugboat:
+      org_id: "111"
+    storage:
+      data_dir: "./clients/a"
+  Client-B:
+    tugboat:
+      org_id: "222"
+      password: ${CLIENT_B_PASSWORD}
+      collector_urls:
+        ET-0002: "https://collector.example.com/b"
+    storage:
+      data_dir: "./clients/b"
+`
+
+func loadProfilesConfig(t *testing.T, profile string) (*Config, string, error) {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".grctool.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(profilesConfig), 0644))
+
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.SetConfigFile(path)
+	require.NoError(t, viper.ReadInConfig())
+	if profile != "" {
+		viper.Set("profile", profile)
+	}
+
+	cfg, err := Load()
+	return cfg, dir, err
+}
+
+func TestApplyProfile(t *testing.T) {
+	t.Setenv("CLIENT_B_PASSWORD", "secret-b")
+
+	t.Run("config default", func(t *testing.T) {
+		cfg, dir, err := loadProfilesConfig(t, "")
+		require.NoError(t, err)
+		assert.Equal(t, "client-a", cfg.Profile)
+		assert.Equal(t, "111", cfg.Tugboat.OrgID)
+		assert.Equal(t, "https://api.example.com", cfg.Tugboat.BaseURL)
+		assert.Equal(t, filepath.Join(dir, "clients", "a"), cfg.Storage.DataDir)
+		assert.Equal(t, "Client A", cfg.Profiles["client-a"].Description)
+	})
+
+	t.Run("selected profile merges nested settings", func(t *testing.T) {
+		cfg, dir, err := loadProfilesConfig(t, "CLIENT-B")
+		require.NoError(t, err)
+		assert.Equal(t, "client-b", cfg.Profile)
+		assert.Equal(t, "222", cfg.Tugboat.OrgID)
+		assert.Equal(t, "secret-b", cfg.Tugboat.Password)
+		assert.Equal(t, filepath.Join(dir, "clients", "b"), cfg.Storage.DataDir)
+		assert.Equal(t, "https://collector.example.com/b", cfg.Tugboat.CollectorURLs["ET-0002"])
+		assert.Equal(t, "https://collector.example.com/shared", cfg.Tugboat.CollectorURLs["ET-0001"])
+	})
+
+	t.Run("undefined profile", func(t *testing.T) {
+		_, _, err := loadProfilesConfig(t, "client-c")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `profile "client-c" is not defined; available profiles: client-a, client-b`)
+	})
+}
+
+func TestProfileNames_None(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	assert.Empty(t, ProfileNames())
+	assert.Empty(t, ActiveProfile())
+	assert.NoError(t, ApplyProfile())
+}
@@ -0,0 +1,176 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// vaultProvider reads vault://<mount>/<path>#<field> from HashiCorp Vault's
+// key/value engine, using VAULT_ADDR, VAULT_TOKEN (or ~/.vault-token) and
+// VAULT_NAMESPACE like the vault CLI. Version 2 mounts are tried first;
+// ?kv=1 reads a version 1 mount directly.
+type vaultProvider struct {
+	client *http.Client
+}
+
+// errVaultNotFound is returned for a path Vault has no secret at
+var errVaultNotFound = errors.New("not found")
+
+func (p *vaultProvider) Resolve(ctx context.Context, ref SecretRef) (string, error) {
+	addr := strings.TrimSuffix(os.Getenv("VAULT_ADDR"), "/")
+	if addr == "" {
+		return "", fmt.Errorf("VAULT_ADDR is not set")
+	}
+	token, err := vaultToken()
+	if err != nil {
+		return "", err
+	}
+
+	mount, rest, _ := strings.Cut(ref.Path, "/")
+	if ref.Params.Get("kv") != "1" && rest != "" {
+		data, err := p.read(ctx, addr, token, mount+"/data/"+rest)
+		if err == nil {
+			inner, ok := data["data"].(map[string]interface{})
+			if !ok {
+				return "", fmt.Errorf("vault secret %s has no data", ref.Path)
+			}
+			return secretField(inner, ref)
+		}
+		if !errors.Is(err, errVaultNotFound) {
+			return "", err
+		}
+	}
+
+	data, err := p.read(ctx, addr, token, ref.Path)
+	if err != nil {
+		return "", err
+	}
+	return secretField(data, ref)
+}
+
+// read returns the data of the secret at a Vault API path
+func (p *vaultProvider) read(ctx context.Context, addr, token, path string) (map[string]interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, addr+"/v1/"+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if ns := os.Getenv("VAULT_NAMESPACE"); ns != "" {
+		req.Header.Set("X-Vault-Namespace", ns)
+	}
+
+	client := p.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("vault secret %s: %w", path, errVaultNotFound)
+	case resp.StatusCode == http.StatusForbidden:
+		return nil, fmt.Errorf("vault denied access to %s: check VAULT_TOKEN and its policies", path)
+	case resp.StatusCode >= 400:
+		return nil, fmt.Errorf("vault returned status %d for %s", resp.StatusCode, path)
+	}
+
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode vault response: %w", err)
+	}
+	return body.Data, nil
+}
+
+func vaultToken() (string, error) {
+	if token := os.Getenv("VAULT_TOKEN"); token != "" {
+		return token, nil
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		if data, err := os.ReadFile(filepath.Join(home, ".vault-token")); err == nil {
+			return strings.TrimSpace(string(data)), nil
+		}
+	}
+	return "", fmt.Errorf("VAULT_TOKEN is not set: export it or run 'vault login'")
+}
+
+// awsSecretsManagerProvider reads awssm://<secret-id>#<field> from AWS
+// Secrets Manager through the aws CLI, so its credential chain, profiles and
+// SSO sessions apply. The secret ID may be a name or an ARN; ?region= and
+// ?version_stage= select the region and version. With a field, the secret
+// string is read as a JSON object.
+type awsSecretsManagerProvider struct {
+	run func(ctx context.Context, name string, args ...string) ([]byte, error)
+}
+
+func (p *awsSecretsManagerProvider) Resolve(ctx context.Context, ref SecretRef) (string, error) {
+	args := []string{"secretsmanager", "get-secret-value",
+		"--secret-id", ref.Path,
+		"--query", "SecretString",
+		"--output", "text",
+	}
+	if region := ref.Params.Get("region"); region != "" {
+		args = append(args, "--region", region)
+	}
+	if stage := ref.Params.Get("version_stage"); stage != "" {
+		args = append(args, "--version-stage", stage)
+	}
+
+	out, err := p.run(ctx, "aws", args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to read AWS secret %s: %w", ref.Path, err)
+	}
+	secret := strings.TrimSuffix(string(out), "\n")
+	if ref.Field == "" {
+		return secret, nil
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(secret), &data); err != nil {
+		return "", fmt.Errorf("AWS secret %s is not a JSON object, so has no field %q", ref.Path, ref.Field)
+	}
+	return secretField(data, ref)
+}
+
+// runCommand runs a command, returning its output or an error with its stderr
+func runCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,227 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// secretTimeout bounds the resolution of one secret reference
+const secretTimeout = 30 * time.Second
+
+// SecretRef is a config value that names a secret in an external store, such
+// as vault://secret/tugboat#password: the store's scheme, the secret's path,
+// an optional field within the secret and optional query parameters
+type SecretRef struct {
+	Scheme string
+	Path   string
+	Field  string
+	Params url.Values
+}
+
+// SecretProvider reads secrets from one store
+type SecretProvider interface {
+	Resolve(ctx context.Context, ref SecretRef) (string, error)
+}
+
+var (
+	secretsMu       sync.Mutex
+	secretProviders = map[string]SecretProvider{
+		"vault":    &vaultProvider{},
+		"awssm":    &awsSecretsManagerProvider{run: runCommand},
+		"keychain": keychainProvider{},
+	}
+
+	// secretCache holds resolved secrets for the life of the process, as the
+	// configuration is loaded many times per command
+	secretCache = map[string]string{}
+)
+
+// RegisterSecretProvider makes config values starting with scheme:// resolve
+// through provider, replacing any provider registered for the scheme
+func RegisterSecretProvider(scheme string, provider SecretProvider) {
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	secretProviders[scheme] = provider
+}
+
+// ParseSecretRef parses value as a secret reference. It reports false for
+// values whose scheme has no registered provider, which are used as given.
+func ParseSecretRef(value string) (SecretRef, bool) {
+	scheme, rest, ok := strings.Cut(value, "://")
+	if !ok {
+		return SecretRef{}, false
+	}
+	secretsMu.Lock()
+	_, registered := secretProviders[scheme]
+	secretsMu.Unlock()
+	if !registered {
+		return SecretRef{}, false
+	}
+
+	ref := SecretRef{Scheme: scheme}
+	if i := strings.LastIndex(rest, "#"); i >= 0 {
+		rest, ref.Field = rest[:i], rest[i+1:]
+	}
+	rest, query, _ := strings.Cut(rest, "?")
+	ref.Path = rest
+	if query != "" {
+		ref.Params, _ = url.ParseQuery(query)
+	}
+	return ref, true
+}
+
+// ResolveSecret returns the value of a secret reference, or value unchanged
+// when it is not one
+func ResolveSecret(ctx context.Context, value string) (string, error) {
+	ref, ok := ParseSecretRef(value)
+	if !ok {
+		return value, nil
+	}
+	if ref.Path == "" {
+		return "", fmt.Errorf("secret reference %s has no path", value)
+	}
+
+	secretsMu.Lock()
+	provider := secretProviders[ref.Scheme]
+	cached, found := secretCache[value]
+	secretsMu.Unlock()
+	if found {
+		return cached, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, secretTimeout)
+	defer cancel()
+	secret, err := provider.Resolve(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+
+	secretsMu.Lock()
+	secretCache[value] = secret
+	secretsMu.Unlock()
+	return secret, nil
+}
+
+// resolveSecrets replaces each secret reference in the configuration with
+// the secret's value. Profiles are skipped, as the active one has already
+// been merged into the rest of the configuration.
+func resolveSecrets(ctx context.Context, cfg *Config) error {
+	return resolveSecretValues(ctx, reflect.ValueOf(cfg).Elem(), "")
+}
+
+func resolveSecretValues(ctx context.Context, v reflect.Value, key string) error {
+	switch v.Kind() {
+	case reflect.String:
+		secret, err := ResolveSecret(ctx, v.String())
+		if err != nil {
+			return fmt.Errorf("failed to resolve secret for %s: %w", key, err)
+		}
+		v.SetString(secret)
+	case reflect.Pointer:
+		if !v.IsNil() {
+			return resolveSecretValues(ctx, v.Elem(), key)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, opts := keyName(field)
+			if name == "-" || (name == "profiles" && key == "") {
+				continue
+			}
+			fieldKey := joinKey(key, name)
+			if opts == "squash" {
+				fieldKey = key
+			}
+			if err := resolveSecretValues(ctx, v.Field(i), fieldKey); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if err := resolveSecretValues(ctx, v.Index(i), fmt.Sprintf("%s[%d]", key, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if v.Type().Elem().Kind() != reflect.String {
+			return nil
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			secret, err := ResolveSecret(ctx, iter.Value().String())
+			if err != nil {
+				return fmt.Errorf("failed to resolve secret for %s: %w", joinKey(key, iter.Key().String()), err)
+			}
+			v.SetMapIndex(iter.Key(), reflect.ValueOf(secret).Convert(v.Type().Elem()))
+		}
+	}
+	return nil
+}
+
+func joinKey(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// secretField returns a field of a secret holding several values. Without a
+// field, a secret with exactly one value gives that value.
+func secretField(data map[string]interface{}, ref SecretRef) (string, error) {
+	field := ref.Field
+	if field == "" {
+		if len(data) != 1 {
+			keys := make([]string, 0, len(data))
+			for k := range data {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			return "", fmt.Errorf("secret %s has several fields (%s): add #<field> to the reference", ref.Path, strings.Join(keys, ", "))
+		}
+		for k := range data {
+			field = k
+		}
+	}
+
+	value, ok := data[field]
+	if !ok {
+		return "", fmt.Errorf("secret %s has no field %q", ref.Path, field)
+	}
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case nil:
+		return "", nil
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(encoded), nil
+	}
+}
@@ -0,0 +1,301 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSecrets is a secret store of path#field -> value that counts lookups
+type fakeSecrets struct {
+	values map[string]string
+	calls  int
+}
+
+func (f *fakeSecrets) Resolve(ctx context.Context, ref SecretRef) (string, error) {
+	f.calls++
+	value, ok := f.values[ref.Path+"#"+ref.Field]
+	if !ok {
+		return "", fmt.Errorf("secret %s not found", ref.Path)
+	}
+	return value, nil
+}
+
+// registerFakeSecrets registers a fake store under a scheme of its own,
+// removing it and its cached secrets when the test ends
+func registerFakeSecrets(t *testing.T, scheme string, values map[string]string) *fakeSecrets {
+	t.Helper()
+	fake := &fakeSecrets{values: values}
+	RegisterSecretProvider(scheme, fake)
+	t.Cleanup(func() {
+		secretsMu.Lock()
+		defer secretsMu.Unlock()
+		delete(secretProviders, scheme)
+		for key := range secretCache {
+			if prefix, _, _ := strings.Cut(key, "://"); prefix == scheme {
+				delete(secretCache, key)
+			}
+		}
+	})
+	return fake
+}
+
+func TestParseSecretRef(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value string
+		want  SecretRef
+		ok    bool
+	}{
+		"vault with field": {
+			value: "vault://secret/tugboat#password",
+			want:  SecretRef{Scheme: "vault", Path: "secret/tugboat", Field: "password"},
+			ok:    true,
+		},
+		"aws ARN with region": {
+			value: "awssm://arn:aws:secretsmanager:us-east-1:123456789012:secret:grctool-AbCdEf?region=us-east-1#password",
+			want: SecretRef{
+				Scheme: "awssm",
+				Path:   "arn:aws:secretsmanager:us-east-1:123456789012:secret:grctool-AbCdEf",
+				Field:  "password",
+				Params: map[string][]string{"region": {"us-east-1"}},
+			},
+			ok: true,
+		},
+		"aws name without field": {
+			value: "awssm://prod/tugboat-password",
+			want:  SecretRef{Scheme: "awssm", Path: "prod/tugboat-password"},
+			ok:    true,
+		},
+		"URL":         {value: "https://api-my.tugboatlogic.com"},
+		"env var":     {value: "${TUGBOAT_PASSWORD}"},
+		"plain value": {value: "hunter2"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ref, ok := ParseSecretRef(tc.value)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.want, ref)
+		})
+	}
+}
+
+func TestResolveSecrets(t *testing.T) {
+	fake := registerFakeSecrets(t, "fake-resolve", map[string]string{
+		"tugboat#password": "tugboat-secret",
+		"smtp#password":    "smtp-secret",
+		"adapters#key":     "adapter-secret",
+	})
+
+	cfg := &Config{
+		Tugboat: TugboatConfig{
+			BaseURL:  "https://api-my.tugboatlogic.com",
+			Password: "fake-resolve://tugboat#password",
+		},
+		Notifications: NotificationsConfig{Email: EmailNotifyConfig{Password: "fake-resolve://smtp#password"}},
+		Providers: ProvidersConfig{Providers: []ProviderConfig{{
+			Name:     "accountablehq",
+			Settings: map[string]string{"api_key": "fake-resolve://adapters#key", "region": "us"},
+		}}},
+		Profiles: map[string]ProfileConfig{
+			"other": {Tugboat: TugboatConfig{Password: "fake-resolve://missing#password"}},
+		},
+	}
+
+	require.NoError(t, resolveSecrets(context.Background(), cfg))
+	assert.Equal(t, "https://api-my.tugboatlogic.com", cfg.Tugboat.BaseURL)
+	assert.Equal(t, "tugboat-secret", cfg.Tugboat.Password)
+	assert.Equal(t, "smtp-secret", cfg.Notifications.Email.Password)
+	assert.Equal(t, map[string]string{"api_key": "adapter-secret", "region": "us"}, cfg.Providers.Providers[0].Settings)
+	assert.Equal(t, "fake-resolve://missing#password", cfg.Profiles["other"].Tugboat.Password, "inactive profiles are not resolved")
+	assert.Equal(t, 3, fake.calls)
+
+	again := &Config{Tugboat: TugboatConfig{Password: "fake-resolve://tugboat#password"}}
+	require.NoError(t, resolveSecrets(context.Background(), again))
+	assert.Equal(t, "tugboat-secret", again.Tugboat.Password)
+	assert.Equal(t, 3, fake.calls, "resolved secrets are cached")
+
+	broken := &Config{Auth: AuthConfig{GitHub: GitHubAuthConfig{Token: "fake-resolve://missing#token"}}}
+	err := resolveSecrets(context.Background(), broken)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "auth.github.token")
+}
+
+func TestLoad_ResolvesSecrets(t *testing.T) {
+	registerFakeSecrets(t, "fake-load", map[string]string{"tugboat#password": "from-store"})
+
+	path := filepath.Join(t.TempDir(), ".grctool.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`tugboat:
+  base_url: "https://api.example.com"
+  password: fake-load://tugboat#password
+storage:
+  data_dir: "./data"
+`), 0644))
+
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.SetConfigFile(path)
+	require.NoError(t, viper.ReadInConfig())
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "from-store", cfg.Tugboat.Password)
+
+	viper.Set("tugboat.password", "fake-load://unknown#password")
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tugboat.password")
+}
+
+func TestVaultProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "test-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/tugboat":
+			fmt.Fprint(w, `{"data":{"data":{"password":"kv2-secret","username":"svc"},"metadata":{"version":3}}}`)
+		case "/v1/kv/tugboat":
+			fmt.Fprint(w, `{"data":{"password":"kv1-secret"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("VAULT_ADDR", server.URL)
+	t.Setenv("VAULT_TOKEN", "test-token")
+	t.Setenv("VAULT_NAMESPACE", "")
+
+	tests := map[string]struct {
+		ref     SecretRef
+		want    string
+		wantErr string
+	}{
+		"kv version 2": {
+			ref:  SecretRef{Path: "secret/tugboat", Field: "password"},
+			want: "kv2-secret",
+		},
+		"kv version 1 fallback": {
+			ref:  SecretRef{Path: "kv/tugboat", Field: "password"},
+			want: "kv1-secret",
+		},
+		"kv version 1 single field": {
+			ref:  SecretRef{Path: "kv/tugboat", Params: map[string][]string{"kv": {"1"}}},
+			want: "kv1-secret",
+		},
+		"missing field": {
+			ref:     SecretRef{Path: "secret/tugboat", Field: "token"},
+			wantErr: `has no field "token"`,
+		},
+		"several fields": {
+			ref:     SecretRef{Path: "secret/tugboat"},
+			wantErr: "add #<field>",
+		},
+		"missing secret": {
+			ref:     SecretRef{Path: "secret/absent", Field: "password"},
+			wantErr: "not found",
+		},
+	}
+
+	provider := &vaultProvider{client: server.Client()}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := provider.Resolve(context.Background(), tc.ref)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("denied", func(t *testing.T) {
+		t.Setenv("VAULT_TOKEN", "wrong")
+		_, err := provider.Resolve(context.Background(), SecretRef{Path: "secret/tugboat", Field: "password"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "denied")
+	})
+}
+
+func TestAWSSecretsManagerProvider(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		ref      SecretRef
+		output   string
+		runErr   error
+		want     string
+		wantArgs []string
+		wantErr  string
+	}{
+		"plain secret": {
+			ref:      SecretRef{Path: "prod/tugboat-password"},
+			output:   "s3cret\n",
+			want:     "s3cret",
+			wantArgs: []string{"secretsmanager", "get-secret-value", "--secret-id", "prod/tugboat-password", "--query", "SecretString", "--output", "text"},
+		},
+		"JSON field with region and stage": {
+			ref: SecretRef{
+				Path:   "prod/tugboat",
+				Field:  "password",
+				Params: map[string][]string{"region": {"eu-west-1"}, "version_stage": {"AWSPREVIOUS"}},
+			},
+			output: `{"username":"svc","password":"json-secret"}` + "\n",
+			want:   "json-secret",
+			wantArgs: []string{"secretsmanager", "get-secret-value", "--secret-id", "prod/tugboat", "--query", "SecretString", "--output", "text",
+				"--region", "eu-west-1", "--version-stage", "AWSPREVIOUS"},
+		},
+		"field of a plain secret": {
+			ref:     SecretRef{Path: "prod/tugboat-password", Field: "password"},
+			output:  "s3cret\n",
+			wantErr: "not a JSON object",
+		},
+		"CLI failure": {
+			ref:     SecretRef{Path: "prod/absent"},
+			runErr:  errors.New("exit status 254: ResourceNotFoundException"),
+			wantErr: "ResourceNotFoundException",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var gotArgs []string
+			provider := &awsSecretsManagerProvider{run: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+				assert.Equal(t, "aws", name)
+				gotArgs = args
+				return []byte(tc.output), tc.runErr
+			}}
+
+			got, err := provider.Resolve(context.Background(), tc.ref)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.wantArgs, gotArgs)
+		})
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package daemon keeps evidence tool data fresh between audits. It polls
+// configured sources (directories and git checkouts), re-runs the tools of
+// the evidence tasks a source feeds when it changes, and runs due schedules
+// on every poll.
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/logger"
+	"gopkg.in/yaml.v3"
+)
+
+// Source is a watched directory or git checkout
+type Source struct {
+	Name    string
+	Path    string
+	Include []string // File patterns to watch; empty watches every file
+	Tasks   []string // Evidence tasks refreshed when the source changes
+	Tools   []string // Tools to re-run; empty lets RefreshFunc choose per task
+}
+
+// RefreshFunc re-runs tools for an evidence task and stores their output in
+// the task's current window
+type RefreshFunc func(ctx context.Context, taskRef string, tools []string) error
+
+// ScheduleFunc runs the schedules that are due at now
+type ScheduleFunc func(ctx context.Context, now time.Time) error
+
+// Options configure a Daemon
+type Options struct {
+	Interval  time.Duration
+	StateDir  string
+	Refresh   RefreshFunc
+	Schedules ScheduleFunc // Optional
+}
+
+// SourceState is the persisted state of one source
+type SourceState struct {
+	Fingerprint string    `yaml:"fingerprint"` // As of the last successful refresh
+	CheckedAt   time.Time `yaml:"checked_at"`
+	RefreshedAt time.Time `yaml:"refreshed_at,omitempty"`
+	LastError   string    `yaml:"last_error,omitempty"`
+}
+
+// State holds the persisted state of all sources
+type State struct {
+	Sources   map[string]*SourceState `yaml:"sources"`
+	UpdatedAt time.Time               `yaml:"updated_at"`
+}
+
+// SourceResult reports what one poll did with a source
+type SourceResult struct {
+	Name      string   `json:"name" yaml:"name"`
+	Changed   bool     `json:"changed" yaml:"changed"`
+	Refreshed []string `json:"refreshed,omitempty" yaml:"refreshed,omitempty"` // Task refs
+	Error     string   `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// PollResult reports one poll
+type PollResult struct {
+	Time          time.Time      `json:"time" yaml:"time"`
+	Sources       []SourceResult `json:"sources" yaml:"sources"`
+	ScheduleError string         `json:"schedule_error,omitempty" yaml:"schedule_error,omitempty"`
+}
+
+// stateFileName is stored next to the scheduler state
+const stateFileName = "daemon_state.yaml"
+
+// Daemon polls sources and refreshes the evidence tasks they feed
+type Daemon struct {
+	sources []Source
+	opts    Options
+	logger  logger.Logger
+}
+
+// New creates a daemon over sources
+func New(sources []Source, opts Options, log logger.Logger) *Daemon {
+	return &Daemon{
+		sources: sources,
+		opts:    opts,
+		logger:  log,
+	}
+}
+
+// Run polls every interval until ctx is cancelled, calling onPoll (when set)
+// after each poll
+func (d *Daemon) Run(ctx context.Context, onPoll func(PollResult)) error {
+	ticker := time.NewTicker(d.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := d.Poll(ctx, time.Now())
+		if err != nil {
+			return err
+		}
+		if onPoll != nil {
+			onPoll(*result)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll checks every source once, refreshes the tasks of changed sources and
+// runs due schedules. A source whose refresh fails keeps its previous
+// fingerprint, so the next poll retries it.
+func (d *Daemon) Poll(ctx context.Context, now time.Time) (*PollResult, error) {
+	state, err := d.LoadState()
+	if err != nil {
+		return nil, fmt.Errorf("loading state: %w", err)
+	}
+
+	result := &PollResult{Time: now}
+	refreshed := make(map[string]error) // Tasks fed by several sources refresh once per poll
+	for _, source := range d.sources {
+		if ctx.Err() != nil {
+			break
+		}
+		result.Sources = append(result.Sources, d.pollSource(ctx, source, state, now, refreshed))
+	}
+
+	state.UpdatedAt = now
+	if err := d.SaveState(state); err != nil {
+		return nil, err
+	}
+
+	if d.opts.Schedules != nil && ctx.Err() == nil {
+		if err := d.opts.Schedules(ctx, now); err != nil {
+			result.ScheduleError = err.Error()
+			d.logger.Error("failed to run due schedules", logger.String("error", err.Error()))
+		}
+	}
+	return result, nil
+}
+
+func (d *Daemon) pollSource(ctx context.Context, source Source, state *State, now time.Time, refreshed map[string]error) SourceResult {
+	result := SourceResult{Name: source.Name}
+	sourceState, ok := state.Sources[source.Name]
+	if !ok {
+		sourceState = &SourceState{}
+		state.Sources[source.Name] = sourceState
+	}
+	sourceState.CheckedAt = now
+
+	fingerprint, err := Fingerprint(source.Path, source.Include)
+	if err != nil {
+		sourceState.LastError = err.Error()
+		result.Error = err.Error()
+		d.logger.Warn("failed to check source",
+			logger.String("source", source.Name),
+			logger.String("error", err.Error()))
+		return result
+	}
+	if fingerprint == sourceState.Fingerprint {
+		sourceState.LastError = ""
+		return result
+	}
+
+	result.Changed = true
+	d.logger.Info("source changed",
+		logger.String("source", source.Name),
+		logger.String("fingerprint", fingerprint))
+
+	var failures []string
+	for _, taskRef := range source.Tasks {
+		key := taskRef + "\x00" + strings.Join(source.Tools, ",")
+		err, done := refreshed[key]
+		if !done {
+			err = d.opts.Refresh(ctx, taskRef, source.Tools)
+			refreshed[key] = err
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", taskRef, err))
+			d.logger.Error("failed to refresh evidence task",
+				logger.String("source", source.Name),
+				logger.String("task_ref", taskRef),
+				logger.String("error", err.Error()))
+			continue
+		}
+		result.Refreshed = append(result.Refreshed, taskRef)
+	}
+
+	if len(failures) > 0 {
+		sourceState.LastError = strings.Join(failures, "; ")
+		result.Error = sourceState.LastError
+		return result
+	}
+	sourceState.Fingerprint = fingerprint
+	sourceState.RefreshedAt = now
+	sourceState.LastError = ""
+	return result
+}
+
+// statePath returns the full path to the state file
+func (d *Daemon) statePath() string {
+	return filepath.Join(d.opts.StateDir, stateFileName)
+}
+
+// LoadState reads persisted state from disk. If the state file does not
+// exist, an empty state is returned.
+func (d *Daemon) LoadState() (*State, error) {
+	state := &State{Sources: make(map[string]*SourceState)}
+
+	data, err := os.ReadFile(d.statePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, fmt.Errorf("reading state file: %w", err)
+	}
+	if err := yaml.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("parsing state file: %w", err)
+	}
+	if state.Sources == nil {
+		state.Sources = make(map[string]*SourceState)
+	}
+	return state, nil
+}
+
+// SaveState writes state to disk atomically
+func (d *Daemon) SaveState(state *State) error {
+	if err := os.MkdirAll(d.opts.StateDir, 0o755); err != nil {
+		return fmt.Errorf("creating state directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshaling state: %w", err)
+	}
+
+	tmp := d.statePath() + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("writing temp state file: %w", err)
+	}
+	if err := os.Rename(tmp, d.statePath()); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("renaming state file: %w", err)
+	}
+	return nil
+}
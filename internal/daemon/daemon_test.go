@@ -0,0 +1,167 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package daemon
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type refreshCall struct {
+	taskRef string
+	tools   []string
+}
+
+func newTestDaemon(t *testing.T, sources []Source, refresh RefreshFunc) *Daemon {
+	t.Helper()
+	log, err := logger.NewTestLogger()
+	require.NoError(t, err)
+	return New(sources, Options{
+		Interval: time.Minute,
+		StateDir: filepath.Join(t.TempDir(), ".state"),
+		Refresh:  refresh,
+	}, log)
+}
+
+func TestDaemon_Poll(t *testing.T) {
+	t.Parallel()
+
+	terraformDir := t.TempDir()
+	policyDir := t.TempDir()
+	writeFile(t, filepath.Join(terraformDir, "main.tf"), "resource {}")
+	writeFile(t, filepath.Join(policyDir, "access.md"), "policy")
+
+	var calls []refreshCall
+	d := newTestDaemon(t, []Source{
+		{Name: "terraform", Path: terraformDir, Tasks: []string{"ET-0001", "ET-0002"}, Tools: []string{"terraform-scanner"}},
+		{Name: "policies", Path: policyDir, Tasks: []string{"ET-0002"}, Tools: []string{"terraform-scanner"}},
+	}, func(_ context.Context, taskRef string, tools []string) error {
+		calls = append(calls, refreshCall{taskRef, tools})
+		return nil
+	})
+	now := time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC)
+
+	// The first poll refreshes everything, each task once
+	result, err := d.Poll(context.Background(), now)
+	require.NoError(t, err)
+	require.Len(t, result.Sources, 2)
+	assert.True(t, result.Sources[0].Changed)
+	assert.Equal(t, []string{"ET-0001", "ET-0002"}, result.Sources[0].Refreshed)
+	assert.Equal(t, []string{"ET-0002"}, result.Sources[1].Refreshed)
+	assert.Equal(t, []refreshCall{
+		{"ET-0001", []string{"terraform-scanner"}},
+		{"ET-0002", []string{"terraform-scanner"}},
+	}, calls)
+
+	// Nothing changed
+	calls = nil
+	result, err = d.Poll(context.Background(), now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.False(t, result.Sources[0].Changed)
+	assert.False(t, result.Sources[1].Changed)
+	assert.Empty(t, calls)
+
+	// Only the changed source's tasks are refreshed
+	writeFile(t, filepath.Join(policyDir, "change.md"), "new policy")
+	result, err = d.Poll(context.Background(), now.Add(2*time.Minute))
+	require.NoError(t, err)
+	assert.False(t, result.Sources[0].Changed)
+	assert.True(t, result.Sources[1].Changed)
+	assert.Equal(t, []refreshCall{{"ET-0002", []string{"terraform-scanner"}}}, calls)
+
+	state, err := d.LoadState()
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(2*time.Minute), state.Sources["policies"].RefreshedAt)
+	assert.Equal(t, now, state.Sources["terraform"].RefreshedAt)
+	assert.Equal(t, now.Add(2*time.Minute), state.Sources["terraform"].CheckedAt)
+}
+
+func TestDaemon_PollRetriesFailedRefresh(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "main.tf"), "resource {}")
+
+	fail := true
+	attempts := 0
+	d := newTestDaemon(t, []Source{{Name: "terraform", Path: dir, Tasks: []string{"ET-0001"}}},
+		func(context.Context, string, []string) error {
+			attempts++
+			if fail {
+				return errors.New("tool failed")
+			}
+			return nil
+		})
+	now := time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC)
+
+	result, err := d.Poll(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, "ET-0001: tool failed", result.Sources[0].Error)
+	assert.Empty(t, result.Sources[0].Refreshed)
+
+	state, err := d.LoadState()
+	require.NoError(t, err)
+	assert.Empty(t, state.Sources["terraform"].Fingerprint)
+	assert.Equal(t, "ET-0001: tool failed", state.Sources["terraform"].LastError)
+
+	// The source has not changed, but the failed refresh is retried
+	fail = false
+	result, err = d.Poll(context.Background(), now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, result.Sources[0].Error)
+	assert.Equal(t, []string{"ET-0001"}, result.Sources[0].Refreshed)
+	assert.Equal(t, 2, attempts)
+
+	state, err = d.LoadState()
+	require.NoError(t, err)
+	assert.NotEmpty(t, state.Sources["terraform"].Fingerprint)
+	assert.Empty(t, state.Sources["terraform"].LastError)
+}
+
+func TestDaemon_PollSchedulesAndMissingSource(t *testing.T) {
+	t.Parallel()
+
+	d := newTestDaemon(t, []Source{{Name: "gone", Path: filepath.Join(t.TempDir(), "missing"), Tasks: []string{"ET-0001"}}},
+		func(context.Context, string, []string) error {
+			t.Fatal("missing source must not refresh")
+			return nil
+		})
+	var scheduledAt time.Time
+	d.opts.Schedules = func(_ context.Context, now time.Time) error {
+		scheduledAt = now
+		return errors.New("collector unavailable")
+	}
+	now := time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC)
+
+	result, err := d.Poll(context.Background(), now)
+	require.NoError(t, err)
+	assert.Contains(t, result.Sources[0].Error, "source not readable")
+	assert.Equal(t, now, scheduledAt)
+	assert.Equal(t, "collector unavailable", result.ScheduleError)
+}
+
+func TestDaemon_Run(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	d := newTestDaemon(t, []Source{{Name: "docs", Path: dir, Tasks: []string{"ET-0001"}}},
+		func(context.Context, string, []string) error { return nil })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var polls []PollResult
+	err := d.Run(ctx, func(result PollResult) {
+		polls = append(polls, result)
+		cancel()
+	})
+	require.NoError(t, err)
+	require.Len(t, polls, 1, "polls immediately and stops when cancelled")
+	assert.True(t, polls[0].Sources[0].Changed)
+}
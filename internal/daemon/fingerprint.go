@@ -0,0 +1,185 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Fingerprint identifies the current content of a source. A git checkout is
+// identified by its HEAD commit, so only new commits count as changes; any
+// other directory by the names, sizes and modification times of the files
+// matching include.
+func Fingerprint(root string, include []string) (string, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return "", fmt.Errorf("source not readable: %w", err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("source is not a directory: %s", root)
+	}
+
+	commit, isRepo, err := gitHead(root)
+	if err != nil {
+		return "", err
+	}
+	if isRepo {
+		return "git:" + commit, nil
+	}
+	return directoryFingerprint(root, include)
+}
+
+// directoryFingerprint hashes file metadata under root, skipping hidden
+// directories such as .terraform
+func directoryFingerprint(root string, include []string) (string, error) {
+	hash := sha256.New()
+	err := filepath.WalkDir(root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if filePath != root && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, filePath)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if !matchesInclude(rel, include) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(hash, "%s\x00%d\x00%d\n", rel, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to scan source: %w", err)
+	}
+	return "files:" + hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// matchesInclude reports whether a slash-separated relative path matches one
+// of the patterns. Patterns without a slash match the file name in any
+// directory; others match the whole path.
+func matchesInclude(rel string, include []string) bool {
+	if len(include) == 0 {
+		return true
+	}
+	for _, pattern := range include {
+		target := rel
+		if !strings.Contains(pattern, "/") {
+			target = path.Base(rel)
+		}
+		if matched, _ := path.Match(pattern, target); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// gitHead returns the commit checked out in root, reading the repository
+// files directly so git need not be installed
+func gitHead(root string) (string, bool, error) {
+	gitDir := filepath.Join(root, ".git")
+	info, err := os.Stat(gitDir)
+	if os.IsNotExist(err) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	// Worktrees and submodules have a .git file pointing at their git directory
+	if !info.IsDir() {
+		data, err := os.ReadFile(gitDir)
+		if err != nil {
+			return "", false, err
+		}
+		target, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir: ")
+		if !ok {
+			return "", false, fmt.Errorf("unrecognized .git file in %s", root)
+		}
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(root, target)
+		}
+		gitDir = target
+	}
+
+	head, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read git HEAD: %w", err)
+	}
+	ref, symbolic := strings.CutPrefix(strings.TrimSpace(string(head)), "ref: ")
+	if !symbolic {
+		return ref, true, nil // Detached HEAD
+	}
+
+	// Branch refs of a worktree live in the main repository's directory
+	refDirs := []string{gitDir}
+	if common, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		commonDir := strings.TrimSpace(string(common))
+		if !filepath.IsAbs(commonDir) {
+			commonDir = filepath.Join(gitDir, commonDir)
+		}
+		refDirs = append(refDirs, commonDir)
+	}
+
+	for _, dir := range refDirs {
+		if commit, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(ref))); err == nil {
+			return strings.TrimSpace(string(commit)), true, nil
+		}
+		if commit, ok := packedRef(filepath.Join(dir, "packed-refs"), ref); ok {
+			return commit, true, nil
+		}
+	}
+
+	// A branch without commits yet
+	return "unborn:" + ref, true, nil
+}
+
+// packedRef looks a ref up in a packed-refs file
+func packedRef(file, ref string) (string, bool) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		commit, name, ok := strings.Cut(scanner.Text(), " ")
+		if ok && name == ref {
+			return commit, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,132 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestFingerprint_Directory(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "main.tf"), "resource {}")
+	writeFile(t, filepath.Join(dir, "modules", "iam", "roles.tf"), "role {}")
+	writeFile(t, filepath.Join(dir, "README.md"), "docs")
+
+	fingerprint := func(include ...string) string {
+		value, err := Fingerprint(dir, include)
+		require.NoError(t, err)
+		return value
+	}
+
+	all := fingerprint()
+	terraform := fingerprint("*.tf")
+	assert.Contains(t, all, "files:")
+	assert.NotEqual(t, all, terraform)
+	assert.Equal(t, all, fingerprint(), "fingerprints are stable")
+
+	// Files outside the include patterns and in hidden directories are ignored
+	writeFile(t, filepath.Join(dir, "README.md"), "more docs")
+	writeFile(t, filepath.Join(dir, ".terraform", "cache.tf"), "cache")
+	assert.Equal(t, terraform, fingerprint("*.tf"))
+	assert.NotEqual(t, all, fingerprint())
+
+	// Path patterns match from the source root
+	iam := fingerprint("modules/iam/*.tf")
+	writeFile(t, filepath.Join(dir, "main.tf"), "resource { changed }")
+	assert.Equal(t, iam, fingerprint("modules/iam/*.tf"))
+	assert.NotEqual(t, terraform, fingerprint("*.tf"))
+
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "modules", "iam", "roles.tf"), later, later))
+	assert.NotEqual(t, iam, fingerprint("modules/iam/*.tf"), "modification time counts as a change")
+}
+
+func TestFingerprint_Git(t *testing.T) {
+	t.Parallel()
+
+	const (
+		first  = "1111111111111111111111111111111111111111"
+		second = "2222222222222222222222222222222222222222"
+	)
+
+	tests := map[string]struct {
+		files    map[string]string
+		expected string
+	}{
+		"loose ref": {
+			files: map[string]string{
+				".git/HEAD":            "ref: refs/heads/main\n",
+				".git/refs/heads/main": first + "\n",
+			},
+			expected: "git:" + first,
+		},
+		"packed ref": {
+			files: map[string]string{
+				".git/HEAD":        "ref: refs/heads/main\n",
+				".git/packed-refs": "# pack-refs with: peeled\n" + second + " refs/heads/main\n",
+			},
+			expected: "git:" + second,
+		},
+		"detached head": {
+			files:    map[string]string{".git/HEAD": first + "\n"},
+			expected: "git:" + first,
+		},
+		"unborn branch": {
+			files:    map[string]string{".git/HEAD": "ref: refs/heads/main\n"},
+			expected: "git:unborn:refs/heads/main",
+		},
+		"worktree": {
+			files: map[string]string{
+				".git":                                  "gitdir: main/.git/worktrees/feature\n",
+				"main/.git/worktrees/feature/HEAD":      "ref: refs/heads/feature\n",
+				"main/.git/worktrees/feature/commondir": "../..\n",
+				"main/.git/refs/heads/feature":          second + "\n",
+			},
+			expected: "git:" + second,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			for path, content := range tc.files {
+				writeFile(t, filepath.Join(dir, filepath.FromSlash(path)), content)
+			}
+			writeFile(t, filepath.Join(dir, "main.tf"), "resource {}")
+
+			fingerprint, err := Fingerprint(dir, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, fingerprint)
+		})
+	}
+}
+
+func TestFingerprint_Errors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	_, err := Fingerprint(filepath.Join(dir, "missing"), nil)
+	assert.ErrorContains(t, err, "source not readable")
+
+	file := filepath.Join(dir, "file.txt")
+	writeFile(t, file, "x")
+	_, err = Fingerprint(file, nil)
+	assert.ErrorContains(t, err, "not a directory")
+}
@@ -0,0 +1,73 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package delivery ships evidence packages to the dropboxes auditors provide:
+// an SFTP server, an S3 bucket or a signed webhook.
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/grctool/grctool/internal/config"
+)
+
+// Deliverer uploads a file to a dropbox
+type Deliverer interface {
+	Deliver(ctx context.Context, filename string, content []byte) (*Receipt, error)
+}
+
+// Receipt records where a delivered file went and what the dropbox returned
+type Receipt struct {
+	Location string // Where the file was delivered, without credentials
+	ID       string // Identifier the dropbox returned, such as an S3 ETag, if any
+}
+
+// String returns the location followed by the identifier, if any
+func (r *Receipt) String() string {
+	if r.ID == "" {
+		return r.Location
+	}
+	return fmt.Sprintf("%s (%s)", r.Location, r.ID)
+}
+
+// New returns the deliverer for an sftp://, s3://, or https:// URL
+func New(rawURL string, cfg config.DeliveryConfig) (Deliverer, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid delivery URL %q", rawURL)
+	}
+	switch u.Scheme {
+	case "sftp":
+		return NewSFTPDeliverer(u, cfg), nil
+	case "s3":
+		return NewS3Deliverer(u, cfg)
+	case "https", "http":
+		return NewWebhookDeliverer(u, cfg), nil
+	default:
+		return nil, fmt.Errorf("unsupported delivery URL scheme %q: use sftp, s3 or https", u.Scheme)
+	}
+}
+
+// joinPath joins a remote directory and a filename with a slash
+func joinPath(dir, filename string) string {
+	dir = strings.Trim(dir, "/")
+	if dir == "" {
+		return filename
+	}
+	return dir + "/" + filename
+}
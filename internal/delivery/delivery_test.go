@@ -0,0 +1,189 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package delivery
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		url  string
+		want Deliverer
+		err  string
+	}{
+		"sftp":    {url: "sftp://auditor@files.example.com/incoming", want: &SFTPDeliverer{}},
+		"s3":      {url: "s3://audit-dropbox/acme", want: &S3Deliverer{}},
+		"webhook": {url: "https://audit.example.com/hooks/evidence", want: &WebhookDeliverer{}},
+		"ftp":     {url: "ftp://files.example.com", err: `unsupported delivery URL scheme "ftp"`},
+		"no host": {url: "incoming/evidence", err: "invalid delivery URL"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			deliverer, err := New(tc.url, config.DeliveryConfig{})
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.IsType(t, tc.want, deliverer)
+		})
+	}
+}
+
+func TestSFTPDeliverer_Deliver(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		url      string
+		cfg      config.DeliveryConfig
+		args     []string
+		batch    string
+		remote   string
+		location string
+	}{
+		"absolute dir": {
+			url:      "sftp://auditor@files.example.com:2222//uploads/acme",
+			cfg:      config.DeliveryConfig{SSHIdentityFile: "/home/grc/.ssh/audit"},
+			args:     []string{"-b", "-", "-o", "BatchMode=yes", "-P", "2222", "-i", "/home/grc/.ssh/audit", "auditor@files.example.com"},
+			batch:    "-mkdir \"/uploads\"\n-mkdir \"/uploads/acme\"\n",
+			remote:   `"/uploads/acme/ET-0001.zip"`,
+			location: "sftp://files.example.com:2222//uploads/acme/ET-0001.zip",
+		},
+		"home dir": {
+			url:      "sftp://files.example.com/incoming",
+			args:     []string{"-b", "-", "-o", "BatchMode=yes", "files.example.com"},
+			batch:    "-mkdir \"incoming\"\n",
+			remote:   `"incoming/ET-0001.zip"`,
+			location: "sftp://files.example.com/incoming/ET-0001.zip",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			u, err := url.Parse(tc.url)
+			require.NoError(t, err)
+			deliverer := NewSFTPDeliverer(u, tc.cfg)
+
+			var args []string
+			var batch string
+			var uploaded []byte
+			deliverer.run = func(ctx context.Context, a []string, b string) error {
+				args, batch = a, b
+				// The package is in a temporary file while sftp runs
+				_, put, _ := strings.Cut(b, "put \"")
+				local, _, _ := strings.Cut(put, "\" ")
+				uploaded, err = os.ReadFile(local)
+				return err
+			}
+
+			receipt, err := deliverer.Deliver(context.Background(), "ET-0001.zip", []byte("zip"))
+			require.NoError(t, err)
+			assert.Equal(t, tc.args, args)
+			assert.True(t, strings.HasPrefix(batch, tc.batch+"put \""), batch)
+			assert.True(t, strings.HasSuffix(batch, " "+tc.remote+"\n"), batch)
+			assert.Equal(t, []byte("zip"), uploaded)
+			assert.Equal(t, tc.location, receipt.String())
+		})
+	}
+}
+
+func TestWebhookDeliverer_Deliver(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1760000000, 0)
+
+	tests := map[string]struct {
+		secret   string
+		status   int
+		response string
+		receipt  string
+		err      string
+	}{
+		"receipt id": {secret: "s3cret", status: http.StatusCreated, response: `{"id": "dlv_123"}`, receipt: "/hooks/evidence (dlv_123)"},
+		"numeric id": {secret: "s3cret", status: http.StatusOK, response: `{"id": 1234567890}`, receipt: "/hooks/evidence (1234567890)"},
+		"no body":    {secret: "s3cret", status: http.StatusAccepted, receipt: "/hooks/evidence"},
+		"rejected":   {secret: "s3cret", status: http.StatusUnauthorized, response: "bad signature", err: "webhook delivery failed with status 401: bad signature"},
+		"no secret":  {err: "webhook secret not configured - set GRCTOOL_DELIVERY_WEBHOOK_SECRET"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.Equal(t, []byte("zip"), body)
+				assert.Equal(t, "application/zip", r.Header.Get("Content-Type"))
+				assert.Equal(t, `attachment; filename="ET-0001.zip"`, r.Header.Get("Content-Disposition"))
+				assert.Equal(t, "1760000000", r.Header.Get(TimestampHeader))
+				assert.Equal(t, "sha256="+Sign(tc.secret, "1760000000", body), r.Header.Get(SignatureHeader))
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.response))
+			}))
+			t.Cleanup(server.Close)
+
+			u, err := url.Parse(server.URL + "/hooks/evidence")
+			require.NoError(t, err)
+			deliverer := NewWebhookDeliverer(u, config.DeliveryConfig{WebhookSecret: tc.secret})
+			deliverer.now = func() time.Time { return now }
+
+			receipt, err := deliverer.Deliver(context.Background(), "ET-0001.zip", []byte("zip"))
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, server.URL+tc.receipt, receipt.String())
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	// echo -n '1760000000.zip' | openssl dgst -sha256 -hmac s3cret
+	assert.Equal(t, "1957a08a043bfc51775e32920a0696c067b9111364f774dee58a54536bf66e82", Sign("s3cret", "1760000000", []byte("zip")))
+}
+
+func TestS3Deliverer_Deliver(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Contains(t, r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/")
+		path = r.URL.Path
+		w.Header().Set("ETag", `"9a0364b9e99bb480dd25e1f0284c8555"`)
+	}))
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse("s3://audit-dropbox/acme/2025/")
+	require.NoError(t, err)
+	deliverer, err := NewS3Deliverer(u, config.DeliveryConfig{S3Region: "eu-west-1", S3Endpoint: server.URL})
+	require.NoError(t, err)
+
+	receipt, err := deliverer.Deliver(context.Background(), "ET-0001.zip", []byte("zip"))
+	require.NoError(t, err)
+	assert.Equal(t, "/audit-dropbox/acme/2025/ET-0001.zip", path)
+	assert.Equal(t, "s3://audit-dropbox/acme/2025/ET-0001.zip (9a0364b9e99bb480dd25e1f0284c8555)", receipt.String())
+}
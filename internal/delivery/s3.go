@@ -0,0 +1,60 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/storage"
+)
+
+// s3PartSize is the part size of multipart uploads of packages larger than
+// one part
+const s3PartSize = 16 * 1024 * 1024 // 16MB
+
+// S3Deliverer puts files in an S3 bucket, or an S3-compatible store when an
+// endpoint is configured. Credentials come from the environment or the aws
+// CLI, as for remote storage.
+type S3Deliverer struct {
+	bucket  string
+	prefix  string
+	backend *storage.S3Backend
+}
+
+// NewS3Deliverer returns a deliverer for s3://bucket/prefix
+func NewS3Deliverer(u *url.URL, cfg config.DeliveryConfig) (*S3Deliverer, error) {
+	prefix := strings.Trim(u.Path, "/")
+	backend, err := storage.NewS3Backend(u.Host, prefix, cfg.S3Region, cfg.S3Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid delivery.s3_endpoint: %w", err)
+	}
+	return &S3Deliverer{bucket: u.Host, prefix: prefix, backend: backend}, nil
+}
+
+// Deliver puts the file under the prefix, in parts when it is larger than
+// one, so a failed part is sent again rather than the whole package. The
+// receipt carries its ETag.
+func (d *S3Deliverer) Deliver(ctx context.Context, filename string, content []byte) (*Receipt, error) {
+	info, err := d.backend.PutMultipart(ctx, filename, content, s3PartSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Receipt{Location: "s3://" + d.bucket + "/" + joinPath(d.prefix, filename), ID: info.Version}, nil
+}
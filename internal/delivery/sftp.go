@@ -0,0 +1,136 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/grctool/grctool/internal/config"
+)
+
+// SFTPDeliverer uploads files with the OpenSSH sftp client, so host keys,
+// agents and ~/.ssh/config apply as they do for the user
+type SFTPDeliverer struct {
+	host         string // [user@]host
+	port         string
+	dir          string
+	identityFile string
+	run          func(ctx context.Context, args []string, batch string) error
+}
+
+// NewSFTPDeliverer returns a deliverer for sftp://[user@]host[:port]/dir. The
+// directory is relative to the login directory unless it starts with a second
+// slash, as in sftp://host//srv/incoming.
+func NewSFTPDeliverer(u *url.URL, cfg config.DeliveryConfig) *SFTPDeliverer {
+	host := u.Hostname()
+	if user := u.User.Username(); user != "" {
+		host = user + "@" + host
+	}
+	return &SFTPDeliverer{
+		host:         host,
+		port:         u.Port(),
+		dir:          strings.TrimPrefix(u.Path, "/"),
+		identityFile: cfg.SSHIdentityFile,
+		run:          runSFTP,
+	}
+}
+
+// Deliver writes the file to a temporary file and puts it in the remote
+// directory, creating the directory when it does not exist
+func (d *SFTPDeliverer) Deliver(ctx context.Context, filename string, content []byte) (*Receipt, error) {
+	tmp, err := os.CreateTemp("", "grctool-delivery-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return nil, fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	remote := joinPath(d.dir, filename)
+	if strings.HasPrefix(d.dir, "/") {
+		remote = "/" + remote
+	}
+	if err := d.run(ctx, d.args(), d.batch(tmp.Name(), remote)); err != nil {
+		return nil, err
+	}
+	return &Receipt{Location: "sftp://" + d.hostPort() + "/" + remote}, nil
+}
+
+// args are the sftp arguments, reading commands from stdin and failing
+// rather than prompting for a password
+func (d *SFTPDeliverer) args() []string {
+	args := []string{"-b", "-", "-o", "BatchMode=yes"}
+	if d.port != "" {
+		args = append(args, "-P", d.port)
+	}
+	if d.identityFile != "" {
+		args = append(args, "-i", d.identityFile)
+	}
+	return append(args, d.host)
+}
+
+// batch is the sftp batch script. The leading - lets mkdir fail when the
+// directory exists.
+func (d *SFTPDeliverer) batch(local, remote string) string {
+	var script strings.Builder
+	if dir := strings.Trim(d.dir, "/"); dir != "" {
+		prefix := ""
+		if strings.HasPrefix(d.dir, "/") {
+			prefix = "/"
+		}
+		parts := strings.Split(dir, "/")
+		for i := range parts {
+			script.WriteString(fmt.Sprintf("-mkdir %s\n", sftpQuote(prefix+strings.Join(parts[:i+1], "/"))))
+		}
+	}
+	script.WriteString(fmt.Sprintf("put %s %s\n", sftpQuote(local), sftpQuote(remote)))
+	return script.String()
+}
+
+func (d *SFTPDeliverer) hostPort() string {
+	host := d.host[strings.LastIndex(d.host, "@")+1:]
+	if d.port == "" {
+		return host
+	}
+	return host + ":" + d.port
+}
+
+// sftpQuote quotes a path for an sftp batch script
+func sftpQuote(path string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(path) + `"`
+}
+
+func runSFTP(ctx context.Context, args []string, batch string) error {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sftp", args...)
+	cmd.Stdin = strings.NewReader(batch)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("sftp upload failed (%v): %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
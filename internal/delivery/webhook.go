@@ -0,0 +1,115 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+)
+
+// Webhook request headers. The signature is the hex HMAC-SHA256, keyed with
+// the webhook secret, of the timestamp, a dot and the body, so receivers can
+// reject replayed deliveries.
+const (
+	SignatureHeader = "X-GRCTool-Signature"
+	TimestampHeader = "X-GRCTool-Timestamp"
+)
+
+// WebhookDeliverer POSTs files to a URL, signed with the webhook secret
+type WebhookDeliverer struct {
+	url        *url.URL
+	secret     string
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewWebhookDeliverer returns a deliverer for an https:// URL
+func NewWebhookDeliverer(u *url.URL, cfg config.DeliveryConfig) *WebhookDeliverer {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Minute
+	}
+	return &WebhookDeliverer{
+		url:        u,
+		secret:     cfg.WebhookSecret,
+		httpClient: &http.Client{Timeout: timeout},
+		now:        time.Now,
+	}
+}
+
+// Deliver POSTs the file. The receipt carries the "id" of a JSON response,
+// if any.
+func (d *WebhookDeliverer) Deliver(ctx context.Context, filename string, content []byte) (*Receipt, error) {
+	if d.secret == "" {
+		return nil, fmt.Errorf("webhook secret not configured - set GRCTOOL_DELIVERY_WEBHOOK_SECRET")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url.String(), bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	timestamp := strconv.FormatInt(d.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/zip")
+	req.Header.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`,
+		strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(filename)))
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, "sha256="+Sign(d.secret, timestamp, content))
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deliver to webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("webhook delivery failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	receipt := &Receipt{Location: d.url.Redacted()}
+	var result map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if decoder.Decode(&result) == nil && result["id"] != nil {
+		receipt.ID = fmt.Sprint(result["id"])
+	}
+	return receipt, nil
+}
+
+// Sign returns the hex HMAC-SHA256 signature of a webhook delivery
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
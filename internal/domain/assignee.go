@@ -0,0 +1,66 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import "strings"
+
+// Matches reports whether who names the person by member ID, email or name,
+// ignoring case
+func (p Person) Matches(who string) bool {
+	who = strings.TrimSpace(who)
+	if who == "" {
+		return false
+	}
+	return (p.ID != "" && p.ID == who) ||
+		(p.Email != "" && strings.EqualFold(p.Email, who)) ||
+		(p.Name != "" && strings.EqualFold(p.Name, who))
+}
+
+// IsAssignedTo reports whether one of the task's assignees matches who
+func (et *EvidenceTask) IsAssignedTo(who string) bool {
+	for _, assignee := range et.Assignees {
+		if assignee.Matches(who) {
+			return true
+		}
+	}
+	return false
+}
+
+// Assign adds person to the task's assignees, reporting false when they are
+// already assigned
+func (et *EvidenceTask) Assign(person Person) bool {
+	for _, assignee := range et.Assignees {
+		if (person.ID != "" && assignee.ID == person.ID) ||
+			(person.Email != "" && strings.EqualFold(assignee.Email, person.Email)) {
+			return false
+		}
+	}
+	et.Assignees = append(et.Assignees, person)
+	return true
+}
+
+// Unassign removes the assignees matching who, reporting false when none do
+func (et *EvidenceTask) Unassign(who string) bool {
+	kept := make([]Person, 0, len(et.Assignees))
+	for _, assignee := range et.Assignees {
+		if !assignee.Matches(who) {
+			kept = append(kept, assignee)
+		}
+	}
+	removed := len(kept) < len(et.Assignees)
+	et.Assignees = kept
+	return removed
+}
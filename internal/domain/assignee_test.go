@@ -0,0 +1,53 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPerson_Matches(t *testing.T) {
+	t.Parallel()
+
+	jane := Person{ID: "4201", Name: "Jane Doe", Email: "jane@example.com"}
+	tests := map[string]struct {
+		person Person
+		who    string
+		want   bool
+	}{
+		"member id":        {person: jane, who: "4201", want: true},
+		"email any case":   {person: jane, who: "Jane@Example.com", want: true},
+		"name any case":    {person: jane, who: "jane doe", want: true},
+		"partial email":    {person: jane, who: "jane@", want: false},
+		"blank":            {person: jane, who: " ", want: false},
+		"blank email only": {person: Person{Name: "Raj Patel"}, who: "", want: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, tc.person.Matches(tc.who))
+		})
+	}
+}
+
+func TestEvidenceTask_AssignUnassign(t *testing.T) {
+	t.Parallel()
+
+	task := EvidenceTask{Assignees: []Person{{ID: "4201", Name: "Jane Doe", Email: "jane@example.com"}}}
+	assert.True(t, task.IsAssignedTo("jane@example.com"))
+	assert.False(t, task.IsAssignedTo("raj@example.com"))
+
+	assert.False(t, task.Assign(Person{Email: "JANE@example.com"}), "already assigned by email")
+	assert.False(t, task.Assign(Person{ID: "4201"}), "already assigned by member ID")
+	assert.True(t, task.Assign(Person{ID: "4202", Name: "Raj Patel", Email: "raj@example.com"}))
+	assert.Len(t, task.Assignees, 2)
+	assert.True(t, task.IsAssignedTo("Raj Patel"))
+
+	assert.False(t, task.Unassign("lee@example.com"))
+	assert.True(t, task.Unassign("Jane Doe"))
+	assert.Equal(t, []Person{{ID: "4202", Name: "Raj Patel", Email: "raj@example.com"}}, task.Assignees)
+}
@@ -0,0 +1,151 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// PolicyReviewDueSoonDays is how far ahead an annual policy review is flagged as coming due
+const PolicyReviewDueSoonDays = 30
+
+// Authored policy statuses, in workflow order. They match the draft, review
+// and approved states of the policy lifecycle.
+const (
+	AuthoredPolicyDraft    = "draft"
+	AuthoredPolicyInReview = "review"
+	AuthoredPolicyApproved = "approved"
+)
+
+// Annual policy review statuses
+const (
+	PolicyReviewCurrent       = "current"
+	PolicyReviewDueSoon       = "due_soon"
+	PolicyReviewOverdue       = "overdue"
+	PolicyReviewNeverApproved = "never_approved"
+)
+
+// AuthoredPolicyStatuses lists the valid statuses, in workflow order
+var AuthoredPolicyStatuses = []string{AuthoredPolicyDraft, AuthoredPolicyInReview, AuthoredPolicyApproved}
+
+// AuthoredPolicy is a policy written locally as markdown with YAML front
+// matter, as opposed to a Policy synced from a provider. It moves from draft
+// to review to approved, and is reviewed again annually.
+type AuthoredPolicy struct {
+	ID           string           `yaml:"-" json:"id"` // File name without extension, e.g. access-control-policy
+	Title        string           `yaml:"title" json:"title"`
+	Owner        string           `yaml:"owner,omitempty" json:"owner,omitempty"`
+	Version      string           `yaml:"version" json:"version"`
+	Status       string           `yaml:"status" json:"status"`
+	Approvers    []string         `yaml:"approvers,omitempty" json:"approvers,omitempty"` // Who must approve each version
+	Approvals    []PolicyApproval `yaml:"approvals,omitempty" json:"approvals,omitempty"` // Approvals of each version
+	ApprovedDate string           `yaml:"approved_date,omitempty" json:"approved_date,omitempty"`
+	ReviewDate   string           `yaml:"review_date,omitempty" json:"review_date,omitempty"` // Next annual review due
+	Body         string           `yaml:"-" json:"-"`                                         // Markdown after the front matter
+}
+
+// PolicyApproval records an approver signing off a policy version
+type PolicyApproval struct {
+	Approver string `yaml:"approver" json:"approver"`
+	Date     string `yaml:"date" json:"date"`
+	Version  string `yaml:"version" json:"version"`
+}
+
+// ReviewStatus reports whether the policy's annual review is current, due
+// within PolicyReviewDueSoonDays or overdue, or that it was never approved.
+// A revision in progress keeps the review date of the approved version.
+func (p *AuthoredPolicy) ReviewStatus(now time.Time) string {
+	due, err := time.Parse("2006-01-02", p.ReviewDate)
+	switch {
+	case p.ApprovedDate == "" || err != nil:
+		return PolicyReviewNeverApproved
+	case !now.Before(due.AddDate(0, 0, 1)):
+		return PolicyReviewOverdue
+	case now.AddDate(0, 0, PolicyReviewDueSoonDays).After(due):
+		return PolicyReviewDueSoon
+	default:
+		return PolicyReviewCurrent
+	}
+}
+
+// PendingApprovers lists the approvers who have not approved the current version
+func (p *AuthoredPolicy) PendingApprovers() []string {
+	pending := []string{}
+	for _, approver := range p.Approvers {
+		if !p.HasApproved(approver) {
+			pending = append(pending, approver)
+		}
+	}
+	return pending
+}
+
+// HasApproved reports whether approver has approved the current version
+func (p *AuthoredPolicy) HasApproved(approver string) bool {
+	return slices.ContainsFunc(p.Approvals, func(a PolicyApproval) bool {
+		return strings.EqualFold(a.Approver, approver) && a.Version == p.Version
+	})
+}
+
+// ApprovedVersion is the most recently approved version of the policy. While
+// a revision is in progress it is the version approved on the approved date.
+func (p *AuthoredPolicy) ApprovedVersion() string {
+	if p.Status == AuthoredPolicyApproved {
+		return p.Version
+	}
+	version := ""
+	for _, approval := range p.Approvals {
+		if approval.Date <= p.ApprovedDate && approval.Version != p.Version {
+			version = approval.Version
+		}
+	}
+	return version
+}
+
+// Validate checks the policy's title, version, status and dates
+func (p *AuthoredPolicy) Validate() error {
+	if p.Title == "" {
+		return fmt.Errorf("policy title is required")
+	}
+	if p.Version == "" {
+		return fmt.Errorf("policy version is required")
+	}
+	if !slices.Contains(AuthoredPolicyStatuses, p.Status) {
+		return fmt.Errorf("invalid status %q (must be one of: %v)", p.Status, AuthoredPolicyStatuses)
+	}
+	for name, date := range map[string]string{"approved_date": p.ApprovedDate, "review_date": p.ReviewDate} {
+		if _, err := time.Parse("2006-01-02", date); date != "" && err != nil {
+			return fmt.Errorf("invalid %s %q: use YYYY-MM-DD", name, date)
+		}
+	}
+	if p.Status == AuthoredPolicyApproved && p.ApprovedDate == "" {
+		return fmt.Errorf("approved policy needs an approved_date")
+	}
+	return nil
+}
+
+// NextPolicyVersion bumps the minor part of a "major.minor" version, so a
+// revision of 1.0 is 1.1. Versions that are not major.minor get ".1" appended.
+func NextPolicyVersion(version string) string {
+	major, minor, ok := strings.Cut(version, ".")
+	var n int
+	if _, err := fmt.Sscanf(minor, "%d", &n); !ok || err != nil || fmt.Sprint(n) != minor {
+		return version + ".1"
+	}
+	return fmt.Sprintf("%s.%d", major, n+1)
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthoredPolicy_ReviewStatus(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 11, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		approved string
+		review   string
+		want     string
+	}{
+		"never approved": {want: PolicyReviewNeverApproved},
+		"current":        {approved: "2025-06-01", review: "2026-06-01", want: PolicyReviewCurrent},
+		"due soon":       {approved: "2024-11-20", review: "2025-11-20", want: PolicyReviewDueSoon},
+		"due today":      {approved: "2024-11-01", review: "2025-11-01", want: PolicyReviewDueSoon},
+		"overdue":        {approved: "2024-10-31", review: "2025-10-31", want: PolicyReviewOverdue},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			policy := AuthoredPolicy{ApprovedDate: tc.approved, ReviewDate: tc.review}
+			assert.Equal(t, tc.want, policy.ReviewStatus(now))
+		})
+	}
+}
+
+func TestAuthoredPolicy_PendingApprovers(t *testing.T) {
+	t.Parallel()
+
+	policy := AuthoredPolicy{
+		Version:   "1.1",
+		Approvers: []string{"cto@example.com", "ciso@example.com"},
+		Approvals: []PolicyApproval{
+			{Approver: "CTO@example.com", Date: "2025-09-01", Version: "1.1"},
+			{Approver: "ciso@example.com", Date: "2024-09-01", Version: "1.0"},
+		},
+	}
+	assert.True(t, policy.HasApproved("cto@example.com"))
+	assert.False(t, policy.HasApproved("ciso@example.com"), "approvals of earlier versions do not count")
+	assert.Equal(t, []string{"ciso@example.com"}, policy.PendingApprovers())
+}
+
+func TestAuthoredPolicy_Validate(t *testing.T) {
+	t.Parallel()
+
+	valid := AuthoredPolicy{Title: "Access Control Policy", Version: "1.0", Status: AuthoredPolicyDraft}
+	assert.NoError(t, valid.Validate())
+
+	invalid := valid
+	invalid.Title = ""
+	assert.ErrorContains(t, invalid.Validate(), "title is required")
+	invalid = valid
+	invalid.Status = "published"
+	assert.ErrorContains(t, invalid.Validate(), `invalid status "published"`)
+	invalid = valid
+	invalid.ReviewDate = "next year"
+	assert.ErrorContains(t, invalid.Validate(), `invalid review_date "next year"`)
+	invalid = valid
+	invalid.Status = AuthoredPolicyApproved
+	assert.ErrorContains(t, invalid.Validate(), "needs an approved_date")
+}
+
+func TestNextPolicyVersion(t *testing.T) {
+	t.Parallel()
+
+	for version, want := range map[string]string{"1.0": "1.1", "1.9": "1.10", "2": "2.1", "v1.x": "v1.x.1"} {
+		assert.Equal(t, want, NextPolicyVersion(version), version)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// TaskDeferral postpones an evidence task past its due date with a
+// justification. Like incidents, deferrals are kept locally rather than
+// synced, and a deferred task is not counted as overdue until Until.
+type TaskDeferral struct {
+	TaskRef    string     `json:"task_ref"` // ET-0103
+	Until      time.Time  `json:"until"`    // Day the task is due again
+	Reason     string     `json:"reason"`
+	DeferredBy string     `json:"deferred_by,omitempty"`
+	DeferredAt time.Time  `json:"deferred_at"`
+	DueDate    *time.Time `json:"due_date,omitempty"` // Task's due date when it was deferred
+	// Tugboat comment recording the deferral on the task, if one was posted
+	TugboatCommentID int `json:"tugboat_comment_id,omitempty"`
+}
+
+// Validate checks that the deferral names a task, an end and a reason
+func (d *TaskDeferral) Validate() error {
+	if d.TaskRef == "" {
+		return fmt.Errorf("deferral task reference is required")
+	}
+	if d.Until.IsZero() {
+		return fmt.Errorf("deferral end date is required")
+	}
+	if d.Reason == "" {
+		return fmt.Errorf("deferral reason is required")
+	}
+	if !d.DeferredAt.IsZero() && !d.Until.After(d.DeferredAt) {
+		return fmt.Errorf("deferral must end after it was made, got %s", d.Until.Format("2006-01-02"))
+	}
+	return nil
+}
+
+// Active reports whether the deferral still applies at now
+func (d *TaskDeferral) Active(now time.Time) bool {
+	return d != nil && now.Before(d.Until)
+}
+
+// IsDeferred reports whether the task has a deferral that applies at now
+func (et *EvidenceTask) IsDeferred(now time.Time) bool {
+	return et.Deferral.Active(now)
+}
+
+// IsOverdue reports whether the task's due date has passed at now and it is
+// not deferred
+func (et *EvidenceTask) IsOverdue(now time.Time) bool {
+	return et.NextDue != nil && et.NextDue.Before(now) && !et.IsDeferred(now)
+}
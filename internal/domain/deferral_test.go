@@ -0,0 +1,72 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskDeferral_Validate(t *testing.T) {
+	t.Parallel()
+
+	deferredAt := time.Date(2025, 11, 1, 9, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		deferral TaskDeferral
+		wantErr  string
+	}{
+		"valid":          {deferral: TaskDeferral{TaskRef: "ET-0103", Until: until, Reason: "Vendor report", DeferredAt: deferredAt}},
+		"missing task":   {deferral: TaskDeferral{Until: until, Reason: "Vendor report"}, wantErr: "task reference is required"},
+		"missing until":  {deferral: TaskDeferral{TaskRef: "ET-0103", Reason: "Vendor report"}, wantErr: "end date is required"},
+		"missing reason": {deferral: TaskDeferral{TaskRef: "ET-0103", Until: until}, wantErr: "reason is required"},
+		"ends in the past": {
+			deferral: TaskDeferral{TaskRef: "ET-0103", Until: deferredAt.AddDate(0, 0, -1), Reason: "Vendor report", DeferredAt: deferredAt},
+			wantErr:  "must end after it was made",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.deferral.Validate()
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestEvidenceTask_IsOverdue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 1, 12, 0, 0, 0, time.UTC)
+	past := now.AddDate(0, 0, -10)
+	future := now.AddDate(0, 0, 10)
+
+	tests := map[string]struct {
+		task         EvidenceTask
+		wantDeferred bool
+		wantOverdue  bool
+	}{
+		"no due date":     {task: EvidenceTask{}},
+		"due later":       {task: EvidenceTask{NextDue: &future}},
+		"past due":        {task: EvidenceTask{NextDue: &past}, wantOverdue: true},
+		"deferred":        {task: EvidenceTask{NextDue: &past, Deferral: &TaskDeferral{Until: future}}, wantDeferred: true},
+		"deferral lapsed": {task: EvidenceTask{NextDue: &past, Deferral: &TaskDeferral{Until: past}}, wantOverdue: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.wantDeferred, tc.task.IsDeferred(now))
+			assert.Equal(t, tc.wantOverdue, tc.task.IsOverdue(now))
+		})
+	}
+}
@@ -82,6 +82,9 @@ type EvidenceTask struct {
 	Associations  *EvidenceTaskAssociations  `json:"associations,omitempty"`
 	// Lifecycle state (managed by lifecycle state machine)
 	LifecycleState string `json:"lifecycle_state,omitempty"`
+	// Deferral recorded with 'grctool evidence defer', attached by storage
+	// when the task is read; it is saved apart from the synced task
+	Deferral *TaskDeferral `json:"deferral,omitempty"`
 	// Multi-provider fields
 	// ExternalIDs maps provider names to their external ID for this entity.
 	// e.g., {"tugboat": "12345", "accountablehq": "et-abc-123"}
@@ -165,6 +168,7 @@ type EvidenceTaskSummary struct {
 	ByPriority map[string]int `json:"by_priority"`
 	Overdue    int            `json:"overdue"`
 	DueSoon    int            `json:"due_soon"`
+	Deferred   int            `json:"deferred"` // Not counted as overdue or due soon
 	LastSync   time.Time      `json:"last_sync"`
 }
 
@@ -176,6 +180,7 @@ type EvidenceFilter struct {
 	AssignedTo      string     `json:"assigned_to,omitempty"`
 	DueBefore       *time.Time `json:"due_before,omitempty"`
 	DueAfter        *time.Time `json:"due_after,omitempty"`
+	ExcludeDeferred bool       `json:"exclude_deferred,omitempty"` // Leave out tasks deferred now
 	Category        []string   `json:"category,omitempty"`
 	AecStatus       []string   `json:"aec_status,omitempty"`
 	CollectionType  []string   `json:"collection_type,omitempty"`
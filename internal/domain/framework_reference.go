@@ -0,0 +1,192 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"strings"
+
+	"github.com/grctool/grctool/internal/frameworks"
+)
+
+// FrameworkReference groups the codes a control or evidence task carries for
+// one framework, e.g. SOC 2 CC6.1 alongside NIST 800-53 AC-2 and AC-3
+type FrameworkReference struct {
+	Framework string   `json:"framework"`
+	Codes     []string `json:"codes"`
+}
+
+// String formats the reference as "SOC 2: CC6.1, CC6.2"
+func (r FrameworkReference) String() string {
+	if r.Framework == "" {
+		return strings.Join(r.Codes, ", ")
+	}
+	return r.Framework + ": " + strings.Join(r.Codes, ", ")
+}
+
+// FrameworkReferences returns the framework codes of the control grouped by
+// framework in the order they were synced. Controls synced without
+// framework_codes fall back to the comma-separated codes field, which
+// belongs to the control's own framework.
+func (c *Control) FrameworkReferences() []FrameworkReference {
+	refs := &frameworkReferenceSet{}
+	if len(c.FrameworkCodes) > 0 {
+		for _, fc := range c.FrameworkCodes {
+			refs.add(fc.Framework, fc.Code)
+		}
+		return refs.refs
+	}
+	for _, code := range strings.Split(c.Codes, ",") {
+		refs.add(c.Framework, code)
+	}
+	return refs.refs
+}
+
+// FrameworkReferences returns the framework codes of the task together with
+// those of its related controls, grouped by framework
+func (et *EvidenceTask) FrameworkReferences() []FrameworkReference {
+	refs := &frameworkReferenceSet{}
+	for _, fc := range et.FrameworkCodes {
+		refs.add(fc.Framework, fc.Code)
+	}
+	for i := range et.RelatedControls {
+		for _, ref := range et.RelatedControls[i].FrameworkReferences() {
+			for _, code := range ref.Codes {
+				refs.add(ref.Framework, code)
+			}
+		}
+	}
+	return refs.refs
+}
+
+// CustomFrameworkCodes returns the controls of a custom framework the
+// control addresses by way of its SOC2 codes and reference, crosswalked
+// through the framework definition, and the definition's keywords found in
+// its name or description. It is how controls synced without codes of the
+// framework map onto it.
+func (c *Control) CustomFrameworkCodes(framework string) []string {
+	if !frameworks.IsCustom(framework) {
+		return nil
+	}
+	var soc2 []string
+	for _, ref := range c.FrameworkReferences() {
+		if frameworks.Key(ref.Framework) == frameworks.SOC2 {
+			soc2 = append(soc2, ref.Codes...)
+		}
+	}
+	if c.ReferenceID != "" {
+		soc2 = append(soc2, c.ReferenceID)
+	}
+	return frameworks.CustomCodes(framework, soc2, c.Name+"\n"+c.Description)
+}
+
+// Requirements returns the controls of the framework the control addresses:
+// its codes for that framework when it carries any, or its reference when the
+// framework is its own, and otherwise its SOC2 codes and reference
+// crosswalked onto the framework
+func (c *Control) Requirements(framework string) []string {
+	var direct, soc2 []string
+	seen := make(map[string]bool)
+	for _, ref := range c.FrameworkReferences() {
+		refFramework, err := frameworks.Resolve(ref.Framework)
+		if err != nil {
+			continue
+		}
+		for _, code := range ref.Codes {
+			switch refFramework {
+			case framework:
+				if code, err := frameworks.NormalizeControl(framework, code); err == nil && !seen[code] {
+					seen[code] = true
+					direct = append(direct, code)
+				}
+			case frameworks.SOC2:
+				soc2 = append(soc2, code)
+			}
+		}
+	}
+	if len(direct) == 0 && c.ReferenceID != "" && frameworks.Key(c.Framework) == framework {
+		if code, err := frameworks.NormalizeControl(framework, c.ReferenceID); err == nil {
+			direct = append(direct, code)
+		}
+	}
+	if len(direct) > 0 {
+		frameworks.Sort(framework, direct)
+		return direct
+	}
+	if frameworks.IsCustom(framework) {
+		return c.CustomFrameworkCodes(framework)
+	}
+	if c.ReferenceID != "" {
+		soc2 = append(soc2, c.ReferenceID)
+	}
+	return frameworks.Crosswalk(framework, soc2...)
+}
+
+// HasFramework reports whether the task belongs to the framework, either as
+// its primary framework or through a framework reference of the task or its
+// related controls, or for a custom framework through the controls its
+// related controls map onto. Names are compared loosely so "SOC 2" matches
+// "soc2".
+func (et *EvidenceTask) HasFramework(name string) bool {
+	key := frameworks.Key(name)
+	if key == "" {
+		return false
+	}
+	if frameworks.Key(et.Framework) == key {
+		return true
+	}
+	for _, ref := range et.FrameworkReferences() {
+		if frameworks.Key(ref.Framework) == key {
+			return true
+		}
+	}
+	for i := range et.RelatedControls {
+		if len(et.RelatedControls[i].CustomFrameworkCodes(key)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// frameworkReferenceSet collects codes by framework, keeping the first
+// spelling of each framework name and dropping duplicate codes
+type frameworkReferenceSet struct {
+	refs  []FrameworkReference
+	index map[string]int
+	seen  map[string]bool
+}
+
+func (s *frameworkReferenceSet) add(framework, code string) {
+	framework, code = strings.TrimSpace(framework), strings.TrimSpace(code)
+	if code == "" {
+		return
+	}
+	if s.index == nil {
+		s.index, s.seen = map[string]int{}, map[string]bool{}
+	}
+	key := frameworks.Key(framework)
+	if s.seen[key+"\x00"+strings.ToUpper(code)] {
+		return
+	}
+	s.seen[key+"\x00"+strings.ToUpper(code)] = true
+
+	i, ok := s.index[key]
+	if !ok {
+		i = len(s.refs)
+		s.index[key] = i
+		s.refs = append(s.refs, FrameworkReference{Framework: framework})
+	}
+	s.refs[i].Codes = append(s.refs[i].Codes, code)
+}
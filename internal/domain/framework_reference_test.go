@@ -0,0 +1,139 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestControl_FrameworkReferences(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		control Control
+		want    []FrameworkReference
+	}{
+		"framework codes": {
+			control: Control{Framework: "SOC2", Codes: "CC6.1", FrameworkCodes: []FrameworkCode{
+				{Framework: "SOC 2", Code: "CC6.1"},
+				{Framework: "NIST 800-53", Code: "AC-2"},
+				{Framework: "SOC2", Code: "CC6.2"},
+				{Framework: "NIST SP 800-53 Rev. 5", Code: "AC-3"},
+				{Framework: "NIST 800-53", Code: "ac-2"},
+			}},
+			want: []FrameworkReference{
+				{Framework: "SOC 2", Codes: []string{"CC6.1", "CC6.2"}},
+				{Framework: "NIST 800-53", Codes: []string{"AC-2", "AC-3"}},
+			},
+		},
+		"codes fallback": {
+			control: Control{Framework: "SOC2", Codes: "CC6.1, CC6.6,"},
+			want:    []FrameworkReference{{Framework: "SOC2", Codes: []string{"CC6.1", "CC6.6"}}},
+		},
+		"no codes": {
+			control: Control{Framework: "SOC2"},
+			want:    nil,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, tc.control.FrameworkReferences())
+		})
+	}
+}
+
+func TestFrameworkReference_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "NIST 800-53: AC-2, AC-3", FrameworkReference{Framework: "NIST 800-53", Codes: []string{"AC-2", "AC-3"}}.String())
+	assert.Equal(t, "CC6.1", FrameworkReference{Codes: []string{"CC6.1"}}.String())
+}
+
+func TestEvidenceTask_FrameworkReferences(t *testing.T) {
+	t.Parallel()
+
+	task := EvidenceTask{
+		Framework:      "SOC2",
+		FrameworkCodes: []FrameworkCode{{Framework: "SOC2", Code: "CC6.1"}},
+		RelatedControls: []Control{
+			{Framework: "SOC2", Codes: "CC6.1, CC6.3"},
+			{FrameworkCodes: []FrameworkCode{{Framework: "NIST 800-53", Code: "AC-2"}}},
+			{Framework: "HIPAA", Codes: "164.312(a)(1)"},
+			{Framework: "PCI DSS v4.0", Codes: "1.3.1"},
+		},
+	}
+
+	assert.Equal(t, []FrameworkReference{
+		{Framework: "SOC2", Codes: []string{"CC6.1", "CC6.3"}},
+		{Framework: "NIST 800-53", Codes: []string{"AC-2"}},
+		{Framework: "HIPAA", Codes: []string{"164.312(a)(1)"}},
+		{Framework: "PCI DSS v4.0", Codes: []string{"1.3.1"}},
+	}, task.FrameworkReferences())
+
+	tests := map[string]bool{
+		"SOC2":                true,
+		"soc 2":               true,
+		"nist80053":           true,
+		"NIST 800-53":         true,
+		"hipaa":               true,
+		"HIPAA Security Rule": true,
+		"pci":                 true,
+		"iso27001":            false,
+		"":                    false,
+	}
+	for name, want := range tests {
+		assert.Equal(t, want, task.HasFramework(name), name)
+	}
+}
+
+func TestControl_Requirements(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		control   Control
+		framework string
+		want      []string
+	}{
+		"direct codes": {
+			control: Control{ReferenceID: "IAM-1", FrameworkCodes: []FrameworkCode{
+				{Framework: "NIST 800-53", Code: "ac-3"},
+				{Framework: "NIST SP 800-53 Rev. 5", Code: "AC-2"},
+				{Framework: "SOC 2", Code: "CC8.1"},
+			}},
+			framework: "nist80053",
+			want:      []string{"AC-2", "AC-3"},
+		},
+		"own reference": {
+			control:   Control{ReferenceID: "cc6.1", Framework: "SOC 2"},
+			framework: "soc2",
+			want:      []string{"CC6.1"},
+		},
+		"crosswalked soc2 codes": {
+			control:   Control{ReferenceID: "AC-01", FrameworkCodes: []FrameworkCode{{Framework: "SOC2", Code: "CC8.1"}}},
+			framework: "pcidss",
+			want:      []string{"6.5.1", "6.5.2"},
+		},
+		"crosswalked reference": {
+			control:   Control{ReferenceID: "CC7.2", Framework: "SOC2"},
+			framework: "hipaa",
+			want:      []string{"164.308(a)(1)(ii)(D)", "164.308(a)(5)(ii)(C)", "164.312(b)"},
+		},
+		"unknown": {
+			control:   Control{ReferenceID: "HR-1", Framework: "Internal"},
+			framework: "iso27001",
+			want:      nil,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, tc.control.Requirements(tc.framework))
+		})
+	}
+}
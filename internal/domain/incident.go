@@ -0,0 +1,108 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Incident severities
+const (
+	IncidentSeverityLow      = "low"
+	IncidentSeverityMedium   = "medium"
+	IncidentSeverityHigh     = "high"
+	IncidentSeverityCritical = "critical"
+)
+
+// Incident statuses
+const (
+	IncidentStatusOpen   = "open"
+	IncidentStatusClosed = "closed"
+)
+
+// IncidentSeverities lists the valid severities, least severe first
+var IncidentSeverities = []string{IncidentSeverityLow, IncidentSeverityMedium, IncidentSeverityHigh, IncidentSeverityCritical}
+
+// IncidentStatuses lists the valid statuses
+var IncidentStatuses = []string{IncidentStatusOpen, IncidentStatusClosed}
+
+// Incident is an entry in the incident log. Like risks, incidents are
+// maintained locally rather than synced from a provider.
+type Incident struct {
+	ID          string `json:"id"` // INC-0001
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Category    string `json:"category,omitempty"`
+	Severity    string `json:"severity"`
+	Owner       string `json:"owner,omitempty"` // Incident lead
+	Status      string `json:"status"`
+	// Response timeline
+	DetectedAt     time.Time  `json:"detected_at"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+	// Outcome
+	RootCause  string `json:"root_cause,omitempty"`
+	Resolution string `json:"resolution,omitempty"`
+	// Lifecycle
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TimeToAcknowledge is how long the incident took to acknowledge, and false
+// if it has not been acknowledged
+func (i *Incident) TimeToAcknowledge() (time.Duration, bool) {
+	if i.AcknowledgedAt == nil {
+		return 0, false
+	}
+	return i.AcknowledgedAt.Sub(i.DetectedAt), true
+}
+
+// TimeToResolve is how long the incident took to resolve, and false if it
+// has not been resolved
+func (i *Incident) TimeToResolve() (time.Duration, bool) {
+	if i.ResolvedAt == nil {
+		return 0, false
+	}
+	return i.ResolvedAt.Sub(i.DetectedAt), true
+}
+
+// Validate checks the incident's title, severity, status and timeline
+func (i *Incident) Validate() error {
+	if i.Title == "" {
+		return fmt.Errorf("incident title is required")
+	}
+	if !slices.Contains(IncidentSeverities, i.Severity) {
+		return fmt.Errorf("invalid severity %q (must be one of: %v)", i.Severity, IncidentSeverities)
+	}
+	if !slices.Contains(IncidentStatuses, i.Status) {
+		return fmt.Errorf("invalid status %q (must be one of: %v)", i.Status, IncidentStatuses)
+	}
+	if i.DetectedAt.IsZero() {
+		return fmt.Errorf("incident detection time is required")
+	}
+	if i.AcknowledgedAt != nil && i.AcknowledgedAt.Before(i.DetectedAt) {
+		return fmt.Errorf("incident acknowledged before it was detected")
+	}
+	if i.ResolvedAt != nil && i.ResolvedAt.Before(i.DetectedAt) {
+		return fmt.Errorf("incident resolved before it was detected")
+	}
+	if i.Status == IncidentStatusClosed && i.ResolvedAt == nil {
+		return fmt.Errorf("closed incident needs a resolution time")
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncident_ResponseTimes(t *testing.T) {
+	t.Parallel()
+
+	detected := time.Date(2025, 9, 3, 10, 0, 0, 0, time.UTC)
+	acknowledged := detected.Add(15 * time.Minute)
+	resolved := detected.Add(26 * time.Hour)
+
+	incident := Incident{DetectedAt: detected}
+	_, ok := incident.TimeToAcknowledge()
+	assert.False(t, ok)
+	_, ok = incident.TimeToResolve()
+	assert.False(t, ok)
+
+	incident.AcknowledgedAt = &acknowledged
+	incident.ResolvedAt = &resolved
+	ack, ok := incident.TimeToAcknowledge()
+	assert.True(t, ok)
+	assert.Equal(t, 15*time.Minute, ack)
+	resolve, ok := incident.TimeToResolve()
+	assert.True(t, ok)
+	assert.Equal(t, 26*time.Hour, resolve)
+}
+
+func TestIncident_Validate(t *testing.T) {
+	t.Parallel()
+
+	detected := time.Date(2025, 9, 3, 10, 0, 0, 0, time.UTC)
+	earlier := detected.Add(-time.Hour)
+	later := detected.Add(time.Hour)
+	valid := Incident{Title: "API outage", Severity: IncidentSeverityHigh, Status: IncidentStatusOpen, DetectedAt: detected}
+
+	tests := map[string]struct {
+		modify func(*Incident)
+		err    string
+	}{
+		"valid":             {modify: func(*Incident) {}},
+		"closed resolved":   {modify: func(i *Incident) { i.Status, i.ResolvedAt = IncidentStatusClosed, &later }},
+		"no title":          {modify: func(i *Incident) { i.Title = "" }, err: "title is required"},
+		"bad severity":      {modify: func(i *Incident) { i.Severity = "sev1" }, err: `invalid severity "sev1"`},
+		"bad status":        {modify: func(i *Incident) { i.Status = "done" }, err: `invalid status "done"`},
+		"no detection":      {modify: func(i *Incident) { i.DetectedAt = time.Time{} }, err: "detection time is required"},
+		"early ack":         {modify: func(i *Incident) { i.AcknowledgedAt = &earlier }, err: "acknowledged before it was detected"},
+		"early resolution":  {modify: func(i *Incident) { i.ResolvedAt = &earlier }, err: "resolved before it was detected"},
+		"closed unresolved": {modify: func(i *Incident) { i.Status = IncidentStatusClosed }, err: "needs a resolution time"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			incident := valid
+			tc.modify(&incident)
+			err := incident.Validate()
+			if tc.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Policy acknowledgment sources
+const (
+	AcknowledgmentSourceCSV         = "csv"
+	AcknowledgmentSourceGoogleForms = "google-forms"
+	AcknowledgmentSourceLink        = "link"
+)
+
+// AcknowledgmentSources lists the valid acknowledgment sources
+var AcknowledgmentSources = []string{AcknowledgmentSourceCSV, AcknowledgmentSourceGoogleForms, AcknowledgmentSourceLink}
+
+// acknowledgmentPeriodPattern matches the periods acknowledgments are
+// collected for: a year, half, quarter or month (2025, 2025-H1, 2025-Q1, 2025-01)
+var acknowledgmentPeriodPattern = regexp.MustCompile(`^\d{4}(-(H[12]|Q[1-4]|0[1-9]|1[0-2]))?$`)
+
+// acknowledgmentPolicyPattern matches policy IDs and references, which name
+// the directory acknowledgments are stored in
+var acknowledgmentPolicyPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// PolicyAcknowledgment records that an employee acknowledged a policy
+type PolicyAcknowledgment struct {
+	Email          string    `json:"email"`
+	Name           string    `json:"name,omitempty"`
+	Version        string    `json:"version,omitempty"` // Policy version acknowledged
+	AcknowledgedAt time.Time `json:"acknowledged_at"`
+	Source         string    `json:"source"`
+}
+
+// PolicyAcknowledgments holds the acknowledgments of a policy collected for a
+// period, such as the annual acknowledgment campaign for 2025
+type PolicyAcknowledgments struct {
+	Policy          string                 `json:"policy"` // Authored policy ID or synced policy reference
+	Period          string                 `json:"period"`
+	Acknowledgments []PolicyAcknowledgment `json:"acknowledgments"`
+	UpdatedAt       time.Time              `json:"updated_at"`
+}
+
+// Add records an acknowledgment, keeping the earliest one per employee. It
+// returns false when the employee had already acknowledged the policy earlier.
+func (p *PolicyAcknowledgments) Add(ack PolicyAcknowledgment) bool {
+	ack.Email = NormalizeEmail(ack.Email)
+	for i, existing := range p.Acknowledgments {
+		if existing.Email != ack.Email {
+			continue
+		}
+		if !ack.AcknowledgedAt.Before(existing.AcknowledgedAt) {
+			return false
+		}
+		p.Acknowledgments[i] = ack
+		return true
+	}
+	p.Acknowledgments = append(p.Acknowledgments, ack)
+	sort.SliceStable(p.Acknowledgments, func(i, j int) bool {
+		return p.Acknowledgments[i].Email < p.Acknowledgments[j].Email
+	})
+	return true
+}
+
+// Find returns the employee's acknowledgment, if any
+func (p *PolicyAcknowledgments) Find(email string) (*PolicyAcknowledgment, bool) {
+	email = NormalizeEmail(email)
+	for i := range p.Acknowledgments {
+		if p.Acknowledgments[i].Email == email {
+			return &p.Acknowledgments[i], true
+		}
+	}
+	return nil, false
+}
+
+// Validate checks the policy, period and each acknowledgment
+func (p *PolicyAcknowledgments) Validate() error {
+	if err := ValidateAcknowledgmentScope(p.Policy, p.Period); err != nil {
+		return err
+	}
+	for _, ack := range p.Acknowledgments {
+		if !strings.Contains(ack.Email, "@") {
+			return fmt.Errorf("invalid acknowledgment email %q", ack.Email)
+		}
+		if ack.AcknowledgedAt.IsZero() {
+			return fmt.Errorf("acknowledgment by %s has no date", ack.Email)
+		}
+		if !slices.Contains(AcknowledgmentSources, ack.Source) {
+			return fmt.Errorf("invalid acknowledgment source %q (must be one of: %v)", ack.Source, AcknowledgmentSources)
+		}
+	}
+	return nil
+}
+
+// ValidateAcknowledgmentScope checks the policy ID and period acknowledgments
+// are recorded under
+func ValidateAcknowledgmentScope(policy, period string) error {
+	if policy == "" {
+		return fmt.Errorf("acknowledgment policy is required")
+	}
+	if !acknowledgmentPolicyPattern.MatchString(policy) {
+		return fmt.Errorf("invalid acknowledgment policy %q", policy)
+	}
+	return ValidateAcknowledgmentPeriod(period)
+}
+
+// ValidateAcknowledgmentPeriod checks that period is a year, half, quarter or month
+func ValidateAcknowledgmentPeriod(period string) error {
+	if !acknowledgmentPeriodPattern.MatchString(period) {
+		return fmt.Errorf("invalid acknowledgment period %q: use e.g. 2025, 2025-H1, 2025-Q1 or 2025-01", period)
+	}
+	return nil
+}
+
+// Employee is a person on the roster expected to acknowledge policies
+type Employee struct {
+	Email      string `json:"email"`
+	Name       string `json:"name,omitempty"`
+	Department string `json:"department,omitempty"`
+}
+
+// EmployeeRoster is the list of employees, imported from an HRIS export,
+// against which acknowledgment rates are measured
+type EmployeeRoster struct {
+	Employees  []Employee `json:"employees"`
+	ImportedAt time.Time  `json:"imported_at"`
+	Source     string     `json:"source,omitempty"` // File the roster was imported from
+}
+
+// AcknowledgmentRate is the coverage of a policy's acknowledgments against
+// the roster for a period
+type AcknowledgmentRate struct {
+	Policy       string     `json:"policy"`
+	Period       string     `json:"period"`
+	Expected     int        `json:"expected"`
+	Acknowledged int        `json:"acknowledged"`
+	Rate         float64    `json:"rate"` // Percentage of the roster, 0 without a roster
+	Pending      []Employee `json:"pending"`
+	// OffRoster counts acknowledgments by people not on the roster, such as
+	// departed employees or contractors
+	OffRoster int `json:"off_roster"`
+}
+
+// CalculateAcknowledgmentRate measures acknowledgments against the roster.
+// Without a roster, every acknowledgment counts and the rate is 0.
+func CalculateAcknowledgmentRate(acks *PolicyAcknowledgments, roster *EmployeeRoster) AcknowledgmentRate {
+	rate := AcknowledgmentRate{Policy: acks.Policy, Period: acks.Period, Pending: []Employee{}}
+	if roster == nil || len(roster.Employees) == 0 {
+		rate.Acknowledged = len(acks.Acknowledgments)
+		return rate
+	}
+
+	onRoster := map[string]bool{}
+	for _, employee := range roster.Employees {
+		email := NormalizeEmail(employee.Email)
+		onRoster[email] = true
+		if _, ok := acks.Find(email); ok {
+			rate.Acknowledged++
+		} else {
+			rate.Pending = append(rate.Pending, employee)
+		}
+	}
+	for _, ack := range acks.Acknowledgments {
+		if !onRoster[ack.Email] {
+			rate.OffRoster++
+		}
+	}
+	rate.Expected = len(roster.Employees)
+	rate.Rate = float64(rate.Acknowledged) * 100 / float64(rate.Expected)
+	return rate
+}
+
+// NormalizeEmail lower-cases and trims an email address for comparison
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,94 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyAcknowledgments_Add(t *testing.T) {
+	t.Parallel()
+
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+	acks := PolicyAcknowledgments{Policy: "acceptable-use-policy", Period: "2025"}
+
+	assert.True(t, acks.Add(PolicyAcknowledgment{Email: " Sam@Example.com", AcknowledgedAt: day(5), Source: AcknowledgmentSourceCSV}))
+	assert.True(t, acks.Add(PolicyAcknowledgment{Email: "alex@example.com", AcknowledgedAt: day(2), Source: AcknowledgmentSourceLink}))
+	assert.False(t, acks.Add(PolicyAcknowledgment{Email: "sam@example.com", AcknowledgedAt: day(9), Source: AcknowledgmentSourceLink}))
+	assert.True(t, acks.Add(PolicyAcknowledgment{Email: "SAM@example.com", AcknowledgedAt: day(1), Source: AcknowledgmentSourceGoogleForms}))
+
+	require.Len(t, acks.Acknowledgments, 2)
+	assert.Equal(t, "alex@example.com", acks.Acknowledgments[0].Email)
+	sam, ok := acks.Find("Sam@example.com")
+	require.True(t, ok)
+	assert.Equal(t, day(1), sam.AcknowledgedAt)
+	assert.Equal(t, AcknowledgmentSourceGoogleForms, sam.Source)
+	_, ok = acks.Find("kim@example.com")
+	assert.False(t, ok)
+}
+
+func TestPolicyAcknowledgments_Validate(t *testing.T) {
+	t.Parallel()
+
+	valid := PolicyAcknowledgment{Email: "alex@example.com", AcknowledgedAt: time.Now(), Source: AcknowledgmentSourceCSV}
+	tests := map[string]struct {
+		acks    PolicyAcknowledgments
+		wantErr string
+	}{
+		"valid year":    {acks: PolicyAcknowledgments{Policy: "p", Period: "2025", Acknowledgments: []PolicyAcknowledgment{valid}}},
+		"valid quarter": {acks: PolicyAcknowledgments{Policy: "p", Period: "2025-Q3"}},
+		"valid month":   {acks: PolicyAcknowledgments{Policy: "p", Period: "2025-12"}},
+		"no policy":     {acks: PolicyAcknowledgments{Period: "2025"}, wantErr: "policy is required"},
+		"unsafe policy": {acks: PolicyAcknowledgments{Policy: "../etc", Period: "2025"}, wantErr: "invalid acknowledgment policy"},
+		"bad period":    {acks: PolicyAcknowledgments{Policy: "p", Period: "2025-13"}, wantErr: "invalid acknowledgment period"},
+		"bad email": {acks: PolicyAcknowledgments{Policy: "p", Period: "2025",
+			Acknowledgments: []PolicyAcknowledgment{{Email: "alex", AcknowledgedAt: time.Now(), Source: AcknowledgmentSourceCSV}}},
+			wantErr: `invalid acknowledgment email "alex"`},
+		"bad source": {acks: PolicyAcknowledgments{Policy: "p", Period: "2025",
+			Acknowledgments: []PolicyAcknowledgment{{Email: "alex@example.com", AcknowledgedAt: time.Now(), Source: "email"}}},
+			wantErr: `invalid acknowledgment source "email"`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.acks.Validate()
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestCalculateAcknowledgmentRate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	acks := &PolicyAcknowledgments{Policy: "acceptable-use-policy", Period: "2025"}
+	for _, email := range []string{"alex@example.com", "sam@example.com", "former@example.com"} {
+		acks.Add(PolicyAcknowledgment{Email: email, AcknowledgedAt: now, Source: AcknowledgmentSourceCSV})
+	}
+	roster := &EmployeeRoster{Employees: []Employee{
+		{Email: "Alex@example.com"}, {Email: "sam@example.com"}, {Email: "kim@example.com", Name: "Kim"}, {Email: "lee@example.com"},
+	}}
+
+	rate := CalculateAcknowledgmentRate(acks, roster)
+	assert.Equal(t, 4, rate.Expected)
+	assert.Equal(t, 2, rate.Acknowledged)
+	assert.InDelta(t, 50.0, rate.Rate, 0.001)
+	assert.Equal(t, 1, rate.OffRoster)
+	require.Len(t, rate.Pending, 2)
+	assert.Equal(t, "Kim", rate.Pending[0].Name)
+
+	rate = CalculateAcknowledgmentRate(acks, nil)
+	assert.Equal(t, 0, rate.Expected)
+	assert.Equal(t, 3, rate.Acknowledged)
+	assert.Zero(t, rate.Rate)
+}
@@ -0,0 +1,146 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Likelihood and impact are rated on a 1-5 scale, so scores range from 1 to 25
+const (
+	RiskRatingMin = 1
+	RiskRatingMax = 5
+)
+
+// Risk treatments
+const (
+	RiskTreatmentMitigate = "mitigate"
+	RiskTreatmentAccept   = "accept"
+	RiskTreatmentTransfer = "transfer"
+	RiskTreatmentAvoid    = "avoid"
+)
+
+// Risk statuses
+const (
+	RiskStatusOpen     = "open"
+	RiskStatusTreating = "treating"
+	RiskStatusAccepted = "accepted"
+	RiskStatusClosed   = "closed"
+)
+
+// RiskTreatments lists the valid treatments
+var RiskTreatments = []string{RiskTreatmentMitigate, RiskTreatmentAccept, RiskTreatmentTransfer, RiskTreatmentAvoid}
+
+// RiskStatuses lists the valid statuses
+var RiskStatuses = []string{RiskStatusOpen, RiskStatusTreating, RiskStatusAccepted, RiskStatusClosed}
+
+// Risk is an entry in the risk register. Unlike policies and controls, risks
+// are maintained locally rather than synced from a provider.
+type Risk struct {
+	ID          string `json:"id"` // RISK-0001
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Category    string `json:"category,omitempty"`
+	Owner       string `json:"owner,omitempty"`
+	Status      string `json:"status"`
+	// Inherent rating, before treatment
+	Likelihood int `json:"likelihood"`
+	Impact     int `json:"impact"`
+	// Treatment
+	Treatment          string `json:"treatment"`
+	TreatmentPlan      string `json:"treatment_plan,omitempty"`
+	ResidualLikelihood int    `json:"residual_likelihood,omitempty"` // Rating expected once treated; 0 when not assessed
+	ResidualImpact     int    `json:"residual_impact,omitempty"`
+	// Relationships, by reference ID
+	Controls      []string `json:"controls,omitempty"`       // Controls that treat the risk (CC6.1)
+	EvidenceTasks []string `json:"evidence_tasks,omitempty"` // Evidence tasks showing the treatment works (ET-0001)
+	// Lifecycle
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Score is the inherent risk score, likelihood times impact
+func (r *Risk) Score() int {
+	return r.Likelihood * r.Impact
+}
+
+// ResidualScore is the score once treated, or 0 when not assessed
+func (r *Risk) ResidualScore() int {
+	return r.ResidualLikelihood * r.ResidualImpact
+}
+
+// Level is the severity band of the inherent score
+func (r *Risk) Level() string {
+	return RiskLevel(r.Score())
+}
+
+// Validate checks the ratings, treatment and status
+func (r *Risk) Validate() error {
+	if r.Title == "" {
+		return fmt.Errorf("risk title is required")
+	}
+	if err := validateRating("likelihood", r.Likelihood, false); err != nil {
+		return err
+	}
+	if err := validateRating("impact", r.Impact, false); err != nil {
+		return err
+	}
+	if err := validateRating("residual likelihood", r.ResidualLikelihood, true); err != nil {
+		return err
+	}
+	if err := validateRating("residual impact", r.ResidualImpact, true); err != nil {
+		return err
+	}
+	if (r.ResidualLikelihood == 0) != (r.ResidualImpact == 0) {
+		return fmt.Errorf("residual likelihood and impact must be set together")
+	}
+	if !slices.Contains(RiskTreatments, r.Treatment) {
+		return fmt.Errorf("invalid treatment %q (must be one of: %v)", r.Treatment, RiskTreatments)
+	}
+	if !slices.Contains(RiskStatuses, r.Status) {
+		return fmt.Errorf("invalid status %q (must be one of: %v)", r.Status, RiskStatuses)
+	}
+	return nil
+}
+
+// RiskLevel bands a score on the 5x5 matrix: low (1-4), medium (5-9),
+// high (10-15) and critical (16-25)
+func RiskLevel(score int) string {
+	switch {
+	case score >= 16:
+		return "critical"
+	case score >= 10:
+		return "high"
+	case score >= 5:
+		return "medium"
+	case score >= 1:
+		return "low"
+	default:
+		return ""
+	}
+}
+
+func validateRating(name string, value int, optional bool) error {
+	if optional && value == 0 {
+		return nil
+	}
+	if value < RiskRatingMin || value > RiskRatingMax {
+		return fmt.Errorf("%s must be between %d and %d, got %d", name, RiskRatingMin, RiskRatingMax, value)
+	}
+	return nil
+}
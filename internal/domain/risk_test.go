@@ -0,0 +1,64 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRisk_Scores(t *testing.T) {
+	t.Parallel()
+
+	risk := Risk{Likelihood: 4, Impact: 5, ResidualLikelihood: 1, ResidualImpact: 5}
+	assert.Equal(t, 20, risk.Score())
+	assert.Equal(t, "critical", risk.Level())
+	assert.Equal(t, 5, risk.ResidualScore())
+
+	assert.Equal(t, 0, (&Risk{Likelihood: 2, Impact: 2}).ResidualScore(), "residual not assessed")
+
+	levels := map[int]string{0: "", 1: "low", 4: "low", 5: "medium", 9: "medium", 10: "high", 15: "high", 16: "critical", 25: "critical"}
+	for score, want := range levels {
+		assert.Equal(t, want, RiskLevel(score), "score %d", score)
+	}
+}
+
+func TestRisk_Validate(t *testing.T) {
+	t.Parallel()
+
+	valid := func() Risk {
+		return Risk{Title: "Leaked credentials", Likelihood: 3, Impact: 5,
+			Treatment: RiskTreatmentMitigate, Status: RiskStatusOpen}
+	}
+
+	tests := map[string]struct {
+		mutate  func(*Risk)
+		wantErr string
+	}{
+		"valid":             {mutate: func(r *Risk) {}},
+		"with residual":     {mutate: func(r *Risk) { r.ResidualLikelihood, r.ResidualImpact = 1, 5 }},
+		"missing title":     {mutate: func(r *Risk) { r.Title = "" }, wantErr: "title is required"},
+		"likelihood zero":   {mutate: func(r *Risk) { r.Likelihood = 0 }, wantErr: "likelihood must be between 1 and 5"},
+		"impact too high":   {mutate: func(r *Risk) { r.Impact = 6 }, wantErr: "impact must be between 1 and 5"},
+		"residual half set": {mutate: func(r *Risk) { r.ResidualImpact = 2 }, wantErr: "must be set together"},
+		"residual invalid":  {mutate: func(r *Risk) { r.ResidualLikelihood, r.ResidualImpact = 7, 1 }, wantErr: "residual likelihood"},
+		"unknown treatment": {mutate: func(r *Risk) { r.Treatment = "ignore" }, wantErr: `invalid treatment "ignore"`},
+		"unknown status":    {mutate: func(r *Risk) { r.Status = "done" }, wantErr: `invalid status "done"`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			risk := valid()
+			tc.mutate(&risk)
+			err := risk.Validate()
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// VendorDueSoonDays is how far ahead a review or SOC report expiry is flagged as coming due
+const VendorDueSoonDays = 30
+
+// Vendor criticality, by the impact of losing the vendor's service
+const (
+	VendorCriticalityLow      = "low"
+	VendorCriticalityMedium   = "medium"
+	VendorCriticalityHigh     = "high"
+	VendorCriticalityCritical = "critical"
+)
+
+// Vendor data access, by the most sensitive data the vendor can reach
+const (
+	VendorDataNone         = "none"
+	VendorDataInternal     = "internal"
+	VendorDataConfidential = "confidential"
+	VendorDataRestricted   = "restricted"
+)
+
+// Vendor statuses
+const (
+	VendorStatusOnboarding = "onboarding"
+	VendorStatusActive     = "active"
+	VendorStatusOffboarded = "offboarded"
+)
+
+// Vendor review statuses
+const (
+	VendorReviewCurrent = "current"
+	VendorReviewDueSoon = "due_soon"
+	VendorReviewOverdue = "overdue"
+	VendorReviewNever   = "never_reviewed"
+)
+
+// SOC report statuses
+const (
+	SOCReportValid    = "valid"
+	SOCReportExpiring = "expiring"
+	SOCReportExpired  = "expired"
+	SOCReportMissing  = "missing"
+)
+
+// VendorCriticalities lists the valid criticalities, least critical first
+var VendorCriticalities = []string{VendorCriticalityLow, VendorCriticalityMedium, VendorCriticalityHigh, VendorCriticalityCritical}
+
+// VendorDataAccessLevels lists the valid data access levels, least sensitive first
+var VendorDataAccessLevels = []string{VendorDataNone, VendorDataInternal, VendorDataConfidential, VendorDataRestricted}
+
+// VendorStatuses lists the valid statuses
+var VendorStatuses = []string{VendorStatusOnboarding, VendorStatusActive, VendorStatusOffboarded}
+
+// Vendor is an entry in the vendor inventory. Like risks, vendors are
+// maintained locally rather than synced from a provider.
+type Vendor struct {
+	ID          string `json:"id"` // VEN-0001
+	Name        string `json:"name"`
+	Service     string `json:"service,omitempty"` // What the vendor provides
+	Website     string `json:"website,omitempty"`
+	Owner       string `json:"owner,omitempty"` // Person responsible for the relationship
+	Status      string `json:"status"`
+	Criticality string `json:"criticality"`
+	DataAccess  string `json:"data_access"`
+	// Assurance
+	SOCReport       string     `json:"soc_report,omitempty"` // e.g. "SOC 2 Type II"
+	SOCReportExpiry *time.Time `json:"soc_report_expiry,omitempty"`
+	// Reviews
+	LastReviewed *time.Time `json:"last_reviewed,omitempty"`
+	NextReview   *time.Time `json:"next_review,omitempty"`
+	Notes        string     `json:"notes,omitempty"`
+	// Lifecycle
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ReviewStatus reports whether the vendor's review is current, due within
+// VendorDueSoonDays, overdue, or has never happened
+func (v *Vendor) ReviewStatus(now time.Time) string {
+	switch {
+	case v.NextReview == nil && v.LastReviewed == nil:
+		return VendorReviewNever
+	case v.NextReview == nil:
+		return VendorReviewCurrent
+	case now.After(*v.NextReview):
+		return VendorReviewOverdue
+	case now.AddDate(0, 0, VendorDueSoonDays).After(*v.NextReview):
+		return VendorReviewDueSoon
+	default:
+		return VendorReviewCurrent
+	}
+}
+
+// SOCReportStatus reports whether the vendor's SOC report is valid, expires
+// within VendorDueSoonDays, has expired, or is missing
+func (v *Vendor) SOCReportStatus(now time.Time) string {
+	switch {
+	case v.SOCReportExpiry == nil:
+		return SOCReportMissing
+	case now.After(*v.SOCReportExpiry):
+		return SOCReportExpired
+	case now.AddDate(0, 0, VendorDueSoonDays).After(*v.SOCReportExpiry):
+		return SOCReportExpiring
+	default:
+		return SOCReportValid
+	}
+}
+
+// NeedsAttention reports whether an active vendor's review or SOC report is
+// overdue, expired or coming due. Vendors without data access need no SOC report.
+func (v *Vendor) NeedsAttention(now time.Time) bool {
+	if v.Status == VendorStatusOffboarded {
+		return false
+	}
+	if review := v.ReviewStatus(now); review != VendorReviewCurrent {
+		return true
+	}
+	return v.DataAccess != VendorDataNone && v.SOCReportStatus(now) != SOCReportValid
+}
+
+// Validate checks the vendor's name, criticality, data access and status
+func (v *Vendor) Validate() error {
+	if v.Name == "" {
+		return fmt.Errorf("vendor name is required")
+	}
+	if !slices.Contains(VendorCriticalities, v.Criticality) {
+		return fmt.Errorf("invalid criticality %q (must be one of: %v)", v.Criticality, VendorCriticalities)
+	}
+	if !slices.Contains(VendorDataAccessLevels, v.DataAccess) {
+		return fmt.Errorf("invalid data access %q (must be one of: %v)", v.DataAccess, VendorDataAccessLevels)
+	}
+	if !slices.Contains(VendorStatuses, v.Status) {
+		return fmt.Errorf("invalid status %q (must be one of: %v)", v.Status, VendorStatuses)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVendor_Statuses(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	date := func(days int) *time.Time {
+		d := now.AddDate(0, 0, days)
+		return &d
+	}
+
+	tests := map[string]struct {
+		vendor    Vendor
+		review    string
+		soc       string
+		attention bool
+	}{
+		"current with valid report": {
+			vendor: Vendor{Status: VendorStatusActive, DataAccess: VendorDataConfidential,
+				LastReviewed: date(-100), NextReview: date(265), SOCReportExpiry: date(200)},
+			review: VendorReviewCurrent, soc: SOCReportValid,
+		},
+		"never reviewed": {
+			vendor: Vendor{Status: VendorStatusActive, DataAccess: VendorDataNone},
+			review: VendorReviewNever, soc: SOCReportMissing, attention: true,
+		},
+		"review due soon": {
+			vendor: Vendor{Status: VendorStatusActive, DataAccess: VendorDataNone, NextReview: date(10)},
+			review: VendorReviewDueSoon, soc: SOCReportMissing, attention: true,
+		},
+		"review overdue": {
+			vendor: Vendor{Status: VendorStatusActive, DataAccess: VendorDataNone, NextReview: date(-1)},
+			review: VendorReviewOverdue, soc: SOCReportMissing, attention: true,
+		},
+		"reviewed without next date": {
+			vendor: Vendor{Status: VendorStatusActive, DataAccess: VendorDataNone, LastReviewed: date(-10)},
+			review: VendorReviewCurrent, soc: SOCReportMissing,
+		},
+		"report expiring": {
+			vendor: Vendor{Status: VendorStatusActive, DataAccess: VendorDataRestricted,
+				NextReview: date(100), SOCReportExpiry: date(29)},
+			review: VendorReviewCurrent, soc: SOCReportExpiring, attention: true,
+		},
+		"report expired": {
+			vendor: Vendor{Status: VendorStatusActive, DataAccess: VendorDataInternal,
+				NextReview: date(100), SOCReportExpiry: date(-1)},
+			review: VendorReviewCurrent, soc: SOCReportExpired, attention: true,
+		},
+		"offboarded": {
+			vendor: Vendor{Status: VendorStatusOffboarded, DataAccess: VendorDataRestricted, NextReview: date(-100)},
+			review: VendorReviewOverdue, soc: SOCReportMissing,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.review, tc.vendor.ReviewStatus(now))
+			assert.Equal(t, tc.soc, tc.vendor.SOCReportStatus(now))
+			assert.Equal(t, tc.attention, tc.vendor.NeedsAttention(now))
+		})
+	}
+}
+
+func TestVendor_Validate(t *testing.T) {
+	t.Parallel()
+
+	vendor := Vendor{Name: "Acme", Status: VendorStatusActive, Criticality: VendorCriticalityHigh, DataAccess: VendorDataNone}
+	assert.NoError(t, vendor.Validate())
+
+	invalid := vendor
+	invalid.Name = ""
+	assert.ErrorContains(t, invalid.Validate(), "name is required")
+	invalid = vendor
+	invalid.DataAccess = "secret"
+	assert.ErrorContains(t, invalid.Validate(), `invalid data access "secret"`)
+	invalid = vendor
+	invalid.Status = "gone"
+	assert.ErrorContains(t, invalid.Validate(), `invalid status "gone"`)
+}
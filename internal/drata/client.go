@@ -0,0 +1,226 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package drata uploads evidence files to Drata controls as external
+// evidence through the Drata public API.
+package drata
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+)
+
+// DefaultBaseURL is the Drata public API used when none is configured
+const DefaultBaseURL = "https://public-api.drata.com"
+
+// MaxFileSize is the largest file Drata accepts as external evidence
+const MaxFileSize = 25 * 1024 * 1024 // 25MB
+
+// Renewal schedule types of external evidence
+const (
+	RenewalNone        = "NONE"
+	RenewalOneMonth    = "ONE_MONTH"
+	RenewalThreeMonths = "THREE_MONTHS"
+	RenewalSixMonths   = "SIX_MONTHS"
+	RenewalOneYear     = "ONE_YEAR"
+)
+
+// Client uploads external evidence to Drata controls
+type Client struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewClient creates a Drata API client. The http client defaults to one with
+// the configured timeout.
+func NewClient(cfg config.DrataConfig, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		timeout := cfg.Timeout
+		if timeout <= 0 {
+			timeout = 30 * time.Second
+		}
+		httpClient = &http.Client{Timeout: timeout}
+	}
+	baseURL := strings.TrimRight(cfg.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{
+		baseURL:    baseURL,
+		apiKey:     cfg.APIKey,
+		httpClient: httpClient,
+	}
+}
+
+// EvidenceRequest is a file to upload as external evidence of a control
+type EvidenceRequest struct {
+	ControlID       string    // Drata control the evidence is attached to
+	Name            string    // Evidence name shown in Drata
+	Description     string    // Optional description shown in Drata
+	Filename        string    // Name of the uploaded file
+	Content         []byte    // File content
+	ContentType     string    // MIME type of the file
+	CreationDate    time.Time // Date the evidence was collected
+	RenewalSchedule string    // One of the Renewal* schedule types; RenewalNone when empty
+}
+
+// EvidenceResponse is Drata's record of uploaded external evidence
+type EvidenceResponse struct {
+	ID          json.Number `json:"id"`
+	Name        string      `json:"name,omitempty"`
+	RenewalDate string      `json:"renewalDate,omitempty"`
+}
+
+// UploadExternalEvidence uploads a file as external evidence of a control
+func (c *Client) UploadExternalEvidence(ctx context.Context, req *EvidenceRequest) (*EvidenceResponse, error) {
+	if c.apiKey == "" {
+		return nil, fmt.Errorf("drata API key not configured - set DRATA_API_KEY")
+	}
+	if req.ControlID == "" {
+		return nil, fmt.Errorf("control ID is required")
+	}
+	if req.Filename == "" {
+		return nil, fmt.Errorf("filename is required")
+	}
+	if len(req.Content) > MaxFileSize {
+		return nil, fmt.Errorf("file size %d bytes exceeds maximum allowed size of %d bytes (25MB)", len(req.Content), MaxFileSize)
+	}
+
+	creation := req.CreationDate
+	if creation.IsZero() {
+		creation = time.Now()
+	}
+	schedule := req.RenewalSchedule
+	if schedule == "" {
+		schedule = RenewalNone
+	}
+	name := req.Name
+	if name == "" {
+		name = req.Filename
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	fields := [][2]string{
+		{"name", name},
+		{"description", req.Description},
+		{"creationDate", creation.Format("2006-01-02")},
+		{"renewalScheduleType", schedule},
+	}
+	if renewal, ok := RenewalDate(creation, schedule); ok {
+		fields = append(fields, [2]string{"renewalDate", renewal.Format("2006-01-02")})
+	}
+	for _, field := range fields {
+		if field[1] == "" {
+			continue
+		}
+		if err := writer.WriteField(field[0], field[1]); err != nil {
+			return nil, fmt.Errorf("failed to write %s field: %w", field[0], err)
+		}
+	}
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`,
+		strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(req.Filename)))
+	contentType := req.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create form file: %w", err)
+	}
+	if _, err := part.Write(req.Content); err != nil {
+		return nil, fmt.Errorf("failed to copy file content: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/public/controls/%s/external-evidence", c.baseURL, url.PathEscape(req.ControlID))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Drata request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
+	httpReq.Header.Set("Content-Type", writer.FormDataContentType())
+	httpReq.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload to Drata: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("drata upload failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	result := &EvidenceResponse{}
+	if len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return nil, fmt.Errorf("failed to parse Drata response: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// RenewalSchedule returns the renewal schedule type matching an evidence
+// task's collection interval, such as "quarter" or "year"
+func RenewalSchedule(interval string) string {
+	switch strings.ToLower(interval) {
+	case "year", "annual", "annually":
+		return RenewalOneYear
+	case "six_month", "semi-annual", "semiannual":
+		return RenewalSixMonths
+	case "quarter", "quarterly":
+		return RenewalThreeMonths
+	case "month", "monthly":
+		return RenewalOneMonth
+	default:
+		return RenewalNone
+	}
+}
+
+// RenewalDate returns when evidence created on the date is due for renewal
+// under the schedule; ok is false for RenewalNone and unknown schedules
+func RenewalDate(created time.Time, schedule string) (time.Time, bool) {
+	months := map[string]int{
+		RenewalOneMonth:    1,
+		RenewalThreeMonths: 3,
+		RenewalSixMonths:   6,
+		RenewalOneYear:     12,
+	}[schedule]
+	if months == 0 {
+		return time.Time{}, false
+	}
+	return created.AddDate(0, months, 0), true
+}
@@ -0,0 +1,128 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package drata
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_UploadExternalEvidence(t *testing.T) {
+	t.Parallel()
+
+	var received map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer key-1" {
+			http.Error(w, `{"message":"Unauthorized"}`, http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/public/controls/42/external-evidence" {
+			http.Error(w, `{"message":"Control not found"}`, http.StatusNotFound)
+			return
+		}
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		content, _ := io.ReadAll(file)
+		received = map[string]string{
+			"name":         r.FormValue("name"),
+			"description":  r.FormValue("description"),
+			"creation":     r.FormValue("creationDate"),
+			"schedule":     r.FormValue("renewalScheduleType"),
+			"renewal":      r.FormValue("renewalDate"),
+			"filename":     header.Filename,
+			"content_type": header.Header.Get("Content-Type"),
+			"content":      string(content),
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": 9001, "name": "Access review", "renewalDate": "2026-01-01"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	upload := EvidenceRequest{
+		ControlID:       "42",
+		Name:            "Access review",
+		Description:     "ET-0001 2025-Q4",
+		Filename:        "access_review.csv",
+		Content:         []byte("user,role\n"),
+		ContentType:     "text/csv",
+		CreationDate:    time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+		RenewalSchedule: RenewalThreeMonths,
+	}
+
+	client := NewClient(config.DrataConfig{BaseURL: server.URL + "/", APIKey: "key-1"}, server.Client())
+	resp, err := client.UploadExternalEvidence(context.Background(), &upload)
+	require.NoError(t, err)
+	assert.Equal(t, "9001", resp.ID.String())
+	assert.Equal(t, map[string]string{
+		"name":         "Access review",
+		"description":  "ET-0001 2025-Q4",
+		"creation":     "2025-10-01",
+		"schedule":     "THREE_MONTHS",
+		"renewal":      "2026-01-01",
+		"filename":     "access_review.csv",
+		"content_type": "text/csv",
+		"content":      "user,role\n",
+	}, received)
+
+	tests := map[string]struct {
+		apiKey    string
+		controlID string
+		err       string
+	}{
+		"no key":          {controlID: "42", err: "drata API key not configured"},
+		"wrong key":       {apiKey: "key-2", controlID: "42", err: "drata upload failed with status 401"},
+		"no control":      {apiKey: "key-1", err: "control ID is required"},
+		"unknown control": {apiKey: "key-1", controlID: "7", err: `drata upload failed with status 404: {"message":"Control not found"}`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			req := upload
+			req.ControlID = tc.controlID
+			client := NewClient(config.DrataConfig{BaseURL: server.URL, APIKey: tc.apiKey}, server.Client())
+			_, err := client.UploadExternalEvidence(context.Background(), &req)
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestRenewalSchedule(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		schedule string
+		renewal  string
+	}{
+		"year":      {schedule: RenewalOneYear, renewal: "2026-01-31"},
+		"Quarterly": {schedule: RenewalThreeMonths, renewal: "2025-05-01"},
+		"six_month": {schedule: RenewalSixMonths, renewal: "2025-07-31"},
+		"month":     {schedule: RenewalOneMonth, renewal: "2025-03-03"},
+		"week":      {schedule: RenewalNone},
+		"":          {schedule: RenewalNone},
+	}
+
+	for interval, tc := range tests {
+		t.Run(interval, func(t *testing.T) {
+			t.Parallel()
+			schedule := RenewalSchedule(interval)
+			assert.Equal(t, tc.schedule, schedule)
+			renewal, ok := RenewalDate(created, schedule)
+			assert.Equal(t, tc.renewal != "", ok)
+			if ok {
+				assert.Equal(t, tc.renewal, renewal.Format("2006-01-02"))
+			}
+		})
+	}
+}
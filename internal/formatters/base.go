@@ -40,6 +40,17 @@ func NewBaseFormatter(interpolator interpolation.Interpolator) *BaseFormatter {
 	}
 }
 
+// WithScope returns a formatter interpolating with the variables of a
+// document's scope, or the formatter itself when its interpolator has no
+// scopes
+func (bf *BaseFormatter) WithScope(scope interpolation.Scope) *BaseFormatter {
+	scoped, ok := bf.interpolator.(interpolation.ScopedInterpolator)
+	if !ok {
+		return bf
+	}
+	return &BaseFormatter{interpolator: scoped.WithScope(scope), mdFormatter: bf.mdFormatter}
+}
+
 // InterpolateText applies variable interpolation to simple text fields
 func (bf *BaseFormatter) InterpolateText(text string) string {
 	if interpolated, err := bf.interpolator.Interpolate(text); err == nil {
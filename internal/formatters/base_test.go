@@ -19,6 +19,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/grctool/grctool/internal/domain"
 	"github.com/grctool/grctool/internal/interpolation"
 )
 
@@ -443,3 +444,51 @@ func TestBaseFormatter_SanitizeFilename(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatters_InterpolationScopes(t *testing.T) {
+	interpolator := interpolation.NewStandardInterpolator(interpolation.InterpolatorConfig{
+		Variables:          map[string]string{"audit.firm": "Internal Audit"},
+		FrameworkVariables: map[string]map[string]string{"SOC 2": {"audit.firm": "Prescient Assurance"}},
+		TaskVariables:      map[string]map[string]string{"ET-0047": {"audit.firm": "Coalfire"}},
+		Enabled:            true,
+		OnMissingVariable:  interpolation.MissingVariableIgnore,
+	})
+
+	taskFormatter := NewEvidenceTaskFormatterWithInterpolation(interpolator)
+	tasks := map[string]struct {
+		task     *domain.EvidenceTask
+		expected string
+	}{
+		"task scope":      {&domain.EvidenceTask{ID: "1", ReferenceID: "ET-0047", Name: "Pen test", Framework: "SOC2", Description: "Reviewed by {{audit.firm}}"}, "Reviewed by Coalfire"},
+		"framework scope": {&domain.EvidenceTask{ID: "2", ReferenceID: "ET-0001", Name: "Access review", Framework: "SOC2", Description: "Reviewed by {{audit.firm}}"}, "Reviewed by Prescient Assurance"},
+		"global":          {&domain.EvidenceTask{ID: "3", ReferenceID: "ET-0002", Name: "Backups", Framework: "HIPAA", Description: "Reviewed by {{audit.firm}}"}, "Reviewed by Internal Audit"},
+	}
+	for name, tc := range tasks {
+		t.Run(name, func(t *testing.T) {
+			if doc := taskFormatter.ToDocumentMarkdown(tc.task); !strings.Contains(doc, tc.expected) {
+				t.Errorf("Expected document to contain %q, got:\n%s", tc.expected, doc)
+			}
+		})
+	}
+
+	controlFormatter := NewControlFormatterWithInterpolation(interpolator)
+	control := &domain.Control{ID: "10", Name: "Audits", Framework: "soc2", Description: "Audited by {{audit.firm}}"}
+	if doc := controlFormatter.ToDocumentMarkdown(control); !strings.Contains(doc, "Audited by Prescient Assurance") {
+		t.Errorf("Expected the control's framework variables, got:\n%s", doc)
+	}
+
+	// Interpolators without scopes keep formatting with their own variables
+	plain := NewBaseFormatter(noScopeInterpolator{})
+	if got := plain.WithScope(interpolation.Scope{TaskRef: "ET-0047"}).InterpolateText("x"); got != "x!" {
+		t.Errorf("Expected the unscoped interpolator, got %q", got)
+	}
+}
+
+// noScopeInterpolator is an Interpolator without scopes
+type noScopeInterpolator struct{}
+
+func (noScopeInterpolator) Interpolate(text string) (string, error) { return text + "!", nil }
+
+func (noScopeInterpolator) InterpolateWithContext(text string) (string, map[string]string, error) {
+	return text + "!", nil, nil
+}
@@ -51,8 +51,15 @@ func NewControlFormatterWithInterpolation(interpolator interpolation.Interpolato
 	}
 }
 
+// scoped returns a formatter interpolating with the variables of the
+// control's framework
+func (cf *ControlFormatter) scoped(control *domain.Control) *ControlFormatter {
+	return &ControlFormatter{baseFormatter: cf.baseFormatter.WithScope(interpolation.Scope{Framework: control.Framework})}
+}
+
 // ToMarkdown converts a control to markdown format
 func (cf *ControlFormatter) ToMarkdown(control *domain.Control) string {
+	cf = cf.scoped(control)
 	var md strings.Builder
 
 	// Header section with ID and basic info
@@ -324,6 +331,7 @@ func (cf *ControlFormatter) ToSummaryMarkdown(control *domain.Control) string {
 // ToDocumentMarkdown creates a comprehensive control document in markdown format
 // This is the main method for generating control documents that will be saved to files
 func (cf *ControlFormatter) ToDocumentMarkdown(control *domain.Control) string {
+	cf = cf.scoped(control)
 	var md strings.Builder
 
 	// Use actual reference ID or generate one as fallback
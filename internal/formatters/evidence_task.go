@@ -127,8 +127,19 @@ func (etf *EvidenceTaskFormatter) RegisterTask(task *domain.EvidenceTask) string
 	return ref
 }
 
+// scoped returns a copy of the formatter interpolating with the variables of
+// the task and its framework
+func (etf *EvidenceTaskFormatter) scoped(task *domain.EvidenceTask) *EvidenceTaskFormatter {
+	// GetFramework stores the framework it derives, so ask a copy
+	derived := *task
+	scoped := *etf
+	scoped.baseFormatter = etf.baseFormatter.WithScope(interpolation.Scope{Framework: derived.GetFramework(), TaskRef: task.ReferenceID})
+	return &scoped
+}
+
 // ToMarkdown converts an evidence task to markdown format
 func (etf *EvidenceTaskFormatter) ToMarkdown(task *domain.EvidenceTask) string {
+	etf = etf.scoped(task)
 	var md strings.Builder
 
 	// Header section with ID and basic info
@@ -519,6 +530,7 @@ func (etf *EvidenceTaskFormatter) ToSummaryMarkdown(task *domain.EvidenceTask) s
 // ToDocumentMarkdown creates a comprehensive evidence task document in markdown format
 // This is the main method for generating evidence task documents that will be saved to files
 func (etf *EvidenceTaskFormatter) ToDocumentMarkdown(task *domain.EvidenceTask) string {
+	etf = etf.scoped(task)
 	var md strings.Builder
 
 	// Generate reference ID for the evidence task
@@ -828,6 +840,8 @@ func (etf *EvidenceTaskFormatter) ToDocumentMarkdown(task *domain.EvidenceTask)
 
 // ToDocumentMarkdownWithContext creates a comprehensive evidence task document with control/policy context
 func (etf *EvidenceTaskFormatter) ToDocumentMarkdownWithContext(task *domain.EvidenceTask, controls []domain.Control, policies []domain.Policy) string {
+	etf = etf.scoped(task)
+
 	// Generate base document content
 	baseContent := etf.ToDocumentMarkdown(task)
 
@@ -0,0 +1,221 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frameworks
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Annex A themes of ISO/IEC 27001:2022
+const (
+	ThemeOrganizational = "Organizational"
+	ThemePeople         = "People"
+	ThemePhysical       = "Physical"
+	ThemeTechnological  = "Technological"
+)
+
+// AnnexAControl is a control of ISO/IEC 27001:2022 Annex A
+type AnnexAControl struct {
+	Code  string `json:"code"`
+	Title string `json:"title"`
+	Theme string `json:"theme"`
+}
+
+// annexATitles lists the Annex A controls by clause, in catalog order
+var annexATitles = [][]string{
+	5: {
+		"Policies for information security",
+		"Information security roles and responsibilities",
+		"Segregation of duties",
+		"Management responsibilities",
+		"Contact with authorities",
+		"Contact with special interest groups",
+		"Threat intelligence",
+		"Information security in project management",
+		"Inventory of information and other associated assets",
+		"Acceptable use of information and other associated assets",
+		"Return of assets",
+		"Classification of information",
+		"Labelling of information",
+		"Information transfer",
+		"Access control",
+		"Identity management",
+		"Authentication information",
+		"Access rights",
+		"Information security in supplier relationships",
+		"Addressing information security within supplier agreements",
+		"Managing information security in the ICT supply chain",
+		"Monitoring, review and change management of supplier services",
+		"Information security for use of cloud services",
+		"Information security incident management planning and preparation",
+		"Assessment and decision on information security events",
+		"Response to information security incidents",
+		"Learning from information security incidents",
+		"Collection of evidence",
+		"Information security during disruption",
+		"ICT readiness for business continuity",
+		"Legal, statutory, regulatory and contractual requirements",
+		"Intellectual property rights",
+		"Protection of records",
+		"Privacy and protection of PII",
+		"Independent review of information security",
+		"Compliance with policies, rules and standards for information security",
+		"Documented operating procedures",
+	},
+	6: {
+		"Screening",
+		"Terms and conditions of employment",
+		"Information security awareness, education and training",
+		"Disciplinary process",
+		"Responsibilities after termination or change of employment",
+		"Confidentiality or non-disclosure agreements",
+		"Remote working",
+		"Information security event reporting",
+	},
+	7: {
+		"Physical security perimeters",
+		"Physical entry",
+		"Securing offices, rooms and facilities",
+		"Physical security monitoring",
+		"Protecting against physical and environmental threats",
+		"Working in secure areas",
+		"Clear desk and clear screen",
+		"Equipment siting and protection",
+		"Security of assets off-premises",
+		"Storage media",
+		"Supporting utilities",
+		"Cabling security",
+		"Equipment maintenance",
+		"Secure disposal or re-use of equipment",
+	},
+	8: {
+		"User endpoint devices",
+		"Privileged access rights",
+		"Information access restriction",
+		"Access to source code",
+		"Secure authentication",
+		"Capacity management",
+		"Protection against malware",
+		"Management of technical vulnerabilities",
+		"Configuration management",
+		"Information deletion",
+		"Data masking",
+		"Data leakage prevention",
+		"Information backup",
+		"Redundancy of information processing facilities",
+		"Logging",
+		"Monitoring activities",
+		"Clock synchronization",
+		"Use of privileged utility programs",
+		"Installation of software on operational systems",
+		"Networks security",
+		"Security of network services",
+		"Segregation of networks",
+		"Web filtering",
+		"Use of cryptography",
+		"Secure development life cycle",
+		"Application security requirements",
+		"Secure system architecture and engineering principles",
+		"Secure coding",
+		"Security testing in development and acceptance",
+		"Outsourced development",
+		"Separation of development, test and production environments",
+		"Change management",
+		"Test information",
+		"Protection of information during testing",
+	},
+}
+
+var annexAThemes = map[int]string{
+	5: ThemeOrganizational,
+	6: ThemePeople,
+	7: ThemePhysical,
+	8: ThemeTechnological,
+}
+
+// annexACodePattern matches "A.8.24", "A 8.24", "A8.24" and "8.24"
+var annexACodePattern = regexp.MustCompile(`^(?:A\.?\s*)?([5-8])\.(\d{1,2})$`)
+
+// AnnexA returns the 93 Annex A controls in catalog order
+func AnnexA() []AnnexAControl {
+	var controls []AnnexAControl
+	for clause, titles := range annexATitles {
+		for i, title := range titles {
+			controls = append(controls, AnnexAControl{
+				Code:  annexACode(clause, i+1),
+				Title: title,
+				Theme: annexAThemes[clause],
+			})
+		}
+	}
+	return controls
+}
+
+// LookupAnnexA returns the Annex A control with the given code in any of the
+// forms NormalizeAnnexACode accepts
+func LookupAnnexA(code string) (AnnexAControl, bool) {
+	clause, number, ok := parseAnnexACode(code)
+	if !ok {
+		return AnnexAControl{}, false
+	}
+	return AnnexAControl{
+		Code:  annexACode(clause, number),
+		Title: annexATitles[clause][number-1],
+		Theme: annexAThemes[clause],
+	}, true
+}
+
+// NormalizeAnnexACode returns the canonical "A.<clause>.<number>" form of an
+// Annex A control code, accepting a missing or unpunctuated "A" prefix
+func NormalizeAnnexACode(code string) (string, bool) {
+	clause, number, ok := parseAnnexACode(code)
+	if !ok {
+		return "", false
+	}
+	return annexACode(clause, number), true
+}
+
+// SortAnnexA sorts Annex A codes in catalog order, so A.8.9 precedes A.8.10
+func SortAnnexA(codes []string) {
+	sort.SliceStable(codes, func(i, j int) bool {
+		ci, ni, _ := parseAnnexACode(codes[i])
+		cj, nj, _ := parseAnnexACode(codes[j])
+		if ci != cj {
+			return ci < cj
+		}
+		return ni < nj
+	})
+}
+
+func parseAnnexACode(code string) (int, int, bool) {
+	match := annexACodePattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(code)))
+	if match == nil {
+		return 0, 0, false
+	}
+	clause, _ := strconv.Atoi(match[1])
+	number, _ := strconv.Atoi(match[2])
+	if number < 1 || number > len(annexATitles[clause]) {
+		return 0, 0, false
+	}
+	return clause, number, true
+}
+
+func annexACode(clause, number int) string {
+	return "A." + strconv.Itoa(clause) + "." + strconv.Itoa(number)
+}
@@ -0,0 +1,297 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frameworks
+
+import (
+	"sort"
+	"strings"
+)
+
+// soc2AnnexA maps the SOC2 trust services criteria to the Annex A controls
+// that address the same requirement. It also defines which SOC2 codes are
+// valid, including the SO2 system operations code used by the Terraform
+// analyzers for capacity and autoscaling.
+var soc2AnnexA = map[string][]string{
+	"CC1.1": {"A.5.4", "A.6.2", "A.6.4"},
+	"CC1.2": {"A.5.4"},
+	"CC1.3": {"A.5.2", "A.5.3"},
+	"CC1.4": {"A.6.1", "A.6.3"},
+	"CC1.5": {"A.5.4", "A.6.4"},
+	"CC2.1": {"A.5.9", "A.5.12"},
+	"CC2.2": {"A.5.1", "A.6.3", "A.6.8"},
+	"CC2.3": {"A.5.5", "A.5.6", "A.5.14"},
+	"CC3.1": {"A.5.1"},
+	"CC3.2": {"A.5.7"},
+	"CC3.3": {"A.5.3"},
+	"CC3.4": {"A.5.7"},
+	"CC4.1": {"A.5.35", "A.5.36"},
+	"CC4.2": {"A.5.35"},
+	"CC5.1": {"A.5.1", "A.5.3"},
+	"CC5.2": {"A.5.1", "A.8.9"},
+	"CC5.3": {"A.5.1", "A.5.37"},
+	"CC6.1": {"A.5.15", "A.8.2", "A.8.3", "A.8.5"},
+	"CC6.2": {"A.5.16", "A.5.18"},
+	"CC6.3": {"A.5.15", "A.5.18", "A.8.2"},
+	"CC6.4": {"A.7.1", "A.7.2", "A.7.4"},
+	"CC6.5": {"A.7.10", "A.7.14", "A.8.10"},
+	"CC6.6": {"A.8.20", "A.8.21", "A.8.22"},
+	"CC6.7": {"A.5.14", "A.8.24"},
+	"CC6.8": {"A.8.7", "A.8.19"},
+	"CC7.1": {"A.8.8", "A.8.9"},
+	"CC7.2": {"A.8.15", "A.8.16"},
+	"CC7.3": {"A.5.25"},
+	"CC7.4": {"A.5.26"},
+	"CC7.5": {"A.5.27", "A.5.29"},
+	"CC8.1": {"A.8.32"},
+	"CC9.1": {"A.5.29", "A.5.30"},
+	"CC9.2": {"A.5.19", "A.5.20", "A.5.22"},
+	"A1.1":  {"A.8.6"},
+	"A1.2":  {"A.8.13", "A.8.14"},
+	"A1.3":  {"A.5.30"},
+	"C1.1":  {"A.5.12", "A.5.33"},
+	"C1.2":  {"A.8.10"},
+	"SO2":   {"A.8.6"},
+}
+
+// soc2NIST maps the SOC2 trust services criteria to the NIST SP 800-53
+// Rev. 5 controls that address the same requirement
+var soc2NIST = map[string][]string{
+	"CC1.1": {"PL-4", "PS-6", "PS-8"},
+	"CC1.2": {"PM-1", "PM-2"},
+	"CC1.3": {"PM-2", "PM-29"},
+	"CC1.4": {"AT-2", "AT-3", "PS-3"},
+	"CC1.5": {"PS-6", "PS-8"},
+	"CC2.1": {"CM-8", "PM-5"},
+	"CC2.2": {"AT-2", "PL-4"},
+	"CC2.3": {"PM-15", "SA-9"},
+	"CC3.1": {"PL-2", "PM-9"},
+	"CC3.2": {"RA-3"},
+	"CC3.3": {"RA-3"},
+	"CC3.4": {"CM-4", "RA-3"},
+	"CC4.1": {"CA-2", "CA-7"},
+	"CC4.2": {"CA-5", "PM-4"},
+	"CC5.1": {"PL-2", "RA-7"},
+	"CC5.2": {"PL-2", "SA-8"},
+	"CC5.3": {"PL-1", "PM-1"},
+	"CC6.1": {"AC-2", "AC-3", "AC-6", "IA-2", "SC-28"},
+	"CC6.2": {"AC-2", "IA-4"},
+	"CC6.3": {"AC-2", "AC-5", "AC-6"},
+	"CC6.4": {"PE-2", "PE-3", "PE-6"},
+	"CC6.5": {"MP-6", "SR-12"},
+	"CC6.6": {"AC-17", "SC-7"},
+	"CC6.7": {"SC-8", "SC-13"},
+	"CC6.8": {"CM-7", "SI-3", "SI-7"},
+	"CC7.1": {"CM-2", "CM-6", "RA-5"},
+	"CC7.2": {"AU-2", "AU-6", "AU-12", "SI-4"},
+	"CC7.3": {"AU-6", "IR-4"},
+	"CC7.4": {"IR-4", "IR-8"},
+	"CC7.5": {"CP-10", "IR-4"},
+	"CC8.1": {"CM-3", "CM-4", "SA-10"},
+	"CC9.1": {"CP-2", "RA-7"},
+	"CC9.2": {"SA-9", "SR-3", "SR-6"},
+	"A1.1":  {"CP-2", "SC-5", "SC-6"},
+	"A1.2":  {"CP-9", "CP-10", "PE-14"},
+	"A1.3":  {"CP-4"},
+	"C1.1":  {"RA-2", "SI-12"},
+	"C1.2":  {"MP-6"},
+	"SO2":   {"SC-6"},
+}
+
+// soc2PCI maps the SOC2 trust services criteria to the PCI DSS v4.0
+// requirements that address the same requirement. Criteria PCI DSS has no
+// counterpart for, such as capacity planning, map to nothing.
+var soc2PCI = map[string][]string{
+	"CC1.1": {"12.1.1"},
+	"CC1.2": {"12.4.1"},
+	"CC1.3": {"12.1.3", "12.4.1"},
+	"CC1.4": {"12.6.1", "12.7.1"},
+	"CC1.5": {"12.1.3"},
+	"CC2.1": {"12.5.1"},
+	"CC2.2": {"12.1.2", "12.6.1"},
+	"CC2.3": {"12.8.2", "12.9.1"},
+	"CC3.1": {"12.3.1"},
+	"CC3.2": {"12.3.1"},
+	"CC3.3": {"12.3.1"},
+	"CC3.4": {"12.3.1", "12.5.2"},
+	"CC4.1": {"11.3.1", "11.4.1", "12.4.2"},
+	"CC4.2": {"12.4.2"},
+	"CC5.1": {"12.1.1"},
+	"CC5.2": {"2.2.1", "12.1.1"},
+	"CC5.3": {"12.1.1", "12.1.2"},
+	"CC6.1": {"3.5.1", "7.2.1", "7.2.2", "8.2.1", "8.3.1"},
+	"CC6.2": {"8.2.4", "8.2.5"},
+	"CC6.3": {"7.2.2", "7.2.4", "8.2.4"},
+	"CC6.4": {"9.2.1", "9.3.1"},
+	"CC6.5": {"3.2.1", "9.4.7"},
+	"CC6.6": {"1.3.1", "1.3.2", "1.4.1", "8.4.2"},
+	"CC6.7": {"3.5.1", "4.2.1"},
+	"CC6.8": {"5.2.1", "5.3.1", "11.5.2"},
+	"CC7.1": {"2.2.1", "6.3.1", "11.3.1"},
+	"CC7.2": {"10.2.1", "10.4.1", "11.5.1"},
+	"CC7.3": {"10.4.1", "12.10.1"},
+	"CC7.4": {"12.10.1"},
+	"CC7.5": {"12.10.1", "12.10.6"},
+	"CC8.1": {"6.5.1", "6.5.2"},
+	"CC9.1": {"12.3.1", "12.10.1"},
+	"CC9.2": {"12.8.1", "12.8.2", "12.8.4"},
+	"A1.1":  nil,
+	"A1.2":  nil,
+	"A1.3":  {"12.10.2"},
+	"C1.1":  {"3.2.1", "12.5.2"},
+	"C1.2":  {"3.2.1", "9.4.6", "9.4.7"},
+	"SO2":   nil,
+}
+
+// soc2HIPAA maps the SOC2 trust services criteria to the HIPAA Security Rule
+// safeguards that address the same requirement
+var soc2HIPAA = map[string][]string{
+	"CC1.1": {"164.308(a)(1)(ii)(C)"},
+	"CC1.2": nil,
+	"CC1.3": {"164.308(a)(2)"},
+	"CC1.4": {"164.308(a)(3)(ii)(B)", "164.308(a)(5)"},
+	"CC1.5": {"164.308(a)(1)(ii)(C)"},
+	"CC2.1": {"164.308(a)(1)(ii)(D)"},
+	"CC2.2": {"164.308(a)(5)", "164.308(a)(5)(ii)(A)"},
+	"CC2.3": {"164.308(b)(1)"},
+	"CC3.1": {"164.308(a)(1)(ii)(A)"},
+	"CC3.2": {"164.308(a)(1)(ii)(A)"},
+	"CC3.3": {"164.308(a)(1)(ii)(A)"},
+	"CC3.4": {"164.308(a)(1)(ii)(A)", "164.308(a)(8)"},
+	"CC4.1": {"164.308(a)(8)"},
+	"CC4.2": {"164.308(a)(1)(ii)(B)"},
+	"CC5.1": {"164.308(a)(1)(ii)(B)"},
+	"CC5.2": {"164.308(a)(1)(ii)(B)"},
+	"CC5.3": {"164.308(a)(1)"},
+	"CC6.1": {"164.312(a)(1)", "164.312(a)(2)(i)", "164.312(a)(2)(iv)", "164.312(d)"},
+	"CC6.2": {"164.308(a)(4)(ii)(B)", "164.308(a)(4)(ii)(C)"},
+	"CC6.3": {"164.308(a)(3)(ii)(A)", "164.308(a)(3)(ii)(C)", "164.308(a)(4)(ii)(C)"},
+	"CC6.4": {"164.310(a)(1)", "164.310(a)(2)(ii)", "164.310(a)(2)(iii)"},
+	"CC6.5": {"164.310(d)(2)(i)", "164.310(d)(2)(ii)"},
+	"CC6.6": {"164.312(e)(1)"},
+	"CC6.7": {"164.310(d)(1)", "164.312(e)(1)", "164.312(e)(2)(ii)"},
+	"CC6.8": {"164.308(a)(5)(ii)(B)"},
+	"CC7.1": {"164.308(a)(1)(ii)(A)", "164.308(a)(8)"},
+	"CC7.2": {"164.308(a)(1)(ii)(D)", "164.308(a)(5)(ii)(C)", "164.312(b)"},
+	"CC7.3": {"164.308(a)(6)"},
+	"CC7.4": {"164.308(a)(6)(ii)"},
+	"CC7.5": {"164.308(a)(7)(ii)(B)"},
+	"CC8.1": {"164.308(a)(8)", "164.312(c)(1)"},
+	"CC9.1": {"164.308(a)(7)"},
+	"CC9.2": {"164.308(b)(1)", "164.308(b)(3)"},
+	"A1.1":  nil,
+	"A1.2":  {"164.308(a)(7)(ii)(A)", "164.310(d)(2)(iv)"},
+	"A1.3":  {"164.308(a)(7)(ii)(D)"},
+	"C1.1":  {"164.312(a)(2)(iv)", "164.312(c)(1)"},
+	"C1.2":  {"164.310(d)(2)(i)"},
+	"SO2":   nil,
+}
+
+// AnnexAForSOC2 returns the Annex A controls addressing any of the SOC2
+// codes, deduplicated and in catalog order. Unknown codes are ignored.
+func AnnexAForSOC2(codes ...string) []string {
+	seen := make(map[string]bool)
+	var annexA []string
+	for _, code := range codes {
+		for _, control := range soc2AnnexA[strings.ToUpper(strings.TrimSpace(code))] {
+			if !seen[control] {
+				seen[control] = true
+				annexA = append(annexA, control)
+			}
+		}
+	}
+	SortAnnexA(annexA)
+	return annexA
+}
+
+// NISTForSOC2 returns the NIST SP 800-53 controls addressing any of the SOC2
+// codes, deduplicated and in catalog order. Unknown codes are ignored.
+func NISTForSOC2(codes ...string) []string {
+	seen := make(map[string]bool)
+	var nist []string
+	for _, code := range codes {
+		for _, control := range soc2NIST[strings.ToUpper(strings.TrimSpace(code))] {
+			if !seen[control] {
+				seen[control] = true
+				nist = append(nist, control)
+			}
+		}
+	}
+	SortNIST(nist)
+	return nist
+}
+
+// PCIForSOC2 returns the PCI DSS requirements addressing any of the SOC2
+// codes, deduplicated and in numeric order. Unknown codes are ignored.
+func PCIForSOC2(codes ...string) []string {
+	seen := make(map[string]bool)
+	var pci []string
+	for _, code := range codes {
+		for _, requirement := range soc2PCI[strings.ToUpper(strings.TrimSpace(code))] {
+			if !seen[requirement] {
+				seen[requirement] = true
+				pci = append(pci, requirement)
+			}
+		}
+	}
+	SortPCI(pci)
+	return pci
+}
+
+// HIPAAForSOC2 returns the HIPAA safeguards addressing any of the SOC2 codes,
+// deduplicated and in regulation order. Unknown codes are ignored.
+func HIPAAForSOC2(codes ...string) []string {
+	seen := make(map[string]bool)
+	var hipaa []string
+	for _, code := range codes {
+		for _, safeguard := range soc2HIPAA[strings.ToUpper(strings.TrimSpace(code))] {
+			if !seen[safeguard] {
+				seen[safeguard] = true
+				hipaa = append(hipaa, safeguard)
+			}
+		}
+	}
+	SortHIPAA(hipaa)
+	return hipaa
+}
+
+// Crosswalk returns the controls of the framework addressing any of the SOC2
+// codes. For SOC2 itself the known codes are returned as they are.
+func Crosswalk(framework string, soc2Codes ...string) []string {
+	switch framework {
+	case ISO27001:
+		return AnnexAForSOC2(soc2Codes...)
+	case NIST80053:
+		return NISTForSOC2(soc2Codes...)
+	case PCIDSS:
+		return PCIForSOC2(soc2Codes...)
+	case HIPAA:
+		return HIPAAForSOC2(soc2Codes...)
+	}
+	if IsCustom(framework) {
+		return CustomCodes(framework, soc2Codes, "")
+	}
+	seen := make(map[string]bool)
+	var soc2 []string
+	for _, code := range soc2Codes {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if _, ok := soc2AnnexA[code]; ok && !seen[code] {
+			seen[code] = true
+			soc2 = append(soc2, code)
+		}
+	}
+	sort.Strings(soc2)
+	return soc2
+}
@@ -0,0 +1,300 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frameworks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CustomFrameworksDir is the directory under the data directory holding
+// custom framework definitions, one YAML file per framework
+const CustomFrameworksDir = "frameworks"
+
+// CustomFramework is an internal control framework defined in YAML rather
+// than built into grctool. Its controls map onto the SOC2 trust services
+// criteria, so controls synced with SOC2 codes crosswalk to it, and onto
+// keywords matched against control names and descriptions.
+type CustomFramework struct {
+	Name        string          `yaml:"name" json:"name"`
+	Label       string          `yaml:"label,omitempty" json:"label,omitempty"`
+	Description string          `yaml:"description,omitempty" json:"description,omitempty"`
+	Controls    []CustomControl `yaml:"controls" json:"controls"`
+}
+
+// CustomControl is a control of a custom framework
+type CustomControl struct {
+	Code               string   `yaml:"code" json:"code"`
+	Title              string   `yaml:"title" json:"title"`
+	Theme              string   `yaml:"theme,omitempty" json:"theme,omitempty"`
+	SOC2               []string `yaml:"soc2,omitempty" json:"soc2,omitempty"`
+	Keywords           []string `yaml:"keywords,omitempty" json:"keywords,omitempty"`
+	TerraformResources []string `yaml:"terraform_resources,omitempty" json:"terraform_resources,omitempty"`
+}
+
+// customRegistry holds the registered custom frameworks by name, in
+// registration order
+var customRegistry = struct {
+	sync.RWMutex
+	byName map[string]*CustomFramework
+	names  []string
+}{byName: make(map[string]*CustomFramework)}
+
+// LoadCustomFrameworks reads the custom framework definitions in dir, in
+// file name order. A missing directory holds no definitions.
+func LoadCustomFrameworks(dir string) ([]CustomFramework, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read custom frameworks: %w", err)
+	}
+
+	var definitions []CustomFramework
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read custom framework %s: %w", path, err)
+		}
+		var definition CustomFramework
+		if err := yaml.Unmarshal(data, &definition); err != nil {
+			return nil, fmt.Errorf("failed to parse custom framework %s: %w", path, err)
+		}
+		definitions = append(definitions, definition)
+	}
+	return definitions, nil
+}
+
+// RegisterCustom validates a custom framework and makes it available under
+// its name and label. Control codes must be unique within the framework and
+// SOC2 mappings must be trust services criteria. Registering a framework
+// again replaces its definition.
+func RegisterCustom(framework CustomFramework) error {
+	name := strings.ToLower(strings.TrimSpace(framework.Name))
+	if name == "" {
+		return fmt.Errorf("custom framework has no name")
+	}
+	if builtin, err := Normalize(name); err == nil {
+		return fmt.Errorf("custom framework %q conflicts with built-in framework %s", framework.Name, builtin)
+	}
+	if label := strings.TrimSpace(framework.Label); label != "" {
+		if builtin, err := Normalize(label); err == nil {
+			return fmt.Errorf("custom framework label %q conflicts with built-in framework %s", label, builtin)
+		}
+	}
+	if len(framework.Controls) == 0 {
+		return fmt.Errorf("custom framework %s has no controls", name)
+	}
+
+	definition := CustomFramework{
+		Name:        name,
+		Label:       strings.TrimSpace(framework.Label),
+		Description: strings.TrimSpace(framework.Description),
+	}
+	if definition.Label == "" {
+		definition.Label = name
+	}
+	seen := make(map[string]bool)
+	for i, control := range framework.Controls {
+		control.Code = strings.TrimSpace(control.Code)
+		if control.Code == "" {
+			return fmt.Errorf("control %d of custom framework %s has no code", i+1, name)
+		}
+		key := customCodeKey(control.Code)
+		if seen[key] {
+			return fmt.Errorf("custom framework %s defines control %s more than once", name, control.Code)
+		}
+		seen[key] = true
+
+		soc2 := make([]string, 0, len(control.SOC2))
+		for _, code := range control.SOC2 {
+			normalized, err := NormalizeControl(SOC2, code)
+			if err != nil {
+				return fmt.Errorf("control %s of custom framework %s: %w", control.Code, name, err)
+			}
+			soc2 = append(soc2, normalized)
+		}
+		control.SOC2 = soc2
+
+		keywords := make([]string, 0, len(control.Keywords))
+		for _, keyword := range control.Keywords {
+			if keyword = strings.ToLower(strings.TrimSpace(keyword)); keyword != "" {
+				keywords = append(keywords, keyword)
+			}
+		}
+		control.Keywords = keywords
+		definition.Controls = append(definition.Controls, control)
+	}
+
+	customRegistry.Lock()
+	defer customRegistry.Unlock()
+	if _, exists := customRegistry.byName[name]; !exists {
+		customRegistry.names = append(customRegistry.names, name)
+	}
+	customRegistry.byName[name] = &definition
+	return nil
+}
+
+// CustomFrameworks returns the registered custom frameworks in registration
+// order
+func CustomFrameworks() []CustomFramework {
+	customRegistry.RLock()
+	defer customRegistry.RUnlock()
+	frameworks := make([]CustomFramework, 0, len(customRegistry.names))
+	for _, name := range customRegistry.names {
+		frameworks = append(frameworks, *customRegistry.byName[name])
+	}
+	return frameworks
+}
+
+// Custom returns the registered custom framework of that name
+func Custom(framework string) (CustomFramework, bool) {
+	customRegistry.RLock()
+	defer customRegistry.RUnlock()
+	if definition, ok := customRegistry.byName[framework]; ok {
+		return *definition, true
+	}
+	return CustomFramework{}, false
+}
+
+// IsCustom reports whether the framework is a registered custom framework
+func IsCustom(framework string) bool {
+	_, ok := Custom(framework)
+	return ok
+}
+
+// Resolve resolves a framework name like Normalize, and also accepts the
+// names and labels of registered custom frameworks. Commands that work from
+// synced controls, such as evidence map and coverage reports, resolve with
+// it; the analyzers, which need built-in resource mappings, normalize.
+func Resolve(name string) (string, error) {
+	if framework, err := Normalize(name); err == nil {
+		return framework, nil
+	}
+	key := looseKey(name)
+	customRegistry.RLock()
+	defer customRegistry.RUnlock()
+	for _, custom := range customRegistry.names {
+		definition := customRegistry.byName[custom]
+		if key == looseKey(definition.Name) || key == looseKey(definition.Label) {
+			return definition.Name, nil
+		}
+	}
+	supported := append(append([]string(nil), Supported...), customRegistry.names...)
+	return "", fmt.Errorf("unsupported framework %q (supported: %s)", name, strings.Join(supported, ", "))
+}
+
+// LookupCustomControl returns the control of the custom framework with that
+// code, compared regardless of case and spacing
+func LookupCustomControl(framework, code string) (CustomControl, bool) {
+	definition, ok := Custom(framework)
+	if !ok {
+		return CustomControl{}, false
+	}
+	key := customCodeKey(code)
+	for _, control := range definition.Controls {
+		if customCodeKey(control.Code) == key {
+			return control, true
+		}
+	}
+	return CustomControl{}, false
+}
+
+// CustomCodes returns the controls of the custom framework addressing any of
+// the SOC2 codes or whose keywords appear in text, in definition order
+func CustomCodes(framework string, soc2Codes []string, text string) []string {
+	definition, ok := Custom(framework)
+	if !ok {
+		return nil
+	}
+	soc2 := make(map[string]bool, len(soc2Codes))
+	for _, code := range soc2Codes {
+		soc2[strings.ToUpper(strings.TrimSpace(code))] = true
+	}
+	text = strings.ToLower(text)
+
+	var codes []string
+	for _, control := range definition.Controls {
+		if customControlMatches(control, soc2, text) {
+			codes = append(codes, control.Code)
+		}
+	}
+	return codes
+}
+
+func customControlMatches(control CustomControl, soc2 map[string]bool, text string) bool {
+	for _, code := range control.SOC2 {
+		if soc2[code] {
+			return true
+		}
+	}
+	if text == "" {
+		return false
+	}
+	for _, keyword := range control.Keywords {
+		if strings.Contains(text, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortCustom sorts codes of a custom framework in definition order, with
+// codes it does not define last
+func sortCustom(framework string, codes []string) {
+	definition, _ := Custom(framework)
+	position := make(map[string]int, len(definition.Controls))
+	for i, control := range definition.Controls {
+		position[customCodeKey(control.Code)] = i
+	}
+	rank := func(code string) int {
+		if i, ok := position[customCodeKey(code)]; ok {
+			return i
+		}
+		return len(position)
+	}
+	sort.SliceStable(codes, func(i, j int) bool {
+		return rank(codes[i]) < rank(codes[j])
+	})
+}
+
+// customCodeKey compares custom control codes regardless of case and spacing
+func customCodeKey(code string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(code), ""))
+}
+
+// looseKey compares names by their letters and digits, ignoring case
+func looseKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, name)
+}
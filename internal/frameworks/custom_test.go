@@ -0,0 +1,119 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package frameworks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadCustomFrameworks(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "acme.yaml"), []byte(`
+name: Load-Acme
+label: Load Acme ICF
+controls:
+  - code: ICF-AC-01
+    title: Access is reviewed quarterly
+    theme: Access Control
+    soc2: [cc6.1]
+    keywords: [Access Review]
+    terraform_resources: [aws_iam_role]
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a framework"), 0644))
+
+	definitions, err := LoadCustomFrameworks(dir)
+	require.NoError(t, err)
+	require.Len(t, definitions, 1)
+	assert.Equal(t, "Load-Acme", definitions[0].Name)
+	assert.Equal(t, []string{"aws_iam_role"}, definitions[0].Controls[0].TerraformResources)
+
+	definitions, err = LoadCustomFrameworks(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, definitions)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.yml"), []byte("controls: [\n"), 0644))
+	_, err = LoadCustomFrameworks(dir)
+	assert.ErrorContains(t, err, "broken.yml")
+}
+
+func TestRegisterCustom(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, RegisterCustom(CustomFramework{
+		Name:  "Acme-Register",
+		Label: "Acme Register ICF",
+		Controls: []CustomControl{
+			{Code: "ICF-LOG-01", Title: "Security events are logged", Theme: "Logging", SOC2: []string{"cc7.2"},
+				Keywords: []string{"Audit Log"}},
+			{Code: "ICF-AC-01", Title: "Access is reviewed quarterly", Theme: "Access Control",
+				SOC2: []string{"CC6.1", "CC6.2"}, Keywords: []string{"access review"}},
+		},
+	}))
+	const framework = "acme-register"
+
+	for _, name := range []string{"acme-register", "Acme Register", "ACME REGISTER ICF"} {
+		resolved, err := Resolve(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, framework, resolved, name)
+	}
+	resolved, err := Resolve("PCI DSS v4.0")
+	require.NoError(t, err)
+	assert.Equal(t, PCIDSS, resolved, "built-in frameworks resolve as before")
+	_, err = Resolve("fedramp")
+	assert.ErrorContains(t, err, `unsupported framework "fedramp"`)
+	_, err = Normalize("acme-register")
+	assert.Error(t, err, "the analyzers keep to built-in frameworks")
+
+	assert.True(t, IsCustom(framework))
+	assert.Equal(t, framework, Key("Acme Register ICF"))
+	assert.Equal(t, "Acme Register ICF", Label(framework))
+	assert.Equal(t, "Access is reviewed quarterly", Title(framework, "icf-ac-01"))
+
+	code, err := NormalizeControl(framework, " icf-ac-01 ")
+	require.NoError(t, err)
+	assert.Equal(t, "ICF-AC-01", code)
+	_, err = NormalizeControl(framework, "ICF-HR-01")
+	assert.ErrorContains(t, err, `invalid Acme Register ICF control "ICF-HR-01"`)
+
+	codes := []string{"ICF-AC-01", "ICF-X-99", "ICF-LOG-01"}
+	Sort(framework, codes)
+	assert.Equal(t, []string{"ICF-LOG-01", "ICF-AC-01", "ICF-X-99"}, codes, "definition order, unknown codes last")
+
+	assert.Equal(t, []string{"ICF-AC-01"}, Crosswalk(framework, "CC6.2", "CC8.1"))
+	assert.Equal(t, []string{"ICF-LOG-01", "ICF-AC-01"}, CustomCodes(framework, []string{"cc6.1"}, "Central AUDIT LOG retention"))
+	assert.Empty(t, CustomCodes(framework, nil, "Vendor management"))
+	assert.Empty(t, CustomCodes("acme-unknown", []string{"CC6.1"}, ""))
+}
+
+func TestRegisterCustom_Invalid(t *testing.T) {
+	t.Parallel()
+
+	control := CustomControl{Code: "ICF-1", Title: "Control"}
+	tests := map[string]struct {
+		framework CustomFramework
+		err       string
+	}{
+		"no name":        {framework: CustomFramework{Controls: []CustomControl{control}}, err: "has no name"},
+		"built-in name":  {framework: CustomFramework{Name: "PCI DSS", Controls: []CustomControl{control}}, err: "conflicts with built-in framework pcidss"},
+		"built-in label": {framework: CustomFramework{Name: "acme-label", Label: "ISO 27001", Controls: []CustomControl{control}}, err: "conflicts with built-in framework iso27001"},
+		"no controls":    {framework: CustomFramework{Name: "acme-empty"}, err: "has no controls"},
+		"no code":        {framework: CustomFramework{Name: "acme-code", Controls: []CustomControl{{Title: "Control"}}}, err: "control 1 of custom framework acme-code has no code"},
+		"duplicate code": {framework: CustomFramework{Name: "acme-dup", Controls: []CustomControl{control, {Code: "icf-1"}}}, err: "defines control icf-1 more than once"},
+		"bad soc2":       {framework: CustomFramework{Name: "acme-soc2", Controls: []CustomControl{{Code: "ICF-1", SOC2: []string{"CC99.1"}}}}, err: `invalid SOC2 control "CC99.1"`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.ErrorContains(t, RegisterCustom(tc.framework), tc.err)
+		})
+	}
+}
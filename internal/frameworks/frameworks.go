@@ -0,0 +1,199 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package frameworks names the compliance frameworks grctool maps evidence
+// to and holds the ISO 27001:2022 Annex A control catalog, the NIST SP
+// 800-53 Rev. 5 control families, the PCI DSS v4.0 principal requirements
+// and the HIPAA Security Rule safeguards, with crosswalks from the SOC2 trust
+// services criteria. Internal control frameworks defined in YAML register
+// alongside them as custom frameworks.
+package frameworks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// SOC2 selects the AICPA trust services criteria (CC6.1, CC7.2, ...)
+	SOC2 = "soc2"
+	// ISO27001 selects the ISO/IEC 27001:2022 Annex A controls (A.5.15, A.8.24, ...)
+	ISO27001 = "iso27001"
+	// NIST80053 selects the NIST SP 800-53 Rev. 5 controls (AC-2, SC-28, ...)
+	NIST80053 = "nist80053"
+	// PCIDSS selects the PCI DSS v4.0 requirements (1.3.1, 10.2.1, ...)
+	PCIDSS = "pcidss"
+	// HIPAA selects the HIPAA Security Rule safeguards (164.308(a)(1), 164.312(b), ...)
+	HIPAA = "hipaa"
+)
+
+// Supported lists the frameworks the analyzers can map controls to
+var Supported = []string{SOC2, ISO27001, NIST80053, PCIDSS, HIPAA}
+
+// Normalize resolves a user supplied framework name such as "SOC 2",
+// "iso-27001:2022", "NIST SP 800-53 Rev. 5", "PCI DSS v4.0" or "HIPAA
+// Security Rule" to SOC2, ISO27001, NIST80053, PCIDSS or HIPAA. An empty name
+// selects SOC2, the default of every analyzer.
+func Normalize(name string) (string, error) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	key = strings.NewReplacer(" ", "", "-", "", "_", "", "/", "", ".", "").Replace(key)
+	key = strings.TrimSuffix(strings.TrimSuffix(key, ":2022"), "2022")
+	key = strings.TrimSuffix(strings.TrimSuffix(key, "rev5"), "r5")
+	if strings.HasPrefix(key, "pci") {
+		key = strings.TrimSuffix(strings.TrimSuffix(strings.TrimSuffix(key, "40"), "4"), "v")
+	}
+	switch key {
+	case "", "soc2":
+		return SOC2, nil
+	case "iso27001", "isoiec27001", "iso":
+		return ISO27001, nil
+	case "nist80053", "nistsp80053", "sp80053", "80053", "nist":
+		return NIST80053, nil
+	case "pcidss", "pci":
+		return PCIDSS, nil
+	case "hipaa", "hipaasecurityrule", "hipaasecurity":
+		return HIPAA, nil
+	}
+	return "", fmt.Errorf("unsupported framework %q (supported: %s)", name, strings.Join(Supported, ", "))
+}
+
+// Label returns the display name of a framework for report headings
+func Label(framework string) string {
+	switch framework {
+	case ISO27001:
+		return "ISO 27001"
+	case NIST80053:
+		return "NIST 800-53"
+	case PCIDSS:
+		return "PCI DSS"
+	case HIPAA:
+		return "HIPAA"
+	}
+	if custom, ok := Custom(framework); ok {
+		return custom.Label
+	}
+	return "SOC2"
+}
+
+// Key identifies a framework name regardless of spelling, for comparing the
+// framework names synced from providers: known and custom frameworks resolve
+// to their canonical name, so "PCI DSS v4.0" and "pci" share a key, and other
+// names compare by their letters and digits. An empty name has an empty key.
+func Key(name string) string {
+	if strings.TrimSpace(name) == "" {
+		return ""
+	}
+	if framework, err := Resolve(name); err == nil {
+		return framework
+	}
+	return looseKey(name)
+}
+
+// IsControl reports whether code is a control of the framework
+func IsControl(framework, code string) bool {
+	_, err := NormalizeControl(framework, code)
+	return err == nil
+}
+
+// NormalizeControl returns the canonical form of a control code of the
+// framework, e.g. "a.8.24" or "8.24" become "A.8.24", "ac-02" becomes "AC-2",
+// "§164.312(B)" becomes "164.312(b)" and "cc6.1" becomes "CC6.1". Codes that are not controls of the framework
+// are rejected.
+func NormalizeControl(framework, code string) (string, error) {
+	switch framework {
+	case ISO27001:
+		if normalized, ok := NormalizeAnnexACode(code); ok {
+			return normalized, nil
+		}
+		return "", fmt.Errorf("invalid ISO 27001 Annex A control %q", code)
+	case NIST80053:
+		if normalized, ok := NormalizeNISTCode(code); ok {
+			return normalized, nil
+		}
+		return "", fmt.Errorf("invalid NIST 800-53 control %q", code)
+	case PCIDSS:
+		if normalized, ok := NormalizePCICode(code); ok {
+			return normalized, nil
+		}
+		return "", fmt.Errorf("invalid PCI DSS requirement %q", code)
+	case HIPAA:
+		if normalized, ok := NormalizeHIPAACode(code); ok {
+			return normalized, nil
+		}
+		return "", fmt.Errorf("invalid HIPAA safeguard %q", code)
+	}
+	if custom, ok := Custom(framework); ok {
+		if control, ok := LookupCustomControl(framework, code); ok {
+			return control.Code, nil
+		}
+		return "", fmt.Errorf("invalid %s control %q", custom.Label, code)
+	}
+	normalized := strings.ToUpper(strings.TrimSpace(code))
+	if _, ok := soc2AnnexA[normalized]; !ok {
+		return "", fmt.Errorf("invalid SOC2 control %q", code)
+	}
+	return normalized, nil
+}
+
+// Sort sorts control codes of the framework in catalog order
+func Sort(framework string, codes []string) {
+	switch framework {
+	case ISO27001:
+		SortAnnexA(codes)
+	case NIST80053:
+		SortNIST(codes)
+	case PCIDSS:
+		SortPCI(codes)
+	case HIPAA:
+		SortHIPAA(codes)
+	default:
+		if IsCustom(framework) {
+			sortCustom(framework, codes)
+			return
+		}
+		sort.Strings(codes)
+	}
+}
+
+// Title returns the title shown next to a control code in reports: the
+// Annex A control title for ISO 27001, the control family for NIST 800-53,
+// the principal requirement for PCI DSS, the safeguard title for HIPAA, the
+// control title for custom frameworks and nothing for SOC2
+func Title(framework, code string) string {
+	switch framework {
+	case ISO27001:
+		if control, ok := LookupAnnexA(code); ok {
+			return control.Title
+		}
+	case NIST80053:
+		if family, ok := LookupNISTFamily(code); ok {
+			return family.Name
+		}
+	case PCIDSS:
+		if requirement, ok := LookupPCIRequirement(code); ok {
+			return requirement.Title
+		}
+	case HIPAA:
+		if safeguard, ok := LookupHIPAASafeguard(code); ok {
+			return safeguard.Title
+		}
+	default:
+		if control, ok := LookupCustomControl(framework, code); ok {
+			return control.Title
+		}
+	}
+	return ""
+}
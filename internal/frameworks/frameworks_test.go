@@ -0,0 +1,289 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package frameworks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		name string
+		want string
+		err  string
+	}{
+		"default":        {name: "", want: SOC2},
+		"soc2":           {name: "SOC2", want: SOC2},
+		"soc 2":          {name: "SOC 2", want: SOC2},
+		"iso27001":       {name: "iso27001", want: ISO27001},
+		"iso 27001":      {name: "ISO 27001", want: ISO27001},
+		"iso27001:2022":  {name: "ISO/IEC-27001:2022", want: ISO27001},
+		"iso_27001_2022": {name: "iso_27001_2022", want: ISO27001},
+		"nist":           {name: "NIST", want: NIST80053},
+		"nist 800-53":    {name: "NIST 800-53", want: NIST80053},
+		"nist sp rev 5":  {name: "NIST SP 800-53 Rev. 5", want: NIST80053},
+		"nist80053r5":    {name: "nist80053r5", want: NIST80053},
+		"pci":            {name: "pci", want: PCIDSS},
+		"pci dss v4.0":   {name: "PCI DSS v4.0", want: PCIDSS},
+		"pci-dss-4":      {name: "pci-dss-4", want: PCIDSS},
+		"hipaa":          {name: "HIPAA", want: HIPAA},
+		"hipaa rule":     {name: "HIPAA Security Rule", want: HIPAA},
+		"unsupported":    {name: "fedramp", err: `unsupported framework "fedramp"`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := Normalize(tc.name)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestAnnexA(t *testing.T) {
+	t.Parallel()
+
+	controls := AnnexA()
+	require.Len(t, controls, 93)
+	assert.Equal(t, AnnexAControl{Code: "A.5.1", Title: "Policies for information security", Theme: ThemeOrganizational}, controls[0])
+	assert.Equal(t, AnnexAControl{Code: "A.8.34", Title: "Protection of information during testing", Theme: ThemeTechnological}, controls[92])
+
+	themes := map[string]int{}
+	for _, control := range controls {
+		themes[control.Theme]++
+	}
+	assert.Equal(t, map[string]int{ThemeOrganizational: 37, ThemePeople: 8, ThemePhysical: 14, ThemeTechnological: 34}, themes)
+
+	control, ok := LookupAnnexA("a.8.24")
+	require.True(t, ok)
+	assert.Equal(t, "Use of cryptography", control.Title)
+}
+
+func TestNormalizeControl(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		framework string
+		code      string
+		want      string
+		err       string
+	}{
+		"annex a":          {framework: ISO27001, code: "A.8.24", want: "A.8.24"},
+		"annex a lower":    {framework: ISO27001, code: " a.5.15 ", want: "A.5.15"},
+		"annex a no dot":   {framework: ISO27001, code: "A8.9", want: "A.8.9"},
+		"annex a clause":   {framework: ISO27001, code: "6.3", want: "A.6.3"},
+		"annex a too high": {framework: ISO27001, code: "A.6.9", err: `invalid ISO 27001 Annex A control "A.6.9"`},
+		"annex a 2013":     {framework: ISO27001, code: "A.12.4.1", err: "invalid ISO 27001 Annex A control"},
+		"soc2 code":        {framework: ISO27001, code: "CC6.1", err: "invalid ISO 27001 Annex A control"},
+		"soc2":             {framework: SOC2, code: "cc6.1", want: "CC6.1"},
+		"soc2 operations":  {framework: SOC2, code: "SO2", want: "SO2"},
+		"soc2 invalid":     {framework: SOC2, code: "CC6.9", err: `invalid SOC2 control "CC6.9"`},
+		"annex a as soc2":  {framework: SOC2, code: "A.8.24", err: "invalid SOC2 control"},
+		"nist":             {framework: NIST80053, code: "AC-2", want: "AC-2"},
+		"nist padded":      {framework: NIST80053, code: "ac-02", want: "AC-2"},
+		"nist enhancement": {framework: NIST80053, code: "AC-2 (1)", want: "AC-2(1)"},
+		"nist no dash":     {framework: NIST80053, code: "SC28", want: "SC-28"},
+		"nist too high":    {framework: NIST80053, code: "AT-7", err: `invalid NIST 800-53 control "AT-7"`},
+		"nist family":      {framework: NIST80053, code: "XY-1", err: "invalid NIST 800-53 control"},
+		"soc2 as nist":     {framework: NIST80053, code: "CC6.1", err: "invalid NIST 800-53 control"},
+		"pci":              {framework: PCIDSS, code: "1.3.1", want: "1.3.1"},
+		"pci prefixed":     {framework: PCIDSS, code: "Req. 10.2.1", want: "10.2.1"},
+		"pci requirement":  {framework: PCIDSS, code: "Requirement 12", want: "12"},
+		"pci padded":       {framework: PCIDSS, code: "PCI DSS 03.5.1", want: "3.5.1"},
+		"pci too high":     {framework: PCIDSS, code: "13.1", err: `invalid PCI DSS requirement "13.1"`},
+		"pci too deep":     {framework: PCIDSS, code: "12.5.2.1.1", err: "invalid PCI DSS requirement"},
+		"soc2 as pci":      {framework: PCIDSS, code: "CC6.1", err: "invalid PCI DSS requirement"},
+		"hipaa":            {framework: HIPAA, code: "164.312(b)", want: "164.312(b)"},
+		"hipaa cfr":        {framework: HIPAA, code: "45 CFR § 164.312 (a)(2)(IV)", want: "164.312(a)(2)(iv)"},
+		"hipaa standard":   {framework: HIPAA, code: "164.308(a)(1)(i)", want: "164.308(a)(1)"},
+		"hipaa spec":       {framework: HIPAA, code: "164.308(A)(1)(ii)(d)", want: "164.308(a)(1)(ii)(D)"},
+		"hipaa spec (i)":   {framework: HIPAA, code: "164.310(a)(2)(i)", want: "164.310(a)(2)(i)"},
+		"hipaa unknown":    {framework: HIPAA, code: "164.312(f)", err: `invalid HIPAA safeguard "164.312(f)"`},
+		"hipaa privacy":    {framework: HIPAA, code: "164.502(a)", err: "invalid HIPAA safeguard"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NormalizeControl(tc.framework, tc.code)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				assert.False(t, IsControl(tc.framework, tc.code))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.True(t, IsControl(tc.framework, tc.code))
+		})
+	}
+}
+
+func TestAnnexAForSOC2(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"A.5.15", "A.5.18", "A.8.2", "A.8.3", "A.8.5", "A.8.20", "A.8.21", "A.8.22"},
+		AnnexAForSOC2("CC6.6", "cc6.1", "CC6.3"))
+	assert.Empty(t, AnnexAForSOC2("unknown"))
+
+	for soc2, annexA := range soc2AnnexA {
+		for _, code := range annexA {
+			_, ok := LookupAnnexA(code)
+			assert.True(t, ok, "%s maps to unknown Annex A control %s", soc2, code)
+		}
+	}
+}
+
+func TestNISTForSOC2(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"AC-2", "AC-3", "AC-6", "AC-17", "IA-2", "SC-7", "SC-28"}, NISTForSOC2("CC6.6", "cc6.1"))
+	assert.Empty(t, NISTForSOC2("unknown"))
+
+	for soc2, nist := range soc2NIST {
+		_, ok := soc2AnnexA[soc2]
+		assert.True(t, ok, "%s is not a SOC2 code", soc2)
+		for _, code := range nist {
+			normalized, ok := NormalizeNISTCode(code)
+			assert.True(t, ok, "%s maps to unknown NIST 800-53 control %s", soc2, code)
+			assert.Equal(t, code, normalized, "%s maps to non-canonical code", soc2)
+		}
+	}
+	assert.Len(t, soc2NIST, len(soc2AnnexA), "every SOC2 code has a NIST 800-53 crosswalk")
+}
+
+func TestPCIForSOC2(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"1.3.1", "1.3.2", "1.4.1", "3.5.1", "4.2.1", "8.4.2"}, PCIForSOC2("CC6.7", "cc6.6"))
+	assert.Empty(t, PCIForSOC2("SO2"))
+
+	for soc2, pci := range soc2PCI {
+		_, ok := soc2AnnexA[soc2]
+		assert.True(t, ok, "%s is not a SOC2 code", soc2)
+		for _, code := range pci {
+			normalized, ok := NormalizePCICode(code)
+			assert.True(t, ok, "%s maps to invalid PCI DSS requirement %s", soc2, code)
+			assert.Equal(t, code, normalized, "%s maps to non-canonical code", soc2)
+		}
+	}
+	assert.Len(t, soc2PCI, len(soc2AnnexA), "every SOC2 code has a PCI DSS entry")
+}
+
+func TestHIPAAForSOC2(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"164.308(a)(1)(ii)(D)", "164.308(a)(5)(ii)(C)", "164.312(b)", "164.312(e)(1)"},
+		HIPAAForSOC2("CC6.6", "cc7.2"))
+	assert.Empty(t, HIPAAForSOC2("SO2"))
+
+	for soc2, hipaa := range soc2HIPAA {
+		_, ok := soc2AnnexA[soc2]
+		assert.True(t, ok, "%s is not a SOC2 code", soc2)
+		for _, code := range hipaa {
+			normalized, ok := NormalizeHIPAACode(code)
+			assert.True(t, ok, "%s maps to invalid HIPAA safeguard %s", soc2, code)
+			assert.Equal(t, code, normalized, "%s maps to non-canonical code", soc2)
+		}
+	}
+	assert.Len(t, soc2HIPAA, len(soc2AnnexA), "every SOC2 code has a HIPAA entry")
+}
+
+func TestHIPAASafeguards(t *testing.T) {
+	t.Parallel()
+
+	safeguards := HIPAASafeguards()
+	require.Len(t, safeguards, 54)
+	counts := map[string]int{}
+	for _, safeguard := range safeguards {
+		counts[safeguard.Safeguard]++
+		code, ok := NormalizeHIPAACode(safeguard.Code)
+		assert.True(t, ok && code == safeguard.Code, "%s is not canonical", safeguard.Code)
+	}
+	assert.Equal(t, map[string]int{SafeguardAdministrative: 30, SafeguardPhysical: 12, SafeguardTechnical: 12}, counts)
+
+	safeguard, ok := LookupHIPAASafeguard("§164.312(a)(2)(iv)")
+	require.True(t, ok)
+	assert.Equal(t, HIPAASafeguard{Code: "164.312(a)(2)(iv)", Title: "Encryption and decryption",
+		Safeguard: SafeguardTechnical, Addressable: true}, safeguard)
+}
+
+func TestKey(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"PCI DSS v4.0":     PCIDSS,
+		"SOC 2":            SOC2,
+		"NIST 800-53":      NIST80053,
+		"HIPAA":            HIPAA,
+		"Cyber-Essentials": "cyberessentials",
+		"":                 "",
+	}
+	for name, want := range tests {
+		assert.Equal(t, want, Key(name), name)
+	}
+}
+
+func TestCrosswalk(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"CC6.1", "CC6.6"}, Crosswalk(SOC2, "cc6.6", "CC6.1", "CC6.6", "unknown"))
+	assert.Equal(t, []string{"A.8.32"}, Crosswalk(ISO27001, "CC8.1"))
+	assert.Equal(t, []string{"CM-3", "CM-4", "SA-10"}, Crosswalk(NIST80053, "CC8.1"))
+	assert.Equal(t, []string{"6.5.1", "6.5.2"}, Crosswalk(PCIDSS, "CC8.1"))
+	assert.Equal(t, []string{"164.308(a)(8)", "164.312(c)(1)"}, Crosswalk(HIPAA, "CC8.1"))
+}
+
+func TestTitle(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Use of cryptography", Title(ISO27001, "A.8.24"))
+	assert.Equal(t, "System and Communications Protection", Title(NIST80053, "sc-28"))
+	assert.Equal(t, "Log and Monitor All Access to System Components and Cardholder Data", Title(PCIDSS, "10.2.1"))
+	assert.Equal(t, "Audit controls", Title(HIPAA, "164.312(b)"))
+	assert.Empty(t, Title(SOC2, "CC6.1"))
+	assert.Len(t, NISTFamilies(), 20)
+	assert.Len(t, PCIRequirements(), 12)
+}
+
+func TestSortNIST(t *testing.T) {
+	t.Parallel()
+
+	codes := []string{"SC-28", "AC-10", "AC-2(1)", "AC-2", "AU-6"}
+	SortNIST(codes)
+	assert.Equal(t, []string{"AC-2", "AC-2(1)", "AC-10", "AU-6", "SC-28"}, codes)
+}
+
+func TestSortPCI(t *testing.T) {
+	t.Parallel()
+
+	codes := []string{"10.2.1", "1.3", "9.4.7", "1.2.8", "1.3.1", "12.5.2.1", "12.5.2"}
+	SortPCI(codes)
+	assert.Equal(t, []string{"1.2.8", "1.3", "1.3.1", "9.4.7", "10.2.1", "12.5.2", "12.5.2.1"}, codes)
+}
+
+func TestSortHIPAA(t *testing.T) {
+	t.Parallel()
+
+	codes := []string{"164.312(b)", "unknown", "164.310(c)", "164.308(a)(7)(ii)(A)", "164.308(a)(1)"}
+	SortHIPAA(codes)
+	assert.Equal(t, []string{"164.308(a)(1)", "164.308(a)(7)(ii)(A)", "164.310(c)", "164.312(b)", "unknown"}, codes)
+}
+
+func TestSortAnnexA(t *testing.T) {
+	t.Parallel()
+
+	codes := []string{"A.8.10", "A.5.15", "A.8.9", "A.6.3"}
+	SortAnnexA(codes)
+	assert.Equal(t, []string{"A.5.15", "A.6.3", "A.8.9", "A.8.10"}, codes)
+}
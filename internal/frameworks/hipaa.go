@@ -0,0 +1,189 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frameworks
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Safeguard categories of the HIPAA Security Rule
+const (
+	SafeguardAdministrative = "Administrative"
+	SafeguardPhysical       = "Physical"
+	SafeguardTechnical      = "Technical"
+)
+
+// HIPAASafeguard is a standard or implementation specification of the HIPAA
+// Security Rule (45 CFR Part 164, Subpart C), identified by its citation
+type HIPAASafeguard struct {
+	Code        string `json:"code"`
+	Title       string `json:"title"`
+	Safeguard   string `json:"safeguard"`
+	Addressable bool   `json:"addressable,omitempty"`
+}
+
+// hipaaSafeguards lists the administrative (164.308), physical (164.310) and
+// technical (164.312) safeguards in regulation order. Standards are followed
+// by their implementation specifications; the category is derived from the
+// section.
+var hipaaSafeguards = []HIPAASafeguard{
+	{Code: "164.308(a)(1)", Title: "Security management process"},
+	{Code: "164.308(a)(1)(ii)(A)", Title: "Risk analysis"},
+	{Code: "164.308(a)(1)(ii)(B)", Title: "Risk management"},
+	{Code: "164.308(a)(1)(ii)(C)", Title: "Sanction policy"},
+	{Code: "164.308(a)(1)(ii)(D)", Title: "Information system activity review"},
+	{Code: "164.308(a)(2)", Title: "Assigned security responsibility"},
+	{Code: "164.308(a)(3)", Title: "Workforce security"},
+	{Code: "164.308(a)(3)(ii)(A)", Title: "Authorization and/or supervision", Addressable: true},
+	{Code: "164.308(a)(3)(ii)(B)", Title: "Workforce clearance procedure", Addressable: true},
+	{Code: "164.308(a)(3)(ii)(C)", Title: "Termination procedures", Addressable: true},
+	{Code: "164.308(a)(4)", Title: "Information access management"},
+	{Code: "164.308(a)(4)(ii)(A)", Title: "Isolating health care clearinghouse functions"},
+	{Code: "164.308(a)(4)(ii)(B)", Title: "Access authorization", Addressable: true},
+	{Code: "164.308(a)(4)(ii)(C)", Title: "Access establishment and modification", Addressable: true},
+	{Code: "164.308(a)(5)", Title: "Security awareness and training"},
+	{Code: "164.308(a)(5)(ii)(A)", Title: "Security reminders", Addressable: true},
+	{Code: "164.308(a)(5)(ii)(B)", Title: "Protection from malicious software", Addressable: true},
+	{Code: "164.308(a)(5)(ii)(C)", Title: "Log-in monitoring", Addressable: true},
+	{Code: "164.308(a)(5)(ii)(D)", Title: "Password management", Addressable: true},
+	{Code: "164.308(a)(6)", Title: "Security incident procedures"},
+	{Code: "164.308(a)(6)(ii)", Title: "Response and reporting"},
+	{Code: "164.308(a)(7)", Title: "Contingency plan"},
+	{Code: "164.308(a)(7)(ii)(A)", Title: "Data backup plan"},
+	{Code: "164.308(a)(7)(ii)(B)", Title: "Disaster recovery plan"},
+	{Code: "164.308(a)(7)(ii)(C)", Title: "Emergency mode operation plan"},
+	{Code: "164.308(a)(7)(ii)(D)", Title: "Testing and revision procedures", Addressable: true},
+	{Code: "164.308(a)(7)(ii)(E)", Title: "Applications and data criticality analysis", Addressable: true},
+	{Code: "164.308(a)(8)", Title: "Evaluation"},
+	{Code: "164.308(b)(1)", Title: "Business associate contracts and other arrangements"},
+	{Code: "164.308(b)(3)", Title: "Written contract or other arrangement"},
+	{Code: "164.310(a)(1)", Title: "Facility access controls"},
+	{Code: "164.310(a)(2)(i)", Title: "Contingency operations", Addressable: true},
+	{Code: "164.310(a)(2)(ii)", Title: "Facility security plan", Addressable: true},
+	{Code: "164.310(a)(2)(iii)", Title: "Access control and validation procedures", Addressable: true},
+	{Code: "164.310(a)(2)(iv)", Title: "Maintenance records", Addressable: true},
+	{Code: "164.310(b)", Title: "Workstation use"},
+	{Code: "164.310(c)", Title: "Workstation security"},
+	{Code: "164.310(d)(1)", Title: "Device and media controls"},
+	{Code: "164.310(d)(2)(i)", Title: "Disposal"},
+	{Code: "164.310(d)(2)(ii)", Title: "Media re-use"},
+	{Code: "164.310(d)(2)(iii)", Title: "Accountability", Addressable: true},
+	{Code: "164.310(d)(2)(iv)", Title: "Data backup and storage", Addressable: true},
+	{Code: "164.312(a)(1)", Title: "Access control"},
+	{Code: "164.312(a)(2)(i)", Title: "Unique user identification"},
+	{Code: "164.312(a)(2)(ii)", Title: "Emergency access procedure"},
+	{Code: "164.312(a)(2)(iii)", Title: "Automatic logoff", Addressable: true},
+	{Code: "164.312(a)(2)(iv)", Title: "Encryption and decryption", Addressable: true},
+	{Code: "164.312(b)", Title: "Audit controls"},
+	{Code: "164.312(c)(1)", Title: "Integrity"},
+	{Code: "164.312(c)(2)", Title: "Mechanism to authenticate electronic protected health information", Addressable: true},
+	{Code: "164.312(d)", Title: "Person or entity authentication"},
+	{Code: "164.312(e)(1)", Title: "Transmission security"},
+	{Code: "164.312(e)(2)(i)", Title: "Integrity controls", Addressable: true},
+	{Code: "164.312(e)(2)(ii)", Title: "Encryption", Addressable: true},
+}
+
+// hipaaSections maps the Security Rule sections to their safeguard category
+var hipaaSections = map[string]string{
+	"164.308": SafeguardAdministrative,
+	"164.310": SafeguardPhysical,
+	"164.312": SafeguardTechnical,
+}
+
+// hipaaIndex maps a lowercased citation to its position in hipaaSafeguards
+var hipaaIndex = func() map[string]int {
+	index := make(map[string]int, len(hipaaSafeguards))
+	for i, safeguard := range hipaaSafeguards {
+		index[strings.ToLower(safeguard.Code)] = i
+	}
+	return index
+}()
+
+// hipaaCodePattern matches citations such as "164.312(a)(2)(iv)", optionally
+// prefixed with "HIPAA", "45 CFR" or "§" and with spaces between paragraphs
+var hipaaCodePattern = regexp.MustCompile(`^(?:hipaa\s*)?(?:45\s*c\.?f\.?r\.?\s*)?(?:(?:§|sec\.?|section)\s*)*(164\.3(?:08|10|12))\s*((?:\(\s*[a-z0-9]+\s*\)\s*)+)$`)
+
+// HIPAASafeguards returns the HIPAA Security Rule safeguards in regulation order
+func HIPAASafeguards() []HIPAASafeguard {
+	safeguards := make([]HIPAASafeguard, len(hipaaSafeguards))
+	for i := range hipaaSafeguards {
+		safeguards[i] = hipaaSafeguard(i)
+	}
+	return safeguards
+}
+
+// LookupHIPAASafeguard returns the safeguard with the given citation in any
+// of the forms NormalizeHIPAACode accepts
+func LookupHIPAASafeguard(code string) (HIPAASafeguard, bool) {
+	i, ok := parseHIPAACode(code)
+	if !ok {
+		return HIPAASafeguard{}, false
+	}
+	return hipaaSafeguard(i), true
+}
+
+// NormalizeHIPAACode returns the canonical citation of a HIPAA safeguard,
+// e.g. "45 CFR § 164.312 (a)(2)(IV)" becomes "164.312(a)(2)(iv)". A standard
+// may also be cited by its first paragraph, so "164.308(a)(1)(i)" becomes
+// "164.308(a)(1)".
+func NormalizeHIPAACode(code string) (string, bool) {
+	i, ok := parseHIPAACode(code)
+	if !ok {
+		return "", false
+	}
+	return hipaaSafeguards[i].Code, true
+}
+
+// SortHIPAA sorts HIPAA citations in regulation order
+func SortHIPAA(codes []string) {
+	sort.SliceStable(codes, func(i, j int) bool {
+		pi, ok := parseHIPAACode(codes[i])
+		if !ok {
+			pi = len(hipaaSafeguards)
+		}
+		pj, ok := parseHIPAACode(codes[j])
+		if !ok {
+			pj = len(hipaaSafeguards)
+		}
+		return pi < pj
+	})
+}
+
+// parseHIPAACode returns the position of a citation in hipaaSafeguards
+func parseHIPAACode(code string) (int, bool) {
+	match := hipaaCodePattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(code)))
+	if match == nil {
+		return 0, false
+	}
+	citation := match[1] + strings.Join(strings.Fields(match[2]), "")
+	if i, ok := hipaaIndex[citation]; ok {
+		return i, true
+	}
+	if standard, found := strings.CutSuffix(citation, "(i)"); found {
+		i, ok := hipaaIndex[standard]
+		return i, ok
+	}
+	return 0, false
+}
+
+// hipaaSafeguard returns the safeguard at position i with its category
+func hipaaSafeguard(i int) HIPAASafeguard {
+	safeguard := hipaaSafeguards[i]
+	safeguard.Safeguard = hipaaSections[strings.SplitN(safeguard.Code, "(", 2)[0]]
+	return safeguard
+}
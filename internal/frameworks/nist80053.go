@@ -0,0 +1,136 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frameworks
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// NISTFamily is a control family of NIST SP 800-53 Rev. 5
+type NISTFamily struct {
+	ID       string
+	Name     string
+	Controls int // number of base controls, including withdrawn ones
+}
+
+// nistFamilies lists the control families of NIST SP 800-53 Rev. 5 in
+// catalog order
+var nistFamilies = []NISTFamily{
+	{"AC", "Access Control", 25},
+	{"AT", "Awareness and Training", 6},
+	{"AU", "Audit and Accountability", 16},
+	{"CA", "Assessment, Authorization, and Monitoring", 9},
+	{"CM", "Configuration Management", 14},
+	{"CP", "Contingency Planning", 13},
+	{"IA", "Identification and Authentication", 13},
+	{"IR", "Incident Response", 10},
+	{"MA", "Maintenance", 7},
+	{"MP", "Media Protection", 8},
+	{"PE", "Physical and Environmental Protection", 23},
+	{"PL", "Planning", 11},
+	{"PM", "Program Management", 32},
+	{"PS", "Personnel Security", 9},
+	{"PT", "PII Processing and Transparency", 8},
+	{"RA", "Risk Assessment", 10},
+	{"SA", "System and Services Acquisition", 23},
+	{"SC", "System and Communications Protection", 51},
+	{"SI", "System and Information Integrity", 23},
+	{"SR", "Supply Chain Risk Management", 12},
+}
+
+// nistCodePattern matches "AC-2", "ac-02", "AC 2", "AC2" and enhancements
+// such as "AC-2(1)" or "AC-2 (1)"
+var nistCodePattern = regexp.MustCompile(`^([A-Z]{2})\s*-?\s*(\d{1,2})(?:\s*\(\s*(\d{1,2})\s*\))?$`)
+
+// NISTFamilies returns the NIST SP 800-53 control families in catalog order
+func NISTFamilies() []NISTFamily {
+	return append([]NISTFamily(nil), nistFamilies...)
+}
+
+// LookupNISTFamily returns the family of a NIST SP 800-53 control code in
+// any of the forms NormalizeNISTCode accepts
+func LookupNISTFamily(code string) (NISTFamily, bool) {
+	family, _, _, ok := parseNISTCode(code)
+	if !ok {
+		return NISTFamily{}, false
+	}
+	return nistFamilies[family], true
+}
+
+// NormalizeNISTCode returns the canonical "AC-2" or "AC-2(1)" form of a
+// NIST SP 800-53 control or control enhancement code
+func NormalizeNISTCode(code string) (string, bool) {
+	family, number, enhancement, ok := parseNISTCode(code)
+	if !ok {
+		return "", false
+	}
+	return nistCode(family, number, enhancement), true
+}
+
+// SortNIST sorts NIST SP 800-53 codes in catalog order, so AC-2 precedes
+// AC-2(1) and AC-10
+func SortNIST(codes []string) {
+	sort.SliceStable(codes, func(i, j int) bool {
+		fi, ni, ei, _ := parseNISTCode(codes[i])
+		fj, nj, ej, _ := parseNISTCode(codes[j])
+		if fi != fj {
+			return fi < fj
+		}
+		if ni != nj {
+			return ni < nj
+		}
+		return ei < ej
+	})
+}
+
+// parseNISTCode returns the family index, control number and enhancement
+// number (0 for a base control) of a code
+func parseNISTCode(code string) (int, int, int, bool) {
+	match := nistCodePattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(code)))
+	if match == nil {
+		return 0, 0, 0, false
+	}
+	family := -1
+	for i, f := range nistFamilies {
+		if f.ID == match[1] {
+			family = i
+			break
+		}
+	}
+	number, _ := strconv.Atoi(match[2])
+	if family < 0 || number < 1 || number > nistFamilies[family].Controls {
+		return 0, 0, 0, false
+	}
+	enhancement := 0
+	if match[3] != "" {
+		enhancement, _ = strconv.Atoi(match[3])
+		if enhancement < 1 {
+			return 0, 0, 0, false
+		}
+	}
+	return family, number, enhancement, true
+}
+
+func nistCode(family, number, enhancement int) string {
+	code := nistFamilies[family].ID + "-" + strconv.Itoa(number)
+	if enhancement > 0 {
+		code += "(" + strconv.Itoa(enhancement) + ")"
+	}
+	return code
+}
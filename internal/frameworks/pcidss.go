@@ -0,0 +1,116 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frameworks
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PCIRequirement is one of the twelve principal requirements of PCI DSS v4.0
+type PCIRequirement struct {
+	Number int
+	Title  string
+}
+
+// pciRequirements lists the principal requirements of PCI DSS v4.0 in order
+var pciRequirements = []PCIRequirement{
+	{1, "Install and Maintain Network Security Controls"},
+	{2, "Apply Secure Configurations to All System Components"},
+	{3, "Protect Stored Account Data"},
+	{4, "Protect Cardholder Data with Strong Cryptography During Transmission"},
+	{5, "Protect All Systems and Networks from Malicious Software"},
+	{6, "Develop and Maintain Secure Systems and Software"},
+	{7, "Restrict Access to System Components and Cardholder Data by Business Need to Know"},
+	{8, "Identify Users and Authenticate Access to System Components"},
+	{9, "Restrict Physical Access to Cardholder Data"},
+	{10, "Log and Monitor All Access to System Components and Cardholder Data"},
+	{11, "Test Security of Systems and Networks Regularly"},
+	{12, "Support Information Security with Organizational Policies and Programs"},
+}
+
+// pciCodePattern matches requirement numbers such as "1.3.1", "10.2" or
+// "12.5.2.1", optionally prefixed with "PCI DSS", "Req." or "Requirement"
+var pciCodePattern = regexp.MustCompile(`^(?:PCI\s*(?:DSS)?\s*)?(?:REQ(?:UIREMENT)?\.?\s*)?(\d{1,2}(?:\.\d{1,2}){0,3})$`)
+
+// PCIRequirements returns the principal requirements of PCI DSS v4.0
+func PCIRequirements() []PCIRequirement {
+	return append([]PCIRequirement(nil), pciRequirements...)
+}
+
+// LookupPCIRequirement returns the principal requirement a PCI DSS
+// requirement code belongs to, e.g. requirement 10 for "10.2.1"
+func LookupPCIRequirement(code string) (PCIRequirement, bool) {
+	parts, ok := parsePCICode(code)
+	if !ok {
+		return PCIRequirement{}, false
+	}
+	return pciRequirements[parts[0]-1], true
+}
+
+// NormalizePCICode returns the canonical dotted form of a PCI DSS
+// requirement code, e.g. "Req. 01.3.1" becomes "1.3.1"
+func NormalizePCICode(code string) (string, bool) {
+	parts, ok := parsePCICode(code)
+	if !ok {
+		return "", false
+	}
+	numbers := make([]string, len(parts))
+	for i, part := range parts {
+		numbers[i] = strconv.Itoa(part)
+	}
+	return strings.Join(numbers, "."), true
+}
+
+// SortPCI sorts PCI DSS requirement codes numerically, so 1.2.8 precedes
+// 1.3 and 10.2 follows 9.4
+func SortPCI(codes []string) {
+	sort.SliceStable(codes, func(i, j int) bool {
+		pi, _ := parsePCICode(codes[i])
+		pj, _ := parsePCICode(codes[j])
+		for k := 0; k < len(pi) && k < len(pj); k++ {
+			if pi[k] != pj[k] {
+				return pi[k] < pj[k]
+			}
+		}
+		return len(pi) < len(pj)
+	})
+}
+
+// parsePCICode returns the numbers of a requirement code, the first being
+// the principal requirement
+func parsePCICode(code string) ([]int, bool) {
+	match := pciCodePattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(code)))
+	if match == nil {
+		return nil, false
+	}
+	var parts []int
+	for _, number := range strings.Split(match[1], ".") {
+		n, _ := strconv.Atoi(number)
+		parts = append(parts, n)
+	}
+	if parts[0] < 1 || parts[0] > len(pciRequirements) {
+		return nil, false
+	}
+	for _, n := range parts[1:] {
+		if n < 1 {
+			return nil, false
+		}
+	}
+	return parts, true
+}
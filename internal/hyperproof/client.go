@@ -0,0 +1,301 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hyperproof uploads evidence files as proof of Hyperproof labels and
+// controls through the Hyperproof API, authenticating with OAuth client
+// credentials.
+package hyperproof
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+)
+
+// Default endpoints used when none are configured
+const (
+	DefaultBaseURL  = "https://api.hyperproof.app"
+	DefaultTokenURL = "https://accounts.hyperproof.app/oauth/token"
+)
+
+// MaxFileSize is the largest file Hyperproof accepts as proof
+const MaxFileSize = 100 * 1024 * 1024 // 100MB
+
+// Kinds of object proof is uploaded to
+const (
+	KindLabel   = "label"
+	KindControl = "control"
+)
+
+// ProofTarget is the label or control a task's proof is uploaded to
+type ProofTarget struct {
+	Kind string // KindLabel or KindControl
+	ID   string
+}
+
+// String returns the target as "label <id>" or "control <id>"
+func (p ProofTarget) String() string {
+	return p.Kind + " " + p.ID
+}
+
+// ProofTargets returns the label or control configured for each task. A task
+// with both uploads to its label.
+func ProofTargets(cfg config.HyperproofConfig) map[string]ProofTarget {
+	targets := make(map[string]ProofTarget, len(cfg.LabelIDs)+len(cfg.ControlIDs))
+	for taskRef, id := range cfg.ControlIDs {
+		if id != "" {
+			targets[taskRef] = ProofTarget{Kind: KindControl, ID: id}
+		}
+	}
+	for taskRef, id := range cfg.LabelIDs {
+		if id != "" {
+			targets[taskRef] = ProofTarget{Kind: KindLabel, ID: id}
+		}
+	}
+	return targets
+}
+
+// Client uploads proof to Hyperproof
+type Client struct {
+	baseURL      string
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	ownerID      string
+	httpClient   *http.Client
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+// NewClient creates a Hyperproof API client. The http client defaults to one
+// with the configured timeout.
+func NewClient(cfg config.HyperproofConfig, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		timeout := cfg.Timeout
+		if timeout <= 0 {
+			timeout = 30 * time.Second
+		}
+		httpClient = &http.Client{Timeout: timeout}
+	}
+	baseURL := strings.TrimRight(cfg.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	tokenURL := cfg.TokenURL
+	if tokenURL == "" {
+		tokenURL = DefaultTokenURL
+	}
+	return &Client{
+		baseURL:      baseURL,
+		tokenURL:     tokenURL,
+		clientID:     cfg.ClientID,
+		clientSecret: cfg.ClientSecret,
+		ownerID:      cfg.OwnerID,
+		httpClient:   httpClient,
+	}
+}
+
+// ProofRequest is a file to upload as proof
+type ProofRequest struct {
+	Target      ProofTarget
+	Filename    string
+	Content     []byte
+	ContentType string
+	Metadata    ProofMetadata
+}
+
+// ProofMetadata describes where proof came from. Hyperproof shows it with the
+// proof and uses the source ID to group re-uploads of the same file.
+type ProofMetadata struct {
+	SourceID    string    // Stable ID of the file in its source, e.g. "grctool/ET-0001/2025-Q4/users.csv"
+	Source      string    // Collecting system, e.g. "grctool"
+	CollectedAt time.Time // When the evidence was collected
+	Description string
+}
+
+// fields returns the proof metadata as Hyperproof's hp-proof-* form fields
+func (m ProofMetadata) fields(ownerID string) [][2]string {
+	var fields [][2]string
+	add := func(name, value string) {
+		if value != "" {
+			fields = append(fields, [2]string{name, value})
+		}
+	}
+	add("hp-proof-ext-source-id", m.SourceID)
+	add("hp-proof-source", m.Source)
+	if !m.CollectedAt.IsZero() {
+		collected := m.CollectedAt.UTC().Format(time.RFC3339)
+		add("hp-proof-ext-created-on", collected)
+		add("hp-proof-ext-modified-on", collected)
+	}
+	add("hp-proof-description", m.Description)
+	add("hp-proof-owned-by", ownerID)
+	return fields
+}
+
+// ProofResponse is Hyperproof's record of uploaded proof
+type ProofResponse struct {
+	ID       string `json:"id"`
+	Filename string `json:"filename,omitempty"`
+}
+
+// UploadProof uploads a file as proof of a label or control
+func (c *Client) UploadProof(ctx context.Context, req *ProofRequest) (*ProofResponse, error) {
+	var collection string
+	switch req.Target.Kind {
+	case KindLabel:
+		collection = "labels"
+	case KindControl:
+		collection = "controls"
+	default:
+		return nil, fmt.Errorf("unsupported proof target %q: use a label or control", req.Target.Kind)
+	}
+	if req.Target.ID == "" {
+		return nil, fmt.Errorf("%s ID is required", req.Target.Kind)
+	}
+	if req.Filename == "" {
+		return nil, fmt.Errorf("filename is required")
+	}
+	if len(req.Content) > MaxFileSize {
+		return nil, fmt.Errorf("file size %d bytes exceeds maximum allowed size of %d bytes (100MB)", len(req.Content), MaxFileSize)
+	}
+
+	token, err := c.accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for _, field := range req.Metadata.fields(c.ownerID) {
+		if err := writer.WriteField(field[0], field[1]); err != nil {
+			return nil, fmt.Errorf("failed to write %s field: %w", field[0], err)
+		}
+	}
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="proof"; filename="%s"`,
+		strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(req.Filename)))
+	contentType := req.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create form file: %w", err)
+	}
+	if _, err := part.Write(req.Content); err != nil {
+		return nil, fmt.Errorf("failed to copy file content: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/v1/%s/%s/proof", c.baseURL, collection, url.PathEscape(req.Target.ID))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Hyperproof request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+token)
+	httpReq.Header.Set("Content-Type", writer.FormDataContentType())
+	httpReq.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload to Hyperproof: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("hyperproof upload failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	result := &ProofResponse{}
+	if len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return nil, fmt.Errorf("failed to parse Hyperproof response: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// accessToken returns a cached OAuth token, requesting a new one shortly
+// before the cached one expires
+func (c *Client) accessToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && time.Now().Before(c.tokenExpiry) {
+		return c.token, nil
+	}
+	if c.clientID == "" || c.clientSecret == "" {
+		return "", fmt.Errorf("hyperproof client credentials not configured - set hyperproof.client_id and HYPERPROOF_CLIENT_SECRET")
+	}
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {c.clientID},
+		"client_secret": {c.clientSecret},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to authenticate with Hyperproof: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("hyperproof authentication failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"` // Seconds
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to parse Hyperproof token: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("hyperproof returned an empty access token")
+	}
+
+	// Refresh a minute early so a token doesn't expire mid-upload
+	c.token = token.AccessToken
+	c.tokenExpiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - time.Minute)
+	return c.token, nil
+}
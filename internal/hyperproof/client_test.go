@@ -0,0 +1,130 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package hyperproof
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProofTargets(t *testing.T) {
+	t.Parallel()
+
+	targets := ProofTargets(config.HyperproofConfig{
+		LabelIDs:   map[string]string{"ET-0001": "lbl-1", "ET-0002": "lbl-2", "ET-0004": ""},
+		ControlIDs: map[string]string{"ET-0002": "ctl-2", "ET-0003": "ctl-3"},
+	})
+	assert.Equal(t, map[string]ProofTarget{
+		"ET-0001": {Kind: KindLabel, ID: "lbl-1"},
+		"ET-0002": {Kind: KindLabel, ID: "lbl-2"},
+		"ET-0003": {Kind: KindControl, ID: "ctl-3"},
+	}, targets)
+	assert.Equal(t, "control ctl-3", targets["ET-0003"].String())
+}
+
+func TestClient_UploadProof(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	tokenCalls := 0
+	var uploads []map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/oauth/token" {
+			tokenCalls++
+			if r.FormValue("client_secret") != "secret" {
+				http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+				return
+			}
+			assert.Equal(t, "client_credentials", r.FormValue("grant_type"))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token-1", "expires_in": 3600})
+			return
+		}
+		assert.Equal(t, "Bearer token-1", r.Header.Get("Authorization"))
+		file, header, err := r.FormFile("proof")
+		require.NoError(t, err)
+		content, _ := io.ReadAll(file)
+		uploads = append(uploads, map[string]string{
+			"path":        r.URL.Path,
+			"filename":    header.Filename,
+			"content":     string(content),
+			"source_id":   r.FormValue("hp-proof-ext-source-id"),
+			"source":      r.FormValue("hp-proof-source"),
+			"created_on":  r.FormValue("hp-proof-ext-created-on"),
+			"description": r.FormValue("hp-proof-description"),
+			"owned_by":    r.FormValue("hp-proof-owned-by"),
+		})
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": "proof-1", "filename": header.Filename})
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := config.HyperproofConfig{
+		BaseURL: server.URL, TokenURL: server.URL + "/oauth/token",
+		ClientID: "id", ClientSecret: "secret", OwnerID: "user-7",
+	}
+	client := NewClient(cfg, server.Client())
+	metadata := ProofMetadata{
+		SourceID:    "grctool/ET-0001/2025-Q4/users.csv",
+		Source:      "grctool",
+		CollectedAt: time.Date(2025, 10, 1, 9, 0, 0, 0, time.UTC),
+		Description: "ET-0001 2025-Q4",
+	}
+
+	resp, err := client.UploadProof(context.Background(), &ProofRequest{
+		Target: ProofTarget{Kind: KindLabel, ID: "lbl-1"}, Filename: "users.csv", Content: []byte("user\n"), Metadata: metadata,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "proof-1", resp.ID)
+	_, err = client.UploadProof(context.Background(), &ProofRequest{
+		Target: ProofTarget{Kind: KindControl, ID: "ctl-3"}, Filename: "users.csv", Content: []byte("user\n"),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, tokenCalls, "token is cached between uploads")
+	require.Len(t, uploads, 2)
+	assert.Equal(t, map[string]string{
+		"path":        "/v1/labels/lbl-1/proof",
+		"filename":    "users.csv",
+		"content":     "user\n",
+		"source_id":   "grctool/ET-0001/2025-Q4/users.csv",
+		"source":      "grctool",
+		"created_on":  "2025-10-01T09:00:00Z",
+		"description": "ET-0001 2025-Q4",
+		"owned_by":    "user-7",
+	}, uploads[0])
+	assert.Equal(t, "/v1/controls/ctl-3/proof", uploads[1]["path"])
+
+	tests := map[string]struct {
+		secret string
+		target ProofTarget
+		err    string
+	}{
+		"no credentials":  {target: ProofTarget{Kind: KindLabel, ID: "lbl-1"}, err: "hyperproof client credentials not configured"},
+		"bad credentials": {secret: "wrong", target: ProofTarget{Kind: KindLabel, ID: "lbl-1"}, err: "hyperproof authentication failed with status 401"},
+		"no id":           {secret: "secret", target: ProofTarget{Kind: KindLabel}, err: "label ID is required"},
+		"unknown kind":    {secret: "secret", target: ProofTarget{Kind: "program", ID: "p-1"}, err: `unsupported proof target "program"`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cfg := cfg
+			cfg.ClientSecret = tc.secret
+			client := NewClient(cfg, server.Client())
+			_, err := client.UploadProof(context.Background(), &ProofRequest{Target: tc.target, Filename: "users.csv"})
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
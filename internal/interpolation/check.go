@@ -0,0 +1,101 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interpolation
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// templateExpression matches {{...}} expressions
+var templateExpression = regexp.MustCompile(`\{\{([^}]+)\}\}`)
+
+// Problem is a {{...}} expression that strict interpolation rejects
+type Problem struct {
+	Expression string `json:"expression"`           // As written, e.g. "{{organisation.name}}"
+	Line       int    `json:"line"`                 // Line of the text it is on, from 1
+	Message    string `json:"message"`              // e.g. "missing variable: organisation.name"
+	Suggestion string `json:"suggestion,omitempty"` // Defined variable it is likely a typo of
+}
+
+// Check reports the {{...}} expressions of text that MissingVariableError
+// rejects: variables that are not defined and function calls that fail. It
+// checks whether or not interpolation is enabled. [Bracket] variables are
+// not checked, since markdown links and checkboxes are written the same way.
+func (si *StandardInterpolator) Check(text string) []Problem {
+	strict := *si
+	strict.enabled = true
+	strict.onMissingVariable = MissingVariableError
+
+	var problems []Problem
+	for _, loc := range templateExpression.FindAllStringIndex(text, -1) {
+		expr := text[loc[0]:loc[1]]
+		if _, _, err := strict.interpolateTemplateVariables(expr); err != nil {
+			problems = append(problems, Problem{
+				Expression: expr,
+				Line:       strings.Count(text[:loc[0]], "\n") + 1,
+				Message:    err.Error(),
+				Suggestion: si.suggest(strings.TrimPrefix(err.Error(), "missing variable: ")),
+			})
+		}
+	}
+	return problems
+}
+
+// suggest returns the defined variable closest to an undefined name, when it
+// is near enough to be a typo. Other errors have no suggestion.
+func (si *StandardInterpolator) suggest(name string) string {
+	if name == "" || strings.ContainsAny(name, " |\":") {
+		return ""
+	}
+	names := make([]string, 0, len(si.variables))
+	for defined := range si.variables {
+		names = append(names, defined)
+	}
+	sort.Strings(names)
+
+	// Allow about one edit in three characters
+	best, bestDistance := "", len(name)/3+2
+	for _, defined := range names {
+		if d := editDistance(strings.ToLower(name), strings.ToLower(defined)); d < bestDistance {
+			best, bestDistance = defined, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interpolation
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	interpolator := scopedInterpolator()
+
+	tests := []struct {
+		name     string
+		text     string
+		expected []Problem
+	}{
+		{
+			name: "defined variables and calls",
+			text: "{{organization.name}} is audited by {{upper audit.firm}} [Company Name]",
+		},
+		{
+			name: "typo",
+			text: "Policy of\n{{organisation.name}}",
+			expected: []Problem{{
+				Expression: "{{organisation.name}}",
+				Line:       2,
+				Message:    "missing variable: organisation.name",
+				Suggestion: "organization.name",
+			}},
+		},
+		{
+			name: "unrelated name",
+			text: "{{support.email}}",
+			expected: []Problem{{
+				Expression: "{{support.email}}",
+				Line:       1,
+				Message:    "missing variable: support.email",
+			}},
+		},
+		{
+			name: "failed call",
+			text: "{{ lower audit.frim }}\n\n{{windowStart}}",
+			expected: []Problem{
+				{Expression: "{{ lower audit.frim }}", Line: 1, Message: "missing variable: audit.frim", Suggestion: "audit.firm"},
+				{Expression: "{{windowStart}}", Line: 3, Message: "windowStart: no collection window"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := interpolator.Check(tt.text)
+			if !reflect.DeepEqual(problems, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, problems)
+			}
+		})
+	}
+}
+
+func TestCheck_Scoped(t *testing.T) {
+	interpolator := NewStandardInterpolator(InterpolatorConfig{
+		Variables:          map[string]string{"organization.name": "Seventh Sense"},
+		FrameworkVariables: map[string]map[string]string{"soc2": {"audit.firm": "Prescient Assurance"}},
+		Enabled:            false,
+	})
+	text := "{{organization.name}} / {{audit.firm}}"
+
+	if problems := interpolator.Check(text); len(problems) != 1 || problems[0].Expression != "{{audit.firm}}" {
+		t.Errorf("Expected audit.firm to be undefined globally, got %+v", problems)
+	}
+	scoped := interpolator.WithScope(Scope{Framework: "SOC 2"}).(*StandardInterpolator)
+	if problems := scoped.Check(text); len(problems) != 0 {
+		t.Errorf("Expected no problems in the SOC 2 scope, got %+v", problems)
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interpolation
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Function is a function templates can call, as {{upper organization.name}}
+// or at the end of a pipeline, as {{organization.name | upper}}. Arguments
+// are variable names or double-quoted strings.
+type Function struct {
+	Name        string `json:"name"`
+	Usage       string `json:"usage"`
+	Description string `json:"description"`
+	call        func(si *StandardInterpolator, args []string) (string, error)
+	minArgs     int
+	maxArgs     int
+}
+
+// dateLayout is how dates are written unless a function is given a Go time
+// layout
+const dateLayout = "2006-01-02"
+
+var functions = []Function{
+	{Name: "upper", Usage: "upper VALUE", Description: "VALUE in upper case", minArgs: 1, maxArgs: 1,
+		call: func(_ *StandardInterpolator, args []string) (string, error) { return strings.ToUpper(args[0]), nil }},
+	{Name: "lower", Usage: "lower VALUE", Description: "VALUE in lower case", minArgs: 1, maxArgs: 1,
+		call: func(_ *StandardInterpolator, args []string) (string, error) { return strings.ToLower(args[0]), nil }},
+	{Name: "title", Usage: "title VALUE", Description: "VALUE with each word capitalized", minArgs: 1, maxArgs: 1,
+		call: func(_ *StandardInterpolator, args []string) (string, error) { return titleCase(args[0]), nil }},
+	{Name: "now", Usage: `now ["LAYOUT"]`, Description: "Today's date, as 2006-01-02 or the Go time layout given", maxArgs: 1,
+		call: func(si *StandardInterpolator, args []string) (string, error) {
+			return si.now().Format(layoutArg(args, 0)), nil
+		}},
+	{Name: "windowStart", Usage: `windowStart [WINDOW ["LAYOUT"]]`, Description: "First day of a collection window such as 2025-Q4, by default the document's window", maxArgs: 2,
+		call: func(si *StandardInterpolator, args []string) (string, error) {
+			start, _, err := si.windowBounds(args)
+			if err != nil {
+				return "", err
+			}
+			return start.Format(layoutArg(args, 1)), nil
+		}},
+	{Name: "windowEnd", Usage: `windowEnd [WINDOW ["LAYOUT"]]`, Description: "Last day of a collection window such as 2025-Q4, by default the document's window", maxArgs: 2,
+		call: func(si *StandardInterpolator, args []string) (string, error) {
+			_, end, err := si.windowBounds(args)
+			if err != nil {
+				return "", err
+			}
+			return end.AddDate(0, 0, -1).Format(layoutArg(args, 1)), nil
+		}},
+	{Name: "env", Usage: `env "NAME"`, Description: "An environment variable listed in interpolation.env", minArgs: 1, maxArgs: 1,
+		call: func(si *StandardInterpolator, args []string) (string, error) {
+			if !si.allowedEnv[args[0]] {
+				return "", fmt.Errorf("environment variable %s is not listed in interpolation.env", args[0])
+			}
+			value, ok := os.LookupEnv(args[0])
+			if !ok {
+				return "", fmt.Errorf("environment variable %s is not set", args[0])
+			}
+			return value, nil
+		}},
+}
+
+// Functions lists the functions templates can call
+func Functions() []Function {
+	return append([]Function(nil), functions...)
+}
+
+func lookupFunction(name string) (Function, bool) {
+	for _, fn := range functions {
+		if fn.Name == name {
+			return fn, true
+		}
+	}
+	return Function{}, false
+}
+
+// evaluate runs a {{...}} expression that calls functions; ok is false when
+// the expression is not a function call, e.g. an unknown variable
+func (si *StandardInterpolator) evaluate(expr string) (value string, ok bool, err error) {
+	stages := splitPipeline(expr)
+	var piped *string
+	for i, stage := range stages {
+		words, err := splitWords(stage)
+		if err != nil || len(words) == 0 {
+			return "", false, nil
+		}
+		fn, isFunction := lookupFunction(words[0].text)
+		if words[0].quoted {
+			isFunction = false
+		}
+		if !isFunction {
+			// Only the start of a pipeline may be a value instead of a call
+			if i > 0 || len(words) > 1 || len(stages) == 1 {
+				return "", false, nil
+			}
+			v, err := si.argument(words[0])
+			if err != nil {
+				return "", true, err
+			}
+			piped = &v
+			continue
+		}
+
+		args := make([]string, 0, len(words))
+		for _, word := range words[1:] {
+			v, err := si.argument(word)
+			if err != nil {
+				return "", true, err
+			}
+			args = append(args, v)
+		}
+		if piped != nil {
+			args = append(args, *piped)
+		}
+		if len(args) < fn.minArgs || len(args) > fn.maxArgs {
+			return "", true, fmt.Errorf("%s: usage is {{%s}}", fn.Name, fn.Usage)
+		}
+		v, err := fn.call(si, args)
+		if err != nil {
+			return "", true, fmt.Errorf("%s: %w", fn.Name, err)
+		}
+		piped = &v
+	}
+	if piped == nil {
+		return "", false, nil
+	}
+	return *piped, true, nil
+}
+
+// argument resolves a quoted string or a variable name
+func (si *StandardInterpolator) argument(w word) (string, error) {
+	if w.quoted {
+		return w.text, nil
+	}
+	if value, ok := si.variables[w.text]; ok {
+		return value, nil
+	}
+	return "", fmt.Errorf("missing variable: %s", w.text)
+}
+
+// windowBounds returns the window named by the first argument, or the
+// document's window
+func (si *StandardInterpolator) windowBounds(args []string) (time.Time, time.Time, error) {
+	window := si.variables[WindowVariable]
+	if len(args) > 0 {
+		window = args[0]
+	}
+	if window == "" {
+		return time.Time{}, time.Time{}, fmt.Errorf("no collection window")
+	}
+	if si.parseWindow == nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("collection windows are not available here")
+	}
+	return si.parseWindow(window)
+}
+
+func (si *StandardInterpolator) now() time.Time {
+	if si.clock != nil {
+		return si.clock()
+	}
+	return time.Now()
+}
+
+func layoutArg(args []string, i int) string {
+	if len(args) > i && args[i] != "" {
+		return args[i]
+	}
+	return dateLayout
+}
+
+func titleCase(s string) string {
+	words := strings.Fields(s)
+	for i, w := range words {
+		r, size := utf8.DecodeRuneInString(w)
+		words[i] = string(unicode.ToUpper(r)) + strings.ToLower(w[size:])
+	}
+	return strings.Join(words, " ")
+}
+
+// word is a function name, variable name or quoted string in an expression
+type word struct {
+	text   string
+	quoted bool
+}
+
+// splitPipeline splits an expression on the | outside quoted strings
+func splitPipeline(expr string) []string {
+	var stages []string
+	inQuote, start := false, 0
+	for i := 0; i < len(expr); i++ {
+		switch expr[i] {
+		case '\\':
+			if inQuote {
+				i++
+			}
+		case '"':
+			inQuote = !inQuote
+		case '|':
+			if !inQuote {
+				stages = append(stages, expr[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(stages, expr[start:])
+}
+
+// splitWords splits a pipeline stage into words, unquoting quoted strings
+func splitWords(stage string) ([]word, error) {
+	var words []word
+	rest := strings.TrimSpace(stage)
+	for rest != "" {
+		if rest[0] == '"' {
+			quoted, err := strconv.QuotedPrefix(rest)
+			if err != nil {
+				return nil, err
+			}
+			text, _ := strconv.Unquote(quoted)
+			words = append(words, word{text: text, quoted: true})
+			rest = strings.TrimSpace(rest[len(quoted):])
+			continue
+		}
+		end := strings.IndexFunc(rest, unicode.IsSpace)
+		if end < 0 {
+			end = len(rest)
+		}
+		words = append(words, word{text: rest[:end]})
+		rest = strings.TrimSpace(rest[end:])
+	}
+	return words, nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interpolation
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// quarterBounds parses the quarter windows the tests use
+func quarterBounds(window string) (time.Time, time.Time, error) {
+	var year, quarter int
+	if _, err := fmt.Sscanf(window, "%d-Q%d", &year, &quarter); err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid evidence window: %q", window)
+	}
+	start := time.Date(year, time.Month((quarter-1)*3+1), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 3, 0), nil
+}
+
+func TestInterpolateFunctions(t *testing.T) {
+	t.Setenv("GRCTOOL_TEST_REGION", "eu-west-1")
+	t.Setenv("GRCTOOL_TEST_SECRET", "hunter2")
+
+	interpolator := NewStandardInterpolator(InterpolatorConfig{
+		Variables: map[string]string{
+			"organization.name": "seventh sense",
+			"audit.window":      "2025-Q3",
+		},
+		Enabled:           true,
+		OnMissingVariable: MissingVariableIgnore,
+		AllowedEnv:        []string{"GRCTOOL_TEST_REGION", "GRCTOOL_TEST_UNSET"},
+		ParseWindow:       quarterBounds,
+		Now:               func() time.Time { return time.Date(2025, time.November, 3, 9, 0, 0, 0, time.UTC) },
+	})
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"upper", "{{upper organization.name}}", "SEVENTH SENSE"},
+		{"title", "{{ title organization.name }}", "Seventh Sense"},
+		{"pipe", "{{organization.name | upper}}", "SEVENTH SENSE"},
+		{"pipe chain", `{{"MIXED case" | lower | title}}`, "Mixed Case"},
+		{"quoted argument", `{{lower "ACME | Corp"}}`, "acme | corp"},
+		{"now", "Reviewed {{now}}", "Reviewed 2025-11-03"},
+		{"now with layout", `{{now "January 2, 2006"}}`, "November 3, 2025"},
+		{"window start", `{{windowStart "2025-Q4"}}`, "2025-10-01"},
+		{"window end is the last day", `{{windowEnd audit.window "Jan 2, 2006"}}`, "Sep 30, 2025"},
+		{"window variable piped", "{{audit.window | windowEnd}}", "2025-09-30"},
+		{"allowed env", `{{env "GRCTOOL_TEST_REGION"}}`, "eu-west-1"},
+		{"env not allowed", `{{env "GRCTOOL_TEST_SECRET"}}`, `{{env "GRCTOOL_TEST_SECRET"}}`},
+		{"env unset", `{{env "GRCTOOL_TEST_UNSET"}}`, `{{env "GRCTOOL_TEST_UNSET"}}`},
+		{"no window", "{{windowStart}}", "{{windowStart}}"},
+		{"invalid window", `{{windowStart "someday"}}`, `{{windowStart "someday"}}`},
+		{"missing argument variable", "{{upper team.name}}", "{{upper team.name}}"},
+		{"wrong argument count", "{{upper}}", "{{upper}}"},
+		{"unknown function", "{{shout organization.name}}", "{{shout organization.name}}"},
+		{"unknown pipe stage", "{{organization.name | shout}}", "{{organization.name | shout}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := interpolator.Interpolate(tt.input)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestInterpolateFunctions_MissingVariableError(t *testing.T) {
+	interpolator := NewStandardInterpolator(InterpolatorConfig{
+		Enabled:           true,
+		OnMissingVariable: MissingVariableError,
+		AllowedEnv:        []string{"GRCTOOL_TEST_REGION"},
+	})
+
+	tests := []struct {
+		input   string
+		wantErr string
+	}{
+		{"{{upper team.name}}", "missing variable: team.name"},
+		{`{{env "HOME"}}`, "env: environment variable HOME is not listed in interpolation.env"},
+		{`{{windowEnd "2025-Q4"}}`, "windowEnd: collection windows are not available here"},
+		{"{{lower}}", "lower: usage is {{lower VALUE}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := interpolator.Interpolate(tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestFunctions(t *testing.T) {
+	names := make(map[string]bool)
+	for _, fn := range Functions() {
+		if fn.Usage == "" || fn.Description == "" {
+			t.Errorf("Function %s needs a usage and description", fn.Name)
+		}
+		names[fn.Name] = true
+	}
+	for _, name := range []string{"upper", "lower", "title", "now", "windowStart", "windowEnd", "env"} {
+		if !names[name] {
+			t.Errorf("Expected function %s", name)
+		}
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 )
 
 // Interpolator defines the interface for variable interpolation
@@ -32,9 +33,14 @@ type Interpolator interface {
 
 // StandardInterpolator implements the Interpolator interface
 type StandardInterpolator struct {
-	variables         map[string]string
-	enabled           bool
-	onMissingVariable MissingVariableAction
+	variables          map[string]string
+	frameworkVariables map[string]map[string]string
+	taskVariables      map[string]map[string]string
+	allowedEnv         map[string]bool
+	parseWindow        func(window string) (time.Time, time.Time, error)
+	clock              func() time.Time
+	enabled            bool
+	onMissingVariable  MissingVariableAction
 }
 
 // MissingVariableAction defines how to handle missing variables
@@ -54,6 +60,12 @@ type InterpolatorConfig struct {
 	Variables         map[string]string
 	Enabled           bool
 	OnMissingVariable MissingVariableAction
+
+	FrameworkVariables map[string]map[string]string                      // Framework -> variables overriding Variables in its documents
+	TaskVariables      map[string]map[string]string                      // Evidence task ref -> variables overriding framework and global ones
+	AllowedEnv         []string                                          // Environment variables the env function may read
+	ParseWindow        func(window string) (time.Time, time.Time, error) // [start, end) of a collection window, for windowStart and windowEnd
+	Now                func() time.Time                                  // Clock of the now function (default: time.Now)
 }
 
 // NewStandardInterpolator creates a new StandardInterpolator with the given configuration
@@ -64,11 +76,33 @@ func NewStandardInterpolator(config InterpolatorConfig) *StandardInterpolator {
 		variables[k] = v
 	}
 
+	allowedEnv := make(map[string]bool, len(config.AllowedEnv))
+	for _, name := range config.AllowedEnv {
+		allowedEnv[name] = true
+	}
+
 	return &StandardInterpolator{
-		variables:         variables,
-		enabled:           config.Enabled,
-		onMissingVariable: config.OnMissingVariable,
+		variables:          variables,
+		frameworkVariables: copyScopes(config.FrameworkVariables),
+		taskVariables:      copyScopes(config.TaskVariables),
+		allowedEnv:         allowedEnv,
+		parseWindow:        config.ParseWindow,
+		clock:              config.Now,
+		enabled:            config.Enabled,
+		onMissingVariable:  config.OnMissingVariable,
+	}
+}
+
+// copyScopes copies per-framework or per-task variables
+func copyScopes(scopes map[string]map[string]string) map[string]map[string]string {
+	copied := make(map[string]map[string]string, len(scopes))
+	for scope, variables := range scopes {
+		copied[scope] = make(map[string]string, len(variables))
+		for k, v := range variables {
+			copied[scope][k] = v
+		}
 	}
+	return copied
 }
 
 // Interpolate replaces variables in the input text with configured values
@@ -103,13 +137,12 @@ func (si *StandardInterpolator) InterpolateWithContext(text string) (string, map
 	return result, substitutions, nil
 }
 
-// interpolateTemplateVariables handles {{variable.name}} format
+// interpolateTemplateVariables handles {{variable.name}} format, and
+// function calls such as {{upper organization.name}}
 func (si *StandardInterpolator) interpolateTemplateVariables(text string) (string, map[string]string, error) {
-	// Regex to match {{variable.name}} patterns
-	templateRegex := regexp.MustCompile(`\{\{([^}]+)\}\}`)
 	substitutions := make(map[string]string)
 
-	result := templateRegex.ReplaceAllStringFunc(text, func(match string) string {
+	result := templateExpression.ReplaceAllStringFunc(text, func(match string) string {
 		// Extract the variable name (remove {{ and }})
 		varName := strings.TrimSpace(match[2 : len(match)-2])
 
@@ -119,6 +152,17 @@ func (si *StandardInterpolator) interpolateTemplateVariables(text string) (strin
 			return value
 		}
 
+		// Call the functions of the expression
+		value, isCall, err := si.evaluate(varName)
+		if isCall && err == nil {
+			substitutions[match] = value
+			return value
+		}
+		if isCall && si.onMissingVariable == MissingVariableError {
+			substitutions["__ERROR__"] = err.Error()
+			return match
+		}
+
 		// Handle missing variable based on configuration
 		switch si.onMissingVariable {
 		case MissingVariableIgnore:
@@ -0,0 +1,147 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interpolation
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/grctool/grctool/internal/frameworks"
+)
+
+// WindowVariable holds the collection window of a scoped document, the
+// default window of windowStart and windowEnd
+const WindowVariable = "window"
+
+// Scope identifies the document being interpolated
+type Scope struct {
+	Framework string // e.g. "SOC 2"
+	TaskRef   string // e.g. "ET-0047"
+	Window    string // e.g. "2025-Q4"
+}
+
+// ScopedInterpolator is an Interpolator whose variables can be narrowed to
+// a document's framework, task and window
+type ScopedInterpolator interface {
+	Interpolator
+	WithScope(scope Scope) Interpolator
+}
+
+// Variable is a variable and the scope defining it: "global",
+// "frameworks.<name>", "tasks.<ref>" or "window"
+type Variable struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	Scope string `json:"scope"`
+}
+
+// WithScope returns an interpolator for a document of the scope, whose
+// variables are those of ScopedVariables
+func (si *StandardInterpolator) WithScope(scope Scope) Interpolator {
+	scoped := *si
+	scoped.variables = si.ScopedVariables(scope)
+	return &scoped
+}
+
+// ScopedVariables returns the variables of a scope: the global variables,
+// overridden by those of the scope's framework, then by those of its task
+func (si *StandardInterpolator) ScopedVariables(scope Scope) map[string]string {
+	variables := make(map[string]string)
+	for _, v := range si.Variables(scope) {
+		variables[v.Name] = v.Value
+	}
+	return variables
+}
+
+// Variables lists the variables of a scope sorted by name, each from the
+// scope whose value wins
+func (si *StandardInterpolator) Variables(scope Scope) []Variable {
+	winners := make(map[string]Variable)
+	for _, layer := range si.layers(scope) {
+		for name, value := range layer.variables {
+			winners[name] = Variable{Name: name, Value: value, Scope: layer.scope}
+		}
+	}
+	variables := make([]Variable, 0, len(winners))
+	for _, v := range winners {
+		variables = append(variables, v)
+	}
+	sort.Slice(variables, func(i, j int) bool { return variables[i].Name < variables[j].Name })
+	return variables
+}
+
+// Definitions lists every configured variable, global ones first, then
+// those of each framework and task scope, sorted by scope and name
+func (si *StandardInterpolator) Definitions() []Variable {
+	definitions := sortedVariables("global", si.variables)
+	for _, name := range sortedKeys(si.frameworkVariables) {
+		definitions = append(definitions, sortedVariables("frameworks."+name, si.frameworkVariables[name])...)
+	}
+	for _, ref := range sortedKeys(si.taskVariables) {
+		definitions = append(definitions, sortedVariables("tasks."+ref, si.taskVariables[ref])...)
+	}
+	return definitions
+}
+
+// scopeLayer is a set of variables overriding those of the layers before it
+type scopeLayer struct {
+	scope     string
+	variables map[string]string
+}
+
+// layers returns the variables a scope sees, least specific first
+func (si *StandardInterpolator) layers(scope Scope) []scopeLayer {
+	layers := []scopeLayer{{scope: "global", variables: si.variables}}
+	if scope.Framework != "" {
+		key := frameworks.Key(scope.Framework)
+		for _, name := range sortedKeys(si.frameworkVariables) {
+			if frameworks.Key(name) == key {
+				layers = append(layers, scopeLayer{scope: "frameworks." + name, variables: si.frameworkVariables[name]})
+				break
+			}
+		}
+	}
+	if scope.TaskRef != "" {
+		for _, ref := range sortedKeys(si.taskVariables) {
+			if strings.EqualFold(ref, scope.TaskRef) {
+				layers = append(layers, scopeLayer{scope: "tasks." + ref, variables: si.taskVariables[ref]})
+				break
+			}
+		}
+	}
+	if scope.Window != "" {
+		layers = append(layers, scopeLayer{scope: WindowVariable, variables: map[string]string{WindowVariable: scope.Window}})
+	}
+	return layers
+}
+
+func sortedVariables(scope string, variables map[string]string) []Variable {
+	sorted := make([]Variable, 0, len(variables))
+	for name, value := range variables {
+		sorted = append(sorted, Variable{Name: name, Value: value, Scope: scope})
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	return sorted
+}
+
+func sortedKeys(scopes map[string]map[string]string) []string {
+	keys := make([]string, 0, len(scopes))
+	for key := range scopes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
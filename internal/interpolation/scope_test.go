@@ -0,0 +1,98 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interpolation
+
+import (
+	"reflect"
+	"testing"
+)
+
+func scopedInterpolator() *StandardInterpolator {
+	return NewStandardInterpolator(InterpolatorConfig{
+		Variables: map[string]string{
+			"organization.name": "Seventh Sense",
+			"audit.firm":        "Internal Audit",
+			"review.cadence":    "annually",
+		},
+		FrameworkVariables: map[string]map[string]string{
+			"soc2":    {"audit.firm": "Prescient Assurance"},
+			"PCI DSS": {"audit.firm": "Coalfire", "review.cadence": "quarterly"},
+		},
+		TaskVariables: map[string]map[string]string{
+			"et-0047": {"review.cadence": "monthly"},
+		},
+		Enabled:           true,
+		OnMissingVariable: MissingVariableIgnore,
+	})
+}
+
+func TestWithScope(t *testing.T) {
+	interpolator := scopedInterpolator()
+	input := "{{organization.name}}: {{audit.firm}}, reviewed {{review.cadence}}"
+
+	tests := []struct {
+		name     string
+		scope    Scope
+		expected string
+	}{
+		{"global", Scope{}, "Seventh Sense: Internal Audit, reviewed annually"},
+		{"framework spelled differently", Scope{Framework: "SOC 2"}, "Seventh Sense: Prescient Assurance, reviewed annually"},
+		{"task over framework", Scope{Framework: "pci", TaskRef: "ET-0047"}, "Seventh Sense: Coalfire, reviewed monthly"},
+		{"unscoped framework", Scope{Framework: "HIPAA", TaskRef: "ET-0001"}, "Seventh Sense: Internal Audit, reviewed annually"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := interpolator.WithScope(tt.scope).Interpolate(input)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+
+	if value := interpolator.GetVariables()["audit.firm"]; value != "Internal Audit" {
+		t.Errorf("Scoping should not change the global variables, got audit.firm %q", value)
+	}
+}
+
+func TestVariables(t *testing.T) {
+	interpolator := scopedInterpolator()
+
+	got := interpolator.Variables(Scope{Framework: "PCI DSS v4.0", TaskRef: "ET-0047", Window: "2025-Q4"})
+	expected := []Variable{
+		{Name: "audit.firm", Value: "Coalfire", Scope: "frameworks.PCI DSS"},
+		{Name: "organization.name", Value: "Seventh Sense", Scope: "global"},
+		{Name: "review.cadence", Value: "monthly", Scope: "tasks.et-0047"},
+		{Name: "window", Value: "2025-Q4", Scope: "window"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+
+	definitions := interpolator.Definitions()
+	if len(definitions) != 7 {
+		t.Fatalf("Expected 7 definitions, got %d: %+v", len(definitions), definitions)
+	}
+	if definitions[0] != (Variable{Name: "audit.firm", Value: "Internal Audit", Scope: "global"}) {
+		t.Errorf("Expected global definitions first, got %+v", definitions[0])
+	}
+	if last := definitions[len(definitions)-1]; last != (Variable{Name: "review.cadence", Value: "monthly", Scope: "tasks.et-0047"}) {
+		t.Errorf("Expected task definitions last, got %+v", last)
+	}
+}
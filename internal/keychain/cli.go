@@ -0,0 +1,117 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keychain
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// runner runs a command with the given standard input, returning its output
+// and exit code
+type runner func(stdin, name string, args ...string) (out []byte, exitCode int, err error)
+
+func runCommand(stdin, name string, args ...string) ([]byte, int, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = fmt.Errorf("%s: %w: %s", name, err, msg)
+		}
+		return out, exitErr.ExitCode(), err
+	}
+	return out, 0, err
+}
+
+// securityStore uses the macOS Keychain through the security command
+type securityStore struct {
+	run runner
+}
+
+// securityNotFound is the exit code security gives for a missing item
+const securityNotFound = 44
+
+func (s securityStore) Name() string { return "macOS Keychain" }
+
+func (s securityStore) Get(account string) (string, error) {
+	out, code, err := s.run("", "security", "find-generic-password", "-s", Service, "-a", account, "-w")
+	if code == securityNotFound {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+// Set passes the secret hex-encoded on the standard input of security's
+// interactive mode, keeping it out of the process list
+func (s securityStore) Set(account, secret string) error {
+	command := fmt.Sprintf("add-generic-password -U -s %s -a %s -l %q -X %s\n",
+		Service, account, Service+" "+account, hex.EncodeToString([]byte(secret)))
+	_, _, err := s.run(command, "security", "-i")
+	return err
+}
+
+func (s securityStore) Delete(account string) error {
+	_, code, err := s.run("", "security", "delete-generic-password", "-s", Service, "-a", account)
+	if code == securityNotFound {
+		return ErrNotFound
+	}
+	return err
+}
+
+// secretToolStore uses the Secret Service (GNOME Keyring, KWallet) through
+// libsecret's secret-tool command
+type secretToolStore struct {
+	run runner
+}
+
+func (s secretToolStore) Name() string { return "Secret Service (libsecret)" }
+
+func (s secretToolStore) Get(account string) (string, error) {
+	out, code, err := s.run("", "secret-tool", "lookup", "service", Service, "account", account)
+	// secret-tool exits 1 with no output for a missing item
+	if code == 1 && len(out) == 0 {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// Set passes the secret on secret-tool's standard input
+func (s secretToolStore) Set(account, secret string) error {
+	_, _, err := s.run(secret, "secret-tool", "store", "--label", Service+" "+account, "service", Service, "account", account)
+	return err
+}
+
+func (s secretToolStore) Delete(account string) error {
+	if _, err := s.Get(account); err != nil {
+		return err
+	}
+	_, _, err := s.run("", "secret-tool", "clear", "service", Service, "account", account)
+	return err
+}
@@ -0,0 +1,173 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package keychain stores credentials in the operating system's credential
+// store: the macOS Keychain, the Secret Service (libsecret) on Linux, and the
+// Windows Credential Manager.
+package keychain
+
+import (
+	"errors"
+	"sync"
+)
+
+// Service is the service name grctool's entries are stored under
+const Service = "grctool"
+
+// Accounts of the credentials grctool stores
+const (
+	AccountTugboat = "tugboat"
+	AccountGitHub  = "github"
+	AccountGoogle  = "google"
+
+	// AccountEvidenceKey holds the key sensitive evidence is encrypted with
+	AccountEvidenceKey = "evidence-key"
+)
+
+// ErrNotFound is returned for an account with no stored credential
+var ErrNotFound = errors.New("credential not found in the keychain")
+
+// ErrUnsupported is returned when no OS keychain is available
+var ErrUnsupported = errors.New("no OS keychain is available")
+
+// Store reads and writes secrets by account name
+type Store interface {
+	// Name describes the store, e.g. "macOS Keychain"
+	Name() string
+	Get(account string) (string, error)
+	Set(account, secret string) error
+	Delete(account string) error
+}
+
+// Default is the platform's keychain. Tests replace it with a MemoryStore.
+var Default Store = Cached(platformStore())
+
+// Account returns the account of a provider's credential for a profile. The
+// credentials of each profile, one per Tugboat organization, are kept apart.
+func Account(provider, profile string) string {
+	if profile == "" {
+		return provider
+	}
+	return provider + ":" + profile
+}
+
+// Lookup returns a provider's credential for a profile, falling back to the
+// one stored without a profile
+func Lookup(store Store, provider, profile string) (string, error) {
+	if profile != "" {
+		secret, err := store.Get(Account(provider, profile))
+		if !errors.Is(err, ErrNotFound) {
+			return secret, err
+		}
+	}
+	return store.Get(provider)
+}
+
+// cachedStore remembers lookups, including misses, for the life of the
+// process: the configuration is loaded many times per command, and each
+// lookup of a CLI-backed store starts a process
+type cachedStore struct {
+	Store
+	mu      sync.Mutex
+	entries map[string]cachedEntry
+}
+
+type cachedEntry struct {
+	secret string
+	err    error
+}
+
+// Cached wraps a store so that each account is read from it at most once
+func Cached(store Store) Store {
+	return &cachedStore{Store: store, entries: map[string]cachedEntry{}}
+}
+
+func (c *cachedStore) Get(account string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, ok := c.entries[account]; ok {
+		return entry.secret, entry.err
+	}
+	secret, err := c.Store.Get(account)
+	c.entries[account] = cachedEntry{secret: secret, err: err}
+	return secret, err
+}
+
+func (c *cachedStore) Set(account, secret string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, account)
+	return c.Store.Set(account, secret)
+}
+
+func (c *cachedStore) Delete(account string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, account)
+	return c.Store.Delete(account)
+}
+
+// unsupportedStore is used on platforms without a keychain
+type unsupportedStore struct {
+	reason string
+}
+
+func (s unsupportedStore) Name() string { return "none (" + s.reason + ")" }
+
+func (s unsupportedStore) Get(string) (string, error) { return "", ErrUnsupported }
+
+func (s unsupportedStore) Set(string, string) error { return ErrUnsupported }
+
+func (s unsupportedStore) Delete(string) error { return ErrUnsupported }
+
+// MemoryStore keeps secrets in memory, for tests
+type MemoryStore struct {
+	mu      sync.Mutex
+	secrets map[string]string
+}
+
+// NewMemoryStore returns an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{secrets: map[string]string{}}
+}
+
+func (m *MemoryStore) Name() string { return "memory" }
+
+func (m *MemoryStore) Get(account string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	secret, ok := m.secrets[account]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return secret, nil
+}
+
+func (m *MemoryStore) Set(account, secret string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.secrets[account] = secret
+	return nil
+}
+
+func (m *MemoryStore) Delete(account string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.secrets[account]; !ok {
+		return ErrNotFound
+	}
+	delete(m.secrets, account)
+	return nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package keychain
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCommand records the commands a store runs and answers with canned
+// output and exit codes
+type fakeCommand struct {
+	calls  [][]string
+	stdins []string
+	out    string
+	code   int
+}
+
+func (f *fakeCommand) run(stdin, name string, args ...string) ([]byte, int, error) {
+	f.calls = append(f.calls, append([]string{name}, args...))
+	f.stdins = append(f.stdins, stdin)
+	if f.code != 0 {
+		return []byte(f.out), f.code, errors.New("exit status")
+	}
+	return []byte(f.out), 0, nil
+}
+
+func TestSecurityStore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("get", func(t *testing.T) {
+		t.Parallel()
+		fake := &fakeCommand{out: "token-value\n"}
+		secret, err := securityStore{run: fake.run}.Get("github")
+		require.NoError(t, err)
+		assert.Equal(t, "token-value", secret)
+		assert.Equal(t, []string{"security", "find-generic-password", "-s", "grctool", "-a", "github", "-w"}, fake.calls[0])
+	})
+
+	t.Run("get missing", func(t *testing.T) {
+		t.Parallel()
+		fake := &fakeCommand{code: securityNotFound}
+		_, err := securityStore{run: fake.run}.Get("github")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("set keeps the secret out of the arguments", func(t *testing.T) {
+		t.Parallel()
+		fake := &fakeCommand{}
+		require.NoError(t, securityStore{run: fake.run}.Set("tugboat:acme", `{"bearer_token":"abc"}`))
+		assert.Equal(t, []string{"security", "-i"}, fake.calls[0])
+		assert.Contains(t, fake.stdins[0], "-a tugboat:acme")
+		assert.Contains(t, fake.stdins[0], "-X "+hex.EncodeToString([]byte(`{"bearer_token":"abc"}`)))
+		assert.NotContains(t, fake.stdins[0], "bearer_token")
+	})
+
+	t.Run("delete missing", func(t *testing.T) {
+		t.Parallel()
+		fake := &fakeCommand{code: securityNotFound}
+		assert.ErrorIs(t, securityStore{run: fake.run}.Delete("google"), ErrNotFound)
+	})
+}
+
+func TestSecretToolStore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("get", func(t *testing.T) {
+		t.Parallel()
+		fake := &fakeCommand{out: "token-value"}
+		secret, err := secretToolStore{run: fake.run}.Get("github")
+		require.NoError(t, err)
+		assert.Equal(t, "token-value", secret)
+		assert.Equal(t, []string{"secret-tool", "lookup", "service", "grctool", "account", "github"}, fake.calls[0])
+	})
+
+	t.Run("get missing", func(t *testing.T) {
+		t.Parallel()
+		fake := &fakeCommand{code: 1}
+		_, err := secretToolStore{run: fake.run}.Get("github")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("set passes the secret on stdin", func(t *testing.T) {
+		t.Parallel()
+		fake := &fakeCommand{}
+		require.NoError(t, secretToolStore{run: fake.run}.Set("github", "ghp_secret"))
+		assert.Equal(t, "ghp_secret", fake.stdins[0])
+		assert.NotContains(t, strings.Join(fake.calls[0], " "), "ghp_secret")
+	})
+
+	t.Run("delete missing", func(t *testing.T) {
+		t.Parallel()
+		fake := &fakeCommand{code: 1}
+		assert.ErrorIs(t, secretToolStore{run: fake.run}.Delete("github"), ErrNotFound)
+		assert.Len(t, fake.calls, 1, "clear is not run for a missing item")
+	})
+}
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryStore()
+	require.NoError(t, store.Set(AccountGitHub, "global"))
+	require.NoError(t, store.Set(Account(AccountTugboat, "acme"), "acme-session"))
+
+	tests := map[string]struct {
+		provider string
+		profile  string
+		want     string
+		wantErr  error
+	}{
+		"profile entry":           {provider: AccountTugboat, profile: "acme", want: "acme-session"},
+		"falls back to global":    {provider: AccountGitHub, profile: "acme", want: "global"},
+		"no profile":              {provider: AccountGitHub, want: "global"},
+		"other profile not found": {provider: AccountTugboat, profile: "globex", wantErr: ErrNotFound},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := Lookup(store, tc.provider, tc.profile)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestCached(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeCommand{code: 1}
+	store := Cached(secretToolStore{run: fake.run})
+
+	_, err := store.Get("github")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = store.Get("github")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Len(t, fake.calls, 1, "misses are cached")
+
+	fake.code = 0
+	require.NoError(t, store.Set("github", "token"))
+	fake.out = "token"
+	secret, err := store.Get("github")
+	require.NoError(t, err)
+	assert.Equal(t, "token", secret, "set invalidates the cached miss")
+}
@@ -0,0 +1,37 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+
+package keychain
+
+import (
+	"os/exec"
+	"runtime"
+)
+
+func platformStore() Store {
+	switch runtime.GOOS {
+	case "darwin":
+		return securityStore{run: runCommand}
+	case "linux", "freebsd", "openbsd", "netbsd":
+		if _, err := exec.LookPath("secret-tool"); err != nil {
+			return unsupportedStore{reason: "install secret-tool (libsecret-tools)"}
+		}
+		return secretToolStore{run: runCommand}
+	default:
+		return unsupportedStore{reason: runtime.GOOS}
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// anthropicVersion is the Messages API version requests are made against
+const anthropicVersion = "2023-06-01"
+
+// anthropicMaxTokens bounds completions when the request does not, since
+// the Messages API requires a bound
+const anthropicMaxTokens = 4096
+
+// AnthropicModel completes prompts with a Claude model through the
+// Anthropic Messages API
+type AnthropicModel struct {
+	baseURL string
+	model   string
+	apiKey  string
+	client  *http.Client
+}
+
+// Name returns the provider and model
+func (m *AnthropicModel) Name() string {
+	return "anthropic/" + m.model
+}
+
+func (m *AnthropicModel) authorize(req *http.Request, _ []byte) error {
+	req.Header.Set("x-api-key", m.apiKey)
+	req.Header.Set("anthropic-version", anthropicVersion)
+	return nil
+}
+
+// Available checks a key is configured and the API serves the model with it
+func (m *AnthropicModel) Available(ctx context.Context) error {
+	if m.apiKey == "" {
+		return fmt.Errorf("anthropic API needs a key: set evidence.llm.api_key, e.g. ${ANTHROPIC_API_KEY}")
+	}
+	err := doJSON(ctx, m.client, http.MethodGet, m.baseURL+"/v1/models/"+url.PathEscape(m.model), nil, nil, m.authorize)
+	var status *StatusError
+	if errors.As(err, &status) {
+		switch status.StatusCode {
+		case http.StatusUnauthorized:
+			return fmt.Errorf("anthropic API rejected the key: check evidence.llm.api_key")
+		case http.StatusNotFound:
+			return fmt.Errorf("anthropic API does not serve %s", m.model)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("anthropic API is not available: %w", err)
+	}
+	return nil
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicMessagesRequest struct {
+	Model       string             `json:"model"`
+	MaxTokens   int                `json:"max_tokens"`
+	Temperature float64            `json:"temperature"`
+	Messages    []anthropicMessage `json:"messages"`
+}
+
+type anthropicMessagesResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	Usage struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+// Generate completes the prompt with /v1/messages. The API has no JSON
+// mode; the prompts that want JSON ask for it.
+func (m *AnthropicModel) Generate(ctx context.Context, req Request) (string, error) {
+	body := anthropicMessagesRequest{
+		Model:     m.model,
+		MaxTokens: req.MaxTokens,
+		Messages:  []anthropicMessage{{Role: "user", Content: req.Prompt}},
+	}
+	if body.MaxTokens <= 0 {
+		body.MaxTokens = anthropicMaxTokens
+	}
+
+	var resp anthropicMessagesResponse
+	if err := doJSON(ctx, m.client, http.MethodPost, m.baseURL+"/v1/messages", body, &resp, m.authorize); err != nil {
+		return "", fmt.Errorf("anthropic messages request failed: %w", err)
+	}
+	req.record(resp.Usage.InputTokens, resp.Usage.OutputTokens)
+	var text strings.Builder
+	for _, block := range resp.Content {
+		if block.Type == "text" {
+			text.WriteString(block.Text)
+		}
+	}
+	return strings.TrimSpace(text.String()), nil
+}
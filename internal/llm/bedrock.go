@@ -0,0 +1,119 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package llm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/storage"
+)
+
+// awsSigner signs requests with AWS credentials
+type awsSigner interface {
+	Region() string
+	Credentials(ctx context.Context) error
+	Sign(ctx context.Context, req *http.Request, body []byte) error
+}
+
+// BedrockModel completes prompts with a model on AWS Bedrock through the
+// Converse API, which accepts the same request for every model family
+type BedrockModel struct {
+	baseURL string
+	model   string // Model or inference profile ID
+	signer  awsSigner
+	client  *http.Client
+}
+
+func newBedrockModel(cfg config.LLMConfig, baseURL string, client *http.Client) *BedrockModel {
+	signer := storage.NewAWSSigner(cfg.Region, "bedrock")
+	if baseURL == "" {
+		baseURL = "https://bedrock-runtime." + signer.Region() + ".amazonaws.com"
+	}
+	return &BedrockModel{baseURL: baseURL, model: cfg.Model, signer: signer, client: client}
+}
+
+// Name returns the provider and model
+func (m *BedrockModel) Name() string {
+	return "bedrock/" + m.model
+}
+
+func (m *BedrockModel) sign(req *http.Request, body []byte) error {
+	return m.signer.Sign(req.Context(), req, body)
+}
+
+// Available checks AWS credentials can be found. Whether they grant access
+// to the model is only known once it is invoked.
+func (m *BedrockModel) Available(ctx context.Context) error {
+	if err := m.signer.Credentials(ctx); err != nil {
+		return fmt.Errorf("bedrock is not available: %w", err)
+	}
+	return nil
+}
+
+type bedrockContent struct {
+	Text string `json:"text"`
+}
+
+type bedrockMessage struct {
+	Role    string           `json:"role"`
+	Content []bedrockContent `json:"content"`
+}
+
+type bedrockConverseRequest struct {
+	Messages        []bedrockMessage `json:"messages"`
+	InferenceConfig map[string]any   `json:"inferenceConfig"`
+}
+
+type bedrockConverseResponse struct {
+	Output struct {
+		Message bedrockMessage `json:"message"`
+	} `json:"output"`
+	Usage struct {
+		InputTokens  int `json:"inputTokens"`
+		OutputTokens int `json:"outputTokens"`
+	} `json:"usage"`
+}
+
+// Generate completes the prompt with /model/{id}/converse. The API has no
+// JSON mode; the prompts that want JSON ask for it.
+func (m *BedrockModel) Generate(ctx context.Context, req Request) (string, error) {
+	body := bedrockConverseRequest{
+		Messages:        []bedrockMessage{{Role: "user", Content: []bedrockContent{{Text: req.Prompt}}}},
+		InferenceConfig: map[string]any{"temperature": 0},
+	}
+	if req.MaxTokens > 0 {
+		body.InferenceConfig["maxTokens"] = req.MaxTokens
+	}
+
+	// Model IDs such as anthropic.claude-3-5-haiku-20241022-v1:0 carry a
+	// colon, which Bedrock expects escaped
+	endpoint := m.baseURL + "/model/" + strings.ReplaceAll(url.PathEscape(m.model), ":", "%3A") + "/converse"
+	var resp bedrockConverseResponse
+	if err := doJSON(ctx, m.client, http.MethodPost, endpoint, body, &resp, m.sign); err != nil {
+		return "", fmt.Errorf("bedrock converse failed: %w", err)
+	}
+	req.record(resp.Usage.InputTokens, resp.Usage.OutputTokens)
+	var text strings.Builder
+	for _, block := range resp.Output.Message.Content {
+		text.WriteString(block.Text)
+	}
+	return strings.TrimSpace(text.String()), nil
+}
@@ -0,0 +1,199 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestOpenAIModel(t *testing.T) {
+	t.Parallel()
+
+	var got openAIChatRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer sk-test" {
+			http.Error(w, `{"error":{"message":"Incorrect API key"}}`, http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/models":
+			_, _ = w.Write([]byte(`{"data":[{"id":"gpt-4o-mini"}]}`))
+		case "/v1/chat/completions":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+			_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"{\"score\": 64}"}}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	model, err := NewModel(config.LLMConfig{Provider: "openai", Model: "gpt-4o-mini", BaseURL: server.URL + "/v1/", APIKey: "sk-test"}, server.Client())
+	require.NoError(t, err)
+	assert.Equal(t, "openai/gpt-4o-mini", model.Name())
+	require.NoError(t, model.Available(context.Background()))
+
+	var answer struct {
+		Score float64 `json:"score"`
+	}
+	require.NoError(t, GenerateJSON(context.Background(), model, "Review this", &answer))
+	assert.Equal(t, 64.0, answer.Score)
+	assert.Equal(t, "gpt-4o-mini", got.Model)
+	assert.Equal(t, []openAIMessage{{Role: "user", Content: "Review this"}}, got.Messages)
+	assert.Equal(t, map[string]any{"type": "json_object"}, got.ResponseFormat)
+
+	unserved, err := NewModel(config.LLMConfig{Provider: "openai", Model: "gpt-4o", BaseURL: server.URL + "/v1", APIKey: "sk-test"}, server.Client())
+	require.NoError(t, err)
+	assert.ErrorContains(t, unserved.Available(context.Background()), "does not serve gpt-4o")
+
+	badKey, err := NewModel(config.LLMConfig{Provider: "openai", Model: "gpt-4o-mini", BaseURL: server.URL + "/v1", APIKey: "sk-wrong"}, server.Client())
+	require.NoError(t, err)
+	assert.ErrorContains(t, badKey.Available(context.Background()), "rejected the key")
+}
+
+func TestAnthropicModel(t *testing.T) {
+	t.Parallel()
+
+	var got anthropicMessagesRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-api-key") != "ant-test" || r.Header.Get("anthropic-version") != anthropicVersion {
+			http.Error(w, `{"type":"error"}`, http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/models/claude-3-5-haiku-latest":
+			_, _ = w.Write([]byte(`{"id":"claude-3-5-haiku-latest","type":"model"}`))
+		case "/v1/messages":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+			_, _ = w.Write([]byte(`{"content":[{"type":"text","text":"1. Export"},{"type":"text","text":" the user list"}],"usage":{"input_tokens":12,"output_tokens":7}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	model, err := NewModel(config.LLMConfig{Provider: "anthropic", Model: "claude-3-5-haiku-latest", BaseURL: server.URL, APIKey: "ant-test"}, server.Client())
+	require.NoError(t, err)
+	assert.Equal(t, "anthropic/claude-3-5-haiku-latest", model.Name())
+	require.NoError(t, model.Available(context.Background()))
+
+	var usage Usage
+	text, err := model.Generate(context.Background(), Request{Prompt: "Plan", Usage: &usage})
+	require.NoError(t, err)
+	assert.Equal(t, "1. Export the user list", text)
+	assert.Equal(t, Usage{InputTokens: 12, OutputTokens: 7}, usage)
+	assert.Equal(t, anthropicMaxTokens, got.MaxTokens)
+	assert.Equal(t, []anthropicMessage{{Role: "user", Content: "Plan"}}, got.Messages)
+
+	tests := map[string]struct {
+		model   string
+		apiKey  string
+		wantErr string
+	}{
+		"no key":        {model: "claude-3-5-haiku-latest", wantErr: "set evidence.llm.api_key"},
+		"rejected key":  {model: "claude-3-5-haiku-latest", apiKey: "wrong", wantErr: "rejected the key"},
+		"unknown model": {model: "claude-2", apiKey: "ant-test", wantErr: "does not serve claude-2"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			model, err := NewModel(config.LLMConfig{Provider: "anthropic", Model: tc.model, BaseURL: server.URL, APIKey: tc.apiKey}, server.Client())
+			require.NoError(t, err)
+			assert.ErrorContains(t, model.Available(context.Background()), tc.wantErr)
+		})
+	}
+}
+
+// stubSigner marks requests as signed, or fails without credentials
+type stubSigner struct {
+	err error
+}
+
+func (s stubSigner) Region() string { return "us-east-1" }
+
+func (s stubSigner) Credentials(context.Context) error { return s.err }
+
+func (s stubSigner) Sign(_ context.Context, req *http.Request, _ []byte) error {
+	if s.err != nil {
+		return s.err
+	}
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 test")
+	return nil
+}
+
+func TestBedrockModel(t *testing.T) {
+	t.Parallel()
+
+	var got bedrockConverseRequest
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "AWS4-HMAC-SHA256 test" {
+			http.Error(w, `{"message":"Missing Authentication Token"}`, http.StatusForbidden)
+			return
+		}
+		gotPath = r.URL.EscapedPath()
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		_, _ = w.Write([]byte(`{"output":{"message":{"role":"assistant","content":[{"text":"{\"score\": 90}"}]}},"stopReason":"end_turn","usage":{"inputTokens":830,"outputTokens":41,"totalTokens":871}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	model := &BedrockModel{baseURL: server.URL, model: "anthropic.claude-3-5-haiku-20241022-v1:0", signer: stubSigner{}, client: server.Client()}
+	require.NoError(t, model.Available(context.Background()))
+
+	var usage Usage
+	text, err := model.Generate(context.Background(), Request{Prompt: "Review", JSON: true, MaxTokens: 512, Usage: &usage})
+	require.NoError(t, err)
+	assert.Equal(t, `{"score": 90}`, text)
+	assert.Equal(t, Usage{InputTokens: 830, OutputTokens: 41}, usage)
+	assert.Equal(t, "/model/anthropic.claude-3-5-haiku-20241022-v1%3A0/converse", gotPath)
+	assert.Equal(t, []bedrockMessage{{Role: "user", Content: []bedrockContent{{Text: "Review"}}}}, got.Messages)
+	assert.Equal(t, 512.0, got.InferenceConfig["maxTokens"])
+
+	unsigned := &BedrockModel{baseURL: server.URL, model: "amazon.nova-lite-v1:0", signer: stubSigner{err: errors.New("no AWS credentials")}, client: server.Client()}
+	assert.ErrorContains(t, unsigned.Available(context.Background()), "bedrock is not available: no AWS credentials")
+	_, err = unsigned.Generate(context.Background(), Request{Prompt: "Review"})
+	assert.ErrorContains(t, err, "no AWS credentials")
+}
+
+func TestVertexModel(t *testing.T) {
+	t.Parallel()
+
+	var got vertexGenerateRequest
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer ya29.test" {
+			http.Error(w, `{"error":{"code":401}}`, http.StatusUnauthorized)
+			return
+		}
+		gotPath = r.URL.Path
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		_, _ = w.Write([]byte(`{"candidates":[{"content":{"role":"model","parts":[{"text":"{\"score\": 55}"}]}}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	model, err := NewModel(config.LLMConfig{Provider: "vertex", Model: "gemini-1.5-flash", Project: "grc-prod", Region: "europe-west4", BaseURL: server.URL}, server.Client())
+	require.NoError(t, err)
+	vertex := model.(*VertexModel)
+	vertex.tokens = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "ya29.test", TokenType: "Bearer"})
+	assert.Equal(t, "vertex/gemini-1.5-flash", model.Name())
+	require.NoError(t, model.Available(context.Background()))
+
+	var answer struct {
+		Score float64 `json:"score"`
+	}
+	require.NoError(t, GenerateJSON(context.Background(), model, "Review this", &answer))
+	assert.Equal(t, 55.0, answer.Score)
+	assert.Equal(t, "/v1/projects/grc-prod/locations/europe-west4/publishers/google/models/gemini-1.5-flash:generateContent", gotPath)
+	assert.Equal(t, "application/json", got.GenerationConfig["responseMimeType"])
+	assert.Equal(t, []vertexContent{{Role: "user", Parts: []vertexPart{{Text: "Review this"}}}}, got.Contents)
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// LlamaCppModel completes prompts with the model a llama.cpp server
+// (llama-server) has loaded
+type LlamaCppModel struct {
+	baseURL string
+	model   string // Only names the model; the server serves the one it loaded
+	client  *http.Client
+}
+
+// Name returns the provider and model
+func (m *LlamaCppModel) Name() string {
+	if m.model == "" {
+		return "llamacpp"
+	}
+	return "llamacpp/" + m.model
+}
+
+// Available checks the server is up and has finished loading its model
+func (m *LlamaCppModel) Available(ctx context.Context) error {
+	err := doJSON(ctx, m.client, http.MethodGet, m.baseURL+"/health", nil, nil, nil)
+	var status *StatusError
+	if errors.As(err, &status) && status.StatusCode == http.StatusServiceUnavailable {
+		return fmt.Errorf("llama.cpp server is still loading its model")
+	}
+	if err != nil {
+		return fmt.Errorf("llama.cpp server is not available: %w", err)
+	}
+	return nil
+}
+
+type llamaCppCompletionRequest struct {
+	Prompt      string  `json:"prompt"`
+	Temperature float64 `json:"temperature"`
+	NPredict    int     `json:"n_predict,omitempty"`
+	JSONSchema  any     `json:"json_schema,omitempty"`
+}
+
+type llamaCppCompletionResponse struct {
+	Content         string `json:"content"`
+	TokensEvaluated int    `json:"tokens_evaluated"`
+	TokensPredicted int    `json:"tokens_predicted"`
+}
+
+// Generate completes the prompt with /completion
+func (m *LlamaCppModel) Generate(ctx context.Context, req Request) (string, error) {
+	body := llamaCppCompletionRequest{Prompt: req.Prompt, NPredict: req.MaxTokens}
+	if req.JSON {
+		body.JSONSchema = map[string]any{"type": "object"}
+	}
+
+	var resp llamaCppCompletionResponse
+	if err := doJSON(ctx, m.client, http.MethodPost, m.baseURL+"/completion", body, &resp, nil); err != nil {
+		return "", fmt.Errorf("llama.cpp completion failed: %w", err)
+	}
+	req.record(resp.TokensEvaluated, resp.TokensPredicted)
+	return strings.TrimSpace(resp.Content), nil
+}
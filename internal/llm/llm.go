@@ -0,0 +1,180 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package llm completes prompts with a language model. Ollama and llama.cpp
+// serve models on the local machine, so evidence sent to them for review
+// stays there; OpenAI, Anthropic, AWS Bedrock and Google Vertex AI serve
+// them from the cloud.
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/grctool/grctool/internal/config"
+)
+
+// Request is one prompt to complete
+type Request struct {
+	Prompt    string
+	JSON      bool // Ask for a single JSON object
+	MaxTokens int  // Longest completion (default: the server's)
+
+	// Usage, when set, receives the tokens the completion used
+	Usage *Usage
+}
+
+// Usage counts the tokens of one completion
+type Usage struct {
+	InputTokens  int
+	OutputTokens int
+}
+
+// record stores the counts when the request asked for them
+func (r Request) record(input, output int) {
+	if r.Usage != nil {
+		*r.Usage = Usage{InputTokens: input, OutputTokens: output}
+	}
+}
+
+// Model completes prompts
+type Model interface {
+	// Name identifies the provider and model
+	Name() string
+
+	// Available returns why the model cannot complete prompts, or nil when
+	// it can
+	Available(ctx context.Context) error
+
+	// Generate returns the completion of a prompt
+	Generate(ctx context.Context, req Request) (string, error)
+}
+
+// NewModel creates the configured model, or returns nil when none is
+// configured. client may be nil to use one with the configured timeout.
+func NewModel(cfg config.LLMConfig, client *http.Client) (Model, error) {
+	if client == nil {
+		client = &http.Client{Timeout: cfg.Timeout}
+	}
+	baseURL := strings.TrimSuffix(cfg.BaseURL, "/")
+	switch cfg.Provider {
+	case "", config.LLMProviderNone:
+		return nil, nil
+	case config.LLMProviderOllama:
+		return &OllamaModel{baseURL: baseURL, model: cfg.Model, client: client}, nil
+	case config.LLMProviderLlamaCpp:
+		return &LlamaCppModel{baseURL: baseURL, model: cfg.Model, client: client}, nil
+	case config.LLMProviderOpenAI:
+		return &OpenAIModel{baseURL: baseURL, model: cfg.Model, apiKey: cfg.APIKey, client: client}, nil
+	case config.LLMProviderAnthropic:
+		return &AnthropicModel{baseURL: baseURL, model: cfg.Model, apiKey: cfg.APIKey, client: client}, nil
+	case config.LLMProviderBedrock:
+		return newBedrockModel(cfg, baseURL, client), nil
+	case config.LLMProviderVertex:
+		return &VertexModel{baseURL: baseURL, model: cfg.Model, project: cfg.Project, region: cfg.Region, client: client}, nil
+	default:
+		return nil, fmt.Errorf("unknown llm provider %q: use one of %s", cfg.Provider, strings.Join(config.LLMProviders, ", "))
+	}
+}
+
+// NewModelFor creates the model configured for an operation, such as
+// config.LLMOperationEvaluate, or returns nil when it has none
+func NewModelFor(cfg config.LLMConfig, operation string) (Model, error) {
+	return NewModel(cfg.For(operation), nil)
+}
+
+// GenerateJSON completes a prompt asking for a JSON object and decodes it
+// into v, tolerating text the model writes around the object
+func GenerateJSON(ctx context.Context, model Model, prompt string, v any) error {
+	text, err := model.Generate(ctx, Request{Prompt: prompt, JSON: true})
+	if err != nil {
+		return err
+	}
+	start, end := strings.Index(text, "{"), strings.LastIndex(text, "}")
+	if start < 0 || end < start {
+		return fmt.Errorf("%s did not answer with JSON", model.Name())
+	}
+	if err := json.Unmarshal([]byte(text[start:end+1]), v); err != nil {
+		return fmt.Errorf("%s answered with invalid JSON: %w", model.Name(), err)
+	}
+	return nil
+}
+
+// prepareFunc authenticates a request before it is sent, given its encoded
+// body
+type prepareFunc func(req *http.Request, body []byte) error
+
+// doJSON sends a request with an optional JSON body and decodes the JSON
+// response into out. prepare, when not nil, authenticates the request.
+func doJSON(ctx context.Context, client *http.Client, method, url string, body, out any, prepare prepareFunc) error {
+	var encoded []byte
+	var reader io.Reader
+	if body != nil {
+		var err error
+		if encoded, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if prepare != nil {
+		if err := prepare(req, encoded); err != nil {
+			return err
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return &StatusError{Status: resp.Status, StatusCode: resp.StatusCode, Detail: strings.TrimSpace(string(detail))}
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// StatusError is a response the model server answered with an error status
+type StatusError struct {
+	Status     string
+	StatusCode int
+	Detail     string
+}
+
+func (e *StatusError) Error() string {
+	if e.Detail == "" {
+		return "model server returned " + e.Status
+	}
+	return fmt.Sprintf("model server returned %s: %s", e.Status, e.Detail)
+}
@@ -0,0 +1,159 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewModel(t *testing.T) {
+	t.Parallel()
+
+	model, err := NewModel(config.LLMConfig{}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, model)
+
+	model, err = NewModel(config.LLMConfig{Provider: "ollama", Model: "llama3.1:8b", BaseURL: "http://localhost:11434/"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ollama/llama3.1:8b", model.Name())
+
+	model, err = NewModel(config.LLMConfig{Provider: "llamacpp", BaseURL: "http://localhost:8080"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "llamacpp", model.Name())
+
+	model, err = NewModel(config.LLMConfig{Provider: "bedrock", Model: "amazon.nova-lite-v1:0", Region: "eu-west-1"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "bedrock/amazon.nova-lite-v1:0", model.Name())
+	assert.Equal(t, "https://bedrock-runtime.eu-west-1.amazonaws.com", model.(*BedrockModel).baseURL)
+
+	model, err = NewModelFor(config.LLMConfig{
+		Provider:   "ollama",
+		Model:      "llama3.1:8b",
+		Operations: map[string]config.LLMConfig{"prompt_assembly": {Provider: "none"}},
+	}, config.LLMOperationPromptAssembly)
+	require.NoError(t, err)
+	assert.Nil(t, model)
+
+	_, err = NewModel(config.LLMConfig{Provider: "cohere"}, nil)
+	assert.ErrorContains(t, err, `unknown llm provider "cohere"`)
+}
+
+func TestOllamaModel(t *testing.T) {
+	t.Parallel()
+
+	var got ollamaGenerateRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/tags":
+			_, _ = w.Write([]byte(`{"models":[{"name":"llama3.1:8b"},{"name":"mistral:latest"}]}`))
+		case "/api/generate":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+			_, _ = w.Write([]byte(`{"response":" Sure: {\"score\": 72, \"gaps\": [\"No reviewer sign-off\"]} ","done":true}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	model, err := NewModel(config.LLMConfig{Provider: "ollama", Model: "llama3.1:8b", BaseURL: server.URL}, server.Client())
+	require.NoError(t, err)
+	require.NoError(t, model.Available(context.Background()))
+
+	var answer struct {
+		Score float64  `json:"score"`
+		Gaps  []string `json:"gaps"`
+	}
+	require.NoError(t, GenerateJSON(context.Background(), model, "Review this", &answer))
+	assert.Equal(t, 72.0, answer.Score)
+	assert.Equal(t, []string{"No reviewer sign-off"}, answer.Gaps)
+	assert.Equal(t, "llama3.1:8b", got.Model)
+	assert.Equal(t, "json", got.Format)
+	assert.False(t, got.Stream)
+
+	latest, err := NewModel(config.LLMConfig{Provider: "ollama", Model: "mistral", BaseURL: server.URL}, server.Client())
+	require.NoError(t, err)
+	assert.NoError(t, latest.Available(context.Background()))
+
+	missing, err := NewModel(config.LLMConfig{Provider: "ollama", Model: "phi3", BaseURL: server.URL}, server.Client())
+	require.NoError(t, err)
+	assert.ErrorContains(t, missing.Available(context.Background()), "run 'ollama pull phi3'")
+}
+
+func TestLlamaCppModel(t *testing.T) {
+	t.Parallel()
+
+	var loading atomic.Bool
+	loading.Store(true)
+	var got llamaCppCompletionRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			if loading.Load() {
+				http.Error(w, `{"status":"loading model"}`, http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":"ok"}`))
+		case "/completion":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+			_, _ = w.Write([]byte(`{"content":"1. Export the user list\n","tokens_evaluated":20,"tokens_predicted":9}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	model, err := NewModel(config.LLMConfig{Provider: "llamacpp", Model: "qwen2.5-7b", BaseURL: server.URL}, server.Client())
+	require.NoError(t, err)
+	assert.Equal(t, "llamacpp/qwen2.5-7b", model.Name())
+	assert.ErrorContains(t, model.Available(context.Background()), "still loading")
+
+	loading.Store(false)
+	require.NoError(t, model.Available(context.Background()))
+	var usage Usage
+	text, err := model.Generate(context.Background(), Request{Prompt: "Plan", MaxTokens: 256, Usage: &usage})
+	require.NoError(t, err)
+	assert.Equal(t, "1. Export the user list", text)
+	assert.Equal(t, Usage{InputTokens: 20, OutputTokens: 9}, usage)
+	assert.Equal(t, 256, got.NPredict)
+	assert.Nil(t, got.JSONSchema)
+}
+
+func TestGenerateJSON_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		status  int
+		body    string
+		wantErr string
+	}{
+		"no JSON":      {status: http.StatusOK, body: `{"content":"I cannot help with that"}`, wantErr: "did not answer with JSON"},
+		"invalid JSON": {status: http.StatusOK, body: `{"content":"{score: high}"}`, wantErr: "answered with invalid JSON"},
+		"server error": {status: http.StatusInternalServerError, body: "out of memory", wantErr: "500 Internal Server Error: out of memory"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			model, err := NewModel(config.LLMConfig{Provider: "llamacpp", BaseURL: server.URL}, server.Client())
+			require.NoError(t, err)
+			var answer map[string]any
+			assert.ErrorContains(t, GenerateJSON(context.Background(), model, "Review", &answer), tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package llm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// OllamaModel completes prompts with a model pulled into Ollama
+type OllamaModel struct {
+	baseURL string
+	model   string
+	client  *http.Client
+}
+
+// Name returns the provider and model
+func (m *OllamaModel) Name() string {
+	return "ollama/" + m.model
+}
+
+type ollamaTagsResponse struct {
+	Models []struct {
+		Name string `json:"name"`
+	} `json:"models"`
+}
+
+// Available checks Ollama is running and has pulled the model
+func (m *OllamaModel) Available(ctx context.Context) error {
+	var tags ollamaTagsResponse
+	if err := doJSON(ctx, m.client, http.MethodGet, m.baseURL+"/api/tags", nil, &tags, nil); err != nil {
+		return fmt.Errorf("ollama is not available: %w", err)
+	}
+	for _, pulled := range tags.Models {
+		if pulled.Name == m.model || pulled.Name == m.model+":latest" {
+			return nil
+		}
+	}
+	return fmt.Errorf("ollama has not pulled %s: run 'ollama pull %s'", m.model, m.model)
+}
+
+type ollamaGenerateRequest struct {
+	Model   string         `json:"model"`
+	Prompt  string         `json:"prompt"`
+	Stream  bool           `json:"stream"`
+	Format  string         `json:"format,omitempty"`
+	Options map[string]any `json:"options,omitempty"`
+}
+
+type ollamaGenerateResponse struct {
+	Response        string `json:"response"`
+	PromptEvalCount int    `json:"prompt_eval_count"`
+	EvalCount       int    `json:"eval_count"`
+}
+
+// Generate completes the prompt with /api/generate
+func (m *OllamaModel) Generate(ctx context.Context, req Request) (string, error) {
+	body := ollamaGenerateRequest{
+		Model:   m.model,
+		Prompt:  req.Prompt,
+		Options: map[string]any{"temperature": 0},
+	}
+	if req.JSON {
+		body.Format = "json"
+	}
+	if req.MaxTokens > 0 {
+		body.Options["num_predict"] = req.MaxTokens
+	}
+
+	var resp ollamaGenerateResponse
+	if err := doJSON(ctx, m.client, http.MethodPost, m.baseURL+"/api/generate", body, &resp, nil); err != nil {
+		return "", fmt.Errorf("ollama generate failed: %w", err)
+	}
+	req.record(resp.PromptEvalCount, resp.EvalCount)
+	return strings.TrimSpace(resp.Response), nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// OpenAIModel completes prompts with an OpenAI-compatible chat completions
+// API, such as OpenAI itself, a gateway or LM Studio
+type OpenAIModel struct {
+	baseURL string
+	model   string
+	apiKey  string
+	client  *http.Client
+}
+
+// Name returns the provider and model
+func (m *OpenAIModel) Name() string {
+	return "openai/" + m.model
+}
+
+func (m *OpenAIModel) authorize(req *http.Request, _ []byte) error {
+	if m.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+m.apiKey)
+	}
+	return nil
+}
+
+type openAIModelsResponse struct {
+	Data []struct {
+		ID string `json:"id"`
+	} `json:"data"`
+}
+
+// Available checks the API accepts the key and serves the model
+func (m *OpenAIModel) Available(ctx context.Context) error {
+	var models openAIModelsResponse
+	err := doJSON(ctx, m.client, http.MethodGet, m.baseURL+"/models", nil, &models, m.authorize)
+	var status *StatusError
+	if errors.As(err, &status) && status.StatusCode == http.StatusUnauthorized {
+		return fmt.Errorf("openai API rejected the key: check evidence.llm.api_key")
+	}
+	if err != nil {
+		return fmt.Errorf("openai API is not available: %w", err)
+	}
+	for _, served := range models.Data {
+		if served.ID == m.model {
+			return nil
+		}
+	}
+	return fmt.Errorf("openai API does not serve %s", m.model)
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIChatRequest struct {
+	Model          string          `json:"model"`
+	Messages       []openAIMessage `json:"messages"`
+	Temperature    float64         `json:"temperature"`
+	MaxTokens      int             `json:"max_tokens,omitempty"`
+	ResponseFormat map[string]any  `json:"response_format,omitempty"`
+}
+
+type openAIChatResponse struct {
+	Choices []struct {
+		Message openAIMessage `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+}
+
+// Generate completes the prompt with /chat/completions
+func (m *OpenAIModel) Generate(ctx context.Context, req Request) (string, error) {
+	body := openAIChatRequest{
+		Model:     m.model,
+		Messages:  []openAIMessage{{Role: "user", Content: req.Prompt}},
+		MaxTokens: req.MaxTokens,
+	}
+	if req.JSON {
+		body.ResponseFormat = map[string]any{"type": "json_object"}
+	}
+
+	var resp openAIChatResponse
+	if err := doJSON(ctx, m.client, http.MethodPost, m.baseURL+"/chat/completions", body, &resp, m.authorize); err != nil {
+		return "", fmt.Errorf("openai chat completion failed: %w", err)
+	}
+	req.record(resp.Usage.PromptTokens, resp.Usage.CompletionTokens)
+	if len(resp.Choices) == 0 {
+		return "", fmt.Errorf("openai chat completion returned no choices")
+	}
+	return strings.TrimSpace(resp.Choices[0].Message.Content), nil
+}
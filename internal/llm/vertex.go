@@ -0,0 +1,140 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package llm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// vertexScope is the OAuth scope Vertex AI requests are authorized with
+const vertexScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// VertexModel completes prompts with a Gemini model on Google Vertex AI,
+// authorized with Application Default Credentials
+type VertexModel struct {
+	baseURL string
+	model   string
+	project string
+	region  string
+	client  *http.Client
+
+	mu     sync.Mutex
+	tokens oauth2.TokenSource // Found on first use
+}
+
+// Name returns the provider and model
+func (m *VertexModel) Name() string {
+	return "vertex/" + m.model
+}
+
+func (m *VertexModel) tokenSource(ctx context.Context) (oauth2.TokenSource, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.tokens == nil {
+		creds, err := google.FindDefaultCredentials(ctx, vertexScope)
+		if err != nil {
+			return nil, fmt.Errorf("no Google credentials: run 'gcloud auth application-default login' or set GOOGLE_APPLICATION_CREDENTIALS (%w)", err)
+		}
+		m.tokens = creds.TokenSource
+	}
+	return m.tokens, nil
+}
+
+func (m *VertexModel) authorize(req *http.Request, _ []byte) error {
+	tokens, err := m.tokenSource(req.Context())
+	if err != nil {
+		return err
+	}
+	token, err := tokens.Token()
+	if err != nil {
+		return fmt.Errorf("failed to get a Google access token: %w", err)
+	}
+	token.SetAuthHeader(req)
+	return nil
+}
+
+// Available checks Google credentials can be found and issue a token
+func (m *VertexModel) Available(ctx context.Context) error {
+	tokens, err := m.tokenSource(ctx)
+	if err == nil {
+		_, err = tokens.Token()
+	}
+	if err != nil {
+		return fmt.Errorf("vertex AI is not available: %w", err)
+	}
+	return nil
+}
+
+type vertexPart struct {
+	Text string `json:"text"`
+}
+
+type vertexContent struct {
+	Role  string       `json:"role"`
+	Parts []vertexPart `json:"parts"`
+}
+
+type vertexGenerateRequest struct {
+	Contents         []vertexContent `json:"contents"`
+	GenerationConfig map[string]any  `json:"generationConfig"`
+}
+
+type vertexGenerateResponse struct {
+	Candidates []struct {
+		Content vertexContent `json:"content"`
+	} `json:"candidates"`
+	UsageMetadata struct {
+		PromptTokenCount     int `json:"promptTokenCount"`
+		CandidatesTokenCount int `json:"candidatesTokenCount"`
+	} `json:"usageMetadata"`
+}
+
+// Generate completes the prompt with the model's generateContent method
+func (m *VertexModel) Generate(ctx context.Context, req Request) (string, error) {
+	body := vertexGenerateRequest{
+		Contents:         []vertexContent{{Role: "user", Parts: []vertexPart{{Text: req.Prompt}}}},
+		GenerationConfig: map[string]any{"temperature": 0},
+	}
+	if req.JSON {
+		body.GenerationConfig["responseMimeType"] = "application/json"
+	}
+	if req.MaxTokens > 0 {
+		body.GenerationConfig["maxOutputTokens"] = req.MaxTokens
+	}
+
+	endpoint := fmt.Sprintf("%s/v1/projects/%s/locations/%s/publishers/google/models/%s:generateContent",
+		m.baseURL, m.project, m.region, m.model)
+	var resp vertexGenerateResponse
+	if err := doJSON(ctx, m.client, http.MethodPost, endpoint, body, &resp, m.authorize); err != nil {
+		return "", fmt.Errorf("vertex AI generateContent failed: %w", err)
+	}
+	req.record(resp.UsageMetadata.PromptTokenCount, resp.UsageMetadata.CandidatesTokenCount)
+	if len(resp.Candidates) == 0 {
+		return "", fmt.Errorf("vertex AI returned no candidates")
+	}
+	var text strings.Builder
+	for _, part := range resp.Candidates[0].Content.Parts {
+		text.WriteString(part.Text)
+	}
+	return strings.TrimSpace(text.String()), nil
+}
@@ -188,6 +188,10 @@ func RequestID(id string) Field {
 	return Field{Key: "request_id", Value: id}
 }
 
+func CorrelationID(id string) Field {
+	return Field{Key: "correlation_id", Value: id}
+}
+
 // Global logger instance for convenience
 var defaultLogger Logger
 
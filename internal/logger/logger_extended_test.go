@@ -82,6 +82,7 @@ func TestFieldHelpers(t *testing.T) {
 	assert.Equal(t, Field{Key: "error", Value: "oops"}, Error(errors.New("oops")))
 	assert.Equal(t, Field{Key: "operation", Value: "sync"}, Operation("sync"))
 	assert.Equal(t, Field{Key: "request_id", Value: "req-123"}, RequestID("req-123"))
+	assert.Equal(t, Field{Key: "correlation_id", Value: "c-123"}, CorrelationID("c-123"))
 }
 
 // --- DefaultConfig tests ---
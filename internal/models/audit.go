@@ -0,0 +1,57 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// Outcomes of an audited action
+const (
+	AuditSucceeded = "succeeded"
+	AuditFailed    = "failed"
+)
+
+// AuditEntry is an entry in the append-only log of state-changing grctool
+// actions, recording who ran which command, where, with what arguments and
+// how it ended. Like custody events, entries are hash-chained.
+type AuditEntry struct {
+	Timestamp     time.Time `yaml:"timestamp" json:"timestamp"` // When the command started
+	Actor         string    `yaml:"actor" json:"actor"`
+	Host          string    `yaml:"host" json:"host"`
+	Command       string    `yaml:"command" json:"command"`                                   // e.g. "evidence submit"
+	Args          []string  `yaml:"args,omitempty" json:"args,omitempty"`                     // Arguments and set flags, with secret values redacted
+	Profile       string    `yaml:"profile,omitempty" json:"profile,omitempty"`               // Active configuration profile
+	Version       string    `yaml:"version,omitempty" json:"version,omitempty"`               // grctool version
+	CorrelationID string    `yaml:"correlation_id,omitempty" json:"correlation_id,omitempty"` // Correlation ID of the run, as in its logs and spans
+	Outcome       string    `yaml:"outcome" json:"outcome"`                                   // succeeded or failed
+	Error         string    `yaml:"error,omitempty" json:"error,omitempty"`                   // Why the command failed
+	DurationMS    int64     `yaml:"duration_ms" json:"duration_ms"`                           // How long the command ran
+	PrevHash      string    `yaml:"prev_hash,omitempty" json:"prev_hash,omitempty"`           // Hash of the previous entry; empty for the first
+	Hash          string    `yaml:"hash,omitempty" json:"hash,omitempty"`                     // ChainHash() when the entry was logged
+}
+
+// ChainHash returns the SHA-256 of the entry's JSON without its own hash,
+// covering the previous entry's hash
+func (e AuditEntry) ChainHash() string {
+	e.Hash = ""
+	data, _ := json.Marshal(e)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
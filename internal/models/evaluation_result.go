@@ -26,9 +26,10 @@ type EvaluationResult struct {
 	Subfolder string `json:"subfolder,omitempty" yaml:"subfolder,omitempty"` // .submitted or archive
 
 	// Overall evaluation
-	OverallScore  float64          `json:"overall_score" yaml:"overall_score"`   // 0-100
-	OverallStatus EvaluationStatus `json:"overall_status" yaml:"overall_status"` // pass, warning, fail
-	PassThreshold float64          `json:"pass_threshold" yaml:"pass_threshold"` // Score needed to pass (default 70)
+	OverallScore  float64          `json:"overall_score" yaml:"overall_score"`       // 0-100
+	OverallStatus EvaluationStatus `json:"overall_status" yaml:"overall_status"`     // pass, warning, fail
+	PassThreshold float64          `json:"pass_threshold" yaml:"pass_threshold"`     // Score needed to pass (default 70)
+	Rubric        string           `json:"rubric,omitempty" yaml:"rubric,omitempty"` // Configured rubric the evidence was scored with
 
 	// Dimension scores
 	Completeness      DimensionScore `json:"completeness" yaml:"completeness"`
@@ -36,6 +37,9 @@ type EvaluationResult struct {
 	QualityScore      DimensionScore `json:"quality_score" yaml:"quality_score"`
 	ControlAlignment  DimensionScore `json:"control_alignment" yaml:"control_alignment"`
 
+	// Checks the rubric requires to pass
+	RequiredChecks []RequiredCheckResult `json:"required_checks,omitempty" yaml:"required_checks,omitempty"`
+
 	// Issues and recommendations
 	Issues              []EvaluationIssue `json:"issues" yaml:"issues"`
 	Recommendations     []string          `json:"recommendations" yaml:"recommendations"`
@@ -43,7 +47,8 @@ type EvaluationResult struct {
 
 	// Metadata
 	EvaluatedAt time.Time `json:"evaluated_at" yaml:"evaluated_at"`
-	EvaluatedBy string    `json:"evaluated_by" yaml:"evaluated_by"` // system, user, etc.
+	EvaluatedBy string    `json:"evaluated_by" yaml:"evaluated_by"`                   // system, user, etc.
+	ReviewedBy  string    `json:"reviewed_by,omitempty" yaml:"reviewed_by,omitempty"` // Model that reviewed the evidence contents
 	FileCount   int       `json:"file_count" yaml:"file_count"`
 	TotalBytes  int64     `json:"total_bytes" yaml:"total_bytes"`
 }
@@ -56,6 +61,18 @@ type DimensionScore struct {
 	Status      string  `json:"status" yaml:"status"`                       // pass, warning, fail
 	Description string  `json:"description" yaml:"description"`             // What this dimension measures
 	Details     string  `json:"details,omitempty" yaml:"details,omitempty"` // Specific findings
+	PassScore   float64 `json:"pass_score" yaml:"pass_score"`               // Score needed to pass (default 80)
+	WarnScore   float64 `json:"warn_score" yaml:"warn_score"`               // Score below which it fails (default 50)
+}
+
+// RequiredCheckResult records whether the evidence met one check required by
+// the rubric
+type RequiredCheckResult struct {
+	Name        string        `json:"name" yaml:"name"`
+	Description string        `json:"description,omitempty" yaml:"description,omitempty"`
+	Status      string        `json:"status" yaml:"status"`     // pass or fail
+	Severity    IssueSeverity `json:"severity" yaml:"severity"` // Severity of the issue raised when it fails
+	Details     string        `json:"details,omitempty" yaml:"details,omitempty"`
 }
 
 // EvaluationIssue represents a specific issue found during evaluation
@@ -87,6 +104,26 @@ const (
 	IssueInfo     IssueSeverity = "info"     // Informational only
 )
 
+// DimensionSkipped is the status of a dimension the rubric does not score
+const DimensionSkipped = "skipped"
+
+// Scored reports whether the dimension counts toward the overall score
+func (d DimensionScore) Scored() bool {
+	return d.Weight > 0
+}
+
+// GradeStatus sets the dimension status from its score and thresholds
+func (d *DimensionScore) GradeStatus() {
+	switch {
+	case d.Score >= d.PassScore:
+		d.Status = "pass"
+	case d.Score >= d.WarnScore:
+		d.Status = "warning"
+	default:
+		d.Status = "fail"
+	}
+}
+
 // CalculateOverallScore calculates the weighted overall score from dimension scores
 func (er *EvaluationResult) CalculateOverallScore() {
 	totalWeight := er.Completeness.Weight + er.RequirementsMatch.Weight +
@@ -136,6 +173,22 @@ func (er *EvaluationResult) DetermineStatus() {
 	}
 }
 
+// Dimension returns the score of a dimension by its rubric name:
+// completeness, requirements_match, quality or control_alignment
+func (er *EvaluationResult) Dimension(name string) *DimensionScore {
+	switch name {
+	case "completeness":
+		return &er.Completeness
+	case "requirements_match":
+		return &er.RequirementsMatch
+	case "quality":
+		return &er.QualityScore
+	case "control_alignment":
+		return &er.ControlAlignment
+	}
+	return nil
+}
+
 // AddIssue adds an issue to the evaluation result
 func (er *EvaluationResult) AddIssue(severity IssueSeverity, category, message, location, suggestion string) {
 	er.Issues = append(er.Issues, EvaluationIssue{
@@ -187,21 +240,29 @@ func NewEvaluationResult(taskRef string, taskID string, window string, subfolder
 		Completeness: DimensionScore{
 			MaxScore:    100,
 			Weight:      0.30, // 30% of overall score
+			PassScore:   80,
+			WarnScore:   50,
 			Description: "Evidence completeness and required files present",
 		},
 		RequirementsMatch: DimensionScore{
 			MaxScore:    100,
 			Weight:      0.30, // 30% of overall score
+			PassScore:   80,
+			WarnScore:   50,
 			Description: "Evidence matches task requirements and guidance",
 		},
 		QualityScore: DimensionScore{
 			MaxScore:    100,
 			Weight:      0.20, // 20% of overall score
+			PassScore:   80,
+			WarnScore:   50,
 			Description: "Evidence quality and presentation",
 		},
 		ControlAlignment: DimensionScore{
 			MaxScore:    100,
 			Weight:      0.20, // 20% of overall score
+			PassScore:   80,
+			WarnScore:   50,
 			Description: "Evidence addresses related controls appropriately",
 		},
 		Issues:              []EvaluationIssue{},
@@ -162,6 +162,39 @@ func TestEvaluationResult_DetermineStatus(t *testing.T) {
 	})
 }
 
+func TestDimensionScore_GradeStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		score float64
+		want  string
+	}{
+		"at pass score":      {score: 90, want: "pass"},
+		"between thresholds": {score: 75, want: "warning"},
+		"at warn score":      {score: 60, want: "warning"},
+		"below warn score":   {score: 59, want: "fail"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dimension := DimensionScore{Score: tc.score, PassScore: 90, WarnScore: 60}
+			dimension.GradeStatus()
+			assert.Equal(t, tc.want, dimension.Status)
+		})
+	}
+}
+
+func TestEvaluationResult_Dimension(t *testing.T) {
+	t.Parallel()
+	er := NewEvaluationResult("ET-0001", "1", "2025-Q4", "")
+
+	er.Dimension("quality").Score = 42
+	assert.Equal(t, 42.0, er.QualityScore.Score)
+	assert.Same(t, &er.ControlAlignment, er.Dimension("control_alignment"))
+	assert.Nil(t, er.Dimension("coverage"))
+}
+
 func TestEvaluationResult_AddIssue(t *testing.T) {
 	t.Parallel()
 	er := NewEvaluationResult("ET-0001", "1", "2025-Q4", "")
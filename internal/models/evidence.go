@@ -111,7 +111,9 @@ type EvidenceContext struct {
 
 // SecurityMappings represents the security control to resource mappings
 type SecurityMappings struct {
-	SOC2 map[string]SecurityControlMapping `json:"soc2"`
+	SOC2      map[string]SecurityControlMapping `json:"soc2"`
+	NIST80053 map[string]SecurityControlMapping `json:"nist_800_53,omitempty"`
+	Custom    map[string]SecurityControlMapping `json:"custom,omitempty"` // Custom framework controls, keyed "<label> <code>"
 }
 
 // SecurityControlMapping represents the mapping of a security control to resources
@@ -29,6 +29,32 @@ type GenerationMetadata struct {
 	ToolsUsed        []string       `yaml:"tools_used,omitempty"`
 	FilesGenerated   []FileMetadata `yaml:"files_generated"`
 	Status           string         `yaml:"status"` // "generated", "validated", "submitted"
+
+	// Evidence drafted by a language model stays pending review until a
+	// reviewer approves it
+	DraftedBy    string      `yaml:"drafted_by,omitempty"`    // Model that drafted the evidence, e.g. "openai/gpt-4o"
+	ReviewStatus string      `yaml:"review_status,omitempty"` // "pending_review" for machine drafts
+	DraftUsage   *ModelUsage `yaml:"draft_usage,omitempty"`   // What drafting the evidence consumed
+}
+
+// ModelUsage records the tokens a language model used and what they cost
+type ModelUsage struct {
+	InputTokens  int     `yaml:"input_tokens"`
+	OutputTokens int     `yaml:"output_tokens"`
+	CostUSD      float64 `yaml:"cost_usd"` // At the configured prices; 0 when unpriced
+}
+
+// Tokens returns the input and output tokens together
+func (u ModelUsage) Tokens() int {
+	return u.InputTokens + u.OutputTokens
+}
+
+// ReviewPending is the review status of evidence a language model drafted
+const ReviewPending = "pending_review"
+
+// MachineDrafted reports whether a language model drafted the evidence
+func (m *GenerationMetadata) MachineDrafted() bool {
+	return m.DraftedBy != ""
 }
 
 // FileMetadata represents metadata about a single evidence file
@@ -37,4 +63,22 @@ type FileMetadata struct {
 	Checksum    string    `yaml:"checksum"` // "sha256:abc123..."
 	SizeBytes   int64     `yaml:"size_bytes"`
 	GeneratedAt time.Time `yaml:"generated_at"`
+
+	// Sources records when each input to the file last changed, so freshness
+	// checks can flag evidence whose sources moved on after collection
+	Sources []SourceTimestamp `yaml:"sources,omitempty"`
+}
+
+// Source types recorded in SourceTimestamp
+const (
+	SourceTypeTool     = "tool"     // Tool run that produced the content
+	SourceTypeFile     = "file"     // Local file or glob pattern, re-checked on disk
+	SourceTypeDocument = "document" // Remote document with a reported last-modified time
+)
+
+// SourceTimestamp records the point in time an evidence source reflects
+type SourceTimestamp struct {
+	Type       string    `yaml:"type"`        // tool, file, document
+	Location   string    `yaml:"location"`    // Tool name, file path/glob, or document URL
+	ModifiedAt time.Time `yaml:"modified_at"` // Tool run time or source last-modified time
 }
@@ -15,7 +15,14 @@
 
 package models
 
-import "time"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
 
 // EvidenceSubmission represents a single task's evidence submission
 type EvidenceSubmission struct {
@@ -25,7 +32,7 @@ type EvidenceSubmission struct {
 	Window  string `yaml:"window" json:"window"`     // 2025-Q4
 
 	// Submission tracking
-	Status       string  `yaml:"status" json:"status"`                                   // draft, validated, submitted, accepted, rejected
+	Status       string  `yaml:"status" json:"status"`                                   // draft, validated, submitted, accepted, rejected, superseded
 	SubmissionID string  `yaml:"submission_id,omitempty" json:"submission_id,omitempty"` // Tugboat submission ID
 	BatchID      string  `yaml:"batch_id,omitempty" json:"batch_id,omitempty"`           // Associated batch
 	BatchName    *string `yaml:"batch_name,omitempty" json:"batch_name,omitempty"`       // Optional batch name reference
@@ -54,6 +61,106 @@ type EvidenceSubmission struct {
 
 	// Tugboat response
 	TugboatResponse *TugboatSubmissionResponse `yaml:"tugboat_response,omitempty" json:"tugboat_response,omitempty"`
+
+	// Files that could not be uploaded, for 'evidence submit --retry-failed'
+	FailedFiles []FailedUpload `yaml:"failed_files,omitempty" json:"failed_files,omitempty"`
+
+	// What the target reported receiving, checked against what was sent
+	Verification *SubmissionVerification `yaml:"verification,omitempty" json:"verification,omitempty"`
+
+	// Why and when the submission was withdrawn with 'evidence withdraw'
+	Withdrawal *SubmissionWithdrawal `yaml:"withdrawal,omitempty" json:"withdrawal,omitempty"`
+
+	// The sign-off the evidence was submitted under, when approval is required
+	Approval *EvidenceApproval `yaml:"approval,omitempty" json:"approval,omitempty"`
+
+	// Files uploaded changed to meet the target's size or type limits
+	Transformations []FileTransformation `yaml:"transformations,omitempty" json:"transformations,omitempty"`
+}
+
+// Steps of a FileTransformation
+const (
+	TransformCompressedImage = "compressed_image" // Re-encoded as a smaller JPEG
+	TransformConvertedToCSV  = "converted_to_csv" // JSON records flattened into a CSV
+	TransformRenamedToText   = "renamed_to_txt"   // Text of an unaccepted type uploaded as .txt
+	TransformArchived        = "archived"         // Compressed into a zip archive
+	TransformSplit           = "split"            // Uploaded in parts with a manifest
+)
+
+// FileTransformation records how an evidence file was changed so the target
+// would take it, instead of the submission failing
+type FileTransformation struct {
+	Filename          string   `yaml:"filename" json:"filename"`
+	Reason            string   `yaml:"reason" json:"reason"`
+	Steps             []string `yaml:"steps" json:"steps"`
+	Details           []string `yaml:"details,omitempty" json:"details,omitempty"`
+	UploadedAs        []string `yaml:"uploaded_as" json:"uploaded_as"`
+	OriginalSizeBytes int64    `yaml:"original_size_bytes" json:"original_size_bytes"`
+	UploadedSizeBytes int64    `yaml:"uploaded_size_bytes" json:"uploaded_size_bytes"`
+	OriginalSHA256    string   `yaml:"original_sha256" json:"original_sha256"`
+}
+
+// FailedUpload records an evidence file that failed to upload after retries
+type FailedUpload struct {
+	Filename    string    `yaml:"filename" json:"filename"`
+	Error       string    `yaml:"error" json:"error"`
+	Attempts    int       `yaml:"attempts" json:"attempts"`
+	LastAttempt time.Time `yaml:"last_attempt" json:"last_attempt"`
+}
+
+// SubmissionVerification records the files a target reported receiving after
+// a submission, read back from the target and checked against the files sent
+type SubmissionVerification struct {
+	VerifiedAt time.Time      `yaml:"verified_at" json:"verified_at"`
+	Status     string         `yaml:"status" json:"status"`                   // verified, mismatch or error
+	Error      string         `yaml:"error,omitempty" json:"error,omitempty"` // Why the target could not be read back
+	Files      []VerifiedFile `yaml:"files,omitempty" json:"files,omitempty"`
+}
+
+// VerifiedFile is a file sent to the target and what the target received
+type VerifiedFile struct {
+	Filename      string `yaml:"filename" json:"filename"`
+	SentBytes     int64  `yaml:"sent_bytes" json:"sent_bytes"`
+	ReceivedBytes int64  `yaml:"received_bytes,omitempty" json:"received_bytes,omitempty"` // Unset when missing or not reported
+	Status        string `yaml:"status" json:"status"`                                     // ok, missing or size_mismatch
+}
+
+// SubmissionWithdrawal records a submission being withdrawn, superseding it
+// so the window's evidence can be reworked and submitted again
+type SubmissionWithdrawal struct {
+	WithdrawnAt  time.Time `yaml:"withdrawn_at" json:"withdrawn_at"`
+	WithdrawnBy  string    `yaml:"withdrawn_by,omitempty" json:"withdrawn_by,omitempty"`
+	Reason       string    `yaml:"reason" json:"reason"`
+	Target       string    `yaml:"target,omitempty" json:"target,omitempty"`
+	Remote       string    `yaml:"remote" json:"remote"` // withdrawn, unsupported or skipped
+	RemovedFiles []string  `yaml:"removed_files,omitempty" json:"removed_files,omitempty"`
+}
+
+// Mismatches returns the files the target did not receive as they were sent
+func (v *SubmissionVerification) Mismatches() []VerifiedFile {
+	mismatches := []VerifiedFile{}
+	for _, file := range v.Files {
+		if file.Status != "ok" {
+			mismatches = append(mismatches, file)
+		}
+	}
+	return mismatches
+}
+
+// UploadedFiles returns the evidence files of the submission that did not
+// fail to upload
+func (s *EvidenceSubmission) UploadedFiles() []EvidenceFileRef {
+	failed := map[string]bool{}
+	for _, failure := range s.FailedFiles {
+		failed[failure.Filename] = true
+	}
+	uploaded := []EvidenceFileRef{}
+	for _, file := range s.EvidenceFiles {
+		if !failed[file.Filename] {
+			uploaded = append(uploaded, file)
+		}
+	}
+	return uploaded
 }
 
 // EvidenceFileRef references a single evidence file
@@ -145,6 +252,199 @@ type SubmissionHistoryEntry struct {
 	BatchID      string    `yaml:"batch_id,omitempty" json:"batch_id,omitempty"`
 }
 
+// SubmissionQueue holds the submissions queued while their target could not
+// be reached, oldest first, with the ones already sent kept as a record of
+// their receipts
+type SubmissionQueue struct {
+	Entries []QueuedSubmission `yaml:"entries" json:"entries"`
+}
+
+// QueuedSubmission is an evidence submission waiting to be sent, or sent, by
+// 'grctool queue flush'
+type QueuedSubmission struct {
+	ID             int               `yaml:"id" json:"id"` // Position in the queue, counting from 1
+	TaskRef        string            `yaml:"task_ref" json:"task_ref"`
+	Window         string            `yaml:"window" json:"window"`
+	Target         string            `yaml:"target,omitempty" json:"target,omitempty"` // --target when queued; empty for the first configured
+	Notes          string            `yaml:"notes,omitempty" json:"notes,omitempty"`
+	SkipValidation bool              `yaml:"skip_validation,omitempty" json:"skip_validation,omitempty"`
+	RetryFailed    bool              `yaml:"retry_failed,omitempty" json:"retry_failed,omitempty"`
+	SubmittedBy    string            `yaml:"submitted_by" json:"submitted_by"`
+	Status         string            `yaml:"status" json:"status"` // queued, sent, failed, removed
+	Reason         string            `yaml:"reason,omitempty" json:"reason,omitempty"`
+	QueuedAt       time.Time         `yaml:"queued_at" json:"queued_at"`
+	Attempts       int               `yaml:"attempts" json:"attempts"` // Flushes that tried to send it
+	LastError      string            `yaml:"last_error,omitempty" json:"last_error,omitempty"`
+	SentAt         *time.Time        `yaml:"sent_at,omitempty" json:"sent_at,omitempty"`
+	SubmissionID   string            `yaml:"submission_id,omitempty" json:"submission_id,omitempty"`
+	Receipts       map[string]string `yaml:"receipts,omitempty" json:"receipts,omitempty"` // Filename -> ID the target assigned
+}
+
+// Pending returns the queued submissions not sent yet, oldest first
+func (q *SubmissionQueue) Pending() []QueuedSubmission {
+	pending := []QueuedSubmission{}
+	for _, entry := range q.Entries {
+		if entry.Status == "queued" {
+			pending = append(pending, entry)
+		}
+	}
+	return pending
+}
+
+// Queued returns the task window's submission waiting in the queue, if any
+func (q *SubmissionQueue) Queued(taskRef, window string) (QueuedSubmission, bool) {
+	for _, entry := range q.Entries {
+		if entry.Status == "queued" && entry.TaskRef == taskRef && entry.Window == window {
+			return entry, true
+		}
+	}
+	return QueuedSubmission{}, false
+}
+
+// Enqueue adds a submission at the end of the queue, numbering it after the
+// last entry, and returns it
+func (q *SubmissionQueue) Enqueue(entry QueuedSubmission) QueuedSubmission {
+	entry.ID = 1
+	if len(q.Entries) > 0 {
+		entry.ID = q.Entries[len(q.Entries)-1].ID + 1
+	}
+	entry.Status = "queued"
+	q.Entries = append(q.Entries, entry)
+	return entry
+}
+
+// EvidenceFeedback holds the auditor comments on a task window, synced from Tugboat
+type EvidenceFeedback struct {
+	TaskRef  string            `yaml:"task_ref" json:"task_ref"`
+	Window   string            `yaml:"window" json:"window"`
+	SyncedAt time.Time         `yaml:"synced_at" json:"synced_at"`
+	Comments []FeedbackComment `yaml:"comments" json:"comments"` // Oldest first
+}
+
+// FeedbackComment is a single auditor comment or review decision
+type FeedbackComment struct {
+	ID           string    `yaml:"id" json:"id"`
+	Author       string    `yaml:"author" json:"author"`
+	Status       string    `yaml:"status" json:"status"` // accepted, rejected, comment
+	Comment      string    `yaml:"comment" json:"comment"`
+	AttachmentID string    `yaml:"attachment_id,omitempty" json:"attachment_id,omitempty"` // Reviewed attachment
+	CreatedAt    time.Time `yaml:"created_at" json:"created_at"`
+	Resolved     bool      `yaml:"resolved" json:"resolved"`
+}
+
+// TaskComments is the comment thread of an evidence task in Tugboat, across
+// all windows, kept in the task's evidence directory
+type TaskComments struct {
+	TaskRef  string            `yaml:"task_ref" json:"task_ref"`
+	SyncedAt time.Time         `yaml:"synced_at,omitempty" json:"synced_at,omitempty"`
+	Comments []FeedbackComment `yaml:"comments" json:"comments"` // Oldest first
+}
+
+// OpenRejections returns the unresolved rejections, which describe the rework
+// needed before resubmitting
+func (f *EvidenceFeedback) OpenRejections() []FeedbackComment {
+	var open []FeedbackComment
+	for _, comment := range f.Comments {
+		if comment.Status == "rejected" && !comment.Resolved {
+			open = append(open, comment)
+		}
+	}
+	return open
+}
+
+// EvidenceApproval is a second reviewer's sign-off on a task window's
+// evidence, required before submission when submission.require_approval is
+// set. It lists the files approved with their checksums, so evidence changed
+// after approval has to be approved again.
+type EvidenceApproval struct {
+	TaskRef    string         `yaml:"task_ref" json:"task_ref"`
+	Window     string         `yaml:"window" json:"window"`
+	Approver   string         `yaml:"approver" json:"approver"`
+	ApprovedAt time.Time      `yaml:"approved_at" json:"approved_at"`
+	Comment    string         `yaml:"comment,omitempty" json:"comment,omitempty"`
+	Files      []ApprovedFile `yaml:"files" json:"files"`                             // Sorted by filename
+	Signature  string         `yaml:"signature,omitempty" json:"signature,omitempty"` // ASCII-armored GPG signature of Statement()
+}
+
+// ApprovedFile is an evidence file as it was approved
+type ApprovedFile struct {
+	Filename string `yaml:"filename" json:"filename"`
+	SHA256   string `yaml:"sha256" json:"sha256"`
+}
+
+// Statement returns the text an approval signature is made over: the task,
+// window, approver, time and the checksum of each approved file, in the
+// format of sha256sum. Verify a signature with:
+//
+//	gpg --verify signature.asc statement.txt
+func (a *EvidenceApproval) Statement() string {
+	var statement strings.Builder
+	statement.WriteString("grctool evidence approval\n")
+	fmt.Fprintf(&statement, "task: %s\nwindow: %s\n", a.TaskRef, a.Window)
+	fmt.Fprintf(&statement, "approver: %s\napproved_at: %s\n", a.Approver, a.ApprovedAt.UTC().Format(time.RFC3339))
+	if a.Comment != "" {
+		fmt.Fprintf(&statement, "comment: %s\n", a.Comment)
+	}
+	statement.WriteString("\n")
+	for _, file := range a.Files {
+		fmt.Fprintf(&statement, "%s  %s\n", file.SHA256, file.Filename)
+	}
+	return statement.String()
+}
+
+// Covers returns an error naming the first file that was not approved with
+// its current content, or nil when every file was
+func (a *EvidenceApproval) Covers(files []EvidenceFileRef) error {
+	approved := map[string]string{}
+	for _, file := range a.Files {
+		approved[file.Filename] = file.SHA256
+	}
+	for _, file := range files {
+		checksum, ok := approved[file.Filename]
+		switch {
+		case !ok:
+			return fmt.Errorf("%s was added after approval", file.Filename)
+		case checksum != file.ChecksumSHA256:
+			return fmt.Errorf("%s changed after approval", file.Filename)
+		}
+	}
+	return nil
+}
+
+// Chain-of-custody events of an evidence file
+const (
+	CustodyGenerated = "generated" // Written by the evidence writer
+	CustodyEdited    = "edited"    // Content changed since the last recorded event
+	CustodyValidated = "validated"
+	CustodyApproved  = "approved"
+	CustodySubmitted = "submitted"
+	CustodyMoved     = "moved" // To .submitted/ or back to the window root
+)
+
+// CustodyEvent is an entry in a window's append-only chain-of-custody log,
+// recording what happened to one evidence file, who did it and when. Entries
+// are hash-chained: each records the hash of the entry before it and its own,
+// so altering, removing or reordering entries breaks the chain.
+type CustodyEvent struct {
+	Timestamp time.Time `yaml:"timestamp" json:"timestamp"`
+	File      string    `yaml:"file" json:"file"`
+	Event     string    `yaml:"event" json:"event"`
+	Actor     string    `yaml:"actor,omitempty" json:"actor,omitempty"`   // Empty when unknown, as for edits made outside grctool
+	SHA256    string    `yaml:"sha256,omitempty" json:"sha256,omitempty"` // File content at the time of the event
+	Details   string    `yaml:"details,omitempty" json:"details,omitempty"`
+	PrevHash  string    `yaml:"prev_hash,omitempty" json:"prev_hash,omitempty"` // Hash of the previous entry; empty for the first
+	Hash      string    `yaml:"hash,omitempty" json:"hash,omitempty"`           // ChainHash() when the entry was logged
+}
+
+// ChainHash returns the SHA-256 of the entry's JSON without its own hash,
+// covering the previous entry's hash
+func (e CustodyEvent) ChainHash() string {
+	e.Hash = ""
+	data, _ := json.Marshal(e)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
 // ValidationResult represents the complete validation result
 type ValidationResult struct {
 	TaskRef             string            `json:"task_ref"`
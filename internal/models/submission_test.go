@@ -215,6 +215,75 @@ func TestSubmissionHistory_JSONRoundTrip(t *testing.T) {
 	assert.Equal(t, "accepted", decoded.Entries[1].Status)
 }
 
+func TestEvidenceFeedback_OpenRejections(t *testing.T) {
+	t.Parallel()
+	feedback := EvidenceFeedback{
+		TaskRef: "ET-0001",
+		Window:  "2025-Q4",
+		Comments: []FeedbackComment{
+			{ID: "1", Status: "rejected", Comment: "Export is from the wrong quarter", Resolved: true},
+			{ID: "2", Status: "comment", Comment: "Please include the reviewer's name"},
+			{ID: "3", Status: "rejected", Comment: "Screenshot is missing the date"},
+		},
+	}
+
+	open := feedback.OpenRejections()
+	require.Len(t, open, 1)
+	assert.Equal(t, "3", open[0].ID)
+	assert.Empty(t, (&EvidenceFeedback{}).OpenRejections())
+}
+
+func TestEvidenceSubmission_UploadedFiles(t *testing.T) {
+	t.Parallel()
+	sub := EvidenceSubmission{
+		EvidenceFiles: []EvidenceFileRef{{Filename: "access.csv"}, {Filename: "notes.md"}, {Filename: "review.pdf"}},
+		FailedFiles:   []FailedUpload{{Filename: "notes.md", Error: "timeout", Attempts: 4}},
+	}
+
+	assert.Equal(t, []EvidenceFileRef{{Filename: "access.csv"}, {Filename: "review.pdf"}}, sub.UploadedFiles())
+	assert.Empty(t, (&EvidenceSubmission{}).UploadedFiles())
+}
+
+func TestSubmissionQueue(t *testing.T) {
+	t.Parallel()
+	queue := &SubmissionQueue{}
+
+	first := queue.Enqueue(QueuedSubmission{TaskRef: "ET-0001", Window: "2025-Q4"})
+	second := queue.Enqueue(QueuedSubmission{TaskRef: "ET-0002", Window: "2025-Q4"})
+	assert.Equal(t, 1, first.ID)
+	assert.Equal(t, "queued", first.Status)
+	assert.Equal(t, 2, second.ID)
+
+	queue.Entries[0].Status = "sent"
+	assert.Equal(t, []QueuedSubmission{second}, queue.Pending())
+	_, ok := queue.Queued("ET-0001", "2025-Q4")
+	assert.False(t, ok, "sent")
+	queued, ok := queue.Queued("ET-0002", "2025-Q4")
+	assert.True(t, ok)
+	assert.Equal(t, 2, queued.ID)
+
+	queue.Entries[1].Status = "removed"
+	assert.Equal(t, 3, queue.Enqueue(QueuedSubmission{TaskRef: "ET-0002", Window: "2025-Q4"}).ID, "IDs are not reused")
+}
+
+func TestEvidenceApproval(t *testing.T) {
+	t.Parallel()
+	approval := &EvidenceApproval{
+		TaskRef:    "ET-0047",
+		Window:     "2025-Q4",
+		Approver:   "sam@example.com",
+		ApprovedAt: time.Date(2025, 11, 10, 14, 30, 0, 0, time.UTC),
+		Files:      []ApprovedFile{{Filename: "access.csv", SHA256: "aaa"}, {Filename: "notes.md", SHA256: "bbb"}},
+	}
+
+	assert.Equal(t, "grctool evidence approval\ntask: ET-0047\nwindow: 2025-Q4\n"+
+		"approver: sam@example.com\napproved_at: 2025-11-10T14:30:00Z\n\naaa  access.csv\nbbb  notes.md\n", approval.Statement())
+
+	assert.NoError(t, approval.Covers([]EvidenceFileRef{{Filename: "access.csv", ChecksumSHA256: "aaa"}}))
+	assert.EqualError(t, approval.Covers([]EvidenceFileRef{{Filename: "notes.md", ChecksumSHA256: "ccc"}}), "notes.md changed after approval")
+	assert.EqualError(t, approval.Covers([]EvidenceFileRef{{Filename: "users.csv", ChecksumSHA256: "ddd"}}), "users.csv was added after approval")
+}
+
 func TestSubmitEvidenceRequest_JSONRoundTrip(t *testing.T) {
 	t.Parallel()
 	req := SubmitEvidenceRequest{
@@ -0,0 +1,102 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import "time"
+
+// SyncCheckpoint records the progress of a sync, so that one which stopped
+// partway can resume without fetching what it already saved
+type SyncCheckpoint struct {
+	Scope     string    `json:"scope"` // What was being synced; a resume must match it
+	StartedAt time.Time `json:"started_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Completed maps a sync stage, such as "tugboat/policies", to the IDs it
+	// saved in full
+	Completed map[string][]string `json:"completed"`
+	// Finished lists the stages that saved everything they listed
+	Finished []string `json:"finished,omitempty"`
+
+	done map[string]map[string]bool
+}
+
+// NewSyncCheckpoint creates an empty checkpoint for a sync of scope
+func NewSyncCheckpoint(scope string) *SyncCheckpoint {
+	now := time.Now()
+	return &SyncCheckpoint{
+		Scope:     scope,
+		StartedAt: now,
+		UpdatedAt: now,
+		Completed: map[string][]string{},
+	}
+}
+
+// IsCompleted reports whether the stage saved the item with this ID
+func (c *SyncCheckpoint) IsCompleted(stage, id string) bool {
+	if c.done == nil {
+		c.done = map[string]map[string]bool{}
+		for s, ids := range c.Completed {
+			c.done[s] = make(map[string]bool, len(ids))
+			for _, completed := range ids {
+				c.done[s][completed] = true
+			}
+		}
+	}
+	return c.done[stage][id]
+}
+
+// MarkCompleted records that the stage saved the item with this ID
+func (c *SyncCheckpoint) MarkCompleted(stage, id string) {
+	if c.IsCompleted(stage, id) {
+		return
+	}
+	if c.Completed == nil {
+		c.Completed = map[string][]string{}
+	}
+	c.Completed[stage] = append(c.Completed[stage], id)
+	if c.done[stage] == nil {
+		c.done[stage] = map[string]bool{}
+	}
+	c.done[stage][id] = true
+	c.UpdatedAt = time.Now()
+}
+
+// IsFinished reports whether the stage saved everything it listed
+func (c *SyncCheckpoint) IsFinished(stage string) bool {
+	for _, finished := range c.Finished {
+		if finished == stage {
+			return true
+		}
+	}
+	return false
+}
+
+// MarkFinished records that the stage saved everything it listed
+func (c *SyncCheckpoint) MarkFinished(stage string) {
+	if !c.IsFinished(stage) {
+		c.Finished = append(c.Finished, stage)
+		c.UpdatedAt = time.Now()
+	}
+}
+
+// CompletedCount returns the number of items saved across all stages
+func (c *SyncCheckpoint) CompletedCount() int {
+	count := 0
+	for _, ids := range c.Completed {
+		count += len(ids)
+	}
+	return count
+}
@@ -0,0 +1,39 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncCheckpoint_JSONRoundTrip(t *testing.T) {
+	t.Parallel()
+	checkpoint := NewSyncCheckpoint("policies,evidence-tasks")
+	checkpoint.MarkCompleted("tugboat/policies", "94001")
+	checkpoint.MarkCompleted("tugboat/policies", "94001")
+	checkpoint.MarkCompleted("tugboat/evidence_tasks", "327992")
+	checkpoint.MarkFinished("tugboat/policies")
+	checkpoint.MarkFinished("tugboat/policies")
+
+	assert.Equal(t, 2, checkpoint.CompletedCount(), "completing an item twice counts once")
+	assert.Equal(t, []string{"tugboat/policies"}, checkpoint.Finished)
+
+	data, err := json.Marshal(checkpoint)
+	require.NoError(t, err)
+	var decoded SyncCheckpoint
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	assert.True(t, decoded.IsCompleted("tugboat/policies", "94001"))
+	assert.True(t, decoded.IsCompleted("tugboat/evidence_tasks", "327992"))
+	assert.False(t, decoded.IsCompleted("tugboat/evidence_tasks", "94001"))
+	assert.True(t, decoded.IsFinished("tugboat/policies"))
+	assert.False(t, decoded.IsFinished("tugboat/evidence_tasks"))
+
+	decoded.MarkCompleted("tugboat/controls", "1001")
+	assert.True(t, decoded.IsCompleted("tugboat/controls", "1001"))
+}
@@ -279,6 +279,7 @@ type TerraformParseResult struct {
 	ParseSummary      *ParseSummary           `json:"parse_summary"`
 	ParsedAt          time.Time               `json:"parsed_at"`
 	ToolVersion       string                  `json:"tool_version"`
+	Framework         string                  `json:"framework,omitempty"`
 }
 
 // ResourceDependency represents dependencies between resources
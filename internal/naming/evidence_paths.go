@@ -37,8 +37,14 @@ const (
 	// SubfolderSubmitted is the hidden folder for submitted files (moved after upload to prevent resubmission)
 	SubfolderSubmitted = ".submitted"
 
+	// SubfolderContext is the hidden folder for assembly context and raw tool outputs
+	SubfolderContext = ".context"
+
 	// SubfolderArchive is the folder for evidence synced FROM Tugboat
 	SubfolderArchive = "archive"
+
+	// SubfolderExports is the folder for rendered exports (PDF) of a window's evidence
+	SubfolderExports = "exports"
 )
 
 var (
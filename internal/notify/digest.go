@@ -0,0 +1,310 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package notify builds per-assignee digests of evidence tasks that need
+// attention and delivers them through notification channels such as Slack and email.
+package notify
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/scheduler"
+)
+
+// Category classifies why a task is in a digest
+type Category string
+
+const (
+	// CategoryOverdue indicates the task's due date has passed
+	CategoryOverdue Category = "overdue"
+
+	// CategoryDueSoon indicates the task is due within the due-soon period
+	CategoryDueSoon Category = "due_soon"
+
+	// CategoryRejected indicates submitted evidence was rejected and needs rework
+	CategoryRejected Category = "rejected"
+)
+
+// Item is a task that needs attention
+type Item struct {
+	TaskRef   string     `json:"task_ref"`
+	TaskName  string     `json:"task_name"`
+	Framework string     `json:"framework,omitempty"`
+	Category  Category   `json:"category"`
+	DueDate   *time.Time `json:"due_date,omitempty"`
+	DaysUntil int        `json:"days_until"`       // Negative when overdue
+	Window    string     `json:"window,omitempty"` // Rejected collection window
+	URL       string     `json:"url,omitempty"`
+}
+
+// TaskSummary is an open task assigned to the recipient with its local
+// evidence state
+type TaskSummary struct {
+	TaskRef   string     `json:"task_ref"`
+	TaskName  string     `json:"task_name"`
+	Framework string     `json:"framework,omitempty"`
+	State     string     `json:"state"` // Local evidence state, e.g. "generated"
+	DueDate   *time.Time `json:"due_date,omitempty"`
+	URL       string     `json:"url,omitempty"`
+}
+
+// Recipient is the assignee a digest is addressed to; both fields are empty
+// for unassigned tasks
+type Recipient struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// Label returns the recipient's display name
+func (r Recipient) Label() string {
+	switch {
+	case r.Name != "":
+		return r.Name
+	case r.Email != "":
+		return r.Email
+	default:
+		return "Unassigned"
+	}
+}
+
+// IsUnassigned reports whether the digest collects unassigned tasks
+func (r Recipient) IsUnassigned() bool {
+	return r.Name == "" && r.Email == ""
+}
+
+// Digest lists the tasks of one assignee that need attention, along with all
+// of their open tasks
+type Digest struct {
+	Recipient   Recipient     `json:"recipient"`
+	Overdue     []Item        `json:"overdue"`
+	DueSoon     []Item        `json:"due_soon"`
+	Rejected    []Item        `json:"rejected"`
+	Assigned    []TaskSummary `json:"assigned"`
+	DueSoonDays int           `json:"due_soon_days"`
+	GeneratedAt time.Time     `json:"generated_at"`
+}
+
+// Total returns the number of items that need attention
+func (d Digest) Total() int {
+	return len(d.Overdue) + len(d.DueSoon) + len(d.Rejected)
+}
+
+// ItemStatus describes an item's deadline or rejection, e.g. "due 2025-11-20 (in 5 days)"
+func ItemStatus(item Item) string {
+	switch item.Category {
+	case CategoryRejected:
+		return fmt.Sprintf("%s evidence rejected", item.Window)
+	case CategoryOverdue:
+		if item.DaysUntil == 0 {
+			return fmt.Sprintf("due %s (overdue)", item.DueDate.Format("2006-01-02"))
+		}
+		return fmt.Sprintf("due %s (%d days overdue)", item.DueDate.Format("2006-01-02"), -item.DaysUntil)
+	default:
+		if item.DaysUntil == 0 {
+			return fmt.Sprintf("due %s (today)", item.DueDate.Format("2006-01-02"))
+		}
+		return fmt.Sprintf("due %s (in %d days)", item.DueDate.Format("2006-01-02"), item.DaysUntil)
+	}
+}
+
+// Options controls which tasks are included in digests
+type Options struct {
+	Now         time.Time // Reference time (default: time.Now)
+	DueSoonDays int       // Tasks due within this many days are due soon
+	Assignee    string    // Only build the digest for this name or email
+}
+
+// BuildDigests groups the tasks that are overdue, due soon or rejected by
+// assignee. A task with several assignees appears in each of their digests;
+// unassigned tasks are collected in a final digest without a recipient.
+// Only recipients with at least one such item get a digest, which then also
+// lists all of their open tasks. states may be nil, in which case no
+// rejected items are reported and open tasks have no local state.
+func BuildDigests(tasks []domain.EvidenceTask, states map[string]*models.EvidenceTaskState, opts Options) []Digest {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	digests := make(map[string]*Digest)
+	var order []string
+
+	for _, task := range tasks {
+		items := taskItems(task, states, now, opts.DueSoonDays)
+		if len(items) == 0 && task.Completed {
+			continue
+		}
+
+		recipients := taskRecipients(task)
+		for _, recipient := range recipients {
+			if opts.Assignee != "" && !strings.EqualFold(recipient.Email, opts.Assignee) && !strings.EqualFold(recipient.Name, opts.Assignee) {
+				continue
+			}
+
+			key := recipientKey(recipient)
+			digest, ok := digests[key]
+			if !ok {
+				digest = &Digest{Recipient: recipient, DueSoonDays: opts.DueSoonDays, GeneratedAt: now}
+				digests[key] = digest
+				order = append(order, key)
+			}
+			for _, item := range items {
+				switch item.Category {
+				case CategoryOverdue:
+					digest.Overdue = append(digest.Overdue, item)
+				case CategoryDueSoon:
+					digest.DueSoon = append(digest.DueSoon, item)
+				case CategoryRejected:
+					digest.Rejected = append(digest.Rejected, item)
+				}
+			}
+			if !task.Completed {
+				digest.Assigned = append(digest.Assigned, taskSummary(task, states))
+			}
+		}
+	}
+
+	result := make([]Digest, 0, len(order))
+	for _, key := range order {
+		digest := digests[key]
+		if digest.Total() == 0 {
+			continue
+		}
+		sortSummaries(digest.Assigned)
+		sortItems(digest.Overdue)
+		sortItems(digest.DueSoon)
+		sortItems(digest.Rejected)
+		result = append(result, *digest)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Recipient.IsUnassigned() != result[j].Recipient.IsUnassigned() {
+			return !result[i].Recipient.IsUnassigned()
+		}
+		return recipientKey(result[i].Recipient) < recipientKey(result[j].Recipient)
+	})
+	return result
+}
+
+// taskItems returns the reasons a task needs attention
+func taskItems(task domain.EvidenceTask, states map[string]*models.EvidenceTaskState, now time.Time, dueSoonDays int) []Item {
+	ref := taskRef(task)
+	base := Item{TaskRef: ref, TaskName: task.Name, Framework: task.Framework, URL: task.TugboatURL}
+
+	var items []Item
+	if !task.Completed && !task.IsDeferred(now) {
+		category, daysUntil := scheduler.ClassifyTaskDue(task.NextDue, now)
+		item := base
+		item.DueDate = task.NextDue
+		item.DaysUntil = daysUntil
+		switch {
+		case category == scheduler.TaskOverdue:
+			item.Category = CategoryOverdue
+			items = append(items, item)
+		case category != scheduler.TaskNoSchedule && daysUntil <= dueSoonDays:
+			item.Category = CategoryDueSoon
+			items = append(items, item)
+		}
+	}
+
+	if state, ok := states[ref]; ok && state != nil {
+		for _, window := range rejectedWindows(state) {
+			item := base
+			item.Category = CategoryRejected
+			item.Window = window
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func taskSummary(task domain.EvidenceTask, states map[string]*models.EvidenceTaskState) TaskSummary {
+	summary := TaskSummary{
+		TaskRef:   taskRef(task),
+		TaskName:  task.Name,
+		Framework: task.Framework,
+		State:     string(models.StateNoEvidence),
+		DueDate:   task.NextDue,
+		URL:       task.TugboatURL,
+	}
+	if state, ok := states[summary.TaskRef]; ok && state != nil && state.LocalState != "" {
+		summary.State = string(state.LocalState)
+	}
+	return summary
+}
+
+func taskRef(task domain.EvidenceTask) string {
+	if task.ReferenceID != "" {
+		return task.ReferenceID
+	}
+	return task.ID
+}
+
+// rejectedWindows lists the windows whose submission was rejected, newest first
+func rejectedWindows(state *models.EvidenceTaskState) []string {
+	var windows []string
+	for name, window := range state.Windows {
+		if window.SubmissionStatus == string(models.StateRejected) {
+			windows = append(windows, name)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(windows)))
+	return windows
+}
+
+func taskRecipients(task domain.EvidenceTask) []Recipient {
+	if len(task.Assignees) == 0 {
+		return []Recipient{{}}
+	}
+	recipients := make([]Recipient, 0, len(task.Assignees))
+	for _, person := range task.Assignees {
+		recipients = append(recipients, Recipient{Name: person.Name, Email: person.Email})
+	}
+	return recipients
+}
+
+func recipientKey(r Recipient) string {
+	if r.Email != "" {
+		return strings.ToLower(r.Email)
+	}
+	return strings.ToLower(r.Name)
+}
+
+func sortItems(items []Item) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].DaysUntil != items[j].DaysUntil {
+			return items[i].DaysUntil < items[j].DaysUntil
+		}
+		return items[i].TaskRef < items[j].TaskRef
+	})
+}
+
+// sortSummaries orders open tasks by due date, undated tasks last
+func sortSummaries(summaries []TaskSummary) {
+	sort.SliceStable(summaries, func(i, j int) bool {
+		a, b := summaries[i].DueDate, summaries[j].DueDate
+		switch {
+		case a != nil && b != nil && !a.Equal(*b):
+			return a.Before(*b)
+		case (a == nil) != (b == nil):
+			return a != nil
+		}
+		return summaries[i].TaskRef < summaries[j].TaskRef
+	})
+}
@@ -0,0 +1,119 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package notify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dueIn(now time.Time, days int) *time.Time {
+	due := now.AddDate(0, 0, days)
+	return &due
+}
+
+func TestBuildDigests(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC)
+	jane := domain.Person{Name: "Jane Doe", Email: "jane@example.com"}
+	raj := domain.Person{Name: "Raj Patel", Email: "raj@example.com"}
+
+	tasks := []domain.EvidenceTask{
+		{ReferenceID: "ET-0001", Name: "Access Review", NextDue: dueIn(now, -3), Assignees: []domain.Person{jane}},
+		{ReferenceID: "ET-0002", Name: "Vulnerability Scans", NextDue: dueIn(now, 5), Assignees: []domain.Person{jane, raj}},
+		{ReferenceID: "ET-0003", Name: "Pen Test", NextDue: dueIn(now, 60), Assignees: []domain.Person{raj}},
+		{ReferenceID: "ET-0004", Name: "Backups", NextDue: dueIn(now, -10), Completed: true, Assignees: []domain.Person{raj}},
+		{ReferenceID: "ET-0005", Name: "Vendor Reviews", NextDue: dueIn(now, 2)},
+		{ReferenceID: "ET-0006", Name: "Change Management", Assignees: []domain.Person{raj}},
+		{ReferenceID: "ET-0007", Name: "Asset Inventory", NextDue: dueIn(now, 90), Assignees: []domain.Person{{Email: "lee@example.com"}}},
+		{ReferenceID: "ET-0008", Name: "Vendor SOC 2 Reports", NextDue: dueIn(now, -5), Assignees: []domain.Person{jane},
+			Deferral: &domain.TaskDeferral{TaskRef: "ET-0008", Until: *dueIn(now, 30), Reason: "Waiting on vendor"}},
+	}
+	states := map[string]*models.EvidenceTaskState{
+		"ET-0003": {LocalState: models.StateGenerated},
+		"ET-0006": {LocalState: models.StateRejected, Windows: map[string]models.WindowState{
+			"2025-Q2": {SubmissionStatus: "rejected"},
+			"2025-Q3": {SubmissionStatus: "rejected"},
+			"2025-Q4": {SubmissionStatus: "submitted"},
+		}},
+	}
+
+	digests := BuildDigests(tasks, states, Options{Now: now, DueSoonDays: 7})
+	require.Len(t, digests, 3, "assignees with nothing needing attention get no digest")
+
+	janeDigest := digests[0]
+	assert.Equal(t, "jane@example.com", janeDigest.Recipient.Email)
+	require.Len(t, janeDigest.Overdue, 1, "deferred tasks are not overdue")
+	assert.Equal(t, "ET-0001", janeDigest.Overdue[0].TaskRef)
+	assert.Equal(t, -3, janeDigest.Overdue[0].DaysUntil)
+	require.Len(t, janeDigest.DueSoon, 1)
+	assert.Equal(t, "ET-0002", janeDigest.DueSoon[0].TaskRef)
+	assert.Equal(t, 7, janeDigest.DueSoonDays)
+	assert.Equal(t, now, janeDigest.GeneratedAt)
+
+	rajDigest := digests[1]
+	assert.Equal(t, "Raj Patel", rajDigest.Recipient.Label())
+	assert.Empty(t, rajDigest.Overdue, "completed tasks are not overdue")
+	require.Len(t, rajDigest.DueSoon, 1)
+	require.Len(t, rajDigest.Rejected, 2)
+	assert.Equal(t, "2025-Q3", rajDigest.Rejected[0].Window)
+	assert.Equal(t, "2025-Q2", rajDigest.Rejected[1].Window)
+	require.Len(t, rajDigest.Assigned, 3, "completed tasks are not listed")
+	assert.Equal(t, TaskSummary{TaskRef: "ET-0002", TaskName: "Vulnerability Scans", State: "no_evidence", DueDate: tasks[1].NextDue}, rajDigest.Assigned[0])
+	assert.Equal(t, "ET-0003", rajDigest.Assigned[1].TaskRef)
+	assert.Equal(t, "generated", rajDigest.Assigned[1].State)
+	assert.Equal(t, "ET-0006", rajDigest.Assigned[2].TaskRef, "undated tasks are listed last")
+	assert.Equal(t, "rejected", rajDigest.Assigned[2].State)
+
+	unassigned := digests[2]
+	assert.True(t, unassigned.Recipient.IsUnassigned())
+	assert.Equal(t, "Unassigned", unassigned.Recipient.Label())
+	assert.Equal(t, 1, unassigned.Total())
+}
+
+func TestBuildDigests_Assignee(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	tasks := []domain.EvidenceTask{
+		{ReferenceID: "ET-0001", NextDue: dueIn(now, -1), Assignees: []domain.Person{{Name: "Jane Doe", Email: "jane@example.com"}}},
+		{ReferenceID: "ET-0002", NextDue: dueIn(now, -1), Assignees: []domain.Person{{Email: "raj@example.com"}}},
+		{ReferenceID: "ET-0003", NextDue: dueIn(now, -1)},
+	}
+
+	digests := BuildDigests(tasks, nil, Options{Now: now, DueSoonDays: 7, Assignee: "JANE@example.com"})
+	require.Len(t, digests, 1)
+	assert.Equal(t, "ET-0001", digests[0].Overdue[0].TaskRef)
+
+	assert.Empty(t, BuildDigests(tasks, nil, Options{Now: now, Assignee: "nobody@example.com"}))
+}
+
+func TestItemStatus(t *testing.T) {
+	t.Parallel()
+
+	due := time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		item Item
+		want string
+	}{
+		"overdue":       {Item{Category: CategoryOverdue, DueDate: &due, DaysUntil: -4}, "due 2025-11-20 (4 days overdue)"},
+		"overdue today": {Item{Category: CategoryOverdue, DueDate: &due}, "due 2025-11-20 (overdue)"},
+		"due soon":      {Item{Category: CategoryDueSoon, DueDate: &due, DaysUntil: 3}, "due 2025-11-20 (in 3 days)"},
+		"due today":     {Item{Category: CategoryDueSoon, DueDate: &due}, "due 2025-11-20 (today)"},
+		"rejected":      {Item{Category: CategoryRejected, Window: "2025-Q3"}, "2025-Q3 evidence rejected"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, ItemStatus(tt.item))
+		})
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+)
+
+//go:embed templates/email-digest.tmpl
+var defaultEmailBody string
+
+const defaultEmailSubject = `[grctool] {{len .Overdue}} overdue, {{len .DueSoon}} due soon, {{len .Rejected}} rejected evidence tasks`
+
+// SendMailFunc delivers a message over SMTP; it has the signature of smtp.SendMail
+type SendMailFunc func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+
+// EmailNotifier sends digests to assignees by email over SMTP
+type EmailNotifier struct {
+	addr         string
+	auth         smtp.Auth
+	from         mail.Address
+	unassignedTo []string
+	subject      *template.Template
+	body         *template.Template
+	sendMail     SendMailFunc
+}
+
+// NewEmailNotifier creates an email notifier and parses its templates. The
+// digest for unassigned tasks goes to cfg.UnassignedTo. sendMail defaults to
+// smtp.SendMail, which upgrades to TLS when the server supports STARTTLS.
+func NewEmailNotifier(cfg config.EmailNotifyConfig, sendMail SendMailFunc) (*EmailNotifier, error) {
+	if cfg.Host == "" {
+		return nil, fmt.Errorf("notifications.email.host is required")
+	}
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid notifications.email.from %q: %w", cfg.From, err)
+	}
+
+	subjectText := cfg.Subject
+	if subjectText == "" {
+		subjectText = defaultEmailSubject
+	}
+	subject, err := template.New("subject").Funcs(templateFuncs).Parse(subjectText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid email subject template: %w", err)
+	}
+
+	bodyText := defaultEmailBody
+	if cfg.BodyTemplate != "" {
+		content, err := os.ReadFile(cfg.BodyTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read email body template: %w", err)
+		}
+		bodyText = string(content)
+	}
+	body, err := template.New("body").Funcs(templateFuncs).Parse(bodyText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid email body template: %w", err)
+	}
+
+	port := cfg.Port
+	if port <= 0 {
+		port = 587
+	}
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	if sendMail == nil {
+		sendMail = smtp.SendMail
+	}
+
+	return &EmailNotifier{
+		addr:         net.JoinHostPort(cfg.Host, strconv.Itoa(port)),
+		auth:         auth,
+		from:         *from,
+		unassignedTo: cfg.UnassignedTo,
+		subject:      subject,
+		body:         body,
+		sendMail:     sendMail,
+	}, nil
+}
+
+// Name returns the notifier name
+func (e *EmailNotifier) Name() string {
+	return "email"
+}
+
+// Send renders the digest and mails it to the recipient
+func (e *EmailNotifier) Send(ctx context.Context, digest Digest) error {
+	to := e.recipients(digest.Recipient)
+	if len(to) == 0 {
+		return ErrNoRoute
+	}
+	for _, address := range to {
+		if _, err := mail.ParseAddress(address); err != nil {
+			return fmt.Errorf("invalid email address %q: %w", address, err)
+		}
+	}
+
+	subject, body, err := e.Render(digest)
+	if err != nil {
+		return err
+	}
+	msg, err := e.buildMessage(to, subject, body, digest.GeneratedAt)
+	if err != nil {
+		return err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := e.sendMail(e.addr, e.auth, e.from.Address, to, msg); err != nil {
+		return fmt.Errorf("failed to send email to %s: %w", strings.Join(to, ", "), err)
+	}
+	return nil
+}
+
+// Render executes the subject and body templates for a digest
+func (e *EmailNotifier) Render(digest Digest) (subject, body string, err error) {
+	var buf bytes.Buffer
+	if err := e.subject.Execute(&buf, digest); err != nil {
+		return "", "", fmt.Errorf("failed to render email subject: %w", err)
+	}
+	// Subjects are a single header line
+	subject = strings.Join(strings.Fields(buf.String()), " ")
+
+	buf.Reset()
+	if err := e.body.Execute(&buf, digest); err != nil {
+		return "", "", fmt.Errorf("failed to render email body: %w", err)
+	}
+	return subject, buf.String(), nil
+}
+
+func (e *EmailNotifier) recipients(recipient Recipient) []string {
+	if recipient.IsUnassigned() {
+		return e.unassignedTo
+	}
+	if recipient.Email == "" {
+		return nil
+	}
+	return []string{recipient.Email}
+}
+
+// buildMessage assembles an RFC 5322 plain-text message
+func (e *EmailNotifier) buildMessage(to []string, subject, body string, date time.Time) ([]byte, error) {
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	var msg bytes.Buffer
+	headers := [][2]string{
+		{"From", e.from.String()},
+		{"To", strings.Join(to, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", subject)},
+		{"Date", date.Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/plain; charset=utf-8"},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+	}
+	for _, header := range headers {
+		fmt.Fprintf(&msg, "%s: %s\r\n", header[0], header[1])
+	}
+	msg.WriteString("\r\n")
+
+	qp := quotedprintable.NewWriter(&msg)
+	body = strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n")
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return nil, fmt.Errorf("failed to encode email body: %w", err)
+	}
+	if err := qp.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode email body: %w", err)
+	}
+	return msg.Bytes(), nil
+}
+
+// templateFuncs are available to subject and body templates
+var templateFuncs = template.FuncMap{
+	"status": ItemStatus,
+	"date": func(value any) string {
+		switch t := value.(type) {
+		case time.Time:
+			return t.Format("2006-01-02")
+		case *time.Time:
+			if t != nil {
+				return t.Format("2006-01-02")
+			}
+		}
+		return ""
+	},
+}
@@ -0,0 +1,199 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package notify
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sentMail struct {
+	addr string
+	auth smtp.Auth
+	from string
+	to   []string
+	msg  []byte
+}
+
+func recordMail(sent *[]sentMail, err error) SendMailFunc {
+	return func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+		*sent = append(*sent, sentMail{addr: addr, auth: auth, from: from, to: to, msg: msg})
+		return err
+	}
+}
+
+func emailConfig() config.EmailNotifyConfig {
+	return config.EmailNotifyConfig{
+		Enabled:      true,
+		Host:         "smtp.example.com",
+		Username:     "grctool@example.com",
+		Password:     "secret",
+		From:         "GRCTool <grctool@example.com>",
+		UnassignedTo: []string{"compliance@example.com"},
+	}
+}
+
+func emailDigest(recipient Recipient) Digest {
+	digest := sampleDigest(recipient)
+	due := time.Date(2025, 12, 15, 0, 0, 0, 0, time.UTC)
+	digest.GeneratedAt = time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC)
+	digest.Assigned = []TaskSummary{
+		{TaskRef: "ET-0001", TaskName: "Access Review", State: "generated", DueDate: digest.Overdue[0].DueDate},
+		{TaskRef: "ET-0009", TaskName: "Pen Test Report", State: "no_evidence", DueDate: &due},
+		{TaskRef: "ET-0006", TaskName: "Change Management", State: "rejected"},
+	}
+	return digest
+}
+
+func TestEmailNotifier_Send(t *testing.T) {
+	t.Parallel()
+
+	var sent []sentMail
+	notifier, err := NewEmailNotifier(emailConfig(), recordMail(&sent, nil))
+	require.NoError(t, err)
+
+	require.NoError(t, notifier.Send(context.Background(), emailDigest(Recipient{Name: "Jane Doe", Email: "jane@example.com"})))
+	require.Len(t, sent, 1)
+	assert.Equal(t, "smtp.example.com:587", sent[0].addr)
+	assert.NotNil(t, sent[0].auth)
+	assert.Equal(t, "grctool@example.com", sent[0].from)
+	assert.Equal(t, []string{"jane@example.com"}, sent[0].to)
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(sent[0].msg)))
+	require.NoError(t, err)
+	assert.Equal(t, `"GRCTool" <grctool@example.com>`, msg.Header.Get("From"))
+	assert.Equal(t, "jane@example.com", msg.Header.Get("To"))
+	assert.Equal(t, "[grctool] 1 overdue, 0 due soon, 1 rejected evidence tasks", msg.Header.Get("Subject"))
+	assert.Equal(t, "quoted-printable", msg.Header.Get("Content-Transfer-Encoding"))
+
+	body, err := io.ReadAll(msg.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "Hello Jane Doe,")
+	assert.Contains(t, string(body), "OVERDUE")
+	assert.NotContains(t, string(body), "DUE WITHIN", "empty sections are omitted")
+	assert.Contains(t, string(body), "ET-0006 Change Management [rejected]\r\n")
+}
+
+func TestEmailNotifier_Routing(t *testing.T) {
+	t.Parallel()
+
+	var sent []sentMail
+	notifier, err := NewEmailNotifier(emailConfig(), recordMail(&sent, nil))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, notifier.Send(ctx, emailDigest(Recipient{})))
+	require.Len(t, sent, 1)
+	assert.Equal(t, []string{"compliance@example.com"}, sent[0].to)
+
+	assert.ErrorIs(t, notifier.Send(ctx, emailDigest(Recipient{Name: "Raj Patel"})), ErrNoRoute)
+
+	err = notifier.Send(ctx, emailDigest(Recipient{Email: "raj@example.com\r\nBcc: all@example.com"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid email address")
+	assert.Len(t, sent, 1)
+
+	failing, err := NewEmailNotifier(emailConfig(), recordMail(&sent, errors.New("535 authentication failed")))
+	require.NoError(t, err)
+	err = failing.Send(ctx, emailDigest(Recipient{Email: "raj@example.com"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to send email to raj@example.com: 535 authentication failed")
+}
+
+func TestEmailNotifier_Render(t *testing.T) {
+	t.Parallel()
+
+	notifier, err := NewEmailNotifier(emailConfig(), nil)
+	require.NoError(t, err)
+
+	subject, body, err := notifier.Render(emailDigest(Recipient{Name: "Jane Doe", Email: "jane@example.com"}))
+	require.NoError(t, err)
+	assert.Equal(t, "[grctool] 1 overdue, 0 due soon, 1 rejected evidence tasks", subject)
+
+	want := `Hello Jane Doe,
+
+The following evidence tasks assigned to you need attention.
+
+OVERDUE
+  - ET-0001 Access Review <admins & owners>: due 2025-11-01 (2 days overdue)
+    https://app.tugboatlogic.com/org/1/evidence/tasks/1
+
+REJECTED
+  - ET-0006 Change Management: 2025-Q3 evidence rejected
+
+ALL OPEN TASKS
+  - ET-0001 Access Review [generated] due 2025-11-01
+  - ET-0009 Pen Test Report [no_evidence] due 2025-12-15
+  - ET-0006 Change Management [rejected]
+
+--
+Sent by grctool on 2025-11-03
+`
+	assert.Equal(t, want, body)
+}
+
+func TestEmailNotifier_CustomTemplates(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	bodyFile := filepath.Join(dir, "digest.tmpl")
+	require.NoError(t, os.WriteFile(bodyFile, []byte(`{{range .Assigned}}{{.TaskRef}}={{.State}};{{end}}`), 0644))
+
+	cfg := emailConfig()
+	cfg.Port = 2525
+	cfg.Username = ""
+	cfg.Subject = "Evidence for {{.Recipient.Label}}\n({{.Total}} items)"
+	cfg.BodyTemplate = bodyFile
+
+	var sent []sentMail
+	notifier, err := NewEmailNotifier(cfg, recordMail(&sent, nil))
+	require.NoError(t, err)
+
+	subject, body, err := notifier.Render(emailDigest(Recipient{Name: "Jane Doe", Email: "jane@example.com"}))
+	require.NoError(t, err)
+	assert.Equal(t, "Evidence for Jane Doe (2 items)", subject)
+	assert.Equal(t, "ET-0001=generated;ET-0009=no_evidence;ET-0006=rejected;", body)
+
+	require.NoError(t, notifier.Send(context.Background(), emailDigest(Recipient{Email: "jane@example.com"})))
+	assert.Equal(t, "smtp.example.com:2525", sent[0].addr)
+	assert.Nil(t, sent[0].auth, "no authentication without a username")
+}
+
+func TestNewEmailNotifier_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		mutate  func(*config.EmailNotifyConfig)
+		wantErr string
+	}{
+		"missing host":     {func(c *config.EmailNotifyConfig) { c.Host = "" }, "notifications.email.host is required"},
+		"invalid from":     {func(c *config.EmailNotifyConfig) { c.From = "not an address" }, "invalid notifications.email.from"},
+		"bad subject":      {func(c *config.EmailNotifyConfig) { c.Subject = "{{.Nope" }, "invalid email subject template"},
+		"missing template": {func(c *config.EmailNotifyConfig) { c.BodyTemplate = "/nonexistent/digest.tmpl" }, "failed to read email body template"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := emailConfig()
+			tt.mutate(&cfg)
+			_, err := NewEmailNotifier(cfg, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoRoute indicates a notifier has no destination for a recipient
+var ErrNoRoute = errors.New("no destination configured for recipient")
+
+// Notifier delivers digests through one notification channel
+type Notifier interface {
+	// Name identifies the channel, e.g. "slack"
+	Name() string
+
+	// Send delivers a digest; it returns ErrNoRoute when the recipient has no destination
+	Send(ctx context.Context, digest Digest) error
+}
+
+// Delivery is the outcome of sending one digest through one notifier
+type Delivery struct {
+	Notifier  string    `json:"notifier"`
+	Recipient Recipient `json:"recipient"`
+	Items     int       `json:"items"`
+	Skipped   bool      `json:"skipped,omitempty"` // No destination for the recipient
+	Error     string    `json:"error,omitempty"`
+}
+
+// Dispatch sends every non-empty digest through every notifier and reports
+// each delivery. A failed delivery does not stop the others.
+func Dispatch(ctx context.Context, notifiers []Notifier, digests []Digest) []Delivery {
+	var deliveries []Delivery
+	for _, digest := range digests {
+		if digest.Total() == 0 {
+			continue
+		}
+		for _, notifier := range notifiers {
+			delivery := Delivery{Notifier: notifier.Name(), Recipient: digest.Recipient, Items: digest.Total()}
+			if err := notifier.Send(ctx, digest); errors.Is(err, ErrNoRoute) {
+				delivery.Skipped = true
+			} else if err != nil {
+				delivery.Error = err.Error()
+			}
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return deliveries
+}
@@ -0,0 +1,170 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+)
+
+// SlackNotifier posts digests to Slack incoming webhooks
+type SlackNotifier struct {
+	webhookURL string
+	channels   map[string]string // Lowercased assignee email -> webhook URL
+	client     *http.Client
+}
+
+// NewSlackNotifier creates a Slack notifier. Digests go to the assignee's
+// channel webhook when configured, otherwise to the default webhook.
+func NewSlackNotifier(cfg config.SlackNotifyConfig, client *http.Client) *SlackNotifier {
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	channels := make(map[string]string, len(cfg.Channels))
+	for assignee, webhook := range cfg.Channels {
+		if webhook != "" {
+			channels[strings.ToLower(assignee)] = webhook
+		}
+	}
+	return &SlackNotifier{
+		webhookURL: cfg.WebhookURL,
+		channels:   channels,
+		client:     client,
+	}
+}
+
+// Name returns the notifier name
+func (s *SlackNotifier) Name() string {
+	return "slack"
+}
+
+// Send posts the digest to the recipient's channel
+func (s *SlackNotifier) Send(ctx context.Context, digest Digest) error {
+	webhook := s.route(digest.Recipient)
+	if webhook == "" {
+		return ErrNoRoute
+	}
+
+	body, err := json.Marshal(BuildSlackMessage(digest))
+	if err != nil {
+		return fmt.Errorf("failed to encode Slack message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create Slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to Slack: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("slack webhook returned %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+func (s *SlackNotifier) route(recipient Recipient) string {
+	if webhook, ok := s.channels[strings.ToLower(recipient.Email)]; ok && recipient.Email != "" {
+		return webhook
+	}
+	return s.webhookURL
+}
+
+// SlackMessage is an incoming webhook payload
+type SlackMessage struct {
+	Text   string       `json:"text"` // Fallback for notifications
+	Blocks []SlackBlock `json:"blocks,omitempty"`
+}
+
+// SlackBlock is a Block Kit layout block
+type SlackBlock struct {
+	Type     string      `json:"type"`
+	Text     *SlackText  `json:"text,omitempty"`
+	Elements []SlackText `json:"elements,omitempty"`
+}
+
+// SlackText is a Block Kit text object
+type SlackText struct {
+	Type string `json:"type"` // plain_text or mrkdwn
+	Text string `json:"text"`
+}
+
+// BuildSlackMessage renders a digest as a Block Kit message with one section
+// per category
+func BuildSlackMessage(digest Digest) SlackMessage {
+	title := fmt.Sprintf("Evidence digest for %s", digest.Recipient.Label())
+	summary := fmt.Sprintf("%d overdue, %d due within %d days, %d rejected",
+		len(digest.Overdue), len(digest.DueSoon), digest.DueSoonDays, len(digest.Rejected))
+
+	msg := SlackMessage{
+		Text: title + ": " + summary,
+		Blocks: []SlackBlock{
+			{Type: "header", Text: &SlackText{Type: "plain_text", Text: title}},
+			{Type: "context", Elements: []SlackText{{Type: "mrkdwn", Text: summary}}},
+		},
+	}
+
+	sections := []struct {
+		heading string
+		items   []Item
+	}{
+		{":rotating_light: *Overdue*", digest.Overdue},
+		{":hourglass_flowing_sand: *Due soon*", digest.DueSoon},
+		{":x: *Rejected*", digest.Rejected},
+	}
+	for _, section := range sections {
+		if len(section.items) == 0 {
+			continue
+		}
+		lines := []string{section.heading}
+		for _, item := range section.items {
+			lines = append(lines, "• "+slackItemLine(item))
+		}
+		msg.Blocks = append(msg.Blocks, SlackBlock{
+			Type: "section",
+			Text: &SlackText{Type: "mrkdwn", Text: strings.Join(lines, "\n")},
+		})
+	}
+	return msg
+}
+
+func slackItemLine(item Item) string {
+	ref := "*" + slackEscape(item.TaskRef) + "*"
+	if item.URL != "" {
+		ref = fmt.Sprintf("<%s|*%s*>", item.URL, slackEscape(item.TaskRef))
+	}
+	line := ref + " " + slackEscape(item.TaskName)
+	return line + " — " + ItemStatus(item)
+}
+
+// slackEscape escapes the control characters of Slack mrkdwn
+func slackEscape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
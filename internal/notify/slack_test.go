@@ -0,0 +1,144 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleDigest(recipient Recipient) Digest {
+	due := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	return Digest{
+		Recipient:   recipient,
+		DueSoonDays: 7,
+		Overdue: []Item{{
+			TaskRef:   "ET-0001",
+			TaskName:  "Access Review <admins & owners>",
+			Category:  CategoryOverdue,
+			DueDate:   &due,
+			DaysUntil: -2,
+			URL:       "https://app.tugboatlogic.com/org/1/evidence/tasks/1",
+		}},
+		Rejected: []Item{{TaskRef: "ET-0006", TaskName: "Change Management", Category: CategoryRejected, Window: "2025-Q3"}},
+	}
+}
+
+func TestBuildSlackMessage(t *testing.T) {
+	t.Parallel()
+
+	msg := BuildSlackMessage(sampleDigest(Recipient{Name: "Jane Doe", Email: "jane@example.com"}))
+
+	assert.Equal(t, "Evidence digest for Jane Doe: 1 overdue, 0 due within 7 days, 1 rejected", msg.Text)
+	require.Len(t, msg.Blocks, 4)
+	assert.Equal(t, "header", msg.Blocks[0].Type)
+	assert.Equal(t, "context", msg.Blocks[1].Type)
+
+	overdue := msg.Blocks[2].Text.Text
+	assert.Contains(t, overdue, "*Overdue*")
+	assert.Contains(t, overdue, "<https://app.tugboatlogic.com/org/1/evidence/tasks/1|*ET-0001*>")
+	assert.Contains(t, overdue, "Access Review &lt;admins &amp; owners&gt; — due 2025-11-01 (2 days overdue)")
+	assert.Contains(t, msg.Blocks[3].Text.Text, "*ET-0006* Change Management — 2025-Q3 evidence rejected")
+}
+
+func TestSlackNotifier_Send(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	received := make(map[string]SlackMessage)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var msg SlackMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		mu.Lock()
+		received[r.URL.Path] = msg
+		mu.Unlock()
+
+		if r.URL.Path == "/broken" {
+			http.Error(w, "invalid_token", http.StatusForbidden)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	notifier := NewSlackNotifier(config.SlackNotifyConfig{
+		Enabled:    true,
+		WebhookURL: server.URL + "/default",
+		Channels: map[string]string{
+			"Jane@Example.com": server.URL + "/security",
+			"raj@example.com":  server.URL + "/broken",
+		},
+	}, server.Client())
+
+	ctx := context.Background()
+	require.NoError(t, notifier.Send(ctx, sampleDigest(Recipient{Name: "Jane Doe", Email: "jane@example.com"})))
+	require.NoError(t, notifier.Send(ctx, sampleDigest(Recipient{})))
+
+	err := notifier.Send(ctx, sampleDigest(Recipient{Email: "raj@example.com"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "403 Forbidden: invalid_token")
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Contains(t, received["/security"].Text, "Jane Doe")
+	assert.Contains(t, received["/default"].Text, "Unassigned")
+}
+
+func TestSlackNotifier_NoRoute(t *testing.T) {
+	t.Parallel()
+
+	notifier := NewSlackNotifier(config.SlackNotifyConfig{
+		Channels: map[string]string{"jane@example.com": "https://hooks.slack.com/services/T/B/X"},
+	}, nil)
+
+	err := notifier.Send(context.Background(), sampleDigest(Recipient{Email: "raj@example.com"}))
+	assert.True(t, errors.Is(err, ErrNoRoute))
+}
+
+type recordingNotifier struct {
+	sent []Recipient
+	err  error
+}
+
+func (r *recordingNotifier) Name() string { return "recording" }
+
+func (r *recordingNotifier) Send(ctx context.Context, digest Digest) error {
+	r.sent = append(r.sent, digest.Recipient)
+	return r.err
+}
+
+func TestDispatch(t *testing.T) {
+	t.Parallel()
+
+	ok := &recordingNotifier{}
+	failing := &recordingNotifier{err: errors.New("smtp unavailable")}
+	unrouted := &recordingNotifier{err: ErrNoRoute}
+
+	digests := []Digest{
+		sampleDigest(Recipient{Email: "jane@example.com"}),
+		{Recipient: Recipient{Email: "empty@example.com"}},
+	}
+
+	deliveries := Dispatch(context.Background(), []Notifier{ok, failing, unrouted}, digests)
+	require.Len(t, deliveries, 3, "empty digests are not sent")
+	assert.Equal(t, 2, deliveries[0].Items)
+	assert.Empty(t, deliveries[0].Error)
+	assert.Equal(t, "smtp unavailable", deliveries[1].Error)
+	assert.True(t, deliveries[2].Skipped)
+	assert.Empty(t, deliveries[2].Error)
+	assert.Len(t, ok.sent, 1)
+}
@@ -0,0 +1,159 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package playbook runs declarative tool pipelines: YAML files listing the
+// tools to run for each evidence task, in order, with parameters that refer
+// to the task, the collection window and the output of earlier steps.
+package playbook
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TaskRefVariable holds the reference of the evidence task a playbook's
+// steps are running for
+const TaskRefVariable = "task.ref"
+
+// Playbook chains tool runs: each step runs a tool whose parameters may
+// refer to {{task.ref}}, {{window}} and {{steps.<id>.output}} of earlier
+// steps. The steps run once for each of the playbook's tasks.
+type Playbook struct {
+	Name        string   `yaml:"name" json:"name"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Tasks       []string `yaml:"tasks,omitempty" json:"tasks,omitempty"` // Evidence tasks run when none are given
+	Steps       []Step   `yaml:"steps" json:"steps"`
+}
+
+// Step is a tool run of a playbook
+type Step struct {
+	ID     string                 `yaml:"id" json:"id"`
+	Tool   string                 `yaml:"tool" json:"tool"`
+	Params map[string]interface{} `yaml:"params,omitempty" json:"params,omitempty"`
+}
+
+// stepID is the form of step IDs, which appear in {{steps.<id>.output}}
+var stepID = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// stepReference matches the steps a parameter refers to
+var stepReference = regexp.MustCompile(`\{\{[^}]*\bsteps\.([A-Za-z0-9_-]+)\.output\b[^}]*\}\}`)
+
+// OutputVariable returns the variable holding the output of a step
+func OutputVariable(id string) string {
+	return "steps." + id + ".output"
+}
+
+// Load reads and validates the playbook at path. A playbook without a name
+// is named after its file.
+func Load(path string) (*Playbook, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read playbook %s: %w", path, err)
+	}
+
+	var playbook Playbook
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&playbook); err != nil {
+		return nil, fmt.Errorf("failed to parse playbook %s: %w", path, err)
+	}
+	if strings.TrimSpace(playbook.Name) == "" {
+		playbook.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if err := playbook.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid playbook %s: %w", path, err)
+	}
+	return &playbook, nil
+}
+
+// Validate checks that the playbook has steps, that step IDs are unique and
+// that steps only use the output of steps before them. Whether the tools
+// exist and the parameters interpolate is checked when the playbook runs.
+func (p *Playbook) Validate() error {
+	if len(p.Steps) == 0 {
+		return fmt.Errorf("playbook %s has no steps", p.Name)
+	}
+
+	seen := make(map[string]bool, len(p.Steps))
+	for i, step := range p.Steps {
+		if step.ID == "" {
+			return fmt.Errorf("step %d has no id", i+1)
+		}
+		if !stepID.MatchString(step.ID) {
+			return fmt.Errorf("step id %q must be letters, digits, dashes and underscores", step.ID)
+		}
+		if seen[step.ID] {
+			return fmt.Errorf("step %s is defined more than once", step.ID)
+		}
+		if step.Tool == "" {
+			return fmt.Errorf("step %s has no tool", step.ID)
+		}
+		for _, ref := range references(step.Params) {
+			if ref == step.ID {
+				return fmt.Errorf("step %s refers to its own output", step.ID)
+			}
+			if !seen[ref] && p.hasStep(ref) {
+				return fmt.Errorf("step %s refers to the output of step %s, which runs after it", step.ID, ref)
+			}
+		}
+		seen[step.ID] = true
+	}
+	return nil
+}
+
+// hasStep reports whether the playbook has a step with the ID
+func (p *Playbook) hasStep(id string) bool {
+	for _, step := range p.Steps {
+		if step.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// references returns the IDs of the steps whose output a parameter value
+// refers to
+func references(value interface{}) []string {
+	var ids []string
+	walkStrings(value, func(s string) {
+		for _, match := range stepReference.FindAllStringSubmatch(s, -1) {
+			ids = append(ids, match[1])
+		}
+	})
+	return ids
+}
+
+// walkStrings calls fn with each string of a parameter value, including
+// those nested in lists and maps
+func walkStrings(value interface{}, fn func(string)) {
+	switch v := value.(type) {
+	case string:
+		fn(v)
+	case []interface{}:
+		for _, item := range v {
+			walkStrings(item, fn)
+		}
+	case map[string]interface{}:
+		for _, item := range v {
+			walkStrings(item, fn)
+		}
+	}
+}
@@ -0,0 +1,310 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package playbook
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grctool/grctool/internal/interpolation"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/testhelpers"
+	"github.com/grctool/grctool/internal/tools"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTool records its runs and answers with run
+type fakeTool struct {
+	name       string
+	properties map[string]interface{}
+	run        func(params map[string]interface{}) (string, error)
+	calls      []map[string]interface{}
+}
+
+func (f *fakeTool) Name() string        { return f.name }
+func (f *fakeTool) Description() string { return "fake " + f.name }
+
+func (f *fakeTool) GetClaudeToolDefinition() models.ClaudeTool {
+	return models.ClaudeTool{
+		Name:        f.name,
+		Description: f.Description(),
+		InputSchema: map[string]interface{}{
+			"type":                 "object",
+			"properties":           f.properties,
+			"additionalProperties": false,
+		},
+	}
+}
+
+func (f *fakeTool) Execute(ctx context.Context, params map[string]interface{}) (string, *models.EvidenceSource, error) {
+	f.calls = append(f.calls, params)
+	output, err := f.run(params)
+	return output, nil, err
+}
+
+// newTestRunner returns a runner over a registry of a collecting tool and
+// a writing tool, and the two tools
+func newTestRunner(t *testing.T, collect func(params map[string]interface{}) (string, error)) (*Runner, *fakeTool, *fakeTool) {
+	t.Helper()
+	collector := &fakeTool{
+		name: "collect",
+		properties: map[string]interface{}{
+			"repository": map[string]interface{}{"type": "string"},
+			"format":     map[string]interface{}{"type": "string", "enum": []string{"markdown", "csv"}},
+		},
+		run: collect,
+	}
+	writer := &fakeTool{
+		name: "write",
+		properties: map[string]interface{}{
+			"task_ref": map[string]interface{}{"type": "string"},
+			"window":   map[string]interface{}{"type": "string"},
+			"title":    map[string]interface{}{"type": "string"},
+			"content":  map[string]interface{}{"type": "string"},
+			"controls": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+		},
+		run: func(params map[string]interface{}) (string, error) { return "written", nil },
+	}
+
+	registry := tools.NewRegistry()
+	require.NoError(t, registry.Register(collector))
+	require.NoError(t, registry.Register(writer))
+
+	interpolator := interpolation.NewStandardInterpolator(interpolation.InterpolatorConfig{
+		Variables:     map[string]string{"organization.name": "Acme"},
+		Enabled:       true,
+		TaskVariables: map[string]map[string]string{"ET-0002": {"github.repository": "acme/api"}},
+		ParseWindow:   tools.ParseEvidenceWindow,
+	})
+	return NewRunner(registry, interpolator, testhelpers.NewStubLogger()), collector, writer
+}
+
+// accessReview collects for a repository and writes the result as evidence
+func accessReview() *Playbook {
+	return &Playbook{
+		Name:  "access-review",
+		Tasks: []string{"ET-0001"},
+		Steps: []Step{
+			{ID: "permissions", Tool: "collect", Params: map[string]interface{}{"repository": "acme/platform", "format": "markdown"}},
+			{ID: "write", Tool: "write", Params: map[string]interface{}{
+				"title":    "{{organization.name}} access review for {{windowStart}}",
+				"content":  "{{steps.permissions.output}}",
+				"controls": []interface{}{"CC6.1", "{{task.ref}}"},
+			}},
+		},
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "access-review.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+tasks: [ET-0001]
+steps:
+  - id: permissions
+    tool: github-permissions
+    params:
+      repository: acme/platform
+      include_org_members: true
+  - id: write
+    tool: evidence-writer
+    params:
+      content: "{{steps.permissions.output}}"
+`), 0o644))
+
+	playbook, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "access-review", playbook.Name)
+	assert.Equal(t, []string{"ET-0001"}, playbook.Tasks)
+	require.Len(t, playbook.Steps, 2)
+	assert.Equal(t, true, playbook.Steps[0].Params["include_org_members"])
+	assert.Equal(t, "evidence-writer", playbook.Steps[1].Tool)
+
+	misspelled := filepath.Join(dir, "misspelled.yaml")
+	require.NoError(t, os.WriteFile(misspelled, []byte("steps:\n  - id: a\n    tool: t\n    parmas: {}\n"), 0o644))
+	_, err = Load(misspelled)
+	assert.ErrorContains(t, err, "parmas")
+
+	_, err = Load(filepath.Join(dir, "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestPlaybook_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		steps   []Step
+		wantErr string
+	}{
+		"valid": {
+			steps: []Step{
+				{ID: "collect", Tool: "a"},
+				{ID: "write", Tool: "b", Params: map[string]interface{}{"content": "{{upper steps.collect.output}}"}},
+			},
+		},
+		"no steps":  {wantErr: "has no steps"},
+		"no id":     {steps: []Step{{Tool: "a"}}, wantErr: "step 1 has no id"},
+		"bad id":    {steps: []Step{{ID: "my step", Tool: "a"}}, wantErr: "must be letters"},
+		"duplicate": {steps: []Step{{ID: "a", Tool: "a"}, {ID: "a", Tool: "b"}}, wantErr: "more than once"},
+		"no tool":   {steps: []Step{{ID: "a"}}, wantErr: "step a has no tool"},
+		"own output": {
+			steps:   []Step{{ID: "a", Tool: "a", Params: map[string]interface{}{"x": "{{steps.a.output}}"}}},
+			wantErr: "refers to its own output",
+		},
+		"later output": {
+			steps: []Step{
+				{ID: "write", Tool: "b", Params: map[string]interface{}{"nested": map[string]interface{}{"x": "{{steps.collect.output}}"}}},
+				{ID: "collect", Tool: "a"},
+			},
+			wantErr: "step write refers to the output of step collect, which runs after it",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := (&Playbook{Name: "test", Steps: tc.steps}).Validate()
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestRunner_Run(t *testing.T) {
+	t.Parallel()
+
+	runner, collector, writer := newTestRunner(t, func(params map[string]interface{}) (string, error) {
+		return fmt.Sprintf("# Permissions of %s", params["repository"]), nil
+	})
+
+	result, err := runner.Run(context.Background(), accessReview(), nil, "2025-Q4")
+	require.NoError(t, err)
+
+	assert.Equal(t, "access-review", result.Playbook)
+	assert.Equal(t, 0, result.Failed)
+	require.Len(t, result.Tasks, 1)
+	assert.Equal(t, "ET-0001", result.Tasks[0].TaskRef)
+	assert.Equal(t, StatusSucceeded, result.Tasks[0].Status)
+	require.Len(t, result.Tasks[0].Steps, 2)
+	assert.Equal(t, len("# Permissions of acme/platform"), result.Tasks[0].Steps[0].OutputBytes)
+
+	require.Len(t, collector.calls, 1)
+	assert.NotContains(t, collector.calls[0], "task_ref", "tools without task_ref get none")
+	require.Len(t, writer.calls, 1)
+	assert.Equal(t, map[string]interface{}{
+		"task_ref": "ET-0001",
+		"window":   "2025-Q4",
+		"title":    "Acme access review for 2025-10-01",
+		"content":  "# Permissions of acme/platform",
+		"controls": []interface{}{"CC6.1", "ET-0001"},
+	}, writer.calls[0])
+}
+
+func TestRunner_Run_TaskVariables(t *testing.T) {
+	t.Parallel()
+
+	runner, collector, _ := newTestRunner(t, func(params map[string]interface{}) (string, error) { return "ok", nil })
+	playbook := &Playbook{
+		Name:  "repositories",
+		Steps: []Step{{ID: "permissions", Tool: "collect", Params: map[string]interface{}{"repository": "{{github.repository}}"}}},
+	}
+
+	_, err := runner.Run(context.Background(), playbook, []string{"ET-0002"}, "2025-Q4")
+	require.NoError(t, err)
+	require.Len(t, collector.calls, 1)
+	assert.Equal(t, "acme/api", collector.calls[0]["repository"])
+
+	_, err = runner.Run(context.Background(), playbook, []string{"ET-0001", "ET-0002"}, "2025-Q4")
+	assert.ErrorContains(t, err, "step permissions, param repository: missing variable: github.repository")
+	assert.Len(t, collector.calls, 1, "no step runs when any task cannot")
+}
+
+func TestRunner_Run_FailedStep(t *testing.T) {
+	t.Parallel()
+
+	runner, _, writer := newTestRunner(t, func(params map[string]interface{}) (string, error) {
+		if params["repository"] == "acme/broken" {
+			return "", fmt.Errorf("repository not found")
+		}
+		return "ok", nil
+	})
+	playbook := accessReview()
+	playbook.Steps[0].Params["repository"] = "{{github.repository}}"
+	interpolator := runner.interpolator
+	interpolator.SetVariable("github.repository", "acme/broken")
+
+	result, err := runner.Run(context.Background(), playbook, []string{"ET-0001", "ET-0002"}, "2025-Q4")
+	assert.EqualError(t, err, "playbook access-review failed for 1 of 2 tasks")
+	require.NotNil(t, result)
+	assert.Equal(t, 1, result.Failed)
+
+	failed := result.Tasks[0]
+	assert.Equal(t, StatusFailed, failed.Status)
+	assert.Equal(t, StatusFailed, failed.Steps[0].Status)
+	assert.Equal(t, "repository not found", failed.Steps[0].Error)
+	assert.Equal(t, StatusSkipped, failed.Steps[1].Status)
+
+	assert.Equal(t, StatusSucceeded, result.Tasks[1].Status, "ET-0002 overrides the repository")
+	require.Len(t, writer.calls, 1)
+	assert.Equal(t, "ET-0002", writer.calls[0]["task_ref"])
+}
+
+func TestRunner_Run_Checks(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		edit    func(p *Playbook)
+		wantErr string
+	}{
+		"misspelled step output": {
+			edit:    func(p *Playbook) { p.Steps[1].Params["content"] = "{{steps.permision.output}}" },
+			wantErr: "step write, param content: missing variable: steps.permision.output (did you mean steps.permissions.output?)",
+		},
+		"misspelled nested variable": {
+			edit:    func(p *Playbook) { p.Steps[1].Params["controls"] = []interface{}{"{{task.rfe}}"} },
+			wantErr: "missing variable: task.rfe (did you mean task.ref?)",
+		},
+		"unknown tool": {
+			edit:    func(p *Playbook) { p.Steps[0].Tool = "colect" },
+			wantErr: "step permissions: unknown tool colect",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			runner, collector, writer := newTestRunner(t, func(params map[string]interface{}) (string, error) { return "ok", nil })
+			playbook := accessReview()
+			tc.edit(playbook)
+
+			result, err := runner.Run(context.Background(), playbook, nil, "2025-Q4")
+			assert.ErrorContains(t, err, tc.wantErr)
+			assert.Nil(t, result)
+			assert.Empty(t, collector.calls)
+			assert.Empty(t, writer.calls)
+		})
+	}
+}
+
+func TestRunner_Run_InvalidParams(t *testing.T) {
+	t.Parallel()
+
+	runner, collector, _ := newTestRunner(t, func(params map[string]interface{}) (string, error) { return "ok", nil })
+	playbook := accessReview()
+	playbook.Steps[0].Params["format"] = "pdf"
+
+	result, err := runner.Run(context.Background(), playbook, nil, "2025-Q4")
+	require.Error(t, err)
+	assert.Empty(t, collector.calls, "the registry rejects parameters outside the tool's schema")
+	assert.Contains(t, result.Tasks[0].Steps[0].Error, "$.format must be one of")
+}
@@ -0,0 +1,274 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package playbook
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/interpolation"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/tools"
+)
+
+// Outcomes of steps and tasks
+const (
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+	StatusSkipped   = "skipped" // Not run because an earlier step of the task failed
+)
+
+// Registry runs tools by name. It is satisfied by *tools.Registry, whose
+// Execute validates parameters and output and uses the result cache.
+type Registry interface {
+	Get(name string) (tools.Tool, error)
+	Execute(ctx context.Context, toolName string, params map[string]interface{}) (string, *models.EvidenceSource, error)
+}
+
+// Result is the outcome of a playbook run
+type Result struct {
+	Playbook string       `json:"playbook"`
+	Window   string       `json:"window"`
+	Tasks    []TaskResult `json:"tasks"`
+	Failed   int          `json:"failed"` // Tasks with a failed step
+}
+
+// TaskResult is the outcome of the steps run for one evidence task
+type TaskResult struct {
+	TaskRef string       `json:"task_ref,omitempty"`
+	Status  string       `json:"status"`
+	Steps   []StepResult `json:"steps"`
+}
+
+// StepResult is the outcome of a step run for one evidence task
+type StepResult struct {
+	ID          string `json:"id"`
+	Tool        string `json:"tool"`
+	Status      string `json:"status"`
+	DurationMS  int64  `json:"duration_ms"`
+	OutputBytes int    `json:"output_bytes"`
+	Error       string `json:"error,omitempty"`
+}
+
+// Runner runs playbooks against a tool registry
+type Runner struct {
+	registry     Registry
+	interpolator *interpolation.StandardInterpolator
+	logger       logger.Logger
+}
+
+// NewRunner creates a runner whose step parameters are interpolated with
+// the variables of interpolator, scoped to each task and the window.
+// Interpolation must be enabled on interpolator.
+func NewRunner(registry Registry, interpolator *interpolation.StandardInterpolator, log logger.Logger) *Runner {
+	return &Runner{registry: registry, interpolator: interpolator, logger: log}
+}
+
+// Run runs the steps of the playbook for each task in turn, or for the
+// playbook's own tasks when none are given. Every step's tool and
+// parameters are checked before anything runs. A failed step skips the
+// rest of its task's steps; the other tasks still run. The error reports
+// the tasks that failed.
+func (r *Runner) Run(ctx context.Context, playbook *Playbook, taskRefs []string, window string) (*Result, error) {
+	if len(taskRefs) == 0 {
+		taskRefs = playbook.Tasks
+	}
+	if len(taskRefs) == 0 {
+		// A playbook without tasks runs its steps once, without {{task.ref}}
+		taskRefs = []string{""}
+	}
+	if err := r.check(playbook, taskRefs, window); err != nil {
+		return nil, err
+	}
+
+	result := &Result{Playbook: playbook.Name, Window: window}
+	for _, taskRef := range taskRefs {
+		if err := ctx.Err(); err != nil {
+			return result, fmt.Errorf("playbook %s cancelled: %w", playbook.Name, err)
+		}
+		task := r.runTask(ctx, playbook, taskRef, window)
+		if task.Status == StatusFailed {
+			result.Failed++
+		}
+		result.Tasks = append(result.Tasks, task)
+	}
+
+	if result.Failed > 0 {
+		return result, fmt.Errorf("playbook %s failed for %d of %d tasks", playbook.Name, result.Failed, len(result.Tasks))
+	}
+	return result, nil
+}
+
+// check reports the steps whose tool is not registered and the parameters
+// that do not interpolate for some task, such as misspelled variables
+func (r *Runner) check(playbook *Playbook, taskRefs []string, window string) error {
+	var problems []string
+	for _, step := range playbook.Steps {
+		if _, err := r.registry.Get(step.Tool); err != nil {
+			problems = append(problems, fmt.Sprintf("step %s: unknown tool %s", step.ID, step.Tool))
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, taskRef := range taskRefs {
+		scoped := r.scope(taskRef, window)
+		for _, step := range playbook.Steps {
+			// Outputs are checked as defined; Validate rejects later steps
+			scoped.SetVariable(OutputVariable(step.ID), "")
+		}
+		for _, step := range playbook.Steps {
+			for _, name := range sortedParams(step.Params) {
+				walkStrings(step.Params[name], func(s string) {
+					for _, problem := range scoped.Check(s) {
+						description := fmt.Sprintf("step %s, param %s: %s", step.ID, name, problem.Message)
+						if problem.Suggestion != "" {
+							description += fmt.Sprintf(" (did you mean %s?)", problem.Suggestion)
+						}
+						if !seen[description] {
+							seen[description] = true
+							problems = append(problems, description)
+						}
+					}
+				})
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("playbook %s cannot run: %s", playbook.Name, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// runTask runs the steps of the playbook for one task
+func (r *Runner) runTask(ctx context.Context, playbook *Playbook, taskRef, window string) TaskResult {
+	task := TaskResult{TaskRef: taskRef, Status: StatusSucceeded}
+	scoped := r.scope(taskRef, window)
+
+	for _, step := range playbook.Steps {
+		if task.Status == StatusFailed {
+			task.Steps = append(task.Steps, StepResult{ID: step.ID, Tool: step.Tool, Status: StatusSkipped})
+			continue
+		}
+
+		start := time.Now()
+		output, err := r.runStep(ctx, scoped, step, taskRef, window)
+		stepResult := StepResult{
+			ID:          step.ID,
+			Tool:        step.Tool,
+			Status:      StatusSucceeded,
+			DurationMS:  time.Since(start).Milliseconds(),
+			OutputBytes: len(output),
+		}
+		if err != nil {
+			stepResult.Status = StatusFailed
+			stepResult.Error = err.Error()
+			task.Status = StatusFailed
+			r.logger.Info("playbook step failed",
+				logger.String("playbook", playbook.Name),
+				logger.String("task_ref", taskRef),
+				logger.String("step", step.ID),
+				logger.Error(err))
+		} else {
+			scoped.SetVariable(OutputVariable(step.ID), output)
+		}
+		task.Steps = append(task.Steps, stepResult)
+	}
+	return task
+}
+
+// runStep interpolates the parameters of a step and runs its tool
+func (r *Runner) runStep(ctx context.Context, scoped *interpolation.StandardInterpolator, step Step, taskRef, window string) (string, error) {
+	params, err := interpolateValue(scoped, step.Params)
+	if err != nil {
+		return "", fmt.Errorf("interpolating parameters: %w", err)
+	}
+	tool, err := r.registry.Get(step.Tool)
+	if err != nil {
+		return "", err
+	}
+	output, _, err := r.registry.Execute(ctx, step.Tool, defaultParams(tool, params.(map[string]interface{}), taskRef, window))
+	return output, err
+}
+
+// scope returns an interpolator with the variables of a task and window,
+// and {{task.ref}}
+func (r *Runner) scope(taskRef, window string) *interpolation.StandardInterpolator {
+	scoped := r.interpolator.WithScope(interpolation.Scope{TaskRef: taskRef, Window: window}).(*interpolation.StandardInterpolator)
+	if taskRef != "" {
+		scoped.SetVariable(TaskRefVariable, taskRef)
+	}
+	return scoped
+}
+
+// defaultParams fills in the task_ref and window parameters a step leaves
+// out, when its tool accepts them
+func defaultParams(tool tools.Tool, params map[string]interface{}, taskRef, window string) map[string]interface{} {
+	properties, _ := tool.GetClaudeToolDefinition().InputSchema["properties"].(map[string]interface{})
+	for name, value := range map[string]string{"task_ref": taskRef, "window": window} {
+		if _, accepted := properties[name]; !accepted || value == "" {
+			continue
+		}
+		if _, set := params[name]; !set {
+			params[name] = value
+		}
+	}
+	return params
+}
+
+// interpolateValue returns a copy of a parameter value with the strings in
+// it, including those nested in lists and maps, interpolated
+func interpolateValue(interpolator interpolation.Interpolator, value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return interpolator.Interpolate(v)
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			interpolated, err := interpolateValue(interpolator, item)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = interpolated
+		}
+		return items, nil
+	case map[string]interface{}:
+		fields := make(map[string]interface{}, len(v))
+		for name, item := range v {
+			interpolated, err := interpolateValue(interpolator, item)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			fields[name] = interpolated
+		}
+		return fields, nil
+	}
+	return value, nil
+}
+
+// sortedParams returns the parameter names of a step in order
+func sortedParams(params map[string]interface{}) []string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
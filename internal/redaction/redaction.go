@@ -0,0 +1,386 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redaction scans evidence for secrets, personal data and the content
+// a team disallows before it is submitted, and redacts the matches to make
+// sanitized copies.
+package redaction
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+)
+
+// Actions taken on a finding, as set by submission.scan
+const (
+	Block  = "block"
+	Warn   = "warn"
+	Off    = "off"
+	Waived = "waived" // Accepted by a waiver
+)
+
+// Kinds of sensitive data
+const (
+	KindSecret = "secret"
+	KindPII    = "pii"
+	KindDLP    = "dlp" // Disallowed by submission.scan.rules
+)
+
+// Rule finds one kind of sensitive data
+type Rule struct {
+	Name       string
+	Kind       string
+	Pattern    *regexp.Regexp
+	Group      int               // Submatch redacted, 0 for the whole match
+	Valid      func(string) bool // Rejects lookalikes when set
+	Action     string            // Overrides the kind's action when set
+	Categories []string          // Task categories it applies to, all when empty
+}
+
+// Rules are the patterns scanned for
+var Rules = []Rule{
+	{Name: "private_key", Kind: KindSecret, Pattern: regexp.MustCompile(`(?s)-----BEGIN [A-Z ]*PRIVATE KEY( BLOCK)?-----.*?(-----END [A-Z ]*PRIVATE KEY( BLOCK)?-----|\z)`)},
+	{Name: "aws_access_key", Kind: KindSecret, Pattern: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{Name: "github_token", Kind: KindSecret, Pattern: regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`)},
+	{Name: "slack_token", Kind: KindSecret, Pattern: regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{Name: "google_api_key", Kind: KindSecret, Pattern: regexp.MustCompile(`\bAIza[0-9A-Za-z_\-]{35}\b`)},
+	{Name: "stripe_key", Kind: KindSecret, Pattern: regexp.MustCompile(`\b[rs]k_live_[0-9A-Za-z]{20,}\b`)},
+	{Name: "jwt", Kind: KindSecret, Pattern: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`)},
+	{
+		Name:    "secret_assignment",
+		Kind:    KindSecret,
+		Pattern: regexp.MustCompile(`(?i)(?:api[_-]?key|secret(?:[_-]?key)?|client[_-]?secret|password|passwd|access[_-]?token|auth[_-]?token)["']?\s*[:=]\s*["']?([^\s"',;]{8,})`),
+		Group:   1,
+		Valid:   isLiteralSecret,
+	},
+	{Name: "email", Kind: KindPII, Pattern: regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`)},
+	{Name: "ssn", Kind: KindPII, Pattern: regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`), Valid: isSSN},
+}
+
+// Finding is sensitive data found in an evidence file
+type Finding struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Rule    string `json:"rule"`
+	Kind    string `json:"kind"`
+	Action  string `json:"action"`           // block, warn or waived
+	Excerpt string `json:"excerpt"`          // The match, masked
+	Waiver  string `json:"waiver,omitempty"` // Why it is waived
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s:%d: %s %s", f.File, f.Line, strings.ReplaceAll(f.Rule, "_", " "), f.Excerpt)
+}
+
+// Scanner finds and redacts the kinds of sensitive data submission.scan does
+// not turn off
+type Scanner struct {
+	actions  map[string]string
+	allow    []*regexp.Regexp
+	rules    []Rule // From submission.scan.rules
+	waivers  []waiver
+	today    string
+	taskRef  string
+	category string
+}
+
+// waiver is a compiled submission.scan.waivers entry
+type waiver struct {
+	config.ScanWaiver
+	match *regexp.Regexp
+}
+
+// New returns the scanner submission.scan configures, or nil when secrets,
+// personal data and every rule are off. Empty actions take their defaults;
+// invalid patterns, which config validation rejects, are ignored.
+func New(cfg config.ScanConfig) *Scanner {
+	s := &Scanner{
+		actions: map[string]string{KindSecret: actionOr(cfg.Secrets, Block), KindPII: actionOr(cfg.PII, Warn)},
+		today:   time.Now().Format("2006-01-02"),
+	}
+	for _, pattern := range cfg.Allow {
+		if re, err := regexp.Compile(pattern); err == nil {
+			s.allow = append(s.allow, re)
+		}
+	}
+	for _, rule := range cfg.Rules {
+		action := actionOr(rule.Action, Block)
+		pattern := dlpPattern(rule)
+		if action == Off || pattern == nil {
+			continue
+		}
+		s.rules = append(s.rules, Rule{Name: rule.Name, Kind: KindDLP, Pattern: pattern, Action: action, Categories: rule.Categories})
+	}
+	if s.actions[KindSecret] == Off && s.actions[KindPII] == Off && len(s.rules) == 0 {
+		return nil
+	}
+	for _, w := range cfg.Waivers {
+		compiled := waiver{ScanWaiver: w}
+		if w.Match != "" {
+			re, err := regexp.Compile(w.Match)
+			if err != nil {
+				continue
+			}
+			compiled.match = re
+		}
+		s.waivers = append(s.waivers, compiled)
+	}
+	return s
+}
+
+// ForTask returns a scanner for a task's evidence, which applies the rules
+// for its category and the waivers for the task
+func (s *Scanner) ForTask(taskRef, category string) *Scanner {
+	if s == nil {
+		return nil
+	}
+	scoped := *s
+	scoped.taskRef, scoped.category = taskRef, category
+	return &scoped
+}
+
+// Scan returns the findings in a file's content, by line
+func (s *Scanner) Scan(file string, data []byte) []Finding {
+	findings := []Finding{}
+	for _, m := range s.matches(data) {
+		finding := Finding{
+			File:    file,
+			Line:    bytes.Count(data[:m.start], []byte("\n")) + 1,
+			Rule:    m.rule.Name,
+			Kind:    m.rule.Kind,
+			Action:  s.action(m.rule),
+			Excerpt: mask(string(data[m.start:m.end])),
+		}
+		if w := s.waiverFor(m.rule.Name, file, string(data[m.start:m.end])); w != nil {
+			finding.Action, finding.Waiver = Waived, w.Reason
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+// Redact returns a file's content with each finding that is not waived
+// replaced by [REDACTED:<rule>], and how many were redacted
+func (s *Scanner) Redact(file string, data []byte) ([]byte, int) {
+	var out bytes.Buffer
+	last, count := 0, 0
+	for _, m := range s.matches(data) {
+		if s.waiverFor(m.rule.Name, file, string(data[m.start:m.end])) != nil {
+			continue
+		}
+		out.Write(data[last:m.start])
+		out.WriteString("[REDACTED:" + m.rule.Name + "]")
+		last = m.end
+		count++
+	}
+	out.Write(data[last:])
+	return out.Bytes(), count
+}
+
+// Blocking returns the findings that block submission
+func Blocking(findings []Finding) []Finding {
+	blocking := []Finding{}
+	for _, finding := range findings {
+		if finding.Action == Block {
+			blocking = append(blocking, finding)
+		}
+	}
+	return blocking
+}
+
+// match is a rule's match, as byte offsets of the part to redact
+type match struct {
+	rule       Rule
+	start, end int
+}
+
+// matches returns the non-overlapping matches of the rules in force, in
+// order; where matches overlap, the earlier rule wins
+func (s *Scanner) matches(data []byte) []match {
+	var found []match
+	for _, rule := range append(Rules[:len(Rules):len(Rules)], s.rules...) {
+		if s.action(rule) == Off || !s.inCategory(rule) {
+			continue
+		}
+		for _, loc := range rule.Pattern.FindAllSubmatchIndex(data, -1) {
+			start, end := loc[2*rule.Group], loc[2*rule.Group+1]
+			value := string(data[start:end])
+			if (rule.Valid != nil && !rule.Valid(value)) || s.allowed(value) || overlaps(found, start, end) {
+				continue
+			}
+			found = append(found, match{rule: rule, start: start, end: end})
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].start < found[j].start })
+	return found
+}
+
+// action is what a finding of rule does
+func (s *Scanner) action(rule Rule) string {
+	if rule.Action != "" {
+		return rule.Action
+	}
+	return s.actions[rule.Kind]
+}
+
+func (s *Scanner) inCategory(rule Rule) bool {
+	if len(rule.Categories) == 0 {
+		return true
+	}
+	for _, category := range rule.Categories {
+		if strings.EqualFold(category, s.category) {
+			return true
+		}
+	}
+	return false
+}
+
+// waiverFor returns the unexpired waiver that accepts a rule's match in a
+// file of the task, if any
+func (s *Scanner) waiverFor(rule, file, value string) *waiver {
+	for i := range s.waivers {
+		w := &s.waivers[i]
+		if w.Rule != rule || (w.Expires != "" && w.Expires < s.today) {
+			continue
+		}
+		if len(w.Tasks) > 0 && !containsTask(w.Tasks, s.taskRef) {
+			continue
+		}
+		if len(w.Files) > 0 && !matchesGlob(w.Files, file) {
+			continue
+		}
+		if w.match != nil && !w.match.MatchString(value) {
+			continue
+		}
+		return w
+	}
+	return nil
+}
+
+func (s *Scanner) allowed(value string) bool {
+	for _, re := range s.allow {
+		if re.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func overlaps(found []match, start, end int) bool {
+	for _, m := range found {
+		if start < m.end && m.start < end {
+			return true
+		}
+	}
+	return false
+}
+
+// dlpPattern compiles a rule's terms, matched case-insensitively as whole
+// words, and patterns into one expression
+func dlpPattern(rule config.DLPRule) *regexp.Regexp {
+	var alternatives []string
+	for _, term := range rule.Terms {
+		if term = strings.TrimSpace(term); term == "" {
+			continue
+		}
+		alt := "(?i:" + regexp.QuoteMeta(term) + ")"
+		if isWordByte(term[0]) {
+			alt = `\b` + alt
+		}
+		if isWordByte(term[len(term)-1]) {
+			alt += `\b`
+		}
+		alternatives = append(alternatives, alt)
+	}
+	for _, pattern := range rule.Patterns {
+		if _, err := regexp.Compile(pattern); err == nil {
+			alternatives = append(alternatives, "(?:"+pattern+")")
+		}
+	}
+	if len(alternatives) == 0 {
+		return nil
+	}
+	return regexp.MustCompile(strings.Join(alternatives, "|"))
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || ('0' <= b && b <= '9') || ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z')
+}
+
+// containsTask reports whether refs holds taskRef, written with or without
+// the dash
+func containsTask(refs []string, taskRef string) bool {
+	normalize := func(ref string) string { return strings.ToUpper(strings.ReplaceAll(ref, "-", "")) }
+	for _, ref := range refs {
+		if normalize(ref) == normalize(taskRef) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesGlob(globs []string, file string) bool {
+	for _, glob := range globs {
+		if ok, _ := filepath.Match(glob, file); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func actionOr(action, fallback string) string {
+	if action == "" {
+		return fallback
+	}
+	return action
+}
+
+// isLiteralSecret rejects assigned values that refer to a secret rather than
+// contain one, such as var.db_password or ${SECRET}
+func isLiteralSecret(value string) bool {
+	lower := strings.ToLower(value)
+	for _, prefix := range []string{"var.", "local.", "data.", "module.", "${", "$", "<", "[redacted", "****", "env("} {
+		if strings.HasPrefix(lower, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// isSSN rejects numbers that are never issued as SSNs
+func isSSN(value string) bool {
+	area, group, serial := value[:3], value[4:6], value[7:]
+	return area != "000" && area != "666" && area[0] != '9' && group != "00" && serial != "0000"
+}
+
+// mask keeps enough of a match to find it: its first line's first four
+// characters
+func mask(value string) string {
+	value, _, _ = strings.Cut(value, "\n")
+	if strings.HasPrefix(value, "-----BEGIN") {
+		return value
+	}
+	if len(value) <= 4 {
+		return strings.Repeat("*", len(value))
+	}
+	return value[:4] + strings.Repeat("*", min(len(value)-4, 8))
+}
@@ -19,6 +19,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/grctool/grctool/internal/config"
 	"github.com/grctool/grctool/internal/logger"
 )
 
@@ -29,6 +30,29 @@ type TaskToolMapping struct {
 	Schedule string   `json:"schedule,omitempty" yaml:"schedule,omitempty"`       // schedule name
 }
 
+// MappingsFromConfig returns the configured task mappings followed by one
+// mapping per task of each schedule that lists tools.
+func MappingsFromConfig(cfg config.SchedulesConfig) []TaskToolMapping {
+	var mappings []TaskToolMapping
+	for _, tm := range cfg.TaskMappings {
+		mappings = append(mappings, TaskToolMapping{
+			TaskRef:  tm.TaskRef,
+			Tools:    tm.Tools,
+			Schedule: tm.Schedule,
+		})
+	}
+	for _, sc := range cfg.Schedules {
+		for _, taskRef := range sc.Tasks {
+			mappings = append(mappings, TaskToolMapping{
+				TaskRef:  taskRef,
+				Tools:    sc.Tools,
+				Schedule: sc.Name,
+			})
+		}
+	}
+	return mappings
+}
+
 // CollectionPlan is an ordered list of tool runs for a collection cycle.
 type CollectionPlan struct {
 	Tasks []PlannedTask `json:"tasks" yaml:"tasks"`
@@ -111,6 +135,17 @@ func (o *Orchestrator) BuildPlan(taskRefs []string) *CollectionPlan {
 	return plan
 }
 
+// BuildSchedulePlan creates a collection plan from the mappings of a schedule.
+// Unlike BuildPlan it leaves out tools that other schedules map to the same
+// tasks.
+func (o *Orchestrator) BuildSchedulePlan(scheduleName string) *CollectionPlan {
+	plan := &CollectionPlan{}
+	for _, m := range o.GetMappingsForSchedule(scheduleName) {
+		plan.Tasks = append(plan.Tasks, buildPlannedTask(m))
+	}
+	return plan
+}
+
 // buildPlannedTask converts a mapping into a PlannedTask.
 func buildPlannedTask(m TaskToolMapping) PlannedTask {
 	pt := PlannedTask{
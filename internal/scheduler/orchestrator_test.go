@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/grctool/grctool/internal/config"
 	"github.com/grctool/grctool/internal/testhelpers"
 )
 
@@ -377,3 +378,47 @@ func TestCollectionSummary_Counts(t *testing.T) {
 			totalResults, summary.TotalTools)
 	}
 }
+
+func TestOrchestrator_BuildSchedulePlan(t *testing.T) {
+	mappings := append(testMappings(), TaskToolMapping{TaskRef: "ET-0001", Tools: []string{"github-permissions"}, Schedule: "weekly"})
+	o := NewOrchestrator(mappings, testhelpers.NewStubLogger())
+
+	plan := o.BuildSchedulePlan("weekly")
+	if len(plan.Tasks) != 2 {
+		t.Fatalf("expected 2 weekly tasks, got %d", len(plan.Tasks))
+	}
+	if plan.Tasks[1].TaskRef != "ET-0001" || len(plan.Tasks[1].Tools) != 1 {
+		t.Errorf("expected only the weekly tool for ET-0001, got %+v", plan.Tasks[1])
+	}
+
+	if plan := o.BuildSchedulePlan("nonexistent"); len(plan.Tasks) != 0 {
+		t.Errorf("expected empty plan for nonexistent schedule, got %d tasks", len(plan.Tasks))
+	}
+}
+
+func TestMappingsFromConfig(t *testing.T) {
+	mappings := MappingsFromConfig(config.SchedulesConfig{
+		Schedules: []config.ScheduleConfig{
+			{Name: "nightly", Cron: "0 2 * * *"},
+			{Name: "weekly-github", Cron: "0 6 * * 1", Tasks: []string{"ET-0047", "ET-0048"}, Tools: []string{"github-permissions"}},
+		},
+		TaskMappings: []config.TaskMappingConfig{
+			{TaskRef: "ET-0021", Tools: []string{"terraform-security-indexer"}, Schedule: "nightly"},
+		},
+	})
+
+	expected := []TaskToolMapping{
+		{TaskRef: "ET-0021", Tools: []string{"terraform-security-indexer"}, Schedule: "nightly"},
+		{TaskRef: "ET-0047", Tools: []string{"github-permissions"}, Schedule: "weekly-github"},
+		{TaskRef: "ET-0048", Tools: []string{"github-permissions"}, Schedule: "weekly-github"},
+	}
+	if len(mappings) != len(expected) {
+		t.Fatalf("expected %d mappings, got %d", len(expected), len(mappings))
+	}
+	for i := range expected {
+		if mappings[i].TaskRef != expected[i].TaskRef || mappings[i].Schedule != expected[i].Schedule ||
+			fmt.Sprint(mappings[i].Tools) != fmt.Sprint(expected[i].Tools) {
+			t.Errorf("mapping %d: expected %+v, got %+v", i, expected[i], mappings[i])
+		}
+	}
+}
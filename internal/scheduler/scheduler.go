@@ -15,8 +15,8 @@
 
 // Package scheduler evaluates cron-based schedules and determines which
 // scheduled tasks are due for execution. It is designed for CLI-based
-// invocation (not a daemon) — an external cron or CI pipeline calls
-// `grctool schedule run` and the scheduler evaluates what is due NOW.
+// invocation — an external cron or CI pipeline calls `grctool schedule run`,
+// or `grctool daemon` polls, and the scheduler evaluates what is due NOW.
 package scheduler
 
 import (
@@ -28,6 +28,7 @@ const (
 	TaskDueThisMonth TaskDueCategory = "due_this_month"
 	TaskUpcoming     TaskDueCategory = "upcoming"
 	TaskNoSchedule   TaskDueCategory = "no_schedule"
+	TaskDeferred     TaskDueCategory = "deferred" // Postponed with 'evidence defer'; never overdue
 )
 
 // TaskDueDetail captures a single evidence task's due status.
@@ -37,11 +38,15 @@ type TaskDueDetail struct {
 	Category  TaskDueCategory `json:"category"`
 	DueDate   *time.Time      `json:"due_date,omitempty"`
 	DaysUntil int             `json:"days_until"` // negative = overdue
+
+	DeferredUntil *time.Time `json:"deferred_until,omitempty"`
+	DeferReason   string     `json:"defer_reason,omitempty"`
 }
 
 // TaskDueGrouping groups evidence tasks by due urgency.
 type TaskDueGrouping struct {
 	Overdue      []TaskDueDetail `json:"overdue"`
+	Deferred     []TaskDueDetail `json:"deferred,omitempty"`
 	DueThisWeek  []TaskDueDetail `json:"due_this_week"`
 	DueThisMonth []TaskDueDetail `json:"due_this_month"`
 	Upcoming     []TaskDueDetail `json:"upcoming"`
@@ -76,6 +81,8 @@ func GroupTasksByDue(details []TaskDueDetail) *TaskDueGrouping {
 		switch d.Category {
 		case TaskOverdue:
 			g.Overdue = append(g.Overdue, d)
+		case TaskDeferred:
+			g.Deferred = append(g.Deferred, d)
 		case TaskDueThisWeek:
 			g.DueThisWeek = append(g.DueThisWeek, d)
 		case TaskDueThisMonth:
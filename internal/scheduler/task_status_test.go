@@ -86,10 +86,12 @@ func TestGroupTasksByDue(t *testing.T) {
 		{TaskRef: "ET-0003", Category: TaskDueThisWeek, DaysUntil: 2},
 		{TaskRef: "ET-0004", Category: TaskDueThisMonth, DaysUntil: 15},
 		{TaskRef: "ET-0005", Category: TaskUpcoming, DaysUntil: 60},
+		{TaskRef: "ET-0006", Category: TaskDeferred, DaysUntil: -4},
 	}
 
 	g := GroupTasksByDue(details)
 	assert.Len(t, g.Overdue, 2)
+	assert.Len(t, g.Deferred, 1)
 	assert.Len(t, g.DueThisWeek, 1)
 	assert.Len(t, g.DueThisMonth, 1)
 	assert.Len(t, g.Upcoming, 1)
@@ -0,0 +1,169 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package secureframe uploads evidence files to Secureframe tests through the
+// Secureframe API.
+package secureframe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+)
+
+// DefaultBaseURL is the Secureframe API used when none is configured
+const DefaultBaseURL = "https://api.secureframe.com"
+
+// MaxFileSize is the largest file Secureframe accepts as test evidence
+const MaxFileSize = 50 * 1024 * 1024 // 50MB
+
+// Client uploads evidence to Secureframe tests
+type Client struct {
+	baseURL    string
+	apiKey     string
+	apiSecret  string
+	httpClient *http.Client
+}
+
+// NewClient creates a Secureframe API client. The http client defaults to
+// one with the configured timeout.
+func NewClient(cfg config.SecureframeConfig, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		timeout := cfg.Timeout
+		if timeout <= 0 {
+			timeout = 30 * time.Second
+		}
+		httpClient = &http.Client{Timeout: timeout}
+	}
+	baseURL := strings.TrimRight(cfg.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{
+		baseURL:    baseURL,
+		apiKey:     cfg.APIKey,
+		apiSecret:  cfg.APISecret,
+		httpClient: httpClient,
+	}
+}
+
+// EvidenceRequest is a file to upload as evidence of a test
+type EvidenceRequest struct {
+	TestID      string    // Secureframe test the evidence is attached to
+	Filename    string    // Name of the uploaded file
+	Content     []byte    // File content
+	ContentType string    // MIME type of the file
+	CollectedAt time.Time // When the evidence was collected
+	Description string    // Optional description shown in Secureframe
+}
+
+// EvidenceResponse is Secureframe's record of uploaded evidence
+type EvidenceResponse struct {
+	ID       string `json:"id"`
+	Type     string `json:"type,omitempty"`
+	FileName string `json:"file_name,omitempty"`
+}
+
+// UploadTestEvidence uploads a file as evidence of a test
+func (c *Client) UploadTestEvidence(ctx context.Context, req *EvidenceRequest) (*EvidenceResponse, error) {
+	if c.apiKey == "" || c.apiSecret == "" {
+		return nil, fmt.Errorf("secureframe API credentials not configured - set SECUREFRAME_API_KEY and SECUREFRAME_API_SECRET")
+	}
+	if req.TestID == "" {
+		return nil, fmt.Errorf("test ID is required")
+	}
+	if req.Filename == "" {
+		return nil, fmt.Errorf("filename is required")
+	}
+	if len(req.Content) > MaxFileSize {
+		return nil, fmt.Errorf("file size %d bytes exceeds maximum allowed size of %d bytes (50MB)", len(req.Content), MaxFileSize)
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	if !req.CollectedAt.IsZero() {
+		if err := writer.WriteField("collected_at", req.CollectedAt.UTC().Format(time.RFC3339)); err != nil {
+			return nil, fmt.Errorf("failed to write collected_at field: %w", err)
+		}
+	}
+	if req.Description != "" {
+		if err := writer.WriteField("description", req.Description); err != nil {
+			return nil, fmt.Errorf("failed to write description field: %w", err)
+		}
+	}
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`,
+		strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(req.Filename)))
+	contentType := req.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create form file: %w", err)
+	}
+	if _, err := part.Write(req.Content); err != nil {
+		return nil, fmt.Errorf("failed to copy file content: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/tests/%s/evidence", c.baseURL, url.PathEscape(req.TestID))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Secureframe request: %w", err)
+	}
+	// Secureframe authenticates with the key and secret in one header
+	httpReq.Header.Set("Authorization", c.apiKey+" "+c.apiSecret)
+	httpReq.Header.Set("Content-Type", writer.FormDataContentType())
+	httpReq.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload to Secureframe: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("secureframe upload failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	// Resources are returned under "data"
+	var result struct {
+		Data EvidenceResponse `json:"data"`
+	}
+	if len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse Secureframe response: %w", err)
+		}
+	}
+	return &result.Data, nil
+}
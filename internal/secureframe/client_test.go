@@ -0,0 +1,89 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package secureframe
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_UploadTestEvidence(t *testing.T) {
+	t.Parallel()
+
+	var received map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "key-1 secret-1" {
+			http.Error(w, `{"errors":[{"title":"Unauthorized"}]}`, http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/tests/tst_123/evidence" {
+			http.Error(w, `{"errors":[{"title":"Not found"}]}`, http.StatusNotFound)
+			return
+		}
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		content, _ := io.ReadAll(file)
+		received = map[string]string{
+			"collected_at": r.FormValue("collected_at"),
+			"description":  r.FormValue("description"),
+			"filename":     header.Filename,
+			"content_type": header.Header.Get("Content-Type"),
+			"content":      string(content),
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"data": {"id": "evd_456", "type": "evidence", "file_name": "mfa.json"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	upload := EvidenceRequest{
+		TestID:      "tst_123",
+		Filename:    "mfa.json",
+		Content:     []byte(`{"mfa": true}`),
+		ContentType: "application/json",
+		CollectedAt: time.Date(2025, 10, 1, 9, 30, 0, 0, time.UTC),
+		Description: "ET-0012 2025-Q4",
+	}
+
+	client := NewClient(config.SecureframeConfig{BaseURL: server.URL + "/", APIKey: "key-1", APISecret: "secret-1"}, server.Client())
+	resp, err := client.UploadTestEvidence(context.Background(), &upload)
+	require.NoError(t, err)
+	assert.Equal(t, "evd_456", resp.ID)
+	assert.Equal(t, map[string]string{
+		"collected_at": "2025-10-01T09:30:00Z",
+		"description":  "ET-0012 2025-Q4",
+		"filename":     "mfa.json",
+		"content_type": "application/json",
+		"content":      `{"mfa": true}`,
+	}, received)
+
+	tests := map[string]struct {
+		secret string
+		testID string
+		err    string
+	}{
+		"no secret":    {testID: "tst_123", err: "secureframe API credentials not configured"},
+		"wrong secret": {secret: "secret-2", testID: "tst_123", err: "secureframe upload failed with status 401"},
+		"no test":      {secret: "secret-1", err: "test ID is required"},
+		"unknown test": {secret: "secret-1", testID: "tst_999", err: `secureframe upload failed with status 404: {"errors":[{"title":"Not found"}]}`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			req := upload
+			req.TestID = tc.testID
+			client := NewClient(config.SecureframeConfig{BaseURL: server.URL, APIKey: "key-1", APISecret: tc.secret}, server.Client())
+			_, err := client.UploadTestEvidence(context.Background(), &req)
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
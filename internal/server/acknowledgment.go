@@ -0,0 +1,148 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/logger"
+)
+
+// AcknowledgmentStore records policy acknowledgments collected through signed
+// links; it is satisfied by storage.Storage
+type AcknowledgmentStore interface {
+	GetPolicyAcknowledgments(policy, period string) (*domain.PolicyAcknowledgments, error)
+	SavePolicyAcknowledgments(acks *domain.PolicyAcknowledgments) error
+}
+
+// acknowledgmentPath is the prefix of signed acknowledgment links. They are
+// opened by employees, so they sit outside the token-protected API.
+const acknowledgmentPath = "/ack/"
+
+// SignAcknowledgment returns the hex HMAC-SHA256, keyed with secret, that
+// authorizes email to acknowledge version of a policy for a period
+func SignAcknowledgment(secret, policy, period, email, version string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strings.Join([]string{policy, period, domain.NormalizeEmail(email), version}, "\n")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyAcknowledgment reports whether sig was produced by SignAcknowledgment
+// for the same policy, period, email and version
+func VerifyAcknowledgment(secret, policy, period, email, version, sig string) bool {
+	got, err := hex.DecodeString(sig)
+	if err != nil || secret == "" {
+		return false
+	}
+	want, _ := hex.DecodeString(SignAcknowledgment(secret, policy, period, email, version))
+	return hmac.Equal(got, want)
+}
+
+// AcknowledgmentLink returns the signed link, under the server's baseURL, at
+// which email acknowledges a policy for a period
+func AcknowledgmentLink(baseURL, secret, policy, period, email, version string) string {
+	query := url.Values{}
+	query.Set("email", domain.NormalizeEmail(email))
+	if version != "" {
+		query.Set("version", version)
+	}
+	query.Set("sig", SignAcknowledgment(secret, policy, period, email, version))
+	return strings.TrimRight(baseURL, "/") + acknowledgmentPath + url.PathEscape(policy) + "/" + url.PathEscape(period) + "?" + query.Encode()
+}
+
+// acknowledgmentPage is the form shown by a signed link and, once submitted,
+// the confirmation
+var acknowledgmentPage = template.Must(template.New("ack").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="utf-8"><title>Policy acknowledgment</title></head>
+<body>
+<h1>{{.Policy}}{{if .Version}} v{{.Version}}{{end}}</h1>
+{{if .Acknowledged}}<p>Thank you. {{.Email}} acknowledged this policy on {{.Date}} for {{.Period}}.</p>
+{{else}}<form method="post">
+<p>Signed in as {{.Email}} for the {{.Period}} acknowledgment.</p>
+<p><label>Full name <input name="name" autocomplete="name"></label></p>
+<p><label><input type="checkbox" name="agree" value="yes" required> I have read, understood and agree to comply with this policy.</label></p>
+<p><button type="submit">Acknowledge</button></p>
+</form>
+{{end}}</body>
+</html>
+`))
+
+type acknowledgmentView struct {
+	Policy, Period, Email, Version, Date string
+	Acknowledged                         bool
+}
+
+// handleAcknowledgment shows the acknowledgment form of a signed link on GET
+// and records the acknowledgment on POST. Links that were already used show
+// the original acknowledgment.
+func (s *Server) handleAcknowledgment(w http.ResponseWriter, r *http.Request) {
+	policy, period := r.PathValue("policy"), r.PathValue("period")
+	query := r.URL.Query()
+	email, version := query.Get("email"), query.Get("version")
+	if !pathSegment.MatchString(policy) || domain.ValidateAcknowledgmentPeriod(period) != nil ||
+		!VerifyAcknowledgment(s.opts.AcknowledgmentSecret, policy, period, email, version, query.Get("sig")) {
+		http.Error(w, "This acknowledgment link is invalid.", http.StatusForbidden)
+		return
+	}
+
+	s.ackMu.Lock()
+	defer s.ackMu.Unlock()
+	acks, err := s.opts.Acknowledgments.GetPolicyAcknowledgments(policy, period)
+	if err != nil {
+		s.logger.Error("failed to load acknowledgments", logger.Error(err))
+		http.Error(w, "Acknowledgments are unavailable, please try again later.", http.StatusInternalServerError)
+		return
+	}
+
+	view := acknowledgmentView{Policy: policy, Period: period, Email: domain.NormalizeEmail(email), Version: version}
+	if r.Method == http.MethodPost {
+		if r.FormValue("agree") != "yes" {
+			http.Error(w, "Confirm that you agree to the policy.", http.StatusBadRequest)
+			return
+		}
+		acks.Add(domain.PolicyAcknowledgment{
+			Email:          email,
+			Name:           strings.TrimSpace(r.FormValue("name")),
+			Version:        version,
+			AcknowledgedAt: time.Now().UTC(),
+			Source:         domain.AcknowledgmentSourceLink,
+		})
+		acks.UpdatedAt = time.Now().UTC()
+		if err := s.opts.Acknowledgments.SavePolicyAcknowledgments(acks); err != nil {
+			s.logger.Error("failed to save acknowledgment", logger.Error(err))
+			http.Error(w, "Your acknowledgment could not be recorded, please try again later.", http.StatusInternalServerError)
+			return
+		}
+	}
+	if ack, ok := acks.Find(email); ok {
+		view.Acknowledged = true
+		view.Date = ack.AcknowledgedAt.Format("2006-01-02")
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; form-action 'self'; frame-ancestors 'none'")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	_ = acknowledgmentPage.Execute(w, view)
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/storage"
+)
+
+// parseTaskFilter builds an evidence filter from query parameters. The
+// parameters mirror the 'evidence list' flags; list values may be repeated
+// or comma-separated.
+func parseTaskFilter(r *http.Request, now time.Time) (domain.EvidenceFilter, error) {
+	query := r.URL.Query()
+	filter := domain.EvidenceFilter{
+		Status:          queryList(query["status"]),
+		Priority:        queryList(query["priority"]),
+		Framework:       query.Get("framework"),
+		AssignedTo:      query.Get("assignee"),
+		Category:        queryList(query["category"]),
+		AecStatus:       queryList(query["aec_status"]),
+		CollectionType:  queryList(query["collection_type"]),
+		ComplexityLevel: queryList(query["complexity"]),
+	}
+
+	if value := query.Get("sensitive"); value != "" {
+		sensitive, err := strconv.ParseBool(value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid sensitive: %q", value)
+		}
+		filter.Sensitive = &sensitive
+	}
+
+	overdue, err := queryBool(query.Get("overdue"), "overdue")
+	if err != nil {
+		return filter, err
+	}
+	dueSoon, err := queryBool(query.Get("due_soon"), "due_soon")
+	if err != nil {
+		return filter, err
+	}
+	if overdue {
+		filter.DueBefore = &now
+		filter.ExcludeDeferred = true
+	}
+	if dueSoon {
+		dueSoonDate := now.AddDate(0, 0, 7)
+		filter.DueAfter = &now
+		filter.DueBefore = &dueSoonDate
+	}
+	return filter, nil
+}
+
+func queryList(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				result = append(result, item)
+			}
+		}
+	}
+	return result
+}
+
+func queryBool(value, name string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s: %q", name, value)
+	}
+	return b, nil
+}
+
+// serveEvidenceFile streams an evidence file, honouring range and
+// conditional requests
+func serveEvidenceFile(w http.ResponseWriter, r *http.Request, baseDir string, ref models.EvidenceFileRef) {
+	path := ref.RelativePath
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(baseDir, path)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "evidence file not found: "+ref.Filename)
+		return
+	}
+	content, err := storage.ReadFile(path)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to read evidence file: "+err.Error())
+		return
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(ref.Filename))
+	switch {
+	case strings.HasSuffix(ref.Filename, ".md"):
+		contentType = "text/markdown; charset=utf-8"
+	case contentType == "":
+		contentType = "application/octet-stream"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if ref.ChecksumSHA256 != "" {
+		w.Header().Set("ETag", `"`+ref.ChecksumSHA256+`"`)
+	}
+	http.ServeContent(w, r, ref.Filename, info.ModTime(), bytes.NewReader(content))
+}
+
+func sortTaskStates(tasks []*models.EvidenceTaskState) {
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].TaskRef < tasks[j].TaskRef
+	})
+}
+
+// normalizeTaskRef normalizes a task reference (et-1 -> ET-0001)
+func normalizeTaskRef(ref string) string {
+	ref = strings.ToUpper(ref)
+	if len(ref) >= 7 && strings.HasPrefix(ref, "ET-") {
+		return ref
+	}
+
+	var num int
+	if _, err := fmt.Sscanf(ref, "ET-%d", &num); err == nil {
+		return fmt.Sprintf("ET-%04d", num)
+	}
+	return ref
+}
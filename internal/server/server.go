@@ -0,0 +1,655 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package server exposes evidence tasks, status, files, validation results
+// and submissions over an HTTP API, along with a web dashboard built on it.
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/services"
+	"github.com/grctool/grctool/internal/services/submission"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/grctool/grctool/internal/webhook"
+)
+
+// TaskService lists evidence tasks; it is satisfied by evidence.Service
+type TaskService interface {
+	ListEvidenceTasks(ctx context.Context, filter domain.EvidenceFilter) ([]domain.EvidenceTask, error)
+	GetEvidenceTaskSummary(ctx context.Context) (*domain.EvidenceTaskSummary, error)
+}
+
+// Store reads tasks and evidence windows; it is satisfied by storage.Storage
+type Store interface {
+	GetEvidenceTask(id string) (*domain.EvidenceTask, error)
+	GetEvidenceFiles(taskRef, window string) ([]models.EvidenceFileRef, error)
+	LoadValidationResult(taskRef, window string) (*models.ValidationResult, error)
+	CheckAlreadySubmitted(taskRef, window string) (bool, error)
+	MoveEvidenceFilesToSubmitted(taskRef, window string, files []models.EvidenceFileRef) error
+	GetBaseDir() string
+}
+
+// Submitter submits evidence windows; it is satisfied by submission.SubmissionService
+type Submitter interface {
+	Submit(ctx context.Context, req *submission.SubmitRequest) (*submission.SubmitResponse, error)
+}
+
+// WebhookHandler applies Tugboat notifications; it is satisfied by webhook.Processor
+type WebhookHandler interface {
+	Handle(ctx context.Context, event webhook.Event) (*webhook.Result, error)
+}
+
+// Options configures the server
+type Options struct {
+	// Token, when set, must be presented as "Authorization: Bearer <token>".
+	// Without one, submissions are refused.
+	Token string
+
+	// AllowedHosts, when set, are the only host names accepted in the Host
+	// header, so that DNS rebinding cannot reach a server bound to localhost
+	AllowedHosts []string
+
+	// Version is reported by the health endpoint
+	Version string
+
+	// Dashboard serves the web dashboard at /
+	Dashboard bool
+
+	// Webhooks, when set, receives Tugboat notifications at webhookPath. They
+	// are authenticated with WebhookSecret instead of Token.
+	Webhooks      WebhookHandler
+	WebhookSecret string
+
+	// Acknowledgments, when set, records policy acknowledgments made through
+	// links signed with AcknowledgmentSecret under /ack/
+	Acknowledgments      AcknowledgmentStore
+	AcknowledgmentSecret string
+}
+
+// webhookPath receives Tugboat notifications
+const webhookPath = "/api/v1/webhooks/tugboat"
+
+// Server serves the HTTP API
+type Server struct {
+	tasks     TaskService
+	scanner   services.EvidenceScanner
+	store     Store
+	submitter Submitter
+	opts      Options
+	logger    logger.Logger
+
+	// ackMu serializes recording acknowledgments, which rewrites the
+	// policy's acknowledgments for the period
+	ackMu sync.Mutex
+}
+
+// NewServer creates an API server. submitter may be nil, in which case
+// submission requests are rejected.
+func NewServer(tasks TaskService, scanner services.EvidenceScanner, store Store, submitter Submitter, opts Options, log logger.Logger) *Server {
+	return &Server{
+		tasks:     tasks,
+		scanner:   scanner,
+		store:     store,
+		submitter: submitter,
+		opts:      opts,
+		logger:    log,
+	}
+}
+
+// Handler returns the HTTP handler for the API and, when enabled, the dashboard
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/health", s.handleHealth)
+	mux.HandleFunc("GET /api/v1/tasks", s.handleListTasks)
+	mux.HandleFunc("GET /api/v1/tasks/summary", s.handleTaskSummary)
+	mux.HandleFunc("GET /api/v1/tasks/{ref}", s.handleGetTask)
+	mux.HandleFunc("GET /api/v1/status", s.handleStatus)
+	mux.HandleFunc("GET /api/v1/status/{ref}", s.handleTaskStatus)
+	mux.HandleFunc("GET /api/v1/tasks/{ref}/windows/{window}/files", s.handleListFiles)
+	mux.HandleFunc("GET /api/v1/tasks/{ref}/windows/{window}/files/{name}", s.handleGetFile)
+	mux.HandleFunc("GET /api/v1/tasks/{ref}/windows/{window}/validation", s.handleValidation)
+	mux.HandleFunc("POST /api/v1/tasks/{ref}/windows/{window}/submit", s.handleSubmit)
+	if s.opts.Webhooks != nil {
+		mux.HandleFunc("POST "+webhookPath, s.handleWebhook)
+	}
+	if s.opts.Acknowledgments != nil && s.opts.AcknowledgmentSecret != "" {
+		mux.HandleFunc("GET "+acknowledgmentPath+"{policy}/{period}", s.handleAcknowledgment)
+		mux.HandleFunc("POST "+acknowledgmentPath+"{policy}/{period}", s.handleAcknowledgment)
+	}
+	mux.HandleFunc("/api/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "unknown endpoint")
+	})
+	if s.opts.Dashboard {
+		mux.Handle("/", dashboardHandler())
+	}
+
+	return s.logRequests(s.checkRequest(s.authenticate(mux)))
+}
+
+// checkRequest rejects requests for hosts other than the allowed ones,
+// cross-origin requests that change state, and API requests that change
+// state without a JSON body, which browsers cannot send cross-origin without
+// a preflight
+func (s *Server) checkRequest(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.hostAllowed(r.Host) {
+			writeError(w, http.StatusMisdirectedRequest, "unexpected host "+r.Host)
+			return
+		}
+		if isMutating(r.Method) {
+			if origin := r.Header.Get("Origin"); origin != "" && !sameOrigin(origin, r.Host) {
+				writeError(w, http.StatusForbidden, "cross-origin request rejected")
+				return
+			}
+			if strings.HasPrefix(r.URL.Path, "/api/") {
+				mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+				if mediaType != "application/json" {
+					writeError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
+					return
+				}
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) hostAllowed(host string) bool {
+	if len(s.opts.AllowedHosts) == 0 {
+		return true
+	}
+	name := hostName(host)
+	for _, allowed := range s.opts.AllowedHosts {
+		if strings.EqualFold(name, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+// sameOrigin reports whether an Origin header names the host being requested
+func sameOrigin(origin, host string) bool {
+	u, err := url.Parse(origin)
+	return err == nil && u.Host != "" && strings.EqualFold(u.Host, host)
+}
+
+// hostName strips the port and IPv6 brackets from a Host header
+func hostName(host string) string {
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		return name
+	}
+	return strings.Trim(host, "[]")
+}
+
+// authenticate enforces the bearer token on API requests, except health checks
+// and webhooks, which carry their own secret
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	if s.opts.Token == "" {
+		return next
+	}
+	want := []byte("Bearer " + s.opts.Token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		exempt := r.URL.Path == "/api/v1/health" || (s.opts.Webhooks != nil && r.URL.Path == webhookPath)
+		if strings.HasPrefix(r.URL.Path, "/api/") && !exempt {
+			if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), want) != 1 {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="grctool"`)
+				writeError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		s.logger.Debug("api request",
+			logger.String("method", r.Method),
+			logger.String("path", r.URL.Path),
+			logger.Int("status", rec.status),
+			logger.Duration("duration", time.Since(start)))
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// HealthResult is the response of GET /api/v1/health
+type HealthResult struct {
+	Status  string `json:"status"`
+	Version string `json:"version,omitempty"`
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, HealthResult{Status: "ok", Version: s.opts.Version})
+}
+
+// TaskListResult is the response of GET /api/v1/tasks
+type TaskListResult struct {
+	Total int                   `json:"total"`
+	Tasks []domain.EvidenceTask `json:"tasks"`
+}
+
+func (s *Server) handleListTasks(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseTaskFilter(r, time.Now())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tasks, err := s.tasks.ListEvidenceTasks(r.Context(), filter)
+	if err != nil {
+		s.internalError(w, "failed to list evidence tasks", err)
+		return
+	}
+	if tasks == nil {
+		tasks = []domain.EvidenceTask{}
+	}
+	writeJSON(w, http.StatusOK, TaskListResult{Total: len(tasks), Tasks: tasks})
+}
+
+func (s *Server) handleTaskSummary(w http.ResponseWriter, r *http.Request) {
+	summary, err := s.tasks.GetEvidenceTaskSummary(r.Context())
+	if err != nil {
+		s.internalError(w, "failed to summarize evidence tasks", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, summary)
+}
+
+func (s *Server) handleGetTask(w http.ResponseWriter, r *http.Request) {
+	task, ok := s.lookupTask(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, task)
+}
+
+// StatusResult is the response of GET /api/v1/status
+type StatusResult struct {
+	ScannedAt    time.Time                           `json:"scanned_at"`
+	TotalTasks   int                                 `json:"total_tasks"`
+	ByState      map[models.LocalEvidenceState]int   `json:"by_state"`
+	ByAutomation map[models.AutomationCapability]int `json:"by_automation"`
+	Tasks        []*models.EvidenceTaskState         `json:"tasks"`
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	states, err := s.scanner.ScanAll(r.Context())
+	if err != nil {
+		s.internalError(w, "failed to scan evidence", err)
+		return
+	}
+
+	filterState := r.URL.Query().Get("state")
+	filterAutomation := r.URL.Query().Get("automation")
+
+	cache := models.NewStateCache()
+	tasks := make([]*models.EvidenceTaskState, 0, len(states))
+	for ref, state := range states {
+		cache.SetTask(ref, state)
+		if filterState != "" && string(state.LocalState) != filterState {
+			continue
+		}
+		if filterAutomation != "" && string(state.AutomationLevel) != filterAutomation {
+			continue
+		}
+		tasks = append(tasks, state)
+	}
+	sortTaskStates(tasks)
+
+	writeJSON(w, http.StatusOK, StatusResult{
+		ScannedAt:    cache.LastScan,
+		TotalTasks:   len(states),
+		ByState:      cache.GetStateSummary(),
+		ByAutomation: cache.GetAutomationSummary(),
+		Tasks:        tasks,
+	})
+}
+
+func (s *Server) handleTaskStatus(w http.ResponseWriter, r *http.Request) {
+	ref, ok := pathParam(w, r, "ref")
+	if !ok {
+		return
+	}
+	state, err := s.scanner.ScanTask(r.Context(), normalizeTaskRef(ref))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, state)
+}
+
+// FileListResult is the response of GET .../windows/{window}/files
+type FileListResult struct {
+	TaskRef          string                   `json:"task_ref"`
+	Window           string                   `json:"window"`
+	AlreadySubmitted bool                     `json:"already_submitted"`
+	Files            []models.EvidenceFileRef `json:"files"`
+}
+
+func (s *Server) handleListFiles(w http.ResponseWriter, r *http.Request) {
+	task, window, ok := s.lookupWindow(w, r)
+	if !ok {
+		return
+	}
+
+	files, err := s.store.GetEvidenceFiles(task.ReferenceID, window)
+	if err != nil {
+		files = nil
+	}
+	submitted, _ := s.store.CheckAlreadySubmitted(task.ReferenceID, window)
+	if files == nil {
+		files = []models.EvidenceFileRef{}
+	}
+	writeJSON(w, http.StatusOK, FileListResult{
+		TaskRef:          task.ReferenceID,
+		Window:           window,
+		AlreadySubmitted: submitted,
+		Files:            files,
+	})
+}
+
+func (s *Server) handleGetFile(w http.ResponseWriter, r *http.Request) {
+	task, window, ok := s.lookupWindow(w, r)
+	if !ok {
+		return
+	}
+	name := r.PathValue("name")
+
+	files, err := s.store.GetEvidenceFiles(task.ReferenceID, window)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	// Only files listed for the window are served, so the name can never
+	// reach outside the evidence directory
+	for _, file := range files {
+		if file.Filename == name {
+			serveEvidenceFile(w, r, s.store.GetBaseDir(), file)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "evidence file not found: "+name)
+}
+
+func (s *Server) handleValidation(w http.ResponseWriter, r *http.Request) {
+	task, window, ok := s.lookupWindow(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := s.store.LoadValidationResult(task.ReferenceID, window)
+	if err != nil || result == nil {
+		writeError(w, http.StatusNotFound, "no validation result for "+task.ReferenceID+"/"+window)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// SubmitRequest is the body of POST .../windows/{window}/submit
+type SubmitRequest struct {
+	Notes          string `json:"notes,omitempty"`
+	SkipValidation bool   `json:"skip_validation,omitempty"`
+	DryRun         bool   `json:"dry_run,omitempty"`
+}
+
+// SubmitResult is the response of POST .../windows/{window}/submit
+type SubmitResult struct {
+	TaskRef          string                   `json:"task_ref"`
+	Window           string                   `json:"window"`
+	DryRun           bool                     `json:"dry_run,omitempty"`
+	Success          bool                     `json:"success"`
+	SubmissionID     string                   `json:"submission_id,omitempty"`
+	Status           string                   `json:"status,omitempty"`
+	Message          string                   `json:"message,omitempty"`
+	Files            []models.EvidenceFileRef `json:"files"`
+	MovedToSubmitted bool                     `json:"moved_to_submitted,omitempty"`
+	MoveError        string                   `json:"move_error,omitempty"`
+	Validation       *models.ValidationResult `json:"validation,omitempty"`
+}
+
+func (s *Server) handleSubmit(w http.ResponseWriter, r *http.Request) {
+	// Without a token anything that can reach the port could upload evidence
+	if s.opts.Token == "" {
+		writeError(w, http.StatusForbidden, "evidence submission requires an API token")
+		return
+	}
+
+	task, window, ok := s.lookupWindow(w, r)
+	if !ok {
+		return
+	}
+
+	var req SubmitRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64<<10)).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+			return
+		}
+	}
+
+	// Hold the window from the resubmission check until files have moved
+	if !req.DryRun {
+		lock, err := storage.LockWindow(s.store.GetBaseDir(), task.ReferenceID, window)
+		var locked *storage.LockedError
+		if errors.As(err, &locked) {
+			writeError(w, http.StatusConflict, err.Error())
+			return
+		}
+		if err != nil {
+			s.internalError(w, "failed to lock evidence window", err)
+			return
+		}
+		defer lock.Unlock()
+	}
+
+	submitted, err := s.store.CheckAlreadySubmitted(task.ReferenceID, window)
+	if err != nil {
+		s.internalError(w, "failed to check submission status", err)
+		return
+	}
+	if submitted {
+		writeError(w, http.StatusConflict, "evidence for "+task.ReferenceID+"/"+window+" has already been submitted")
+		return
+	}
+
+	files, err := s.store.GetEvidenceFiles(task.ReferenceID, window)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	result := SubmitResult{TaskRef: task.ReferenceID, Window: window, DryRun: req.DryRun, Files: files}
+	if req.DryRun {
+		result.Success = true
+		writeJSON(w, http.StatusOK, result)
+		return
+	}
+	if s.submitter == nil {
+		writeError(w, http.StatusServiceUnavailable, "evidence submission is disabled")
+		return
+	}
+
+	s.logger.Info("api submission",
+		logger.String("task_ref", task.ReferenceID),
+		logger.String("window", window))
+	resp, err := s.submitter.Submit(r.Context(), &submission.SubmitRequest{
+		TaskRef:        task.ReferenceID,
+		Window:         window,
+		Notes:          req.Notes,
+		SkipValidation: req.SkipValidation,
+		SubmittedBy:    "grctool-api",
+	})
+	if err != nil {
+		writeError(w, http.StatusBadGateway, "submission failed: "+err.Error())
+		return
+	}
+
+	result.Success = resp.Success
+	result.SubmissionID = resp.SubmissionID
+	result.Status = resp.Status
+	result.Message = resp.Message
+	if !resp.Success {
+		result.Validation = resp.ValidationResult
+		writeJSON(w, http.StatusUnprocessableEntity, result)
+		return
+	}
+
+	// Mirror 'evidence submit': submitted files move to .submitted/, while
+	// files that failed to upload stay for a retry
+	if resp.Submission != nil {
+		files = resp.Submission.UploadedFiles()
+	}
+	if err := s.store.MoveEvidenceFilesToSubmitted(task.ReferenceID, window, files); err != nil {
+		result.MoveError = err.Error()
+	} else {
+		result.MovedToSubmitted = true
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// handleWebhook applies a Tugboat notification. The request must be signed
+// with the webhook secret in webhook.SignatureHeader, or present the secret
+// as a bearer token for senders that cannot sign.
+func (s *Server) handleWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 64<<10))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	signed := s.opts.WebhookSecret != "" && webhook.VerifySignature(s.opts.WebhookSecret, body, r.Header.Get(webhook.SignatureHeader))
+	bearer := s.opts.WebhookSecret != "" &&
+		subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+s.opts.WebhookSecret)) == 1
+	if !signed && !bearer {
+		writeError(w, http.StatusUnauthorized, "missing or invalid webhook signature")
+		return
+	}
+
+	event, err := webhook.ParseEvent(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := s.opts.Webhooks.Handle(r.Context(), event)
+	switch {
+	case errors.Is(err, webhook.ErrInvalidEvent):
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, webhook.ErrUnknownTask):
+		writeError(w, http.StatusNotFound, err.Error())
+	case err != nil:
+		s.internalError(w, "failed to process webhook", err)
+	default:
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+// lookupTask resolves the {ref} path parameter to a task, writing a 404 when
+// it does not exist
+func (s *Server) lookupTask(w http.ResponseWriter, r *http.Request) (*domain.EvidenceTask, bool) {
+	ref, ok := pathParam(w, r, "ref")
+	if !ok {
+		return nil, false
+	}
+	task, err := s.store.GetEvidenceTask(normalizeTaskRef(ref))
+	if err != nil || task == nil {
+		writeError(w, http.StatusNotFound, "evidence task not found: "+ref)
+		return nil, false
+	}
+	return task, true
+}
+
+func (s *Server) lookupWindow(w http.ResponseWriter, r *http.Request) (*domain.EvidenceTask, string, bool) {
+	window, ok := pathParam(w, r, "window")
+	if !ok {
+		return nil, "", false
+	}
+	task, ok := s.lookupTask(w, r)
+	if !ok {
+		return nil, "", false
+	}
+	return task, window, true
+}
+
+func (s *Server) internalError(w http.ResponseWriter, msg string, err error) {
+	s.logger.Error(msg, logger.Error(err))
+	writeError(w, http.StatusInternalServerError, msg+": "+err.Error())
+}
+
+// pathSegment restricts task references and windows to names that cannot
+// escape the evidence directory
+var pathSegment = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+func pathParam(w http.ResponseWriter, r *http.Request, name string) (string, bool) {
+	value := r.PathValue(name)
+	if !pathSegment.MatchString(value) || strings.Contains(value, "..") {
+		writeError(w, http.StatusBadRequest, "invalid "+name+": "+value)
+		return "", false
+	}
+	return value, true
+}
+
+// ErrorResult is the body of every error response
+type ErrorResult struct {
+	Error string `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, ErrorResult{Error: msg})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	// Headers are already sent, so an encoding error cannot be reported
+	_ = encoder.Encode(v)
+}
@@ -0,0 +1,679 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/services/submission"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/grctool/grctool/internal/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTasks struct {
+	tasks  []domain.EvidenceTask
+	filter domain.EvidenceFilter
+}
+
+func (f *fakeTasks) ListEvidenceTasks(ctx context.Context, filter domain.EvidenceFilter) ([]domain.EvidenceTask, error) {
+	f.filter = filter
+	return f.tasks, nil
+}
+
+func (f *fakeTasks) GetEvidenceTaskSummary(ctx context.Context) (*domain.EvidenceTaskSummary, error) {
+	return &domain.EvidenceTaskSummary{Total: len(f.tasks)}, nil
+}
+
+type fakeScanner struct {
+	states map[string]*models.EvidenceTaskState
+}
+
+func (f *fakeScanner) ScanAll(ctx context.Context) (map[string]*models.EvidenceTaskState, error) {
+	return f.states, nil
+}
+
+func (f *fakeScanner) ScanTask(ctx context.Context, taskRef string) (*models.EvidenceTaskState, error) {
+	if state, ok := f.states[taskRef]; ok {
+		return state, nil
+	}
+	return nil, fmt.Errorf("task %s not found", taskRef)
+}
+
+func (f *fakeScanner) ScanWindow(ctx context.Context, taskRef, window string) (*models.WindowState, error) {
+	return nil, errors.New("not implemented")
+}
+
+type fakeStore struct {
+	baseDir    string
+	tasks      map[string]*domain.EvidenceTask
+	validation map[string]*models.ValidationResult
+	submitted  map[string]bool
+	moved      []string
+	movedFiles []string
+}
+
+func (f *fakeStore) GetEvidenceTask(id string) (*domain.EvidenceTask, error) {
+	if task, ok := f.tasks[id]; ok {
+		return task, nil
+	}
+	return nil, fmt.Errorf("evidence task not found: %s", id)
+}
+
+func (f *fakeStore) GetEvidenceFiles(taskRef, window string) ([]models.EvidenceFileRef, error) {
+	dir := filepath.Join(f.baseDir, "evidence", taskRef, window)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("evidence directory not found for %s in window %s", taskRef, window)
+	}
+	var files []models.EvidenceFileRef
+	for _, entry := range entries {
+		info, _ := entry.Info()
+		files = append(files, models.EvidenceFileRef{
+			Filename:     entry.Name(),
+			RelativePath: filepath.Join("evidence", taskRef, window, entry.Name()),
+			SizeBytes:    info.Size(),
+		})
+	}
+	return files, nil
+}
+
+func (f *fakeStore) LoadValidationResult(taskRef, window string) (*models.ValidationResult, error) {
+	if result, ok := f.validation[taskRef+"/"+window]; ok {
+		return result, nil
+	}
+	return nil, errors.New("validation result not found")
+}
+
+func (f *fakeStore) CheckAlreadySubmitted(taskRef, window string) (bool, error) {
+	return f.submitted[taskRef+"/"+window], nil
+}
+
+func (f *fakeStore) MoveEvidenceFilesToSubmitted(taskRef, window string, files []models.EvidenceFileRef) error {
+	f.moved = append(f.moved, taskRef+"/"+window)
+	for _, file := range files {
+		f.movedFiles = append(f.movedFiles, file.Filename)
+	}
+	return nil
+}
+
+func (f *fakeStore) GetBaseDir() string {
+	return f.baseDir
+}
+
+type fakeSubmitter struct {
+	requests []*submission.SubmitRequest
+	response *submission.SubmitResponse
+}
+
+func (f *fakeSubmitter) Submit(ctx context.Context, req *submission.SubmitRequest) (*submission.SubmitResponse, error) {
+	f.requests = append(f.requests, req)
+	return f.response, nil
+}
+
+type fixture struct {
+	tasks     *fakeTasks
+	store     *fakeStore
+	submitter *fakeSubmitter
+	handler   http.Handler
+}
+
+func newFixture(t *testing.T, opts Options) *fixture {
+	t.Helper()
+
+	baseDir := t.TempDir()
+	windowDir := filepath.Join(baseDir, "evidence", "ET-0001", "2025-Q4")
+	require.NoError(t, os.MkdirAll(windowDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(windowDir, "01_users.csv"), []byte("user,role\njane,admin\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(windowDir, "02_summary.md"), []byte("# Summary\n"), 0644))
+
+	task := &domain.EvidenceTask{ID: "327992", ReferenceID: "ET-0001", Name: "Access Review", Status: "pending"}
+	f := &fixture{
+		tasks: &fakeTasks{tasks: []domain.EvidenceTask{*task}},
+		store: &fakeStore{
+			baseDir: baseDir,
+			tasks:   map[string]*domain.EvidenceTask{"ET-0001": task},
+			validation: map[string]*models.ValidationResult{
+				"ET-0001/2025-Q4": {TaskRef: "ET-0001", Window: "2025-Q4", Status: "passed", CompletenessScore: 0.9},
+			},
+			submitted: map[string]bool{"ET-0001/2025-Q3": true},
+		},
+		submitter: &fakeSubmitter{response: &submission.SubmitResponse{Success: true, SubmissionID: "sub-1", Status: "submitted"}},
+	}
+	scanner := &fakeScanner{states: map[string]*models.EvidenceTaskState{
+		"ET-0002": {TaskRef: "ET-0002", LocalState: models.StateNoEvidence, AutomationLevel: models.AutomationManual},
+		"ET-0001": {TaskRef: "ET-0001", LocalState: models.StateGenerated, AutomationLevel: models.AutomationFully},
+	}}
+
+	log, err := logger.NewTestLogger()
+	require.NoError(t, err)
+	f.handler = NewServer(f.tasks, scanner, f.store, f.submitter, opts, log).Handler()
+	return f
+}
+
+func (f *fixture) do(t *testing.T, method, path, body string, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	rec := httptest.NewRecorder()
+	f.handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func decode[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+	var v T
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &v), rec.Body.String())
+	return v
+}
+
+func TestServer_Tasks(t *testing.T) {
+	t.Parallel()
+	f := newFixture(t, Options{Version: "1.2.3"})
+
+	rec := f.do(t, http.MethodGet, "/api/v1/health", "", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, HealthResult{Status: "ok", Version: "1.2.3"}, decode[HealthResult](t, rec))
+
+	rec = f.do(t, http.MethodGet, "/api/v1/tasks?status=pending,submitted&framework=soc2&priority=high&priority=low&overdue=true", "", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	list := decode[TaskListResult](t, rec)
+	assert.Equal(t, 1, list.Total)
+	assert.Equal(t, "ET-0001", list.Tasks[0].ReferenceID)
+	assert.Equal(t, []string{"pending", "submitted"}, f.tasks.filter.Status)
+	assert.Equal(t, []string{"high", "low"}, f.tasks.filter.Priority)
+	assert.Equal(t, "soc2", f.tasks.filter.Framework)
+	assert.NotNil(t, f.tasks.filter.DueBefore)
+
+	rec = f.do(t, http.MethodGet, "/api/v1/tasks?overdue=maybe", "", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, decode[ErrorResult](t, rec).Error, "invalid overdue")
+
+	rec = f.do(t, http.MethodGet, "/api/v1/tasks/summary", "", nil)
+	assert.Equal(t, 1, decode[domain.EvidenceTaskSummary](t, rec).Total)
+
+	rec = f.do(t, http.MethodGet, "/api/v1/tasks/et-1", "", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "Access Review", decode[domain.EvidenceTask](t, rec).Name)
+
+	rec = f.do(t, http.MethodGet, "/api/v1/tasks/ET-0404", "", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = f.do(t, http.MethodGet, "/api/v1/nope", "", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestServer_Status(t *testing.T) {
+	t.Parallel()
+	f := newFixture(t, Options{})
+
+	rec := f.do(t, http.MethodGet, "/api/v1/status", "", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	status := decode[StatusResult](t, rec)
+	assert.Equal(t, 2, status.TotalTasks)
+	require.Len(t, status.Tasks, 2)
+	assert.Equal(t, "ET-0001", status.Tasks[0].TaskRef)
+	assert.Equal(t, 1, status.ByState[models.StateGenerated])
+
+	rec = f.do(t, http.MethodGet, "/api/v1/status?state=no_evidence", "", nil)
+	status = decode[StatusResult](t, rec)
+	assert.Equal(t, 2, status.TotalTasks)
+	require.Len(t, status.Tasks, 1)
+	assert.Equal(t, "ET-0002", status.Tasks[0].TaskRef)
+
+	rec = f.do(t, http.MethodGet, "/api/v1/status/et-2", "", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "ET-0002", decode[models.EvidenceTaskState](t, rec).TaskRef)
+
+	rec = f.do(t, http.MethodGet, "/api/v1/status/ET-0404", "", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestServer_Files(t *testing.T) {
+	t.Parallel()
+	f := newFixture(t, Options{})
+
+	rec := f.do(t, http.MethodGet, "/api/v1/tasks/ET-0001/windows/2025-Q4/files", "", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	files := decode[FileListResult](t, rec)
+	assert.False(t, files.AlreadySubmitted)
+	require.Len(t, files.Files, 2)
+	assert.Equal(t, "01_users.csv", files.Files[0].Filename)
+
+	rec = f.do(t, http.MethodGet, "/api/v1/tasks/ET-0001/windows/2025-Q1/files", "", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, decode[FileListResult](t, rec).Files)
+
+	rec = f.do(t, http.MethodGet, "/api/v1/tasks/ET-0001/windows/2025-Q4/files/01_users.csv", "", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "user,role\njane,admin\n", rec.Body.String())
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+
+	rec = f.do(t, http.MethodGet, "/api/v1/tasks/ET-0001/windows/2025-Q4/files/02_summary.md", "", nil)
+	assert.Equal(t, "text/markdown; charset=utf-8", rec.Header().Get("Content-Type"))
+
+	rec = f.do(t, http.MethodGet, "/api/v1/tasks/ET-0001/windows/2025-Q4/files/missing.csv", "", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = f.do(t, http.MethodGet, "/api/v1/tasks/ET-0001/windows/..%2F..%2Fetc/files", "", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestServer_Validation(t *testing.T) {
+	t.Parallel()
+	f := newFixture(t, Options{})
+
+	rec := f.do(t, http.MethodGet, "/api/v1/tasks/ET-0001/windows/2025-Q4/validation", "", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 0.9, decode[models.ValidationResult](t, rec).CompletenessScore)
+
+	rec = f.do(t, http.MethodGet, "/api/v1/tasks/ET-0001/windows/2025-Q1/validation", "", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestServer_Submit(t *testing.T) {
+	t.Parallel()
+	f := newFixture(t, Options{Token: "s3cret"})
+	header := http.Header{"Authorization": {"Bearer s3cret"}, "Content-Type": {"application/json"}}
+
+	rec := f.do(t, http.MethodPost, "/api/v1/tasks/ET-0001/windows/2025-Q4/submit", `{"dry_run": true}`, header)
+	require.Equal(t, http.StatusOK, rec.Code)
+	result := decode[SubmitResult](t, rec)
+	assert.True(t, result.DryRun)
+	assert.Len(t, result.Files, 2)
+	assert.Empty(t, f.submitter.requests)
+
+	rec = f.do(t, http.MethodPost, "/api/v1/tasks/ET-0001/windows/2025-Q4/submit", `{"notes": "Q4 access review"}`, header)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	result = decode[SubmitResult](t, rec)
+	assert.True(t, result.Success)
+	assert.Equal(t, "sub-1", result.SubmissionID)
+	assert.True(t, result.MovedToSubmitted)
+	require.Len(t, f.submitter.requests, 1)
+	assert.Equal(t, "Q4 access review", f.submitter.requests[0].Notes)
+	assert.Equal(t, "grctool-api", f.submitter.requests[0].SubmittedBy)
+	assert.Equal(t, []string{"ET-0001/2025-Q4"}, f.store.moved)
+	assert.Equal(t, []string{"01_users.csv", "02_summary.md"}, f.store.movedFiles)
+
+	// Files that failed to upload stay for a retry
+	f.store.movedFiles = nil
+	f.submitter.response = &submission.SubmitResponse{Success: true, SubmissionID: "sub-2", Status: "submitted",
+		Submission: &models.EvidenceSubmission{
+			EvidenceFiles: []models.EvidenceFileRef{{Filename: "01_users.csv"}, {Filename: "02_summary.md"}},
+			FailedFiles:   []models.FailedUpload{{Filename: "02_summary.md", Error: "timeout", Attempts: 4}},
+		}}
+	rec = f.do(t, http.MethodPost, "/api/v1/tasks/ET-0001/windows/2025-Q4/submit", "", header)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, []string{"01_users.csv"}, f.store.movedFiles)
+
+	rec = f.do(t, http.MethodPost, "/api/v1/tasks/ET-0001/windows/2025-Q3/submit", "", header)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = f.do(t, http.MethodPost, "/api/v1/tasks/ET-0001/windows/2025-Q4/submit", `{"notes":`, header)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	f.submitter.response = &submission.SubmitResponse{
+		Success:          false,
+		Message:          "validation failed",
+		ValidationResult: &models.ValidationResult{Status: "failed", FailedChecks: 2},
+	}
+	rec = f.do(t, http.MethodPost, "/api/v1/tasks/ET-0001/windows/2025-Q4/submit", "", header)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, 2, decode[SubmitResult](t, rec).Validation.FailedChecks)
+
+	// Another run is submitting the window
+	lock, err := storage.LockWindow(f.store.baseDir, "ET-0001", "2025-Q4")
+	require.NoError(t, err)
+	defer lock.Unlock()
+	rec = f.do(t, http.MethodPost, "/api/v1/tasks/ET-0001/windows/2025-Q4/submit", "", header)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "locked by PID")
+}
+
+func TestServer_ReadOnly(t *testing.T) {
+	t.Parallel()
+
+	log, err := logger.NewTestLogger()
+	require.NoError(t, err)
+	f := newFixture(t, Options{})
+	handler := NewServer(f.tasks, &fakeScanner{}, f.store, nil, Options{Token: "s3cret"}, log).Handler()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks/ET-0001/windows/2025-Q4/submit", nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestServer_Token(t *testing.T) {
+	t.Parallel()
+	f := newFixture(t, Options{Token: "s3cret"})
+
+	tests := map[string]struct {
+		path   string
+		auth   string
+		status int
+	}{
+		"health is public": {"/api/v1/health", "", http.StatusOK},
+		"missing token":    {"/api/v1/tasks/summary", "", http.StatusUnauthorized},
+		"wrong token":      {"/api/v1/tasks/summary", "Bearer nope", http.StatusUnauthorized},
+		"valid token":      {"/api/v1/tasks/summary", "Bearer s3cret", http.StatusOK},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			header := http.Header{}
+			if tt.auth != "" {
+				header.Set("Authorization", tt.auth)
+			}
+			rec := f.do(t, http.MethodGet, tt.path, "", header)
+			assert.Equal(t, tt.status, rec.Code)
+			if tt.status == http.StatusUnauthorized {
+				assert.Equal(t, `Bearer realm="grctool"`, rec.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestServer_SubmitRequiresToken(t *testing.T) {
+	t.Parallel()
+	f := newFixture(t, Options{})
+
+	rec := f.do(t, http.MethodPost, "/api/v1/tasks/ET-0001/windows/2025-Q4/submit", `{"skip_validation": true}`,
+		http.Header{"Content-Type": {"application/json"}})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, decode[ErrorResult](t, rec).Error, "requires an API token")
+	assert.Empty(t, f.submitter.requests)
+}
+
+func TestServer_RequestChecks(t *testing.T) {
+	t.Parallel()
+	f := newFixture(t, Options{Token: "s3cret", AllowedHosts: []string{"localhost", "127.0.0.1", "::1"}})
+
+	const submitPath = "/api/v1/tasks/ET-0001/windows/2025-Q4/submit"
+	tests := map[string]struct {
+		method string
+		path   string
+		host   string
+		header http.Header
+		status int
+	}{
+		"allowed host": {
+			method: http.MethodGet, path: "/api/v1/health", host: "localhost:8080",
+			status: http.StatusOK,
+		},
+		"allowed ipv6 host": {
+			method: http.MethodGet, path: "/api/v1/health", host: "[::1]:8080",
+			status: http.StatusOK,
+		},
+		"rebound host": {
+			method: http.MethodGet, path: "/api/v1/health", host: "attacker.example:8080",
+			status: http.StatusMisdirectedRequest,
+		},
+		"rebound host on dashboard": {
+			method: http.MethodGet, path: "/", host: "attacker.example",
+			status: http.StatusMisdirectedRequest,
+		},
+		"json submit": {
+			method: http.MethodPost, path: submitPath, host: "127.0.0.1:8080",
+			header: http.Header{"Content-Type": {"application/json; charset=utf-8"}},
+			status: http.StatusOK,
+		},
+		"same-origin submit": {
+			method: http.MethodPost, path: submitPath, host: "localhost:8080",
+			header: http.Header{"Content-Type": {"application/json"}, "Origin": {"http://localhost:8080"}},
+			status: http.StatusOK,
+		},
+		"form submit": {
+			method: http.MethodPost, path: submitPath, host: "localhost:8080",
+			header: http.Header{"Content-Type": {"application/x-www-form-urlencoded"}},
+			status: http.StatusUnsupportedMediaType,
+		},
+		"text submit": {
+			method: http.MethodPost, path: submitPath, host: "localhost:8080",
+			header: http.Header{"Content-Type": {"text/plain"}},
+			status: http.StatusUnsupportedMediaType,
+		},
+		"submit without content type": {
+			method: http.MethodPost, path: submitPath, host: "localhost:8080",
+			status: http.StatusUnsupportedMediaType,
+		},
+		"cross-origin submit": {
+			method: http.MethodPost, path: submitPath, host: "localhost:8080",
+			header: http.Header{"Content-Type": {"application/json"}, "Origin": {"https://attacker.example"}},
+			status: http.StatusForbidden,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(`{"dry_run": true}`))
+			req.Host = tt.host
+			for key, values := range tt.header {
+				req.Header[key] = values
+			}
+			req.Header.Set("Authorization", "Bearer s3cret")
+			rec := httptest.NewRecorder()
+			f.handler.ServeHTTP(rec, req)
+			assert.Equal(t, tt.status, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+type fakeWebhooks struct {
+	events []webhook.Event
+	err    error
+}
+
+func (f *fakeWebhooks) Handle(ctx context.Context, event webhook.Event) (*webhook.Result, error) {
+	f.events = append(f.events, event)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &webhook.Result{Event: event.Type, TaskRef: "ET-0001", Window: event.Window, Status: "accepted"}, nil
+}
+
+func TestServer_Webhook(t *testing.T) {
+	t.Parallel()
+
+	body := `{"event":"evidence.accepted","task_id":"327992","window":"2025-Q4"}`
+
+	tests := map[string]struct {
+		body       string
+		header     http.Header
+		handlerErr error
+		status     int
+	}{
+		"signed": {
+			body:   body,
+			header: http.Header{webhook.SignatureHeader: {webhook.Sign("hook-secret", []byte(body))}},
+			status: http.StatusOK,
+		},
+		"bearer secret": {
+			body:   body,
+			header: http.Header{"Authorization": {"Bearer hook-secret"}},
+			status: http.StatusOK,
+		},
+		"api token is not enough": {
+			body:   body,
+			header: http.Header{"Authorization": {"Bearer s3cret"}},
+			status: http.StatusUnauthorized,
+		},
+		"bad signature": {
+			body:   body,
+			header: http.Header{webhook.SignatureHeader: {webhook.Sign("other", []byte(body))}},
+			status: http.StatusUnauthorized,
+		},
+		"invalid event": {
+			body:   `{"event":"policy.updated","task_id":"1"}`,
+			header: http.Header{"Authorization": {"Bearer hook-secret"}},
+			status: http.StatusBadRequest,
+		},
+		"unknown task": {
+			body:       body,
+			header:     http.Header{"Authorization": {"Bearer hook-secret"}},
+			handlerErr: fmt.Errorf("%w: 327992", webhook.ErrUnknownTask),
+			status:     http.StatusNotFound,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			hooks := &fakeWebhooks{err: tt.handlerErr}
+			f := newFixture(t, Options{Token: "s3cret", Webhooks: hooks, WebhookSecret: "hook-secret"})
+
+			header := tt.header.Clone()
+			header.Set("Content-Type", "application/json")
+			rec := f.do(t, http.MethodPost, "/api/v1/webhooks/tugboat", tt.body, header)
+			assert.Equal(t, tt.status, rec.Code, rec.Body.String())
+			if tt.status == http.StatusOK {
+				require.Len(t, hooks.events, 1)
+				assert.Equal(t, "2025-Q4", hooks.events[0].Window)
+				assert.Equal(t, "accepted", decode[webhook.Result](t, rec).Status)
+			}
+		})
+	}
+
+	// Without a handler the endpoint does not exist
+	f := newFixture(t, Options{})
+	jsonHeader := http.Header{"Content-Type": {"application/json"}}
+	assert.Equal(t, http.StatusNotFound, f.do(t, http.MethodPost, "/api/v1/webhooks/tugboat", body, jsonHeader).Code)
+}
+
+func TestNormalizeTaskRef(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"ET-1":    "ET-0001",
+		"et-0042": "ET-0042",
+		"ET-0001": "ET-0001",
+		"327992":  "327992",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, normalizeTaskRef(in), in)
+	}
+}
+
+func TestServer_Dashboard(t *testing.T) {
+	t.Parallel()
+
+	withDashboard := newFixture(t, Options{Dashboard: true, Token: "s3cret"})
+
+	tests := map[string]struct {
+		method      string
+		path        string
+		status      int
+		contentType string
+		contains    string
+	}{
+		"index":          {http.MethodGet, "/", http.StatusOK, "text/html", "<title>GRCTool Dashboard</title>"},
+		"script":         {http.MethodGet, "/app.js", http.StatusOK, "javascript", "function route()"},
+		"stylesheet":     {http.MethodGet, "/style.css", http.StatusOK, "text/css", ".badge"},
+		"missing asset":  {http.MethodGet, "/nope.js", http.StatusNotFound, "", ""},
+		"wrong method":   {http.MethodPost, "/", http.StatusMethodNotAllowed, "", ""},
+		"api still JSON": {http.MethodGet, "/api/v1/unknown", http.StatusUnauthorized, "application/json", "bearer token"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			rec := withDashboard.do(t, tt.method, tt.path, "", nil)
+			assert.Equal(t, tt.status, rec.Code)
+			if tt.contentType != "" {
+				assert.Contains(t, rec.Header().Get("Content-Type"), tt.contentType)
+			}
+			if tt.contains != "" {
+				assert.Contains(t, rec.Body.String(), tt.contains)
+			}
+			if tt.status == http.StatusOK {
+				assert.Contains(t, rec.Header().Get("Content-Security-Policy"), "default-src 'self'")
+			}
+		})
+	}
+
+	withoutDashboard := newFixture(t, Options{})
+	rec := withoutDashboard.do(t, http.MethodGet, "/", "", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+type fakeAcknowledgments struct {
+	saved map[string]*domain.PolicyAcknowledgments
+}
+
+func (f *fakeAcknowledgments) GetPolicyAcknowledgments(policy, period string) (*domain.PolicyAcknowledgments, error) {
+	if acks, ok := f.saved[policy+"/"+period]; ok {
+		return acks, nil
+	}
+	return &domain.PolicyAcknowledgments{Policy: policy, Period: period}, nil
+}
+
+func (f *fakeAcknowledgments) SavePolicyAcknowledgments(acks *domain.PolicyAcknowledgments) error {
+	f.saved[acks.Policy+"/"+acks.Period] = acks
+	return nil
+}
+
+func TestServer_Acknowledgment(t *testing.T) {
+	t.Parallel()
+
+	acks := &fakeAcknowledgments{saved: map[string]*domain.PolicyAcknowledgments{}}
+	f := newFixture(t, Options{Token: "s3cret", Acknowledgments: acks, AcknowledgmentSecret: "ack-secret"})
+	link := AcknowledgmentLink("https://grc.example.com/", "ack-secret", "acceptable-use-policy", "2025", "Alex@Example.com", "1.2")
+	assert.True(t, strings.HasPrefix(link, "https://grc.example.com/ack/acceptable-use-policy/2025?"))
+	path := strings.TrimPrefix(link, "https://grc.example.com")
+
+	rec := f.do(t, http.MethodGet, path, "", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "<form")
+	assert.Contains(t, rec.Body.String(), "alex@example.com")
+
+	form := http.Header{"Content-Type": {"application/x-www-form-urlencoded"}}
+	rec = f.do(t, http.MethodPost, path, "name=Alex+Kim", form)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = f.do(t, http.MethodPost, path, "name=Alex+Kim&agree=yes", form)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Thank you")
+
+	saved := acks.saved["acceptable-use-policy/2025"]
+	require.NotNil(t, saved)
+	require.Len(t, saved.Acknowledgments, 1)
+	ack := saved.Acknowledgments[0]
+	assert.Equal(t, "alex@example.com", ack.Email)
+	assert.Equal(t, "Alex Kim", ack.Name)
+	assert.Equal(t, "1.2", ack.Version)
+	assert.Equal(t, domain.AcknowledgmentSourceLink, ack.Source)
+
+	// Tampered links are rejected
+	for _, tampered := range []string{
+		strings.Replace(path, "alex%40example.com", "sam%40example.com", 1),
+		strings.Replace(path, "/2025?", "/2026?", 1),
+		strings.Replace(path, "version=1.2", "version=2.0", 1),
+		"/ack/acceptable-use-policy/2025?email=alex%40example.com",
+	} {
+		assert.Equal(t, http.StatusForbidden, f.do(t, http.MethodGet, tampered, "", nil).Code, tampered)
+	}
+
+	// Without a store or secret the links are not served
+	without := newFixture(t, Options{Dashboard: true})
+	assert.NotEqual(t, http.StatusOK, without.do(t, http.MethodPost, path, "agree=yes", form).Code)
+}
@@ -0,0 +1,327 @@
+// GRCTool dashboard: a dependency-free client for the /api/v1 endpoints.
+'use strict';
+
+const API = 'api/v1';
+const TOKEN_KEY = 'grctool.token';
+
+const STATES = ['no_evidence', 'generated', 'validated', 'submitted', 'accepted', 'rejected'];
+const STATE_COLORS = {
+  no_evidence: '#d1d9e0',
+  generated: '#d4a72c',
+  validated: '#bf8700',
+  submitted: '#4ac26b',
+  accepted: '#1a7f37',
+  rejected: '#cf222e',
+};
+
+class AuthError extends Error {}
+
+class NotFoundError extends Error {}
+
+// el builds a DOM element; children may be strings, nodes, arrays or null.
+// Text is always inserted as text nodes, never parsed as HTML.
+function el(tag, attrs, ...children) {
+  const node = document.createElement(tag);
+  for (const [key, value] of Object.entries(attrs || {})) {
+    if (value === null || value === undefined || value === false) continue;
+    if (key.startsWith('on')) node.addEventListener(key.slice(2), value);
+    else if (key === 'style') node.style.cssText = value;
+    else node.setAttribute(key, value);
+  }
+  for (const child of children.flat()) {
+    if (child === null || child === undefined || child === false) continue;
+    node.append(child instanceof Node ? child : String(child));
+  }
+  return node;
+}
+
+function authHeaders() {
+  const token = sessionStorage.getItem(TOKEN_KEY);
+  return token ? { Authorization: 'Bearer ' + token } : {};
+}
+
+async function request(path) {
+  const resp = await fetch(path, { headers: authHeaders() });
+  if (resp.status === 401) throw new AuthError('unauthorized');
+  if (resp.status === 404) throw new NotFoundError(path);
+  if (!resp.ok) {
+    let message = resp.statusText;
+    try { message = (await resp.json()).error || message; } catch (e) { /* not JSON */ }
+    throw new Error(message);
+  }
+  return resp;
+}
+
+async function api(path) {
+  return (await request(API + path)).json();
+}
+
+// apiOptional returns null instead of failing when the resource does not exist
+async function apiOptional(path) {
+  try {
+    return await api(path);
+  } catch (err) {
+    if (err instanceof NotFoundError) return null;
+    throw err;
+  }
+}
+
+const enc = encodeURIComponent;
+
+function formatDate(value) {
+  if (!value || value.startsWith('0001-')) return '—';
+  return new Date(value).toISOString().slice(0, 10);
+}
+
+function formatBytes(bytes) {
+  if (bytes < 1024) return bytes + ' B';
+  if (bytes < 1024 * 1024) return (bytes / 1024).toFixed(1) + ' KB';
+  return (bytes / 1024 / 1024).toFixed(1) + ' MB';
+}
+
+function label(value) {
+  return String(value || 'unknown').replace(/_/g, ' ');
+}
+
+function stateBadge(state) {
+  return el('span', { class: 'badge state-' + state }, label(state));
+}
+
+function taskLink(ref, text) {
+  return el('a', { href: '#/tasks/' + enc(ref) }, text || ref);
+}
+
+// Views
+
+async function renderDashboard(app) {
+  const [status, summary] = await Promise.all([api('/status'), api('/tasks/summary')]);
+  const total = status.total_tasks || 0;
+  const byState = status.by_state || {};
+  const done = (byState.submitted || 0) + (byState.accepted || 0);
+
+  const cards = el('div', { class: 'cards' },
+    card(total, 'Evidence tasks'),
+    card(total ? Math.round((done / total) * 100) + '%' : '—', 'Submitted or accepted'),
+    card(summary.overdue || 0, 'Overdue'),
+    card(summary.due_soon || 0, 'Due soon'),
+    STATES.map((state) => card(byState[state] || 0, label(state))));
+
+  const progress = el('div', { class: 'progress', title: 'Local evidence state' },
+    STATES.filter((state) => byState[state]).map((state) => el('span', {
+      style: `width:${(byState[state] / total) * 100}%;background:${STATE_COLORS[state]}`,
+      title: `${label(state)}: ${byState[state]}`,
+    })));
+
+  const rows = (status.tasks || []).map((task) => {
+    const windows = Object.keys(task.windows || {}).sort().reverse();
+    return el('tr', null,
+      el('td', null, taskLink(task.task_ref)),
+      el('td', null, task.task_name),
+      el('td', null, stateBadge(task.local_state)),
+      el('td', null, label(task.automation_level)),
+      el('td', null, windows.length ? windows.join(', ') : '—'),
+      el('td', null, formatDate(task.last_generated_at)),
+      el('td', null, formatDate(task.last_submitted_at)));
+  });
+
+  app.replaceChildren(
+    el('h1', null, 'Evidence Status'),
+    cards,
+    el('h2', null, 'Progress'),
+    progress,
+    el('h2', null, 'Tasks'),
+    table(['Task', 'Name', 'State', 'Automation', 'Windows', 'Generated', 'Submitted'], rows),
+    el('p', { class: 'meta' }, 'Scanned ' + new Date(status.scanned_at).toLocaleString()));
+}
+
+function card(value, text) {
+  return el('div', { class: 'card' }, el('div', { class: 'value' }, value), el('div', { class: 'label' }, text));
+}
+
+function table(headings, rows) {
+  return el('table', null,
+    el('thead', null, el('tr', null, headings.map((h) => el('th', null, h)))),
+    el('tbody', null, rows.length ? rows : el('tr', null, el('td', { colspan: headings.length, class: 'meta' }, 'Nothing to show'))));
+}
+
+async function renderTasks(app, params) {
+  const query = new URLSearchParams();
+  for (const key of ['status', 'framework', 'assignee', 'overdue', 'due_soon']) {
+    if (params.get(key)) query.set(key, params.get(key));
+  }
+  const result = await api('/tasks?' + query.toString());
+  const search = (params.get('q') || '').toLowerCase();
+  const tasks = result.tasks.filter((task) => !search ||
+    (task.reference_id + ' ' + task.name).toLowerCase().includes(search));
+
+  const filters = el('form', { class: 'filters', onsubmit: (event) => {
+    event.preventDefault();
+    const form = new URLSearchParams(new FormData(event.target));
+    for (const [key, value] of [...form.entries()]) if (!value) form.delete(key);
+    location.hash = '#/tasks?' + form.toString();
+  } },
+    el('input', { name: 'q', placeholder: 'Search', value: params.get('q') || '' }),
+    el('input', { name: 'status', placeholder: 'Status', value: params.get('status') || '' }),
+    el('input', { name: 'framework', placeholder: 'Framework', value: params.get('framework') || '' }),
+    el('input', { name: 'assignee', placeholder: 'Assignee', value: params.get('assignee') || '' }),
+    select('overdue', params.get('overdue'), ['', 'true'], 'overdue only'),
+    select('due_soon', params.get('due_soon'), ['', 'true'], 'due soon only'),
+    el('button', { type: 'submit' }, 'Filter'));
+
+  const rows = tasks.map((task) => el('tr', null,
+    el('td', null, taskLink(task.reference_id || task.id)),
+    el('td', null, task.name),
+    el('td', null, label(task.status)),
+    el('td', null, task.framework || '—'),
+    el('td', null, task.collection_interval || '—'),
+    el('td', null, formatDate(task.next_due)),
+    el('td', null, (task.assignees || []).map((person) => person.name || person.email).join(', ') || '—')));
+
+  app.replaceChildren(
+    el('h1', null, `Evidence Tasks (${tasks.length})`),
+    filters,
+    table(['Task', 'Name', 'Status', 'Framework', 'Interval', 'Next due', 'Assignees'], rows));
+}
+
+function select(name, value, options, allLabel) {
+  return el('select', { name },
+    options.map((option) => el('option', { value: option, selected: option === (value || '') },
+      option === '' ? 'any ' + label(name) : (allLabel || label(option)))));
+}
+
+async function renderTask(app, ref) {
+  const [task, status] = await Promise.all([api('/tasks/' + enc(ref)), apiOptional('/status/' + enc(ref))]);
+  const taskRef = task.reference_id || ref;
+  const windows = Object.keys((status && status.windows) || {}).sort().reverse();
+
+  const details = el('table', null, el('tbody', null,
+    detailRow('State', status ? stateBadge(status.local_state) : '—'),
+    detailRow('Tugboat status', label(task.status)),
+    detailRow('Framework', task.framework || '—'),
+    detailRow('Next due', formatDate(task.next_due)),
+    detailRow('Assignees', (task.assignees || []).map((p) => p.name || p.email).join(', ') || '—'),
+    detailRow('Automation', status ? label(status.automation_level) : '—'),
+    detailRow('Tools', status && status.applicable_tools ? status.applicable_tools.join(', ') : '—'),
+    task.tugboat_url ? detailRow('Tugboat', el('a', { href: task.tugboat_url, target: '_blank', rel: 'noopener' }, 'Open in Tugboat Logic')) : null));
+
+  const windowSections = await Promise.all(windows.map((name) => renderWindow(taskRef, status.windows[name])));
+
+  app.replaceChildren(
+    el('p', null, el('a', { href: '#/tasks' }, '← All tasks')),
+    el('h1', null, `${taskRef}: ${task.name}`),
+    details,
+    task.description ? [el('h2', null, 'Description'), el('p', null, task.description)] : null,
+    el('h2', null, 'Evidence Windows'),
+    windowSections.length ? windowSections : el('p', { class: 'meta' }, 'No evidence collected yet.'),
+    el('div', { id: 'preview' }));
+}
+
+function detailRow(name, value) {
+  return el('tr', null, el('th', { style: 'width:180px' }, name), el('td', null, value));
+}
+
+async function renderWindow(ref, window) {
+  const [files, validation] = await Promise.all([
+    apiOptional(`/tasks/${enc(ref)}/windows/${enc(window.window)}/files`),
+    apiOptional(`/tasks/${enc(ref)}/windows/${enc(window.window)}/validation`),
+  ]);
+
+  const pending = (files && files.files) || [];
+  const fileRows = pending.map((file) => el('tr', null,
+    el('td', null, el('a', { href: '#', onclick: (event) => {
+      event.preventDefault();
+      previewFile(ref, window.window, file.filename);
+    } }, file.filename)),
+    el('td', null, formatBytes(file.size_bytes)),
+    el('td', null, file.source || '—')));
+
+  return el('section', { class: 'window' },
+    el('h3', null, window.window, ' ',
+      window.submission_status ? el('span', { class: 'badge state-' + window.submission_status }, label(window.submission_status)) : null),
+    el('p', { class: 'meta' },
+      `${window.file_count} files, ${formatBytes(window.total_bytes || 0)}`,
+      window.generated_at ? ` · generated ${formatDate(window.generated_at)} by ${window.generated_by || 'unknown'}` : '',
+      window.submitted_at ? ` · submitted ${formatDate(window.submitted_at)}` : ''),
+    validation ? renderValidation(validation) : el('p', { class: 'meta' }, 'Not validated'),
+    files && files.already_submitted ? el('p', { class: 'meta' }, 'Evidence has been submitted; files are archived in .submitted/.') : null,
+    pending.length ? table(['File', 'Size', 'Source'], fileRows) : null);
+}
+
+function renderValidation(result) {
+  const checks = (result.checks || []).map((check) => el('tr', null,
+    el('td', null, el('span', { class: 'badge status-' + check.status }, check.status)),
+    el('td', null, check.name || check.code),
+    el('td', null, check.message)));
+
+  return el('div', null,
+    el('p', null,
+      el('span', { class: 'score' }, Math.round((result.completeness_score || 0) * 100) + '%'),
+      ' completeness · ',
+      el('span', { class: 'badge status-' + result.status }, result.status),
+      ` · ${result.passed_checks}/${result.total_checks} checks passed`,
+      result.ready_for_submission ? ' · ready for submission' : '',
+      el('span', { class: 'meta' }, ' · validated ' + formatDate(result.validation_timestamp))),
+    checks.length ? el('details', null, el('summary', null, 'Validation checks'), table(['Status', 'Check', 'Message'], checks)) : null);
+}
+
+const TEXT_FILE = /\.(md|markdown|txt|csv|json|ya?ml|log|tf|hcl)$/i;
+
+async function previewFile(ref, window, filename) {
+  const preview = document.getElementById('preview');
+  const resp = await request(`${API}/tasks/${enc(ref)}/windows/${enc(window)}/files/${enc(filename)}`);
+  if (!TEXT_FILE.test(filename)) {
+    const url = URL.createObjectURL(await resp.blob());
+    const link = el('a', { href: url, download: filename }, filename);
+    document.body.append(link);
+    link.click();
+    link.remove();
+    URL.revokeObjectURL(url);
+    return;
+  }
+  preview.replaceChildren(
+    el('h2', null, `${window} / ${filename}`),
+    el('pre', { class: 'preview' }, await resp.text()));
+  preview.scrollIntoView({ behavior: 'smooth' });
+}
+
+// Routing
+
+async function route() {
+  const app = document.getElementById('app');
+  const [path, queryString] = location.hash.replace(/^#/, '').split('?');
+  const params = new URLSearchParams(queryString || '');
+  const parts = path.split('/').filter(Boolean);
+
+  document.getElementById('login').hidden = true;
+  app.hidden = false;
+  app.replaceChildren(el('p', { class: 'meta' }, 'Loading…'));
+  try {
+    if (parts[0] === 'tasks' && parts[1]) await renderTask(app, decodeURIComponent(parts[1]));
+    else if (parts[0] === 'tasks') await renderTasks(app, params);
+    else await renderDashboard(app);
+  } catch (err) {
+    if (err instanceof AuthError) {
+      sessionStorage.removeItem(TOKEN_KEY);
+      app.hidden = true;
+      document.getElementById('login').hidden = false;
+      document.getElementById('token').focus();
+      return;
+    }
+    app.replaceChildren(el('p', { class: 'error' }, err instanceof NotFoundError ? 'Not found.' : 'Error: ' + err.message));
+  }
+}
+
+document.getElementById('login').addEventListener('submit', (event) => {
+  event.preventDefault();
+  sessionStorage.setItem(TOKEN_KEY, document.getElementById('token').value);
+  document.getElementById('token').value = '';
+  route();
+});
+
+window.addEventListener('hashchange', route);
+
+fetch(API + '/health').then((resp) => resp.json()).then((health) => {
+  if (health.version) document.getElementById('version').textContent = 'grctool ' + health.version;
+}).catch(() => {});
+
+route();
@@ -0,0 +1,249 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package calendar projects evidence task deadlines onto a calendar and
+// exports them as iCalendar (.ics) feeds.
+package calendar
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+)
+
+// Event sources
+const (
+	SourceSchedule = "schedule" // Projected from the task's collection interval
+	SourceTugboat  = "tugboat"  // Next due date synced from Tugboat
+)
+
+// Event is an evidence deadline on a single day
+type Event struct {
+	UID       string    `json:"uid"`
+	TaskRef   string    `json:"task_ref"`
+	TaskName  string    `json:"task_name"`
+	Framework string    `json:"framework,omitempty"`
+	Interval  string    `json:"interval,omitempty"`
+	Window    string    `json:"window,omitempty"` // Collection window the deadline closes, e.g. 2025-Q4
+	Date      time.Time `json:"date"`             // Due date, midnight UTC
+	Source    string    `json:"source"`
+	Owners    []string  `json:"owners,omitempty"`
+	URL       string    `json:"url,omitempty"`
+}
+
+// Options restricts the projected events
+type Options struct {
+	From      time.Time // First day included
+	To        time.Time // First day excluded
+	Assignee  string    // Only tasks assigned to this name or email
+	Framework string    // Only tasks in this framework
+}
+
+// period is one collection window; end is exclusive
+type period struct {
+	label      string
+	start, end time.Time
+}
+
+// Project returns the deadlines of the tasks between opts.From and opts.To,
+// sorted by date. Recurring deadlines fall DueDaysBefore days before the end
+// of each collection window; a synced next due date is added when it does not
+// match a projected deadline.
+func Project(tasks []domain.EvidenceTask, opts Options) []Event {
+	from := day(opts.From)
+	to := day(opts.To)
+
+	var events []Event
+	for _, task := range tasks {
+		if !matchesTask(task, opts) {
+			continue
+		}
+
+		projected := make(map[time.Time]bool)
+		if !task.AdHoc {
+			for _, p := range periodsBetween(task.CollectionInterval, from, to) {
+				due := dueDate(p, task.DueDaysBefore)
+				if due.Before(from) || !due.Before(to) {
+					continue
+				}
+				projected[due] = true
+				events = append(events, newEvent(task, p.label, due, SourceSchedule))
+			}
+		}
+
+		if task.NextDue != nil {
+			due := day(*task.NextDue)
+			if !projected[due] && !due.Before(from) && due.Before(to) {
+				events = append(events, newEvent(task, "", due, SourceTugboat))
+			}
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].Date.Equal(events[j].Date) {
+			return events[i].Date.Before(events[j].Date)
+		}
+		return events[i].TaskRef < events[j].TaskRef
+	})
+	return events
+}
+
+func newEvent(task domain.EvidenceTask, window string, due time.Time, source string) Event {
+	ref := task.ReferenceID
+	if ref == "" {
+		ref = task.ID
+	}
+	key := window
+	if key == "" {
+		key = due.Format("20060102")
+	}
+
+	event := Event{
+		UID:       fmt.Sprintf("%s-%s@grctool", ref, key),
+		TaskRef:   ref,
+		TaskName:  task.Name,
+		Framework: task.Framework,
+		Interval:  task.CollectionInterval,
+		Window:    window,
+		Date:      due,
+		Source:    source,
+		URL:       task.TugboatURL,
+	}
+	for _, person := range task.Assignees {
+		event.Owners = append(event.Owners, personLabel(person))
+	}
+	return event
+}
+
+func matchesTask(task domain.EvidenceTask, opts Options) bool {
+	if opts.Framework != "" && !task.HasFramework(opts.Framework) {
+		return false
+	}
+	if opts.Assignee == "" {
+		return true
+	}
+	for _, person := range task.Assignees {
+		if strings.EqualFold(person.Email, opts.Assignee) || strings.EqualFold(person.Name, opts.Assignee) {
+			return true
+		}
+	}
+	return false
+}
+
+func personLabel(person domain.Person) string {
+	switch {
+	case person.Name != "" && person.Email != "":
+		return fmt.Sprintf("%s <%s>", person.Name, person.Email)
+	case person.Name != "":
+		return person.Name
+	default:
+		return person.Email
+	}
+}
+
+// dueDate is the last day of the period minus the task's lead time, never
+// earlier than the first day of the period
+func dueDate(p period, dueDaysBefore int) time.Time {
+	due := p.end.AddDate(0, 0, -1-dueDaysBefore)
+	if due.Before(p.start) {
+		return p.start
+	}
+	return due
+}
+
+// periodsBetween lists the collection windows of an interval that start
+// before to and end after from. Window labels match the evidence directory
+// names (2025, 2025-H2, 2025-Q4, 2025-10). Unknown intervals have no periods.
+func periodsBetween(interval string, from, to time.Time) []period {
+	next, start := periodStepper(interval, from)
+	if next == nil {
+		return nil
+	}
+
+	var periods []period
+	for p := next(start); p.start.Before(to); p = next(p.end) {
+		if p.end.After(from) {
+			periods = append(periods, p)
+		}
+	}
+	return periods
+}
+
+// periodStepper returns a function building the period that starts at a
+// given time, and the start of the period containing from
+func periodStepper(interval string, from time.Time) (func(time.Time) period, time.Time) {
+	if isWeekly(interval) {
+		offset := (int(from.Weekday()) + 6) % 7 // Days since Monday
+		start := from.AddDate(0, 0, -offset)
+		return func(start time.Time) period {
+			year, week := start.ISOWeek()
+			return period{label: fmt.Sprintf("%d-W%02d", year, week), start: start, end: start.AddDate(0, 0, 7)}
+		}, start
+	}
+
+	months := intervalMonths(interval)
+	if months == 0 {
+		return nil, time.Time{}
+	}
+	first := time.Month((int(from.Month())-1)/months*months + 1)
+	start := time.Date(from.Year(), first, 1, 0, 0, 0, 0, time.UTC)
+	return func(start time.Time) period {
+		return period{label: windowLabel(start, months), start: start, end: start.AddDate(0, months, 0)}
+	}, start
+}
+
+func isWeekly(interval string) bool {
+	switch strings.ToLower(interval) {
+	case "week", "weekly":
+		return true
+	}
+	return false
+}
+
+func intervalMonths(interval string) int {
+	switch strings.ToLower(interval) {
+	case "year", "annual", "annually":
+		return 12
+	case "six_month", "semi-annual", "semiannual":
+		return 6
+	case "quarter", "quarterly":
+		return 3
+	case "month", "monthly":
+		return 1
+	default:
+		return 0
+	}
+}
+
+func windowLabel(start time.Time, months int) string {
+	switch months {
+	case 12:
+		return fmt.Sprintf("%d", start.Year())
+	case 6:
+		return fmt.Sprintf("%d-H%d", start.Year(), (int(start.Month())-1)/6+1)
+	case 3:
+		return fmt.Sprintf("%d-Q%d", start.Year(), (int(start.Month())-1)/3+1)
+	default:
+		return fmt.Sprintf("%d-%02d", start.Year(), start.Month())
+	}
+}
+
+// day truncates t to midnight UTC of its calendar date
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
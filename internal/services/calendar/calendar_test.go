@@ -0,0 +1,136 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestProject(t *testing.T) {
+	t.Parallel()
+
+	nextDue := date(2025, 11, 20)
+	tasks := []domain.EvidenceTask{
+		{
+			ReferenceID:        "ET-0002",
+			Name:               "Access Review",
+			CollectionInterval: "quarter",
+			DueDaysBefore:      14,
+			Framework:          "SOC2",
+			Assignees:          []domain.Person{{Name: "Jane Doe", Email: "jane@example.com"}},
+			NextDue:            &nextDue,
+		},
+		{
+			ReferenceID:        "ET-0001",
+			Name:               "Vulnerability Scans",
+			CollectionInterval: "month",
+			Framework:          "SOC2",
+		},
+		{
+			ReferenceID:        "ET-0003",
+			Name:               "Annual Pen Test",
+			CollectionInterval: "year",
+			Framework:          "ISO27001",
+		},
+		{
+			ReferenceID: "ET-0004",
+			Name:        "Incident Postmortems",
+			AdHoc:       true,
+		},
+	}
+
+	events := Project(tasks, Options{From: date(2025, 10, 15), To: date(2026, 1, 15)})
+
+	var got []string
+	for _, event := range events {
+		got = append(got, event.Date.Format("2006-01-02")+" "+event.TaskRef+" "+event.Window)
+	}
+	assert.Equal(t, []string{
+		"2025-10-31 ET-0001 2025-10",
+		"2025-11-20 ET-0002 ",
+		"2025-11-30 ET-0001 2025-11",
+		"2025-12-17 ET-0002 2025-Q4",
+		"2025-12-31 ET-0001 2025-12",
+		"2025-12-31 ET-0003 2025",
+	}, got)
+
+	review := events[3]
+	assert.Equal(t, "ET-0002-2025-Q4@grctool", review.UID)
+	assert.Equal(t, SourceSchedule, review.Source)
+	assert.Equal(t, []string{"Jane Doe <jane@example.com>"}, review.Owners)
+
+	synced := events[1]
+	assert.Equal(t, SourceTugboat, synced.Source)
+	assert.Equal(t, "ET-0002-20251120@grctool", synced.UID)
+}
+
+func TestProject_Filters(t *testing.T) {
+	t.Parallel()
+
+	tasks := []domain.EvidenceTask{
+		{ReferenceID: "ET-0001", CollectionInterval: "quarter", Framework: "SOC2", Assignees: []domain.Person{{Email: "Jane@Example.com"}}},
+		{ReferenceID: "ET-0002", CollectionInterval: "quarter", Framework: "ISO27001", Assignees: []domain.Person{{Email: "jane@example.com"}}},
+		{ReferenceID: "ET-0003", CollectionInterval: "quarter", Framework: "SOC2"},
+	}
+	opts := Options{From: date(2025, 1, 1), To: date(2026, 1, 1), Assignee: "jane@example.com", Framework: "soc2"}
+
+	events := Project(tasks, opts)
+	require.Len(t, events, 4)
+	for _, event := range events {
+		assert.Equal(t, "ET-0001", event.TaskRef)
+	}
+}
+
+func TestProject_SyncedDueMatchesSchedule(t *testing.T) {
+	t.Parallel()
+
+	nextDue := date(2025, 12, 31).Add(17 * time.Hour)
+	tasks := []domain.EvidenceTask{{ReferenceID: "ET-0001", CollectionInterval: "quarter", NextDue: &nextDue}}
+
+	events := Project(tasks, Options{From: date(2025, 10, 1), To: date(2026, 1, 1)})
+	require.Len(t, events, 1)
+	assert.Equal(t, SourceSchedule, events[0].Source)
+}
+
+func TestPeriodsBetween(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		interval string
+		want     []string
+	}{
+		"semi-annual": {interval: "six_month", want: []string{"2025-H2"}},
+		"weekly":      {interval: "weekly", want: []string{"2025-W52", "2026-W01"}},
+		"unknown":     {interval: "biennial"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var labels []string
+			for _, p := range periodsBetween(tt.interval, date(2025, 12, 24), date(2026, 1, 1)) {
+				labels = append(labels, p.label)
+			}
+			assert.Equal(t, tt.want, labels)
+		})
+	}
+}
+
+func TestDueDate_ClampedToPeriodStart(t *testing.T) {
+	t.Parallel()
+
+	p := period{start: date(2025, 10, 1), end: date(2025, 11, 1)}
+	assert.Equal(t, date(2025, 10, 31), dueDate(p, 0))
+	assert.Equal(t, date(2025, 10, 1), dueDate(p, 45))
+}
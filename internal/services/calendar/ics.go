@@ -0,0 +1,142 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calendar
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// icsLineLimit is the maximum line length in octets before folding (RFC 5545 3.1)
+const icsLineLimit = 75
+
+// ICSOptions controls the iCalendar feed
+type ICSOptions struct {
+	Name         string    // Calendar name shown by subscribing clients
+	ReminderDays int       // Days before the deadline to alert; zero disables reminders
+	Now          time.Time // DTSTAMP of the events (default: time.Now)
+}
+
+// WriteICS writes the events as an iCalendar feed of all-day events. Event
+// UIDs are stable, so re-importing or refreshing a subscription updates the
+// existing entries instead of duplicating them.
+func WriteICS(w io.Writer, events []Event, opts ICSOptions) error {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	stamp := now.UTC().Format("20060102T150405Z")
+
+	out := bufio.NewWriter(w)
+	write := func(name, value string) {
+		writeFolded(out, name+":"+value)
+	}
+
+	write("BEGIN", "VCALENDAR")
+	write("VERSION", "2.0")
+	write("PRODID", "-//grctool//Compliance Calendar//EN")
+	write("CALSCALE", "GREGORIAN")
+	write("METHOD", "PUBLISH")
+	if opts.Name != "" {
+		write("X-WR-CALNAME", escapeText(opts.Name))
+	}
+
+	for _, event := range events {
+		write("BEGIN", "VEVENT")
+		write("UID", escapeText(event.UID))
+		write("DTSTAMP", stamp)
+		write("DTSTART;VALUE=DATE", event.Date.Format("20060102"))
+		write("DTEND;VALUE=DATE", event.Date.AddDate(0, 0, 1).Format("20060102"))
+		write("SUMMARY", escapeText(eventSummary(event)))
+		write("DESCRIPTION", escapeText(eventDescription(event)))
+		if event.Framework != "" {
+			write("CATEGORIES", escapeText(event.Framework))
+		}
+		if event.URL != "" {
+			write("URL", event.URL)
+		}
+		write("TRANSP", "TRANSPARENT")
+		if opts.ReminderDays > 0 {
+			write("BEGIN", "VALARM")
+			write("ACTION", "DISPLAY")
+			write("DESCRIPTION", escapeText(eventSummary(event)))
+			write("TRIGGER", fmt.Sprintf("-P%dD", opts.ReminderDays))
+			write("END", "VALARM")
+		}
+		write("END", "VEVENT")
+	}
+
+	write("END", "VCALENDAR")
+	return out.Flush()
+}
+
+func eventSummary(event Event) string {
+	summary := fmt.Sprintf("%s due: %s", event.TaskRef, event.TaskName)
+	if event.Window != "" {
+		summary += " (" + event.Window + ")"
+	}
+	return summary
+}
+
+func eventDescription(event Event) string {
+	var lines []string
+	if event.Window != "" {
+		lines = append(lines, fmt.Sprintf("Evidence for the %s collection window of %s is due.", event.Window, event.TaskRef))
+	} else {
+		lines = append(lines, fmt.Sprintf("Next due date of %s from Tugboat.", event.TaskRef))
+	}
+	if event.Interval != "" {
+		lines = append(lines, "Collection interval: "+event.Interval)
+	}
+	if len(event.Owners) > 0 {
+		lines = append(lines, "Owners: "+strings.Join(event.Owners, ", "))
+	}
+	lines = append(lines, fmt.Sprintf("Generate with: grctool evidence generate %s", event.TaskRef))
+	return strings.Join(lines, "\n")
+}
+
+// escapeText escapes a TEXT property value (RFC 5545 3.3.11)
+func escapeText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(value)
+}
+
+// writeFolded writes a content line, folding it at 75 octets without
+// splitting UTF-8 characters
+func writeFolded(w *bufio.Writer, line string) {
+	limit := icsLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut])
+		w.WriteString("\r\n ")
+		line = line[cut:]
+		limit = icsLineLimit - 1 // Continuation lines start with a space
+	}
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package calendar
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteICS(t *testing.T) {
+	t.Parallel()
+
+	events := []Event{
+		{
+			UID:       "ET-0002-2025-Q4@grctool",
+			TaskRef:   "ET-0002",
+			TaskName:  "Access Review; privileged, and service accounts",
+			Framework: "SOC2",
+			Interval:  "quarter",
+			Window:    "2025-Q4",
+			Date:      date(2025, 12, 17),
+			Source:    SourceSchedule,
+			Owners:    []string{"Jane Doe <jane@example.com>"},
+			URL:       "https://app.tugboatlogic.com/org/1/evidence/tasks/2",
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteICS(&buf, events, ICSOptions{
+		Name:         "SOC 2, Deadlines",
+		ReminderDays: 7,
+		Now:          time.Date(2025, 10, 15, 9, 30, 0, 0, time.UTC),
+	}))
+	output := buf.String()
+
+	assert.True(t, strings.HasPrefix(output, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(output, "END:VEVENT\r\nEND:VCALENDAR\r\n"))
+	assert.Contains(t, output, "X-WR-CALNAME:SOC 2\\, Deadlines\r\n")
+	assert.Contains(t, output, "UID:ET-0002-2025-Q4@grctool\r\n")
+	assert.Contains(t, output, "DTSTAMP:20251015T093000Z\r\n")
+	assert.Contains(t, output, "DTSTART;VALUE=DATE:20251217\r\n")
+	assert.Contains(t, output, "DTEND;VALUE=DATE:20251218\r\n")
+	assert.Contains(t, output, "CATEGORIES:SOC2\r\n")
+	assert.Contains(t, output, "TRIGGER:-P7D\r\n")
+
+	unfolded := strings.ReplaceAll(output, "\r\n ", "")
+	assert.Contains(t, unfolded, "SUMMARY:ET-0002 due: Access Review\\; privileged\\, and service accounts (2025-Q4)\r\n")
+	assert.Contains(t, unfolded, "\\nOwners: Jane Doe <jane@example.com>\\n")
+
+	for _, line := range strings.Split(output, "\r\n") {
+		assert.LessOrEqual(t, len(line), icsLineLimit, "line %q is not folded", line)
+	}
+}
+
+func TestWriteICS_NoReminder(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteICS(&buf, []Event{{UID: "ET-0001-20251120@grctool", TaskRef: "ET-0001", Date: date(2025, 11, 20)}}, ICSOptions{}))
+
+	assert.NotContains(t, buf.String(), "VALARM")
+	assert.NotContains(t, buf.String(), "X-WR-CALNAME")
+	assert.Contains(t, buf.String(), "Next due date of ET-0001 from Tugboat.")
+}
+
+func TestWriteFolded_MultiByte(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	out := bufio.NewWriter(&buf)
+	line := "SUMMARY:" + strings.Repeat("é", 60)
+	writeFolded(out, line)
+	require.NoError(t, out.Flush())
+
+	for _, part := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(part), icsLineLimit)
+		assert.True(t, utf8.ValidString(strings.TrimPrefix(part, " ")), "fold splits a character: %q", part)
+	}
+	assert.Equal(t, line, strings.ReplaceAll(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n ", ""))
+}
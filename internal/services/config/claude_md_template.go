@@ -165,10 +165,17 @@ grctool tool github-permissions --repository owner/repo
 ### "What Terraform security evidence can be collected?"
 ` + "```bash" + `
 # Use the comprehensive indexer for fast queries
-grctool tool terraform-security-indexer --query-type control_mapping
+grctool tool terraform-security-indexer --query-type by_control
 
 # Or use the security analyzer for deep analysis
 grctool tool terraform-security-analyzer --security-domain all
+
+# Map the analysis to ISO 27001 Annex A or NIST 800-53 controls instead of SOC2
+grctool tool terraform-security-analyzer --framework iso27001
+grctool tool terraform-security-analyzer --framework nist80053
+
+# Evaluate CIS AWS/GCP Foundations benchmark items (pass/fail per item)
+grctool tool terraform-security-analyzer --cis-benchmark all --output-format summary_markdown
 ` + "```" + `
 
 ## 🔐 AUTHENTICATION
@@ -279,7 +286,7 @@ func getToolListings() (int, map[string][]ToolInfo, []string, []ToolInfo) {
 	// Define categories and their keywords
 	categories := map[string][]string{
 		"Evidence Analysis Tools":   {"evidence-task", "evidence-relationships", "prompt-assembler", "policy-summary", "control-summary"},
-		"Data Source Tools":         {"terraform", "github", "docs-reader", "google-workspace"},
+		"Data Source Tools":         {"terraform", "github", "docs-reader", "google-workspace", "vendor-review", "incident-log", "policy-review", "policy-acknowledgment"},
 		"Evidence Management Tools": {"evidence-generator", "evidence-validator", "evidence-writer", "storage-read", "storage-write", "tugboat-sync-wrapper", "grctool-run"},
 		"Utility Tools":             {"name-generator"},
 	}
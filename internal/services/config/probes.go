@@ -0,0 +1,127 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/grctool/grctool/internal/tugboat"
+	"golang.org/x/oauth2/google"
+)
+
+// probeTimeout bounds each credential check
+const probeTimeout = 15 * time.Second
+
+// githubAPIURL is the endpoint the GitHub token is checked against
+const githubAPIURL = "https://api.github.com/user"
+
+// googleProbeScope is requested when checking Google credentials; obtaining
+// a token for it reads nothing
+const googleProbeScope = "https://www.googleapis.com/auth/drive.metadata.readonly"
+
+// probeTugboat lists one evidence record, the connection test sync uses
+func probeTugboat(ctx context.Context, cfg *config.TugboatConfig) error {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	client := tugboat.NewClient(cfg, nil)
+	defer client.Close()
+	return client.TestConnection(ctx)
+}
+
+// probeGitHub fetches the user the token belongs to
+func probeGitHub(ctx context.Context, token string) error {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, githubAPIURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "token "+token)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("User-Agent", "grctool")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("GitHub API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return fmt.Errorf("token is invalid or expired")
+	case resp.StatusCode >= 400:
+		return fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// probeGoogle obtains an access token with the configured credentials. An
+// impersonated service account is not checked, as that needs IAM calls.
+func probeGoogle(ctx context.Context, cfg config.GoogleDocsToolConfig) error {
+	if cfg.ImpersonateServiceAccount != "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	var creds *google.Credentials
+	if cfg.CredentialsFile != "" && !cfg.UseDefaultCredentials {
+		data, err := os.ReadFile(cfg.CredentialsFile)
+		if err != nil {
+			return fmt.Errorf("failed to read credentials file: %w", err)
+		}
+		if creds, err = google.CredentialsFromJSON(ctx, data, googleProbeScope); err != nil {
+			return fmt.Errorf("invalid credentials file %s: %w", cfg.CredentialsFile, err)
+		}
+	} else {
+		var err error
+		if creds, err = google.FindDefaultCredentials(ctx, googleProbeScope); err != nil {
+			return fmt.Errorf("no Application Default Credentials: %w", err)
+		}
+	}
+
+	if _, err := creds.TokenSource.Token(); err != nil {
+		return fmt.Errorf("failed to obtain an access token: %w", err)
+	}
+	return nil
+}
+
+// storedTaskRefs returns the references of the evidence tasks in storage
+func storedTaskRefs(cfg *config.Config) ([]string, error) {
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := store.GetAllEvidenceTasks()
+	if err != nil {
+		return nil, err
+	}
+	refs := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		if task.ReferenceID != "" {
+			refs = append(refs, task.ReferenceID)
+		}
+	}
+	return refs, nil
+}
@@ -18,12 +18,17 @@ package config
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/grctool/grctool/internal/config"
 	"github.com/grctool/grctool/internal/logger"
+	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
 )
 
@@ -53,17 +58,30 @@ func (s *ServiceImpl) InitializeConfig(outputPath string, force bool) error {
 	return s.SaveConfigFile(defaultConfig, outputPath)
 }
 
-// ValidateConfig performs comprehensive configuration validation
-func (s *ServiceImpl) ValidateConfig(ctx context.Context) (*ValidationResult, error) {
-	// Load configuration
-	cfg, err := config.Load()
-	if err != nil {
-		return nil, fmt.Errorf("failed to load configuration: %w", err)
+// ValidateConfig performs comprehensive configuration validation. A config
+// file that does not load is reported as a failed check rather than an error,
+// since explaining why is the point of validating it.
+func (s *ServiceImpl) ValidateConfig(ctx context.Context, opts ValidationOptions) (*ValidationResult, error) {
+	start := time.Now()
+
+	cfg, loadErr := config.Load()
+	fileCheck := newValidationResult()
+	validateConfigFile(fileCheck, loadErr)
+	if loadErr != nil {
+		fileCheck.Duration = time.Since(start)
+		return fileCheck, nil
 	}
 
 	// Create validator and run validation
-	validator := NewConfigValidator(cfg)
-	return validator.Validate(ctx)
+	validator := NewConfigValidatorWithOptions(cfg, opts)
+	result, err := validator.Validate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result.Checks["config_file"] = fileCheck.Checks["config_file"]
+	result.Warnings = append(fileCheck.Warnings, result.Warnings...)
+	result.Duration = time.Since(start)
+	return result, nil
 }
 
 // GenerateDefaultConfig returns a default configuration structure
@@ -190,22 +208,48 @@ func (s *ServiceImpl) LoadAndValidateConfig() (*config.Config, *ValidationResult
 
 // ConfigValidatorImpl implements the ConfigValidator interface
 type ConfigValidatorImpl struct {
-	cfg *config.Config
+	cfg  *config.Config
+	opts ValidationOptions
+
+	// Probes make one harmless authenticated call to each service
+	probeTugboat func(ctx context.Context, cfg *config.TugboatConfig) error
+	probeGitHub  func(ctx context.Context, token string) error
+	probeGoogle  func(ctx context.Context, cfg config.GoogleDocsToolConfig) error
+
+	// taskRefs lists the references of the synced evidence tasks
+	taskRefs func(cfg *config.Config) ([]string, error)
 }
 
 // NewConfigValidator creates a new configuration validator
 func NewConfigValidator(cfg *config.Config) ConfigValidator {
-	return &ConfigValidatorImpl{cfg: cfg}
+	return NewConfigValidatorWithOptions(cfg, ValidationOptions{})
 }
 
-// Validate performs comprehensive configuration validation
-func (v *ConfigValidatorImpl) Validate(ctx context.Context) (*ValidationResult, error) {
-	start := time.Now()
-	result := &ValidationResult{
+// NewConfigValidatorWithOptions creates a configuration validator that
+// honours opts, for example skipping the credential checks when offline
+func NewConfigValidatorWithOptions(cfg *config.Config, opts ValidationOptions) ConfigValidator {
+	return &ConfigValidatorImpl{
+		cfg:          cfg,
+		opts:         opts,
+		probeTugboat: probeTugboat,
+		probeGitHub:  probeGitHub,
+		probeGoogle:  probeGoogle,
+		taskRefs:     storedTaskRefs,
+	}
+}
+
+func newValidationResult() *ValidationResult {
+	return &ValidationResult{
 		Valid:  true,
 		Checks: make(map[string]ValidationCheck),
 		Errors: []string{},
 	}
+}
+
+// Validate performs comprehensive configuration validation
+func (v *ConfigValidatorImpl) Validate(ctx context.Context) (*ValidationResult, error) {
+	start := time.Now()
+	result := newValidationResult()
 
 	// Run all validation checks
 	v.ValidatePaths(ctx, result)
@@ -214,12 +258,14 @@ func (v *ConfigValidatorImpl) Validate(ctx context.Context) (*ValidationResult,
 	v.ValidateTugboatConnectivity(ctx, result)
 	v.ValidateToolConfigurations(ctx, result)
 	v.ValidateStorageConfiguration(ctx, result)
+	v.ValidateCollectorURLs(ctx, result)
 
 	result.Duration = time.Since(start)
 	return result, nil
 }
 
-// ValidatePaths validates that all configured paths exist
+// ValidatePaths validates that all configured paths exist. The data
+// directory is created if missing; other paths must already exist.
 func (v *ConfigValidatorImpl) ValidatePaths(ctx context.Context, result *ValidationResult) {
 	start := time.Now()
 	check := ValidationCheck{
@@ -229,41 +275,59 @@ func (v *ConfigValidatorImpl) ValidatePaths(ctx context.Context, result *Validat
 		Duration: 0,
 	}
 
-	pathsToCheck := []struct {
-		path     string
-		name     string
-		required bool
-	}{
-		{v.cfg.Storage.DataDir, "storage.data_dir", true},
+	var errors []string
+	if v.cfg.Storage.DataDir == "" {
+		errors = append(errors, "storage.data_dir is required but not set")
+	} else if _, err := os.Stat(v.cfg.Storage.DataDir); os.IsNotExist(err) {
+		// For directories, try to create them
+		if err := os.MkdirAll(v.cfg.Storage.DataDir, 0755); err != nil {
+			errors = append(errors, fmt.Sprintf("failed to create directory %s: %v", v.cfg.Storage.DataDir, err))
+		}
+	}
+
+	// Paths that must already exist
+	pathsToCheck := []existingPath{
+		{v.cfg.Notifications.Email.BodyTemplate, "notifications.email.body_template", "fix the path or remove it to use the built-in template"},
+	}
+	for i, source := range v.cfg.Daemon.Sources {
+		pathsToCheck = append(pathsToCheck, existingPath{source.Path, fmt.Sprintf("daemon.sources[%d] (%s) path", i, source.Name), "fix the path or remove the source"})
+	}
+	if v.cfg.Evidence.Tools.Terraform.Enabled {
+		for i, pattern := range v.cfg.Evidence.Tools.Terraform.ScanPaths {
+			pathsToCheck = append(pathsToCheck, existingPath{globRoot(pattern), fmt.Sprintf("evidence.tools.terraform.scan_paths[%d]", i), "point it at your Terraform checkout"})
+		}
 	}
 
-	var errors []string
 	for _, p := range pathsToCheck {
 		if p.path == "" {
-			if p.required {
-				errors = append(errors, fmt.Sprintf("%s is required but not set", p.name))
-			}
 			continue
 		}
-
-		// Check if path exists, create if it doesn't exist and it's a directory
 		if _, err := os.Stat(p.path); os.IsNotExist(err) {
-			// For directories, try to create them
-			if err := os.MkdirAll(p.path, 0755); err != nil {
-				errors = append(errors, fmt.Sprintf("failed to create directory %s: %v", p.path, err))
-			}
+			errors = append(errors, fmt.Sprintf("%s does not exist: %s (%s)", p.name, p.path, p.fix))
 		}
 	}
 
 	if len(errors) > 0 {
-		check.Status = "fail"
 		check.Message = fmt.Sprintf("Path validation failed: %d issues found", len(errors))
-		result.Valid = false
-		result.Errors = append(result.Errors, errors...)
 	}
+	recordCheck(result, "paths", check, errors, nil, start)
+}
 
-	check.Duration = time.Since(start)
-	result.Checks["paths"] = check
+// existingPath is a configured path that must exist, with how to fix it
+type existingPath struct {
+	path string
+	name string
+	fix  string
+}
+
+// globRoot returns the directory a glob pattern such as terraform/**/*.tf
+// searches, which must exist for the pattern to match anything
+func globRoot(pattern string) string {
+	root := pattern
+	if i := strings.IndexAny(pattern, "*?["); i >= 0 {
+		root = filepath.Dir(pattern[:i+1])
+	}
+	return root
 }
 
 // ValidatePermissions validates file and directory permissions
@@ -276,21 +340,26 @@ func (v *ConfigValidatorImpl) ValidatePermissions(ctx context.Context, result *V
 		Duration: 0,
 	}
 
+	if v.opts.SkipPermissions {
+		check.Status = "skipped"
+		check.Message = "Permission checks skipped"
+		recordCheck(result, "permissions", check, nil, nil, start)
+		return
+	}
+
 	// Check write permissions for data directory
+	var errors []string
 	if v.cfg.Storage.DataDir != "" {
 		testFile := filepath.Join(v.cfg.Storage.DataDir, ".grctool-test")
 		if err := os.WriteFile(testFile, []byte("test"), 0644); err != nil {
-			check.Status = "fail"
 			check.Message = fmt.Sprintf("Cannot write to data directory: %v", err)
-			result.Valid = false
-			result.Errors = append(result.Errors, fmt.Sprintf("Data directory not writable: %s", v.cfg.Storage.DataDir))
+			errors = append(errors, fmt.Sprintf("Data directory not writable: %s", v.cfg.Storage.DataDir))
 		} else {
 			os.Remove(testFile) // Clean up
 		}
 	}
 
-	check.Duration = time.Since(start)
-	result.Checks["permissions"] = check
+	recordCheck(result, "permissions", check, errors, nil, start)
 }
 
 // ValidateEnvironmentVariables validates required environment variables
@@ -313,6 +382,10 @@ func (v *ConfigValidatorImpl) ValidateEnvironmentVariables(ctx context.Context,
 		}
 	}
 
+	if overrides := config.EnvOverrides(); len(overrides) > 0 {
+		check.Message = fmt.Sprintf("Config keys set by %s", strings.Join(overrides, ", "))
+	}
+
 	if len(missing) > 0 {
 		check.Status = "fail"
 		check.Message = fmt.Sprintf("Missing required environment variables: %v", missing)
@@ -327,7 +400,8 @@ func (v *ConfigValidatorImpl) ValidateEnvironmentVariables(ctx context.Context,
 	result.Checks["environment"] = check
 }
 
-// ValidateTugboatConnectivity validates connectivity to Tugboat Logic API
+// ValidateTugboatConnectivity validates the Tugboat Logic base URL and
+// tests the saved credentials with a read-only API call
 func (v *ConfigValidatorImpl) ValidateTugboatConnectivity(ctx context.Context, result *ValidationResult) {
 	start := time.Now()
 	check := ValidationCheck{
@@ -337,20 +411,43 @@ func (v *ConfigValidatorImpl) ValidateTugboatConnectivity(ctx context.Context, r
 		Duration: 0,
 	}
 
-	// Simple connectivity check - this is a placeholder
-	// In practice, you might want to make an actual API call
-	if v.cfg.Tugboat.BaseURL == "" {
-		check.Status = "fail"
+	tugboat := v.cfg.Tugboat
+	if tugboat.BaseURL == "" {
 		check.Message = "Tugboat base URL is not configured"
-		result.Valid = false
-		result.Errors = append(result.Errors, "Tugboat base URL is required")
+		recordCheck(result, "tugboat_connectivity", check, []string{"Tugboat base URL is required: set tugboat.base_url"}, nil, start)
+		return
+	}
+	if u, err := url.Parse(tugboat.BaseURL); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		check.Message = "Tugboat base URL is invalid"
+		recordCheck(result, "tugboat_connectivity", check, []string{fmt.Sprintf("tugboat.base_url %q is not an http(s) URL, e.g. https://api-my.tugboatlogic.com", tugboat.BaseURL)}, nil, start)
+		return
 	}
 
-	check.Duration = time.Since(start)
-	result.Checks["tugboat_connectivity"] = check
+	var errors, warnings []string
+	switch {
+	case tugboat.BearerToken == "" && tugboat.CookieHeader == "":
+		check.Message = "Base URL is valid; no saved credentials to test (run 'grctool auth login')"
+	case v.opts.SkipConnectivity:
+		check.Status = "skipped"
+		check.Message = "Credentials not tested (offline)"
+	default:
+		if err := v.probeTugboat(ctx, &tugboat); err != nil {
+			check.Message = "Tugboat rejected the request"
+			errors = append(errors, fmt.Sprintf("Tugboat credentials could not be used: %v (run 'grctool auth login' to refresh them)", err))
+		} else {
+			check.Message = "Authenticated with Tugboat Logic"
+		}
+	}
+
+	if expires, err := time.Parse(time.RFC3339, tugboat.AuthExpires); err == nil && time.Now().After(expires) && len(errors) == 0 {
+		warnings = append(warnings, fmt.Sprintf("Tugboat session expired at %s: run 'grctool auth login'", expires.Format("2006-01-02 15:04")))
+	}
+
+	recordCheck(result, "tugboat_connectivity", check, errors, warnings, start)
 }
 
-// ValidateToolConfigurations validates tool-specific configurations
+// ValidateToolConfigurations validates the enabled evidence tools and tests
+// their credentials
 func (v *ConfigValidatorImpl) ValidateToolConfigurations(ctx context.Context, result *ValidationResult) {
 	start := time.Now()
 	check := ValidationCheck{
@@ -360,11 +457,46 @@ func (v *ConfigValidatorImpl) ValidateToolConfigurations(ctx context.Context, re
 		Duration: 0,
 	}
 
-	// This is a placeholder for tool configuration validation
-	// In practice, you would validate specific tool configurations
+	var errors, warnings []string
+	var tested []string
 
-	check.Duration = time.Since(start)
-	result.Checks["tools"] = check
+	github := v.cfg.Evidence.Tools.GitHub
+	if github.Enabled {
+		if github.Repository == "" {
+			warnings = append(warnings, "evidence.tools.github.repository is not set: GitHub tools will need --repository")
+		} else if parts := strings.Split(github.Repository, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			errors = append(errors, fmt.Sprintf("evidence.tools.github.repository must be owner/name, got %q", github.Repository))
+		}
+
+		token := v.cfg.Auth.GitHub.Token
+		if token == "" {
+			token = github.APIToken
+		}
+		switch {
+		case token == "":
+			warnings = append(warnings, "No GitHub token: set auth.github.token (e.g. ${GITHUB_TOKEN}) or run 'gh auth login'")
+		case !v.opts.SkipConnectivity:
+			if err := v.probeGitHub(ctx, token); err != nil {
+				errors = append(errors, fmt.Sprintf("GitHub token could not be used: %v (create a new token or run 'gh auth login')", err))
+			} else {
+				tested = append(tested, "GitHub")
+			}
+		}
+	}
+
+	googleDocs := v.cfg.Evidence.Tools.GoogleDocs
+	if googleDocs.Enabled && !v.opts.SkipConnectivity {
+		if err := v.probeGoogle(ctx, googleDocs); err != nil {
+			errors = append(errors, fmt.Sprintf("Google credentials could not be used: %v (check evidence.tools.google_docs.credentials_file, or run 'gcloud auth application-default login')", err))
+		} else {
+			tested = append(tested, "Google")
+		}
+	}
+
+	if len(tested) > 0 {
+		check.Message = fmt.Sprintf("All tool configurations are valid; authenticated with %s", strings.Join(tested, " and "))
+	}
+	recordCheck(result, "tools", check, errors, warnings, start)
 }
 
 // ValidateStorageConfiguration validates storage configuration
@@ -389,6 +521,126 @@ func (v *ConfigValidatorImpl) ValidateStorageConfiguration(ctx context.Context,
 	result.Checks["storage"] = check
 }
 
+// ValidateCollectorURLs checks that each collector URL is a Tugboat
+// collector for a synced evidence task, and that the credentials evidence
+// submission needs are set
+func (v *ConfigValidatorImpl) ValidateCollectorURLs(ctx context.Context, result *ValidationResult) {
+	start := time.Now()
+	check := ValidationCheck{
+		Name:     "Collector URLs",
+		Status:   "pass",
+		Message:  "No collector URLs configured",
+		Duration: 0,
+	}
+
+	urls := v.cfg.Tugboat.CollectorURLs
+	if len(urls) == 0 {
+		recordCheck(result, "collector_urls", check, nil, nil, start)
+		return
+	}
+	check.Message = fmt.Sprintf("%d collector URLs map to synced evidence tasks", len(urls))
+
+	refs := make([]string, 0, len(urls))
+	for ref := range urls {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+
+	var errors, warnings []string
+	for _, ref := range refs {
+		if !collectorURLPattern.MatchString(urls[ref]) {
+			errors = append(errors, fmt.Sprintf("tugboat.collector_urls %s: %q is not a collector URL like https://openapi.tugboatlogic.com/api/v0/evidence/collector/<ID>/ (copy it from Tugboat: Custom Integrations > Evidence Services)", ref, urls[ref]))
+		}
+	}
+
+	known, err := v.taskRefs(v.cfg)
+	switch {
+	case err != nil:
+		warnings = append(warnings, fmt.Sprintf("Could not load evidence tasks to check collector URL references: %v", err))
+	case len(known) == 0:
+		warnings = append(warnings, "No synced evidence tasks to check collector URL references against: run 'grctool sync'")
+	default:
+		knownRefs := make(map[string]bool, len(known))
+		for _, ref := range known {
+			knownRefs[strings.ToUpper(ref)] = true
+		}
+		for _, ref := range refs {
+			if !knownRefs[ref] {
+				errors = append(errors, fmt.Sprintf("tugboat.collector_urls %s: no evidence task has this reference (check it, or run 'grctool sync' if the task is new)", ref))
+			}
+		}
+	}
+
+	// Submission authenticates with HTTP Basic Auth and an API key
+	if v.cfg.Tugboat.Username == "" {
+		errors = append(errors, "tugboat.username is required to submit evidence to collector URLs")
+	}
+	if v.cfg.Tugboat.Password == "" {
+		errors = append(errors, "tugboat.password is required to submit evidence to collector URLs (e.g. password: ${TUGBOAT_PASSWORD})")
+	}
+	if os.Getenv("TUGBOAT_API_KEY") == "" {
+		errors = append(errors, "TUGBOAT_API_KEY is not set: export the API key from Tugboat's Custom Integrations page to submit evidence")
+	}
+
+	if len(errors) > 0 {
+		check.Message = fmt.Sprintf("Collector URL validation failed: %d issues found", len(errors))
+	}
+	recordCheck(result, "collector_urls", check, errors, warnings, start)
+}
+
+// collectorURLPattern matches Tugboat Custom Evidence Integration collector URLs
+var collectorURLPattern = regexp.MustCompile(`^https://openapi\.tugboatlogic\.com/api/v0/evidence/collector/\d+/$`)
+
+// validateConfigFile records whether a config file was found and loaded,
+// with any unknown or misplaced keys in it
+func validateConfigFile(result *ValidationResult, loadErr error) {
+	start := time.Now()
+	check := ValidationCheck{
+		Name:     "Config File",
+		Status:   "pass",
+		Duration: 0,
+	}
+
+	var errors []string
+	path := viper.ConfigFileUsed()
+	overrides := config.EnvOverrides()
+	if path == "" {
+		if len(overrides) > 0 {
+			check.Message = fmt.Sprintf("No config file; configured by %d environment variables", len(overrides))
+		} else {
+			check.Message = "No config file found"
+			errors = append(errors, "No .grctool.yaml found in the current or home directory: run 'grctool init', pass --config, or set GRCTOOL_* environment variables")
+		}
+	} else {
+		check.Message = fmt.Sprintf("Loaded %s", path)
+		if profile := config.ActiveProfile(); profile != "" {
+			check.Message += fmt.Sprintf(" with profile %s", profile)
+		}
+	}
+	if loadErr != nil {
+		check.Message = fmt.Sprintf("Failed to load %s", path)
+		errors = append(errors, loadErr.Error())
+	}
+
+	recordCheck(result, "config_file", check, errors, config.StructureWarnings(), start)
+}
+
+// recordCheck stores a check in result. Errors fail the check and the
+// validation; warnings mark a passing check as a warning.
+func recordCheck(result *ValidationResult, key string, check ValidationCheck, errors, warnings []string, start time.Time) {
+	switch {
+	case len(errors) > 0:
+		check.Status = "fail"
+		result.Valid = false
+		result.Errors = append(result.Errors, errors...)
+	case len(warnings) > 0 && check.Status == "pass":
+		check.Status = "warning"
+	}
+	result.Warnings = append(result.Warnings, warnings...)
+	check.Duration = time.Since(start)
+	result.Checks[key] = check
+}
+
 // GenerateClaudeMd generates CLAUDE.md file with config-aware content
 func (s *ServiceImpl) GenerateClaudeMd(outputPath string, force bool) error {
 	// Check if file exists and force is not set
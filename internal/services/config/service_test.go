@@ -2,12 +2,14 @@ package config
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/grctool/grctool/internal/config"
 	"github.com/grctool/grctool/internal/testhelpers"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
@@ -382,6 +384,25 @@ func TestValidateEnvironmentVariables_BrowserAuth(t *testing.T) {
 	assert.Equal(t, "pass", result.Checks["environment"].Status)
 }
 
+func TestValidateConfigFile_EnvironmentOnly(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	result := newValidationResult()
+	validateConfigFile(result, nil)
+	assert.Equal(t, "fail", result.Checks["config_file"].Status, "no file and no variables")
+
+	t.Setenv("GRCTOOL_TUGBOAT_ORG_ID", "12345")
+	result = newValidationResult()
+	validateConfigFile(result, nil)
+	assert.Equal(t, "pass", result.Checks["config_file"].Status)
+	assert.Contains(t, result.Checks["config_file"].Message, "configured by 1 environment variables")
+
+	v := NewConfigValidator(&config.Config{Tugboat: config.TugboatConfig{AuthMode: "browser"}}).(*ConfigValidatorImpl)
+	v.ValidateEnvironmentVariables(context.Background(), result)
+	assert.Equal(t, "Config keys set by GRCTOOL_TUGBOAT_ORG_ID", result.Checks["environment"].Message)
+}
+
 func TestValidateEnvironmentVariables_MissingCredentials(t *testing.T) {
 	t.Parallel()
 	cfg := &config.Config{
@@ -637,3 +658,210 @@ func TestGenerateClaudeMd_ExistingFileNoForce(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "already exists")
 }
+
+// ---------------------------------------------------------------------------
+// Credential probes and collector URLs
+// ---------------------------------------------------------------------------
+
+// testValidator returns a validator whose probes fail with probeErr and whose
+// synced tasks are refs
+func testValidator(cfg *config.Config, opts ValidationOptions, probeErr error, refs ...string) *ConfigValidatorImpl {
+	v := NewConfigValidatorWithOptions(cfg, opts).(*ConfigValidatorImpl)
+	v.probeTugboat = func(context.Context, *config.TugboatConfig) error { return probeErr }
+	v.probeGitHub = func(context.Context, string) error { return probeErr }
+	v.probeGoogle = func(context.Context, config.GoogleDocsToolConfig) error { return probeErr }
+	v.taskRefs = func(*config.Config) ([]string, error) { return refs, nil }
+	return v
+}
+
+func TestValidateTugboatConnectivity_Credentials(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		tugboat  config.TugboatConfig
+		opts     ValidationOptions
+		probeErr error
+		status   string
+		errorMsg string
+	}{
+		"credentials accepted": {
+			tugboat: config.TugboatConfig{BaseURL: "https://api-my.tugboatlogic.com", BearerToken: "token"},
+			status:  "pass",
+		},
+		"credentials rejected": {
+			tugboat:  config.TugboatConfig{BaseURL: "https://api-my.tugboatlogic.com", BearerToken: "token"},
+			probeErr: errors.New("authentication failed - invalid token"),
+			status:   "fail",
+			errorMsg: "grctool auth login",
+		},
+		"offline": {
+			tugboat:  config.TugboatConfig{BaseURL: "https://api-my.tugboatlogic.com", BearerToken: "token"},
+			opts:     ValidationOptions{SkipConnectivity: true},
+			probeErr: errors.New("should not be called"),
+			status:   "skipped",
+		},
+		"invalid base URL": {
+			tugboat:  config.TugboatConfig{BaseURL: "api-my.tugboatlogic.com"},
+			status:   "fail",
+			errorMsg: "is not an http(s) URL",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			v := testValidator(&config.Config{Tugboat: tc.tugboat}, tc.opts, tc.probeErr)
+			result := newValidationResult()
+			v.ValidateTugboatConnectivity(context.Background(), result)
+
+			assert.Equal(t, tc.status, result.Checks["tugboat_connectivity"].Status)
+			if tc.errorMsg != "" {
+				require.Len(t, result.Errors, 1)
+				assert.Contains(t, result.Errors[0], tc.errorMsg)
+			} else {
+				assert.Empty(t, result.Errors)
+			}
+		})
+	}
+}
+
+func TestValidateToolConfigurations_GitHub(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		github   config.GitHubToolConfig
+		probeErr error
+		status   string
+		message  string
+	}{
+		"valid": {
+			github: config.GitHubToolConfig{Enabled: true, Repository: "acme/infra", APIToken: "token"},
+			status: "pass",
+		},
+		"malformed repository": {
+			github:  config.GitHubToolConfig{Enabled: true, Repository: "infra", APIToken: "token"},
+			status:  "fail",
+			message: "must be owner/name",
+		},
+		"no token": {
+			github:  config.GitHubToolConfig{Enabled: true, Repository: "acme/infra"},
+			status:  "warning",
+			message: "No GitHub token",
+		},
+		"token rejected": {
+			github:   config.GitHubToolConfig{Enabled: true, Repository: "acme/infra", APIToken: "token"},
+			probeErr: errors.New("token is invalid or expired"),
+			status:   "fail",
+			message:  "GitHub token could not be used: token is invalid or expired",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &config.Config{}
+			cfg.Evidence.Tools.GitHub = tc.github
+			v := testValidator(cfg, ValidationOptions{}, tc.probeErr)
+			result := newValidationResult()
+			v.ValidateToolConfigurations(context.Background(), result)
+
+			assert.Equal(t, tc.status, result.Checks["tools"].Status)
+			if tc.message != "" {
+				issues := append(append([]string{}, result.Errors...), result.Warnings...)
+				require.Len(t, issues, 1)
+				assert.Contains(t, issues[0], tc.message)
+			}
+		})
+	}
+}
+
+func TestValidateCollectorURLs(t *testing.T) {
+	t.Setenv("TUGBOAT_API_KEY", "key")
+	const collector = "https://openapi.tugboatlogic.com/api/v0/evidence/collector/805/"
+
+	cfg := &config.Config{Tugboat: config.TugboatConfig{
+		Username: "collector",
+		Password: "secret",
+		CollectorURLs: map[string]string{
+			"ET-0001": collector,
+			"ET-0999": collector,
+			"ET-0002": "https://example.com/upload",
+		},
+	}}
+
+	v := testValidator(cfg, ValidationOptions{}, nil, "ET-0001", "ET-0002")
+	result := newValidationResult()
+	v.ValidateCollectorURLs(context.Background(), result)
+
+	assert.Equal(t, "fail", result.Checks["collector_urls"].Status)
+	require.Len(t, result.Errors, 2)
+	assert.Contains(t, result.Errors[0], "ET-0002: \"https://example.com/upload\" is not a collector URL")
+	assert.Contains(t, result.Errors[1], "ET-0999: no evidence task has this reference")
+
+	t.Run("missing submission credentials", func(t *testing.T) {
+		t.Setenv("TUGBOAT_API_KEY", "")
+		cfg := &config.Config{Tugboat: config.TugboatConfig{CollectorURLs: map[string]string{"ET-0001": collector}}}
+		v := testValidator(cfg, ValidationOptions{}, nil, "ET-0001")
+		result := newValidationResult()
+		v.ValidateCollectorURLs(context.Background(), result)
+
+		require.Len(t, result.Errors, 3)
+		assert.Contains(t, result.Errors[0], "tugboat.username")
+		assert.Contains(t, result.Errors[1], "tugboat.password")
+		assert.Contains(t, result.Errors[2], "TUGBOAT_API_KEY")
+	})
+
+	t.Run("no synced tasks", func(t *testing.T) {
+		cfg := &config.Config{Tugboat: config.TugboatConfig{
+			Username:      "collector",
+			Password:      "secret",
+			CollectorURLs: map[string]string{"ET-0001": collector},
+		}}
+		v := testValidator(cfg, ValidationOptions{}, nil)
+		result := newValidationResult()
+		v.ValidateCollectorURLs(context.Background(), result)
+
+		assert.Equal(t, "warning", result.Checks["collector_urls"].Status)
+		require.Len(t, result.Warnings, 1)
+		assert.Contains(t, result.Warnings[0], "run 'grctool sync'")
+	})
+}
+
+func TestValidatePaths_ReferencedPaths(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		Storage: config.StorageConfig{DataDir: tmpDir},
+		Daemon: config.DaemonConfig{Sources: []config.WatchSourceConfig{
+			{Name: "infra", Path: filepath.Join(tmpDir, "missing")},
+			{Name: "data", Path: tmpDir},
+		}},
+	}
+	cfg.Evidence.Tools.Terraform = config.TerraformToolConfig{
+		Enabled:   true,
+		ScanPaths: []string{filepath.Join(tmpDir, "terraform", "**", "*.tf")},
+	}
+
+	v := NewConfigValidator(cfg).(*ConfigValidatorImpl)
+	result := newValidationResult()
+	v.ValidatePaths(context.Background(), result)
+
+	assert.Equal(t, "fail", result.Checks["paths"].Status)
+	require.Len(t, result.Errors, 2)
+	assert.Contains(t, result.Errors[0], "daemon.sources[0] (infra) path does not exist")
+	assert.Contains(t, result.Errors[1], "evidence.tools.terraform.scan_paths[0] does not exist: "+filepath.Join(tmpDir, "terraform"))
+}
+
+func TestGlobRoot(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"terraform/**/*.tf": "terraform",
+		"/repo/infra/*.tf":  "/repo/infra",
+		"*.tf":              ".",
+		"/repo/main.tf":     "/repo/main.tf",
+	}
+	for pattern, want := range tests {
+		assert.Equal(t, want, globRoot(pattern), pattern)
+	}
+}
@@ -26,7 +26,7 @@ import (
 type Service interface {
 	// Configuration initialization and management
 	InitializeConfig(outputPath string, force bool) error
-	ValidateConfig(ctx context.Context) (*ValidationResult, error)
+	ValidateConfig(ctx context.Context, opts ValidationOptions) (*ValidationResult, error)
 
 	// Template and default configuration generation
 	GenerateDefaultConfig() map[string]interface{}
@@ -46,12 +46,13 @@ type ValidationResult struct {
 	Checks   map[string]ValidationCheck `json:"checks"`
 	Duration time.Duration              `json:"duration"`
 	Errors   []string                   `json:"errors,omitempty"`
+	Warnings []string                   `json:"warnings,omitempty"`
 }
 
 // ValidationCheck represents a single validation check result
 type ValidationCheck struct {
 	Name     string        `json:"name"`
-	Status   string        `json:"status"` // "pass", "fail", "warning"
+	Status   string        `json:"status"` // "pass", "fail", "warning", "skipped"
 	Message  string        `json:"message"`
 	Duration time.Duration `json:"duration"`
 }
@@ -65,6 +66,7 @@ type ConfigValidator interface {
 	ValidateTugboatConnectivity(ctx context.Context, result *ValidationResult)
 	ValidateToolConfigurations(ctx context.Context, result *ValidationResult)
 	ValidateStorageConfiguration(ctx context.Context, result *ValidationResult)
+	ValidateCollectorURLs(ctx context.Context, result *ValidationResult)
 }
 
 // InitializationOptions controls config initialization
@@ -78,7 +80,7 @@ type InitializationOptions struct {
 
 // ValidationOptions controls validation behavior
 type ValidationOptions struct {
-	SkipConnectivity bool `json:"skip_connectivity"`
+	SkipConnectivity bool `json:"skip_connectivity"` // Skip the calls that test credentials
 	SkipPermissions  bool `json:"skip_permissions"`
 	CreatePaths      bool `json:"create_paths"`
 	Verbose          bool `json:"verbose"`
@@ -0,0 +1,235 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/naming"
+)
+
+// ControlGapSource provides the data gap analysis reads. It is satisfied by *storage.Storage.
+type ControlGapSource interface {
+	GetAllEvidenceTasks() ([]domain.EvidenceTask, error)
+	GetAllControls() ([]domain.Control, error)
+	GetEvidenceFiles(taskRef, window string) ([]models.EvidenceFileRef, error)
+	GetEvidenceFilesFromSubfolder(taskRef, window, subfolder string) ([]models.EvidenceFileRef, error)
+	LoadValidationResult(taskRef, window string) (*models.ValidationResult, error)
+}
+
+// ControlGapOptions controls a gap analysis
+type ControlGapOptions struct {
+	Framework string                           // Only controls and tasks of this framework
+	Window    string                           // Window checked for every task; empty uses WindowFor
+	WindowFor func(domain.EvidenceTask) string // A task's current window
+}
+
+// ControlGap is a control lacking evidence
+type ControlGap struct {
+	Ref       string   `json:"ref" yaml:"ref"`
+	ID        string   `json:"id" yaml:"id"`
+	Name      string   `json:"name" yaml:"name"`
+	Framework string   `json:"framework,omitempty" yaml:"framework,omitempty"`
+	Tasks     []string `json:"tasks,omitempty" yaml:"tasks,omitempty"` // Mapped tasks whose evidence failed validation
+}
+
+// TaskGap is an evidence task with no evidence in its window
+type TaskGap struct {
+	TaskRef  string   `json:"task_ref" yaml:"task_ref"`
+	TaskName string   `json:"task_name" yaml:"task_name"`
+	Window   string   `json:"window" yaml:"window"`
+	Controls []string `json:"controls,omitempty" yaml:"controls,omitempty"` // Controls the task provides evidence for
+}
+
+// ControlGapReport is the result of a gap analysis
+type ControlGapReport struct {
+	Controls             int          `json:"controls" yaml:"controls"` // Controls analyzed
+	Tasks                int          `json:"tasks" yaml:"tasks"`       // Tasks analyzed
+	UnmappedControls     []ControlGap `json:"unmapped_controls" yaml:"unmapped_controls"`
+	TasksWithoutEvidence []TaskGap    `json:"tasks_without_evidence" yaml:"tasks_without_evidence"`
+	FailedControls       []ControlGap `json:"failed_controls" yaml:"failed_controls"`
+}
+
+// Total is the number of gaps found
+func (r *ControlGapReport) Total() int {
+	return len(r.UnmappedControls) + len(r.TasksWithoutEvidence) + len(r.FailedControls)
+}
+
+// taskEvidence is what a task has collected in its window
+type taskEvidence struct {
+	files  int
+	failed bool // Validated, and validation failed
+}
+
+// AnalyzeControlGaps cross-references controls with the evidence tasks mapped
+// to them and the evidence collected for each task's window. It reports
+// controls with no mapped task, tasks with no evidence (working or
+// submitted), and controls whose evidence all failed validation.
+func AnalyzeControlGaps(source ControlGapSource, opts ControlGapOptions) (*ControlGapReport, error) {
+	tasks, err := source.GetAllEvidenceTasks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load evidence tasks: %w", err)
+	}
+	controls, err := source.GetAllControls()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load controls: %w", err)
+	}
+	if opts.Framework != "" {
+		tasks = filterByFramework(tasks, func(t domain.EvidenceTask) string { return t.Framework }, opts.Framework)
+		controls = filterByFramework(controls, func(c domain.Control) string { return c.Framework }, opts.Framework)
+	}
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].ReferenceID < tasks[j].ReferenceID })
+	sort.SliceStable(controls, func(i, j int) bool { return controlRef(controls[i]) < controlRef(controls[j]) })
+
+	// Controls are referred to by ID or reference; index both
+	controlKeys := make(map[string]string, len(controls)*2)
+	for _, control := range controls {
+		controlKeys[control.ID] = control.ID
+		if control.ReferenceID != "" {
+			controlKeys[strings.ToUpper(control.ReferenceID)] = control.ID
+		}
+	}
+
+	// Map each control to its tasks, from either side of the relationship
+	taskByRef := make(map[string]domain.EvidenceTask, len(tasks))
+	controlTasks := make(map[string][]string)
+	taskControls := make(map[string][]string)
+	link := func(controlID, taskRef string) {
+		for _, ref := range controlTasks[controlID] {
+			if ref == taskRef {
+				return
+			}
+		}
+		controlTasks[controlID] = append(controlTasks[controlID], taskRef)
+		taskControls[taskRef] = append(taskControls[taskRef], controlID)
+	}
+	for _, task := range tasks {
+		taskByRef[task.ReferenceID] = task
+		for _, key := range task.Controls {
+			if id, ok := controlKeys[key]; ok {
+				link(id, task.ReferenceID)
+			} else if id, ok := controlKeys[strings.ToUpper(key)]; ok {
+				link(id, task.ReferenceID)
+			}
+		}
+	}
+	for _, control := range controls {
+		for _, related := range control.RelatedEvidenceTasks {
+			if _, ok := taskByRef[related.ReferenceID]; ok {
+				link(control.ID, related.ReferenceID)
+			}
+		}
+	}
+
+	report := &ControlGapReport{
+		Controls:             len(controls),
+		Tasks:                len(tasks),
+		UnmappedControls:     []ControlGap{},
+		TasksWithoutEvidence: []TaskGap{},
+		FailedControls:       []ControlGap{},
+	}
+	refs := make(map[string]string, len(controls))
+	for _, control := range controls {
+		refs[control.ID] = controlRef(control)
+	}
+
+	evidence := make(map[string]taskEvidence, len(tasks))
+	for _, task := range tasks {
+		window := opts.Window
+		if window == "" && opts.WindowFor != nil {
+			window = opts.WindowFor(task)
+		}
+		collected := collectedEvidence(source, task.ReferenceID, window)
+		evidence[task.ReferenceID] = collected
+		if collected.files > 0 {
+			continue
+		}
+		gap := TaskGap{TaskRef: task.ReferenceID, TaskName: task.Name, Window: window}
+		for _, id := range taskControls[task.ReferenceID] {
+			gap.Controls = append(gap.Controls, refs[id])
+		}
+		sort.Strings(gap.Controls)
+		report.TasksWithoutEvidence = append(report.TasksWithoutEvidence, gap)
+	}
+
+	for _, control := range controls {
+		gap := ControlGap{Ref: controlRef(control), ID: control.ID, Name: control.Name, Framework: control.Framework}
+		mapped := controlTasks[control.ID]
+		if len(mapped) == 0 {
+			report.UnmappedControls = append(report.UnmappedControls, gap)
+			continue
+		}
+
+		// Failed when some task has evidence and every task with evidence failed validation
+		allFailed := false
+		for _, taskRef := range mapped {
+			collected := evidence[taskRef]
+			if collected.files == 0 {
+				continue
+			}
+			if !collected.failed {
+				allFailed = false
+				break
+			}
+			allFailed = true
+			gap.Tasks = append(gap.Tasks, taskRef)
+		}
+		if allFailed {
+			sort.Strings(gap.Tasks)
+			report.FailedControls = append(report.FailedControls, gap)
+		}
+	}
+
+	return report, nil
+}
+
+// collectedEvidence counts a task's working and submitted evidence files in
+// window and reads the window's validation result
+func collectedEvidence(source ControlGapSource, taskRef, window string) taskEvidence {
+	var collected taskEvidence
+	if files, err := source.GetEvidenceFiles(taskRef, window); err == nil {
+		collected.files += len(files)
+	}
+	if files, err := source.GetEvidenceFilesFromSubfolder(taskRef, window, naming.SubfolderSubmitted); err == nil {
+		collected.files += len(files)
+	}
+	if result, err := source.LoadValidationResult(taskRef, window); err == nil && result != nil {
+		collected.failed = strings.EqualFold(result.Status, "failed")
+	}
+	return collected
+}
+
+func filterByFramework[T any](items []T, framework func(T) string, want string) []T {
+	var filtered []T
+	for _, item := range items {
+		if strings.EqualFold(framework(item), want) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
+
+// controlRef is how a control is referred to
+func controlRef(control domain.Control) string {
+	if control.ReferenceID != "" {
+		return control.ReferenceID
+	}
+	return control.ID
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gapSource serves tasks, controls and evidence keyed by "<task>/<window>"
+type gapSource struct {
+	tasks      []domain.EvidenceTask
+	controls   []domain.Control
+	working    map[string]int
+	submitted  map[string]int
+	validation map[string]string
+}
+
+func (s *gapSource) GetAllEvidenceTasks() ([]domain.EvidenceTask, error) { return s.tasks, nil }
+func (s *gapSource) GetAllControls() ([]domain.Control, error)          { return s.controls, nil }
+
+func (s *gapSource) GetEvidenceFiles(taskRef, window string) ([]models.EvidenceFileRef, error) {
+	n, ok := s.working[taskRef+"/"+window]
+	if !ok {
+		return nil, errors.New("evidence directory not found")
+	}
+	return make([]models.EvidenceFileRef, n), nil
+}
+
+func (s *gapSource) GetEvidenceFilesFromSubfolder(taskRef, window, _ string) ([]models.EvidenceFileRef, error) {
+	return make([]models.EvidenceFileRef, s.submitted[taskRef+"/"+window]), nil
+}
+
+func (s *gapSource) LoadValidationResult(taskRef, window string) (*models.ValidationResult, error) {
+	status, ok := s.validation[taskRef+"/"+window]
+	if !ok {
+		return nil, errors.New("validation result not found")
+	}
+	return &models.ValidationResult{Status: status}, nil
+}
+
+func TestAnalyzeControlGaps(t *testing.T) {
+	t.Parallel()
+
+	source := &gapSource{
+		tasks: []domain.EvidenceTask{
+			{ReferenceID: "ET-0001", Name: "Access Review", Framework: "SOC2", CollectionInterval: "quarter", Controls: []string{"101"}},
+			{ReferenceID: "ET-0002", Name: "Offboarding", Framework: "SOC2", CollectionInterval: "year", Controls: []string{"cc6.2"}},
+			{ReferenceID: "ET-0003", Name: "Change Log", Framework: "SOC2", CollectionInterval: "quarter"},
+			{ReferenceID: "ET-0004", Name: "Backups", Framework: "SOC2", CollectionInterval: "quarter", Controls: []string{"104"}},
+			{ReferenceID: "ET-0005", Name: "Restore Test", Framework: "SOC2", CollectionInterval: "quarter", Controls: []string{"104"}},
+			{ReferenceID: "ET-0009", Name: "Annex A", Framework: "ISO27001", CollectionInterval: "year"},
+		},
+		controls: []domain.Control{
+			{ID: "101", ReferenceID: "CC6.1", Name: "Logical Access", Framework: "SOC2"},
+			{ID: "102", ReferenceID: "CC6.2", Name: "Access Removal", Framework: "SOC2"},
+			{ID: "103", ReferenceID: "CC8.1", Name: "Change Management", Framework: "SOC2",
+				RelatedEvidenceTasks: []domain.EvidenceTask{{ReferenceID: "ET-0003"}}},
+			{ID: "104", ReferenceID: "A1.2", Name: "Backups", Framework: "SOC2"},
+			{ID: "105", ReferenceID: "CC9.9", Name: "Vendor Risk", Framework: "SOC2"},
+		},
+		working:   map[string]int{"ET-0001/2025-Q4": 2, "ET-0003/2025-Q4": 1, "ET-0004/2025-Q4": 1, "ET-0005/2025-Q4": 0},
+		submitted: map[string]int{"ET-0005/2025-Q4": 1},
+		validation: map[string]string{
+			"ET-0001/2025-Q4": "passed",
+			"ET-0003/2025-Q4": "warning",
+			"ET-0004/2025-Q4": "failed",
+			"ET-0005/2025-Q4": "failed",
+		},
+	}
+	windowFor := func(task domain.EvidenceTask) string {
+		if task.CollectionInterval == "year" {
+			return "2025"
+		}
+		return "2025-Q4"
+	}
+
+	report, err := AnalyzeControlGaps(source, ControlGapOptions{Framework: "soc2", WindowFor: windowFor})
+	require.NoError(t, err)
+	assert.Equal(t, 5, report.Controls)
+	assert.Equal(t, 5, report.Tasks)
+
+	require.Len(t, report.UnmappedControls, 1)
+	assert.Equal(t, "CC9.9", report.UnmappedControls[0].Ref)
+
+	// Submitted evidence counts; the annual task has none in its window
+	require.Len(t, report.TasksWithoutEvidence, 1)
+	assert.Equal(t, TaskGap{TaskRef: "ET-0002", TaskName: "Offboarding", Window: "2025", Controls: []string{"CC6.2"}},
+		report.TasksWithoutEvidence[0])
+
+	require.Len(t, report.FailedControls, 1)
+	assert.Equal(t, "A1.2", report.FailedControls[0].Ref)
+	assert.Equal(t, []string{"ET-0004", "ET-0005"}, report.FailedControls[0].Tasks)
+	assert.Equal(t, 3, report.Total())
+
+	// One window for every task; one passing task clears a control
+	source.working["ET-0002/2025-Q4"] = 1
+	source.validation["ET-0005/2025-Q4"] = "passed"
+	report, err = AnalyzeControlGaps(source, ControlGapOptions{Framework: "SOC2", Window: "2025-Q4", WindowFor: windowFor})
+	require.NoError(t, err)
+	assert.Empty(t, report.TasksWithoutEvidence)
+	assert.Empty(t, report.FailedControls)
+}
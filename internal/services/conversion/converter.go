@@ -57,6 +57,8 @@ type Margins struct {
 type Converter interface {
 	// ConvertMarkdownToPDF converts a markdown file to PDF
 	ConvertMarkdownToPDF(inputPath, outputPath string, opts *ConversionOptions) error
+	// ConvertMarkdownBytesToPDF converts markdown source already in memory to PDF
+	ConvertMarkdownBytesToPDF(source []byte, outputPath string, opts *ConversionOptions) error
 }
 
 // NewConverter creates a new markdown to PDF converter
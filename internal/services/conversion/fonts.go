@@ -152,6 +152,35 @@ func (fm *FontManager) findSystemFont(family, variant string) string {
 		}
 	}
 
+	// Metric-compatible substitutes commonly installed on Linux
+	linuxPaths := []string{
+		"/usr/share/fonts/truetype",
+		"/usr/share/fonts",
+	}
+	linuxFontNames := map[string]map[string][]string{
+		"Helvetica": {
+			"Regular": {"liberation/LiberationSans-Regular.ttf", "dejavu/DejaVuSans.ttf"},
+			"Bold":    {"liberation/LiberationSans-Bold.ttf", "dejavu/DejaVuSans-Bold.ttf"},
+			"Italic":  {"liberation/LiberationSans-Italic.ttf", "dejavu/DejaVuSans-Oblique.ttf"},
+		},
+		"Courier": {
+			"Regular": {"liberation/LiberationMono-Regular.ttf", "dejavu/DejaVuSansMono.ttf"},
+			"Bold":    {"liberation/LiberationMono-Bold.ttf", "dejavu/DejaVuSansMono-Bold.ttf"},
+			"Italic":  {"liberation/LiberationMono-Italic.ttf", "dejavu/DejaVuSansMono-Oblique.ttf"},
+		},
+	}
+
+	if families, ok := linuxFontNames[family]; ok {
+		for _, filename := range families[variant] {
+			for _, basePath := range linuxPaths {
+				fullPath := filepath.Join(basePath, filename)
+				if _, err := os.Stat(fullPath); err == nil {
+					return fullPath
+				}
+			}
+		}
+	}
+
 	return ""
 }
 
@@ -73,17 +73,23 @@ type renderContext struct {
 
 // ConvertMarkdownToPDF converts a markdown file to PDF
 func (c *GoldmarkGoPDFConverter) ConvertMarkdownToPDF(inputPath, outputPath string, opts *ConversionOptions) error {
-	// Use default options if not provided
-	if opts == nil {
-		opts = DefaultOptions()
-	}
-
 	// Read markdown file
 	source, err := os.ReadFile(inputPath)
 	if err != nil {
 		return fmt.Errorf("reading markdown file: %w", err)
 	}
 
+	return c.ConvertMarkdownBytesToPDF(source, outputPath, opts)
+}
+
+// ConvertMarkdownBytesToPDF converts markdown source to PDF, for callers that
+// read the file themselves (e.g. to decrypt evidence first)
+func (c *GoldmarkGoPDFConverter) ConvertMarkdownBytesToPDF(source []byte, outputPath string, opts *ConversionOptions) error {
+	// Use default options if not provided
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+
 	// Parse markdown to AST
 	doc := c.md.Parser().Parse(text.NewReader(source))
 
@@ -119,7 +125,7 @@ func (c *GoldmarkGoPDFConverter) ConvertMarkdownToPDF(inputPath, outputPath stri
 	pdf.AddPage()
 
 	// Render title page
-	_, err = pageLayout.RenderTitlePage(pdf)
+	_, err := pageLayout.RenderTitlePage(pdf)
 	if err != nil {
 		return fmt.Errorf("rendering title page: %w", err)
 	}
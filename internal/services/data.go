@@ -17,6 +17,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/grctool/grctool/internal/domain"
 	"github.com/grctool/grctool/internal/storage"
@@ -181,7 +182,12 @@ func (d *DataServiceImpl) matchesEvidenceFilter(task domain.EvidenceTask, filter
 	}
 
 	// Framework filter
-	if filter.Framework != "" && task.Framework != filter.Framework {
+	if filter.Framework != "" && !task.HasFramework(filter.Framework) {
+		return false
+	}
+
+	// Assignee filter
+	if filter.AssignedTo != "" && !task.IsAssignedTo(filter.AssignedTo) {
 		return false
 	}
 
@@ -194,6 +200,10 @@ func (d *DataServiceImpl) matchesEvidenceFilter(task domain.EvidenceTask, filter
 		return false
 	}
 
+	if filter.ExcludeDeferred && task.IsDeferred(time.Now()) {
+		return false
+	}
+
 	// Category filter
 	if len(filter.Category) > 0 {
 		taskCategory := task.GetCategory()
@@ -253,6 +253,12 @@ func (s *EvidenceServiceSimple) GetEvidenceTaskSummary(ctx context.Context) (*do
 		// Count by priority
 		summary.ByPriority[task.Priority]++
 
+		// Deferred tasks are counted apart from overdue and due soon ones
+		if task.IsDeferred(now) {
+			summary.Deferred++
+			continue
+		}
+
 		// Check if overdue
 		if task.NextDue != nil && task.NextDue.Before(now) {
 			summary.Overdue++
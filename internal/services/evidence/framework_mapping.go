@@ -0,0 +1,143 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evidence
+
+import (
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/frameworks"
+)
+
+// FrameworkControlMapping records which controls and evidence tasks address a
+// control of the framework the evidence map was produced for
+type FrameworkControlMapping struct {
+	Code     string   `json:"code"`
+	Title    string   `json:"title"`
+	Theme    string   `json:"theme,omitempty"`
+	Controls []string `json:"controls,omitempty"`
+	Tasks    []string `json:"tasks,omitempty"`
+}
+
+// Covered reports whether any evidence task addresses the control
+func (m FrameworkControlMapping) Covered() bool {
+	return len(m.Tasks) > 0
+}
+
+// MapFrameworkControls maps the controls and evidence tasks of an evidence map
+// onto the controls of the framework. Controls carrying framework codes of
+// the framework map directly, and the remaining SOC2 controls through the
+// SOC2 crosswalk. For ISO 27001 every Annex A control is listed and for
+// HIPAA every Security Rule safeguard, themed by safeguard category; for NIST
+// 800-53 and PCI DSS, whose catalogs run to hundreds of controls and
+// requirements, only the referenced ones. Custom frameworks list every
+// control they define. SOC2 maps are left as they are.
+func MapFrameworkControls(result *EvidenceMapResult, framework string) {
+	result.Framework = framework
+	result.FrameworkControls = nil
+	if framework == frameworks.SOC2 {
+		return
+	}
+
+	controlsByCode := make(map[string][]string)
+	codesByControl := make(map[string][]string)
+	for _, control := range result.Controls {
+		codes := control.Requirements(framework)
+		for _, code := range codes {
+			controlsByCode[code] = append(controlsByCode[code], controlLabel(control))
+		}
+		codesByControl[control.ID] = codes
+		if control.ReferenceID != "" {
+			codesByControl[control.ReferenceID] = codes
+		}
+	}
+
+	tasksByCode := make(map[string][]string)
+	for _, task := range result.Tasks {
+		seen := make(map[string]bool)
+		for _, controlID := range task.Controls {
+			for _, code := range codesByControl[controlID] {
+				if !seen[code] {
+					seen[code] = true
+					tasksByCode[code] = append(tasksByCode[code], taskLabel(task))
+				}
+			}
+		}
+	}
+
+	if framework == frameworks.ISO27001 {
+		for _, control := range frameworks.AnnexA() {
+			result.FrameworkControls = append(result.FrameworkControls, FrameworkControlMapping{
+				Code:     control.Code,
+				Title:    control.Title,
+				Theme:    control.Theme,
+				Controls: controlsByCode[control.Code],
+				Tasks:    tasksByCode[control.Code],
+			})
+		}
+		return
+	}
+	if framework == frameworks.HIPAA {
+		for _, safeguard := range frameworks.HIPAASafeguards() {
+			result.FrameworkControls = append(result.FrameworkControls, FrameworkControlMapping{
+				Code:     safeguard.Code,
+				Title:    safeguard.Title,
+				Theme:    safeguard.Safeguard,
+				Controls: controlsByCode[safeguard.Code],
+				Tasks:    tasksByCode[safeguard.Code],
+			})
+		}
+		return
+	}
+	if custom, ok := frameworks.Custom(framework); ok {
+		for _, control := range custom.Controls {
+			result.FrameworkControls = append(result.FrameworkControls, FrameworkControlMapping{
+				Code:     control.Code,
+				Title:    control.Title,
+				Theme:    control.Theme,
+				Controls: controlsByCode[control.Code],
+				Tasks:    tasksByCode[control.Code],
+			})
+		}
+		return
+	}
+
+	codes := make([]string, 0, len(controlsByCode))
+	for code := range controlsByCode {
+		codes = append(codes, code)
+	}
+	frameworks.Sort(framework, codes)
+	for _, code := range codes {
+		result.FrameworkControls = append(result.FrameworkControls, FrameworkControlMapping{
+			Code:     code,
+			Title:    frameworks.Title(framework, code),
+			Controls: controlsByCode[code],
+			Tasks:    tasksByCode[code],
+		})
+	}
+}
+
+func controlLabel(control domain.Control) string {
+	if control.ReferenceID != "" {
+		return control.ReferenceID
+	}
+	return control.ID
+}
+
+func taskLabel(task domain.EvidenceTask) string {
+	if task.ReferenceID != "" {
+		return task.ReferenceID
+	}
+	return task.ID
+}
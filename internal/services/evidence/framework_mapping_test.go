@@ -0,0 +1,146 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package evidence
+
+import (
+	"testing"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/frameworks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapFrameworkControls(t *testing.T) {
+	t.Parallel()
+
+	newResult := func() *EvidenceMapResult {
+		return &EvidenceMapResult{
+			Controls: []domain.Control{
+				{ID: "101", ReferenceID: "CC8.1"},
+				{ID: "102", ReferenceID: "AC-01", FrameworkCodes: []domain.FrameworkCode{
+					{Framework: "ISO/IEC 27001:2022", Code: "A.5.15"},
+					{Framework: "ISO 27001", Code: "5.15"},
+					{Framework: "SOC 2", Code: "CC6.1"},
+				}},
+				{ID: "103", ReferenceID: "LOG-01", FrameworkCodes: []domain.FrameworkCode{
+					{Framework: "SOC 2", Code: "CC7.2"},
+				}},
+			},
+			Tasks: []domain.EvidenceTask{
+				{ID: "1", ReferenceID: "ET-0001", Controls: []string{"101"}},
+				{ID: "2", ReferenceID: "ET-0002", Controls: []string{"AC-01", "LOG-01"}},
+				{ID: "3", Controls: []string{"LOG-01"}},
+			},
+		}
+	}
+
+	soc2 := newResult()
+	MapFrameworkControls(soc2, frameworks.SOC2)
+	assert.Equal(t, frameworks.SOC2, soc2.Framework)
+	assert.Empty(t, soc2.FrameworkControls)
+
+	iso := newResult()
+	MapFrameworkControls(iso, frameworks.ISO27001)
+	assert.Equal(t, frameworks.ISO27001, iso.Framework)
+	require.Len(t, iso.FrameworkControls, 93, "every Annex A control is listed")
+
+	byCode := make(map[string]FrameworkControlMapping)
+	for _, control := range iso.FrameworkControls {
+		byCode[control.Code] = control
+	}
+	assert.Equal(t, FrameworkControlMapping{Code: "A.5.15", Title: "Access control", Theme: frameworks.ThemeOrganizational,
+		Controls: []string{"AC-01"}, Tasks: []string{"ET-0002"}}, byCode["A.5.15"])
+	assert.Equal(t, []string{"CC8.1"}, byCode["A.8.32"].Controls, "crosswalked from the SOC2 reference")
+	assert.Equal(t, []string{"ET-0001"}, byCode["A.8.32"].Tasks)
+	assert.Equal(t, []string{"ET-0002", "3"}, byCode["A.8.15"].Tasks, "crosswalked from SOC2 framework codes")
+	assert.Empty(t, byCode["A.8.2"].Controls, "ISO codes on a control replace its SOC2 crosswalk")
+	assert.False(t, byCode["A.7.4"].Covered())
+
+	nist := newResult()
+	nist.Controls = append(nist.Controls, domain.Control{ID: "104", ReferenceID: "IAM-02", Framework: "NIST 800-53",
+		Codes: "ac-2, AC-6(1)"})
+	nist.Tasks = append(nist.Tasks, domain.EvidenceTask{ID: "4", ReferenceID: "ET-0004", Controls: []string{"104"}})
+	MapFrameworkControls(nist, frameworks.NIST80053)
+	assert.Equal(t, frameworks.NIST80053, nist.Framework)
+
+	byCode = make(map[string]FrameworkControlMapping)
+	var codes []string
+	for _, control := range nist.FrameworkControls {
+		byCode[control.Code] = control
+		codes = append(codes, control.Code)
+	}
+	assert.Equal(t, []string{"AC-2", "AC-3", "AC-6", "AC-6(1)", "AU-2", "AU-6", "AU-12", "CM-3", "CM-4", "IA-2", "SA-10", "SC-28", "SI-4"}, codes,
+		"only referenced controls are listed, in catalog order")
+	assert.Equal(t, FrameworkControlMapping{Code: "AC-6(1)", Title: "Access Control",
+		Controls: []string{"IAM-02"}, Tasks: []string{"ET-0004"}}, byCode["AC-6(1)"])
+	assert.Equal(t, []string{"AC-01", "IAM-02"}, byCode["AC-2"].Controls, "crosswalked and direct references")
+	assert.Equal(t, []string{"ET-0001"}, byCode["SA-10"].Tasks)
+
+	pci := newResult()
+	pci.Controls = append(pci.Controls, domain.Control{ID: "105", ReferenceID: "NET-01", Framework: "PCI DSS v4.0",
+		Codes: "Req. 1.3.1, 1.2.5"})
+	pci.Tasks = append(pci.Tasks, domain.EvidenceTask{ID: "5", ReferenceID: "ET-0005", Controls: []string{"105"}})
+	MapFrameworkControls(pci, frameworks.PCIDSS)
+	assert.Equal(t, frameworks.PCIDSS, pci.Framework)
+
+	byCode = make(map[string]FrameworkControlMapping)
+	codes = nil
+	for _, control := range pci.FrameworkControls {
+		byCode[control.Code] = control
+		codes = append(codes, control.Code)
+	}
+	assert.Equal(t, []string{"1.2.5", "1.3.1", "3.5.1", "6.5.1", "6.5.2", "7.2.1", "7.2.2", "8.2.1", "8.3.1", "10.2.1", "10.4.1", "11.5.1"},
+		codes,
+		"only referenced requirements are listed, in numeric order")
+	assert.Equal(t, FrameworkControlMapping{Code: "1.2.5", Title: "Install and Maintain Network Security Controls",
+		Controls: []string{"NET-01"}, Tasks: []string{"ET-0005"}}, byCode["1.2.5"])
+	assert.Equal(t, []string{"CC8.1"}, byCode["6.5.1"].Controls, "crosswalked from the SOC2 reference")
+
+	hipaa := newResult()
+	hipaa.Controls = append(hipaa.Controls, domain.Control{ID: "106", ReferenceID: "PHI-01", FrameworkCodes: []domain.FrameworkCode{
+		{Framework: "HIPAA Security Rule", Code: "45 CFR 164.312(e)(2)(II)"},
+		{Framework: "SOC 2", Code: "CC6.7"},
+	}})
+	hipaa.Tasks = append(hipaa.Tasks, domain.EvidenceTask{ID: "6", ReferenceID: "ET-0006", Controls: []string{"PHI-01"}})
+	MapFrameworkControls(hipaa, frameworks.HIPAA)
+	assert.Equal(t, frameworks.HIPAA, hipaa.Framework)
+	require.Len(t, hipaa.FrameworkControls, len(frameworks.HIPAASafeguards()), "every safeguard is listed")
+
+	byCode = make(map[string]FrameworkControlMapping)
+	for _, control := range hipaa.FrameworkControls {
+		byCode[control.Code] = control
+	}
+	assert.Equal(t, FrameworkControlMapping{Code: "164.312(e)(2)(ii)", Title: "Encryption", Theme: frameworks.SafeguardTechnical,
+		Controls: []string{"PHI-01"}, Tasks: []string{"ET-0006"}}, byCode["164.312(e)(2)(ii)"])
+	assert.Empty(t, byCode["164.312(e)(1)"].Controls, "HIPAA codes on a control replace its SOC2 crosswalk")
+	assert.Equal(t, []string{"AC-01"}, byCode["164.312(a)(2)(iv)"].Controls, "crosswalked from SOC2 framework codes")
+	assert.Equal(t, []string{"ET-0002", "3"}, byCode["164.312(b)"].Tasks)
+	assert.Equal(t, []string{"ET-0001"}, byCode["164.308(a)(8)"].Tasks, "crosswalked from the SOC2 reference")
+	assert.False(t, byCode["164.310(c)"].Covered())
+
+	require.NoError(t, frameworks.RegisterCustom(frameworks.CustomFramework{
+		Name:  "acme-evidence-map",
+		Label: "Acme ICF",
+		Controls: []frameworks.CustomControl{
+			{Code: "ICF-CM-01", Title: "Changes are approved", Theme: "Change", SOC2: []string{"CC8.1"}},
+			{Code: "ICF-BK-01", Title: "Backups are tested", Theme: "Resilience", Keywords: []string{"backup"}},
+			{Code: "ICF-AC-01", Title: "Access is reviewed", Theme: "Access", SOC2: []string{"CC6.1"}},
+		},
+	}))
+	custom := newResult()
+	custom.Controls = append(custom.Controls,
+		domain.Control{ID: "107", ReferenceID: "BK-01", Name: "Nightly backup restore test"},
+		domain.Control{ID: "108", ReferenceID: "ICF-07", FrameworkCodes: []domain.FrameworkCode{
+			{Framework: "Acme ICF", Code: "icf-bk-01"},
+			{Framework: "SOC 2", Code: "CC6.1"},
+		}})
+	custom.Tasks = append(custom.Tasks, domain.EvidenceTask{ID: "7", ReferenceID: "ET-0007", Controls: []string{"107"}})
+	MapFrameworkControls(custom, "acme-evidence-map")
+	assert.Equal(t, []FrameworkControlMapping{
+		{Code: "ICF-CM-01", Title: "Changes are approved", Theme: "Change", Controls: []string{"CC8.1"}, Tasks: []string{"ET-0001"}},
+		{Code: "ICF-BK-01", Title: "Backups are tested", Theme: "Resilience", Controls: []string{"BK-01", "ICF-07"}, Tasks: []string{"ET-0007"}},
+		{Code: "ICF-AC-01", Title: "Access is reviewed", Theme: "Access", Controls: []string{"AC-01"}, Tasks: []string{"ET-0002"}},
+	}, custom.FrameworkControls, "every custom control is listed in definition order, mapped by code, SOC2 crosswalk or keyword")
+}
@@ -457,7 +457,7 @@ func (s *ServiceImpl) calculateMapSummary(tasks []domain.EvidenceTask, controls
 		summary.PriorityCounts[task.Priority]++
 
 		// Check if overdue
-		if task.NextDue != nil && task.NextDue.Before(now) {
+		if task.IsOverdue(now) {
 			summary.OverdueCount++
 		}
 	}
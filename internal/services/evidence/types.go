@@ -52,6 +52,8 @@ type EvidenceMapResult struct {
 	FrameworkGroups    map[string][]domain.EvidenceTask `json:"framework_groups"`
 	TotalRelationships int                              `json:"total_relationships"`
 	Summary            *EvidenceMapSummary              `json:"summary"`
+	Framework          string                           `json:"framework,omitempty"`
+	FrameworkControls  []FrameworkControlMapping        `json:"framework_controls,omitempty"`
 }
 
 // EvidenceMapSummary provides summary statistics for evidence mapping
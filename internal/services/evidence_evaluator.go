@@ -21,6 +21,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/grctool/grctool/internal/config"
 	"github.com/grctool/grctool/internal/domain"
 	"github.com/grctool/grctool/internal/logger"
 	"github.com/grctool/grctool/internal/models"
@@ -33,6 +34,8 @@ type EvidenceEvaluatorService struct {
 	storage     *storage.Storage
 	scanner     EvidenceScanner
 	logger      logger.Logger
+	rubrics     config.EvaluationConfig
+	review      *modelReview
 }
 
 // NewEvidenceEvaluatorService creates a new evidence evaluator service
@@ -45,6 +48,12 @@ func NewEvidenceEvaluatorService(evidenceDir string, storage *storage.Storage, s
 	}
 }
 
+// SetRubrics makes evaluations score evidence with the configured rubrics
+// instead of the default weights and thresholds
+func (s *EvidenceEvaluatorService) SetRubrics(rubrics config.EvaluationConfig) {
+	s.rubrics = rubrics
+}
+
 // EvaluateWindow evaluates all evidence in a window (across all subfolders)
 func (s *EvidenceEvaluatorService) EvaluateWindow(ctx context.Context, taskRef string, window string) (*models.EvaluationResult, error) {
 	s.logger.Info("Evaluating evidence window",
@@ -68,18 +77,7 @@ func (s *EvidenceEvaluatorService) EvaluateWindow(ctx context.Context, taskRef s
 	result.FileCount = windowState.FileCount
 	result.TotalBytes = windowState.TotalBytes
 
-	// Evaluate each dimension
-	s.evaluateCompleteness(task, windowState, result)
-	s.evaluateRequirementsMatch(task, windowState, result)
-	s.evaluateQuality(task, windowState, result)
-	s.evaluateControlAlignment(task, windowState, result)
-
-	// Calculate overall score and determine status
-	result.CalculateOverallScore()
-	result.DetermineStatus()
-
-	// Generate recommendations
-	s.generateRecommendations(task, result)
+	s.score(ctx, task, windowState, result)
 
 	s.logger.Info("Evaluation complete",
 		logger.String("task_ref", taskRef),
@@ -132,18 +130,7 @@ func (s *EvidenceEvaluatorService) EvaluateSubfolder(ctx context.Context, taskRe
 	result.FileCount = windowState.FileCount
 	result.TotalBytes = windowState.TotalBytes
 
-	// Evaluate each dimension
-	s.evaluateCompleteness(task, windowState, result)
-	s.evaluateRequirementsMatch(task, windowState, result)
-	s.evaluateQuality(task, windowState, result)
-	s.evaluateControlAlignment(task, windowState, result)
-
-	// Calculate overall score and determine status
-	result.CalculateOverallScore()
-	result.DetermineStatus()
-
-	// Generate recommendations
-	s.generateRecommendations(task, result)
+	s.score(ctx, task, windowState, result)
 
 	s.logger.Info("Evaluation complete",
 		logger.String("task_ref", taskRef),
@@ -155,6 +142,114 @@ func (s *EvidenceEvaluatorService) EvaluateSubfolder(ctx context.Context, taskRe
 	return result, nil
 }
 
+// score evaluates the window on each dimension its rubric scores, then its
+// required checks, and sets the overall score and status
+func (s *EvidenceEvaluatorService) score(ctx context.Context, task *domain.EvidenceTask, window *models.WindowState, result *models.EvaluationResult) {
+	rubric := s.rubrics.RubricFor(result.TaskRef, task.GetCategory())
+	applyRubric(rubric, result)
+
+	evaluators := map[string]func(*domain.EvidenceTask, *models.WindowState, *models.EvaluationResult){
+		"completeness":       s.evaluateCompleteness,
+		"requirements_match": s.evaluateRequirementsMatch,
+		"quality":            s.evaluateQuality,
+		"control_alignment":  s.evaluateControlAlignment,
+	}
+	for _, name := range config.EvaluationDimensions {
+		dimension := result.Dimension(name)
+		if !dimension.Scored() {
+			dimension.Status = models.DimensionSkipped
+			dimension.Details = fmt.Sprintf("Not scored by rubric %s", rubric.Name)
+			continue
+		}
+		evaluators[name](task, window, result)
+	}
+	s.reviewWithModel(ctx, task, window, result)
+	if rubric != nil {
+		s.evaluateRequiredChecks(rubric, window, result)
+	}
+
+	// Calculate overall score and determine status
+	result.CalculateOverallScore()
+	result.DetermineStatus()
+
+	// Generate recommendations
+	s.generateRecommendations(task, result)
+}
+
+// applyRubric sets the weights and thresholds of a rubric on the result.
+// Weights are normalized so they sum to 1, as the defaults do.
+func applyRubric(rubric *config.EvaluationRubric, result *models.EvaluationResult) {
+	if rubric == nil {
+		return
+	}
+	result.Rubric = rubric.Name
+	if rubric.PassThreshold > 0 {
+		result.PassThreshold = rubric.PassThreshold
+	}
+	if len(rubric.Dimensions) == 0 {
+		return
+	}
+
+	totalWeight := 0.0
+	for _, dimension := range rubric.Dimensions {
+		totalWeight += dimension.Weight
+	}
+	for _, name := range config.EvaluationDimensions {
+		score := result.Dimension(name)
+		configured, ok := rubric.Dimensions[name]
+		if !ok || totalWeight == 0 {
+			score.Weight = 0
+			continue
+		}
+		score.Weight = configured.Weight / totalWeight
+		if configured.Pass > 0 {
+			score.PassScore = configured.Pass
+		}
+		if configured.Warn > 0 {
+			score.WarnScore = configured.Warn
+		}
+	}
+}
+
+// evaluateRequiredChecks records whether the window holds the files each
+// check of the rubric requires, raising an issue for each check not met
+func (s *EvidenceEvaluatorService) evaluateRequiredChecks(rubric *config.EvaluationRubric, window *models.WindowState, result *models.EvaluationResult) {
+	for _, check := range rubric.RequiredChecks {
+		minFiles := max(check.MinFiles, 1)
+		severity := models.IssueSeverity(check.Severity)
+		if severity == "" {
+			severity = models.IssueCritical
+		}
+
+		matched := 0
+		for _, file := range window.Files {
+			if matchesFilePattern(check.Files, file.Filename) {
+				matched++
+			}
+		}
+
+		checkResult := models.RequiredCheckResult{
+			Name:        check.Name,
+			Description: check.Description,
+			Status:      "pass",
+			Severity:    severity,
+			Details:     fmt.Sprintf("%d file(s) match %s, %d required", matched, strings.Join(check.Files, ", "), minFiles),
+		}
+		if matched < minFiles {
+			checkResult.Status = "fail"
+			requirement := check.Name
+			if check.Description != "" {
+				requirement = check.Description
+			}
+			result.MissingRequirements = append(result.MissingRequirements, requirement)
+			result.AddIssue(severity, "required_check",
+				fmt.Sprintf("Required check %s not met: %s", check.Name, checkResult.Details),
+				"", fmt.Sprintf("Add evidence matching %s", strings.Join(check.Files, ", ")))
+		}
+		result.RequiredChecks = append(result.RequiredChecks, checkResult)
+	}
+}
+
 // evaluateCompleteness evaluates if all required evidence is present
 func (s *EvidenceEvaluatorService) evaluateCompleteness(task *domain.EvidenceTask, window *models.WindowState, result *models.EvaluationResult) {
 	score := 0.0
@@ -211,13 +306,7 @@ func (s *EvidenceEvaluatorService) evaluateCompleteness(task *domain.EvidenceTas
 	result.Completeness.Score = score
 	result.Completeness.MaxScore = maxScore
 
-	if score >= 80 {
-		result.Completeness.Status = "pass"
-	} else if score >= 50 {
-		result.Completeness.Status = "warning"
-	} else {
-		result.Completeness.Status = "fail"
-	}
+	result.Completeness.GradeStatus()
 }
 
 // evaluateRequirementsMatch evaluates if evidence matches task requirements
@@ -275,13 +364,7 @@ func (s *EvidenceEvaluatorService) evaluateRequirementsMatch(task *domain.Eviden
 	result.RequirementsMatch.Score = score
 	result.RequirementsMatch.MaxScore = maxScore
 
-	if score >= 80 {
-		result.RequirementsMatch.Status = "pass"
-	} else if score >= 50 {
-		result.RequirementsMatch.Status = "warning"
-	} else {
-		result.RequirementsMatch.Status = "fail"
-	}
+	result.RequirementsMatch.GradeStatus()
 }
 
 // evaluateQuality evaluates evidence quality
@@ -363,13 +446,7 @@ func (s *EvidenceEvaluatorService) evaluateQuality(task *domain.EvidenceTask, wi
 	result.QualityScore.Score = score
 	result.QualityScore.MaxScore = maxScore
 
-	if score >= 80 {
-		result.QualityScore.Status = "pass"
-	} else if score >= 50 {
-		result.QualityScore.Status = "warning"
-	} else {
-		result.QualityScore.Status = "fail"
-	}
+	result.QualityScore.GradeStatus()
 }
 
 // evaluateControlAlignment evaluates how well evidence addresses related controls
@@ -415,13 +492,7 @@ func (s *EvidenceEvaluatorService) evaluateControlAlignment(task *domain.Evidenc
 	result.ControlAlignment.Score = score
 	result.ControlAlignment.MaxScore = maxScore
 
-	if score >= 80 {
-		result.ControlAlignment.Status = "pass"
-	} else if score >= 50 {
-		result.ControlAlignment.Status = "warning"
-	} else {
-		result.ControlAlignment.Status = "fail"
-	}
+	result.ControlAlignment.GradeStatus()
 }
 
 // Helper methods
@@ -556,6 +627,18 @@ func (s *EvidenceEvaluatorService) extractControlKeywords(controls []domain.Cont
 	return result
 }
 
+// matchesFilePattern reports whether a file name matches one of the glob
+// patterns, ignoring case
+func matchesFilePattern(patterns []string, filename string) bool {
+	name := strings.ToLower(filepath.Base(filename))
+	for _, pattern := range patterns {
+		if matched, _ := filepath.Match(strings.ToLower(pattern), name); matched {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *EvidenceEvaluatorService) calculateKeywordCoverage(files []models.FileState, keywords []string) float64 {
 	if len(keywords) == 0 {
 		return 1.0 // No keywords to match
@@ -578,19 +661,19 @@ func (s *EvidenceEvaluatorService) calculateKeywordCoverage(files []models.FileS
 func (s *EvidenceEvaluatorService) generateRecommendations(task *domain.EvidenceTask, result *models.EvaluationResult) {
 	// Generate recommendations based on scores and issues
 
-	if result.Completeness.Score < 70 {
+	if result.Completeness.Scored() && result.Completeness.Score < 70 {
 		result.AddRecommendation("Add more evidence files to improve completeness")
 	}
 
-	if result.RequirementsMatch.Score < 70 {
+	if result.RequirementsMatch.Scored() && result.RequirementsMatch.Score < 70 {
 		result.AddRecommendation("Review task requirements and ensure evidence addresses all requirements")
 	}
 
-	if result.QualityScore.Score < 70 {
+	if result.QualityScore.Scored() && result.QualityScore.Score < 70 {
 		result.AddRecommendation("Improve evidence quality by using structured formats and better naming")
 	}
 
-	if result.ControlAlignment.Score < 70 {
+	if result.ControlAlignment.Scored() && result.ControlAlignment.Score < 70 {
 		result.AddRecommendation(fmt.Sprintf("Ensure evidence demonstrates implementation of all %d related controls", len(task.RelatedControls)))
 	}
 
@@ -0,0 +1,220 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/utils"
+)
+
+// FreshnessReason explains why an evidence file is stale
+type FreshnessReason string
+
+const (
+	// FreshnessExpired indicates the evidence is older than the maximum age
+	FreshnessExpired FreshnessReason = "expired"
+
+	// FreshnessSourceChanged indicates a source changed after the evidence was collected
+	FreshnessSourceChanged FreshnessReason = "source_changed"
+)
+
+// collectionPlanFile is maintained alongside the evidence and is never stale itself
+const collectionPlanFile = "collection_plan.md"
+
+// StaleEvidence is an evidence file that should be collected again
+type StaleEvidence struct {
+	TaskRef          string          `json:"task_ref" yaml:"task_ref"`
+	Window           string          `json:"window" yaml:"window"`
+	File             string          `json:"file" yaml:"file"`
+	Reason           FreshnessReason `json:"reason" yaml:"reason"`
+	CollectedAt      time.Time       `json:"collected_at" yaml:"collected_at"`
+	AgeDays          int             `json:"age_days" yaml:"age_days"`
+	Source           string          `json:"source,omitempty" yaml:"source,omitempty"`                         // Changed source location
+	SourceModifiedAt *time.Time      `json:"source_modified_at,omitempty" yaml:"source_modified_at,omitempty"` // Current source modification time
+	Message          string          `json:"message" yaml:"message"`
+}
+
+// FreshnessOptions controls a freshness check
+type FreshnessOptions struct {
+	MaxAge time.Duration // Evidence collected longer ago is expired; zero disables the age check
+	Window string        // Check only this window; empty checks each task's latest window
+	Now    time.Time     // Reference time (default: time.Now)
+}
+
+// EvidenceFreshnessService flags evidence whose sources changed after
+// collection or that exceeds the maximum age
+type EvidenceFreshnessService struct {
+	scanner EvidenceScanner
+	layout  *evidenceScannerImpl // Locates task directories and generation metadata
+	logger  logger.Logger
+}
+
+// NewEvidenceFreshnessService creates a new evidence freshness service
+func NewEvidenceFreshnessService(evidenceDir string, scanner EvidenceScanner, log logger.Logger) *EvidenceFreshnessService {
+	return &EvidenceFreshnessService{
+		scanner: scanner,
+		layout:  &evidenceScannerImpl{evidenceDir: evidenceDir, logger: log},
+		logger:  log.WithComponent("evidence_freshness"),
+	}
+}
+
+// CheckAll checks the evidence of every task
+func (s *EvidenceFreshnessService) CheckAll(ctx context.Context, opts FreshnessOptions) ([]StaleEvidence, error) {
+	taskStates, err := s.scanner.ScanAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan evidence: %w", err)
+	}
+
+	taskRefs := make([]string, 0, len(taskStates))
+	for taskRef := range taskStates {
+		taskRefs = append(taskRefs, taskRef)
+	}
+	sort.Strings(taskRefs)
+
+	var stale []StaleEvidence
+	for _, taskRef := range taskRefs {
+		stale = append(stale, s.checkTaskState(taskRef, taskStates[taskRef], opts)...)
+	}
+	return stale, nil
+}
+
+// CheckTask checks the evidence of a single task
+func (s *EvidenceFreshnessService) CheckTask(ctx context.Context, taskRef string, opts FreshnessOptions) ([]StaleEvidence, error) {
+	state, err := s.scanner.ScanTask(ctx, taskRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan evidence for %s: %w", taskRef, err)
+	}
+	return s.checkTaskState(taskRef, state, opts), nil
+}
+
+func (s *EvidenceFreshnessService) checkTaskState(taskRef string, state *models.EvidenceTaskState, opts FreshnessOptions) []StaleEvidence {
+	window := opts.Window
+	if window == "" {
+		window = latestWindowName(state.Windows)
+	}
+	windowState, ok := state.Windows[window]
+	if !ok {
+		return nil
+	}
+
+	var metadata *models.GenerationMetadata
+	taskDir, err := s.layout.findTaskDirectory(taskRef)
+	if err == nil && taskDir != "" {
+		metadata, err = s.layout.readGenerationMetadata(filepath.Join(taskDir, window))
+		if err != nil {
+			s.logger.Warn("Ignoring unreadable generation metadata",
+				logger.String("task_ref", taskRef),
+				logger.String("window", window),
+				logger.Error(err))
+		}
+	}
+
+	return CheckWindowFreshness(taskRef, windowState, metadata, opts)
+}
+
+// CheckWindowFreshness checks the files of one window. Collection times come
+// from the generation metadata, falling back to the file modification time
+// for files added by hand. File sources are re-read from disk.
+func CheckWindowFreshness(taskRef string, window models.WindowState, metadata *models.GenerationMetadata, opts FreshnessOptions) []StaleEvidence {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	recorded := make(map[string]models.FileMetadata)
+	if metadata != nil {
+		for _, file := range metadata.FilesGenerated {
+			recorded[filepath.Base(file.Path)] = file
+		}
+	}
+
+	var stale []StaleEvidence
+	seen := make(map[string]bool)
+	for _, file := range window.Files {
+		// Submitted and archived copies share the working file's name
+		if file.Filename == collectionPlanFile || seen[file.Filename] {
+			continue
+		}
+		seen[file.Filename] = true
+
+		collectedAt := file.ModifiedAt
+		fileMeta, hasMeta := recorded[file.Filename]
+		if hasMeta && !fileMeta.GeneratedAt.IsZero() {
+			collectedAt = fileMeta.GeneratedAt
+		}
+		ageDays := int(now.Sub(collectedAt).Hours() / 24)
+
+		entry := StaleEvidence{
+			TaskRef:     taskRef,
+			Window:      window.Window,
+			File:        file.Filename,
+			CollectedAt: collectedAt,
+			AgeDays:     ageDays,
+		}
+
+		if opts.MaxAge > 0 && now.Sub(collectedAt) > opts.MaxAge {
+			expired := entry
+			expired.Reason = FreshnessExpired
+			expired.Message = fmt.Sprintf("collected %d days ago (max age %d days)", ageDays, int(opts.MaxAge.Hours()/24))
+			stale = append(stale, expired)
+		}
+
+		for _, source := range fileMeta.Sources {
+			modified := currentSourceTime(source)
+			if !modified.After(collectedAt) {
+				continue
+			}
+			changed := entry
+			changed.Reason = FreshnessSourceChanged
+			changed.Source = source.Location
+			changed.SourceModifiedAt = &modified
+			changed.Message = fmt.Sprintf("%s source %s changed %s, after collection on %s",
+				source.Type, source.Location, modified.Format("2006-01-02 15:04"), collectedAt.Format("2006-01-02 15:04"))
+			stale = append(stale, changed)
+		}
+	}
+
+	return stale
+}
+
+// currentSourceTime returns the latest known modification time of a source.
+// Local files are checked on disk; other sources keep their recorded time.
+func currentSourceTime(source models.SourceTimestamp) time.Time {
+	if source.Type == models.SourceTypeFile {
+		if modified, ok := utils.LatestModTime(source.Location); ok {
+			return modified
+		}
+	}
+	return source.ModifiedAt
+}
+
+// latestWindowName returns the most recent window name; window names sort chronologically
+func latestWindowName(windows map[string]models.WindowState) string {
+	latest := ""
+	for name := range windows {
+		if name > latest {
+			latest = name
+		}
+	}
+	return latest
+}
@@ -0,0 +1,137 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestCheckWindowFreshness(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)
+	collected := now.AddDate(0, 0, -10)
+
+	sourceFile := filepath.Join(t.TempDir(), "main.tf")
+	require.NoError(t, os.WriteFile(sourceFile, []byte(`resource "aws_s3_bucket" "logs" {}`), 0644))
+	require.NoError(t, os.Chtimes(sourceFile, now, now.AddDate(0, 0, -2)))
+
+	window := models.WindowState{
+		Window: "2025-Q4",
+		Files: []models.FileState{
+			{Filename: "01_terraform.md", ModifiedAt: collected},
+			{Filename: "02_manual_upload.pdf", ModifiedAt: now.AddDate(0, 0, -120)},
+			{Filename: "03_policy.md", ModifiedAt: collected},
+			{Filename: "collection_plan.md", ModifiedAt: now.AddDate(-1, 0, 0)},
+			{Filename: "02_manual_upload.pdf", ModifiedAt: now.AddDate(0, 0, -120)},
+		},
+	}
+	metadata := &models.GenerationMetadata{
+		FilesGenerated: []models.FileMetadata{
+			{
+				Path:        "01_terraform.md",
+				GeneratedAt: collected,
+				Sources: []models.SourceTimestamp{
+					{Type: models.SourceTypeTool, Location: "terraform", ModifiedAt: collected},
+					{Type: models.SourceTypeFile, Location: sourceFile, ModifiedAt: collected.AddDate(0, 0, -30)},
+				},
+			},
+			{
+				Path:        "03_policy.md",
+				GeneratedAt: collected,
+				Sources: []models.SourceTimestamp{
+					{Type: models.SourceTypeDocument, Location: "https://docs.google.com/document/d/abc", ModifiedAt: collected.AddDate(0, 0, -1)},
+				},
+			},
+		},
+	}
+
+	stale := CheckWindowFreshness("ET-0001", window, metadata, FreshnessOptions{MaxAge: 90 * 24 * time.Hour, Now: now})
+	require.Len(t, stale, 2)
+
+	changed := stale[0]
+	assert.Equal(t, "01_terraform.md", changed.File)
+	assert.Equal(t, FreshnessSourceChanged, changed.Reason)
+	assert.Equal(t, sourceFile, changed.Source)
+	require.NotNil(t, changed.SourceModifiedAt)
+	assert.True(t, changed.SourceModifiedAt.Equal(now.AddDate(0, 0, -2)), "source time is read from disk")
+	assert.Equal(t, 10, changed.AgeDays)
+
+	expired := stale[1]
+	assert.Equal(t, "02_manual_upload.pdf", expired.File)
+	assert.Equal(t, FreshnessExpired, expired.Reason)
+	assert.Equal(t, "collected 120 days ago (max age 90 days)", expired.Message)
+	assert.Equal(t, "2025-Q4", expired.Window)
+}
+
+func TestCheckWindowFreshness_NoMaxAge(t *testing.T) {
+	t.Parallel()
+
+	window := models.WindowState{
+		Window: "2024-Q1",
+		Files:  []models.FileState{{Filename: "old.csv", ModifiedAt: time.Now().AddDate(-2, 0, 0)}},
+	}
+
+	assert.Empty(t, CheckWindowFreshness("ET-0002", window, nil, FreshnessOptions{}))
+}
+
+func TestEvidenceFreshnessService_CheckAll(t *testing.T) {
+	t.Parallel()
+
+	evidenceDir := filepath.Join(t.TempDir(), "evidence")
+	taskDir := filepath.Join(evidenceDir, "Access_Review_ET-0001_100")
+	oldWindow := filepath.Join(taskDir, "2025-Q2")
+	newWindow := filepath.Join(taskDir, "2025-Q3")
+	require.NoError(t, os.MkdirAll(oldWindow, 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(newWindow, ".generation"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(oldWindow, "01_old.md"), []byte("# Old"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(newWindow, "01_review.md"), []byte("# Review"), 0644))
+
+	generatedAt := time.Now().AddDate(0, 0, -200)
+	metadata := models.GenerationMetadata{
+		GeneratedAt:    generatedAt,
+		TaskRef:        "ET-0001",
+		Window:         "2025-Q3",
+		FilesGenerated: []models.FileMetadata{{Path: "01_review.md", GeneratedAt: generatedAt}},
+	}
+	data, err := yaml.Marshal(&metadata)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(newWindow, ".generation", "metadata.yaml"), data, 0644))
+
+	log := newTestLogger(t)
+	service := NewEvidenceFreshnessService(evidenceDir, NewEvidenceScanner(evidenceDir, nil, log), log)
+
+	stale, err := service.CheckAll(context.Background(), FreshnessOptions{MaxAge: 90 * 24 * time.Hour})
+	require.NoError(t, err)
+	require.Len(t, stale, 1, "only the latest window is checked")
+	assert.Equal(t, "ET-0001", stale[0].TaskRef)
+	assert.Equal(t, "2025-Q3", stale[0].Window)
+	assert.Equal(t, FreshnessExpired, stale[0].Reason)
+	assert.Equal(t, 200, stale[0].AgeDays, "collection time comes from the generation metadata")
+
+	stale, err = service.CheckTask(context.Background(), "ET-0001", FreshnessOptions{MaxAge: 90 * 24 * time.Hour, Window: "2025-Q2"})
+	require.NoError(t, err)
+	assert.Empty(t, stale, "files without metadata use their modification time")
+}
@@ -0,0 +1,336 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/naming"
+	"github.com/grctool/grctool/internal/storage"
+)
+
+// IntegrityStatus is the outcome of verifying one evidence file
+type IntegrityStatus string
+
+const (
+	// IntegrityVerified indicates the file matches its recorded checksum
+	IntegrityVerified IntegrityStatus = "verified"
+
+	// IntegrityModified indicates the file changed after it was written
+	IntegrityModified IntegrityStatus = "modified"
+
+	// IntegrityMissing indicates a recorded file no longer exists
+	IntegrityMissing IntegrityStatus = "missing"
+
+	// IntegrityUnreadable indicates the file or its metadata could not be read
+	IntegrityUnreadable IntegrityStatus = "unreadable"
+
+	// IntegrityUnrecorded indicates the metadata has no checksum for the file
+	IntegrityUnrecorded IntegrityStatus = "unrecorded"
+
+	// IntegrityUntracked indicates a file grctool did not write, e.g. one
+	// added by hand
+	IntegrityUntracked IntegrityStatus = "untracked"
+)
+
+// Window integrity results
+const (
+	WindowIntact      = "intact"      // Every recorded file verified
+	WindowCompromised = "compromised" // A recorded file was modified, is missing or unreadable
+	WindowNoMetadata  = "no_metadata" // No generation metadata records checksums
+)
+
+// generationMetadataPath is where the evidence writer records each window's files
+var generationMetadataPath = filepath.Join(".generation", "metadata.yaml")
+
+// FileIntegrity is the verification result of one evidence file
+type FileIntegrity struct {
+	File             string          `json:"file" yaml:"file"` // Relative to the window, e.g. ".submitted/01_users.csv"
+	Status           IntegrityStatus `json:"status" yaml:"status"`
+	ExpectedChecksum string          `json:"expected_checksum,omitempty" yaml:"expected_checksum,omitempty"`
+	ActualChecksum   string          `json:"actual_checksum,omitempty" yaml:"actual_checksum,omitempty"`
+	ExpectedSize     int64           `json:"expected_size,omitempty" yaml:"expected_size,omitempty"`
+	ActualSize       int64           `json:"actual_size,omitempty" yaml:"actual_size,omitempty"`
+	Message          string          `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// WindowIntegrity is the verification result of one evidence window
+type WindowIntegrity struct {
+	TaskRef   string          `json:"task_ref" yaml:"task_ref"`
+	Window    string          `json:"window" yaml:"window"`
+	Status    string          `json:"status" yaml:"status"`
+	Verified  int             `json:"verified" yaml:"verified"`
+	Modified  int             `json:"modified" yaml:"modified"`
+	Missing   int             `json:"missing" yaml:"missing"`
+	Untracked int             `json:"untracked" yaml:"untracked"`
+	Files     []FileIntegrity `json:"files" yaml:"files"`
+}
+
+// Compromised reports whether a recorded file was modified, is missing or
+// could not be checked
+func (w WindowIntegrity) Compromised() bool {
+	return w.Status == WindowCompromised
+}
+
+// EvidenceIntegrityService re-verifies evidence files against the SHA256
+// checksums recorded in .generation/metadata.yaml when they were written
+type EvidenceIntegrityService struct {
+	layout *evidenceScannerImpl // Locates task directories
+	logger logger.Logger
+}
+
+// NewEvidenceIntegrityService creates a new evidence integrity service
+func NewEvidenceIntegrityService(evidenceDir string, log logger.Logger) *EvidenceIntegrityService {
+	return &EvidenceIntegrityService{
+		layout: &evidenceScannerImpl{evidenceDir: evidenceDir, logger: log},
+		logger: log.WithComponent("evidence_integrity"),
+	}
+}
+
+// VerifyAll verifies every task's windows, or only the named window when
+// window is set
+func (s *EvidenceIntegrityService) VerifyAll(ctx context.Context, window string) ([]WindowIntegrity, error) {
+	entries, err := os.ReadDir(s.layout.evidenceDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read evidence directory: %w", err)
+	}
+
+	var results []WindowIntegrity
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		taskRef := naming.ExtractTaskRef(entry.Name())
+		if !entry.IsDir() || taskRef == "" {
+			continue
+		}
+		windows, err := s.verifyTaskDir(taskRef, filepath.Join(s.layout.evidenceDir, entry.Name()), window)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, windows...)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].TaskRef != results[j].TaskRef {
+			return results[i].TaskRef < results[j].TaskRef
+		}
+		return results[i].Window < results[j].Window
+	})
+	return results, nil
+}
+
+// VerifyTask verifies one task's windows, or only the named window when
+// window is set
+func (s *EvidenceIntegrityService) VerifyTask(ctx context.Context, taskRef, window string) ([]WindowIntegrity, error) {
+	taskDir, err := s.layout.findTaskDirectory(taskRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find evidence for %s: %w", taskRef, err)
+	}
+	if taskDir == "" {
+		return nil, fmt.Errorf("no evidence found for %s", taskRef)
+	}
+	results, err := s.verifyTaskDir(taskRef, taskDir, window)
+	if err != nil {
+		return nil, err
+	}
+	if window != "" && len(results) == 0 {
+		return nil, fmt.Errorf("no evidence found for %s in window %s", taskRef, window)
+	}
+	return results, nil
+}
+
+func (s *EvidenceIntegrityService) verifyTaskDir(taskRef, taskDir, window string) ([]WindowIntegrity, error) {
+	entries, err := os.ReadDir(taskDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read evidence for %s: %w", taskRef, err)
+	}
+
+	var results []WindowIntegrity
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || strings.HasPrefix(name, ".") || name == "metadata" {
+			continue
+		}
+		if window != "" && name != window {
+			continue
+		}
+		result := VerifyWindow(taskRef, name, filepath.Join(taskDir, name))
+		if result.Compromised() {
+			s.logger.Warn("Evidence integrity check failed",
+				logger.String("task_ref", taskRef),
+				logger.String("window", name),
+				logger.Int("modified", result.Modified),
+				logger.Int("missing", result.Missing))
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// VerifyWindow checks the files recorded in a window's generation metadata:
+// that of the working files at the window root and, once submitted, that
+// moved with them to .submitted/. Files are hashed as plaintext, so
+// encrypted evidence verifies against the checksum taken when it was
+// written. Files no metadata records are listed as untracked.
+func VerifyWindow(taskRef, window, windowDir string) WindowIntegrity {
+	result := WindowIntegrity{TaskRef: taskRef, Window: window, Files: []FileIntegrity{}}
+	submittedDir := filepath.Join(windowDir, naming.SubfolderSubmitted)
+	hasMetadata := false
+	checked := make(map[string]bool)
+
+	layout := &evidenceScannerImpl{}
+	for _, dir := range []string{windowDir, submittedDir} {
+		metadata, err := layout.readGenerationMetadata(dir)
+		if err != nil {
+			result.Files = append(result.Files, FileIntegrity{
+				File:    relativeToWindow(windowDir, filepath.Join(dir, generationMetadataPath)),
+				Status:  IntegrityUnreadable,
+				Message: err.Error(),
+			})
+			continue
+		}
+		if metadata == nil {
+			continue
+		}
+		hasMetadata = true
+
+		for _, recorded := range metadata.FilesGenerated {
+			path := filepath.Join(dir, recorded.Path)
+			if _, err := os.Stat(path); err != nil && dir == windowDir {
+				// Submission moves files to .submitted/ but leaves the
+				// metadata behind when .submitted/ already has its own
+				if _, err := os.Stat(filepath.Join(submittedDir, recorded.Path)); err == nil {
+					path = filepath.Join(submittedDir, recorded.Path)
+				}
+			}
+			if checked[path] {
+				continue
+			}
+			checked[path] = true
+			result.Files = append(result.Files, verifyFile(windowDir, path, recorded.Checksum, recorded.SizeBytes))
+		}
+	}
+
+	// Evidence files nobody recorded
+	for _, dir := range []string{windowDir, submittedDir} {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			path := filepath.Join(dir, name)
+			if !entry.Type().IsRegular() || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "collection_plan") || checked[path] {
+				continue
+			}
+			result.Files = append(result.Files, FileIntegrity{
+				File:    relativeToWindow(windowDir, path),
+				Status:  IntegrityUntracked,
+				Message: "not recorded in generation metadata",
+			})
+		}
+	}
+
+	sort.SliceStable(result.Files, func(i, j int) bool { return result.Files[i].File < result.Files[j].File })
+
+	compromised := false
+	for _, file := range result.Files {
+		switch file.Status {
+		case IntegrityVerified:
+			result.Verified++
+		case IntegrityModified:
+			result.Modified++
+			compromised = true
+		case IntegrityMissing:
+			result.Missing++
+			compromised = true
+		case IntegrityUnreadable:
+			compromised = true
+		case IntegrityUntracked:
+			result.Untracked++
+		}
+	}
+	switch {
+	case compromised:
+		result.Status = WindowCompromised
+	case !hasMetadata:
+		result.Status = WindowNoMetadata
+	default:
+		result.Status = WindowIntact
+	}
+	return result
+}
+
+// verifyFile compares a file's plaintext with its recorded checksum and size
+func verifyFile(windowDir, path, checksum string, size int64) FileIntegrity {
+	result := FileIntegrity{
+		File:             relativeToWindow(windowDir, path),
+		ExpectedChecksum: checksum,
+		ExpectedSize:     size,
+	}
+
+	data, err := storage.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		result.Status = IntegrityMissing
+		result.Message = "recorded in generation metadata but not found"
+		return result
+	}
+	if err != nil {
+		result.Status = IntegrityUnreadable
+		result.Message = err.Error()
+		return result
+	}
+
+	sum := sha256.Sum256(data)
+	result.ActualChecksum = "sha256:" + hex.EncodeToString(sum[:])
+	result.ActualSize = int64(len(data))
+
+	expected := strings.TrimPrefix(checksum, "sha256:")
+	switch {
+	case expected == "":
+		result.Status = IntegrityUnrecorded
+		result.Message = "no checksum recorded"
+	case !strings.EqualFold(expected, hex.EncodeToString(sum[:])):
+		result.Status = IntegrityModified
+		result.Message = "content differs from the recorded checksum"
+		if size > 0 && size != result.ActualSize {
+			result.Message += fmt.Sprintf(" (%d bytes recorded, %d now)", size, result.ActualSize)
+		}
+	default:
+		result.Status = IntegrityVerified
+	}
+	return result
+}
+
+func relativeToWindow(windowDir, path string) string {
+	if rel, err := filepath.Rel(windowDir, path); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
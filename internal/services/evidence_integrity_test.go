@@ -0,0 +1,145 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// writeTrackedEvidence writes evidence files to dir and records their
+// checksums in dir's generation metadata
+func writeTrackedEvidence(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	metadata := models.GenerationMetadata{TaskRef: "ET-0001"}
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+		sum := sha256.Sum256([]byte(content))
+		metadata.FilesGenerated = append(metadata.FilesGenerated, models.FileMetadata{
+			Path:      name,
+			Checksum:  "sha256:" + hex.EncodeToString(sum[:]),
+			SizeBytes: int64(len(content)),
+		})
+	}
+	data, err := yaml.Marshal(&metadata)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".generation"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".generation", "metadata.yaml"), data, 0644))
+}
+
+func TestVerifyWindow(t *testing.T) {
+	t.Parallel()
+
+	t.Run("intact", func(t *testing.T) {
+		t.Parallel()
+		windowDir := t.TempDir()
+		writeTrackedEvidence(t, windowDir, map[string]string{"01_users.csv": "user,mfa\nalice,true\n", "02_policy.md": "# Policy\n"})
+		require.NoError(t, os.WriteFile(filepath.Join(windowDir, "collection_plan.md"), []byte("# Plan"), 0644))
+
+		result := VerifyWindow("ET-0001", "2025-Q1", windowDir)
+		assert.Equal(t, WindowIntact, result.Status)
+		assert.Equal(t, 2, result.Verified)
+		assert.False(t, result.Compromised())
+	})
+
+	t.Run("modified, missing and untracked", func(t *testing.T) {
+		t.Parallel()
+		windowDir := t.TempDir()
+		writeTrackedEvidence(t, windowDir, map[string]string{"01_users.csv": "user,mfa\nalice,true\n", "02_policy.md": "# Policy\n", "03_logs.md": "# Logs\n"})
+		require.NoError(t, os.WriteFile(filepath.Join(windowDir, "01_users.csv"), []byte("user,mfa\nalice,false\n"), 0644))
+		require.NoError(t, os.Remove(filepath.Join(windowDir, "02_policy.md")))
+		require.NoError(t, os.WriteFile(filepath.Join(windowDir, "notes.md"), []byte("added by hand"), 0644))
+
+		result := VerifyWindow("ET-0001", "2025-Q1", windowDir)
+		assert.Equal(t, WindowCompromised, result.Status)
+		assert.True(t, result.Compromised())
+		assert.Equal(t, 1, result.Verified)
+		assert.Equal(t, 1, result.Modified)
+		assert.Equal(t, 1, result.Missing)
+		assert.Equal(t, 1, result.Untracked)
+
+		statuses := map[string]IntegrityStatus{}
+		for _, file := range result.Files {
+			statuses[file.File] = file.Status
+		}
+		assert.Equal(t, map[string]IntegrityStatus{
+			"01_users.csv": IntegrityModified,
+			"02_policy.md": IntegrityMissing,
+			"03_logs.md":   IntegrityVerified,
+			"notes.md":     IntegrityUntracked,
+		}, statuses)
+		assert.Contains(t, result.Files[0].Message, "(20 bytes recorded, 21 now)")
+	})
+
+	t.Run("submitted files", func(t *testing.T) {
+		t.Parallel()
+		windowDir := t.TempDir()
+		submittedDir := filepath.Join(windowDir, ".submitted")
+		writeTrackedEvidence(t, submittedDir, map[string]string{"01_users.csv": "user,mfa\n"})
+
+		// Metadata left at the root for a file that moved to .submitted/
+		writeTrackedEvidence(t, windowDir, map[string]string{"02_policy.md": "# Policy\n"})
+		require.NoError(t, os.Rename(filepath.Join(windowDir, "02_policy.md"), filepath.Join(submittedDir, "02_policy.md")))
+
+		result := VerifyWindow("ET-0001", "2025-Q1", windowDir)
+		assert.Equal(t, WindowIntact, result.Status)
+		assert.Equal(t, 2, result.Verified)
+		assert.Equal(t, ".submitted/01_users.csv", result.Files[0].File)
+		assert.Equal(t, ".submitted/02_policy.md", result.Files[1].File)
+	})
+
+	t.Run("no metadata", func(t *testing.T) {
+		t.Parallel()
+		windowDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(windowDir, "upload.pdf"), []byte("%PDF"), 0644))
+
+		result := VerifyWindow("ET-0001", "2025-Q1", windowDir)
+		assert.Equal(t, WindowNoMetadata, result.Status)
+		assert.Equal(t, 1, result.Untracked)
+	})
+}
+
+func TestEvidenceIntegrityService(t *testing.T) {
+	t.Parallel()
+	log, err := logger.NewTestLogger()
+	require.NoError(t, err)
+
+	evidenceDir := t.TempDir()
+	writeTrackedEvidence(t, filepath.Join(evidenceDir, "Access_Review_ET-0001_101", "2025-Q1"), map[string]string{"01_users.csv": "a"})
+	writeTrackedEvidence(t, filepath.Join(evidenceDir, "Access_Review_ET-0001_101", "2025-Q2"), map[string]string{"01_users.csv": "b"})
+	writeTrackedEvidence(t, filepath.Join(evidenceDir, "Change_Log_ET-0002_102", "2025-Q1"), map[string]string{"01_changes.md": "c"})
+	service := NewEvidenceIntegrityService(evidenceDir, log)
+	ctx := context.Background()
+
+	all, err := service.VerifyAll(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, "ET-0001", all[0].TaskRef)
+	assert.Equal(t, "2025-Q2", all[1].Window)
+	assert.Equal(t, "ET-0002", all[2].TaskRef)
+
+	q1, err := service.VerifyAll(ctx, "2025-Q1")
+	require.NoError(t, err)
+	assert.Len(t, q1, 2)
+
+	task, err := service.VerifyTask(ctx, "ET-0001", "")
+	require.NoError(t, err)
+	assert.Len(t, task, 2)
+
+	_, err = service.VerifyTask(ctx, "ET-0003", "")
+	assert.EqualError(t, err, "no evidence found for ET-0003")
+	_, err = service.VerifyTask(ctx, "ET-0002", "2025-Q4")
+	assert.EqualError(t, err, "no evidence found for ET-0002 in window 2025-Q4")
+}
@@ -0,0 +1,180 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/llm"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/naming"
+	"github.com/grctool/grctool/internal/storage"
+)
+
+// modelReview is the language model reading evidence contents during
+// evaluation. Whether it is available is checked once, on first use.
+type modelReview struct {
+	model           llm.Model
+	maxContextChars int
+
+	once     sync.Once
+	unusable error
+}
+
+// modelReviewAnswer is the JSON the model is asked to answer with
+type modelReviewAnswer struct {
+	Score   float64  `json:"score"`
+	Summary string   `json:"summary"`
+	Gaps    []string `json:"gaps"`
+}
+
+// SetModel makes evaluations have a language model read the evidence and score
+// how well it meets the task, sending it at most maxContextChars of evidence
+// text per window. Without a model, requirements match is scored on file
+// names and formats only.
+func (s *EvidenceEvaluatorService) SetModel(model llm.Model, maxContextChars int) {
+	if model == nil {
+		s.review = nil
+		return
+	}
+	s.review = &modelReview{model: model, maxContextChars: maxContextChars}
+}
+
+// reviewWithModel replaces the heuristic requirements match score with the
+// model's when one is set. When the model cannot be reached or answers
+// unusably, the heuristic score stands and an informational issue says why.
+func (s *EvidenceEvaluatorService) reviewWithModel(ctx context.Context, task *domain.EvidenceTask, window *models.WindowState, result *models.EvaluationResult) {
+	review := s.review
+	if review == nil || !result.RequirementsMatch.Scored() || window.FileCount == 0 {
+		return
+	}
+
+	review.once.Do(func() {
+		review.unusable = review.model.Available(ctx)
+		if review.unusable != nil {
+			s.logger.Warn("Model unavailable, evaluating without it",
+				logger.String("model", review.model.Name()),
+				logger.Field{Key: "error", Value: review.unusable})
+		}
+	})
+	if review.unusable != nil {
+		result.AddIssue(models.IssueInfo, "requirements",
+			"Evidence contents were not reviewed by the model", "", review.unusable.Error())
+		return
+	}
+
+	excerpts, err := s.evidenceExcerpts(result, window.Files, review.maxContextChars)
+	if err != nil {
+		s.logger.Warn("Cannot read evidence for model review",
+			logger.String("task_ref", result.TaskRef),
+			logger.Field{Key: "error", Value: err})
+		result.AddIssue(models.IssueInfo, "requirements",
+			"Evidence contents were not reviewed by the model", "", err.Error())
+		return
+	}
+	if excerpts == "" {
+		return
+	}
+
+	var answer modelReviewAnswer
+	if err := llm.GenerateJSON(ctx, review.model, modelReviewPrompt(task, excerpts), &answer); err != nil {
+		s.logger.Warn("Model review failed",
+			logger.String("task_ref", result.TaskRef),
+			logger.Field{Key: "error", Value: err})
+		result.AddIssue(models.IssueInfo, "requirements",
+			"Evidence contents were not reviewed by the model", "", err.Error())
+		return
+	}
+
+	result.ReviewedBy = review.model.Name()
+	result.RequirementsMatch.Score = min(max(answer.Score, 0), 100)
+	result.RequirementsMatch.Details += fmt.Sprintf("Reviewed by %s. ", review.model.Name())
+	if answer.Summary != "" {
+		result.RequirementsMatch.Details += strings.TrimSpace(answer.Summary) + " "
+	}
+	for _, gap := range answer.Gaps {
+		if gap = strings.TrimSpace(gap); gap != "" {
+			result.AddIssue(models.IssueMedium, "requirements", gap, "", "Collect evidence that shows this")
+		}
+	}
+	result.RequirementsMatch.GradeStatus()
+}
+
+// evidenceExcerpts reads the text evidence files of the evaluated window or
+// subfolder, splitting the character budget evenly between them. Encrypted
+// and deduplicated files are read as their plaintext; binary files are left
+// out. A file that exists but cannot be read fails the whole review, so the
+// model never scores an incomplete window.
+func (s *EvidenceEvaluatorService) evidenceExcerpts(result *models.EvaluationResult, files []models.FileState, budget int) (string, error) {
+	if len(files) == 0 || budget <= 0 {
+		return "", nil
+	}
+	windowDir := s.storage.EvidenceWindowDir(result.TaskRef, result.Window)
+	dirs := []string{windowDir, filepath.Join(windowDir, naming.SubfolderSubmitted), filepath.Join(windowDir, naming.SubfolderArchive)}
+	if result.Subfolder != "" && result.Subfolder != "all" {
+		dirs = []string{filepath.Join(windowDir, result.Subfolder)}
+	}
+	perFile := budget / len(files)
+
+	var excerpts strings.Builder
+	for _, file := range files {
+		for _, dir := range dirs {
+			data, err := storage.ReadFile(filepath.Join(dir, file.Filename))
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return "", fmt.Errorf("cannot read %s: %w", file.Filename, err)
+			}
+			if bytes.IndexByte(data, 0) >= 0 {
+				break // Binary
+			}
+			if len(data) > perFile {
+				data = data[:perFile]
+			}
+			fmt.Fprintf(&excerpts, "--- %s ---\n%s\n\n", file.Filename, strings.ToValidUTF8(string(data), ""))
+			break
+		}
+	}
+	return excerpts.String(), nil
+}
+
+// modelReviewPrompt asks the model to score evidence against its task
+func modelReviewPrompt(task *domain.EvidenceTask, excerpts string) string {
+	var prompt strings.Builder
+	prompt.WriteString("You review compliance evidence for an audit. Judge only whether the evidence below shows what the task asks for.\n\n")
+	fmt.Fprintf(&prompt, "Task: %s (%s)\n", task.Name, task.ReferenceID)
+	if task.Description != "" {
+		fmt.Fprintf(&prompt, "Description: %s\n", task.Description)
+	}
+	if task.Guidance != "" {
+		fmt.Fprintf(&prompt, "Collection guidance: %s\n", task.Guidance)
+	}
+	prompt.WriteString("\nEvidence files (excerpts):\n\n")
+	prompt.WriteString(excerpts)
+	prompt.WriteString(`Answer with one JSON object and nothing else:
+{"score": <0-100, how fully the evidence meets the task>, "summary": "<one sentence>", "gaps": ["<something the task asks for that the evidence does not show>"]}`)
+	return prompt.String()
+}
@@ -0,0 +1,331 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/frameworks"
+	"github.com/grctool/grctool/internal/models"
+)
+
+// CoverageOptions controls a framework coverage report
+type CoverageOptions struct {
+	Framework   string                           // Framework whose criteria are reported, e.g. SOC2
+	Window      string                           // Window checked for every task; empty uses WindowFor
+	WindowFor   func(domain.EvidenceTask) string // A task's current window; nil uses its latest window
+	Crosswalk   bool                             // Credit each control with the evidence of equivalent controls in other frameworks
+	GeneratedAt time.Time
+}
+
+// CoverageReport is per-criterion coverage of one framework
+type CoverageReport struct {
+	Framework   string              `json:"framework" yaml:"framework"`
+	Window      string              `json:"window,omitempty" yaml:"window,omitempty"`
+	GeneratedAt time.Time           `json:"generated_at" yaml:"generated_at"`
+	Criteria    []CriterionCoverage `json:"criteria" yaml:"criteria"`
+	Summary     CriterionCoverage   `json:"summary" yaml:"summary"` // Distinct controls and tasks across all criteria
+}
+
+// CriterionCoverage is the state of the controls and evidence behind one
+// framework criterion, such as SOC 2 CC6.1
+type CriterionCoverage struct {
+	Criterion   string   `json:"criterion" yaml:"criterion"`
+	Name        string   `json:"name,omitempty" yaml:"name,omitempty"`
+	Controls    int      `json:"controls" yaml:"controls"`
+	Implemented int      `json:"implemented" yaml:"implemented"`
+	Tasks       int      `json:"tasks" yaml:"tasks"`
+	Submitted   int      `json:"submitted" yaml:"submitted"` // Tasks with evidence submitted or accepted
+	Accepted    int      `json:"accepted" yaml:"accepted"`
+	Gaps        []string `json:"gaps,omitempty" yaml:"gaps,omitempty"`
+}
+
+// Covered reports whether every control is implemented and every task has submitted evidence
+func (c CriterionCoverage) Covered() bool {
+	return len(c.Gaps) == 0
+}
+
+// criterionMembers collects the distinct controls and tasks behind a criterion
+type criterionMembers struct {
+	name     string
+	controls map[string]bool
+	tasks    map[string]bool
+}
+
+// BuildCoverageReport groups the framework's controls by the criteria they
+// satisfy and reports, per criterion, how many controls are implemented and
+// how many of their evidence tasks have evidence submitted and accepted.
+// Controls without framework codes are reported under their own reference.
+// With Crosswalk, evidence tasks of equivalent controls in other frameworks
+// count toward a control too, so one submission covers them all.
+func BuildCoverageReport(data *ComplianceData, opts CoverageOptions) *CoverageReport {
+	report := &CoverageReport{
+		Framework:   opts.Framework,
+		Window:      opts.Window,
+		GeneratedAt: opts.GeneratedAt,
+		Criteria:    []CriterionCoverage{},
+	}
+
+	controlByID := make(map[string]domain.Control)
+	criteria := make(map[string]*criterionMembers)
+	addControl := func(code, name, controlID string) {
+		members, ok := criteria[code]
+		if !ok {
+			members = &criterionMembers{controls: map[string]bool{}, tasks: map[string]bool{}}
+			criteria[code] = members
+		}
+		if members.name == "" {
+			members.name = name
+		}
+		members.controls[controlID] = true
+	}
+	for _, control := range data.Controls {
+		codes := frameworkCodes(control, opts.Framework)
+		if len(codes) == 0 && !sameFramework(control.Framework, opts.Framework) {
+			continue
+		}
+		controlByID[control.ID] = control
+		if len(codes) == 0 {
+			addControl(controlLabel(control), control.Name, control.ID)
+		}
+		for _, code := range codes {
+			addControl(code.Code, code.Name, control.ID)
+		}
+	}
+
+	taskByRef := make(map[string]domain.EvidenceTask, len(data.Tasks))
+	for _, task := range data.Tasks {
+		taskByRef[task.ReferenceID] = task
+	}
+	linked := controlTaskLinks(data)
+	var equivalents map[string][]string
+	if opts.Crosswalk {
+		equivalents = controlEquivalents(data.Controls)
+	}
+	controlTasks := make(map[string]map[string]bool)
+	for id := range controlByID {
+		for _, source := range append([]string{id}, equivalents[id]...) {
+			for taskRef := range linked[source] {
+				if controlTasks[id] == nil {
+					controlTasks[id] = map[string]bool{}
+				}
+				controlTasks[id][taskRef] = true
+			}
+		}
+	}
+
+	submission := submissionLookup(data, taskByRef, opts.Window, opts.WindowFor)
+
+	summary := criterionMembers{controls: map[string]bool{}, tasks: map[string]bool{}}
+	for code, members := range criteria {
+		for controlID := range members.controls {
+			summary.controls[controlID] = true
+			for taskRef := range controlTasks[controlID] {
+				members.tasks[taskRef] = true
+				summary.tasks[taskRef] = true
+			}
+		}
+		coverage := tallyCoverage(members, controlByID, controlTasks, taskByRef, submission)
+		coverage.Criterion = code
+		coverage.Name = members.name
+		report.Criteria = append(report.Criteria, coverage)
+	}
+	sort.Slice(report.Criteria, func(i, j int) bool {
+		return naturalLess(report.Criteria[i].Criterion, report.Criteria[j].Criterion)
+	})
+
+	report.Summary = tallyCoverage(&summary, controlByID, controlTasks, taskByRef, submission)
+	report.Summary.Criterion = "Total"
+	return report
+}
+
+// tallyCoverage counts implemented controls and submitted and accepted tasks,
+// listing what keeps the criterion from being covered
+func tallyCoverage(members *criterionMembers, controls map[string]domain.Control, controlTasks map[string]map[string]bool,
+	tasks map[string]domain.EvidenceTask, submission func(string) (string, string)) CriterionCoverage {
+	coverage := CriterionCoverage{Controls: len(members.controls), Tasks: len(members.tasks)}
+
+	controlIDs := sortedKeys(members.controls)
+	sort.Slice(controlIDs, func(i, j int) bool {
+		return naturalLess(controlLabel(controls[controlIDs[i]]), controlLabel(controls[controlIDs[j]]))
+	})
+	for _, id := range controlIDs {
+		control := controls[id]
+		if controlImplemented(control) {
+			coverage.Implemented++
+		} else {
+			coverage.Gaps = append(coverage.Gaps, fmt.Sprintf("%s %s is not implemented", controlLabel(control), control.Name))
+		}
+		if len(controlTasks[id]) == 0 {
+			coverage.Gaps = append(coverage.Gaps, fmt.Sprintf("%s %s has no evidence tasks", controlLabel(control), control.Name))
+		}
+	}
+
+	for _, taskRef := range sortedKeys(members.tasks) {
+		status, window := submission(taskRef)
+		switch status {
+		case "accepted":
+			coverage.Accepted++
+			coverage.Submitted++
+		case "submitted":
+			coverage.Submitted++
+		default:
+			gap := fmt.Sprintf("%s %s has no submitted evidence", taskRef, tasks[taskRef].Name)
+			if window != "" {
+				gap += " for " + window
+			}
+			coverage.Gaps = append(coverage.Gaps, gap)
+		}
+	}
+	return coverage
+}
+
+// controlTaskLinks maps each control ID to the references of its evidence
+// tasks, from either side of the relationship
+func controlTaskLinks(data *ComplianceData) map[string]map[string]bool {
+	index := controlIndex(data.Controls)
+	tasks := make(map[string]bool, len(data.Tasks))
+	links := make(map[string]map[string]bool)
+	link := func(controlID, taskRef string) {
+		if links[controlID] == nil {
+			links[controlID] = map[string]bool{}
+		}
+		links[controlID][taskRef] = true
+	}
+	for _, task := range data.Tasks {
+		tasks[task.ReferenceID] = true
+		for _, key := range task.Controls {
+			if id, ok := index[key]; ok {
+				link(id, task.ReferenceID)
+			}
+		}
+	}
+	for _, control := range data.Controls {
+		for _, related := range control.RelatedEvidenceTasks {
+			if tasks[related.ReferenceID] {
+				link(control.ID, related.ReferenceID)
+			}
+		}
+	}
+	return links
+}
+
+// submissionLookup returns a task's submission status and the window it was
+// read from: window for every task when set, else the task's current window
+// from windowFor, else its latest window
+func submissionLookup(data *ComplianceData, tasks map[string]domain.EvidenceTask, window string,
+	windowFor func(domain.EvidenceTask) string) func(string) (string, string) {
+	states := make(map[string]*models.EvidenceTaskState, len(data.States))
+	for _, state := range data.States {
+		states[state.TaskRef] = state
+	}
+	return func(taskRef string) (status, taskWindow string) {
+		taskWindow = window
+		if taskWindow == "" && windowFor != nil {
+			taskWindow = windowFor(tasks[taskRef])
+		}
+		state := states[taskRef]
+		if state == nil {
+			return "", taskWindow
+		}
+		if taskWindow == "" {
+			latest, ok := latestWindow(state)
+			if !ok {
+				return "", ""
+			}
+			return latest.SubmissionStatus, latest.Window
+		}
+		return state.Windows[taskWindow].SubmissionStatus, taskWindow
+	}
+}
+
+// frameworkCodes returns the control's criteria within framework. Controls
+// synced without codes of a custom framework map onto it through their SOC2
+// codes and the framework's keywords.
+func frameworkCodes(control domain.Control, framework string) []domain.FrameworkCode {
+	var codes []domain.FrameworkCode
+	for _, code := range control.FrameworkCodes {
+		if code.Code != "" && sameFramework(code.Framework, framework) {
+			codes = append(codes, code)
+		}
+	}
+	if custom := frameworks.Key(framework); len(codes) == 0 && frameworks.IsCustom(custom) {
+		for _, code := range control.CustomFrameworkCodes(custom) {
+			codes = append(codes, domain.FrameworkCode{Code: code, Framework: custom, Name: frameworks.Title(custom, code)})
+		}
+	}
+	return codes
+}
+
+// sameFramework compares framework names ignoring case, spaces and punctuation,
+// so "SOC 2", "soc2" and "SOC-2" are the same framework, as are aliases such
+// as "PCI DSS v4.0" and "pci"
+func sameFramework(a, b string) bool {
+	return frameworks.Key(a) != "" && frameworks.Key(a) == frameworks.Key(b)
+}
+
+func controlImplemented(control domain.Control) bool {
+	return strings.EqualFold(control.Status, "implemented") || control.ImplementedDate != nil
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// naturalLess orders references with embedded numbers numerically, so CC2.1
+// sorts before CC10.1
+func naturalLess(a, b string) bool {
+	for a != "" && b != "" {
+		ca, restA := leadingChunk(a)
+		cb, restB := leadingChunk(b)
+		if ca != cb {
+			da, db := unicode.IsDigit(rune(ca[0])), unicode.IsDigit(rune(cb[0]))
+			if da && db {
+				na, nb := strings.TrimLeft(ca, "0"), strings.TrimLeft(cb, "0")
+				if len(na) != len(nb) {
+					return len(na) < len(nb)
+				}
+				if na != nb {
+					return na < nb
+				}
+			} else {
+				return ca < cb
+			}
+		}
+		a, b = restA, restB
+	}
+	return len(a) < len(b)
+}
+
+// leadingChunk splits off the leading run of digits or non-digits
+func leadingChunk(s string) (string, string) {
+	digit := unicode.IsDigit(rune(s[0]))
+	i := 1
+	for i < len(s) && unicode.IsDigit(rune(s[i])) == digit {
+		i++
+	}
+	return s[:i], s[i:]
+}
@@ -0,0 +1,190 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/grctool/grctool/internal/templates"
+)
+
+// coverageCSVHeader is the column order of the CSV coverage report
+var coverageCSVHeader = []string{
+	"criterion", "name", "controls", "implemented", "tasks", "submitted", "accepted", "covered", "gaps",
+}
+
+// WriteCoverageCSV writes one row per criterion followed by a total row
+func WriteCoverageCSV(w io.Writer, report *CoverageReport) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(coverageCSVHeader); err != nil {
+		return err
+	}
+
+	rows := append(append([]CriterionCoverage{}, report.Criteria...), report.Summary)
+	for i, c := range rows {
+		gaps := strings.Join(c.Gaps, "; ")
+		if i == len(report.Criteria) {
+			gaps = "" // The total row repeats every criterion's gaps
+		}
+		record := []string{
+			c.Criterion, c.Name,
+			strconv.Itoa(c.Controls), strconv.Itoa(c.Implemented),
+			strconv.Itoa(c.Tasks), strconv.Itoa(c.Submitted), strconv.Itoa(c.Accepted),
+			strconv.FormatBool(c.Covered()), gaps,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteCoverageMarkdown writes the report as a markdown document: a summary,
+// a table of criteria and the gaps of each criterion not fully covered
+func WriteCoverageMarkdown(w io.Writer, report *CoverageReport) error {
+	var md strings.Builder
+
+	md.WriteString(fmt.Sprintf("# %s Coverage Report\n\n", report.Framework))
+	md.WriteString(fmt.Sprintf("Generated: %s\n\n", report.GeneratedAt.Format("2006-01-02 15:04:05")))
+	if report.Window != "" {
+		md.WriteString(fmt.Sprintf("Window: %s\n\n", report.Window))
+	}
+
+	if len(report.Criteria) == 0 {
+		md.WriteString(fmt.Sprintf("No controls found for framework %s.\n", report.Framework))
+		_, err := io.WriteString(w, md.String())
+		return err
+	}
+
+	s := report.Summary
+	md.WriteString("## Summary\n\n")
+	md.WriteString(fmt.Sprintf("- **Criteria covered:** %d of %d\n", coveredCriteria(report), len(report.Criteria)))
+	md.WriteString(fmt.Sprintf("- **Controls implemented:** %d of %d (%s)\n", s.Implemented, s.Controls, percent(s.Implemented, s.Controls)))
+	md.WriteString(fmt.Sprintf("- **Evidence submitted:** %d of %d tasks (%s)\n", s.Submitted, s.Tasks, percent(s.Submitted, s.Tasks)))
+	md.WriteString(fmt.Sprintf("- **Evidence accepted:** %d of %d tasks (%s)\n\n", s.Accepted, s.Tasks, percent(s.Accepted, s.Tasks)))
+
+	md.WriteString("## Criteria\n\n")
+	md.WriteString("| Criterion | Name | Controls Implemented | Evidence Submitted | Evidence Accepted | Gaps |\n")
+	md.WriteString("|-----------|------|----------------------|--------------------|-------------------|------|\n")
+	for _, c := range report.Criteria {
+		md.WriteString(fmt.Sprintf("| %s | %s | %d/%d | %d/%d | %d/%d | %d |\n",
+			templates.MarkdownCell(c.Criterion), templates.MarkdownCell(c.Name), c.Implemented, c.Controls,
+			c.Submitted, c.Tasks, c.Accepted, c.Tasks, len(c.Gaps)))
+	}
+
+	if coveredCriteria(report) < len(report.Criteria) {
+		md.WriteString("\n## Gaps\n")
+		for _, c := range report.Criteria {
+			if c.Covered() {
+				continue
+			}
+			md.WriteString(fmt.Sprintf("\n### %s %s\n\n", c.Criterion, c.Name))
+			for _, gap := range c.Gaps {
+				md.WriteString(fmt.Sprintf("- %s\n", gap))
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, md.String())
+	return err
+}
+
+// coverageHTML is a self-contained page suitable for sharing or printing
+var coverageHTML = template.Must(template.New("coverage").Funcs(template.FuncMap{
+	"percent": percent,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Report.Framework}} Coverage Report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem; color: #222; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 2rem; }
+th, td { border: 1px solid #ccc; padding: 0.4rem 0.6rem; text-align: left; vertical-align: top; }
+th { background: #f3f3f3; }
+td.num { text-align: right; white-space: nowrap; }
+tr.gap td.status { color: #b00020; }
+tr.covered td.status { color: #1b7f3b; }
+.meta { color: #666; }
+</style>
+</head>
+<body>
+<h1>{{.Report.Framework}} Coverage Report</h1>
+<p class="meta">Generated {{.Report.GeneratedAt.Format "2006-01-02 15:04:05"}}{{if .Report.Window}} &middot; Window {{.Report.Window}}{{end}}</p>
+{{- if not .Report.Criteria}}
+<p>No controls found for framework {{.Report.Framework}}.</p>
+{{- else}}
+{{- with .Report.Summary}}
+<h2>Summary</h2>
+<ul>
+<li><strong>Criteria covered:</strong> {{$.Covered}} of {{len $.Report.Criteria}}</li>
+<li><strong>Controls implemented:</strong> {{.Implemented}} of {{.Controls}} ({{percent .Implemented .Controls}})</li>
+<li><strong>Evidence submitted:</strong> {{.Submitted}} of {{.Tasks}} tasks ({{percent .Submitted .Tasks}})</li>
+<li><strong>Evidence accepted:</strong> {{.Accepted}} of {{.Tasks}} tasks ({{percent .Accepted .Tasks}})</li>
+</ul>
+{{- end}}
+<h2>Criteria</h2>
+<table>
+<thead><tr><th>Criterion</th><th>Name</th><th>Controls Implemented</th><th>Evidence Submitted</th><th>Evidence Accepted</th><th>Status</th><th>Gaps</th></tr></thead>
+<tbody>
+{{- range .Report.Criteria}}
+<tr class="{{if .Covered}}covered{{else}}gap{{end}}">
+<td>{{.Criterion}}</td><td>{{.Name}}</td>
+<td class="num">{{.Implemented}}/{{.Controls}}</td>
+<td class="num">{{.Submitted}}/{{.Tasks}}</td>
+<td class="num">{{.Accepted}}/{{.Tasks}}</td>
+<td class="status">{{if .Covered}}Covered{{else}}Gaps{{end}}</td>
+<td>{{if .Gaps}}<ul>{{range .Gaps}}<li>{{.}}</li>{{end}}</ul>{{end}}</td>
+</tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+</body>
+</html>
+`))
+
+// WriteCoverageHTML writes the report as a standalone HTML page
+func WriteCoverageHTML(w io.Writer, report *CoverageReport) error {
+	return coverageHTML.Execute(w, struct {
+		Report  *CoverageReport
+		Covered int
+	}{report, coveredCriteria(report)})
+}
+
+func coveredCriteria(report *CoverageReport) int {
+	covered := 0
+	for _, c := range report.Criteria {
+		if c.Covered() {
+			covered++
+		}
+	}
+	return covered
+}
+
+func percent(part, total int) string {
+	if total == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%d%%", part*100/total)
+}
@@ -0,0 +1,193 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/frameworks"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCoverageData() *ComplianceData {
+	implemented := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	return &ComplianceData{
+		Tasks: []domain.EvidenceTask{
+			{ReferenceID: "ET-0001", Name: "Access review", CollectionInterval: "quarter", Controls: []string{"101"}},
+			{ReferenceID: "ET-0002", Name: "Offboarding", CollectionInterval: "quarter", Controls: []string{"CC6.2"}},
+			{ReferenceID: "ET-0003", Name: "Change log", CollectionInterval: "year"},
+		},
+		Controls: []domain.Control{
+			{ID: "101", ReferenceID: "AC-1", Name: "Logical access", Framework: "SOC2", Status: "implemented",
+				FrameworkCodes: []domain.FrameworkCode{
+					{Code: "CC6.1", Framework: "SOC 2", Name: "Logical Access Security"},
+					{Code: "A.9.1", Framework: "ISO 27001", Name: "Access control"},
+				}},
+			{ID: "102", ReferenceID: "CC6.2", Name: "User provisioning", Framework: "SOC2", ImplementedDate: &implemented,
+				FrameworkCodes: []domain.FrameworkCode{{Code: "CC6.2", Framework: "SOC2", Name: "Access Provisioning"}}},
+			{ID: "103", ReferenceID: "CC8.1", Name: "Change management", Framework: "SOC2",
+				RelatedEvidenceTasks: []domain.EvidenceTask{{ReferenceID: "ET-0003"}}},
+			{ID: "104", ReferenceID: "CC10.1", Name: "Vendor risk", Framework: "SOC2", Status: "implemented"},
+			{ID: "105", ReferenceID: "A.12.1", Name: "Operations", Framework: "ISO27001", Status: "implemented"},
+			{ID: "106", ReferenceID: "NET-1", Name: "Network segmentation", Framework: "PCI DSS", Status: "implemented",
+				FrameworkCodes: []domain.FrameworkCode{
+					{Code: "1.3.1", Framework: "PCI DSS v4.0", Name: "Inbound traffic to the CDE is restricted"},
+					{Code: "1.4.1", Framework: "PCI DSS v4.0", Name: "NSCs between trusted and untrusted networks"},
+				}},
+		},
+		States: []*models.EvidenceTaskState{
+			{TaskRef: "ET-0001", Windows: map[string]models.WindowState{
+				"2025-Q3": {SubmissionStatus: "accepted"},
+				"2025-Q4": {SubmissionStatus: "submitted"},
+			}},
+			{TaskRef: "ET-0002", Windows: map[string]models.WindowState{
+				"2025-Q3": {SubmissionStatus: "accepted"},
+			}},
+			{TaskRef: "ET-0003", Windows: map[string]models.WindowState{
+				"2025": {SubmissionStatus: "accepted"},
+			}},
+		},
+	}
+}
+
+func TestBuildCoverageReport(t *testing.T) {
+	t.Parallel()
+
+	windowFor := func(task domain.EvidenceTask) string {
+		if task.CollectionInterval == "year" {
+			return "2025"
+		}
+		return "2025-Q4"
+	}
+	report := BuildCoverageReport(testCoverageData(), CoverageOptions{Framework: "soc2", WindowFor: windowFor})
+
+	var criteria []string
+	for _, c := range report.Criteria {
+		criteria = append(criteria, c.Criterion)
+	}
+	assert.Equal(t, []string{"CC6.1", "CC6.2", "CC8.1", "CC10.1"}, criteria, "criteria sort numerically")
+
+	cc61 := report.Criteria[0]
+	assert.Equal(t, "Logical Access Security", cc61.Name)
+	assert.Equal(t, CriterionCoverage{Criterion: "CC6.1", Name: "Logical Access Security",
+		Controls: 1, Implemented: 1, Tasks: 1, Submitted: 1}, cc61)
+	assert.True(t, cc61.Covered())
+
+	cc62 := report.Criteria[1]
+	assert.Equal(t, 1, cc62.Implemented, "an implemented date counts as implemented")
+	assert.Equal(t, []string{"ET-0002 Offboarding has no submitted evidence for 2025-Q4"}, cc62.Gaps)
+
+	cc81 := report.Criteria[2]
+	assert.Equal(t, 1, cc81.Accepted, "tasks linked from the control side count")
+	assert.Equal(t, []string{"CC8.1 Change management is not implemented"}, cc81.Gaps)
+
+	assert.Equal(t, []string{"CC10.1 Vendor risk has no evidence tasks"}, report.Criteria[3].Gaps)
+
+	assert.Equal(t, 4, report.Summary.Controls)
+	assert.Equal(t, 3, report.Summary.Implemented)
+	assert.Equal(t, 3, report.Summary.Tasks)
+	assert.Equal(t, 2, report.Summary.Submitted)
+	assert.Equal(t, 1, report.Summary.Accepted)
+
+	// One window for every task; tasks without state are gaps
+	report = BuildCoverageReport(testCoverageData(), CoverageOptions{Framework: "SOC2", Window: "2025-Q3"})
+	assert.Equal(t, 2, report.Summary.Accepted)
+	assert.Contains(t, report.Criteria[2].Gaps, "ET-0003 Change log has no submitted evidence for 2025-Q3")
+
+	// No window uses each task's latest
+	report = BuildCoverageReport(testCoverageData(), CoverageOptions{Framework: "SOC2"})
+	assert.Equal(t, 3, report.Summary.Submitted)
+	assert.Equal(t, 2, report.Summary.Accepted)
+
+	report = BuildCoverageReport(testCoverageData(), CoverageOptions{Framework: "ISO 27001"})
+	require.Len(t, report.Criteria, 2)
+	assert.Equal(t, "A.9.1", report.Criteria[0].Criterion)
+	assert.Equal(t, "A.12.1", report.Criteria[1].Criterion)
+
+	report = BuildCoverageReport(testCoverageData(), CoverageOptions{Framework: "pci"})
+	require.Len(t, report.Criteria, 2, "pci matches codes synced as PCI DSS v4.0")
+	assert.Equal(t, "1.3.1", report.Criteria[0].Criterion)
+	assert.Equal(t, "1.4.1", report.Criteria[1].Criterion)
+	assert.Equal(t, 1, report.Summary.Controls)
+
+	require.NoError(t, frameworks.RegisterCustom(frameworks.CustomFramework{
+		Name:  "acme-coverage",
+		Label: "Acme Coverage ICF",
+		Controls: []frameworks.CustomControl{
+			{Code: "ICF-AC-01", Title: "Access is reviewed", SOC2: []string{"CC6.1", "CC6.2"}},
+			{Code: "ICF-VM-01", Title: "Vendors are assessed", Keywords: []string{"vendor"}},
+		},
+	}))
+	report = BuildCoverageReport(testCoverageData(), CoverageOptions{Framework: "Acme Coverage ICF"})
+	require.Len(t, report.Criteria, 2, "controls map through their SOC2 codes and keywords")
+	assert.Equal(t, CriterionCoverage{Criterion: "ICF-AC-01", Name: "Access is reviewed",
+		Controls: 2, Implemented: 2, Tasks: 2, Submitted: 2, Accepted: 1}, report.Criteria[0])
+	assert.Equal(t, "Vendors are assessed", report.Criteria[1].Name)
+	assert.Equal(t, []string{"CC10.1 Vendor risk has no evidence tasks"}, report.Criteria[1].Gaps)
+}
+
+func TestWriteCoverageReport(t *testing.T) {
+	t.Parallel()
+
+	report := BuildCoverageReport(testCoverageData(), CoverageOptions{
+		Framework:   "SOC2",
+		Window:      "2025-Q4",
+		GeneratedAt: time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC),
+	})
+
+	tests := map[string]struct {
+		write    func(*bytes.Buffer) error
+		contains []string
+	}{
+		"markdown": {
+			write: func(buf *bytes.Buffer) error { return WriteCoverageMarkdown(buf, report) },
+			contains: []string{
+				"# SOC2 Coverage Report",
+				"Window: 2025-Q4",
+				"- **Criteria covered:** 1 of 4",
+				"- **Controls implemented:** 3 of 4 (75%)",
+				"| CC6.1 | Logical Access Security | 1/1 | 1/1 | 0/1 | 0 |",
+				"### CC10.1 Vendor risk",
+				"- CC10.1 Vendor risk has no evidence tasks",
+			},
+		},
+		"html": {
+			write: func(buf *bytes.Buffer) error { return WriteCoverageHTML(buf, report) },
+			contains: []string{
+				"<title>SOC2 Coverage Report</title>",
+				"<li><strong>Criteria covered:</strong> 1 of 4</li>",
+				`<tr class="covered">`,
+				"<li>CC10.1 Vendor risk has no evidence tasks</li>",
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			require.NoError(t, tc.write(&buf))
+			for _, want := range tc.contains {
+				assert.Contains(t, buf.String(), want)
+			}
+		})
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		require.NoError(t, WriteCoverageCSV(&buf, report))
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 6)
+		assert.Equal(t, coverageCSVHeader, records[0])
+		assert.Equal(t, []string{"CC6.1", "Logical Access Security", "1", "1", "1", "1", "0", "true", ""}, records[1])
+		assert.Equal(t, []string{"Total", "", "4", "3", "3", "1", "0", "false", ""}, records[5])
+	})
+}
@@ -0,0 +1,238 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/frameworks"
+)
+
+// CrosswalkOptions controls a control crosswalk
+type CrosswalkOptions struct {
+	Frameworks  []string                         // Frameworks whose requirements are shown; empty shows every built-in and custom framework
+	Controls    []string                         // Only these controls, by ID or reference; empty crosswalks every control
+	Window      string                           // Window checked for every task; empty uses WindowFor
+	WindowFor   func(domain.EvidenceTask) string // A task's current window; nil uses its latest window
+	GeneratedAt time.Time
+}
+
+// Crosswalk lists, for each control, the equivalent requirements of other
+// frameworks and the evidence that satisfies it, its own and that shared by
+// equivalent controls
+type Crosswalk struct {
+	Frameworks  []string           `json:"frameworks" yaml:"frameworks"`
+	Window      string             `json:"window,omitempty" yaml:"window,omitempty"`
+	GeneratedAt time.Time          `json:"generated_at" yaml:"generated_at"`
+	Controls    []CrosswalkControl `json:"controls" yaml:"controls"`
+}
+
+// CrosswalkControl is one control and what it maps to
+type CrosswalkControl struct {
+	Ref          string                 `json:"ref" yaml:"ref"`
+	ID           string                 `json:"id" yaml:"id"`
+	Name         string                 `json:"name" yaml:"name"`
+	Framework    string                 `json:"framework,omitempty" yaml:"framework,omitempty"`
+	Requirements []CrosswalkRequirement `json:"requirements" yaml:"requirements"`
+	Equivalents  []string               `json:"equivalents,omitempty" yaml:"equivalents,omitempty"` // Controls of other frameworks sharing a requirement
+	Evidence     []CrosswalkEvidence    `json:"evidence,omitempty" yaml:"evidence,omitempty"`
+}
+
+// CrosswalkRequirement is the requirements of one framework a control addresses
+type CrosswalkRequirement struct {
+	Framework string   `json:"framework" yaml:"framework"`
+	Codes     []string `json:"codes" yaml:"codes"`
+}
+
+// CrosswalkEvidence is an evidence task satisfying a control
+type CrosswalkEvidence struct {
+	TaskRef  string `json:"task_ref" yaml:"task_ref"`
+	TaskName string `json:"task_name" yaml:"task_name"`
+	Window   string `json:"window,omitempty" yaml:"window,omitempty"`
+	Status   string `json:"status,omitempty" yaml:"status,omitempty"` // Submission status in the window
+	Via      string `json:"via,omitempty" yaml:"via,omitempty"`       // Equivalent control the task belongs to; empty for the control's own tasks
+}
+
+// Satisfied reports whether any of the control's evidence, its own or
+// shared, has been submitted
+func (c CrosswalkControl) Satisfied() bool {
+	for _, evidence := range c.Evidence {
+		if evidence.Status == "submitted" || evidence.Status == "accepted" {
+			return true
+		}
+	}
+	return false
+}
+
+// Codes returns the control's requirements within framework
+func (c CrosswalkControl) Codes(framework string) []string {
+	for _, requirement := range c.Requirements {
+		if requirement.Framework == framework {
+			return requirement.Codes
+		}
+	}
+	return nil
+}
+
+// BuildCrosswalk maps every control onto the requirements of each framework,
+// from its framework codes or through the SOC2 crosswalk, and credits it with
+// the evidence tasks of the equivalent controls of other frameworks: those
+// addressing one of its requirements in either control's own framework. One
+// submission then satisfies the control in every framework it maps to.
+func BuildCrosswalk(data *ComplianceData, opts CrosswalkOptions) *Crosswalk {
+	crosswalk := &Crosswalk{
+		Frameworks:  crosswalkFrameworks(opts.Frameworks),
+		Window:      opts.Window,
+		GeneratedAt: opts.GeneratedAt,
+		Controls:    []CrosswalkControl{},
+	}
+
+	wanted := make(map[string]bool, len(opts.Controls))
+	for _, ref := range opts.Controls {
+		wanted[strings.ToUpper(strings.TrimSpace(ref))] = true
+	}
+	taskByRef := make(map[string]domain.EvidenceTask, len(data.Tasks))
+	for _, task := range data.Tasks {
+		taskByRef[task.ReferenceID] = task
+	}
+	controlByID := make(map[string]domain.Control, len(data.Controls))
+	for _, control := range data.Controls {
+		controlByID[control.ID] = control
+	}
+	linked := controlTaskLinks(data)
+	equivalents := controlEquivalents(data.Controls)
+	submission := submissionLookup(data, taskByRef, opts.Window, opts.WindowFor)
+
+	for _, control := range data.Controls {
+		if len(wanted) > 0 && !wanted[strings.ToUpper(control.ID)] && !wanted[strings.ToUpper(control.ReferenceID)] {
+			continue
+		}
+		entry := CrosswalkControl{
+			Ref:          controlLabel(control),
+			ID:           control.ID,
+			Name:         control.Name,
+			Framework:    control.Framework,
+			Requirements: []CrosswalkRequirement{},
+		}
+		for _, framework := range crosswalk.Frameworks {
+			if codes := control.Requirements(framework); len(codes) > 0 {
+				entry.Requirements = append(entry.Requirements, CrosswalkRequirement{Framework: framework, Codes: codes})
+			}
+		}
+
+		seen := make(map[string]bool)
+		addEvidence := func(controlID, via string) {
+			for _, taskRef := range sortedKeys(linked[controlID]) {
+				if seen[taskRef] {
+					continue
+				}
+				seen[taskRef] = true
+				status, window := submission(taskRef)
+				entry.Evidence = append(entry.Evidence, CrosswalkEvidence{
+					TaskRef: taskRef, TaskName: taskByRef[taskRef].Name, Window: window, Status: status, Via: via,
+				})
+			}
+		}
+		addEvidence(control.ID, "")
+		for _, id := range equivalents[control.ID] {
+			equivalent := controlLabel(controlByID[id])
+			entry.Equivalents = append(entry.Equivalents, equivalent)
+			addEvidence(id, equivalent)
+		}
+		crosswalk.Controls = append(crosswalk.Controls, entry)
+	}
+
+	sort.SliceStable(crosswalk.Controls, func(i, j int) bool {
+		return naturalLess(crosswalk.Controls[i].Ref, crosswalk.Controls[j].Ref)
+	})
+	return crosswalk
+}
+
+// crosswalkFrameworks resolves the frameworks to crosswalk, defaulting to
+// every built-in and custom framework. Names that do not resolve are dropped.
+func crosswalkFrameworks(names []string) []string {
+	if len(names) == 0 {
+		all := append([]string(nil), frameworks.Supported...)
+		for _, custom := range frameworks.CustomFrameworks() {
+			all = append(all, custom.Name)
+		}
+		return all
+	}
+	var resolved []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if framework, err := frameworks.Resolve(name); err == nil && !seen[framework] {
+			seen[framework] = true
+			resolved = append(resolved, framework)
+		}
+	}
+	return resolved
+}
+
+// controlEquivalents maps each control ID to the IDs of the controls of other
+// frameworks it is equivalent to: two controls are equivalent when they
+// address a common requirement of either one's own framework, such as a
+// SOC2 control and an ISO 27001 control crosswalked to the same Annex A
+// control. Controls whose framework is unknown have no equivalents.
+func controlEquivalents(controls []domain.Control) map[string][]string {
+	own := make([]string, len(controls))
+	requirements := make([]map[string]map[string]bool, len(controls))
+	for i := range controls {
+		requirements[i] = make(map[string]map[string]bool)
+		if name := strings.TrimSpace(controls[i].Framework); name != "" {
+			if framework, err := frameworks.Resolve(name); err == nil {
+				own[i] = framework
+			}
+		}
+	}
+	requirementsOf := func(i int, framework string) map[string]bool {
+		codes, ok := requirements[i][framework]
+		if !ok {
+			codes = make(map[string]bool)
+			for _, code := range controls[i].Requirements(framework) {
+				codes[code] = true
+			}
+			requirements[i][framework] = codes
+		}
+		return codes
+	}
+	shares := func(i, j int, framework string) bool {
+		theirs := requirementsOf(j, framework)
+		for code := range requirementsOf(i, framework) {
+			if theirs[code] {
+				return true
+			}
+		}
+		return false
+	}
+
+	equivalents := make(map[string][]string)
+	for i := range controls {
+		for j := i + 1; j < len(controls); j++ {
+			if own[i] == "" || own[j] == "" || own[i] == own[j] {
+				continue
+			}
+			if shares(i, j, own[i]) || shares(i, j, own[j]) {
+				equivalents[controls[i].ID] = append(equivalents[controls[i].ID], controls[j].ID)
+				equivalents[controls[j].ID] = append(equivalents[controls[j].ID], controls[i].ID)
+			}
+		}
+	}
+	return equivalents
+}
@@ -0,0 +1,94 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"testing"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/frameworks"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCrosswalkData() *ComplianceData {
+	return &ComplianceData{
+		Tasks: []domain.EvidenceTask{
+			{ReferenceID: "ET-0001", Name: "Access review", Controls: []string{"CC6.1"}},
+			{ReferenceID: "ET-0002", Name: "Key rotation", Controls: []string{"ISO-2"}},
+		},
+		Controls: []domain.Control{
+			{ID: "101", ReferenceID: "CC6.1", Name: "Logical access", Framework: "SOC2"},
+			{ID: "102", ReferenceID: "ISO-1", Name: "Access control", Framework: "ISO 27001",
+				FrameworkCodes: []domain.FrameworkCode{{Framework: "ISO/IEC 27001:2022", Code: "A.5.15"}}},
+			{ID: "103", ReferenceID: "ISO-2", Name: "Cryptography", Framework: "ISO 27001",
+				FrameworkCodes: []domain.FrameworkCode{{Framework: "ISO 27001", Code: "A.8.24"}}},
+			{ID: "104", ReferenceID: "HR-1", Name: "Screening", Framework: "Internal"},
+		},
+		States: []*models.EvidenceTaskState{
+			{TaskRef: "ET-0001", Windows: map[string]models.WindowState{"2025-Q4": {SubmissionStatus: "submitted"}}},
+		},
+	}
+}
+
+func TestBuildCrosswalk(t *testing.T) {
+	t.Parallel()
+
+	windowFor := func(domain.EvidenceTask) string { return "2025-Q4" }
+	crosswalk := BuildCrosswalk(testCrosswalkData(), CrosswalkOptions{
+		Frameworks: []string{"SOC 2", "iso", "nist", "iso27001"},
+		WindowFor:  windowFor,
+	})
+	assert.Equal(t, []string{frameworks.SOC2, frameworks.ISO27001, frameworks.NIST80053}, crosswalk.Frameworks)
+	require.Len(t, crosswalk.Controls, 4)
+
+	byRef := make(map[string]CrosswalkControl)
+	for _, control := range crosswalk.Controls {
+		byRef[control.Ref] = control
+	}
+
+	soc2 := byRef["CC6.1"]
+	assert.Equal(t, []string{"CC6.1"}, soc2.Codes(frameworks.SOC2))
+	assert.Contains(t, soc2.Codes(frameworks.ISO27001), "A.5.15")
+	assert.Contains(t, soc2.Codes(frameworks.NIST80053), "AC-2")
+	assert.Equal(t, []string{"ISO-1"}, soc2.Equivalents, "ISO-2 addresses no Annex A control CC6.1 crosswalks to")
+	assert.Equal(t, []CrosswalkEvidence{{TaskRef: "ET-0001", TaskName: "Access review", Window: "2025-Q4", Status: "submitted"}},
+		soc2.Evidence)
+	assert.True(t, soc2.Satisfied())
+
+	iso := byRef["ISO-1"]
+	assert.Equal(t, []CrosswalkRequirement{{Framework: frameworks.ISO27001, Codes: []string{"A.5.15"}}}, iso.Requirements,
+		"controls carrying ISO codes do not crosswalk")
+	assert.Equal(t, []string{"CC6.1"}, iso.Equivalents)
+	assert.Equal(t, []CrosswalkEvidence{{TaskRef: "ET-0001", TaskName: "Access review", Window: "2025-Q4", Status: "submitted", Via: "CC6.1"}},
+		iso.Evidence, "the SOC2 control's submission satisfies it too")
+	assert.True(t, iso.Satisfied())
+
+	assert.False(t, byRef["ISO-2"].Satisfied(), "its own task has no submission")
+	assert.Empty(t, byRef["HR-1"].Requirements)
+	assert.Empty(t, byRef["HR-1"].Equivalents, "controls of unknown frameworks have no equivalents")
+
+	crosswalk = BuildCrosswalk(testCrosswalkData(), CrosswalkOptions{Controls: []string{"iso-2", "101"}})
+	require.Len(t, crosswalk.Controls, 2)
+	assert.Equal(t, "CC6.1", crosswalk.Controls[0].Ref)
+	assert.Equal(t, "ISO-2", crosswalk.Controls[1].Ref)
+	assert.Subset(t, crosswalk.Frameworks, frameworks.Supported, "every built-in framework by default")
+}
+
+func TestBuildCoverageReport_Crosswalk(t *testing.T) {
+	t.Parallel()
+
+	report := BuildCoverageReport(testCrosswalkData(), CoverageOptions{Framework: "iso27001", Window: "2025-Q4"})
+	require.Len(t, report.Criteria, 2)
+	assert.Equal(t, "A.5.15", report.Criteria[0].Criterion)
+	assert.Contains(t, report.Criteria[0].Gaps, "ISO-1 Access control has no evidence tasks")
+
+	report = BuildCoverageReport(testCrosswalkData(), CoverageOptions{Framework: "iso27001", Window: "2025-Q4", Crosswalk: true})
+	require.Len(t, report.Criteria, 2)
+	assert.Equal(t, 1, report.Criteria[0].Tasks, "credited with the equivalent SOC2 control's task")
+	assert.Equal(t, 1, report.Criteria[0].Submitted)
+	assert.NotContains(t, report.Criteria[0].Gaps, "ISO-1 Access control has no evidence tasks")
+	assert.Equal(t, 1, report.Criteria[1].Tasks)
+}
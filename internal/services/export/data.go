@@ -0,0 +1,69 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/models"
+)
+
+// DataSource provides the synced compliance data. It is satisfied by *storage.Storage.
+type DataSource interface {
+	GetAllEvidenceTasks() ([]domain.EvidenceTask, error)
+	GetAllControls() ([]domain.Control, error)
+	GetAllPolicies() ([]domain.Policy, error)
+}
+
+// ComplianceData is the compliance program data rendered by the exporters
+type ComplianceData struct {
+	Tasks    []domain.EvidenceTask
+	Controls []domain.Control
+	Policies []domain.Policy
+	// States holds local evidence state per task, used for submission status
+	States []*models.EvidenceTaskState
+}
+
+// LoadComplianceData reads tasks, controls and policies from the data source,
+// sorted by reference ID
+func LoadComplianceData(source DataSource, states []*models.EvidenceTaskState) (*ComplianceData, error) {
+	tasks, err := source.GetAllEvidenceTasks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load evidence tasks: %w", err)
+	}
+	controls, err := source.GetAllControls()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load controls: %w", err)
+	}
+	policies, err := source.GetAllPolicies()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load policies: %w", err)
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].ReferenceID < tasks[j].ReferenceID })
+	sort.SliceStable(controls, func(i, j int) bool { return controls[i].ReferenceID < controls[j].ReferenceID })
+	sort.SliceStable(policies, func(i, j int) bool { return policies[i].ReferenceID < policies[j].ReferenceID })
+	sort.SliceStable(states, func(i, j int) bool { return states[i].TaskRef < states[j].TaskRef })
+
+	return &ComplianceData{
+		Tasks:    tasks,
+		Controls: controls,
+		Policies: policies,
+		States:   states,
+	}, nil
+}
@@ -0,0 +1,402 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/models"
+)
+
+// OSCALVersion is the OSCAL schema version the exporter targets
+const OSCALVersion = "1.1.2"
+
+// OSCALNamespace qualifies grctool-specific OSCAL property names
+const OSCALNamespace = "https://github.com/grctool/grctool/ns/oscal"
+
+// oscalUUIDNamespace seeds name-based (v5) UUIDs so repeated exports of the
+// same data keep stable identifiers
+var oscalUUIDNamespace = uuid.MustParse("5f0c6a2e-8f43-4a8e-9d7b-2c1e64b0a9f1")
+
+// OSCALOptions controls the generated component definition
+type OSCALOptions struct {
+	// Title of the component definition and its single component
+	Title string
+	// Version is the document version recorded in the metadata
+	Version string
+	// Source is the catalog or profile href used for every control
+	// implementation. When empty, a per-framework URN is used.
+	Source string
+	// Now is the last-modified timestamp
+	Now time.Time
+}
+
+// OSCALDocument is the root of an OSCAL component definition JSON document
+type OSCALDocument struct {
+	ComponentDefinition OSCALComponentDefinition `json:"component-definition"`
+}
+
+// OSCALComponentDefinition describes the controls a component implements
+type OSCALComponentDefinition struct {
+	UUID       string           `json:"uuid"`
+	Metadata   OSCALMetadata    `json:"metadata"`
+	Components []OSCALComponent `json:"components,omitempty"`
+	BackMatter *OSCALBackMatter `json:"back-matter,omitempty"`
+}
+
+// OSCALMetadata is the document metadata block
+type OSCALMetadata struct {
+	Title        string    `json:"title"`
+	LastModified time.Time `json:"last-modified"`
+	Version      string    `json:"version"`
+	OSCALVersion string    `json:"oscal-version"`
+}
+
+// OSCALComponent is a component with its control implementations
+type OSCALComponent struct {
+	UUID                   string                       `json:"uuid"`
+	Type                   string                       `json:"type"`
+	Title                  string                       `json:"title"`
+	Description            string                       `json:"description"`
+	ControlImplementations []OSCALControlImplementation `json:"control-implementations,omitempty"`
+}
+
+// OSCALControlImplementation groups implemented requirements from one source
+type OSCALControlImplementation struct {
+	UUID                    string                        `json:"uuid"`
+	Source                  string                        `json:"source"`
+	Description             string                        `json:"description"`
+	ImplementedRequirements []OSCALImplementedRequirement `json:"implemented-requirements"`
+}
+
+// OSCALImplementedRequirement is the implementation statement for one control
+type OSCALImplementedRequirement struct {
+	UUID        string          `json:"uuid"`
+	ControlID   string          `json:"control-id"`
+	Description string          `json:"description"`
+	Props       []OSCALProperty `json:"props,omitempty"`
+	Links       []OSCALLink     `json:"links,omitempty"`
+}
+
+// OSCALProperty is a name/value annotation
+type OSCALProperty struct {
+	Name  string `json:"name"`
+	NS    string `json:"ns,omitempty"`
+	Value string `json:"value"`
+}
+
+// OSCALLink references a back-matter resource or external document
+type OSCALLink struct {
+	Href string `json:"href"`
+	Rel  string `json:"rel,omitempty"`
+	Text string `json:"text,omitempty"`
+}
+
+// OSCALBackMatter holds the resources referenced by links
+type OSCALBackMatter struct {
+	Resources []OSCALResource `json:"resources"`
+}
+
+// OSCALResource is a back-matter resource such as an evidence task or policy
+type OSCALResource struct {
+	UUID        string          `json:"uuid"`
+	Title       string          `json:"title,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Props       []OSCALProperty `json:"props,omitempty"`
+	RLinks      []OSCALRLink    `json:"rlinks,omitempty"`
+}
+
+// OSCALRLink points at a resource location
+type OSCALRLink struct {
+	Href      string `json:"href"`
+	MediaType string `json:"media-type,omitempty"`
+}
+
+// BuildOSCALComponentDefinition renders controls as implemented requirements,
+// one control implementation per framework, linking each to the evidence tasks
+// and policies that support it. Tasks and policies are emitted as back-matter
+// resources.
+func BuildOSCALComponentDefinition(data *ComplianceData, opts OSCALOptions) *OSCALDocument {
+	title := opts.Title
+	if title == "" {
+		title = "Compliance Program"
+	}
+	version := opts.Version
+	if version == "" {
+		version = "1.0.0"
+	}
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	now = now.UTC().Truncate(time.Second)
+
+	index := controlIndex(data.Controls)
+	states := make(map[string]*models.EvidenceTaskState, len(data.States))
+	for _, state := range data.States {
+		states[state.TaskRef] = state
+	}
+
+	var resources []OSCALResource
+	links := make(map[string][]OSCALLink)
+
+	for _, task := range data.Tasks {
+		resource := evidenceTaskResource(task, states[task.ReferenceID])
+		resources = append(resources, resource)
+		linked := make(map[string]bool)
+		for _, ref := range task.Controls {
+			id, ok := index[ref]
+			if !ok || linked[id] {
+				continue
+			}
+			linked[id] = true
+			links[id] = append(links[id], OSCALLink{Href: "#" + resource.UUID, Rel: "evidence", Text: resource.Title})
+		}
+	}
+
+	for _, policy := range data.Policies {
+		resource := policyResource(policy)
+		resources = append(resources, resource)
+		linked := make(map[string]bool)
+		for _, control := range policy.Controls {
+			id, ok := index[control.ID]
+			if !ok {
+				id, ok = index[control.ReferenceID]
+			}
+			if !ok || linked[id] {
+				continue
+			}
+			linked[id] = true
+			links[id] = append(links[id], OSCALLink{Href: "#" + resource.UUID, Rel: "policy", Text: resource.Title})
+		}
+	}
+
+	byFramework := make(map[string][]OSCALImplementedRequirement)
+	for _, control := range data.Controls {
+		requirement := OSCALImplementedRequirement{
+			UUID:        oscalUUID("control", control.ID),
+			ControlID:   oscalControlID(control),
+			Description: implementationStatement(control),
+			Props: oscalProps(
+				"reference-id", control.ReferenceID,
+				"status", control.Status,
+				"category", control.Category,
+				"risk-level", control.RiskLevel,
+			),
+			Links: links[control.ID],
+		}
+		byFramework[control.Framework] = append(byFramework[control.Framework], requirement)
+	}
+
+	frameworks := make([]string, 0, len(byFramework))
+	for framework := range byFramework {
+		frameworks = append(frameworks, framework)
+	}
+	sort.Strings(frameworks)
+
+	component := OSCALComponent{
+		UUID:        oscalUUID("component", title),
+		Type:        "this-system",
+		Title:       title,
+		Description: fmt.Sprintf("Controls, implementation statements and evidence of %s as synced by grctool.", title),
+	}
+	for _, framework := range frameworks {
+		name := framework
+		if name == "" {
+			name = "unspecified"
+		}
+		source := opts.Source
+		if source == "" {
+			source = "urn:grctool:framework:" + oscalToken(strings.ToLower(name), "framework")
+		}
+		component.ControlImplementations = append(component.ControlImplementations, OSCALControlImplementation{
+			UUID:                    oscalUUID("control-implementation", name),
+			Source:                  source,
+			Description:             fmt.Sprintf("%s control implementations", name),
+			ImplementedRequirements: byFramework[framework],
+		})
+	}
+
+	doc := &OSCALDocument{
+		ComponentDefinition: OSCALComponentDefinition{
+			UUID: oscalUUID("component-definition", title+"@"+now.Format(time.RFC3339)),
+			Metadata: OSCALMetadata{
+				Title:        title,
+				LastModified: now,
+				Version:      version,
+				OSCALVersion: OSCALVersion,
+			},
+			Components: []OSCALComponent{component},
+		},
+	}
+	if len(resources) > 0 {
+		doc.ComponentDefinition.BackMatter = &OSCALBackMatter{Resources: resources}
+	}
+	return doc
+}
+
+// evidenceTaskResource describes an evidence task and its latest local evidence
+func evidenceTaskResource(task domain.EvidenceTask, state *models.EvidenceTaskState) OSCALResource {
+	resource := OSCALResource{
+		UUID:        oscalUUID("evidence-task", task.ID),
+		Title:       resourceTitle(task.ReferenceID, task.Name),
+		Description: task.Description,
+		Props: append([]OSCALProperty{{Name: "type", Value: "evidence"}}, oscalProps(
+			"reference-id", task.ReferenceID,
+			"collection-interval", task.CollectionInterval,
+			"status", task.Status,
+		)...),
+	}
+
+	if state != nil {
+		resource.Props = append(resource.Props, oscalProps("local-state", string(state.LocalState))...)
+		if window, ok := latestWindow(state); ok {
+			resource.Props = append(resource.Props, oscalProps(
+				"evidence-window", window.Window,
+				"evidence-file-count", strconv.Itoa(window.FileCount),
+				"submission-status", window.SubmissionStatus,
+			)...)
+		}
+	}
+
+	if task.TugboatURL != "" {
+		resource.RLinks = []OSCALRLink{{Href: task.TugboatURL, MediaType: "text/html"}}
+	}
+	return resource
+}
+
+func policyResource(policy domain.Policy) OSCALResource {
+	description := policy.Summary
+	if description == "" {
+		description = policy.Description
+	}
+	return OSCALResource{
+		UUID:        oscalUUID("policy", policy.ID),
+		Title:       resourceTitle(policy.ReferenceID, policy.Name),
+		Description: description,
+		Props: append([]OSCALProperty{{Name: "type", Value: "policy"}}, oscalProps(
+			"reference-id", policy.ReferenceID,
+			"version", policy.Version,
+		)...),
+	}
+}
+
+// latestWindow returns the most recent collection window with evidence
+func latestWindow(state *models.EvidenceTaskState) (models.WindowState, bool) {
+	var latest string
+	for name := range state.Windows {
+		if name > latest {
+			latest = name
+		}
+	}
+	if latest == "" {
+		return models.WindowState{}, false
+	}
+	window := state.Windows[latest]
+	if window.Window == "" {
+		window.Window = latest
+	}
+	return window, true
+}
+
+// implementationStatement picks the most descriptive text available for a control
+func implementationStatement(control domain.Control) string {
+	candidates := []string{control.Description}
+	if control.MasterContent != nil {
+		candidates = append(candidates, control.MasterContent.Description)
+	}
+	candidates = append(candidates, control.Help, control.Name)
+	for _, text := range candidates {
+		if strings.TrimSpace(text) != "" {
+			return text
+		}
+	}
+	return "Implementation statement not provided."
+}
+
+// oscalControlID converts a control reference such as "CC6.1" or "AC-01" into
+// an OSCAL control-id token ("cc6.1", "ac-01")
+func oscalControlID(control domain.Control) string {
+	return oscalToken(strings.ToLower(controlLabel(control)), "control-"+control.ID)
+}
+
+// oscalToken replaces characters not allowed in OSCAL tokens with hyphens and
+// ensures the token starts with a letter or underscore
+func oscalToken(value, fallback string) string {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(value) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '-' || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('-')
+		}
+	}
+	token := b.String()
+	if token == "" {
+		return fallback
+	}
+	if first := []rune(token)[0]; !unicode.IsLetter(first) && first != '_' {
+		token = "_" + token
+	}
+	return token
+}
+
+// oscalProps builds grctool-namespaced properties from name/value pairs,
+// skipping empty values
+func oscalProps(pairs ...string) []OSCALProperty {
+	var props []OSCALProperty
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] == "" {
+			continue
+		}
+		props = append(props, OSCALProperty{Name: pairs[i], NS: OSCALNamespace, Value: pairs[i+1]})
+	}
+	return props
+}
+
+func oscalUUID(kind, key string) string {
+	return uuid.NewSHA1(oscalUUIDNamespace, []byte(kind+":"+key)).String()
+}
+
+func resourceTitle(ref, name string) string {
+	switch {
+	case ref == "":
+		return name
+	case name == "":
+		return ref
+	default:
+		return ref + ": " + name
+	}
+}
+
+// controlIndex maps control IDs and reference IDs to control IDs
+func controlIndex(controls []domain.Control) map[string]string {
+	index := make(map[string]string, len(controls)*2)
+	for _, control := range controls {
+		index[control.ID] = control.ID
+		if control.ReferenceID != "" {
+			index[control.ReferenceID] = control.ID
+		}
+	}
+	return index
+}
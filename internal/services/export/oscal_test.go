@@ -0,0 +1,114 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildOSCALComponentDefinition(t *testing.T) {
+	t.Parallel()
+
+	data := testComplianceData()
+	data.Controls[0].Framework = "SOC2"
+	data.Controls[0].Description = "Access is restricted to authorized users."
+	data.Controls[1].Framework = "SOC2"
+	data.Controls[2].Framework = "ISO27001"
+	data.Tasks[0].TugboatURL = "https://my.tugboatlogic.com/org/1/evidence/tasks/327992"
+	now := time.Date(2025, 8, 1, 12, 30, 0, 0, time.UTC)
+
+	doc := BuildOSCALComponentDefinition(data, OSCALOptions{Title: "Acme", Version: "2.0", Now: now})
+	def := doc.ComponentDefinition
+
+	assert.Equal(t, "Acme", def.Metadata.Title)
+	assert.Equal(t, OSCALVersion, def.Metadata.OSCALVersion)
+	assert.Equal(t, "2.0", def.Metadata.Version)
+	assert.Equal(t, now, def.Metadata.LastModified)
+
+	require.Len(t, def.Components, 1)
+	impls := def.Components[0].ControlImplementations
+	require.Len(t, impls, 2)
+	assert.Equal(t, "urn:grctool:framework:iso27001", impls[0].Source)
+	assert.Equal(t, "urn:grctool:framework:soc2", impls[1].Source)
+
+	soc2 := impls[1].ImplementedRequirements
+	require.Len(t, soc2, 2)
+	assert.Equal(t, "cc6.1", soc2[0].ControlID)
+	assert.Equal(t, "Access is restricted to authorized users.", soc2[0].Description)
+	assert.Equal(t, "User provisioning", soc2[1].Description, "falls back to the control name")
+
+	require.Len(t, soc2[0].Links, 2, "evidence task and policy")
+	assert.Equal(t, "evidence", soc2[0].Links[0].Rel)
+	assert.Equal(t, "policy", soc2[0].Links[1].Rel)
+	require.Len(t, soc2[1].Links, 1)
+
+	resources := def.BackMatter.Resources
+	require.Len(t, resources, 3)
+	assert.Equal(t, "#"+resources[0].UUID, soc2[0].Links[0].Href)
+	assert.Equal(t, "ET-0001: Access review", resources[0].Title)
+	assert.Equal(t, OSCALProperty{Name: "type", Value: "evidence"}, resources[0].Props[0])
+	assert.Contains(t, resources[0].Props, OSCALProperty{Name: "evidence-window", NS: OSCALNamespace, Value: "2025-Q3"})
+	require.Len(t, resources[0].RLinks, 1)
+	assert.Equal(t, "#"+resources[2].UUID, soc2[0].Links[1].Href)
+
+	for _, id := range []string{def.UUID, def.Components[0].UUID, soc2[0].UUID, resources[0].UUID} {
+		parsed, err := uuid.Parse(id)
+		require.NoError(t, err)
+		assert.Equal(t, uuid.Version(5), parsed.Version())
+	}
+
+	again := BuildOSCALComponentDefinition(data, OSCALOptions{Title: "Acme", Version: "2.0", Now: now})
+	assert.Equal(t, doc, again, "export is deterministic")
+
+	encoded, err := json.Marshal(doc)
+	require.NoError(t, err)
+	assert.Contains(t, string(encoded), `"component-definition"`)
+	assert.Contains(t, string(encoded), `"implemented-requirements"`)
+	assert.Contains(t, string(encoded), `"last-modified":"2025-08-01T12:30:00Z"`)
+}
+
+func TestBuildOSCALComponentDefinition_Source(t *testing.T) {
+	t.Parallel()
+
+	data := &ComplianceData{Controls: []domain.Control{{ID: "1", ReferenceID: "AC-01", Framework: "NIST"}}}
+	doc := BuildOSCALComponentDefinition(data, OSCALOptions{Source: "https://example.com/profile.json"})
+
+	impls := doc.ComponentDefinition.Components[0].ControlImplementations
+	require.Len(t, impls, 1)
+	assert.Equal(t, "https://example.com/profile.json", impls[0].Source)
+	assert.Equal(t, "Compliance Program", doc.ComponentDefinition.Metadata.Title)
+	assert.Nil(t, doc.ComponentDefinition.BackMatter)
+}
+
+func TestOSCALControlID(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		control domain.Control
+		want    string
+	}{
+		"soc2 reference":   {control: domain.Control{ID: "1", ReferenceID: "CC6.1"}, want: "cc6.1"},
+		"nist reference":   {control: domain.Control{ID: "2", ReferenceID: "AC-01"}, want: "ac-01"},
+		"spaces":           {control: domain.Control{ID: "3", ReferenceID: "A 5.1"}, want: "a-5.1"},
+		"leading digit":    {control: domain.Control{ID: "4", ReferenceID: "5.1"}, want: "_5.1"},
+		"id only":          {control: domain.Control{ID: "778805"}, want: "_778805"},
+		"no identifiers":   {control: domain.Control{}, want: "control-"},
+		"iso with colon":   {control: domain.Control{ID: "5", ReferenceID: "A.5:1"}, want: "a.5-1"},
+		"underscore start": {control: domain.Control{ID: "6", ReferenceID: "_x"}, want: "_x"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, oscalControlID(tt.control))
+		})
+	}
+}
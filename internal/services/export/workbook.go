@@ -0,0 +1,162 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/grctool/grctool/internal/domain"
+)
+
+// Sheet names of the compliance workbook
+const (
+	SheetTasks            = "Evidence Tasks"
+	SheetControls         = "Controls"
+	SheetPolicies         = "Policies"
+	SheetMatrix           = "Task-Control Matrix"
+	SheetSubmissionStatus = "Submission Status"
+)
+
+// BuildComplianceWorkbook lays out tasks, controls, policies, the task-to-control
+// relationship matrix and submission status as separate sheets
+func BuildComplianceWorkbook(data *ComplianceData) *Workbook {
+	wb := &Workbook{}
+
+	tasks := wb.AddSheet(SheetTasks, "Reference", "ID", "Name", "Framework", "Category", "Status",
+		"Priority", "Collection Interval", "Collection Type", "Next Due", "Last Collected",
+		"Assignees", "Controls", "Policies", "Tugboat URL")
+	for _, task := range data.Tasks {
+		tasks.AddRow(task.ReferenceID, task.ID, task.Name, task.Framework, task.GetCategory(), task.Status,
+			task.Priority, task.CollectionInterval, task.GetCollectionType(), task.NextDue, task.LastCollected,
+			personNames(task.Assignees), len(task.Controls), len(task.Policies), task.TugboatURL)
+	}
+
+	controls := wb.AddSheet(SheetControls, "Reference", "ID", "Name", "Framework", "Category", "Status",
+		"Risk Level", "Codes", "Implemented", "Tested", "Evidence Tasks")
+	taskCounts := controlTaskCounts(data.Tasks, data.Controls)
+	for _, control := range data.Controls {
+		controls.AddRow(control.ReferenceID, control.ID, control.Name, control.Framework, control.Category,
+			control.Status, control.RiskLevel, control.Codes, control.ImplementedDate, control.TestedDate,
+			taskCounts[control.ID])
+	}
+
+	policies := wb.AddSheet(SheetPolicies, "Reference", "ID", "Name", "Framework", "Category", "Status",
+		"Version", "Controls", "Updated")
+	for _, policy := range data.Policies {
+		policies.AddRow(policy.ReferenceID, policy.ID, policy.Name, policy.Framework, policy.Category,
+			policy.Status, policy.Version, controlRefs(policy.Controls), policy.UpdatedAt)
+	}
+
+	buildRelationshipMatrix(wb, data.Tasks, data.Controls)
+
+	status := wb.AddSheet(SheetSubmissionStatus, "Task", "Name", "Window", "Local State", "Files",
+		"Generated", "Generated By", "Submission Status", "Submitted", "Submission ID", "Tugboat Status")
+	for _, state := range data.States {
+		if len(state.Windows) == 0 {
+			status.AddRow(state.TaskRef, state.TaskName, "", string(state.LocalState), 0,
+				nil, "", "", nil, "", state.TugboatStatus)
+			continue
+		}
+		windows := make([]string, 0, len(state.Windows))
+		for window := range state.Windows {
+			windows = append(windows, window)
+		}
+		sort.Sort(sort.Reverse(sort.StringSlice(windows)))
+		for _, name := range windows {
+			window := state.Windows[name]
+			status.AddRow(state.TaskRef, state.TaskName, name, string(state.LocalState), window.FileCount,
+				window.GeneratedAt, window.GeneratedBy, window.SubmissionStatus, window.SubmittedAt,
+				window.SubmissionID, state.TugboatStatus)
+		}
+	}
+
+	return wb
+}
+
+// buildRelationshipMatrix adds a sheet with one row per task and one column per
+// control, marking the controls each task provides evidence for
+func buildRelationshipMatrix(wb *Workbook, tasks []domain.EvidenceTask, controls []domain.Control) {
+	header := []string{"Task", "Name"}
+	for _, control := range controls {
+		header = append(header, controlLabel(control))
+	}
+	matrix := wb.AddSheet(SheetMatrix, header...)
+
+	columns := make(map[string]int, len(controls)*2)
+	for i, control := range controls {
+		if control.ID != "" {
+			columns[control.ID] = i
+		}
+		if control.ReferenceID != "" {
+			columns[control.ReferenceID] = i
+		}
+	}
+
+	for _, task := range tasks {
+		row := make([]interface{}, len(controls)+2)
+		row[0] = task.ReferenceID
+		row[1] = task.Name
+		for _, controlID := range task.Controls {
+			if i, ok := columns[controlID]; ok {
+				row[i+2] = "X"
+			}
+		}
+		matrix.AddRow(row...)
+	}
+}
+
+// controlTaskCounts counts the evidence tasks mapped to each control, keyed by control ID
+func controlTaskCounts(tasks []domain.EvidenceTask, controls []domain.Control) map[string]int {
+	ids := controlIndex(controls)
+	counts := make(map[string]int)
+	for _, task := range tasks {
+		for _, controlID := range task.Controls {
+			if id, ok := ids[controlID]; ok {
+				counts[id]++
+			}
+		}
+	}
+	return counts
+}
+
+func controlLabel(control domain.Control) string {
+	if control.ReferenceID != "" {
+		return control.ReferenceID
+	}
+	return control.ID
+}
+
+func controlRefs(controls []domain.Control) string {
+	refs := make([]string, 0, len(controls))
+	for _, control := range controls {
+		refs = append(refs, controlLabel(control))
+	}
+	return strings.Join(refs, ", ")
+}
+
+func personNames(people []domain.Person) string {
+	names := make([]string, 0, len(people))
+	for _, person := range people {
+		switch {
+		case person.Name != "":
+			names = append(names, person.Name)
+		case person.Email != "":
+			names = append(names, person.Email)
+		}
+	}
+	return strings.Join(names, ", ")
+}
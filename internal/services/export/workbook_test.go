@@ -0,0 +1,122 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubDataSource struct {
+	tasks    []domain.EvidenceTask
+	controls []domain.Control
+	policies []domain.Policy
+	err      error
+}
+
+func (s *stubDataSource) GetAllEvidenceTasks() ([]domain.EvidenceTask, error) { return s.tasks, s.err }
+func (s *stubDataSource) GetAllControls() ([]domain.Control, error)           { return s.controls, nil }
+func (s *stubDataSource) GetAllPolicies() ([]domain.Policy, error)            { return s.policies, nil }
+
+func findSheet(t *testing.T, wb *Workbook, name string) *Sheet {
+	t.Helper()
+	for _, sheet := range wb.Sheets {
+		if sheet.Name == name {
+			return sheet
+		}
+	}
+	t.Fatalf("sheet %s not found", name)
+	return nil
+}
+
+func testComplianceData() *ComplianceData {
+	submitted := time.Date(2025, 7, 2, 10, 0, 0, 0, time.UTC)
+	return &ComplianceData{
+		Tasks: []domain.EvidenceTask{
+			{ID: "327992", ReferenceID: "ET-0001", Name: "Access review", Controls: []string{"778805", "CC6.2"},
+				Assignees: []domain.Person{{Name: "Alex"}, {Email: "sam@example.com"}}},
+			{ID: "327993", ReferenceID: "ET-0002", Name: "Backups", Controls: []string{"unknown"}},
+		},
+		Controls: []domain.Control{
+			{ID: "778805", ReferenceID: "CC6.1", Name: "Logical access"},
+			{ID: "778806", ReferenceID: "CC6.2", Name: "User provisioning"},
+			{ID: "778807", Name: "Unreferenced"},
+		},
+		Policies: []domain.Policy{
+			{ID: "94641", ReferenceID: "POL-0001", Name: "Access Control Policy",
+				Controls: []domain.Control{{ID: "778805", ReferenceID: "CC6.1"}, {ID: "778807"}}},
+		},
+		States: []*models.EvidenceTaskState{
+			{TaskRef: "ET-0001", TaskName: "Access review", LocalState: models.StateSubmitted, TugboatStatus: "completed",
+				Windows: map[string]models.WindowState{
+					"2025-Q2": {FileCount: 2, SubmissionStatus: "submitted", SubmittedAt: &submitted},
+					"2025-Q3": {FileCount: 1},
+				}},
+			{TaskRef: "ET-0002", TaskName: "Backups", LocalState: models.StateNoEvidence},
+		},
+	}
+}
+
+func TestBuildComplianceWorkbook(t *testing.T) {
+	t.Parallel()
+
+	wb := BuildComplianceWorkbook(testComplianceData())
+
+	names := make([]string, 0, len(wb.Sheets))
+	for _, sheet := range wb.Sheets {
+		names = append(names, sheet.Name)
+	}
+	assert.Equal(t, []string{SheetTasks, SheetControls, SheetPolicies, SheetMatrix, SheetSubmissionStatus}, names)
+
+	tasks := findSheet(t, wb, SheetTasks)
+	require.Len(t, tasks.Rows, 2)
+	assert.Equal(t, "Alex, sam@example.com", tasks.Rows[0][11])
+	assert.Equal(t, 2, tasks.Rows[0][12])
+
+	controls := findSheet(t, wb, SheetControls)
+	assert.Equal(t, 1, controls.Rows[0][10], "CC6.1 is mapped by ID")
+	assert.Equal(t, 1, controls.Rows[1][10], "CC6.2 is mapped by reference")
+	assert.Equal(t, 0, controls.Rows[2][10])
+
+	policies := findSheet(t, wb, SheetPolicies)
+	assert.Equal(t, "CC6.1, 778807", policies.Rows[0][7])
+
+	matrix := findSheet(t, wb, SheetMatrix)
+	assert.Equal(t, []string{"Task", "Name", "CC6.1", "CC6.2", "778807"}, matrix.Header)
+	assert.Equal(t, []interface{}{"ET-0001", "Access review", "X", "X", nil}, matrix.Rows[0])
+	assert.Equal(t, []interface{}{"ET-0002", "Backups", nil, nil, nil}, matrix.Rows[1])
+
+	status := findSheet(t, wb, SheetSubmissionStatus)
+	require.Len(t, status.Rows, 3)
+	assert.Equal(t, "2025-Q3", status.Rows[0][2], "newest window first")
+	assert.Equal(t, "submitted", status.Rows[1][7])
+	assert.Equal(t, "ET-0002", status.Rows[2][0])
+	assert.Equal(t, "no_evidence", status.Rows[2][3])
+}
+
+func TestLoadComplianceData(t *testing.T) {
+	t.Parallel()
+
+	source := &stubDataSource{
+		tasks:    []domain.EvidenceTask{{ReferenceID: "ET-0002"}, {ReferenceID: "ET-0001"}},
+		controls: []domain.Control{{ReferenceID: "CC7.1"}, {ReferenceID: "CC6.1"}},
+	}
+	states := []*models.EvidenceTaskState{{TaskRef: "ET-0002"}, {TaskRef: "ET-0001"}}
+
+	data, err := LoadComplianceData(source, states)
+	require.NoError(t, err)
+	assert.Equal(t, "ET-0001", data.Tasks[0].ReferenceID)
+	assert.Equal(t, "CC6.1", data.Controls[0].ReferenceID)
+	assert.Equal(t, "ET-0001", data.States[0].TaskRef)
+
+	_, err = LoadComplianceData(&stubDataSource{err: errors.New("boom")}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to load evidence tasks")
+}
@@ -0,0 +1,344 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Workbook is a minimal Office Open XML spreadsheet: one header row per sheet
+// followed by data rows. Cells hold strings, integers, floats, booleans or
+// times; anything else is rendered with fmt.
+type Workbook struct {
+	Sheets []*Sheet
+}
+
+// Sheet is a single worksheet
+type Sheet struct {
+	Name   string
+	Header []string
+	Rows   [][]interface{}
+}
+
+// AddSheet appends a worksheet and returns it for populating
+func (wb *Workbook) AddSheet(name string, header ...string) *Sheet {
+	sheet := &Sheet{Name: name, Header: header}
+	wb.Sheets = append(wb.Sheets, sheet)
+	return sheet
+}
+
+// AddRow appends a data row
+func (s *Sheet) AddRow(values ...interface{}) {
+	s.Rows = append(s.Rows, values)
+}
+
+// maxSheetNameLength is Excel's limit on worksheet names
+const maxSheetNameLength = 31
+
+// maxColumnWidth caps auto-sized column widths (in characters)
+const maxColumnWidth = 60
+
+// Write encodes the workbook as an .xlsx archive
+func (wb *Workbook) Write(w io.Writer) error {
+	if len(wb.Sheets) == 0 {
+		return fmt.Errorf("workbook has no sheets")
+	}
+
+	names := make([]string, len(wb.Sheets))
+	seen := make(map[string]bool)
+	for i, sheet := range wb.Sheets {
+		name := sanitizeSheetName(sheet.Name, i+1)
+		if seen[strings.ToLower(name)] {
+			return fmt.Errorf("duplicate sheet name: %s", name)
+		}
+		seen[strings.ToLower(name)] = true
+		names[i] = name
+	}
+
+	zw := zip.NewWriter(w)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypesXML(len(wb.Sheets))},
+		{"_rels/.rels", rootRelsXML},
+		{"xl/workbook.xml", workbookXML(names)},
+		{"xl/_rels/workbook.xml.rels", workbookRelsXML(len(wb.Sheets))},
+		{"xl/styles.xml", stylesXML},
+	}
+	for i, sheet := range wb.Sheets {
+		parts = append(parts, struct {
+			name    string
+			content string
+		}{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), sheet.xml()})
+	}
+
+	for _, part := range parts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", part.name, err)
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return fmt.Errorf("failed to write %s: %w", part.name, err)
+		}
+	}
+
+	return zw.Close()
+}
+
+// sanitizeSheetName removes characters Excel rejects and enforces the length limit
+func sanitizeSheetName(name string, index int) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '-'
+		}
+		return r
+	}, strings.TrimSpace(name))
+	name = strings.Trim(name, "'")
+	if name == "" {
+		name = fmt.Sprintf("Sheet%d", index)
+	}
+	if utf8.RuneCountInString(name) > maxSheetNameLength {
+		name = string([]rune(name)[:maxSheetNameLength])
+	}
+	return name
+}
+
+// columnName converts a zero-based column index to a spreadsheet column letter (0 -> A, 26 -> AA)
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+func (s *Sheet) columnCount() int {
+	count := len(s.Header)
+	for _, row := range s.Rows {
+		if len(row) > count {
+			count = len(row)
+		}
+	}
+	return count
+}
+
+func (s *Sheet) xml() string {
+	var b strings.Builder
+	columns := s.columnCount()
+
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+
+	// Freeze the header row
+	if len(s.Header) > 0 {
+		b.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	}
+
+	if columns > 0 {
+		b.WriteString("<cols>")
+		for i, width := range s.columnWidths(columns) {
+			fmt.Fprintf(&b, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, i+1, i+1, width)
+		}
+		b.WriteString("</cols>")
+	}
+
+	b.WriteString("<sheetData>")
+	rowNum := 1
+	if len(s.Header) > 0 {
+		header := make([]interface{}, len(s.Header))
+		for i, h := range s.Header {
+			header[i] = h
+		}
+		writeRow(&b, rowNum, header, 1)
+		rowNum++
+	}
+	for _, row := range s.Rows {
+		writeRow(&b, rowNum, row, 0)
+		rowNum++
+	}
+	b.WriteString("</sheetData>")
+
+	if len(s.Header) > 0 && columns > 0 {
+		fmt.Fprintf(&b, `<autoFilter ref="A1:%s%d"/>`, columnName(columns-1), rowNum-1)
+	}
+
+	b.WriteString("</worksheet>")
+	return b.String()
+}
+
+// columnWidths sizes each column to its longest value, within limits
+func (s *Sheet) columnWidths(columns int) []int {
+	widths := make([]int, columns)
+	measure := func(i int, value interface{}) {
+		if n := utf8.RuneCountInString(cellText(value)) + 2; n > widths[i] {
+			widths[i] = n
+		}
+	}
+	for i, h := range s.Header {
+		measure(i, h)
+	}
+	for _, row := range s.Rows {
+		for i, value := range row {
+			measure(i, value)
+		}
+	}
+	for i := range widths {
+		widths[i] = max(8, min(widths[i], maxColumnWidth))
+	}
+	return widths
+}
+
+func writeRow(b *strings.Builder, rowNum int, values []interface{}, style int) {
+	fmt.Fprintf(b, `<row r="%d">`, rowNum)
+	for i, value := range values {
+		ref := fmt.Sprintf("%s%d", columnName(i), rowNum)
+		styleAttr := ""
+		if style > 0 {
+			styleAttr = fmt.Sprintf(` s="%d"`, style)
+		}
+
+		switch v := value.(type) {
+		case nil:
+			continue
+		case int, int32, int64, float32, float64:
+			fmt.Fprintf(b, `<c r="%s"%s><v>%s</v></c>`, ref, styleAttr, cellText(v))
+		case bool:
+			n := 0
+			if v {
+				n = 1
+			}
+			fmt.Fprintf(b, `<c r="%s"%s t="b"><v>%d</v></c>`, ref, styleAttr, n)
+		default:
+			text := cellText(v)
+			if text == "" {
+				continue
+			}
+			fmt.Fprintf(b, `<c r="%s"%s t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, styleAttr, escapeXML(text))
+		}
+	}
+	b.WriteString("</row>")
+}
+
+// cellText renders a cell value as text
+func cellText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format("2006-01-02 15:04")
+	case *time.Time:
+		if v == nil || v.IsZero() {
+			return ""
+		}
+		return v.Format("2006-01-02 15:04")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// escapeXML escapes text content and drops characters that are invalid in XML 1.0
+func escapeXML(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' || r >= 0x20 && r != 0xFFFE && r != 0xFFFF {
+			return r
+		}
+		return -1
+	}, s)
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func contentTypesXML(sheets int) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	b.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := 1; i <= sheets; i++ {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+	}
+	b.WriteString(`</Types>`)
+	return b.String()
+}
+
+const rootRelsXML = xml.Header +
+	`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+func workbookXML(names []string) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i, name := range names {
+		fmt.Fprintf(&b, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escapeXML(name), i+1, i+1)
+	}
+	b.WriteString(`</sheets></workbook>`)
+	return b.String()
+}
+
+func workbookRelsXML(sheets int) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := 1; i <= sheets; i++ {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i, i)
+	}
+	fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, sheets+1)
+	b.WriteString(`</Relationships>`)
+	return b.String()
+}
+
+// stylesXML defines two cell formats: 0 = default, 1 = bold header
+const stylesXML = xml.Header +
+	`<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
@@ -0,0 +1,108 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readZipPart(t *testing.T, archive []byte, name string) string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+	for _, f := range zr.File {
+		if f.Name == name {
+			rc, err := f.Open()
+			require.NoError(t, err)
+			defer rc.Close()
+			data, err := io.ReadAll(rc)
+			require.NoError(t, err)
+			return string(data)
+		}
+	}
+	t.Fatalf("part %s not found", name)
+	return ""
+}
+
+func TestColumnName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[int]string{0: "A", 1: "B", 25: "Z", 26: "AA", 27: "AB", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"}
+	for index, want := range tests {
+		assert.Equal(t, want, columnName(index), "index %d", index)
+	}
+}
+
+func TestSanitizeSheetName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Task-Control Matrix", sanitizeSheetName("Task/Control Matrix", 1))
+	assert.Equal(t, "Sheet3", sanitizeSheetName("  ", 3))
+	assert.Equal(t, "A very long sheet name that is ", sanitizeSheetName("A very long sheet name that is truncated", 1))
+	assert.Equal(t, "Q3", sanitizeSheetName("'Q3'", 1))
+}
+
+func TestWorkbookWrite(t *testing.T) {
+	t.Parallel()
+
+	due := time.Date(2025, 9, 30, 0, 0, 0, 0, time.UTC)
+	var missing *time.Time
+
+	wb := &Workbook{}
+	sheet := wb.AddSheet("Tasks", "Ref", "Name", "Count", "Done", "Due", "Missing")
+	sheet.AddRow("ET-0001", "Access <review> & sign-off", 3, true, &due, missing)
+	sheet.AddRow("ET-0002", "Bell\x07 character", 1.5, false, nil, nil)
+	wb.AddSheet("Empty")
+
+	var buf bytes.Buffer
+	require.NoError(t, wb.Write(&buf))
+
+	workbook := readZipPart(t, buf.Bytes(), "xl/workbook.xml")
+	assert.Contains(t, workbook, `<sheet name="Tasks" sheetId="1" r:id="rId1"/>`)
+	assert.Contains(t, workbook, `<sheet name="Empty" sheetId="2" r:id="rId2"/>`)
+
+	types := readZipPart(t, buf.Bytes(), "[Content_Types].xml")
+	assert.Contains(t, types, "/xl/worksheets/sheet2.xml")
+
+	sheetXML := readZipPart(t, buf.Bytes(), "xl/worksheets/sheet1.xml")
+	require.NoError(t, xml.Unmarshal([]byte(sheetXML), new(interface{})), "sheet must be well-formed XML")
+	assert.Contains(t, sheetXML, `<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">Ref</t></is></c>`)
+	assert.Contains(t, sheetXML, "Access &lt;review&gt; &amp; sign-off")
+	assert.Contains(t, sheetXML, `<c r="C2"><v>3</v></c>`)
+	assert.Contains(t, sheetXML, `<c r="D2" t="b"><v>1</v></c>`)
+	assert.Contains(t, sheetXML, "2025-09-30 00:00")
+	assert.NotContains(t, sheetXML, `r="F2"`)
+	assert.Contains(t, sheetXML, "Bell character")
+	assert.Contains(t, sheetXML, `<c r="C3"><v>1.5</v></c>`)
+	assert.Contains(t, sheetXML, `<autoFilter ref="A1:F3"/>`)
+	assert.Contains(t, sheetXML, `state="frozen"`)
+
+	for _, part := range []string{"_rels/.rels", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet2.xml"} {
+		readZipPart(t, buf.Bytes(), part)
+	}
+}
+
+func TestWorkbookWrite_Errors(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	err := (&Workbook{}).Write(&buf)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no sheets")
+
+	wb := &Workbook{}
+	wb.AddSheet("Controls")
+	wb.AddSheet("controls")
+	err = wb.Write(&buf)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate sheet name")
+}
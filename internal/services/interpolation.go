@@ -0,0 +1,128 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"fmt"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/interpolation"
+	"github.com/grctool/grctool/internal/tools"
+)
+
+// NewInterpolator creates the interpolator of policy, control and evidence
+// task documents from the interpolation config: the global variables, the
+// per-framework and per-task scopes, and the environment variables env may
+// read. Unknown variables are left as written.
+func NewInterpolator(cfg *config.Config) *interpolation.StandardInterpolator {
+	return interpolation.NewStandardInterpolator(interpolation.InterpolatorConfig{
+		Variables:          cfg.Interpolation.GetFlatVariables(),
+		Enabled:            cfg.Interpolation.Enabled,
+		OnMissingVariable:  interpolation.MissingVariableIgnore,
+		FrameworkVariables: cfg.Interpolation.GetFrameworkVariables(),
+		TaskVariables:      cfg.Interpolation.GetTaskVariables(),
+		AllowedEnv:         cfg.Interpolation.Env,
+		ParseWindow:        tools.ParseEvidenceWindow,
+	})
+}
+
+// DocumentSource provides the synced documents CheckDocuments reads. It is
+// satisfied by *storage.Storage.
+type DocumentSource interface {
+	GetAllPolicies() ([]domain.Policy, error)
+	GetAllControls() ([]domain.Control, error)
+	GetAllEvidenceTasks() ([]domain.EvidenceTask, error)
+}
+
+// DocumentProblem is a {{...}} expression of a synced document that cannot
+// be filled in
+type DocumentProblem struct {
+	Kind     string `json:"kind"`     // policy, control or evidence_task
+	Document string `json:"document"` // Reference ID, else ID
+	Field    string `json:"field"`    // e.g. "description", "master_content.guidance"
+	interpolation.Problem
+}
+
+// documentField is a field of a synced document that is interpolated
+type documentField struct {
+	name, text string
+}
+
+// CheckDocuments reports the {{...}} expressions of the synced policies,
+// controls and evidence tasks that strict interpolation rejects, each checked
+// with the variables of its framework and task
+func CheckDocuments(interpolator *interpolation.StandardInterpolator, source DocumentSource) ([]DocumentProblem, error) {
+	var problems []DocumentProblem
+	check := func(kind, document string, scope interpolation.Scope, fields ...documentField) {
+		scoped := interpolator.WithScope(scope).(*interpolation.StandardInterpolator)
+		for _, field := range fields {
+			for _, problem := range scoped.Check(field.text) {
+				problems = append(problems, DocumentProblem{Kind: kind, Document: document, Field: field.name, Problem: problem})
+			}
+		}
+	}
+
+	policies, err := source.GetAllPolicies()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load policies: %w", err)
+	}
+	for _, policy := range policies {
+		check("policy", documentID(policy.ReferenceID, policy.ID), interpolation.Scope{},
+			documentField{"name", policy.Name},
+			documentField{"summary", policy.Summary},
+			documentField{"description", policy.Description},
+			documentField{"content", policy.Content})
+	}
+
+	controls, err := source.GetAllControls()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load controls: %w", err)
+	}
+	for _, control := range controls {
+		fields := []documentField{
+			{"name", control.Name},
+			{"description", control.Description},
+			{"risk", control.Risk},
+		}
+		if control.MasterContent != nil {
+			fields = append(fields,
+				documentField{"master_content.help", control.MasterContent.Help},
+				documentField{"master_content.guidance", control.MasterContent.Guidance})
+		}
+		check("control", documentID(control.ReferenceID, control.ID), interpolation.Scope{Framework: control.Framework}, fields...)
+	}
+
+	tasks, err := source.GetAllEvidenceTasks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load evidence tasks: %w", err)
+	}
+	for i := range tasks {
+		task := &tasks[i]
+		check("evidence_task", documentID(task.ReferenceID, task.ID), interpolation.Scope{Framework: task.GetFramework(), TaskRef: task.ReferenceID},
+			documentField{"name", task.Name},
+			documentField{"description", task.Description},
+			documentField{"guidance", task.Guidance})
+	}
+	return problems, nil
+}
+
+func documentID(referenceID, id string) string {
+	if referenceID != "" {
+		return referenceID
+	}
+	return id
+}
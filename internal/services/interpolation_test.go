@@ -0,0 +1,70 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// documentSource serves fixed synced documents
+type documentSource struct {
+	policies []domain.Policy
+	controls []domain.Control
+	tasks    []domain.EvidenceTask
+	err      error
+}
+
+func (s *documentSource) GetAllPolicies() ([]domain.Policy, error)            { return s.policies, s.err }
+func (s *documentSource) GetAllControls() ([]domain.Control, error)           { return s.controls, nil }
+func (s *documentSource) GetAllEvidenceTasks() ([]domain.EvidenceTask, error) { return s.tasks, nil }
+
+func TestCheckDocuments(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Interpolation: config.InterpolationConfig{
+		Enabled:    true,
+		Variables:  map[string]interface{}{"organization": map[string]interface{}{"name": "Seventh Sense"}},
+		Frameworks: map[string]map[string]interface{}{"soc2": {"audit": map[string]interface{}{"firm": "Prescient Assurance"}}},
+		Tasks:      map[string]map[string]interface{}{"et-0002": {"vendor": "Acme"}},
+	}}
+	source := &documentSource{
+		policies: []domain.Policy{
+			{ID: "1", ReferenceID: "POL-0001", Name: "{{organization.name}} Access Policy", Content: "<p>Owned by\n{{organisation.name}}</p>"},
+			{ID: "2", Description: "Audited by {{audit.firm}}"},
+		},
+		controls: []domain.Control{
+			{ID: "101", ReferenceID: "CC-01", Framework: "SOC 2", Description: "Audited by {{audit.firm}}",
+				MasterContent: &domain.ControlMasterContent{Guidance: "{{upper audit.frm}}"}},
+		},
+		tasks: []domain.EvidenceTask{
+			{ID: "201", ReferenceID: "ET-0001", Framework: "SOC2", Guidance: "Review {{vendor}}"},
+			{ID: "202", ReferenceID: "ET-0002", Framework: "SOC2", Guidance: "Review {{vendor}} for {{audit.firm}}"},
+		},
+	}
+
+	problems, err := CheckDocuments(NewInterpolator(cfg), source)
+	require.NoError(t, err)
+
+	type found struct{ kind, document, field, expression, suggestion string }
+	var got []found
+	for _, p := range problems {
+		got = append(got, found{p.Kind, p.Document, p.Field, p.Expression, p.Suggestion})
+	}
+	assert.Equal(t, []found{
+		{"policy", "POL-0001", "content", "{{organisation.name}}", "organization.name"},
+		{"policy", "2", "description", "{{audit.firm}}", ""},
+		{"control", "CC-01", "master_content.guidance", "{{upper audit.frm}}", "audit.firm"},
+		{"evidence_task", "ET-0001", "guidance", "{{vendor}}", ""},
+	}, got)
+	assert.Equal(t, 2, problems[0].Line)
+
+	_, err = CheckDocuments(NewInterpolator(cfg), &documentSource{err: errors.New("unreadable")})
+	assert.ErrorContains(t, err, "failed to load policies: unreadable")
+}
@@ -0,0 +1,124 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matching
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+)
+
+var unsafeNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// CachedEmbedder keeps the vectors of another embedder on disk, one file per
+// provider and model keyed by a hash of each text, so unchanged tasks,
+// policies and evidence are embedded once
+type CachedEmbedder struct {
+	embedder Embedder
+	path     string
+
+	mu      sync.Mutex
+	vectors map[string][]float32
+}
+
+// NewCachedEmbedder caches the vectors of embedder under dir
+func NewCachedEmbedder(embedder Embedder, dir string) *CachedEmbedder {
+	name := unsafeNameChars.ReplaceAllString(embedder.Name(), "_")
+	return &CachedEmbedder{
+		embedder: embedder,
+		path:     filepath.Join(dir, name+".json"),
+	}
+}
+
+// Name returns the cached embedder's name
+func (c *CachedEmbedder) Name() string {
+	return c.embedder.Name()
+}
+
+// Path returns the cache file
+func (c *CachedEmbedder) Path() string {
+	return c.path
+}
+
+// Embed returns cached vectors and embeds only the texts not seen before.
+// The cache is best effort: a cache that cannot be read or written is
+// rebuilt or skipped.
+func (c *CachedEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.load()
+
+	vectors := make([][]float32, len(texts))
+	var missing []string
+	var missingAt []int
+	for i, text := range texts {
+		if vector, ok := c.vectors[cacheKey(text)]; ok {
+			vectors[i] = vector
+			continue
+		}
+		missing = append(missing, text)
+		missingAt = append(missingAt, i)
+	}
+	if len(missing) == 0 {
+		return vectors, nil
+	}
+
+	embedded, err := c.embedder.Embed(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	for j, vector := range embedded {
+		vectors[missingAt[j]] = vector
+		c.vectors[cacheKey(missing[j])] = vector
+	}
+	c.save()
+	return vectors, nil
+}
+
+func (c *CachedEmbedder) load() {
+	if c.vectors != nil {
+		return
+	}
+	c.vectors = make(map[string][]float32)
+	if data, err := os.ReadFile(c.path); err == nil {
+		_ = json.Unmarshal(data, &c.vectors)
+	}
+}
+
+func (c *CachedEmbedder) save() {
+	data, err := json.Marshal(c.vectors)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return
+	}
+	_ = os.Rename(tmp, c.path)
+}
+
+func cacheKey(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
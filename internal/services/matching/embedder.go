@@ -0,0 +1,68 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package matching ranks tools, policies, controls and prior evidence by
+// their relevance to an evidence task, comparing text embeddings
+package matching
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grctool/grctool/internal/config"
+)
+
+// Embedding providers
+const (
+	ProviderLocal  = "local"  // Offline hashed bag of words
+	ProviderOpenAI = "openai" // OpenAI-compatible /embeddings API
+)
+
+// defaultMinScores is the least similarity counted as related, per provider.
+// Model embeddings score unrelated text well above zero, hashed words do not.
+var defaultMinScores = map[string]float64{
+	ProviderLocal:  0.1,
+	ProviderOpenAI: 0.35,
+}
+
+// Embedder turns texts into vectors whose cosine similarity reflects how
+// related the texts are
+type Embedder interface {
+	// Name identifies the provider and model; cached vectors are kept per name
+	Name() string
+
+	// Embed returns one vector per text, in order
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// NewEmbedder creates the configured embedder. Vectors from remote
+// providers are cached under cacheDir; an empty cacheDir disables the cache.
+// It returns nil when no provider is configured.
+func NewEmbedder(cfg config.MatchingConfig, cacheDir string) (Embedder, error) {
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case ProviderLocal:
+		return NewLocalEmbedder(), nil
+	case ProviderOpenAI:
+		embedder := NewOpenAIEmbedder(cfg, nil)
+		if cacheDir == "" {
+			return embedder, nil
+		}
+		return NewCachedEmbedder(embedder, cacheDir), nil
+	default:
+		return nil, fmt.Errorf("unknown matching provider %q: use %s or %s", cfg.Provider, ProviderLocal, ProviderOpenAI)
+	}
+}
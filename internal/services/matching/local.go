@@ -0,0 +1,151 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matching
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// localDimensions is the size of local embeddings
+const localDimensions = 4096
+
+// stopWords carry no meaning for matching
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true,
+	"for": true, "from": true, "has": true, "have": true, "in": true, "is": true, "it": true, "its": true,
+	"of": true, "on": true, "or": true, "that": true, "the": true, "this": true, "to": true, "was": true,
+	"were": true, "will": true, "with": true, "all": true, "any": true, "each": true, "must": true,
+	"should": true, "shall": true, "such": true, "their": true, "these": true, "those": true, "which": true,
+}
+
+// conceptWords maps compliance vocabulary onto a shared concept, so texts
+// naming the same thing differently still overlap
+var conceptWords = map[string]string{
+	"mfa": "authentication", "2fa": "authentication", "sso": "authentication", "saml": "authentication",
+	"login": "authentication", "password": "authentication", "authentication": "authentication",
+	"repo": "repository", "repository": "repository", "github": "repository", "git": "repository",
+	"ci": "pipeline", "cd": "pipeline", "workflow": "pipeline", "pipeline": "pipeline", "deployment": "pipeline", "actions": "pipeline",
+	"terraform": "infrastructure", "iac": "infrastructure", "atmos": "infrastructure", "infrastructure": "infrastructure",
+	"aws": "cloud", "gcp": "cloud", "azure": "cloud", "cloud": "cloud",
+	"kms": "encryption", "tls": "encryption", "encrypted": "encryption", "encryption": "encryption",
+	"permissions": "access", "roles": "access", "privileged": "access", "provisioning": "access", "access": "access",
+	"review": "approval", "approved": "approval", "approval": "approval",
+	"drive": "document", "docs": "document", "sheets": "document", "forms": "document", "document": "document",
+}
+
+// concepts maps stemmed terms to their concept
+var concepts = func() map[string]string {
+	stemmed := make(map[string]string, len(conceptWords))
+	for word, concept := range conceptWords {
+		stemmed[stem(word)] = concept
+	}
+	return stemmed
+}()
+
+// LocalEmbedder embeds text offline as a hashed bag of stemmed words and
+// word pairs. It matches vocabulary rather than meaning, helped by a small
+// table of compliance synonyms; use a model provider for true semantics.
+type LocalEmbedder struct{}
+
+// NewLocalEmbedder creates a local embedder
+func NewLocalEmbedder() *LocalEmbedder {
+	return &LocalEmbedder{}
+}
+
+// Name returns the embedder name
+func (e *LocalEmbedder) Name() string {
+	return ProviderLocal
+}
+
+// Embed returns a normalized vector per text
+func (e *LocalEmbedder) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = localVector(text)
+	}
+	return vectors, nil
+}
+
+func localVector(text string) []float32 {
+	counts := make(map[string]float64)
+	terms := localTerms(text)
+	for i, term := range terms {
+		counts[term]++
+		if concept, ok := concepts[term]; ok {
+			counts["~"+concept]++
+		}
+		if i > 0 {
+			counts[terms[i-1]+" "+term] += 0.5
+		}
+	}
+
+	vector := make([]float32, localDimensions)
+	for feature, count := range counts {
+		h := fnv.New64a()
+		h.Write([]byte(feature))
+		sum := mix(h.Sum64())
+		weight := float32(math.Sqrt(count))
+		if sum&(1<<63) != 0 {
+			weight = -weight
+		}
+		vector[sum%localDimensions] += weight
+	}
+	return normalize(vector)
+}
+
+// mix spreads hash bits (splitmix64 finalizer), as FNV's low bits alone
+// collide for similar short words
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	return x ^ x>>31
+}
+
+// localTerms splits text into lower-case stemmed words, without stop words
+func localTerms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		if len(word) < 2 || stopWords[word] {
+			continue
+		}
+		terms = append(terms, stem(word))
+	}
+	return terms
+}
+
+// stem strips common English suffixes so word forms match
+func stem(word string) string {
+	for _, suffix := range []string{"ies", "ied"} {
+		if strings.HasSuffix(word, suffix) && len(word)-len(suffix) >= 3 {
+			return strings.TrimSuffix(word, suffix) + "y"
+		}
+	}
+	for _, suffix := range []string{"ations", "ation", "ments", "ment", "ings", "ing", "ed", "es", "ion", "s", "e"} {
+		if strings.HasSuffix(word, suffix) && len(word)-len(suffix) >= 3 {
+			return strings.TrimSuffix(word, suffix)
+		}
+	}
+	return word
+}
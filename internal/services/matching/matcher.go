@@ -0,0 +1,146 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matching
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/grctool/grctool/internal/config"
+)
+
+// Kinds of candidates
+const (
+	KindTool     = "tool"
+	KindPolicy   = "policy"
+	KindControl  = "control"
+	KindEvidence = "evidence"
+)
+
+// Candidate is something a task may be matched to
+type Candidate struct {
+	Kind  string `json:"kind" yaml:"kind"`
+	Ref   string `json:"ref" yaml:"ref"`     // Tool name, policy or control reference, or evidence task reference
+	Title string `json:"title" yaml:"title"` // Name shown to the reader; the <window>/<file> of evidence
+	Text  string `json:"-" yaml:"-"`         // What is compared with the task
+}
+
+// Match is a candidate with its similarity to the task
+type Match struct {
+	Candidate `yaml:",inline"`
+	Score     float64 `json:"score" yaml:"score"` // Cosine similarity, higher is more relevant
+}
+
+// Matcher ranks candidates by similarity to a query
+type Matcher struct {
+	embedder Embedder
+	minScore float64
+}
+
+// NewMatcher creates a matcher counting candidates scoring at least
+// minScore as related
+func NewMatcher(embedder Embedder, minScore float64) *Matcher {
+	return &Matcher{embedder: embedder, minScore: minScore}
+}
+
+// New creates the configured matcher, caching vectors under cacheDir. It
+// returns nil when no provider is configured.
+func New(cfg config.MatchingConfig, cacheDir string) (*Matcher, error) {
+	embedder, err := NewEmbedder(cfg, cacheDir)
+	if err != nil || embedder == nil {
+		return nil, err
+	}
+	minScore := cfg.MinScore
+	if minScore <= 0 {
+		minScore = defaultMinScores[cfg.Provider]
+	}
+	return NewMatcher(embedder, minScore), nil
+}
+
+// Name identifies the matcher's embedder
+func (m *Matcher) Name() string {
+	return m.embedder.Name()
+}
+
+// Rank returns the related candidates, most similar to query first; a
+// positive limit keeps only that many
+func (m *Matcher) Rank(ctx context.Context, query string, candidates []Candidate, limit int) ([]Match, error) {
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	texts := make([]string, 0, len(candidates)+1)
+	texts = append(texts, query)
+	for _, candidate := range candidates {
+		texts = append(texts, candidate.Text)
+	}
+	vectors, err := m.embedder.Embed(ctx, texts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed with %s: %w", m.embedder.Name(), err)
+	}
+	if len(vectors) != len(texts) {
+		return nil, fmt.Errorf("%s returned %d embeddings for %d texts", m.embedder.Name(), len(vectors), len(texts))
+	}
+
+	var matches []Match
+	for i, candidate := range candidates {
+		score := cosine(vectors[0], vectors[i+1])
+		if score >= m.minScore {
+			matches = append(matches, Match{Candidate: candidate, Score: math.Round(score*1000) / 1000})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+// cosine is the cosine similarity of two vectors
+func cosine(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
+
+// normalize scales a vector to unit length
+func normalize(vector []float32) []float32 {
+	var sum float64
+	for _, v := range vector {
+		sum += float64(v) * float64(v)
+	}
+	if sum == 0 {
+		return vector
+	}
+	norm := float32(math.Sqrt(sum))
+	for i := range vector {
+		vector[i] /= norm
+	}
+	return vector
+}
@@ -0,0 +1,165 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package matching
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingEmbedder records how many texts it was asked to embed
+type countingEmbedder struct {
+	LocalEmbedder
+	embedded int
+}
+
+func (e *countingEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	e.embedded += len(texts)
+	return e.LocalEmbedder.Embed(ctx, texts)
+}
+
+func TestStem(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		word string
+		want string
+	}{
+		"plural":      {word: "policies", want: "policy"},
+		"singular":    {word: "policy", want: "policy"},
+		"ation":       {word: "authentication", want: "authentic"},
+		"ed":          {word: "encrypted", want: "encrypt"},
+		"ion":         {word: "encryption", want: "encrypt"},
+		"short words": {word: "aws", want: "aws"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, stem(tt.word))
+		})
+	}
+}
+
+func TestMatcher_RankLocal(t *testing.T) {
+	t.Parallel()
+	matcher := NewMatcher(NewLocalEmbedder(), defaultMinScores[ProviderLocal])
+
+	candidates := []Candidate{
+		{Kind: KindTool, Ref: "google-workspace", Text: "Reads Google Drive documents, sheets and forms"},
+		{Kind: KindTool, Ref: "github-permissions", Text: "Lists repository collaborators, teams and their permissions"},
+		{Kind: KindTool, Ref: "terraform-security-analyzer", Text: "Analyzes Terraform for encryption and network security settings"},
+	}
+	matches, err := matcher.Rank(context.Background(), "Quarterly review of who has access to source code repositories", candidates, 0)
+	require.NoError(t, err)
+	require.NotEmpty(t, matches)
+	assert.Equal(t, "github-permissions", matches[0].Ref)
+	for _, match := range matches {
+		assert.NotEqual(t, "google-workspace", match.Ref, "unrelated tools score below the minimum")
+	}
+
+	// Synonyms relate MFA to authentication
+	matches, err = matcher.Rank(context.Background(), "Evidence that MFA is enforced", []Candidate{
+		{Kind: KindControl, Ref: "CC6.1", Text: "Multi-factor authentication is required for production access"},
+		{Kind: KindControl, Ref: "CC8.1", Text: "Changes are tested before release"},
+	}, 1)
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "CC6.1", matches[0].Ref)
+
+	none, err := matcher.Rank(context.Background(), "anything", nil, 0)
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func TestCachedEmbedder(t *testing.T) {
+	t.Parallel()
+	inner := &countingEmbedder{}
+	dir := t.TempDir()
+
+	cached := NewCachedEmbedder(inner, dir)
+	first, err := cached.Embed(context.Background(), []string{"access review", "encryption"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, inner.embedded)
+	assert.FileExists(t, cached.Path())
+
+	// A new embedder over the same directory reuses the stored vectors
+	reopened := NewCachedEmbedder(inner, dir)
+	second, err := reopened.Embed(context.Background(), []string{"encryption", "access review", "backups"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, inner.embedded, "only the new text is embedded")
+	assert.Equal(t, first[0], second[1])
+	assert.Equal(t, first[1], second[0])
+
+	// A corrupt cache is rebuilt
+	require.NoError(t, os.WriteFile(cached.Path(), []byte("not json"), 0644))
+	_, err = NewCachedEmbedder(inner, dir).Embed(context.Background(), []string{"backups"})
+	require.NoError(t, err)
+	assert.Equal(t, 4, inner.embedded)
+}
+
+func TestOpenAIEmbedder(t *testing.T) {
+	t.Parallel()
+
+	var got embeddingRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/embeddings", r.URL.Path)
+		assert.Equal(t, "Bearer sk-test", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		// Out of order, as the API allows
+		_, _ = w.Write([]byte(`{"data":[{"index":1,"embedding":[0,2]},{"index":0,"embedding":[3,4]}]}`))
+	}))
+	defer server.Close()
+
+	embedder := NewOpenAIEmbedder(config.MatchingConfig{BaseURL: server.URL + "/v1/", APIKey: "sk-test"}, server.Client())
+	assert.Equal(t, "openai/text-embedding-3-small", embedder.Name())
+
+	vectors, err := embedder.Embed(context.Background(), []string{"first", ""})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", " "}, got.Input)
+	assert.Equal(t, [][]float32{{0.6, 0.8}, {0, 1}}, vectors)
+}
+
+func TestOpenAIEmbedder_Error(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid api key", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	embedder := NewOpenAIEmbedder(config.MatchingConfig{BaseURL: server.URL, Model: "nomic-embed-text"}, server.Client())
+	_, err := NewMatcher(embedder, 0).Rank(context.Background(), "query", []Candidate{{Ref: "x", Text: "x"}}, 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "openai/nomic-embed-text")
+	assert.Contains(t, err.Error(), "401 Unauthorized: invalid api key")
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	matcher, err := New(config.MatchingConfig{}, t.TempDir())
+	require.NoError(t, err)
+	assert.Nil(t, matcher, "matching is off without a provider")
+
+	matcher, err = New(config.MatchingConfig{Provider: ProviderLocal}, "")
+	require.NoError(t, err)
+	assert.Equal(t, "local", matcher.Name())
+	assert.Equal(t, defaultMinScores[ProviderLocal], matcher.minScore)
+
+	matcher, err = New(config.MatchingConfig{Provider: ProviderOpenAI, MinScore: 0.5}, t.TempDir())
+	require.NoError(t, err)
+	assert.IsType(t, &CachedEmbedder{}, matcher.embedder)
+	assert.Equal(t, 0.5, matcher.minScore)
+
+	_, err = New(config.MatchingConfig{Provider: "bert"}, "")
+	assert.EqualError(t, err, `unknown matching provider "bert": use local or openai`)
+}
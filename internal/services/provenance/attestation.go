@@ -0,0 +1,289 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provenance
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/signing"
+)
+
+// in-toto and SLSA identifiers of evidence attestations
+const (
+	StatementType      = "https://in-toto.io/Statement/v1"
+	SLSAProvenanceType = "https://slsa.dev/provenance/v1"
+	PayloadType        = "application/vnd.in-toto+json"
+
+	// EvidenceBuildType identifies evidence files written by grctool's
+	// evidence writer, whose external parameters are the writer's inputs
+	EvidenceBuildType = "https://github.com/grctool/grctool/evidence-writer/v1"
+
+	// SynthesisBuildType identifies evidence drafted by a language model
+	// with 'evidence generate --synthesize', whose external parameters name
+	// the model and the tool outputs it was given
+	SynthesisBuildType = "https://github.com/grctool/grctool/evidence-synthesis/v1"
+
+	// BuilderID identifies grctool as the builder of evidence files
+	BuilderID = "https://github.com/grctool/grctool"
+)
+
+// attestationsDir holds the attestations of a window's evidence files,
+// moving with the generation metadata when the window is submitted
+var attestationsDir = filepath.Join(".generation", "attestations")
+
+// builderVersion is the grctool version recorded as the builder's
+var builderVersion = "dev"
+
+// SetBuilderVersion sets the grctool version recorded in attestations
+func SetBuilderVersion(version string) {
+	builderVersion = version
+}
+
+// Statement is an in-toto v1 statement of SLSA provenance for evidence files
+type Statement struct {
+	Type          string         `json:"_type"`
+	Subject       []Subject      `json:"subject"`
+	PredicateType string         `json:"predicateType"`
+	Predicate     SLSAProvenance `json:"predicate"`
+}
+
+// Subject is a file the statement is about, identified by its digests
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// SLSAProvenance is a SLSA v1 provenance predicate
+type SLSAProvenance struct {
+	BuildDefinition BuildDefinition `json:"buildDefinition"`
+	RunDetails      RunDetails      `json:"runDetails"`
+}
+
+// BuildDefinition records what produced the evidence: the writer's inputs
+// and the sources it drew on
+type BuildDefinition struct {
+	BuildType            string                 `json:"buildType"`
+	ExternalParameters   map[string]interface{} `json:"externalParameters"`
+	InternalParameters   map[string]interface{} `json:"internalParameters,omitempty"`
+	ResolvedDependencies []ResourceDescriptor   `json:"resolvedDependencies,omitempty"`
+}
+
+// ResourceDescriptor identifies a source of the evidence, such as a git
+// commit or a document
+type ResourceDescriptor struct {
+	URI         string                 `json:"uri,omitempty"`
+	Name        string                 `json:"name,omitempty"`
+	Digest      map[string]string      `json:"digest,omitempty"`
+	Annotations map[string]interface{} `json:"annotations,omitempty"`
+}
+
+// RunDetails records who produced the evidence, and when
+type RunDetails struct {
+	Builder  Builder       `json:"builder"`
+	Metadata BuildMetadata `json:"metadata"`
+}
+
+// Builder identifies grctool and its version
+type Builder struct {
+	ID      string            `json:"id"`
+	Version map[string]string `json:"version,omitempty"`
+}
+
+// BuildMetadata holds the timestamps of the evidence writer run
+type BuildMetadata struct {
+	InvocationID string     `json:"invocationId,omitempty"`
+	StartedOn    *time.Time `json:"startedOn,omitempty"`
+	FinishedOn   *time.Time `json:"finishedOn,omitempty"`
+}
+
+// NewStatement returns a provenance statement for one evidence file
+// identified by its SHA-256 digest
+func NewStatement(name, sha256Hex string, definition BuildDefinition, startedOn, finishedOn time.Time) *Statement {
+	started, finished := startedOn.UTC(), finishedOn.UTC()
+	return &Statement{
+		Type:          StatementType,
+		Subject:       []Subject{{Name: name, Digest: map[string]string{"sha256": sha256Hex}}},
+		PredicateType: SLSAProvenanceType,
+		Predicate: SLSAProvenance{
+			BuildDefinition: definition,
+			RunDetails: RunDetails{
+				Builder:  Builder{ID: BuilderID, Version: map[string]string{"grctool": builderVersion}},
+				Metadata: BuildMetadata{StartedOn: &started, FinishedOn: &finished},
+			},
+		},
+	}
+}
+
+// Envelope is a DSSE envelope carrying a statement and its signatures
+type Envelope struct {
+	PayloadType string              `json:"payloadType"`
+	Payload     string              `json:"payload"` // Base64 of the statement JSON
+	Signatures  []EnvelopeSignature `json:"signatures"`
+}
+
+// EnvelopeSignature is a base64 signature of an envelope's PAE encoding
+type EnvelopeSignature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// PAE returns the DSSE pre-authentication encoding that is signed
+func PAE(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+// Seal wraps a statement in a DSSE envelope, signed by signer unless it is
+// nil
+func Seal(ctx context.Context, statement *Statement, signer signing.Signer) (*Envelope, error) {
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return nil, err
+	}
+	envelope := &Envelope{
+		PayloadType: PayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  []EnvelopeSignature{},
+	}
+	if signer != nil {
+		signature, err := signer.Sign(ctx, PAE(PayloadType, payload))
+		if err != nil {
+			return nil, fmt.Errorf("signing attestation: %w", err)
+		}
+		envelope.Signatures = append(envelope.Signatures, EnvelopeSignature{KeyID: signer.Method(), Sig: base64.StdEncoding.EncodeToString(signature)})
+	}
+	return envelope, nil
+}
+
+// Statement decodes the envelope's statement
+func (e *Envelope) Statement() (*Statement, error) {
+	payload, err := base64.StdEncoding.DecodeString(e.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("attestation payload is not base64: %w", err)
+	}
+	var statement Statement
+	if err := json.Unmarshal(payload, &statement); err != nil {
+		return nil, fmt.Errorf("attestation payload is not an in-toto statement: %w", err)
+	}
+	return &statement, nil
+}
+
+// Verify checks that the envelope carries a good signature from signer
+func (e *Envelope) Verify(ctx context.Context, signer signing.Signer) error {
+	payload, err := base64.StdEncoding.DecodeString(e.Payload)
+	if err != nil {
+		return fmt.Errorf("attestation payload is not base64: %w", err)
+	}
+	if len(e.Signatures) == 0 {
+		return signing.ErrUnsigned
+	}
+	for _, signature := range e.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(signature.Sig)
+		if err != nil {
+			continue
+		}
+		if err = signer.Verify(ctx, PAE(e.PayloadType, payload), sig); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("no attestation signature verifies with %s", signer.Method())
+}
+
+// SourceDependencies describes the sources of an evidence file: each local
+// file or remote document with when it last changed, the commit of a local
+// source in a git repository, and the commits named by *_git_hash tool
+// parameters such as terraform_git_hash
+func (s *Service) SourceDependencies(sources []models.SourceTimestamp, toolParameters map[string]interface{}) []ResourceDescriptor {
+	var dependencies []ResourceDescriptor
+	for _, source := range sources {
+		annotations := map[string]interface{}{"modified_at": source.ModifiedAt.UTC().Format(time.RFC3339)}
+		switch source.Type {
+		case models.SourceTypeDocument:
+			dependencies = append(dependencies, ResourceDescriptor{URI: source.Location, Annotations: annotations})
+		case models.SourceTypeFile:
+			dependency := ResourceDescriptor{Name: source.Location, Annotations: annotations}
+			if matches, _ := filepath.Glob(source.Location); len(matches) > 0 {
+				if prov, err := s.CaptureForSourceFile(matches[0]); err == nil && prov.GitCommitSHA != "" {
+					dependency.Digest = map[string]string{"gitCommit": prov.GitCommitSHA}
+					if prov.GitRemoteURL != "" {
+						dependency.URI = "git+" + prov.GitRemoteURL
+						if prov.GitBranch != "" {
+							dependency.URI += "@refs/heads/" + prov.GitBranch
+						}
+					}
+				}
+			}
+			dependencies = append(dependencies, dependency)
+		}
+	}
+
+	names := make([]string, 0, len(toolParameters))
+	for name := range toolParameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if hash, ok := toolParameters[name].(string); ok && hash != "" && strings.HasSuffix(name, "_git_hash") {
+			dependencies = append(dependencies, ResourceDescriptor{Name: name, Digest: map[string]string{"gitCommit": hash}})
+		}
+	}
+	return dependencies
+}
+
+// AttestationPath returns where the attestation of an evidence file in a
+// window is kept: .generation/attestations/<file>.intoto.jsonl
+func AttestationPath(windowDir, filename string) string {
+	return filepath.Join(windowDir, attestationsDir, filename+".intoto.jsonl")
+}
+
+// WriteAttestation saves the attestation of an evidence file in a window as
+// a line of JSON, replacing any earlier one
+func WriteAttestation(windowDir, filename string, envelope *Envelope) error {
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+	path := AttestationPath(windowDir, filename)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// LoadAttestation reads the attestation of an evidence file in a window
+func LoadAttestation(windowDir, filename string) (*Envelope, error) {
+	data, err := os.ReadFile(AttestationPath(windowDir, filename))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("no attestation for %s", filename)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var envelope Envelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("attestation of %s is not a DSSE envelope: %w", filename, err)
+	}
+	return &envelope, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package provenance
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/signing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// checksumSigner signs with the SHA-256 of the data
+type checksumSigner struct{}
+
+func (checksumSigner) Method() string { return "checksum" }
+
+func (checksumSigner) Sign(_ context.Context, data []byte) ([]byte, error) {
+	sum := sha256.Sum256(data)
+	return sum[:], nil
+}
+
+func (checksumSigner) Verify(_ context.Context, data, signature []byte) error {
+	if sum := sha256.Sum256(data); !bytes.Equal(sum[:], signature) {
+		return errors.New("bad signature")
+	}
+	return nil
+}
+
+func TestPAE(t *testing.T) {
+	t.Parallel()
+
+	// Example from the DSSE protocol specification
+	assert.Equal(t, "DSSEv1 29 http://example.com/HelloWorld 11 hello world", string(PAE("http://example.com/HelloWorld", []byte("hello world"))))
+}
+
+func TestSeal(t *testing.T) {
+	t.Parallel()
+
+	started := time.Date(2025, 11, 1, 9, 0, 0, 0, time.UTC)
+	definition := BuildDefinition{
+		BuildType:          EvidenceBuildType,
+		ExternalParameters: map[string]interface{}{"task_ref": "ET-0001"},
+	}
+	statement := NewStatement("01_iam_roles.md", "9a03", definition, started, started.Add(time.Second))
+
+	tests := map[string]struct {
+		signer signing.Signer
+		tamper bool
+		err    string
+	}{
+		"signed":   {signer: checksumSigner{}},
+		"unsigned": {err: signing.ErrUnsigned.Error()},
+		"tampered": {signer: checksumSigner{}, tamper: true, err: "no attestation signature verifies with checksum"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			envelope, err := Seal(context.Background(), statement, tc.signer)
+			require.NoError(t, err)
+			assert.Equal(t, PayloadType, envelope.PayloadType)
+			if tc.tamper {
+				tampered := *statement
+				tampered.Subject = []Subject{{Name: "01_iam_roles.md", Digest: map[string]string{"sha256": "ffff"}}}
+				resealed, err := Seal(context.Background(), &tampered, nil)
+				require.NoError(t, err)
+				envelope.Payload = resealed.Payload
+			}
+
+			decoded, err := envelope.Statement()
+			require.NoError(t, err)
+			assert.Equal(t, StatementType, decoded.Type)
+			assert.Equal(t, SLSAProvenanceType, decoded.PredicateType)
+			assert.Equal(t, "ET-0001", decoded.Predicate.BuildDefinition.ExternalParameters["task_ref"])
+			assert.Equal(t, BuilderID, decoded.Predicate.RunDetails.Builder.ID)
+
+			err = envelope.Verify(context.Background(), checksumSigner{})
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestWriteAttestation(t *testing.T) {
+	t.Parallel()
+
+	windowDir := t.TempDir()
+	_, err := LoadAttestation(windowDir, "01_iam_roles.md")
+	assert.EqualError(t, err, "no attestation for 01_iam_roles.md")
+
+	statement := NewStatement("01_iam_roles.md", "9a03", BuildDefinition{BuildType: EvidenceBuildType}, time.Now(), time.Now())
+	envelope, err := Seal(context.Background(), statement, checksumSigner{})
+	require.NoError(t, err)
+	require.NoError(t, WriteAttestation(windowDir, "01_iam_roles.md", envelope))
+
+	loaded, err := LoadAttestation(windowDir, "01_iam_roles.md")
+	require.NoError(t, err)
+	assert.Equal(t, envelope, loaded)
+	assert.NoError(t, loaded.Verify(context.Background(), checksumSigner{}))
+}
+
+func TestSourceDependencies(t *testing.T) {
+	t.Parallel()
+
+	modified := time.Date(2025, 10, 20, 12, 0, 0, 0, time.UTC)
+	sources := []models.SourceTimestamp{
+		{Type: models.SourceTypeTool, Location: "terraform", ModifiedAt: modified},
+		{Type: models.SourceTypeDocument, Location: "https://docs.google.com/document/d/abc", ModifiedAt: modified},
+		{Type: models.SourceTypeFile, Location: "/nonexistent/*.tf", ModifiedAt: modified},
+	}
+	parameters := map[string]interface{}{
+		"terraform_git_hash": "3f2c1e9",
+		"github_git_hash":    "",
+		"pattern":            "*.tf",
+	}
+
+	annotations := map[string]interface{}{"modified_at": "2025-10-20T12:00:00Z"}
+	assert.Equal(t, []ResourceDescriptor{
+		{URI: "https://docs.google.com/document/d/abc", Annotations: annotations},
+		{Name: "/nonexistent/*.tf", Annotations: annotations},
+		{Name: "terraform_git_hash", Digest: map[string]string{"gitCommit": "3f2c1e9"}},
+	}, NewService("test").SourceDependencies(sources, parameters))
+}
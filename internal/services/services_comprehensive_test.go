@@ -306,6 +306,18 @@ func TestMatchesEvidenceFilter(t *testing.T) {
 			filter:   domain.EvidenceFilter{Framework: "SOC2"},
 			expected: false,
 		},
+		{
+			name:     "assignee match",
+			task:     domain.EvidenceTask{Assignees: []domain.Person{{Name: "Jane Doe", Email: "jane@example.com"}}},
+			filter:   domain.EvidenceFilter{AssignedTo: "JANE@example.com"},
+			expected: true,
+		},
+		{
+			name:     "assignee no match",
+			task:     domain.EvidenceTask{Assignees: []domain.Person{{Name: "Jane Doe", Email: "jane@example.com"}}},
+			filter:   domain.EvidenceFilter{AssignedTo: "raj@example.com"},
+			expected: false,
+		},
 		{
 			name:     "due before - within range",
 			task:     domain.EvidenceTask{NextDue: &past},
@@ -1171,6 +1183,150 @@ func TestEvidenceEvaluator_EvaluateWindow(t *testing.T) {
 	assert.Equal(t, 3, result.FileCount)
 }
 
+func TestEvidenceEvaluator_EvaluateWindow_Rubric(t *testing.T) {
+	t.Parallel()
+
+	files := []models.FileState{
+		{Filename: "github_permissions.csv", SizeBytes: 4000},
+		{Filename: "access_controls.json", SizeBytes: 3000},
+		{Filename: "evidence_summary.md", SizeBytes: 3000},
+	}
+	tests := map[string]struct {
+		rubrics       config.EvaluationConfig
+		wantRubric    string
+		wantThreshold float64
+		wantStatus    models.EvaluationStatus
+		wantChecks    map[string]string
+		wantMissing   []string
+	}{
+		"default scoring": {
+			wantThreshold: 70,
+		},
+		"category rubric": {
+			rubrics: config.EvaluationConfig{Rubrics: []config.EvaluationRubric{
+				{Name: "strict", PassThreshold: 99},
+				{Name: "access", Categories: []string{"personnel"}, PassThreshold: 60},
+			}},
+			wantRubric:    "access",
+			wantThreshold: 60,
+		},
+		"required check met": {
+			rubrics: config.EvaluationConfig{Rubrics: []config.EvaluationRubric{{
+				Name:           "access-review",
+				Tasks:          []string{"ET-0001"},
+				RequiredChecks: []config.RubricCheck{{Name: "permissions-export", Files: []string{"*permissions*.csv"}, MinFiles: 1, Severity: "critical"}},
+			}}},
+			wantRubric:    "access-review",
+			wantThreshold: 70,
+			wantChecks:    map[string]string{"permissions-export": "pass"},
+		},
+		"required check missing fails": {
+			rubrics: config.EvaluationConfig{Rubrics: []config.EvaluationRubric{{
+				Name:           "access-review",
+				Tasks:          []string{"et-0001"},
+				RequiredChecks: []config.RubricCheck{{Name: "signoff", Description: "Manager sign-off", Files: []string{"*signoff*.pdf"}, Severity: "critical"}},
+			}}},
+			wantRubric:    "access-review",
+			wantThreshold: 70,
+			wantStatus:    models.EvaluationFail,
+			wantChecks:    map[string]string{"signoff": "fail"},
+			wantMissing:   []string{"Manager sign-off"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			store := newTestStorage(t, dir)
+			require.NoError(t, store.SaveEvidenceTask(&domain.EvidenceTask{
+				ID:          "1",
+				ReferenceID: "ET-0001",
+				Name:        "GitHub Access Controls",
+				Category:    "Personnel",
+			}))
+			now := time.Now()
+			scanner := &stubEvidenceScanner{scanWindowState: &models.WindowState{
+				Window: "2025-Q1", FileCount: len(files), TotalBytes: 10000, NewestFile: &now, Files: files,
+			}}
+
+			evaluator := NewEvidenceEvaluatorService(filepath.Join(dir, "evidence"), store, scanner, newTestLogger(t))
+			evaluator.SetRubrics(tc.rubrics)
+			result, err := evaluator.EvaluateWindow(context.Background(), "ET-0001", "2025-Q1")
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.wantRubric, result.Rubric)
+			assert.Equal(t, tc.wantThreshold, result.PassThreshold)
+			if tc.wantStatus != "" {
+				assert.Equal(t, tc.wantStatus, result.OverallStatus)
+			}
+			checks := map[string]string{}
+			for _, check := range result.RequiredChecks {
+				checks[check.Name] = check.Status
+			}
+			if tc.wantChecks == nil {
+				assert.Empty(t, checks)
+			} else {
+				assert.Equal(t, tc.wantChecks, checks)
+			}
+			if tc.wantMissing == nil {
+				assert.Empty(t, result.MissingRequirements)
+			} else {
+				assert.Equal(t, tc.wantMissing, result.MissingRequirements)
+			}
+		})
+	}
+
+	t.Run("dimension weights and thresholds", func(t *testing.T) {
+		t.Parallel()
+		result := models.NewEvaluationResult("ET-0001", "1", "2025-Q1", "all")
+		applyRubric(&config.EvaluationRubric{
+			Name: "infra",
+			Dimensions: map[string]config.RubricDimension{
+				"completeness": {Weight: 60, Pass: 90, Warn: 70},
+				"quality":      {Weight: 40},
+			},
+		}, result)
+
+		assert.InDelta(t, 0.6, result.Completeness.Weight, 0.0001)
+		assert.Equal(t, 90.0, result.Completeness.PassScore)
+		assert.Equal(t, 70.0, result.Completeness.WarnScore)
+		assert.InDelta(t, 0.4, result.QualityScore.Weight, 0.0001)
+		assert.Equal(t, 80.0, result.QualityScore.PassScore)
+		assert.False(t, result.RequirementsMatch.Scored())
+		assert.False(t, result.ControlAlignment.Scored())
+
+		result.Completeness.Score = 85
+		result.Completeness.GradeStatus()
+		assert.Equal(t, "warning", result.Completeness.Status)
+	})
+
+	t.Run("unscored dimensions are skipped", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		store := newTestStorage(t, dir)
+		require.NoError(t, store.SaveEvidenceTask(&domain.EvidenceTask{
+			ID: "1", ReferenceID: "ET-0001", Name: "GitHub Access Controls",
+			RelatedControls: []domain.Control{{Name: "Encryption at rest"}},
+		}))
+		scanner := &stubEvidenceScanner{scanWindowState: &models.WindowState{Window: "2025-Q1", FileCount: len(files), TotalBytes: 10000, Files: files}}
+		evaluator := NewEvidenceEvaluatorService(filepath.Join(dir, "evidence"), store, scanner, newTestLogger(t))
+		evaluator.SetRubrics(config.EvaluationConfig{Rubrics: []config.EvaluationRubric{{
+			Name:       "no-controls",
+			Dimensions: map[string]config.RubricDimension{"completeness": {Weight: 1}, "quality": {Weight: 1}},
+		}}})
+
+		result, err := evaluator.EvaluateWindow(context.Background(), "ET-0001", "2025-Q1")
+		require.NoError(t, err)
+		assert.Equal(t, models.DimensionSkipped, result.ControlAlignment.Status)
+		assert.Equal(t, models.DimensionSkipped, result.RequirementsMatch.Status)
+		for _, issue := range result.Issues {
+			assert.NotEqual(t, "control_alignment", issue.Category)
+		}
+		assert.InDelta(t, (result.Completeness.Score+result.QualityScore.Score)/2, result.OverallScore, 0.0001)
+	})
+}
+
 // ---------------------------------------------------------------------------
 // evidence_scanner.go – Scanner tests
 // ---------------------------------------------------------------------------
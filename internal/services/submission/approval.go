@@ -0,0 +1,145 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/signing"
+	"github.com/grctool/grctool/internal/storage"
+)
+
+// ErrApprovalRequired is matched by the errors of submissions refused because
+// the evidence was not approved, or changed after it was
+var ErrApprovalRequired = errors.New("submission approval required")
+
+// approvalError is a submission refused for want of a current approval
+type approvalError struct {
+	err error
+}
+
+func (e *approvalError) Error() string { return e.err.Error() }
+
+func (e *approvalError) Unwrap() error { return e.err }
+
+func (e *approvalError) Is(target error) bool { return target == ErrApprovalRequired }
+
+// Signer signs an approval statement and returns the ASCII-armored detached
+// signature
+type Signer func(ctx context.Context, statement string) (string, error)
+
+// GPGSigner signs with gpg using key, or gpg's default key when key is empty
+func GPGSigner(key string) Signer {
+	gpg := signing.NewGPG(key)
+	return func(ctx context.Context, statement string) (string, error) {
+		signature, err := gpg.Sign(ctx, []byte(statement))
+		return string(signature), err
+	}
+}
+
+// ApproveRequest defines a request to approve a window's evidence
+type ApproveRequest struct {
+	TaskRef  string
+	Window   string
+	Approver string
+	Comment  string
+	Signer   Signer // Signs the approval statement; nil leaves it unsigned
+}
+
+// SetRequireApproval makes Submit refuse evidence without a current approval
+func (s *SubmissionService) SetRequireApproval(required bool) {
+	s.requireApproval = required
+}
+
+// Approve records the approver's sign-off on the evidence files in the
+// window root, with their checksums, replacing any earlier approval. The
+// approval is signed when the request has a Signer.
+func (s *SubmissionService) Approve(ctx context.Context, req *ApproveRequest) (*models.EvidenceApproval, error) {
+	if req.Approver == "" {
+		return nil, fmt.Errorf("an approver is required")
+	}
+	if submitted, err := s.storage.CheckAlreadySubmitted(req.TaskRef, req.Window); err != nil {
+		return nil, err
+	} else if submitted {
+		return nil, fmt.Errorf("evidence for %s in window %s has already been submitted; withdraw it to approve a rework", req.TaskRef, req.Window)
+	}
+	files, err := s.storage.GetEvidenceFiles(req.TaskRef, req.Window)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no evidence files to approve for %s in window %s", req.TaskRef, req.Window)
+	}
+
+	approval := &models.EvidenceApproval{
+		TaskRef:    req.TaskRef,
+		Window:     req.Window,
+		Approver:   req.Approver,
+		ApprovedAt: time.Now().UTC().Truncate(time.Second),
+		Comment:    req.Comment,
+		Files:      make([]models.ApprovedFile, 0, len(files)),
+	}
+	for _, file := range files {
+		approval.Files = append(approval.Files, models.ApprovedFile{Filename: file.Filename, SHA256: file.ChecksumSHA256})
+	}
+	sort.Slice(approval.Files, func(i, j int) bool { return approval.Files[i].Filename < approval.Files[j].Filename })
+
+	if req.Signer != nil {
+		if approval.Signature, err = req.Signer(ctx, approval.Statement()); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.storage.SaveApproval(approval); err != nil {
+		return nil, err
+	}
+	return approval, nil
+}
+
+// CheckApproval returns the window's approval when it covers the files as
+// they are now, or an error matching ErrApprovalRequired naming what to do
+func CheckApproval(st *storage.Storage, taskRef, window string, files []models.EvidenceFileRef) (*models.EvidenceApproval, error) {
+	approval, err := st.LoadApproval(taskRef, window)
+	if err != nil {
+		return nil, &approvalError{fmt.Errorf("%s/%s has not been approved; a second reviewer must run 'grctool evidence approve %s --window %s'",
+			taskRef, window, taskRef, window)}
+	}
+	if err := approval.Covers(files); err != nil {
+		return nil, &approvalError{fmt.Errorf("evidence for %s/%s changed since %s approved it: %s; it must be approved again",
+			taskRef, window, approval.Approver, err)}
+	}
+	return approval, nil
+}
+
+// CheckDraftReviewed returns an error matching ErrApprovalRequired when a
+// language model drafted the window's evidence and no approval covers the
+// files as they are now: machine drafts need a reviewer's sign-off whether or
+// not approval is otherwise required
+func CheckDraftReviewed(st *storage.Storage, taskRef, window string, files []models.EvidenceFileRef) error {
+	generation, err := st.LoadGenerationMetadata(taskRef, window)
+	if err != nil || generation == nil || !generation.MachineDrafted() || generation.ReviewStatus != models.ReviewPending {
+		return nil
+	}
+	if _, err := CheckApproval(st, taskRef, window, files); err != nil {
+		return &approvalError{fmt.Errorf("evidence for %s/%s was drafted by %s and is pending human review: %w",
+			taskRef, window, generation.DraftedBy, errors.Unwrap(err))}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package submission
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApprove(t *testing.T) {
+	t.Parallel()
+	st, tmpDir := setupTestStorage(t)
+	svc := NewSubmissionServiceWithTargets(st)
+
+	_, err := svc.Approve(context.Background(), &ApproveRequest{TaskRef: "ET-0047", Window: "2025-Q4"})
+	assert.EqualError(t, err, "an approver is required")
+
+	writeEvidence(t, tmpDir, map[string]string{"notes.md": "# Notes", "access.csv": "user,role\n"})
+	var signed string
+	approval, err := svc.Approve(context.Background(), &ApproveRequest{
+		TaskRef: "ET-0047", Window: "2025-Q4", Approver: "sam@example.com", Comment: "Checked against the HR roster",
+		Signer: func(_ context.Context, statement string) (string, error) {
+			signed = statement
+			return "-----BEGIN PGP SIGNATURE-----", nil
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "sam@example.com", approval.Approver)
+	require.Len(t, approval.Files, 2)
+	assert.Equal(t, "access.csv", approval.Files[0].Filename, "sorted by filename")
+	assert.Len(t, approval.Files[0].SHA256, 64)
+	assert.Equal(t, "-----BEGIN PGP SIGNATURE-----", approval.Signature)
+	assert.Equal(t, approval.Statement(), signed)
+
+	loaded, err := st.LoadApproval("ET-0047", "2025-Q4")
+	require.NoError(t, err)
+	assert.Equal(t, approval.Statement(), loaded.Statement(), "the signed statement can be rebuilt from the saved approval")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "evidence", "ET-0047", "2025-Q4", ".submitted"), 0755))
+	require.NoError(t, os.Rename(filepath.Join(tmpDir, "evidence", "ET-0047", "2025-Q4", "notes.md"),
+		filepath.Join(tmpDir, "evidence", "ET-0047", "2025-Q4", ".submitted", "notes.md")))
+	_, err = svc.Approve(context.Background(), &ApproveRequest{TaskRef: "ET-0047", Window: "2025-Q4", Approver: "sam@example.com"})
+	assert.ErrorContains(t, err, "has already been submitted")
+}
+
+func TestSubmit_RequiresApproval(t *testing.T) {
+	t.Parallel()
+	st, tmpDir := setupTestStorage(t)
+	writeEvidence(t, tmpDir, map[string]string{"access.csv": "user,role\n"})
+
+	target := &stubTarget{}
+	svc := NewSubmissionServiceWithTargets(st, target)
+	svc.SetRequireApproval(true)
+	req := &SubmitRequest{TaskRef: "ET-0047", Window: "2025-Q4", SkipValidation: true}
+
+	_, err := svc.Submit(context.Background(), req)
+	assert.ErrorIs(t, err, ErrApprovalRequired)
+	assert.EqualError(t, err, "ET-0047/2025-Q4 has not been approved; a second reviewer must run 'grctool evidence approve ET-0047 --window 2025-Q4'")
+	assert.Empty(t, target.files)
+
+	_, err = svc.Approve(context.Background(), &ApproveRequest{TaskRef: "ET-0047", Window: "2025-Q4", Approver: "sam@example.com"})
+	require.NoError(t, err)
+
+	// Evidence changed after approval is refused
+	writeEvidence(t, tmpDir, map[string]string{"access.csv": "user,role\nalex,admin\n"})
+	_, err = svc.Submit(context.Background(), req)
+	assert.ErrorIs(t, err, ErrApprovalRequired)
+	assert.EqualError(t, err, "evidence for ET-0047/2025-Q4 changed since sam@example.com approved it: access.csv changed after approval; it must be approved again")
+
+	_, err = svc.Approve(context.Background(), &ApproveRequest{TaskRef: "ET-0047", Window: "2025-Q4", Approver: "sam@example.com"})
+	require.NoError(t, err)
+	resp, err := svc.Submit(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "submitted", resp.Status)
+	require.NotNil(t, resp.Submission.Approval)
+	assert.Equal(t, "sam@example.com", resp.Submission.Approval.Approver)
+	require.Len(t, target.files, 1)
+}
+
+func TestSubmit_MachineDraftNeedsReview(t *testing.T) {
+	t.Parallel()
+	st, tmpDir := setupTestStorage(t)
+	writeEvidence(t, tmpDir, map[string]string{"ET-0047_Evidence.md": "# Access Review\n"})
+	generationDir := filepath.Join(tmpDir, "evidence", "ET-0047", "2025-Q4", ".generation")
+	require.NoError(t, os.MkdirAll(generationDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(generationDir, "metadata.yaml"),
+		[]byte("generated_by: grctool-synthesis\ndrafted_by: openai/gpt-4o\nreview_status: pending_review\n"), 0644))
+
+	target := &stubTarget{}
+	svc := NewSubmissionServiceWithTargets(st, target)
+	req := &SubmitRequest{TaskRef: "ET-0047", Window: "2025-Q4", SkipValidation: true}
+
+	_, err := svc.Submit(context.Background(), req)
+	assert.ErrorIs(t, err, ErrApprovalRequired)
+	assert.EqualError(t, err, "evidence for ET-0047/2025-Q4 was drafted by openai/gpt-4o and is pending human review: "+
+		"ET-0047/2025-Q4 has not been approved; a second reviewer must run 'grctool evidence approve ET-0047 --window 2025-Q4'")
+	assert.Empty(t, target.files)
+
+	_, err = svc.Approve(context.Background(), &ApproveRequest{TaskRef: "ET-0047", Window: "2025-Q4", Approver: "sam@example.com"})
+	require.NoError(t, err)
+	resp, err := svc.Submit(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "submitted", resp.Status)
+}
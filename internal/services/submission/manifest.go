@@ -0,0 +1,223 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submission
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/storage"
+)
+
+// ManifestControl is a control an evidence task supports
+type ManifestControl struct {
+	ID          string `json:"id"`
+	ReferenceID string `json:"reference_id,omitempty"` // e.g. CC6.1
+	Name        string `json:"name,omitempty"`
+	Framework   string `json:"framework,omitempty"`
+	Codes       string `json:"codes,omitempty"`
+}
+
+// ManifestSource is an input an evidence file was produced from
+type ManifestSource struct {
+	Type       string    `json:"type"`     // tool, file, document
+	Location   string    `json:"location"` // Tool name, file path or document URL
+	ModifiedAt time.Time `json:"modified_at,omitempty"`
+}
+
+// BuildManifest indexes the files of a submission. Sources and collection
+// times come from the window's generation metadata when there is any;
+// otherwise a file's collection time is when it was last modified.
+func BuildManifest(task *domain.EvidenceTask, submission *models.EvidenceSubmission, files []EvidenceFile, generation *models.GenerationMetadata, now time.Time) PackageManifest {
+	manifest := PackageManifest{
+		TaskRef:     submission.TaskRef,
+		TaskName:    task.Name,
+		Window:      submission.Window,
+		Framework:   task.Framework,
+		SubmittedBy: submission.SubmittedBy,
+		Notes:       submission.Notes,
+		CreatedAt:   now.UTC(),
+		Files:       []PackageFile{},
+	}
+	for _, control := range task.RelatedControls {
+		manifest.Controls = append(manifest.Controls, ManifestControl{
+			ID:          control.ID,
+			ReferenceID: control.ReferenceID,
+			Name:        control.Name,
+			Framework:   control.Framework,
+			Codes:       control.Codes,
+		})
+	}
+	if len(manifest.Controls) == 0 {
+		for _, id := range task.Controls {
+			manifest.Controls = append(manifest.Controls, ManifestControl{ID: id})
+		}
+	}
+
+	generated := map[string]models.FileMetadata{}
+	if generation != nil {
+		manifest.GeneratedBy = generation.GeneratedBy
+		manifest.ToolsUsed = generation.ToolsUsed
+		for _, file := range generation.FilesGenerated {
+			generated[filepath.Base(file.Path)] = file
+		}
+	}
+	refs := map[string]models.EvidenceFileRef{}
+	for _, ref := range submission.EvidenceFiles {
+		refs[ref.Filename] = ref
+	}
+
+	for _, file := range files {
+		sum := sha256.Sum256(file.Content)
+		entry := PackageFile{
+			Filename:    file.Filename,
+			ContentType: file.ContentType,
+			SizeBytes:   int64(len(file.Content)),
+			SHA256:      hex.EncodeToString(sum[:]),
+			CollectedAt: file.CollectedDate.UTC(),
+			Controls:    refs[file.Filename].ControlsSatisfied,
+		}
+		if file.Path != "" {
+			if info, err := os.Stat(file.Path); err == nil {
+				entry.CollectedAt = info.ModTime().UTC()
+			}
+		}
+		if source := refs[file.Filename].Source; source != "" {
+			entry.Sources = append(entry.Sources, ManifestSource{Type: models.SourceTypeTool, Location: source})
+		}
+		if meta, ok := generated[file.Filename]; ok {
+			if !meta.GeneratedAt.IsZero() {
+				entry.CollectedAt = meta.GeneratedAt.UTC()
+			}
+			for _, source := range meta.Sources {
+				entry.Sources = append(entry.Sources, ManifestSource{Type: source.Type, Location: source.Location, ModifiedAt: source.ModifiedAt.UTC()})
+			}
+		}
+		manifest.Files = append(manifest.Files, entry)
+	}
+	return manifest
+}
+
+// SetUploadManifest sets whether the window's manifest is uploaded as
+// manifest.json after the evidence files, for targets that take files one
+// by one. Packages always contain it.
+func (s *SubmissionService) SetUploadManifest(upload bool) {
+	s.uploadManifest = upload
+}
+
+// Manifest indexes the evidence files in a window's root, as they would be
+// submitted, and saves the manifest in the window's .submission/ directory
+func (s *SubmissionService) Manifest(taskRef, window string) (*PackageManifest, error) {
+	task, err := s.getEvidenceTask(taskRef)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := s.storage.GetEvidenceFiles(taskRef, window)
+	if err != nil {
+		return nil, err
+	}
+	refs = filterPreferPDF(refs)
+
+	baseDir := s.storage.GetBaseDir()
+	files := make([]EvidenceFile, 0, len(refs))
+	for _, ref := range refs {
+		path := filepath.Join(baseDir, ref.RelativePath)
+		content, err := storage.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", ref.Filename, err)
+		}
+		files = append(files, EvidenceFile{Filename: ref.Filename, Path: path, ContentType: s.getContentType(ref.Filename), Content: content})
+	}
+
+	submission := &models.EvidenceSubmission{TaskRef: taskRef, Window: window, EvidenceFiles: refs}
+	manifest, err := s.buildManifest(task, submission, files, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.saveManifest(manifest); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// buildManifest indexes files with the window's generation metadata
+func (s *SubmissionService) buildManifest(task *domain.EvidenceTask, submission *models.EvidenceSubmission, files []EvidenceFile, now time.Time) (PackageManifest, error) {
+	generation, err := s.storage.LoadGenerationMetadata(submission.TaskRef, submission.Window)
+	if err != nil {
+		return PackageManifest{}, err
+	}
+	return BuildManifest(task, submission, files, generation, now), nil
+}
+
+// saveManifest keeps a manifest in the window's .submission/ directory
+func (s *SubmissionService) saveManifest(manifest PackageManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	return s.storage.SaveManifest(manifest.TaskRef, manifest.Window, data)
+}
+
+// mergeManifest lists the files of the window's saved manifest that are
+// not in manifest before its own, so a retry's manifest still covers the
+// files uploaded the first time
+func (s *SubmissionService) mergeManifest(manifest PackageManifest) PackageManifest {
+	data, err := s.storage.LoadManifest(manifest.TaskRef, manifest.Window)
+	var saved PackageManifest
+	if err != nil || data == nil || json.Unmarshal(data, &saved) != nil {
+		return manifest
+	}
+	listed := map[string]bool{}
+	for _, file := range manifest.Files {
+		listed[file.Filename] = true
+	}
+	files := []PackageFile{}
+	for _, file := range saved.Files {
+		if !listed[file.Filename] {
+			files = append(files, file)
+		}
+	}
+	manifest.Files = append(files, manifest.Files...)
+	return manifest
+}
+
+// sendManifest uploads a manifest as a file, named manifest.json unless an
+// evidence file already is
+func (s *SubmissionService) sendManifest(ctx context.Context, target Target, task *domain.EvidenceTask, submission *models.EvidenceSubmission, manifest PackageManifest, collected time.Time) (EvidenceFile, string, error) {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return EvidenceFile{}, "", fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	filename := ManifestFilename
+	for _, file := range manifest.Files {
+		if file.Filename == filename {
+			filename = fmt.Sprintf("%s_%s_%s", manifest.TaskRef, manifest.Window, ManifestFilename)
+		}
+	}
+	file := EvidenceFile{Filename: filename, ContentType: s.getContentType(filename), Content: data, CollectedDate: collected}
+	receipt, _, err := s.withRetry(ctx, func() (string, error) {
+		return target.SubmitFile(ctx, task, submission, file)
+	})
+	return file, receipt, err
+}
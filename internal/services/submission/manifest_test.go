@@ -0,0 +1,163 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package submission
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestBuildManifest(t *testing.T) {
+	t.Parallel()
+	collected := time.Date(2025, 10, 2, 9, 30, 0, 0, time.UTC)
+	changed := time.Date(2025, 9, 30, 17, 0, 0, 0, time.UTC)
+	now := time.Date(2025, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	task := &domain.EvidenceTask{
+		Name:            "GitHub Repository Access Controls",
+		Framework:       "SOC2",
+		RelatedControls: []domain.Control{{ID: "778805", ReferenceID: "CC6.1", Name: "Logical Access", Framework: "SOC2"}},
+	}
+	submission := &models.EvidenceSubmission{
+		TaskRef:       "ET-0047",
+		Window:        "2025-Q4",
+		SubmittedBy:   "alex",
+		EvidenceFiles: []models.EvidenceFileRef{{Filename: "access.csv", Source: "github-permissions", ControlsSatisfied: []string{"CC6.1"}}},
+	}
+	generation := &models.GenerationMetadata{
+		GeneratedBy: "grctool-cli",
+		ToolsUsed:   []string{"github-permissions"},
+		FilesGenerated: []models.FileMetadata{{
+			Path:        "access.csv",
+			GeneratedAt: collected,
+			Sources:     []models.SourceTimestamp{{Type: models.SourceTypeDocument, Location: "https://github.com/org/repo/settings/access", ModifiedAt: changed}},
+		}},
+	}
+	files := []EvidenceFile{
+		{Filename: "access.csv", ContentType: "text/csv", Content: []byte("user,role\n"), CollectedDate: now},
+		{Filename: "notes.md", ContentType: "text/markdown", Content: []byte("# Notes"), CollectedDate: now},
+	}
+
+	manifest := BuildManifest(task, submission, files, generation, now)
+	assert.Equal(t, "ET-0047", manifest.TaskRef)
+	assert.Equal(t, "SOC2", manifest.Framework)
+	assert.Equal(t, "alex", manifest.SubmittedBy)
+	assert.Equal(t, "grctool-cli", manifest.GeneratedBy)
+	assert.Equal(t, []string{"github-permissions"}, manifest.ToolsUsed)
+	assert.Equal(t, []ManifestControl{{ID: "778805", ReferenceID: "CC6.1", Name: "Logical Access", Framework: "SOC2"}}, manifest.Controls)
+	require.Len(t, manifest.Files, 2)
+	assert.Equal(t, PackageFile{
+		Filename:    "access.csv",
+		ContentType: "text/csv",
+		SizeBytes:   10,
+		SHA256:      "149c4dfcc255bfa22c64ddf2d80c6acf5534f65732e94cfde4ce72f03bd7711b",
+		CollectedAt: collected,
+		Sources: []ManifestSource{
+			{Type: models.SourceTypeTool, Location: "github-permissions"},
+			{Type: models.SourceTypeDocument, Location: "https://github.com/org/repo/settings/access", ModifiedAt: changed},
+		},
+		Controls: []string{"CC6.1"},
+	}, manifest.Files[0])
+	assert.Equal(t, now, manifest.Files[1].CollectedAt, "without metadata or a path, the file was collected when submitted")
+	assert.Empty(t, manifest.Files[1].Sources)
+
+	// Without control details, the task's control IDs are listed
+	task = &domain.EvidenceTask{Controls: []string{"778805", "778806"}}
+	manifest = BuildManifest(task, submission, nil, nil, now)
+	assert.Equal(t, []ManifestControl{{ID: "778805"}, {ID: "778806"}}, manifest.Controls)
+	assert.Empty(t, manifest.Files)
+}
+
+func TestSubmissionService_Manifest(t *testing.T) {
+	t.Parallel()
+	st, tmpDir := setupTestStorage(t)
+	writeEvidence(t, tmpDir, map[string]string{"access.csv": "user,role\n", "collection_plan.md": "# Plan"})
+	generatedAt := time.Date(2025, 10, 2, 9, 30, 0, 0, time.UTC)
+	metadata, err := yaml.Marshal(models.GenerationMetadata{
+		GeneratedBy:    "grctool-cli",
+		FilesGenerated: []models.FileMetadata{{Path: "access.csv", GeneratedAt: generatedAt}},
+	})
+	require.NoError(t, err)
+	generationDir := filepath.Join(tmpDir, "evidence", "ET-0047", "2025-Q4", ".generation")
+	require.NoError(t, os.MkdirAll(generationDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(generationDir, "metadata.yaml"), metadata, 0644))
+
+	manifest, err := NewSubmissionServiceWithTargets(st).Manifest("ET-0047", "2025-Q4")
+	require.NoError(t, err)
+	assert.Equal(t, "GitHub Repository Access Controls", manifest.TaskName)
+	assert.Equal(t, "grctool-cli", manifest.GeneratedBy)
+	require.Len(t, manifest.Files, 1)
+	assert.Equal(t, generatedAt, manifest.Files[0].CollectedAt)
+
+	data, err := st.LoadManifest("ET-0047", "2025-Q4")
+	require.NoError(t, err)
+	var saved PackageManifest
+	require.NoError(t, json.Unmarshal(data, &saved))
+	assert.Equal(t, manifest.Files, saved.Files)
+
+	_, err = NewSubmissionServiceWithTargets(st).Manifest("ET-0047", "2025-Q1")
+	assert.Error(t, err)
+}
+
+func TestSubmit_UploadsManifest(t *testing.T) {
+	t.Parallel()
+	st, tmpDir := setupTestStorage(t)
+	writeEvidence(t, tmpDir, map[string]string{"access.csv": "user,role\n", "notes.md": "# Notes"})
+
+	target := &stubTarget{failures: map[string]bool{"notes.md": true}}
+	svc := NewSubmissionServiceWithTargets(st, target)
+	svc.retryBackoff = time.Millisecond
+	svc.SetUploadManifest(true)
+
+	resp, err := svc.Submit(context.Background(), &SubmitRequest{TaskRef: "ET-0047", Window: "2025-Q4", SkipValidation: true})
+	require.NoError(t, err)
+	assert.Equal(t, "submitted", resp.Status)
+	require.Len(t, target.files, 2)
+	assert.Equal(t, ManifestFilename, target.files[1].Filename)
+	assert.Equal(t, "application/json", target.files[1].ContentType)
+	var uploaded PackageManifest
+	require.NoError(t, json.Unmarshal(target.files[1].Content, &uploaded))
+	require.Len(t, uploaded.Files, 1, "only what was submitted is listed")
+	assert.Equal(t, "access.csv", uploaded.Files[0].Filename)
+	assert.Equal(t, "stub-manifest.json", resp.Submission.TugboatResponse.Metadata["receipts"].(map[string]string)[ManifestFilename])
+
+	// A retry's manifest still lists the files uploaded the first time
+	target.failures = nil
+	_, err = svc.Submit(context.Background(), &SubmitRequest{TaskRef: "ET-0047", Window: "2025-Q4", RetryFailed: true})
+	require.NoError(t, err)
+	require.Len(t, target.files, 4)
+	require.NoError(t, json.Unmarshal(target.files[3].Content, &uploaded))
+	require.Len(t, uploaded.Files, 2)
+	assert.Equal(t, []string{"access.csv", "notes.md"}, []string{uploaded.Files[0].Filename, uploaded.Files[1].Filename})
+
+	data, err := st.LoadManifest("ET-0047", "2025-Q4")
+	require.NoError(t, err)
+	assert.Equal(t, string(target.files[3].Content), string(data))
+}
+
+func TestSubmit_ManifestNameTaken(t *testing.T) {
+	t.Parallel()
+	st, tmpDir := setupTestStorage(t)
+	writeEvidence(t, tmpDir, map[string]string{"manifest.json": `{"resources": []}`})
+
+	target := &stubTarget{}
+	svc := NewSubmissionServiceWithTargets(st, target)
+	svc.SetUploadManifest(true)
+
+	_, err := svc.Submit(context.Background(), &SubmitRequest{TaskRef: "ET-0047", Window: "2025-Q4", SkipValidation: true})
+	require.NoError(t, err)
+	require.Len(t, target.files, 2)
+	assert.Equal(t, "manifest.json", target.files[0].Filename)
+	assert.Equal(t, "ET-0047_2025-Q4_manifest.json", target.files[1].Filename)
+}
@@ -0,0 +1,112 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submission
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ManifestFilename is the manifest's name inside an evidence package
+const ManifestFilename = "manifest.json"
+
+// EvidencePackage is a zip of a submission's files with a manifest, for
+// targets that take the whole submission at once
+type EvidencePackage struct {
+	Filename string
+	Content  []byte
+	SHA256   string
+	Manifest PackageManifest
+}
+
+// PackageManifest is a machine-readable index of the evidence provided for
+// a window: what each file is, where it came from and which controls it
+// supports, with checksums so the recipient can verify the files. It is
+// packed into evidence packages, kept in the window's .submission/
+// directory and, with submission.upload_manifest, uploaded beside the files.
+type PackageManifest struct {
+	TaskRef     string            `json:"task_ref"`
+	TaskName    string            `json:"task_name"`
+	Window      string            `json:"window"`
+	Framework   string            `json:"framework,omitempty"`
+	SubmittedBy string            `json:"submitted_by,omitempty"`
+	Notes       string            `json:"notes,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	GeneratedBy string            `json:"generated_by,omitempty"` // How the evidence was generated, from its generation metadata
+	ToolsUsed   []string          `json:"tools_used,omitempty"`
+	Controls    []ManifestControl `json:"controls,omitempty"` // Controls the task supports
+	Files       []PackageFile     `json:"files"`
+}
+
+// PackageFile is a file listed in a package manifest
+type PackageFile struct {
+	Filename    string           `json:"filename"`
+	ContentType string           `json:"content_type"`
+	SizeBytes   int64            `json:"size_bytes"`
+	SHA256      string           `json:"sha256"`
+	CollectedAt time.Time        `json:"collected_at"`
+	Sources     []ManifestSource `json:"sources,omitempty"`
+	Controls    []string         `json:"controls,omitempty"`    // Controls this file satisfies, when recorded
+	UploadedAs  []string         `json:"uploaded_as,omitempty"` // Names uploaded when the file was converted or split
+}
+
+// BuildPackage zips files with their manifest. The package is named after
+// the task, window and creation time, e.g.
+// ET-0001_2025-Q4_20251015T120000Z.zip.
+func BuildPackage(manifest PackageManifest, files []EvidenceFile, now time.Time) (*EvidencePackage, error) {
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for _, file := range files {
+		if err := writeZipFile(writer, file.Filename, file.Content, now); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if err := writeZipFile(writer, ManifestFilename, data, now); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close package: %w", err)
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	return &EvidencePackage{
+		Filename: fmt.Sprintf("%s_%s_%s.zip", manifest.TaskRef, manifest.Window, now.UTC().Format("20060102T150405Z")),
+		Content:  buf.Bytes(),
+		SHA256:   hex.EncodeToString(sum[:]),
+		Manifest: manifest,
+	}, nil
+}
+
+func writeZipFile(writer *zip.Writer, name string, content []byte, modified time.Time) error {
+	w, err := writer.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return fmt.Errorf("failed to add %s to package: %w", name, err)
+	}
+	if _, err := w.Write(content); err != nil {
+		return fmt.Errorf("failed to add %s to package: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submission
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/redaction"
+	"github.com/grctool/grctool/internal/storage"
+)
+
+// SetScanner makes validation and Submit scan the evidence for secrets and
+// personal data with scanner
+func (s *SubmissionService) SetScanner(scanner *redaction.Scanner) {
+	s.scanner = scanner
+	s.validator.SetScanner(scanner)
+}
+
+// scanSensitiveData refuses evidence containing what submission.scan blocks,
+// even when validation is skipped. Warnings pass, as validation reports them.
+func (s *SubmissionService) scanSensitiveData(taskRef, window string, files []models.EvidenceFileRef) error {
+	windowDir := s.storage.EvidenceWindowDir(taskRef, window)
+	scanner := s.scanner.ForTask(taskRef, s.storage.EvidenceTaskCategory(taskRef))
+	var blocking []string
+	for _, file := range files {
+		data, err := storage.ReadFile(filepath.Join(windowDir, file.Filename))
+		if err != nil {
+			return fmt.Errorf("failed to scan %s: %w", file.Filename, err)
+		}
+		for _, finding := range redaction.Blocking(scanner.Scan(file.Filename, data)) {
+			blocking = append(blocking, finding.String())
+		}
+	}
+	if len(blocking) > 0 {
+		return fmt.Errorf("evidence for %s/%s contains secrets or personal data:\n  %s\nredact it with 'grctool evidence scan %s --window %s --redact' or waive it in submission.scan.waivers before submitting",
+			taskRef, window, strings.Join(blocking, "\n  "), taskRef, window)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package submission
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/redaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmit_ScansSensitiveData(t *testing.T) {
+	t.Parallel()
+	st, tmpDir := setupTestStorage(t)
+	writeEvidence(t, tmpDir, map[string]string{
+		"access.csv": "user,role\nalex@example.com,admin\n",
+		"config.tf":  "password = \"hunter2hunter2\"\n",
+	})
+
+	target := &stubTarget{}
+	svc := NewSubmissionServiceWithTargets(st, target)
+	svc.SetScanner(redaction.New(config.ScanConfig{}))
+
+	resp, err := svc.Submit(context.Background(), &SubmitRequest{TaskRef: "ET-0047", Window: "2025-Q4"})
+	require.NoError(t, err)
+	assert.Equal(t, "validation_failed", resp.Status)
+	require.Len(t, resp.ValidationResult.Errors, 1)
+	assert.Equal(t, "config.tf:1: secret assignment hunt********", resp.ValidationResult.Errors[0].Message)
+
+	_, err = svc.Submit(context.Background(), &SubmitRequest{TaskRef: "ET-0047", Window: "2025-Q4", SkipValidation: true})
+	assert.ErrorContains(t, err, "evidence for ET-0047/2025-Q4 contains secrets or personal data:\n  config.tf:1: secret assignment hunt********\n")
+	assert.Empty(t, target.files)
+
+	// Personal data is only a warning
+	writeEvidence(t, tmpDir, map[string]string{"config.tf": "password = var.db_password\n"})
+	resp, err = svc.Submit(context.Background(), &SubmitRequest{TaskRef: "ET-0047", Window: "2025-Q4"})
+	require.NoError(t, err)
+	assert.Equal(t, "submitted", resp.Status)
+	assert.Equal(t, "access.csv:2: email alex********", resp.ValidationResult.WarningsList[len(resp.ValidationResult.WarningsList)-1].Message)
+	assert.Len(t, target.files, 2)
+}
+
+func TestSubmit_WaivesFindings(t *testing.T) {
+	t.Parallel()
+	st, tmpDir := setupTestStorage(t)
+	writeEvidence(t, tmpDir, map[string]string{
+		"contracts.md": "Signed with Globex on 2025-10-01\n",
+		"hosts.md":     "Globex tenant: globex.corp.internal\n",
+	})
+
+	target := &stubTarget{}
+	svc := NewSubmissionServiceWithTargets(st, target)
+	cfg := config.ScanConfig{
+		Secrets: redaction.Off,
+		PII:     redaction.Off,
+		Rules:   []config.DLPRule{{Name: "customer-names", Terms: []string{"Globex"}}},
+		Waivers: []config.ScanWaiver{{Rule: "customer-names", Tasks: []string{"ET-0047"}, Files: []string{"contracts.md"}, Reason: "The contract names the customer"}},
+	}
+	svc.SetScanner(redaction.New(cfg))
+
+	_, err := svc.Submit(context.Background(), &SubmitRequest{TaskRef: "ET-0047", Window: "2025-Q4", SkipValidation: true})
+	assert.ErrorContains(t, err, "contains secrets or personal data:\n  hosts.md:1: customer-names Glob**\n  hosts.md:1: customer-names glob**\n")
+
+	cfg.Waivers[0].Files = append(cfg.Waivers[0].Files, "hosts.md")
+	svc = NewSubmissionServiceWithTargets(st, target)
+	svc.SetScanner(redaction.New(cfg))
+	resp, err := svc.Submit(context.Background(), &SubmitRequest{TaskRef: "ET-0047", Window: "2025-Q4"})
+	require.NoError(t, err)
+	assert.Equal(t, "submitted", resp.Status)
+	assert.Len(t, target.files, 2)
+}
@@ -17,27 +17,76 @@ package submission
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
+	"net/url"
 	"path/filepath"
 	"time"
 
 	"github.com/grctool/grctool/internal/domain"
 	"github.com/grctool/grctool/internal/interfaces"
 	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/redaction"
 	"github.com/grctool/grctool/internal/services/validation"
+	"github.com/grctool/grctool/internal/signing"
 	"github.com/grctool/grctool/internal/storage"
 	"github.com/grctool/grctool/internal/tugboat"
 )
 
-// SubmissionService handles evidence submission to Tugboat
+// Upload retry defaults: a failed upload is retried defaultUploadRetries
+// times, waiting defaultUploadBackoff before the first retry and doubling the
+// wait for each further one
+const (
+	defaultUploadRetries = 3
+	defaultUploadBackoff = 500 * time.Millisecond
+)
+
+// ErrTargetUnreachable is matched by the errors of submissions that failed
+// because the target could not be reached at all, rather than because it
+// rejected the evidence, so they can be queued and sent later
+var ErrTargetUnreachable = errors.New("submission target unreachable")
+
+// unreachableError is a submission error caused by the target being
+// unreachable
+type unreachableError struct {
+	err error
+}
+
+func (e *unreachableError) Error() string { return e.err.Error() }
+
+func (e *unreachableError) Unwrap() error { return e.err }
+
+func (e *unreachableError) Is(target error) bool { return target == ErrTargetUnreachable }
+
+// isUnreachable reports whether an upload failed to connect to the target:
+// the HTTP clients of all targets wrap transport errors, such as a failed DNS
+// lookup, a refused connection or a timeout, in a *url.Error
+func isUnreachable(err error) bool {
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// SubmissionService handles evidence submission to a GRC platform such as
+// Tugboat or Vanta
 type SubmissionService struct {
-	storage       *storage.Storage
-	tugboatClient *tugboat.Client               // legacy direct client (used if submitter is nil)
-	submitter     interfaces.EvidenceSubmitter   // preferred: resolved from provider registry
-	validator     *validation.EvidenceValidationService
-	orgID         string
-	collectorURLs map[string]string // TaskRef -> Collector URL mapping
+	storage      *storage.Storage
+	targets      []Target // Tried in order; none saves submissions locally as drafts
+	validator    *validation.EvidenceValidationService
+	orgID        string
+	retries      int
+	retryBackoff time.Duration
+
+	// requireApproval refuses evidence without a current approval
+	requireApproval bool
+
+	// signer verifies the signatures made when the evidence was written
+	signer signing.Signer
+
+	// scanner refuses evidence containing secrets or personal data
+	scanner *redaction.Scanner
+
+	// uploadManifest uploads the window's manifest beside its files
+	uploadManifest bool
 }
 
 // NewSubmissionService creates a new submission service using a direct Tugboat client.
@@ -48,12 +97,25 @@ func NewSubmissionService(
 	orgID string,
 	collectorURLs map[string]string,
 ) *SubmissionService {
+	var targets []Target
+	if tugboatClient != nil {
+		targets = append(targets, NewTugboatTarget(tugboatClient, collectorURLs))
+	}
+	svc := NewSubmissionServiceWithTargets(storage, targets...)
+	svc.orgID = orgID
+	return svc
+}
+
+// NewSubmissionServiceWithTargets creates a submission service uploading each
+// task's evidence to the first target the task is configured for. Without
+// targets, submissions are saved locally as drafts.
+func NewSubmissionServiceWithTargets(st *storage.Storage, targets ...Target) *SubmissionService {
 	return &SubmissionService{
-		storage:       storage,
-		tugboatClient: tugboatClient,
-		validator:     validation.NewEvidenceValidationService(storage),
-		orgID:         orgID,
-		collectorURLs: collectorURLs,
+		storage:      st,
+		targets:      targets,
+		validator:    validation.NewEvidenceValidationService(st),
+		retries:      defaultUploadRetries,
+		retryBackoff: defaultUploadBackoff,
 	}
 }
 
@@ -74,12 +136,12 @@ func NewSubmissionServiceWithRegistry(
 	if !ok {
 		return nil, fmt.Errorf("provider %q does not support evidence submission", providerName)
 	}
-	return &SubmissionService{
-		storage:       st,
-		submitter:     submitter,
-		validator:     validation.NewEvidenceValidationService(st),
-		collectorURLs: collectorURLs,
-	}, nil
+	return NewSubmissionServiceWithTargets(st, &providerTarget{name: providerName, submitter: submitter, collectorURLs: collectorURLs}), nil
+}
+
+// AddTarget adds a target tried after the service's existing targets
+func (s *SubmissionService) AddTarget(target Target) {
+	s.targets = append(s.targets, target)
 }
 
 // SubmitRequest defines a submission request
@@ -90,6 +152,7 @@ type SubmitRequest struct {
 	SkipValidation bool
 	ValidationMode validation.EvidenceValidationMode
 	SubmittedBy    string
+	RetryFailed    bool // Upload only the files that failed in the last submission
 }
 
 // SubmitResponse defines the submission response
@@ -102,11 +165,25 @@ type SubmitResponse struct {
 	Submission       *models.EvidenceSubmission
 }
 
-// Submit submits evidence for a task/window
+// Submit submits evidence for a task/window. With RetryFailed, only the files
+// recorded as failed in the window's last submission are uploaded, without
+// validating again, and that submission is updated. When approval is
+// required, evidence without a current approval is refused with an error
+// matching ErrApprovalRequired. With a signer set, evidence that changed
+// after it was signed is refused, and with a scanner set, evidence
+// containing secrets or personal data that submission.scan blocks.
 func (s *SubmissionService) Submit(ctx context.Context, req *SubmitRequest) (*SubmitResponse, error) {
+	var previous *models.EvidenceSubmission
+	if req.RetryFailed {
+		var err error
+		if previous, err = s.storage.LoadSubmission(req.TaskRef, req.Window); err != nil || len(previous.FailedFiles) == 0 {
+			return nil, fmt.Errorf("no failed uploads recorded for %s in window %s", req.TaskRef, req.Window)
+		}
+	}
+
 	// Step 1: Validate evidence unless skipped
 	var validationResult *models.ValidationResult
-	if !req.SkipValidation {
+	if !req.SkipValidation && previous == nil {
 		valReq := &validation.EvidenceValidationRequest{
 			TaskRef:        req.TaskRef,
 			Window:         req.Window,
@@ -141,12 +218,47 @@ func (s *SubmissionService) Submit(ctx context.Context, req *SubmitRequest) (*Su
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare submission: %w", err)
 	}
+	if previous != nil {
+		if err := retainFailedFiles(submission, previous.FailedFiles); err != nil {
+			return nil, err
+		}
+	}
+	if s.requireApproval {
+		if submission.Approval, err = CheckApproval(s.storage, req.TaskRef, req.Window, submission.EvidenceFiles); err != nil {
+			return nil, err
+		}
+	} else if err := CheckDraftReviewed(s.storage, req.TaskRef, req.Window, submission.EvidenceFiles); err != nil {
+		return nil, err
+	}
+	if s.signer != nil {
+		if err := s.verifySignatures(ctx, req.TaskRef, req.Window, submission.EvidenceFiles); err != nil {
+			return nil, err
+		}
+	}
+	if s.scanner != nil {
+		if err := s.scanSensitiveData(req.TaskRef, req.Window, submission.EvidenceFiles); err != nil {
+			return nil, err
+		}
+	}
+
+	// Deduplicated evidence is uploaded, and kept once submitted, as the
+	// real files
+	if _, err := s.storage.MaterializeEvidence(req.TaskRef, req.Window); err != nil {
+		return nil, fmt.Errorf("failed to materialize deduplicated evidence: %w", err)
+	}
 
-	// Step 4: Submit evidence via provider interface or legacy client
-	canSubmit := s.submitter != nil || s.tugboatClient != nil
-	if canSubmit {
-		tugboatResp, err := s.doSubmit(ctx, submission, task)
+	// Step 4: Submit evidence to the task's target
+	if target := s.targetFor(req.TaskRef); target != nil {
+		tugboatResp, failures, err := s.doSubmit(ctx, target, submission, task, previous != nil)
+		submission.FailedFiles = failures
 		if err != nil {
+			if previous != nil {
+				// The earlier uploads stand; only the failures are updated
+				previous.FailedFiles = failures
+				s.storage.SaveSubmission(previous)
+				return nil, fmt.Errorf("%s submission failed: %w", target.Name(), err)
+			}
+
 			// Mark submission as failed
 			submission.Status = "submission_failed"
 			submission.TugboatResponse = &models.TugboatSubmissionResponse{
@@ -156,17 +268,20 @@ func (s *SubmissionService) Submit(ctx context.Context, req *SubmitRequest) (*Su
 			}
 			s.storage.SaveSubmission(submission)
 
-			return nil, fmt.Errorf("tugboat submission failed: %w", err)
+			return nil, fmt.Errorf("%s submission failed: %w", target.Name(), err)
 		}
 
-		// Update submission with Tugboat response
+		// Update submission with the target's response
 		submission.SubmissionID = tugboatResp.SubmissionID
 		submission.Status = "submitted"
 		submission.TugboatResponse = tugboatResp
 		submittedAt := time.Now()
 		submission.SubmittedAt = &submittedAt
+		if previous != nil {
+			submission = mergeRetry(previous, submission)
+		}
 	} else {
-		// No Tugboat client - mark as submitted locally only
+		// No target - mark as submitted locally only
 		submission.Status = "draft"
 		submission.SubmissionID = fmt.Sprintf("local-%d", time.Now().Unix())
 	}
@@ -176,7 +291,24 @@ func (s *SubmissionService) Submit(ctx context.Context, req *SubmitRequest) (*Su
 		return nil, fmt.Errorf("failed to save submission: %w", err)
 	}
 
-	// Step 6: Add to history
+	// Step 6: Record the submission in each file's chain of custody
+	details := fmt.Sprintf("submission %s (%s)", submission.SubmissionID, submission.Status)
+	custody := make([]models.CustodyEvent, 0, len(submission.EvidenceFiles))
+	for _, file := range submission.EvidenceFiles {
+		custody = append(custody, models.CustodyEvent{
+			File:    file.Filename,
+			Event:   models.CustodySubmitted,
+			Actor:   storage.CustodyActor(),
+			SHA256:  file.ChecksumSHA256,
+			Details: details,
+		})
+	}
+	if err := s.storage.RecordCustody(req.TaskRef, req.Window, custody...); err != nil {
+		// The evidence is already submitted, so only warn
+		fmt.Printf("Warning: failed to record submission in custody log: %v\n", err)
+	}
+
+	// Step 7: Add to history
 	historyEntry := models.SubmissionHistoryEntry{
 		SubmissionID: submission.SubmissionID,
 		SubmittedAt:  time.Now(),
@@ -246,6 +378,70 @@ func (s *SubmissionService) prepareSubmission(
 	return submission, nil
 }
 
+// retainFailedFiles limits a retry to the files that failed to upload
+func retainFailedFiles(submission *models.EvidenceSubmission, failures []models.FailedUpload) error {
+	failed := map[string]bool{}
+	for _, failure := range failures {
+		failed[failure.Filename] = true
+	}
+	files := []models.EvidenceFileRef{}
+	var totalSize int64
+	for _, file := range submission.EvidenceFiles {
+		if failed[file.Filename] {
+			files = append(files, file)
+			totalSize += file.SizeBytes
+		}
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("none of the %d failed file(s) are in the window directory any more", len(failures))
+	}
+	submission.EvidenceFiles = files
+	submission.TotalFileCount = len(files)
+	submission.TotalSizeBytes = totalSize
+	return nil
+}
+
+// mergeRetry applies a retry to the submission it retried: the remaining
+// failures replace the earlier ones and the receipts of both are kept
+func mergeRetry(previous, retry *models.EvidenceSubmission) *models.EvidenceSubmission {
+	merged := *previous
+	merged.Status = retry.Status
+	merged.SubmissionID = retry.SubmissionID
+	merged.SubmittedAt = retry.SubmittedAt
+	merged.FailedFiles = retry.FailedFiles
+	merged.Verification = mergeVerification(previous.Verification, retry.Verification)
+	merged.Transformations = append(previous.Transformations[:len(previous.Transformations):len(previous.Transformations)], retry.Transformations...)
+
+	receipts := map[string]interface{}{}
+	if previous.TugboatResponse != nil {
+		switch earlier := previous.TugboatResponse.Metadata["receipts"].(type) {
+		case map[string]interface{}:
+			for filename, receipt := range earlier {
+				receipts[filename] = receipt
+			}
+		case map[string]string:
+			for filename, receipt := range earlier {
+				receipts[filename] = receipt
+			}
+		}
+	}
+	response := *retry.TugboatResponse
+	response.Metadata = map[string]interface{}{}
+	for key, value := range retry.TugboatResponse.Metadata {
+		response.Metadata[key] = value
+	}
+	if retried, ok := retry.TugboatResponse.Metadata["receipts"].(map[string]string); ok {
+		for filename, receipt := range retried {
+			receipts[filename] = receipt
+		}
+	}
+	if len(receipts) > 0 {
+		response.Metadata["receipts"] = receipts
+	}
+	merged.TugboatResponse = &response
+	return &merged
+}
+
 // filterPreferPDF filters evidence files to prefer PDF over markdown when both exist
 func filterPreferPDF(files []models.EvidenceFileRef) []models.EvidenceFileRef {
 	// Build map of basenames (without extension) that have PDF versions
@@ -274,61 +470,222 @@ func filterPreferPDF(files []models.EvidenceFileRef) []models.EvidenceFileRef {
 	return result
 }
 
-// doSubmit dispatches to the provider-based or legacy submission path.
-func (s *SubmissionService) doSubmit(
-	ctx context.Context,
-	submission *models.EvidenceSubmission,
-	task *domain.EvidenceTask,
-) (*models.TugboatSubmissionResponse, error) {
-	if s.submitter != nil {
-		return s.submitViaProvider(ctx, submission, task)
+// targetFor returns the first target the task is configured for, or the first
+// target when none is, so its configuration error is reported
+func (s *SubmissionService) targetFor(taskRef string) Target {
+	if len(s.targets) == 0 {
+		return nil
+	}
+	for _, target := range s.targets {
+		if _, err := target.Destination(taskRef); err == nil {
+			return target
+		}
 	}
-	return s.submitToTugboat(ctx, submission, task)
+	return s.targets[0]
 }
 
-// submitViaProvider submits evidence files through the EvidenceSubmitter interface.
-func (s *SubmissionService) submitViaProvider(
+// doSubmit uploads each evidence file of the submission to the target, or
+// all of them as one package to a PackageTarget, retrying failed uploads with
+// exponential backoff. Files over a SizeLimitedTarget's limit or of a type a
+// TypeLimitedTarget refuses are compressed, converted or archived, see
+// FitFile, and uploaded in parts with a manifest when still too large; the
+// changes are recorded in the submission's Transformations. Files that still fail are returned and reported in the
+// response metadata; the submission only fails when no file could be
+// uploaded, with an error matching ErrTargetUnreachable when that is because
+// the target could not be reached. What a VerifyingTarget received is
+// checked against the uploads and set as the submission's Verification.
+func (s *SubmissionService) doSubmit(
 	ctx context.Context,
+	target Target,
 	submission *models.EvidenceSubmission,
 	task *domain.EvidenceTask,
-) (*models.TugboatSubmissionResponse, error) {
+	retry bool,
+) (*models.TugboatSubmissionResponse, []models.FailedUpload, error) {
+	if _, err := target.Destination(submission.TaskRef); err != nil {
+		return nil, nil, err
+	}
+
+	// Get storage base directory to resolve file paths
 	baseDir := s.storage.GetBaseDir()
 	submittedFiles := 0
-	failedFiles := []string{}
+	failures := []models.FailedUpload{}
+	receipts := map[string]string{} // Filename -> ID the target assigned
+	splitFiles := map[string]interface{}{}
+	rejected := false            // Set when the target was reached but an upload failed
+	collectionDate := time.Now() // Use current time as collection date
+	var pkg *EvidencePackage
+	sent := []EvidenceFile{}      // Uploads the target accepted, to verify
+	submitted := []EvidenceFile{} // Files submitted, for the manifest
 
+	files := []EvidenceFile{}
 	for _, fileRef := range submission.EvidenceFiles {
 		filePath := filepath.Join(baseDir, fileRef.RelativePath)
-		f, err := os.Open(filePath)
+
+		// Encrypted evidence is uploaded decrypted
+		content, err := storage.ReadFile(filePath)
 		if err != nil {
-			failedFiles = append(failedFiles, fmt.Sprintf("%s: %v", fileRef.Filename, err))
+			failures = append(failures, models.FailedUpload{Filename: fileRef.Filename, Error: err.Error(), LastAttempt: time.Now()})
 			continue
 		}
 
-		meta := interfaces.SubmissionMetadata{
-			CollectedDate: time.Now().Format("2006-01-02"),
+		files = append(files, EvidenceFile{
 			Filename:      fileRef.Filename,
+			Path:          filePath,
 			ContentType:   s.getContentType(fileRef.Filename),
-			Window:        submission.Window,
-			Notes:         submission.Notes,
-		}
+			Content:       content,
+			CollectedDate: collectionDate,
+		})
+	}
 
-		err = s.submitter.SubmitEvidence(ctx, task.ID, f, meta)
-		f.Close()
+	if packageTarget, ok := target.(PackageTarget); ok && len(files) > 0 {
+		manifest, err := s.buildManifest(task, submission, files, collectionDate)
 		if err != nil {
-			failedFiles = append(failedFiles, fmt.Sprintf("%s: %v", fileRef.Filename, err))
-			continue
+			return nil, failures, err
+		}
+		if pkg, err = BuildPackage(manifest, files, collectionDate); err != nil {
+			return nil, failures, err
+		}
+		receipt, attempts, err := s.withRetry(ctx, func() (string, error) {
+			return packageTarget.SubmitPackage(ctx, task, submission, pkg)
+		})
+		if err != nil {
+			// The files travel together, so they fail together
+			for _, file := range files {
+				failures = append(failures, models.FailedUpload{Filename: file.Filename, Error: err.Error(), Attempts: attempts, LastAttempt: time.Now()})
+			}
+			if isUnreachable(err) {
+				return nil, failures, &unreachableError{err}
+			}
+			return nil, failures, err
+		}
+		receipts[pkg.Filename] = receipt
+		sent = append(sent, EvidenceFile{Filename: pkg.Filename, Content: pkg.Content})
+		submitted = files
+		submittedFiles = len(files)
+	} else {
+		var maxSize int64
+		if limited, ok := target.(SizeLimitedTarget); ok {
+			maxSize = limited.MaxFileSize()
+		}
+		accepts := func(string) bool { return true }
+		if typed, ok := target.(TypeLimitedTarget); ok {
+			accepts = typed.AcceptsFile
+		}
+		for _, file := range files {
+			fitted, transformation, err := FitFile(file, maxSize, accepts)
+			if err != nil {
+				failures = append(failures, models.FailedUpload{Filename: file.Filename, Error: err.Error(), LastAttempt: time.Now()})
+				continue
+			}
+			uploads := []EvidenceFile{fitted}
+			if maxSize > 0 && int64(len(fitted.Content)) > maxSize {
+				parts, manifest, err := SplitFile(fitted, maxSize)
+				if err != nil {
+					failures = append(failures, models.FailedUpload{Filename: file.Filename, Error: err.Error(), LastAttempt: time.Now()})
+					continue
+				}
+				uploads = parts
+				splitFiles[file.Filename] = map[string]interface{}{
+					"parts":  len(manifest.Parts),
+					"sha256": manifest.SHA256,
+				}
+				transformation.Steps = append(transformation.Steps, models.TransformSplit)
+				transformation.Details = append(transformation.Details, fmt.Sprintf("%d parts of %s with manifest %s", len(manifest.Parts), fitted.Filename, parts[len(parts)-1].Filename))
+				transformation.UploadedAs = []string{}
+				transformation.UploadedSizeBytes = 0
+				for _, part := range parts {
+					transformation.UploadedAs = append(transformation.UploadedAs, part.Filename)
+					transformation.UploadedSizeBytes += int64(len(part.Content))
+				}
+			}
+
+			// A split file is submitted once all of its parts are; each part
+			// is retried on its own, so a dropped connection costs one part
+			var failure *models.FailedUpload
+			for _, upload := range uploads {
+				receipt, attempts, err := s.withRetry(ctx, func() (string, error) {
+					return target.SubmitFile(ctx, task, submission, upload)
+				})
+				if err != nil {
+					rejected = rejected || !isUnreachable(err)
+					message := err.Error()
+					if len(uploads) > 1 {
+						message = fmt.Sprintf("%s: %s", upload.Filename, message)
+					}
+					failure = &models.FailedUpload{Filename: file.Filename, Error: message, Attempts: attempts, LastAttempt: time.Now()}
+					break
+				}
+				if receipt != "" {
+					receipts[upload.Filename] = receipt
+				}
+				sent = append(sent, upload)
+			}
+			if failure != nil {
+				// Collect error but continue with other files
+				failures = append(failures, *failure)
+				continue
+			}
+			if transformation != nil {
+				submission.Transformations = append(submission.Transformations, *transformation)
+			}
+			submitted = append(submitted, file)
+			submittedFiles++
 		}
-		submittedFiles++
 	}
 
+	failedFiles := []string{}
+	for _, failure := range failures {
+		failedFiles = append(failedFiles, fmt.Sprintf("%s: %s", failure.Filename, failure.Error))
+	}
 	if submittedFiles == 0 {
 		if len(failedFiles) > 0 {
-			return nil, fmt.Errorf("all %d file(s) failed submission:\n  - %s", len(failedFiles), failedFiles[0])
+			err := fmt.Errorf("all %d file(s) failed submission:\n  - %s", len(failedFiles), failedFiles[0])
+			if len(files) > 0 && !rejected {
+				return nil, failures, &unreachableError{err}
+			}
+			return nil, failures, err
 		}
-		return nil, fmt.Errorf("no evidence files to submit")
+		return nil, failures, fmt.Errorf("no evidence files to submit")
 	}
 
-	message := fmt.Sprintf("Successfully submitted %d file(s)", submittedFiles)
+	// Index what was provided; packages already hold the manifest
+	var manifest PackageManifest
+	if pkg != nil {
+		manifest = pkg.Manifest
+	} else {
+		var err error
+		if manifest, err = s.buildManifest(task, submission, submitted, collectionDate); err != nil {
+			return nil, failures, err
+		}
+		uploadedAs := map[string][]string{}
+		for _, transformation := range submission.Transformations {
+			uploadedAs[transformation.Filename] = transformation.UploadedAs
+		}
+		for i, file := range manifest.Files {
+			manifest.Files[i].UploadedAs = uploadedAs[file.Filename]
+		}
+	}
+	if retry {
+		manifest = s.mergeManifest(manifest)
+	}
+	if err := s.saveManifest(manifest); err != nil {
+		fmt.Printf("Warning: failed to save manifest: %v\n", err)
+	}
+	var manifestError error
+	if s.uploadManifest && pkg == nil {
+		file, receipt, err := s.sendManifest(ctx, target, task, submission, manifest, collectionDate)
+		if err != nil {
+			// The evidence is already submitted, so only report it
+			manifestError = err
+		} else {
+			if receipt != "" {
+				receipts[file.Filename] = receipt
+			}
+			sent = append(sent, file)
+		}
+	}
+
+	message := fmt.Sprintf("Successfully submitted %d file(s) to %s", submittedFiles, target.Name())
 	if len(failedFiles) > 0 {
 		message = fmt.Sprintf("Submitted %d file(s), %d failed", submittedFiles, len(failedFiles))
 	}
@@ -339,6 +696,7 @@ func (s *SubmissionService) submitViaProvider(
 		Message:      message,
 		ReceivedAt:   time.Now(),
 		Metadata: map[string]interface{}{
+			"target":          target.Name(),
 			"files_submitted": submittedFiles,
 			"files_failed":    len(failedFiles),
 		},
@@ -346,94 +704,47 @@ func (s *SubmissionService) submitViaProvider(
 	if len(failedFiles) > 0 {
 		response.Metadata["failed_files"] = failedFiles
 	}
-	return response, nil
-}
-
-// submitToTugboat submits evidence to Tugboat Custom Evidence Integration API
-func (s *SubmissionService) submitToTugboat(
-	ctx context.Context,
-	submission *models.EvidenceSubmission,
-	task *domain.EvidenceTask,
-) (*models.TugboatSubmissionResponse, error) {
-	// Get collector URL for this task from config
-	collectorURL, ok := s.collectorURLs[submission.TaskRef]
-	if !ok || collectorURL == "" {
-		return nil, fmt.Errorf("collector URL not configured for task %s - add to tugboat.collector_urls in config", submission.TaskRef)
+	if len(receipts) > 0 {
+		response.Metadata["receipts"] = receipts
 	}
-
-	// Get storage base directory to resolve file paths
-	baseDir := s.storage.GetBaseDir()
-
-	// Submit each evidence file individually
-	// Note: Custom Evidence Integration API accepts one file per submission
-	var lastResponse *tugboat.SubmitEvidenceResponse
-	submittedFiles := 0
-	failedFiles := []string{}
-	collectionDate := time.Now() // Use current time as collection date
-
-	for _, fileRef := range submission.EvidenceFiles {
-		// Resolve full file path
-		filePath := filepath.Join(baseDir, fileRef.RelativePath)
-
-		// Validate file type before submission
-		if err := tugboat.ValidateFileType(fileRef.Filename); err != nil {
-			failedFiles = append(failedFiles, fmt.Sprintf("%s: %v", fileRef.Filename, err))
-			continue
-		}
-
-		// Build submission request for this file
-		submitReq := &tugboat.SubmitEvidenceRequest{
-			CollectorURL:  collectorURL,
-			FilePath:      filePath,
-			CollectedDate: collectionDate,
-			ContentType:   s.getContentType(fileRef.Filename),
-		}
-
-		// Submit to Tugboat
-		resp, err := s.tugboatClient.SubmitEvidence(ctx, submitReq)
-		if err != nil {
-			// Collect error but continue with other files
-			failedFiles = append(failedFiles, fmt.Sprintf("%s: %v", fileRef.Filename, err))
-			continue
-		}
-
-		lastResponse = resp
-		submittedFiles++
+	if len(splitFiles) > 0 {
+		response.Metadata["split_files"] = splitFiles
 	}
-
-	if submittedFiles == 0 {
-		if len(failedFiles) > 0 {
-			return nil, fmt.Errorf("all %d file(s) failed submission:\n  - %s", len(failedFiles), failedFiles[0])
+	if manifestError != nil {
+		response.Metadata["manifest_error"] = manifestError.Error()
+	}
+	if pkg != nil {
+		response.Metadata["package"] = map[string]interface{}{
+			"filename":   pkg.Filename,
+			"sha256":     pkg.SHA256,
+			"size_bytes": len(pkg.Content),
 		}
-		return nil, fmt.Errorf("no evidence files to submit")
 	}
 
-	// Build response message
-	message := fmt.Sprintf("Successfully submitted %d file(s) to Tugboat", submittedFiles)
-	if len(failedFiles) > 0 {
-		message = fmt.Sprintf("Submitted %d file(s), %d failed", submittedFiles, len(failedFiles))
-	}
-
-	// Return response from last submission
-	// Note: In the Custom Evidence Integration API, each file is submitted separately
-	// so we track the last successful response
-	response := &models.TugboatSubmissionResponse{
-		SubmissionID: fmt.Sprintf("batch-%d-files-%d", time.Now().Unix(), submittedFiles),
-		Status:       "submitted",
-		Message:      message,
-		ReceivedAt:   lastResponse.ReceivedAt,
-		Metadata: map[string]interface{}{
-			"files_submitted": submittedFiles,
-			"files_failed":    len(failedFiles),
-		},
+	// Read back what the target received and record it on the submission
+	if verifier, ok := target.(VerifyingTarget); ok {
+		submission.Verification = s.verifySubmission(ctx, verifier, task, sent, collectionDate)
 	}
+	return response, failures, nil
+}
 
-	// Add failed files to metadata if any
-	if len(failedFiles) > 0 {
-		response.Metadata["failed_files"] = failedFiles
+// withRetry calls upload until it succeeds, the context ends, or it has been
+// retried s.retries times, doubling the wait between attempts. It returns the
+// number of attempts made.
+func (s *SubmissionService) withRetry(ctx context.Context, upload func() (string, error)) (string, int, error) {
+	backoff := s.retryBackoff
+	for attempt := 1; ; attempt++ {
+		receipt, err := upload()
+		if err == nil || attempt > s.retries || ctx.Err() != nil {
+			return receipt, attempt, err
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return "", attempt, err
+		}
+		backoff *= 2
 	}
-
-	return response, nil
 }
 
 // getContentType determines the MIME type based on file extension
@@ -460,6 +771,7 @@ func (s *SubmissionService) getContentType(filename string) string {
 		"xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
 		"odt":  "application/vnd.oasis.opendocument.text",
 		"ods":  "application/vnd.oasis.opendocument.spreadsheet",
+		"zip":  "application/zip",
 	}
 
 	if ct, ok := contentTypes[ext]; ok {
@@ -316,7 +316,12 @@ func TestNewSubmissionService(t *testing.T) {
 	svc := NewSubmissionService(nil, nil, "test-org", collectorURLs)
 	require.NotNil(t, svc)
 	assert.Equal(t, "test-org", svc.orgID)
-	assert.Equal(t, collectorURLs, svc.collectorURLs)
+	assert.Empty(t, svc.targets, "no client saves drafts locally")
+
+	svc = NewSubmissionService(nil, &tugboat.Client{}, "test-org", collectorURLs)
+	require.Len(t, svc.targets, 1)
+	require.IsType(t, &tugboatTarget{}, svc.targets[0])
+	assert.Equal(t, collectorURLs, svc.targets[0].(*tugboatTarget).collectorURLs)
 }
 
 func TestNewSubmissionServiceWithRegistry_Success(t *testing.T) {
@@ -328,8 +333,9 @@ func TestNewSubmissionServiceWithRegistry_Success(t *testing.T) {
 	svc, err := NewSubmissionServiceWithRegistry(nil, reg, "test-submitter", collectorURLs)
 	require.NoError(t, err)
 	require.NotNil(t, svc)
-	assert.NotNil(t, svc.submitter, "submitter should be resolved from registry")
-	assert.Nil(t, svc.tugboatClient, "legacy client should be nil")
+	require.Len(t, svc.targets, 1)
+	require.IsType(t, &providerTarget{}, svc.targets[0], "submitter should be resolved from registry")
+	assert.Equal(t, "test-submitter", svc.targets[0].Name())
 }
 
 func TestNewSubmissionServiceWithRegistry_ProviderNotFound(t *testing.T) {
@@ -387,7 +393,7 @@ func TestSubmit_MissingCollectorURL(t *testing.T) {
 	}
 	tugboatClient := tugboat.NewClient(tugboatCfg, nil)
 
-	// Create service with empty collector URLs (will cause submitToTugboat to fail)
+	// Create service with empty collector URLs (will cause the Tugboat target to fail)
 	svc := NewSubmissionService(st, tugboatClient, "42", map[string]string{})
 
 	_, err := svc.Submit(context.Background(), &SubmitRequest{
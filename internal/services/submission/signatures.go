@@ -0,0 +1,54 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submission
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/signing"
+	"github.com/grctool/grctool/internal/storage"
+)
+
+// SetSigner makes validation and Submit verify the signatures made when the
+// evidence was written with signer
+func (s *SubmissionService) SetSigner(signer signing.Signer) {
+	s.signer = signer
+	s.validator.SetSigner(signer)
+}
+
+// verifySignatures refuses evidence that changed after it was signed. Unsigned
+// files pass, as validation already warns of them.
+func (s *SubmissionService) verifySignatures(ctx context.Context, taskRef, window string, files []models.EvidenceFileRef) error {
+	filenames := make([]string, 0, len(files))
+	for _, file := range files {
+		filenames = append(filenames, file.Filename)
+	}
+
+	var invalid []string
+	for _, signature := range signing.VerifyWindow(ctx, s.signer, s.storage.EvidenceWindowDir(taskRef, window), filenames, storage.ReadFile) {
+		if signature.Status == signing.SignatureInvalid {
+			invalid = append(invalid, signature.File)
+		}
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("evidence for %s/%s fails %s signature verification: %s changed after it was signed",
+			taskRef, window, s.signer.Method(), strings.Join(invalid, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package submission
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/grctool/grctool/internal/signing"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// checksumSigner "signs" with a plain checksum
+type checksumSigner struct{}
+
+func (checksumSigner) Method() string { return "checksum" }
+
+func (checksumSigner) Sign(_ context.Context, data []byte) ([]byte, error) {
+	sum := sha256.Sum256(data)
+	return []byte(hex.EncodeToString(sum[:])), nil
+}
+
+func (s checksumSigner) Verify(ctx context.Context, data, signature []byte) error {
+	if want, _ := s.Sign(ctx, data); string(want) != string(signature) {
+		return errors.New("BAD signature")
+	}
+	return nil
+}
+
+func TestSubmit_VerifiesSignatures(t *testing.T) {
+	t.Parallel()
+	st, tmpDir := setupTestStorage(t)
+	writeEvidence(t, tmpDir, map[string]string{"access.csv": "user,role\n", "groups.csv": "group\n"})
+	windowDir := filepath.Join(tmpDir, "evidence", "ET-0047", "2025-Q4")
+	require.NoError(t, signing.SignFile(context.Background(), checksumSigner{}, windowDir, "access.csv", storage.ReadFile))
+
+	target := &stubTarget{}
+	svc := NewSubmissionServiceWithTargets(st, target)
+	svc.SetSigner(checksumSigner{})
+
+	// Altered after signing
+	writeEvidence(t, tmpDir, map[string]string{"access.csv": "user,role\nalex,admin\n"})
+	resp, err := svc.Submit(context.Background(), &SubmitRequest{TaskRef: "ET-0047", Window: "2025-Q4"})
+	require.NoError(t, err)
+	assert.Equal(t, "validation_failed", resp.Status)
+	require.Len(t, resp.ValidationResult.Errors, 1)
+	assert.Equal(t, "access.csv does not match its checksum signature: BAD signature", resp.ValidationResult.Errors[0].Message)
+
+	_, err = svc.Submit(context.Background(), &SubmitRequest{TaskRef: "ET-0047", Window: "2025-Q4", SkipValidation: true})
+	assert.EqualError(t, err, "evidence for ET-0047/2025-Q4 fails checksum signature verification: access.csv changed after it was signed")
+	assert.Empty(t, target.files)
+
+	// Unsigned files are only a warning
+	writeEvidence(t, tmpDir, map[string]string{"access.csv": "user,role\n"})
+	resp, err = svc.Submit(context.Background(), &SubmitRequest{TaskRef: "ET-0047", Window: "2025-Q4"})
+	require.NoError(t, err)
+	assert.Equal(t, "submitted", resp.Status)
+	assert.Contains(t, resp.ValidationResult.WarningsList[len(resp.ValidationResult.WarningsList)-1].Message, "groups.csv is not signed")
+	assert.Len(t, target.files, 2)
+}
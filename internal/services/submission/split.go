@@ -0,0 +1,106 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submission
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// SplitManifest ties the parts of an evidence file that was split for a
+// target's size limit together, so the recipient can reassemble the file and
+// verify it
+type SplitManifest struct {
+	Filename    string        `json:"filename"`
+	ContentType string        `json:"content_type"`
+	SizeBytes   int64         `json:"size_bytes"`
+	SHA256      string        `json:"sha256"`
+	Reassemble  string        `json:"reassemble"`
+	Parts       []PackageFile `json:"parts"`
+}
+
+// SplitFile splits an evidence file into parts of at most maxSize bytes,
+// named like report.part1of3.csv so targets still accept their type, and
+// returns them followed by a manifest named report.parts.json. Text is split
+// after a line break where there is one, so each part can be read on its own.
+// The parts concatenated in order are the original file.
+func SplitFile(file EvidenceFile, maxSize int64) ([]EvidenceFile, *SplitManifest, error) {
+	if maxSize <= 0 {
+		return nil, nil, fmt.Errorf("invalid part size %d", maxSize)
+	}
+	content := file.Content
+	text := strings.HasPrefix(file.ContentType, "text/") || file.ContentType == "application/json"
+
+	var chunks [][]byte
+	for offset := int64(0); offset < int64(len(content)); {
+		end := min(offset+maxSize, int64(len(content)))
+		if text && end < int64(len(content)) {
+			if i := bytes.LastIndexByte(content[offset:end], '\n'); i >= 0 {
+				end = offset + int64(i) + 1
+			}
+		}
+		chunks = append(chunks, content[offset:end])
+		offset = end
+	}
+
+	ext := filepath.Ext(file.Filename)
+	base := strings.TrimSuffix(file.Filename, ext)
+	sum := sha256.Sum256(content)
+	manifest := &SplitManifest{
+		Filename:    file.Filename,
+		ContentType: file.ContentType,
+		SizeBytes:   int64(len(content)),
+		SHA256:      hex.EncodeToString(sum[:]),
+		Parts:       []PackageFile{},
+	}
+	parts := []EvidenceFile{}
+	names := []string{}
+	for i, chunk := range chunks {
+		part := file
+		part.Filename = fmt.Sprintf("%s.part%dof%d%s", base, i+1, len(chunks), ext)
+		part.Content = chunk
+		parts = append(parts, part)
+		names = append(names, part.Filename)
+
+		sum := sha256.Sum256(chunk)
+		manifest.Parts = append(manifest.Parts, PackageFile{
+			Filename:    part.Filename,
+			ContentType: part.ContentType,
+			SizeBytes:   int64(len(chunk)),
+			SHA256:      hex.EncodeToString(sum[:]),
+			CollectedAt: file.CollectedDate.UTC(),
+		})
+	}
+	manifest.Reassemble = fmt.Sprintf("cat %s > %s", strings.Join(names, " "), file.Filename)
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal parts manifest: %w", err)
+	}
+	parts = append(parts, EvidenceFile{
+		Filename:      base + ".parts.json",
+		Path:          file.Path,
+		ContentType:   "application/json",
+		Content:       data,
+		CollectedDate: file.CollectedDate,
+	})
+	return parts, manifest, nil
+}
@@ -0,0 +1,582 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submission
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/delivery"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/drata"
+	"github.com/grctool/grctool/internal/hyperproof"
+	"github.com/grctool/grctool/internal/interfaces"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/secureframe"
+	"github.com/grctool/grctool/internal/tugboat"
+	tugboatmodels "github.com/grctool/grctool/internal/tugboat/models"
+	"github.com/grctool/grctool/internal/vanta"
+)
+
+// Submission target names
+const (
+	TargetTugboat     = "tugboat"
+	TargetVanta       = "vanta"
+	TargetDrata       = "drata"
+	TargetSecureframe = "secureframe"
+	TargetHyperproof  = "hyperproof"
+	TargetDelivery    = "delivery"
+)
+
+// Target is a GRC platform evidence files are uploaded to
+type Target interface {
+	// Name returns the display name of the target, e.g. "Tugboat"
+	Name() string
+
+	// Destination returns where the task's evidence is uploaded, or an error
+	// naming the config to add when the task is not mapped to the target
+	Destination(taskRef string) (string, error)
+
+	// SubmitFile uploads one evidence file of the submission and returns the
+	// ID the target assigned to it, empty when the target returns none
+	SubmitFile(ctx context.Context, task *domain.EvidenceTask, submission *models.EvidenceSubmission, file EvidenceFile) (string, error)
+}
+
+// PackageTarget is a Target that takes all files of a submission at once, as
+// an evidence package, rather than file by file
+type PackageTarget interface {
+	Target
+
+	// SubmitPackage uploads the package and returns the receipt of the upload
+	SubmitPackage(ctx context.Context, task *domain.EvidenceTask, submission *models.EvidenceSubmission, pkg *EvidencePackage) (string, error)
+}
+
+// SizeLimitedTarget is a Target that rejects files over a size limit. Larger
+// files are compressed where that makes them fit, see FitFile, and split into
+// parts the target accepts otherwise, see SplitFile.
+type SizeLimitedTarget interface {
+	Target
+
+	// MaxFileSize returns the largest file in bytes the target accepts
+	MaxFileSize() int64
+}
+
+// VerifyingTarget is a Target that can list the files it received, so a
+// submission can be checked against what was sent
+type VerifyingTarget interface {
+	Target
+
+	// ReceivedFiles returns the files the target received for the task's
+	// evidence since the given time, newest first
+	ReceivedFiles(ctx context.Context, task *domain.EvidenceTask, since time.Time) ([]ReceivedFile, error)
+}
+
+// WithdrawingTarget is a Target that can remove the files of an earlier
+// submission, so a withdrawn submission is withdrawn on the target as well
+type WithdrawingTarget interface {
+	Target
+
+	// WithdrawSubmission removes the files the submission uploaded from the
+	// target and returns the names of the files removed
+	WithdrawSubmission(ctx context.Context, task *domain.EvidenceTask, submission *models.EvidenceSubmission) ([]string, error)
+}
+
+// ReceivedFile is a file a target reports having received
+type ReceivedFile struct {
+	Filename  string
+	SizeBytes int64 // -1 when the target cannot report it
+}
+
+// EvidenceFile is an evidence file read for upload
+type EvidenceFile struct {
+	Filename      string
+	Path          string // Absolute path of the file in the data directory
+	ContentType   string
+	Content       []byte // Decrypted content
+	CollectedDate time.Time
+}
+
+// tugboatTarget uploads to Tugboat Custom Evidence Integration collectors
+type tugboatTarget struct {
+	client        *tugboat.Client
+	collectorURLs map[string]string // TaskRef -> Collector URL mapping
+}
+
+// NewTugboatTarget creates a target uploading to the Tugboat collector URL
+// configured for each task
+func NewTugboatTarget(client *tugboat.Client, collectorURLs map[string]string) Target {
+	return &tugboatTarget{client: client, collectorURLs: collectorURLs}
+}
+
+func (t *tugboatTarget) Name() string {
+	return "Tugboat"
+}
+
+func (t *tugboatTarget) MaxFileSize() int64 {
+	return tugboat.MaxFileSize
+}
+
+func (t *tugboatTarget) AcceptsFile(filename string) bool {
+	ext := strings.ToLower(filepath.Ext(filename))
+	for _, supported := range tugboat.SupportedFileTypes {
+		if ext == supported {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *tugboatTarget) Destination(taskRef string) (string, error) {
+	collectorURL := t.collectorURLs[taskRef]
+	if collectorURL == "" {
+		return "", fmt.Errorf("collector URL not configured for task %s - add to tugboat.collector_urls in config", taskRef)
+	}
+	return collectorURL, nil
+}
+
+func (t *tugboatTarget) SubmitFile(ctx context.Context, task *domain.EvidenceTask, submission *models.EvidenceSubmission, file EvidenceFile) (string, error) {
+	collectorURL, err := t.Destination(submission.TaskRef)
+	if err != nil {
+		return "", err
+	}
+	if err := tugboat.ValidateFileType(file.Filename); err != nil {
+		return "", err
+	}
+	// Note: Custom Evidence Integration API accepts one file per submission
+	// and returns no ID for it
+	_, err = t.client.SubmitEvidence(ctx, &tugboat.SubmitEvidenceRequest{
+		CollectorURL:  collectorURL,
+		FilePath:      file.Path,
+		Content:       file.Content,
+		Filename:      file.Filename,
+		CollectedDate: file.CollectedDate,
+		ContentType:   file.ContentType,
+	})
+	return "", err
+}
+
+// tugboatClockSkew allows for Tugboat's clock being behind ours when
+// matching attachments to the upload that created them
+const tugboatClockSkew = 5 * time.Minute
+
+// ReceivedFiles lists the task's file attachments created since the upload
+// started. Tugboat does not report sizes, so each is read from the file.
+func (t *tugboatTarget) ReceivedFiles(ctx context.Context, task *domain.EvidenceTask, since time.Time) ([]ReceivedFile, error) {
+	attachments, err := t.attachmentsSince(ctx, task, since)
+	if err != nil {
+		return nil, err
+	}
+
+	received := []ReceivedFile{}
+	for _, attachment := range attachments {
+		size, err := t.client.GetAttachmentSize(ctx, attachment.ID)
+		if err != nil {
+			size = -1
+		}
+		received = append(received, ReceivedFile{Filename: attachment.Attachment.OriginalFilename, SizeBytes: size})
+	}
+	return received, nil
+}
+
+// WithdrawSubmission deletes the task's file attachments created by the
+// submission, matched by the names of the files it uploaded
+func (t *tugboatTarget) WithdrawSubmission(ctx context.Context, task *domain.EvidenceTask, submission *models.EvidenceSubmission) ([]string, error) {
+	// Verification names each upload, including the parts of split files
+	uploaded := map[string]bool{}
+	if submission.Verification != nil && len(submission.Verification.Files) > 0 {
+		for _, file := range submission.Verification.Files {
+			uploaded[file.Filename] = file.Status != "missing"
+		}
+	} else {
+		for _, file := range submission.UploadedFiles() {
+			uploaded[file.Filename] = true
+		}
+	}
+
+	attachments, err := t.attachmentsSince(ctx, task, submission.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	removed := []string{}
+	for _, attachment := range attachments {
+		filename := attachment.Attachment.OriginalFilename
+		if !uploaded[filename] {
+			continue
+		}
+		if err := t.client.DeleteEvidenceAttachment(ctx, attachment.ID); err != nil {
+			return removed, err
+		}
+		removed = append(removed, filename)
+	}
+	return removed, nil
+}
+
+// attachmentsSince returns the task's file attachments created since the
+// given time, allowing for clock skew
+func (t *tugboatTarget) attachmentsSince(ctx context.Context, task *domain.EvidenceTask, since time.Time) ([]tugboatmodels.EvidenceAttachment, error) {
+	taskID, err := strconv.Atoi(task.ID)
+	if err != nil {
+		return nil, fmt.Errorf("task %s has no Tugboat ID", task.ReferenceID)
+	}
+	// Evidence is collected on the day it is submitted
+	attachments, err := t.client.GetEvidenceAttachmentsByTaskAndWindow(ctx, taskID,
+		since.Format("2006-01-02"), time.Now().Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+
+	files := []tugboatmodels.EvidenceAttachment{}
+	for _, attachment := range attachments {
+		if attachment.Type != "file" || attachment.Deleted || attachment.Attachment == nil {
+			continue
+		}
+		if created, err := time.Parse(time.RFC3339, attachment.Created); err == nil && created.Before(since.Add(-tugboatClockSkew)) {
+			continue
+		}
+		files = append(files, attachment)
+	}
+	return files, nil
+}
+
+// vantaTarget uploads to Vanta custom evidence documents
+type vantaTarget struct {
+	client      *vanta.Client
+	documentIDs map[string]string // TaskRef -> Vanta document ID mapping
+}
+
+// NewVantaTarget creates a target uploading to the Vanta document configured
+// for each task
+func NewVantaTarget(client *vanta.Client, documentIDs map[string]string) Target {
+	return &vantaTarget{client: client, documentIDs: documentIDs}
+}
+
+func (t *vantaTarget) Name() string {
+	return "Vanta"
+}
+
+func (t *vantaTarget) MaxFileSize() int64 {
+	return vanta.MaxFileSize
+}
+
+func (t *vantaTarget) Destination(taskRef string) (string, error) {
+	documentID := t.documentIDs[taskRef]
+	if documentID == "" {
+		return "", fmt.Errorf("vanta document ID not configured for task %s - add to vanta.document_ids in config", taskRef)
+	}
+	return documentID, nil
+}
+
+func (t *vantaTarget) SubmitFile(ctx context.Context, task *domain.EvidenceTask, submission *models.EvidenceSubmission, file EvidenceFile) (string, error) {
+	documentID, err := t.Destination(submission.TaskRef)
+	if err != nil {
+		return "", err
+	}
+	resp, err := t.client.UploadDocumentFile(ctx, &vanta.UploadRequest{
+		DocumentID:    documentID,
+		Filename:      file.Filename,
+		Content:       file.Content,
+		ContentType:   file.ContentType,
+		EffectiveDate: file.CollectedDate,
+		Description:   submissionDescription(task, submission),
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// WithdrawSubmission deletes the uploads recorded in the submission's
+// receipts from the task's Vanta document
+func (t *vantaTarget) WithdrawSubmission(ctx context.Context, task *domain.EvidenceTask, submission *models.EvidenceSubmission) ([]string, error) {
+	documentID, err := t.Destination(submission.TaskRef)
+	if err != nil {
+		return nil, err
+	}
+	receipts := submissionReceipts(submission)
+	filenames := make([]string, 0, len(receipts))
+	for filename := range receipts {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	removed := []string{}
+	for _, filename := range filenames {
+		if err := t.client.DeleteDocumentUpload(ctx, documentID, receipts[filename]); err != nil {
+			return removed, err
+		}
+		removed = append(removed, filename)
+	}
+	return removed, nil
+}
+
+// drataTarget uploads to Drata controls as external evidence
+type drataTarget struct {
+	client     *drata.Client
+	controlIDs map[string]string // TaskRef -> Drata control ID mapping
+}
+
+// NewDrataTarget creates a target uploading external evidence to the Drata
+// control configured for each task
+func NewDrataTarget(client *drata.Client, controlIDs map[string]string) Target {
+	return &drataTarget{client: client, controlIDs: controlIDs}
+}
+
+func (t *drataTarget) Name() string {
+	return "Drata"
+}
+
+func (t *drataTarget) MaxFileSize() int64 {
+	return drata.MaxFileSize
+}
+
+func (t *drataTarget) Destination(taskRef string) (string, error) {
+	controlID := t.controlIDs[taskRef]
+	if controlID == "" {
+		return "", fmt.Errorf("drata control ID not configured for task %s - add to drata.control_ids in config", taskRef)
+	}
+	return controlID, nil
+}
+
+func (t *drataTarget) SubmitFile(ctx context.Context, task *domain.EvidenceTask, submission *models.EvidenceSubmission, file EvidenceFile) (string, error) {
+	controlID, err := t.Destination(submission.TaskRef)
+	if err != nil {
+		return "", err
+	}
+	// Evidence is due for renewal when the task's next window opens
+	resp, err := t.client.UploadExternalEvidence(ctx, &drata.EvidenceRequest{
+		ControlID:       controlID,
+		Name:            fmt.Sprintf("%s %s: %s", submission.TaskRef, submission.Window, file.Filename),
+		Description:     submissionDescription(task, submission),
+		Filename:        file.Filename,
+		Content:         file.Content,
+		ContentType:     file.ContentType,
+		CreationDate:    file.CollectedDate,
+		RenewalSchedule: drata.RenewalSchedule(task.CollectionInterval),
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.ID.String(), nil
+}
+
+// secureframeTarget uploads to Secureframe tests
+type secureframeTarget struct {
+	client  *secureframe.Client
+	testIDs map[string]string // TaskRef -> Secureframe test ID mapping
+}
+
+// NewSecureframeTarget creates a target uploading to the Secureframe test
+// configured for each task
+func NewSecureframeTarget(client *secureframe.Client, testIDs map[string]string) Target {
+	return &secureframeTarget{client: client, testIDs: testIDs}
+}
+
+func (t *secureframeTarget) Name() string {
+	return "Secureframe"
+}
+
+func (t *secureframeTarget) MaxFileSize() int64 {
+	return secureframe.MaxFileSize
+}
+
+func (t *secureframeTarget) Destination(taskRef string) (string, error) {
+	testID := t.testIDs[taskRef]
+	if testID == "" {
+		return "", fmt.Errorf("secureframe test ID not configured for task %s - add to secureframe.test_ids in config", taskRef)
+	}
+	return testID, nil
+}
+
+func (t *secureframeTarget) SubmitFile(ctx context.Context, task *domain.EvidenceTask, submission *models.EvidenceSubmission, file EvidenceFile) (string, error) {
+	testID, err := t.Destination(submission.TaskRef)
+	if err != nil {
+		return "", err
+	}
+	resp, err := t.client.UploadTestEvidence(ctx, &secureframe.EvidenceRequest{
+		TestID:      testID,
+		Filename:    file.Filename,
+		Content:     file.Content,
+		ContentType: file.ContentType,
+		CollectedAt: file.CollectedDate,
+		Description: submissionDescription(task, submission),
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// hyperproofTarget uploads proof to Hyperproof labels or controls
+type hyperproofTarget struct {
+	client  *hyperproof.Client
+	targets map[string]hyperproof.ProofTarget // TaskRef -> label or control
+}
+
+// NewHyperproofTarget creates a target uploading proof to the Hyperproof
+// label or control configured for each task
+func NewHyperproofTarget(client *hyperproof.Client, targets map[string]hyperproof.ProofTarget) Target {
+	return &hyperproofTarget{client: client, targets: targets}
+}
+
+func (t *hyperproofTarget) Name() string {
+	return "Hyperproof"
+}
+
+func (t *hyperproofTarget) MaxFileSize() int64 {
+	return hyperproof.MaxFileSize
+}
+
+func (t *hyperproofTarget) Destination(taskRef string) (string, error) {
+	target, ok := t.targets[taskRef]
+	if !ok {
+		return "", fmt.Errorf("hyperproof label or control not configured for task %s - add to hyperproof.label_ids or hyperproof.control_ids in config", taskRef)
+	}
+	return target.String(), nil
+}
+
+func (t *hyperproofTarget) SubmitFile(ctx context.Context, task *domain.EvidenceTask, submission *models.EvidenceSubmission, file EvidenceFile) (string, error) {
+	target, ok := t.targets[submission.TaskRef]
+	if !ok {
+		_, err := t.Destination(submission.TaskRef)
+		return "", err
+	}
+	// The source ID stays the same across resubmissions of the file
+	resp, err := t.client.UploadProof(ctx, &hyperproof.ProofRequest{
+		Target:      target,
+		Filename:    file.Filename,
+		Content:     file.Content,
+		ContentType: file.ContentType,
+		Metadata: hyperproof.ProofMetadata{
+			SourceID:    fmt.Sprintf("grctool/%s/%s/%s", submission.TaskRef, submission.Window, file.Filename),
+			Source:      "grctool",
+			CollectedAt: file.CollectedDate,
+			Description: submissionDescription(task, submission),
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// deliveryTarget ships evidence packages to an SFTP server, S3 bucket or
+// webhook
+type deliveryTarget struct {
+	urls map[string]string // TaskRef -> delivery URL
+	open func(rawURL string) (delivery.Deliverer, error)
+}
+
+// NewDeliveryTarget creates a target delivering evidence packages to the
+// delivery URL configured for each task
+func NewDeliveryTarget(cfg config.DeliveryConfig) Target {
+	return &deliveryTarget{
+		urls: cfg.URLs,
+		open: func(rawURL string) (delivery.Deliverer, error) {
+			return delivery.New(rawURL, cfg)
+		},
+	}
+}
+
+func (t *deliveryTarget) Name() string {
+	return "evidence dropbox"
+}
+
+func (t *deliveryTarget) Destination(taskRef string) (string, error) {
+	rawURL, ok := t.urls[taskRef]
+	if !ok {
+		return "", fmt.Errorf("delivery URL not configured for task %s - add to delivery.urls in config", taskRef)
+	}
+	// Never show a password given in the URL
+	if u, err := url.Parse(rawURL); err == nil {
+		return u.Redacted(), nil
+	}
+	return rawURL, nil
+}
+
+func (t *deliveryTarget) SubmitFile(ctx context.Context, task *domain.EvidenceTask, submission *models.EvidenceSubmission, file EvidenceFile) (string, error) {
+	files, now := []EvidenceFile{file}, time.Now()
+	pkg, err := BuildPackage(BuildManifest(task, submission, files, nil, now), files, now)
+	if err != nil {
+		return "", err
+	}
+	return t.SubmitPackage(ctx, task, submission, pkg)
+}
+
+func (t *deliveryTarget) SubmitPackage(ctx context.Context, task *domain.EvidenceTask, submission *models.EvidenceSubmission, pkg *EvidencePackage) (string, error) {
+	rawURL, ok := t.urls[submission.TaskRef]
+	if !ok {
+		_, err := t.Destination(submission.TaskRef)
+		return "", err
+	}
+	deliverer, err := t.open(rawURL)
+	if err != nil {
+		return "", err
+	}
+	receipt, err := deliverer.Deliver(ctx, pkg.Filename, pkg.Content)
+	if err != nil {
+		return "", err
+	}
+	return receipt.String(), nil
+}
+
+// submissionDescription describes the submission's evidence for targets that
+// show a description with each file
+func submissionDescription(task *domain.EvidenceTask, submission *models.EvidenceSubmission) string {
+	description := fmt.Sprintf("%s %s evidence for window %s", submission.TaskRef, task.Name, submission.Window)
+	if submission.Notes != "" {
+		description += ": " + submission.Notes
+	}
+	return description
+}
+
+// providerTarget uploads through an EvidenceSubmitter resolved from the
+// provider registry, for tasks with a Tugboat collector URL
+type providerTarget struct {
+	name          string
+	submitter     interfaces.EvidenceSubmitter
+	collectorURLs map[string]string // TaskRef -> Collector URL mapping
+}
+
+func (t *providerTarget) Name() string {
+	return t.name
+}
+
+func (t *providerTarget) Destination(taskRef string) (string, error) {
+	collectorURL := t.collectorURLs[taskRef]
+	if collectorURL == "" {
+		return "", fmt.Errorf("collector URL not configured for task %s - add to tugboat.collector_urls in config", taskRef)
+	}
+	return collectorURL, nil
+}
+
+func (t *providerTarget) SubmitFile(ctx context.Context, task *domain.EvidenceTask, submission *models.EvidenceSubmission, file EvidenceFile) (string, error) {
+	meta := interfaces.SubmissionMetadata{
+		CollectedDate: file.CollectedDate.Format("2006-01-02"),
+		Filename:      file.Filename,
+		ContentType:   file.ContentType,
+		Window:        submission.Window,
+		Notes:         submission.Notes,
+	}
+	return "", t.submitter.SubmitEvidence(ctx, task.ID, bytes.NewReader(file.Content), meta)
+}
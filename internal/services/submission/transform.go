@@ -0,0 +1,317 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submission
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // Decoded for compression
+	"image/jpeg"
+	_ "image/png" // Decoded for compression
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/grctool/grctool/internal/models"
+)
+
+// TypeLimitedTarget is a Target that only takes some file types. Other files
+// are converted or archived into a type it takes, see FitFile.
+type TypeLimitedTarget interface {
+	Target
+
+	// AcceptsFile reports whether the target takes files with the name's
+	// extension
+	AcceptsFile(filename string) bool
+}
+
+// FitFile changes an evidence file the target would refuse, for its type or
+// for being over maxSize, into one it takes: oversize images are re-encoded
+// as smaller JPEGs, JSON records are flattened into a CSV, text of a type the
+// target refuses is uploaded as .txt, and what is still refused is compressed
+// into a zip archive. The file returned may still be over maxSize, to be split
+// with SplitFile. The transformation records the steps taken; it is nil when
+// the file fits as it is. accepts reports whether the target takes a file
+// name's type.
+func FitFile(file EvidenceFile, maxSize int64, accepts func(filename string) bool) (EvidenceFile, *models.FileTransformation, error) {
+	oversize := func(f EvidenceFile) bool { return maxSize > 0 && int64(len(f.Content)) > maxSize }
+	if accepts(file.Filename) && !oversize(file) {
+		return file, nil, nil
+	}
+
+	var reasons []string
+	if oversize(file) {
+		reasons = append(reasons, fmt.Sprintf("%d bytes, over the upload limit of %d", len(file.Content), maxSize))
+	}
+	if !accepts(file.Filename) {
+		reasons = append(reasons, fmt.Sprintf("the target does not take %s files", strings.ToLower(filepath.Ext(file.Filename))))
+	}
+	sum := sha256.Sum256(file.Content)
+	transformation := &models.FileTransformation{
+		Filename:          file.Filename,
+		Reason:            strings.Join(reasons, "; "),
+		Steps:             []string{},
+		OriginalSizeBytes: int64(len(file.Content)),
+		OriginalSHA256:    hex.EncodeToString(sum[:]),
+	}
+	fitted := file
+	apply := func(next EvidenceFile, step, detail string) {
+		fitted = next
+		transformation.Steps = append(transformation.Steps, step)
+		transformation.Details = append(transformation.Details, detail)
+	}
+	// smaller keeps a step when it helps: the result is smaller, or the
+	// current file is refused anyway
+	smaller := func(next EvidenceFile) bool {
+		return accepts(next.Filename) && (len(next.Content) < len(fitted.Content) || !accepts(fitted.Filename))
+	}
+
+	if oversize(fitted) && isImage(fitted.Filename) {
+		if compressed, detail, err := compressImage(fitted, maxSize); err == nil && smaller(compressed) {
+			apply(compressed, models.TransformCompressedImage, detail)
+		}
+	}
+	if (oversize(fitted) || !accepts(fitted.Filename)) && strings.EqualFold(filepath.Ext(fitted.Filename), ".json") {
+		if converted, detail, err := jsonToCSV(fitted); err == nil && smaller(converted) {
+			apply(converted, models.TransformConvertedToCSV, detail)
+		}
+	}
+	if !accepts(fitted.Filename) && isText(fitted.Content) && accepts(fitted.Filename+".txt") {
+		renamed := fitted
+		renamed.Filename += ".txt"
+		renamed.ContentType = "text/plain"
+		apply(renamed, models.TransformRenamedToText, "uploaded as "+renamed.Filename)
+	}
+	if oversize(fitted) || !accepts(fitted.Filename) {
+		if archived, err := archiveFile(fitted); err == nil && smaller(archived) {
+			apply(archived, models.TransformArchived, fmt.Sprintf("zip archive of %s, %d bytes", fitted.Filename, len(archived.Content)))
+		}
+	}
+	if !accepts(fitted.Filename) {
+		return file, nil, fmt.Errorf("the target does not take %s files and %s could not be converted", filepath.Ext(file.Filename), file.Filename)
+	}
+
+	transformation.UploadedAs = []string{fitted.Filename}
+	transformation.UploadedSizeBytes = int64(len(fitted.Content))
+	return fitted, transformation, nil
+}
+
+func isImage(filename string) bool {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".png", ".jpg", ".jpeg", ".gif":
+		return true
+	}
+	return false
+}
+
+func isText(content []byte) bool {
+	return utf8.Valid(content) && bytes.IndexByte(content, 0) < 0
+}
+
+// jpegQualities are tried in order at each scale until an image fits
+var jpegQualities = []int{85, 70, 55, 40}
+
+// compressImage re-encodes an image as a JPEG, at lower quality and then at
+// half the size each time, until it fits in maxSize or is 256 pixels on its
+// shorter side. It returns the smallest encoding when none fits. Transparent
+// areas are flattened onto white.
+func compressImage(file EvidenceFile, maxSize int64) (EvidenceFile, string, error) {
+	src, format, err := image.Decode(bytes.NewReader(file.Content))
+	if err != nil {
+		return file, "", fmt.Errorf("failed to decode %s: %w", file.Filename, err)
+	}
+	bounds := src.Bounds()
+	img := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(img, img.Bounds(), src, bounds.Min, draw.Over)
+
+	var best []byte
+	var bestQuality int
+	var bestBounds image.Rectangle
+	for {
+		for _, quality := range jpegQualities {
+			var buf bytes.Buffer
+			if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+				return file, "", fmt.Errorf("failed to encode %s: %w", file.Filename, err)
+			}
+			if best == nil || buf.Len() < len(best) {
+				best, bestQuality, bestBounds = buf.Bytes(), quality, img.Bounds()
+			}
+			if int64(buf.Len()) <= maxSize {
+				break
+			}
+		}
+		if int64(len(best)) <= maxSize || min(img.Bounds().Dx(), img.Bounds().Dy()) < 512 {
+			break
+		}
+		img = halve(img)
+	}
+
+	compressed := file
+	compressed.Filename = strings.TrimSuffix(file.Filename, filepath.Ext(file.Filename)) + ".jpg"
+	compressed.ContentType = "image/jpeg"
+	compressed.Content = best
+	detail := fmt.Sprintf("%s %dx%d re-encoded as JPEG quality %d at %dx%d",
+		strings.ToUpper(format), bounds.Dx(), bounds.Dy(), bestQuality, bestBounds.Dx(), bestBounds.Dy())
+	return compressed, detail, nil
+}
+
+// halve scales an image to half its width and height, averaging each 2x2
+// block of pixels
+func halve(src *image.RGBA) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, src.Bounds().Dx()/2, src.Bounds().Dy()/2))
+	for y := 0; y < dst.Bounds().Dy(); y++ {
+		for x := 0; x < dst.Bounds().Dx(); x++ {
+			var r, g, b, a int
+			for _, offset := range [4]image.Point{{0, 0}, {1, 0}, {0, 1}, {1, 1}} {
+				c := src.RGBAAt(2*x+offset.X, 2*y+offset.Y)
+				r, g, b, a = r+int(c.R), g+int(c.G), b+int(c.B), a+int(c.A)
+			}
+			dst.SetRGBA(x, y, color.RGBA{R: uint8(r / 4), G: uint8(g / 4), B: uint8(b / 4), A: uint8(a / 4)})
+		}
+	}
+	return dst
+}
+
+// jsonToCSV flattens the records of a JSON file, an array of objects at the
+// top or the largest under a top-level key, into a CSV summary with a column
+// per key. Nested values are kept as compact JSON in their cell.
+func jsonToCSV(file EvidenceFile) (EvidenceFile, string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(file.Content))
+	decoder.UseNumber()
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return file, "", fmt.Errorf("failed to parse %s: %w", file.Filename, err)
+	}
+
+	records, ok := jsonRecords(doc)
+	where := "the top-level array"
+	if object, isObject := doc.(map[string]interface{}); isObject {
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if candidate, isRecords := jsonRecords(object[key]); isRecords && len(candidate) > len(records) {
+				records, ok, where = candidate, true, fmt.Sprintf("%q", key)
+			}
+		}
+	}
+	if !ok || len(records) == 0 {
+		return file, "", fmt.Errorf("%s has no array of records", file.Filename)
+	}
+
+	columnSet := map[string]bool{}
+	for _, record := range records {
+		for key := range record {
+			columnSet[key] = true
+		}
+	}
+	columns := make([]string, 0, len(columnSet))
+	for key := range columnSet {
+		columns = append(columns, key)
+	}
+	sort.Strings(columns)
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write(columns)
+	for _, record := range records {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			row[i] = csvCell(record[column])
+		}
+		writer.Write(row)
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return file, "", fmt.Errorf("failed to write CSV for %s: %w", file.Filename, err)
+	}
+
+	converted := file
+	converted.Filename = strings.TrimSuffix(file.Filename, filepath.Ext(file.Filename)) + ".csv"
+	converted.ContentType = "text/csv"
+	converted.Content = buf.Bytes()
+	return converted, fmt.Sprintf("%d records of %s flattened into %d columns", len(records), where, len(columns)), nil
+}
+
+// jsonRecords returns value as records when it is an array of objects
+func jsonRecords(value interface{}) ([]map[string]interface{}, bool) {
+	array, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	records := make([]map[string]interface{}, 0, len(array))
+	for _, element := range array {
+		record, ok := element.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		records = append(records, record)
+	}
+	return records, true
+}
+
+func csvCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return fmt.Sprint(v)
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
+
+// archiveFile compresses a file into a zip archive holding it alone, named
+// like report.log.zip
+func archiveFile(file EvidenceFile) (EvidenceFile, error) {
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	header := &zip.FileHeader{Name: file.Filename, Method: zip.Deflate}
+	header.Modified = file.CollectedDate
+	w, err := writer.CreateHeader(header)
+	if err != nil {
+		return file, fmt.Errorf("failed to archive %s: %w", file.Filename, err)
+	}
+	if _, err := w.Write(file.Content); err != nil {
+		return file, fmt.Errorf("failed to archive %s: %w", file.Filename, err)
+	}
+	if err := writer.Close(); err != nil {
+		return file, fmt.Errorf("failed to archive %s: %w", file.Filename, err)
+	}
+
+	archived := file
+	archived.Filename = file.Filename + ".zip"
+	archived.ContentType = "application/zip"
+	archived.Content = buf.Bytes()
+	return archived, nil
+}
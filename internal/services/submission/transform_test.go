@@ -0,0 +1,228 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package submission
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math/rand"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grctool/grctool/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// acceptsOnly returns an accepts function for the given extensions
+func acceptsOnly(exts ...string) func(string) bool {
+	return func(filename string) bool {
+		for _, ext := range exts {
+			if strings.EqualFold(filepath.Ext(filename), ext) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// noisyPNG returns a PNG of random pixels, which PNG compresses poorly
+func noisyPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	rng := rand.New(rand.NewSource(1))
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(rng.Intn(256)), G: uint8(rng.Intn(256)), B: uint8(rng.Intn(256)), A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestFitFile(t *testing.T) {
+	t.Parallel()
+
+	csvContent := []byte("user,role\n" + strings.Repeat("alice,admin\n", 200))
+	tests := map[string]struct {
+		file      EvidenceFile
+		maxSize   int64
+		accepts   func(string) bool
+		wantName  string
+		wantSteps []string
+		wantErr   string
+	}{
+		"fits": {
+			file:     EvidenceFile{Filename: "access.csv", Content: csvContent},
+			maxSize:  4096,
+			accepts:  acceptsOnly(".csv"),
+			wantName: "access.csv",
+		},
+		"oversize text is archived": {
+			file:      EvidenceFile{Filename: "access.csv", Content: csvContent},
+			maxSize:   1024,
+			accepts:   acceptsOnly(".csv", ".zip"),
+			wantName:  "access.csv.zip",
+			wantSteps: []string{models.TransformArchived},
+		},
+		"archive still oversize": {
+			file:      EvidenceFile{Filename: "access.csv", Content: csvContent},
+			maxSize:   64,
+			accepts:   acceptsOnly(".csv", ".zip"),
+			wantName:  "access.csv.zip",
+			wantSteps: []string{models.TransformArchived},
+		},
+		"unaccepted text is renamed": {
+			file:      EvidenceFile{Filename: "main.tf", Content: []byte(`resource "aws_s3_bucket" "logs" {}`)},
+			accepts:   acceptsOnly(".txt", ".zip"),
+			wantName:  "main.tf.txt",
+			wantSteps: []string{models.TransformRenamedToText},
+		},
+		"unaccepted binary is archived": {
+			file:      EvidenceFile{Filename: "backup.tar", Content: []byte{0x1f, 0x8b, 0x00, 0xff}},
+			accepts:   acceptsOnly(".txt", ".zip"),
+			wantName:  "backup.tar.zip",
+			wantSteps: []string{models.TransformArchived},
+		},
+		"json becomes csv": {
+			file:      EvidenceFile{Filename: "users.json", Content: []byte(`{"users":[{"name":"alice","mfa":true,"groups":["admin"]},{"name":"bob","mfa":false}],"count":2}`)},
+			accepts:   acceptsOnly(".csv"),
+			wantName:  "users.csv",
+			wantSteps: []string{models.TransformConvertedToCSV},
+		},
+		"cannot convert": {
+			file:    EvidenceFile{Filename: "backup.tar", Content: []byte{0x00, 0x01}},
+			accepts: acceptsOnly(".pdf"),
+			wantErr: "the target does not take .tar files and backup.tar could not be converted",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			fitted, transformation, err := FitFile(tc.file, tc.maxSize, tc.accepts)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantName, fitted.Filename)
+			if tc.wantSteps == nil {
+				assert.Nil(t, transformation)
+				return
+			}
+			require.NotNil(t, transformation)
+			assert.Equal(t, tc.wantSteps, transformation.Steps)
+			assert.Len(t, transformation.Details, len(tc.wantSteps))
+			assert.Equal(t, tc.file.Filename, transformation.Filename)
+			assert.Equal(t, []string{fitted.Filename}, transformation.UploadedAs)
+			assert.Equal(t, int64(len(fitted.Content)), transformation.UploadedSizeBytes)
+			assert.Equal(t, int64(len(tc.file.Content)), transformation.OriginalSizeBytes)
+		})
+	}
+}
+
+func TestFitFile_Archive(t *testing.T) {
+	t.Parallel()
+
+	content := []byte(strings.Repeat("2025-10-15 login alice\n", 100))
+	fitted, _, err := FitFile(EvidenceFile{Filename: "auth.log", Content: content}, 0, acceptsOnly(".zip"))
+	require.NoError(t, err)
+	assert.Equal(t, "application/zip", fitted.ContentType)
+
+	archive, err := zip.NewReader(bytes.NewReader(fitted.Content), int64(len(fitted.Content)))
+	require.NoError(t, err)
+	require.Len(t, archive.File, 1)
+	assert.Equal(t, "auth.log", archive.File[0].Name)
+	r, err := archive.File[0].Open()
+	require.NoError(t, err)
+	unpacked, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, content, unpacked, "the archive holds the file unchanged")
+}
+
+func TestFitFile_JSONToCSV(t *testing.T) {
+	t.Parallel()
+
+	file := EvidenceFile{Filename: "users.json", Content: []byte(`{"users":[{"name":"alice","mfa":true,"groups":["admin"],"logins":1200},{"name":"bob, jr","mfa":false}],"count":2}`)}
+	fitted, transformation, err := FitFile(file, 0, acceptsOnly(".csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "text/csv", fitted.ContentType)
+	assert.Equal(t, "groups,logins,mfa,name\n"+
+		"\"[\"\"admin\"\"]\",1200,true,alice\n"+
+		",,false,\"bob, jr\"\n", string(fitted.Content))
+	assert.Equal(t, []string{`2 records of "users" flattened into 4 columns`}, transformation.Details)
+	assert.Equal(t, "the target does not take .json files", transformation.Reason)
+
+	_, _, err = FitFile(EvidenceFile{Filename: "config.json", Content: []byte(`{"enabled":true}`)}, 0, acceptsOnly(".csv"))
+	assert.EqualError(t, err, "the target does not take .json files and config.json could not be converted")
+}
+
+func TestFitFile_CompressesImages(t *testing.T) {
+	t.Parallel()
+
+	content := noisyPNG(t, 600, 600)
+	maxSize := int64(len(content) / 4)
+	fitted, transformation, err := FitFile(EvidenceFile{Filename: "console.png", ContentType: "image/png", Content: content}, maxSize, acceptsOnly(".png", ".jpg"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "console.jpg", fitted.Filename)
+	assert.Equal(t, "image/jpeg", fitted.ContentType)
+	assert.LessOrEqual(t, int64(len(fitted.Content)), maxSize)
+	assert.Equal(t, []string{models.TransformCompressedImage}, transformation.Steps)
+	assert.Contains(t, transformation.Details[0], "PNG 600x600 re-encoded as JPEG quality")
+
+	decoded, format, err := image.Decode(bytes.NewReader(fitted.Content))
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", format)
+	assert.LessOrEqual(t, decoded.Bounds().Dx(), 600)
+}
+
+// typedTarget is a limitedTarget that only takes some file types
+type typedTarget struct {
+	limitedTarget
+	exts []string
+}
+
+func (t *typedTarget) AcceptsFile(filename string) bool { return acceptsOnly(t.exts...)(filename) }
+
+func TestSubmit_TransformsFiles(t *testing.T) {
+	t.Parallel()
+	st, tmpDir := setupTestStorage(t)
+	writeEvidence(t, tmpDir, map[string]string{
+		"access.csv": "user,role\nalice,admin\n",
+		"main.tf":    `resource "aws_s3_bucket" "logs" {}`,
+		"users.json": `[{"name":"alice"},{"name":"bob"}]`,
+	})
+
+	target := &typedTarget{limitedTarget: limitedTarget{max: 1024}, exts: []string{".csv", ".txt", ".zip"}}
+	svc := NewSubmissionServiceWithTargets(st, target)
+
+	resp, err := svc.Submit(context.Background(), &SubmitRequest{TaskRef: "ET-0047", Window: "2025-Q4", SkipValidation: true})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Submission.FailedFiles)
+
+	var uploaded []string
+	for _, file := range target.files {
+		uploaded = append(uploaded, file.Filename)
+	}
+	assert.Equal(t, []string{"access.csv", "main.tf.txt", "users.csv"}, uploaded)
+
+	require.Len(t, resp.Submission.Transformations, 2)
+	assert.Equal(t, "main.tf", resp.Submission.Transformations[0].Filename)
+	assert.Equal(t, []string{models.TransformRenamedToText}, resp.Submission.Transformations[0].Steps)
+	assert.Equal(t, "users.json", resp.Submission.Transformations[1].Filename)
+	assert.Equal(t, []string{"users.csv"}, resp.Submission.Transformations[1].UploadedAs)
+
+	saved, err := svc.GetSubmissionStatus(context.Background(), "ET-0047", "2025-Q4")
+	require.NoError(t, err)
+	assert.Equal(t, resp.Submission.Transformations, saved.Transformations, "the transformations are saved with the submission")
+}
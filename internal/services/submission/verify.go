@@ -0,0 +1,116 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submission
+
+import (
+	"context"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/models"
+)
+
+// verifySubmission reads back what the target received since the upload
+// started and checks that each file sent arrived with its size. Uploads can
+// take a moment to show up, so an incomplete result is read again with the
+// same backoff as a failed upload.
+func (s *SubmissionService) verifySubmission(ctx context.Context, target VerifyingTarget, task *domain.EvidenceTask, sent []EvidenceFile, since time.Time) *models.SubmissionVerification {
+	backoff := s.retryBackoff
+	for attempt := 1; ; attempt++ {
+		var verification *models.SubmissionVerification
+		received, err := target.ReceivedFiles(ctx, task, since)
+		if err != nil {
+			verification = &models.SubmissionVerification{VerifiedAt: time.Now(), Status: "error", Error: err.Error()}
+		} else {
+			verification = compareReceived(sent, received, time.Now())
+		}
+		if verification.Status == "verified" || attempt > s.retries || ctx.Err() != nil {
+			return verification
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return verification
+		}
+		backoff *= 2
+	}
+}
+
+// compareReceived checks the files sent against the newest file of each name
+// the target received. A size the target does not report is not compared.
+func compareReceived(sent []EvidenceFile, received []ReceivedFile, now time.Time) *models.SubmissionVerification {
+	newest := map[string]ReceivedFile{}
+	for _, file := range received {
+		if _, seen := newest[file.Filename]; !seen {
+			newest[file.Filename] = file
+		}
+	}
+
+	verification := &models.SubmissionVerification{VerifiedAt: now}
+	for _, file := range sent {
+		verified := models.VerifiedFile{Filename: file.Filename, SentBytes: int64(len(file.Content)), Status: "ok"}
+		got, ok := newest[file.Filename]
+		switch {
+		case !ok:
+			verified.Status = "missing"
+		case got.SizeBytes >= 0:
+			verified.ReceivedBytes = got.SizeBytes
+			if got.SizeBytes != verified.SentBytes {
+				verified.Status = "size_mismatch"
+			}
+		}
+		verification.Files = append(verification.Files, verified)
+	}
+	verification.Status = verificationStatus(verification)
+	return verification
+}
+
+// mergeVerification adds the verification of a --retry-failed upload to the
+// one of the submission it retried
+func mergeVerification(previous, retry *models.SubmissionVerification) *models.SubmissionVerification {
+	if previous == nil || retry == nil {
+		if retry == nil {
+			return previous
+		}
+		return retry
+	}
+
+	merged := *retry
+	merged.Files = append([]models.VerifiedFile{}, previous.Files...)
+	if retry.Status == "error" {
+		return &merged
+	}
+	for _, file := range retry.Files {
+		replaced := false
+		for i := range merged.Files {
+			if merged.Files[i].Filename == file.Filename {
+				merged.Files[i], replaced = file, true
+			}
+		}
+		if !replaced {
+			merged.Files = append(merged.Files, file)
+		}
+	}
+	merged.Status = verificationStatus(&merged)
+	return &merged
+}
+
+func verificationStatus(verification *models.SubmissionVerification) string {
+	if len(verification.Mismatches()) > 0 {
+		return "mismatch"
+	}
+	return "verified"
+}
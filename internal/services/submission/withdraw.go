@@ -0,0 +1,173 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/models"
+)
+
+// Remote outcomes of a withdrawal
+const (
+	WithdrawnRemotely   = "withdrawn"   // The target removed the submitted files
+	WithdrawUnsupported = "unsupported" // The target cannot remove files, or is not configured; remove them there by hand
+	WithdrawSkipped     = "skipped"     // Nothing was uploaded, or only the local record was superseded
+)
+
+// ErrRemoteWithdrawal is matched by the errors of withdrawals that failed
+// to remove the submitted files from the target, which leave the local
+// submission unchanged
+var ErrRemoteWithdrawal = errors.New("withdrawal from target failed")
+
+// remoteWithdrawalError is a withdrawal error returned by the target
+type remoteWithdrawalError struct {
+	err error
+}
+
+func (e *remoteWithdrawalError) Error() string { return e.err.Error() }
+
+func (e *remoteWithdrawalError) Unwrap() error { return e.err }
+
+func (e *remoteWithdrawalError) Is(target error) bool { return target == ErrRemoteWithdrawal }
+
+// WithdrawRequest defines a request to withdraw a submission
+type WithdrawRequest struct {
+	TaskRef     string
+	Window      string
+	Reason      string
+	WithdrawnBy string
+	LocalOnly   bool // Supersede the local record without removing files from the target
+}
+
+// Withdraw supersedes the window's submission. The files it uploaded are
+// removed from the target first, when the target is a WithdrawingTarget; if
+// that fails nothing changes locally, so the withdrawal can be run again.
+// Then the submitted files move back to the window root and the submission
+// is marked superseded with the reason, and the withdrawal is added to the
+// submission history.
+func (s *SubmissionService) Withdraw(ctx context.Context, req *WithdrawRequest) (*models.EvidenceSubmission, error) {
+	if req.Reason == "" {
+		return nil, fmt.Errorf("a reason for the withdrawal is required")
+	}
+	submission, err := s.storage.LoadSubmission(req.TaskRef, req.Window)
+	if err != nil {
+		return nil, fmt.Errorf("no submission recorded for %s in window %s", req.TaskRef, req.Window)
+	}
+	if submission.Status == "superseded" && submission.Withdrawal != nil {
+		return nil, fmt.Errorf("submission for %s in window %s was already withdrawn on %s",
+			req.TaskRef, req.Window, submission.Withdrawal.WithdrawnAt.Format("2006-01-02"))
+	}
+
+	withdrawal := models.SubmissionWithdrawal{
+		WithdrawnAt: time.Now(),
+		WithdrawnBy: req.WithdrawnBy,
+		Reason:      req.Reason,
+		Remote:      WithdrawSkipped,
+	}
+	if name, target := s.submittedTo(submission); name != "" {
+		withdrawal.Target = name
+		withdrawer, ok := target.(WithdrawingTarget)
+		switch {
+		case req.LocalOnly:
+		case !ok:
+			withdrawal.Remote = WithdrawUnsupported
+		default:
+			task, err := s.getEvidenceTask(req.TaskRef)
+			if err != nil {
+				return nil, err
+			}
+			removed, err := withdrawer.WithdrawSubmission(ctx, task, submission)
+			if err != nil {
+				return nil, &remoteWithdrawalError{fmt.Errorf("failed to withdraw from %s after removing %d file(s): %w", name, len(removed), err)}
+			}
+			withdrawal.Remote = WithdrawnRemotely
+			withdrawal.RemovedFiles = removed
+		}
+	}
+
+	withdrawn, err := s.storage.WithdrawSubmission(req.TaskRef, req.Window, withdrawal)
+	if err != nil {
+		return nil, err
+	}
+
+	historyEntry := models.SubmissionHistoryEntry{
+		SubmissionID: withdrawn.SubmissionID,
+		SubmittedAt:  withdrawal.WithdrawnAt,
+		SubmittedBy:  req.WithdrawnBy,
+		Status:       withdrawn.Status,
+		FileCount:    withdrawn.TotalFileCount,
+		Notes:        req.Reason,
+	}
+	if err := s.storage.AddSubmissionHistory(req.TaskRef, req.Window, historyEntry); err != nil {
+		// Log error but don't fail
+		fmt.Printf("Warning: failed to save submission history: %v\n", err)
+	}
+	return withdrawn, nil
+}
+
+// submittedTo returns the name of the target the submission was uploaded to,
+// empty when it was not uploaded, and the service's target of that name, nil
+// when it is not configured. Names match regardless of case, preferring a
+// WithdrawingTarget, since the provider registry records Tugboat as "tugboat".
+func (s *SubmissionService) submittedTo(submission *models.EvidenceSubmission) (string, Target) {
+	if submission.TugboatResponse == nil {
+		return "", nil
+	}
+	name, _ := submission.TugboatResponse.Metadata["target"].(string)
+	if name == "" {
+		return "", nil
+	}
+	var found Target
+	for _, target := range s.targets {
+		if !strings.EqualFold(target.Name(), name) {
+			continue
+		}
+		if _, ok := target.(WithdrawingTarget); ok {
+			return name, target
+		}
+		if found == nil {
+			found = target
+		}
+	}
+	return name, found
+}
+
+// submissionReceipts returns the IDs the target assigned to the submission's
+// uploads by filename, as recorded in its response metadata
+func submissionReceipts(submission *models.EvidenceSubmission) map[string]string {
+	receipts := map[string]string{}
+	if submission.TugboatResponse == nil {
+		return receipts
+	}
+	switch recorded := submission.TugboatResponse.Metadata["receipts"].(type) {
+	case map[string]string:
+		for filename, receipt := range recorded {
+			receipts[filename] = receipt
+		}
+	case map[string]interface{}:
+		for filename, receipt := range recorded {
+			if id, ok := receipt.(string); ok && id != "" {
+				receipts[filename] = id
+			}
+		}
+	}
+	return receipts
+}
@@ -0,0 +1,184 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package submission
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/tugboat"
+	"github.com/grctool/grctool/internal/vanta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withdrawingTarget is a stubTarget that removes the files it was sent, or
+// fails to when err is set
+type withdrawingTarget struct {
+	stubTarget
+	err     error
+	removed []string
+}
+
+func (w *withdrawingTarget) WithdrawSubmission(_ context.Context, _ *domain.EvidenceTask, submission *models.EvidenceSubmission) ([]string, error) {
+	if w.err != nil {
+		return nil, w.err
+	}
+	for filename := range submissionReceipts(submission) {
+		w.removed = append(w.removed, filename)
+	}
+	return w.removed, nil
+}
+
+func TestWithdraw(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		target    Target
+		localOnly bool
+		remote    string
+		removed   []string
+		err       string
+	}{
+		"withdrawn remotely": {target: &withdrawingTarget{}, remote: WithdrawnRemotely, removed: []string{"access.csv"}},
+		"unsupported":        {target: &stubTarget{}, remote: WithdrawUnsupported},
+		"local only":         {target: &withdrawingTarget{}, localOnly: true, remote: WithdrawSkipped},
+		"remote fails": {
+			target: &withdrawingTarget{err: errors.New("attachment is locked")},
+			err:    "failed to withdraw from Stub after removing 0 file(s): attachment is locked",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			st, tmpDir := setupTestStorage(t)
+			writeEvidence(t, tmpDir, map[string]string{"access.csv": "user,role\n"})
+			evidenceDir := filepath.Join(tmpDir, "evidence", "ET-0047", "2025-Q4")
+
+			svc := NewSubmissionServiceWithTargets(st, tc.target)
+			resp, err := svc.Submit(context.Background(), &SubmitRequest{TaskRef: "ET-0047", Window: "2025-Q4", SkipValidation: true})
+			require.NoError(t, err)
+			require.NoError(t, st.MoveEvidenceFilesToSubmitted("ET-0047", "2025-Q4", resp.Submission.UploadedFiles()))
+
+			withdrawn, err := svc.Withdraw(context.Background(), &WithdrawRequest{
+				TaskRef: "ET-0047", Window: "2025-Q4", Reason: "Exported the wrong quarter", WithdrawnBy: "alex@example.com", LocalOnly: tc.localOnly,
+			})
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				assert.ErrorIs(t, err, ErrRemoteWithdrawal)
+				assert.FileExists(t, filepath.Join(evidenceDir, ".submitted", "access.csv"), "nothing changes locally")
+				loaded, err := st.LoadSubmission("ET-0047", "2025-Q4")
+				require.NoError(t, err)
+				assert.Equal(t, "submitted", loaded.Status)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "superseded", withdrawn.Status)
+			require.NotNil(t, withdrawn.Withdrawal)
+			assert.Equal(t, "Stub", withdrawn.Withdrawal.Target)
+			assert.Equal(t, tc.remote, withdrawn.Withdrawal.Remote)
+			assert.Equal(t, tc.removed, withdrawn.Withdrawal.RemovedFiles)
+			assert.Equal(t, "Exported the wrong quarter", withdrawn.Withdrawal.Reason)
+			assert.Equal(t, "alex@example.com", withdrawn.Withdrawal.WithdrawnBy)
+			assert.FileExists(t, filepath.Join(evidenceDir, "access.csv"), "files are back in the root")
+
+			history, err := st.LoadSubmissionHistory("ET-0047", "2025-Q4")
+			require.NoError(t, err)
+			require.NotEmpty(t, history.Entries)
+			assert.Equal(t, "superseded", history.Entries[0].Status)
+			assert.Equal(t, "Exported the wrong quarter", history.Entries[0].Notes)
+
+			_, err = svc.Withdraw(context.Background(), &WithdrawRequest{TaskRef: "ET-0047", Window: "2025-Q4", Reason: "Again"})
+			assert.ErrorContains(t, err, "was already withdrawn on")
+		})
+	}
+}
+
+func TestWithdraw_Errors(t *testing.T) {
+	t.Parallel()
+	st, _ := setupTestStorage(t)
+	svc := NewSubmissionServiceWithTargets(st, &withdrawingTarget{})
+
+	_, err := svc.Withdraw(context.Background(), &WithdrawRequest{TaskRef: "ET-0047", Window: "2025-Q4"})
+	assert.EqualError(t, err, "a reason for the withdrawal is required")
+	_, err = svc.Withdraw(context.Background(), &WithdrawRequest{TaskRef: "ET-0047", Window: "2025-Q4", Reason: "Wrong file"})
+	assert.EqualError(t, err, "no submission recorded for ET-0047 in window 2025-Q4")
+}
+
+func TestTugboatTarget_WithdrawSubmission(t *testing.T) {
+	t.Parallel()
+
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/org_evidence_attachment/":
+			_, _ = w.Write([]byte(`{"count": 3, "results": [
+				{"id": 5, "type": "file", "created": "2025-10-15T12:00:06Z", "attachment": {"original_filename": "access.csv"}},
+				{"id": 4, "type": "file", "created": "2025-10-15T12:00:05Z", "attachment": {"original_filename": "auditor_notes.md"}},
+				{"id": 1, "type": "file", "created": "2025-10-15T09:00:00Z", "attachment": {"original_filename": "access.csv"}}
+			]}`))
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := tugboat.NewClient(&config.TugboatConfig{BaseURL: server.URL, Timeout: 5 * time.Second}, nil)
+	target := NewTugboatTarget(client, map[string]string{"ET-0047": server.URL + "/collector/1"}).(WithdrawingTarget)
+	submission := &models.EvidenceSubmission{
+		TaskRef:       "ET-0047",
+		CreatedAt:     time.Date(2025, 10, 15, 12, 0, 0, 0, time.UTC),
+		EvidenceFiles: []models.EvidenceFileRef{{Filename: "access.csv"}},
+	}
+
+	removed, err := target.WithdrawSubmission(context.Background(), &domain.EvidenceTask{ID: "327992", ReferenceID: "ET-0047"}, submission)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"access.csv"}, removed)
+	assert.Equal(t, []string{"/api/org_evidence_attachment/5/"}, deleted, "only the submission's uploads are deleted")
+}
+
+func TestVantaTarget_WithdrawSubmission(t *testing.T) {
+	t.Parallel()
+	st, tmpDir := setupTestStorage(t)
+	writeEvidence(t, tmpDir, map[string]string{"github_access.md": "# Evidence"})
+
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/oauth/token":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token", "expires_in": 3600})
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			_ = json.NewEncoder(w).Encode(map[string]string{"id": "upload-1"})
+		}
+	}))
+	defer server.Close()
+
+	client := vanta.NewClient(config.VantaConfig{BaseURL: server.URL, ClientID: "id", ClientSecret: "secret"}, server.Client())
+	svc := NewSubmissionServiceWithTargets(st, NewVantaTarget(client, map[string]string{"ET-0047": "repo-access"}))
+	_, err := svc.Submit(context.Background(), &SubmitRequest{TaskRef: "ET-0047", Window: "2025-Q4", SkipValidation: true})
+	require.NoError(t, err)
+
+	// Receipts are read back from the saved submission
+	withdrawn, err := svc.Withdraw(context.Background(), &WithdrawRequest{TaskRef: "ET-0047", Window: "2025-Q4", Reason: "Wrong repository"})
+	require.NoError(t, err)
+	assert.Equal(t, WithdrawnRemotely, withdrawn.Withdrawal.Remote)
+	assert.Equal(t, []string{"github_access.md"}, withdrawn.Withdrawal.RemovedFiles)
+	assert.Equal(t, []string{"/v1/documents/repo-access/uploads/upload-1"}, deleted)
+}
@@ -30,7 +30,6 @@ import (
 	"github.com/grctool/grctool/internal/domain"
 	"github.com/grctool/grctool/internal/formatters"
 	"github.com/grctool/grctool/internal/interfaces"
-	"github.com/grctool/grctool/internal/interpolation"
 	"github.com/grctool/grctool/internal/logger"
 	"github.com/grctool/grctool/internal/models"
 	"github.com/grctool/grctool/internal/naming"
@@ -38,8 +37,10 @@ import (
 	tugboatProvider "github.com/grctool/grctool/internal/providers/tugboat"
 	"github.com/grctool/grctool/internal/registry"
 	"github.com/grctool/grctool/internal/storage"
+	"github.com/grctool/grctool/internal/telemetry"
 	"github.com/grctool/grctool/internal/tugboat"
 	tugboatModels "github.com/grctool/grctool/internal/tugboat/models"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // SyncService handles synchronization between data providers and local storage.
@@ -56,6 +57,9 @@ type SyncService struct {
 	evidenceTaskRegistry  *registry.EvidenceTaskRegistry
 	documentService       *DocumentService
 	baseDir               string
+	syncConcurrency       int
+	syncRetries           int
+	retryBackoff          time.Duration
 	logger                logger.Logger
 }
 
@@ -85,12 +89,7 @@ func NewSyncService(tugboatClient *tugboat.Client, storage *storage.Storage, cfg
 // should also set it explicitly or use NewSyncService instead.
 func NewSyncServiceWithRegistry(reg interfaces.ProviderRegistry, storage *storage.Storage, cfg *config.Config, log logger.Logger) *SyncService {
 	// Create interpolator from config
-	interpolatorConfig := interpolation.InterpolatorConfig{
-		Variables:         cfg.Interpolation.GetFlatVariables(),
-		Enabled:           cfg.Interpolation.Enabled,
-		OnMissingVariable: interpolation.MissingVariableIgnore,
-	}
-	interpolator := interpolation.NewStandardInterpolator(interpolatorConfig)
+	interpolator := NewInterpolator(cfg)
 
 	// Create evidence task registry and load existing entries
 	evidenceTaskRegistry := registry.NewEvidenceTaskRegistry(cfg.Storage.DataDir)
@@ -112,18 +111,31 @@ func NewSyncServiceWithRegistry(reg interfaces.ProviderRegistry, storage *storag
 		evidenceTaskRegistry:  evidenceTaskRegistry,
 		documentService:       NewDocumentService(cfg),
 		baseDir:               cfg.Storage.DataDir,
+		syncConcurrency:       cfg.Tugboat.SyncConcurrency,
+		syncRetries:           cfg.Tugboat.SyncRetries,
+		retryBackoff:          defaultRetryBackoff,
 		logger:                log.WithComponent("sync_service"),
 	}
 }
 
 // SyncOptions represents options for synchronization
 type SyncOptions struct {
-	OrgID       string `json:"org_id"`
-	Framework   string `json:"framework,omitempty"`
-	Policies    bool   `json:"policies"`
-	Controls    bool   `json:"controls"`
-	Evidence    bool   `json:"evidence"`
-	Submissions bool   `json:"submissions"`
+	OrgID       string   `json:"org_id"`
+	Framework   string   `json:"framework,omitempty"`
+	Policies    bool     `json:"policies"`
+	Controls    bool     `json:"controls"`
+	Evidence    bool     `json:"evidence"`
+	Submissions bool     `json:"submissions"`
+	DryRun      bool     `json:"dry_run,omitempty"` // Fetch and compare with local storage without writing
+	Refs        []string `json:"refs,omitempty"`    // Limit to these reference or provider IDs
+	Resume      bool     `json:"resume,omitempty"`  // Continue the checkpoint of a sync that did not finish
+
+	Concurrency int          `json:"concurrency,omitempty"` // Parallel API fetches; defaults to tugboat.sync_concurrency
+	Retries     int          `json:"retries,omitempty"`     // Retries of a failed fetch; defaults to tugboat.sync_retries
+	Progress    SyncProgress `json:"-"`
+
+	refFilter  *refFilter      // Shared by the types of a SyncAll, to report unmatched Refs
+	checkpoint *syncCheckpoint // Progress of a SyncAll, for Resume
 }
 
 // SyncResult represents the result of a synchronization operation
@@ -134,6 +146,8 @@ type SyncResult struct {
 	Submissions   SyncStats     `json:"submissions"`
 	Duration      time.Duration `json:"duration"`
 	Errors        []string      `json:"errors,omitempty"`
+	Resumed       bool          `json:"resumed,omitempty"`   // Continued an earlier sync's checkpoint
+	Resumable     bool          `json:"resumable,omitempty"` // Did not finish; a checkpoint was kept for Resume
 	StartTime     time.Time     `json:"start_time"`
 	EndTime       time.Time     `json:"end_time"`
 }
@@ -146,6 +160,11 @@ type SyncStats struct {
 	Errors     int `json:"errors"`
 	Skipped    int `json:"skipped"`
 	Downloaded int `json:"downloaded"` // For submissions: number of files downloaded
+	Feedback   int `json:"feedback"`   // For submissions: number of auditor comments synced
+
+	Changes []SyncChange `json:"changes,omitempty"` // For dry runs: what the sync would change locally
+
+	listed []string // IDs listed by the provider, for dry-run deletion detection
 }
 
 // SyncAll performs a complete synchronization of all data types.
@@ -157,6 +176,26 @@ func (s *SyncService) SyncAll(ctx context.Context, opts SyncOptions) (*SyncResul
 		StartTime: time.Now(),
 		Errors:    []string{},
 	}
+	opts.refFilter = newRefFilter(opts.Refs)
+	if !opts.DryRun {
+		checkpoint, err := s.startCheckpoint(opts)
+		if err != nil {
+			return nil, err
+		}
+		opts.checkpoint = checkpoint
+		result.Resumed = checkpoint.resumed
+	}
+	// The stages that must all finish for the checkpoint to be removed
+	var stages []string
+
+	// Dry runs track what every provider listed, to find deleted documents
+	seen := map[string]map[string]bool{"policy": {}, "control": {}, "evidence_task": {}}
+	failed := map[string]bool{}
+	markSeen := func(entityType string, stats *SyncStats) {
+		for _, id := range stats.listed {
+			seen[entityType][id] = true
+		}
+	}
 
 	// Sync policies, controls, and evidence tasks from all registered providers
 	providerNames := s.registry.List()
@@ -164,55 +203,86 @@ func (s *SyncService) SyncAll(ctx context.Context, opts SyncOptions) (*SyncResul
 		provider, err := s.registry.Get(name)
 		if err != nil {
 			result.Errors = append(result.Errors, fmt.Sprintf("Failed to get provider %s: %v", name, err))
+			failed["policy"], failed["control"], failed["evidence_task"] = true, true, true
+			stages = append(stages, syncStage(name, "unavailable"))
 			continue
 		}
 
 		s.logger.Info("Syncing from provider", logger.String("provider", name))
 
 		if opts.Policies {
+			stages = append(stages, syncStage(name, "policies"))
 			stats, err := s.syncPoliciesFromProvider(ctx, provider, opts)
 			if err != nil {
 				result.Errors = append(result.Errors, fmt.Sprintf("Policy sync failed (provider %s): %v", name, err))
+				failed["policy"] = true
 			} else {
 				result.Policies.Total += stats.Total
 				result.Policies.Synced += stats.Synced
 				result.Policies.Detailed += stats.Detailed
 				result.Policies.Errors += stats.Errors
+				result.Policies.Skipped += stats.Skipped
+				result.Policies.Changes = append(result.Policies.Changes, stats.Changes...)
+				markSeen("policy", stats)
 			}
 		}
 
 		if opts.Controls {
+			stages = append(stages, syncStage(name, "controls"))
 			stats, err := s.syncControlsFromProvider(ctx, provider, opts)
 			if err != nil {
 				result.Errors = append(result.Errors, fmt.Sprintf("Control sync failed (provider %s): %v", name, err))
+				failed["control"] = true
 			} else {
 				result.Controls.Total += stats.Total
 				result.Controls.Synced += stats.Synced
 				result.Controls.Detailed += stats.Detailed
 				result.Controls.Errors += stats.Errors
+				result.Controls.Skipped += stats.Skipped
+				result.Controls.Changes = append(result.Controls.Changes, stats.Changes...)
+				markSeen("control", stats)
 			}
 		}
 
 		if opts.Evidence {
+			stages = append(stages, syncStage(name, "evidence_tasks"))
 			stats, err := s.syncEvidenceTasksFromProvider(ctx, provider, opts)
 			if err != nil {
 				result.Errors = append(result.Errors, fmt.Sprintf("Evidence task sync failed (provider %s): %v", name, err))
+				failed["evidence_task"] = true
 			} else {
 				result.EvidenceTasks.Total += stats.Total
 				result.EvidenceTasks.Synced += stats.Synced
 				result.EvidenceTasks.Detailed += stats.Detailed
 				result.EvidenceTasks.Errors += stats.Errors
+				result.EvidenceTasks.Skipped += stats.Skipped
+				result.EvidenceTasks.Changes = append(result.EvidenceTasks.Changes, stats.Changes...)
+				markSeen("evidence_task", stats)
 			}
 		}
 	}
 
-	// Sync submissions if requested (still uses direct Tugboat client)
-	if opts.Submissions {
+	if opts.DryRun {
+		s.previewDeletions(opts, result, seen, failed)
+	}
+
+	// Sync submissions if requested (still uses direct Tugboat client).
+	// Dry runs skip them, as they download files rather than documents.
+	if opts.Submissions && !opts.DryRun {
+		stages = append(stages, submissionsStage)
 		if err := s.syncSubmissions(ctx, opts, &result.Submissions); err != nil {
 			result.Errors = append(result.Errors, fmt.Sprintf("Submission sync failed: %v", err))
 		}
 	}
 
+	// Stages a resume skipped match no references
+	if !result.Resumed {
+		for _, ref := range opts.refFilter.unmatched() {
+			result.Errors = append(result.Errors, fmt.Sprintf("No synced item matches reference %s", ref))
+		}
+	}
+	result.Resumable = opts.checkpoint.end(stages)
+
 	result.EndTime = time.Now()
 	result.Duration = result.EndTime.Sub(result.StartTime)
 
@@ -291,6 +361,10 @@ func (s *SyncService) syncEvidenceTasks(ctx context.Context, opts SyncOptions, s
 // processes reference IDs, saves to storage, and generates documents.
 func (s *SyncService) syncPoliciesFromProvider(ctx context.Context, provider interfaces.DataProvider, opts SyncOptions) (*SyncStats, error) {
 	stats := &SyncStats{}
+	stage := syncStage(provider.Name(), "policies")
+	if opts.checkpoint.skipFinished(stage, stats) {
+		return stats, nil
+	}
 
 	// Fetch all policies from the provider using pagination
 	allPolicies, err := s.fetchAllPolicies(ctx, provider, opts.Framework)
@@ -298,14 +372,41 @@ func (s *SyncService) syncPoliciesFromProvider(ctx context.Context, provider int
 		return stats, fmt.Errorf("failed to get policies from provider %s: %w", provider.Name(), err)
 	}
 
-	stats.Total = len(allPolicies)
-	stats.Detailed = len(allPolicies)
+	// A selective sync only fetches and saves the selected policies. Their
+	// reference IDs are assigned over the full list, as in a full sync.
+	refProcessor := domain.NewPolicyReferenceProcessor()
+	selected := selectIndexes(opts.refs(), refProcessor.ProcessPolicyReferences(allPolicies), policyKeys)
+	stats.Total = len(selected)
+
+	// A resumed sync skips the policies it already saved
+	selected, stats.Skipped = remaining(opts.checkpoint, stage, allPolicies, selected, func(p domain.Policy) string { return p.ID })
+	policies := pick(allPolicies, selected)
+
+	// Fetch full details concurrently, then save in list order
+	details, errs := fetchAll(ctx, s.fetchPool(opts), "policies", len(policies), func(ctx context.Context, i int) (*domain.Policy, error) {
+		return provider.GetPolicy(ctx, policies[i].ID)
+	})
+	if err := ctx.Err(); err != nil {
+		return stats, err
+	}
+	stats.Detailed = mergeDetails(s.logger, "policy", policies, details, errs, func(p domain.Policy) string { return p.ID })
+	for i, idx := range selected {
+		allPolicies[idx] = policies[i]
+	}
 
 	// Process reference IDs for all policies
-	refProcessor := domain.NewPolicyReferenceProcessor()
-	processedPolicies := refProcessor.ProcessPolicyReferences(allPolicies)
+	processedPolicies := pick(refProcessor.ProcessPolicyReferences(allPolicies), selected)
+
+	if opts.DryRun {
+		local := localDocuments(s.logger, "policies", s.storage.GetAllPolicies, policyDocument)
+		stats.Changes = diffDocuments("policy", local, toSyncDocuments(processedPolicies, policyDocument))
+		stats.listed = toIDs(processedPolicies, func(p domain.Policy) string { return p.ID })
+		return stats, nil
+	}
 
-	// Save all processed policies
+	// Save all processed policies. Those saved with their details are
+	// checkpointed; a resume retries the rest.
+	completed := 0
 	for i := range processedPolicies {
 		policy := &processedPolicies[i]
 
@@ -324,6 +425,13 @@ func (s *SyncService) syncPoliciesFromProvider(ctx context.Context, provider int
 		}
 
 		stats.Synced++
+		if errs[i] == nil {
+			opts.checkpoint.complete(stage, policy.ID)
+			completed++
+		}
+	}
+	if completed == len(processedPolicies) {
+		opts.checkpoint.finish(stage)
 	}
 
 	return stats, nil
@@ -333,6 +441,10 @@ func (s *SyncService) syncPoliciesFromProvider(ctx context.Context, provider int
 // processes reference IDs, saves to storage, and generates documents.
 func (s *SyncService) syncControlsFromProvider(ctx context.Context, provider interfaces.DataProvider, opts SyncOptions) (*SyncStats, error) {
 	stats := &SyncStats{}
+	stage := syncStage(provider.Name(), "controls")
+	if opts.checkpoint.skipFinished(stage, stats) {
+		return stats, nil
+	}
 
 	// Fetch all controls from the provider using pagination
 	allControls, err := s.fetchAllControls(ctx, provider, opts.Framework)
@@ -340,15 +452,42 @@ func (s *SyncService) syncControlsFromProvider(ctx context.Context, provider int
 		return stats, fmt.Errorf("failed to get controls from provider %s: %w", provider.Name(), err)
 	}
 
-	stats.Total = len(allControls)
-	stats.Detailed = len(allControls)
+	// A selective sync only fetches and saves the selected controls. Their
+	// reference IDs are assigned over the full list, as in a full sync.
+	refProcessor := domain.NewControlReferenceProcessor()
+	selected := selectIndexes(opts.refs(), refProcessor.ProcessControlReferences(allControls), controlKeys)
+	stats.Total = len(selected)
+
+	// A resumed sync skips the controls it already saved
+	selected, stats.Skipped = remaining(opts.checkpoint, stage, allControls, selected, func(c domain.Control) string { return c.ID })
+	controls := pick(allControls, selected)
+
+	// Fetch full details concurrently, then save in list order
+	details, errs := fetchAll(ctx, s.fetchPool(opts), "controls", len(controls), func(ctx context.Context, i int) (*domain.Control, error) {
+		return provider.GetControl(ctx, controls[i].ID)
+	})
+	if err := ctx.Err(); err != nil {
+		return stats, err
+	}
+	stats.Detailed = mergeDetails(s.logger, "control", controls, details, errs, func(c domain.Control) string { return c.ID })
+	for i, idx := range selected {
+		allControls[idx] = controls[i]
+	}
 
 	// Process reference IDs for all controls
-	refProcessor := domain.NewControlReferenceProcessor()
-	processedControls := refProcessor.ProcessControlReferences(allControls)
+	processedControls := pick(refProcessor.ProcessControlReferences(allControls), selected)
 
-	// Save all processed controls
-	for _, domainControl := range processedControls {
+	if opts.DryRun {
+		local := localDocuments(s.logger, "controls", s.storage.GetAllControls, controlDocument)
+		stats.Changes = diffDocuments("control", local, toSyncDocuments(processedControls, controlDocument))
+		stats.listed = toIDs(processedControls, func(c domain.Control) string { return c.ID })
+		return stats, nil
+	}
+
+	// Save all processed controls. Those saved with their details are
+	// checkpointed; a resume retries the rest.
+	completed := 0
+	for i, domainControl := range processedControls {
 		// Save complete control info
 		if err := s.saveControlThroughDataService(ctx, &domainControl); err != nil {
 			stats.Errors++
@@ -364,6 +503,13 @@ func (s *SyncService) syncControlsFromProvider(ctx context.Context, provider int
 		}
 
 		stats.Synced++
+		if errs[i] == nil {
+			opts.checkpoint.complete(stage, domainControl.ID)
+			completed++
+		}
+	}
+	if completed == len(processedControls) {
+		opts.checkpoint.finish(stage)
 	}
 
 	return stats, nil
@@ -374,6 +520,10 @@ func (s *SyncService) syncControlsFromProvider(ctx context.Context, provider int
 // and generates documents.
 func (s *SyncService) syncEvidenceTasksFromProvider(ctx context.Context, provider interfaces.DataProvider, opts SyncOptions) (*SyncStats, error) {
 	stats := &SyncStats{}
+	stage := syncStage(provider.Name(), "evidence_tasks")
+	if opts.checkpoint.skipFinished(stage, stats) {
+		return stats, nil
+	}
 
 	// Fetch all evidence tasks from the provider using pagination
 	allTasks, err := s.fetchAllEvidenceTasks(ctx, provider, opts.Framework)
@@ -381,8 +531,27 @@ func (s *SyncService) syncEvidenceTasksFromProvider(ctx context.Context, provide
 		return stats, fmt.Errorf("failed to get evidence tasks from provider %s: %w", provider.Name(), err)
 	}
 
+	// A selective sync only fetches and saves the selected tasks
+	if filter := opts.refs(); filter != nil {
+		keys := s.evidenceTaskKeys()
+		allTasks = pick(allTasks, selectIndexes(filter, allTasks, func(t domain.EvidenceTask) []string { return keys(t.ID) }))
+	}
 	stats.Total = len(allTasks)
 
+	// A resumed sync skips the tasks it already saved
+	taskID := func(t domain.EvidenceTask) string { return t.ID }
+	indexes, skipped := remaining(opts.checkpoint, stage, allTasks, toIndexes(len(allTasks)), taskID)
+	allTasks, stats.Skipped = pick(allTasks, indexes), skipped
+
+	// Fetch full details concurrently, then save in list order
+	details, errs := fetchAll(ctx, s.fetchPool(opts), "evidence tasks", len(allTasks), func(ctx context.Context, i int) (*domain.EvidenceTask, error) {
+		return provider.GetEvidenceTask(ctx, allTasks[i].ID)
+	})
+	if err := ctx.Err(); err != nil {
+		return stats, err
+	}
+	stats.Detailed = mergeDetails(s.logger, "evidence task", allTasks, details, errs, func(t domain.EvidenceTask) string { return t.ID })
+
 	// First pass: process related controls and preserve existing reference IDs
 	var domainTasks []domain.EvidenceTask
 	for _, domainTask := range allTasks {
@@ -399,21 +568,36 @@ func (s *SyncService) syncEvidenceTasksFromProvider(ctx context.Context, provide
 		}
 
 		domainTasks = append(domainTasks, domainTask)
-		stats.Synced++
-		stats.Detailed++
+	}
+
+	if opts.DryRun {
+		local := localDocuments(s.logger, "evidence tasks", s.storage.GetAllEvidenceTasks, evidenceTaskDocument)
+		stats.Changes = diffDocuments("evidence_task", local, toSyncDocuments(domainTasks, evidenceTaskDocument))
+		stats.listed = toIDs(domainTasks, func(t domain.EvidenceTask) string { return t.ID })
+		return stats, nil
+	}
+	stats.Synced = len(domainTasks)
+
+	// Checkpointed tasks keep the references the registry assigned them, so
+	// the registry is saved before each checkpoint
+	if cp := opts.checkpoint; cp != nil {
+		cp.beforeFlush = s.saveEvidenceTaskRegistry
+		defer func() { cp.beforeFlush = nil }()
 	}
 
 	// Register all tasks in the registry and update their information
+	completed := 0
 	for i := range domainTasks {
 		// Register/update the task in the registry (this modifies the task)
 		s.evidenceTaskFormatter.RegisterTask(&domainTasks[i])
 
 		// Save the updated task with its reference ID
-		if err := s.saveEvidenceTaskThroughDataService(ctx, &domainTasks[i]); err != nil {
+		saveErr := s.saveEvidenceTaskThroughDataService(ctx, &domainTasks[i])
+		if saveErr != nil {
 			s.logger.Warn("Failed to save updated evidence task",
 				logger.String("task_id", domainTasks[i].ID),
 				logger.String("provider", provider.Name()),
-				logger.Error(err))
+				logger.Error(saveErr))
 		}
 
 		// Generate evidence task document
@@ -423,14 +607,72 @@ func (s *SyncService) syncEvidenceTasksFromProvider(ctx context.Context, provide
 				logger.String("provider", provider.Name()),
 				logger.Error(err))
 		}
+
+		if saveErr == nil && errs[i] == nil {
+			opts.checkpoint.complete(stage, domainTasks[i].ID)
+			completed++
+		}
 	}
 
 	// Save the updated registry
+	s.saveEvidenceTaskRegistry()
+	if completed == len(domainTasks) {
+		opts.checkpoint.finish(stage)
+	}
+
+	return stats, nil
+}
+
+func (s *SyncService) saveEvidenceTaskRegistry() {
 	if err := s.evidenceTaskRegistry.SaveRegistry(); err != nil {
 		s.logger.Warn("Failed to save evidence task registry", logger.Error(err))
 	}
+}
 
-	return stats, nil
+// SyncEvidenceTask fetches a single evidence task by its provider ID and saves
+// it as a full sync would, for when a provider reports the task was created or
+// changed. Registered providers are tried in order until one returns the task.
+func (s *SyncService) SyncEvidenceTask(ctx context.Context, id string) (*domain.EvidenceTask, error) {
+	var lastErr error
+	for _, name := range s.registry.List() {
+		provider, err := s.registry.Get(name)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		fetched, err := provider.GetEvidenceTask(ctx, id)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		task := *fetched
+		refProcessor := domain.NewControlReferenceProcessor()
+		task.RelatedControls = refProcessor.ProcessControlReferences(task.RelatedControls)
+		if existingTask, err := s.storage.GetEvidenceTask(task.ID); err == nil && existingTask.ReferenceID != "" {
+			task.ReferenceID = existingTask.ReferenceID
+		}
+
+		s.evidenceTaskFormatter.RegisterTask(&task)
+		if err := s.saveEvidenceTaskThroughDataService(ctx, &task); err != nil {
+			return nil, fmt.Errorf("failed to save evidence task %s: %w", id, err)
+		}
+		if err := s.generateEvidenceTaskDocument(&task); err != nil {
+			s.logger.Warn("Failed to generate evidence task document",
+				logger.String("task_id", task.ID),
+				logger.String("provider", provider.Name()),
+				logger.Error(err))
+		}
+		if err := s.evidenceTaskRegistry.SaveRegistry(); err != nil {
+			s.logger.Warn("Failed to save evidence task registry", logger.Error(err))
+		}
+		return &task, nil
+	}
+
+	if lastErr == nil {
+		return nil, fmt.Errorf("no providers registered to fetch evidence task %s", id)
+	}
+	return nil, fmt.Errorf("failed to fetch evidence task %s: %w", id, lastErr)
 }
 
 // fetchAllPolicies retrieves all policies from a DataProvider, handling pagination.
@@ -515,6 +757,10 @@ func (s *SyncService) fetchAllEvidenceTasks(ctx context.Context, provider interf
 }
 
 func (s *SyncService) syncSubmissions(ctx context.Context, opts SyncOptions, stats *SyncStats) error {
+	if opts.checkpoint.skipFinished(submissionsStage, stats) {
+		return nil
+	}
+
 	// Try provider-based path: resolve EvidenceSubmitter from registry
 	submitter := s.resolveEvidenceSubmitter("tugboat")
 	if submitter != nil {
@@ -547,23 +793,48 @@ func (s *SyncService) syncSubmissionsViaProvider(ctx context.Context, opts SyncO
 	if err != nil {
 		return fmt.Errorf("failed to get evidence tasks for submission sync: %w", err)
 	}
+	if filter := opts.refs(); filter != nil {
+		keys := s.evidenceTaskKeys()
+		tasks = pick(tasks, selectIndexes(filter, tasks, func(t domain.EvidenceTask) []string { return keys(t.ID) }))
+	}
 
-	totalAttachments := 0
-
-	for _, task := range tasks {
-		taskID := task.ID
+	taskIDs := make([]string, len(tasks))
+	for i, task := range tasks {
+		taskIDs[i] = task.ID
 		if extID, ok := task.ExternalIDs["tugboat"]; ok {
-			taskID = extID
+			taskIDs[i] = extID
 		}
+	}
 
-		atts, _, err := submitter.ListAttachments(ctx, taskID, interfaces.ListOptions{})
-		if err != nil {
+	// A resumed sync skips the tasks whose submissions it already saved
+	indexes, skipped := remaining(opts.checkpoint, submissionsStage, taskIDs, toIndexes(len(taskIDs)), func(id string) string { return id })
+	tasks, taskIDs, stats.Skipped = pick(tasks, indexes), pick(taskIDs, indexes), skipped
+
+	// Fetch attachments and comments concurrently, then save in task order
+	pool := s.fetchPool(opts)
+	attachments, errs := fetchAll(ctx, pool, "attachments", len(tasks), func(ctx context.Context, i int) ([]interfaces.Attachment, error) {
+		atts, _, err := submitter.ListAttachments(ctx, taskIDs[i], interfaces.ListOptions{})
+		return atts, err
+	})
+	comments, commentErrs := s.fetchComments(ctx, pool, taskIDs)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	totalAttachments := 0
+	completed := 0
+
+	for i, task := range tasks {
+		taskID := taskIDs[i]
+		errorsBefore := stats.Errors
+		if errs[i] != nil {
 			s.logger.Warn("Failed to list attachments for evidence task",
 				logger.String("task_id", taskID),
-				logger.Error(err))
+				logger.Error(errs[i]))
 			stats.Errors++
 			continue
 		}
+		atts := attachments[i]
 
 		totalAttachments += len(atts)
 
@@ -575,7 +846,24 @@ func (s *SyncService) syncSubmissionsViaProvider(ctx context.Context, opts SyncO
 			continue
 		}
 
+		if id, err := strconv.Atoi(taskID); err == nil && s.tugboatClient != nil {
+			collected := make(map[int]string, len(atts))
+			for _, att := range atts {
+				if attID, err := strconv.Atoi(att.ID); err == nil {
+					collected[attID] = att.CollectedDate
+				}
+			}
+			s.saveFeedbackForTask(id, comments[i], commentErrs[i], collected, stats)
+		}
+
 		stats.Synced++
+		if stats.Errors == errorsBefore {
+			opts.checkpoint.complete(submissionsStage, taskID)
+			completed++
+		}
+	}
+	if completed == len(tasks) {
+		opts.checkpoint.finish(submissionsStage)
 	}
 
 	stats.Total = totalAttachments
@@ -675,18 +963,45 @@ func (s *SyncService) syncSubmissionsLegacy(ctx context.Context, opts SyncOption
 	if err != nil {
 		return fmt.Errorf("failed to get evidence tasks for submission sync: %w", err)
 	}
+	if filter := opts.refs(); filter != nil {
+		keys := s.evidenceTaskKeys()
+		apiTasks = pick(apiTasks, selectIndexes(filter, apiTasks, func(t tugboatModels.EvidenceTask) []string {
+			return keys(strconv.Itoa(t.ID))
+		}))
+	}
+
+	taskIDs := make([]string, len(apiTasks))
+	for i, apiTask := range apiTasks {
+		taskIDs[i] = strconv.Itoa(apiTask.ID)
+	}
+
+	// A resumed sync skips the tasks whose submissions it already saved
+	indexes, skipped := remaining(opts.checkpoint, submissionsStage, taskIDs, toIndexes(len(taskIDs)), func(id string) string { return id })
+	apiTasks, taskIDs, stats.Skipped = pick(apiTasks, indexes), pick(taskIDs, indexes), skipped
+
+	// Fetch attachments and comments concurrently, then save in task order
+	pool := s.fetchPool(opts)
+	fetched, errs := fetchAll(ctx, pool, "attachments", len(apiTasks), func(ctx context.Context, i int) ([]tugboatModels.EvidenceAttachment, error) {
+		return s.tugboatClient.GetEvidenceAttachmentsByTask(ctx, apiTasks[i].ID)
+	})
+	comments, commentErrs := s.fetchComments(ctx, pool, taskIDs)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 
 	totalAttachments := 0
+	completed := 0
 
-	for _, apiTask := range apiTasks {
-		attachments, err := s.tugboatClient.GetEvidenceAttachmentsByTask(ctx, apiTask.ID)
-		if err != nil {
+	for i, apiTask := range apiTasks {
+		errorsBefore := stats.Errors
+		if errs[i] != nil {
 			s.logger.Warn("Failed to get attachments for evidence task",
 				logger.Int("task_id", apiTask.ID),
-				logger.Error(err))
+				logger.Error(errs[i]))
 			stats.Errors++
 			continue
 		}
+		attachments := fetched[i]
 
 		totalAttachments += len(attachments)
 
@@ -698,7 +1013,20 @@ func (s *SyncService) syncSubmissionsLegacy(ctx context.Context, opts SyncOption
 			continue
 		}
 
+		collected := make(map[int]string, len(attachments))
+		for _, att := range attachments {
+			collected[att.ID] = att.Collected
+		}
+		s.saveFeedbackForTask(apiTask.ID, comments[i], commentErrs[i], collected, stats)
+
 		stats.Synced++
+		if stats.Errors == errorsBefore {
+			opts.checkpoint.complete(submissionsStage, taskIDs[i])
+			completed++
+		}
+	}
+	if completed == len(apiTasks) {
+		opts.checkpoint.finish(submissionsStage)
 	}
 
 	stats.Total = totalAttachments
@@ -740,128 +1068,255 @@ func (s *SyncService) saveAttachmentsForTask(ctx context.Context, taskID int, at
 
 	// Save attachments for each window
 	for window, windowAttachments := range windowMap {
-		// Download actual files to archive/ subfolder for file-type attachments
-		taskDirName := naming.GetEvidenceTaskDirName(task.Name, task.ReferenceID, task.ID)
-		evidenceDir := filepath.Join(s.baseDir, "evidence", taskDirName, window, naming.SubfolderArchive)
-		if err := os.MkdirAll(evidenceDir, 0755); err != nil {
-			s.logger.Warn("Failed to create evidence directory",
+		lock, err := s.storage.LockWindow(task.ReferenceID, window)
+		if err != nil {
+			s.logger.Warn("Skipping attachments for locked evidence window",
 				logger.String("task_ref", task.ReferenceID),
 				logger.String("window", window),
 				logger.Error(err))
 			stats.Errors++
 			continue
 		}
+		s.saveWindowAttachments(ctx, taskID, task, window, windowAttachments, stats)
+		lock.Unlock()
+	}
+
+	return nil
+}
 
-		s.logger.Debug("Processing attachments for window",
+// saveWindowAttachments downloads a window's attachments to its archive/
+// subfolder and records them as the window's archived submission and history
+func (s *SyncService) saveWindowAttachments(ctx context.Context, taskID int, task *domain.EvidenceTask, window string, windowAttachments []tugboatModels.EvidenceAttachment, stats *SyncStats) {
+	// Download actual files to archive/ subfolder for file-type attachments
+	taskDirName := naming.GetEvidenceTaskDirName(task.Name, task.ReferenceID, task.ID)
+	evidenceDir := filepath.Join(s.baseDir, "evidence", taskDirName, window, naming.SubfolderArchive)
+	if err := os.MkdirAll(evidenceDir, 0755); err != nil {
+		s.logger.Warn("Failed to create evidence directory",
 			logger.String("task_ref", task.ReferenceID),
 			logger.String("window", window),
-			logger.Int("total_attachments", len(windowAttachments)))
-
-		for _, att := range windowAttachments {
-			if att.Type == "file" && att.Attachment != nil {
-				// Download the file
-				filename := att.Attachment.OriginalFilename
-				if filename == "" {
-					filename = fmt.Sprintf("attachment_%d", att.ID)
-				}
-				destPath := filepath.Join(evidenceDir, filename)
+			logger.Error(err))
+		stats.Errors++
+		return
+	}
+
+	s.logger.Debug("Processing attachments for window",
+		logger.String("task_ref", task.ReferenceID),
+		logger.String("window", window),
+		logger.Int("total_attachments", len(windowAttachments)))
 
-				s.logger.Debug("Downloading attachment file",
+	for _, att := range windowAttachments {
+		if att.Type == "file" && att.Attachment != nil {
+			// Download the file
+			filename := att.Attachment.OriginalFilename
+			if filename == "" {
+				filename = fmt.Sprintf("attachment_%d", att.ID)
+			}
+			destPath := filepath.Join(evidenceDir, filename)
+
+			s.logger.Debug("Downloading attachment file",
+				logger.Int("attachment_id", att.ID),
+				logger.String("filename", filename),
+				logger.String("dest", destPath))
+
+			if err := s.tugboatClient.DownloadAttachment(ctx, att.ID, destPath); err != nil {
+				s.logger.Warn("Failed to download attachment",
 					logger.Int("attachment_id", att.ID),
 					logger.String("filename", filename),
-					logger.String("dest", destPath))
-
-				if err := s.tugboatClient.DownloadAttachment(ctx, att.ID, destPath); err != nil {
-					s.logger.Warn("Failed to download attachment",
-						logger.Int("attachment_id", att.ID),
-						logger.String("filename", filename),
-						logger.Error(err))
-					stats.Errors++
-					continue
-				}
+					logger.Error(err))
+				stats.Errors++
+				continue
+			}
 
-				stats.Downloaded++
-			} else if att.Type == "url" {
-				// Save URL to a text file
-				filename := fmt.Sprintf("url_reference_%d.txt", att.ID)
-				destPath := filepath.Join(evidenceDir, filename)
-				urlContent := fmt.Sprintf("URL: %s\nNotes: %s\nCollected: %s\n", att.URL, att.Notes, att.Collected)
-				if err := os.WriteFile(destPath, []byte(urlContent), 0644); err != nil {
-					s.logger.Warn("Failed to save URL reference",
-						logger.Int("attachment_id", att.ID),
-						logger.Error(err))
-					stats.Errors++
-				} else {
-					stats.Downloaded++
-				}
-			} else {
-				// Log skipped attachments for debugging
-				s.logger.Debug("Skipping attachment - not downloadable",
+			stats.Downloaded++
+		} else if att.Type == "url" {
+			// Save URL to a text file
+			filename := fmt.Sprintf("url_reference_%d.txt", att.ID)
+			destPath := filepath.Join(evidenceDir, filename)
+			urlContent := fmt.Sprintf("URL: %s\nNotes: %s\nCollected: %s\n", att.URL, att.Notes, att.Collected)
+			if err := os.WriteFile(destPath, []byte(urlContent), 0644); err != nil {
+				s.logger.Warn("Failed to save URL reference",
 					logger.Int("attachment_id", att.ID),
-					logger.String("type", att.Type),
-					logger.Field{Key: "has_attachment_object", Value: att.Attachment != nil})
-				stats.Skipped++
+					logger.Error(err))
+				stats.Errors++
+			} else {
+				stats.Downloaded++
 			}
+		} else {
+			// Log skipped attachments for debugging
+			s.logger.Debug("Skipping attachment - not downloadable",
+				logger.Int("attachment_id", att.ID),
+				logger.String("type", att.Type),
+				logger.Field{Key: "has_attachment_object", Value: att.Attachment != nil})
+			stats.Skipped++
 		}
+	}
 
-		submission := s.convertAttachmentsToSubmission(task.ReferenceID, taskDirName, strconv.Itoa(taskID), window, windowAttachments)
-		if err := s.storage.SaveSubmissionToSubfolder(submission, naming.SubfolderArchive); err != nil {
-			s.logger.Warn("Failed to save submission for window",
-				logger.String("task_ref", task.ReferenceID),
-				logger.String("window", window),
-				logger.Error(err))
-			continue
+	submission := s.convertAttachmentsToSubmission(task.ReferenceID, taskDirName, strconv.Itoa(taskID), window, windowAttachments)
+	if err := s.storage.SaveSubmissionToSubfolder(submission, naming.SubfolderArchive); err != nil {
+		s.logger.Warn("Failed to save submission for window",
+			logger.String("task_ref", task.ReferenceID),
+			logger.String("window", window),
+			logger.Error(err))
+		return
+	}
+
+	// Also save to submission history in submitted/ subfolder
+	history, err := s.storage.LoadSubmissionHistory(task.ReferenceID, window)
+	if err != nil {
+		// History doesn't exist yet, create new one
+		history = &models.SubmissionHistory{
+			TaskRef: task.ReferenceID,
+			Window:  window,
+			Entries: []models.SubmissionHistoryEntry{},
+		}
+	}
+
+	// Add entries from attachments (one per attachment)
+	for _, att := range windowAttachments {
+		entry := models.SubmissionHistoryEntry{
+			SubmissionID: strconv.Itoa(att.ID),
+			SubmittedAt:  s.parseTime(att.Created),
+			SubmittedBy:  s.getDisplayName(att.Owner),
+			Status:       "accepted", // Tugboat attachments are already accepted
+			FileCount:    1,
+			Notes:        att.Notes,
 		}
+		history.Entries = append(history.Entries, entry)
+	}
+
+	if err := s.storage.SaveSubmissionHistoryToSubfolder(history, naming.SubfolderArchive); err != nil {
+		s.logger.Warn("Failed to save submission history",
+			logger.String("task_ref", task.ReferenceID),
+			logger.String("window", window),
+			logger.Error(err))
+	}
+}
 
-		// Also save to submission history in submitted/ subfolder
-		history, err := s.storage.LoadSubmissionHistory(task.ReferenceID, window)
+// fetchComments fetches the auditor comments on each task. Tasks without a
+// numeric Tugboat ID, or without a Tugboat client, have none.
+func (s *SyncService) fetchComments(ctx context.Context, pool fetchPool, taskIDs []string) ([][]tugboatModels.EvidenceComment, []error) {
+	if s.tugboatClient == nil {
+		return make([][]tugboatModels.EvidenceComment, len(taskIDs)), make([]error, len(taskIDs))
+	}
+	return fetchAll(ctx, pool, "comments", len(taskIDs), func(ctx context.Context, i int) ([]tugboatModels.EvidenceComment, error) {
+		id, err := strconv.Atoi(taskIDs[i])
 		if err != nil {
-			// History doesn't exist yet, create new one
-			history = &models.SubmissionHistory{
-				TaskRef: task.ReferenceID,
-				Window:  window,
-				Entries: []models.SubmissionHistoryEntry{},
-			}
+			return nil, nil
 		}
+		return s.tugboatClient.GetEvidenceComments(ctx, id)
+	})
+}
 
-		// Add entries from attachments (one per attachment)
-		for _, att := range windowAttachments {
-			entry := models.SubmissionHistoryEntry{
-				SubmissionID: strconv.Itoa(att.ID),
-				SubmittedAt:  s.parseTime(att.Created),
-				SubmittedBy:  s.getDisplayName(att.Owner),
-				Status:       "accepted", // Tugboat attachments are already accepted
-				FileCount:    1,
-				Notes:        att.Notes,
+// saveFeedbackForTask saves the auditor comments on a task to each window's
+// .submission/feedback.yaml. Comments on an attachment belong to the window the
+// attachment was collected in (collected maps attachment IDs to collection
+// dates); task-level comments belong to the window they were made in. The
+// whole thread is also saved to the task's comments.yaml.
+func (s *SyncService) saveFeedbackForTask(taskID int, comments []tugboatModels.EvidenceComment, fetchErr error, collected map[int]string, stats *SyncStats) {
+	if fetchErr != nil {
+		s.logger.Warn("Failed to get comments for evidence task",
+			logger.Int("task_id", taskID),
+			logger.Error(fetchErr))
+		stats.Errors++
+		return
+	}
+	if len(comments) == 0 {
+		return
+	}
+
+	task, err := s.storage.GetEvidenceTask(strconv.Itoa(taskID))
+	if err != nil || task.ReferenceID == "" {
+		s.logger.Warn("Evidence task not found in storage, skipping feedback save",
+			logger.Int("task_id", taskID))
+		return
+	}
+
+	now := time.Now()
+	thread := &models.TaskComments{TaskRef: task.ReferenceID, SyncedAt: now}
+	windowMap := make(map[string]*models.EvidenceFeedback)
+	var windows []string
+	for _, comment := range comments {
+		entry := s.feedbackComment(comment)
+		thread.Comments = append(thread.Comments, entry)
+		window := s.getWindowFromDate(entry.CreatedAt.Format("2006-01-02"))
+		if comment.OrgEvidenceAttachmentID != nil {
+			if date, ok := collected[*comment.OrgEvidenceAttachmentID]; ok {
+				window = s.getWindowFromDate(date)
 			}
-			history.Entries = append(history.Entries, entry)
 		}
 
-		if err := s.storage.SaveSubmissionHistoryToSubfolder(history, naming.SubfolderArchive); err != nil {
-			s.logger.Warn("Failed to save submission history",
+		feedback, ok := windowMap[window]
+		if !ok {
+			feedback = &models.EvidenceFeedback{TaskRef: task.ReferenceID, Window: window, SyncedAt: now}
+			windowMap[window] = feedback
+			windows = append(windows, window)
+		}
+		feedback.Comments = append(feedback.Comments, entry)
+	}
+
+	for _, window := range windows {
+		feedback := windowMap[window]
+		if err := s.storage.SaveFeedback(feedback); err != nil {
+			s.logger.Warn("Failed to save feedback",
 				logger.String("task_ref", task.ReferenceID),
 				logger.String("window", window),
 				logger.Error(err))
+			stats.Errors++
+			continue
 		}
+		stats.Feedback += len(feedback.Comments)
 	}
 
-	return nil
+	if err := s.storage.SaveTaskComments(thread); err != nil {
+		s.logger.Warn("Failed to save comment thread",
+			logger.String("task_ref", task.ReferenceID),
+			logger.Error(err))
+		stats.Errors++
+	}
+}
+
+// feedbackComment converts a Tugboat comment to its local form
+func (s *SyncService) feedbackComment(comment tugboatModels.EvidenceComment) models.FeedbackComment {
+	entry := models.FeedbackComment{
+		ID:        strconv.Itoa(comment.ID),
+		Author:    s.getDisplayName(comment.Owner),
+		Status:    comment.ReviewStatus,
+		Comment:   comment.Body,
+		CreatedAt: s.parseTime(comment.Created),
+		Resolved:  comment.Resolved,
+	}
+	if entry.Status == "" {
+		entry.Status = "comment"
+	}
+	if comment.OrgEvidenceAttachmentID != nil {
+		entry.AttachmentID = strconv.Itoa(*comment.OrgEvidenceAttachmentID)
+	}
+	return entry
 }
 
 // Domain storage integration methods
 // These methods save domain models directly to domain storage
 
-func (s *SyncService) savePolicyThroughDataService(ctx context.Context, policy *domain.Policy) error {
+func (s *SyncService) savePolicyThroughDataService(ctx context.Context, policy *domain.Policy) (err error) {
+	_, span := telemetry.Start(ctx, "storage save policy", attribute.String("document.id", policy.ID))
+	defer func() { telemetry.End(span, err) }()
+
 	// Always use unified storage for consistent naming
 	return s.storage.SavePolicy(policy)
 }
 
-func (s *SyncService) saveControlThroughDataService(ctx context.Context, control *domain.Control) error {
+func (s *SyncService) saveControlThroughDataService(ctx context.Context, control *domain.Control) (err error) {
+	_, span := telemetry.Start(ctx, "storage save control", attribute.String("document.id", control.ID))
+	defer func() { telemetry.End(span, err) }()
+
 	// Always use unified storage for consistent naming
 	return s.storage.SaveControl(control)
 }
 
-func (s *SyncService) saveEvidenceTaskThroughDataService(ctx context.Context, task *domain.EvidenceTask) error {
+func (s *SyncService) saveEvidenceTaskThroughDataService(ctx context.Context, task *domain.EvidenceTask) (err error) {
+	_, span := telemetry.Start(ctx, "storage save evidence task", attribute.String("document.id", task.ID))
+	defer func() { telemetry.End(span, err) }()
+
 	// Always use unified storage for consistent naming
 	return s.storage.SaveEvidenceTask(task)
 }
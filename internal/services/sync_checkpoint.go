@@ -0,0 +1,194 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/storage"
+)
+
+// checkpointEvery is how many saved items a sync records between checkpoint
+// writes, bounding the work lost if it is killed
+const checkpointEvery = 25
+
+// submissionsStage is the checkpoint stage of submission sync, which is not
+// per provider
+const submissionsStage = "submissions"
+
+// syncStage names the checkpoint stage for one type from one provider
+func syncStage(provider, kind string) string {
+	return provider + "/" + kind
+}
+
+// syncScope describes what a sync covers, so a resume can check it continues
+// the same sync
+func syncScope(opts SyncOptions) string {
+	var types []string
+	for _, t := range []struct {
+		enabled bool
+		name    string
+	}{
+		{opts.Policies, "policies"},
+		{opts.Controls, "controls"},
+		{opts.Evidence, "evidence-tasks"},
+		{opts.Submissions, "submissions"},
+	} {
+		if t.enabled {
+			types = append(types, t.name)
+		}
+	}
+
+	scope := strings.Join(types, ",")
+	if opts.Framework != "" {
+		scope += " framework=" + opts.Framework
+	}
+	if len(opts.Refs) > 0 {
+		scope += " refs=" + strings.Join(opts.Refs, ",")
+	}
+	return scope
+}
+
+// syncCheckpoint records a sync's progress in storage as it goes. A nil
+// checkpoint, as used by dry runs, records nothing.
+type syncCheckpoint struct {
+	*models.SyncCheckpoint
+	storage *storage.Storage
+	logger  logger.Logger
+	resumed bool
+	unsaved int
+
+	// beforeFlush saves state the recorded items depend on, such as the
+	// evidence task registry, before the checkpoint claims them
+	beforeFlush func()
+}
+
+// startCheckpoint begins recording a sync. With opts.Resume it continues the
+// checkpoint of the sync that did not finish, which must have the same scope.
+func (s *SyncService) startCheckpoint(opts SyncOptions) (*syncCheckpoint, error) {
+	scope := syncScope(opts)
+	cp := &syncCheckpoint{storage: s.storage, logger: s.logger}
+
+	if opts.Resume {
+		previous, err := s.storage.LoadSyncCheckpoint()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case previous == nil:
+			s.logger.Info("No sync checkpoint to resume, starting a full sync")
+		case previous.Scope != scope:
+			return nil, fmt.Errorf("the unfinished sync was for %q, not %q: resume it with the same options or sync without --resume", previous.Scope, scope)
+		default:
+			s.logger.Info("Resuming sync from checkpoint",
+				logger.String("scope", scope),
+				logger.Int("completed", previous.CompletedCount()))
+			cp.SyncCheckpoint = previous
+			cp.resumed = true
+			return cp, nil
+		}
+	}
+
+	cp.SyncCheckpoint = models.NewSyncCheckpoint(scope)
+	cp.flush()
+	return cp, nil
+}
+
+func (cp *syncCheckpoint) completed(stage, id string) bool {
+	return cp != nil && cp.IsCompleted(stage, id)
+}
+
+func (cp *syncCheckpoint) finished(stage string) bool {
+	return cp != nil && cp.IsFinished(stage)
+}
+
+// skipFinished reports whether a resumed sync already finished the stage,
+// counting its items as skipped
+func (cp *syncCheckpoint) skipFinished(stage string, stats *SyncStats) bool {
+	if !cp.finished(stage) {
+		return false
+	}
+	stats.Skipped = len(cp.Completed[stage])
+	return true
+}
+
+// complete records a saved item, writing the checkpoint every checkpointEvery items
+func (cp *syncCheckpoint) complete(stage, id string) {
+	if cp == nil {
+		return
+	}
+	cp.MarkCompleted(stage, id)
+	if cp.unsaved++; cp.unsaved >= checkpointEvery {
+		cp.flush()
+	}
+}
+
+// finish records a stage that saved everything it listed
+func (cp *syncCheckpoint) finish(stage string) {
+	if cp == nil {
+		return
+	}
+	cp.MarkFinished(stage)
+	cp.flush()
+}
+
+// end removes the checkpoint of a sync that finished every stage, and keeps
+// it for --resume otherwise. It reports whether the checkpoint was kept.
+func (cp *syncCheckpoint) end(stages []string) bool {
+	if cp == nil {
+		return false
+	}
+	for _, stage := range stages {
+		if !cp.IsFinished(stage) {
+			cp.flush()
+			return true
+		}
+	}
+	if err := cp.storage.ClearSyncCheckpoint(); err != nil {
+		cp.logger.Warn("Failed to remove sync checkpoint", logger.Error(err))
+	}
+	return false
+}
+
+// flush writes the checkpoint. A failure only costs the ability to resume,
+// so it is logged rather than failing the sync.
+func (cp *syncCheckpoint) flush() {
+	cp.unsaved = 0
+	if cp.beforeFlush != nil {
+		cp.beforeFlush()
+	}
+	if err := cp.storage.SaveSyncCheckpoint(cp.SyncCheckpoint); err != nil {
+		cp.logger.Warn("Failed to save sync checkpoint", logger.Error(err))
+	}
+}
+
+// remaining drops the indexes of items a resumed sync already saved,
+// returning the rest and how many were dropped
+func remaining[T any](cp *syncCheckpoint, stage string, items []T, indexes []int, id func(T) string) ([]int, int) {
+	if cp == nil {
+		return indexes, 0
+	}
+	kept := indexes[:0:0]
+	for _, idx := range indexes {
+		if !cp.completed(stage, id(items[idx])) {
+			kept = append(kept, idx)
+		}
+	}
+	return kept, len(indexes) - len(kept)
+}
@@ -0,0 +1,123 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/providers"
+	"github.com/grctool/grctool/internal/testhelpers"
+	"github.com/grctool/grctool/internal/tugboat"
+)
+
+// flakyProvider fails policy detail fetches for the IDs in failing, and
+// records the IDs fetched
+type flakyProvider struct {
+	*testhelpers.StubDataProvider
+	mu      sync.Mutex
+	failing map[string]bool
+	fetched []string
+}
+
+func (p *flakyProvider) GetPolicy(ctx context.Context, id string) (*domain.Policy, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.fetched = append(p.fetched, id)
+	if p.failing[id] {
+		return nil, &tugboat.HTTPError{StatusCode: 401, Message: "HTTP 401: session expired"}
+	}
+	return p.StubDataProvider.GetPolicy(ctx, id)
+}
+
+func TestSyncServiceWithRegistry_Resume(t *testing.T) {
+	// Documents are written relative to the working directory
+	t.Chdir(t.TempDir())
+
+	stub := &flakyProvider{StubDataProvider: testhelpers.NewStubDataProvider("test"), failing: map[string]bool{}}
+	for i := 1; i <= 30; i++ {
+		policy := testhelpers.SamplePolicy()
+		policy.ID = fmt.Sprintf("%d", 95000+i)
+		policy.Name = fmt.Sprintf("Policy %d", i)
+		stub.Policies[policy.ID] = policy
+		if i > 26 {
+			stub.failing[policy.ID] = true
+		}
+	}
+	reg := providers.NewProviderRegistry()
+	if err := reg.Register(stub); err != nil {
+		t.Fatal(err)
+	}
+	svc, st := testSyncService(t, reg)
+	ctx := context.Background()
+
+	// The session expires partway: four policies are saved without details
+	result, err := svc.SyncAll(ctx, SyncOptions{Policies: true})
+	if err != nil {
+		t.Fatalf("SyncAll failed: %v", err)
+	}
+	if !result.Resumable {
+		t.Fatal("expected an incomplete sync to keep its checkpoint")
+	}
+	checkpoint, err := st.LoadSyncCheckpoint()
+	if err != nil || checkpoint == nil {
+		t.Fatalf("expected a checkpoint, got %v, %v", checkpoint, err)
+	}
+	if got := len(checkpoint.Completed["test/policies"]); got != 26 {
+		t.Errorf("expected 26 completed policies, got %d", got)
+	}
+
+	// A resume with other options does not continue this sync
+	if _, err := svc.SyncAll(ctx, SyncOptions{Controls: true, Resume: true}); err == nil || !strings.Contains(err.Error(), "same options") {
+		t.Errorf("expected a scope mismatch error, got %v", err)
+	}
+
+	// After logging in again, the resume fetches only what is left
+	stub.failing = map[string]bool{}
+	stub.fetched = nil
+	result, err = svc.SyncAll(ctx, SyncOptions{Policies: true, Resume: true})
+	if err != nil {
+		t.Fatalf("SyncAll failed: %v", err)
+	}
+	if !result.Resumed || result.Resumable {
+		t.Errorf("expected a resumed, finished sync: %+v", result)
+	}
+	if result.Policies.Skipped != 26 || result.Policies.Synced != 4 || result.Policies.Detailed != 4 {
+		t.Errorf("unexpected stats: %+v", result.Policies)
+	}
+	if len(stub.fetched) != 4 {
+		t.Errorf("expected 4 detail fetches, got %v", stub.fetched)
+	}
+	if checkpoint, err := st.LoadSyncCheckpoint(); err != nil || checkpoint != nil {
+		t.Errorf("expected the checkpoint to be removed, got %+v, %v", checkpoint, err)
+	}
+}
+
+func TestSyncServiceWithRegistry_ResumeWithoutCheckpoint(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	stub := testhelpers.NewStubDataProvider("test")
+	policy := testhelpers.SamplePolicy()
+	stub.Policies[policy.ID] = policy
+	reg := providers.NewProviderRegistry()
+	if err := reg.Register(stub); err != nil {
+		t.Fatal(err)
+	}
+	svc, st := testSyncService(t, reg)
+
+	result, err := svc.SyncAll(context.Background(), SyncOptions{Policies: true, Resume: true})
+	if err != nil {
+		t.Fatalf("SyncAll failed: %v", err)
+	}
+	if result.Resumed || result.Resumable || result.Policies.Synced != 1 {
+		t.Errorf("expected a full sync, got %+v", result)
+	}
+	if checkpoint, _ := st.LoadSyncCheckpoint(); checkpoint != nil {
+		t.Errorf("expected no checkpoint after a finished sync, got %+v", checkpoint)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/storage"
+)
+
+// PostComment posts a comment on an evidence task in Tugboat Logic and adds
+// it to the task's local comment thread
+func (s *SyncService) PostComment(ctx context.Context, taskRef, body string) (*models.FeedbackComment, error) {
+	if s.tugboatClient == nil {
+		return nil, errors.New("posting comments requires a Tugboat client")
+	}
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, errors.New("comment cannot be empty")
+	}
+
+	task, taskID, err := s.commentTask(taskRef)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := s.tugboatClient.CreateEvidenceComment(ctx, taskID, body)
+	if err != nil {
+		return nil, err
+	}
+	entry := s.feedbackComment(*comment)
+	if comment.Owner == nil {
+		// The response does not embed the author
+		entry.Author = storage.CustodyActor()
+	}
+	if entry.Comment == "" {
+		entry.Comment = body
+	}
+
+	thread, err := s.storage.LoadTaskComments(task.ReferenceID)
+	if err != nil {
+		return nil, err
+	}
+	thread.Comments = append(thread.Comments, entry)
+	if err := s.storage.SaveTaskComments(thread); err != nil {
+		// The comment is in Tugboat; the next sync brings it back
+		s.logger.Warn("Failed to save posted comment locally; run 'grctool sync --submissions'",
+			logger.String("task_ref", task.ReferenceID),
+			logger.Error(err))
+	}
+	return &entry, nil
+}
+
+// SyncTaskComments fetches the comment thread of an evidence task from
+// Tugboat Logic and saves it locally
+func (s *SyncService) SyncTaskComments(ctx context.Context, taskRef string) (*models.TaskComments, error) {
+	if s.tugboatClient == nil {
+		return nil, errors.New("fetching comments requires a Tugboat client")
+	}
+
+	task, taskID, err := s.commentTask(taskRef)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := s.tugboatClient.GetEvidenceComments(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	thread := &models.TaskComments{TaskRef: task.ReferenceID, SyncedAt: time.Now()}
+	for _, comment := range comments {
+		thread.Comments = append(thread.Comments, s.feedbackComment(comment))
+	}
+	if err := s.storage.SaveTaskComments(thread); err != nil {
+		return nil, err
+	}
+	return thread, nil
+}
+
+// commentTask looks up a synced task and its numeric Tugboat ID
+func (s *SyncService) commentTask(taskRef string) (*domain.EvidenceTask, int, error) {
+	task, err := s.storage.GetEvidenceTask(taskRef)
+	if err != nil {
+		return nil, 0, err
+	}
+	taskID, err := strconv.Atoi(task.ID)
+	if err != nil || task.ReferenceID == "" {
+		return nil, 0, fmt.Errorf("task %s has not been synced from Tugboat", taskRef)
+	}
+	return task, taskID, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/providers"
+	"github.com/grctool/grctool/internal/testhelpers"
+	"github.com/grctool/grctool/internal/tugboat"
+	tugboatModels "github.com/grctool/grctool/internal/tugboat/models"
+)
+
+func TestSyncService_PostComment(t *testing.T) {
+	// Task documents are written relative to the working directory
+	t.Chdir(t.TempDir())
+
+	stub := testhelpers.NewStubDataProvider("tugboat")
+	task := testhelpers.SampleEvidenceTask()
+	stub.Tasks[task.ID] = task
+
+	reg := providers.NewProviderRegistry()
+	if err := reg.Register(stub); err != nil {
+		t.Fatal(err)
+	}
+	svc, st := testSyncService(t, reg)
+	ctx := context.Background()
+	synced, err := svc.SyncEvidenceTask(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("SyncEvidenceTask failed: %v", err)
+	}
+
+	if _, err := svc.PostComment(ctx, synced.ReferenceID, "question"); err == nil {
+		t.Error("expected an error without a Tugboat client")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("bad request body: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(tugboatModels.EvidenceComment{
+				ID: 903, Created: "2025-11-06T08:30:00Z", Body: body["body"].(string),
+			})
+		default:
+			_ = json.NewEncoder(w).Encode(tugboatModels.EvidenceCommentListResponse{Results: []tugboatModels.EvidenceComment{
+				{ID: 903, Created: "2025-11-06T08:30:00Z", Body: "Does the export need service accounts?",
+					Owner: &tugboatModels.OrganizationMember{DisplayName: "Jane Doe"}},
+				{ID: 904, Created: "2025-11-06T11:00:00Z", Body: "Yes, include them",
+					Owner: &tugboatModels.OrganizationMember{DisplayName: "Alex Auditor"}},
+			}})
+		}
+	}))
+	defer server.Close()
+	svc.tugboatClient = tugboat.NewClient(&config.TugboatConfig{BaseURL: server.URL, Timeout: 5 * time.Second}, nil)
+
+	if _, err := svc.PostComment(ctx, synced.ReferenceID, "  "); err == nil {
+		t.Error("expected an error for an empty comment")
+	}
+
+	posted, err := svc.PostComment(ctx, synced.ReferenceID, "Does the export need service accounts?")
+	if err != nil {
+		t.Fatalf("PostComment failed: %v", err)
+	}
+	if posted.ID != "903" || posted.Status != "comment" || posted.Author == "" || posted.Author == "Unknown" {
+		t.Errorf("unexpected posted comment: %+v", posted)
+	}
+
+	thread, err := st.LoadTaskComments(synced.ReferenceID)
+	if err != nil {
+		t.Fatalf("LoadTaskComments failed: %v", err)
+	}
+	if len(thread.Comments) != 1 || thread.Comments[0].Comment != "Does the export need service accounts?" {
+		t.Errorf("posted comment not saved locally: %+v", thread)
+	}
+
+	thread, err = svc.SyncTaskComments(ctx, synced.ReferenceID)
+	if err != nil {
+		t.Fatalf("SyncTaskComments failed: %v", err)
+	}
+	if len(thread.Comments) != 2 || thread.Comments[1].Author != "Alex Auditor" {
+		t.Errorf("unexpected synced thread: %+v", thread)
+	}
+	saved, err := st.LoadTaskComments(synced.ReferenceID)
+	if err != nil {
+		t.Fatalf("LoadTaskComments failed: %v", err)
+	}
+	if len(saved.Comments) != 2 || saved.SyncedAt.IsZero() {
+		t.Errorf("synced thread not saved: %+v", saved)
+	}
+}
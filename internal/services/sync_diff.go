@@ -0,0 +1,247 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/logger"
+)
+
+// Sync preview actions
+const (
+	SyncChangeNew     = "new"
+	SyncChangeUpdated = "updated"
+	SyncChangeDeleted = "deleted" // Gone upstream; sync keeps the local copy
+)
+
+// SyncChange describes how a sync would change one local document
+type SyncChange struct {
+	EntityType  string        `json:"entity_type"` // "policy", "control", "evidence_task"
+	ID          string        `json:"id"`
+	ReferenceID string        `json:"reference_id,omitempty"`
+	Name        string        `json:"name"`
+	Action      string        `json:"action"`
+	Fields      []FieldChange `json:"fields,omitempty"` // For updates: the key metadata that changed
+}
+
+// FieldChange is a changed metadata field. Long text fields are summarized
+// by length rather than shown in full.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// syncDocument is the identity and key metadata of a document, as compared
+// by a sync preview
+type syncDocument struct {
+	id          string
+	referenceID string
+	name        string
+	fields      []syncField
+}
+
+type syncField struct {
+	name  string
+	value string
+}
+
+func policyDocument(p domain.Policy) syncDocument {
+	return syncDocument{id: p.ID, referenceID: p.ReferenceID, name: p.Name, fields: []syncField{
+		{"name", p.Name},
+		{"status", p.Status},
+		{"framework", p.Framework},
+		{"category", p.Category},
+		{"version", p.Version},
+		{"control_count", strconv.Itoa(p.ControlCount)},
+		{"description", p.Description},
+		{"content", p.Content},
+		{"updated_at", formatSyncTime(p.UpdatedAt)},
+	}}
+}
+
+func controlDocument(c domain.Control) syncDocument {
+	return syncDocument{id: c.ID, referenceID: c.ReferenceID, name: c.Name, fields: []syncField{
+		{"name", c.Name},
+		{"status", c.Status},
+		{"category", c.Category},
+		{"framework", c.Framework},
+		{"risk_level", c.RiskLevel},
+		{"codes", c.Codes},
+		{"description", c.Description},
+	}}
+}
+
+func evidenceTaskDocument(t domain.EvidenceTask) syncDocument {
+	assignees := make([]string, 0, len(t.Assignees))
+	for _, a := range t.Assignees {
+		assignees = append(assignees, a.Name)
+	}
+	sort.Strings(assignees)
+	controls := make([]string, 0, len(t.RelatedControls))
+	for _, c := range t.RelatedControls {
+		controls = append(controls, c.ReferenceID)
+	}
+	sort.Strings(controls)
+
+	return syncDocument{id: t.ID, referenceID: t.ReferenceID, name: t.Name, fields: []syncField{
+		{"name", t.Name},
+		{"status", t.Status},
+		{"completed", strconv.FormatBool(t.Completed)},
+		{"collection_interval", t.CollectionInterval},
+		{"priority", t.Priority},
+		{"assignees", strings.Join(assignees, ", ")},
+		{"controls", strings.Join(controls, ", ")},
+		{"description", t.Description},
+		{"guidance", t.Guidance},
+		{"updated_at", formatSyncTime(t.UpdatedAt)},
+	}}
+}
+
+// diffDocuments compares the documents a sync would write with those stored
+// locally, returning the new and updated ones in remote order
+func diffDocuments(entityType string, local, remote []syncDocument) []SyncChange {
+	byID := make(map[string]syncDocument, len(local))
+	for _, doc := range local {
+		byID[doc.id] = doc
+	}
+
+	var changes []SyncChange
+	for _, doc := range remote {
+		change := SyncChange{EntityType: entityType, ID: doc.id, ReferenceID: doc.referenceID, Name: doc.name}
+		existing, ok := byID[doc.id]
+		if !ok {
+			change.Action = SyncChangeNew
+			changes = append(changes, change)
+			continue
+		}
+		if change.ReferenceID == "" {
+			change.ReferenceID = existing.referenceID
+		}
+		change.Fields = diffFields(existing.fields, doc.fields)
+		if len(change.Fields) > 0 {
+			change.Action = SyncChangeUpdated
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// deletedDocuments returns the local documents that no provider listed
+func deletedDocuments(entityType string, local []syncDocument, seen map[string]bool) []SyncChange {
+	var changes []SyncChange
+	for _, doc := range local {
+		if !seen[doc.id] {
+			changes = append(changes, SyncChange{
+				EntityType:  entityType,
+				ID:          doc.id,
+				ReferenceID: doc.referenceID,
+				Name:        doc.name,
+				Action:      SyncChangeDeleted,
+			})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].ID < changes[j].ID })
+	return changes
+}
+
+// diffFields compares fields by name, in the order they are listed
+func diffFields(old, new []syncField) []FieldChange {
+	oldValues := make(map[string]string, len(old))
+	for _, f := range old {
+		oldValues[f.name] = f.value
+	}
+
+	var changes []FieldChange
+	for _, f := range new {
+		if oldValue := oldValues[f.name]; oldValue != f.value {
+			changes = append(changes, FieldChange{Field: f.name, Old: summarizeField(oldValue), New: summarizeField(f.value)})
+		}
+	}
+	return changes
+}
+
+// maxFieldPreview is the longest value a FieldChange shows in full
+const maxFieldPreview = 60
+
+// summarizeField shortens long values, such as policy content, to their length
+func summarizeField(value string) string {
+	if len(value) <= maxFieldPreview && !strings.Contains(value, "\n") {
+		return value
+	}
+	return fmt.Sprintf("(%d chars)", len(value))
+}
+
+func formatSyncTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func toSyncDocuments[T any](items []T, document func(T) syncDocument) []syncDocument {
+	docs := make([]syncDocument, len(items))
+	for i, item := range items {
+		docs[i] = document(item)
+	}
+	return docs
+}
+
+// localDocuments loads the stored documents of one type for a preview. A
+// load failure is logged and treated as an empty store.
+func localDocuments[T any](log logger.Logger, kind string, load func() ([]T, error), document func(T) syncDocument) []syncDocument {
+	items, err := load()
+	if err != nil {
+		log.Warn("Failed to load local "+kind+" for sync preview", logger.Error(err))
+		return nil
+	}
+	return toSyncDocuments(items, document)
+}
+
+// previewDeletions adds the stored documents that no provider listed to a
+// dry-run result. It is skipped for any type whose listing failed or was
+// filtered by framework or reference, where absence does not mean deletion.
+func (s *SyncService) previewDeletions(opts SyncOptions, result *SyncResult, seen map[string]map[string]bool, failed map[string]bool) {
+	if opts.Framework != "" || len(opts.Refs) > 0 {
+		return
+	}
+	if opts.Policies && !failed["policy"] {
+		local := localDocuments(s.logger, "policies", s.storage.GetAllPolicies, policyDocument)
+		result.Policies.Changes = append(result.Policies.Changes, deletedDocuments("policy", local, seen["policy"])...)
+	}
+	if opts.Controls && !failed["control"] {
+		local := localDocuments(s.logger, "controls", s.storage.GetAllControls, controlDocument)
+		result.Controls.Changes = append(result.Controls.Changes, deletedDocuments("control", local, seen["control"])...)
+	}
+	if opts.Evidence && !failed["evidence_task"] {
+		local := localDocuments(s.logger, "evidence tasks", s.storage.GetAllEvidenceTasks, evidenceTaskDocument)
+		result.EvidenceTasks.Changes = append(result.EvidenceTasks.Changes, deletedDocuments("evidence_task", local, seen["evidence_task"])...)
+	}
+}
+
+func toIDs[T any](items []T, id func(T) string) []string {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = id(item)
+	}
+	return ids
+}
@@ -0,0 +1,158 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/providers"
+	"github.com/grctool/grctool/internal/testhelpers"
+)
+
+func TestSyncServiceWithRegistry_DryRun(t *testing.T) {
+	// Documents are written relative to the working directory
+	t.Chdir(t.TempDir())
+
+	stub := testhelpers.NewStubDataProvider("test")
+	kept := testhelpers.SamplePolicy()
+	changed := testhelpers.SamplePolicy()
+	changed.ID, changed.Name = "12346", "Data Retention Policy"
+	removed := testhelpers.SamplePolicy()
+	removed.ID, removed.Name = "12347", "Legacy Policy"
+	for _, p := range []*domain.Policy{kept, changed, removed} {
+		stub.Policies[p.ID] = p
+	}
+
+	reg := providers.NewProviderRegistry()
+	if err := reg.Register(stub); err != nil {
+		t.Fatal(err)
+	}
+	svc, st := testSyncService(t, reg)
+	ctx := context.Background()
+	if _, err := svc.SyncAll(ctx, SyncOptions{Policies: true}); err != nil {
+		t.Fatalf("SyncAll failed: %v", err)
+	}
+
+	// Upstream: one policy changes, one is removed and one is added
+	changed.Status = "draft"
+	changed.Content = changed.Content + " Records are retained for seven years."
+	delete(stub.Policies, removed.ID)
+	added := testhelpers.SamplePolicy()
+	added.ID, added.Name = "12348", "Vendor Management Policy"
+	stub.Policies[added.ID] = added
+
+	result, err := svc.SyncAll(ctx, SyncOptions{Policies: true, Submissions: true, DryRun: true})
+	if err != nil {
+		t.Fatalf("SyncAll failed: %v", err)
+	}
+
+	byID := map[string]SyncChange{}
+	for _, change := range result.Policies.Changes {
+		byID[change.ID] = change
+	}
+	if len(byID) != 3 {
+		t.Fatalf("expected 3 changes, got %+v", result.Policies.Changes)
+	}
+	if byID[added.ID].Action != SyncChangeNew {
+		t.Errorf("expected %s to be new, got %+v", added.ID, byID[added.ID])
+	}
+	if byID[removed.ID].Action != SyncChangeDeleted || byID[removed.ID].Name != "Legacy Policy" {
+		t.Errorf("expected %s to be deleted, got %+v", removed.ID, byID[removed.ID])
+	}
+
+	update := byID[changed.ID]
+	if update.Action != SyncChangeUpdated || len(update.Fields) != 2 {
+		t.Fatalf("expected a status and content update, got %+v", update)
+	}
+	if f := update.Fields[0]; f.Field != "status" || f.Old != "active" || f.New != "draft" {
+		t.Errorf("unexpected status change: %+v", f)
+	}
+	if f := update.Fields[1]; f.Field != "content" || f.Old != "(79 chars)" || f.New != "(117 chars)" {
+		t.Errorf("unexpected content change: %+v", f)
+	}
+
+	if result.Policies.Synced != 0 || result.Submissions.Total != 0 {
+		t.Errorf("dry run should not sync anything: %+v", result)
+	}
+	if _, err := st.GetPolicy(added.ID); err == nil {
+		t.Errorf("dry run saved new policy %s", added.ID)
+	}
+	stored, err := st.GetPolicy(changed.ID)
+	if err != nil {
+		t.Fatalf("GetPolicy failed: %v", err)
+	}
+	if stored.Status != "active" {
+		t.Errorf("dry run updated policy %s: status %q", changed.ID, stored.Status)
+	}
+}
+
+func TestSyncServiceWithRegistry_DryRunSkipsDeletionsOnFailure(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	stub := testhelpers.NewStubDataProvider("test")
+	task := testhelpers.SampleEvidenceTask()
+	stub.Tasks[task.ID] = task
+	reg := providers.NewProviderRegistry()
+	if err := reg.Register(stub); err != nil {
+		t.Fatal(err)
+	}
+	svc, _ := testSyncService(t, reg)
+	ctx := context.Background()
+	if _, err := svc.SyncAll(ctx, SyncOptions{Evidence: true}); err != nil {
+		t.Fatalf("SyncAll failed: %v", err)
+	}
+
+	// A listing failure must not make every local task look deleted
+	stub.ConnError = fmt.Errorf("simulated connection failure")
+	result, err := svc.SyncAll(ctx, SyncOptions{Evidence: true, DryRun: true})
+	if err != nil {
+		t.Fatalf("SyncAll failed: %v", err)
+	}
+	if len(result.Errors) != 1 || len(result.EvidenceTasks.Changes) != 0 {
+		t.Errorf("expected an error and no changes, got %+v", result)
+	}
+}
+
+func TestDiffFields(t *testing.T) {
+	t.Parallel()
+
+	longText := "line one\nline two"
+	tests := map[string]struct {
+		old, new []syncField
+		want     []FieldChange
+	}{
+		"unchanged": {
+			old: []syncField{{"status", "active"}},
+			new: []syncField{{"status", "active"}},
+		},
+		"short value shown in full": {
+			old:  []syncField{{"status", "active"}, {"name", "A"}},
+			new:  []syncField{{"status", "retired"}, {"name", "A"}},
+			want: []FieldChange{{Field: "status", Old: "active", New: "retired"}},
+		},
+		"multi-line value summarized": {
+			old:  []syncField{{"content", ""}},
+			new:  []syncField{{"content", longText}},
+			want: []FieldChange{{Field: "content", Old: "", New: "(17 chars)"}},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := diffFields(tc.old, tc.new)
+			if len(got) != len(tc.want) {
+				t.Fatalf("expected %+v, got %+v", tc.want, got)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("expected %+v, got %+v", tc.want[i], got[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/providers"
+	"github.com/grctool/grctool/internal/testhelpers"
+	"github.com/grctool/grctool/internal/tugboat"
+	tugboatModels "github.com/grctool/grctool/internal/tugboat/models"
+)
+
+func TestSyncService_SaveFeedbackForTask(t *testing.T) {
+	// Task documents are written relative to the working directory
+	t.Chdir(t.TempDir())
+
+	stub := testhelpers.NewStubDataProvider("tugboat")
+	task := testhelpers.SampleEvidenceTask()
+	stub.Tasks[task.ID] = task
+
+	reg := providers.NewProviderRegistry()
+	if err := reg.Register(stub); err != nil {
+		t.Fatal(err)
+	}
+	svc, st := testSyncService(t, reg)
+	ctx := context.Background()
+	synced, err := svc.SyncEvidenceTask(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("SyncEvidenceTask failed: %v", err)
+	}
+
+	attachmentID := 50001
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/org_evidence_comment/" || r.URL.Query().Get("org_evidence") != task.ID {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		_ = json.NewEncoder(w).Encode(tugboatModels.EvidenceCommentListResponse{Results: []tugboatModels.EvidenceComment{
+			{
+				ID:                      901,
+				Created:                 "2025-10-02T09:00:00Z",
+				Body:                    "Screenshot is missing the date",
+				ReviewStatus:            "rejected",
+				OrgEvidenceAttachmentID: &attachmentID,
+				Owner:                   &tugboatModels.OrganizationMember{DisplayName: "Alex Auditor"},
+			},
+			{ID: 902, Created: "2025-11-05T10:00:00Z", Body: "Please include the reviewer's name"},
+		}})
+	}))
+	defer server.Close()
+	svc.tugboatClient = tugboat.NewClient(&config.TugboatConfig{BaseURL: server.URL, Timeout: 5 * time.Second}, nil)
+
+	// The attachment was collected in Q3, so its rejection belongs to Q3
+	// even though it was made in Q4
+	comments, errs := svc.fetchComments(ctx, svc.fetchPool(SyncOptions{}), []string{task.ID})
+	stats := &SyncStats{}
+	svc.saveFeedbackForTask(327992, comments[0], errs[0], map[int]string{attachmentID: "2025-09-30"}, stats)
+	if stats.Feedback != 2 || stats.Errors != 0 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	q3, err := st.LoadFeedback(synced.ReferenceID, "2025-Q3")
+	if err != nil {
+		t.Fatalf("LoadFeedback failed: %v", err)
+	}
+	if len(q3.Comments) != 1 {
+		t.Fatalf("expected one Q3 comment, got %+v", q3.Comments)
+	}
+	got := q3.Comments[0]
+	if got.ID != "901" || got.Status != "rejected" || got.Author != "Alex Auditor" || got.AttachmentID != "50001" {
+		t.Errorf("unexpected comment: %+v", got)
+	}
+	if len(q3.OpenRejections()) != 1 {
+		t.Errorf("expected an open rejection, got %+v", q3.OpenRejections())
+	}
+
+	q4, err := st.LoadFeedback(synced.ReferenceID, "2025-Q4")
+	if err != nil {
+		t.Fatalf("LoadFeedback failed: %v", err)
+	}
+	if len(q4.Comments) != 1 || q4.Comments[0].Status != "comment" || q4.Comments[0].Author != "Unknown" {
+		t.Errorf("unexpected Q4 feedback: %+v", q4.Comments)
+	}
+
+	// The whole thread is kept with the task as well
+	thread, err := st.LoadTaskComments(synced.ReferenceID)
+	if err != nil {
+		t.Fatalf("LoadTaskComments failed: %v", err)
+	}
+	if len(thread.Comments) != 2 || thread.Comments[0].ID != "901" || thread.Comments[1].ID != "902" || thread.SyncedAt.IsZero() {
+		t.Errorf("unexpected comment thread: %+v", thread)
+	}
+}
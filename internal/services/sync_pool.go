@@ -0,0 +1,170 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/tugboat"
+)
+
+// defaultRetryBackoff is the wait before the first retry of a failed fetch;
+// it doubles on each further attempt
+const defaultRetryBackoff = 500 * time.Millisecond
+
+// SyncProgress is called as a sync stage completes fetches. stage names what
+// is being fetched, e.g. "policies".
+type SyncProgress func(stage string, done, total int)
+
+// fetchPool bounds and retries the API fetches of a sync
+type fetchPool struct {
+	concurrency int
+	retries     int
+	backoff     time.Duration
+	progress    SyncProgress
+	logger      logger.Logger
+}
+
+// fetchPool returns the pool for a sync, taking unset options from the
+// service configuration
+func (s *SyncService) fetchPool(opts SyncOptions) fetchPool {
+	pool := fetchPool{
+		concurrency: opts.Concurrency,
+		retries:     opts.Retries,
+		backoff:     s.retryBackoff,
+		progress:    opts.Progress,
+		logger:      s.logger,
+	}
+	if pool.concurrency <= 0 {
+		pool.concurrency = s.syncConcurrency
+	}
+	if pool.concurrency <= 0 {
+		pool.concurrency = 1
+	}
+	if pool.retries <= 0 {
+		pool.retries = s.syncRetries
+	}
+	return pool
+}
+
+// fetchAll calls fetch for each index below n, with at most pool.concurrency
+// calls in flight, retrying failures. Results and errors are returned in
+// index order, so callers can write them in the same order as a serial sync.
+func fetchAll[T any](ctx context.Context, pool fetchPool, stage string, n int, fetch func(ctx context.Context, i int) (T, error)) ([]T, []error) {
+	results := make([]T, n)
+	errs := make([]error, n)
+	if n == 0 {
+		return results, errs
+	}
+
+	workers := min(pool.concurrency, n)
+	indexes := make(chan int)
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		done int
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i], errs[i] = withRetry(ctx, pool, func() (T, error) { return fetch(ctx, i) })
+				if pool.progress != nil {
+					mu.Lock()
+					done++
+					pool.progress(stage, done, n)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			for ; i < n; i++ {
+				errs[i] = ctx.Err()
+			}
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results, errs
+}
+
+// withRetry calls fetch until it succeeds, fails with an error that is not
+// worth retrying, or has been retried pool.retries times
+func withRetry[T any](ctx context.Context, pool fetchPool, fetch func() (T, error)) (T, error) {
+	backoff := pool.backoff
+	for attempt := 0; ; attempt++ {
+		result, err := fetch()
+		if err == nil || attempt >= pool.retries || !isRetryable(err) {
+			return result, err
+		}
+
+		pool.logger.Debug("Retrying failed fetch",
+			logger.Int("attempt", attempt+1),
+			logger.Error(err))
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return result, ctx.Err()
+		}
+		backoff *= 2
+	}
+}
+
+// isRetryable reports whether a failed fetch may succeed when repeated:
+// cancellations and client errors other than rate limiting will not
+func isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var httpErr *tugboat.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= 500
+	}
+	return true
+}
+
+// mergeDetails replaces list summaries with their fetched details. Where a
+// fetch failed the summary is kept, so the item is still synced. It returns
+// the number of items with details.
+func mergeDetails[T any](log logger.Logger, kind string, items []T, details []*T, errs []error, id func(T) string) int {
+	detailed := 0
+	for i := range items {
+		if errs[i] != nil {
+			log.Warn("Failed to fetch "+kind+" details, saving list summary",
+				logger.String("id", id(items[i])),
+				logger.Error(errs[i]))
+			continue
+		}
+		if details[i] == nil {
+			continue
+		}
+		items[i] = *details[i]
+		detailed++
+	}
+	return detailed
+}
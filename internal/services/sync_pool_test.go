@@ -0,0 +1,190 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/providers"
+	"github.com/grctool/grctool/internal/testhelpers"
+	"github.com/grctool/grctool/internal/tugboat"
+)
+
+func testFetchPool(t *testing.T, concurrency, retries int) fetchPool {
+	t.Helper()
+	log, err := logger.NewTestLogger()
+	if err != nil {
+		t.Fatalf("failed to create test logger: %v", err)
+	}
+	return fetchPool{concurrency: concurrency, retries: retries, backoff: time.Millisecond, logger: log}
+}
+
+func TestFetchAll_OrderAndConcurrency(t *testing.T) {
+	pool := testFetchPool(t, 3, 0)
+	var inFlight, maxInFlight atomic.Int32
+	var mu sync.Mutex
+	var progress []int
+	pool.progress = func(stage string, done, total int) {
+		if stage != "items" || total != 20 {
+			t.Errorf("unexpected progress %s %d/%d", stage, done, total)
+		}
+		mu.Lock()
+		progress = append(progress, done)
+		mu.Unlock()
+	}
+
+	results, errs := fetchAll(context.Background(), pool, "items", 20, func(ctx context.Context, i int) (string, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			max := maxInFlight.Load()
+			if n <= max || maxInFlight.CompareAndSwap(max, n) {
+				break
+			}
+		}
+		// Later items finish first
+		time.Sleep(time.Duration(20-i) * 100 * time.Microsecond)
+		return fmt.Sprintf("item-%d", i), nil
+	})
+
+	for i, result := range results {
+		if errs[i] != nil || result != fmt.Sprintf("item-%d", i) {
+			t.Errorf("result %d: got %q, %v", i, result, errs[i])
+		}
+	}
+	if got := maxInFlight.Load(); got > 3 {
+		t.Errorf("expected at most 3 fetches in flight, got %d", got)
+	}
+	if len(progress) != 20 || progress[19] != 20 {
+		t.Errorf("expected progress for each fetch, got %v", progress)
+	}
+}
+
+func TestFetchAll_Retries(t *testing.T) {
+	tests := map[string]struct {
+		err       error
+		wantCalls int32
+	}{
+		"server error is retried":  {err: &tugboat.HTTPError{StatusCode: 503, Message: "HTTP 503"}, wantCalls: 3},
+		"rate limit is retried":    {err: fmt.Errorf("wrapped: %w", &tugboat.HTTPError{StatusCode: 429, Message: "HTTP 429"}), wantCalls: 3},
+		"network error is retried": {err: errors.New("connection reset"), wantCalls: 3},
+		"not found is not retried": {err: &tugboat.HTTPError{StatusCode: 404, Message: "HTTP 404"}, wantCalls: 1},
+		"cancellation not retried": {err: context.Canceled, wantCalls: 1},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls atomic.Int32
+			_, errs := fetchAll(context.Background(), testFetchPool(t, 2, 2), "items", 1, func(ctx context.Context, i int) (int, error) {
+				calls.Add(1)
+				return 0, tc.err
+			})
+			if !errors.Is(errs[0], tc.err) {
+				t.Errorf("expected %v, got %v", tc.err, errs[0])
+			}
+			if calls.Load() != tc.wantCalls {
+				t.Errorf("expected %d calls, got %d", tc.wantCalls, calls.Load())
+			}
+		})
+	}
+
+	var calls atomic.Int32
+	results, errs := fetchAll(context.Background(), testFetchPool(t, 1, 3), "items", 1, func(ctx context.Context, i int) (int, error) {
+		if calls.Add(1) < 3 {
+			return 0, errors.New("timeout")
+		}
+		return 42, nil
+	})
+	if errs[0] != nil || results[0] != 42 {
+		t.Errorf("expected success on the third attempt, got %d, %v", results[0], errs[0])
+	}
+}
+
+func TestFetchAll_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, errs := fetchAll(ctx, testFetchPool(t, 2, 0), "items", 5, func(ctx context.Context, i int) (int, error) {
+		return i, ctx.Err()
+	})
+	for i, err := range errs {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("item %d: expected cancellation, got %v", i, err)
+		}
+	}
+}
+
+// detailFailingProvider fails detail fetches for one policy
+type detailFailingProvider struct {
+	*testhelpers.StubDataProvider
+	failID string
+}
+
+func (p *detailFailingProvider) GetPolicy(ctx context.Context, id string) (*domain.Policy, error) {
+	if id == p.failID {
+		return nil, &tugboat.HTTPError{StatusCode: 404, Message: "HTTP 404"}
+	}
+	policy, err := p.StubDataProvider.GetPolicy(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	detailed := *policy
+	detailed.Content = "Full policy text for " + id
+	return &detailed, nil
+}
+
+func TestSyncServiceWithRegistry_SyncPoliciesParallel(t *testing.T) {
+	// Policy documents are written relative to the working directory
+	t.Chdir(t.TempDir())
+
+	stub := testhelpers.NewStubDataProvider("test")
+	for i := 1; i <= 12; i++ {
+		policy := testhelpers.SamplePolicy()
+		policy.ID = fmt.Sprintf("%d", 94000+i)
+		policy.Name = fmt.Sprintf("Policy %d", i)
+		stub.Policies[policy.ID] = policy
+	}
+	reg := providers.NewProviderRegistry()
+	if err := reg.Register(&detailFailingProvider{StubDataProvider: stub, failID: "94005"}); err != nil {
+		t.Fatal(err)
+	}
+
+	svc, st := testSyncService(t, reg)
+	var calls atomic.Int32
+	result, err := svc.SyncAll(context.Background(), SyncOptions{
+		Policies:    true,
+		Concurrency: 4,
+		Progress:    func(stage string, done, total int) { calls.Add(1) },
+	})
+	if err != nil {
+		t.Fatalf("SyncAll failed: %v", err)
+	}
+
+	if result.Policies.Total != 12 || result.Policies.Synced != 12 || result.Policies.Detailed != 11 {
+		t.Errorf("unexpected stats: %+v", result.Policies)
+	}
+	if calls.Load() != 12 {
+		t.Errorf("expected 12 progress updates, got %d", calls.Load())
+	}
+
+	detailed, err := st.GetPolicy("94001")
+	if err != nil {
+		t.Fatalf("GetPolicy failed: %v", err)
+	}
+	if detailed.Content != "Full policy text for 94001" {
+		t.Errorf("expected the detailed content, got %q", detailed.Content)
+	}
+	// A policy whose details could not be fetched is saved from the list
+	if _, err := st.GetPolicy("94005"); err != nil {
+		t.Errorf("expected the list summary to be saved: %v", err)
+	}
+}
@@ -310,3 +310,50 @@ func TestSyncServiceWithRegistry_EmptyRegistry(t *testing.T) {
 		t.Errorf("expected Total=0 for evidence tasks, got %d", result.EvidenceTasks.Total)
 	}
 }
+
+func TestSyncServiceWithRegistry_SyncEvidenceTask(t *testing.T) {
+	// Task documents are written relative to the working directory
+	t.Chdir(t.TempDir())
+
+	stub := testhelpers.NewStubDataProvider("test")
+	task := testhelpers.SampleEvidenceTask()
+	stub.Tasks[task.ID] = task
+
+	reg := providers.NewProviderRegistry()
+	if err := reg.Register(stub); err != nil {
+		t.Fatal(err)
+	}
+
+	svc, st := testSyncService(t, reg)
+	ctx := context.Background()
+
+	synced, err := svc.SyncEvidenceTask(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("SyncEvidenceTask failed: %v", err)
+	}
+	if synced.ReferenceID == "" {
+		t.Error("expected the synced task to have a reference ID")
+	}
+
+	stored, err := st.GetEvidenceTask(task.ID)
+	if err != nil {
+		t.Fatalf("GetEvidenceTask failed: %v", err)
+	}
+	if stored.Name != task.Name {
+		t.Errorf("expected task name %q, got %q", task.Name, stored.Name)
+	}
+
+	// A renamed task keeps its reference ID
+	task.Name = "Renamed task"
+	resynced, err := svc.SyncEvidenceTask(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("SyncEvidenceTask failed: %v", err)
+	}
+	if resynced.ReferenceID != synced.ReferenceID {
+		t.Errorf("expected reference ID %q to be preserved, got %q", synced.ReferenceID, resynced.ReferenceID)
+	}
+
+	if _, err := svc.SyncEvidenceTask(ctx, "999999"); err == nil {
+		t.Error("expected an error for an unknown task")
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/tugboat"
+)
+
+// TaskUpdate is a change to push to an evidence task in Tugboat Logic; unset
+// fields are left unchanged
+type TaskUpdate struct {
+	Completed *bool
+	Assignees []string // Member IDs, or emails of people assigned to synced tasks
+	Notes     *string
+}
+
+// PushOptions control how local changes are pushed
+type PushOptions struct {
+	DryRun bool // Report what would change without pushing
+	Force  bool // Push even if the task changed in Tugboat since the last sync
+}
+
+// Push outcomes
+const (
+	PushStatusPushed    = "pushed"
+	PushStatusWouldPush = "would_push"
+	PushStatusUnchanged = "unchanged"
+	PushStatusConflict  = "conflict"
+	PushStatusFailed    = "failed"
+)
+
+// PushResult reports the outcome of pushing one evidence task
+type PushResult struct {
+	TaskRef string   `json:"task_ref"`
+	TaskID  string   `json:"task_id"`
+	Status  string   `json:"status"`
+	Changes []string `json:"changes,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// PushEvidenceTask pushes a change to an evidence task to Tugboat Logic. The
+// remote task is fetched first: fields that already hold the requested value
+// are not sent, and when the task was updated in Tugboat after the last sync
+// the push is refused as a conflict unless opts.Force is set. After a push the
+// local copy is refreshed from Tugboat.
+func (s *SyncService) PushEvidenceTask(ctx context.Context, taskRef string, update TaskUpdate, opts PushOptions) (*PushResult, error) {
+	if s.tugboatClient == nil {
+		return nil, errors.New("pushing changes requires a Tugboat client")
+	}
+
+	local, err := s.storage.GetEvidenceTask(taskRef)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := s.registry.Get("tugboat")
+	if err != nil {
+		return nil, err
+	}
+	remote, err := provider.GetEvidenceTask(ctx, local.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PushResult{TaskRef: local.ReferenceID, TaskID: local.ID}
+	request := &tugboat.EvidenceTaskUpdate{}
+
+	if update.Completed != nil && *update.Completed != remote.Completed {
+		request.Completed = update.Completed
+		result.Changes = append(result.Changes, fmt.Sprintf("completed: %t → %t", remote.Completed, *update.Completed))
+	}
+	if update.Assignees != nil {
+		ids, err := s.resolveAssignees(update.Assignees)
+		if err != nil {
+			return nil, err
+		}
+		current := assigneeIDs(remote.Assignees)
+		wanted := make([]string, len(ids))
+		for i, id := range ids {
+			wanted[i] = strconv.Itoa(id)
+		}
+		sort.Strings(wanted)
+		if strings.Join(current, ",") != strings.Join(wanted, ",") {
+			request.Assignees = ids
+			result.Changes = append(result.Changes, fmt.Sprintf("assignees: [%s] → [%s]",
+				strings.Join(current, ", "), strings.Join(wanted, ", ")))
+		}
+	}
+	if update.Notes != nil {
+		request.Notes = update.Notes
+		result.Changes = append(result.Changes, "notes")
+	}
+
+	switch {
+	case len(result.Changes) == 0:
+		result.Status = PushStatusUnchanged
+		return result, nil
+	case remote.UpdatedAt.After(local.UpdatedAt) && !opts.Force:
+		result.Status = PushStatusConflict
+		result.Error = fmt.Sprintf("changed in Tugboat at %s, after the last sync (%s); run 'grctool sync --evidence' or push with --force",
+			remote.UpdatedAt.Format("2006-01-02 15:04"), local.UpdatedAt.Format("2006-01-02 15:04"))
+		return result, nil
+	case opts.DryRun:
+		result.Status = PushStatusWouldPush
+		return result, nil
+	}
+
+	if _, err := s.tugboatClient.UpdateEvidenceTask(ctx, local.ID, request); err != nil {
+		result.Status = PushStatusFailed
+		result.Error = err.Error()
+		return result, nil
+	}
+	result.Status = PushStatusPushed
+	s.logger.Info("pushed evidence task changes",
+		logger.String("task_ref", local.ReferenceID),
+		logger.String("changes", strings.Join(result.Changes, "; ")))
+
+	// Without a refresh the next push would see its own change as a conflict
+	if _, err := s.SyncEvidenceTask(ctx, local.ID); err != nil {
+		s.logger.Warn("Failed to refresh evidence task after push; run 'grctool sync --evidence'",
+			logger.String("task_ref", local.ReferenceID),
+			logger.Error(err))
+	}
+	return result, nil
+}
+
+// CompleteAcceptedTasks marks tasks complete in Tugboat Logic when the
+// submission of their latest evidence window was accepted
+func (s *SyncService) CompleteAcceptedTasks(ctx context.Context, scanner EvidenceScanner, opts PushOptions) ([]PushResult, error) {
+	states, err := scanner.ScanAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan evidence: %w", err)
+	}
+
+	refs := make([]string, 0, len(states))
+	for ref := range states {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+
+	completed := true
+	var results []PushResult
+	for _, ref := range refs {
+		state := states[ref]
+		latest := ""
+		for window := range state.Windows {
+			if window > latest {
+				latest = window
+			}
+		}
+		if latest == "" || state.Windows[latest].SubmissionStatus != "accepted" {
+			continue
+		}
+		if task, err := s.storage.GetEvidenceTask(ref); err == nil && task.Completed {
+			continue
+		}
+
+		result, err := s.PushEvidenceTask(ctx, ref, TaskUpdate{Completed: &completed}, opts)
+		if err != nil {
+			result = &PushResult{TaskRef: ref, Status: PushStatusFailed, Error: err.Error()}
+		}
+		results = append(results, *result)
+	}
+	return results, nil
+}
+
+// resolveAssignees converts member IDs and emails to Tugboat member IDs.
+// Emails are looked up among the assignees of synced evidence tasks.
+func (s *SyncService) resolveAssignees(values []string) ([]int, error) {
+	var byEmail map[string]string
+	ids := make([]int, 0, len(values))
+	for _, value := range values {
+		if id, err := strconv.Atoi(value); err == nil {
+			ids = append(ids, id)
+			continue
+		}
+
+		if byEmail == nil {
+			tasks, err := s.storage.GetAllEvidenceTasks()
+			if err != nil {
+				return nil, fmt.Errorf("failed to load evidence tasks: %w", err)
+			}
+			byEmail = make(map[string]string)
+			for _, task := range tasks {
+				for _, person := range task.Assignees {
+					if person.Email != "" {
+						byEmail[strings.ToLower(person.Email)] = person.ID
+					}
+				}
+			}
+		}
+
+		id, err := strconv.Atoi(byEmail[strings.ToLower(value)])
+		if err != nil {
+			return nil, fmt.Errorf("unknown assignee %q: use a Tugboat member ID or the email of someone assigned to a synced task", value)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// assigneeIDs returns the sorted IDs of people
+func assigneeIDs(people []domain.Person) []string {
+	ids := make([]string, 0, len(people))
+	for _, person := range people {
+		ids = append(ids, person.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
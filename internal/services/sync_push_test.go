@@ -0,0 +1,194 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/providers"
+	"github.com/grctool/grctool/internal/testhelpers"
+	"github.com/grctool/grctool/internal/tugboat"
+)
+
+// pushFixture is a synced task backed by a stub provider, with a Tugboat
+// server whose PATCHes update the stub's task
+type pushFixture struct {
+	svc     *SyncService
+	task    *domain.EvidenceTask
+	mu      sync.Mutex
+	patches []map[string]interface{}
+}
+
+func newPushFixture(t *testing.T) *pushFixture {
+	t.Helper()
+	// Task documents are written relative to the working directory
+	t.Chdir(t.TempDir())
+
+	stub := testhelpers.NewStubDataProvider("tugboat")
+	task := testhelpers.SampleEvidenceTask()
+	task.Assignees = []domain.Person{{ID: "7", Email: "jane@example.com"}}
+	stub.Tasks[task.ID] = task
+
+	reg := providers.NewProviderRegistry()
+	if err := reg.Register(stub); err != nil {
+		t.Fatal(err)
+	}
+	svc, _ := testSyncService(t, reg)
+	f := &pushFixture{svc: svc, task: task}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/org_evidence/"+task.ID+"/" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+		f.mu.Lock()
+		f.patches = append(f.patches, body)
+		f.mu.Unlock()
+
+		if completed, ok := body["completed"].(bool); ok {
+			task.Completed = completed
+		}
+		task.UpdatedAt = task.UpdatedAt.Add(time.Minute)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 327992, "completed": task.Completed})
+	}))
+	t.Cleanup(server.Close)
+	svc.tugboatClient = tugboat.NewClient(&config.TugboatConfig{BaseURL: server.URL, Timeout: 5 * time.Second}, nil)
+
+	if _, err := svc.SyncEvidenceTask(context.Background(), task.ID); err != nil {
+		t.Fatalf("SyncEvidenceTask failed: %v", err)
+	}
+	return f
+}
+
+func TestSyncService_PushEvidenceTask(t *testing.T) {
+	f := newPushFixture(t)
+	ctx := context.Background()
+	completed := true
+	notes := "Collected from the Q4 access review"
+
+	// A dry run reports the change without pushing
+	result, err := f.svc.PushEvidenceTask(ctx, f.task.ID, TaskUpdate{Completed: &completed}, PushOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("PushEvidenceTask failed: %v", err)
+	}
+	if result.Status != PushStatusWouldPush {
+		t.Errorf("expected %s, got %s", PushStatusWouldPush, result.Status)
+	}
+	if len(f.patches) != 0 {
+		t.Errorf("dry run must not push, got %d requests", len(f.patches))
+	}
+
+	result, err = f.svc.PushEvidenceTask(ctx, f.task.ID, TaskUpdate{
+		Completed: &completed,
+		Assignees: []string{"JANE@example.com", "12"},
+		Notes:     &notes,
+	}, PushOptions{})
+	if err != nil {
+		t.Fatalf("PushEvidenceTask failed: %v", err)
+	}
+	if result.Status != PushStatusPushed {
+		t.Fatalf("expected %s, got %s (%s)", PushStatusPushed, result.Status, result.Error)
+	}
+	if len(result.Changes) != 3 || result.Changes[0] != "completed: false → true" || result.Changes[1] != "assignees: [7] → [12, 7]" {
+		t.Errorf("unexpected changes: %v", result.Changes)
+	}
+	if len(f.patches) != 1 || f.patches[0]["notes"] != notes {
+		t.Errorf("unexpected requests: %v", f.patches)
+	}
+
+	// The local copy was refreshed, so pushing again is not a conflict
+	result, err = f.svc.PushEvidenceTask(ctx, f.task.ID, TaskUpdate{Completed: &completed}, PushOptions{})
+	if err != nil {
+		t.Fatalf("PushEvidenceTask failed: %v", err)
+	}
+	if result.Status != PushStatusUnchanged {
+		t.Errorf("expected %s, got %s (%s)", PushStatusUnchanged, result.Status, result.Error)
+	}
+
+	if _, err := f.svc.PushEvidenceTask(ctx, f.task.ID, TaskUpdate{Assignees: []string{"nobody@example.com"}}, PushOptions{}); err == nil ||
+		!strings.Contains(err.Error(), "unknown assignee") {
+		t.Errorf("expected an unknown assignee error, got %v", err)
+	}
+}
+
+func TestSyncService_PushEvidenceTaskConflict(t *testing.T) {
+	f := newPushFixture(t)
+	ctx := context.Background()
+	completed := true
+
+	// Someone changes the task in Tugboat after the sync
+	f.task.UpdatedAt = f.task.UpdatedAt.Add(time.Hour)
+
+	result, err := f.svc.PushEvidenceTask(ctx, f.task.ID, TaskUpdate{Completed: &completed}, PushOptions{})
+	if err != nil {
+		t.Fatalf("PushEvidenceTask failed: %v", err)
+	}
+	if result.Status != PushStatusConflict || !strings.Contains(result.Error, "after the last sync") {
+		t.Errorf("expected a conflict, got %s (%s)", result.Status, result.Error)
+	}
+	if len(f.patches) != 0 {
+		t.Errorf("conflicting push must not be sent, got %d requests", len(f.patches))
+	}
+
+	result, err = f.svc.PushEvidenceTask(ctx, f.task.ID, TaskUpdate{Completed: &completed}, PushOptions{Force: true})
+	if err != nil {
+		t.Fatalf("PushEvidenceTask failed: %v", err)
+	}
+	if result.Status != PushStatusPushed {
+		t.Errorf("expected a forced push, got %s (%s)", result.Status, result.Error)
+	}
+}
+
+func TestSyncService_CompleteAcceptedTasks(t *testing.T) {
+	f := newPushFixture(t)
+	ctx := context.Background()
+
+	scanner := &stubEvidenceScanner{scanAllResult: map[string]*models.EvidenceTaskState{
+		"ET-0001": {TaskRef: "ET-0001", Windows: map[string]models.WindowState{
+			"2025-Q3": {Window: "2025-Q3", SubmissionStatus: "accepted"},
+			"2025-Q4": {Window: "2025-Q4", SubmissionStatus: "submitted"},
+		}},
+	}}
+	results, err := f.svc.CompleteAcceptedTasks(ctx, scanner, PushOptions{})
+	if err != nil {
+		t.Fatalf("CompleteAcceptedTasks failed: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("only the latest window counts, got %v", results)
+	}
+
+	scanner.scanAllResult["ET-0001"].Windows["2025-Q4"] = models.WindowState{Window: "2025-Q4", SubmissionStatus: "accepted"}
+	results, err = f.svc.CompleteAcceptedTasks(ctx, scanner, PushOptions{})
+	if err != nil {
+		t.Fatalf("CompleteAcceptedTasks failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Status != PushStatusPushed {
+		t.Fatalf("expected the task to be completed, got %+v", results)
+	}
+	if !f.task.Completed {
+		t.Error("expected the remote task to be completed")
+	}
+
+	// Completed tasks are skipped
+	results, err = f.svc.CompleteAcceptedTasks(ctx, scanner, PushOptions{})
+	if err != nil {
+		t.Fatalf("CompleteAcceptedTasks failed: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("expected no pushes, got %+v", results)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"strings"
+
+	"github.com/grctool/grctool/internal/domain"
+)
+
+// refFilter limits a selective sync to the items matching SyncOptions.Refs,
+// by reference ID or provider ID, and records which references matched. A
+// nil filter matches everything.
+type refFilter struct {
+	refs    []string
+	matched map[string]bool
+}
+
+func newRefFilter(refs []string) *refFilter {
+	if len(refs) == 0 {
+		return nil
+	}
+	return &refFilter{refs: refs, matched: make(map[string]bool, len(refs))}
+}
+
+// refs returns the filter shared by a SyncAll, or a new one for a single
+// type synced directly
+func (o SyncOptions) refs() *refFilter {
+	if o.refFilter != nil {
+		return o.refFilter
+	}
+	return newRefFilter(o.Refs)
+}
+
+// match reports whether any of an item's keys is a selected reference
+func (f *refFilter) match(keys ...string) bool {
+	if f == nil {
+		return true
+	}
+	found := false
+	for _, ref := range f.refs {
+		for _, key := range keys {
+			if key != "" && strings.EqualFold(ref, key) {
+				f.matched[ref] = true
+				found = true
+			}
+		}
+	}
+	return found
+}
+
+// unmatched returns the references that selected nothing, in the order given
+func (f *refFilter) unmatched() []string {
+	if f == nil {
+		return nil
+	}
+	var refs []string
+	for _, ref := range f.refs {
+		if !f.matched[ref] {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// selectIndexes returns the indexes of the items the filter matches
+func selectIndexes[T any](f *refFilter, items []T, keys func(T) []string) []int {
+	indexes := make([]int, 0, len(items))
+	for i, item := range items {
+		if f.match(keys(item)...) {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// pick returns the items at the given indexes
+func pick[T any](items []T, indexes []int) []T {
+	picked := make([]T, len(indexes))
+	for i, idx := range indexes {
+		picked[i] = items[idx]
+	}
+	return picked
+}
+
+func policyKeys(p domain.Policy) []string {
+	return []string{p.ReferenceID, p.ID}
+}
+
+// controlKeys also matches a control by its framework codes, such as CC6.8
+func controlKeys(c domain.Control) []string {
+	keys := []string{c.ReferenceID, c.ID}
+	keys = append(keys, strings.FieldsFunc(c.Codes, func(r rune) bool { return r == ',' || r == ' ' })...)
+	for _, code := range c.FrameworkCodes {
+		keys = append(keys, code.Code)
+	}
+	return keys
+}
+
+// evidenceTaskKeys returns a function giving the keys of an evidence task by
+// its provider ID. Providers do not list task references, which are assigned
+// locally, so they are looked up in storage and the task registry.
+func (s *SyncService) evidenceTaskKeys() func(id string) []string {
+	stored := map[string]string{}
+	if tasks, err := s.storage.GetAllEvidenceTasks(); err == nil {
+		for _, task := range tasks {
+			stored[task.ID] = task.ReferenceID
+		}
+	}
+	return func(id string) []string {
+		keys := []string{id, stored[id]}
+		if ref, ok := s.evidenceTaskRegistry.GetReference(id); ok {
+			keys = append(keys, ref)
+		}
+		return keys
+	}
+}
+
+// toIndexes returns the indexes below n
+func toIndexes(n int) []int {
+	indexes := make([]int, n)
+	for i := range indexes {
+		indexes[i] = i
+	}
+	return indexes
+}
@@ -0,0 +1,124 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/providers"
+	"github.com/grctool/grctool/internal/testhelpers"
+)
+
+// countingProvider counts control detail fetches
+type countingProvider struct {
+	*testhelpers.StubDataProvider
+	controlFetches atomic.Int32
+}
+
+func (p *countingProvider) GetControl(ctx context.Context, id string) (*domain.Control, error) {
+	p.controlFetches.Add(1)
+	return p.StubDataProvider.GetControl(ctx, id)
+}
+
+func TestSyncServiceWithRegistry_SelectiveSync(t *testing.T) {
+	// Documents are written relative to the working directory
+	t.Chdir(t.TempDir())
+
+	stub := &countingProvider{StubDataProvider: testhelpers.NewStubDataProvider("test")}
+	for i, name := range []string{"AC1 - Access Reviews", "AC2 - Provisioning", "Change Management", "Monitoring"} {
+		control := testhelpers.SampleControl()
+		control.ID = fmt.Sprintf("%d", 2001+i)
+		control.Name = name
+		control.Codes = fmt.Sprintf("CC%d.%d", 6+i/2, 1+i)
+		stub.Controls[control.ID] = control
+	}
+	task := testhelpers.SampleEvidenceTask()
+	stub.Tasks[task.ID] = task
+
+	reg := providers.NewProviderRegistry()
+	if err := reg.Register(stub); err != nil {
+		t.Fatal(err)
+	}
+	svc, st := testSyncService(t, reg)
+
+	result, err := svc.SyncAll(context.Background(), SyncOptions{
+		Controls: true,
+		Evidence: true,
+		Refs:     []string{"ac1", "CC7.3", "AC99"},
+	})
+	if err != nil {
+		t.Fatalf("SyncAll failed: %v", err)
+	}
+
+	if result.Controls.Total != 2 || result.Controls.Synced != 2 {
+		t.Errorf("expected 2 selected controls, got %+v", result.Controls)
+	}
+	if got := stub.controlFetches.Load(); got != 2 {
+		t.Errorf("expected details fetched for 2 controls, got %d", got)
+	}
+	if result.EvidenceTasks.Total != 0 {
+		t.Errorf("expected no evidence tasks selected, got %+v", result.EvidenceTasks)
+	}
+	if len(result.Errors) != 1 || result.Errors[0] != "No synced item matches reference AC99" {
+		t.Errorf("expected AC99 to be reported, got %v", result.Errors)
+	}
+
+	controls, err := st.GetAllControls()
+	if err != nil {
+		t.Fatalf("GetAllControls failed: %v", err)
+	}
+	saved := map[string]bool{}
+	for _, c := range controls {
+		saved[c.ID] = true
+	}
+	if len(saved) != 2 || !saved["2001"] || !saved["2003"] {
+		t.Errorf("expected controls 2001 and 2003 saved, got %v", saved)
+	}
+}
+
+func TestSyncServiceWithRegistry_SelectiveSyncByTaskRef(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	stub := testhelpers.NewStubDataProvider("test")
+	first := testhelpers.SampleEvidenceTask()
+	second := testhelpers.SampleEvidenceTask()
+	second.ID, second.Name = "327993", "Quarterly Access Review"
+	stub.Tasks[first.ID] = first
+	stub.Tasks[second.ID] = second
+
+	reg := providers.NewProviderRegistry()
+	if err := reg.Register(stub); err != nil {
+		t.Fatal(err)
+	}
+	svc, st := testSyncService(t, reg)
+	ctx := context.Background()
+	if _, err := svc.SyncAll(ctx, SyncOptions{Evidence: true}); err != nil {
+		t.Fatalf("SyncAll failed: %v", err)
+	}
+	synced, err := st.GetEvidenceTask(second.ID)
+	if err != nil {
+		t.Fatalf("GetEvidenceTask failed: %v", err)
+	}
+
+	// Task references are assigned locally, so they select by the stored task
+	second.Guidance = "Export the review from the IdP"
+	result, err := svc.SyncAll(ctx, SyncOptions{Evidence: true, Refs: []string{synced.ReferenceID}})
+	if err != nil {
+		t.Fatalf("SyncAll failed: %v", err)
+	}
+	if result.EvidenceTasks.Total != 1 || len(result.Errors) != 0 {
+		t.Errorf("expected one selected task, got %+v", result)
+	}
+	updated, err := st.GetEvidenceTask(second.ID)
+	if err != nil {
+		t.Fatalf("GetEvidenceTask failed: %v", err)
+	}
+	if updated.Guidance != second.Guidance || updated.ReferenceID != synced.ReferenceID {
+		t.Errorf("unexpected task after selective sync: %s %q", updated.ReferenceID, updated.Guidance)
+	}
+}
@@ -16,12 +16,15 @@
 package validation
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/redaction"
+	"github.com/grctool/grctool/internal/signing"
 	"github.com/grctool/grctool/internal/storage"
 )
 
@@ -41,6 +44,22 @@ type EvidenceValidationService struct {
 	rules   []EvidenceValidationRule
 }
 
+// SetSigner adds a check that the evidence files and generation metadata
+// have good signatures from signer
+func (vs *EvidenceValidationService) SetSigner(signer signing.Signer) {
+	if signer != nil {
+		vs.rules = append(vs.rules, &SignaturesValidRule{signer: signer})
+	}
+}
+
+// SetScanner adds a check that the evidence files contain no secrets or
+// personal data that scanner reports
+func (vs *EvidenceValidationService) SetScanner(scanner *redaction.Scanner) {
+	if scanner != nil {
+		vs.rules = append(vs.rules, &SensitiveDataRule{scanner: scanner})
+	}
+}
+
 // NewEvidenceValidationService creates a new evidence validation service
 func NewEvidenceValidationService(stor *storage.Storage) *EvidenceValidationService {
 	svc := &EvidenceValidationService{
@@ -401,6 +420,130 @@ func (r *ChecksumPresentRule) Validate(taskRef, window string, files []models.Ev
 	return result
 }
 
+// SignaturesValidRule verifies the signatures made when the evidence was
+// written. A file changed after it was signed fails; an unsigned one, such as
+// a file added by hand, is a warning.
+type SignaturesValidRule struct {
+	signer signing.Signer
+}
+
+func (r *SignaturesValidRule) Validate(taskRef, window string, files []models.EvidenceFileRef, st *storage.Storage) EvidenceValidationRuleResult {
+	result := EvidenceValidationRuleResult{
+		Check: models.ValidationCheck{
+			Code:     "SIGNATURES_VALID",
+			Name:     "Signatures Valid",
+			Severity: "error",
+		},
+	}
+
+	filenames := make([]string, 0, len(files))
+	for _, file := range files {
+		filenames = append(filenames, file.Filename)
+	}
+	invalid, unsigned := 0, 0
+	for _, signature := range signing.VerifyWindow(context.Background(), r.signer, st.EvidenceWindowDir(taskRef, window), filenames, storage.ReadFile) {
+		switch signature.Status {
+		case signing.SignatureInvalid:
+			invalid++
+			result.Errors = append(result.Errors, models.ValidationError{
+				Code:       "SIGNATURES_VALID",
+				Severity:   "error",
+				Message:    fmt.Sprintf("%s does not match its %s signature: %s", signature.File, r.signer.Method(), signature.Message),
+				Suggestion: "The file changed after it was signed; collect the evidence again",
+			})
+		case signing.SignatureMissing:
+			unsigned++
+			result.Warnings = append(result.Warnings, models.ValidationError{
+				Code:       "SIGNATURES_VALID",
+				Severity:   "warning",
+				Message:    fmt.Sprintf("%s is not signed", signature.File),
+				Suggestion: "Only evidence written by grctool with evidence.signing enabled is signed",
+			})
+		}
+	}
+
+	switch {
+	case invalid > 0:
+		result.Check.Status = "failed"
+		result.Check.Message = fmt.Sprintf("%d files fail signature verification", invalid)
+	case unsigned > 0:
+		result.Check.Status = "warning"
+		result.Check.Message = fmt.Sprintf("%d files not signed", unsigned)
+	default:
+		result.Check.Status = "passed"
+		result.Check.Message = "All signatures verified"
+	}
+
+	return result
+}
+
+// SensitiveDataRule scans the evidence files for secrets, personal data and
+// the content submission.scan.rules disallow for the task's category.
+// Findings that submission.scan blocks fail the check, waived ones are only
+// counted and the others are warnings.
+type SensitiveDataRule struct {
+	scanner *redaction.Scanner
+}
+
+func (r *SensitiveDataRule) Validate(taskRef, window string, files []models.EvidenceFileRef, st *storage.Storage) EvidenceValidationRuleResult {
+	result := EvidenceValidationRuleResult{
+		Check: models.ValidationCheck{
+			Code:     "SENSITIVE_DATA",
+			Name:     "No Secrets or Personal Data",
+			Severity: "error",
+		},
+	}
+
+	windowDir := st.EvidenceWindowDir(taskRef, window)
+	suggestion := fmt.Sprintf("Run 'grctool evidence scan %s --window %s --redact' for sanitized copies, or waive the finding in submission.scan.waivers", taskRef, window)
+	scanner := r.scanner.ForTask(taskRef, st.EvidenceTaskCategory(taskRef))
+	blocked, warned, waived := 0, 0, 0
+	for _, file := range files {
+		data, err := storage.ReadFile(filepath.Join(windowDir, file.Filename))
+		if err != nil {
+			continue
+		}
+		for _, finding := range scanner.Scan(file.Filename, data) {
+			if finding.Action == redaction.Waived {
+				waived++
+				continue
+			}
+			issue := models.ValidationError{
+				Code:       "SENSITIVE_DATA",
+				Severity:   "warning",
+				Message:    finding.String(),
+				Field:      file.Filename,
+				Suggestion: suggestion,
+			}
+			if finding.Action == redaction.Block {
+				blocked++
+				issue.Severity = "error"
+				result.Errors = append(result.Errors, issue)
+			} else {
+				warned++
+				result.Warnings = append(result.Warnings, issue)
+			}
+		}
+	}
+
+	switch {
+	case blocked > 0:
+		result.Check.Status = "failed"
+		result.Check.Message = fmt.Sprintf("%d secrets or personal data found that block submission", blocked)
+	case warned > 0:
+		result.Check.Status = "warning"
+		result.Check.Message = fmt.Sprintf("%d possible secrets or personal data found", warned)
+	default:
+		result.Check.Status = "passed"
+		result.Check.Message = "No secrets or personal data found"
+	}
+	if waived > 0 {
+		result.Check.Message += fmt.Sprintf(" (%d waived)", waived)
+	}
+
+	return result
+}
+
 // ValidTaskRefRule validates task reference format
 type ValidTaskRefRule struct{}
 
@@ -0,0 +1,172 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package signing signs evidence files with gpg or cosign as they are written
+// and verifies the signatures before the evidence is submitted.
+package signing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/grctool/grctool/internal/config"
+)
+
+// Signing methods
+const (
+	MethodGPG    = "gpg"
+	MethodCosign = "cosign"
+)
+
+// ErrUnsigned is returned when a file has no signature
+var ErrUnsigned = errors.New("not signed")
+
+// Signer signs evidence and verifies the signatures it made
+type Signer interface {
+	// Method names how the signer signs: gpg or cosign
+	Method() string
+
+	// Sign returns a detached signature of data
+	Sign(ctx context.Context, data []byte) ([]byte, error)
+
+	// Verify returns an error unless signature is a good signature of data
+	Verify(ctx context.Context, data, signature []byte) error
+}
+
+// New returns the signer evidence.signing configures, or nil when signing is
+// disabled
+func New(cfg config.SigningConfig) Signer {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.Method == MethodCosign {
+		return NewCosign(cfg.Key, cfg.PublicKey)
+	}
+	return NewGPG(cfg.Key)
+}
+
+// runFunc runs a command with stdin and returns its stdout
+type runFunc func(ctx context.Context, name string, args []string, stdin []byte) ([]byte, error)
+
+// runCommand runs a command, reporting its stderr when it fails
+func runCommand(ctx context.Context, name string, args []string, stdin []byte) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s failed: %w: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// GPG makes ASCII-armored detached signatures with gpg and verifies them
+// against the keys in the gpg keyring
+type GPG struct {
+	key string
+	run runFunc
+}
+
+// NewGPG returns a gpg signer using key, or gpg's default key when key is
+// empty
+func NewGPG(key string) *GPG {
+	return &GPG{key: key, run: runCommand}
+}
+
+// Method returns "gpg"
+func (g *GPG) Method() string {
+	return MethodGPG
+}
+
+// Sign signs data with the signer's key
+func (g *GPG) Sign(ctx context.Context, data []byte) ([]byte, error) {
+	args := []string{"--armor", "--detach-sign"}
+	if g.key != "" {
+		args = append(args, "--local-user", g.key)
+	}
+	return g.run(ctx, "gpg", args, data)
+}
+
+// Verify checks signature with gpg --verify, reading data from stdin
+func (g *GPG) Verify(ctx context.Context, data, signature []byte) error {
+	return withTempFiles(map[string][]byte{"signature.asc": signature}, func(paths map[string]string) error {
+		_, err := g.run(ctx, "gpg", []string{"--verify", paths["signature.asc"], "-"}, data)
+		return err
+	})
+}
+
+// Cosign signs blobs with a cosign key pair. Signatures are not uploaded to
+// the Rekor transparency log, so evidence contents never leave the machine.
+type Cosign struct {
+	key       string
+	publicKey string
+	run       runFunc
+}
+
+// NewCosign returns a cosign signer using a private key file or KMS URI and
+// verifying with the matching public key
+func NewCosign(key, publicKey string) *Cosign {
+	return &Cosign{key: key, publicKey: publicKey, run: runCommand}
+}
+
+// Method returns "cosign"
+func (c *Cosign) Method() string {
+	return MethodCosign
+}
+
+// Sign signs data with cosign sign-blob, returning the base64 signature
+func (c *Cosign) Sign(ctx context.Context, data []byte) ([]byte, error) {
+	var signature []byte
+	err := withTempFiles(map[string][]byte{"blob": data}, func(paths map[string]string) error {
+		var err error
+		signature, err = c.run(ctx, "cosign", []string{"sign-blob", "--yes", "--tlog-upload=false", "--key", c.key, paths["blob"]}, nil)
+		return err
+	})
+	return signature, err
+}
+
+// Verify checks signature with cosign verify-blob and the public key
+func (c *Cosign) Verify(ctx context.Context, data, signature []byte) error {
+	return withTempFiles(map[string][]byte{"blob": data, "blob.sig": signature}, func(paths map[string]string) error {
+		_, err := c.run(ctx, "cosign", []string{"verify-blob", "--insecure-ignore-tlog", "--key", c.publicKey,
+			"--signature", paths["blob.sig"], paths["blob"]}, nil)
+		return err
+	})
+}
+
+// withTempFiles writes files to a private temporary directory, passing fn
+// their paths, and removes them afterwards
+func withTempFiles(files map[string][]byte, fn func(paths map[string]string) error) error {
+	dir, err := os.MkdirTemp("", "grctool-signing-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	paths := make(map[string]string, len(files))
+	for name, data := range files {
+		paths[name] = filepath.Join(dir, name)
+		if err := os.WriteFile(paths[name], data, 0600); err != nil {
+			return err
+		}
+	}
+	return fn(paths)
+}
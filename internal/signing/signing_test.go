@@ -0,0 +1,133 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package signing
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// checksumSigner "signs" with a plain checksum
+type checksumSigner struct{}
+
+func (checksumSigner) Method() string { return "checksum" }
+
+func (checksumSigner) Sign(_ context.Context, data []byte) ([]byte, error) {
+	sum := sha256.Sum256(data)
+	return []byte(hex.EncodeToString(sum[:])), nil
+}
+
+func (s checksumSigner) Verify(ctx context.Context, data, signature []byte) error {
+	if want, _ := s.Sign(ctx, data); string(want) != string(signature) {
+		return errors.New("BAD signature")
+	}
+	return nil
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, New(config.SigningConfig{Method: MethodGPG}))
+	assert.Equal(t, MethodGPG, New(config.SigningConfig{Enabled: true, Key: "grc@example.com"}).Method())
+	assert.Equal(t, MethodCosign, New(config.SigningConfig{Enabled: true, Method: MethodCosign, Key: "cosign.key", PublicKey: "cosign.pub"}).Method())
+}
+
+// invocation records a command a signer ran, with the temporary files it
+// passed read back while they existed
+type invocation struct {
+	name  string
+	args  []string
+	stdin string
+	files map[string]string
+}
+
+func recordRuns(calls *[]invocation, stdout string) runFunc {
+	return func(_ context.Context, name string, args []string, stdin []byte) ([]byte, error) {
+		call := invocation{name: name, stdin: string(stdin), files: map[string]string{}}
+		for _, arg := range args {
+			if filepath.IsAbs(arg) {
+				data, _ := os.ReadFile(arg)
+				call.files[filepath.Base(arg)] = string(data)
+				arg = filepath.Base(arg)
+			}
+			call.args = append(call.args, arg)
+		}
+		*calls = append(*calls, call)
+		return []byte(stdout), nil
+	}
+}
+
+func TestGPG(t *testing.T) {
+	t.Parallel()
+
+	var calls []invocation
+	gpg := &GPG{key: "grc@example.com", run: recordRuns(&calls, "-----BEGIN PGP SIGNATURE-----")}
+
+	signature, err := gpg.Sign(context.Background(), []byte("user,role\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "-----BEGIN PGP SIGNATURE-----", string(signature))
+	require.NoError(t, gpg.Verify(context.Background(), []byte("user,role\n"), signature))
+
+	assert.Equal(t, []invocation{
+		{name: "gpg", args: []string{"--armor", "--detach-sign", "--local-user", "grc@example.com"}, stdin: "user,role\n", files: map[string]string{}},
+		{name: "gpg", args: []string{"--verify", "signature.asc", "-"}, stdin: "user,role\n",
+			files: map[string]string{"signature.asc": "-----BEGIN PGP SIGNATURE-----"}},
+	}, calls)
+}
+
+func TestCosign(t *testing.T) {
+	t.Parallel()
+
+	var calls []invocation
+	cosign := &Cosign{key: "awskms:///alias/evidence", publicKey: "cosign.pub", run: recordRuns(&calls, "MEUCIQ==")}
+
+	signature, err := cosign.Sign(context.Background(), []byte("user,role\n"))
+	require.NoError(t, err)
+	require.NoError(t, cosign.Verify(context.Background(), []byte("user,role\n"), signature))
+
+	assert.Equal(t, []invocation{
+		{name: "cosign", args: []string{"sign-blob", "--yes", "--tlog-upload=false", "--key", "awskms:///alias/evidence", "blob"},
+			files: map[string]string{"blob": "user,role\n"}},
+		{name: "cosign", args: []string{"verify-blob", "--insecure-ignore-tlog", "--key", "cosign.pub", "--signature", "blob.sig", "blob"},
+			files: map[string]string{"blob": "user,role\n", "blob.sig": "MEUCIQ=="}},
+	}, calls)
+}
+
+func TestVerifyWindow(t *testing.T) {
+	t.Parallel()
+
+	windowDir := t.TempDir()
+	write := func(file, content string) {
+		path := filepath.Join(windowDir, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	write(MetadataPath, "files_generated: []\n")
+	write("01_users.csv", "user,role\n")
+	write("02_groups.csv", "group\n")
+	write("03_notes.md", "# Notes\n")
+	for _, file := range []string{MetadataPath, "01_users.csv", "02_groups.csv"} {
+		require.NoError(t, SignFile(context.Background(), checksumSigner{}, windowDir, file, os.ReadFile))
+	}
+	assert.FileExists(t, filepath.Join(windowDir, ".generation", "signatures", "01_users.csv.sig"))
+	assert.FileExists(t, filepath.Join(windowDir, ".generation", "metadata.yaml.sig"))
+
+	write("02_groups.csv", "group\nadmins\n")
+	results := VerifyWindow(context.Background(), checksumSigner{}, windowDir, []string{"01_users.csv", "02_groups.csv", "03_notes.md"}, os.ReadFile)
+	assert.Equal(t, []FileSignature{
+		{File: ".generation/metadata.yaml", Status: SignatureValid},
+		{File: "01_users.csv", Status: SignatureValid},
+		{File: "02_groups.csv", Status: SignatureInvalid, Message: "BAD signature"},
+		{File: "03_notes.md", Status: SignatureMissing},
+	}, results)
+}
@@ -0,0 +1,115 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// MetadataPath is a window's generation metadata, signed with its files
+var MetadataPath = filepath.Join(".generation", "metadata.yaml")
+
+// signaturesDir holds the signatures of a window's evidence files. It is
+// inside .generation so they move with the metadata when the window is
+// submitted, and are never taken for evidence themselves.
+var signaturesDir = filepath.Join(".generation", "signatures")
+
+// Signature verification results
+const (
+	SignatureValid   = "valid"    // The signature matches the file
+	SignatureInvalid = "invalid"  // The file changed after it was signed, or the signature is bad
+	SignatureMissing = "unsigned" // The file has no signature
+)
+
+// FileSignature is the verification result of one file's signature
+type FileSignature struct {
+	File    string `json:"file" yaml:"file"` // Relative to the window
+	Status  string `json:"status" yaml:"status"`
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// SignaturePath returns where the signature of a file in a window is kept:
+// beside the generation metadata for the metadata, and under
+// .generation/signatures/ for an evidence file
+func SignaturePath(windowDir, file string) string {
+	if file == MetadataPath {
+		return filepath.Join(windowDir, MetadataPath+".sig")
+	}
+	return filepath.Join(windowDir, signaturesDir, file+".sig")
+}
+
+// SignFile signs a file in a window and stores the signature, replacing any
+// earlier one. The file is read with read, so encrypted evidence is signed as
+// its plaintext.
+func SignFile(ctx context.Context, signer Signer, windowDir, file string, read func(string) ([]byte, error)) error {
+	data, err := read(filepath.Join(windowDir, file))
+	if err != nil {
+		return err
+	}
+	signature, err := signer.Sign(ctx, data)
+	if err != nil {
+		return fmt.Errorf("signing %s: %w", file, err)
+	}
+	path := SignaturePath(windowDir, file)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, signature, 0644)
+}
+
+// VerifyFile checks a file's stored signature against data, its content as
+// signed. It returns ErrUnsigned when the file has no signature.
+func VerifyFile(ctx context.Context, signer Signer, windowDir, file string, data []byte) error {
+	signature, err := os.ReadFile(SignaturePath(windowDir, file))
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrUnsigned
+	}
+	if err != nil {
+		return err
+	}
+	return signer.Verify(ctx, data, signature)
+}
+
+// VerifyWindow verifies the signatures of evidence files in a window, and of
+// its generation metadata when there is one. Files are read with read, so
+// encrypted evidence is verified as the plaintext that was signed.
+func VerifyWindow(ctx context.Context, signer Signer, windowDir string, files []string, read func(string) ([]byte, error)) []FileSignature {
+	if _, err := os.Stat(filepath.Join(windowDir, MetadataPath)); err == nil {
+		files = append([]string{MetadataPath}, files...)
+	}
+
+	results := make([]FileSignature, 0, len(files))
+	for _, file := range files {
+		result := FileSignature{File: filepath.ToSlash(file), Status: SignatureValid}
+		data, err := read(filepath.Join(windowDir, file))
+		if err == nil {
+			err = VerifyFile(ctx, signer, windowDir, file, data)
+		}
+		switch {
+		case errors.Is(err, ErrUnsigned):
+			result.Status = SignatureMissing
+		case err != nil:
+			result.Status, result.Message = SignatureInvalid, err.Error()
+		}
+		results = append(results, result)
+	}
+	return results
+}
@@ -0,0 +1,114 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/grctool/grctool/internal/domain"
+)
+
+// acknowledgmentsCollection is the docs subdirectory holding a directory per
+// policy with one JSON file of acknowledgments per period
+const acknowledgmentsCollection = "acknowledgments"
+
+// employeesCollection is the docs subdirectory holding the employee roster
+const employeesCollection = "employees"
+
+// rosterID names the employee roster file
+const rosterID = "roster"
+
+// SavePolicyAcknowledgments validates and saves a policy's acknowledgments for a period
+func (us *Storage) SavePolicyAcknowledgments(acks *domain.PolicyAcknowledgments) error {
+	if acks == nil {
+		return fmt.Errorf("acknowledgments cannot be nil")
+	}
+	if err := acks.Validate(); err != nil {
+		return err
+	}
+	return us.fileStorage.Save(path.Join(acknowledgmentsCollection, acks.Policy), acks.Period, acks)
+}
+
+// GetPolicyAcknowledgments retrieves a policy's acknowledgments for a period,
+// returning an empty set when none have been recorded
+func (us *Storage) GetPolicyAcknowledgments(policy, period string) (*domain.PolicyAcknowledgments, error) {
+	if err := domain.ValidateAcknowledgmentScope(policy, period); err != nil {
+		return nil, err
+	}
+	collection := path.Join(acknowledgmentsCollection, policy)
+	if !us.fileStorage.Exists(collection, period) {
+		return &domain.PolicyAcknowledgments{Policy: policy, Period: period, Acknowledgments: []domain.PolicyAcknowledgment{}}, nil
+	}
+
+	var acks domain.PolicyAcknowledgments
+	if err := us.fileStorage.Load(collection, period, &acks); err != nil {
+		return nil, fmt.Errorf("failed to load acknowledgments of %s for %s: %w", policy, period, err)
+	}
+	return &acks, nil
+}
+
+// GetAllPolicyAcknowledgments retrieves every policy's acknowledgments for a
+// period, sorted by policy
+func (us *Storage) GetAllPolicyAcknowledgments(period string) ([]domain.PolicyAcknowledgments, error) {
+	policies, err := us.fileStorage.ListDirectories(filepath.Join(us.docsDir, acknowledgmentsCollection))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []domain.PolicyAcknowledgments{}, nil
+		}
+		return nil, err
+	}
+
+	all := []domain.PolicyAcknowledgments{}
+	for _, policy := range policies {
+		collection := path.Join(acknowledgmentsCollection, policy)
+		if !us.fileStorage.Exists(collection, period) {
+			continue
+		}
+		acks, err := us.GetPolicyAcknowledgments(policy, period)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, *acks)
+	}
+
+	sort.Slice(all, func(i, j int) bool { return all[i].Policy < all[j].Policy })
+	return all, nil
+}
+
+// SaveEmployeeRoster replaces the employee roster
+func (us *Storage) SaveEmployeeRoster(roster *domain.EmployeeRoster) error {
+	if roster == nil {
+		return fmt.Errorf("roster cannot be nil")
+	}
+	return us.fileStorage.Save(employeesCollection, rosterID, roster)
+}
+
+// GetEmployeeRoster retrieves the employee roster, or nil when none has been imported
+func (us *Storage) GetEmployeeRoster() (*domain.EmployeeRoster, error) {
+	if !us.fileStorage.Exists(employeesCollection, rosterID) {
+		return nil, nil
+	}
+	var roster domain.EmployeeRoster
+	if err := us.fileStorage.Load(employeesCollection, rosterID, &roster); err != nil {
+		return nil, fmt.Errorf("failed to load employee roster: %w", err)
+	}
+	return &roster, nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcknowledgmentStorage(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+	store, err := NewStorage(config.StorageConfig{DataDir: dataDir})
+	require.NoError(t, err)
+
+	all, err := store.GetAllPolicyAcknowledgments("2025")
+	require.NoError(t, err)
+	assert.Empty(t, all)
+
+	acks, err := store.GetPolicyAcknowledgments("acceptable-use-policy", "2025")
+	require.NoError(t, err)
+	assert.Empty(t, acks.Acknowledgments)
+
+	acks.Add(domain.PolicyAcknowledgment{Email: "Alex@Example.com", AcknowledgedAt: time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC),
+		Source: domain.AcknowledgmentSourceCSV})
+	require.NoError(t, store.SavePolicyAcknowledgments(acks))
+	assert.FileExists(t, filepath.Join(dataDir, "docs", "acknowledgments", "acceptable-use-policy", "2025.json"))
+
+	loaded, err := store.GetPolicyAcknowledgments("acceptable-use-policy", "2025")
+	require.NoError(t, err)
+	require.Len(t, loaded.Acknowledgments, 1)
+	assert.Equal(t, "alex@example.com", loaded.Acknowledgments[0].Email)
+
+	other, err := store.GetPolicyAcknowledgments("code-of-conduct", "2024")
+	require.NoError(t, err)
+	other.Add(domain.PolicyAcknowledgment{Email: "sam@example.com", AcknowledgedAt: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		Source: domain.AcknowledgmentSourceLink})
+	require.NoError(t, store.SavePolicyAcknowledgments(other))
+
+	all, err = store.GetAllPolicyAcknowledgments("2025")
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, "acceptable-use-policy", all[0].Policy)
+
+	_, err = store.GetPolicyAcknowledgments("acceptable-use-policy", "last year")
+	assert.ErrorContains(t, err, "invalid acknowledgment period")
+
+	roster, err := store.GetEmployeeRoster()
+	require.NoError(t, err)
+	assert.Nil(t, roster)
+	require.NoError(t, store.SaveEmployeeRoster(&domain.EmployeeRoster{Employees: []domain.Employee{{Email: "alex@example.com"}}}))
+	roster, err = store.GetEmployeeRoster()
+	require.NoError(t, err)
+	require.NotNil(t, roster)
+	assert.Len(t, roster.Employees, 1)
+}
@@ -0,0 +1,240 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/models"
+)
+
+const (
+	// AuditDir is the directory of the action logs in data_dir, unless
+	// audit.log_dir moves them
+	AuditDir = "audit"
+
+	// auditLogExt is the extension of a host's action log
+	auditLogExt = ".jsonl"
+
+	// auditLockTimeout bounds how long an append waits for another grctool
+	// run on the same host to finish appending
+	auditLockTimeout = 5 * time.Second
+)
+
+// AuditLogPath returns the action log a host appends to in dir. Each host
+// has a log of its own, so logs mirrored or committed from several machines
+// never conflict.
+func AuditLogPath(dir, host string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, host)
+	if strings.Trim(name, ".") == "" {
+		name = "unknown"
+	}
+	return filepath.Join(dir, name+auditLogExt)
+}
+
+// AppendAuditEntry appends an entry to its host's action log in dir,
+// chaining it to the entry before it by hash. Earlier entries are never
+// rewritten.
+func AppendAuditEntry(dir string, entry models.AuditEntry) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	path := AuditLogPath(dir, entry.Host)
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	// Another run on this host may be appending; wait for it so both
+	// entries chain
+	deadline := time.Now().Add(auditLockTimeout)
+	for {
+		err = tryLock(file)
+		if !errors.Is(err, errLockHeld) || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock audit log %s: %w", path, err)
+	}
+	defer func() { _ = unlock(file) }()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return fmt.Errorf("failed to read audit log: %w", err)
+	}
+	if last := lastLine(data); last != nil {
+		var previous models.AuditEntry
+		if err := json.Unmarshal(last, &previous); err != nil {
+			return fmt.Errorf("audit log %s ends with a line that is not an audit entry: %w", path, err)
+		}
+		entry.PrevHash = previous.Hash
+	}
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+	entry.Hash = entry.ChainHash()
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// lastLine returns the last non-blank line of data, or nil when it has none
+func lastLine(data []byte) []byte {
+	data = bytes.TrimRight(data, " \t\r\n")
+	if len(data) == 0 {
+		return nil
+	}
+	return data[bytes.LastIndexByte(data, '\n')+1:]
+}
+
+// ReadAuditLog reads the action logs of every host in dir, ordered by when
+// each action started. A directory with no logs has no entries.
+func ReadAuditLog(dir string) ([]models.AuditEntry, error) {
+	paths, err := auditLogPaths(dir)
+	if err != nil {
+		return nil, err
+	}
+	entries := []models.AuditEntry{}
+	for _, path := range paths {
+		logged, err := readAuditFile(path)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, logged...)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp.Before(entries[j].Timestamp)
+	})
+	return entries, nil
+}
+
+// auditLogPaths returns the action logs in dir, sorted by name
+func auditLogPaths(dir string) ([]string, error) {
+	files, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit log directory: %w", err)
+	}
+	var paths []string
+	for _, file := range files {
+		if !file.IsDir() && strings.HasSuffix(file.Name(), auditLogExt) {
+			paths = append(paths, filepath.Join(dir, file.Name()))
+		}
+	}
+	return paths, nil
+}
+
+// readAuditFile reads one host's action log in the order it was written
+func readAuditFile(path string) ([]models.AuditEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	defer file.Close()
+
+	var entries []models.AuditEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var entry models.AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("audit log %s line %d is not an audit entry: %w", filepath.Base(path), line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return entries, nil
+}
+
+// AuditChain is the outcome of verifying one host's action log
+type AuditChain struct {
+	Log     string `json:"log"` // File name of the log
+	Entries int    `json:"entries"`
+	Head    string `json:"head,omitempty"` // Hash of the last entry
+}
+
+// AuditChainError reports the first entry of an action log that breaks its
+// hash chain, counting entries from 1
+type AuditChainError struct {
+	Log    string
+	Entry  int
+	Reason string
+}
+
+func (e *AuditChainError) Error() string {
+	return fmt.Sprintf("audit log %s entry %d %s", e.Log, e.Entry, e.Reason)
+}
+
+// VerifyAuditLog checks every action log in dir is an unbroken hash chain:
+// each entry's hash matches its content and names the entry before it
+func VerifyAuditLog(dir string) ([]AuditChain, error) {
+	paths, err := auditLogPaths(dir)
+	if err != nil {
+		return nil, err
+	}
+	chains := []AuditChain{}
+	for _, path := range paths {
+		entries, err := readAuditFile(path)
+		if err != nil {
+			return nil, err
+		}
+		chain := AuditChain{Log: filepath.Base(path), Entries: len(entries)}
+		for i, entry := range entries {
+			switch {
+			case entry.Hash == "" || entry.Hash != entry.ChainHash():
+				return nil, &AuditChainError{Log: chain.Log, Entry: i + 1, Reason: "has been altered: its hash does not match its content"}
+			case entry.PrevHash != chain.Head:
+				return nil, &AuditChainError{Log: chain.Log, Entry: i + 1, Reason: "does not follow the entry before it: entries were removed, reordered or inserted"}
+			}
+			chain.Head = entry.Hash
+		}
+		chains = append(chains, chain)
+	}
+	return chains, nil
+}
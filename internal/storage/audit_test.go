@@ -0,0 +1,110 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendAuditEntry(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), AuditDir)
+	entries, err := ReadAuditLog(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	start := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+	require.NoError(t, AppendAuditEntry(dir, models.AuditEntry{
+		Timestamp: start, Actor: "alex@example.com", Host: "laptop.local", Command: "sync", Outcome: models.AuditSucceeded,
+	}))
+	require.NoError(t, AppendAuditEntry(dir, models.AuditEntry{
+		Timestamp: start.Add(time.Hour), Actor: "ci", Host: "runner/1", Command: "evidence generate", Args: []string{"ET-0001"}, Outcome: models.AuditFailed, Error: "no tools",
+	}))
+	require.NoError(t, AppendAuditEntry(dir, models.AuditEntry{
+		Timestamp: start.Add(30 * time.Minute), Actor: "alex@example.com", Host: "laptop.local", Command: "evidence submit", Outcome: models.AuditSucceeded,
+	}))
+
+	assert.FileExists(t, filepath.Join(dir, "laptop.local.jsonl"))
+	assert.FileExists(t, filepath.Join(dir, "runner_1.jsonl"))
+
+	entries, err = ReadAuditLog(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, []string{"sync", "evidence submit", "evidence generate"},
+		[]string{entries[0].Command, entries[1].Command, entries[2].Command})
+	assert.Empty(t, entries[0].PrevHash)
+	assert.Equal(t, entries[0].Hash, entries[1].PrevHash, "entries of a host chain")
+	assert.Empty(t, entries[2].PrevHash, "each host's log has its own chain")
+
+	chains, err := VerifyAuditLog(dir)
+	require.NoError(t, err)
+	require.Len(t, chains, 2)
+	assert.Equal(t, AuditChain{Log: "laptop.local.jsonl", Entries: 2, Head: entries[1].Hash}, chains[0])
+	assert.Equal(t, 1, chains[1].Entries)
+}
+
+func TestVerifyAuditLog_Tampered(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		tamper  func(lines []string) []string
+		wantErr string
+	}{
+		"altered entry": {
+			tamper: func(lines []string) []string {
+				lines[0] = strings.Replace(lines[0], `"outcome":"failed"`, `"outcome":"succeeded"`, 1)
+				return lines
+			},
+			wantErr: "audit log host.jsonl entry 1 has been altered",
+		},
+		"removed entry": {
+			tamper:  func(lines []string) []string { return lines[1:] },
+			wantErr: "audit log host.jsonl entry 1 does not follow the entry before it",
+		},
+		"reordered entries": {
+			tamper:  func(lines []string) []string { return []string{lines[0], lines[2], lines[1]} },
+			wantErr: "audit log host.jsonl entry 2 does not follow the entry before it",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			for _, command := range []string{"sync", "evidence generate", "evidence submit"} {
+				require.NoError(t, AppendAuditEntry(dir, models.AuditEntry{Host: "host", Command: command, Outcome: models.AuditFailed}))
+			}
+			path := AuditLogPath(dir, "host")
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			lines := tc.tamper(strings.Split(strings.TrimSpace(string(data)), "\n"))
+			require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644))
+
+			_, err = VerifyAuditLog(dir)
+			var chainErr *AuditChainError
+			require.ErrorAs(t, err, &chainErr)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
+
+func TestAppendAuditEntry_CorruptLog(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(AuditLogPath(dir, "host"), []byte("not json\n"), 0644))
+
+	err := AppendAuditEntry(dir, models.AuditEntry{Host: "host", Command: "sync"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ends with a line that is not an audit entry")
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/grctool/grctool/internal/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// authoredPoliciesDir is where authored policies are kept, relative to the docs directory
+var authoredPoliciesDir = filepath.Join("policies", "authored")
+
+// frontMatterDelimiter opens and closes the YAML front matter of an authored policy
+const frontMatterDelimiter = "---"
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ErrAuthoredPolicyNotFound is returned when no authored policy matches a reference
+var ErrAuthoredPolicyNotFound = errors.New("authored policy not found")
+
+// AuthoredPolicyID derives the ID, and file name, of an authored policy from its title
+func AuthoredPolicyID(title string) string {
+	return strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(title), "-"), "-")
+}
+
+// AuthoredPolicyPath returns the markdown file holding the authored policy with the given ID
+func (us *Storage) AuthoredPolicyPath(id string) string {
+	return filepath.Join(us.docsDir, authoredPoliciesDir, id+".md")
+}
+
+// SaveAuthoredPolicy validates and writes an authored policy as markdown with front matter
+func (us *Storage) SaveAuthoredPolicy(policy *domain.AuthoredPolicy) error {
+	if policy == nil {
+		return fmt.Errorf("policy cannot be nil")
+	}
+	if policy.ID == "" {
+		return fmt.Errorf("policy ID cannot be empty")
+	}
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+
+	data, err := MarshalAuthoredPolicy(policy)
+	if err != nil {
+		return err
+	}
+	path := us.AuthoredPolicyPath(policy.ID)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create policy directory: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// GetAuthoredPolicy retrieves an authored policy by ID or title, ignoring case
+func (us *Storage) GetAuthoredPolicy(ref string) (*domain.AuthoredPolicy, error) {
+	if policy, err := us.loadAuthoredPolicy(AuthoredPolicyID(ref)); err == nil {
+		return policy, nil
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	return nil, fmt.Errorf("%w: %s", ErrAuthoredPolicyNotFound, ref)
+}
+
+// GetAllAuthoredPolicies retrieves the authored policies, sorted by ID
+func (us *Storage) GetAllAuthoredPolicies() ([]domain.AuthoredPolicy, error) {
+	entries, err := os.ReadDir(filepath.Join(us.docsDir, authoredPoliciesDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []domain.AuthoredPolicy{}, nil
+		}
+		return nil, fmt.Errorf("failed to read policy directory: %w", err)
+	}
+
+	policies := make([]domain.AuthoredPolicy, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+		policy, err := us.loadAuthoredPolicy(strings.TrimSuffix(entry.Name(), ".md"))
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, *policy)
+	}
+
+	sort.Slice(policies, func(i, j int) bool { return policies[i].ID < policies[j].ID })
+	return policies, nil
+}
+
+func (us *Storage) loadAuthoredPolicy(id string) (*domain.AuthoredPolicy, error) {
+	data, err := os.ReadFile(us.AuthoredPolicyPath(id))
+	if err != nil {
+		return nil, err
+	}
+	policy, err := UnmarshalAuthoredPolicy(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy %s: %w", id, err)
+	}
+	policy.ID = id
+	return policy, nil
+}
+
+// MarshalAuthoredPolicy renders a policy as YAML front matter followed by its markdown body
+func MarshalAuthoredPolicy(policy *domain.AuthoredPolicy) ([]byte, error) {
+	frontMatter, err := yaml.Marshal(policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal policy front matter: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(frontMatterDelimiter + "\n")
+	buf.Write(frontMatter)
+	buf.WriteString(frontMatterDelimiter + "\n\n")
+	buf.WriteString(strings.TrimLeft(policy.Body, "\n"))
+	return buf.Bytes(), nil
+}
+
+// UnmarshalAuthoredPolicy parses markdown with YAML front matter into a policy
+func UnmarshalAuthoredPolicy(data []byte) (*domain.AuthoredPolicy, error) {
+	content := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if !strings.HasPrefix(content, frontMatterDelimiter+"\n") {
+		return nil, fmt.Errorf("missing front matter")
+	}
+	frontMatter, body, found := strings.Cut(content[len(frontMatterDelimiter)+1:], "\n"+frontMatterDelimiter+"\n")
+	if !found {
+		return nil, fmt.Errorf("unterminated front matter")
+	}
+
+	var policy domain.AuthoredPolicy
+	if err := yaml.Unmarshal([]byte(frontMatter), &policy); err != nil {
+		return nil, fmt.Errorf("invalid front matter: %w", err)
+	}
+	policy.Body = strings.TrimLeft(body, "\n")
+	return &policy, nil
+}
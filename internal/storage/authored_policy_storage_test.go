@@ -0,0 +1,105 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthoredPolicyID(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		title string
+		want  string
+	}{
+		"words":       {title: "Access Control Policy", want: "access-control-policy"},
+		"punctuation": {title: "  Business Continuity & DR (BC/DR) ", want: "business-continuity-dr-bc-dr"},
+		"already id":  {title: "access-control-policy", want: "access-control-policy"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, AuthoredPolicyID(tc.title))
+		})
+	}
+}
+
+func TestAuthoredPolicyStorage(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+	store, err := NewStorage(config.StorageConfig{DataDir: dataDir})
+	require.NoError(t, err)
+
+	policies, err := store.GetAllAuthoredPolicies()
+	require.NoError(t, err)
+	assert.Empty(t, policies)
+
+	policy := &domain.AuthoredPolicy{ID: "access-control-policy", Title: "Access Control Policy", Owner: "alex@example.com",
+		Version: "1.0", Status: domain.AuthoredPolicyApproved, Approvers: []string{"cto@example.com"},
+		Approvals:    []domain.PolicyApproval{{Approver: "cto@example.com", Date: "2025-09-01", Version: "1.0"}},
+		ApprovedDate: "2025-09-01", ReviewDate: "2026-09-01", Body: "# Access Control Policy\n\n## Purpose\n"}
+	require.NoError(t, store.SaveAuthoredPolicy(policy))
+
+	path := filepath.Join(dataDir, "docs", "policies", "authored", "access-control-policy.md")
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "---\ntitle: Access Control Policy\n")
+	assert.Contains(t, string(data), "---\n\n# Access Control Policy\n")
+
+	for _, ref := range []string{"access-control-policy", "Access Control Policy"} {
+		loaded, err := store.GetAuthoredPolicy(ref)
+		require.NoError(t, err, ref)
+		assert.Equal(t, *policy, *loaded)
+	}
+	_, err = store.GetAuthoredPolicy("Encryption Policy")
+	assert.ErrorContains(t, err, "authored policy not found")
+
+	// Hand-edited front matter with unquoted dates and versions
+	edited := "---\ntitle: Encryption Policy\nversion: 2.0\nstatus: draft\nreview_date: 2026-01-31\n---\nBody\n"
+	require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(path), "encryption-policy.md"), []byte(edited), 0644))
+	loaded, err := store.GetAuthoredPolicy("encryption-policy")
+	require.NoError(t, err)
+	assert.Equal(t, "2.0", loaded.Version)
+	assert.Equal(t, "2026-01-31", loaded.ReviewDate)
+	assert.Equal(t, "Body\n", loaded.Body)
+
+	policies, err = store.GetAllAuthoredPolicies()
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+	assert.Equal(t, "access-control-policy", policies[0].ID)
+
+	assert.ErrorContains(t, store.SaveAuthoredPolicy(&domain.AuthoredPolicy{ID: "x", Title: "X", Version: "1.0",
+		Status: domain.AuthoredPolicyApproved}), "needs an approved_date")
+}
+
+func TestUnmarshalAuthoredPolicy_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		data string
+		err  string
+	}{
+		"no front matter": {data: "# Policy\n", err: "missing front matter"},
+		"unterminated":    {data: "---\ntitle: X\n", err: "unterminated front matter"},
+		"bad yaml":        {data: "---\ntitle: [\n---\n", err: "invalid front matter"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := UnmarshalAuthoredPolicy([]byte(tc.data))
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// AWSSigner signs requests to an AWS service with the credential chain the
+// S3 backend and KMS use, for callers outside this package such as the
+// Bedrock model provider
+type AWSSigner struct {
+	region  string
+	service string
+	creds   func(ctx context.Context) (awsCredentials, error)
+	now     func() time.Time
+}
+
+// NewAWSSigner creates a signer for a service, such as bedrock. An empty
+// region uses the one the environment configures, else us-east-1.
+func NewAWSSigner(region, service string) *AWSSigner {
+	return &AWSSigner{
+		region:  awsRegion(region),
+		service: service,
+		creds:   newAWSCredentialChain(),
+		now:     time.Now,
+	}
+}
+
+// Region returns the region requests are signed for
+func (s *AWSSigner) Region() string {
+	return s.region
+}
+
+// Credentials returns why no credentials can be found, or nil when they can
+func (s *AWSSigner) Credentials(ctx context.Context) error {
+	_, err := s.creds(ctx)
+	return err
+}
+
+// Sign adds Signature Version 4 headers to a request whose body is body
+func (s *AWSSigner) Sign(ctx context.Context, req *http.Request, body []byte) error {
+	creds, err := s.creds(ctx)
+	if err != nil {
+		return err
+	}
+	signV4(req, body, creds, s.region, s.service, s.now())
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ErrObjectNotFound is returned by a Backend for a missing object
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectInfo describes a stored object. Version changes whenever the
+// object's content does: an S3 ETag or a GCS generation.
+type ObjectInfo struct {
+	Key     string
+	Size    int64
+	Version string
+}
+
+// Backend is an object store that data_dir can be mirrored to. Keys are
+// slash-separated paths relative to the backend's prefix.
+type Backend interface {
+	// Name describes the backend, e.g. s3://bucket/prefix
+	Name() string
+	Get(ctx context.Context, key string) ([]byte, error)
+	Put(ctx context.Context, key string, data []byte) (ObjectInfo, error)
+	Delete(ctx context.Context, key string) error
+	List(ctx context.Context) ([]ObjectInfo, error)
+}
+
+// NewBackend returns the backend for a storage.remote configuration
+func NewBackend(ctx context.Context, cfg config.RemoteStorageConfig) (Backend, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid storage.remote.url: %w", err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("storage.remote.url %s has no bucket", cfg.URL)
+	}
+	prefix := strings.Trim(u.Path, "/")
+
+	var backend Backend
+	switch u.Scheme {
+	case "s3":
+		backend, err = NewS3Backend(u.Host, prefix, cfg.Region, cfg.Endpoint)
+	case "gs":
+		backend, err = NewGCSBackend(ctx, u.Host, prefix)
+	default:
+		return nil, fmt.Errorf("unsupported storage.remote.url scheme %q: use s3:// or gs://", u.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tracedBackend{backend: backend}, nil
+}
+
+// tracedBackend records a span for every object store operation
+type tracedBackend struct {
+	backend Backend
+}
+
+func (b *tracedBackend) Name() string { return b.backend.Name() }
+
+func (b *tracedBackend) Get(ctx context.Context, key string) (data []byte, err error) {
+	ctx, span := telemetry.Start(ctx, "storage get", b.attributes(key)...)
+	defer func() {
+		span.SetAttributes(attribute.Int("storage.size", len(data)))
+		if errors.Is(err, ErrObjectNotFound) {
+			// A missing object is an expected answer, not a failure
+			telemetry.End(span, nil)
+			return
+		}
+		telemetry.End(span, err)
+	}()
+	return b.backend.Get(ctx, key)
+}
+
+func (b *tracedBackend) Put(ctx context.Context, key string, data []byte) (info ObjectInfo, err error) {
+	ctx, span := telemetry.Start(ctx, "storage put", append(b.attributes(key), attribute.Int("storage.size", len(data)))...)
+	defer func() { telemetry.End(span, err) }()
+	return b.backend.Put(ctx, key, data)
+}
+
+func (b *tracedBackend) Delete(ctx context.Context, key string) (err error) {
+	ctx, span := telemetry.Start(ctx, "storage delete", b.attributes(key)...)
+	defer func() { telemetry.End(span, err) }()
+	return b.backend.Delete(ctx, key)
+}
+
+func (b *tracedBackend) List(ctx context.Context) (objects []ObjectInfo, err error) {
+	ctx, span := telemetry.Start(ctx, "storage list", attribute.String("storage.backend", b.backend.Name()))
+	defer func() {
+		span.SetAttributes(attribute.Int("storage.objects", len(objects)))
+		telemetry.End(span, err)
+	}()
+	return b.backend.List(ctx)
+}
+
+func (b *tracedBackend) attributes(key string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("storage.backend", b.backend.Name()),
+		attribute.String("storage.key", key),
+	}
+}
+
+// objectKey joins a backend prefix and a key
+func objectKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "/" + key
+}
@@ -0,0 +1,47 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// Not parallel: spans are recorded through the global tracer provider
+func TestTracedBackend(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	ctx := context.Background()
+	backend := &tracedBackend{backend: newMemoryBackend()}
+	assert.Equal(t, "memory://bucket", backend.Name())
+
+	_, err := backend.Put(ctx, "evidence/ET-0001.md", []byte("evidence"))
+	require.NoError(t, err)
+	data, err := backend.Get(ctx, "evidence/ET-0001.md")
+	require.NoError(t, err)
+	assert.Equal(t, "evidence", string(data))
+	_, err = backend.Get(ctx, "evidence/missing.md")
+	assert.ErrorIs(t, err, ErrObjectNotFound)
+	objects, err := backend.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, objects, 1)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 4)
+	var names []string
+	for _, span := range spans {
+		names = append(names, span.Name())
+		// A missing object is not a failed operation
+		assert.Equal(t, codes.Unset, span.Status().Code, span.Name())
+	}
+	assert.Equal(t, []string{"storage put", "storage get", "storage get", "storage list"}, names)
+}
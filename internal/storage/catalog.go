@@ -0,0 +1,731 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/naming"
+	"gopkg.in/yaml.v3"
+
+	_ "modernc.org/sqlite" // Pure Go SQLite driver
+)
+
+// catalogDir holds the catalog database, which is derived from data_dir and
+// so never committed or mirrored
+var catalogDir = filepath.Join(gitStateDir, "catalog")
+
+const (
+	catalogFilename = "catalog.db"
+
+	// catalogSchemaVersion is bumped whenever the schema changes; a catalog
+	// of another version is dropped and rebuilt
+	catalogSchemaVersion = "2"
+)
+
+// Kinds of files the catalog indexes, each refreshed on its own
+const (
+	catalogTasks    = "tasks"
+	catalogControls = "controls"
+	catalogPolicies = "policies"
+	catalogEvidence = "evidence"
+)
+
+var catalogSchema = []string{
+	`CREATE TABLE meta (key TEXT PRIMARY KEY, value TEXT NOT NULL)`,
+	`CREATE TABLE tasks (
+		source TEXT PRIMARY KEY, id TEXT NOT NULL, reference_id TEXT NOT NULL,
+		name TEXT NOT NULL, framework TEXT NOT NULL, status TEXT NOT NULL,
+		priority TEXT NOT NULL, sensitive INTEGER NOT NULL, next_due TEXT,
+		data BLOB NOT NULL)`,
+	`CREATE INDEX tasks_id ON tasks (id)`,
+	`CREATE INDEX tasks_reference_id ON tasks (reference_id)`,
+	`CREATE TABLE controls (
+		source TEXT PRIMARY KEY, id TEXT NOT NULL, reference_id TEXT NOT NULL,
+		name TEXT NOT NULL, framework TEXT NOT NULL, category TEXT NOT NULL,
+		status TEXT NOT NULL, data BLOB NOT NULL)`,
+	`CREATE INDEX controls_id ON controls (id)`,
+	`CREATE INDEX controls_reference_id ON controls (reference_id)`,
+	`CREATE TABLE policies (
+		source TEXT PRIMARY KEY, id TEXT NOT NULL, reference_id TEXT NOT NULL,
+		name TEXT NOT NULL, framework TEXT NOT NULL, status TEXT NOT NULL,
+		data BLOB NOT NULL)`,
+	`CREATE INDEX policies_id ON policies (id)`,
+	`CREATE INDEX policies_reference_id ON policies (reference_id)`,
+	`CREATE TABLE evidence_windows (
+		task_ref TEXT NOT NULL, window TEXT NOT NULL, task_dir TEXT NOT NULL,
+		file_count INTEGER NOT NULL, submitted_count INTEGER NOT NULL,
+		total_bytes INTEGER NOT NULL, newest_file TEXT, generated INTEGER NOT NULL,
+		submission_status TEXT NOT NULL, PRIMARY KEY (task_ref, window))`,
+	`CREATE TABLE evidence_files (
+		task_ref TEXT NOT NULL, window TEXT NOT NULL, path TEXT NOT NULL,
+		size INTEGER NOT NULL, mod_time TEXT NOT NULL, submitted INTEGER NOT NULL,
+		PRIMARY KEY (task_ref, window, path))`,
+	`CREATE VIRTUAL TABLE search USING fts5 (
+		kind UNINDEXED, ref UNINDEXED, location UNINDEXED, title, body,
+		tokenize = 'porter unicode61')`,
+}
+
+var catalogTables = []string{"meta", "tasks", "controls", "policies", "evidence_windows", "evidence_files", "search"}
+
+// taskRefPattern matches ET-0001 and ET0001
+var taskRefPattern = regexp.MustCompile(`^ET-?(\d+)$`)
+
+// Catalog is a SQLite index of data_dir: the synced evidence tasks, controls
+// and policies, and the evidence files of each window with its generation
+// and submission state, plus a full-text index of their text (see Search).
+// Each kind is re-indexed from the files whenever they change, detected from
+// their names, sizes and modification times, so the catalog is never
+// authoritative and can be deleted at any time.
+type Catalog struct {
+	db      *sql.DB
+	path    string
+	sources map[string]string // Kind -> directory it is indexed from
+
+	mu     sync.Mutex
+	inited bool
+}
+
+// CatalogWindow is the catalog entry of one evidence window
+type CatalogWindow struct {
+	TaskRef          string     `json:"task_ref" yaml:"task_ref"`
+	Window           string     `json:"window" yaml:"window"`
+	TaskDir          string     `json:"task_dir" yaml:"task_dir"`
+	FileCount        int        `json:"file_count" yaml:"file_count"`           // Working and submitted files
+	SubmittedCount   int        `json:"submitted_count" yaml:"submitted_count"` // Files in .submitted/
+	TotalBytes       int64      `json:"total_bytes" yaml:"total_bytes"`
+	NewestFile       *time.Time `json:"newest_file,omitempty" yaml:"newest_file,omitempty"`
+	Generated        bool       `json:"generated" yaml:"generated"` // Has .generation/metadata.yaml
+	SubmissionStatus string     `json:"submission_status,omitempty" yaml:"submission_status,omitempty"`
+}
+
+// CatalogStats summarizes what the catalog holds
+type CatalogStats struct {
+	Path          string    `json:"path" yaml:"path"`
+	Tasks         int       `json:"tasks" yaml:"tasks"`
+	Controls      int       `json:"controls" yaml:"controls"`
+	Policies      int       `json:"policies" yaml:"policies"`
+	Windows       int       `json:"windows" yaml:"windows"`
+	EvidenceFiles int       `json:"evidence_files" yaml:"evidence_files"`
+	RefreshedAt   time.Time `json:"refreshed_at" yaml:"refreshed_at"`
+}
+
+// OpenCatalog opens the catalog of cfg.DataDir. The database is created on
+// first use.
+func OpenCatalog(cfg config.StorageConfig) (*Catalog, error) {
+	if cfg.DataDir == "" {
+		return nil, fmt.Errorf("data_dir cannot be empty")
+	}
+	paths := cfg.Paths.WithDefaults().ResolveRelativeTo(cfg.DataDir)
+	path := filepath.Join(cfg.DataDir, catalogDir, catalogFilename)
+
+	db, err := sql.Open("sqlite", "file:"+filepath.ToSlash(path)+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open catalog: %w", err)
+	}
+	// One connection keeps a refresh from waiting on this process's own reads
+	db.SetMaxOpenConns(1)
+
+	return &Catalog{
+		db:   db,
+		path: path,
+		sources: map[string]string{
+			catalogTasks:    paths.EvidenceTasksJSON,
+			catalogControls: paths.ControlsJSON,
+			catalogPolicies: paths.PoliciesJSON,
+			catalogEvidence: paths.Evidence,
+		},
+	}, nil
+}
+
+// Path returns the catalog database file
+func (c *Catalog) Path() string {
+	return c.path
+}
+
+// Close closes the catalog database
+func (c *Catalog) Close() error {
+	return c.db.Close()
+}
+
+// init creates the schema, dropping a catalog of another schema version
+func (c *Catalog) init() error {
+	if c.inited {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("failed to create catalog directory: %w", err)
+	}
+
+	var version string
+	err := c.db.QueryRow(`SELECT value FROM meta WHERE key = 'schema_version'`).Scan(&version)
+	if err == nil && version == catalogSchemaVersion {
+		c.inited = true
+		return nil
+	}
+
+	tx, err := c.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to initialize catalog: %w", err)
+	}
+	defer tx.Rollback()
+	for _, table := range catalogTables {
+		if _, err := tx.Exec(`DROP TABLE IF EXISTS ` + table); err != nil {
+			return fmt.Errorf("failed to initialize catalog: %w", err)
+		}
+	}
+	for _, stmt := range catalogSchema {
+		if _, err := tx.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to initialize catalog: %w", err)
+		}
+	}
+	if _, err := tx.Exec(`INSERT INTO meta (key, value) VALUES ('schema_version', ?)`, catalogSchemaVersion); err != nil {
+		return fmt.Errorf("failed to initialize catalog: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to initialize catalog: %w", err)
+	}
+	c.inited = true
+	return nil
+}
+
+// Refresh re-indexes every kind of file that changed since it was last
+// indexed
+func (c *Catalog) Refresh() error {
+	for _, kind := range []string{catalogTasks, catalogControls, catalogPolicies, catalogEvidence} {
+		if err := c.refresh(kind); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rebuild re-indexes every kind of file whether or not it changed
+func (c *Catalog) Rebuild() error {
+	c.mu.Lock()
+	if err := c.init(); err != nil {
+		c.mu.Unlock()
+		return err
+	}
+	_, err := c.db.Exec(`DELETE FROM meta WHERE key LIKE 'fingerprint:%'`)
+	c.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to reset catalog: %w", err)
+	}
+	return c.Refresh()
+}
+
+// refresh re-indexes one kind of file if it changed
+func (c *Catalog) refresh(kind string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.init(); err != nil {
+		return err
+	}
+
+	dir := c.sources[kind]
+	var files []catalogFile
+	var err error
+	if kind == catalogEvidence {
+		files, err = scanEvidenceFiles(dir)
+	} else {
+		files, err = scanJSONFiles(dir)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to scan %s: %w", kind, err)
+	}
+	fingerprint := catalogFingerprint(files)
+
+	var indexed string
+	_ = c.db.QueryRow(`SELECT value FROM meta WHERE key = ?`, "fingerprint:"+kind).Scan(&indexed)
+	if indexed == fingerprint {
+		return nil
+	}
+
+	tx, err := c.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to index %s: %w", kind, err)
+	}
+	defer tx.Rollback()
+
+	switch kind {
+	case catalogTasks:
+		err = indexTasks(tx, dir, files)
+	case catalogControls:
+		err = indexControls(tx, dir, files)
+	case catalogPolicies:
+		err = indexPolicies(tx, dir, files)
+	case catalogEvidence:
+		err = indexEvidence(tx, dir, files)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to index %s: %w", kind, err)
+	}
+
+	if _, err := tx.Exec(`INSERT OR REPLACE INTO meta (key, value) VALUES (?, ?), ('refreshed_at', ?)`,
+		"fingerprint:"+kind, fingerprint, time.Now().UTC().Format(time.RFC3339Nano)); err != nil {
+		return fmt.Errorf("failed to index %s: %w", kind, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to index %s: %w", kind, err)
+	}
+	return nil
+}
+
+// catalogFile is a file the catalog indexes, relative to its kind's directory
+type catalogFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+func catalogFingerprint(files []catalogFile) string {
+	h := sha256.New()
+	for _, file := range files {
+		fmt.Fprintf(h, "%s\x00%d\x00%d\n", file.path, file.size, file.modTime.UnixNano())
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// scanJSONFiles lists the JSON documents in dir, in the order GetAll* reads
+// them
+func scanJSONFiles(dir string) ([]catalogFile, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var files []catalogFile
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, catalogFile{path: entry.Name(), size: info.Size(), modTime: info.ModTime()})
+	}
+	return files, nil
+}
+
+// scanEvidenceFiles lists every regular file under the evidence directory,
+// metadata included so generation and submission changes are noticed
+func scanEvidenceFiles(dir string) ([]catalogFile, error) {
+	var files []catalogFile
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, catalogFile{path: filepath.ToSlash(rel), size: info.Size(), modTime: info.ModTime()})
+		return nil
+	})
+	return files, err
+}
+
+func indexTasks(tx *sql.Tx, dir string, files []catalogFile) error {
+	if err := clearTable(tx, "tasks", SearchTask); err != nil {
+		return err
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(dir, file.path))
+		if err != nil {
+			continue
+		}
+		var task domain.EvidenceTask
+		if json.Unmarshal(data, &task) != nil {
+			continue // Unreadable documents are skipped, as GetAllEvidenceTasks does
+		}
+		var nextDue any
+		if task.NextDue != nil {
+			nextDue = task.NextDue.UTC().Format(time.RFC3339)
+		}
+		if _, err := tx.Exec(`INSERT INTO tasks (source, id, reference_id, name, framework, status, priority, sensitive, next_due, data)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			file.path, task.ID, task.ReferenceID, task.Name, task.Framework, task.Status, task.Priority, task.Sensitive, nextDue, data); err != nil {
+			return err
+		}
+		body := []string{task.Description, task.Guidance}
+		if task.MasterContent != nil {
+			body = append(body, task.MasterContent.Description, task.MasterContent.Guidance, task.MasterContent.Help)
+		}
+		if err := indexSearch(tx, SearchTask, documentRef(task.ReferenceID, task.ID), "", task.Name, body...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func indexControls(tx *sql.Tx, dir string, files []catalogFile) error {
+	if err := clearTable(tx, "controls", SearchControl); err != nil {
+		return err
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(dir, file.path))
+		if err != nil {
+			continue
+		}
+		var control domain.Control
+		if json.Unmarshal(data, &control) != nil {
+			continue
+		}
+		if _, err := tx.Exec(`INSERT INTO controls (source, id, reference_id, name, framework, category, status, data)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			file.path, control.ID, control.ReferenceID, control.Name, control.Framework, control.Category, control.Status, data); err != nil {
+			return err
+		}
+		body := []string{control.Description, control.Help}
+		if control.MasterContent != nil {
+			body = append(body, control.MasterContent.Description, control.MasterContent.Guidance, control.MasterContent.Help)
+		}
+		if err := indexSearch(tx, SearchControl, documentRef(control.ReferenceID, control.ID), "", control.Name, body...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func indexPolicies(tx *sql.Tx, dir string, files []catalogFile) error {
+	if err := clearTable(tx, "policies", SearchPolicy); err != nil {
+		return err
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(dir, file.path))
+		if err != nil {
+			continue
+		}
+		var policy domain.Policy
+		if json.Unmarshal(data, &policy) != nil {
+			continue
+		}
+		if _, err := tx.Exec(`INSERT INTO policies (source, id, reference_id, name, framework, status, data)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			file.path, policy.ID, policy.ReferenceID, policy.Name, policy.Framework, policy.Status, data); err != nil {
+			return err
+		}
+		if err := indexSearch(tx, SearchPolicy, documentRef(policy.ReferenceID, policy.ID), "", policy.Name, policy.Summary, policy.Description, policy.Content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexEvidence records the evidence files of each window, laid out as
+// <task dir>/<window>/[.submitted/|archive/]<file>, and the window's
+// generation and submission state
+func indexEvidence(tx *sql.Tx, dir string, files []catalogFile) error {
+	if err := clearTable(tx, "evidence_windows", SearchEvidence); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM evidence_files`); err != nil {
+		return err
+	}
+
+	windows := make(map[string]*CatalogWindow)
+	var order []string
+	for _, file := range files {
+		parts := strings.SplitN(file.path, "/", 3)
+		if len(parts) < 3 || strings.HasPrefix(parts[1], ".") || parts[1] == "metadata" {
+			continue
+		}
+		taskRef := naming.ExtractTaskRef(parts[0])
+		if taskRef == "" {
+			continue
+		}
+		key := parts[0] + "/" + parts[1]
+		window, ok := windows[key]
+		if !ok {
+			window = &CatalogWindow{TaskRef: taskRef, Window: parts[1], TaskDir: parts[0]}
+			windows[key] = window
+			order = append(order, key)
+		}
+
+		rel := parts[2]
+		switch {
+		case rel == filepath.ToSlash(filepath.Join(".generation", "metadata.yaml")):
+			window.Generated = true
+			continue
+		case rel == submissionMetadataDir+"/"+submissionFilename:
+			window.SubmissionStatus = readSubmissionStatus(filepath.Join(dir, filepath.FromSlash(file.path)))
+			continue
+		}
+
+		name := rel
+		submitted := false
+		if after, ok := strings.CutPrefix(rel, naming.SubfolderSubmitted+"/"); ok {
+			name, submitted = after, true
+		} else {
+			name = strings.TrimPrefix(rel, "archive/")
+		}
+		if strings.Contains(name, "/") || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "collection_plan") {
+			continue // Bookkeeping, tool outputs and plans are not evidence
+		}
+
+		window.FileCount++
+		window.TotalBytes += file.size
+		if submitted {
+			window.SubmittedCount++
+		}
+		if window.NewestFile == nil || file.modTime.After(*window.NewestFile) {
+			modTime := file.modTime
+			window.NewestFile = &modTime
+		}
+		if _, err := tx.Exec(`INSERT OR REPLACE INTO evidence_files (task_ref, window, path, size, mod_time, submitted) VALUES (?, ?, ?, ?, ?, ?)`,
+			taskRef, window.Window, rel, file.size, file.modTime.UTC().Format(time.RFC3339Nano), submitted); err != nil {
+			return err
+		}
+		if text, ok := searchableText(filepath.Join(dir, filepath.FromSlash(file.path)), file.size); ok {
+			if err := indexSearch(tx, SearchEvidence, taskRef, window.Window+"/"+rel, name, text); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, key := range order {
+		window := windows[key]
+		var newest any
+		if window.NewestFile != nil {
+			newest = window.NewestFile.UTC().Format(time.RFC3339Nano)
+		}
+		if _, err := tx.Exec(`INSERT OR REPLACE INTO evidence_windows
+			(task_ref, window, task_dir, file_count, submitted_count, total_bytes, newest_file, generated, submission_status)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			window.TaskRef, window.Window, window.TaskDir, window.FileCount, window.SubmittedCount,
+			window.TotalBytes, newest, window.Generated, window.SubmissionStatus); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readSubmissionStatus(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	var submission models.EvidenceSubmission
+	if yaml.Unmarshal(data, &submission) != nil {
+		return ""
+	}
+	return submission.Status
+}
+
+// EvidenceTasks returns every indexed evidence task
+func (c *Catalog) EvidenceTasks() ([]domain.EvidenceTask, error) {
+	if err := c.refresh(catalogTasks); err != nil {
+		return nil, err
+	}
+	return queryDocuments[domain.EvidenceTask](c, `SELECT data FROM tasks ORDER BY source`)
+}
+
+// EvidenceTask returns the task with the given ID or reference (ET-0001 or
+// ET0001), or nil when none is indexed
+func (c *Catalog) EvidenceTask(id string) (*domain.EvidenceTask, error) {
+	if err := c.refresh(catalogTasks); err != nil {
+		return nil, err
+	}
+	trimmed := strings.TrimSpace(id)
+	if _, err := strconv.Atoi(trimmed); err == nil {
+		return queryDocument[domain.EvidenceTask](c, `SELECT data FROM tasks WHERE id = ? ORDER BY source LIMIT 1`, trimmed)
+	}
+	if match := taskRefPattern.FindStringSubmatch(strings.ToUpper(trimmed)); match != nil {
+		num, _ := strconv.Atoi(match[1])
+		return queryDocument[domain.EvidenceTask](c, `SELECT data FROM tasks WHERE reference_id = ? ORDER BY source LIMIT 1`, fmt.Sprintf("ET-%04d", num))
+	}
+	return nil, nil
+}
+
+// Controls returns every indexed control
+func (c *Catalog) Controls() ([]domain.Control, error) {
+	if err := c.refresh(catalogControls); err != nil {
+		return nil, err
+	}
+	return queryDocuments[domain.Control](c, `SELECT data FROM controls ORDER BY source`)
+}
+
+// Control returns the control with the given ID or reference (CC1.1 or
+// CC1_1), or nil when none is indexed
+func (c *Catalog) Control(id string) (*domain.Control, error) {
+	if err := c.refresh(catalogControls); err != nil {
+		return nil, err
+	}
+	return lookupDocument[domain.Control](c, "controls", id, strings.ReplaceAll(id, "_", "."))
+}
+
+// Policies returns every indexed policy
+func (c *Catalog) Policies() ([]domain.Policy, error) {
+	if err := c.refresh(catalogPolicies); err != nil {
+		return nil, err
+	}
+	return queryDocuments[domain.Policy](c, `SELECT data FROM policies ORDER BY source`)
+}
+
+// Policy returns the policy with the given ID or reference (POL-001 or
+// POL_001), or nil when none is indexed
+func (c *Catalog) Policy(id string) (*domain.Policy, error) {
+	if err := c.refresh(catalogPolicies); err != nil {
+		return nil, err
+	}
+	return lookupDocument[domain.Policy](c, "policies", id, strings.ReplaceAll(id, "_", "-"))
+}
+
+// EvidenceWindows returns the indexed windows of a task, or of every task
+// when taskRef is empty
+func (c *Catalog) EvidenceWindows(taskRef string) ([]CatalogWindow, error) {
+	if err := c.refresh(catalogEvidence); err != nil {
+		return nil, err
+	}
+	query := `SELECT task_ref, window, task_dir, file_count, submitted_count, total_bytes, newest_file, generated, submission_status
+		FROM evidence_windows`
+	var args []any
+	if taskRef != "" {
+		query += ` WHERE task_ref = ?`
+		args = append(args, strings.ToUpper(taskRef))
+	}
+	rows, err := c.db.Query(query+` ORDER BY task_ref, window`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query catalog: %w", err)
+	}
+	defer rows.Close()
+
+	var windows []CatalogWindow
+	for rows.Next() {
+		var window CatalogWindow
+		var newest sql.NullString
+		if err := rows.Scan(&window.TaskRef, &window.Window, &window.TaskDir, &window.FileCount, &window.SubmittedCount,
+			&window.TotalBytes, &newest, &window.Generated, &window.SubmissionStatus); err != nil {
+			return nil, fmt.Errorf("failed to query catalog: %w", err)
+		}
+		if t, err := time.Parse(time.RFC3339Nano, newest.String); err == nil {
+			window.NewestFile = &t
+		}
+		windows = append(windows, window)
+	}
+	return windows, rows.Err()
+}
+
+// Stats refreshes the catalog and counts what it holds
+func (c *Catalog) Stats() (*CatalogStats, error) {
+	if err := c.Refresh(); err != nil {
+		return nil, err
+	}
+	stats := &CatalogStats{Path: c.path}
+	for table, count := range map[string]*int{
+		"tasks":            &stats.Tasks,
+		"controls":         &stats.Controls,
+		"policies":         &stats.Policies,
+		"evidence_windows": &stats.Windows,
+		"evidence_files":   &stats.EvidenceFiles,
+	} {
+		if err := c.db.QueryRow(`SELECT COUNT(*) FROM ` + table).Scan(count); err != nil {
+			return nil, fmt.Errorf("failed to query catalog: %w", err)
+		}
+	}
+	var refreshed string
+	if err := c.db.QueryRow(`SELECT value FROM meta WHERE key = 'refreshed_at'`).Scan(&refreshed); err == nil {
+		stats.RefreshedAt, _ = time.Parse(time.RFC3339Nano, refreshed)
+	}
+	return stats, nil
+}
+
+// lookupDocument finds id as an ID, then as a reference, then as the
+// reference in filename form, as the file-based Get* methods do
+func lookupDocument[T any](c *Catalog, table, id, converted string) (*T, error) {
+	for _, match := range []struct{ column, value string }{{"id", id}, {"reference_id", id}, {"reference_id", converted}} {
+		doc, err := queryDocument[T](c, `SELECT data FROM `+table+` WHERE `+match.column+` = ? ORDER BY source LIMIT 1`, match.value)
+		if err != nil || doc != nil {
+			return doc, err
+		}
+	}
+	return nil, nil
+}
+
+// queryDocument decodes the document a query selects, or returns nil when
+// it selects none
+func queryDocument[T any](c *Catalog, query string, args ...any) (*T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var data []byte
+	err := c.db.QueryRow(query, args...).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query catalog: %w", err)
+	}
+	var doc T
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode catalog entry: %w", err)
+	}
+	return &doc, nil
+}
+
+// queryDocuments decodes every document a query selects
+func queryDocuments[T any](c *Catalog, query string) ([]T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rows, err := c.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query catalog: %w", err)
+	}
+	defer rows.Close()
+
+	var docs []T
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to query catalog: %w", err)
+		}
+		var doc T
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to decode catalog entry: %w", err)
+		}
+		docs = append(docs, doc)
+	}
+	return docs, rows.Err()
+}
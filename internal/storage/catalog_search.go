@@ -0,0 +1,193 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Kinds of documents the search index holds
+const (
+	SearchPolicy   = "policy"
+	SearchControl  = "control"
+	SearchTask     = "task"
+	SearchEvidence = "evidence"
+)
+
+// SearchKinds lists every kind of searchable document
+var SearchKinds = []string{SearchPolicy, SearchControl, SearchTask, SearchEvidence}
+
+// maxSearchableSize bounds the evidence files whose content is indexed
+const maxSearchableSize = 1 << 20
+
+// searchableExtensions are the evidence file types indexed as text
+var searchableExtensions = map[string]bool{
+	".md": true, ".txt": true, ".csv": true, ".json": true, ".yaml": true,
+	".yml": true, ".log": true, ".html": true, ".htm": true, ".xml": true,
+}
+
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// SearchOptions narrows a search
+type SearchOptions struct {
+	Kinds []string // Only these kinds (default: all)
+	Limit int      // Maximum results (default: 20)
+}
+
+// SearchResult is one document matching a search
+type SearchResult struct {
+	Kind     string  `json:"kind" yaml:"kind"`
+	Ref      string  `json:"ref" yaml:"ref"`                               // Policy, control or task reference; the task for evidence
+	Title    string  `json:"title" yaml:"title"`                           // Document name, or the evidence file name
+	Location string  `json:"location,omitempty" yaml:"location,omitempty"` // Evidence file as <window>/<path>
+	Snippet  string  `json:"snippet" yaml:"snippet"`                       // Matched text, terms marked **like this**
+	Score    float64 `json:"score" yaml:"score"`                           // Higher is more relevant
+}
+
+// Search refreshes the catalog and returns the documents matching query,
+// most relevant first. Every word must match; "quoted phrases" must match
+// in order.
+func (c *Catalog) Search(query string, opts SearchOptions) ([]SearchResult, error) {
+	match := searchExpression(query)
+	if match == "" {
+		return nil, fmt.Errorf("search query is empty")
+	}
+	for _, kind := range opts.Kinds {
+		if !isSearchKind(kind) {
+			return nil, fmt.Errorf("unknown search type %q: use %s", kind, strings.Join(SearchKinds, ", "))
+		}
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = 20
+	}
+	if err := c.Refresh(); err != nil {
+		return nil, err
+	}
+
+	// Titles weigh ten times the body in the ranking
+	q := `SELECT kind, ref, location, title, snippet(search, -1, '**', '**', '…', 16), -bm25(search, 0, 0, 0, 10, 1)
+		FROM search WHERE search MATCH ?`
+	args := []any{match}
+	if len(opts.Kinds) > 0 {
+		q += ` AND kind IN (?` + strings.Repeat(`, ?`, len(opts.Kinds)-1) + `)`
+		for _, kind := range opts.Kinds {
+			args = append(args, kind)
+		}
+	}
+	q += ` ORDER BY bm25(search, 0, 0, 0, 10, 1) LIMIT ?`
+	args = append(args, opts.Limit)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rows, err := c.db.Query(q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search catalog: %w", err)
+	}
+	defer rows.Close()
+
+	results := []SearchResult{}
+	for rows.Next() {
+		var result SearchResult
+		if err := rows.Scan(&result.Kind, &result.Ref, &result.Location, &result.Title, &result.Snippet, &result.Score); err != nil {
+			return nil, fmt.Errorf("failed to search catalog: %w", err)
+		}
+		result.Snippet = strings.Join(strings.Fields(result.Snippet), " ")
+		results = append(results, result)
+	}
+	return results, rows.Err()
+}
+
+func isSearchKind(kind string) bool {
+	for _, k := range SearchKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// searchExpression turns a user query into an FTS5 expression matching
+// every word and "quoted phrase", so punctuation and FTS5 operators in the
+// query are taken literally
+func searchExpression(query string) string {
+	var terms []string
+	for i, part := range strings.Split(query, `"`) {
+		if i%2 == 1 {
+			// Inside quotes: one phrase
+			if phrase := strings.Join(strings.Fields(part), " "); phrase != "" {
+				terms = append(terms, `"`+phrase+`"`)
+			}
+			continue
+		}
+		for _, word := range strings.Fields(part) {
+			terms = append(terms, `"`+word+`"`)
+		}
+	}
+	return strings.Join(terms, " ")
+}
+
+// clearTable empties a table and the search entries of its kind
+func clearTable(tx *sql.Tx, table, kind string) error {
+	if _, err := tx.Exec(`DELETE FROM ` + table); err != nil {
+		return err
+	}
+	_, err := tx.Exec(`DELETE FROM search WHERE kind = ?`, kind)
+	return err
+}
+
+// indexSearch adds a document to the search index, HTML reduced to text
+func indexSearch(tx *sql.Tx, kind, ref, location, title string, body ...string) error {
+	var parts []string
+	for _, text := range body {
+		if text = strings.TrimSpace(text); text != "" {
+			parts = append(parts, html.UnescapeString(htmlTagPattern.ReplaceAllString(text, " ")))
+		}
+	}
+	_, err := tx.Exec(`INSERT INTO search (kind, ref, location, title, body) VALUES (?, ?, ?, ?, ?)`,
+		kind, ref, location, title, strings.Join(parts, "\n\n"))
+	return err
+}
+
+// documentRef is how a policy, control or task is referred to
+func documentRef(referenceID, id string) string {
+	if referenceID != "" {
+		return referenceID
+	}
+	return id
+}
+
+// searchableText reads an evidence file to index. Encrypted evidence is
+// never indexed, so the catalog cannot leak its plaintext.
+func searchableText(path string, size int64) (string, bool) {
+	if size > maxSearchableSize || !searchableExtensions[strings.ToLower(filepath.Ext(path))] {
+		return "", false
+	}
+	data, err := os.ReadFile(path)
+	if err == nil && IsReference(data) {
+		data, err = resolveReference(path, data)
+	}
+	if err != nil || int64(len(data)) > maxSearchableSize || IsEncrypted(data) || !utf8.Valid(data) {
+		return "", false
+	}
+	return string(data), true
+}
@@ -0,0 +1,82 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchExpression(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		query string
+		want  string
+	}{
+		"words":       {query: "encryption at rest", want: `"encryption" "at" "rest"`},
+		"phrase":      {query: `"access review" quarterly`, want: `"access review" "quarterly"`},
+		"operators":   {query: "mfa OR sso*", want: `"mfa" "OR" "sso*"`},
+		"blank":       {query: "  ", want: ""},
+		"empty quote": {query: `"" x`, want: `"x"`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, searchExpression(tt.query))
+		})
+	}
+}
+
+func TestCatalog_Search(t *testing.T) {
+	t.Parallel()
+	s := newCatalogStorage(t)
+	catalog := s.Catalog()
+
+	require.NoError(t, s.SavePolicy(&domain.Policy{ID: "1", ReferenceID: "POL-0001", Name: "Encryption Policy",
+		Content: "<p>All customer data is <b>encrypted at rest</b> with AES-256.</p>"}))
+	require.NoError(t, s.SaveControl(&domain.Control{ID: "2", ReferenceID: "CC6.1", Name: "Logical Access",
+		Description: "Access to production requires MFA."}))
+	require.NoError(t, s.SaveEvidenceTask(&domain.EvidenceTask{ID: "3", ReferenceID: "ET-0003", Name: "Access Review",
+		Description: "Quarterly review of user access."}))
+	evidenceDir := s.paths.Evidence
+	writeTestFile(t, evidenceDir, "KMS_Keys_ET-0004_104/2025-Q1/01_kms_keys.md", "# KMS keys\n\nEvery bucket is encrypted at rest with a customer managed key.\n")
+	writeTestFile(t, evidenceDir, "KMS_Keys_ET-0004_104/2025-Q1/02_screenshot.png", "encrypted at rest")
+	writeTestFile(t, evidenceDir, "KMS_Keys_ET-0004_104/2025-Q1/03_secret.md", encryptedMagic+"key\nciphertext encrypted at rest")
+
+	results, err := catalog.Search("encryption at rest", SearchOptions{})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	// The policy's name matches too, so it ranks first
+	assert.Equal(t, SearchPolicy, results[0].Kind)
+	assert.Equal(t, "POL-0001", results[0].Ref)
+	assert.Contains(t, results[0].Snippet, "**encrypted** **at** **rest**")
+	assert.NotContains(t, results[0].Snippet, "<b>")
+	assert.Equal(t, SearchEvidence, results[1].Kind)
+	assert.Equal(t, "ET-0004", results[1].Ref)
+	assert.Equal(t, "2025-Q1/01_kms_keys.md", results[1].Location)
+
+	phrase, err := catalog.Search(`"production requires"`, SearchOptions{})
+	require.NoError(t, err)
+	require.Len(t, phrase, 1)
+	assert.Equal(t, "CC6.1", phrase[0].Ref)
+
+	access, err := catalog.Search("access", SearchOptions{Kinds: []string{SearchTask}})
+	require.NoError(t, err)
+	require.Len(t, access, 1)
+	assert.Equal(t, "ET-0003", access[0].Ref)
+
+	none, err := catalog.Search("kubernetes", SearchOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, none)
+
+	_, err = catalog.Search(" ", SearchOptions{})
+	assert.EqualError(t, err, "search query is empty")
+	_, err = catalog.Search("mfa", SearchOptions{Kinds: []string{"memo"}})
+	assert.EqualError(t, err, `unknown search type "memo": use policy, control, task, evidence`)
+}
@@ -0,0 +1,156 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCatalogStorage(t *testing.T) *Storage {
+	t.Helper()
+	s, err := NewStorage(config.StorageConfig{DataDir: t.TempDir(), Catalog: config.CatalogConfig{Enabled: true}})
+	require.NoError(t, err)
+	require.NotNil(t, s.Catalog())
+	t.Cleanup(func() { s.Catalog().Close() })
+	return s
+}
+
+func TestCatalog_Documents(t *testing.T) {
+	t.Parallel()
+	s := newCatalogStorage(t)
+	catalog := s.Catalog()
+
+	task := testhelpers.SampleEvidenceTask()
+	require.NoError(t, s.SaveEvidenceTask(task))
+	require.NoError(t, s.SaveControl(testhelpers.SampleControl()))
+	require.NoError(t, s.SavePolicy(testhelpers.SamplePolicy()))
+
+	tasks, err := catalog.EvidenceTasks()
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, task.Name, tasks[0].Name)
+
+	for _, id := range []string{task.ID, "ET-0047", "et0047", "ET-47"} {
+		found, err := catalog.EvidenceTask(id)
+		require.NoError(t, err)
+		require.NotNil(t, found, id)
+		assert.Equal(t, task.ID, found.ID)
+	}
+	missing, err := catalog.EvidenceTask("ET-9999")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	control, err := catalog.Control(testhelpers.SampleControl().ReferenceID)
+	require.NoError(t, err)
+	require.NotNil(t, control)
+	policy, err := catalog.Policy("POL-0001")
+	require.NoError(t, err)
+	require.NotNil(t, policy)
+
+	// Changed files are re-indexed on the next read
+	task.Status = "completed_by_auditor"
+	require.NoError(t, s.SaveEvidenceTask(task))
+	tasks, err = catalog.EvidenceTasks()
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "completed_by_auditor", tasks[0].Status)
+
+	stats, err := catalog.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Tasks)
+	assert.Equal(t, 1, stats.Controls)
+	assert.Equal(t, 1, stats.Policies)
+	assert.False(t, stats.RefreshedAt.IsZero())
+}
+
+func TestCatalog_EvidenceWindows(t *testing.T) {
+	t.Parallel()
+	s := newCatalogStorage(t)
+	evidenceDir := s.paths.Evidence
+
+	writeTestFile(t, evidenceDir, "Access_Review_ET-0001_101/2025-Q1/.submitted/01_users.csv", "user,mfa\n")
+	writeTestFile(t, evidenceDir, "Access_Review_ET-0001_101/2025-Q1/.submission/submission.yaml", "status: accepted\n")
+	writeTestFile(t, evidenceDir, "Access_Review_ET-0001_101/2025-Q2/01_users.csv", "user,mfa\nalice,true\n")
+	writeTestFile(t, evidenceDir, "Access_Review_ET-0001_101/2025-Q2/collection_plan.md", "# Plan")
+	writeTestFile(t, evidenceDir, "Access_Review_ET-0001_101/2025-Q2/.generation/metadata.yaml", "task_ref: ET-0001\n")
+	writeTestFile(t, evidenceDir, "Access_Review_ET-0001_101/2025-Q2/.context/tool_outputs/github.json", "{}")
+	writeTestFile(t, evidenceDir, "Change_Log_ET-0002_102/2025-Q1/01_changes.md", "# Changes")
+
+	windows, err := s.Catalog().EvidenceWindows("et-0001")
+	require.NoError(t, err)
+	require.Len(t, windows, 2)
+
+	assert.Equal(t, "2025-Q1", windows[0].Window)
+	assert.Equal(t, 1, windows[0].FileCount)
+	assert.Equal(t, 1, windows[0].SubmittedCount)
+	assert.Equal(t, "accepted", windows[0].SubmissionStatus)
+	assert.False(t, windows[0].Generated)
+
+	assert.Equal(t, "2025-Q2", windows[1].Window)
+	assert.Equal(t, 1, windows[1].FileCount)
+	assert.Equal(t, int64(len("user,mfa\nalice,true\n")), windows[1].TotalBytes)
+	assert.True(t, windows[1].Generated)
+	assert.Empty(t, windows[1].SubmissionStatus)
+	assert.NotNil(t, windows[1].NewestFile)
+
+	all, err := s.Catalog().EvidenceWindows("")
+	require.NoError(t, err)
+	assert.Len(t, all, 3)
+
+	// New evidence is picked up without a rebuild
+	writeTestFile(t, evidenceDir, "Change_Log_ET-0002_102/2025-Q1/02_approvals.md", "# Approvals")
+	windows, err = s.Catalog().EvidenceWindows("ET-0002")
+	require.NoError(t, err)
+	require.Len(t, windows, 1)
+	assert.Equal(t, 2, windows[0].FileCount)
+}
+
+func TestCatalog_Rebuild(t *testing.T) {
+	t.Parallel()
+	s := newCatalogStorage(t)
+	require.NoError(t, s.SaveEvidenceTask(testhelpers.SampleEvidenceTask()))
+
+	require.NoError(t, s.Catalog().Rebuild())
+	stats, err := s.Catalog().Stats()
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Tasks)
+	assert.FileExists(t, s.Catalog().Path())
+
+	// A deleted catalog is recreated from the files
+	require.NoError(t, s.Catalog().Close())
+	require.NoError(t, os.RemoveAll(filepath.Dir(s.Catalog().Path())))
+	catalog, err := OpenCatalog(config.StorageConfig{DataDir: s.GetBaseDir()})
+	require.NoError(t, err)
+	defer catalog.Close()
+	tasks, err := catalog.EvidenceTasks()
+	require.NoError(t, err)
+	assert.Len(t, tasks, 1)
+}
+
+func TestStorage_WithCatalog(t *testing.T) {
+	t.Parallel()
+	s := newCatalogStorage(t)
+	task := testhelpers.SampleEvidenceTask()
+	require.NoError(t, s.SaveEvidenceTask(task))
+
+	found, err := s.GetEvidenceTask("ET-0047")
+	require.NoError(t, err)
+	assert.Equal(t, task.ID, found.ID)
+
+	_, err = s.GetEvidenceTask("ET-9999")
+	assert.Error(t, err)
+
+	// Reads fall back to the files when the catalog is unusable
+	require.NoError(t, s.Catalog().Close())
+	tasks, err := s.GetAllEvidenceTasks()
+	require.NoError(t, err)
+	assert.Len(t, tasks, 1)
+}
@@ -0,0 +1,349 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/naming"
+)
+
+const (
+	// custodyFilename is the chain-of-custody log of a window, kept in its
+	// .submission directory so it stays at the window root as files move
+	custodyFilename = "custody.jsonl"
+
+	// custodyAnchorTrailer is the commit trailer anchoring a custody log's
+	// head hash in the data_dir git history: "Custody-Head: <window> <hash>"
+	custodyAnchorTrailer = "Custody-Head"
+)
+
+var (
+	custodyActorOnce sync.Once
+	custodyActor     string
+)
+
+// CustodyActor returns who is recorded as acting in custody events: git's
+// user.email, else the login name, else "unknown"
+func CustodyActor() string {
+	custodyActorOnce.Do(func() {
+		if out, err := exec.Command("git", "config", "user.email").Output(); err == nil {
+			custodyActor = strings.TrimSpace(string(out))
+		}
+		if custodyActor == "" {
+			if current, err := user.Current(); err == nil {
+				custodyActor = current.Username
+			}
+		}
+		if custodyActor == "" {
+			custodyActor = "unknown"
+		}
+	})
+	return custodyActor
+}
+
+// CustodyLogPath returns the chain-of-custody log of a window
+func CustodyLogPath(windowDir string) string {
+	return filepath.Join(windowDir, submissionMetadataDir, custodyFilename)
+}
+
+// AppendCustodyEvents appends events to a window's chain-of-custody log, one
+// JSON line each, chaining each to the entry before it by hash; earlier
+// entries are never rewritten. When an event records a checksum that differs
+// from the last one logged for its file, an edited event is logged first,
+// dated when the file was last modified and with no actor, since the change
+// was made outside grctool.
+func AppendCustodyEvents(windowDir string, events ...models.CustodyEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	logged, err := ReadCustodyLog(windowDir)
+	if err != nil {
+		return err
+	}
+	head := ""
+	checksums := map[string]string{}
+	for _, event := range logged {
+		head = event.Hash
+		if event.SHA256 != "" {
+			checksums[event.File] = event.SHA256
+		}
+	}
+
+	var buf strings.Builder
+	encoder := json.NewEncoder(&buf)
+	write := func(event models.CustodyEvent) error {
+		event.PrevHash = head
+		event.Hash = event.ChainHash()
+		head = event.Hash
+		return encoder.Encode(event)
+	}
+	for _, event := range events {
+		event.SHA256 = strings.TrimPrefix(event.SHA256, "sha256:")
+		if event.Timestamp.IsZero() {
+			event.Timestamp = time.Now()
+		}
+		previous, known := checksums[event.File]
+		if event.SHA256 != "" && known && previous != event.SHA256 && event.Event != models.CustodyGenerated {
+			edited := models.CustodyEvent{
+				Timestamp: custodyModTime(windowDir, event.File, event.Timestamp),
+				File:      event.File,
+				Event:     models.CustodyEdited,
+				SHA256:    event.SHA256,
+				Details:   "content changed from sha256 " + shortChecksum(previous),
+			}
+			if err := write(edited); err != nil {
+				return err
+			}
+		}
+		if event.SHA256 != "" {
+			checksums[event.File] = event.SHA256
+		}
+		if err := write(event); err != nil {
+			return err
+		}
+	}
+
+	path := CustodyLogPath(windowDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create submission directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open custody log: %w", err)
+	}
+	if _, err := file.WriteString(buf.String()); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write custody log: %w", err)
+	}
+	return file.Close()
+}
+
+// ReadCustodyLog reads a window's chain-of-custody log in the order it was
+// written. A window with no log has no events.
+func ReadCustodyLog(windowDir string) ([]models.CustodyEvent, error) {
+	file, err := os.Open(CustodyLogPath(windowDir))
+	if os.IsNotExist(err) {
+		return []models.CustodyEvent{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read custody log: %w", err)
+	}
+	defer file.Close()
+
+	events := []models.CustodyEvent{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var event models.CustodyEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("custody log line %d is not a custody event: %w", line, err)
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read custody log: %w", err)
+	}
+	return events, nil
+}
+
+// CustodyChain is the outcome of verifying a custody log's hash chain
+type CustodyChain struct {
+	Entries int    `json:"entries"`
+	Head    string `json:"head,omitempty"`   // Hash of the last entry
+	Anchor  string `json:"anchor,omitempty"` // Anchored head found in the chain, if one was checked
+}
+
+// CustodyChainError reports the first entry of a custody log that breaks its
+// hash chain, counting entries from 1
+type CustodyChainError struct {
+	Entry  int
+	Reason string
+}
+
+func (e *CustodyChainError) Error() string {
+	return fmt.Sprintf("custody log entry %d %s", e.Entry, e.Reason)
+}
+
+// VerifyCustodyLog checks a window's custody log is an unbroken hash chain:
+// every entry's hash matches its content and names the entry before it. With
+// an anchor, a head hash recorded elsewhere, the chain must also still
+// contain it, which detects entries removed from the end.
+func VerifyCustodyLog(windowDir, anchor string) (*CustodyChain, error) {
+	events, err := ReadCustodyLog(windowDir)
+	if err != nil {
+		return nil, err
+	}
+
+	chain := &CustodyChain{Entries: len(events)}
+	head := ""
+	anchored := false
+	for i, event := range events {
+		switch {
+		case event.Hash == "":
+			return nil, &CustodyChainError{Entry: i + 1, Reason: "has no hash: it was added by hand or its hash was removed"}
+		case event.Hash != event.ChainHash():
+			return nil, &CustodyChainError{Entry: i + 1, Reason: "has been altered: its hash does not match its content"}
+		case event.PrevHash != head:
+			return nil, &CustodyChainError{Entry: i + 1, Reason: "does not follow the entry before it: entries were removed, reordered or inserted"}
+		}
+		head = event.Hash
+		if anchor != "" && event.Hash == anchor {
+			anchored = true
+		}
+	}
+	chain.Head = head
+	if anchor != "" {
+		if !anchored {
+			return nil, fmt.Errorf("custody log no longer contains its anchored head %s: entries were removed from its end", shortChecksum(anchor))
+		}
+		chain.Anchor = anchor
+	}
+	return chain, nil
+}
+
+// AnchorCustodyLog commits data_dir with the head hash of a window's custody
+// log in a Custody-Head trailer, so the log can later be checked against a
+// record kept outside it. It returns the head and the commit's short hash.
+func (h *GitHistory) AnchorCustodyLog(ctx context.Context, windowDir string) (string, string, error) {
+	chain, err := VerifyCustodyLog(windowDir, "")
+	if err != nil {
+		return "", "", err
+	}
+	if chain.Head == "" {
+		return "", "", fmt.Errorf("custody log of %s has no entries to anchor", windowDir)
+	}
+	window, err := h.relative(windowDir)
+	if err != nil {
+		return "", "", err
+	}
+	if err := h.ensureRepository(ctx); err != nil {
+		return "", "", err
+	}
+	pathspecs := h.pathspecs(ctx)
+	if _, err := h.git(ctx, nil, append([]string{"add", "--all", "--"}, pathspecs...)...); err != nil {
+		return "", "", err
+	}
+	subject := fmt.Sprintf("Anchor custody log of %s", window)
+	trailer := fmt.Sprintf("%s: %s %s", custodyAnchorTrailer, window, chain.Head)
+	args := []string{"commit", "--quiet", "--no-verify", "--allow-empty", "-m", subject, "-m", trailer, "--"}
+	if _, err := h.git(ctx, h.identity(ctx), append(args, pathspecs...)...); err != nil {
+		return "", "", err
+	}
+	commit, err := h.git(ctx, nil, "rev-parse", "--short", "HEAD")
+	return chain.Head, commit, err
+}
+
+// CustodyAnchor returns the head hash last anchored for a window's custody
+// log, or "" when it was never anchored or data_dir is not a repository
+func (h *GitHistory) CustodyAnchor(ctx context.Context, windowDir string) (string, error) {
+	if _, err := h.git(ctx, nil, "rev-parse", "--verify", "--quiet", "HEAD"); err != nil {
+		return "", nil
+	}
+	window, err := h.relative(windowDir)
+	if err != nil {
+		return "", err
+	}
+	prefix := fmt.Sprintf("%s: %s ", custodyAnchorTrailer, window)
+	body, err := h.git(ctx, nil, "log", "-n", "1", "--format=%B", "--fixed-strings", "--grep", prefix)
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(body, "\n") {
+		if head, ok := strings.CutPrefix(line, prefix); ok {
+			return strings.TrimSpace(head), nil
+		}
+	}
+	return "", nil
+}
+
+// relative returns a path under data_dir relative to it, slash-separated
+func (h *GitHistory) relative(path string) (string, error) {
+	base, err := filepath.Abs(h.dir)
+	if err != nil {
+		return "", err
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(base, abs)
+	if err != nil || !filepath.IsLocal(rel) {
+		return "", fmt.Errorf("%s is not in the data directory", path)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// RecordCustody appends events to the chain-of-custody log of a task window
+func (us *Storage) RecordCustody(taskRef, window string, events ...models.CustodyEvent) error {
+	return AppendCustodyEvents(us.getEvidenceWindowDir(taskRef, window), events...)
+}
+
+// LoadCustodyLog reads the chain-of-custody log of a task window
+func (us *Storage) LoadCustodyLog(taskRef, window string) ([]models.CustodyEvent, error) {
+	return ReadCustodyLog(us.getEvidenceWindowDir(taskRef, window))
+}
+
+// custodyEventsFor returns an event for each evidence file, acted on by the
+// current user
+func custodyEventsFor(files []models.EvidenceFileRef, event, details string) []models.CustodyEvent {
+	now := time.Now()
+	events := make([]models.CustodyEvent, 0, len(files))
+	for _, file := range files {
+		events = append(events, models.CustodyEvent{
+			Timestamp: now,
+			File:      file.Filename,
+			Event:     event,
+			Actor:     CustodyActor(),
+			SHA256:    file.ChecksumSHA256,
+			Details:   details,
+		})
+	}
+	return events
+}
+
+// custodyModTime returns when a file in a window was last modified, wherever
+// it is, or fallback when it cannot be found
+func custodyModTime(windowDir, filename string, fallback time.Time) time.Time {
+	for _, dir := range []string{windowDir, filepath.Join(windowDir, naming.SubfolderSubmitted)} {
+		if info, err := os.Stat(filepath.Join(dir, filename)); err == nil {
+			return info.ModTime()
+		}
+	}
+	return fallback
+}
+
+// shortChecksum abbreviates a checksum for display
+func shortChecksum(checksum string) string {
+	if len(checksum) > 12 {
+		return checksum[:12]
+	}
+	return checksum
+}
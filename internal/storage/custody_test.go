@@ -0,0 +1,239 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendCustodyEvents(t *testing.T) {
+	t.Parallel()
+
+	windowDir := t.TempDir()
+	generatedAt := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+	editedAt := time.Date(2025, 10, 21, 9, 0, 0, 0, time.UTC)
+	require.NoError(t, os.WriteFile(filepath.Join(windowDir, "01_users.csv"), []byte("user\n"), 0644))
+	require.NoError(t, os.Chtimes(filepath.Join(windowDir, "01_users.csv"), editedAt, editedAt))
+
+	events, err := ReadCustodyLog(windowDir)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	require.NoError(t, AppendCustodyEvents(windowDir, models.CustodyEvent{
+		Timestamp: generatedAt, File: "01_users.csv", Event: models.CustodyGenerated, Actor: "alex@example.com", SHA256: "sha256:aaaa",
+	}))
+	require.NoError(t, AppendCustodyEvents(windowDir,
+		models.CustodyEvent{File: "01_users.csv", Event: models.CustodyValidated, Actor: "alex@example.com", SHA256: "aaaa"},
+		models.CustodyEvent{File: "01_users.csv", Event: models.CustodyApproved, Actor: "sam@example.com", SHA256: "bbbb"},
+		models.CustodyEvent{File: "01_users.csv", Event: models.CustodyMoved, Actor: "alex@example.com", Details: "to .submitted/"},
+	))
+
+	events, err = ReadCustodyLog(windowDir)
+	require.NoError(t, err)
+	require.Len(t, events, 5)
+	assert.Equal(t, []string{"generated", "validated", "edited", "approved", "moved"},
+		[]string{events[0].Event, events[1].Event, events[2].Event, events[3].Event, events[4].Event})
+	assert.Equal(t, "aaaa", events[0].SHA256)
+	assert.Equal(t, generatedAt, events[0].Timestamp.UTC())
+	assert.False(t, events[1].Timestamp.IsZero())
+
+	edited := events[2]
+	assert.Equal(t, editedAt, edited.Timestamp.UTC())
+	assert.Empty(t, edited.Actor)
+	assert.Equal(t, "bbbb", edited.SHA256)
+	assert.Equal(t, "content changed from sha256 aaaa", edited.Details)
+}
+
+func TestReadCustodyLog_Invalid(t *testing.T) {
+	t.Parallel()
+
+	windowDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(windowDir, ".submission"), 0755))
+	require.NoError(t, os.WriteFile(CustodyLogPath(windowDir), []byte("{\"event\":\"generated\"}\nnot json\n"), 0644))
+
+	_, err := ReadCustodyLog(windowDir)
+	assert.ErrorContains(t, err, "custody log line 2 is not a custody event")
+}
+
+func TestStorage_CustodyLifecycle(t *testing.T) {
+	tmpDir := t.TempDir()
+	storage, err := NewStorage(config.StorageConfig{DataDir: tmpDir, Paths: config.StoragePaths{}.WithDefaults()})
+	require.NoError(t, err)
+
+	windowDir := filepath.Join(tmpDir, "evidence", "ET-0001", "2025-Q4")
+	require.NoError(t, os.MkdirAll(windowDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(windowDir, "01_users.csv"), []byte("user\n"), 0644))
+	files := []models.EvidenceFileRef{{Filename: "01_users.csv", ChecksumSHA256: "aaaa"}}
+
+	require.NoError(t, storage.SaveValidationResult("ET-0001", "2025-Q4", &models.ValidationResult{Status: "passed", EvidenceFiles: files}))
+	require.NoError(t, storage.SaveApproval(&models.EvidenceApproval{
+		TaskRef: "ET-0001", Window: "2025-Q4", Approver: "sam@example.com", ApprovedAt: time.Now(), Comment: "Checked",
+		Files: []models.ApprovedFile{{Filename: "01_users.csv", SHA256: "aaaa"}},
+	}))
+	require.NoError(t, storage.MoveEvidenceFilesToSubmitted("ET-0001", "2025-Q4", files))
+	require.NoError(t, storage.MoveEvidenceFilesFromSubmitted("ET-0001", "2025-Q4"))
+
+	events, err := storage.LoadCustodyLog("ET-0001", "2025-Q4")
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+	assert.Equal(t, models.CustodyValidated, events[0].Event)
+	assert.Equal(t, "validation passed", events[0].Details)
+	assert.Equal(t, models.CustodyApproved, events[1].Event)
+	assert.Equal(t, "sam@example.com", events[1].Actor)
+	assert.Equal(t, "Checked", events[1].Details)
+	assert.Equal(t, models.CustodyMoved, events[2].Event)
+	assert.Equal(t, "to .submitted/", events[2].Details)
+	assert.Equal(t, models.CustodyMoved, events[3].Event)
+	assert.Equal(t, "from .submitted/ back to the window root", events[3].Details)
+	for _, event := range events {
+		assert.Equal(t, "01_users.csv", event.File)
+		assert.NotEmpty(t, event.Actor)
+	}
+}
+
+func TestVerifyCustodyLog(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+	chained := func(t *testing.T, windowDir string) []string {
+		require.NoError(t, AppendCustodyEvents(windowDir,
+			models.CustodyEvent{Timestamp: at, File: "01_users.csv", Event: models.CustodyValidated, Actor: "alex@example.com"},
+			models.CustodyEvent{Timestamp: at, File: "01_users.csv", Event: models.CustodyApproved, Actor: "sam@example.com"},
+			models.CustodyEvent{Timestamp: at, File: "01_users.csv", Event: models.CustodySubmitted, Actor: "alex@example.com"},
+		))
+		data, err := os.ReadFile(CustodyLogPath(windowDir))
+		require.NoError(t, err)
+		return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	}
+
+	tests := map[string]struct {
+		tamper  func(lines []string) []string
+		anchor  func(lines []string) string
+		entries int
+		err     string
+	}{
+		"intact": {entries: 3},
+		"anchored head": {
+			anchor:  func(lines []string) string { return custodyLine(t, lines[2]).Hash },
+			entries: 3,
+		},
+		"altered entry": {
+			tamper: func(lines []string) []string {
+				lines[1] = strings.Replace(lines[1], "sam@example.com", "eve@example.com", 1)
+				return lines
+			},
+			err: "custody log entry 2 has been altered: its hash does not match its content",
+		},
+		"removed entry": {
+			tamper: func(lines []string) []string { return append(lines[:1], lines[2]) },
+			err:    "custody log entry 2 does not follow the entry before it: entries were removed, reordered or inserted",
+		},
+		"reordered entries": {
+			tamper: func(lines []string) []string { return []string{lines[1], lines[0], lines[2]} },
+			err:    "custody log entry 1 does not follow the entry before it: entries were removed, reordered or inserted",
+		},
+		"entry added by hand": {
+			tamper: func(lines []string) []string {
+				return append(lines, `{"timestamp":"2025-10-21T00:00:00Z","file":"01_users.csv","event":"approved","actor":"eve@example.com"}`)
+			},
+			err: "custody log entry 4 has no hash: it was added by hand or its hash was removed",
+		},
+		"hashes stripped": {
+			tamper: func(lines []string) []string {
+				for i, line := range lines {
+					event := custodyLine(t, line)
+					event.PrevHash, event.Hash = "", ""
+					data, err := json.Marshal(event)
+					require.NoError(t, err)
+					lines[i] = string(data)
+				}
+				return lines
+			},
+			err: "custody log entry 1 has no hash: it was added by hand or its hash was removed",
+		},
+		"truncated after anchoring": {
+			tamper: func(lines []string) []string { return lines[:2] },
+			anchor: func(lines []string) string { return custodyLine(t, lines[2]).Hash },
+			err:    "custody log no longer contains its anchored head",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			windowDir := t.TempDir()
+			lines := chained(t, windowDir)
+			anchor := ""
+			if tc.anchor != nil {
+				anchor = tc.anchor(lines)
+			}
+			if tc.tamper != nil {
+				lines = tc.tamper(append([]string{}, lines...))
+				require.NoError(t, os.WriteFile(CustodyLogPath(windowDir), []byte(strings.Join(lines, "\n")+"\n"), 0644))
+			}
+
+			chain, err := VerifyCustodyLog(windowDir, anchor)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.entries, chain.Entries)
+			assert.Equal(t, custodyLine(t, lines[len(lines)-1]).Hash, chain.Head)
+			assert.Equal(t, anchor, chain.Anchor)
+		})
+	}
+}
+
+func TestGitHistory_AnchorCustodyLog(t *testing.T) {
+	t.Parallel()
+	requireGit(t)
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "data")
+	history := NewGitHistory(gitStorageConfig(dir))
+	windowDir := filepath.Join(dir, "evidence", "ET-0001", "2025-Q4")
+
+	anchor, err := history.CustodyAnchor(ctx, windowDir)
+	require.NoError(t, err)
+	assert.Empty(t, anchor)
+	_, _, err = history.AnchorCustodyLog(ctx, windowDir)
+	assert.ErrorContains(t, err, "has no entries to anchor")
+
+	require.NoError(t, AppendCustodyEvents(windowDir, models.CustodyEvent{File: "01_users.csv", Event: models.CustodyGenerated, Actor: "alex@example.com"}))
+	head, commit, err := history.AnchorCustodyLog(ctx, windowDir)
+	require.NoError(t, err)
+	assert.NotEmpty(t, commit)
+	assert.Contains(t, runGit(t, dir, "log", "-1", "--format=%B"), "Custody-Head: evidence/ET-0001/2025-Q4 "+head)
+	assert.Contains(t, runGit(t, dir, "show", "--name-only", "--format=", "HEAD"), "evidence/ET-0001/2025-Q4/.submission/custody.jsonl")
+
+	// Anchoring again with nothing changed still records the head
+	_, _, err = history.AnchorCustodyLog(ctx, windowDir)
+	require.NoError(t, err)
+	anchor, err = history.CustodyAnchor(ctx, windowDir)
+	require.NoError(t, err)
+	assert.Equal(t, head, anchor)
+
+	other, err := history.CustodyAnchor(ctx, filepath.Join(dir, "evidence", "ET-0002", "2025-Q4"))
+	require.NoError(t, err)
+	assert.Empty(t, other)
+}
+
+// custodyLine decodes a line of a custody log
+func custodyLine(t *testing.T, line string) models.CustodyEvent {
+	t.Helper()
+	var event models.CustodyEvent
+	require.NoError(t, json.Unmarshal([]byte(line), &event))
+	return event
+}
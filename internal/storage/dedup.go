@@ -0,0 +1,478 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/naming"
+)
+
+// ObjectsDir holds one content-addressed copy of each deduplicated evidence
+// file, at <data_dir>/.objects/sha256/<first two hex digits>/<the rest>
+const ObjectsDir = ".objects"
+
+// referenceMagic begins a reference record, which stands in for an evidence
+// file stored in ObjectsDir. The rest of its line is "sha256:<hex> <size>".
+const referenceMagic = "GRCTOOL-EVIDENCE-REF-V1 "
+
+// maxReferenceSize bounds a reference record, so only files this small are
+// read to tell whether they are one
+const maxReferenceSize = 256
+
+// EvidenceReference points to an evidence file's content in ObjectsDir
+type EvidenceReference struct {
+	SHA256 string
+	Size   int64
+}
+
+// IsReference reports whether data is a reference record
+func IsReference(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(referenceMagic))
+}
+
+// ParseReference reads a reference record
+func ParseReference(data []byte) (EvidenceReference, error) {
+	if !IsReference(data) {
+		return EvidenceReference{}, errors.New("not an evidence reference")
+	}
+	fields := strings.Fields(string(data[len(referenceMagic):]))
+	if len(fields) != 2 || !strings.HasPrefix(fields[0], "sha256:") {
+		return EvidenceReference{}, errors.New("malformed evidence reference")
+	}
+	sum := strings.TrimPrefix(fields[0], "sha256:")
+	size, err := strconv.ParseInt(fields[1], 10, 64)
+	if _, hexErr := hex.DecodeString(sum); hexErr != nil || len(sum) != sha256.Size*2 || err != nil || size < 0 {
+		return EvidenceReference{}, errors.New("malformed evidence reference")
+	}
+	return EvidenceReference{SHA256: sum, Size: size}, nil
+}
+
+// Bytes returns the reference record
+func (r EvidenceReference) Bytes() []byte {
+	return []byte(fmt.Sprintf("%ssha256:%s %d\n", referenceMagic, r.SHA256, r.Size))
+}
+
+// objectPath returns where the object of a SHA-256 lies under dataDir
+func objectPath(dataDir, sum string) string {
+	return filepath.Join(dataDir, ObjectsDir, "sha256", sum[:2], sum[2:])
+}
+
+// resolveReference returns the content a reference record at path points
+// to, found in the ObjectsDir of the nearest directory above path that has
+// the object
+func resolveReference(path string, data []byte) ([]byte, error) {
+	ref, err := ParseReference(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	dir, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+	for {
+		content, err := os.ReadFile(objectPath(dir, ref.SHA256))
+		if err == nil {
+			if sum := sha256.Sum256(content); hex.EncodeToString(sum[:]) != ref.SHA256 {
+				return nil, fmt.Errorf("stored copy of %s is corrupt", filepath.Base(path))
+			}
+			return content, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, fmt.Errorf("%s refers to sha256:%s, which is not in %s", filepath.Base(path), ref.SHA256[:12], ObjectsDir)
+		}
+		dir = parent
+	}
+}
+
+// referencedSize returns the size of the content a file refers to, or the
+// file's own size when it is not a reference
+func referencedSize(path string, info fs.FileInfo) int64 {
+	if info.Size() > maxReferenceSize {
+		return info.Size()
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || !IsReference(data) {
+		return info.Size()
+	}
+	ref, err := ParseReference(data)
+	if err != nil {
+		return info.Size()
+	}
+	return ref.Size
+}
+
+// storeObject keeps content in ObjectsDir, unless it is there already
+func (us *Storage) storeObject(content []byte) (EvidenceReference, error) {
+	sum := sha256.Sum256(content)
+	ref := EvidenceReference{SHA256: hex.EncodeToString(sum[:]), Size: int64(len(content))}
+	path := objectPath(us.localDataStore.GetBaseDir(), ref.SHA256)
+	if _, err := os.Stat(path); err == nil {
+		return ref, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return ref, fmt.Errorf("failed to create object directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".object.*.tmp")
+	if err != nil {
+		return ref, err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return ref, err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return ref, err
+	}
+	if err := tmp.Close(); err != nil {
+		return ref, err
+	}
+	return ref, os.Rename(tmp.Name(), path)
+}
+
+// DedupFile is one evidence file replaced by a reference
+type DedupFile struct {
+	Path   string `json:"path" yaml:"path"` // Relative to data_dir
+	SHA256 string `json:"sha256" yaml:"sha256"`
+	Bytes  int64  `json:"bytes" yaml:"bytes"`
+}
+
+// DedupReport lists what a deduplication replaced, or would in a dry run
+type DedupReport struct {
+	DryRun     bool        `json:"dry_run" yaml:"dry_run"`
+	Files      []DedupFile `json:"files" yaml:"files"`
+	Objects    int         `json:"objects" yaml:"objects"`                     // Contents newly stored in ObjectsDir
+	Skipped    []string    `json:"skipped,omitempty" yaml:"skipped,omitempty"` // Windows locked by another run
+	SavedBytes int64       `json:"saved_bytes" yaml:"saved_bytes"`
+}
+
+// evidenceCopy is a working evidence file, read during deduplication
+type evidenceCopy struct {
+	path, taskRef, window string
+	size                  int64
+}
+
+// DedupEvidence replaces evidence files whose content appears more than
+// once across the working files of every window, or is already stored, with
+// references to a single stored copy. Files smaller than minSize, encrypted
+// files and submitted or archived evidence are left as they are.
+func (us *Storage) DedupEvidence(minSize int64, dryRun bool) (*DedupReport, error) {
+	dataDir := us.localDataStore.GetBaseDir()
+	report := &DedupReport{DryRun: dryRun, Files: []DedupFile{}}
+
+	copies := map[string][]evidenceCopy{}
+	referenced := map[string]bool{}
+	err := us.forEachWorkingFile(func(c evidenceCopy) error {
+		data, err := os.ReadFile(c.path)
+		if err != nil {
+			return err
+		}
+		switch {
+		case IsReference(data):
+			if ref, err := ParseReference(data); err == nil {
+				referenced[ref.SHA256] = true
+			}
+		case IsEncrypted(data) || c.size < minSize:
+		default:
+			sum := sha256.Sum256(data)
+			key := hex.EncodeToString(sum[:])
+			copies[key] = append(copies[key], c)
+		}
+		return nil
+	})
+	if err != nil {
+		return report, err
+	}
+
+	sums := make([]string, 0, len(copies))
+	for sum, group := range copies {
+		if len(group) > 1 || referenced[sum] {
+			sums = append(sums, sum)
+		}
+	}
+	sort.Strings(sums)
+
+	locks := map[string]*WindowLock{}
+	defer func() {
+		for _, lock := range locks {
+			lock.Unlock()
+		}
+	}()
+	for _, sum := range sums {
+		_, statErr := os.Stat(objectPath(dataDir, sum))
+		stored := statErr == nil
+		for _, c := range copies[sum] {
+			key := c.taskRef + "/" + c.window
+			lock, ok := locks[key]
+			if !ok && !dryRun {
+				var err error
+				lock, err = us.LockWindow(c.taskRef, c.window)
+				var locked *LockedError
+				if errors.As(err, &locked) {
+					report.Skipped = append(report.Skipped, us.relPath(filepath.Dir(c.path)))
+				} else if err != nil {
+					return report, err
+				}
+				locks[key] = lock
+			}
+			if lock == nil && !dryRun {
+				continue
+			}
+
+			ref := EvidenceReference{SHA256: sum, Size: c.size}
+			if !dryRun {
+				// The file may have changed since it was read
+				data, err := os.ReadFile(c.path)
+				if err != nil {
+					return report, err
+				}
+				if current := sha256.Sum256(data); hex.EncodeToString(current[:]) != sum {
+					continue
+				}
+				if ref, err = us.storeObject(data); err != nil {
+					return report, fmt.Errorf("failed to store %s: %w", us.relPath(c.path), err)
+				}
+				if err := writeFileAtomic(c.path, ref.Bytes()); err != nil {
+					return report, err
+				}
+			}
+			if !stored {
+				stored = true
+				report.Objects++
+				report.SavedBytes -= c.size
+			}
+			report.SavedBytes += c.size - int64(len(ref.Bytes()))
+			report.Files = append(report.Files, DedupFile{Path: us.relPath(c.path), SHA256: sum, Bytes: c.size})
+		}
+	}
+	return report, nil
+}
+
+// forEachWorkingFile calls fn with each evidence file in the root of a
+// window, not counting hidden bookkeeping and collection plans
+func (us *Storage) forEachWorkingFile(fn func(evidenceCopy) error) error {
+	evidenceBase := filepath.Join(us.localDataStore.GetBaseDir(), "evidence")
+	taskDirs, err := os.ReadDir(evidenceBase)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read evidence directory: %w", err)
+	}
+	for _, taskDir := range taskDirs {
+		taskRef := naming.ExtractTaskRef(taskDir.Name())
+		if !taskDir.IsDir() || taskRef == "" {
+			continue
+		}
+		windows, err := os.ReadDir(filepath.Join(evidenceBase, taskDir.Name()))
+		if err != nil {
+			return err
+		}
+		for _, window := range windows {
+			if !window.IsDir() || strings.HasPrefix(window.Name(), ".") || window.Name() == "metadata" {
+				continue
+			}
+			windowDir := filepath.Join(evidenceBase, taskDir.Name(), window.Name())
+			entries, err := os.ReadDir(windowDir)
+			if err != nil {
+				return err
+			}
+			for _, entry := range entries {
+				name := entry.Name()
+				if !entry.Type().IsRegular() || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "collection_plan") {
+					continue
+				}
+				info, err := entry.Info()
+				if err != nil {
+					continue
+				}
+				c := evidenceCopy{path: filepath.Join(windowDir, name), taskRef: taskRef, window: window.Name(), size: info.Size()}
+				if err := fn(c); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ReuseEvidence adds evidence files of one task window to another as
+// references to a single stored copy, rather than copying them. With no
+// files named, every evidence file of the source window is reused. It
+// returns the files added, relative to data_dir.
+func (us *Storage) ReuseEvidence(fromRef, fromWindow, toRef, toWindow string, files []string) ([]string, error) {
+	srcDir := us.getEvidenceWindowDir(fromRef, fromWindow)
+	if len(files) == 0 {
+		refs, err := us.GetEvidenceFiles(fromRef, fromWindow)
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range refs {
+			files = append(files, ref.Filename)
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no evidence files for %s in window %s", fromRef, fromWindow)
+	}
+
+	dstDir, err := us.evidenceWindowDirFor(toRef, toWindow)
+	if err != nil {
+		return nil, err
+	}
+	if dstDir == srcDir {
+		return nil, errors.New("cannot reuse evidence in the window it comes from")
+	}
+	lock, err := us.LockWindow(toRef, toWindow)
+	if err != nil {
+		return nil, err
+	}
+	defer lock.Unlock()
+
+	var added []string
+	var custody []models.CustodyEvent
+	for _, name := range files {
+		if name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+			return added, fmt.Errorf("invalid evidence file name: %s", name)
+		}
+		src := filepath.Join(srcDir, name)
+		data, err := os.ReadFile(src)
+		if err != nil {
+			return added, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		if IsEncrypted(data) {
+			return added, fmt.Errorf("%s is encrypted; sensitive evidence is not reused across tasks", name)
+		}
+
+		var ref EvidenceReference
+		if IsReference(data) {
+			if _, err := resolveReference(src, data); err != nil {
+				return added, err
+			}
+			ref, _ = ParseReference(data)
+		} else {
+			if ref, err = us.storeObject(data); err != nil {
+				return added, fmt.Errorf("failed to store %s: %w", name, err)
+			}
+			if err := writeFileAtomic(src, ref.Bytes()); err != nil {
+				return added, err
+			}
+		}
+
+		dst := filepath.Join(dstDir, name)
+		if existing, err := ReadFile(dst); err == nil {
+			if sum := sha256.Sum256(existing); hex.EncodeToString(sum[:]) == ref.SHA256 {
+				continue
+			}
+			return added, fmt.Errorf("%s already exists in %s window %s", name, toRef, toWindow)
+		}
+		if err := os.MkdirAll(dstDir, 0755); err != nil {
+			return added, fmt.Errorf("failed to create evidence directory: %w", err)
+		}
+		if err := os.WriteFile(dst, ref.Bytes(), 0644); err != nil {
+			return added, err
+		}
+		added = append(added, us.relPath(dst))
+		custody = append(custody, models.CustodyEvent{
+			Timestamp: time.Now(),
+			File:      name,
+			Event:     models.CustodyGenerated,
+			Actor:     CustodyActor(),
+			SHA256:    ref.SHA256,
+			Details:   fmt.Sprintf("reused from %s window %s", fromRef, fromWindow),
+		})
+	}
+	return added, AppendCustodyEvents(dstDir, custody...)
+}
+
+// evidenceWindowDirFor returns the window directory of a task, naming the
+// task directory after the synced task when it does not exist yet
+func (us *Storage) evidenceWindowDirFor(taskRef, window string) (string, error) {
+	dir := us.getEvidenceWindowDir(taskRef, window)
+	if _, err := os.Stat(filepath.Dir(dir)); err == nil {
+		return dir, nil
+	}
+	task, err := us.GetEvidenceTask(taskRef)
+	if err != nil {
+		return "", fmt.Errorf("evidence task %s not found: %w", taskRef, err)
+	}
+	taskDir := naming.GetEvidenceTaskDirName(task.Name, task.ReferenceID, task.ID)
+	return filepath.Join(us.localDataStore.GetBaseDir(), "evidence", taskDir, window), nil
+}
+
+// MaterializeEvidence turns the references in a window's root back into the
+// files they point to, returning the files it replaced, relative to
+// data_dir
+func (us *Storage) MaterializeEvidence(taskRef, window string) ([]string, error) {
+	windowDir := us.getEvidenceWindowDir(taskRef, window)
+	entries, err := os.ReadDir(windowDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read evidence directory: %w", err)
+	}
+
+	var materialized []string
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() || info.Size() > maxReferenceSize {
+			continue
+		}
+		path := filepath.Join(windowDir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return materialized, err
+		}
+		if !IsReference(data) {
+			continue
+		}
+		content, err := resolveReference(path, data)
+		if err != nil {
+			return materialized, err
+		}
+		if err := writeFileAtomic(path, content); err != nil {
+			return materialized, err
+		}
+		materialized = append(materialized, us.relPath(path))
+	}
+	return materialized, nil
+}
+
+// relPath returns path relative to data_dir, with forward slashes
+func (us *Storage) relPath(path string) string {
+	if rel, err := filepath.Rel(us.localDataStore.GetBaseDir(), path); err == nil && filepath.IsLocal(rel) {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
@@ -0,0 +1,256 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dedupFixture returns a store with the same user export in two tasks'
+// windows, and the two window directories
+func dedupFixture(t *testing.T) (*Storage, string, string) {
+	t.Helper()
+	dataDir := t.TempDir()
+	store, err := NewStorage(config.StorageConfig{DataDir: dataDir, Paths: config.StoragePaths{}.WithDefaults()})
+	require.NoError(t, err)
+	require.NoError(t, store.SaveEvidenceTask(&domain.EvidenceTask{ID: "101", ReferenceID: "ET-0001", Name: "Access Review"}))
+	require.NoError(t, store.SaveEvidenceTask(&domain.EvidenceTask{ID: "102", ReferenceID: "ET-0002", Name: "MFA Review"}))
+
+	export := strings.Repeat("user,mfa\nalice,true\n", 10)
+	first := filepath.Join(dataDir, "evidence", "Access_Review_ET-0001_101", "2025-Q1")
+	second := filepath.Join(dataDir, "evidence", "MFA_Review_ET-0002_102", "2025-Q1")
+	writeTestFile(t, first, "users.csv", export)
+	writeTestFile(t, first, "notes.md", "# Notes\n")
+	writeTestFile(t, first, "collection_plan.md", "# Plan\n")
+	writeTestFile(t, first, ".submitted/users.csv", export)
+	writeTestFile(t, second, "users.csv", export)
+	return store, first, second
+}
+
+func TestParseReference(t *testing.T) {
+	t.Parallel()
+	ref := EvidenceReference{SHA256: strings.Repeat("ab", 32), Size: 1234}
+
+	parsed, err := ParseReference(ref.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, ref, parsed)
+	assert.LessOrEqual(t, len(ref.Bytes()), maxReferenceSize)
+
+	tests := map[string]string{
+		"not a reference": "user,mfa\n",
+		"short digest":    referenceMagic + "sha256:abcd 12\n",
+		"no size":         referenceMagic + "sha256:" + strings.Repeat("ab", 32) + "\n",
+		"negative size":   referenceMagic + "sha256:" + strings.Repeat("ab", 32) + " -1\n",
+		"other algorithm": referenceMagic + "md5:" + strings.Repeat("ab", 32) + " 12\n",
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := ParseReference([]byte(data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestStorage_DedupEvidence(t *testing.T) {
+	t.Parallel()
+	store, first, second := dedupFixture(t)
+	export := readTestFile(t, first, "users.csv")
+
+	report, err := store.DedupEvidence(64, true)
+	require.NoError(t, err)
+	assert.Len(t, report.Files, 2)
+	assert.Equal(t, 1, report.Objects)
+	assert.Equal(t, export, readTestFile(t, first, "users.csv"), "a dry run changes nothing")
+
+	report, err = store.DedupEvidence(64, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"evidence/Access_Review_ET-0001_101/2025-Q1/users.csv",
+		"evidence/MFA_Review_ET-0002_102/2025-Q1/users.csv",
+	}, []string{report.Files[0].Path, report.Files[1].Path})
+	assert.Equal(t, 1, report.Objects)
+	assert.Equal(t, int64(len(export))-2*int64(len(EvidenceReference{SHA256: report.Files[0].SHA256, Size: int64(len(export))}.Bytes())), report.SavedBytes)
+
+	for _, dir := range []string{first, second} {
+		raw, err := os.ReadFile(filepath.Join(dir, "users.csv"))
+		require.NoError(t, err)
+		assert.True(t, IsReference(raw))
+
+		content, err := ReadFile(filepath.Join(dir, "users.csv"))
+		require.NoError(t, err)
+		assert.Equal(t, export, string(content), "references read as the file")
+	}
+	assert.Equal(t, export, readTestFile(t, first, ".submitted/users.csv"), "submitted evidence stays a real file")
+	assert.Equal(t, "# Notes\n", readTestFile(t, first, "notes.md"), "unique files are left alone")
+
+	files, err := store.GetEvidenceFiles("ET-0002", "2025-Q1")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, int64(len(export)), files[0].SizeBytes)
+	assert.Equal(t, report.Files[0].SHA256, files[0].ChecksumSHA256)
+
+	// A new copy of content already stored is deduplicated on its own
+	writeTestFile(t, first, "users-copy.csv", export)
+	report, err = store.DedupEvidence(64, false)
+	require.NoError(t, err)
+	require.Len(t, report.Files, 1)
+	assert.Equal(t, 0, report.Objects)
+}
+
+func TestStorage_DedupEvidence_MinSize(t *testing.T) {
+	t.Parallel()
+	store, _, _ := dedupFixture(t)
+
+	report, err := store.DedupEvidence(4096, false)
+	require.NoError(t, err)
+	assert.Empty(t, report.Files)
+	_, err = os.Stat(filepath.Join(store.GetBaseDir(), ObjectsDir))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestStorage_MaterializeEvidence(t *testing.T) {
+	t.Parallel()
+	store, first, second := dedupFixture(t)
+	export := readTestFile(t, first, "users.csv")
+	_, err := store.DedupEvidence(0, false)
+	require.NoError(t, err)
+
+	materialized, err := store.MaterializeEvidence("ET-0001", "2025-Q1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"evidence/Access_Review_ET-0001_101/2025-Q1/users.csv"}, materialized)
+	assert.Equal(t, export, readTestFile(t, first, "users.csv"))
+
+	raw, err := os.ReadFile(filepath.Join(second, "users.csv"))
+	require.NoError(t, err)
+	assert.True(t, IsReference(raw), "other windows keep their references")
+
+	materialized, err = store.MaterializeEvidence("ET-0003", "2025-Q1")
+	require.NoError(t, err)
+	assert.Empty(t, materialized)
+}
+
+func TestStorage_ReuseEvidence(t *testing.T) {
+	t.Parallel()
+	store, first, _ := dedupFixture(t)
+	require.NoError(t, store.SaveEvidenceTask(&domain.EvidenceTask{ID: "103", ReferenceID: "ET-0003", Name: "Offboarding"}))
+	export := readTestFile(t, first, "users.csv")
+
+	added, err := store.ReuseEvidence("ET-0001", "2025-Q1", "ET-0003", "2025-Q2", []string{"users.csv"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"evidence/Offboarding_ET-0003_103/2025-Q2/users.csv"}, added)
+
+	target := filepath.Join(store.GetBaseDir(), "evidence", "Offboarding_ET-0003_103", "2025-Q2")
+	content, err := ReadFile(filepath.Join(target, "users.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, export, string(content))
+	raw, err := os.ReadFile(filepath.Join(first, "users.csv"))
+	require.NoError(t, err)
+	assert.True(t, IsReference(raw), "the source shares the stored copy")
+
+	events, err := store.LoadCustodyLog("ET-0003", "2025-Q2")
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, models.CustodyGenerated, events[0].Event)
+	assert.Equal(t, "reused from ET-0001 window 2025-Q1", events[0].Details)
+
+	// Reusing again is a no-op; a different file of the same name is refused
+	added, err = store.ReuseEvidence("ET-0001", "2025-Q1", "ET-0003", "2025-Q2", []string{"users.csv"})
+	require.NoError(t, err)
+	assert.Empty(t, added)
+	writeTestFile(t, first, "notes.md", "# Other notes\n")
+	writeTestFile(t, target, "notes.md", "# Notes\n")
+	_, err = store.ReuseEvidence("ET-0001", "2025-Q1", "ET-0003", "2025-Q2", []string{"notes.md"})
+	assert.ErrorContains(t, err, "notes.md already exists")
+
+	_, err = store.ReuseEvidence("ET-0001", "2025-Q1", "ET-0003", "2025-Q2", []string{"../users.csv"})
+	assert.ErrorContains(t, err, "invalid evidence file name")
+	_, err = store.ReuseEvidence("ET-0001", "2025-Q1", "ET-0001", "2025-Q1", nil)
+	assert.ErrorContains(t, err, "window it comes from")
+}
+
+func TestStorage_ReuseEvidence_Encrypted(t *testing.T) {
+	c := newTestCipher(t)
+	useTestCipher(t, c)
+	store, first, second := dedupFixture(t)
+	export := readTestFile(t, first, "users.csv")
+	_, err := store.DedupEvidence(0, false)
+	require.NoError(t, err)
+
+	// Encrypting a reference encrypts the content, not the shared copy
+	encrypted, err := store.EncryptEvidence([]string{"ET-0001"})
+	require.NoError(t, err)
+	assert.Contains(t, encrypted, filepath.Join(first, "users.csv"))
+	content, err := ReadFile(filepath.Join(first, "users.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, export, string(content))
+	content, err = ReadFile(filepath.Join(second, "users.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, export, string(content))
+
+	_, err = store.ReuseEvidence("ET-0001", "2025-Q1", "ET-0002", "2025-Q2", []string{"users.csv"})
+	assert.ErrorContains(t, err, "is encrypted")
+}
+
+func TestStorage_GarbageCollect_Objects(t *testing.T) {
+	t.Parallel()
+	store, first, second := dedupFixture(t)
+	export := readTestFile(t, first, "users.csv")
+	report, err := store.DedupEvidence(0, false)
+	require.NoError(t, err)
+	object := ObjectsDir + "/sha256/" + report.Files[0].SHA256[:2] + "/" + report.Files[0].SHA256[2:]
+
+	result, err := store.GarbageCollect(GCOptions{})
+	require.NoError(t, err)
+	assert.NotContains(t, gcPaths(result.Items), object, "referenced content is kept")
+
+	_, err = store.MaterializeEvidence("ET-0001", "2025-Q1")
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(filepath.Join(second, "users.csv")))
+	result, err = store.GarbageCollect(GCOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, GCKindObject, gcPaths(result.Items)[object])
+	_, err = os.Stat(filepath.Join(store.GetBaseDir(), filepath.FromSlash(object)))
+	assert.True(t, os.IsNotExist(err))
+	assert.Equal(t, export, readTestFile(t, first, "users.csv"))
+}
+
+func TestWriteTarGz_InlinesReferences(t *testing.T) {
+	t.Parallel()
+	store, first, _ := dedupFixture(t)
+	export := readTestFile(t, first, "users.csv")
+	_, err := store.DedupEvidence(0, false)
+	require.NoError(t, err)
+
+	require.NoError(t, writeTarGz(first+".tar.gz", first))
+	file, err := os.Open(first + ".tar.gz")
+	require.NoError(t, err)
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+	contents := map[string]string{}
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			break
+		}
+		data, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		contents[header.Name] = string(data)
+	}
+	assert.Equal(t, export, contents["2025-Q1/users.csv"], "archives hold the content, not the reference")
+}
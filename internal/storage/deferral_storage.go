@@ -0,0 +1,95 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/grctool/grctool/internal/domain"
+)
+
+// deferralsCollection is the docs subdirectory holding one JSON file per
+// deferred evidence task, named by its reference
+const deferralsCollection = "deferrals"
+
+// SaveTaskDeferral validates and saves a task's deferral, replacing any
+// earlier one
+func (us *Storage) SaveTaskDeferral(deferral *domain.TaskDeferral) error {
+	if deferral == nil {
+		return fmt.Errorf("deferral cannot be nil")
+	}
+	if err := deferral.Validate(); err != nil {
+		return err
+	}
+	return us.fileStorage.Save(deferralsCollection, strings.ToUpper(deferral.TaskRef), deferral)
+}
+
+// GetTaskDeferral retrieves a task's deferral, whether or not it still
+// applies, or nil when the task was never deferred
+func (us *Storage) GetTaskDeferral(taskRef string) (*domain.TaskDeferral, error) {
+	id := strings.ToUpper(taskRef)
+	if !us.fileStorage.Exists(deferralsCollection, id) {
+		return nil, nil
+	}
+	var deferral domain.TaskDeferral
+	if err := us.fileStorage.Load(deferralsCollection, id, &deferral); err != nil {
+		return nil, fmt.Errorf("failed to load deferral of %s: %w", taskRef, err)
+	}
+	return &deferral, nil
+}
+
+// DeleteTaskDeferral removes a task's deferral
+func (us *Storage) DeleteTaskDeferral(taskRef string) error {
+	return us.fileStorage.Delete(deferralsCollection, strings.ToUpper(taskRef))
+}
+
+// GetAllTaskDeferrals retrieves every task's deferral, sorted by task
+func (us *Storage) GetAllTaskDeferrals() ([]domain.TaskDeferral, error) {
+	ids, err := us.fileStorage.List(deferralsCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	deferrals := make([]domain.TaskDeferral, 0, len(ids))
+	for _, id := range ids {
+		var deferral domain.TaskDeferral
+		if err := us.fileStorage.Load(deferralsCollection, id, &deferral); err != nil {
+			return nil, fmt.Errorf("failed to load deferral %s: %w", id, err)
+		}
+		deferrals = append(deferrals, deferral)
+	}
+
+	sort.Slice(deferrals, func(i, j int) bool { return deferrals[i].TaskRef < deferrals[j].TaskRef })
+	return deferrals, nil
+}
+
+// attachDeferrals sets the Deferral of each task that has one; deferrals
+// that cannot be read are left off
+func (us *Storage) attachDeferrals(tasks []domain.EvidenceTask) {
+	deferrals, err := us.GetAllTaskDeferrals()
+	if err != nil || len(deferrals) == 0 {
+		return
+	}
+	byRef := make(map[string]*domain.TaskDeferral, len(deferrals))
+	for i := range deferrals {
+		byRef[deferrals[i].TaskRef] = &deferrals[i]
+	}
+	for i := range tasks {
+		tasks[i].Deferral = byRef[strings.ToUpper(tasks[i].ReferenceID)]
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskDeferralStorage(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+	store, err := NewStorage(config.StorageConfig{DataDir: dataDir})
+	require.NoError(t, err)
+
+	now := time.Now()
+	overdue := now.AddDate(0, 0, -5)
+	require.NoError(t, store.SaveEvidenceTask(&domain.EvidenceTask{ID: "328094", ReferenceID: "ET-0103", Name: "Vendor Reviews", NextDue: &overdue}))
+	require.NoError(t, store.SaveEvidenceTask(&domain.EvidenceTask{ID: "328095", ReferenceID: "ET-0104", Name: "Access Reviews", NextDue: &overdue}))
+
+	deferral, err := store.GetTaskDeferral("ET-0103")
+	require.NoError(t, err)
+	assert.Nil(t, deferral)
+
+	err = store.SaveTaskDeferral(&domain.TaskDeferral{TaskRef: "et-0103", Until: now.AddDate(0, 1, 0)})
+	assert.ErrorContains(t, err, "reason is required")
+
+	require.NoError(t, store.SaveTaskDeferral(&domain.TaskDeferral{TaskRef: "ET-0103", Until: now.AddDate(0, 1, 0),
+		Reason: "Waiting on vendor SOC 2 report", DeferredAt: now, DueDate: &overdue}))
+	assert.FileExists(t, filepath.Join(dataDir, "docs", "deferrals", "ET-0103.json"))
+
+	task, err := store.GetEvidenceTask("ET-0103")
+	require.NoError(t, err)
+	require.NotNil(t, task.Deferral)
+	assert.Equal(t, "Waiting on vendor SOC 2 report", task.Deferral.Reason)
+	assert.False(t, task.IsOverdue(now))
+
+	// Resyncing the task keeps the deferral, which is stored on its own
+	require.NoError(t, store.SaveEvidenceTask(task))
+	task.Deferral = nil
+	require.NoError(t, store.SaveEvidenceTask(task))
+	task, err = store.GetEvidenceTask("ET-0103")
+	require.NoError(t, err)
+	assert.NotNil(t, task.Deferral)
+
+	summary, err := store.GetEvidenceTaskSummary()
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.Deferred)
+	assert.Equal(t, 1, summary.Overdue)
+
+	deferrals, err := store.GetAllTaskDeferrals()
+	require.NoError(t, err)
+	require.Len(t, deferrals, 1)
+	assert.Equal(t, "ET-0103", deferrals[0].TaskRef)
+
+	require.NoError(t, store.DeleteTaskDeferral("ET-0103"))
+	task, err = store.GetEvidenceTask("ET-0103")
+	require.NoError(t, err)
+	assert.Nil(t, task.Deferral)
+	assert.True(t, task.IsOverdue(now))
+}
@@ -0,0 +1,376 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/naming"
+)
+
+// encryptedMagic begins every file grctool has encrypted. The rest of the
+// header line names the key, and AES-256-GCM nonce and ciphertext follow.
+const encryptedMagic = "GRCTOOL-ENCRYPTED-V1 "
+
+// ErrNoEncryptionKey is returned when an encrypted file is read without a
+// key configured
+var ErrNoEncryptionKey = errors.New("no evidence encryption key: run 'grctool storage keygen', or set storage.encryption.key or storage.encryption.kms_key_id")
+
+// Cipher encrypts and decrypts evidence files with an AES-256 key
+type Cipher struct {
+	aead  cipher.AEAD
+	keyID string
+}
+
+// NewCipher returns a cipher for a 32-byte key
+func NewCipher(key []byte) (*Cipher, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("evidence encryption key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(key)
+	return &Cipher{aead: aead, keyID: hex.EncodeToString(sum[:4])}, nil
+}
+
+// GenerateKey returns a new random key, base64-encoded as
+// storage.encryption.key expects
+func GenerateKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// LoadCipher returns the cipher for a storage configuration's key
+func LoadCipher(ctx context.Context, cfg config.StorageConfig) (*Cipher, error) {
+	switch {
+	case cfg.Encryption.Key != "":
+		key, err := base64.StdEncoding.DecodeString(cfg.Encryption.Key)
+		if err != nil {
+			return nil, fmt.Errorf("storage.encryption.key is not base64: %w", err)
+		}
+		return NewCipher(key)
+	case cfg.Encryption.KMSKeyID != "":
+		key, err := newKMSClient(cfg.Encryption.KMSKeyID).dataKey(ctx, filepath.Join(cfg.DataDir, KMSDataKeyFile))
+		if err != nil {
+			return nil, err
+		}
+		return NewCipher(key)
+	default:
+		return nil, ErrNoEncryptionKey
+	}
+}
+
+// KeyID identifies the key in encrypted files without revealing it
+func (c *Cipher) KeyID() string {
+	return c.keyID
+}
+
+// IsEncrypted reports whether data is a file grctool encrypted
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(encryptedMagic))
+}
+
+// Encrypt seals plaintext under a fresh nonce
+func (c *Cipher) Encrypt(plaintext []byte) ([]byte, error) {
+	header := []byte(encryptedMagic + c.keyID + "\n")
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, len(header)+len(nonce)+len(plaintext)+c.aead.Overhead())
+	out = append(append(out, header...), nonce...)
+	return c.aead.Seal(out, nonce, plaintext, header), nil
+}
+
+// Decrypt opens data Encrypt produced
+func (c *Cipher) Decrypt(data []byte) ([]byte, error) {
+	end := bytes.IndexByte(data, '\n')
+	if !IsEncrypted(data) || end < 0 {
+		return nil, errors.New("not an encrypted evidence file")
+	}
+	header, body := data[:end+1], data[end+1:]
+	if keyID := string(header[len(encryptedMagic):end]); keyID != c.keyID {
+		return nil, fmt.Errorf("encrypted with key %s, but the configured key is %s", keyID, c.keyID)
+	}
+	if len(body) < c.aead.NonceSize() {
+		return nil, errors.New("encrypted evidence file is truncated")
+	}
+	nonce, ciphertext := body[:c.aead.NonceSize()], body[c.aead.NonceSize():]
+	plaintext, err := c.aead.Open(nil, nonce, ciphertext, header)
+	if err != nil {
+		return nil, errors.New("encrypted evidence file is corrupt or was modified")
+	}
+	return plaintext, nil
+}
+
+var encryption struct {
+	sync.Mutex
+	load   func() (*Cipher, error)
+	cipher *Cipher
+}
+
+// UseEncryption sets how ReadFile finds the key for encrypted files. The
+// key is only loaded once an encrypted file is read.
+func UseEncryption(load func() (*Cipher, error)) {
+	encryption.Lock()
+	defer encryption.Unlock()
+	encryption.load = load
+	encryption.cipher = nil
+}
+
+// defaultCipher returns the cipher UseEncryption configured
+func defaultCipher() (*Cipher, error) {
+	encryption.Lock()
+	defer encryption.Unlock()
+	if encryption.cipher != nil {
+		return encryption.cipher, nil
+	}
+	if encryption.load == nil {
+		return nil, ErrNoEncryptionKey
+	}
+	c, err := encryption.load()
+	if err != nil {
+		return nil, err
+	}
+	encryption.cipher = c
+	return c, nil
+}
+
+// ReadFile reads a file like os.ReadFile, decrypting it when grctool
+// encrypted it and reading the stored copy when it is a reference
+func ReadFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err == nil && IsReference(data) {
+		data, err = resolveReference(path, data)
+	}
+	if err != nil || !IsEncrypted(data) {
+		return data, err
+	}
+	c, err := defaultCipher()
+	if err != nil {
+		return nil, fmt.Errorf("%s is encrypted: %w", filepath.Base(path), err)
+	}
+	plaintext, err := c.Decrypt(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt %s: %w", filepath.Base(path), err)
+	}
+	return plaintext, nil
+}
+
+// EncryptsEvidence reports whether storage.encryption covers a task, so its
+// evidence is encrypted as it is written
+func EncryptsEvidence(cfg config.EncryptionConfig, task *domain.EvidenceTask) bool {
+	if !cfg.Enabled || task == nil {
+		return false
+	}
+	if task.Sensitive {
+		return true
+	}
+	for _, ref := range cfg.Tasks {
+		if strings.EqualFold(ref, task.ReferenceID) {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteEvidenceFile writes an evidence file like os.WriteFile, first
+// encrypting it with the key UseEncryption configured when encrypt is set,
+// so sensitive evidence never reaches the disk as plaintext
+func WriteEvidenceFile(path string, data []byte, encrypt bool) error {
+	if encrypt {
+		c, err := defaultCipher()
+		if err != nil {
+			return fmt.Errorf("cannot encrypt %s: %w", filepath.Base(path), err)
+		}
+		if data, err = c.Encrypt(data); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// EncryptedTasks returns the refs of the tasks whose evidence is encrypted:
+// those flagged sensitive and those listed in the configuration
+func (us *Storage) EncryptedTasks(cfg config.EncryptionConfig) ([]string, error) {
+	tasks, err := us.GetAllEvidenceTasks()
+	if err != nil {
+		return nil, err
+	}
+	refs := append([]string{}, cfg.Tasks...)
+	for _, task := range tasks {
+		if task.Sensitive && task.ReferenceID != "" {
+			refs = append(refs, task.ReferenceID)
+		}
+	}
+	return refs, nil
+}
+
+// EncryptEvidence encrypts the plaintext evidence files of the given tasks
+// in every window with the key UseEncryption configured, returning the
+// paths it encrypted. The key is only loaded when there is a file to encrypt.
+func (us *Storage) EncryptEvidence(taskRefs []string) ([]string, error) {
+	return us.transformEvidence(taskRefs, func(data []byte) ([]byte, bool, error) {
+		if IsEncrypted(data) {
+			return nil, false, nil
+		}
+		c, err := defaultCipher()
+		if err != nil {
+			return nil, false, err
+		}
+		sealed, err := c.Encrypt(data)
+		return sealed, true, err
+	})
+}
+
+// DecryptEvidence turns the given tasks' encrypted evidence files back into
+// plaintext, returning the paths it decrypted
+func (us *Storage) DecryptEvidence(taskRefs []string) ([]string, error) {
+	return us.transformEvidence(taskRefs, func(data []byte) ([]byte, bool, error) {
+		if !IsEncrypted(data) {
+			return nil, false, nil
+		}
+		c, err := defaultCipher()
+		if err != nil {
+			return nil, false, err
+		}
+		plaintext, err := c.Decrypt(data)
+		return plaintext, true, err
+	})
+}
+
+// transformEvidence rewrites each evidence file of the tasks that transform
+// reports it changed, including the raw tool outputs and assembly context in
+// .context. Submission metadata, collection plans and other hidden
+// bookkeeping stay plaintext so status and scans work without a key.
+func (us *Storage) transformEvidence(taskRefs []string, transform func([]byte) ([]byte, bool, error)) ([]string, error) {
+	evidenceBase := filepath.Join(us.localDataStore.GetBaseDir(), "evidence")
+	entries, err := os.ReadDir(evidenceBase)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read evidence directory: %w", err)
+	}
+
+	var changed []string
+	for _, entry := range entries {
+		if !entry.IsDir() || !matchesAnyTaskRef(entry.Name(), taskRefs) {
+			continue
+		}
+		err := filepath.WalkDir(filepath.Join(evidenceBase, entry.Name()), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			name := d.Name()
+			if d.IsDir() {
+				if name == "metadata" || (strings.HasPrefix(name, ".") && name != naming.SubfolderSubmitted && name != naming.SubfolderContext) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !d.Type().IsRegular() || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "collection_plan") {
+				return nil
+			}
+
+			data, err := os.ReadFile(path)
+			if err == nil && IsReference(data) {
+				// References are transformed as the content they point
+				// to, leaving the stored copy other windows share alone
+				data, err = resolveReference(path, data)
+			}
+			if err != nil {
+				return err
+			}
+			out, ok, err := transform(data)
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			if !ok {
+				return nil
+			}
+			if err := writeFileAtomic(path, out); err != nil {
+				return err
+			}
+			changed = append(changed, path)
+			return nil
+		})
+		if err != nil {
+			return changed, err
+		}
+	}
+	return changed, nil
+}
+
+func matchesAnyTaskRef(dirName string, taskRefs []string) bool {
+	for _, ref := range taskRefs {
+		if naming.MatchesTaskRef(dirName, ref) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeFileAtomic replaces a file's content, keeping its mode, so a crash
+// never leaves it half written
+func writeFileAtomic(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(info.Mode().Perm()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,206 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCipher(t *testing.T) *Cipher {
+	t.Helper()
+	key, err := GenerateKey()
+	require.NoError(t, err)
+	c, err := LoadCipher(t.Context(), config.StorageConfig{Encryption: config.EncryptionConfig{Key: key}})
+	require.NoError(t, err)
+	return c
+}
+
+// useTestCipher makes c the key ReadFile and EncryptEvidence use
+func useTestCipher(t *testing.T, c *Cipher) {
+	t.Helper()
+	UseEncryption(func() (*Cipher, error) { return c, nil })
+	t.Cleanup(func() { UseEncryption(nil) })
+}
+
+func TestCipher_RoundTrip(t *testing.T) {
+	t.Parallel()
+	c := newTestCipher(t)
+	plaintext := []byte("user,mfa_enabled\nalice,true\n")
+
+	sealed, err := c.Encrypt(plaintext)
+	require.NoError(t, err)
+	assert.True(t, IsEncrypted(sealed))
+	assert.False(t, bytes.Contains(sealed, []byte("alice")))
+
+	again, err := c.Encrypt(plaintext)
+	require.NoError(t, err)
+	assert.NotEqual(t, sealed, again, "each encryption uses a fresh nonce")
+
+	opened, err := c.Decrypt(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, opened)
+}
+
+func TestCipher_Decrypt_Errors(t *testing.T) {
+	t.Parallel()
+	c := newTestCipher(t)
+	sealed, err := c.Encrypt([]byte("secret evidence"))
+	require.NoError(t, err)
+
+	_, err = newTestCipher(t).Decrypt(sealed)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "encrypted with key "+c.KeyID())
+
+	tampered := append([]byte{}, sealed...)
+	tampered[len(tampered)-1] ^= 1
+	_, err = c.Decrypt(tampered)
+	assert.EqualError(t, err, "encrypted evidence file is corrupt or was modified")
+
+	_, err = c.Decrypt([]byte("plain text"))
+	assert.Error(t, err)
+}
+
+func TestLoadCipher(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadCipher(t.Context(), config.StorageConfig{})
+	assert.True(t, errors.Is(err, ErrNoEncryptionKey))
+
+	_, err = LoadCipher(t.Context(), config.StorageConfig{Encryption: config.EncryptionConfig{
+		Key: base64.StdEncoding.EncodeToString([]byte("too short")),
+	}})
+	assert.ErrorContains(t, err, "must be 32 bytes")
+}
+
+func TestStorage_EncryptEvidence(t *testing.T) {
+	c := newTestCipher(t)
+	useTestCipher(t, c)
+
+	dataDir := t.TempDir()
+	store, err := NewStorage(config.StorageConfig{DataDir: dataDir, Paths: config.StoragePaths{}.WithDefaults()})
+	require.NoError(t, err)
+	require.NoError(t, store.SaveEvidenceTask(&domain.EvidenceTask{ID: "101", ReferenceID: "ET-0001", Name: "Access Review", Sensitive: true}))
+	require.NoError(t, store.SaveEvidenceTask(&domain.EvidenceTask{ID: "102", ReferenceID: "ET-0002", Name: "Change Log"}))
+
+	window := filepath.Join(dataDir, "evidence", "Access_Review_ET-0001_101", "2025-Q1")
+	writeTestFile(t, window, "users.csv", "user,mfa\nalice,true\n")
+	writeTestFile(t, window, ".submitted/old.csv", "user,mfa\nbob,false\n")
+	writeTestFile(t, window, ".submission/submission.yaml", "status: draft\n")
+	writeTestFile(t, window, ".context/tool_outputs/github-permissions.json", `{"admins":["alice"]}`)
+	writeTestFile(t, window, "collection_plan.md", "# Plan\n")
+	other := filepath.Join(dataDir, "evidence", "Change_Log_ET-0002_102", "2025-Q1")
+	writeTestFile(t, other, "changes.md", "# Changes\n")
+
+	refs, err := store.EncryptedTasks(config.EncryptionConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ET-0001"}, refs)
+
+	plainSum := sha256.Sum256([]byte("user,mfa\nalice,true\n"))
+	encrypted, err := store.EncryptEvidence(refs)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(window, "users.csv"),
+		filepath.Join(window, ".submitted", "old.csv"),
+		filepath.Join(window, ".context", "tool_outputs", "github-permissions.json"),
+	}, encrypted, "raw tool outputs are encrypted with the evidence")
+
+	raw, err := os.ReadFile(filepath.Join(window, "users.csv"))
+	require.NoError(t, err)
+	assert.True(t, IsEncrypted(raw))
+	assert.Equal(t, "status: draft\n", readTestFile(t, window, ".submission/submission.yaml"), "metadata stays plaintext")
+	assert.Equal(t, "# Plan\n", readTestFile(t, window, "collection_plan.md"))
+	assert.Equal(t, "# Changes\n", readTestFile(t, other, "changes.md"), "tasks that are not sensitive stay plaintext")
+
+	// Reads and checksums see the plaintext
+	content, err := ReadFile(filepath.Join(window, "users.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "user,mfa\nalice,true\n", string(content))
+	checksum, err := store.CalculateFileChecksum(filepath.Join(window, "users.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(plainSum[:]), checksum)
+
+	encrypted, err = store.EncryptEvidence(refs)
+	require.NoError(t, err)
+	assert.Empty(t, encrypted, "encrypted files are left alone")
+
+	decrypted, err := store.DecryptEvidence(refs)
+	require.NoError(t, err)
+	assert.Len(t, decrypted, 3)
+	assert.Equal(t, "user,mfa\nalice,true\n", readTestFile(t, window, "users.csv"))
+}
+
+func TestEncryptsEvidence(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		cfg  config.EncryptionConfig
+		task *domain.EvidenceTask
+		want bool
+	}{
+		"sensitive task":      {cfg: config.EncryptionConfig{Enabled: true}, task: &domain.EvidenceTask{ReferenceID: "ET-0001", Sensitive: true}, want: true},
+		"listed task":         {cfg: config.EncryptionConfig{Enabled: true, Tasks: []string{"et-0002"}}, task: &domain.EvidenceTask{ReferenceID: "ET-0002"}, want: true},
+		"other task":          {cfg: config.EncryptionConfig{Enabled: true, Tasks: []string{"ET-0002"}}, task: &domain.EvidenceTask{ReferenceID: "ET-0003"}},
+		"encryption disabled": {cfg: config.EncryptionConfig{Tasks: []string{"ET-0001"}}, task: &domain.EvidenceTask{ReferenceID: "ET-0001", Sensitive: true}},
+		"no task":             {cfg: config.EncryptionConfig{Enabled: true}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, EncryptsEvidence(tc.cfg, tc.task))
+		})
+	}
+}
+
+func TestWriteEvidenceFile(t *testing.T) {
+	c := newTestCipher(t)
+	useTestCipher(t, c)
+	dir := t.TempDir()
+
+	require.NoError(t, WriteEvidenceFile(filepath.Join(dir, "users.csv"), []byte("user,mfa\nalice,true\n"), true))
+	raw, err := os.ReadFile(filepath.Join(dir, "users.csv"))
+	require.NoError(t, err)
+	assert.True(t, IsEncrypted(raw))
+	content, err := ReadFile(filepath.Join(dir, "users.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "user,mfa\nalice,true\n", string(content))
+
+	require.NoError(t, WriteEvidenceFile(filepath.Join(dir, "changes.md"), []byte("# Changes\n"), false))
+	assert.Equal(t, "# Changes\n", readTestFile(t, dir, "changes.md"))
+
+	UseEncryption(nil)
+	err = WriteEvidenceFile(filepath.Join(dir, "keys.csv"), []byte("secret"), true)
+	assert.True(t, errors.Is(err, ErrNoEncryptionKey))
+	assert.NoFileExists(t, filepath.Join(dir, "keys.csv"), "nothing is written without a key")
+}
+
+func TestReadFile_NoKey(t *testing.T) {
+	c := newTestCipher(t)
+	UseEncryption(nil)
+
+	dir := t.TempDir()
+	sealed, err := c.Encrypt([]byte("secret"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "report.md"), sealed, 0o644))
+	writeTestFile(t, dir, "plain.md", "plain")
+
+	_, err = ReadFile(filepath.Join(dir, "report.md"))
+	assert.True(t, errors.Is(err, ErrNoEncryptionKey))
+
+	content, err := ReadFile(filepath.Join(dir, "plain.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "plain", string(content), "plaintext files need no key")
+}
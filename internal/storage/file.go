@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -144,6 +145,33 @@ func (fs *FileStorage) Exists(collection, id string) bool {
 	return err == nil
 }
 
+// NextSequentialID returns the ID after the highest {prefix}NNNN ID in a
+// collection, such as RISK-0002 after RISK-0001
+func (fs *FileStorage) NextSequentialID(collection, prefix string) (string, error) {
+	ids, err := fs.List(collection)
+	if err != nil {
+		return "", err
+	}
+
+	highest := 0
+	for _, id := range ids {
+		if n, err := strconv.Atoi(strings.TrimPrefix(id, prefix)); err == nil && n > highest {
+			highest = n
+		}
+	}
+	return fmt.Sprintf("%s%04d", prefix, highest+1), nil
+}
+
+// normalizeSequentialID turns a lower-case or bare number ID, such as risk-1
+// or 1, into its {prefix}NNNN form; other IDs are returned upper-cased
+func normalizeSequentialID(prefix, id string) string {
+	trimmed := strings.ToUpper(strings.TrimSpace(id))
+	if n, err := strconv.Atoi(strings.TrimPrefix(trimmed, prefix)); err == nil {
+		return fmt.Sprintf("%s%04d", prefix, n)
+	}
+	return trimmed
+}
+
 // Clear removes all files from a collection
 func (fs *FileStorage) Clear(collection string) error {
 	fs.mu.Lock()
@@ -0,0 +1,512 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/naming"
+	"gopkg.in/yaml.v3"
+)
+
+// Kinds of storage garbage
+const (
+	GCKindCache       = "cache"        // Cache entry past its age or expiration
+	GCKindToolOutputs = "tool_outputs" // .context/tool_outputs no longer needed
+	GCKindEmptyWindow = "empty_window" // Window directory without any files
+	GCKindCompressed  = "compressed"   // Closed window packed into an archive
+	GCKindObject      = "object"       // Deduplicated content no evidence file refers to
+)
+
+// githubCacheDir is where the GitHub client caches searches, outside the
+// configured cache directory
+const githubCacheDir = "github_cache"
+
+// GCOptions controls a storage garbage collection
+type GCOptions struct {
+	DryRun      bool          // List what would be removed without removing it
+	CacheMaxAge time.Duration // Cache files older than this are removed
+	Compress    bool          // Pack closed windows into <window>.tar.gz
+
+	// WindowEnd returns when a window's collection period ends; windows it
+	// cannot parse are never compressed
+	WindowEnd func(window string) (time.Time, error)
+	Now       time.Time // Reference time (default: time.Now)
+
+	// Held reports whether a legal hold covers a window, whose tool outputs
+	// are then kept
+	Held func(taskRef, window string) bool
+}
+
+// GCItem is one path garbage collection removed or compressed
+type GCItem struct {
+	Kind   string `json:"kind" yaml:"kind"`
+	Path   string `json:"path" yaml:"path"` // Relative to data_dir
+	Bytes  int64  `json:"bytes" yaml:"bytes"`
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// GCResult lists what a garbage collection did, or would do in a dry run
+type GCResult struct {
+	DryRun     bool     `json:"dry_run" yaml:"dry_run"`
+	Items      []GCItem `json:"items" yaml:"items"`
+	Skipped    []GCItem `json:"skipped,omitempty" yaml:"skipped,omitempty"` // Windows locked by another run
+	FreedBytes int64    `json:"freed_bytes" yaml:"freed_bytes"`
+}
+
+// GarbageCollect removes stale cache entries, tool outputs of windows that
+// were submitted or whose task no longer exists and that no legal hold
+// covers, empty window directories
+// and deduplicated content no evidence file refers to anymore; with
+// opts.Compress it also packs windows that are closed, accepted and have no
+// unsubmitted evidence into archives. Evidence files are never removed
+// otherwise.
+func (us *Storage) GarbageCollect(opts GCOptions) (*GCResult, error) {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	gc := &garbageCollector{
+		dataDir: us.localDataStore.GetBaseDir(),
+		opts:    opts,
+		result:  &GCResult{DryRun: opts.DryRun, Items: []GCItem{}},
+	}
+
+	for _, dir := range []string{us.paths.Cache, filepath.Join(gc.dataDir, githubCacheDir)} {
+		if err := gc.collectCache(dir); err != nil {
+			return gc.result, err
+		}
+	}
+
+	// Task directories whose name matches a synced task; with no tasks
+	// synced, no directory is treated as orphaned
+	known := make(map[string]bool)
+	tasks, _ := us.GetAllEvidenceTasks()
+	for _, task := range tasks {
+		known[naming.GetEvidenceTaskDirName(task.Name, task.ReferenceID, task.ID)] = true
+	}
+	if err := gc.collectEvidence(us.paths.Evidence, known); err != nil {
+		return gc.result, err
+	}
+	if err := gc.collectObjects(us.paths.Evidence); err != nil {
+		return gc.result, err
+	}
+
+	for _, item := range gc.result.Items {
+		gc.result.FreedBytes += item.Bytes
+	}
+	return gc.result, nil
+}
+
+type garbageCollector struct {
+	dataDir string
+	opts    GCOptions
+	result  *GCResult
+}
+
+// collectCache removes cache files past their age or recorded expiration,
+// then the directories that leaves empty
+func (gc *garbageCollector) collectCache(dir string) error {
+	cutoff := gc.opts.Now.Add(-gc.opts.CacheMaxAge)
+	var dirs []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path == filepath.Join(dir, "auth") {
+				return filepath.SkipDir // Credentials, not cache
+			}
+			if path != dir {
+				dirs = append(dirs, path)
+			}
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			return nil
+		}
+
+		reason := ""
+		if gc.opts.CacheMaxAge > 0 && info.ModTime().Before(cutoff) {
+			reason = fmt.Sprintf("not modified since %s", info.ModTime().Format("2006-01-02"))
+		} else if expiration, ok := cacheExpiration(path); ok && expiration.Before(gc.opts.Now) {
+			reason = fmt.Sprintf("expired %s", expiration.Format("2006-01-02 15:04"))
+		}
+		if reason == "" {
+			return nil
+		}
+		return gc.remove(GCItem{Kind: GCKindCache, Path: gc.rel(path), Bytes: info.Size(), Reason: reason}, path)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to clean cache %s: %w", dir, err)
+	}
+
+	// Deepest first, so parents emptied by their children go too
+	if !gc.opts.DryRun {
+		sort.Sort(sort.Reverse(sort.StringSlice(dirs)))
+		for _, path := range dirs {
+			_ = os.Remove(path) // Fails unless empty
+		}
+	}
+	return nil
+}
+
+// cacheExpiration reads the expiration a CacheManager entry records
+func cacheExpiration(path string) (time.Time, bool) {
+	if filepath.Ext(path) != ".json" {
+		return time.Time{}, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return time.Time{}, false
+	}
+	var entry struct {
+		Expiration time.Time `json:"expiration"`
+	}
+	if json.Unmarshal(data, &entry) != nil || entry.Expiration.IsZero() {
+		return time.Time{}, false
+	}
+	return entry.Expiration, true
+}
+
+// collectEvidence cleans up each window of each task directory
+func (gc *garbageCollector) collectEvidence(evidenceDir string, known map[string]bool) error {
+	taskDirs, err := os.ReadDir(evidenceDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read evidence directory: %w", err)
+	}
+
+	for _, taskDir := range taskDirs {
+		taskRef := naming.ExtractTaskRef(taskDir.Name())
+		if !taskDir.IsDir() || taskRef == "" {
+			continue
+		}
+		orphaned := len(known) > 0 && !known[taskDir.Name()]
+		taskPath := filepath.Join(evidenceDir, taskDir.Name())
+		windows, err := os.ReadDir(taskPath)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", gc.rel(taskPath), err)
+		}
+		for _, window := range windows {
+			name := window.Name()
+			if !window.IsDir() || strings.HasPrefix(name, ".") || name == "metadata" {
+				continue
+			}
+			if err := gc.collectWindow(taskRef, name, filepath.Join(taskPath, name), orphaned); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (gc *garbageCollector) collectWindow(taskRef, window, windowDir string, orphaned bool) error {
+	lock, err := LockWindow(gc.dataDir, taskRef, window)
+	var locked *LockedError
+	if errors.As(err, &locked) {
+		gc.result.Skipped = append(gc.result.Skipped, GCItem{Path: gc.rel(windowDir), Reason: err.Error()})
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	files, size := countFiles(windowDir)
+	if files == 0 {
+		return gc.remove(GCItem{Kind: GCKindEmptyWindow, Path: gc.rel(windowDir), Reason: "no files"}, windowDir)
+	}
+
+	working := hasWorkingFiles(windowDir)
+	submitted := hasWorkingFiles(filepath.Join(windowDir, naming.SubfolderSubmitted))
+	toolOutputs := filepath.Join(windowDir, ".context", "tool_outputs")
+	if outputFiles, outputSize := countFiles(toolOutputs); outputFiles > 0 {
+		reason := ""
+		switch {
+		case orphaned:
+			reason = "task no longer synced under this directory"
+		case submitted && !working:
+			reason = "window submitted"
+		}
+		if reason != "" && gc.opts.Held != nil && gc.opts.Held(taskRef, window) {
+			reason = ""
+		}
+		if reason != "" {
+			if err := gc.remove(GCItem{Kind: GCKindToolOutputs, Path: gc.rel(toolOutputs), Bytes: outputSize, Reason: reason}, toolOutputs); err != nil {
+				return err
+			}
+			size -= outputSize
+		}
+	}
+
+	if !gc.opts.Compress || gc.opts.WindowEnd == nil || working {
+		return nil
+	}
+	end, err := gc.opts.WindowEnd(window)
+	if err != nil || end.After(gc.opts.Now) || submissionStatus(windowDir) != "accepted" {
+		return nil
+	}
+	return gc.compress(windowDir, size)
+}
+
+// compress packs a closed window into <window>.tar.gz beside it and removes
+// the directory
+func (gc *garbageCollector) compress(windowDir string, size int64) error {
+	archive := windowDir + ".tar.gz"
+	item := GCItem{Kind: GCKindCompressed, Path: gc.rel(windowDir), Reason: "closed and accepted; packed into " + filepath.Base(archive)}
+	if _, err := os.Stat(archive); err == nil {
+		return nil // Packed before; leave both for a person to reconcile
+	}
+	if gc.opts.DryRun {
+		item.Bytes = size
+		gc.result.Items = append(gc.result.Items, item)
+		return nil
+	}
+
+	if err := writeTarGz(archive, windowDir); err != nil {
+		os.Remove(archive)
+		return fmt.Errorf("failed to compress %s: %w", item.Path, err)
+	}
+	if info, err := os.Stat(archive); err == nil {
+		item.Bytes = size - info.Size()
+	}
+	if err := os.RemoveAll(windowDir); err != nil {
+		return fmt.Errorf("failed to remove %s after compressing it: %w", item.Path, err)
+	}
+	gc.result.Items = append(gc.result.Items, item)
+	return nil
+}
+
+// collectObjects removes the stored copies of deduplicated evidence that no
+// reference under evidenceDir points to
+func (gc *garbageCollector) collectObjects(evidenceDir string) error {
+	objectsDir := filepath.Join(gc.dataDir, ObjectsDir)
+	if _, err := os.Stat(objectsDir); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	referenced := map[string]bool{}
+	err := filepath.WalkDir(evidenceDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil || !info.Mode().IsRegular() || info.Size() > maxReferenceSize {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil || !IsReference(data) {
+			return nil
+		}
+		if ref, err := ParseReference(data); err == nil {
+			referenced[ref.SHA256] = true
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to read evidence directory: %w", err)
+	}
+
+	var dirs []string
+	err = filepath.WalkDir(objectsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != objectsDir {
+				dirs = append(dirs, path)
+			}
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			return nil
+		}
+		sum := filepath.Base(filepath.Dir(path)) + d.Name()
+		if referenced[sum] {
+			return nil
+		}
+		return gc.remove(GCItem{Kind: GCKindObject, Path: gc.rel(path), Bytes: info.Size(), Reason: "no evidence file refers to it"}, path)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to clean %s: %w", ObjectsDir, err)
+	}
+	if !gc.opts.DryRun {
+		sort.Sort(sort.Reverse(sort.StringSlice(dirs)))
+		for _, path := range dirs {
+			_ = os.Remove(path) // Fails unless empty
+		}
+	}
+	return nil
+}
+
+// writeTarGz writes dir's tree to a gzipped tar, its entries prefixed with
+// dir's name so the archive extracts back into place
+func writeTarGz(archive, dir string) error {
+	file, err := os.OpenFile(archive, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+
+	base := filepath.Dir(dir)
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(base, path)
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		// Archives hold the content of references, so they stay whole
+		// once the stored copies are collected
+		var content []byte
+		if !info.IsDir() && info.Size() <= maxReferenceSize {
+			if data, err := os.ReadFile(path); err == nil && IsReference(data) {
+				if content, err = resolveReference(path, data); err != nil {
+					return err
+				}
+				header.Size = int64(len(content))
+			}
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if content != nil {
+			_, err := tw.Write(content)
+			return err
+		}
+		src, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		_, err = io.Copy(tw, src)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
+// remove deletes path, or only records it in a dry run
+func (gc *garbageCollector) remove(item GCItem, path string) error {
+	if !gc.opts.DryRun {
+		if err := os.RemoveAll(path); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", item.Path, err)
+		}
+	}
+	gc.result.Items = append(gc.result.Items, item)
+	return nil
+}
+
+func (gc *garbageCollector) rel(path string) string {
+	if rel, err := filepath.Rel(gc.dataDir, path); err == nil && filepath.IsLocal(rel) {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
+
+// countFiles returns the number and total size of the regular files in a tree
+func countFiles(dir string) (int, int64) {
+	var count int
+	var size int64
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info, err := d.Info(); err == nil && info.Mode().IsRegular() {
+			count++
+			size += info.Size()
+		}
+		return nil
+	})
+	return count, size
+}
+
+// hasWorkingFiles reports whether dir directly holds evidence files, not
+// counting hidden bookkeeping and collection plans
+func hasWorkingFiles(dir string) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.Type().IsRegular() && !strings.HasPrefix(name, ".") && !strings.HasPrefix(name, "collection_plan") {
+			return true
+		}
+	}
+	return false
+}
+
+// submissionStatus returns the status in a window's submission metadata
+func submissionStatus(windowDir string) string {
+	data, err := os.ReadFile(filepath.Join(windowDir, submissionMetadataDir, submissionFilename))
+	if err != nil {
+		return ""
+	}
+	var submission models.EvidenceSubmission
+	if yaml.Unmarshal(data, &submission) != nil {
+		return ""
+	}
+	return submission.Status
+}
@@ -0,0 +1,184 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gcPaths(items []GCItem) map[string]string {
+	paths := make(map[string]string)
+	for _, item := range items {
+		paths[item.Path] = item.Kind
+	}
+	return paths
+}
+
+func quarterEnd(window string) (time.Time, error) {
+	var year, quarter int
+	if _, err := fmt.Sscanf(window, "%d-Q%d", &year, &quarter); err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(year, time.Month(quarter*3+1), 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond), nil
+}
+
+// gcFixture lays out a data directory with something for each kind of
+// garbage, plus evidence that must survive
+func gcFixture(t *testing.T) (*Storage, string) {
+	t.Helper()
+	s := newTestStorage(t)
+	dataDir := s.localDataStore.GetBaseDir()
+	cacheDir, err := filepath.Rel(dataDir, s.paths.Cache)
+	require.NoError(t, err)
+	old := time.Now().Add(-30 * 24 * time.Hour)
+
+	writeTestFile(t, dataDir, cacheDir+"/terraform/scans/old.json", `{"findings":[]}`)
+	require.NoError(t, os.Chtimes(filepath.Join(dataDir, cacheDir, "terraform/scans/old.json"), old, old))
+	writeTestFile(t, dataDir, cacheDir+"/fresh.json", `{"expiration":"2999-01-01T00:00:00Z"}`)
+	writeTestFile(t, dataDir, "github_cache/search.json", `{"expiration":"2020-01-01T00:00:00Z"}`)
+	writeTestFile(t, dataDir, cacheDir+"/auth/session.json", `{"token":"x"}`)
+	require.NoError(t, os.Chtimes(filepath.Join(dataDir, cacheDir, "auth/session.json"), old, old))
+
+	// Submitted window: tool outputs are no longer needed
+	writeTestFile(t, dataDir, "evidence/Access_Review_ET-0001_101/2025-Q1/.submitted/01_users.csv", "user,mfa\n")
+	writeTestFile(t, dataDir, "evidence/Access_Review_ET-0001_101/2025-Q1/.context/tool_outputs/github.json", "{}")
+	writeTestFile(t, dataDir, "evidence/Access_Review_ET-0001_101/2025-Q1/.submission/submission.yaml", "status: accepted\n")
+	// Window in progress: tool outputs are kept
+	writeTestFile(t, dataDir, "evidence/Access_Review_ET-0001_101/2025-Q2/01_users.csv", "user,mfa\n")
+	writeTestFile(t, dataDir, "evidence/Access_Review_ET-0001_101/2025-Q2/.context/tool_outputs/github.json", "{}")
+	// Window left empty
+	require.NoError(t, os.MkdirAll(filepath.Join(dataDir, "evidence/Access_Review_ET-0001_101/2025-Q3/.context"), 0755))
+	return s, dataDir
+}
+
+func TestStorage_GarbageCollect(t *testing.T) {
+	t.Parallel()
+
+	t.Run("dry run", func(t *testing.T) {
+		t.Parallel()
+		s, dataDir := gcFixture(t)
+
+		result, err := s.GarbageCollect(GCOptions{DryRun: true, CacheMaxAge: 7 * 24 * time.Hour})
+		require.NoError(t, err)
+		assert.True(t, result.DryRun)
+		assert.Equal(t, map[string]string{
+			".cache/terraform/scans/old.json":                                  GCKindCache,
+			"github_cache/search.json":                                         GCKindCache,
+			"evidence/Access_Review_ET-0001_101/2025-Q1/.context/tool_outputs": GCKindToolOutputs,
+			"evidence/Access_Review_ET-0001_101/2025-Q3":                       GCKindEmptyWindow,
+		}, gcPaths(result.Items))
+		assert.Positive(t, result.FreedBytes)
+
+		// Nothing is removed
+		assert.FileExists(t, filepath.Join(dataDir, ".cache/terraform/scans/old.json"))
+		assert.DirExists(t, filepath.Join(dataDir, "evidence/Access_Review_ET-0001_101/2025-Q3"))
+	})
+
+	t.Run("removes garbage and keeps evidence", func(t *testing.T) {
+		t.Parallel()
+		s, dataDir := gcFixture(t)
+
+		result, err := s.GarbageCollect(GCOptions{CacheMaxAge: 7 * 24 * time.Hour})
+		require.NoError(t, err)
+		assert.Len(t, result.Items, 4)
+
+		assert.NoDirExists(t, filepath.Join(dataDir, ".cache/terraform"))
+		assert.NoFileExists(t, filepath.Join(dataDir, "github_cache/search.json"))
+		assert.NoDirExists(t, filepath.Join(dataDir, "evidence/Access_Review_ET-0001_101/2025-Q1/.context/tool_outputs"))
+		assert.NoDirExists(t, filepath.Join(dataDir, "evidence/Access_Review_ET-0001_101/2025-Q3"))
+
+		assert.FileExists(t, filepath.Join(dataDir, ".cache/fresh.json"))
+		assert.FileExists(t, filepath.Join(dataDir, ".cache/auth/session.json"))
+		assert.FileExists(t, filepath.Join(dataDir, "evidence/Access_Review_ET-0001_101/2025-Q1/.submitted/01_users.csv"))
+		assert.FileExists(t, filepath.Join(dataDir, "evidence/Access_Review_ET-0001_101/2025-Q2/.context/tool_outputs/github.json"))
+
+		// A second run finds nothing left
+		again, err := s.GarbageCollect(GCOptions{CacheMaxAge: 7 * 24 * time.Hour})
+		require.NoError(t, err)
+		assert.Empty(t, again.Items)
+	})
+
+	t.Run("orphaned task", func(t *testing.T) {
+		t.Parallel()
+		s, dataDir := gcFixture(t)
+		require.NoError(t, s.SaveEvidenceTask(testhelpers.SampleEvidenceTask()))
+
+		// Access_Review_ET-0001_101 no longer matches the synced task's directory
+		result, err := s.GarbageCollect(GCOptions{DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, GCKindToolOutputs, gcPaths(result.Items)["evidence/Access_Review_ET-0001_101/2025-Q2/.context/tool_outputs"])
+		assert.DirExists(t, filepath.Join(dataDir, "evidence/Access_Review_ET-0001_101/2025-Q2/.context/tool_outputs"))
+	})
+
+	t.Run("keeps tool outputs under legal hold", func(t *testing.T) {
+		t.Parallel()
+		s, dataDir := gcFixture(t)
+
+		result, err := s.GarbageCollect(GCOptions{Held: func(taskRef, window string) bool {
+			return taskRef == "ET-0001" && window == "2025-Q1"
+		}})
+		require.NoError(t, err)
+		assert.NotContains(t, gcPaths(result.Items), "evidence/Access_Review_ET-0001_101/2025-Q1/.context/tool_outputs")
+		assert.FileExists(t, filepath.Join(dataDir, "evidence/Access_Review_ET-0001_101/2025-Q1/.context/tool_outputs/github.json"))
+	})
+
+	t.Run("compress closed windows", func(t *testing.T) {
+		t.Parallel()
+		s, dataDir := gcFixture(t)
+		now := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+
+		result, err := s.GarbageCollect(GCOptions{Compress: true, WindowEnd: quarterEnd, Now: now})
+		require.NoError(t, err)
+		assert.Equal(t, GCKindCompressed, gcPaths(result.Items)["evidence/Access_Review_ET-0001_101/2025-Q1"])
+
+		// Q1 was accepted; Q2 still has working files
+		windowDir := filepath.Join(dataDir, "evidence/Access_Review_ET-0001_101/2025-Q1")
+		assert.NoDirExists(t, windowDir)
+		assert.DirExists(t, filepath.Join(dataDir, "evidence/Access_Review_ET-0001_101/2025-Q2"))
+
+		file, err := os.Open(windowDir + ".tar.gz")
+		require.NoError(t, err)
+		defer file.Close()
+		gz, err := gzip.NewReader(file)
+		require.NoError(t, err)
+		tr := tar.NewReader(gz)
+		var names []string
+		for {
+			header, err := tr.Next()
+			if err != nil {
+				break
+			}
+			if header.Typeflag == tar.TypeReg {
+				names = append(names, header.Name)
+			}
+		}
+		sort.Strings(names)
+		assert.Equal(t, []string{"2025-Q1/.submission/submission.yaml", "2025-Q1/.submitted/01_users.csv"}, names)
+	})
+
+	t.Run("skips locked windows", func(t *testing.T) {
+		t.Parallel()
+		s, dataDir := gcFixture(t)
+		lock, err := LockWindow(dataDir, "ET-0001", "2025-Q3")
+		require.NoError(t, err)
+		defer lock.Unlock()
+
+		result, err := s.GarbageCollect(GCOptions{})
+		require.NoError(t, err)
+		require.Len(t, result.Skipped, 1)
+		assert.Equal(t, "evidence/Access_Review_ET-0001_101/2025-Q3", result.Skipped[0].Path)
+		assert.DirExists(t, filepath.Join(dataDir, "evidence/Access_Review_ET-0001_101/2025-Q3"))
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	gcs "google.golang.org/api/storage/v1"
+)
+
+// GCSBackend stores objects in a Google Cloud Storage bucket, authenticating
+// with Application Default Credentials
+type GCSBackend struct {
+	bucket  string
+	prefix  string
+	service *gcs.Service
+}
+
+// NewGCSBackend returns a backend for a bucket and key prefix
+func NewGCSBackend(ctx context.Context, bucket, prefix string, opts ...option.ClientOption) (*GCSBackend, error) {
+	if len(opts) == 0 {
+		opts = []option.ClientOption{option.WithScopes(gcs.DevstorageReadWriteScope)}
+	}
+	service, err := gcs.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCS client: %w", err)
+	}
+	return &GCSBackend{bucket: bucket, prefix: prefix, service: service}, nil
+}
+
+func (b *GCSBackend) Name() string {
+	return "gs://" + objectKey(b.bucket, b.prefix)
+}
+
+func (b *GCSBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := b.service.Objects.Get(b.bucket, objectKey(b.prefix, key)).Context(ctx).Download()
+	if err != nil {
+		return nil, gcsError(err, "get "+key)
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+func (b *GCSBackend) Put(ctx context.Context, key string, data []byte) (ObjectInfo, error) {
+	obj, err := b.service.Objects.Insert(b.bucket, &gcs.Object{Name: objectKey(b.prefix, key)}).
+		Media(bytes.NewReader(data)).Context(ctx).Do()
+	if err != nil {
+		return ObjectInfo{}, gcsError(err, "put "+key)
+	}
+	return ObjectInfo{Key: key, Size: int64(obj.Size), Version: strconv.FormatInt(obj.Generation, 10)}, nil
+}
+
+func (b *GCSBackend) Delete(ctx context.Context, key string) error {
+	err := b.service.Objects.Delete(b.bucket, objectKey(b.prefix, key)).Context(ctx).Do()
+	if err != nil && !errors.Is(gcsError(err, ""), ErrObjectNotFound) {
+		return gcsError(err, "delete "+key)
+	}
+	return nil
+}
+
+func (b *GCSBackend) List(ctx context.Context) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	call := b.service.Objects.List(b.bucket).Fields("nextPageToken", "items(name,size,generation)")
+	if b.prefix != "" {
+		call = call.Prefix(b.prefix + "/")
+	}
+	err := call.Pages(ctx, func(page *gcs.Objects) error {
+		for _, obj := range page.Items {
+			key := obj.Name
+			if b.prefix != "" {
+				key = strings.TrimPrefix(key, b.prefix+"/")
+			}
+			if strings.HasSuffix(key, "/") {
+				continue // folder placeholder
+			}
+			objects = append(objects, ObjectInfo{Key: key, Size: int64(obj.Size), Version: strconv.FormatInt(obj.Generation, 10)})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, gcsError(err, "list")
+	}
+	return objects, nil
+}
+
+// gcsError maps a missing object to ErrObjectNotFound
+func gcsError(err error, op string) error {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound {
+		return fmt.Errorf("%s: %w", op, ErrObjectNotFound)
+	}
+	return fmt.Errorf("GCS %s failed: %w", op, err)
+}
@@ -0,0 +1,225 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/grctool/grctool/internal/config"
+)
+
+const (
+	// defaultGitBranch is the branch a new data_dir repository starts on
+	defaultGitBranch = "main"
+
+	// gitCommitFileLimit bounds the files listed in a commit message body
+	gitCommitFileLimit = 50
+
+	// gitStateDir holds daemon and scheduler state, which changes on every
+	// run and is not compliance data
+	gitStateDir = ".state"
+)
+
+// GitHistory commits what changes in data_dir to a git repository, so the
+// compliance data has a reviewable, revertible history. data_dir is
+// initialized as a repository of its own unless it already lies inside one;
+// either way only paths under data_dir are staged and committed, leaving the
+// cache, run state and the remote storage manifest out.
+type GitHistory struct {
+	dir         string
+	branch      string
+	authorName  string
+	authorEmail string
+	exclude     []string // slash-separated paths relative to dir
+}
+
+// NewGitHistory returns the git history for a storage configuration, or nil
+// when storage.git.enabled is off
+func NewGitHistory(cfg config.StorageConfig) *GitHistory {
+	if !cfg.Git.Enabled {
+		return nil
+	}
+	h := &GitHistory{
+		dir:         cfg.DataDir,
+		branch:      cfg.Git.Branch,
+		authorName:  cfg.Git.AuthorName,
+		authorEmail: cfg.Git.AuthorEmail,
+		exclude:     []string{gitStateDir, RemoteManifestName},
+	}
+	paths := cfg.Paths.WithDefaults().ResolveRelativeTo(cfg.DataDir)
+	if rel, err := filepath.Rel(cfg.DataDir, paths.Cache); err == nil && filepath.IsLocal(rel) {
+		h.exclude = append(h.exclude, filepath.ToSlash(rel))
+	}
+	return h
+}
+
+// Commit records every change under data_dir in one commit with subject as
+// its first line, returning the commit's short hash, or "" when nothing
+// changed
+func (h *GitHistory) Commit(ctx context.Context, subject string) (string, error) {
+	if err := h.ensureRepository(ctx); err != nil {
+		return "", err
+	}
+	pathspecs := h.pathspecs(ctx)
+
+	if _, err := h.git(ctx, nil, append([]string{"add", "--all", "--"}, pathspecs...)...); err != nil {
+		return "", err
+	}
+	changed, err := h.git(ctx, nil, append([]string{"diff", "--cached", "--name-status", "--relative", "--"}, pathspecs...)...)
+	if err != nil {
+		return "", err
+	}
+	if changed == "" {
+		return "", nil
+	}
+
+	env := h.identity(ctx)
+	args := []string{"commit", "--quiet", "--no-verify", "-m", subject, "-m", commitBody(changed), "--"}
+	if _, err := h.git(ctx, env, append(args, pathspecs...)...); err != nil {
+		return "", err
+	}
+	return h.git(ctx, nil, "rev-parse", "--short", "HEAD")
+}
+
+// ensureRepository initializes data_dir as a repository when it is not in
+// one, and checks a configured branch is the one checked out
+func (h *GitHistory) ensureRepository(ctx context.Context) error {
+	if _, err := h.git(ctx, nil, "rev-parse", "--git-dir"); err != nil {
+		if err := os.MkdirAll(h.dir, 0755); err != nil {
+			return fmt.Errorf("failed to create data directory: %w", err)
+		}
+		branch := h.branch
+		if branch == "" {
+			branch = defaultGitBranch
+		}
+		if _, err := h.git(ctx, nil, "init", "--quiet"); err != nil {
+			return err
+		}
+		if _, err := h.git(ctx, nil, "symbolic-ref", "HEAD", "refs/heads/"+branch); err != nil {
+			return err
+		}
+		return h.writeGitignore()
+	}
+
+	if h.branch == "" {
+		return nil
+	}
+	current, err := h.git(ctx, nil, "symbolic-ref", "--quiet", "--short", "HEAD")
+	if err != nil {
+		return fmt.Errorf("data directory repository is not on a branch (detached HEAD); storage.git.branch is %q", h.branch)
+	}
+	if current != h.branch {
+		return fmt.Errorf("data directory repository is on branch %q but storage.git.branch is %q; check out %q or change the setting", current, h.branch, h.branch)
+	}
+	return nil
+}
+
+// writeGitignore keeps the excluded paths out of `git status` in a
+// repository created for data_dir
+func (h *GitHistory) writeGitignore() error {
+	path := filepath.Join(h.dir, ".gitignore")
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	var b strings.Builder
+	b.WriteString("# Not compliance data; see storage.git in the grctool config\n")
+	for _, excluded := range h.exclude {
+		b.WriteString("/" + excluded + "\n")
+	}
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
+
+// pathspecs selects data_dir without the excluded paths. Paths a
+// .gitignore already covers are left out, as git refuses to add a pathspec
+// naming an ignored file even to exclude it.
+func (h *GitHistory) pathspecs(ctx context.Context) []string {
+	ignored, _ := h.git(ctx, nil, append([]string{"check-ignore", "--"}, h.exclude...)...)
+	ignoredSet := map[string]bool{}
+	for _, path := range strings.Split(ignored, "\n") {
+		ignoredSet[path] = true
+	}
+
+	specs := []string{"."}
+	for _, excluded := range h.exclude {
+		if !ignoredSet[excluded] {
+			specs = append(specs, ":(exclude)"+excluded)
+		}
+	}
+	return specs
+}
+
+// identity returns the environment setting the commit's author and
+// committer: the configured ones, else git's own, else grctool
+func (h *GitHistory) identity(ctx context.Context) []string {
+	name, email := h.authorName, h.authorEmail
+	if name == "" {
+		name, _ = h.git(ctx, nil, "config", "user.name")
+	}
+	if email == "" {
+		email, _ = h.git(ctx, nil, "config", "user.email")
+	}
+	if name == "" {
+		name = "grctool"
+	}
+	if email == "" {
+		email = "grctool@localhost"
+	}
+	return []string{
+		"GIT_AUTHOR_NAME=" + name, "GIT_AUTHOR_EMAIL=" + email,
+		"GIT_COMMITTER_NAME=" + name, "GIT_COMMITTER_EMAIL=" + email,
+	}
+}
+
+// git runs a git command in data_dir and returns its trimmed output
+func (h *GitHistory) git(ctx context.Context, env []string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = h.dir
+	cmd.Env = append(os.Environ(), env...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return "", fmt.Errorf("storage.git needs git installed: %w", err)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s failed: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s failed: %w", args[0], err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// commitBody lists the changed files from `git diff --name-status`
+func commitBody(nameStatus string) string {
+	lines := strings.Split(nameStatus, "\n")
+	var b strings.Builder
+	for i, line := range lines {
+		if i == gitCommitFileLimit {
+			fmt.Fprintf(&b, "... and %d more\n", len(lines)-i)
+			break
+		}
+		b.WriteString(strings.ReplaceAll(line, "\t", " ") + "\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
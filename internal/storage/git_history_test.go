@@ -0,0 +1,134 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func requireGit(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	return strings.TrimSpace(string(out))
+}
+
+func gitStorageConfig(dir string) config.StorageConfig {
+	return config.StorageConfig{
+		DataDir: dir,
+		Git: config.GitStorageConfig{
+			Enabled:     true,
+			Branch:      "compliance",
+			AuthorName:  "Compliance Bot",
+			AuthorEmail: "compliance@example.com",
+		},
+	}
+}
+
+func TestNewGitHistory_Disabled(t *testing.T) {
+	t.Parallel()
+	assert.Nil(t, NewGitHistory(config.StorageConfig{DataDir: t.TempDir()}))
+}
+
+func TestGitHistory_Commit(t *testing.T) {
+	t.Parallel()
+	requireGit(t)
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "data")
+	history := NewGitHistory(gitStorageConfig(dir))
+
+	writeTestFile(t, dir, "evidence/ET-0001/2025-Q1/report.md", "# evidence")
+	writeTestFile(t, dir, "evidence/ET-0001/2025-Q1/.submission/submission.yaml", "status: draft")
+	writeTestFile(t, dir, ".cache/tool/result.json", "cached")
+	writeTestFile(t, dir, ".state/daemon_state.yaml", "sources: {}")
+
+	hash, err := history.Commit(ctx, "grctool evidence generate ET-0001")
+	require.NoError(t, err)
+	assert.NotEmpty(t, hash)
+
+	assert.Equal(t, "compliance", runGit(t, dir, "symbolic-ref", "--short", "HEAD"), "a new repository starts on the configured branch")
+	assert.Equal(t, "Compliance Bot <compliance@example.com>", runGit(t, dir, "log", "-1", "--format=%an <%ae>"))
+	assert.Equal(t, "grctool evidence generate ET-0001", runGit(t, dir, "log", "-1", "--format=%s"))
+	assert.Contains(t, runGit(t, dir, "log", "-1", "--format=%b"), "A evidence/ET-0001/2025-Q1/report.md")
+
+	files := runGit(t, dir, "ls-files")
+	assert.Contains(t, files, "evidence/ET-0001/2025-Q1/.submission/submission.yaml")
+	assert.NotContains(t, files, ".cache/", "the cache is not committed")
+	assert.NotContains(t, files, ".state/", "run state is not committed")
+
+	// Nothing changed, nothing committed
+	hash, err = history.Commit(ctx, "grctool evidence list")
+	require.NoError(t, err)
+	assert.Empty(t, hash)
+
+	writeTestFile(t, dir, "evidence/ET-0001/2025-Q1/.submission/submission.yaml", "status: submitted")
+	hash, err = history.Commit(ctx, "grctool evidence submit ET-0001")
+	require.NoError(t, err)
+	assert.NotEmpty(t, hash)
+	assert.Equal(t, "2", runGit(t, dir, "rev-list", "--count", "HEAD"))
+}
+
+func TestGitHistory_CommitInsideExistingRepository(t *testing.T) {
+	t.Parallel()
+	requireGit(t)
+	ctx := context.Background()
+	repo := t.TempDir()
+	runGit(t, repo, "init", "--quiet")
+	runGit(t, repo, "symbolic-ref", "HEAD", "refs/heads/compliance")
+
+	// Someone has work staged outside data_dir
+	writeTestFile(t, repo, "main.tf", "resource {}")
+	runGit(t, repo, "add", "main.tf")
+
+	dir := filepath.Join(repo, "grc")
+	writeTestFile(t, dir, "docs/policies/json/POL-0001.json", "{}")
+	_, err := NewGitHistory(gitStorageConfig(dir)).Commit(ctx, "grctool sync")
+	require.NoError(t, err)
+
+	assert.Equal(t, "grc/docs/policies/json/POL-0001.json", runGit(t, repo, "show", "--name-only", "--format=", "HEAD"))
+	assert.Equal(t, "A  main.tf", runGit(t, repo, "status", "--short"), "staged work outside data_dir is left alone")
+	assert.NoFileExists(t, filepath.Join(dir, ".gitignore"), "an existing repository is not modified")
+}
+
+func TestGitHistory_BranchMismatch(t *testing.T) {
+	t.Parallel()
+	requireGit(t)
+	dir := t.TempDir()
+	runGit(t, dir, "init", "--quiet")
+	runGit(t, dir, "symbolic-ref", "HEAD", "refs/heads/feature")
+	writeTestFile(t, dir, "evidence/report.md", "# evidence")
+
+	_, err := NewGitHistory(gitStorageConfig(dir)).Commit(context.Background(), "grctool evidence generate")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `on branch "feature" but storage.git.branch is "compliance"`)
+}
+
+func TestCommitBody(t *testing.T) {
+	t.Parallel()
+	var lines []string
+	for i := 0; i < gitCommitFileLimit+3; i++ {
+		lines = append(lines, "M\tevidence/file.md")
+	}
+	body := commitBody(strings.Join(lines, "\n"))
+	assert.Equal(t, gitCommitFileLimit+1, strings.Count(body, "\n")+1)
+	assert.True(t, strings.HasSuffix(body, "... and 3 more"))
+	assert.True(t, strings.HasPrefix(body, "M evidence/file.md\n"))
+}
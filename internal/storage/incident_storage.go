@@ -0,0 +1,77 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/grctool/grctool/internal/domain"
+)
+
+// incidentsCollection is the docs subdirectory holding one JSON file per incident
+const incidentsCollection = "incidents"
+
+// incidentIDPrefix prefixes generated incident IDs (INC-0001)
+const incidentIDPrefix = "INC-"
+
+// SaveIncident validates and saves an incident in the incident log
+func (us *Storage) SaveIncident(incident *domain.Incident) error {
+	if incident == nil {
+		return fmt.Errorf("incident cannot be nil")
+	}
+	if incident.ID == "" {
+		return fmt.Errorf("incident ID cannot be empty")
+	}
+	if err := incident.Validate(); err != nil {
+		return err
+	}
+	return us.fileStorage.Save(incidentsCollection, incident.ID, incident)
+}
+
+// GetIncident retrieves an incident by ID, accepting INC-0001, inc-1 or 1
+func (us *Storage) GetIncident(id string) (*domain.Incident, error) {
+	var incident domain.Incident
+	if err := us.fileStorage.Load(incidentsCollection, normalizeSequentialID(incidentIDPrefix, id), &incident); err != nil {
+		return nil, fmt.Errorf("incident not found: %s", id)
+	}
+	return &incident, nil
+}
+
+// GetAllIncidents retrieves the incident log, sorted by ID
+func (us *Storage) GetAllIncidents() ([]domain.Incident, error) {
+	ids, err := us.fileStorage.List(incidentsCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	incidents := make([]domain.Incident, 0, len(ids))
+	for _, id := range ids {
+		var incident domain.Incident
+		if err := us.fileStorage.Load(incidentsCollection, id, &incident); err != nil {
+			return nil, fmt.Errorf("failed to load incident %s: %w", id, err)
+		}
+		incidents = append(incidents, incident)
+	}
+
+	sort.Slice(incidents, func(i, j int) bool { return incidents[i].ID < incidents[j].ID })
+	return incidents, nil
+}
+
+// NextIncidentID returns the ID after the highest one in the log
+func (us *Storage) NextIncidentID() (string, error) {
+	return us.fileStorage.NextSequentialID(incidentsCollection, incidentIDPrefix)
+}
@@ -0,0 +1,54 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncidentStorage(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+	store, err := NewStorage(config.StorageConfig{DataDir: dataDir})
+	require.NoError(t, err)
+
+	id, err := store.NextIncidentID()
+	require.NoError(t, err)
+	assert.Equal(t, "INC-0001", id)
+
+	detected := time.Date(2025, 9, 3, 10, 0, 0, 0, time.UTC)
+	incident := &domain.Incident{ID: id, Title: "API outage", Severity: domain.IncidentSeverityHigh,
+		Status: domain.IncidentStatusOpen, DetectedAt: detected}
+	require.NoError(t, store.SaveIncident(incident))
+	assert.FileExists(t, filepath.Join(dataDir, "docs", "incidents", "INC-0001.json"))
+
+	for _, ref := range []string{"INC-0001", "inc-1", "1"} {
+		loaded, err := store.GetIncident(ref)
+		require.NoError(t, err, ref)
+		assert.Equal(t, "API outage", loaded.Title)
+		assert.True(t, detected.Equal(loaded.DetectedAt))
+	}
+	_, err = store.GetIncident("INC-0002")
+	assert.ErrorContains(t, err, "incident not found")
+
+	id, err = store.NextIncidentID()
+	require.NoError(t, err)
+	assert.Equal(t, "INC-0002", id)
+
+	err = store.SaveIncident(&domain.Incident{ID: id, Title: "Closed without resolution", Severity: domain.IncidentSeverityLow,
+		Status: domain.IncidentStatusClosed, DetectedAt: detected})
+	assert.ErrorContains(t, err, "needs a resolution time")
+
+	incidents, err := store.GetAllIncidents()
+	require.NoError(t, err)
+	assert.Len(t, incidents, 1)
+}
@@ -0,0 +1,159 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// KMSDataKeyFile holds the evidence data key as wrapped by AWS KMS. It is
+// safe to commit and mirror: only principals allowed to decrypt with the KMS
+// key can unwrap it.
+const KMSDataKeyFile = ".grctool-datakey.json"
+
+type kmsDataKey struct {
+	KeyID          string `json:"kms_key_id"`
+	CiphertextBlob string `json:"ciphertext_blob"` // Base64
+}
+
+// kmsClient calls the AWS KMS JSON API, signed with the same credential
+// chain as the S3 backend
+type kmsClient struct {
+	keyID    string
+	region   string
+	endpoint string
+	client   *http.Client
+	creds    func(ctx context.Context) (awsCredentials, error)
+	now      func() time.Time
+}
+
+func newKMSClient(keyID string) *kmsClient {
+	region := ""
+	// arn:aws:kms:<region>:<account>:key/<id>
+	if parts := strings.Split(keyID, ":"); len(parts) >= 6 && parts[0] == "arn" {
+		region = parts[3]
+	}
+	region = awsRegion(region)
+	return &kmsClient{
+		keyID:    keyID,
+		region:   region,
+		endpoint: "https://kms." + region + ".amazonaws.com/",
+		client:   &http.Client{Timeout: 30 * time.Second},
+		creds:    newAWSCredentialChain(),
+		now:      time.Now,
+	}
+}
+
+// dataKey unwraps the data key stored at path, first generating and storing
+// one when there is none
+func (k *kmsClient) dataKey(ctx context.Context, path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return k.generateDataKey(ctx, path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var stored kmsDataKey
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+	var out struct {
+		Plaintext string `json:"Plaintext"`
+	}
+	in := map[string]string{"CiphertextBlob": stored.CiphertextBlob, "KeyId": k.keyID}
+	if err := k.call(ctx, "Decrypt", in, &out); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(out.Plaintext)
+}
+
+func (k *kmsClient) generateDataKey(ctx context.Context, path string) ([]byte, error) {
+	var out struct {
+		CiphertextBlob string `json:"CiphertextBlob"`
+		Plaintext      string `json:"Plaintext"`
+		KeyID          string `json:"KeyId"`
+	}
+	if err := k.call(ctx, "GenerateDataKey", map[string]string{"KeyId": k.keyID, "KeySpec": "AES_256"}, &out); err != nil {
+		return nil, err
+	}
+	key, err := base64.StdEncoding.DecodeString(out.Plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("KMS returned an invalid data key: %w", err)
+	}
+
+	data, err := json.MarshalIndent(kmsDataKey{KeyID: out.KeyID, CiphertextBlob: out.CiphertextBlob}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to store the KMS data key: %w", err)
+	}
+	return key, nil
+}
+
+// call invokes a KMS action, decoding its JSON response into out
+func (k *kmsClient) call(ctx context.Context, action string, in, out interface{}) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	creds, err := k.creds(ctx)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, k.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "TrentService."+action)
+	signV4(req, body, creds, k.region, "kms", k.now())
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("KMS %s failed: %w", action, err)
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(respBody, &e) == nil && e.Type != "" {
+			return fmt.Errorf("KMS %s failed: %s: %s", action, e.Type[strings.LastIndex(e.Type, "#")+1:], e.Message)
+		}
+		return fmt.Errorf("KMS %s failed with status %d", action, resp.StatusCode)
+	}
+	return json.Unmarshal(respBody, out)
+}
@@ -0,0 +1,83 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeKMS hands out one fixed data key, wrapped as "wrapped"
+func fakeKMS(t *testing.T, calls *[]string) *httptest.Server {
+	t.Helper()
+	plaintext := bytes.Repeat([]byte{7}, 32)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		action := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "TrentService.")
+		*calls = append(*calls, action)
+		assert.Contains(t, r.Header.Get("Authorization"), "/us-west-2/kms/aws4_request")
+
+		var in map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		switch action {
+		case "GenerateDataKey":
+			assert.Equal(t, "AES_256", in["KeySpec"])
+			json.NewEncoder(w).Encode(map[string]string{
+				"KeyId":          in["KeyId"],
+				"Plaintext":      base64.StdEncoding.EncodeToString(plaintext),
+				"CiphertextBlob": base64.StdEncoding.EncodeToString([]byte("wrapped")),
+			})
+		case "Decrypt":
+			if in["CiphertextBlob"] != base64.StdEncoding.EncodeToString([]byte("wrapped")) {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"__type":"com.amazonaws.kms#InvalidCiphertextException","message":"bad blob"}`))
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"Plaintext": base64.StdEncoding.EncodeToString(plaintext)})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+}
+
+func TestKMSClient_DataKey(t *testing.T) {
+	t.Parallel()
+	var calls []string
+	server := fakeKMS(t, &calls)
+	defer server.Close()
+
+	k := newKMSClient("arn:aws:kms:us-west-2:111122223333:key/1234abcd")
+	assert.Equal(t, "us-west-2", k.region, "the region comes from the key ARN")
+	k.endpoint = server.URL
+	k.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+	k.creds = func(ctx context.Context) (awsCredentials, error) {
+		return awsCredentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}, nil
+	}
+	path := filepath.Join(t.TempDir(), KMSDataKeyFile)
+
+	generated, err := k.dataKey(context.Background(), path)
+	require.NoError(t, err)
+	assert.Len(t, generated, 32)
+	assert.FileExists(t, path)
+
+	unwrapped, err := k.dataKey(context.Background(), path)
+	require.NoError(t, err)
+	assert.Equal(t, generated, unwrapped, "the stored data key is reused")
+	assert.Equal(t, []string{"GenerateDataKey", "Decrypt"}, calls)
+
+	writeTestFile(t, filepath.Dir(path), KMSDataKeyFile, `{"kms_key_id":"k","ciphertext_blob":"b3RoZXI="}`)
+	_, err = k.dataKey(context.Background(), path)
+	assert.EqualError(t, err, "KMS Decrypt failed: InvalidCiphertextException: bad blob")
+}
@@ -0,0 +1,149 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// lockDir holds the lock files of evidence windows. It is part of the run
+// state, so locks are never committed or taken from another machine.
+var lockDir = filepath.Join(gitStateDir, "locks")
+
+// errLockHeld is returned by tryLock when another open file holds the lock
+var errLockHeld = errors.New("lock held")
+
+// LockHolder identifies the grctool run holding a lock
+type LockHolder struct {
+	PID     int       `json:"pid"`
+	Host    string    `json:"host"`
+	Command string    `json:"command,omitempty"`
+	Since   time.Time `json:"since"`
+}
+
+// LockedError is returned when another grctool run holds the lock on an
+// evidence window
+type LockedError struct {
+	TaskRef string
+	Window  string
+	Holder  LockHolder // Zero when the holder could not be read
+}
+
+func (e *LockedError) Error() string {
+	if e.Holder.PID == 0 {
+		return fmt.Sprintf("evidence window %s %s is locked by another grctool run; try again when it finishes", e.TaskRef, e.Window)
+	}
+	by := fmt.Sprintf("PID %d on %s", e.Holder.PID, e.Holder.Host)
+	if e.Holder.Command != "" {
+		by += fmt.Sprintf(" (%s)", e.Holder.Command)
+	}
+	return fmt.Sprintf("evidence window %s %s is locked by %s since %s; try again when it finishes",
+		e.TaskRef, e.Window, by, e.Holder.Since.Local().Format("15:04:05"))
+}
+
+// WindowLock is an advisory lock on one task's evidence window, taken
+// around operations that read and rewrite its files or metadata. The
+// operating system releases it if grctool exits without unlocking.
+type WindowLock struct {
+	file *os.File
+}
+
+// LockWindow locks the evidence window of a task in dataDir, failing with a
+// *LockedError naming the holder when another run, or another operation in
+// this one, has it locked
+func LockWindow(dataDir, taskRef, window string) (*WindowLock, error) {
+	dir := filepath.Join(dataDir, lockDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+	path := filepath.Join(dir, lockFileName(taskRef, window))
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	if err := tryLock(file); err != nil {
+		file.Close()
+		if !errors.Is(err, errLockHeld) {
+			return nil, fmt.Errorf("failed to lock evidence window %s %s: %w", taskRef, window, err)
+		}
+		locked := &LockedError{TaskRef: taskRef, Window: window}
+		if data, err := os.ReadFile(path); err == nil {
+			_ = json.Unmarshal(data, &locked.Holder)
+		}
+		return nil, locked
+	}
+
+	host, _ := os.Hostname()
+	holder, _ := json.Marshal(LockHolder{PID: os.Getpid(), Host: host, Command: lockCommand(), Since: time.Now()})
+	if err := file.Truncate(0); err == nil {
+		_, _ = file.WriteAt(holder, 0)
+	}
+	return &WindowLock{file: file}, nil
+}
+
+// LockWindow locks the evidence window of a task; see LockWindow
+func (us *Storage) LockWindow(taskRef, window string) (*WindowLock, error) {
+	return LockWindow(us.localDataStore.GetBaseDir(), taskRef, window)
+}
+
+// Unlock releases the lock. It is safe to call on a nil lock and more than
+// once.
+func (l *WindowLock) Unlock() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+	_ = l.file.Truncate(0)
+	err := unlock(l.file)
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+	l.file = nil
+	return err
+}
+
+// lockFileName names a window's lock file after the task and window
+func lockFileName(taskRef, window string) string {
+	name := strings.ToUpper(taskRef) + "_" + window
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' || r == os.PathSeparator {
+			return '_'
+		}
+		return r
+	}, name) + ".lock"
+}
+
+// lockCommand describes this run for lock holders: the command without
+// flags, which may carry secrets
+func lockCommand() string {
+	if len(os.Args) == 0 {
+		return ""
+	}
+	words := []string{filepath.Base(os.Args[0])}
+	for _, arg := range os.Args[1:] {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		words = append(words, arg)
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockWindow(t *testing.T) {
+	t.Parallel()
+	dataDir := t.TempDir()
+
+	lock, err := LockWindow(dataDir, "ET-0001", "2025-Q1")
+	require.NoError(t, err)
+
+	_, err = LockWindow(dataDir, "et-0001", "2025-Q1")
+	var locked *LockedError
+	require.True(t, errors.As(err, &locked), "got %v", err)
+	assert.Equal(t, os.Getpid(), locked.Holder.PID)
+	assert.NotEmpty(t, locked.Holder.Host)
+	assert.WithinDuration(t, time.Now(), locked.Holder.Since, time.Minute)
+	assert.Contains(t, err.Error(), "evidence window et-0001 2025-Q1 is locked by PID")
+
+	other, err := LockWindow(dataDir, "ET-0001", "2025-Q2")
+	require.NoError(t, err, "other windows are not locked")
+	require.NoError(t, other.Unlock())
+
+	require.NoError(t, lock.Unlock())
+	require.NoError(t, lock.Unlock(), "unlocking twice is harmless")
+	assert.NoError(t, (*WindowLock)(nil).Unlock())
+
+	lock, err = LockWindow(dataDir, "ET-0001", "2025-Q1")
+	require.NoError(t, err, "an unlocked window can be locked again")
+	require.NoError(t, lock.Unlock())
+	assert.FileExists(t, filepath.Join(dataDir, ".state", "locks", "ET-0001_2025-Q1.lock"))
+}
+
+func TestLockedError(t *testing.T) {
+	t.Parallel()
+	since := time.Date(2025, 3, 1, 9, 30, 0, 0, time.Local)
+
+	err := &LockedError{TaskRef: "ET-0001", Window: "2025-Q1", Holder: LockHolder{PID: 4242, Host: "ci-runner", Command: "grctool evidence submit ET-0001", Since: since}}
+	assert.Equal(t, "evidence window ET-0001 2025-Q1 is locked by PID 4242 on ci-runner (grctool evidence submit ET-0001) since 09:30:00; try again when it finishes", err.Error())
+
+	err = &LockedError{TaskRef: "ET-0001", Window: "2025-Q1"}
+	assert.Equal(t, "evidence window ET-0001 2025-Q1 is locked by another grctool run; try again when it finishes", err.Error())
+}
+
+func TestRecordSubmissionReview_Locked(t *testing.T) {
+	t.Parallel()
+	dataDir := t.TempDir()
+	store, err := NewStorage(config.StorageConfig{DataDir: dataDir, Paths: config.StoragePaths{}.WithDefaults()})
+	require.NoError(t, err)
+
+	lock, err := store.LockWindow("ET-0001", "2025-Q1")
+	require.NoError(t, err)
+	_, err = store.RecordSubmissionReview("ET-0001", "2025-Q1", models.TugboatSubmissionResponse{Status: "accepted", ReceivedAt: time.Now()})
+	var locked *LockedError
+	assert.True(t, errors.As(err, &locked))
+
+	require.NoError(t, lock.Unlock())
+	_, err = store.RecordSubmissionReview("ET-0001", "2025-Q1", models.TugboatSubmissionResponse{Status: "accepted", ReceivedAt: time.Now()})
+	assert.NoError(t, err)
+}
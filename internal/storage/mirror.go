@@ -0,0 +1,360 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/grctool/grctool/internal/config"
+)
+
+// RemoteManifestName is the file in data_dir recording what was last synced
+const RemoteManifestName = ".grctool-remote.json"
+
+// mirrorWorkers bounds concurrent transfers
+const mirrorWorkers = 8
+
+// Mirror keeps data_dir and a remote Backend in step. data_dir stays the
+// local cache every read is served from: Pull fetches what changed remotely
+// and Push uploads what changed locally. A manifest of content hashes and
+// remote versions from the last sync tells the two apart, so a file changed
+// on both sides is reported as a conflict and the local copy kept.
+type Mirror struct {
+	backend Backend
+	dir     string
+	exclude []string // slash-separated directories relative to dir
+}
+
+// SyncResult reports what a Pull or Push did, or would do on a dry run
+type SyncResult struct {
+	Transferred []string `json:"transferred"`
+	Unchanged   int      `json:"unchanged"`
+	Conflicts   []string `json:"conflicts,omitempty"`
+}
+
+type manifestEntry struct {
+	Hash    string `json:"hash"`
+	Version string `json:"version"`
+}
+
+type remoteManifest struct {
+	Remote  string                   `json:"remote"`
+	Objects map[string]manifestEntry `json:"objects"`
+}
+
+// NewMirror returns the mirror for a storage configuration, or nil when no
+// storage.remote.url is set. The cache directory, lock files and catalog
+// are not mirrored.
+func NewMirror(ctx context.Context, cfg config.StorageConfig) (*Mirror, error) {
+	if cfg.Remote.URL == "" {
+		return nil, nil
+	}
+	backend, err := NewBackend(ctx, cfg.Remote)
+	if err != nil {
+		return nil, err
+	}
+	paths := cfg.Paths.WithDefaults().ResolveRelativeTo(cfg.DataDir)
+	return NewMirrorWithBackend(cfg.DataDir, backend, paths.Cache, filepath.Join(cfg.DataDir, lockDir), filepath.Join(cfg.DataDir, catalogDir)), nil
+}
+
+// NewMirrorWithBackend mirrors dir to backend, leaving out the excluded
+// directories
+func NewMirrorWithBackend(dir string, backend Backend, exclude ...string) *Mirror {
+	m := &Mirror{backend: backend, dir: dir}
+	for _, path := range exclude {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		if rel, err := filepath.Rel(dir, path); err == nil && filepath.IsLocal(rel) {
+			m.exclude = append(m.exclude, filepath.ToSlash(rel))
+		}
+	}
+	return m
+}
+
+// Remote returns the name of the mirrored backend
+func (m *Mirror) Remote() string {
+	return m.backend.Name()
+}
+
+// Push uploads files added or changed locally since the last sync
+func (m *Mirror) Push(ctx context.Context, dryRun bool) (*SyncResult, error) {
+	manifest := m.loadManifest()
+	local, err := m.localHashes()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SyncResult{}
+	var pending []string
+	for _, key := range sortedKeys(local) {
+		if manifest.Objects[key].Hash == local[key] {
+			result.Unchanged++
+			continue
+		}
+		pending = append(pending, key)
+	}
+	if dryRun {
+		result.Transferred = pending
+		return result, nil
+	}
+
+	var mu sync.Mutex
+	err = forEach(ctx, pending, func(key string) error {
+		data, err := os.ReadFile(m.localPath(key))
+		if err != nil {
+			return err
+		}
+		info, err := m.backend.Put(ctx, key, data)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		manifest.Objects[key] = manifestEntry{Hash: local[key], Version: info.Version}
+		result.Transferred = append(result.Transferred, key)
+		return nil
+	})
+	sort.Strings(result.Transferred)
+	if saveErr := m.saveManifest(manifest); err == nil {
+		err = saveErr
+	}
+	return result, err
+}
+
+// Pull downloads objects added or changed remotely since the last sync
+func (m *Mirror) Pull(ctx context.Context, dryRun bool) (*SyncResult, error) {
+	manifest := m.loadManifest()
+	objects, err := m.backend.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	local, err := m.localHashes()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SyncResult{}
+	var pending []ObjectInfo
+	for _, obj := range objects {
+		if !m.mirrored(obj.Key) {
+			continue
+		}
+		if entry, ok := manifest.Objects[obj.Key]; ok && entry.Version == obj.Version {
+			result.Unchanged++
+			continue
+		}
+		pending = append(pending, obj)
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].Key < pending[j].Key })
+
+	if dryRun {
+		for _, obj := range pending {
+			if hash, ok := local[obj.Key]; ok && hash != manifest.Objects[obj.Key].Hash {
+				result.Conflicts = append(result.Conflicts, obj.Key)
+			} else {
+				result.Transferred = append(result.Transferred, obj.Key)
+			}
+		}
+		return result, nil
+	}
+
+	var mu sync.Mutex
+	keys := make([]string, len(pending))
+	versions := make(map[string]string, len(pending))
+	for i, obj := range pending {
+		keys[i] = obj.Key
+		versions[obj.Key] = obj.Version
+	}
+	err = forEach(ctx, keys, func(key string) error {
+		data, err := m.backend.Get(ctx, key)
+		if errors.Is(err, ErrObjectNotFound) {
+			return nil // deleted since it was listed
+		}
+		if err != nil {
+			return err
+		}
+		hash := sha256Hex(data)
+
+		mu.Lock()
+		localHash, exists := local[key]
+		synced := manifest.Objects[key].Hash
+		mu.Unlock()
+
+		changedLocally := exists && localHash != synced
+		if changedLocally && localHash != hash {
+			mu.Lock()
+			result.Conflicts = append(result.Conflicts, key)
+			mu.Unlock()
+			return nil
+		}
+		if !exists || localHash != hash {
+			path := m.localPath(key)
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return err
+			}
+			if err := os.WriteFile(path, data, 0644); err != nil {
+				return err
+			}
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		manifest.Objects[key] = manifestEntry{Hash: hash, Version: versions[key]}
+		result.Transferred = append(result.Transferred, key)
+		return nil
+	})
+	sort.Strings(result.Transferred)
+	sort.Strings(result.Conflicts)
+	if saveErr := m.saveManifest(manifest); err == nil {
+		err = saveErr
+	}
+	return result, err
+}
+
+// mirrored reports whether a key is one the mirror manages: inside data_dir,
+// outside the excluded directories and any git repository, and not the
+// manifest itself
+func (m *Mirror) mirrored(key string) bool {
+	if key == RemoteManifestName || key == ".git" || strings.HasPrefix(key, ".git/") || !filepath.IsLocal(filepath.FromSlash(key)) {
+		return false
+	}
+	for _, dir := range m.exclude {
+		if key == dir || strings.HasPrefix(key, dir+"/") {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *Mirror) localPath(key string) string {
+	return filepath.Join(m.dir, filepath.FromSlash(key))
+}
+
+// localHashes returns the content hash of each mirrored file in data_dir
+func (m *Mirror) localHashes() (map[string]string, error) {
+	hashes := map[string]string{}
+	err := filepath.WalkDir(m.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		rel, err := filepath.Rel(m.dir, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !m.mirrored(key) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		hashes[key] = sha256Hex(data)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", m.dir, err)
+	}
+	return hashes, nil
+}
+
+// loadManifest reads the last sync's manifest, starting afresh when it is
+// missing or was written for another remote
+func (m *Mirror) loadManifest() *remoteManifest {
+	manifest := &remoteManifest{}
+	if data, err := os.ReadFile(filepath.Join(m.dir, RemoteManifestName)); err == nil {
+		_ = json.Unmarshal(data, manifest)
+	}
+	if manifest.Remote != m.backend.Name() || manifest.Objects == nil {
+		manifest = &remoteManifest{Remote: m.backend.Name(), Objects: map[string]manifestEntry{}}
+	}
+	return manifest
+}
+
+func (m *Mirror) saveManifest(manifest *remoteManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(m.dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(m.dir, RemoteManifestName), data, 0644)
+}
+
+// forEach runs fn over keys with bounded concurrency, returning the first
+// error once every started call has finished
+func forEach(ctx context.Context, keys []string, fn func(key string) error) error {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	sem := make(chan struct{}, mirrorWorkers)
+	for _, key := range keys {
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed || ctx.Err() != nil {
+			break
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(key string) {
+			defer func() { <-sem; wg.Done() }()
+			if err := fn(key); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("%s: %w", key, err)
+				}
+				mu.Unlock()
+			}
+		}(key)
+	}
+	wg.Wait()
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	return firstErr
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,183 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryBackend is a Backend held in memory. Each write bumps the object's
+// version, like an S3 ETag or GCS generation would change.
+type memoryBackend struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	versions map[string]int
+	puts     int
+}
+
+func newMemoryBackend() *memoryBackend {
+	return &memoryBackend{objects: map[string][]byte{}, versions: map[string]int{}}
+}
+
+func (b *memoryBackend) Name() string { return "memory://bucket" }
+
+func (b *memoryBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	data, ok := b.objects[key]
+	if !ok {
+		return nil, ErrObjectNotFound
+	}
+	return data, nil
+}
+
+func (b *memoryBackend) Put(ctx context.Context, key string, data []byte) (ObjectInfo, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.puts++
+	b.objects[key] = data
+	b.versions[key]++
+	return ObjectInfo{Key: key, Size: int64(len(data)), Version: fmt.Sprint(b.versions[key])}, nil
+}
+
+func (b *memoryBackend) Delete(ctx context.Context, key string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.objects, key)
+	return nil
+}
+
+func (b *memoryBackend) List(ctx context.Context) ([]ObjectInfo, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var objects []ObjectInfo
+	for key, data := range b.objects {
+		objects = append(objects, ObjectInfo{Key: key, Size: int64(len(data)), Version: fmt.Sprint(b.versions[key])})
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+func writeTestFile(t *testing.T, dir, rel, content string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(rel))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func readTestFile(t *testing.T, dir, rel string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(rel)))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestMirror_PushAndPull(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	backend := newMemoryBackend()
+
+	// A developer's workstation pushes synced docs and evidence
+	laptop := t.TempDir()
+	writeTestFile(t, laptop, "docs/policies/json/POL-0001.json", `{"id":"1"}`)
+	writeTestFile(t, laptop, "evidence/ET-0001/2025-Q1/report.md", "# evidence")
+	writeTestFile(t, laptop, ".cache/tool/result.json", "cached")
+	laptopMirror := NewMirrorWithBackend(laptop, backend, ".cache")
+
+	result, err := laptopMirror.Push(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/policies/json/POL-0001.json", "evidence/ET-0001/2025-Q1/report.md"}, result.Transferred)
+	assert.NotContains(t, backend.objects, ".cache/tool/result.json", "the cache is not mirrored")
+
+	result, err = laptopMirror.Push(ctx, false)
+	require.NoError(t, err)
+	assert.Empty(t, result.Transferred)
+	assert.Equal(t, 2, result.Unchanged)
+	assert.Equal(t, 2, backend.puts, "unchanged files are not uploaded again")
+
+	// An ephemeral CI runner starts empty and pulls everything
+	runner := t.TempDir()
+	runnerMirror := NewMirrorWithBackend(runner, backend, ".cache")
+	result, err = runnerMirror.Pull(ctx, false)
+	require.NoError(t, err)
+	assert.Len(t, result.Transferred, 2)
+	assert.Equal(t, "# evidence", readTestFile(t, runner, "evidence/ET-0001/2025-Q1/report.md"))
+
+	// The runner adds evidence and pushes; the laptop pulls it
+	writeTestFile(t, runner, "evidence/ET-0002/2025-Q1/report.md", "# more evidence")
+	result, err = runnerMirror.Push(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"evidence/ET-0002/2025-Q1/report.md"}, result.Transferred)
+
+	result, err = laptopMirror.Pull(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"evidence/ET-0002/2025-Q1/report.md"}, result.Transferred)
+	assert.Equal(t, 2, result.Unchanged)
+	assert.Equal(t, "# more evidence", readTestFile(t, laptop, "evidence/ET-0002/2025-Q1/report.md"))
+}
+
+func TestMirror_PullConflict(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	backend := newMemoryBackend()
+
+	a, b := t.TempDir(), t.TempDir()
+	writeTestFile(t, a, "docs/controls/json/AC-01.json", "v1")
+	mirrorA := NewMirrorWithBackend(a, backend)
+	mirrorB := NewMirrorWithBackend(b, backend)
+	_, err := mirrorA.Push(ctx, false)
+	require.NoError(t, err)
+	_, err = mirrorB.Pull(ctx, false)
+	require.NoError(t, err)
+
+	// Both sides change the same file
+	writeTestFile(t, a, "docs/controls/json/AC-01.json", "v2 from a")
+	_, err = mirrorA.Push(ctx, false)
+	require.NoError(t, err)
+	writeTestFile(t, b, "docs/controls/json/AC-01.json", "v2 from b")
+
+	preview, err := mirrorB.Pull(ctx, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/controls/json/AC-01.json"}, preview.Conflicts)
+
+	result, err := mirrorB.Pull(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/controls/json/AC-01.json"}, result.Conflicts)
+	assert.Empty(t, result.Transferred)
+	assert.Equal(t, "v2 from b", readTestFile(t, b, "docs/controls/json/AC-01.json"), "the local copy is kept")
+}
+
+func TestMirror_DryRunAndUnsafeKeys(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	backend := newMemoryBackend()
+	_, err := backend.Put(ctx, "../outside.txt", []byte("escape"))
+	require.NoError(t, err)
+	_, err = backend.Put(ctx, RemoteManifestName, []byte("{}"))
+	require.NoError(t, err)
+	_, err = backend.Put(ctx, "docs/new.json", []byte("{}"))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	mirror := NewMirrorWithBackend(dir, backend)
+
+	preview, err := mirror.Pull(ctx, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/new.json"}, preview.Transferred)
+	assert.NoFileExists(t, filepath.Join(dir, "docs", "new.json"), "a dry run changes nothing")
+
+	_, err = mirror.Pull(ctx, false)
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(filepath.Dir(dir), "outside.txt"))
+	assert.FileExists(t, filepath.Join(dir, "docs", "new.json"))
+}
@@ -0,0 +1,382 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/naming"
+)
+
+// Retention actions
+const (
+	RetentionArchived = "archived" // Window packed into an archive, or its archive moved to cold storage
+	RetentionDeleted  = "deleted"  // Window or archive past its retention period removed
+	RetentionHeld     = "held"     // Past its retention period, but kept under a legal hold
+)
+
+// RetentionOptions controls applying the retention policy
+type RetentionOptions struct {
+	DryRun bool                   // List what would change without changing it
+	Policy config.RetentionConfig // Periods, cold storage and legal holds
+
+	// WindowEnd returns when a window's collection period ends; windows it
+	// cannot parse are left alone
+	WindowEnd func(window string) (time.Time, error)
+	Now       time.Time // Reference time (default: time.Now)
+}
+
+// RetentionItem is one window, directory or archive, retention archived,
+// deleted or kept under a legal hold
+type RetentionItem struct {
+	Action  string `json:"action" yaml:"action"`
+	TaskRef string `json:"task_ref" yaml:"task_ref"`
+	Window  string `json:"window" yaml:"window"`
+	Path    string `json:"path" yaml:"path"` // Relative to data_dir, or absolute in cold storage
+	Bytes   int64  `json:"bytes" yaml:"bytes"`
+	Reason  string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// RetentionResult lists what applying retention did, or would do in a dry run
+type RetentionResult struct {
+	DryRun     bool            `json:"dry_run" yaml:"dry_run"`
+	Items      []RetentionItem `json:"items" yaml:"items"`
+	Skipped    []RetentionItem `json:"skipped,omitempty" yaml:"skipped,omitempty"` // Windows locked by another run, or archived before
+	FreedBytes int64           `json:"freed_bytes" yaml:"freed_bytes"`
+}
+
+// ApplyRetention enforces the retention policy on every evidence window:
+// windows that ended longer ago than their task's keep_for are deleted,
+// with their archives, unless a legal hold covers them; windows that ended
+// longer ago than archive_after are packed into <window>.tar.gz, in
+// archive_dir when set, and archives gc left beside windows move there too.
+func (us *Storage) ApplyRetention(opts RetentionOptions) (*RetentionResult, error) {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	r := &retention{
+		dataDir: us.localDataStore.GetBaseDir(),
+		opts:    opts,
+		result:  &RetentionResult{DryRun: opts.DryRun, Items: []RetentionItem{}},
+	}
+	if opts.WindowEnd == nil {
+		return r.result, nil
+	}
+
+	// Task directories under evidence, and in cold storage those whose
+	// windows were all archived there
+	taskDirs := map[string]bool{}
+	for _, dir := range []string{us.paths.Evidence, opts.Policy.ArchiveDir} {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return r.result, fmt.Errorf("failed to read %s: %w", dir, err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() && naming.ExtractTaskRef(entry.Name()) != "" {
+				taskDirs[entry.Name()] = true
+			}
+		}
+	}
+	names := make([]string, 0, len(taskDirs))
+	for name := range taskDirs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		taskRef := naming.ExtractTaskRef(name)
+		keepFor, archiveAfter := opts.Policy.PeriodsFor(taskRef, us.EvidenceTaskCategory(taskRef))
+		if keepFor == "" && archiveAfter == "" {
+			continue
+		}
+		task := retentionTask{ref: taskRef, dir: filepath.Join(us.paths.Evidence, name), keepFor: keepFor, archiveAfter: archiveAfter}
+		if opts.Policy.ArchiveDir != "" {
+			task.coldDir = filepath.Join(opts.Policy.ArchiveDir, name)
+		}
+		var err error
+		if keepFor != "" {
+			if task.deleteBefore, err = config.RetentionCutoff(keepFor, opts.Now); err != nil {
+				return r.result, err
+			}
+		}
+		if archiveAfter != "" {
+			if task.archiveBefore, err = config.RetentionCutoff(archiveAfter, opts.Now); err != nil {
+				return r.result, err
+			}
+		}
+		for _, window := range task.windows() {
+			if err := r.applyWindow(task, window); err != nil {
+				return r.result, err
+			}
+		}
+	}
+
+	for _, item := range r.result.Items {
+		if item.Action != RetentionHeld {
+			r.result.FreedBytes += item.Bytes
+		}
+	}
+	return r.result, nil
+}
+
+type retention struct {
+	dataDir string
+	opts    RetentionOptions
+	result  *RetentionResult
+}
+
+// retentionTask is a task directory with the retention periods that apply
+// to it
+type retentionTask struct {
+	ref           string
+	dir           string // Under the evidence directory
+	coldDir       string // Under archive_dir, if set
+	keepFor       string
+	archiveAfter  string
+	deleteBefore  time.Time // Zero when kept forever
+	archiveBefore time.Time // Zero when never archived
+}
+
+// windows lists the windows of the task, whether directories or archives
+// beside them or in cold storage
+func (t retentionTask) windows() []string {
+	seen := map[string]bool{}
+	for _, dir := range []string{t.dir, t.coldDir} {
+		if dir == "" {
+			continue
+		}
+		entries, _ := os.ReadDir(dir)
+		for _, entry := range entries {
+			name := entry.Name()
+			if strings.HasPrefix(name, ".") {
+				continue
+			}
+			if entry.IsDir() && dir == t.dir && name != "metadata" {
+				seen[name] = true
+			} else if entry.Type().IsRegular() && strings.HasSuffix(name, ".tar.gz") {
+				seen[strings.TrimSuffix(name, ".tar.gz")] = true
+			}
+		}
+	}
+	windows := make([]string, 0, len(seen))
+	for window := range seen {
+		windows = append(windows, window)
+	}
+	sort.Strings(windows)
+	return windows
+}
+
+func (r *retention) applyWindow(task retentionTask, window string) error {
+	end, err := r.opts.WindowEnd(window)
+	if err != nil {
+		return nil
+	}
+	deleteDue := !task.deleteBefore.IsZero() && end.Before(task.deleteBefore)
+	archiveDue := !task.archiveBefore.IsZero() && end.Before(task.archiveBefore)
+	if !deleteDue && !archiveDue {
+		return nil
+	}
+
+	windowDir := filepath.Join(task.dir, window)
+	localArchive := windowDir + ".tar.gz"
+	coldArchive := ""
+	if task.coldDir != "" {
+		coldArchive = filepath.Join(task.coldDir, window+".tar.gz")
+	}
+	item := RetentionItem{TaskRef: task.ref, Window: window}
+
+	// Held evidence is never deleted, though it may still be archived
+	if hold := r.opts.Policy.HoldOn(task.ref, window); deleteDue && hold != nil {
+		held := item
+		held.Action = RetentionHeld
+		held.Reason = fmt.Sprintf("past %s retention, but under legal hold %s: %s", task.keepFor, hold.Name, hold.Reason)
+		for _, path := range []string{windowDir, localArchive, coldArchive} {
+			if _, err := os.Stat(path); path != "" && err == nil {
+				held.Path = r.rel(path)
+				break
+			}
+		}
+		r.result.Items = append(r.result.Items, held)
+		if !archiveDue {
+			return nil
+		}
+		deleteDue = false
+	}
+
+	lock, err := LockWindow(r.dataDir, task.ref, window)
+	var locked *LockedError
+	if errors.As(err, &locked) {
+		item.Path = r.rel(windowDir)
+		item.Reason = err.Error()
+		r.result.Skipped = append(r.result.Skipped, item)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	if deleteDue {
+		item.Action = RetentionDeleted
+		item.Reason = fmt.Sprintf("ended %s, more than %s ago", end.Format("2006-01-02"), task.keepFor)
+		for _, path := range []string{windowDir, localArchive, coldArchive} {
+			if path == "" {
+				continue
+			}
+			info, err := os.Stat(path)
+			if err != nil {
+				continue
+			}
+			item.Path, item.Bytes = r.rel(path), info.Size()
+			if info.IsDir() {
+				_, item.Bytes = countFiles(path)
+			}
+			if !r.opts.DryRun {
+				if err := os.RemoveAll(path); err != nil {
+					return fmt.Errorf("failed to remove %s: %w", item.Path, err)
+				}
+			}
+			r.result.Items = append(r.result.Items, item)
+		}
+		return nil
+	}
+
+	item.Action = RetentionArchived
+	archive := localArchive
+	if coldArchive != "" {
+		archive = coldArchive
+	}
+	if info, err := os.Stat(windowDir); err == nil && info.IsDir() {
+		return r.archive(item, windowDir, archive, end, task.archiveAfter)
+	}
+	if coldArchive != "" {
+		if _, err := os.Stat(localArchive); err == nil {
+			return r.moveArchive(item, localArchive, coldArchive)
+		}
+	}
+	return nil
+}
+
+// archive packs a window directory into archive and removes the directory
+func (r *retention) archive(item RetentionItem, windowDir, archive string, end time.Time, archiveAfter string) error {
+	item.Path = r.rel(windowDir)
+	item.Reason = fmt.Sprintf("ended %s, more than %s ago; packed into %s", end.Format("2006-01-02"), archiveAfter, r.rel(archive))
+	if _, err := os.Stat(archive); err == nil {
+		item.Reason = r.rel(archive) + " already exists; reconcile it with the window by hand"
+		r.result.Skipped = append(r.result.Skipped, item)
+		return nil
+	}
+	_, size := countFiles(windowDir)
+	if r.opts.DryRun {
+		item.Bytes = size
+		r.result.Items = append(r.result.Items, item)
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(archive), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(archive), err)
+	}
+	if err := writeTarGz(archive, windowDir); err != nil {
+		os.Remove(archive)
+		return fmt.Errorf("failed to archive %s: %w", item.Path, err)
+	}
+	item.Bytes = size
+	if filepath.Dir(archive) == filepath.Dir(windowDir) {
+		if info, err := os.Stat(archive); err == nil {
+			item.Bytes = size - info.Size()
+		}
+	}
+	if err := os.RemoveAll(windowDir); err != nil {
+		return fmt.Errorf("failed to remove %s after archiving it: %w", item.Path, err)
+	}
+	r.result.Items = append(r.result.Items, item)
+	return nil
+}
+
+// moveArchive moves an archive gc left beside its window to cold storage
+func (r *retention) moveArchive(item RetentionItem, from, to string) error {
+	item.Path = r.rel(from)
+	item.Reason = "moved to " + to
+	if _, err := os.Stat(to); err == nil {
+		item.Reason = to + " already exists; reconcile it with " + item.Path + " by hand"
+		r.result.Skipped = append(r.result.Skipped, item)
+		return nil
+	}
+	if info, err := os.Stat(from); err == nil {
+		item.Bytes = info.Size()
+	}
+	if !r.opts.DryRun {
+		if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", filepath.Dir(to), err)
+		}
+		if err := moveFile(from, to); err != nil {
+			return fmt.Errorf("failed to move %s to cold storage: %w", item.Path, err)
+		}
+	}
+	r.result.Items = append(r.result.Items, item)
+	return nil
+}
+
+func (r *retention) rel(path string) string {
+	if rel, err := filepath.Rel(r.dataDir, path); err == nil && filepath.IsLocal(rel) {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
+
+// moveFile renames from to to, copying it when they are on different
+// filesystems
+func moveFile(from, to string) error {
+	if err := os.Rename(from, to); err == nil {
+		return nil
+	}
+	src, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(to, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		os.Remove(to)
+		return err
+	}
+	if err := dst.Sync(); err != nil {
+		dst.Close()
+		os.Remove(to)
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(to)
+		return err
+	}
+	return os.Remove(from)
+}
@@ -0,0 +1,164 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func retentionActions(items []RetentionItem) map[string]string {
+	actions := make(map[string]string)
+	for _, item := range items {
+		actions[item.Path] = item.Action
+	}
+	return actions
+}
+
+// retentionFixture lays out windows of 2017, 2022 and 2025 for two tasks,
+// one of them archived by gc
+func retentionFixture(t *testing.T) (*Storage, string) {
+	t.Helper()
+	s := newTestStorage(t)
+	dataDir := s.localDataStore.GetBaseDir()
+
+	writeTestFile(t, dataDir, "evidence/Access_Review_ET-0001_101/2017-Q4/.submitted/01_users.csv", "user,mfa\n")
+	writeTestFile(t, dataDir, "evidence/Access_Review_ET-0001_101/2022-Q2/.submitted/01_users.csv", "user,mfa\n")
+	writeTestFile(t, dataDir, "evidence/Access_Review_ET-0001_101/2025-Q3/01_users.csv", "user,mfa\n")
+	writeTestFile(t, dataDir, "evidence/Access_Review_ET-0001_101/metadata/notes.md", "notes\n")
+	writeTestFile(t, dataDir, "evidence/Firewall_Rules_ET-0002_102/2017-Q3.tar.gz", "archive")
+	writeTestFile(t, dataDir, "evidence/Firewall_Rules_ET-0002_102/2022-Q1.tar.gz", "archive")
+	return s, dataDir
+}
+
+func TestStorage_ApplyRetention(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("dry run", func(t *testing.T) {
+		t.Parallel()
+		s, dataDir := retentionFixture(t)
+
+		result, err := s.ApplyRetention(RetentionOptions{
+			DryRun:    true,
+			Policy:    config.RetentionConfig{KeepFor: "7y", ArchiveAfter: "2y"},
+			WindowEnd: quarterEnd,
+			Now:       now,
+		})
+		require.NoError(t, err)
+		assert.True(t, result.DryRun)
+		assert.Equal(t, map[string]string{
+			"evidence/Access_Review_ET-0001_101/2017-Q4":         RetentionDeleted,
+			"evidence/Access_Review_ET-0001_101/2022-Q2":         RetentionArchived,
+			"evidence/Firewall_Rules_ET-0002_102/2017-Q3.tar.gz": RetentionDeleted,
+		}, retentionActions(result.Items))
+		assert.Positive(t, result.FreedBytes)
+
+		// Nothing changes
+		assert.DirExists(t, filepath.Join(dataDir, "evidence/Access_Review_ET-0001_101/2017-Q4"))
+		assert.FileExists(t, filepath.Join(dataDir, "evidence/Firewall_Rules_ET-0002_102/2017-Q3.tar.gz"))
+		assert.NoFileExists(t, filepath.Join(dataDir, "evidence/Access_Review_ET-0001_101/2022-Q2.tar.gz"))
+	})
+
+	t.Run("archives and deletes", func(t *testing.T) {
+		t.Parallel()
+		s, dataDir := retentionFixture(t)
+		policy := config.RetentionConfig{KeepFor: "7y", ArchiveAfter: "2y"}
+
+		result, err := s.ApplyRetention(RetentionOptions{Policy: policy, WindowEnd: quarterEnd, Now: now})
+		require.NoError(t, err)
+		assert.Len(t, result.Items, 3)
+
+		taskDir := filepath.Join(dataDir, "evidence/Access_Review_ET-0001_101")
+		assert.NoDirExists(t, filepath.Join(taskDir, "2017-Q4"))
+		assert.NoDirExists(t, filepath.Join(taskDir, "2022-Q2"))
+		assert.FileExists(t, filepath.Join(taskDir, "2022-Q2.tar.gz"))
+		assert.FileExists(t, filepath.Join(taskDir, "2025-Q3/01_users.csv"))
+		assert.FileExists(t, filepath.Join(taskDir, "metadata/notes.md"))
+		assert.NoFileExists(t, filepath.Join(dataDir, "evidence/Firewall_Rules_ET-0002_102/2017-Q3.tar.gz"))
+		assert.FileExists(t, filepath.Join(dataDir, "evidence/Firewall_Rules_ET-0002_102/2022-Q1.tar.gz"))
+
+		// A second run finds nothing left
+		again, err := s.ApplyRetention(RetentionOptions{Policy: policy, WindowEnd: quarterEnd, Now: now})
+		require.NoError(t, err)
+		assert.Empty(t, again.Items)
+	})
+
+	t.Run("cold storage", func(t *testing.T) {
+		t.Parallel()
+		s, dataDir := retentionFixture(t)
+		coldDir := t.TempDir()
+		writeTestFile(t, coldDir, "Access_Review_ET-0001_101/2016-Q1.tar.gz", "archive")
+		policy := config.RetentionConfig{KeepFor: "7y", ArchiveAfter: "2y", ArchiveDir: coldDir}
+
+		result, err := s.ApplyRetention(RetentionOptions{Policy: policy, WindowEnd: quarterEnd, Now: now})
+		require.NoError(t, err)
+		actions := retentionActions(result.Items)
+		assert.Equal(t, RetentionArchived, actions["evidence/Access_Review_ET-0001_101/2022-Q2"])
+		assert.Equal(t, RetentionArchived, actions["evidence/Firewall_Rules_ET-0002_102/2022-Q1.tar.gz"])
+		assert.Equal(t, RetentionDeleted, actions[filepath.Join(coldDir, "Access_Review_ET-0001_101/2016-Q1.tar.gz")])
+
+		assert.FileExists(t, filepath.Join(coldDir, "Access_Review_ET-0001_101/2022-Q2.tar.gz"))
+		assert.FileExists(t, filepath.Join(coldDir, "Firewall_Rules_ET-0002_102/2022-Q1.tar.gz"))
+		assert.NoFileExists(t, filepath.Join(coldDir, "Access_Review_ET-0001_101/2016-Q1.tar.gz"))
+		assert.NoDirExists(t, filepath.Join(dataDir, "evidence/Access_Review_ET-0001_101/2022-Q2"))
+		assert.NoFileExists(t, filepath.Join(dataDir, "evidence/Access_Review_ET-0001_101/2022-Q2.tar.gz"))
+		assert.NoFileExists(t, filepath.Join(dataDir, "evidence/Firewall_Rules_ET-0002_102/2022-Q1.tar.gz"))
+	})
+
+	t.Run("legal hold", func(t *testing.T) {
+		t.Parallel()
+		s, dataDir := retentionFixture(t)
+		policy := config.RetentionConfig{
+			KeepFor: "7y",
+			LegalHolds: []config.LegalHold{
+				{Name: "acme-v-us", Tasks: []string{"et-0001"}, Reason: "Litigation hold"},
+			},
+		}
+
+		result, err := s.ApplyRetention(RetentionOptions{Policy: policy, WindowEnd: quarterEnd, Now: now})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"evidence/Access_Review_ET-0001_101/2017-Q4":         RetentionHeld,
+			"evidence/Firewall_Rules_ET-0002_102/2017-Q3.tar.gz": RetentionDeleted,
+		}, retentionActions(result.Items))
+		assert.Contains(t, result.Items[0].Reason, "acme-v-us")
+		assert.DirExists(t, filepath.Join(dataDir, "evidence/Access_Review_ET-0001_101/2017-Q4"))
+		assert.Equal(t, int64(len("archive")), result.FreedBytes)
+	})
+
+	t.Run("rules by task", func(t *testing.T) {
+		t.Parallel()
+		s, _ := retentionFixture(t)
+		policy := config.RetentionConfig{
+			KeepFor: "7y",
+			Rules:   []config.RetentionRule{{Tasks: []string{"ET-0002"}, KeepFor: "10y"}},
+		}
+
+		result, err := s.ApplyRetention(RetentionOptions{DryRun: true, Policy: policy, WindowEnd: quarterEnd, Now: now})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"evidence/Access_Review_ET-0001_101/2017-Q4": RetentionDeleted,
+		}, retentionActions(result.Items))
+	})
+
+	t.Run("skips locked windows", func(t *testing.T) {
+		t.Parallel()
+		s, dataDir := retentionFixture(t)
+		lock, err := LockWindow(dataDir, "ET-0001", "2017-Q4")
+		require.NoError(t, err)
+		defer lock.Unlock()
+
+		result, err := s.ApplyRetention(RetentionOptions{Policy: config.RetentionConfig{KeepFor: "7y"}, WindowEnd: quarterEnd, Now: now})
+		require.NoError(t, err)
+		require.Len(t, result.Skipped, 1)
+		assert.Equal(t, "evidence/Access_Review_ET-0001_101/2017-Q4", result.Skipped[0].Path)
+		assert.DirExists(t, filepath.Join(dataDir, "evidence/Access_Review_ET-0001_101/2017-Q4"))
+	})
+}
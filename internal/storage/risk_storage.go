@@ -0,0 +1,77 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/grctool/grctool/internal/domain"
+)
+
+// risksCollection is the docs subdirectory holding one JSON file per risk
+const risksCollection = "risks"
+
+// riskIDPrefix prefixes generated risk IDs (RISK-0001)
+const riskIDPrefix = "RISK-"
+
+// SaveRisk validates and saves a risk in the risk register
+func (us *Storage) SaveRisk(risk *domain.Risk) error {
+	if risk == nil {
+		return fmt.Errorf("risk cannot be nil")
+	}
+	if risk.ID == "" {
+		return fmt.Errorf("risk ID cannot be empty")
+	}
+	if err := risk.Validate(); err != nil {
+		return err
+	}
+	return us.fileStorage.Save(risksCollection, risk.ID, risk)
+}
+
+// GetRisk retrieves a risk by ID, accepting RISK-0001, risk-1 or 1
+func (us *Storage) GetRisk(id string) (*domain.Risk, error) {
+	var risk domain.Risk
+	if err := us.fileStorage.Load(risksCollection, normalizeSequentialID(riskIDPrefix, id), &risk); err != nil {
+		return nil, fmt.Errorf("risk not found: %s", id)
+	}
+	return &risk, nil
+}
+
+// GetAllRisks retrieves the risk register, sorted by ID
+func (us *Storage) GetAllRisks() ([]domain.Risk, error) {
+	ids, err := us.fileStorage.List(risksCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	risks := make([]domain.Risk, 0, len(ids))
+	for _, id := range ids {
+		var risk domain.Risk
+		if err := us.fileStorage.Load(risksCollection, id, &risk); err != nil {
+			return nil, fmt.Errorf("failed to load risk %s: %w", id, err)
+		}
+		risks = append(risks, risk)
+	}
+
+	sort.Slice(risks, func(i, j int) bool { return risks[i].ID < risks[j].ID })
+	return risks, nil
+}
+
+// NextRiskID returns the ID after the highest one in the register
+func (us *Storage) NextRiskID() (string, error) {
+	return us.fileStorage.NextSequentialID(risksCollection, riskIDPrefix)
+}
@@ -0,0 +1,64 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRiskStorage(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+	store, err := NewStorage(config.StorageConfig{DataDir: dataDir})
+	require.NoError(t, err)
+
+	risks, err := store.GetAllRisks()
+	require.NoError(t, err)
+	assert.Empty(t, risks)
+
+	id, err := store.NextRiskID()
+	require.NoError(t, err)
+	assert.Equal(t, "RISK-0001", id)
+
+	risk := &domain.Risk{ID: id, Title: "Leaked deploy credentials", Likelihood: 3, Impact: 5,
+		Treatment: domain.RiskTreatmentMitigate, Status: domain.RiskStatusOpen, Controls: []string{"CC6.1"}}
+	require.NoError(t, store.SaveRisk(risk))
+	assert.FileExists(t, filepath.Join(dataDir, "docs", "risks", "RISK-0001.json"))
+	require.NoError(t, store.SaveRisk(&domain.Risk{ID: "RISK-0010", Title: "Vendor outage", Likelihood: 2, Impact: 2,
+		Treatment: domain.RiskTreatmentAccept, Status: domain.RiskStatusAccepted}))
+
+	for _, ref := range []string{"RISK-0001", "risk-1", "1"} {
+		loaded, err := store.GetRisk(ref)
+		require.NoError(t, err, ref)
+		assert.Equal(t, "Leaked deploy credentials", loaded.Title)
+		assert.Equal(t, []string{"CC6.1"}, loaded.Controls)
+	}
+	_, err = store.GetRisk("RISK-0002")
+	assert.ErrorContains(t, err, "risk not found")
+
+	risks, err = store.GetAllRisks()
+	require.NoError(t, err)
+	require.Len(t, risks, 2)
+	assert.Equal(t, "RISK-0001", risks[0].ID)
+
+	id, err = store.NextRiskID()
+	require.NoError(t, err)
+	assert.Equal(t, "RISK-0011", id, "IDs continue after the highest")
+
+	// Invalid risks are not saved
+	err = store.SaveRisk(&domain.Risk{ID: "RISK-0011", Title: "Bad", Likelihood: 6, Impact: 1,
+		Treatment: domain.RiskTreatmentAccept, Status: domain.RiskStatusOpen})
+	assert.ErrorContains(t, err, "likelihood must be between 1 and 5")
+	_, err = os.Stat(filepath.Join(dataDir, "docs", "risks", "RISK-0011.json"))
+	assert.True(t, os.IsNotExist(err))
+	assert.Error(t, store.SaveRisk(&domain.Risk{Title: "No ID"}))
+}
@@ -0,0 +1,497 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// S3Backend stores objects in an Amazon S3 bucket, or an S3-compatible store
+// such as MinIO when an endpoint is set. Requests are signed with AWS
+// Signature Version 4.
+type S3Backend struct {
+	bucket   string
+	prefix   string
+	region   string
+	endpoint *url.URL // nil for AWS itself
+	client   *http.Client
+	creds    func(ctx context.Context) (awsCredentials, error)
+	now      func() time.Time
+}
+
+// awsCredentials are the keys requests are signed with
+type awsCredentials struct {
+	AccessKeyID     string    `json:"AccessKeyId"`
+	SecretAccessKey string    `json:"SecretAccessKey"`
+	SessionToken    string    `json:"SessionToken"`
+	Expiration      time.Time `json:"Expiration"`
+}
+
+// NewS3Backend returns a backend for a bucket and key prefix. The region
+// defaults to AWS_REGION, then AWS_DEFAULT_REGION, then us-east-1.
+func NewS3Backend(bucket, prefix, region, endpoint string) (*S3Backend, error) {
+	b := &S3Backend{
+		bucket: bucket,
+		prefix: prefix,
+		region: awsRegion(region),
+		client: &http.Client{Timeout: 5 * time.Minute},
+		creds:  newAWSCredentialChain(),
+		now:    time.Now,
+	}
+	if endpoint != "" {
+		u, err := url.Parse(endpoint)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid storage.remote.endpoint %q", endpoint)
+		}
+		b.endpoint = u
+	}
+	return b, nil
+}
+
+func (b *S3Backend) Name() string {
+	return "s3://" + objectKey(b.bucket, b.prefix)
+}
+
+func (b *S3Backend) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := b.do(ctx, http.MethodGet, objectKey(b.prefix, key), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%s: %w", key, ErrObjectNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, s3Error(resp, "get "+key)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (b *S3Backend) Put(ctx context.Context, key string, data []byte) (ObjectInfo, error) {
+	resp, err := b.do(ctx, http.MethodPut, objectKey(b.prefix, key), nil, data)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return ObjectInfo{}, s3Error(resp, "put "+key)
+	}
+	return ObjectInfo{Key: key, Size: int64(len(data)), Version: strings.Trim(resp.Header.Get("ETag"), `"`)}, nil
+}
+
+// MinPartSize is the smallest part S3 accepts in a multipart upload, other
+// than the last
+const MinPartSize = 5 * 1024 * 1024 // 5MB
+
+// partRetries is how many times PutMultipart sends a failed part again before
+// it gives up on the upload
+const partRetries = 3
+
+// initiateMultipartUploadResult is the CreateMultipartUpload response
+type initiateMultipartUploadResult struct {
+	UploadID string `xml:"UploadId"`
+}
+
+// completeMultipartUpload is the CompleteMultipartUpload request
+type completeMultipartUpload struct {
+	XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+	Parts   []completedPart `xml:"Part"`
+}
+
+// completedPart is a part listed in the CompleteMultipartUpload request
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+// PutMultipart stores data as a multipart upload in parts of partSize bytes,
+// at least MinPartSize, so a dropped connection costs one part rather than
+// the whole object: a failed part is sent again up to partRetries times
+// before the upload is aborted. Data that fits in one part is stored with
+// Put.
+func (b *S3Backend) PutMultipart(ctx context.Context, key string, data []byte, partSize int64) (ObjectInfo, error) {
+	partSize = max(partSize, MinPartSize)
+	if int64(len(data)) <= partSize {
+		return b.Put(ctx, key, data)
+	}
+	objKey := objectKey(b.prefix, key)
+
+	resp, err := b.do(ctx, http.MethodPost, objKey, url.Values{"uploads": {""}}, nil)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		err := s3Error(resp, "create multipart upload of "+key)
+		resp.Body.Close()
+		return ObjectInfo{}, err
+	}
+	var initiated initiateMultipartUploadResult
+	err = xml.NewDecoder(resp.Body).Decode(&initiated)
+	resp.Body.Close()
+	if err != nil || initiated.UploadID == "" {
+		return ObjectInfo{}, fmt.Errorf("failed to parse S3 multipart upload of %s", key)
+	}
+
+	var complete completeMultipartUpload
+	for offset, number := int64(0), 1; offset < int64(len(data)); offset, number = offset+partSize, number+1 {
+		part := data[offset:min(offset+partSize, int64(len(data)))]
+		etag, err := b.putPart(ctx, objKey, initiated.UploadID, number, part)
+		if err != nil {
+			b.abortMultipart(objKey, initiated.UploadID)
+			return ObjectInfo{}, fmt.Errorf("%w (part %d of %s)", err, number, key)
+		}
+		complete.Parts = append(complete.Parts, completedPart{PartNumber: number, ETag: etag})
+	}
+
+	body, err := xml.Marshal(complete)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	resp, err = b.do(ctx, http.MethodPost, objKey, url.Values{"uploadId": {initiated.UploadID}}, body)
+	if err != nil {
+		b.abortMultipart(objKey, initiated.UploadID)
+		return ObjectInfo{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		b.abortMultipart(objKey, initiated.UploadID)
+		return ObjectInfo{}, s3Error(resp, "complete multipart upload of "+key)
+	}
+	// S3 can report a failed completion in a 200 response
+	var result struct {
+		XMLName xml.Name
+		ETag    string `xml:"ETag"`
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return ObjectInfo{}, fmt.Errorf("failed to parse S3 multipart completion of %s: %w", key, err)
+	}
+	if result.XMLName.Local == "Error" {
+		b.abortMultipart(objKey, initiated.UploadID)
+		return ObjectInfo{}, fmt.Errorf("S3 complete multipart upload of %s failed: %s: %s", key, result.Code, result.Message)
+	}
+	return ObjectInfo{Key: key, Size: int64(len(data)), Version: strings.Trim(result.ETag, `"`)}, nil
+}
+
+// putPart uploads one part of a multipart upload and returns its ETag
+func (b *S3Backend) putPart(ctx context.Context, objKey, uploadID string, number int, part []byte) (string, error) {
+	query := url.Values{"partNumber": {fmt.Sprint(number)}, "uploadId": {uploadID}}
+	var err error
+	for attempt := 0; attempt <= partRetries; attempt++ {
+		var resp *http.Response
+		if resp, err = b.do(ctx, http.MethodPut, objKey, query, part); err == nil {
+			if resp.StatusCode == http.StatusOK {
+				resp.Body.Close()
+				return resp.Header.Get("ETag"), nil
+			}
+			err = s3Error(resp, "upload part")
+			resp.Body.Close()
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return "", err
+}
+
+// abortMultipart discards the parts of a failed multipart upload, which S3
+// would otherwise keep, and bill for, until a lifecycle rule removes them
+func (b *S3Backend) abortMultipart(objKey, uploadID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if resp, err := b.do(ctx, http.MethodDelete, objKey, url.Values{"uploadId": {uploadID}}, nil); err == nil {
+		resp.Body.Close()
+	}
+}
+
+func (b *S3Backend) Delete(ctx context.Context, key string) error {
+	resp, err := b.do(ctx, http.MethodDelete, objectKey(b.prefix, key), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return s3Error(resp, "delete "+key)
+	}
+	return nil
+}
+
+// listBucketResult is the ListObjectsV2 response
+type listBucketResult struct {
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+	Contents              []struct {
+		Key  string `xml:"Key"`
+		ETag string `xml:"ETag"`
+		Size int64  `xml:"Size"`
+	} `xml:"Contents"`
+}
+
+func (b *S3Backend) List(ctx context.Context) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	query := url.Values{"list-type": {"2"}}
+	if b.prefix != "" {
+		query.Set("prefix", b.prefix+"/")
+	}
+	for {
+		resp, err := b.do(ctx, http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			err := s3Error(resp, "list")
+			resp.Body.Close()
+			return nil, err
+		}
+		var result listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse S3 listing: %w", err)
+		}
+
+		for _, c := range result.Contents {
+			key := strings.TrimPrefix(c.Key, b.prefix+"/")
+			if b.prefix == "" {
+				key = c.Key
+			}
+			if strings.HasSuffix(key, "/") {
+				continue // folder placeholder
+			}
+			objects = append(objects, ObjectInfo{Key: key, Size: c.Size, Version: strings.Trim(c.ETag, `"`)})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		query.Set("continuation-token", result.NextContinuationToken)
+	}
+}
+
+// objectURL addresses a key virtual-hosted style on AWS and path style on a
+// custom endpoint, which S3-compatible stores support more widely
+func (b *S3Backend) objectURL(key string) *url.URL {
+	path := "/" + key
+	u := &url.URL{Scheme: "https", Host: b.bucket + ".s3." + b.region + ".amazonaws.com"}
+	if b.endpoint != nil {
+		u = &url.URL{Scheme: b.endpoint.Scheme, Host: b.endpoint.Host}
+		path = strings.TrimSuffix(b.endpoint.Path, "/") + "/" + b.bucket + path
+	}
+	u.Path = path
+	u.RawPath = awsEscape(path, false)
+	return u
+}
+
+func (b *S3Backend) do(ctx context.Context, method, key string, query url.Values, body []byte) (*http.Response, error) {
+	creds, err := b.creds(ctx)
+	if err != nil {
+		return nil, err
+	}
+	u := b.objectURL(key)
+	u.RawQuery = canonicalQuery(query)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = int64(len(body))
+	signV4(req, body, creds, b.region, "s3", b.now())
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("S3 request failed: %w", err)
+	}
+	return resp, nil
+}
+
+// signV4 adds AWS Signature Version 4 headers to a request for an AWS
+// service such as s3 or kms
+func signV4(req *http.Request, body []byte, creds awsCredentials, region, service string, now time.Time) {
+	payloadHash := sha256Hex(body)
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           amzDate,
+	}
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+		headers["x-amz-security-token"] = creds.SessionToken
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalURI := req.URL.EscapedPath()
+	if service != "s3" {
+		// Services other than S3 expect the path escaped a second time
+		canonicalURI = awsEscape(canonicalURI, false)
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI,
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// canonicalQuery encodes a query string the way Signature Version 4 expects:
+// sorted, with every reserved character escaped
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		values := append([]string(nil), query[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, awsEscape(k, true)+"="+awsEscape(v, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// awsEscape percent-encodes everything but RFC 3986 unreserved characters,
+// and slashes unless escapeSlash is set
+func awsEscape(s string, escapeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '.', c == '_', c == '~', c == '/' && !escapeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3Error turns an S3 error response into an error
+func s3Error(resp *http.Response, op string) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	var e struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if xml.Unmarshal(body, &e) == nil && e.Code != "" {
+		return fmt.Errorf("S3 %s failed: %s: %s", op, e.Code, e.Message)
+	}
+	return fmt.Errorf("S3 %s failed with status %d", op, resp.StatusCode)
+}
+
+// awsRegion returns region, else the one the environment configures, else
+// us-east-1
+func awsRegion(region string) string {
+	for _, candidate := range []string{region, os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION")} {
+		if candidate != "" {
+			return candidate
+		}
+	}
+	return "us-east-1"
+}
+
+// newAWSCredentialChain returns credentials from AWS_ACCESS_KEY_ID and
+// AWS_SECRET_ACCESS_KEY or, failing those, from the aws CLI, which covers
+// profiles, SSO and instance roles. CLI credentials are reused until shortly
+// before they expire.
+func newAWSCredentialChain() func(ctx context.Context) (awsCredentials, error) {
+	var mu sync.Mutex
+	var cached awsCredentials
+	return func(ctx context.Context) (awsCredentials, error) {
+		if id, secret := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY"); id != "" && secret != "" {
+			return awsCredentials{AccessKeyID: id, SecretAccessKey: secret, SessionToken: os.Getenv("AWS_SESSION_TOKEN")}, nil
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if cached.AccessKeyID != "" && (cached.Expiration.IsZero() || time.Until(cached.Expiration) > 5*time.Minute) {
+			return cached, nil
+		}
+		var stderr bytes.Buffer
+		c := exec.CommandContext(ctx, "aws", "configure", "export-credentials", "--format", "process")
+		c.Stderr = &stderr
+		out, err := c.Output()
+		if err != nil {
+			return awsCredentials{}, fmt.Errorf("no AWS credentials: set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY or configure the aws CLI (%v: %s)",
+				err, strings.TrimSpace(stderr.String()))
+		}
+		var creds awsCredentials
+		if err := json.Unmarshal(out, &creds); err != nil {
+			return awsCredentials{}, fmt.Errorf("failed to parse aws CLI credentials: %w", err)
+		}
+		cached = creds
+		return creds, nil
+	}
+}
@@ -19,7 +19,6 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -36,7 +35,12 @@ const (
 	submissionFilename    = "submission.yaml"
 	validationFilename    = "validation.yaml"
 	historyFilename       = "history.yaml"
+	feedbackFilename      = "feedback.yaml"
+	commentsFilename      = "comments.yaml"
+	approvalFilename      = "approval.yaml"
+	manifestFilename      = "manifest.json"
 	batchStorageDir       = "submissions"
+	queueFilename         = "queue.yaml"
 )
 
 // SaveSubmission saves submission metadata for a task window
@@ -120,6 +124,129 @@ func (us *Storage) LoadSubmission(taskRef, window string) (*models.EvidenceSubmi
 	return &submission, nil
 }
 
+// RecordSubmissionReview records Tugboat's review of a submitted window. The
+// response status (accepted or rejected) is applied to every copy of the
+// submission metadata: at the window root, in .submitted/ and in archive/.
+// When the window has no submission metadata yet, it is created at the window
+// root. The first updated submission is returned. The window is locked
+// while its metadata is rewritten.
+func (us *Storage) RecordSubmissionReview(taskRef, window string, response models.TugboatSubmissionResponse) (*models.EvidenceSubmission, error) {
+	lock, err := us.LockWindow(taskRef, window)
+	if err != nil {
+		return nil, err
+	}
+	defer lock.Unlock()
+
+	first, err := us.updateSubmissionCopies(taskRef, window, func(submission *models.EvidenceSubmission) {
+		applySubmissionReview(submission, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if first != nil {
+		return first, nil
+	}
+
+	submission := &models.EvidenceSubmission{
+		TaskRef:       taskRef,
+		Window:        window,
+		CreatedAt:     response.ReceivedAt,
+		EvidenceFiles: []models.EvidenceFileRef{},
+	}
+	applySubmissionReview(submission, response)
+	if err := us.SaveSubmission(submission); err != nil {
+		return nil, err
+	}
+	return submission, nil
+}
+
+// WithdrawSubmission supersedes the window's submission: the submitted
+// evidence files move from .submitted/ back to the window root, so the window
+// can be submitted again, and every copy of the submission metadata is marked
+// superseded with the withdrawal. The window is locked throughout.
+func (us *Storage) WithdrawSubmission(taskRef, window string, withdrawal models.SubmissionWithdrawal) (*models.EvidenceSubmission, error) {
+	lock, err := us.LockWindow(taskRef, window)
+	if err != nil {
+		return nil, err
+	}
+	defer lock.Unlock()
+
+	if err := us.MoveEvidenceFilesFromSubmitted(taskRef, window); err != nil {
+		return nil, err
+	}
+	first, err := us.updateSubmissionCopies(taskRef, window, func(submission *models.EvidenceSubmission) {
+		submission.Status = "superseded"
+		submission.AcceptedAt = nil
+		submission.Withdrawal = &withdrawal
+	})
+	if err != nil {
+		return nil, err
+	}
+	if first == nil {
+		return nil, fmt.Errorf("no submission recorded for %s in window %s", taskRef, window)
+	}
+	return first, nil
+}
+
+// updateSubmissionCopies applies update to every copy of the window's
+// submission metadata, at the window root, in .submitted/ and in archive/,
+// and returns the first copy updated, or nil when there is none. The caller
+// holds the window lock.
+func (us *Storage) updateSubmissionCopies(taskRef, window string, update func(*models.EvidenceSubmission)) (*models.EvidenceSubmission, error) {
+	windowDir := us.getEvidenceWindowDir(taskRef, window)
+
+	var first *models.EvidenceSubmission
+	for _, dir := range []string{
+		windowDir,
+		filepath.Join(windowDir, naming.SubfolderSubmitted),
+		filepath.Join(windowDir, naming.SubfolderArchive),
+	} {
+		submissionPath := filepath.Join(dir, submissionMetadataDir, submissionFilename)
+		data, err := os.ReadFile(submissionPath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read submission file: %w", err)
+		}
+
+		var submission models.EvidenceSubmission
+		if err := yaml.Unmarshal(data, &submission); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal submission %s: %w", submissionPath, err)
+		}
+		update(&submission)
+
+		data, err = yaml.Marshal(&submission)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal submission: %w", err)
+		}
+		if err := os.WriteFile(submissionPath, data, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write submission file: %w", err)
+		}
+		if first == nil {
+			first = &submission
+		}
+	}
+	return first, nil
+}
+
+// applySubmissionReview sets the review outcome on a submission
+func applySubmissionReview(submission *models.EvidenceSubmission, response models.TugboatSubmissionResponse) {
+	submission.Status = response.Status
+	if response.SubmissionID == "" {
+		response.SubmissionID = submission.SubmissionID
+	} else if submission.SubmissionID == "" {
+		submission.SubmissionID = response.SubmissionID
+	}
+	if response.Status == "accepted" {
+		acceptedAt := response.ReceivedAt
+		submission.AcceptedAt = &acceptedAt
+	} else {
+		submission.AcceptedAt = nil
+	}
+	submission.TugboatResponse = &response
+}
+
 // SaveValidationResult saves validation results for a task window
 // For backward compatibility, saves at window level (use SaveValidationResultToSubfolder for new structure)
 func (us *Storage) SaveValidationResult(taskRef, window string, result *models.ValidationResult) error {
@@ -145,6 +272,11 @@ func (us *Storage) SaveValidationResult(taskRef, window string, result *models.V
 		return fmt.Errorf("failed to write validation file: %w", err)
 	}
 
+	events := custodyEventsFor(result.EvidenceFiles, models.CustodyValidated, "validation "+result.Status)
+	if err := AppendCustodyEvents(evidenceDir, events...); err != nil {
+		return fmt.Errorf("failed to record validation in custody log: %w", err)
+	}
+
 	return nil
 }
 
@@ -197,6 +329,48 @@ func (us *Storage) LoadValidationResult(taskRef, window string) (*models.Validat
 	return &result, nil
 }
 
+// SaveManifest saves the evidence manifest of a task window, as JSON
+func (us *Storage) SaveManifest(taskRef, window string, data []byte) error {
+	submissionDir := filepath.Join(us.getEvidenceWindowDir(taskRef, window), submissionMetadataDir)
+	if err := os.MkdirAll(submissionDir, 0755); err != nil {
+		return fmt.Errorf("failed to create submission directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(submissionDir, manifestFilename), data, 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+// LoadManifest loads the evidence manifest of a task window, returning nil
+// when none was saved
+func (us *Storage) LoadManifest(taskRef, window string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(us.getEvidenceWindowDir(taskRef, window), submissionMetadataDir, manifestFilename))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	return data, nil
+}
+
+// LoadGenerationMetadata loads the .generation/metadata.yaml the evidence
+// writer keeps for a task window, returning nil when there is none
+func (us *Storage) LoadGenerationMetadata(taskRef, window string) (*models.GenerationMetadata, error) {
+	data, err := os.ReadFile(filepath.Join(us.getEvidenceWindowDir(taskRef, window), ".generation", "metadata.yaml"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read generation metadata: %w", err)
+	}
+	var metadata models.GenerationMetadata
+	if err := yaml.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse generation metadata: %w", err)
+	}
+	return &metadata, nil
+}
+
 // AddSubmissionHistory adds an entry to the submission history
 func (us *Storage) AddSubmissionHistory(taskRef, window string, entry models.SubmissionHistoryEntry) error {
 	// Load existing history or create new
@@ -318,6 +492,162 @@ func (us *Storage) LoadSubmissionHistory(taskRef, window string) (*models.Submis
 	return &history, nil
 }
 
+// SaveFeedback saves the auditor feedback for a task window, replacing any
+// feedback saved by a previous sync
+func (us *Storage) SaveFeedback(feedback *models.EvidenceFeedback) error {
+	if feedback == nil {
+		return fmt.Errorf("feedback cannot be nil")
+	}
+
+	evidenceDir := us.getEvidenceWindowDir(feedback.TaskRef, feedback.Window)
+	submissionDir := filepath.Join(evidenceDir, submissionMetadataDir)
+
+	// Create .submission directory if it doesn't exist
+	if err := os.MkdirAll(submissionDir, 0755); err != nil {
+		return fmt.Errorf("failed to create submission directory: %w", err)
+	}
+
+	feedbackPath := filepath.Join(submissionDir, feedbackFilename)
+	data, err := yaml.Marshal(feedback)
+	if err != nil {
+		return fmt.Errorf("failed to marshal feedback: %w", err)
+	}
+
+	if err := os.WriteFile(feedbackPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write feedback file: %w", err)
+	}
+
+	return nil
+}
+
+// LoadFeedback loads the auditor feedback for a task window
+func (us *Storage) LoadFeedback(taskRef, window string) (*models.EvidenceFeedback, error) {
+	evidenceDir := us.getEvidenceWindowDir(taskRef, window)
+	feedbackPath := filepath.Join(evidenceDir, submissionMetadataDir, feedbackFilename)
+
+	if _, err := os.Stat(feedbackPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("feedback not found for %s in window %s", taskRef, window)
+	}
+
+	data, err := os.ReadFile(feedbackPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feedback file: %w", err)
+	}
+
+	var feedback models.EvidenceFeedback
+	if err := yaml.Unmarshal(data, &feedback); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal feedback: %w", err)
+	}
+
+	return &feedback, nil
+}
+
+// SaveTaskComments saves the comment thread of a task to comments.yaml in its
+// evidence directory
+func (us *Storage) SaveTaskComments(thread *models.TaskComments) error {
+	if thread == nil {
+		return fmt.Errorf("comment thread cannot be nil")
+	}
+
+	taskDir := us.getEvidenceWindowDir(thread.TaskRef, "")
+	if err := os.MkdirAll(taskDir, 0755); err != nil {
+		return fmt.Errorf("failed to create evidence directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(thread)
+	if err != nil {
+		return fmt.Errorf("failed to marshal comments: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(taskDir, commentsFilename), data, 0644); err != nil {
+		return fmt.Errorf("failed to write comments file: %w", err)
+	}
+	return nil
+}
+
+// LoadTaskComments loads the comment thread of a task, which is empty until
+// the task's comments are synced or one is posted
+func (us *Storage) LoadTaskComments(taskRef string) (*models.TaskComments, error) {
+	path := filepath.Join(us.getEvidenceWindowDir(taskRef, ""), commentsFilename)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &models.TaskComments{TaskRef: taskRef}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read comments file: %w", err)
+	}
+
+	var thread models.TaskComments
+	if err := yaml.Unmarshal(data, &thread); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal comments: %w", err)
+	}
+	return &thread, nil
+}
+
+// SaveApproval saves the sign-off on a task window's evidence, replacing any
+// earlier approval
+func (us *Storage) SaveApproval(approval *models.EvidenceApproval) error {
+	if approval == nil {
+		return fmt.Errorf("approval cannot be nil")
+	}
+
+	evidenceDir := us.getEvidenceWindowDir(approval.TaskRef, approval.Window)
+	submissionDir := filepath.Join(evidenceDir, submissionMetadataDir)
+
+	// Create .submission directory if it doesn't exist
+	if err := os.MkdirAll(submissionDir, 0755); err != nil {
+		return fmt.Errorf("failed to create submission directory: %w", err)
+	}
+
+	approvalPath := filepath.Join(submissionDir, approvalFilename)
+	data, err := yaml.Marshal(approval)
+	if err != nil {
+		return fmt.Errorf("failed to marshal approval: %w", err)
+	}
+
+	if err := os.WriteFile(approvalPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write approval file: %w", err)
+	}
+
+	events := make([]models.CustodyEvent, 0, len(approval.Files))
+	for _, file := range approval.Files {
+		events = append(events, models.CustodyEvent{
+			Timestamp: approval.ApprovedAt,
+			File:      file.Filename,
+			Event:     models.CustodyApproved,
+			Actor:     approval.Approver,
+			SHA256:    file.SHA256,
+			Details:   approval.Comment,
+		})
+	}
+	if err := AppendCustodyEvents(evidenceDir, events...); err != nil {
+		return fmt.Errorf("failed to record approval in custody log: %w", err)
+	}
+
+	return nil
+}
+
+// LoadApproval loads the sign-off on a task window's evidence
+func (us *Storage) LoadApproval(taskRef, window string) (*models.EvidenceApproval, error) {
+	evidenceDir := us.getEvidenceWindowDir(taskRef, window)
+	approvalPath := filepath.Join(evidenceDir, submissionMetadataDir, approvalFilename)
+
+	if _, err := os.Stat(approvalPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("approval not found for %s in window %s", taskRef, window)
+	}
+
+	data, err := os.ReadFile(approvalPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read approval file: %w", err)
+	}
+
+	var approval models.EvidenceApproval
+	if err := yaml.Unmarshal(data, &approval); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal approval: %w", err)
+	}
+
+	return &approval, nil
+}
+
 // SaveBatch saves a submission batch
 func (us *Storage) SaveBatch(batch *models.SubmissionBatch) error {
 	if batch == nil {
@@ -405,20 +735,59 @@ func (us *Storage) ListBatches() ([]models.SubmissionBatch, error) {
 	return batches, nil
 }
 
-// CalculateFileChecksum calculates SHA256 checksum for a file
-func (us *Storage) CalculateFileChecksum(filePath string) (string, error) {
-	file, err := os.Open(filePath)
+// LoadSubmissionQueue loads the offline submission queue, which is empty
+// until a submission is queued
+func (us *Storage) LoadSubmissionQueue() (*models.SubmissionQueue, error) {
+	queuePath := filepath.Join(us.localDataStore.GetBaseDir(), batchStorageDir, queueFilename)
+
+	data, err := os.ReadFile(queuePath)
+	if os.IsNotExist(err) {
+		return &models.SubmissionQueue{Entries: []models.QueuedSubmission{}}, nil
+	}
 	if err != nil {
-		return "", fmt.Errorf("failed to open file: %w", err)
+		return nil, fmt.Errorf("failed to read submission queue: %w", err)
 	}
-	defer file.Close()
 
-	hash := sha256.New()
-	if _, err := io.Copy(hash, file); err != nil {
-		return "", fmt.Errorf("failed to hash file: %w", err)
+	var queue models.SubmissionQueue
+	if err := yaml.Unmarshal(data, &queue); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal submission queue: %w", err)
+	}
+	return &queue, nil
+}
+
+// SaveSubmissionQueue saves the offline submission queue
+func (us *Storage) SaveSubmissionQueue(queue *models.SubmissionQueue) error {
+	if queue == nil {
+		return fmt.Errorf("queue cannot be nil")
 	}
 
-	return hex.EncodeToString(hash.Sum(nil)), nil
+	queueDir := filepath.Join(us.localDataStore.GetBaseDir(), batchStorageDir)
+	if err := os.MkdirAll(queueDir, 0755); err != nil {
+		return fmt.Errorf("failed to create submissions directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(queue)
+	if err != nil {
+		return fmt.Errorf("failed to marshal submission queue: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(queueDir, queueFilename), data, 0644); err != nil {
+		return fmt.Errorf("failed to write submission queue: %w", err)
+	}
+
+	return nil
+}
+
+// CalculateFileChecksum calculates SHA256 checksum for a file, over its
+// plaintext when it is encrypted so the checksum survives encryption
+func (us *Storage) CalculateFileChecksum(filePath string) (string, error) {
+	data, err := ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
 }
 
 // GetEvidenceFiles gets all evidence files for a task window with metadata
@@ -469,7 +838,7 @@ func (us *Storage) GetEvidenceFiles(taskRef, window string) ([]models.EvidenceFi
 			Filename:       entry.Name(),
 			RelativePath:   relPath,
 			Title:          entry.Name(),
-			SizeBytes:      info.Size(),
+			SizeBytes:      referencedSize(filePath, info),
 			ChecksumSHA256: checksum,
 		})
 	}
@@ -526,7 +895,7 @@ func (us *Storage) GetEvidenceFilesFromSubfolder(taskRef, window, subfolder stri
 			Filename:       entry.Name(),
 			RelativePath:   relPath,
 			Title:          entry.Name(),
-			SizeBytes:      info.Size(),
+			SizeBytes:      referencedSize(filePath, info),
 			ChecksumSHA256: checksum,
 		})
 	}
@@ -546,6 +915,7 @@ func (us *Storage) MoveEvidenceFilesToSubmitted(taskRef, window string, files []
 	}
 
 	// Move each file
+	moved := []models.EvidenceFileRef{}
 	for _, file := range files {
 		sourcePath := filepath.Join(windowDir, file.Filename)
 		destPath := filepath.Join(submittedDir, file.Filename)
@@ -560,6 +930,10 @@ func (us *Storage) MoveEvidenceFilesToSubmitted(taskRef, window string, files []
 		if err := os.Rename(sourcePath, destPath); err != nil {
 			return fmt.Errorf("failed to move file %s: %w", file.Filename, err)
 		}
+		moved = append(moved, file)
+	}
+	if err := AppendCustodyEvents(windowDir, custodyEventsFor(moved, models.CustodyMoved, "to .submitted/")...); err != nil {
+		return fmt.Errorf("failed to record move in custody log: %w", err)
 	}
 
 	// Move metadata directories too
@@ -581,6 +955,62 @@ func (us *Storage) MoveEvidenceFilesToSubmitted(taskRef, window string, files []
 	return nil
 }
 
+// MoveEvidenceFilesFromSubmitted moves the evidence files in .submitted/ back
+// to the window root, undoing MoveEvidenceFilesToSubmitted so the window can
+// be submitted again. Nothing is moved when a file of the same name is
+// already in the root.
+func (us *Storage) MoveEvidenceFilesFromSubmitted(taskRef, window string) error {
+	windowDir := us.getEvidenceWindowDir(taskRef, window)
+	submittedDir := filepath.Join(windowDir, naming.SubfolderSubmitted)
+
+	entries, err := os.ReadDir(submittedDir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read .submitted directory: %w", err)
+	}
+
+	// Check every file first so a conflict leaves the window as it was
+	files := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(windowDir, entry.Name())); err == nil {
+			return fmt.Errorf("%s is in both .submitted/ and the window root - move or remove one of them first", entry.Name())
+		}
+		files = append(files, entry.Name())
+	}
+
+	moved := []models.EvidenceFileRef{}
+	for _, filename := range files {
+		if err := os.Rename(filepath.Join(submittedDir, filename), filepath.Join(windowDir, filename)); err != nil {
+			return fmt.Errorf("failed to move file %s: %w", filename, err)
+		}
+		moved = append(moved, models.EvidenceFileRef{Filename: filename})
+	}
+	if err := AppendCustodyEvents(windowDir, custodyEventsFor(moved, models.CustodyMoved, "from .submitted/ back to the window root")...); err != nil {
+		return fmt.Errorf("failed to record move in custody log: %w", err)
+	}
+
+	// Move metadata directories back too, unless regenerated since
+	for _, metadataDir := range []string{".generation", ".validation"} {
+		sourcePath := filepath.Join(submittedDir, metadataDir)
+		if stat, err := os.Stat(sourcePath); err == nil && stat.IsDir() {
+			destPath := filepath.Join(windowDir, metadataDir)
+			if _, err := os.Stat(destPath); os.IsNotExist(err) {
+				if err := os.Rename(sourcePath, destPath); err != nil {
+					// Metadata move is best-effort
+					continue
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
 // CheckAlreadySubmitted checks if files exist in .submitted/ folder (prevents resubmission)
 // NEW HYBRID APPROACH: Hidden folder check
 func (us *Storage) CheckAlreadySubmitted(taskRef, window string) (bool, error) {
@@ -615,6 +1045,11 @@ func (us *Storage) CheckAlreadySubmitted(taskRef, window string) (bool, error) {
 	return fileCount > 0, nil
 }
 
+// EvidenceWindowDir returns the directory of a task's evidence window
+func (us *Storage) EvidenceWindowDir(taskRef, window string) string {
+	return us.getEvidenceWindowDir(taskRef, window)
+}
+
 // getEvidenceWindowDir returns the evidence directory path for a task/window
 func (us *Storage) getEvidenceWindowDir(taskRef, window string) string {
 	// Evidence directory pattern: evidence/{name}_ET-{num}_{tugboat_id}/{window}/
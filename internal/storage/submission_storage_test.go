@@ -309,3 +309,217 @@ func TestSubmissionStorage_SubmissionExists(t *testing.T) {
 	exists = storage.SubmissionExists("ET-0001", "2025-Q4")
 	assert.True(t, exists)
 }
+
+func TestSubmissionStorage_RecordSubmissionReview(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := config.StorageConfig{
+		DataDir: tmpDir,
+		Paths:   config.StoragePaths{}.WithDefaults(),
+	}
+	storage, err := NewStorage(cfg)
+	require.NoError(t, err)
+
+	evidenceDir := filepath.Join(tmpDir, "evidence", "ET-0001", "2025-Q4")
+	require.NoError(t, os.MkdirAll(evidenceDir, 0755))
+
+	submittedAt := time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC)
+	submitted := &models.EvidenceSubmission{
+		TaskRef:      "ET-0001",
+		Window:       "2025-Q4",
+		Status:       "submitted",
+		SubmissionID: "sub-42",
+		SubmittedAt:  &submittedAt,
+		Notes:        "Quarterly access review",
+	}
+	require.NoError(t, storage.SaveSubmission(submitted))
+	require.NoError(t, storage.SaveSubmissionToSubfolder(submitted, ".submitted"))
+
+	reviewedAt := time.Date(2025, 11, 10, 14, 30, 0, 0, time.UTC)
+	updated, err := storage.RecordSubmissionReview("ET-0001", "2025-Q4", models.TugboatSubmissionResponse{
+		Status:     "accepted",
+		Message:    "Looks good",
+		ReceivedAt: reviewedAt,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "accepted", updated.Status)
+	require.NotNil(t, updated.AcceptedAt)
+	assert.Equal(t, reviewedAt, *updated.AcceptedAt)
+	assert.Equal(t, "Quarterly access review", updated.Notes, "submitter notes are kept")
+	require.NotNil(t, updated.TugboatResponse)
+	assert.Equal(t, "sub-42", updated.TugboatResponse.SubmissionID)
+	assert.Equal(t, "Looks good", updated.TugboatResponse.Message)
+
+	// Both copies are updated
+	loaded, err := storage.LoadSubmission("ET-0001", "2025-Q4")
+	require.NoError(t, err)
+	assert.Equal(t, "accepted", loaded.Status)
+	data, err := os.ReadFile(filepath.Join(evidenceDir, ".submitted", submissionMetadataDir, submissionFilename))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "status: accepted")
+
+	// A window without submission metadata gets it at the window root
+	rejected, err := storage.RecordSubmissionReview("ET-0001", "2025-Q3", models.TugboatSubmissionResponse{
+		SubmissionID: "sub-7",
+		Status:       "rejected",
+		Message:      "Screenshot is missing the date",
+		ReceivedAt:   reviewedAt,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "rejected", rejected.Status)
+	assert.Equal(t, "sub-7", rejected.SubmissionID)
+	assert.Nil(t, rejected.AcceptedAt)
+	assert.True(t, storage.SubmissionExists("ET-0001", "2025-Q3"))
+}
+
+func TestSubmissionStorage_WithdrawSubmission(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := config.StorageConfig{
+		DataDir: tmpDir,
+		Paths:   config.StoragePaths{}.WithDefaults(),
+	}
+	storage, err := NewStorage(cfg)
+	require.NoError(t, err)
+
+	evidenceDir := filepath.Join(tmpDir, "evidence", "ET-0001", "2025-Q4")
+	submittedDir := filepath.Join(evidenceDir, ".submitted")
+	require.NoError(t, os.MkdirAll(filepath.Join(submittedDir, ".generation"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(submittedDir, "access_review.csv"), []byte("user,role\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(submittedDir, "summary.md"), []byte("# Summary"), 0644))
+
+	acceptedAt := time.Date(2025, 11, 10, 14, 30, 0, 0, time.UTC)
+	submitted := &models.EvidenceSubmission{
+		TaskRef:      "ET-0001",
+		Window:       "2025-Q4",
+		Status:       "accepted",
+		SubmissionID: "sub-42",
+		AcceptedAt:   &acceptedAt,
+	}
+	require.NoError(t, storage.SaveSubmission(submitted))
+	require.NoError(t, storage.SaveSubmissionToSubfolder(submitted, ".submitted"))
+
+	// A file of the same name in the root leaves the window as it was
+	require.NoError(t, os.WriteFile(filepath.Join(evidenceDir, "summary.md"), []byte("# Reworked"), 0644))
+	withdrawal := models.SubmissionWithdrawal{
+		WithdrawnAt: time.Date(2025, 11, 12, 8, 0, 0, 0, time.UTC),
+		Reason:      "Wrong quarter exported",
+		Remote:      "withdrawn",
+	}
+	_, err = storage.WithdrawSubmission("ET-0001", "2025-Q4", withdrawal)
+	assert.ErrorContains(t, err, "summary.md is in both .submitted/ and the window root")
+	assert.FileExists(t, filepath.Join(submittedDir, "access_review.csv"))
+	loaded, err := storage.LoadSubmission("ET-0001", "2025-Q4")
+	require.NoError(t, err)
+	assert.Equal(t, "accepted", loaded.Status)
+
+	require.NoError(t, os.Remove(filepath.Join(evidenceDir, "summary.md")))
+	withdrawn, err := storage.WithdrawSubmission("ET-0001", "2025-Q4", withdrawal)
+	require.NoError(t, err)
+	assert.Equal(t, "superseded", withdrawn.Status)
+	assert.Nil(t, withdrawn.AcceptedAt)
+	require.NotNil(t, withdrawn.Withdrawal)
+	assert.Equal(t, "Wrong quarter exported", withdrawn.Withdrawal.Reason)
+
+	// Files are back in the root, so the window can be submitted again
+	assert.FileExists(t, filepath.Join(evidenceDir, "access_review.csv"))
+	assert.FileExists(t, filepath.Join(evidenceDir, "summary.md"))
+	assert.DirExists(t, filepath.Join(evidenceDir, ".generation"))
+	alreadySubmitted, err := storage.CheckAlreadySubmitted("ET-0001", "2025-Q4")
+	require.NoError(t, err)
+	assert.False(t, alreadySubmitted)
+	data, err := os.ReadFile(filepath.Join(submittedDir, submissionMetadataDir, submissionFilename))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "status: superseded")
+
+	// A window never submitted has nothing to withdraw
+	_, err = storage.WithdrawSubmission("ET-0001", "2025-Q3", withdrawal)
+	assert.ErrorContains(t, err, "no submission recorded for ET-0001 in window 2025-Q3")
+}
+
+func TestSubmissionStorage_Feedback(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := config.StorageConfig{
+		DataDir: tmpDir,
+		Paths:   config.StoragePaths{}.WithDefaults(),
+	}
+	storage, err := NewStorage(cfg)
+	require.NoError(t, err)
+
+	_, err = storage.LoadFeedback("ET-0001", "2025-Q4")
+	assert.ErrorContains(t, err, "feedback not found")
+
+	feedback := &models.EvidenceFeedback{
+		TaskRef:  "ET-0001",
+		Window:   "2025-Q4",
+		SyncedAt: time.Date(2025, 11, 10, 14, 30, 0, 0, time.UTC),
+		Comments: []models.FeedbackComment{
+			{ID: "901", Author: "Alex Auditor", Status: "rejected", Comment: "Screenshot is missing the date", AttachmentID: "50001"},
+		},
+	}
+	require.NoError(t, storage.SaveFeedback(feedback))
+
+	_, err = os.Stat(filepath.Join(tmpDir, "evidence", "ET-0001", "2025-Q4", submissionMetadataDir, feedbackFilename))
+	require.NoError(t, err)
+
+	loaded, err := storage.LoadFeedback("ET-0001", "2025-Q4")
+	require.NoError(t, err)
+	assert.Equal(t, feedback, loaded)
+
+	assert.Error(t, storage.SaveFeedback(nil))
+}
+
+func TestSubmissionStorage_Approval(t *testing.T) {
+	tmpDir := t.TempDir()
+	storage, err := NewStorage(config.StorageConfig{DataDir: tmpDir})
+	require.NoError(t, err)
+
+	_, err = storage.LoadApproval("ET-0001", "2025-Q4")
+	assert.ErrorContains(t, err, "approval not found")
+
+	approval := &models.EvidenceApproval{
+		TaskRef:    "ET-0001",
+		Window:     "2025-Q4",
+		Approver:   "sam@example.com",
+		ApprovedAt: time.Date(2025, 11, 10, 14, 30, 0, 0, time.UTC),
+		Files:      []models.ApprovedFile{{Filename: "access.csv", SHA256: "9a0364b9"}},
+	}
+	require.NoError(t, storage.SaveApproval(approval))
+	assert.FileExists(t, filepath.Join(tmpDir, "evidence", "ET-0001", "2025-Q4", submissionMetadataDir, approvalFilename))
+
+	loaded, err := storage.LoadApproval("ET-0001", "2025-Q4")
+	require.NoError(t, err)
+	assert.Equal(t, approval, loaded)
+
+	assert.Error(t, storage.SaveApproval(nil))
+}
+
+func TestSubmissionStorage_SubmissionQueue(t *testing.T) {
+	tmpDir := t.TempDir()
+	storage, err := NewStorage(config.StorageConfig{DataDir: tmpDir})
+	require.NoError(t, err)
+
+	queue, err := storage.LoadSubmissionQueue()
+	require.NoError(t, err)
+	assert.Empty(t, queue.Entries, "empty until a submission is queued")
+
+	sentAt := time.Date(2025, 10, 15, 12, 0, 0, 0, time.UTC)
+	queue.Enqueue(models.QueuedSubmission{TaskRef: "ET-0001", Window: "2025-Q4", QueuedAt: sentAt.Add(-time.Hour), Reason: "Tugboat Logic is unreachable"})
+	queue.Entries[0].Status = "sent"
+	queue.Entries[0].SentAt = &sentAt
+	queue.Entries[0].Receipts = map[string]string{"access.csv": "doc-1"}
+	queue.Enqueue(models.QueuedSubmission{TaskRef: "ET-0002", Window: "2025-Q4", QueuedAt: sentAt})
+	require.NoError(t, storage.SaveSubmissionQueue(queue))
+	assert.FileExists(t, filepath.Join(tmpDir, batchStorageDir, queueFilename))
+
+	loaded, err := storage.LoadSubmissionQueue()
+	require.NoError(t, err)
+	assert.Equal(t, queue, loaded)
+
+	batches, err := storage.ListBatches()
+	require.NoError(t, err)
+	assert.Empty(t, batches, "the queue is not a batch")
+
+	assert.Error(t, storage.SaveSubmissionQueue(nil))
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/grctool/grctool/internal/config"
 	"github.com/grctool/grctool/internal/domain"
 	"github.com/grctool/grctool/internal/interfaces"
+	"github.com/grctool/grctool/internal/models"
 	"github.com/grctool/grctool/internal/utils"
 )
 
@@ -36,6 +37,7 @@ type Storage struct {
 	filenameGenerator *utils.FilenameGenerator
 	docsDir           string              // Directory for synced documents
 	paths             config.StoragePaths // Configured paths
+	catalog           *Catalog            // SQLite index of docs and evidence, when enabled
 }
 
 // Ensure Storage implements the StorageService interface
@@ -64,15 +66,31 @@ func NewStorage(cfg config.StorageConfig) (*Storage, error) {
 		return nil, err
 	}
 
+	// The catalog only speeds up reads, so one that cannot be opened leaves
+	// every read to the files
+	var catalog *Catalog
+	if cfg.Catalog.Enabled {
+		if catalog, err = OpenCatalog(cfg); err != nil {
+			catalog = nil
+		}
+	}
+
 	return &Storage{
 		fileStorage:       fileStorage,
 		localDataStore:    localDataStore,
 		filenameGenerator: utils.NewFilenameGenerator(),
 		docsDir:           docsDir,
 		paths:             paths,
+		catalog:           catalog,
 	}, nil
 }
 
+// Catalog returns the metadata catalog, or nil when storage.catalog is not
+// enabled
+func (us *Storage) Catalog() *Catalog {
+	return us.catalog
+}
+
 // Helper methods to get relative paths for fileStorage
 func (us *Storage) policiesPath() string {
 	return strings.TrimPrefix(us.paths.PoliciesJSON, us.fileStorage.baseDir+"/")
@@ -136,8 +154,12 @@ func (us *Storage) SaveEvidenceTask(task *domain.EvidenceTask) error {
 		"json",
 	)
 
+	// Deferrals are kept in their own collection, so syncs do not drop them
+	saved := *task
+	saved.Deferral = nil
+
 	// Save using the new filename pattern
-	return us.fileStorage.Save(us.evidenceTasksPath(), filename[:len(filename)-5], task) // Remove .json extension
+	return us.fileStorage.Save(us.evidenceTasksPath(), filename[:len(filename)-5], &saved) // Remove .json extension
 }
 
 // GetPolicyByReferenceAndID retrieves a policy by reference ID and numeric ID
@@ -253,6 +275,12 @@ func (us *Storage) GetEvidenceTaskByExternalID(provider, externalID string) (*do
 
 // GetPolicy retrieves a policy by ID (numeric, reference ID, or filename)
 func (us *Storage) GetPolicy(id string) (*domain.Policy, error) {
+	if us.catalog != nil {
+		if policy, err := us.catalog.Policy(id); err == nil && policy != nil {
+			return policy, nil
+		}
+	}
+
 	policies, err := us.GetAllPolicies()
 	if err != nil {
 		return nil, err
@@ -285,6 +313,12 @@ func (us *Storage) GetPolicy(id string) (*domain.Policy, error) {
 
 // GetControl retrieves a control by ID (numeric, reference ID, or filename)
 func (us *Storage) GetControl(id string) (*domain.Control, error) {
+	if us.catalog != nil {
+		if control, err := us.catalog.Control(id); err == nil && control != nil {
+			return control, nil
+		}
+	}
+
 	controls, err := us.GetAllControls()
 	if err != nil {
 		return nil, err
@@ -317,6 +351,13 @@ func (us *Storage) GetControl(id string) (*domain.Control, error) {
 
 // GetEvidenceTask retrieves an evidence task by ID or task reference (ET-0001, ET0001, or 327992)
 func (us *Storage) GetEvidenceTask(id string) (*domain.EvidenceTask, error) {
+	if us.catalog != nil {
+		if task, err := us.catalog.EvidenceTask(id); err == nil && task != nil {
+			task.Deferral, _ = us.GetTaskDeferral(task.ReferenceID)
+			return task, nil
+		}
+	}
+
 	tasks, err := us.GetAllEvidenceTasks()
 	if err != nil {
 		return nil, err
@@ -338,6 +379,16 @@ func (us *Storage) GetEvidenceTask(id string) (*domain.EvidenceTask, error) {
 	return nil, fmt.Errorf("evidence task not found: %s (parsed as ID %s)", id, taskID)
 }
 
+// EvidenceTaskCategory returns the category of an evidence task, or "" when
+// the task is not synced
+func (us *Storage) EvidenceTaskCategory(taskRef string) string {
+	task, err := us.GetEvidenceTask(taskRef)
+	if err != nil {
+		return ""
+	}
+	return task.GetCategory()
+}
+
 // parseTaskID extracts the task ID from various formats:
 // - "327992" -> "327992" (pure numeric string)
 // - "ET-0001" -> looks up by ReferenceID, returns the task's ID
@@ -393,6 +444,12 @@ func (us *Storage) lookupTaskByReferenceNumber(refNum int) string {
 
 // GetAllPolicies retrieves all policies regardless of filename format
 func (us *Storage) GetAllPolicies() ([]domain.Policy, error) {
+	if us.catalog != nil {
+		if policies, err := us.catalog.Policies(); err == nil {
+			return policies, nil
+		}
+	}
+
 	var policies []domain.Policy
 
 	ids, err := us.fileStorage.List(us.policiesPath())
@@ -412,6 +469,12 @@ func (us *Storage) GetAllPolicies() ([]domain.Policy, error) {
 
 // GetAllControls retrieves all controls regardless of filename format
 func (us *Storage) GetAllControls() ([]domain.Control, error) {
+	if us.catalog != nil {
+		if controls, err := us.catalog.Controls(); err == nil {
+			return controls, nil
+		}
+	}
+
 	var controls []domain.Control
 
 	ids, err := us.fileStorage.List(us.controlsPath())
@@ -431,6 +494,13 @@ func (us *Storage) GetAllControls() ([]domain.Control, error) {
 
 // GetAllEvidenceTasks retrieves all evidence tasks regardless of filename format
 func (us *Storage) GetAllEvidenceTasks() ([]domain.EvidenceTask, error) {
+	if us.catalog != nil {
+		if tasks, err := us.catalog.EvidenceTasks(); err == nil {
+			us.attachDeferrals(tasks)
+			return tasks, nil
+		}
+	}
+
 	var tasks []domain.EvidenceTask
 
 	ids, err := us.fileStorage.List(us.evidenceTasksPath())
@@ -445,6 +515,7 @@ func (us *Storage) GetAllEvidenceTasks() ([]domain.EvidenceTask, error) {
 		}
 	}
 
+	us.attachDeferrals(tasks)
 	return tasks, nil
 }
 
@@ -567,8 +638,10 @@ func (us *Storage) GetEvidenceTaskSummary() (*domain.EvidenceTaskSummary, error)
 			summary.ByPriority[task.Priority]++
 		}
 
-		// Count overdue and due soon
-		if task.NextDue != nil {
+		// Count overdue and due soon; deferred tasks are counted apart
+		if task.IsDeferred(now) {
+			summary.Deferred++
+		} else if task.NextDue != nil {
 			if task.NextDue.Before(now) {
 				summary.Overdue++
 			} else if task.NextDue.Before(now.AddDate(0, 0, 7)) {
@@ -631,6 +704,29 @@ func (us *Storage) GetSyncTime(syncType string) (time.Time, error) {
 	return time.Time{}, fmt.Errorf("invalid sync time format")
 }
 
+// SaveSyncCheckpoint stores the progress of the current sync
+func (us *Storage) SaveSyncCheckpoint(checkpoint *models.SyncCheckpoint) error {
+	return us.fileStorage.Save("sync_state", "checkpoint", checkpoint)
+}
+
+// LoadSyncCheckpoint retrieves the progress of a sync that did not finish,
+// or nil if there is none
+func (us *Storage) LoadSyncCheckpoint() (*models.SyncCheckpoint, error) {
+	if !us.fileStorage.Exists("sync_state", "checkpoint") {
+		return nil, nil
+	}
+	var checkpoint models.SyncCheckpoint
+	if err := us.fileStorage.Load("sync_state", "checkpoint", &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to load sync checkpoint: %w", err)
+	}
+	return &checkpoint, nil
+}
+
+// ClearSyncCheckpoint removes the checkpoint once a sync has finished
+func (us *Storage) ClearSyncCheckpoint() error {
+	return us.fileStorage.Delete("sync_state", "checkpoint")
+}
+
 // Clear removes all stored data (use with caution!)
 func (us *Storage) Clear() error {
 	// Clear local data store first
@@ -9,6 +9,7 @@ import (
 
 	"github.com/grctool/grctool/internal/config"
 	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/models"
 	"github.com/grctool/grctool/internal/testhelpers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -394,6 +395,34 @@ func TestStorage_GetSyncTime_NotFound(t *testing.T) {
 	assert.Error(t, err)
 }
 
+// --- SyncCheckpoint ---
+
+func TestStorage_SyncCheckpoint(t *testing.T) {
+	t.Parallel()
+	s := newTestStorage(t)
+
+	checkpoint, err := s.LoadSyncCheckpoint()
+	require.NoError(t, err)
+	assert.Nil(t, checkpoint, "no checkpoint before a sync")
+
+	saved := models.NewSyncCheckpoint("policies,controls")
+	saved.MarkCompleted("tugboat/policies", "94001")
+	saved.MarkFinished("tugboat/policies")
+	require.NoError(t, s.SaveSyncCheckpoint(saved))
+
+	loaded, err := s.LoadSyncCheckpoint()
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	assert.Equal(t, "policies,controls", loaded.Scope)
+	assert.True(t, loaded.IsCompleted("tugboat/policies", "94001"))
+	assert.True(t, loaded.IsFinished("tugboat/policies"))
+
+	require.NoError(t, s.ClearSyncCheckpoint())
+	loaded, err = s.LoadSyncCheckpoint()
+	require.NoError(t, err)
+	assert.Nil(t, loaded)
+}
+
 // --- GetStats ---
 
 func TestStorage_GetStats_WithData(t *testing.T) {
@@ -0,0 +1,89 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/grctool/grctool/internal/domain"
+)
+
+// vendorsCollection is the docs subdirectory holding one JSON file per vendor
+const vendorsCollection = "vendors"
+
+// vendorIDPrefix prefixes generated vendor IDs (VEN-0001)
+const vendorIDPrefix = "VEN-"
+
+// SaveVendor validates and saves a vendor in the vendor inventory
+func (us *Storage) SaveVendor(vendor *domain.Vendor) error {
+	if vendor == nil {
+		return fmt.Errorf("vendor cannot be nil")
+	}
+	if vendor.ID == "" {
+		return fmt.Errorf("vendor ID cannot be empty")
+	}
+	if err := vendor.Validate(); err != nil {
+		return err
+	}
+	return us.fileStorage.Save(vendorsCollection, vendor.ID, vendor)
+}
+
+// GetVendor retrieves a vendor by ID (VEN-0001, ven-1 or 1) or by name,
+// ignoring case
+func (us *Storage) GetVendor(id string) (*domain.Vendor, error) {
+	var vendor domain.Vendor
+	if err := us.fileStorage.Load(vendorsCollection, normalizeSequentialID(vendorIDPrefix, id), &vendor); err == nil {
+		return &vendor, nil
+	}
+
+	vendors, err := us.GetAllVendors()
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range vendors {
+		if strings.EqualFold(v.Name, strings.TrimSpace(id)) {
+			return &v, nil
+		}
+	}
+	return nil, fmt.Errorf("vendor not found: %s", id)
+}
+
+// GetAllVendors retrieves the vendor inventory, sorted by ID
+func (us *Storage) GetAllVendors() ([]domain.Vendor, error) {
+	ids, err := us.fileStorage.List(vendorsCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	vendors := make([]domain.Vendor, 0, len(ids))
+	for _, id := range ids {
+		var vendor domain.Vendor
+		if err := us.fileStorage.Load(vendorsCollection, id, &vendor); err != nil {
+			return nil, fmt.Errorf("failed to load vendor %s: %w", id, err)
+		}
+		vendors = append(vendors, vendor)
+	}
+
+	sort.Slice(vendors, func(i, j int) bool { return vendors[i].ID < vendors[j].ID })
+	return vendors, nil
+}
+
+// NextVendorID returns the ID after the highest one in the inventory
+func (us *Storage) NextVendorID() (string, error) {
+	return us.fileStorage.NextSequentialID(vendorsCollection, vendorIDPrefix)
+}
@@ -0,0 +1,51 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVendorStorage(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+	store, err := NewStorage(config.StorageConfig{DataDir: dataDir})
+	require.NoError(t, err)
+
+	id, err := store.NextVendorID()
+	require.NoError(t, err)
+	assert.Equal(t, "VEN-0001", id)
+
+	vendor := &domain.Vendor{ID: id, Name: "Acme Cloud", Status: domain.VendorStatusActive,
+		Criticality: domain.VendorCriticalityHigh, DataAccess: domain.VendorDataConfidential}
+	require.NoError(t, store.SaveVendor(vendor))
+	assert.FileExists(t, filepath.Join(dataDir, "docs", "vendors", "VEN-0001.json"))
+
+	for _, ref := range []string{"VEN-0001", "ven-1", "1", "acme cloud"} {
+		loaded, err := store.GetVendor(ref)
+		require.NoError(t, err, ref)
+		assert.Equal(t, "Acme Cloud", loaded.Name)
+	}
+	_, err = store.GetVendor("Globex")
+	assert.ErrorContains(t, err, "vendor not found")
+
+	id, err = store.NextVendorID()
+	require.NoError(t, err)
+	assert.Equal(t, "VEN-0002", id)
+
+	err = store.SaveVendor(&domain.Vendor{ID: id, Name: "Globex", Status: domain.VendorStatusActive,
+		Criticality: "extreme", DataAccess: domain.VendorDataNone})
+	assert.ErrorContains(t, err, `invalid criticality "extreme"`)
+
+	vendors, err := store.GetAllVendors()
+	require.NoError(t, err)
+	assert.Len(t, vendors, 1)
+}
@@ -0,0 +1,267 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// OTLPExporter exports spans to an OpenTelemetry collector with OTLP/HTTP,
+// JSON encoded, as collectors accept on port 4318
+type OTLPExporter struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+// NewOTLPExporter creates an exporter posting to the /v1/traces path of
+// endpoint, unless endpoint already names it, with the given headers
+func NewOTLPExporter(endpoint string, headers map[string]string) *OTLPExporter {
+	url := strings.TrimRight(endpoint, "/")
+	if !strings.HasSuffix(url, "/v1/traces") {
+		url += "/v1/traces"
+	}
+	return &OTLPExporter{url: url, headers: headers, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// ExportSpans posts a batch of ended spans to the collector
+func (e *OTLPExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(otlpRequest(spans))
+	if err != nil {
+		return fmt.Errorf("failed to encode spans: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create OTLP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range e.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to export spans to %s: %w", e.url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("failed to export spans to %s: %s: %s", e.url, resp.Status, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+// Shutdown releases the exporter's connections
+func (e *OTLPExporter) Shutdown(ctx context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// The OTLP/JSON encoding of ExportTraceServiceRequest: IDs are hex, 64-bit
+// integers are decimal strings
+
+type otlpTraces struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Events            []otlpEvent    `json:"events,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"` // 1 ok, 2 error
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string     `json:"stringValue,omitempty"`
+	BoolValue   *bool       `json:"boolValue,omitempty"`
+	IntValue    *string     `json:"intValue,omitempty"`
+	DoubleValue *float64    `json:"doubleValue,omitempty"`
+	ArrayValue  *otlpValues `json:"arrayValue,omitempty"`
+}
+
+type otlpValues struct {
+	Values []otlpValue `json:"values"`
+}
+
+// otlpRequest groups spans by resource and instrumentation scope
+func otlpRequest(spans []sdktrace.ReadOnlySpan) otlpTraces {
+	var request otlpTraces
+	resources := make(map[attribute.Distinct]int)
+	scopes := make(map[attribute.Distinct]map[instrumentation.Scope]int)
+	for _, span := range spans {
+		resourceKey := span.Resource().Equivalent()
+		r, ok := resources[resourceKey]
+		if !ok {
+			r = len(request.ResourceSpans)
+			resources[resourceKey] = r
+			scopes[resourceKey] = make(map[instrumentation.Scope]int)
+			request.ResourceSpans = append(request.ResourceSpans, otlpResourceSpans{
+				Resource: otlpResource{Attributes: otlpAttributes(span.Resource().Attributes())},
+			})
+		}
+		scope := span.InstrumentationScope()
+		s, ok := scopes[resourceKey][scope]
+		if !ok {
+			s = len(request.ResourceSpans[r].ScopeSpans)
+			scopes[resourceKey][scope] = s
+			request.ResourceSpans[r].ScopeSpans = append(request.ResourceSpans[r].ScopeSpans, otlpScopeSpans{
+				Scope: otlpScope{Name: scope.Name, Version: scope.Version},
+			})
+		}
+		request.ResourceSpans[r].ScopeSpans[s].Spans = append(request.ResourceSpans[r].ScopeSpans[s].Spans, encodeSpan(span))
+	}
+	return request
+}
+
+func encodeSpan(span sdktrace.ReadOnlySpan) otlpSpan {
+	encoded := otlpSpan{
+		TraceID:           span.SpanContext().TraceID().String(),
+		SpanID:            span.SpanContext().SpanID().String(),
+		Name:              span.Name(),
+		Kind:              int(span.SpanKind()), // trace.SpanKind numbers kinds as OTLP does
+		StartTimeUnixNano: unixNano(span.StartTime()),
+		EndTimeUnixNano:   unixNano(span.EndTime()),
+		Attributes:        otlpAttributes(span.Attributes()),
+	}
+	if span.Parent().HasSpanID() {
+		encoded.ParentSpanID = span.Parent().SpanID().String()
+	}
+	for _, event := range span.Events() {
+		encoded.Events = append(encoded.Events, otlpEvent{
+			TimeUnixNano: unixNano(event.Time),
+			Name:         event.Name,
+			Attributes:   otlpAttributes(event.Attributes),
+		})
+	}
+	switch span.Status().Code {
+	case codes.Ok:
+		encoded.Status = otlpStatus{Code: 1}
+	case codes.Error:
+		encoded.Status = otlpStatus{Code: 2, Message: span.Status().Description}
+	}
+	return encoded
+}
+
+func otlpAttributes(attrs []attribute.KeyValue) []otlpKeyValue {
+	encoded := make([]otlpKeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		encoded = append(encoded, otlpKeyValue{Key: string(attr.Key), Value: encodeValue(attr.Value)})
+	}
+	return encoded
+}
+
+func encodeValue(value attribute.Value) otlpValue {
+	switch value.Type() {
+	case attribute.BOOL:
+		v := value.AsBool()
+		return otlpValue{BoolValue: &v}
+	case attribute.INT64:
+		v := strconv.FormatInt(value.AsInt64(), 10)
+		return otlpValue{IntValue: &v}
+	case attribute.FLOAT64:
+		v := value.AsFloat64()
+		return otlpValue{DoubleValue: &v}
+	case attribute.BOOLSLICE:
+		var values []otlpValue
+		for _, v := range value.AsBoolSlice() {
+			values = append(values, encodeValue(attribute.BoolValue(v)))
+		}
+		return otlpValue{ArrayValue: &otlpValues{Values: values}}
+	case attribute.INT64SLICE:
+		var values []otlpValue
+		for _, v := range value.AsInt64Slice() {
+			values = append(values, encodeValue(attribute.Int64Value(v)))
+		}
+		return otlpValue{ArrayValue: &otlpValues{Values: values}}
+	case attribute.FLOAT64SLICE:
+		var values []otlpValue
+		for _, v := range value.AsFloat64Slice() {
+			values = append(values, encodeValue(attribute.Float64Value(v)))
+		}
+		return otlpValue{ArrayValue: &otlpValues{Values: values}}
+	case attribute.STRINGSLICE:
+		var values []otlpValue
+		for _, v := range value.AsStringSlice() {
+			values = append(values, encodeValue(attribute.StringValue(v)))
+		}
+		return otlpValue{ArrayValue: &otlpValues{Values: values}}
+	default:
+		v := value.Emit()
+		return otlpValue{StringValue: &v}
+	}
+}
+
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
@@ -0,0 +1,135 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestOTLPExporter(t *testing.T) {
+	t.Parallel()
+
+	var (
+		path, contentType, apiKey string
+		received                  otlpTraces
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, contentType, apiKey = r.URL.Path, r.Header.Get("Content-Type"), r.Header.Get("X-API-Key")
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(NewOTLPExporter(server.URL+"/", map[string]string{"X-API-Key": "secret"})),
+		sdktrace.WithResource(sdkresource.NewSchemaless(attribute.String("service.name", "grctool"))),
+	)
+	_, span := provider.Tracer(instrumentationName).Start(context.Background(), "grctool sync")
+	End(span, nil)
+	require.NoError(t, provider.Shutdown(context.Background()))
+
+	assert.Equal(t, "/v1/traces", path)
+	assert.Equal(t, "application/json", contentType)
+	assert.Equal(t, "secret", apiKey)
+
+	require.Len(t, received.ResourceSpans, 1)
+	assert.Equal(t, "service.name", received.ResourceSpans[0].Resource.Attributes[0].Key)
+	require.Len(t, received.ResourceSpans[0].ScopeSpans, 1)
+	assert.Equal(t, instrumentationName, received.ResourceSpans[0].ScopeSpans[0].Scope.Name)
+
+	exported := received.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	assert.Equal(t, "grctool sync", exported.Name)
+	assert.Equal(t, span.SpanContext().TraceID().String(), exported.TraceID)
+	assert.Equal(t, span.SpanContext().SpanID().String(), exported.SpanID)
+	assert.Empty(t, exported.ParentSpanID)
+	assert.Equal(t, otlpStatus{}, exported.Status)
+}
+
+func TestOTLPExporter_Span(t *testing.T) {
+	t.Parallel()
+
+	var received otlpTraces
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(NewOTLPExporter(server.URL+"/v1/traces", nil)))
+	ctx, parent := provider.Tracer(instrumentationName).Start(context.Background(), "grctool sync")
+	_, child := provider.Tracer(instrumentationName).Start(ctx, "tool.execute", trace.WithAttributes(
+		attribute.String("tool.name", "github-permissions"),
+		attribute.Int("tool.params", 3),
+		attribute.Bool("tool.cached", false),
+		attribute.StringSlice("tool.tasks", []string{"ET-0001"}),
+	))
+	End(child, errors.New("rate limited"))
+	require.NoError(t, provider.Shutdown(context.Background()))
+
+	span := received.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	assert.Equal(t, "tool.execute", span.Name)
+	assert.Equal(t, parent.SpanContext().SpanID().String(), span.ParentSpanID)
+	assert.Equal(t, 1, span.Kind)
+	assert.Equal(t, otlpStatus{Code: 2, Message: "rate limited"}, span.Status)
+	require.Len(t, span.Events, 1)
+	assert.Equal(t, "exception", span.Events[0].Name)
+
+	values := make(map[string]otlpValue)
+	for _, attr := range span.Attributes {
+		values[attr.Key] = attr.Value
+	}
+	assert.Equal(t, "github-permissions", *values["tool.name"].StringValue)
+	assert.Equal(t, "3", *values["tool.params"].IntValue)
+	assert.False(t, *values["tool.cached"].BoolValue)
+	assert.Equal(t, "ET-0001", *values["tool.tasks"].ArrayValue.Values[0].StringValue)
+}
+
+func TestOTLPExporter_Failure(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	provider := sdktrace.NewTracerProvider()
+	_, span := provider.Tracer(instrumentationName).Start(context.Background(), "grctool sync")
+	span.End()
+
+	err := NewOTLPExporter(server.URL, nil).ExportSpans(context.Background(), []sdktrace.ReadOnlySpan{span.(sdktrace.ReadOnlySpan)})
+	assert.ErrorContains(t, err, "401 Unauthorized: unauthorized")
+}
+
+func TestSetup_Disabled(t *testing.T) {
+	t.Parallel()
+
+	shutdown := Setup(config.TracingConfig{}, "dev")
+	assert.NoError(t, shutdown(context.Background()))
+
+	_, span := Start(context.Background(), "grctool sync")
+	assert.False(t, span.IsRecording())
+}
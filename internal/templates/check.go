@@ -0,0 +1,152 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/interpolation"
+)
+
+// TemplateProblem is a problem found in an evidence template
+type TemplateProblem struct {
+	Template string `json:"template"` // Template name, or the mapping that selects the file, e.g. "tasks.ET-0047"
+	Path     string `json:"path"`     // File read, or "built-in"
+	interpolation.Problem
+}
+
+// Check renders every evidence template, the overrides and the mapped files
+// included, against a sample task and reports what it leaves undefined
+func (t *EvidenceTemplates) Check(cfg *config.Config) ([]TemplateProblem, error) {
+	var problems []TemplateProblem
+	for _, name := range EvidenceTemplateNames {
+		content, err := t.Get(name)
+		if err != nil {
+			return problems, err
+		}
+		path := t.OverridePath(name)
+		if path == "" {
+			path = "built-in"
+		}
+		for _, problem := range CheckEvidence(name, content, cfg) {
+			problems = append(problems, TemplateProblem{Template: name, Path: path, Problem: problem})
+		}
+	}
+
+	files := t.mappings.Files()
+	mappings := make([]string, 0, len(files))
+	for mapping := range files {
+		mappings = append(mappings, mapping)
+	}
+	sort.Strings(mappings)
+	for _, mapping := range mappings {
+		path := files[mapping]
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return problems, fmt.Errorf("failed to read template %s mapped by evidence.generation.templates.%s: %w", path, mapping, err)
+		}
+		for _, problem := range CheckEvidence(filepath.Base(path), string(raw), cfg) {
+			problems = append(problems, TemplateProblem{Template: mapping, Path: path, Problem: problem})
+		}
+	}
+	return problems, nil
+}
+
+// templateError picks the line, action and message out of text/template
+// errors such as
+// template: generic.md:12:8: executing "generic.md" at <.Task.Owner>: can't evaluate field Owner ...
+var templateError = regexp.MustCompile(`^template: [^:]*:(\d+)(?::\d+)?: (?:executing "[^"]*" at <([^>]*)>: )?(.*)$`)
+
+// CheckEvidence reports what evidence template content leaves undefined:
+// {{TASK_REF}}-style placeholders that are not known, which are left as
+// written, and the first action that fails to parse or render against a
+// sample task
+func CheckEvidence(name, content string, cfg *config.Config) []interpolation.Problem {
+	var problems []interpolation.Problem
+
+	// The control mapping row is on a single line, so blanking it keeps the
+	// line numbers of the rest
+	scanned := strings.ReplaceAll(content, legacyControlRow, strings.Repeat(" ", len(legacyControlRow)))
+	for _, loc := range legacyPlaceholderPattern.FindAllStringSubmatchIndex(scanned, -1) {
+		placeholder := scanned[loc[2]:loc[3]]
+		if _, ok := legacyPlaceholders[placeholder]; ok {
+			continue
+		}
+		problems = append(problems, interpolation.Problem{
+			Expression: scanned[loc[0]:loc[1]],
+			Line:       strings.Count(scanned[:loc[0]], "\n") + 1,
+			Message:    fmt.Sprintf("unknown placeholder %s, which is left as written", placeholder),
+		})
+	}
+
+	if _, err := RenderEvidence(name, content, sampleEvidenceData(cfg)); err != nil {
+		problem := interpolation.Problem{Message: err.Error()}
+		if match := templateError.FindStringSubmatch(errorCause(err)); match != nil {
+			problem.Line, _ = strconv.Atoi(match[1])
+			if match[2] != "" {
+				problem.Expression = "{{" + match[2] + "}}"
+			}
+			problem.Message = match[3]
+		}
+		problems = append(problems, problem)
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].Line < problems[j].Line })
+	return problems
+}
+
+// errorCause returns the message of the text/template error RenderEvidence
+// wraps
+func errorCause(err error) string {
+	for {
+		unwrapped := errors.Unwrap(err)
+		if unwrapped == nil {
+			return err.Error()
+		}
+		err = unwrapped
+	}
+}
+
+// sampleEvidenceData is a task with a related control in a quarterly window,
+// for rendering templates without synced data
+func sampleEvidenceData(cfg *config.Config) EvidenceData {
+	task := &domain.EvidenceTask{
+		ID:                 "1",
+		ReferenceID:        "ET-0001",
+		Name:               "Sample evidence task",
+		Description:        "Sample description",
+		Guidance:           "Sample guidance",
+		CollectionInterval: "quarter",
+		Framework:          "SOC 2",
+		Category:           "Process",
+		RelatedControls: []domain.Control{{
+			ID:          "1",
+			ReferenceID: "CC-01",
+			Name:        "Sample control",
+			Framework:   "SOC 2",
+		}},
+	}
+	return NewEvidenceData(task, "2025-Q1", cfg)
+}
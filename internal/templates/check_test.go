@@ -0,0 +1,100 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/interpolation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckEvidence(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{}
+
+	tests := map[string]struct {
+		content string
+		want    []interpolation.Problem
+	}{
+		"known fields and placeholders": {
+			content: "# {{TASK_REF}} {{.Task.Name}}\n" + legacyControlRow + "\n{{range .Controls}}{{.ID}}{{end}}",
+		},
+		"unknown placeholder": {
+			content: "# {{TASK_REF}}\nOwner: {{TASK_OWNER}}",
+			want: []interpolation.Problem{
+				{Expression: "{{TASK_OWNER}}", Line: 2, Message: "unknown placeholder TASK_OWNER, which is left as written"},
+			},
+		},
+		"unknown field after the control row": {
+			content: legacyControlRow + "\n\n{{.Task.Owner}}",
+			want: []interpolation.Problem{
+				{Expression: "{{.Task.Owner}}", Line: 3, Message: "can't evaluate field Owner in type *domain.EvidenceTask"},
+			},
+		},
+		"interpolation variable": {
+			content: "Prepared for\n{{organization.name}}",
+			want: []interpolation.Problem{
+				{Line: 2, Message: `function "organization" not defined`},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, CheckEvidence("check.md", tc.content, cfg))
+		})
+	}
+}
+
+func TestEvidenceTemplates_Check(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "generic.md"), []byte("# {{TASK_REF}}\n{{.Task.Owner}}\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "vendor.md"), []byte("# {{VENDOR}}\n"), 0644))
+	evidenceTemplates := NewEvidenceTemplates(dir).WithMappings(config.EvidenceTemplateMappings{
+		Categories: map[string]string{"Vendor": filepath.Join(dir, "vendor.md")},
+	})
+
+	problems, err := evidenceTemplates.Check(&config.Config{})
+	require.NoError(t, err)
+	require.Len(t, problems, 2)
+	assert.Equal(t, "generic", problems[0].Template)
+	assert.Equal(t, filepath.Join(dir, "generic.md"), problems[0].Path)
+	assert.Equal(t, 2, problems[0].Line)
+	assert.Equal(t, "categories.Vendor", problems[1].Template)
+	assert.Equal(t, "{{VENDOR}}", problems[1].Expression)
+
+	_, err = NewEvidenceTemplates("").WithMappings(config.EvidenceTemplateMappings{
+		Tasks: map[string]string{"ET-0001": filepath.Join(dir, "missing.md")},
+	}).Check(&config.Config{})
+	assert.ErrorContains(t, err, "mapped by evidence.generation.templates.tasks.ET-0001")
+}
+
+func TestEvidenceTemplates_CheckBuiltins(t *testing.T) {
+	t.Parallel()
+
+	problems, err := NewEvidenceTemplates("").Check(&config.Config{})
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+}
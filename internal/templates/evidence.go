@@ -0,0 +1,222 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package templates
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/frameworks"
+)
+
+//go:embed evidence/*.md
+var evidenceFS embed.FS
+
+// Evidence templates written by 'grctool evidence generate'
+const (
+	InstructionsTemplate   = "instructions"   // How an assistant uses the assembly materials
+	GenericTemplate        = "generic"        // Evidence outline for tasks without a category template
+	InfrastructureTemplate = "infrastructure" // Evidence outline per task category
+	PersonnelTemplate      = "personnel"
+	ProcessTemplate        = "process"
+	ComplianceTemplate     = "compliance"
+	MonitoringTemplate     = "monitoring"
+	DataTemplate           = "data"
+)
+
+// EvidenceTemplateNames lists the evidence templates in the order they are
+// shown
+var EvidenceTemplateNames = []string{
+	InstructionsTemplate, GenericTemplate, InfrastructureTemplate, PersonnelTemplate,
+	ProcessTemplate, ComplianceTemplate, MonitoringTemplate, DataTemplate,
+}
+
+// EvidenceTemplates reads the instructions and evidence outlines written for
+// each task. A file <name>.md in the override directory replaces the built-in
+// template of that name, so organizations can change instructions, tone and
+// required sections without rebuilding grctool, and mapped files replace the
+// outline of particular tasks, categories or frameworks. Templates are
+// markdown rendered with text/template when they are written.
+type EvidenceTemplates struct {
+	dir      string
+	mappings config.EvidenceTemplateMappings
+}
+
+// NewEvidenceTemplates creates a reader of the templates overridden in dir;
+// an empty dir uses the built-in templates only
+func NewEvidenceTemplates(dir string) *EvidenceTemplates {
+	return &EvidenceTemplates{dir: dir}
+}
+
+// WithMappings sets the template files mapped to task references,
+// categories and frameworks
+func (t *EvidenceTemplates) WithMappings(mappings config.EvidenceTemplateMappings) *EvidenceTemplates {
+	t.mappings = mappings
+	return t
+}
+
+// MappedPath returns the template file mapped to the task and the mapping
+// that selected it, e.g. "categories.Vendor", trying the task reference, then
+// its category, then its frameworks. It returns "" when no mapping applies.
+func (t *EvidenceTemplates) MappedPath(task *domain.EvidenceTask) (path, mapping string) {
+	if key, path := lookupMapping(t.mappings.Tasks, task.ReferenceID, strings.EqualFold); path != "" {
+		return path, "tasks." + key
+	}
+	if key, path := lookupMapping(t.mappings.Categories, task.GetCategory(), strings.EqualFold); path != "" {
+		return path, "categories." + key
+	}
+	sameFramework := func(a, b string) bool { return frameworks.Key(a) == frameworks.Key(b) }
+	for _, framework := range taskFrameworks(task) {
+		if key, path := lookupMapping(t.mappings.Frameworks, framework, sameFramework); path != "" {
+			return path, "frameworks." + key
+		}
+	}
+	return "", ""
+}
+
+// RenderMapped renders the template file mapped to the task; ok is false
+// when no mapping applies
+func (t *EvidenceTemplates) RenderMapped(data EvidenceData) (content string, ok bool, err error) {
+	path, mapping := t.MappedPath(data.Task)
+	if path == "" {
+		return "", false, nil
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", true, fmt.Errorf("failed to read template %s mapped by evidence.generation.templates.%s: %w", path, mapping, err)
+	}
+	if strings.TrimSpace(string(raw)) == "" {
+		return "", true, fmt.Errorf("template %s mapped by evidence.generation.templates.%s is empty", path, mapping)
+	}
+	content, err = RenderEvidence(filepath.Base(path), string(raw), data)
+	return content, true, err
+}
+
+// lookupMapping finds the mapping whose key matches value, preferring the
+// first key in sorted order so overlapping keys resolve the same way every run
+func lookupMapping(mapping map[string]string, value string, match func(a, b string) bool) (key, path string) {
+	if value == "" {
+		return "", ""
+	}
+	keys := make([]string, 0, len(mapping))
+	for key := range mapping {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if match(key, value) {
+			return key, mapping[key]
+		}
+	}
+	return "", ""
+}
+
+// taskFrameworks lists the task's framework followed by those it and its
+// related controls reference
+func taskFrameworks(task *domain.EvidenceTask) []string {
+	var names []string
+	if task.Framework != "" {
+		names = append(names, task.Framework)
+	}
+	for _, ref := range task.FrameworkReferences() {
+		names = append(names, ref.Framework)
+	}
+	return names
+}
+
+// Get returns the named template, from the override directory when it has
+// one
+func (t *EvidenceTemplates) Get(name string) (string, error) {
+	if path := t.OverridePath(name); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read template override %s: %w", path, err)
+		}
+		if strings.TrimSpace(string(data)) == "" {
+			return "", fmt.Errorf("template override %s is empty", path)
+		}
+		return string(data), nil
+	}
+	return Builtin(name)
+}
+
+// OverridePath returns the file overriding the named template, or "" when
+// the built-in template is used
+func (t *EvidenceTemplates) OverridePath(name string) string {
+	if t.dir == "" {
+		return ""
+	}
+	path := filepath.Join(t.dir, name+".md")
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+// Mappings returns the template files mapped to task references,
+// categories and frameworks
+func (t *EvidenceTemplates) Mappings() config.EvidenceTemplateMappings {
+	return t.mappings
+}
+
+// Dir returns the override directory
+func (t *EvidenceTemplates) Dir() string {
+	return t.dir
+}
+
+// Builtin returns the built-in evidence template of that name
+func Builtin(name string) (string, error) {
+	data, err := evidenceFS.ReadFile("evidence/" + name + ".md")
+	if err != nil {
+		return "", fmt.Errorf("unknown evidence template: %s", name)
+	}
+	return string(data), nil
+}
+
+// WriteBuiltins copies the built-in templates into the override directory
+// as a starting point for customizing them. Templates already overridden are
+// left alone; the paths written are returned.
+func (t *EvidenceTemplates) WriteBuiltins() ([]string, error) {
+	if t.dir == "" {
+		return nil, fmt.Errorf("no template override directory is configured")
+	}
+	if err := os.MkdirAll(t.dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create template directory: %w", err)
+	}
+
+	var written []string
+	for _, name := range EvidenceTemplateNames {
+		if t.OverridePath(name) != "" {
+			continue
+		}
+		content, err := Builtin(name)
+		if err != nil {
+			return written, err
+		}
+		path := filepath.Join(t.dir, name+".md")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return written, fmt.Errorf("failed to write template %s: %w", path, err)
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
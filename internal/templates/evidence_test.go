@@ -0,0 +1,217 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvidenceTemplates_Get(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "personnel.md"), []byte("# People Evidence for {{TASK_REF}}\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "process.md"), []byte("\n  \n"), 0644))
+	evidenceTemplates := NewEvidenceTemplates(dir)
+
+	tests := map[string]struct {
+		name     string
+		contains string
+		override bool
+		wantErr  string
+	}{
+		"built-in":         {name: InstructionsTemplate, contains: "{{.Task.ReferenceID}}_Evidence.md"},
+		"category":         {name: InfrastructureTemplate, contains: "# Infrastructure Evidence Report"},
+		"override":         {name: PersonnelTemplate, contains: "# People Evidence for {{TASK_REF}}", override: true},
+		"empty override":   {name: ProcessTemplate, override: true, wantErr: "process.md is empty"},
+		"unknown template": {name: "audit-letter", wantErr: "unknown evidence template: audit-letter"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.override, evidenceTemplates.OverridePath(tc.name) != "")
+			content, err := evidenceTemplates.Get(tc.name)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, content, tc.contains)
+		})
+	}
+}
+
+func TestEvidenceTemplates_Builtins(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range EvidenceTemplateNames {
+		content, err := Builtin(name)
+		require.NoError(t, err, name)
+		assert.NotEmpty(t, content, name)
+	}
+	content, err := NewEvidenceTemplates("").Get(DataTemplate)
+	require.NoError(t, err)
+	assert.Contains(t, content, "# Data Security Evidence Report")
+}
+
+func TestEvidenceTemplates_CategoryOutlines(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		name     string
+		contains []string
+	}{
+		"generic":        {name: GenericTemplate, contains: []string{"Evidence Report", "Executive Summary", "Technical Evidence"}},
+		"infrastructure": {name: InfrastructureTemplate, contains: []string{"Infrastructure"}},
+		"personnel":      {name: PersonnelTemplate, contains: []string{"Personnel"}},
+		"process":        {name: ProcessTemplate, contains: []string{"Process"}},
+		"compliance":     {name: ComplianceTemplate, contains: []string{"Compliance"}},
+		"monitoring":     {name: MonitoringTemplate, contains: []string{"Monitoring"}},
+		"data":           {name: DataTemplate, contains: []string{"Data"}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			content, err := NewEvidenceTemplates("").Get(tc.name)
+			require.NoError(t, err)
+			for _, want := range tc.contains {
+				assert.Contains(t, content, want)
+			}
+		})
+	}
+}
+
+func TestEvidenceTemplates_WriteBuiltins(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "templates")
+	evidenceTemplates := NewEvidenceTemplates(dir)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "instructions.md"), []byte("# Our instructions\n"), 0644))
+
+	written, err := evidenceTemplates.WriteBuiltins()
+	require.NoError(t, err)
+	assert.Len(t, written, len(EvidenceTemplateNames)-1)
+	assert.NotContains(t, written, filepath.Join(dir, "instructions.md"))
+
+	instructions, err := evidenceTemplates.Get(InstructionsTemplate)
+	require.NoError(t, err)
+	assert.Equal(t, "# Our instructions\n", instructions, "existing overrides are kept")
+	builtin, err := Builtin(DataTemplate)
+	require.NoError(t, err)
+	data, err := os.ReadFile(filepath.Join(dir, "data.md"))
+	require.NoError(t, err)
+	assert.Equal(t, builtin, string(data))
+
+	_, err = NewEvidenceTemplates("").WriteBuiltins()
+	assert.EqualError(t, err, "no template override directory is configured")
+}
+
+func TestEvidenceTemplates_MappedPath(t *testing.T) {
+	t.Parallel()
+
+	evidenceTemplates := NewEvidenceTemplates("").WithMappings(config.EvidenceTemplateMappings{
+		Tasks:      map[string]string{"et-0047": "/templates/et-0047.md"},
+		Categories: map[string]string{"Vendor": "/templates/vendor.md"},
+		Frameworks: map[string]string{"SOC 2": "/templates/soc2.md", "pci": "/templates/pci.md"},
+	})
+	pciControl := domain.Control{ID: "7", FrameworkCodes: []domain.FrameworkCode{{Framework: "PCI DSS v4.0", Code: "8.3.1"}}}
+
+	tests := map[string]struct {
+		task        *domain.EvidenceTask
+		wantPath    string
+		wantMapping string
+	}{
+		"task reference, any case": {
+			task:     &domain.EvidenceTask{ReferenceID: "ET-0047", Category: "Vendor", Framework: "SOC2"},
+			wantPath: "/templates/et-0047.md", wantMapping: "tasks.et-0047",
+		},
+		"category before framework": {
+			task:     &domain.EvidenceTask{ReferenceID: "ET-0090", Category: "vendor", Framework: "SOC2"},
+			wantPath: "/templates/vendor.md", wantMapping: "categories.Vendor",
+		},
+		"framework spelled differently": {
+			task:     &domain.EvidenceTask{ReferenceID: "ET-0001", Category: "Process", Framework: "SOC2"},
+			wantPath: "/templates/soc2.md", wantMapping: "frameworks.SOC 2",
+		},
+		"framework of a related control": {
+			task:     &domain.EvidenceTask{ReferenceID: "ET-0002", Category: "Process", RelatedControls: []domain.Control{pciControl}},
+			wantPath: "/templates/pci.md", wantMapping: "frameworks.pci",
+		},
+		"no mapping": {
+			task: &domain.EvidenceTask{ReferenceID: "ET-0003", Category: "Process", Framework: "HIPAA"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			path, mapping := evidenceTemplates.MappedPath(tc.task)
+			assert.Equal(t, tc.wantPath, path)
+			assert.Equal(t, tc.wantMapping, mapping)
+		})
+	}
+}
+
+func TestEvidenceTemplates_RenderMapped(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "vendor.md"), []byte("# Vendor Review: {{.Task.ReferenceID}}\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "blank.md"), []byte("\n"), 0644))
+	evidenceTemplates := NewEvidenceTemplates("").WithMappings(config.EvidenceTemplateMappings{
+		Tasks: map[string]string{
+			"ET-0001": filepath.Join(dir, "vendor.md"),
+			"ET-0002": filepath.Join(dir, "blank.md"),
+			"ET-0003": filepath.Join(dir, "missing.md"),
+		},
+	})
+
+	tests := map[string]struct {
+		ref     string
+		want    string
+		mapped  bool
+		wantErr string
+	}{
+		"rendered":   {ref: "ET-0001", want: "# Vendor Review: ET-0001\n", mapped: true},
+		"empty file": {ref: "ET-0002", mapped: true, wantErr: "mapped by evidence.generation.templates.tasks.ET-0002 is empty"},
+		"missing":    {ref: "ET-0003", mapped: true, wantErr: "failed to read template"},
+		"unmapped":   {ref: "ET-0004"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			content, mapped, err := evidenceTemplates.RenderMapped(EvidenceData{Task: &domain.EvidenceTask{ReferenceID: tc.ref}})
+			assert.Equal(t, tc.mapped, mapped)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, content)
+		})
+	}
+}
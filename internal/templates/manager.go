@@ -158,6 +158,11 @@ func (m *Manager) Execute(name string, data interface{}) (string, error) {
 
 // getTemplateFuncs returns custom template functions
 func (m *Manager) getTemplateFuncs() template.FuncMap {
+	return templateFuncs()
+}
+
+// templateFuncs returns the functions prompt and evidence templates can call
+func templateFuncs() template.FuncMap {
 	return template.FuncMap{
 		// String manipulation functions
 		"truncate": func(max int, s string) string {
@@ -0,0 +1,170 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/frameworks"
+)
+
+// EvidenceData is what evidence templates are rendered with
+type EvidenceData struct {
+	Task     *domain.EvidenceTask
+	Controls []ControlMapping // The task's related controls
+	Window   string
+	Period   string // e.g. "Quarterly period 2025-Q4"
+	Date     string // Day the template is rendered, 2006-01-02
+	Config   *config.Config
+}
+
+// ControlMapping is a control related to the task, with the framework codes
+// it maps to
+type ControlMapping struct {
+	ID         string // Reference ID, else Tugboat ID
+	Name       string
+	Frameworks string // e.g. "SOC 2: CC6.1; NIST 800-53: AC-2, AC-3"
+	Control    *domain.Control
+}
+
+// NewEvidenceData collects what a task's templates are rendered with
+func NewEvidenceData(task *domain.EvidenceTask, window string, cfg *config.Config) EvidenceData {
+	return EvidenceData{
+		Task:     task,
+		Controls: controlMappings(task),
+		Window:   window,
+		Period:   windowPeriod(window),
+		Date:     time.Now().Format("2006-01-02"),
+		Config:   cfg,
+	}
+}
+
+// Render renders the named template with data
+func (t *EvidenceTemplates) Render(name string, data EvidenceData) (string, error) {
+	content, err := t.Get(name)
+	if err != nil {
+		return "", err
+	}
+	return RenderEvidence(name, content, data)
+}
+
+// RenderEvidence renders evidence template content with text/template.
+// Placeholders of the earlier {{TASK_REF}} form keep working: the known ones
+// are filled in, the control mapping row is repeated per related control,
+// and any other is left as written.
+func RenderEvidence(name, content string, data EvidenceData) (string, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs()).Funcs(template.FuncMap{
+		"cell": MarkdownCell,
+	}).Parse(upgradeLegacyPlaceholders(content))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render template %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// legacyControlRow is the Control Mapping table row that earlier templates
+// had replaced with a row per related control
+const legacyControlRow = "| {{CONTROL_ID}} | {{CONTROL_NAME}} | {{FRAMEWORK}} | ✅ Compliant |"
+
+// legacyPlaceholders maps the earlier placeholders to template actions
+var legacyPlaceholders = map[string]string{
+	"TASK_REF":   "{{.Task.ReferenceID}}",
+	"TASK_NAME":  "{{.Task.Name}}",
+	"TUGBOAT_ID": "{{.Task.ID}}",
+	"WINDOW":     "{{.Window}}",
+	"DATE":       "{{.Date}}",
+	"PERIOD":     "{{.Period}}",
+}
+
+var legacyPlaceholderPattern = regexp.MustCompile(`\{\{([A-Z][A-Z0-9_]*)\}\}`)
+
+// upgradeLegacyPlaceholders rewrites {{TASK_REF}}-style placeholders as
+// template actions
+func upgradeLegacyPlaceholders(content string) string {
+	content = strings.ReplaceAll(content, legacyControlRow,
+		"{{if .Controls}}{{range $i, $c := .Controls}}{{if $i}}{{\"\\n\"}}{{end}}"+
+			"| {{cell $c.ID}} | {{cell $c.Name}} | {{cell $c.Frameworks}} | ✅ Compliant |{{end}}"+
+			"{{else}}"+legacyControlRow+"{{end}}")
+	return legacyPlaceholderPattern.ReplaceAllStringFunc(content, func(placeholder string) string {
+		if action, ok := legacyPlaceholders[strings.Trim(placeholder, "{}")]; ok {
+			return action
+		}
+		return fmt.Sprintf("{{%q}}", placeholder)
+	})
+}
+
+// controlMappings lists the task's related controls with the built-in and
+// custom framework codes they map to
+func controlMappings(task *domain.EvidenceTask) []ControlMapping {
+	mappings := make([]ControlMapping, 0, len(task.RelatedControls))
+	for i := range task.RelatedControls {
+		control := &task.RelatedControls[i]
+		id := control.ReferenceID
+		if id == "" {
+			id = control.ID
+		}
+		var refs []string
+		referenced := make(map[string]bool)
+		for _, ref := range control.FrameworkReferences() {
+			refs = append(refs, ref.String())
+			referenced[frameworks.Key(ref.Framework)] = true
+		}
+		for _, custom := range frameworks.CustomFrameworks() {
+			if referenced[custom.Name] {
+				continue
+			}
+			if codes := control.CustomFrameworkCodes(custom.Name); len(codes) > 0 {
+				refs = append(refs, domain.FrameworkReference{Framework: custom.Label, Codes: codes}.String())
+			}
+		}
+		framework := strings.Join(refs, "; ")
+		if framework == "" {
+			framework = control.Framework
+		}
+		mappings = append(mappings, ControlMapping{ID: id, Name: control.Name, Frameworks: framework, Control: control})
+	}
+	return mappings
+}
+
+// windowPeriod describes the period a window covers
+func windowPeriod(window string) string {
+	if strings.Contains(window, "Q") {
+		return fmt.Sprintf("Quarterly period %s", window)
+	}
+	return window
+}
+
+// MarkdownCell keeps a value from breaking out of a markdown table cell,
+// showing "-" for an empty one
+func MarkdownCell(value string) string {
+	value = strings.Join(strings.Fields(strings.ReplaceAll(value, "|", "\\|")), " ")
+	if value == "" {
+		return "-"
+	}
+	return value
+}
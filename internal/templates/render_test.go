@@ -0,0 +1,245 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderEvidence(t *testing.T) {
+	t.Parallel()
+
+	task := &domain.EvidenceTask{
+		ID:          "327992",
+		ReferenceID: "ET-0001",
+		Name:        "Access Control Evidence",
+		RelatedControls: []domain.Control{
+			{ID: "101", ReferenceID: "AC-01", Name: "Access Provisioning", FrameworkCodes: []domain.FrameworkCode{
+				{Framework: "SOC 2", Code: "CC6.1"},
+				{Framework: "NIST 800-53", Code: "AC-2"},
+				{Framework: "NIST 800-53", Code: "AC-3"},
+			}},
+			{ID: "102", Name: "Logging | Monitoring", Framework: "SOC2", Codes: "CC7.2"},
+		},
+	}
+	cfg := &config.Config{Interpolation: config.InterpolationConfig{Variables: map[string]interface{}{"organization": "Seventh Sense"}}}
+	data := NewEvidenceData(task, "2025-Q4", cfg)
+	uncontrolled := NewEvidenceData(&domain.EvidenceTask{ID: "1", ReferenceID: "ET-0002"}, "2025", nil)
+
+	tests := map[string]struct {
+		content string
+		data    EvidenceData
+		want    string
+		wantErr string
+	}{
+		"fields": {
+			content: "# {{.Task.ReferenceID}} - {{.Task.Name}} ({{.Window}}, {{.Period}})",
+			data:    data,
+			want:    "# ET-0001 - Access Control Evidence (2025-Q4, Quarterly period 2025-Q4)",
+		},
+		"loop over controls": {
+			content: "{{range .Controls}}- {{.ID}}: {{cell .Name}} [{{.Frameworks}}]\n{{end}}",
+			data:    data,
+			want:    "- AC-01: Access Provisioning [SOC 2: CC6.1; NIST 800-53: AC-2, AC-3]\n- 102: Logging \\| Monitoring [SOC2: CC7.2]\n",
+		},
+		"conditional": {
+			content: "{{if .Controls}}{{len .Controls}} controls{{else}}no controls{{end}}",
+			data:    uncontrolled,
+			want:    "no controls",
+		},
+		"config": {
+			content: `Prepared for {{index .Config.Interpolation.Variables "organization"}}`,
+			data:    data,
+			want:    "Prepared for Seventh Sense",
+		},
+		"legacy placeholders": {
+			content: "# {{TASK_REF}} - {{TASK_NAME}}\nTugboat ID: {{TUGBOAT_ID}}\nWindow: {{WINDOW}}\nPeriod: {{PERIOD}}",
+			data:    data,
+			want:    "# ET-0001 - Access Control Evidence\nTugboat ID: 327992\nWindow: 2025-Q4\nPeriod: Quarterly period 2025-Q4",
+		},
+		"legacy control row": {
+			content: "| Control | Name | Framework | Status |\n" + legacyControlRow + "\n",
+			data:    data,
+			want: "| Control | Name | Framework | Status |\n" +
+				"| AC-01 | Access Provisioning | SOC 2: CC6.1; NIST 800-53: AC-2, AC-3 | ✅ Compliant |\n" +
+				"| 102 | Logging \\| Monitoring | SOC2: CC7.2 | ✅ Compliant |\n",
+		},
+		"legacy control row without controls": {
+			content: legacyControlRow,
+			data:    uncontrolled,
+			want:    legacyControlRow,
+		},
+		"unknown legacy placeholder": {
+			content: "Next review: {{NEXT_DATE}}",
+			data:    data,
+			want:    "Next review: {{NEXT_DATE}}",
+		},
+		"parse error": {
+			content: "{{if .Controls}}unterminated",
+			data:    data,
+			wantErr: "failed to parse template outline",
+		},
+		"unknown field": {
+			content: "{{.Task.Owner}}",
+			data:    data,
+			wantErr: "failed to render template outline",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := RenderEvidence("outline", tc.content, tc.data)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRenderEvidence_LegacyOutline(t *testing.T) {
+	t.Parallel()
+
+	// The head of the outline grctool wrote before templates used text/template
+	outline := "# Evidence Report: {{TASK_REF}} - {{TASK_NAME}}\n\n" +
+		"**Tugboat ID:** {{TUGBOAT_ID}}\n" +
+		"**Collection Window:** {{WINDOW}}\n" +
+		"**Period Covered:** {{PERIOD}}\n\n" +
+		"## Control Mapping\n\n" +
+		"| Control ID | Control Name | Framework | Compliance Status |\n" +
+		"|------------|--------------|-----------|-------------------|\n" +
+		legacyControlRow + "\n\n" +
+		"### Primary Policy: {{POLICY_REF}} - {{POLICY_NAME}}\n"
+	task := &domain.EvidenceTask{
+		ID:          "327992",
+		ReferenceID: "ET-0001",
+		Name:        "Access Control Evidence",
+		RelatedControls: []domain.Control{
+			{ID: "101", ReferenceID: "AC-01", Name: "Access Provisioning", FrameworkCodes: []domain.FrameworkCode{
+				{Framework: "SOC 2", Code: "CC6.1"},
+				{Framework: "NIST 800-53", Code: "AC-2"},
+				{Framework: "NIST 800-53", Code: "AC-3"},
+			}},
+			{ID: "102", Name: "Logging | Monitoring", Framework: "SOC2", Codes: "CC7.2"},
+		},
+	}
+
+	result, err := RenderEvidence("outline", outline, NewEvidenceData(task, "2025-Q4", nil))
+	require.NoError(t, err)
+	assert.Contains(t, result, "# Evidence Report: ET-0001 - Access Control Evidence")
+	assert.Contains(t, result, "**Tugboat ID:** 327992")
+	assert.Contains(t, result, "**Collection Window:** 2025-Q4")
+	assert.Contains(t, result, "**Period Covered:** Quarterly period 2025-Q4")
+	assert.Contains(t, result, "| AC-01 | Access Provisioning | SOC 2: CC6.1; NIST 800-53: AC-2, AC-3 | ✅ Compliant |\n"+
+		"| 102 | Logging \\| Monitoring | SOC2: CC7.2 | ✅ Compliant |\n\n")
+	assert.NotContains(t, result, "{{CONTROL_ID}}")
+	assert.Contains(t, result, "### Primary Policy: {{POLICY_REF}} - {{POLICY_NAME}}", "unknown placeholders are left as written")
+
+	result, err = RenderEvidence("outline", outline, NewEvidenceData(&domain.EvidenceTask{ID: "1"}, "2025-Q4", nil))
+	require.NoError(t, err)
+	assert.Contains(t, result, legacyControlRow, "placeholder kept without related controls")
+}
+
+func TestControlMappings(t *testing.T) {
+	t.Parallel()
+
+	task := &domain.EvidenceTask{
+		RelatedControls: []domain.Control{
+			{ID: "101", ReferenceID: "AC-01", Name: "Access Provisioning", FrameworkCodes: []domain.FrameworkCode{
+				{Framework: "SOC 2", Code: "CC6.1"},
+				{Framework: "NIST 800-53", Code: "AC-2"},
+				{Framework: "NIST 800-53", Code: "AC-3"},
+			}},
+			{ID: "102", Name: "Logging | Monitoring", Framework: "SOC2", Codes: "CC7.2"},
+			{ID: "103", Name: "Vendor Review", Framework: "Internal"},
+		},
+	}
+
+	mappings := controlMappings(task)
+	require.Len(t, mappings, 3)
+
+	tests := map[string]struct {
+		index      int
+		id         string
+		name       string
+		frameworks string
+	}{
+		"codes grouped by framework":     {index: 0, id: "AC-01", name: "Access Provisioning", frameworks: "SOC 2: CC6.1; NIST 800-53: AC-2, AC-3"},
+		"tugboat id without a reference": {index: 1, id: "102", name: "Logging | Monitoring", frameworks: "SOC2: CC7.2"},
+		"framework without codes":        {index: 2, id: "103", name: "Vendor Review", frameworks: "Internal"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			mapping := mappings[tc.index]
+			assert.Equal(t, tc.id, mapping.ID)
+			assert.Equal(t, tc.name, mapping.Name)
+			assert.Equal(t, tc.frameworks, mapping.Frameworks)
+			assert.Same(t, &task.RelatedControls[tc.index], mapping.Control)
+		})
+	}
+	assert.Empty(t, controlMappings(&domain.EvidenceTask{}))
+}
+
+func TestEvidenceTemplates_Render(t *testing.T) {
+	t.Parallel()
+
+	task := &domain.EvidenceTask{ReferenceID: "ET-0001", Name: "Access Control Evidence"}
+	data := NewEvidenceData(task, "2025-Q4", nil)
+
+	// Every built-in template renders
+	builtins := NewEvidenceTemplates("")
+	for _, name := range EvidenceTemplateNames {
+		content, err := builtins.Render(name, data)
+		require.NoError(t, err, name)
+		assert.NotContains(t, content, "{{", name)
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "personnel.md"), []byte("# People Evidence for {{TASK_REF}}{{if .Controls}} and controls{{end}}\n"), 0644))
+	content, err := NewEvidenceTemplates(dir).Render(PersonnelTemplate, data)
+	require.NoError(t, err)
+	assert.Equal(t, "# People Evidence for ET-0001\n", content)
+}
+
+func TestWindowPeriod(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"2025-Q4": "Quarterly period 2025-Q4",
+		"2025-Q1": "Quarterly period 2025-Q1",
+		"2025":    "2025",
+		"2025-03": "2025-03",
+	}
+
+	for window, want := range tests {
+		t.Run(window, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, want, windowPeriod(window))
+		})
+	}
+}
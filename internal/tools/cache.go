@@ -0,0 +1,210 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/interfaces"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/storage"
+)
+
+// CacheableTool is an optional interface for tools whose results can be
+// reused while the data they read is unchanged. Tools that write files or
+// otherwise have side effects must not implement it.
+type CacheableTool interface {
+	Tool
+	// SourceFingerprint identifies the state of the data a run with params
+	// reads, such as a repository HEAD or index hash. A cached result is
+	// reused only while the fingerprint is unchanged; an error skips the
+	// cache for the run.
+	SourceFingerprint(ctx context.Context, params map[string]interface{}) (string, error)
+}
+
+// cacheFingerprintParam carries the source fingerprint in the cache key
+// alongside the tool parameters
+const cacheFingerprintParam = "_source_fingerprint"
+
+// cachedToolResult is a tool result as stored in the cache
+type cachedToolResult struct {
+	Result string                 `json:"result"`
+	Source *models.EvidenceSource `json:"source,omitempty"`
+}
+
+// ToolResultCache reuses the results of cacheable tools across runs, keyed by
+// tool name, parameters and source fingerprint
+type ToolResultCache struct {
+	cache  interfaces.CacheService
+	config config.ToolCacheConfig
+	logger logger.Logger
+}
+
+// NewToolResultCache creates a result cache over a cache service
+func NewToolResultCache(cache interfaces.CacheService, cfg config.ToolCacheConfig, log logger.Logger) *ToolResultCache {
+	return &ToolResultCache{cache: cache, config: cfg, logger: log}
+}
+
+// EnableResultCache caches the results of cacheable tools in the global
+// registry under the configured tool cache directory
+func EnableResultCache(cfg *config.Config, log logger.Logger) error {
+	dir := cfg.Storage.Paths.WithDefaults().ResolveRelativeTo(cfg.Storage.DataDir).ToolCache
+	cache, err := storage.NewCacheManager(dir)
+	if err != nil {
+		return fmt.Errorf("failed to open tool result cache: %w", err)
+	}
+	GlobalRegistry.SetCache(NewToolResultCache(cache, cfg.Evidence.Tools.Cache, log))
+	return nil
+}
+
+// TTL returns how long a tool's results are reused
+func (c *ToolResultCache) TTL(toolName string) time.Duration {
+	if ttl, ok := c.config.Tools[toolName]; ok {
+		return ttl
+	}
+	return c.config.TTL
+}
+
+// Get returns a cached result of a tool run
+func (c *ToolResultCache) Get(toolName string, params map[string]interface{}, fingerprint string) (string, *models.EvidenceSource, bool) {
+	var cached cachedToolResult
+	if err := c.cache.GetToolResult(toolName, cacheKeyParams(params, fingerprint), &cached); err != nil {
+		return "", nil, false
+	}
+	return cached.Result, cached.Source, true
+}
+
+// Put stores the result of a tool run, unless the tool's TTL is zero
+func (c *ToolResultCache) Put(toolName string, params map[string]interface{}, fingerprint, result string, source *models.EvidenceSource) {
+	ttl := c.TTL(toolName)
+	if ttl <= 0 {
+		return
+	}
+	cached := cachedToolResult{Result: result, Source: source}
+	if err := c.cache.SetToolResult(toolName, cacheKeyParams(params, fingerprint), cached, ttl); err != nil {
+		c.logger.Warn("failed to cache tool result", logger.String("tool", toolName), logger.Error(err))
+	}
+}
+
+// cacheKeyParams returns the parameters that identify a run: the tool
+// parameters and source fingerprint. The cache service hashes them as JSON,
+// which orders map keys, and ints and whole floats encode alike.
+func cacheKeyParams(params map[string]interface{}, fingerprint string) map[string]interface{} {
+	key := make(map[string]interface{}, len(params)+1)
+	for name, value := range params {
+		if name == "use_cache" || name == "skip_cache" {
+			continue // Tools' own caches don't change the result
+		}
+		key[name] = value
+	}
+	key[cacheFingerprintParam] = fingerprint
+	return key
+}
+
+// cacheDeclined reports whether the parameters ask a tool to bypass its own
+// cache, in which case the result cache is refreshed rather than read
+func cacheDeclined(params map[string]interface{}) bool {
+	if use, ok := params["use_cache"].(bool); ok && !use {
+		return true
+	}
+	skip, _ := params["skip_cache"].(bool)
+	return skip
+}
+
+// PathsFingerprint fingerprints the files under local source paths: the git
+// HEAD of each path in a repository, and the name, size and modification time
+// of every file with one of the extensions outside hidden directories, so
+// uncommitted edits count too. Without extensions every file counts. Glob
+// patterns are fingerprinted from their directory prefix.
+func PathsFingerprint(ctx context.Context, paths []string, extensions ...string) (string, error) {
+	hash := sha256.New()
+	for _, path := range paths {
+		root := globRoot(path)
+		fmt.Fprintf(hash, "path %s\n", root)
+
+		if head, err := exec.CommandContext(ctx, "git", "-C", root, "rev-parse", "HEAD").Output(); err == nil {
+			fmt.Fprintf(hash, "head %s", head)
+		}
+
+		err := filepath.Walk(root, func(file string, info os.FileInfo, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil // A missing path has no files
+				}
+				return err
+			}
+			if info.IsDir() {
+				if file != root && strings.HasPrefix(info.Name(), ".") {
+					return filepath.SkipDir // .git, .terraform and caches
+				}
+				return nil
+			}
+			if len(extensions) > 0 && !slices.Contains(extensions, filepath.Ext(file)) {
+				return nil
+			}
+			fmt.Fprintf(hash, "%s %d %d\n", file, info.Size(), info.ModTime().UnixNano())
+			return nil
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to fingerprint %s: %w", root, err)
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// FileFingerprint returns the SHA-256 of a file's content, or an empty
+// fingerprint when it does not exist
+func FileFingerprint(path string) (string, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", fmt.Errorf("failed to fingerprint %s: %w", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// globRoot returns the directory a glob pattern is rooted at, or the path
+// itself when it has no pattern
+func globRoot(path string) string {
+	if !strings.ContainsAny(path, "*?[") {
+		return path
+	}
+	root := path
+	for strings.ContainsAny(root, "*?[") {
+		root = filepath.Dir(root)
+	}
+	return root
+}
@@ -240,7 +240,7 @@ func (e *EvidenceGeneratorTool) generateFromPromptFile(ctx context.Context, prom
 		return nil, fmt.Errorf("unsafe path: %s", promptFile)
 	}
 
-	promptContent, err := os.ReadFile(promptFile)
+	promptContent, err := storage.ReadFile(promptFile)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read prompt file: %w", err)
 	}
@@ -23,7 +23,9 @@ import (
 	"github.com/grctool/grctool/internal/config"
 	"github.com/grctool/grctool/internal/logger"
 	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/redaction"
 	"github.com/grctool/grctool/internal/services/validation"
+	"github.com/grctool/grctool/internal/signing"
 	"github.com/grctool/grctool/internal/storage"
 )
 
@@ -44,6 +46,8 @@ func NewEvidenceSubmissionValidatorTool(cfg *config.Config, log logger.Logger) (
 	}
 
 	validator := validation.NewEvidenceValidationService(storage)
+	validator.SetSigner(signing.New(cfg.Evidence.Signing))
+	validator.SetScanner(redaction.New(cfg.Submission.Scan))
 
 	return &EvidenceSubmissionValidatorTool{
 		config:    cfg,
@@ -21,16 +21,22 @@ import (
 	"fmt"
 
 	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/drata"
+	"github.com/grctool/grctool/internal/hyperproof"
 	"github.com/grctool/grctool/internal/logger"
 	"github.com/grctool/grctool/internal/models"
 	"github.com/grctool/grctool/internal/providers"
+	"github.com/grctool/grctool/internal/redaction"
+	"github.com/grctool/grctool/internal/secureframe"
 	"github.com/grctool/grctool/internal/services/submission"
 	"github.com/grctool/grctool/internal/services/validation"
+	"github.com/grctool/grctool/internal/signing"
 	"github.com/grctool/grctool/internal/storage"
 	"github.com/grctool/grctool/internal/tugboat"
+	"github.com/grctool/grctool/internal/vanta"
 )
 
-// EvidenceSubmitterTool submits evidence to Tugboat Logic
+// EvidenceSubmitterTool submits evidence to Tugboat Logic or another GRC platform
 type EvidenceSubmitterTool struct {
 	config            *config.Config
 	logger            logger.Logger
@@ -66,6 +72,26 @@ func NewEvidenceSubmitterTool(cfg *config.Config, log logger.Logger) (*EvidenceS
 			cfg.Tugboat.CollectorURLs,
 		)
 	}
+	// Tasks without a Tugboat collector go to their Vanta document, Drata
+	// control, Secureframe test, Hyperproof label or delivery URL, if any
+	if len(cfg.Vanta.DocumentIDs) > 0 {
+		submissionService.AddTarget(submission.NewVantaTarget(vanta.NewClient(cfg.Vanta, nil), cfg.Vanta.DocumentIDs))
+	}
+	if len(cfg.Drata.ControlIDs) > 0 {
+		submissionService.AddTarget(submission.NewDrataTarget(drata.NewClient(cfg.Drata, nil), cfg.Drata.ControlIDs))
+	}
+	if len(cfg.Secureframe.TestIDs) > 0 {
+		submissionService.AddTarget(submission.NewSecureframeTarget(secureframe.NewClient(cfg.Secureframe, nil), cfg.Secureframe.TestIDs))
+	}
+	if proofTargets := hyperproof.ProofTargets(cfg.Hyperproof); len(proofTargets) > 0 {
+		submissionService.AddTarget(submission.NewHyperproofTarget(hyperproof.NewClient(cfg.Hyperproof, nil), proofTargets))
+	}
+	if len(cfg.Delivery.URLs) > 0 {
+		submissionService.AddTarget(submission.NewDeliveryTarget(cfg.Delivery))
+	}
+	submissionService.SetRequireApproval(cfg.Submission.RequireApproval)
+	submissionService.SetSigner(signing.New(cfg.Evidence.Signing))
+	submissionService.SetScanner(redaction.New(cfg.Submission.Scan))
 
 	return &EvidenceSubmitterTool{
 		config:            cfg,
@@ -79,7 +105,7 @@ func NewEvidenceSubmitterTool(cfg *config.Config, log logger.Logger) (*EvidenceS
 func (t *EvidenceSubmitterTool) GetClaudeToolDefinition() models.ClaudeTool {
 	return models.ClaudeTool{
 		Name:        "evidence-submitter",
-		Description: "Submit evidence to Tugboat Logic for compliance review. Validates and uploads evidence files.",
+		Description: "Submit evidence to Tugboat Logic, Vanta, Drata, Secureframe or Hyperproof for compliance review, or deliver it to an auditor's SFTP server, S3 bucket or webhook. Validates and uploads evidence files.",
 		InputSchema: map[string]interface{}{
 			"type": "object",
 			"properties": map[string]interface{}{
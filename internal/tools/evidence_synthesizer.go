@@ -0,0 +1,365 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/llm"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/naming"
+	"github.com/grctool/grctool/internal/services/provenance"
+	"github.com/grctool/grctool/internal/signing"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/grctool/grctool/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"gopkg.in/yaml.v3"
+)
+
+// synthesisMaxTokens bounds the length of a drafted evidence document
+const synthesisMaxTokens = 4096
+
+// EvidenceSynthesizer drafts a task's evidence document with a language
+// model, from the assembly prompt and the tool outputs collected for the
+// window. Drafts are recorded as machine-drafted and pending review, so they
+// cannot be submitted until a reviewer approves them.
+type EvidenceSynthesizer struct {
+	config          *config.Config
+	logger          logger.Logger
+	model           llm.Model
+	llmConfig       config.LLMConfig // Context limit and prices of the model
+	maxContextChars int
+	signer          signing.Signer // Signs the draft and metadata; nil when signing is disabled
+}
+
+// NewEvidenceSynthesizer creates a synthesizer drafting with model, sending
+// it at most llmConfig.MaxContextChars of assembly prompt and of tool outputs
+// and costing drafts at llmConfig's prices
+func NewEvidenceSynthesizer(cfg *config.Config, log logger.Logger, model llm.Model, llmConfig config.LLMConfig) *EvidenceSynthesizer {
+	return &EvidenceSynthesizer{
+		config:          cfg,
+		logger:          log,
+		model:           model,
+		llmConfig:       llmConfig,
+		maxContextChars: llmConfig.MaxContextChars,
+		signer:          signing.New(cfg.Evidence.Signing),
+	}
+}
+
+// SynthesisResult describes a drafted evidence document
+type SynthesisResult struct {
+	Path      string            `json:"path"`
+	DraftedBy string            `json:"drafted_by"`
+	ToolsUsed []string          `json:"tools_used,omitempty"`
+	Usage     models.ModelUsage `json:"usage"`
+}
+
+// SynthesisFilename is the evidence document drafted for a task
+func SynthesisFilename(task *domain.EvidenceTask) string {
+	return task.ReferenceID + "_Evidence.md"
+}
+
+// Synthesize drafts the evidence document of a task's window, reading the
+// tool outputs saved in toolOutputDir. An earlier draft still pending review
+// and unchanged since is replaced; any other existing document is left alone.
+func (s *EvidenceSynthesizer) Synthesize(ctx context.Context, task *domain.EvidenceTask, window, assemblyPrompt, toolOutputDir string) (result *SynthesisResult, err error) {
+	ctx, span := telemetry.Start(ctx, "synthesize evidence",
+		attribute.String("task.ref", task.ReferenceID),
+		attribute.String("evidence.window", window),
+		attribute.String("llm.model", s.model.Name()))
+	defer func() {
+		if result != nil {
+			span.SetAttributes(
+				attribute.Int("llm.input_tokens", result.Usage.InputTokens),
+				attribute.Int("llm.output_tokens", result.Usage.OutputTokens))
+		}
+		telemetry.End(span, err)
+	}()
+
+	start := time.Now()
+	if err := s.model.Available(ctx); err != nil {
+		return nil, fmt.Errorf("cannot synthesize evidence: %w", err)
+	}
+
+	lock, err := storage.LockWindow(s.config.Storage.DataDir, task.ReferenceID, window)
+	if err != nil {
+		return nil, fmt.Errorf("locking evidence window: %w", err)
+	}
+	defer lock.Unlock()
+
+	windowDir := s.windowDir(task, window)
+	filename := SynthesisFilename(task)
+	if err := replaceableDraft(windowDir, filename); err != nil {
+		return nil, err
+	}
+
+	outputs, toolsUsed, err := s.toolOutputs(toolOutputDir)
+	if err != nil {
+		return nil, err
+	}
+	var tokens llm.Usage
+	draft, err := s.model.Generate(ctx, llm.Request{
+		Prompt:    s.synthesisPrompt(task, assemblyPrompt, outputs),
+		MaxTokens: synthesisMaxTokens,
+		Usage:     &tokens,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s failed to draft evidence: %w", s.model.Name(), err)
+	}
+	usage := models.ModelUsage{
+		InputTokens:  tokens.InputTokens,
+		OutputTokens: tokens.OutputTokens,
+		CostUSD:      s.llmConfig.Cost(tokens.InputTokens, tokens.OutputTokens),
+	}
+	draft = stripMarkdownFence(draft)
+	if draft == "" {
+		return nil, fmt.Errorf("%s drafted an empty evidence document", s.model.Name())
+	}
+
+	draftedAt := time.Now()
+	content := fmt.Sprintf("> **Machine-drafted evidence, pending human review.** Drafted by %s on %s from the assembly prompt and tool outputs of this window. "+
+		"Check every statement against its source, then approve it with `grctool evidence approve %s --window %s`.\n\n%s\n",
+		s.model.Name(), draftedAt.Format("2006-01-02"), task.ReferenceID, window, draft)
+	if err := os.MkdirAll(windowDir, 0755); err != nil {
+		return nil, fmt.Errorf("creating evidence directory '%s': %w: %w", windowDir, ErrDirectoryCreation, err)
+	}
+	path := filepath.Join(windowDir, filename)
+	if err := storage.WriteEvidenceFile(path, []byte(content), storage.EncryptsEvidence(s.config.Storage.Encryption, task)); err != nil {
+		return nil, fmt.Errorf("writing evidence file '%s': %w: %w", path, ErrFileWrite, err)
+	}
+	if err := s.record(ctx, task, window, windowDir, filename, toolsUsed, usage, start, draftedAt); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Evidence drafted by language model",
+		logger.String("task_ref", task.ReferenceID),
+		logger.String("window", window),
+		logger.String("model", s.model.Name()),
+		logger.Field{Key: "tools_used", Value: toolsUsed},
+		logger.Int("tokens", usage.Tokens()))
+	return &SynthesisResult{Path: path, DraftedBy: s.model.Name(), ToolsUsed: toolsUsed, Usage: usage}, nil
+}
+
+// Drafted reports whether the task's window already has the evidence
+// document Synthesize writes, whether drafted by a model or by hand
+func (s *EvidenceSynthesizer) Drafted(task *domain.EvidenceTask, window string) bool {
+	_, err := os.Stat(filepath.Join(s.windowDir(task, window), SynthesisFilename(task)))
+	return err == nil
+}
+
+// windowDir is the directory of a task's evidence window
+func (s *EvidenceSynthesizer) windowDir(task *domain.EvidenceTask, window string) string {
+	return filepath.Join(s.config.Storage.DataDir, "evidence", naming.GetEvidenceTaskDirName(task.Name, task.ReferenceID, task.ID), window)
+}
+
+// replaceableDraft returns an error unless the window has no document named
+// filename, or only a machine draft pending review that was not edited since
+func replaceableDraft(windowDir, filename string) error {
+	path := filepath.Join(windowDir, filename)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+
+	refusal := fmt.Errorf("%s already exists and is not an unedited machine draft; move it aside to draft it again", path)
+	data, err := os.ReadFile(filepath.Join(windowDir, signing.MetadataPath))
+	if err != nil {
+		return refusal
+	}
+	var metadata models.GenerationMetadata
+	if err := yaml.Unmarshal(data, &metadata); err != nil || !metadata.MachineDrafted() || metadata.ReviewStatus != models.ReviewPending {
+		return refusal
+	}
+	checksum, err := calculateFileChecksum(path)
+	if err != nil {
+		return refusal
+	}
+	for _, file := range metadata.FilesGenerated {
+		if file.Path == filename && file.Checksum == checksum {
+			return nil
+		}
+	}
+	return refusal
+}
+
+// toolOutputs reads the tool outputs of the window, keyed by file name, and
+// the tools that produced them
+func (s *EvidenceSynthesizer) toolOutputs(dir string) (map[string]string, []string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read tool outputs: %w", err)
+	}
+
+	outputs := make(map[string]string)
+	var toolsUsed []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		data, err := storage.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			s.logger.Warn("Failed to read tool output", logger.String("file", entry.Name()), logger.Field{Key: "error", Value: err})
+			continue
+		}
+		outputs[entry.Name()] = string(data)
+		toolsUsed = append(toolsUsed, strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())))
+	}
+	sort.Strings(toolsUsed)
+	return outputs, toolsUsed, nil
+}
+
+// synthesisPrompt asks the model for the evidence document, giving it the
+// assembly prompt and the tool outputs, which share maxContextChars
+func (s *EvidenceSynthesizer) synthesisPrompt(task *domain.EvidenceTask, assemblyPrompt string, outputs map[string]string) string {
+	var b strings.Builder
+	b.WriteString(truncateText(assemblyPrompt, s.maxContextChars))
+
+	b.WriteString("\n\n## Tool Outputs\n\n")
+	if len(outputs) == 0 {
+		b.WriteString("No tool outputs were collected for this window.\n")
+	}
+	names := make([]string, 0, len(outputs))
+	for name := range outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	budget := s.maxContextChars
+	if len(names) > 0 && budget > 0 {
+		budget /= len(names)
+	}
+	for _, name := range names {
+		fmt.Fprintf(&b, "### %s\n\n```\n%s\n```\n\n", name, truncateText(outputs[name], budget))
+	}
+
+	fmt.Fprintf(&b, "## Your Task\n\n"+
+		"Write the evidence document for %s (%s) in markdown, as the evidence template above describes. "+
+		"State only facts found in the context and tool outputs above, naming the output each comes from. "+
+		"Where they do not show something the task asks for, list it under a \"Gaps\" heading instead of inventing it. "+
+		"Answer with the document only.\n", task.ReferenceID, task.Name)
+	return b.String()
+}
+
+// record writes the generation metadata marking the draft pending review,
+// signs the draft and metadata, attests how the draft was produced and
+// starts its chain of custody
+func (s *EvidenceSynthesizer) record(ctx context.Context, task *domain.EvidenceTask, window, windowDir, filename string, toolsUsed []string, usage models.ModelUsage, start, draftedAt time.Time) error {
+	checksum, err := calculateFileChecksum(filepath.Join(windowDir, filename))
+	if err != nil {
+		return fmt.Errorf("checksumming evidence file '%s': %w", filename, err)
+	}
+	info, err := os.Stat(filepath.Join(windowDir, filename))
+	if err != nil {
+		return err
+	}
+
+	sources := make([]models.SourceTimestamp, 0, len(toolsUsed))
+	for _, tool := range toolsUsed {
+		sources = append(sources, models.SourceTimestamp{Type: models.SourceTypeTool, Location: tool, ModifiedAt: draftedAt})
+	}
+	file := models.FileMetadata{
+		Path:        filename,
+		Checksum:    checksum,
+		SizeBytes:   info.Size(),
+		GeneratedAt: draftedAt,
+		Sources:     sources,
+	}
+	if err := writeGenerationMetadata(s.logger, windowDir, task, window, []models.FileMetadata{file}, models.GenerationMetadata{
+		GeneratedBy:      "grctool-synthesis",
+		GenerationMethod: "llm_synthesis",
+		ToolsUsed:        toolsUsed,
+		DraftedBy:        s.model.Name(),
+		ReviewStatus:     models.ReviewPending,
+		DraftUsage:       &usage,
+	}); err != nil {
+		// Without metadata the draft would not be held for review
+		return fmt.Errorf("recording machine draft: %w", err)
+	}
+
+	if s.signer != nil {
+		for _, signed := range []string{filename, signing.MetadataPath} {
+			if err := signing.SignFile(ctx, s.signer, windowDir, signed, storage.ReadFile); err != nil {
+				return fmt.Errorf("signing evidence file '%s': %w", signed, err)
+			}
+		}
+	}
+
+	definition := provenance.BuildDefinition{
+		BuildType: provenance.SynthesisBuildType,
+		ExternalParameters: map[string]interface{}{
+			"task_ref":   task.ReferenceID,
+			"window":     window,
+			"model":      s.model.Name(),
+			"tools_used": toolsUsed,
+		},
+		ResolvedDependencies: provenance.NewService("").SourceDependencies(sources, nil),
+	}
+	statement := provenance.NewStatement(filename, strings.TrimPrefix(checksum, "sha256:"), definition, start, time.Now())
+	envelope, err := provenance.Seal(ctx, statement, s.signer)
+	if err != nil {
+		return fmt.Errorf("attesting evidence file '%s': %w", filename, err)
+	}
+	if err := provenance.WriteAttestation(windowDir, filename, envelope); err != nil {
+		return fmt.Errorf("writing attestation of evidence file '%s': %w", filename, err)
+	}
+
+	drafted := models.CustodyEvent{
+		Timestamp: draftedAt,
+		File:      filename,
+		Event:     models.CustodyGenerated,
+		Actor:     storage.CustodyActor(),
+		SHA256:    checksum,
+		Details:   "Drafted by " + s.model.Name() + ", pending review",
+	}
+	if err := storage.AppendCustodyEvents(windowDir, drafted); err != nil {
+		s.logger.Warn("Failed to record evidence in custody log",
+			logger.Field{Key: "error", Value: err},
+			logger.Field{Key: "file", Value: filename})
+	}
+	return nil
+}
+
+// truncateText shortens text to at most limit bytes, keeping it valid UTF-8;
+// a limit of zero or less keeps it whole
+func truncateText(text string, limit int) string {
+	if limit <= 0 || len(text) <= limit {
+		return text
+	}
+	return strings.ToValidUTF8(text[:limit], "") + "\n[truncated]"
+}
+
+// stripMarkdownFence removes a code fence the model wrapped its whole answer
+// in
+func stripMarkdownFence(text string) string {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "```") || !strings.HasSuffix(text, "```") {
+		return text
+	}
+	if newline := strings.Index(text, "\n"); newline >= 0 {
+		text = text[newline+1 : len(text)-3]
+	}
+	return strings.TrimSpace(text)
+}
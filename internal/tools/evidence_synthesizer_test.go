@@ -0,0 +1,189 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/llm"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/naming"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// draftingModel answers every prompt with a fixed draft and usage,
+// recording the last prompt it was given
+type draftingModel struct {
+	draft       string
+	usage       llm.Usage
+	unavailable error
+	prompt      string
+}
+
+func (m *draftingModel) Name() string { return "openai/gpt-4o" }
+
+func (m *draftingModel) Available(context.Context) error { return m.unavailable }
+
+func (m *draftingModel) Generate(_ context.Context, req llm.Request) (string, error) {
+	m.prompt = req.Prompt
+	if req.Usage != nil {
+		*req.Usage = m.usage
+	}
+	return m.draft, nil
+}
+
+func TestEvidenceSynthesizer_Synthesize(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+	cfg := &config.Config{Storage: config.StorageConfig{DataDir: dataDir}}
+	log, err := logger.NewTestLogger()
+	require.NoError(t, err)
+	task := &domain.EvidenceTask{ID: "327992", ReferenceID: "ET-0047", Name: "Access Review"}
+	windowDir := filepath.Join(dataDir, "evidence", naming.GetEvidenceTaskDirName(task.Name, task.ReferenceID, task.ID), "2025-Q4")
+	toolDir := filepath.Join(windowDir, ".context", "tool_outputs")
+	require.NoError(t, os.MkdirAll(toolDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(toolDir, "github-permissions.json"), []byte(`{"admins":["sam"]}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(toolDir, "docs-reader.json"), []byte(`{"policy":"Quarterly access review"}`), 0644))
+
+	model := &draftingModel{
+		draft: "```markdown\n# Access Review Evidence\n\nsam is the only admin (github-permissions).\n```",
+		usage: llm.Usage{InputTokens: 2000, OutputTokens: 500},
+	}
+	synthesizer := NewEvidenceSynthesizer(cfg, log, model, config.LLMConfig{MaxContextChars: 12000, InputCostPerMillion: 2.5, OutputCostPerMillion: 10})
+	assert.False(t, synthesizer.Drafted(task, "2025-Q4"))
+	result, err := synthesizer.Synthesize(context.Background(), task, "2025-Q4", "# Evidence Collection Task: Access Review", toolDir)
+	require.NoError(t, err)
+
+	assert.Equal(t, filepath.Join(windowDir, "ET-0047_Evidence.md"), result.Path)
+	assert.Equal(t, "openai/gpt-4o", result.DraftedBy)
+	assert.Equal(t, []string{"docs-reader", "github-permissions"}, result.ToolsUsed)
+	assert.Equal(t, 2500, result.Usage.Tokens())
+	assert.InDelta(t, 0.01, result.Usage.CostUSD, 1e-9)
+	assert.True(t, synthesizer.Drafted(task, "2025-Q4"))
+	assert.Contains(t, model.prompt, "# Evidence Collection Task: Access Review")
+	assert.Contains(t, model.prompt, "### github-permissions.json")
+	assert.Contains(t, model.prompt, `{"admins":["sam"]}`)
+
+	content, err := os.ReadFile(result.Path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "**Machine-drafted evidence, pending human review.** Drafted by openai/gpt-4o")
+	assert.Contains(t, string(content), "`grctool evidence approve ET-0047 --window 2025-Q4`")
+	assert.Contains(t, string(content), "\n# Access Review Evidence\n\nsam is the only admin (github-permissions).\n")
+	assert.NotContains(t, string(content), "```")
+
+	data, err := os.ReadFile(filepath.Join(windowDir, ".generation", "metadata.yaml"))
+	require.NoError(t, err)
+	var metadata models.GenerationMetadata
+	require.NoError(t, yaml.Unmarshal(data, &metadata))
+	assert.True(t, metadata.MachineDrafted())
+	assert.Equal(t, "openai/gpt-4o", metadata.DraftedBy)
+	assert.Equal(t, models.ReviewPending, metadata.ReviewStatus)
+	assert.Equal(t, "llm_synthesis", metadata.GenerationMethod)
+	assert.Equal(t, &result.Usage, metadata.DraftUsage)
+	require.Len(t, metadata.FilesGenerated, 1)
+	assert.Equal(t, "ET-0047_Evidence.md", metadata.FilesGenerated[0].Path)
+	assert.FileExists(t, filepath.Join(windowDir, ".generation", "attestations", "ET-0047_Evidence.md.intoto.jsonl"))
+
+	// An unedited draft pending review is drafted again
+	model.draft = "# Access Review Evidence\n\nRedrafted."
+	_, err = synthesizer.Synthesize(context.Background(), task, "2025-Q4", "# Evidence Collection Task: Access Review", toolDir)
+	require.NoError(t, err)
+
+	// An edited draft is left alone
+	require.NoError(t, os.WriteFile(result.Path, []byte("# Access Review Evidence\n\nReviewed by hand.\n"), 0644))
+	_, err = synthesizer.Synthesize(context.Background(), task, "2025-Q4", "# Evidence Collection Task: Access Review", toolDir)
+	assert.ErrorContains(t, err, "is not an unedited machine draft")
+	content, err = os.ReadFile(result.Path)
+	require.NoError(t, err)
+	assert.Equal(t, "# Access Review Evidence\n\nReviewed by hand.\n", string(content))
+}
+
+// useTestEvidenceKey makes a fresh key the one evidence is encrypted and
+// read with
+func useTestEvidenceKey(t *testing.T) *storage.Cipher {
+	t.Helper()
+	key, err := storage.GenerateKey()
+	require.NoError(t, err)
+	cipher, err := storage.LoadCipher(context.Background(), config.StorageConfig{Encryption: config.EncryptionConfig{Key: key}})
+	require.NoError(t, err)
+	storage.UseEncryption(func() (*storage.Cipher, error) { return cipher, nil })
+	t.Cleanup(func() { storage.UseEncryption(nil) })
+	return cipher
+}
+
+func TestEvidenceSynthesizer_Encryption(t *testing.T) {
+	useTestEvidenceKey(t)
+
+	dataDir := t.TempDir()
+	cfg := &config.Config{Storage: config.StorageConfig{DataDir: dataDir, Encryption: config.EncryptionConfig{Enabled: true}}}
+	log, err := logger.NewTestLogger()
+	require.NoError(t, err)
+	task := &domain.EvidenceTask{ID: "327992", ReferenceID: "ET-0047", Name: "Access Review", Sensitive: true}
+	toolDir := filepath.Join(dataDir, "evidence", naming.GetEvidenceTaskDirName(task.Name, task.ReferenceID, task.ID), "2025-Q4", ".context", "tool_outputs")
+	require.NoError(t, os.MkdirAll(toolDir, 0755))
+	require.NoError(t, storage.WriteEvidenceFile(filepath.Join(toolDir, "github-permissions.json"), []byte(`{"admins":["sam"]}`), true))
+	model := &draftingModel{draft: "# Access Review Evidence\n\nsam is the only admin."}
+	synthesizer := NewEvidenceSynthesizer(cfg, log, model, config.LLMConfig{MaxContextChars: 12000})
+
+	result, err := synthesizer.Synthesize(context.Background(), task, "2025-Q4", "# Prompt", toolDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"github-permissions"}, result.ToolsUsed)
+	assert.Contains(t, model.prompt, `{"admins":["sam"]}`, "encrypted tool outputs are read as plaintext")
+	raw, err := os.ReadFile(result.Path)
+	require.NoError(t, err)
+	assert.True(t, storage.IsEncrypted(raw), "drafted evidence of a sensitive task is written encrypted")
+	content, err := storage.ReadFile(result.Path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "sam is the only admin.")
+
+	// The encrypted draft is still recognized as unedited
+	_, err = synthesizer.Synthesize(context.Background(), task, "2025-Q4", "# Prompt", toolDir)
+	require.NoError(t, err)
+}
+
+func TestEvidenceSynthesizer_ModelUnavailable(t *testing.T) {
+	t.Parallel()
+
+	log, err := logger.NewTestLogger()
+	require.NoError(t, err)
+	cfg := &config.Config{Storage: config.StorageConfig{DataDir: t.TempDir()}}
+	model := &draftingModel{unavailable: errors.New("anthropic API needs a key")}
+	task := &domain.EvidenceTask{ID: "327992", ReferenceID: "ET-0047", Name: "Access Review"}
+
+	_, err = NewEvidenceSynthesizer(cfg, log, model, config.LLMConfig{MaxContextChars: 12000}).Synthesize(context.Background(), task, "2025-Q4", "# Prompt", "")
+	assert.EqualError(t, err, "cannot synthesize evidence: anthropic API needs a key")
+	assert.Empty(t, model.prompt)
+}
+
+func TestStripMarkdownFence(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		text string
+		want string
+	}{
+		"plain":          {text: "# Evidence\n", want: "# Evidence"},
+		"fenced":         {text: "```markdown\n# Evidence\n```", want: "# Evidence"},
+		"inner fence":    {text: "# Evidence\n\n```\nls -l\n```\n\nDone.", want: "# Evidence\n\n```\nls -l\n```\n\nDone."},
+		"fenced no lang": {text: "```\n# Evidence\n```\n", want: "# Evidence"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, stripMarkdownFence(tc.text))
+		})
+	}
+}
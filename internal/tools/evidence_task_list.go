@@ -154,6 +154,24 @@ func (e *EvidenceTaskListTool) GetClaudeToolDefinition() models.ClaudeTool {
 	}
 }
 
+// OutputSchema returns the JSON Schema of the task list document
+func (e *EvidenceTaskListTool) OutputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":     "object",
+		"required": []string{"total_tasks", "filtered_tasks", "filter_applied", "tasks", "generated_at"},
+		"properties": map[string]interface{}{
+			"total_tasks":    map[string]interface{}{"type": "integer", "minimum": 0},
+			"filtered_tasks": map[string]interface{}{"type": "integer", "minimum": 0},
+			"filter_applied": map[string]interface{}{"type": "object"},
+			"tasks": map[string]interface{}{
+				"type":  []string{"array", "null"},
+				"items": map[string]interface{}{"type": "object"},
+			},
+			"generated_at": map[string]interface{}{"type": "string", "format": "date-time"},
+		},
+	}
+}
+
 // Execute runs the evidence task list tool with the given parameters
 func (e *EvidenceTaskListTool) Execute(ctx context.Context, params map[string]interface{}) (string, *models.EvidenceSource, error) {
 	e.logger.Debug("Executing evidence task list tool", logger.Field{Key: "params", Value: params})
@@ -171,11 +189,8 @@ func (e *EvidenceTaskListTool) Execute(ctx context.Context, params map[string]in
 	filteredTasks := e.applyFilters(allTasks, filter, params)
 
 	// Apply limit if specified
-	if limit, ok := params["limit"].(float64); ok && limit > 0 {
-		limitInt := int(limit)
-		if len(filteredTasks) > limitInt {
-			filteredTasks = filteredTasks[:limitInt]
-		}
+	if limit, ok := IntParam(params, "limit"); ok && limit > 0 && len(filteredTasks) > limit {
+		filteredTasks = filteredTasks[:limit]
 	}
 
 	// Enrich tasks with Tugboat web URLs
@@ -314,7 +329,7 @@ func (e *EvidenceTaskListTool) matchesFilter(task domain.EvidenceTask, filter do
 
 	// Framework filter
 	if filter.Framework != "" {
-		if !strings.EqualFold(task.Framework, filter.Framework) {
+		if !task.HasFramework(filter.Framework) {
 			return false
 		}
 	}
@@ -224,8 +224,8 @@ func (e *EvidenceValidatorTool) validateEvidenceFile(ctx context.Context, filePa
 		return nil, fmt.Errorf("evidence file does not exist: %s", filePath)
 	}
 
-	// Read file content
-	content, err := os.ReadFile(filePath)
+	// Read file content, decrypting encrypted evidence
+	content, err := storage.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read evidence file: %w", err)
 	}
@@ -33,7 +33,10 @@ import (
 	"github.com/grctool/grctool/internal/logger"
 	"github.com/grctool/grctool/internal/models"
 	"github.com/grctool/grctool/internal/naming"
+	"github.com/grctool/grctool/internal/services/provenance"
+	"github.com/grctool/grctool/internal/signing"
 	"github.com/grctool/grctool/internal/storage"
+	"github.com/grctool/grctool/internal/utils"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 	"gopkg.in/yaml.v3"
@@ -48,6 +51,7 @@ var (
 	ErrInvalidInterval   = errors.New("invalid collection interval")
 	ErrDirectoryCreation = errors.New("failed to create directory")
 	ErrFileWrite         = errors.New("failed to write file")
+	ErrInvalidTimestamp  = errors.New("invalid timestamp")
 )
 
 // EvidenceWriterTool provides evidence writing with window management
@@ -57,6 +61,8 @@ type EvidenceWriterTool struct {
 	dataStore   interfaces.LocalDataStore
 	validator   *Validator
 	planManager *CollectionPlanManager
+	signer      signing.Signer // Signs evidence files and metadata; nil when signing is disabled
+	provenance  *provenance.Service
 }
 
 // NewEvidenceWriterTool creates a new evidence writer tool
@@ -82,6 +88,8 @@ func NewEvidenceWriterTool(cfg *config.Config, log logger.Logger) Tool {
 		dataStore:   localDataStore,
 		validator:   validator,
 		planManager: planManager,
+		signer:      signing.New(cfg.Evidence.Signing),
+		provenance:  provenance.NewService(""),
 	}
 }
 
@@ -131,6 +139,11 @@ func (ewt *EvidenceWriterTool) GetClaudeToolDefinition() models.ClaudeTool {
 					"type":        "string",
 					"description": "Source location (file path, URL, or description)",
 				},
+				"source_modified_at": map[string]interface{}{
+					"type":        "string",
+					"format":      "date-time",
+					"description": "When the source was last modified (RFC 3339), e.g. a Google Doc's last edit time; local file sources are read from disk",
+				},
 				"controls": map[string]interface{}{
 					"type":        "array",
 					"items":       map[string]interface{}{"type": "string"},
@@ -151,20 +164,30 @@ func (ewt *EvidenceWriterTool) GetClaudeToolDefinition() models.ClaudeTool {
 					"default":     "complete",
 					"description": "Evidence collection status",
 				},
+				"window": map[string]interface{}{
+					"type":        "string",
+					"pattern":     `^\d{4}(-(H[12]|Q[1-4]|0[1-9]|1[0-2]))?$`,
+					"description": "Collection window to write into (2025, 2025-H1, 2025-Q3, or 2025-07); defaults to the current window of the task's collection interval",
+					"examples":    []string{"2025-Q4", "2025"},
+				},
 				"update_plan": map[string]interface{}{
 					"type":        "boolean",
 					"default":     true,
 					"description": "Whether to update the collection plan",
 				},
+				"tool_parameters": map[string]interface{}{
+					"type":        "object",
+					"description": "Parameters of the tool run that produced the content, recorded in the provenance attestation; *_git_hash values such as terraform_git_hash are recorded as source commits",
+				},
 			},
 			"required": []string{"task_ref", "title", "content", "format"},
 		},
 	}
 }
 
-// calculateFileChecksum computes the SHA256 checksum of a file
+// calculateFileChecksum computes the SHA256 checksum of a file's plaintext
 func calculateFileChecksum(filePath string) (string, error) {
-	data, err := os.ReadFile(filePath)
+	data, err := storage.ReadFile(filePath)
 	if err != nil {
 		return "", fmt.Errorf("reading file for checksum: %w", err)
 	}
@@ -230,6 +253,14 @@ func (ewt *EvidenceWriterTool) Execute(ctx context.Context, params map[string]in
 
 	sourceType, _ := params["source_type"].(string)
 	sourceLocation, _ := params["source_location"].(string)
+	var sourceModifiedAt time.Time
+	if modified, _ := params["source_modified_at"].(string); modified != "" {
+		parsed, err := time.Parse(time.RFC3339, modified)
+		if err != nil {
+			return "", nil, fmt.Errorf("validating source_modified_at parameter '%s': %w", modified, ErrInvalidTimestamp)
+		}
+		sourceModifiedAt = parsed
+	}
 	summary, _ := params["summary"].(string)
 	reasoning, _ := params["reasoning"].(string)
 	status, _ := params["status"].(string)
@@ -251,6 +282,7 @@ func (ewt *EvidenceWriterTool) Execute(ctx context.Context, params map[string]in
 	if up, ok := params["update_plan"].(bool); ok {
 		updatePlan = up
 	}
+	toolParameters, _ := params["tool_parameters"].(map[string]interface{})
 
 	// Parse controls array
 	controls := []string{}
@@ -273,14 +305,26 @@ func (ewt *EvidenceWriterTool) Execute(ctx context.Context, params map[string]in
 		return "", nil, fmt.Errorf("failed to resolve task reference '%s': %w", taskRef, err)
 	}
 
-	// Calculate evidence window
-	window := CalculateEvidenceWindow(task.CollectionInterval, time.Now())
+	// Use the requested evidence window, or the current one for the task's interval
+	window, _ := params["window"].(string)
+	if window == "" {
+		window = CalculateEvidenceWindow(task.CollectionInterval, time.Now())
+	} else if _, _, err := ParseEvidenceWindow(window); err != nil {
+		return "", nil, fmt.Errorf("validating parameters: %w", err)
+	}
 
 	// Check context cancellation before directory operations
 	if err := ctx.Err(); err != nil {
 		return "", nil, fmt.Errorf("operation cancelled before directory creation: %w", err)
 	}
 
+	// Lock the window so concurrent runs do not interleave plan and metadata updates
+	lock, err := storage.LockWindow(ewt.config.Storage.DataDir, task.ReferenceID, window)
+	if err != nil {
+		return "", nil, fmt.Errorf("locking evidence window: %w", err)
+	}
+	defer lock.Unlock()
+
 	// Create evidence directory structure (hybrid approach - working files at root)
 	taskDirName := naming.GetEvidenceTaskDirName(task.Name, task.ReferenceID, task.ID)
 	windowDir := filepath.Join(ewt.config.Storage.DataDir, "evidence", taskDirName, window)
@@ -314,7 +358,8 @@ func (ewt *EvidenceWriterTool) Execute(ctx context.Context, params map[string]in
 	}
 
 	// Write evidence file
-	if err := ewt.writeEvidenceFile(evidencePath, content, format); err != nil {
+	encrypt := storage.EncryptsEvidence(ewt.config.Storage.Encryption, task)
+	if err := ewt.writeEvidenceFile(evidencePath, content, format, encrypt); err != nil {
 		return "", nil, fmt.Errorf("writing evidence file '%s': %w: %w", evidencePath, ErrFileWrite, err)
 	}
 
@@ -339,11 +384,13 @@ func (ewt *EvidenceWriterTool) Execute(ctx context.Context, params map[string]in
 	}
 
 	// Create file metadata entry
+	collectedAt := time.Now()
 	fileMetadata := models.FileMetadata{
 		Path:        filename, // Just the filename, not full path
 		Checksum:    checksum,
 		SizeBytes:   sizeBytes,
-		GeneratedAt: time.Now(),
+		GeneratedAt: collectedAt,
+		Sources:     SourceTimestamps(sourceType, sourceLocation, sourceModifiedAt, collectedAt),
 	}
 
 	// Determine tools used from parameters
@@ -353,18 +400,76 @@ func (ewt *EvidenceWriterTool) Execute(ctx context.Context, params map[string]in
 	}
 
 	// Write generation metadata
-	if err := ewt.writeGenerationMetadata(
+	signedFiles := []string{filename}
+	if err := writeGenerationMetadata(
+		ewt.logger,
 		evidenceDir,
 		task,
 		window,
 		[]models.FileMetadata{fileMetadata},
-		"grctool-cli", // Generated by CLI directly
-		toolsUsed,
+		models.GenerationMetadata{
+			GeneratedBy:      "grctool-cli", // Generated by CLI directly
+			GenerationMethod: "tool_coordination",
+			ToolsUsed:        toolsUsed,
+		},
 	); err != nil {
 		ewt.logger.Warn("Failed to write generation metadata",
 			logger.Field{Key: "error", Value: err},
 			logger.Field{Key: "evidence_dir", Value: evidenceDir})
 		// Don't fail the operation for metadata errors
+	} else {
+		signedFiles = append(signedFiles, signing.MetadataPath)
+	}
+
+	// Sign the file and the metadata now recording its checksum
+	if ewt.signer != nil {
+		for _, file := range signedFiles {
+			if err := signing.SignFile(ctx, ewt.signer, evidenceDir, file, storage.ReadFile); err != nil {
+				return "", nil, fmt.Errorf("signing evidence file '%s': %w", evidencePath, err)
+			}
+		}
+	}
+
+	// Attest how the file was produced, signed like the file itself
+	if checksum != "" {
+		definition := provenance.BuildDefinition{
+			BuildType: provenance.EvidenceBuildType,
+			ExternalParameters: map[string]interface{}{
+				"task_ref":           task.ReferenceID,
+				"window":             window,
+				"title":              title,
+				"format":             format,
+				"source_type":        sourceType,
+				"source_location":    sourceLocation,
+				"source_modified_at": params["source_modified_at"],
+				"tool_parameters":    toolParameters,
+				"status":             status,
+			},
+			ResolvedDependencies: ewt.provenance.SourceDependencies(fileMetadata.Sources, toolParameters),
+		}
+		statement := provenance.NewStatement(filename, strings.TrimPrefix(checksum, "sha256:"), definition, start, time.Now())
+		envelope, err := provenance.Seal(ctx, statement, ewt.signer)
+		if err != nil {
+			return "", nil, fmt.Errorf("attesting evidence file '%s': %w", evidencePath, err)
+		}
+		if err := provenance.WriteAttestation(evidenceDir, filename, envelope); err != nil {
+			return "", nil, fmt.Errorf("writing attestation of evidence file '%s': %w", evidencePath, err)
+		}
+	}
+
+	// Start the file's chain of custody
+	generated := models.CustodyEvent{
+		Timestamp: collectedAt,
+		File:      filename,
+		Event:     models.CustodyGenerated,
+		Actor:     storage.CustodyActor(),
+		SHA256:    checksum,
+		Details:   ewt.formatSource(sourceType, sourceLocation),
+	}
+	if err := storage.AppendCustodyEvents(evidenceDir, generated); err != nil {
+		ewt.logger.Warn("Failed to record evidence in custody log",
+			logger.Field{Key: "error", Value: err},
+			logger.Field{Key: "file", Value: evidencePath})
 	}
 
 	// Update collection plan if requested
@@ -474,8 +579,9 @@ func (ewt *EvidenceWriterTool) resolveTaskReference(taskRef string) (*domain.Evi
 	return task, nil
 }
 
-// writeEvidenceFile writes the evidence content to the specified file
-func (ewt *EvidenceWriterTool) writeEvidenceFile(filePath, content, format string) error {
+// writeEvidenceFile writes the evidence content to the specified file,
+// encrypted when encrypt is set
+func (ewt *EvidenceWriterTool) writeEvidenceFile(filePath, content, format string, encrypt bool) error {
 	// For markdown files, ensure proper formatting
 	if format == "markdown" && !strings.HasPrefix(content, "#") {
 		// Add a basic header if content doesn't start with one
@@ -483,22 +589,24 @@ func (ewt *EvidenceWriterTool) writeEvidenceFile(filePath, content, format strin
 	}
 
 	// Write the file
-	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+	if err := storage.WriteEvidenceFile(filePath, []byte(content), encrypt); err != nil {
 		return fmt.Errorf("writing file to disk: %w", err)
 	}
 
 	return nil
 }
 
-// writeGenerationMetadata creates a .generation/metadata.yaml file with generation details
+// writeGenerationMetadata creates or updates the .generation/metadata.yaml file with generation details.
+// Entries for previously written files are kept so their source timestamps survive later writes, as is
+// the review status of a machine draft. generation says how the files were generated.
 // windowDir should point to the window root directory where evidence files are written
-func (ewt *EvidenceWriterTool) writeGenerationMetadata(
+func writeGenerationMetadata(
+	log logger.Logger,
 	windowDir string,
 	task *domain.EvidenceTask,
 	window string,
 	files []models.FileMetadata,
-	generatedBy string,
-	toolsUsed []string,
+	generation models.GenerationMetadata,
 ) error {
 	// Create .generation directory inside window root
 	metadataDir := filepath.Join(windowDir, ".generation")
@@ -506,19 +614,29 @@ func (ewt *EvidenceWriterTool) writeGenerationMetadata(
 		return fmt.Errorf("creating metadata directory: %w", err)
 	}
 
-	// Build metadata structure
-	metadata := models.GenerationMetadata{
-		GeneratedAt:      time.Now(),
-		GeneratedBy:      generatedBy,
-		GenerationMethod: "tool_coordination",
-		TaskID:           task.ID,
-		TaskRef:          task.ReferenceID,
-		Window:           window,
-		ToolsUsed:        toolsUsed,
-		FilesGenerated:   files,
-		Status:           "generated",
+	// Keep files recorded by earlier writes to this window
+	metadataPath := filepath.Join(metadataDir, "metadata.yaml")
+	if existing, err := os.ReadFile(metadataPath); err == nil {
+		var previous models.GenerationMetadata
+		if err := yaml.Unmarshal(existing, &previous); err == nil {
+			files = mergeFileMetadata(previous.FilesGenerated, files)
+			if generation.DraftedBy == "" {
+				generation.DraftedBy = previous.DraftedBy
+				generation.ReviewStatus = previous.ReviewStatus
+				generation.DraftUsage = previous.DraftUsage
+			}
+		}
 	}
 
+	// Build metadata structure
+	metadata := generation
+	metadata.GeneratedAt = time.Now()
+	metadata.TaskID = task.ID
+	metadata.TaskRef = task.ReferenceID
+	metadata.Window = window
+	metadata.FilesGenerated = files
+	metadata.Status = "generated"
+
 	// Serialize to YAML
 	yamlData, err := yaml.Marshal(&metadata)
 	if err != nil {
@@ -526,18 +644,69 @@ func (ewt *EvidenceWriterTool) writeGenerationMetadata(
 	}
 
 	// Write metadata file
-	metadataPath := filepath.Join(metadataDir, "metadata.yaml")
 	if err := os.WriteFile(metadataPath, yamlData, 0644); err != nil {
 		return fmt.Errorf("writing metadata file: %w", err)
 	}
 
-	ewt.logger.Info("Generation metadata written successfully",
+	log.Info("Generation metadata written successfully",
 		logger.Field{Key: "metadata_path", Value: metadataPath},
 		logger.Field{Key: "files_tracked", Value: len(files)})
 
 	return nil
 }
 
+// mergeFileMetadata replaces entries with the same path and appends new ones
+func mergeFileMetadata(existing, updates []models.FileMetadata) []models.FileMetadata {
+	merged := append([]models.FileMetadata{}, existing...)
+	for _, update := range updates {
+		replaced := false
+		for i := range merged {
+			if merged[i].Path == update.Path {
+				merged[i] = update
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, update)
+		}
+	}
+	return merged
+}
+
+// SourceTimestamps records the sources behind an evidence file: the tool run
+// time, and the last-modified time of the source location when it is a local
+// file or glob pattern or was reported by the caller
+func SourceTimestamps(sourceType, sourceLocation string, sourceModifiedAt, collectedAt time.Time) []models.SourceTimestamp {
+	var sources []models.SourceTimestamp
+	if sourceType != "" && sourceType != "manual" {
+		sources = append(sources, models.SourceTimestamp{
+			Type:       models.SourceTypeTool,
+			Location:   sourceType,
+			ModifiedAt: collectedAt,
+		})
+	}
+	if sourceLocation == "" {
+		return sources
+	}
+
+	if !sourceModifiedAt.IsZero() {
+		return append(sources, models.SourceTimestamp{
+			Type:       models.SourceTypeDocument,
+			Location:   sourceLocation,
+			ModifiedAt: sourceModifiedAt,
+		})
+	}
+	if modified, ok := utils.LatestModTime(sourceLocation); ok {
+		sources = append(sources, models.SourceTimestamp{
+			Type:       models.SourceTypeFile,
+			Location:   sourceLocation,
+			ModifiedAt: modified,
+		})
+	}
+	return sources
+}
+
 // formatSource creates a human-readable source description
 func (ewt *EvidenceWriterTool) formatSource(sourceType, sourceLocation string) string {
 	if sourceType == "" && sourceLocation == "" {
@@ -579,6 +748,43 @@ func CalculateEvidenceWindow(interval string, date time.Time) string {
 	}
 }
 
+// ParseEvidenceWindow returns the UTC time range [start, end) covered by a window
+// produced by CalculateEvidenceWindow ("2025", "2025-H1", "2025-Q3", "2025-07")
+func ParseEvidenceWindow(window string) (time.Time, time.Time, error) {
+	var year, n int
+	var start, end time.Time
+
+	switch {
+	case evidenceWindowYear.MatchString(window):
+		fmt.Sscanf(window, "%d", &year)
+		start = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		end = start.AddDate(1, 0, 0)
+	case evidenceWindowHalf.MatchString(window):
+		fmt.Sscanf(window, "%d-H%d", &year, &n)
+		start = time.Date(year, time.Month((n-1)*6+1), 1, 0, 0, 0, 0, time.UTC)
+		end = start.AddDate(0, 6, 0)
+	case evidenceWindowQuarter.MatchString(window):
+		fmt.Sscanf(window, "%d-Q%d", &year, &n)
+		start = time.Date(year, time.Month((n-1)*3+1), 1, 0, 0, 0, 0, time.UTC)
+		end = start.AddDate(0, 3, 0)
+	case evidenceWindowMonth.MatchString(window):
+		fmt.Sscanf(window, "%d-%d", &year, &n)
+		start = time.Date(year, time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+		end = start.AddDate(0, 1, 0)
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid evidence window: %q", window)
+	}
+
+	return start, end, nil
+}
+
+var (
+	evidenceWindowYear    = regexp.MustCompile(`^\d{4}$`)
+	evidenceWindowHalf    = regexp.MustCompile(`^\d{4}-H[12]$`)
+	evidenceWindowQuarter = regexp.MustCompile(`^\d{4}-Q[1-4]$`)
+	evidenceWindowMonth   = regexp.MustCompile(`^\d{4}-(0[1-9]|1[0-2])$`)
+)
+
 // GenerateEvidenceFilename creates a numbered filename
 // Pure function for deterministic file naming
 func GenerateEvidenceFilename(index int, title string) string {
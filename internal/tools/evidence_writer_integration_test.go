@@ -21,10 +21,14 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/grctool/grctool/internal/config"
 	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/services/provenance"
+	"github.com/grctool/grctool/internal/storage"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -79,6 +83,220 @@ func TestEvidenceWriterIntegration(t *testing.T) {
 	assert.Len(t, planFiles, 1)
 }
 
+// TestEvidenceWriterIntegration_Window tests that evidence is written into
+// the requested window rather than the current one
+func TestEvidenceWriterIntegration_Window(t *testing.T) {
+	tempDir := t.TempDir()
+	cfg := &config.Config{Storage: config.StorageConfig{DataDir: tempDir}}
+	log, err := logger.NewTestLogger()
+	require.NoError(t, err)
+	setupTestData(t, tempDir)
+
+	tool := NewEvidenceWriterTool(cfg, log)
+	params := map[string]interface{}{
+		"task_ref": "327992",
+		"title":    "Last Year's Review",
+		"content":  "# Review",
+		"format":   "markdown",
+		"window":   "2024-Q3",
+	}
+	_, _, err = tool.Execute(context.Background(), params)
+	require.NoError(t, err)
+
+	evidenceFiles := findEvidenceFiles(tempDir)
+	require.Len(t, evidenceFiles, 1)
+	assert.Equal(t, "2024-Q3", filepath.Base(filepath.Dir(evidenceFiles[0])))
+
+	params["window"] = "2024-Q5"
+	_, _, err = tool.Execute(context.Background(), params)
+	assert.ErrorContains(t, err, "invalid evidence window")
+	assert.Error(t, ValidateParams(tool, params), "the schema rejects malformed windows")
+}
+
+// stubSigner signs with a fixed prefix, recording what it signed
+type stubSigner struct {
+	signed []string
+}
+
+func (s *stubSigner) Method() string { return "stub" }
+
+func (s *stubSigner) Sign(_ context.Context, data []byte) ([]byte, error) {
+	s.signed = append(s.signed, string(data))
+	return append([]byte("signed:"), data...), nil
+}
+
+func (s *stubSigner) Verify(_ context.Context, data, signature []byte) error {
+	return nil
+}
+
+// TestEvidenceWriterIntegration_Signing tests that written evidence and its
+// generation metadata are signed
+func TestEvidenceWriterIntegration_Signing(t *testing.T) {
+	tempDir := t.TempDir()
+	cfg := &config.Config{Storage: config.StorageConfig{DataDir: tempDir}}
+	log, err := logger.NewTestLogger()
+	require.NoError(t, err)
+	setupTestData(t, tempDir)
+
+	tool := NewEvidenceWriterTool(cfg, log).(*EvidenceWriterTool)
+	signer := &stubSigner{}
+	tool.signer = signer
+
+	_, _, err = tool.Execute(context.Background(), map[string]interface{}{
+		"task_ref": "327992",
+		"title":    "Signed Evidence",
+		"content":  "user,role\nalex,admin\n",
+		"format":   "csv",
+	})
+	require.NoError(t, err)
+
+	evidenceFiles := findEvidenceFiles(tempDir)
+	require.Len(t, evidenceFiles, 1)
+	windowDir := filepath.Dir(evidenceFiles[0])
+	signature, err := os.ReadFile(filepath.Join(windowDir, ".generation", "signatures", filepath.Base(evidenceFiles[0])+".sig"))
+	require.NoError(t, err)
+	assert.Equal(t, "signed:user,role\nalex,admin\n", string(signature))
+
+	metadata, err := os.ReadFile(filepath.Join(windowDir, ".generation", "metadata.yaml"))
+	require.NoError(t, err)
+	signature, err = os.ReadFile(filepath.Join(windowDir, ".generation", "metadata.yaml.sig"))
+	require.NoError(t, err)
+	assert.Equal(t, "signed:"+string(metadata), string(signature))
+	assert.Len(t, signer.signed, 3)
+
+	envelope, err := provenance.LoadAttestation(windowDir, filepath.Base(evidenceFiles[0]))
+	require.NoError(t, err)
+	require.Len(t, envelope.Signatures, 1)
+	assert.Equal(t, "stub", envelope.Signatures[0].KeyID)
+}
+
+// TestEvidenceWriterIntegration_Attestation tests that written evidence gets
+// an in-toto provenance attestation naming the file's digest and sources
+func TestEvidenceWriterIntegration_Attestation(t *testing.T) {
+	tempDir := t.TempDir()
+	cfg := &config.Config{Storage: config.StorageConfig{DataDir: tempDir}}
+	log, err := logger.NewTestLogger()
+	require.NoError(t, err)
+	setupTestData(t, tempDir)
+
+	tool := NewEvidenceWriterTool(cfg, log).(*EvidenceWriterTool)
+	_, _, err = tool.Execute(context.Background(), map[string]interface{}{
+		"task_ref":        "327992",
+		"title":           "IAM Roles",
+		"content":         "# IAM Roles\n",
+		"format":          "markdown",
+		"source_type":     "terraform",
+		"tool_parameters": map[string]interface{}{"terraform_git_hash": "3f2c1e9", "pattern": "*.tf"},
+	})
+	require.NoError(t, err)
+
+	evidenceFiles := findEvidenceFiles(tempDir)
+	require.Len(t, evidenceFiles, 1)
+	windowDir, filename := filepath.Dir(evidenceFiles[0]), filepath.Base(evidenceFiles[0])
+	envelope, err := provenance.LoadAttestation(windowDir, filename)
+	require.NoError(t, err)
+	assert.Empty(t, envelope.Signatures)
+
+	statement, err := envelope.Statement()
+	require.NoError(t, err)
+	checksum, err := calculateFileChecksum(evidenceFiles[0])
+	require.NoError(t, err)
+	require.Len(t, statement.Subject, 1)
+	assert.Equal(t, filename, statement.Subject[0].Name)
+	assert.Equal(t, "sha256:"+statement.Subject[0].Digest["sha256"], checksum)
+
+	definition := statement.Predicate.BuildDefinition
+	assert.Equal(t, provenance.EvidenceBuildType, definition.BuildType)
+	assert.Equal(t, "ET1", definition.ExternalParameters["task_ref"])
+	assert.Equal(t, "terraform", definition.ExternalParameters["source_type"])
+	assert.Equal(t, []provenance.ResourceDescriptor{
+		{Name: "terraform_git_hash", Digest: map[string]string{"gitCommit": "3f2c1e9"}},
+	}, definition.ResolvedDependencies)
+	metadata := statement.Predicate.RunDetails.Metadata
+	require.NotNil(t, metadata.StartedOn)
+	require.NotNil(t, metadata.FinishedOn)
+	assert.False(t, metadata.FinishedOn.Before(*metadata.StartedOn))
+}
+
+// TestEvidenceWriterIntegration_Custody tests that written evidence starts
+// its chain of custody
+func TestEvidenceWriterIntegration_Custody(t *testing.T) {
+	tempDir := t.TempDir()
+	cfg := &config.Config{Storage: config.StorageConfig{DataDir: tempDir}}
+	log, err := logger.NewTestLogger()
+	require.NoError(t, err)
+	setupTestData(t, tempDir)
+
+	tool := NewEvidenceWriterTool(cfg, log).(*EvidenceWriterTool)
+	_, _, err = tool.Execute(context.Background(), map[string]interface{}{
+		"task_ref":        "327992",
+		"title":           "IAM Roles",
+		"content":         "# IAM Roles\n",
+		"format":          "markdown",
+		"source_type":     "terraform",
+		"source_location": "iam.tf",
+	})
+	require.NoError(t, err)
+
+	evidenceFiles := findEvidenceFiles(tempDir)
+	require.Len(t, evidenceFiles, 1)
+	events, err := storage.ReadCustodyLog(filepath.Dir(evidenceFiles[0]))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	checksum, err := calculateFileChecksum(evidenceFiles[0])
+	require.NoError(t, err)
+	assert.Equal(t, models.CustodyEvent{
+		Timestamp: events[0].Timestamp,
+		File:      filepath.Base(evidenceFiles[0]),
+		Event:     models.CustodyGenerated,
+		Actor:     storage.CustodyActor(),
+		SHA256:    strings.TrimPrefix(checksum, "sha256:"),
+		Details:   "Terraform - iam.tf",
+		Hash:      events[0].ChainHash(),
+	}, events[0])
+}
+
+// TestEvidenceWriterIntegration_Encryption tests that evidence of a task
+// storage.encryption covers is encrypted as it is written, and signed and
+// checksummed as its plaintext
+func TestEvidenceWriterIntegration_Encryption(t *testing.T) {
+	useTestEvidenceKey(t)
+
+	tempDir := t.TempDir()
+	cfg := &config.Config{Storage: config.StorageConfig{
+		DataDir:    tempDir,
+		Encryption: config.EncryptionConfig{Enabled: true, Tasks: []string{"ET1"}},
+	}}
+	log, err := logger.NewTestLogger()
+	require.NoError(t, err)
+	setupTestData(t, tempDir)
+
+	tool := NewEvidenceWriterTool(cfg, log).(*EvidenceWriterTool)
+	tool.signer = &stubSigner{}
+	_, _, err = tool.Execute(context.Background(), map[string]interface{}{
+		"task_ref": "327992",
+		"title":    "Admin Users",
+		"content":  "user,role\nalex,admin\n",
+		"format":   "csv",
+	})
+	require.NoError(t, err)
+
+	evidenceFiles := findEvidenceFiles(tempDir)
+	require.Len(t, evidenceFiles, 1)
+	raw, err := os.ReadFile(evidenceFiles[0])
+	require.NoError(t, err)
+	assert.True(t, storage.IsEncrypted(raw))
+	assert.NotContains(t, string(raw), "alex")
+
+	content, err := storage.ReadFile(evidenceFiles[0])
+	require.NoError(t, err)
+	assert.Equal(t, "user,role\nalex,admin\n", string(content))
+	windowDir := filepath.Dir(evidenceFiles[0])
+	signature, err := os.ReadFile(filepath.Join(windowDir, ".generation", "signatures", filepath.Base(evidenceFiles[0])+".sig"))
+	require.NoError(t, err)
+	assert.Equal(t, "signed:user,role\nalex,admin\n", string(signature))
+}
+
 // Helper functions following AGENTS.md - use real implementations, not mocks
 
 // setupTestData copies test fixtures to the temp directory
@@ -23,8 +23,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/grctool/grctool/internal/models"
 	"github.com/grctool/grctool/internal/naming"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCalculateEvidenceWindow(t *testing.T) {
@@ -119,6 +121,62 @@ func TestCalculateEvidenceWindow(t *testing.T) {
 	}
 }
 
+func TestParseEvidenceWindow(t *testing.T) {
+	tests := map[string]struct {
+		window    string
+		wantStart time.Time
+		wantEnd   time.Time
+		wantErr   bool
+	}{
+		"annual": {
+			window:    "2025",
+			wantStart: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"second half": {
+			window:    "2025-H2",
+			wantStart: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"quarter": {
+			window:    "2025-Q3",
+			wantStart: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"month": {
+			window:    "2025-12",
+			wantStart: time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"invalid quarter":  {window: "2025-Q5", wantErr: true},
+		"invalid month":    {window: "2025-13", wantErr: true},
+		"arbitrary string": {window: "last-year", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			start, end, err := ParseEvidenceWindow(tt.window)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantEnd, end)
+		})
+	}
+}
+
+func TestParseEvidenceWindow_RoundTrip(t *testing.T) {
+	date := time.Date(2025, 5, 20, 12, 0, 0, 0, time.UTC)
+	for _, interval := range []string{"year", "six_month", "quarter", "month"} {
+		start, end, err := ParseEvidenceWindow(CalculateEvidenceWindow(interval, date))
+		assert.NoError(t, err, interval)
+		assert.False(t, date.Before(start), interval)
+		assert.True(t, date.Before(end), interval)
+	}
+}
+
 func TestGenerateEvidenceFilename(t *testing.T) {
 	tests := map[string]struct {
 		index int
@@ -542,3 +600,79 @@ func TestCalculateFileChecksumUniqueness(t *testing.T) {
 	// Verify checksums are different
 	assert.NotEqual(t, checksum1, checksum2, "Different file contents should produce different checksums")
 }
+
+func TestSourceTimestamps(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	older := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2025, 9, 15, 0, 0, 0, 0, time.UTC)
+	for name, modified := range map[string]time.Time{"iam.tf": older, "s3.tf": newer} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte("# terraform"), 0644))
+		require.NoError(t, os.Chtimes(path, modified, modified))
+	}
+	collectedAt := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	docModified := time.Date(2025, 8, 20, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		sourceType       string
+		sourceLocation   string
+		sourceModifiedAt time.Time
+		want             []models.SourceTimestamp
+	}{
+		"glob of local files": {
+			sourceType:     "terraform",
+			sourceLocation: filepath.Join(dir, "*.tf"),
+			want: []models.SourceTimestamp{
+				{Type: models.SourceTypeTool, Location: "terraform", ModifiedAt: collectedAt},
+				{Type: models.SourceTypeFile, Location: filepath.Join(dir, "*.tf"), ModifiedAt: newer},
+			},
+		},
+		"remote document": {
+			sourceType:       "google_docs",
+			sourceLocation:   "Security Policies/Access Control Policy",
+			sourceModifiedAt: docModified,
+			want: []models.SourceTimestamp{
+				{Type: models.SourceTypeTool, Location: "google_docs", ModifiedAt: collectedAt},
+				{Type: models.SourceTypeDocument, Location: "Security Policies/Access Control Policy", ModifiedAt: docModified},
+			},
+		},
+		"manual description": {
+			sourceType:     "manual",
+			sourceLocation: "Screenshot from the admin console",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := SourceTimestamps(tt.sourceType, tt.sourceLocation, tt.sourceModifiedAt, collectedAt)
+			require.Len(t, got, len(tt.want))
+			for i := range tt.want {
+				assert.Equal(t, tt.want[i].Type, got[i].Type)
+				assert.Equal(t, tt.want[i].Location, got[i].Location)
+				assert.True(t, tt.want[i].ModifiedAt.Equal(got[i].ModifiedAt), "modified_at of %s", got[i].Location)
+			}
+		})
+	}
+}
+
+func TestMergeFileMetadata(t *testing.T) {
+	t.Parallel()
+
+	existing := []models.FileMetadata{
+		{Path: "01_users.csv", Checksum: "sha256:old"},
+		{Path: "02_groups.csv", Checksum: "sha256:groups"},
+	}
+	merged := mergeFileMetadata(existing, []models.FileMetadata{
+		{Path: "01_users.csv", Checksum: "sha256:new"},
+		{Path: "03_roles.csv", Checksum: "sha256:roles"},
+	})
+
+	require.Len(t, merged, 3)
+	assert.Equal(t, "sha256:new", merged[0].Checksum)
+	assert.Equal(t, "02_groups.csv", merged[1].Path)
+	assert.Equal(t, "03_roles.csv", merged[2].Path)
+	assert.Equal(t, "sha256:old", existing[0].Checksum, "input is not modified")
+}
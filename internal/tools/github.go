@@ -28,6 +28,7 @@ import (
 	"github.com/grctool/grctool/internal/config"
 	"github.com/grctool/grctool/internal/logger"
 	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/telemetry"
 	"github.com/grctool/grctool/internal/transport"
 	"github.com/grctool/grctool/internal/vcr"
 )
@@ -51,6 +52,7 @@ func NewGitHubTool(cfg *config.Config, log logger.Logger) Tool {
 	} else {
 		// Add logging if VCR not enabled
 		httpTransport = transport.NewLoggingTransport(httpTransport, log.WithComponent("github-api"))
+		httpTransport = telemetry.Transport(httpTransport)
 	}
 
 	return &GitHubTool{
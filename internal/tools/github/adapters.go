@@ -28,15 +28,34 @@ import (
 // These adapters maintain backward compatibility with the existing Tool interface
 // while using the new consolidated GitHub client architecture.
 
+// repositorySource fingerprints GitHub tool results for the result cache by
+// the configured repository. The API offers no cheap version of permissions
+// or settings, so cached results otherwise last for the cache TTL.
+type repositorySource struct {
+	repository string
+}
+
+func newRepositorySource(cfg *config.Config) repositorySource {
+	return repositorySource{repository: cfg.Evidence.Tools.GitHub.Repository}
+}
+
+// SourceFingerprint identifies the repository read when the parameters do
+// not name one
+func (rs repositorySource) SourceFingerprint(_ context.Context, _ map[string]interface{}) (string, error) {
+	return "github:" + rs.repository, nil
+}
+
 // GitHubAdapter provides the main GitHub tool interface
 type GitHubAdapter struct {
+	repositorySource
 	tool types.LegacyTool
 }
 
 // NewGitHubAdapter creates a GitHub tool adapter (legacy "github" tool)
 func NewGitHubAdapter(cfg *config.Config, log logger.Logger) types.LegacyTool {
 	return &GitHubAdapter{
-		tool: NewGitHubTool(cfg, log),
+		repositorySource: newRepositorySource(cfg),
+		tool:             NewGitHubTool(cfg, log),
 	}
 }
 
@@ -62,13 +81,15 @@ func (ga *GitHubAdapter) Execute(ctx context.Context, params map[string]interfac
 
 // GitHubEnhancedAdapter provides the enhanced GitHub search interface
 type GitHubEnhancedAdapter struct {
+	repositorySource
 	tool types.LegacyTool
 }
 
 // NewGitHubEnhancedAdapter creates an enhanced GitHub search tool adapter
 func NewGitHubEnhancedAdapter(cfg *config.Config, log logger.Logger) types.LegacyTool {
 	return &GitHubEnhancedAdapter{
-		tool: NewGitHubEnhancedTool(cfg, log),
+		repositorySource: newRepositorySource(cfg),
+		tool:             NewGitHubEnhancedTool(cfg, log),
 	}
 }
 
@@ -94,13 +115,15 @@ func (gea *GitHubEnhancedAdapter) Execute(ctx context.Context, params map[string
 
 // GitHubPermissionsAdapter provides the permissions analysis interface
 type GitHubPermissionsAdapter struct {
+	repositorySource
 	tool types.LegacyTool
 }
 
 // NewGitHubPermissionsAdapter creates a permissions analysis tool adapter
 func NewGitHubPermissionsAdapter(cfg *config.Config, log logger.Logger) types.LegacyTool {
 	return &GitHubPermissionsAdapter{
-		tool: NewGitHubPermissionsTool(cfg, log),
+		repositorySource: newRepositorySource(cfg),
+		tool:             NewGitHubPermissionsTool(cfg, log),
 	}
 }
 
@@ -126,13 +149,15 @@ func (gpa *GitHubPermissionsAdapter) Execute(ctx context.Context, params map[str
 
 // GitHubDeploymentAccessAdapter provides the deployment access analysis interface
 type GitHubDeploymentAccessAdapter struct {
+	repositorySource
 	tool types.LegacyTool
 }
 
 // NewGitHubDeploymentAccessAdapter creates a deployment access analysis tool adapter
 func NewGitHubDeploymentAccessAdapter(cfg *config.Config, log logger.Logger) types.LegacyTool {
 	return &GitHubDeploymentAccessAdapter{
-		tool: NewGitHubDeploymentAccessTool(cfg, log),
+		repositorySource: newRepositorySource(cfg),
+		tool:             NewGitHubDeploymentAccessTool(cfg, log),
 	}
 }
 
@@ -158,13 +183,15 @@ func (gdaa *GitHubDeploymentAccessAdapter) Execute(ctx context.Context, params m
 
 // GitHubSecurityFeaturesAdapter provides the security features analysis interface
 type GitHubSecurityFeaturesAdapter struct {
+	repositorySource
 	tool types.LegacyTool
 }
 
 // NewGitHubSecurityFeaturesAdapter creates a security features analysis tool adapter
 func NewGitHubSecurityFeaturesAdapter(cfg *config.Config, log logger.Logger) types.LegacyTool {
 	return &GitHubSecurityFeaturesAdapter{
-		tool: NewGitHubSecurityFeaturesTool(cfg, log),
+		repositorySource: newRepositorySource(cfg),
+		tool:             NewGitHubSecurityFeaturesTool(cfg, log),
 	}
 }
 
@@ -190,13 +217,15 @@ func (gsfa *GitHubSecurityFeaturesAdapter) Execute(ctx context.Context, params m
 
 // GitHubWorkflowAnalyzerAdapter provides the workflow analysis interface
 type GitHubWorkflowAnalyzerAdapter struct {
+	repositorySource
 	tool types.LegacyTool
 }
 
 // NewGitHubWorkflowAnalyzerAdapter creates a workflow analysis tool adapter
 func NewGitHubWorkflowAnalyzerAdapter(cfg *config.Config, log logger.Logger) types.LegacyTool {
 	return &GitHubWorkflowAnalyzerAdapter{
-		tool: NewGitHubWorkflowAnalyzer(cfg, log),
+		repositorySource: newRepositorySource(cfg),
+		tool:             NewGitHubWorkflowAnalyzer(cfg, log),
 	}
 }
 
@@ -222,13 +251,15 @@ func (gwaa *GitHubWorkflowAnalyzerAdapter) Execute(ctx context.Context, params m
 
 // GitHubReviewAnalyzerAdapter provides the review analysis interface
 type GitHubReviewAnalyzerAdapter struct {
+	repositorySource
 	tool types.LegacyTool
 }
 
 // NewGitHubReviewAnalyzerAdapter creates a review analysis tool adapter
 func NewGitHubReviewAnalyzerAdapter(cfg *config.Config, log logger.Logger) types.LegacyTool {
 	return &GitHubReviewAnalyzerAdapter{
-		tool: NewGitHubReviewAnalyzer(cfg, log),
+		repositorySource: newRepositorySource(cfg),
+		tool:             NewGitHubReviewAnalyzer(cfg, log),
 	}
 }
 
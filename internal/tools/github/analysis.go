@@ -104,7 +104,7 @@ func (gwa *GitHubWorkflowAnalyzer) GetClaudeToolDefinition() models.ClaudeTool {
 // Execute runs the GitHub workflow analysis
 func (gwa *GitHubWorkflowAnalyzer) Execute(ctx context.Context, params map[string]interface{}) (string, *models.EvidenceSource, error) {
 	startTime := time.Now()
-	correlationID := GenerateCorrelationID()
+	correlationID := CorrelationID(ctx)
 
 	gwa.logger.Debug("Executing GitHub workflow analyzer",
 		logger.String("correlation_id", correlationID),
@@ -270,7 +270,7 @@ func (gra *GitHubReviewAnalyzer) GetClaudeToolDefinition() models.ClaudeTool {
 // Execute runs the GitHub PR review analysis
 func (gra *GitHubReviewAnalyzer) Execute(ctx context.Context, params map[string]interface{}) (string, *models.EvidenceSource, error) {
 	startTime := time.Now()
-	correlationID := GenerateCorrelationID()
+	correlationID := CorrelationID(ctx)
 
 	gra.logger.Debug("Executing GitHub review analyzer",
 		logger.String("correlation_id", correlationID),
@@ -435,7 +435,7 @@ func (get *GitHubEnhancedTool) GetClaudeToolDefinition() models.ClaudeTool {
 // Execute runs the enhanced GitHub search tool
 func (get *GitHubEnhancedTool) Execute(ctx context.Context, params map[string]interface{}) (string, *models.EvidenceSource, error) {
 	startTime := time.Now()
-	correlationID := GenerateCorrelationID()
+	correlationID := CorrelationID(ctx)
 
 	get.logger.Debug("Executing enhanced GitHub searcher",
 		logger.String("correlation_id", correlationID),
@@ -30,6 +30,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/grctool/grctool/internal/appcontext"
 	"github.com/grctool/grctool/internal/auth"
 	"github.com/grctool/grctool/internal/config"
 	"github.com/grctool/grctool/internal/logger"
@@ -1128,3 +1129,12 @@ func GenerateCorrelationID() string {
 	// Use timestamp and simple hash for correlation ID
 	return fmt.Sprintf("github-%d", time.Now().UnixNano())
 }
+
+// CorrelationID returns the correlation ID of the command running the tool,
+// or a new one when run outside a command
+func CorrelationID(ctx context.Context) string {
+	if id := appcontext.GetCorrelationID(ctx); id != "" {
+		return id
+	}
+	return GenerateCorrelationID()
+}
@@ -96,7 +96,7 @@ func (gpt *GitHubPermissionsTool) GetClaudeToolDefinition() models.ClaudeTool {
 // Execute runs the GitHub permissions extraction tool
 func (gpt *GitHubPermissionsTool) Execute(ctx context.Context, params map[string]interface{}) (string, *models.EvidenceSource, error) {
 	startTime := time.Now()
-	correlationID := GenerateCorrelationID()
+	correlationID := CorrelationID(ctx)
 
 	gpt.logger.Debug("Executing GitHub permissions extraction",
 		logger.String("correlation_id", correlationID),
@@ -322,7 +322,7 @@ func (gdat *GitHubDeploymentAccessTool) GetClaudeToolDefinition() models.ClaudeT
 // Execute runs the GitHub deployment access extraction tool
 func (gdat *GitHubDeploymentAccessTool) Execute(ctx context.Context, params map[string]interface{}) (string, *models.EvidenceSource, error) {
 	startTime := time.Now()
-	correlationID := GenerateCorrelationID()
+	correlationID := CorrelationID(ctx)
 
 	gdat.logger.Debug("Executing GitHub deployment access extraction",
 		logger.String("correlation_id", correlationID),
@@ -528,7 +528,7 @@ func (gsft *GitHubSecurityFeaturesTool) GetClaudeToolDefinition() models.ClaudeT
 // Execute runs the GitHub security features extraction tool
 func (gsft *GitHubSecurityFeaturesTool) Execute(ctx context.Context, params map[string]interface{}) (string, *models.EvidenceSource, error) {
 	startTime := time.Now()
-	correlationID := GenerateCorrelationID()
+	correlationID := CorrelationID(ctx)
 
 	gsft.logger.Debug("Executing GitHub security features extraction",
 		logger.String("correlation_id", correlationID),
@@ -27,6 +27,7 @@ import (
 	"github.com/grctool/grctool/internal/config"
 	"github.com/grctool/grctool/internal/logger"
 	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/telemetry"
 	"github.com/grctool/grctool/internal/transport"
 )
 
@@ -44,6 +45,7 @@ func NewGitHubAPIClient(cfg *config.Config, log logger.Logger) *GitHubAPIClient
 	// Create HTTP transport with logging
 	httpTransport := http.DefaultTransport
 	httpTransport = transport.NewLoggingTransport(httpTransport, log.WithComponent("github-api-client"))
+	httpTransport = telemetry.Transport(httpTransport)
 
 	return &GitHubAPIClient{
 		config: &cfg.Evidence.Tools.GitHub,
@@ -110,7 +110,7 @@ func (gdat *GitHubDeploymentAccessTool) GetClaudeToolDefinition() models.ClaudeT
 // Execute runs the GitHub deployment access extraction tool
 func (gdat *GitHubDeploymentAccessTool) Execute(ctx context.Context, params map[string]interface{}) (string, *models.EvidenceSource, error) {
 	startTime := time.Now()
-	correlationID := GenerateCorrelationID()
+	correlationID := CorrelationID(ctx)
 
 	gdat.logger.Debug("Executing GitHub deployment access extraction",
 		logger.String("correlation_id", correlationID),
@@ -0,0 +1,110 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !e2e
+
+package tools
+
+import (
+	"testing"
+
+	"github.com/grctool/grctool/internal/frameworks"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyWorkflowFramework(t *testing.T) {
+	t.Parallel()
+
+	gwa := &GitHubWorkflowAnalyzer{}
+	newAnalysis := func() *models.GitHubWorkflowAnalysis {
+		workflow := &models.GitHubWorkflowFile{Name: "deploy"}
+		workflow.ComplianceRules = gwa.analyzeWorkflowCompliance(workflow)
+		return &models.GitHubWorkflowAnalysis{WorkflowFiles: []models.GitHubWorkflowFile{*workflow}}
+	}
+
+	soc2 := newAnalysis()
+	applyWorkflowFramework(soc2, frameworks.SOC2)
+	rules := soc2.WorkflowFiles[0].ComplianceRules
+	require.Len(t, rules, 2)
+	assert.Equal(t, "SOC2", rules[0].Framework)
+	assert.Equal(t, "CC6.1", rules[0].ControlID)
+	assert.Equal(t, "CC6.2", rules[1].ControlID)
+
+	iso := newAnalysis()
+	applyWorkflowFramework(iso, frameworks.ISO27001)
+	rules = iso.WorkflowFiles[0].ComplianceRules
+	assert.Equal(t, "ISO 27001", rules[0].Framework)
+	assert.Equal(t, "A.8.29", rules[0].ControlID)
+	assert.Equal(t, "A.8.32", rules[1].ControlID)
+
+	nist := newAnalysis()
+	applyWorkflowFramework(nist, frameworks.NIST80053)
+	rules = nist.WorkflowFiles[0].ComplianceRules
+	assert.Equal(t, "NIST 800-53", rules[0].Framework)
+	assert.Equal(t, "RA-5", rules[0].ControlID)
+	assert.Equal(t, "CM-3", rules[1].ControlID)
+
+	pci := newAnalysis()
+	applyWorkflowFramework(pci, frameworks.PCIDSS)
+	rules = pci.WorkflowFiles[0].ComplianceRules
+	assert.Equal(t, "PCI DSS", rules[0].Framework)
+	assert.Equal(t, "6.3.1", rules[0].ControlID)
+	assert.Equal(t, "6.5.1", rules[1].ControlID)
+
+	hipaa := newAnalysis()
+	applyWorkflowFramework(hipaa, frameworks.HIPAA)
+	rules = hipaa.WorkflowFiles[0].ComplianceRules
+	assert.Equal(t, "HIPAA", rules[0].Framework)
+	assert.Equal(t, "164.308(a)(1)(ii)(A)", rules[0].ControlID)
+	assert.Equal(t, "164.312(c)(1)", rules[1].ControlID)
+}
+
+func TestBuildComplianceMapping(t *testing.T) {
+	t.Parallel()
+
+	features := map[string]SecurityFeatureDetail{
+		"secret_scanning": {Name: "Secret Scanning", Enabled: true,
+			SOC2Controls: []string{"CC6.1"}, AnnexAControls: []string{"A.5.17", "A.8.12"}, NISTControls: []string{"IA-5"},
+			PCIControls: []string{"8.6.2"}, HIPAAControls: []string{"164.308(a)(5)(ii)(D)", "164.312(d)"}},
+		"code_scanning": {Name: "Code Scanning", Enabled: false,
+			SOC2Controls: []string{"CC7.1"}, AnnexAControls: []string{"A.8.28", "A.8.29"}, NISTControls: []string{"SA-11"},
+			PCIControls: []string{"6.2.3", "6.2.4"}, HIPAAControls: []string{"164.308(a)(8)"}},
+	}
+	gsft := &GitHubSecurityFeaturesTool{}
+
+	tests := map[string]struct {
+		framework string
+		want      map[string][]string
+	}{
+		"soc2": {
+			framework: frameworks.SOC2,
+			want:      map[string][]string{"CC6.1": {"Secret Scanning"}},
+		},
+		"iso27001": {
+			framework: frameworks.ISO27001,
+			want:      map[string][]string{"A.5.17": {"Secret Scanning"}, "A.8.12": {"Secret Scanning"}},
+		},
+		"nist80053": {
+			framework: frameworks.NIST80053,
+			want:      map[string][]string{"IA-5": {"Secret Scanning"}},
+		},
+		"pcidss": {
+			framework: frameworks.PCIDSS,
+			want:      map[string][]string{"8.6.2": {"Secret Scanning"}},
+		},
+		"hipaa": {
+			framework: frameworks.HIPAA,
+			want:      map[string][]string{"164.308(a)(5)(ii)(D)": {"Secret Scanning"}, "164.312(d)": {"Secret Scanning"}},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			info := &SecurityFeaturesInfo{Framework: tc.framework, AllFeatures: features}
+			assert.Equal(t, tc.want, gsft.buildComplianceMapping(info))
+		})
+	}
+}
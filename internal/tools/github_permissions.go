@@ -111,7 +111,7 @@ func (gpt *GitHubPermissionsTool) GetClaudeToolDefinition() models.ClaudeTool {
 // Execute runs the GitHub permissions extraction tool
 func (gpt *GitHubPermissionsTool) Execute(ctx context.Context, params map[string]interface{}) (string, *models.EvidenceSource, error) {
 	startTime := time.Now()
-	correlationID := GenerateCorrelationID()
+	correlationID := CorrelationID(ctx)
 
 	gpt.logger.Debug("Executing GitHub permissions extraction",
 		logger.String("correlation_id", correlationID),
@@ -30,6 +30,7 @@ import (
 	"github.com/grctool/grctool/internal/config"
 	"github.com/grctool/grctool/internal/logger"
 	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/telemetry"
 	"github.com/grctool/grctool/internal/transport"
 )
 
@@ -47,6 +48,7 @@ func NewGitHubReviewAnalyzer(cfg *config.Config, log logger.Logger) Tool {
 	// Create HTTP transport with logging
 	httpTransport := http.DefaultTransport
 	httpTransport = transport.NewLoggingTransport(httpTransport, log.WithComponent("github-review-api"))
+	httpTransport = telemetry.Transport(httpTransport)
 
 	// Set up cache directory
 	cacheDir := filepath.Join(cfg.Storage.DataDir, "github_cache", "reviews")
@@ -142,7 +144,7 @@ func (gra *GitHubReviewAnalyzer) GetClaudeToolDefinition() models.ClaudeTool {
 // Execute runs the GitHub PR review analysis
 func (gra *GitHubReviewAnalyzer) Execute(ctx context.Context, params map[string]interface{}) (string, *models.EvidenceSource, error) {
 	startTime := time.Now()
-	correlationID := GenerateCorrelationID()
+	correlationID := CorrelationID(ctx)
 
 	gra.logger.Debug("Executing GitHub review analyzer",
 		logger.String("correlation_id", correlationID),
@@ -32,6 +32,7 @@ import (
 	"github.com/grctool/grctool/internal/config"
 	"github.com/grctool/grctool/internal/logger"
 	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/telemetry"
 	"github.com/grctool/grctool/internal/transport"
 )
 
@@ -49,6 +50,7 @@ func NewGitHubEnhancedTool(cfg *config.Config, log logger.Logger) Tool {
 	// Create HTTP transport with logging if enabled
 	httpTransport := http.DefaultTransport
 	httpTransport = transport.NewLoggingTransport(httpTransport, log.WithComponent("github-enhanced-api"))
+	httpTransport = telemetry.Transport(httpTransport)
 
 	// Set up cache directory
 	cacheDir := filepath.Join(cfg.Storage.DataDir, "github_cache")
@@ -140,7 +142,7 @@ func (get *GitHubEnhancedTool) GetClaudeToolDefinition() models.ClaudeTool {
 // Execute runs the enhanced GitHub search tool
 func (get *GitHubEnhancedTool) Execute(ctx context.Context, params map[string]interface{}) (string, *models.EvidenceSource, error) {
 	startTime := time.Now()
-	correlationID := GenerateCorrelationID()
+	correlationID := CorrelationID(ctx)
 
 	get.logger.Debug("Executing enhanced GitHub searcher",
 		logger.String("correlation_id", correlationID),
@@ -24,6 +24,7 @@ import (
 
 	"github.com/grctool/grctool/internal/auth"
 	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/frameworks"
 	"github.com/grctool/grctool/internal/logger"
 	"github.com/grctool/grctool/internal/models"
 	"golang.org/x/text/cases"
@@ -104,6 +105,12 @@ func (gsft *GitHubSecurityFeaturesTool) GetClaudeToolDefinition() models.ClaudeT
 					"description": "Include SOC2/compliance framework mapping",
 					"default":     false,
 				},
+				"framework": map[string]interface{}{
+					"type":        "string",
+					"description": "Framework to map features to: soc2, iso27001 (ISO 27001:2022 Annex A), nist80053 (NIST SP 800-53 Rev. 5), pcidss (PCI DSS v4.0) or hipaa (HIPAA Security Rule safeguards)",
+					"enum":        frameworks.Supported,
+					"default":     frameworks.SOC2,
+				},
 			},
 			"required": []string{"repository"},
 		},
@@ -113,7 +120,7 @@ func (gsft *GitHubSecurityFeaturesTool) GetClaudeToolDefinition() models.ClaudeT
 // Execute runs the GitHub security features extraction tool
 func (gsft *GitHubSecurityFeaturesTool) Execute(ctx context.Context, params map[string]interface{}) (string, *models.EvidenceSource, error) {
 	startTime := time.Now()
-	correlationID := GenerateCorrelationID()
+	correlationID := CorrelationID(ctx)
 
 	gsft.logger.Debug("Executing GitHub security features extraction",
 		logger.String("correlation_id", correlationID),
@@ -159,8 +166,14 @@ func (gsft *GitHubSecurityFeaturesTool) Execute(ctx context.Context, params map[
 		includeComplianceMapping = icm
 	}
 
+	framework, _ := params["framework"].(string)
+	framework, err := frameworks.Normalize(framework)
+	if err != nil {
+		return "", nil, err
+	}
+
 	// Extract security features information
-	securityInfo, err := gsft.extractSecurityFeatures(ctx, owner, repo, includePolicyAnalysis, includeComplianceMapping)
+	securityInfo, err := gsft.extractSecurityFeatures(ctx, owner, repo, framework, includePolicyAnalysis, includeComplianceMapping)
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to extract security features: %w", err)
 	}
@@ -191,6 +204,7 @@ func (gsft *GitHubSecurityFeaturesTool) Execute(ctx context.Context, params map[
 			"output_format":              outputFormat,
 			"include_policy_analysis":    includePolicyAnalysis,
 			"include_compliance_mapping": includeComplianceMapping,
+			"framework":                  framework,
 			"security_score":             securityInfo.SecurityScore,
 			"enabled_features":           len(securityInfo.EnabledFeatures),
 			"total_features":             len(securityInfo.AllFeatures),
@@ -209,6 +223,7 @@ func (gsft *GitHubSecurityFeaturesTool) Execute(ctx context.Context, params map[
 // SecurityFeaturesInfo contains comprehensive security features information
 type SecurityFeaturesInfo struct {
 	Repository              string                           `json:"repository"`
+	Framework               string                           `json:"framework"`
 	SecuritySettings        models.GitHubSecuritySettings    `json:"security_settings"`
 	BranchProtections       []models.GitHubBranch            `json:"branch_protections"`
 	SecurityPolicies        []SecurityPolicyInfo             `json:"security_policies,omitempty"`
@@ -223,12 +238,31 @@ type SecurityFeaturesInfo struct {
 
 // SecurityFeatureDetail provides detailed information about a security feature
 type SecurityFeatureDetail struct {
-	Name         string   `json:"name"`
-	Enabled      bool     `json:"enabled"`
-	Description  string   `json:"description"`
-	SOC2Controls []string `json:"soc2_controls,omitempty"`
-	RiskLevel    string   `json:"risk_level"` // high, medium, low
-	Category     string   `json:"category"`   // vulnerability, secrets, code_quality, access_control
+	Name           string   `json:"name"`
+	Enabled        bool     `json:"enabled"`
+	Description    string   `json:"description"`
+	SOC2Controls   []string `json:"soc2_controls,omitempty"`
+	AnnexAControls []string `json:"iso27001_controls,omitempty"`
+	NISTControls   []string `json:"nist_800_53_controls,omitempty"`
+	PCIControls    []string `json:"pci_dss_controls,omitempty"`
+	HIPAAControls  []string `json:"hipaa_controls,omitempty"`
+	RiskLevel      string   `json:"risk_level"` // high, medium, low
+	Category       string   `json:"category"`   // vulnerability, secrets, code_quality, access_control
+}
+
+// Controls returns the controls of the framework the feature supports
+func (f SecurityFeatureDetail) Controls(framework string) []string {
+	switch framework {
+	case frameworks.ISO27001:
+		return f.AnnexAControls
+	case frameworks.NIST80053:
+		return f.NISTControls
+	case frameworks.PCIDSS:
+		return f.PCIControls
+	case frameworks.HIPAA:
+		return f.HIPAAControls
+	}
+	return f.SOC2Controls
 }
 
 // SecurityPolicyInfo contains information about security policies
@@ -250,9 +284,10 @@ type SecurityRecommendation struct {
 }
 
 // extractSecurityFeatures extracts comprehensive security features information
-func (gsft *GitHubSecurityFeaturesTool) extractSecurityFeatures(ctx context.Context, owner, repo string, includePolicyAnalysis, includeComplianceMapping bool) (*SecurityFeaturesInfo, error) {
+func (gsft *GitHubSecurityFeaturesTool) extractSecurityFeatures(ctx context.Context, owner, repo, framework string, includePolicyAnalysis, includeComplianceMapping bool) (*SecurityFeaturesInfo, error) {
 	info := &SecurityFeaturesInfo{
 		Repository:  fmt.Sprintf("%s/%s", owner, repo),
+		Framework:   framework,
 		ExtractedAt: time.Now(),
 		AllFeatures: make(map[string]SecurityFeatureDetail),
 	}
@@ -309,64 +344,92 @@ func (gsft *GitHubSecurityFeaturesTool) buildFeatureAnalysis(info *SecurityFeatu
 	// Define all security features with detailed information
 	features := map[string]SecurityFeatureDetail{
 		"vulnerability_alerts": {
-			Name:         "Vulnerability Alerts",
-			Enabled:      info.SecuritySettings.VulnerabilityAlertsEnabled,
-			Description:  "Automated notifications when security vulnerabilities are found in dependencies",
-			SOC2Controls: []string{"CC6.1", "CC6.2", "CC6.3"},
-			RiskLevel:    "high",
-			Category:     "vulnerability",
+			Name:           "Vulnerability Alerts",
+			Enabled:        info.SecuritySettings.VulnerabilityAlertsEnabled,
+			Description:    "Automated notifications when security vulnerabilities are found in dependencies",
+			SOC2Controls:   []string{"CC6.1", "CC6.2", "CC6.3"},
+			AnnexAControls: []string{"A.8.8"},
+			NISTControls:   []string{"RA-5", "SI-2"},
+			PCIControls:    []string{"6.3.1", "6.3.3"},
+			HIPAAControls:  []string{"164.308(a)(1)(ii)(A)", "164.308(a)(1)(ii)(B)"},
+			RiskLevel:      "high",
+			Category:       "vulnerability",
 		},
 		"automated_security_fixes": {
-			Name:         "Automated Security Fixes (Dependabot)",
-			Enabled:      info.SecuritySettings.AutomatedSecurityFixesEnabled,
-			Description:  "Automatic pull requests to fix security vulnerabilities in dependencies",
-			SOC2Controls: []string{"CC6.1", "CC6.3", "CC8.1"},
-			RiskLevel:    "high",
-			Category:     "vulnerability",
+			Name:           "Automated Security Fixes (Dependabot)",
+			Enabled:        info.SecuritySettings.AutomatedSecurityFixesEnabled,
+			Description:    "Automatic pull requests to fix security vulnerabilities in dependencies",
+			SOC2Controls:   []string{"CC6.1", "CC6.3", "CC8.1"},
+			AnnexAControls: []string{"A.8.8", "A.8.32"},
+			NISTControls:   []string{"SI-2", "CM-3"},
+			PCIControls:    []string{"6.3.3"},
+			HIPAAControls:  []string{"164.308(a)(1)(ii)(B)"},
+			RiskLevel:      "high",
+			Category:       "vulnerability",
 		},
 		"secret_scanning": {
-			Name:         "Secret Scanning",
-			Enabled:      info.SecuritySettings.SecretScanningEnabled,
-			Description:  "Automatic detection of secrets, tokens, and credentials in code",
-			SOC2Controls: []string{"CC6.1", "CC6.7", "CC6.8"},
-			RiskLevel:    "high",
-			Category:     "secrets",
+			Name:           "Secret Scanning",
+			Enabled:        info.SecuritySettings.SecretScanningEnabled,
+			Description:    "Automatic detection of secrets, tokens, and credentials in code",
+			SOC2Controls:   []string{"CC6.1", "CC6.7", "CC6.8"},
+			AnnexAControls: []string{"A.5.17", "A.8.12"},
+			NISTControls:   []string{"IA-5", "SC-28"},
+			PCIControls:    []string{"8.6.2"},
+			HIPAAControls:  []string{"164.308(a)(5)(ii)(D)", "164.312(d)"},
+			RiskLevel:      "high",
+			Category:       "secrets",
 		},
 		"code_scanning": {
-			Name:         "Code Scanning",
-			Enabled:      info.SecuritySettings.CodeScanningEnabled,
-			Description:  "Static analysis to find security vulnerabilities in code",
-			SOC2Controls: []string{"CC6.1", "CC6.2", "CC8.1"},
-			RiskLevel:    "medium",
-			Category:     "code_quality",
+			Name:           "Code Scanning",
+			Enabled:        info.SecuritySettings.CodeScanningEnabled,
+			Description:    "Static analysis to find security vulnerabilities in code",
+			SOC2Controls:   []string{"CC6.1", "CC6.2", "CC8.1"},
+			AnnexAControls: []string{"A.8.28", "A.8.29"},
+			NISTControls:   []string{"RA-5", "SA-11"},
+			PCIControls:    []string{"6.2.3", "6.2.4"},
+			HIPAAControls:  []string{"164.308(a)(1)(ii)(A)", "164.308(a)(8)"},
+			RiskLevel:      "medium",
+			Category:       "code_quality",
 		},
 		"dependency_graph": {
-			Name:         "Dependency Graph",
-			Enabled:      info.SecuritySettings.DependencyGraphEnabled,
-			Description:  "Visualization and tracking of project dependencies",
-			SOC2Controls: []string{"CC6.3", "CC8.1"},
-			RiskLevel:    "medium",
-			Category:     "vulnerability",
+			Name:           "Dependency Graph",
+			Enabled:        info.SecuritySettings.DependencyGraphEnabled,
+			Description:    "Visualization and tracking of project dependencies",
+			SOC2Controls:   []string{"CC6.3", "CC8.1"},
+			AnnexAControls: []string{"A.5.21", "A.8.8"},
+			NISTControls:   []string{"CM-8", "SR-4"},
+			PCIControls:    []string{"6.3.2"},
+			HIPAAControls:  []string{"164.308(a)(1)(ii)(A)"},
+			RiskLevel:      "medium",
+			Category:       "vulnerability",
 		},
 		"security_advisories": {
-			Name:         "Security Advisories",
-			Enabled:      info.SecuritySettings.SecurityAdvisoryEnabled,
-			Description:  "Ability to create and manage security advisories for vulnerabilities",
-			SOC2Controls: []string{"CC6.1", "CC6.3"},
-			RiskLevel:    "medium",
-			Category:     "vulnerability",
+			Name:           "Security Advisories",
+			Enabled:        info.SecuritySettings.SecurityAdvisoryEnabled,
+			Description:    "Ability to create and manage security advisories for vulnerabilities",
+			SOC2Controls:   []string{"CC6.1", "CC6.3"},
+			AnnexAControls: []string{"A.6.8", "A.8.8"},
+			NISTControls:   []string{"IR-6", "SI-5"},
+			PCIControls:    []string{"6.3.1"},
+			HIPAAControls:  []string{"164.308(a)(6)(ii)"},
+			RiskLevel:      "medium",
+			Category:       "vulnerability",
 		},
 	}
 
 	// Add branch protection as a security feature
 	branchProtectionEnabled := len(info.BranchProtections) > 0
 	features["branch_protection"] = SecurityFeatureDetail{
-		Name:         "Branch Protection",
-		Enabled:      branchProtectionEnabled,
-		Description:  "Rules that protect important branches from unauthorized changes",
-		SOC2Controls: []string{"CC6.1", "CC6.2", "CC6.3", "CC6.8"},
-		RiskLevel:    "high",
-		Category:     "access_control",
+		Name:           "Branch Protection",
+		Enabled:        branchProtectionEnabled,
+		Description:    "Rules that protect important branches from unauthorized changes",
+		SOC2Controls:   []string{"CC6.1", "CC6.2", "CC6.3", "CC6.8"},
+		AnnexAControls: []string{"A.5.3", "A.8.4", "A.8.32"},
+		NISTControls:   []string{"AC-5", "CM-3", "CM-5"},
+		PCIControls:    []string{"6.2.3", "6.5.1"},
+		HIPAAControls:  []string{"164.312(a)(1)", "164.312(c)(1)"},
+		RiskLevel:      "high",
+		Category:       "access_control",
 	}
 
 	info.AllFeatures = features
@@ -407,14 +470,14 @@ func (gsft *GitHubSecurityFeaturesTool) analyzeSecurityPolicies(ctx context.Cont
 	return policies, nil
 }
 
-// buildComplianceMapping builds SOC2 compliance mapping
+// buildComplianceMapping builds the compliance mapping of the info's framework
 func (gsft *GitHubSecurityFeaturesTool) buildComplianceMapping(info *SecurityFeaturesInfo) map[string][]string {
 	mapping := make(map[string][]string)
 
-	// Map enabled features to SOC2 controls
+	// Map enabled features to the framework's controls
 	for _, feature := range info.AllFeatures {
-		if feature.Enabled && len(feature.SOC2Controls) > 0 {
-			for _, control := range feature.SOC2Controls {
+		if feature.Enabled {
+			for _, control := range feature.Controls(info.Framework) {
 				mapping[control] = append(mapping[control], feature.Name)
 			}
 		}
@@ -475,8 +538,8 @@ func (gsft *GitHubSecurityFeaturesTool) generateSecurityRecommendations(info *Se
 					"Configure appropriate notifications and workflows",
 				},
 			}
-			if len(feature.SOC2Controls) > 0 {
-				rec.SOC2Relevance = fmt.Sprintf("Required for SOC2 controls: %s", strings.Join(feature.SOC2Controls, ", "))
+			if controls := feature.Controls(info.Framework); len(controls) > 0 {
+				rec.SOC2Relevance = fmt.Sprintf("Required for %s controls: %s", frameworks.Label(info.Framework), strings.Join(controls, ", "))
 			}
 			recommendations = append(recommendations, rec)
 		}
@@ -495,7 +558,8 @@ func (gsft *GitHubSecurityFeaturesTool) generateSecurityRecommendations(info *Se
 				"Enable required reviews and status checks",
 				"Consider restricting push access to specific users/teams",
 			},
-			SOC2Relevance: "Required for SOC2 controls: CC6.1, CC6.2, CC6.3, CC6.8",
+			SOC2Relevance: fmt.Sprintf("Required for %s controls: %s", frameworks.Label(info.Framework),
+				strings.Join(info.AllFeatures["branch_protection"].Controls(info.Framework), ", ")),
 		})
 	}
 
@@ -512,7 +576,7 @@ func (gsft *GitHubSecurityFeaturesTool) generateSecurityRecommendations(info *Se
 				"Create and maintain security policies",
 				"Regular security audits and reviews",
 			},
-			SOC2Relevance: "Supports multiple SOC2 controls across all categories",
+			SOC2Relevance: fmt.Sprintf("Supports multiple %s controls across all categories", frameworks.Label(info.Framework)),
 		})
 	}
 
@@ -538,7 +602,7 @@ func (gsft *GitHubSecurityFeaturesTool) generateDetailedSecurityReport(info *Sec
 
 	// Security Features Status
 	report.WriteString("## Security Features Status\n\n")
-	report.WriteString("| Feature | Status | Risk Level | Category | SOC2 Controls |\n")
+	report.WriteString(fmt.Sprintf("| Feature | Status | Risk Level | Category | %s Controls |\n", frameworks.Label(info.Framework)))
 	report.WriteString("|---------|--------|------------|----------|---------------|\n")
 
 	for _, feature := range info.AllFeatures {
@@ -547,7 +611,7 @@ func (gsft *GitHubSecurityFeaturesTool) generateDetailedSecurityReport(info *Sec
 			status = "✅ Enabled"
 		}
 
-		controls := strings.Join(feature.SOC2Controls, ", ")
+		controls := strings.Join(feature.Controls(info.Framework), ", ")
 		if controls == "" {
 			controls = "None"
 		}
@@ -590,8 +654,8 @@ func (gsft *GitHubSecurityFeaturesTool) generateDetailedSecurityReport(info *Sec
 			report.WriteString(fmt.Sprintf("- **Status:** %s\n", status))
 			report.WriteString(fmt.Sprintf("- **Description:** %s\n", feature.Description))
 			report.WriteString(fmt.Sprintf("- **Risk Level:** %s\n", cases.Title(language.English).String(feature.RiskLevel)))
-			if len(feature.SOC2Controls) > 0 {
-				report.WriteString(fmt.Sprintf("- **SOC2 Controls:** %s\n", strings.Join(feature.SOC2Controls, ", ")))
+			if controls := feature.Controls(info.Framework); len(controls) > 0 {
+				report.WriteString(fmt.Sprintf("- **%s Controls:** %s\n", frameworks.Label(info.Framework), strings.Join(controls, ", ")))
 			}
 			report.WriteString("\n")
 		}
@@ -643,11 +707,15 @@ func (gsft *GitHubSecurityFeaturesTool) generateDetailedSecurityReport(info *Sec
 
 	// Compliance Mapping
 	if len(info.ComplianceMapping) > 0 {
-		report.WriteString("## SOC2 Compliance Mapping\n\n")
-		report.WriteString("| SOC2 Control | Covered by Features |\n")
+		label := frameworks.Label(info.Framework)
+		report.WriteString(fmt.Sprintf("## %s Compliance Mapping\n\n", label))
+		report.WriteString(fmt.Sprintf("| %s Control | Covered by Features |\n", label))
 		report.WriteString("|--------------|---------------------|\n")
 
 		for control, features := range info.ComplianceMapping {
+			if title := frameworks.Title(info.Framework, control); title != "" {
+				control = fmt.Sprintf("%s %s", control, title)
+			}
 			report.WriteString(fmt.Sprintf("| %s | %s |\n", control, strings.Join(features, ", ")))
 		}
 		report.WriteString("\n")
@@ -687,7 +755,7 @@ func (gsft *GitHubSecurityFeaturesTool) generateDetailedSecurityReport(info *Sec
 					report.WriteString(fmt.Sprintf("1. %s\n", item))
 				}
 				if rec.SOC2Relevance != "" {
-					report.WriteString(fmt.Sprintf("\n**SOC2 Relevance:** %s\n", rec.SOC2Relevance))
+					report.WriteString(fmt.Sprintf("\n**%s Relevance:** %s\n", frameworks.Label(info.Framework), rec.SOC2Relevance))
 				}
 				report.WriteString("\n")
 			}
@@ -708,7 +776,7 @@ func (gsft *GitHubSecurityFeaturesTool) generateSecurityMatrix(info *SecurityFea
 
 	// Security Features Matrix
 	report.WriteString("## Security Features Matrix\n\n")
-	report.WriteString("| Category | Feature | Status | Risk Level | SOC2 Controls |\n")
+	report.WriteString(fmt.Sprintf("| Category | Feature | Status | Risk Level | %s Controls |\n", frameworks.Label(info.Framework)))
 	report.WriteString("|----------|---------|--------|------------|---------------|\n")
 
 	// Group and sort by category
@@ -724,7 +792,7 @@ func (gsft *GitHubSecurityFeaturesTool) generateSecurityMatrix(info *SecurityFea
 				status = "✅"
 			}
 
-			controls := strings.Join(feature.SOC2Controls, ", ")
+			controls := strings.Join(feature.Controls(info.Framework), ", ")
 			if controls == "" {
 				controls = "None"
 			}
@@ -28,8 +28,10 @@ import (
 
 	"github.com/grctool/grctool/internal/auth"
 	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/frameworks"
 	"github.com/grctool/grctool/internal/logger"
 	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/telemetry"
 	"github.com/grctool/grctool/internal/transport"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -50,6 +52,7 @@ func NewGitHubWorkflowAnalyzer(cfg *config.Config, log logger.Logger) Tool {
 	// Create HTTP transport with logging
 	httpTransport := http.DefaultTransport
 	httpTransport = transport.NewLoggingTransport(httpTransport, log.WithComponent("github-workflow-api"))
+	httpTransport = telemetry.Transport(httpTransport)
 
 	// Set up cache directory
 	cacheDir := filepath.Join(cfg.Storage.DataDir, "github_cache", "workflows")
@@ -125,6 +128,12 @@ func (gwa *GitHubWorkflowAnalyzer) GetClaudeToolDefinition() models.ClaudeTool {
 					"description": "Use cached results when available",
 					"default":     true,
 				},
+				"framework": map[string]interface{}{
+					"type":        "string",
+					"description": "Framework of the workflow compliance rules: soc2, iso27001 (ISO 27001:2022 Annex A), nist80053 (NIST SP 800-53 Rev. 5), pcidss (PCI DSS v4.0) or hipaa (HIPAA Security Rule safeguards)",
+					"enum":        frameworks.Supported,
+					"default":     frameworks.SOC2,
+				},
 			},
 			"required": []string{},
 		},
@@ -134,7 +143,7 @@ func (gwa *GitHubWorkflowAnalyzer) GetClaudeToolDefinition() models.ClaudeTool {
 // Execute runs the GitHub workflow analysis
 func (gwa *GitHubWorkflowAnalyzer) Execute(ctx context.Context, params map[string]interface{}) (string, *models.EvidenceSource, error) {
 	startTime := time.Now()
-	correlationID := GenerateCorrelationID()
+	correlationID := CorrelationID(ctx)
 
 	gwa.logger.Debug("Executing GitHub workflow analyzer",
 		logger.String("correlation_id", correlationID),
@@ -180,12 +189,20 @@ func (gwa *GitHubWorkflowAnalyzer) Execute(ctx context.Context, params map[strin
 		useCache = uc
 	}
 
+	framework, _ := params["framework"].(string)
+	framework, err := frameworks.Normalize(framework)
+	if err != nil {
+		return "", nil, err
+	}
+
 	// Perform analysis
 	analysis, err := gwa.analyzeWorkflows(ctx, analysisType, includeContent, filterWorkflows, checkBranchProtection, useCache)
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to analyze workflows: %w", err)
 	}
 
+	applyWorkflowFramework(analysis, framework)
+
 	// Generate report
 	report := gwa.generateWorkflowReport(analysis, analysisType)
 
@@ -203,6 +220,7 @@ func (gwa *GitHubWorkflowAnalyzer) Execute(ctx context.Context, params map[strin
 		Metadata: map[string]interface{}{
 			"repository":       gwa.config.Repository,
 			"analysis_type":    analysisType,
+			"framework":        framework,
 			"workflow_count":   len(analysis.WorkflowFiles),
 			"security_scans":   len(analysis.SecurityScans),
 			"approval_rules":   len(analysis.ApprovalRules),
@@ -939,6 +957,37 @@ func (gwa *GitHubWorkflowAnalyzer) extractToolFromCommand(command string) string
 	return "custom"
 }
 
+// workflowRuleControls maps each workflow compliance rule to the control it
+// evidences in each framework
+var workflowRuleControls = map[string]map[string]string{
+	"SEC-001": {
+		frameworks.SOC2: "CC6.1", frameworks.ISO27001: "A.8.29", frameworks.NIST80053: "RA-5",
+		frameworks.PCIDSS: "6.3.1", frameworks.HIPAA: "164.308(a)(1)(ii)(A)",
+	},
+	"APP-001": {
+		frameworks.SOC2: "CC6.2", frameworks.ISO27001: "A.8.32", frameworks.NIST80053: "CM-3",
+		frameworks.PCIDSS: "6.5.1", frameworks.HIPAA: "164.312(c)(1)",
+	},
+}
+
+// applyWorkflowFramework points the workflow compliance rules at the controls
+// of the framework. Rules are built against SOC2, so only other frameworks
+// need rewriting.
+func applyWorkflowFramework(analysis *models.GitHubWorkflowAnalysis, framework string) {
+	if framework == frameworks.SOC2 {
+		return
+	}
+	for i := range analysis.WorkflowFiles {
+		rules := analysis.WorkflowFiles[i].ComplianceRules
+		for j := range rules {
+			if control, ok := workflowRuleControls[rules[j].RuleID][framework]; ok {
+				rules[j].Framework = frameworks.Label(framework)
+				rules[j].ControlID = control
+			}
+		}
+	}
+}
+
 func (gwa *GitHubWorkflowAnalyzer) analyzeWorkflowCompliance(workflow *models.GitHubWorkflowFile) []models.GitHubComplianceRule {
 	var rules []models.GitHubComplianceRule
 
@@ -950,8 +999,8 @@ func (gwa *GitHubWorkflowAnalyzer) analyzeWorkflowCompliance(workflow *models.Gi
 		Description: "Workflows should include security scanning steps",
 		Status:      gwa.boolToStatus(hasSecurityScanning),
 		Evidence:    fmt.Sprintf("Found %d security steps", len(workflow.SecuritySteps)),
-		Framework:   "SOC2",
-		ControlID:   "CC6.1",
+		Framework:   frameworks.Label(frameworks.SOC2),
+		ControlID:   workflowRuleControls["SEC-001"][frameworks.SOC2],
 	})
 
 	// Check for approval steps
@@ -962,8 +1011,8 @@ func (gwa *GitHubWorkflowAnalyzer) analyzeWorkflowCompliance(workflow *models.Gi
 		Description: "Deployment workflows should require approval",
 		Status:      gwa.boolToStatus(hasApprovalSteps),
 		Evidence:    fmt.Sprintf("Found %d approval steps", len(workflow.ApprovalSteps)),
-		Framework:   "SOC2",
-		ControlID:   "CC6.2",
+		Framework:   frameworks.Label(frameworks.SOC2),
+		ControlID:   workflowRuleControls["APP-001"][frameworks.SOC2],
 	})
 
 	return rules
@@ -1128,12 +1177,15 @@ func (gwa *GitHubWorkflowAnalyzer) generateWorkflowReport(analysis *models.GitHu
 			// Compliance status
 			if len(workflow.ComplianceRules) > 0 {
 				passed := 0
+				var results []string
 				for _, rule := range workflow.ComplianceRules {
 					if rule.Status == "pass" {
 						passed++
 					}
+					results = append(results, fmt.Sprintf("%s %s %s", rule.Framework, rule.ControlID, rule.Status))
 				}
-				report.WriteString(fmt.Sprintf("- **Compliance**: %d/%d rules passed\n", passed, len(workflow.ComplianceRules)))
+				report.WriteString(fmt.Sprintf("- **Compliance**: %d/%d rules passed (%s)\n",
+					passed, len(workflow.ComplianceRules), strings.Join(results, ", ")))
 			}
 
 			report.WriteString(fmt.Sprintf("- **URL**: %s\n\n", workflow.HTMLURL))
@@ -20,7 +20,6 @@ import (
 	"fmt"
 	"net/http"
 	"os"
-	"path/filepath"
 	"sort"
 	"strings"
 	"time"
@@ -28,7 +27,8 @@ import (
 	"github.com/grctool/grctool/internal/config"
 	"github.com/grctool/grctool/internal/logger"
 	"github.com/grctool/grctool/internal/models"
-	"golang.org/x/oauth2/google"
+	admin "google.golang.org/api/admin/directory/v1"
+	reports "google.golang.org/api/admin/reports/v1"
 	"google.golang.org/api/docs/v1"
 	"google.golang.org/api/drive/v3"
 	"google.golang.org/api/forms/v1"
@@ -57,7 +57,7 @@ func (gwt *GoogleWorkspaceTool) Name() string {
 
 // Description returns the tool description
 func (gwt *GoogleWorkspaceTool) Description() string {
-	return "Extract evidence from Google Workspace documents including Drive, Docs, Sheets, and Forms"
+	return "Extract evidence from Google Workspace documents including Drive, Docs, Sheets, and Forms, audit Drive sharing settings, export directory users with 2-Step Verification status, and sample login and admin audit activity"
 }
 
 // GetClaudeToolDefinition returns the tool definition for Claude
@@ -70,12 +70,12 @@ func (gwt *GoogleWorkspaceTool) GetClaudeToolDefinition() models.ClaudeTool {
 			"properties": map[string]interface{}{
 				"document_id": map[string]interface{}{
 					"type":        "string",
-					"description": "Google document ID (from URL or share link)",
+					"description": "Google document ID (from URL or share link). For sharing: folder or shared drive ID. For directory: customer ID (my_customer) or domain. For activity: user email or 'all'",
 				},
 				"document_type": map[string]interface{}{
 					"type":        "string",
-					"description": "Type of Google document: drive, docs, sheets, forms",
-					"enum":        []string{"drive", "docs", "sheets", "forms"},
+					"description": "Type of Google document: drive, docs, sheets, forms, sharing, directory, activity",
+					"enum":        []string{"drive", "docs", "sheets", "forms", "sharing", "directory", "activity"},
 					"default":     "drive",
 				},
 				"extraction_rules": map[string]interface{}{
@@ -107,11 +107,46 @@ func (gwt *GoogleWorkspaceTool) GetClaudeToolDefinition() models.ClaudeTool {
 							"maximum":     100,
 							"default":     20,
 						},
+						"internal_domains": map[string]interface{}{
+							"type":        "array",
+							"description": "For sharing: domains treated as internal (defaults to the folder owner's domain, or the admin_email domain for shared drives)",
+							"items":       map[string]interface{}{"type": "string"},
+						},
+						"recursive": map[string]interface{}{
+							"type":        "boolean",
+							"description": "For sharing: audit subfolders as well",
+							"default":     false,
+						},
+						"window": map[string]interface{}{
+							"type":        "string",
+							"description": "For activity: evidence window to sample (e.g., '2025', '2025-Q3', '2025-07')",
+						},
+						"start_time": map[string]interface{}{
+							"type":        "string",
+							"description": "For activity: start of the sampling range (RFC3339 or YYYY-MM-DD), ignored when window is set",
+						},
+						"end_time": map[string]interface{}{
+							"type":        "string",
+							"description": "For activity: end of the sampling range (RFC3339 or YYYY-MM-DD), ignored when window is set",
+						},
+						"applications": map[string]interface{}{
+							"type":        "array",
+							"description": "For activity: Reports API applications to sample (default: login, admin)",
+							"items":       map[string]interface{}{"type": "string"},
+						},
 					},
 				},
 				"credentials_path": map[string]interface{}{
 					"type":        "string",
-					"description": "Path to Google service account credentials JSON file",
+					"description": "Path to Google credentials JSON file (service account key or workload identity federation config). Defaults to Application Default Credentials",
+				},
+				"impersonate_service_account": map[string]interface{}{
+					"type":        "string",
+					"description": "Service account to impersonate keylessly via the IAM Credentials API (base credentials from Application Default Credentials or credentials_path)",
+				},
+				"admin_email": map[string]interface{}{
+					"type":        "string",
+					"description": "Workspace admin to impersonate via domain-wide delegation (required for directory and activity; for sharing, its domain is treated as internal on shared drives)",
 				},
 			},
 			"required": []string{"document_id"},
@@ -157,37 +192,56 @@ func (gwt *GoogleWorkspaceTool) Execute(ctx context.Context, params map[string]i
 		if maxResults, ok := rules["max_results"].(int); ok {
 			extractionRules.MaxResults = maxResults
 		}
+		extractionRules.InternalDomains = stringSliceParam(rules["internal_domains"])
+		if recursive, ok := rules["recursive"].(bool); ok {
+			extractionRules.Recursive = recursive
+		}
+		if window, ok := rules["window"].(string); ok {
+			extractionRules.Window = window
+		}
+		if startTime, ok := rules["start_time"].(string); ok {
+			extractionRules.StartTime = startTime
+		}
+		if endTime, ok := rules["end_time"].(string); ok {
+			extractionRules.EndTime = endTime
+		}
+		extractionRules.Applications = stringSliceParam(rules["applications"])
 	}
 
-	// Get credentials path
-	credentialsPath := ""
-	if cp, ok := params["credentials_path"].(string); ok && cp != "" {
-		credentialsPath = cp
-	} else {
-		// Try common locations for service account credentials
-		commonPaths := []string{
-			"google-credentials.json",
-			"service-account.json",
-			filepath.Join(os.Getenv("HOME"), ".config", "gcloud", "application_default_credentials.json"),
-			os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"),
-		}
-
-		for _, path := range commonPaths {
-			if path != "" {
-				if _, err := os.Stat(path); err == nil {
-					credentialsPath = path
-					break
-				}
-			}
-		}
+	adminEmail := ""
+	if ae, ok := params["admin_email"].(string); ok {
+		adminEmail = ae
 	}
 
-	if credentialsPath == "" {
-		return "", nil, fmt.Errorf("google credentials not found. Set credentials_path parameter or GOOGLE_APPLICATION_CREDENTIALS environment variable")
+	auth := googleAuthOptions{CredentialsPath: gwt.resolveCredentialsPath(params)}
+	if auth.CredentialsPath == "" && gwt.config != nil {
+		auth.CredentialsJSON = []byte(gwt.config.Evidence.Tools.GoogleDocs.CredentialsJSON)
+	}
+	if sa, ok := params["impersonate_service_account"].(string); ok && sa != "" {
+		auth.ImpersonateServiceAccount = sa
+	} else if gwt.config != nil {
+		auth.ImpersonateServiceAccount = gwt.config.Evidence.Tools.GoogleDocs.ImpersonateServiceAccount
 	}
 
 	// Initialize Google API client
-	client, err := gwt.initializeGoogleClient(ctx, credentialsPath)
+	var client *http.Client
+	var err error
+	// Admin SDK APIs require impersonating an admin; only request the scopes
+	// granted for that document type so partial delegation still works
+	switch documentType {
+	case "directory", "activity":
+		if adminEmail == "" {
+			return "", nil, fmt.Errorf("admin_email parameter is required for %s exports", documentType)
+		}
+		auth.Subject = adminEmail
+		scopes := []string{admin.AdminDirectoryUserReadonlyScope, admin.AdminDirectoryRolemanagementReadonlyScope}
+		if documentType == "activity" {
+			scopes = []string{reports.AdminReportsAuditReadonlyScope}
+		}
+		client, err = gwt.newGoogleHTTPClient(ctx, auth, scopes...)
+	default:
+		client, err = gwt.newGoogleHTTPClient(ctx, auth, googleDocumentScopes...)
+	}
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to initialize Google client: %w", err)
 	}
@@ -203,6 +257,12 @@ func (gwt *GoogleWorkspaceTool) Execute(ctx context.Context, params map[string]i
 		result, err = gwt.extractFromSheets(ctx, client, documentID, extractionRules)
 	case "forms":
 		result, err = gwt.extractFromForms(ctx, client, documentID, extractionRules)
+	case "sharing":
+		result, err = gwt.extractSharingAudit(ctx, client, documentID, extractionRules, adminEmail)
+	case "directory":
+		result, err = gwt.extractFromDirectory(ctx, client, documentID, extractionRules)
+	case "activity":
+		result, err = gwt.extractActivity(ctx, client, documentID, extractionRules)
 	default:
 		return "", nil, fmt.Errorf("unsupported document type: %s", documentType)
 	}
@@ -239,30 +299,45 @@ func (gwt *GoogleWorkspaceTool) Execute(ctx context.Context, params map[string]i
 	return report, source, nil
 }
 
-// initializeGoogleClient initializes the Google API client with service account credentials
-func (gwt *GoogleWorkspaceTool) initializeGoogleClient(ctx context.Context, credentialsPath string) (*http.Client, error) {
-	gwt.logger.Debug("Initializing Google client", logger.String("credentials_path", credentialsPath))
+// googleDocumentScopes are the read-only scopes used for Drive, Docs, Sheets and Forms
+var googleDocumentScopes = []string{
+	drive.DriveReadonlyScope,
+	docs.DocumentsReadonlyScope,
+	sheets.SpreadsheetsReadonlyScope,
+	forms.FormsResponsesReadonlyScope,
+}
 
-	// Read service account key file
-	credentialsData, err := os.ReadFile(credentialsPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read credentials file: %w", err)
+// resolveCredentialsPath finds a credentials file from the credentials_path parameter,
+// the configured credentials file, or common key file locations. An empty result
+// means the key stored in the OS keychain or Application Default Credentials
+// should be used.
+func (gwt *GoogleWorkspaceTool) resolveCredentialsPath(params map[string]interface{}) string {
+	if cp, ok := params["credentials_path"].(string); ok && cp != "" {
+		return cp
 	}
 
-	// Create OAuth2 config for service account
-	config, err := google.JWTConfigFromJSON(credentialsData,
-		drive.DriveReadonlyScope,
-		docs.DocumentsReadonlyScope,
-		sheets.SpreadsheetsReadonlyScope,
-		forms.FormsResponsesReadonlyScope,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create JWT config: %w", err)
+	if gwt.config != nil {
+		googleCfg := gwt.config.Evidence.Tools.GoogleDocs
+		if googleCfg.UseDefaultCredentials {
+			return ""
+		}
+		if googleCfg.CredentialsFile != "" {
+			return googleCfg.CredentialsFile
+		}
+		if googleCfg.CredentialsJSON != "" {
+			return ""
+		}
 	}
 
-	// Create HTTP client
-	client := config.Client(ctx)
-	return client, nil
+	// Try common locations for service account credentials; GOOGLE_APPLICATION_CREDENTIALS
+	// and the gcloud well-known file are picked up by Application Default Credentials
+	for _, path := range []string{"google-credentials.json", "service-account.json"} {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+
+	return ""
 }
 
 // extractFromDrive extracts content from Google Drive (file or folder)
@@ -574,6 +649,23 @@ func (gwt *GoogleWorkspaceTool) extractFileContent(ctx context.Context, client *
 
 // Helper functions
 
+// stringSliceParam converts a string list parameter from flags ([]string) or JSON ([]interface{})
+func stringSliceParam(value interface{}) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		var values []string
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
 func (gwt *GoogleWorkspaceTool) getMimeTypeCategory(mimeType string) string {
 	switch {
 	case strings.Contains(mimeType, "folder"):
@@ -660,6 +752,8 @@ func (gwt *GoogleWorkspaceTool) calculateRelevance(result *GoogleWorkspaceResult
 		relevance += 0.1 // Structured data often more relevant
 	case "forms":
 		relevance += 0.15 // Forms responses are usually highly relevant
+	case "sharing", "directory", "activity":
+		relevance += 0.2 // Sharing audits and account inventories directly support access reviews
 	}
 
 	// Increase relevance if it's a folder with multiple items
@@ -725,6 +819,76 @@ func (gwt *GoogleWorkspaceTool) generateReport(result *GoogleWorkspaceResult, do
 		}
 	}
 
+	// Sharing audit (if applicable)
+	if len(result.SharedFiles) > 0 {
+		report.WriteString("## Sharing Audit\n\n")
+		report.WriteString("| Path | Link Visibility | External | Owners | Grantees |\n")
+		report.WriteString("|------|-----------------|----------|--------|----------|\n")
+		for _, file := range result.SharedFiles {
+			var grantees []string
+			for _, perm := range file.Permissions {
+				if perm.Role == "owner" {
+					continue
+				}
+				grantee := perm.Email
+				if grantee == "" {
+					grantee = perm.Domain
+				}
+				if grantee == "" {
+					grantee = perm.Type
+				}
+				grantees = append(grantees, fmt.Sprintf("%s (%s)", grantee, perm.Role))
+			}
+			report.WriteString(fmt.Sprintf("| %s | %s | %t | %s | %s |\n",
+				file.Path, file.LinkVisibility, file.ExternalSharing,
+				strings.Join(file.Owners, ", "), strings.Join(grantees, ", ")))
+		}
+		report.WriteString("\n")
+	}
+
+	// Directory users (if applicable)
+	if len(result.DirectoryUsers) > 0 {
+		report.WriteString("## Directory Users\n\n")
+		report.WriteString("| Email | Status | 2SV Enrolled | 2SV Enforced | Admin Roles | Last Login |\n")
+		report.WriteString("|-------|--------|--------------|--------------|-------------|------------|\n")
+		for _, user := range result.DirectoryUsers {
+			status := "active"
+			if user.Suspended {
+				status = "suspended"
+			} else if user.Archived {
+				status = "archived"
+			}
+			roles := strings.Join(user.AdminRoles, ", ")
+			if roles == "" && user.IsAdmin {
+				roles = "Super Admin"
+			}
+			lastLogin := "never"
+			if !user.LastLoginAt.IsZero() {
+				lastLogin = user.LastLoginAt.Format("2006-01-02")
+			}
+			report.WriteString(fmt.Sprintf("| %s | %s | %t | %t | %s | %s |\n",
+				user.Email, status, user.TwoStepEnrolled, user.TwoStepEnforced, roles, lastLogin))
+		}
+		report.WriteString("\n")
+	}
+
+	// Audit activity sample (if applicable)
+	if result.ActivityWindow != nil {
+		report.WriteString("## Audit Activity Sample\n\n")
+		report.WriteString(fmt.Sprintf("- **Window Start**: %s\n", result.ActivityWindow.Start.Format(time.RFC3339)))
+		report.WriteString(fmt.Sprintf("- **Window End**: %s\n", result.ActivityWindow.End.Format(time.RFC3339)))
+		report.WriteString(fmt.Sprintf("- **Sampled Events**: %d\n\n", len(result.AuditEvents)))
+		if len(result.AuditEvents) > 0 {
+			report.WriteString("| Time | Application | Actor | Event | IP Address |\n")
+			report.WriteString("|------|-------------|-------|-------|------------|\n")
+			for _, event := range result.AuditEvents {
+				report.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
+					event.Time.Format("2006-01-02 15:04:05"), event.Application, event.Actor, event.EventName, event.IPAddress))
+			}
+			report.WriteString("\n")
+		}
+	}
+
 	// Sheet data summary (if applicable)
 	if len(result.SheetData) > 0 {
 		report.WriteString("## Sheet Data Summary\n\n")
@@ -761,11 +925,17 @@ func (gwt *GoogleWorkspaceTool) generateReport(result *GoogleWorkspaceResult, do
 
 // ExtractionRules defines rules for extracting content from Google Workspace
 type ExtractionRules struct {
-	IncludeMetadata  bool   `json:"include_metadata"`
-	IncludeRevisions bool   `json:"include_revisions"`
-	SheetRange       string `json:"sheet_range,omitempty"`
-	SearchQuery      string `json:"search_query,omitempty"`
-	MaxResults       int    `json:"max_results"`
+	IncludeMetadata  bool     `json:"include_metadata"`
+	IncludeRevisions bool     `json:"include_revisions"`
+	SheetRange       string   `json:"sheet_range,omitempty"`
+	SearchQuery      string   `json:"search_query,omitempty"`
+	MaxResults       int      `json:"max_results"`
+	InternalDomains  []string `json:"internal_domains,omitempty"`
+	Recursive        bool     `json:"recursive,omitempty"`
+	Window           string   `json:"window,omitempty"`
+	StartTime        string   `json:"start_time,omitempty"`
+	EndTime          string   `json:"end_time,omitempty"`
+	Applications     []string `json:"applications,omitempty"`
 }
 
 // GoogleWorkspaceResult represents the extracted data from Google Workspace
@@ -781,6 +951,10 @@ type GoogleWorkspaceResult struct {
 	Content        string                 `json:"content"`
 	SheetData      [][]interface{}        `json:"sheet_data,omitempty"`
 	FolderContents []FolderItem           `json:"folder_contents,omitempty"`
+	SharedFiles    []SharedFile           `json:"shared_files,omitempty"`
+	DirectoryUsers []DirectoryUser        `json:"directory_users,omitempty"`
+	AuditEvents    []AuditEvent           `json:"audit_events,omitempty"`
+	ActivityWindow *ActivityWindow        `json:"activity_window,omitempty"`
 	Metadata       map[string]interface{} `json:"metadata,omitempty"`
 }
 
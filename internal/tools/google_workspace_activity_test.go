@@ -0,0 +1,139 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	reports "google.golang.org/api/admin/reports/v1"
+)
+
+func TestResolveActivityRange(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 8, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		rules     ExtractionRules
+		wantStart time.Time
+		wantEnd   time.Time
+		wantErr   string
+	}{
+		"defaults to last 30 days": {
+			wantStart: now.Add(-30 * 24 * time.Hour),
+			wantEnd:   now,
+		},
+		"closed evidence window": {
+			rules:     ExtractionRules{Window: "2025-Q2"},
+			wantStart: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"current window is capped at now": {
+			rules:     ExtractionRules{Window: "2025-Q3"},
+			wantStart: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   now,
+		},
+		"explicit dates": {
+			rules:     ExtractionRules{StartTime: "2025-01-01", EndTime: "2025-02-01T00:00:00Z"},
+			wantStart: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"invalid window": {
+			rules:   ExtractionRules{Window: "Q3"},
+			wantErr: "invalid evidence window",
+		},
+		"invalid start": {
+			rules:   ExtractionRules{StartTime: "yesterday"},
+			wantErr: "invalid start_time",
+		},
+		"future window": {
+			rules:   ExtractionRules{Window: "2026"},
+			wantErr: "is not before end",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			start, end, err := resolveActivityRange(tt.rules, now)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantEnd, end)
+		})
+	}
+}
+
+func TestConvertActivity(t *testing.T) {
+	t.Parallel()
+
+	activity := &reports.Activity{
+		Id:        &reports.ActivityId{Time: "2025-07-02T09:30:00Z", ApplicationName: "login"},
+		Actor:     &reports.ActivityActor{Email: "alice@example.com"},
+		IpAddress: "203.0.113.10",
+		Events: []*reports.ActivityEvents{
+			{Type: "login", Name: "login_success"},
+			nil,
+			{Type: "login", Name: "login_verification"},
+		},
+	}
+
+	events := convertActivity(activity, "login")
+
+	require.Len(t, events, 2)
+	assert.Equal(t, "alice@example.com", events[0].Actor)
+	assert.Equal(t, "login_success", events[0].EventName)
+	assert.Equal(t, "203.0.113.10", events[1].IPAddress)
+	assert.Equal(t, time.Date(2025, 7, 2, 9, 30, 0, 0, time.UTC), events[1].Time)
+
+	system := convertActivity(&reports.Activity{
+		Actor:  &reports.ActivityActor{CallerType: "KEY"},
+		Events: []*reports.ActivityEvents{{Name: "CHANGE_APPLICATION_SETTING"}},
+	}, "admin")
+	require.Len(t, system, 1)
+	assert.Equal(t, "KEY", system[0].Actor)
+
+	assert.Nil(t, convertActivity(nil, "login"))
+}
+
+func TestSummarizeActivityAndReport(t *testing.T) {
+	t.Parallel()
+	gwt := newTestGoogleWorkspaceTool(t)
+
+	events := []AuditEvent{
+		{Application: "login", Actor: "alice@example.com", EventName: "login_success"},
+		{Application: "login", Actor: "alice@example.com", EventName: "login_success"},
+		{Application: "login", Actor: "bob@example.com", EventName: "login_failure"},
+	}
+
+	sample := summarizeActivity("login", events)
+	assert.Equal(t, 3, sample.SampledEvents)
+	assert.Equal(t, 2, sample.UniqueActors)
+	assert.Equal(t, map[string]int{"login_success": 2, "login_failure": 1}, sample.EventCounts)
+
+	sample.Truncated = true
+	start := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	result := &GoogleWorkspaceResult{
+		DocumentID:     "all",
+		DocumentType:   "activity",
+		Content:        formatActivityContent([]ActivitySample{sample}, start, end),
+		AuditEvents:    events,
+		ActivityWindow: &ActivityWindow{Start: start, End: end},
+	}
+
+	report := gwt.generateReport(result, "activity", ExtractionRules{})
+
+	assert.Contains(t, report, "## Audit Activity Sample")
+	assert.Contains(t, report, "- **Window Start**: 2025-04-01T00:00:00Z")
+	assert.Contains(t, report, "- **Sampled Events**: 3")
+	assert.Contains(t, report, "Sampled events: 3 (more available)")
+	assert.Contains(t, report, "login_failure: 1")
+}
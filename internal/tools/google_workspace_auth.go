@@ -0,0 +1,125 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/grctool/grctool/internal/logger"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/impersonate"
+	"google.golang.org/api/option"
+)
+
+// googleAuthOptions describes how the Google Workspace tool obtains credentials.
+// With no credentials path, Application Default Credentials are used, which
+// covers gcloud user credentials, workload identity federation configs referenced
+// by GOOGLE_APPLICATION_CREDENTIALS, and the GCE/GKE metadata server.
+// CredentialsJSON is a key held in the OS keychain, used in place of a file.
+type googleAuthOptions struct {
+	CredentialsPath           string
+	CredentialsJSON           []byte
+	Subject                   string
+	ImpersonateServiceAccount string
+}
+
+// credentialsFileType returns the "type" field of a Google credentials JSON file
+func credentialsFileType(data []byte) string {
+	var f struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &f); err != nil {
+		return ""
+	}
+	return f.Type
+}
+
+// newGoogleHTTPClient builds an authenticated HTTP client for the given scopes.
+// Domain-wide delegation (a non-empty Subject) works with a service account key
+// or, keylessly, by impersonating a service account through the IAM Credentials API.
+func (gwt *GoogleWorkspaceTool) newGoogleHTTPClient(ctx context.Context, auth googleAuthOptions, scopes ...string) (*http.Client, error) {
+	gwt.logger.Debug("Initializing Google client",
+		logger.String("credentials_path", auth.CredentialsPath),
+		logger.String("subject", auth.Subject),
+		logger.String("impersonate_service_account", auth.ImpersonateServiceAccount))
+
+	if auth.ImpersonateServiceAccount != "" {
+		var opts []option.ClientOption
+		if auth.CredentialsPath != "" {
+			opts = append(opts, option.WithCredentialsFile(auth.CredentialsPath))
+		} else if len(auth.CredentialsJSON) > 0 {
+			opts = append(opts, option.WithCredentialsJSON(auth.CredentialsJSON))
+		}
+		ts, err := impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
+			TargetPrincipal: auth.ImpersonateServiceAccount,
+			Scopes:          scopes,
+			Subject:         auth.Subject,
+		}, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to impersonate service account %s: %w", auth.ImpersonateServiceAccount, err)
+		}
+		return oauth2.NewClient(ctx, ts), nil
+	}
+
+	params := google.CredentialsParams{Scopes: scopes, Subject: auth.Subject}
+
+	if auth.CredentialsPath == "" && len(auth.CredentialsJSON) == 0 {
+		creds, err := google.FindDefaultCredentialsWithParams(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("google credentials not found. Set credentials_path, GOOGLE_APPLICATION_CREDENTIALS, or configure Application Default Credentials: %w", err)
+		}
+		if auth.Subject != "" && credentialsFileType(creds.JSON) != "service_account" {
+			return nil, fmt.Errorf("impersonating %s requires a service account key or impersonate_service_account", auth.Subject)
+		}
+		gwt.logger.Debug("Using Application Default Credentials")
+		return oauth2.NewClient(ctx, creds.TokenSource), nil
+	}
+
+	// Read credentials file
+	credentialsData := auth.CredentialsJSON
+	if auth.CredentialsPath != "" {
+		var err error
+		if credentialsData, err = os.ReadFile(auth.CredentialsPath); err != nil {
+			return nil, fmt.Errorf("failed to read credentials file: %w", err)
+		}
+	}
+
+	switch credentialsFileType(credentialsData) {
+	case "external_account", "authorized_user", "impersonated_service_account", "external_account_authorized_user":
+		if auth.Subject != "" {
+			return nil, fmt.Errorf("impersonating %s requires a service account key or impersonate_service_account", auth.Subject)
+		}
+		creds, err := google.CredentialsFromJSONWithParams(ctx, credentialsData, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load credentials: %w", err)
+		}
+		return oauth2.NewClient(ctx, creds.TokenSource), nil
+	}
+
+	// Create OAuth2 config for service account
+	config, err := google.JWTConfigFromJSON(credentialsData, scopes...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JWT config: %w", err)
+	}
+	config.Subject = auth.Subject
+
+	return config.Client(ctx), nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAuthorizedUserJSON = `{
+  "type": "authorized_user",
+  "client_id": "test-client.apps.googleusercontent.com",
+  "client_secret": "test-secret",
+  "refresh_token": "test-refresh-token"
+}`
+
+const testExternalAccountJSON = `{
+  "type": "external_account",
+  "audience": "//iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/ci/providers/github",
+  "subject_token_type": "urn:ietf:params:oauth:token-type:jwt",
+  "token_url": "https://sts.googleapis.com/v1/token",
+  "credential_source": {"file": "/var/run/ci/token"}
+}`
+
+func writeTestCredentials(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestCredentialsFileType(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "authorized_user", credentialsFileType([]byte(testAuthorizedUserJSON)))
+	assert.Equal(t, "external_account", credentialsFileType([]byte(testExternalAccountJSON)))
+	assert.Equal(t, "", credentialsFileType([]byte(`{invalid`)))
+	assert.Equal(t, "", credentialsFileType(nil))
+}
+
+func TestNewGoogleHTTPClient_CredentialFiles(t *testing.T) {
+	t.Parallel()
+	gwt := newTestGoogleWorkspaceTool(t)
+	ctx := context.Background()
+
+	t.Run("workload identity federation config", func(t *testing.T) {
+		path := writeTestCredentials(t, testExternalAccountJSON)
+		client, err := gwt.newGoogleHTTPClient(ctx, googleAuthOptions{CredentialsPath: path}, googleDocumentScopes...)
+		require.NoError(t, err)
+		assert.NotNil(t, client)
+	})
+
+	t.Run("user credentials", func(t *testing.T) {
+		path := writeTestCredentials(t, testAuthorizedUserJSON)
+		client, err := gwt.newGoogleHTTPClient(ctx, googleAuthOptions{CredentialsPath: path}, googleDocumentScopes...)
+		require.NoError(t, err)
+		assert.NotNil(t, client)
+	})
+
+	t.Run("domain-wide delegation needs a service account", func(t *testing.T) {
+		path := writeTestCredentials(t, testExternalAccountJSON)
+		_, err := gwt.newGoogleHTTPClient(ctx, googleAuthOptions{
+			CredentialsPath: path,
+			Subject:         "admin@example.com",
+		}, googleDocumentScopes...)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "impersonate_service_account")
+	})
+
+	t.Run("credentials from the keychain", func(t *testing.T) {
+		client, err := gwt.newGoogleHTTPClient(ctx, googleAuthOptions{CredentialsJSON: []byte(testAuthorizedUserJSON)}, googleDocumentScopes...)
+		require.NoError(t, err)
+		assert.NotNil(t, client)
+	})
+
+	t.Run("invalid key file", func(t *testing.T) {
+		path := writeTestCredentials(t, `{"invalid": "json structure"}`)
+		_, err := gwt.newGoogleHTTPClient(ctx, googleAuthOptions{CredentialsPath: path}, googleDocumentScopes...)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to create JWT config")
+	})
+}
+
+func TestNewGoogleHTTPClient_ApplicationDefaultCredentials(t *testing.T) {
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", writeTestCredentials(t, testAuthorizedUserJSON))
+	gwt := newTestGoogleWorkspaceTool(t)
+	ctx := context.Background()
+
+	client, err := gwt.newGoogleHTTPClient(ctx, googleAuthOptions{}, googleDocumentScopes...)
+	require.NoError(t, err)
+	assert.NotNil(t, client)
+
+	_, err = gwt.newGoogleHTTPClient(ctx, googleAuthOptions{Subject: "admin@example.com"}, googleDocumentScopes...)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires a service account key")
+}
+
+func TestResolveCredentialsPath(t *testing.T) {
+	t.Parallel()
+
+	newTool := func(googleDocs config.GoogleDocsToolConfig) *GoogleWorkspaceTool {
+		cfg := newTestConfig(t.TempDir())
+		cfg.Evidence.Tools.GoogleDocs = googleDocs
+		return NewGoogleWorkspaceTool(cfg, testhelpers.NewStubLogger()).(*GoogleWorkspaceTool)
+	}
+
+	explicit := map[string]interface{}{"credentials_path": "/explicit.json"}
+
+	assert.Equal(t, "/explicit.json", newTool(config.GoogleDocsToolConfig{CredentialsFile: "/configured.json"}).resolveCredentialsPath(explicit))
+	assert.Equal(t, "/configured.json", newTool(config.GoogleDocsToolConfig{CredentialsFile: "/configured.json"}).resolveCredentialsPath(nil))
+	assert.Equal(t, "", newTool(config.GoogleDocsToolConfig{CredentialsFile: "/configured.json", UseDefaultCredentials: true}).resolveCredentialsPath(nil))
+	assert.Equal(t, "", newTool(config.GoogleDocsToolConfig{CredentialsJSON: testAuthorizedUserJSON}).resolveCredentialsPath(nil))
+}
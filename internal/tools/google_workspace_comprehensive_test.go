@@ -65,7 +65,7 @@ func TestGoogleWorkspaceComprehensiveCoverage(t *testing.T) {
 
 	t.Run("ExtractFromDrive", func(t *testing.T) {
 		ctx := context.Background()
-		client, err := gwt.initializeGoogleClient(ctx, credentialsPath)
+		client, err := gwt.newGoogleHTTPClient(ctx, googleAuthOptions{CredentialsPath: credentialsPath}, googleDocumentScopes...)
 		require.NoError(t, err)
 
 		// Test extracting from a regular file
@@ -92,7 +92,7 @@ func TestGoogleWorkspaceComprehensiveCoverage(t *testing.T) {
 
 	t.Run("ExtractFromDocs", func(t *testing.T) {
 		ctx := context.Background()
-		client, err := gwt.initializeGoogleClient(ctx, credentialsPath)
+		client, err := gwt.newGoogleHTTPClient(ctx, googleAuthOptions{CredentialsPath: credentialsPath}, googleDocumentScopes...)
 		require.NoError(t, err)
 
 		rules := ExtractionRules{
@@ -116,7 +116,7 @@ func TestGoogleWorkspaceComprehensiveCoverage(t *testing.T) {
 
 	t.Run("ExtractFromSheets", func(t *testing.T) {
 		ctx := context.Background()
-		client, err := gwt.initializeGoogleClient(ctx, credentialsPath)
+		client, err := gwt.newGoogleHTTPClient(ctx, googleAuthOptions{CredentialsPath: credentialsPath}, googleDocumentScopes...)
 		require.NoError(t, err)
 
 		// Test with default range
@@ -147,7 +147,7 @@ func TestGoogleWorkspaceComprehensiveCoverage(t *testing.T) {
 
 	t.Run("ExtractFromForms", func(t *testing.T) {
 		ctx := context.Background()
-		client, err := gwt.initializeGoogleClient(ctx, credentialsPath)
+		client, err := gwt.newGoogleHTTPClient(ctx, googleAuthOptions{CredentialsPath: credentialsPath}, googleDocumentScopes...)
 		require.NoError(t, err)
 
 		rules := ExtractionRules{
@@ -0,0 +1,279 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/logger"
+	admin "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/option"
+)
+
+// defaultDirectoryCustomer is the Admin SDK alias for the customer that owns
+// the impersonated admin account.
+const defaultDirectoryCustomer = "my_customer"
+
+// DirectoryUser represents a Google Workspace user account as exported for
+// access review and MFA evidence.
+type DirectoryUser struct {
+	ID               string    `json:"id"`
+	Email            string    `json:"email"`
+	Name             string    `json:"name"`
+	OrgUnitPath      string    `json:"org_unit_path"`
+	Suspended        bool      `json:"suspended"`
+	SuspensionReason string    `json:"suspension_reason,omitempty"`
+	Archived         bool      `json:"archived"`
+	IsAdmin          bool      `json:"is_admin"`
+	IsDelegatedAdmin bool      `json:"is_delegated_admin"`
+	TwoStepEnrolled  bool      `json:"two_step_enrolled"`
+	TwoStepEnforced  bool      `json:"two_step_enforced"`
+	AdminRoles       []string  `json:"admin_roles,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+	LastLoginAt      time.Time `json:"last_login_at"`
+}
+
+// DirectorySummary aggregates directory user counts for reporting
+type DirectorySummary struct {
+	TotalUsers       int     `json:"total_users"`
+	ActiveUsers      int     `json:"active_users"`
+	SuspendedUsers   int     `json:"suspended_users"`
+	AdminUsers       int     `json:"admin_users"`
+	TwoStepEnrolled  int     `json:"two_step_enrolled"`
+	TwoStepEnforced  int     `json:"two_step_enforced"`
+	ActiveWithout2SV int     `json:"active_without_2sv"`
+	EnrollmentRate   float64 `json:"enrollment_rate"`
+}
+
+// extractFromDirectory exports users, suspension status, 2-Step Verification
+// enrollment and admin role assignments using the Admin SDK Directory API.
+// The documentID is either a customer ID (e.g. "my_customer") or a domain name.
+func (gwt *GoogleWorkspaceTool) extractFromDirectory(ctx context.Context, client *http.Client, documentID string, rules ExtractionRules) (*GoogleWorkspaceResult, error) {
+	directoryService, err := admin.NewService(ctx, option.WithHTTPClient(client))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Directory service: %w", err)
+	}
+
+	customer, domain := directoryScope(documentID)
+
+	usersCall := directoryService.Users.List().MaxResults(500).OrderBy("email")
+	if domain != "" {
+		usersCall = usersCall.Domain(domain)
+	} else {
+		usersCall = usersCall.Customer(customer)
+	}
+
+	var adminUsers []*admin.User
+	if err := usersCall.Pages(ctx, func(page *admin.Users) error {
+		adminUsers = append(adminUsers, page.Users...)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list directory users: %w", err)
+	}
+
+	// Role lookups require a customer ID; domains resolve to the admin's customer
+	roleNames := make(map[int64]string)
+	if err := directoryService.Roles.List(customer).Pages(ctx, func(page *admin.Roles) error {
+		for _, role := range page.Items {
+			roleNames[role.RoleId] = role.RoleName
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list admin roles: %w", err)
+	}
+
+	var assignments []*admin.RoleAssignment
+	if err := directoryService.RoleAssignments.List(customer).Pages(ctx, func(page *admin.RoleAssignments) error {
+		assignments = append(assignments, page.Items...)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list admin role assignments: %w", err)
+	}
+
+	users := gwt.convertDirectoryUsers(adminUsers, assignments, roleNames)
+	summary := summarizeDirectoryUsers(users)
+
+	gwt.logger.Debug("Exported directory users",
+		logger.String("customer", customer),
+		logger.String("domain", domain),
+		logger.Int("users", summary.TotalUsers),
+		logger.Int("role_assignments", len(assignments)))
+
+	result := &GoogleWorkspaceResult{
+		DocumentID:     documentID,
+		DocumentName:   directoryDisplayName(customer, domain),
+		DocumentType:   "directory",
+		ModifiedAt:     time.Now(),
+		Content:        formatDirectoryContent(users, summary),
+		DirectoryUsers: users,
+	}
+
+	if rules.IncludeMetadata {
+		result.Metadata = map[string]interface{}{
+			"total_users":        summary.TotalUsers,
+			"active_users":       summary.ActiveUsers,
+			"suspended_users":    summary.SuspendedUsers,
+			"admin_users":        summary.AdminUsers,
+			"two_step_enrolled":  summary.TwoStepEnrolled,
+			"two_step_enforced":  summary.TwoStepEnforced,
+			"active_without_2sv": summary.ActiveWithout2SV,
+			"enrollment_rate":    fmt.Sprintf("%.1f%%", summary.EnrollmentRate*100),
+			"role_assignments":   len(assignments),
+		}
+	}
+
+	return result, nil
+}
+
+// convertDirectoryUsers maps Admin SDK users to DirectoryUser records and
+// attaches the names of any admin roles assigned to each user
+func (gwt *GoogleWorkspaceTool) convertDirectoryUsers(adminUsers []*admin.User, assignments []*admin.RoleAssignment, roleNames map[int64]string) []DirectoryUser {
+	rolesByUser := make(map[string][]string)
+	for _, assignment := range assignments {
+		if assignment == nil || assignment.AssignedTo == "" {
+			continue
+		}
+		name := roleNames[assignment.RoleId]
+		if name == "" {
+			name = fmt.Sprintf("role-%d", assignment.RoleId)
+		}
+		rolesByUser[assignment.AssignedTo] = append(rolesByUser[assignment.AssignedTo], name)
+	}
+
+	users := make([]DirectoryUser, 0, len(adminUsers))
+	for _, u := range adminUsers {
+		if u == nil {
+			continue
+		}
+
+		user := DirectoryUser{
+			ID:               u.Id,
+			Email:            u.PrimaryEmail,
+			OrgUnitPath:      u.OrgUnitPath,
+			Suspended:        u.Suspended,
+			SuspensionReason: u.SuspensionReason,
+			Archived:         u.Archived,
+			IsAdmin:          u.IsAdmin,
+			IsDelegatedAdmin: u.IsDelegatedAdmin,
+			TwoStepEnrolled:  u.IsEnrolledIn2Sv,
+			TwoStepEnforced:  u.IsEnforcedIn2Sv,
+			AdminRoles:       rolesByUser[u.Id],
+			CreatedAt:        gwt.parseGoogleTime(u.CreationTime),
+			LastLoginAt:      gwt.parseGoogleTime(u.LastLoginTime),
+		}
+		if u.Name != nil {
+			user.Name = u.Name.FullName
+		}
+		sort.Strings(user.AdminRoles)
+
+		users = append(users, user)
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].Email < users[j].Email
+	})
+
+	return users
+}
+
+// summarizeDirectoryUsers computes the counts reported for access and MFA reviews
+func summarizeDirectoryUsers(users []DirectoryUser) DirectorySummary {
+	summary := DirectorySummary{TotalUsers: len(users)}
+
+	for _, user := range users {
+		if user.Suspended || user.Archived {
+			summary.SuspendedUsers++
+		} else {
+			summary.ActiveUsers++
+			if !user.TwoStepEnrolled {
+				summary.ActiveWithout2SV++
+			}
+		}
+		if user.IsAdmin || user.IsDelegatedAdmin || len(user.AdminRoles) > 0 {
+			summary.AdminUsers++
+		}
+		if user.TwoStepEnrolled {
+			summary.TwoStepEnrolled++
+		}
+		if user.TwoStepEnforced {
+			summary.TwoStepEnforced++
+		}
+	}
+
+	if summary.ActiveUsers > 0 {
+		summary.EnrollmentRate = float64(summary.ActiveUsers-summary.ActiveWithout2SV) / float64(summary.ActiveUsers)
+	}
+
+	return summary
+}
+
+// formatDirectoryContent renders the user export as plain text for the report body
+func formatDirectoryContent(users []DirectoryUser, summary DirectorySummary) string {
+	var content strings.Builder
+
+	content.WriteString(fmt.Sprintf("Users: %d (active: %d, suspended: %d)\n", summary.TotalUsers, summary.ActiveUsers, summary.SuspendedUsers))
+	content.WriteString(fmt.Sprintf("Admins: %d\n", summary.AdminUsers))
+	content.WriteString(fmt.Sprintf("2-Step Verification enrolled: %d, enforced: %d\n", summary.TwoStepEnrolled, summary.TwoStepEnforced))
+	content.WriteString(fmt.Sprintf("Active users without 2-Step Verification: %d\n\n", summary.ActiveWithout2SV))
+
+	for _, user := range users {
+		status := "active"
+		if user.Suspended {
+			status = "suspended"
+		} else if user.Archived {
+			status = "archived"
+		}
+
+		content.WriteString(fmt.Sprintf("- %s (%s)\n", user.Email, status))
+		content.WriteString(fmt.Sprintf("  2SV enrolled: %t, enforced: %t\n", user.TwoStepEnrolled, user.TwoStepEnforced))
+		if user.IsAdmin {
+			content.WriteString("  Super admin: true\n")
+		}
+		if len(user.AdminRoles) > 0 {
+			content.WriteString(fmt.Sprintf("  Admin roles: %s\n", strings.Join(user.AdminRoles, ", ")))
+		}
+		if !user.LastLoginAt.IsZero() {
+			content.WriteString(fmt.Sprintf("  Last login: %s\n", user.LastLoginAt.Format("2006-01-02 15:04:05")))
+		}
+	}
+
+	return content.String()
+}
+
+// directoryScope splits a document ID into the customer ID and, when the ID
+// looks like a domain name, the domain to filter users by
+func directoryScope(documentID string) (customer, domain string) {
+	documentID = strings.TrimSpace(documentID)
+	if documentID == "" {
+		return defaultDirectoryCustomer, ""
+	}
+	if strings.Contains(documentID, ".") {
+		return defaultDirectoryCustomer, documentID
+	}
+	return documentID, ""
+}
+
+func directoryDisplayName(customer, domain string) string {
+	if domain != "" {
+		return fmt.Sprintf("Directory (%s)", domain)
+	}
+	return fmt.Sprintf("Directory (%s)", customer)
+}
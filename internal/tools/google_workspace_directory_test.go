@@ -0,0 +1,152 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admin "google.golang.org/api/admin/directory/v1"
+)
+
+func newTestGoogleWorkspaceTool(t *testing.T) *GoogleWorkspaceTool {
+	t.Helper()
+	return NewGoogleWorkspaceTool(newTestConfig(t.TempDir()), testhelpers.NewStubLogger()).(*GoogleWorkspaceTool)
+}
+
+func TestDirectoryScope(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		customer string
+		domain   string
+	}{
+		{"", "my_customer", ""},
+		{"my_customer", "my_customer", ""},
+		{"C01abc23d", "C01abc23d", ""},
+		{"example.com", "my_customer", "example.com"},
+	}
+
+	for _, tt := range tests {
+		customer, domain := directoryScope(tt.input)
+		assert.Equal(t, tt.customer, customer, tt.input)
+		assert.Equal(t, tt.domain, domain, tt.input)
+	}
+}
+
+func TestConvertDirectoryUsers(t *testing.T) {
+	t.Parallel()
+	gwt := newTestGoogleWorkspaceTool(t)
+
+	adminUsers := []*admin.User{
+		{
+			Id:              "2",
+			PrimaryEmail:    "zoe@example.com",
+			Name:            &admin.UserName{FullName: "Zoe Admin"},
+			IsAdmin:         true,
+			IsEnrolledIn2Sv: true,
+			IsEnforcedIn2Sv: true,
+			LastLoginTime:   "2024-03-01T10:00:00.000Z",
+		},
+		{
+			Id:           "1",
+			PrimaryEmail: "alex@example.com",
+			Suspended:    true,
+		},
+		nil,
+	}
+	assignments := []*admin.RoleAssignment{
+		{AssignedTo: "2", RoleId: 11},
+		{AssignedTo: "2", RoleId: 99},
+		{AssignedTo: ""},
+	}
+	roleNames := map[int64]string{11: "_SEED_ADMIN_ROLE"}
+
+	users := gwt.convertDirectoryUsers(adminUsers, assignments, roleNames)
+
+	require.Len(t, users, 2)
+	assert.Equal(t, "alex@example.com", users[0].Email)
+	assert.True(t, users[0].Suspended)
+	assert.Empty(t, users[0].AdminRoles)
+
+	assert.Equal(t, "zoe@example.com", users[1].Email)
+	assert.Equal(t, "Zoe Admin", users[1].Name)
+	assert.Equal(t, []string{"_SEED_ADMIN_ROLE", "role-99"}, users[1].AdminRoles)
+	assert.Equal(t, 2024, users[1].LastLoginAt.Year())
+}
+
+func TestSummarizeDirectoryUsers(t *testing.T) {
+	t.Parallel()
+
+	users := []DirectoryUser{
+		{Email: "a@example.com", TwoStepEnrolled: true, TwoStepEnforced: true, IsAdmin: true},
+		{Email: "b@example.com", TwoStepEnrolled: true},
+		{Email: "c@example.com"},
+		{Email: "d@example.com", Suspended: true},
+		{Email: "e@example.com", AdminRoles: []string{"Help Desk Admin"}, TwoStepEnrolled: true},
+	}
+
+	summary := summarizeDirectoryUsers(users)
+
+	assert.Equal(t, 5, summary.TotalUsers)
+	assert.Equal(t, 4, summary.ActiveUsers)
+	assert.Equal(t, 1, summary.SuspendedUsers)
+	assert.Equal(t, 2, summary.AdminUsers)
+	assert.Equal(t, 3, summary.TwoStepEnrolled)
+	assert.Equal(t, 1, summary.TwoStepEnforced)
+	assert.Equal(t, 1, summary.ActiveWithout2SV)
+	assert.InDelta(t, 0.75, summary.EnrollmentRate, 0.001)
+
+	empty := summarizeDirectoryUsers(nil)
+	assert.Equal(t, 0, empty.TotalUsers)
+	assert.Equal(t, 0.0, empty.EnrollmentRate)
+}
+
+func TestGenerateReport_DirectoryUsers(t *testing.T) {
+	t.Parallel()
+	gwt := newTestGoogleWorkspaceTool(t)
+
+	users := []DirectoryUser{
+		{Email: "a@example.com", TwoStepEnrolled: true, IsAdmin: true, LastLoginAt: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{Email: "b@example.com", Suspended: true},
+	}
+	result := &GoogleWorkspaceResult{
+		DocumentID:     "my_customer",
+		DocumentName:   "Directory (my_customer)",
+		DocumentType:   "directory",
+		Content:        formatDirectoryContent(users, summarizeDirectoryUsers(users)),
+		DirectoryUsers: users,
+	}
+
+	report := gwt.generateReport(result, "directory", ExtractionRules{})
+
+	assert.Contains(t, report, "## Directory Users")
+	assert.Contains(t, report, "| a@example.com | active | true | false | Super Admin | 2024-05-01 |")
+	assert.Contains(t, report, "| b@example.com | suspended | false | false |  | never |")
+	assert.Contains(t, report, "Active users without 2-Step Verification: 0")
+}
+
+func TestGoogleWorkspaceExecute_DirectoryRequiresAdminEmail(t *testing.T) {
+	t.Parallel()
+	gwt := newTestGoogleWorkspaceTool(t)
+
+	credentialsPath := filepath.Join(t.TempDir(), "credentials.json")
+	require.NoError(t, os.WriteFile(credentialsPath, []byte("{}"), 0600))
+
+	_, _, err := gwt.Execute(context.Background(), map[string]interface{}{
+		"document_id":      "my_customer",
+		"document_type":    "directory",
+		"credentials_path": credentialsPath,
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "admin_email")
+}
@@ -0,0 +1,372 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/logger"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+// Link visibility levels reported by the sharing audit, from least to most exposed
+const (
+	LinkVisibilityRestricted     = "restricted"
+	LinkVisibilityDomainWithLink = "domain_with_link"
+	LinkVisibilityDomain         = "domain"
+	LinkVisibilityAnyoneWithLink = "anyone_with_link"
+	LinkVisibilityPublic         = "public"
+)
+
+// SharingPermission represents a single Drive permission on an audited file
+type SharingPermission struct {
+	Type               string `json:"type"`
+	Role               string `json:"role"`
+	Email              string `json:"email,omitempty"`
+	Domain             string `json:"domain,omitempty"`
+	DisplayName        string `json:"display_name,omitempty"`
+	AllowFileDiscovery bool   `json:"allow_file_discovery"`
+	External           bool   `json:"external"`
+}
+
+// SharedFile represents the sharing posture of a Drive file or folder
+type SharedFile struct {
+	ID              string              `json:"id"`
+	Name            string              `json:"name"`
+	Path            string              `json:"path"`
+	MimeType        string              `json:"mime_type"`
+	Owners          []string            `json:"owners,omitempty"`
+	WebViewLink     string              `json:"web_view_link,omitempty"`
+	LinkVisibility  string              `json:"link_visibility"`
+	ExternalSharing bool                `json:"external_sharing"`
+	Permissions     []SharingPermission `json:"permissions"`
+}
+
+// SharingSummary aggregates sharing audit counts for reporting
+type SharingSummary struct {
+	FilesAudited      int `json:"files_audited"`
+	ExternallyShared  int `json:"externally_shared"`
+	AnyoneWithLink    int `json:"anyone_with_link"`
+	Public            int `json:"public"`
+	DomainShared      int `json:"domain_shared"`
+	ExternalGrantees  int `json:"external_grantees"`
+	RestrictedToUsers int `json:"restricted_to_users"`
+}
+
+const sharingFileFields = "nextPageToken, files(id,name,mimeType,owners(displayName,emailAddress),webViewLink,permissions(type,role,emailAddress,domain,displayName,allowFileDiscovery))"
+
+// extractSharingAudit audits sharing settings of a Drive folder or shared drive
+// and everything inside it. Internal domains default to the owners' domains,
+// or the admin's domain for shared drives, when none are configured.
+func (gwt *GoogleWorkspaceTool) extractSharingAudit(ctx context.Context, client *http.Client, documentID string, rules ExtractionRules, adminEmail string) (*GoogleWorkspaceResult, error) {
+	driveService, err := drive.NewService(ctx, option.WithHTTPClient(client))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Drive service: %w", err)
+	}
+
+	root, err := driveService.Files.Get(documentID).
+		SupportsAllDrives(true).
+		Fields("id,name,mimeType,driveId,owners(displayName,emailAddress),webViewLink,permissions(type,role,emailAddress,domain,displayName,allowFileDiscovery)").
+		Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get folder information: %w", err)
+	}
+
+	internalDomains, err := resolveInternalDomains(rules.InternalDomains, root, adminEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []*drive.File{root}
+	paths := map[string]string{root.Id: root.Name}
+
+	// Breadth-first walk; subfolders are only descended into when requested
+	queue := []string{root.Id}
+	for len(queue) > 0 {
+		folderID := queue[0]
+		queue = queue[1:]
+
+		call := driveService.Files.List().
+			Q(fmt.Sprintf("'%s' in parents and trashed=false", folderID)).
+			SupportsAllDrives(true).
+			IncludeItemsFromAllDrives(true).
+			PageSize(100).
+			Fields(sharingFileFields)
+		if root.DriveId != "" {
+			call = call.Corpora("drive").DriveId(root.DriveId)
+		}
+
+		err := call.Pages(ctx, func(page *drive.FileList) error {
+			for _, file := range page.Files {
+				files = append(files, file)
+				paths[file.Id] = paths[folderID] + "/" + file.Name
+				if rules.Recursive && strings.HasPrefix(file.MimeType, "application/vnd.google-apps.folder") {
+					queue = append(queue, file.Id)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list folder contents: %w", err)
+		}
+	}
+
+	// Shared drive items do not return permissions from files.list
+	for _, file := range files {
+		if len(file.Permissions) > 0 || root.DriveId == "" {
+			continue
+		}
+		err := driveService.Permissions.List(file.Id).
+			SupportsAllDrives(true).
+			Fields("nextPageToken, permissions(type,role,emailAddress,domain,displayName,allowFileDiscovery)").
+			Pages(ctx, func(page *drive.PermissionList) error {
+				file.Permissions = append(file.Permissions, page.Permissions...)
+				return nil
+			})
+		if err != nil {
+			gwt.logger.Warn("Failed to list permissions",
+				logger.String("file_id", file.Id),
+				logger.Field{Key: "error", Value: err})
+		}
+	}
+
+	sharedFiles := make([]SharedFile, 0, len(files))
+	for _, file := range files {
+		sharedFiles = append(sharedFiles, auditFileSharing(file, paths[file.Id], internalDomains))
+	}
+	summary := summarizeSharing(sharedFiles)
+
+	gwt.logger.Debug("Completed sharing audit",
+		logger.String("folder_id", documentID),
+		logger.Int("files_audited", summary.FilesAudited),
+		logger.Int("externally_shared", summary.ExternallyShared))
+
+	result := &GoogleWorkspaceResult{
+		DocumentID:   documentID,
+		DocumentName: root.Name,
+		DocumentType: "sharing",
+		MimeType:     root.MimeType,
+		ModifiedAt:   time.Now(),
+		Content:      formatSharingContent(sharedFiles, summary, internalDomains),
+		SharedFiles:  sharedFiles,
+	}
+	if len(root.Owners) > 0 {
+		result.Owner = root.Owners[0].DisplayName
+	}
+
+	if rules.IncludeMetadata {
+		result.Metadata = map[string]interface{}{
+			"files_audited":     summary.FilesAudited,
+			"externally_shared": summary.ExternallyShared,
+			"anyone_with_link":  summary.AnyoneWithLink,
+			"public":            summary.Public,
+			"domain_shared":     summary.DomainShared,
+			"external_grantees": summary.ExternalGrantees,
+			"internal_domains":  strings.Join(internalDomains, ", "),
+			"shared_drive_id":   root.DriveId,
+			"recursive":         rules.Recursive,
+		}
+	}
+
+	return result, nil
+}
+
+// auditFileSharing classifies the permissions on a file against the internal domains
+func auditFileSharing(file *drive.File, path string, internalDomains []string) SharedFile {
+	shared := SharedFile{
+		ID:             file.Id,
+		Name:           file.Name,
+		Path:           path,
+		MimeType:       file.MimeType,
+		WebViewLink:    file.WebViewLink,
+		LinkVisibility: LinkVisibilityRestricted,
+	}
+
+	for _, owner := range file.Owners {
+		shared.Owners = append(shared.Owners, owner.EmailAddress)
+	}
+
+	for _, p := range file.Permissions {
+		if p == nil {
+			continue
+		}
+
+		perm := SharingPermission{
+			Type:               p.Type,
+			Role:               p.Role,
+			Email:              p.EmailAddress,
+			Domain:             p.Domain,
+			DisplayName:        p.DisplayName,
+			AllowFileDiscovery: p.AllowFileDiscovery,
+		}
+
+		switch p.Type {
+		case "anyone":
+			perm.External = true
+			visibility := LinkVisibilityAnyoneWithLink
+			if p.AllowFileDiscovery {
+				visibility = LinkVisibilityPublic
+			}
+			shared.LinkVisibility = moreExposed(shared.LinkVisibility, visibility)
+		case "domain":
+			perm.External = !isInternalDomain(p.Domain, internalDomains)
+			visibility := LinkVisibilityDomainWithLink
+			if p.AllowFileDiscovery {
+				visibility = LinkVisibilityDomain
+			}
+			shared.LinkVisibility = moreExposed(shared.LinkVisibility, visibility)
+		default:
+			perm.External = !isInternalDomain(emailDomain(p.EmailAddress), internalDomains)
+		}
+
+		if perm.External {
+			shared.ExternalSharing = true
+		}
+		shared.Permissions = append(shared.Permissions, perm)
+	}
+
+	return shared
+}
+
+// summarizeSharing computes the counts reported for data-access reviews
+func summarizeSharing(files []SharedFile) SharingSummary {
+	summary := SharingSummary{FilesAudited: len(files)}
+
+	for _, file := range files {
+		if file.ExternalSharing {
+			summary.ExternallyShared++
+		}
+		switch file.LinkVisibility {
+		case LinkVisibilityPublic:
+			summary.Public++
+		case LinkVisibilityAnyoneWithLink:
+			summary.AnyoneWithLink++
+		case LinkVisibilityDomain, LinkVisibilityDomainWithLink:
+			summary.DomainShared++
+		default:
+			summary.RestrictedToUsers++
+		}
+		for _, perm := range file.Permissions {
+			if perm.External && (perm.Type == "user" || perm.Type == "group") {
+				summary.ExternalGrantees++
+			}
+		}
+	}
+
+	return summary
+}
+
+// formatSharingContent renders the sharing audit as plain text for the report body
+func formatSharingContent(files []SharedFile, summary SharingSummary, internalDomains []string) string {
+	var content strings.Builder
+
+	content.WriteString(fmt.Sprintf("Internal domains: %s\n", strings.Join(internalDomains, ", ")))
+	content.WriteString(fmt.Sprintf("Files audited: %d\n", summary.FilesAudited))
+	content.WriteString(fmt.Sprintf("Externally shared: %d\n", summary.ExternallyShared))
+	content.WriteString(fmt.Sprintf("Public: %d, anyone with link: %d, domain: %d\n\n", summary.Public, summary.AnyoneWithLink, summary.DomainShared))
+
+	for _, file := range files {
+		if !file.ExternalSharing && file.LinkVisibility == LinkVisibilityRestricted {
+			continue
+		}
+		content.WriteString(fmt.Sprintf("- %s (%s)\n", file.Path, file.LinkVisibility))
+		for _, perm := range file.Permissions {
+			if !perm.External {
+				continue
+			}
+			grantee := perm.Email
+			if grantee == "" {
+				grantee = perm.Domain
+			}
+			if grantee == "" {
+				grantee = perm.Type
+			}
+			content.WriteString(fmt.Sprintf("  External %s: %s (%s)\n", perm.Type, grantee, perm.Role))
+		}
+	}
+
+	return content.String()
+}
+
+var linkVisibilityRank = map[string]int{
+	LinkVisibilityRestricted:     0,
+	LinkVisibilityDomainWithLink: 1,
+	LinkVisibilityDomain:         2,
+	LinkVisibilityAnyoneWithLink: 3,
+	LinkVisibilityPublic:         4,
+}
+
+func moreExposed(current, candidate string) string {
+	if linkVisibilityRank[candidate] > linkVisibilityRank[current] {
+		return candidate
+	}
+	return current
+}
+
+// resolveInternalDomains picks the domains treated as internal: the configured
+// list, else the root's owners' domains, else the admin's domain. Items on
+// shared drives have no owners, so without either hint every grantee would be
+// reported as external.
+func resolveInternalDomains(configured []string, root *drive.File, adminEmail string) ([]string, error) {
+	if len(configured) > 0 {
+		return configured, nil
+	}
+	if domains := ownerDomains(root.Owners); len(domains) > 0 {
+		return domains, nil
+	}
+	if domain := emailDomain(adminEmail); domain != "" {
+		return []string{domain}, nil
+	}
+	return nil, fmt.Errorf("cannot determine internal domains for %q (shared drive items have no owners): set extraction_rules.internal_domains or admin_email", root.Name)
+}
+
+func ownerDomains(owners []*drive.User) []string {
+	seen := make(map[string]bool)
+	var domains []string
+	for _, owner := range owners {
+		if domain := emailDomain(owner.EmailAddress); domain != "" && !seen[domain] {
+			seen[domain] = true
+			domains = append(domains, domain)
+		}
+	}
+	sort.Strings(domains)
+	return domains
+}
+
+func emailDomain(email string) string {
+	if idx := strings.LastIndex(email, "@"); idx >= 0 {
+		return strings.ToLower(email[idx+1:])
+	}
+	return ""
+}
+
+func isInternalDomain(domain string, internalDomains []string) bool {
+	if domain == "" {
+		return false
+	}
+	for _, internal := range internalDomains {
+		if strings.EqualFold(domain, internal) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,221 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/drive/v3"
+)
+
+func TestAuditFileSharing(t *testing.T) {
+	t.Parallel()
+
+	internal := []string{"example.com"}
+
+	tests := []struct {
+		name       string
+		perms      []*drive.Permission
+		visibility string
+		external   bool
+	}{
+		{
+			name: "internal users only",
+			perms: []*drive.Permission{
+				{Type: "user", Role: "owner", EmailAddress: "owner@example.com"},
+				{Type: "group", Role: "reader", EmailAddress: "security@EXAMPLE.com"},
+			},
+			visibility: LinkVisibilityRestricted,
+		},
+		{
+			name: "external user",
+			perms: []*drive.Permission{
+				{Type: "user", Role: "writer", EmailAddress: "auditor@partner.io"},
+			},
+			visibility: LinkVisibilityRestricted,
+			external:   true,
+		},
+		{
+			name: "internal domain link",
+			perms: []*drive.Permission{
+				{Type: "domain", Role: "reader", Domain: "example.com"},
+			},
+			visibility: LinkVisibilityDomainWithLink,
+		},
+		{
+			name: "anyone with link",
+			perms: []*drive.Permission{
+				{Type: "domain", Role: "reader", Domain: "example.com", AllowFileDiscovery: true},
+				{Type: "anyone", Role: "reader"},
+			},
+			visibility: LinkVisibilityAnyoneWithLink,
+			external:   true,
+		},
+		{
+			name: "public on the web",
+			perms: []*drive.Permission{
+				{Type: "anyone", Role: "reader", AllowFileDiscovery: true},
+			},
+			visibility: LinkVisibilityPublic,
+			external:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := &drive.File{
+				Id:          "file-1",
+				Name:        "Access Review.xlsx",
+				Owners:      []*drive.User{{EmailAddress: "owner@example.com"}},
+				Permissions: tt.perms,
+			}
+
+			shared := auditFileSharing(file, "Security/Access Review.xlsx", internal)
+
+			assert.Equal(t, tt.visibility, shared.LinkVisibility)
+			assert.Equal(t, tt.external, shared.ExternalSharing)
+			assert.Equal(t, []string{"owner@example.com"}, shared.Owners)
+			assert.Len(t, shared.Permissions, len(tt.perms))
+		})
+	}
+}
+
+func TestSummarizeSharing(t *testing.T) {
+	t.Parallel()
+
+	files := []SharedFile{
+		{LinkVisibility: LinkVisibilityRestricted},
+		{LinkVisibility: LinkVisibilityRestricted, ExternalSharing: true, Permissions: []SharingPermission{
+			{Type: "user", External: true},
+			{Type: "group", External: true},
+		}},
+		{LinkVisibility: LinkVisibilityDomain},
+		{LinkVisibility: LinkVisibilityAnyoneWithLink, ExternalSharing: true, Permissions: []SharingPermission{
+			{Type: "anyone", External: true},
+		}},
+		{LinkVisibility: LinkVisibilityPublic, ExternalSharing: true},
+	}
+
+	summary := summarizeSharing(files)
+
+	assert.Equal(t, 5, summary.FilesAudited)
+	assert.Equal(t, 3, summary.ExternallyShared)
+	assert.Equal(t, 1, summary.Public)
+	assert.Equal(t, 1, summary.AnyoneWithLink)
+	assert.Equal(t, 1, summary.DomainShared)
+	assert.Equal(t, 2, summary.RestrictedToUsers)
+	assert.Equal(t, 2, summary.ExternalGrantees)
+}
+
+func TestOwnerDomains(t *testing.T) {
+	t.Parallel()
+
+	domains := ownerDomains([]*drive.User{
+		{EmailAddress: "a@Example.com"},
+		{EmailAddress: "b@example.com"},
+		{EmailAddress: "c@sub.example.org"},
+		{EmailAddress: ""},
+	})
+
+	assert.Equal(t, []string{"example.com", "sub.example.org"}, domains)
+}
+
+func TestResolveInternalDomains(t *testing.T) {
+	t.Parallel()
+
+	ownedFolder := &drive.File{Name: "Policies", Owners: []*drive.User{{EmailAddress: "owner@example.com"}}}
+	sharedDrive := &drive.File{Name: "Security", DriveId: "0AbCdEf"}
+
+	tests := map[string]struct {
+		configured []string
+		root       *drive.File
+		adminEmail string
+		want       []string
+		wantErr    bool
+	}{
+		"configured domains win":        {configured: []string{"example.io"}, root: ownedFolder, adminEmail: "admin@example.org", want: []string{"example.io"}},
+		"owner domain for my drive":     {root: ownedFolder, adminEmail: "admin@example.org", want: []string{"example.com"}},
+		"admin domain for shared drive": {root: sharedDrive, adminEmail: "admin@Example.com", want: []string{"example.com"}},
+		"shared drive without hints":    {root: sharedDrive, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := resolveInternalDomains(tc.configured, tc.root, tc.adminEmail)
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "internal_domains")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestAuditFileSharing_SharedDrive(t *testing.T) {
+	t.Parallel()
+
+	root := &drive.File{Id: "0AbCdEf", Name: "Security", DriveId: "0AbCdEf"}
+	internal, err := resolveInternalDomains(nil, root, "admin@example.com")
+	require.NoError(t, err)
+
+	shared := auditFileSharing(&drive.File{
+		Id:       "f1",
+		Name:     "Access Review.xlsx",
+		MimeType: "application/vnd.google-apps.spreadsheet",
+		Permissions: []*drive.Permission{
+			{Type: "user", Role: "organizer", EmailAddress: "it@example.com"},
+			{Type: "group", Role: "reader", EmailAddress: "auditors@example.com"},
+			{Type: "user", Role: "reader", EmailAddress: "vendor@partner.io"},
+		},
+	}, "Security/Access Review.xlsx", internal)
+
+	require.Len(t, shared.Permissions, 3)
+	assert.False(t, shared.Permissions[0].External)
+	assert.False(t, shared.Permissions[1].External)
+	assert.True(t, shared.Permissions[2].External)
+	assert.True(t, shared.ExternalSharing)
+}
+
+func TestGenerateReport_SharingAudit(t *testing.T) {
+	t.Parallel()
+	gwt := newTestGoogleWorkspaceTool(t)
+
+	files := []SharedFile{
+		{
+			Path:            "Security",
+			LinkVisibility:  LinkVisibilityRestricted,
+			Owners:          []string{"owner@example.com"},
+			Permissions:     []SharingPermission{{Type: "user", Role: "owner", Email: "owner@example.com"}},
+			ExternalSharing: false,
+		},
+		{
+			Path:            "Security/Vendor List",
+			LinkVisibility:  LinkVisibilityAnyoneWithLink,
+			Owners:          []string{"owner@example.com"},
+			Permissions:     []SharingPermission{{Type: "anyone", Role: "reader", External: true}},
+			ExternalSharing: true,
+		},
+	}
+	summary := summarizeSharing(files)
+	result := &GoogleWorkspaceResult{
+		DocumentID:   "folder-1",
+		DocumentName: "Security",
+		DocumentType: "sharing",
+		Content:      formatSharingContent(files, summary, []string{"example.com"}),
+		SharedFiles:  files,
+	}
+
+	report := gwt.generateReport(result, "sharing", ExtractionRules{})
+
+	require.Contains(t, report, "## Sharing Audit")
+	assert.Contains(t, report, "| Security/Vendor List | anyone_with_link | true | owner@example.com | anyone (reader) |")
+	assert.Contains(t, report, "| Security | restricted | false | owner@example.com |  |")
+	assert.Contains(t, report, "External anyone: anyone (reader)")
+	assert.NotContains(t, result.Content, "- Security (restricted)")
+}
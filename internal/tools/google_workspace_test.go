@@ -252,7 +252,7 @@ func TestGoogleWorkspaceTool(t *testing.T) {
 		gwt := tool.(*GoogleWorkspaceTool)
 
 		// Test credentials file reading
-		client, err := gwt.initializeGoogleClient(ctx, credentialsPath)
+		client, err := gwt.newGoogleHTTPClient(ctx, googleAuthOptions{CredentialsPath: credentialsPath}, googleDocumentScopes...)
 		require.NoError(t, err)
 		assert.NotNil(t, client)
 	})
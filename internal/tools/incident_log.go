@@ -0,0 +1,337 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/storage"
+)
+
+// incidentTimeFormat is how incident timeline entries are shown, always in UTC
+const incidentTimeFormat = "2006-01-02 15:04"
+
+// IncidentSource provides the incident log. It is satisfied by *storage.Storage.
+type IncidentSource interface {
+	GetAllIncidents() ([]domain.Incident, error)
+}
+
+// IncidentLogTool exports the incidents detected within an evidence window
+// with their response timelines, for incident management tasks
+type IncidentLogTool struct {
+	logger    logger.Logger
+	incidents IncidentSource
+	now       func() time.Time
+}
+
+// NewIncidentLogTool creates an incident log tool reading the local incident log
+func NewIncidentLogTool(cfg *config.Config, log logger.Logger) Tool {
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		log.Error("Failed to initialize storage for incident log tool",
+			logger.Field{Key: "error", Value: err})
+		return nil
+	}
+	return &IncidentLogTool{logger: log, incidents: store, now: time.Now}
+}
+
+// Name returns the tool name
+func (i *IncidentLogTool) Name() string {
+	return "incident-log"
+}
+
+// Description returns the tool description
+func (i *IncidentLogTool) Description() string {
+	return "Export the incidents detected within an evidence window with their response timelines"
+}
+
+// GetClaudeToolDefinition returns the tool definition for Claude
+func (i *IncidentLogTool) GetClaudeToolDefinition() models.ClaudeTool {
+	return models.ClaudeTool{
+		Name: i.Name(),
+		Description: "Export the incident log maintained with 'grctool incident' for an evidence window: every incident " +
+			"detected in the window with its severity, detection, acknowledgement and resolution times, time to " +
+			"acknowledge and resolve, root cause and resolution. Use for incident management and response evidence.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"window": map[string]interface{}{
+					"type":        "string",
+					"description": "Evidence window the incidents were detected in (2025, 2025-H1, 2025-Q3 or 2025-07); defaults to the current quarter",
+				},
+				"output_format": map[string]interface{}{
+					"type":        "string",
+					"description": "markdown report with a summary, or csv of the incidents",
+					"enum":        []string{"markdown", "csv"},
+					"default":     "markdown",
+				},
+				"min_severity": map[string]interface{}{
+					"type":        "string",
+					"description": "Only incidents at least this severe",
+					"enum":        domain.IncidentSeverities,
+				},
+			},
+		},
+	}
+}
+
+// Execute exports the incidents detected in the window
+func (i *IncidentLogTool) Execute(ctx context.Context, params map[string]interface{}) (string, *models.EvidenceSource, error) {
+	window, _ := params["window"].(string)
+	format, _ := params["output_format"].(string)
+	minSeverity, _ := params["min_severity"].(string)
+	now := i.now()
+	if window == "" {
+		window = CalculateEvidenceWindow("quarter", now)
+	}
+	if format == "" {
+		format = "markdown"
+	}
+	if minSeverity != "" && !slices.Contains(domain.IncidentSeverities, minSeverity) {
+		return "", nil, fmt.Errorf("invalid min_severity %q (must be one of: %v)", minSeverity, domain.IncidentSeverities)
+	}
+	start, end, err := ParseEvidenceWindow(window)
+	if err != nil {
+		return "", nil, err
+	}
+
+	all, err := i.incidents.GetAllIncidents()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to load incidents: %w", err)
+	}
+	incidents := filterIncidents(all, start, end, minSeverity)
+
+	var report string
+	switch format {
+	case "markdown":
+		report = formatIncidentLogMarkdown(incidents, window, now)
+	case "csv":
+		if report, err = formatIncidentLogCSV(incidents); err != nil {
+			return "", nil, fmt.Errorf("failed to write incident log: %w", err)
+		}
+	default:
+		return "", nil, fmt.Errorf("unsupported output format: %s", format)
+	}
+
+	open := 0
+	for _, incident := range incidents {
+		if incident.Status == domain.IncidentStatusOpen {
+			open++
+		}
+	}
+
+	source := &models.EvidenceSource{
+		Type:        "incident-log",
+		Resource:    fmt.Sprintf("Incident log %s (%d incidents)", window, len(incidents)),
+		Content:     report,
+		Relevance:   0.9,
+		ExtractedAt: now,
+		Metadata: map[string]interface{}{
+			"window":         window,
+			"window_start":   start.Format(time.RFC3339),
+			"window_end":     end.Format(time.RFC3339),
+			"incident_count": len(incidents),
+			"open_incidents": open,
+			"min_severity":   minSeverity,
+		},
+	}
+	return report, source, nil
+}
+
+// filterIncidents keeps incidents detected in [start, end) at least
+// minSeverity, in order of detection
+func filterIncidents(incidents []domain.Incident, start, end time.Time, minSeverity string) []domain.Incident {
+	rank := func(severity string) int { return slices.Index(domain.IncidentSeverities, severity) }
+
+	filtered := []domain.Incident{}
+	for _, incident := range incidents {
+		if incident.DetectedAt.Before(start) || !incident.DetectedAt.Before(end) {
+			continue
+		}
+		if minSeverity != "" && rank(incident.Severity) < rank(minSeverity) {
+			continue
+		}
+		filtered = append(filtered, incident)
+	}
+	sort.SliceStable(filtered, func(a, b int) bool { return filtered[a].DetectedAt.Before(filtered[b].DetectedAt) })
+	return filtered
+}
+
+func formatIncidentLogMarkdown(incidents []domain.Incident, window string, now time.Time) string {
+	var md strings.Builder
+
+	md.WriteString(fmt.Sprintf("# Incident Log %s\n\n", window))
+	md.WriteString(fmt.Sprintf("Generated: %s. Times are UTC.\n\n", now.Format("2006-01-02")))
+
+	if len(incidents) == 0 {
+		md.WriteString("No incidents were detected in this window.\n")
+		return md.String()
+	}
+
+	bySeverity := map[string]int{}
+	var acks, resolves []time.Duration
+	open := 0
+	for _, incident := range incidents {
+		bySeverity[incident.Severity]++
+		if incident.Status == domain.IncidentStatusOpen {
+			open++
+		}
+		if d, ok := incident.TimeToAcknowledge(); ok {
+			acks = append(acks, d)
+		}
+		if d, ok := incident.TimeToResolve(); ok {
+			resolves = append(resolves, d)
+		}
+	}
+
+	md.WriteString("## Summary\n\n")
+	md.WriteString(fmt.Sprintf("- **Incidents:** %d (%d critical, %d high, %d medium, %d low)\n", len(incidents),
+		bySeverity[domain.IncidentSeverityCritical], bySeverity[domain.IncidentSeverityHigh],
+		bySeverity[domain.IncidentSeverityMedium], bySeverity[domain.IncidentSeverityLow]))
+	md.WriteString(fmt.Sprintf("- **Open:** %d\n", open))
+	md.WriteString(fmt.Sprintf("- **Median time to acknowledge:** %s\n", medianResponseTime(acks)))
+	md.WriteString(fmt.Sprintf("- **Median time to resolve:** %s\n\n", medianResponseTime(resolves)))
+
+	md.WriteString("## Incidents\n\n")
+	md.WriteString("| ID | Title | Severity | Status | Detected | Acknowledged | Resolved | Time to Acknowledge | Time to Resolve |\n")
+	md.WriteString("|----|-------|----------|--------|----------|--------------|----------|---------------------|-----------------|\n")
+	for _, incident := range incidents {
+		ack, resolve := incidentResponseTimes(incident)
+		md.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %s | %s | %s |\n",
+			incident.ID, incident.Title, incident.Severity, incident.Status,
+			incident.DetectedAt.UTC().Format(incidentTimeFormat),
+			orDash(formatIncidentTime(incident.AcknowledgedAt)), orDash(formatIncidentTime(incident.ResolvedAt)),
+			orDash(ack), orDash(resolve)))
+	}
+
+	var details []string
+	for _, incident := range incidents {
+		if incident.RootCause == "" && incident.Resolution == "" {
+			continue
+		}
+		var entry strings.Builder
+		entry.WriteString(fmt.Sprintf("### %s: %s\n\n", incident.ID, incident.Title))
+		if incident.RootCause != "" {
+			entry.WriteString(fmt.Sprintf("- **Root cause:** %s\n", incident.RootCause))
+		}
+		if incident.Resolution != "" {
+			entry.WriteString(fmt.Sprintf("- **Resolution:** %s\n", incident.Resolution))
+		}
+		details = append(details, entry.String())
+	}
+	if len(details) > 0 {
+		md.WriteString("\n## Root Causes and Resolutions\n\n")
+		md.WriteString(strings.Join(details, "\n"))
+	}
+
+	return md.String()
+}
+
+func formatIncidentLogCSV(incidents []domain.Incident) (string, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	header := []string{
+		"id", "title", "severity", "category", "owner", "status", "detected_at", "acknowledged_at", "resolved_at",
+		"minutes_to_acknowledge", "minutes_to_resolve", "root_cause", "resolution",
+	}
+	if err := writer.Write(header); err != nil {
+		return "", err
+	}
+	minutes := func(d time.Duration, ok bool) string {
+		if !ok {
+			return ""
+		}
+		return fmt.Sprintf("%d", int(d.Minutes()))
+	}
+	for _, incident := range incidents {
+		record := []string{
+			incident.ID, incident.Title, incident.Severity, incident.Category, incident.Owner, incident.Status,
+			incident.DetectedAt.UTC().Format(time.RFC3339), formatIncidentRFC3339(incident.AcknowledgedAt),
+			formatIncidentRFC3339(incident.ResolvedAt), minutes(incident.TimeToAcknowledge()),
+			minutes(incident.TimeToResolve()), incident.RootCause, incident.Resolution,
+		}
+		if err := writer.Write(record); err != nil {
+			return "", err
+		}
+	}
+	writer.Flush()
+	return buf.String(), writer.Error()
+}
+
+// incidentResponseTimes formats the incident's time to acknowledge and resolve
+func incidentResponseTimes(incident domain.Incident) (string, string) {
+	var ack, resolve string
+	if d, ok := incident.TimeToAcknowledge(); ok {
+		ack = FormatResponseTime(d)
+	}
+	if d, ok := incident.TimeToResolve(); ok {
+		resolve = FormatResponseTime(d)
+	}
+	return ack, resolve
+}
+
+// FormatResponseTime formats an incident response time to the minute, as
+// "45m", "3h 20m" or "2d 4h"
+func FormatResponseTime(d time.Duration) string {
+	minutes := int(d.Round(time.Minute).Minutes())
+	days, hours, mins := minutes/(24*60), minutes/60%24, minutes%60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, mins)
+	default:
+		return fmt.Sprintf("%dm", mins)
+	}
+}
+
+func medianResponseTime(durations []time.Duration) string {
+	if len(durations) == 0 {
+		return "-"
+	}
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return FormatResponseTime((sorted[mid-1] + sorted[mid]) / 2)
+	}
+	return FormatResponseTime(sorted[mid])
+}
+
+func formatIncidentTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(incidentTimeFormat)
+}
+
+func formatIncidentRFC3339(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
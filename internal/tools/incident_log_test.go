@@ -0,0 +1,129 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubIncidentSource []domain.Incident
+
+func (s stubIncidentSource) GetAllIncidents() ([]domain.Incident, error) { return s, nil }
+
+func TestIncidentLogTool_Execute(t *testing.T) {
+	t.Parallel()
+
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2025, month, day, hour, minute, 0, 0, time.UTC)
+	}
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	incidents := stubIncidentSource{
+		{ID: "INC-0001", Title: "Phishing report", Severity: domain.IncidentSeverityLow, Status: domain.IncidentStatusClosed,
+			DetectedAt: at(6, 20, 9, 0), AcknowledgedAt: ptr(at(6, 20, 9, 30)), ResolvedAt: ptr(at(6, 20, 11, 0))},
+		{ID: "INC-0002", Title: "API outage", Severity: domain.IncidentSeverityHigh, Status: domain.IncidentStatusClosed,
+			DetectedAt: at(9, 3, 10, 0), AcknowledgedAt: ptr(at(9, 3, 10, 10)), ResolvedAt: ptr(at(9, 4, 14, 0)),
+			RootCause: "Expired TLS certificate", Resolution: "Renewed and automated renewal"},
+		{ID: "INC-0003", Title: "Lost laptop", Severity: domain.IncidentSeverityMedium, Status: domain.IncidentStatusOpen,
+			DetectedAt: at(7, 14, 8, 0), AcknowledgedAt: ptr(at(7, 14, 8, 20))},
+	}
+	log, err := logger.NewTestLogger()
+	require.NoError(t, err)
+	tool := &IncidentLogTool{logger: log, incidents: incidents, now: func() time.Time { return at(9, 30, 0, 0) }}
+
+	tests := map[string]struct {
+		params   map[string]interface{}
+		count    int
+		contains []string
+		excludes []string
+	}{
+		"current quarter by default": {
+			params: map[string]interface{}{},
+			count:  2,
+			contains: []string{
+				"# Incident Log 2025-Q3",
+				"- **Incidents:** 2 (0 critical, 1 high, 1 medium, 0 low)",
+				"- **Open:** 1",
+				"- **Median time to acknowledge:** 15m",
+				"- **Median time to resolve:** 1d 4h",
+				"| INC-0003 | Lost laptop | medium | open | 2025-07-14 08:00 | 2025-07-14 08:20 | - | 20m | - |",
+				"| INC-0002 | API outage | high | closed | 2025-09-03 10:00 | 2025-09-03 10:10 | 2025-09-04 14:00 | 10m | 1d 4h |",
+				"- **Root cause:** Expired TLS certificate",
+			},
+			excludes: []string{"Phishing report"},
+		},
+		"min severity": {
+			params:   map[string]interface{}{"window": "2025", "min_severity": "high"},
+			count:    1,
+			contains: []string{"API outage"},
+			excludes: []string{"Lost laptop", "Phishing report"},
+		},
+		"csv": {
+			params: map[string]interface{}{"window": "2025-06", "output_format": "csv"},
+			count:  1,
+			contains: []string{
+				"id,title,severity,category,owner,status,detected_at,acknowledged_at,resolved_at,minutes_to_acknowledge,minutes_to_resolve,root_cause,resolution",
+				"INC-0001,Phishing report,low,,,closed,2025-06-20T09:00:00Z,2025-06-20T09:30:00Z,2025-06-20T11:00:00Z,30,120,,",
+			},
+		},
+		"empty window": {
+			params:   map[string]interface{}{"window": "2024"},
+			count:    0,
+			contains: []string{"No incidents were detected in this window."},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			report, source, err := tool.Execute(context.Background(), tc.params)
+			require.NoError(t, err)
+			require.NotNil(t, source)
+			assert.Equal(t, "incident-log", source.Type)
+			assert.Equal(t, tc.count, source.Metadata["incident_count"])
+			for _, want := range tc.contains {
+				assert.Contains(t, report, want)
+			}
+			for _, unwanted := range tc.excludes {
+				assert.NotContains(t, report, unwanted)
+			}
+		})
+	}
+
+	_, _, err = tool.Execute(context.Background(), map[string]interface{}{"window": "last quarter"})
+	assert.ErrorContains(t, err, "invalid evidence window")
+	_, _, err = tool.Execute(context.Background(), map[string]interface{}{"min_severity": "sev1"})
+	assert.ErrorContains(t, err, `invalid min_severity "sev1"`)
+}
+
+func TestFormatResponseTime(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		duration time.Duration
+		want     string
+	}{
+		"minutes":       {duration: 45 * time.Minute, want: "45m"},
+		"rounded":       {duration: 90 * time.Second, want: "2m"},
+		"hours":         {duration: 3*time.Hour + 20*time.Minute, want: "3h 20m"},
+		"days":          {duration: 52 * time.Hour, want: "2d 4h"},
+		"zero":          {duration: 0, want: "0m"},
+		"exact hour":    {duration: time.Hour, want: "1h 0m"},
+		"exact one day": {duration: 24 * time.Hour, want: "1d 0h"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, FormatResponseTime(tc.duration))
+		})
+	}
+}
@@ -16,12 +16,14 @@
 package tools
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/grctool/grctool/internal/appcontext"
 )
 
 // ToolOutput represents the standardized JSON envelope for all tool outputs
@@ -136,6 +138,16 @@ func GenerateCorrelationID() string {
 	return uuid.New().String()
 }
 
+// CorrelationID returns the correlation ID of the command running the tool,
+// so a tool's logs and output join the command's, or a new one when run
+// outside a command
+func CorrelationID(ctx context.Context) string {
+	if id := appcontext.GetCorrelationID(ctx); id != "" {
+		return id
+	}
+	return GenerateCorrelationID()
+}
+
 // RedactSensitiveData removes sensitive information from data structures
 func RedactSensitiveData(data interface{}) interface{} {
 	return redactValue(data)
@@ -0,0 +1,311 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/models"
+)
+
+// PluginProtocolVersion is the version of the JSON contract between grctool
+// and plugin tools, sent with every request
+const PluginProtocolVersion = 1
+
+// PluginRequest is written to a plugin's stdin as a single JSON document
+type PluginRequest struct {
+	ProtocolVersion int                    `json:"protocol_version"`
+	Tool            string                 `json:"tool"`
+	Params          map[string]interface{} `json:"params"`
+	TaskRef         string                 `json:"task_ref,omitempty"`
+	CorrelationID   string                 `json:"correlation_id"`
+}
+
+// PluginResponse is read from a plugin's stdout. A plugin reports a failure
+// by setting Error or exiting non-zero; anything it writes to stderr is
+// logged.
+type PluginResponse struct {
+	Result string                 `json:"result"`           // Evidence content, e.g. markdown or CSV
+	Source *models.EvidenceSource `json:"source,omitempty"` // Where the evidence came from
+	Error  string                 `json:"error,omitempty"`
+}
+
+// PluginTool is an evidence collector outside grctool, run as a subprocess
+// for each execution, so teams can add proprietary collectors through the
+// config alone
+type PluginTool struct {
+	config config.PluginToolConfig
+	logger logger.Logger
+}
+
+// NewPluginTool creates a tool running the plugin the config describes
+func NewPluginTool(cfg config.PluginToolConfig, log logger.Logger) *PluginTool {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Minute
+	}
+	return &PluginTool{config: cfg, logger: log}
+}
+
+// Name returns the tool name
+func (p *PluginTool) Name() string {
+	return p.config.Name
+}
+
+// Description returns the tool description
+func (p *PluginTool) Description() string {
+	if p.config.Description == "" {
+		return fmt.Sprintf("External evidence collector %s", p.config.Command)
+	}
+	return p.config.Description
+}
+
+// Version returns the protocol version the plugin is spoken to with
+func (p *PluginTool) Version() string {
+	return fmt.Sprintf("plugin/v%d", PluginProtocolVersion)
+}
+
+// Category returns the tool category
+func (p *PluginTool) Category() string {
+	return "plugin"
+}
+
+// Parameters returns the parameters the plugin declares
+func (p *PluginTool) Parameters() []config.PluginParameterConfig {
+	return p.config.Parameters
+}
+
+// AppliesTo reports whether any of the plugin's keywords occur in text,
+// which should be lower case
+func (p *PluginTool) AppliesTo(text string) bool {
+	for _, keyword := range p.config.Keywords {
+		if keyword != "" && strings.Contains(text, strings.ToLower(keyword)) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetClaudeToolDefinition returns the tool definition for Claude
+func (p *PluginTool) GetClaudeToolDefinition() models.ClaudeTool {
+	properties := make(map[string]interface{}, len(p.config.Parameters))
+	var required []string
+	for _, param := range p.config.Parameters {
+		property := map[string]interface{}{
+			"type":        param.Type,
+			"description": param.Description,
+		}
+		if len(param.Values) > 0 {
+			property["enum"] = param.Values
+		}
+		properties[param.Name] = property
+		if param.Required {
+			required = append(required, param.Name)
+		}
+	}
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return models.ClaudeTool{
+		Name:        p.Name(),
+		Description: p.Description(),
+		InputSchema: schema,
+	}
+}
+
+// ValidationRules returns the rules checking the plugin's parameters
+func (p *PluginTool) ValidationRules() map[string]ValidationRule {
+	types := map[string]string{"string": "string", "integer": "int", "boolean": "bool"}
+	rules := make(map[string]ValidationRule, len(p.config.Parameters))
+	for _, param := range p.config.Parameters {
+		rules[param.Name] = ValidationRule{
+			Required:      param.Required,
+			Type:          types[param.Type],
+			AllowedValues: param.Values,
+		}
+	}
+	return rules
+}
+
+// Params converts parameter values given as text, as on the command line,
+// to the types the plugin declares
+func (p *PluginTool) Params(values map[string]string) (map[string]interface{}, error) {
+	params := make(map[string]interface{}, len(values))
+	for name, value := range values {
+		index := slices.IndexFunc(p.config.Parameters, func(param config.PluginParameterConfig) bool {
+			return param.Name == name
+		})
+		if index < 0 {
+			return nil, fmt.Errorf("plugin %s has no parameter %q", p.Name(), name)
+		}
+
+		switch p.config.Parameters[index].Type {
+		case "integer":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("parameter %s must be an integer, got: %s", name, value)
+			}
+			params[name] = n
+		case "boolean":
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("parameter %s must be true or false, got: %s", name, value)
+			}
+			params[name] = b
+		default:
+			params[name] = value
+		}
+	}
+	return params, nil
+}
+
+// Execute runs the plugin with the parameters as a PluginRequest on stdin
+// and returns the result and evidence source of its PluginResponse
+func (p *PluginTool) Execute(ctx context.Context, params map[string]interface{}) (string, *models.EvidenceSource, error) {
+	startTime := time.Now()
+	correlationID := CorrelationID(ctx)
+	taskRef, _ := params["task_ref"].(string)
+
+	request, err := json.Marshal(PluginRequest{
+		ProtocolVersion: PluginProtocolVersion,
+		Tool:            p.Name(),
+		Params:          params,
+		TaskRef:         taskRef,
+		CorrelationID:   correlationID,
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to encode request for plugin %s: %w", p.Name(), err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.config.Timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.config.Command, p.config.Args...)
+	cmd.Stdin = bytes.NewReader(request)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = p.environment()
+	// Don't wait on processes the plugin started that still hold its output
+	cmd.WaitDelay = time.Second
+
+	p.logger.Debug("Running plugin tool",
+		logger.String("correlation_id", correlationID),
+		logger.String("plugin", p.Name()),
+		logger.String("command", p.config.Command))
+
+	runErr := cmd.Run()
+	if output := strings.TrimSpace(stderr.String()); output != "" {
+		p.logger.Debug("Plugin tool stderr",
+			logger.String("correlation_id", correlationID),
+			logger.String("plugin", p.Name()),
+			logger.String("stderr", output))
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return "", nil, fmt.Errorf("plugin %s timed out after %s", p.Name(), p.config.Timeout)
+	}
+
+	var response PluginResponse
+	if err := json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		if runErr != nil {
+			return "", nil, p.runError(runErr, stderr.String())
+		}
+		return "", nil, fmt.Errorf("plugin %s did not write a JSON response: %w", p.Name(), err)
+	}
+	if response.Error != "" {
+		return "", nil, fmt.Errorf("plugin %s: %s", p.Name(), response.Error)
+	}
+	if runErr != nil {
+		return "", nil, p.runError(runErr, stderr.String())
+	}
+
+	source := response.Source
+	if source == nil {
+		source = &models.EvidenceSource{}
+	}
+	if source.Type == "" {
+		source.Type = p.Name()
+	}
+	if source.Resource == "" {
+		source.Resource = p.Description()
+	}
+	if source.Content == "" {
+		source.Content = response.Result
+	}
+	if source.ExtractedAt.IsZero() {
+		source.ExtractedAt = startTime
+	}
+	if source.Metadata == nil {
+		source.Metadata = make(map[string]interface{})
+	}
+	source.Metadata["plugin"] = p.Name()
+	source.Metadata["correlation_id"] = correlationID
+	source.Metadata["duration_ms"] = time.Since(startTime).Milliseconds()
+
+	return response.Result, source, nil
+}
+
+// environment returns grctool's environment with the plugin's variables
+func (p *PluginTool) environment() []string {
+	env := os.Environ()
+	names := make([]string, 0, len(p.config.Env))
+	for name := range p.config.Env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		env = append(env, name+"="+p.config.Env[name])
+	}
+	return env
+}
+
+// runError describes a plugin that exited without a usable response
+func (p *PluginTool) runError(err error, stderr string) error {
+	if msg := strings.TrimSpace(stderr); msg != "" {
+		return fmt.Errorf("plugin %s failed: %w: %s", p.Name(), err, msg)
+	}
+	return fmt.Errorf("plugin %s failed: %w", p.Name(), err)
+}
+
+// PluginTools returns the plugin tools in the global registry, sorted by name
+func PluginTools() []*PluginTool {
+	var plugins []*PluginTool
+	for _, name := range GlobalRegistry.ListNames() {
+		if tool, err := GlobalRegistry.Get(name); err == nil {
+			if plugin, ok := tool.(*PluginTool); ok {
+				plugins = append(plugins, plugin)
+			}
+		}
+	}
+	return plugins
+}
@@ -0,0 +1,166 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/appcontext"
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePlugin writes a shell script plugin and returns its path
+func writePlugin(t *testing.T, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin scripts need a POSIX shell")
+	}
+	path := filepath.Join(t.TempDir(), "plugin.sh")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755))
+	return path
+}
+
+func newTestPlugin(t *testing.T, cfg config.PluginToolConfig) *PluginTool {
+	t.Helper()
+	log, err := logger.NewTestLogger()
+	require.NoError(t, err)
+	if cfg.Name == "" {
+		cfg.Name = "okta-mfa"
+	}
+	return NewPluginTool(cfg, log)
+}
+
+func TestPluginTool_Execute(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	requestFile := filepath.Join(dir, "request.json")
+	command := writePlugin(t, `cat > "$REQUEST_FILE"
+echo "collecting for $OKTA_ORG" >&2
+cat <<'JSON'
+{"result": "| user | mfa |\n| alex | yes |", "source": {"type": "okta", "resource": "Okta users", "relevance": 0.8, "metadata": {"users": 1}}}
+JSON
+`)
+	plugin := newTestPlugin(t, config.PluginToolConfig{
+		Command: command,
+		Env:     map[string]string{"REQUEST_FILE": requestFile, "OKTA_ORG": "acme"},
+	})
+
+	ctx := appcontext.WithCorrelationID(context.Background(), "run-123")
+	result, source, err := plugin.Execute(ctx, map[string]interface{}{"task_ref": "ET-0047", "group": "engineering"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "| user | mfa |\n| alex | yes |", result)
+	require.NotNil(t, source)
+	assert.Equal(t, "okta", source.Type)
+	assert.Equal(t, "Okta users", source.Resource)
+	assert.Equal(t, result, source.Content)
+	assert.InDelta(t, 0.8, source.Relevance, 0.001)
+	assert.False(t, source.ExtractedAt.IsZero())
+	assert.Equal(t, "okta-mfa", source.Metadata["plugin"])
+	assert.Equal(t, "run-123", source.Metadata["correlation_id"])
+	assert.EqualValues(t, 1, source.Metadata["users"])
+
+	request, err := os.ReadFile(requestFile)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"protocol_version": 1,
+		"tool": "okta-mfa",
+		"params": {"task_ref": "ET-0047", "group": "engineering"},
+		"task_ref": "ET-0047",
+		"correlation_id": "run-123"
+	}`, string(request))
+}
+
+func TestPluginTool_Execute_Failures(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		script  string
+		timeout time.Duration
+		wantErr string
+	}{
+		"reported error": {
+			script:  `echo '{"error": "okta token expired"}'`,
+			wantErr: "plugin okta-mfa: okta token expired",
+		},
+		"non-zero exit": {
+			script:  "echo 'cannot reach okta' >&2\nexit 3",
+			wantErr: "plugin okta-mfa failed: exit status 3: cannot reach okta",
+		},
+		"not JSON": {
+			script:  "echo 'done'",
+			wantErr: "plugin okta-mfa did not write a JSON response",
+		},
+		"timeout": {
+			script:  "sleep 5",
+			timeout: 100 * time.Millisecond,
+			wantErr: "plugin okta-mfa timed out after 100ms",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			plugin := newTestPlugin(t, config.PluginToolConfig{Command: writePlugin(t, tc.script), Timeout: tc.timeout})
+			_, _, err := plugin.Execute(context.Background(), map[string]interface{}{})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
+
+func TestPluginTool_Params(t *testing.T) {
+	t.Parallel()
+
+	plugin := newTestPlugin(t, config.PluginToolConfig{
+		Command: "grctool-okta",
+		Parameters: []config.PluginParameterConfig{
+			{Name: "group", Type: "string", Required: true},
+			{Name: "days", Type: "integer"},
+			{Name: "include_admins", Type: "boolean"},
+			{Name: "format", Type: "string", Values: []string{"csv", "markdown"}},
+		},
+	})
+
+	params, err := plugin.Params(map[string]string{"group": "engineering", "days": "30", "include_admins": "true"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"group": "engineering", "days": 30, "include_admins": true}, params)
+
+	_, err = plugin.Params(map[string]string{"days": "thirty"})
+	assert.ErrorContains(t, err, "parameter days must be an integer")
+	_, err = plugin.Params(map[string]string{"team": "engineering"})
+	assert.ErrorContains(t, err, `plugin okta-mfa has no parameter "team"`)
+
+	rules := plugin.ValidationRules()
+	assert.Equal(t, ValidationRule{Required: true, Type: "string"}, rules["group"])
+	assert.Equal(t, "int", rules["days"].Type)
+	assert.Equal(t, []string{"csv", "markdown"}, rules["format"].AllowedValues)
+
+	definition := plugin.GetClaudeToolDefinition()
+	assert.Equal(t, "okta-mfa", definition.Name)
+	assert.Equal(t, []string{"group"}, definition.InputSchema["required"])
+	properties := definition.InputSchema["properties"].(map[string]interface{})
+	assert.Equal(t, "integer", properties["days"].(map[string]interface{})["type"])
+	assert.Equal(t, []string{"csv", "markdown"}, properties["format"].(map[string]interface{})["enum"])
+}
+
+func TestPluginTool_AppliesTo(t *testing.T) {
+	t.Parallel()
+
+	plugin := newTestPlugin(t, config.PluginToolConfig{Command: "grctool-okta", Keywords: []string{"Okta", "multi-factor"}})
+
+	assert.True(t, plugin.AppliesTo("okta sso configuration"))
+	assert.True(t, plugin.AppliesTo("multi-factor authentication is enforced"))
+	assert.False(t, plugin.AppliesTo("terraform state encryption"))
+	assert.Equal(t, "plugin", plugin.Category())
+}
@@ -0,0 +1,331 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/storage"
+)
+
+// PolicyAcknowledgmentSource provides recorded policy acknowledgments, the
+// employee roster and the authored policies expected to be acknowledged. It
+// is satisfied by *storage.Storage.
+type PolicyAcknowledgmentSource interface {
+	GetAllPolicyAcknowledgments(period string) ([]domain.PolicyAcknowledgments, error)
+	GetPolicyAcknowledgments(policy, period string) (*domain.PolicyAcknowledgments, error)
+	GetEmployeeRoster() (*domain.EmployeeRoster, error)
+	GetAllAuthoredPolicies() ([]domain.AuthoredPolicy, error)
+}
+
+// PolicyAcknowledgmentTool exports employee policy acknowledgment rates as
+// evidence for security awareness and policy acknowledgment tasks
+type PolicyAcknowledgmentTool struct {
+	logger logger.Logger
+	source PolicyAcknowledgmentSource
+	now    func() time.Time
+}
+
+// NewPolicyAcknowledgmentTool creates a policy acknowledgment tool reading the
+// local acknowledgment records
+func NewPolicyAcknowledgmentTool(cfg *config.Config, log logger.Logger) Tool {
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		log.Error("Failed to initialize storage for policy acknowledgment tool",
+			logger.Field{Key: "error", Value: err})
+		return nil
+	}
+	return &PolicyAcknowledgmentTool{logger: log, source: store, now: time.Now}
+}
+
+// Name returns the tool name
+func (p *PolicyAcknowledgmentTool) Name() string {
+	return "policy-acknowledgment"
+}
+
+// Description returns the tool description
+func (p *PolicyAcknowledgmentTool) Description() string {
+	return "Export employee policy acknowledgment rates against the roster, with pending employees, for a period"
+}
+
+// GetClaudeToolDefinition returns the tool definition for Claude
+func (p *PolicyAcknowledgmentTool) GetClaudeToolDefinition() models.ClaudeTool {
+	return models.ClaudeTool{
+		Name: p.Name(),
+		Description: "Export the employee policy acknowledgments recorded with 'grctool policy ack' (imported from Google " +
+			"Forms or HRIS exports, or collected through signed links): per policy, how many employees on the roster " +
+			"acknowledged it in the period, the acknowledgment rate, and who is still pending. Use for evidence that " +
+			"employees acknowledge policies such as the acceptable use policy or code of conduct.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"policy": map[string]interface{}{
+					"type":        "string",
+					"description": "Only this policy (authored policy ID or synced policy reference); all policies when omitted",
+				},
+				"period": map[string]interface{}{
+					"type":        "string",
+					"description": "Acknowledgment period: a year, half, quarter or month (e.g. 2025, 2025-Q1); defaults to the current year",
+				},
+				"output_format": map[string]interface{}{
+					"type":        "string",
+					"description": "markdown report with rates and pending employees, or csv with a row per employee",
+					"enum":        []string{"markdown", "csv"},
+					"default":     "markdown",
+				},
+			},
+		},
+	}
+}
+
+// Execute exports the acknowledgment rates of the period
+func (p *PolicyAcknowledgmentTool) Execute(ctx context.Context, params map[string]interface{}) (string, *models.EvidenceSource, error) {
+	policy, _ := params["policy"].(string)
+	period, _ := params["period"].(string)
+	format, _ := params["output_format"].(string)
+	now := p.now()
+	if period == "" {
+		period = strconv.Itoa(now.Year())
+	}
+	if format == "" {
+		format = "markdown"
+	}
+	if err := domain.ValidateAcknowledgmentPeriod(period); err != nil {
+		return "", nil, err
+	}
+
+	roster, err := p.source.GetEmployeeRoster()
+	if err != nil {
+		return "", nil, err
+	}
+	var records []domain.PolicyAcknowledgments
+	if policy != "" {
+		acks, err := p.source.GetPolicyAcknowledgments(policy, period)
+		if err != nil {
+			return "", nil, err
+		}
+		records = []domain.PolicyAcknowledgments{*acks}
+	} else if records, err = CollectPolicyAcknowledgments(p.source, period); err != nil {
+		return "", nil, err
+	}
+
+	var report string
+	switch format {
+	case "markdown":
+		report = formatPolicyAcknowledgmentMarkdown(records, roster, period, now)
+	case "csv":
+		if report, err = formatPolicyAcknowledgmentCSV(records, roster); err != nil {
+			return "", nil, fmt.Errorf("failed to write policy acknowledgments: %w", err)
+		}
+	default:
+		return "", nil, fmt.Errorf("unsupported output format: %s", format)
+	}
+
+	lowest := 100.0
+	acknowledged := 0
+	for i := range records {
+		rate := domain.CalculateAcknowledgmentRate(&records[i], roster)
+		acknowledged += rate.Acknowledged
+		if rate.Rate < lowest {
+			lowest = rate.Rate
+		}
+	}
+	relevance := 0.0
+	if acknowledged > 0 {
+		relevance = 0.9
+	}
+	metadata := map[string]interface{}{
+		"period":       period,
+		"policy_count": len(records),
+		"has_roster":   roster != nil,
+	}
+	if roster != nil && len(records) > 0 {
+		metadata["roster_size"] = len(roster.Employees)
+		metadata["lowest_rate"] = lowest
+	}
+
+	source := &models.EvidenceSource{
+		Type:        "policy-acknowledgment",
+		Resource:    fmt.Sprintf("Policy acknowledgments for %s (%d policies)", period, len(records)),
+		Content:     report,
+		Relevance:   relevance,
+		ExtractedAt: now,
+		Metadata:    metadata,
+	}
+	return report, source, nil
+}
+
+// CollectPolicyAcknowledgments returns the acknowledgments of each policy
+// with acknowledgments in the period, plus an empty set for each approved
+// authored policy nobody has acknowledged yet, sorted by policy
+func CollectPolicyAcknowledgments(source PolicyAcknowledgmentSource, period string) ([]domain.PolicyAcknowledgments, error) {
+	records, err := source.GetAllPolicyAcknowledgments(period)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load acknowledgments: %w", err)
+	}
+	authored, err := source.GetAllAuthoredPolicies()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load policies: %w", err)
+	}
+
+	for _, policy := range authored {
+		if policy.ApprovedDate == "" || slices.ContainsFunc(records, func(r domain.PolicyAcknowledgments) bool { return r.Policy == policy.ID }) {
+			continue
+		}
+		records = append(records, domain.PolicyAcknowledgments{Policy: policy.ID, Period: period, Acknowledgments: []domain.PolicyAcknowledgment{}})
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Policy < records[j].Policy })
+	return records, nil
+}
+
+func formatPolicyAcknowledgmentMarkdown(records []domain.PolicyAcknowledgments, roster *domain.EmployeeRoster, period string, now time.Time) string {
+	var md strings.Builder
+
+	md.WriteString("# Policy Acknowledgments\n\n")
+	md.WriteString(fmt.Sprintf("Period: %s\n", period))
+	md.WriteString(fmt.Sprintf("Generated: %s\n", now.Format("2006-01-02")))
+	if roster != nil {
+		md.WriteString(fmt.Sprintf("Roster: %d employees, imported %s\n\n", len(roster.Employees), roster.ImportedAt.Format("2006-01-02")))
+	} else {
+		md.WriteString("\n> No employee roster has been imported, so rates cannot be measured. " +
+			"Import one with 'grctool policy ack roster'.\n\n")
+	}
+
+	if len(records) == 0 {
+		md.WriteString("No acknowledgments recorded for this period. Import them with 'grctool policy ack import'.\n")
+		return md.String()
+	}
+
+	md.WriteString("## Summary\n\n")
+	md.WriteString("| Policy | Acknowledged | Expected | Rate | Pending | Sources |\n")
+	md.WriteString("|--------|--------------|----------|------|---------|---------|\n")
+	rates := make([]domain.AcknowledgmentRate, len(records))
+	for i := range records {
+		rates[i] = domain.CalculateAcknowledgmentRate(&records[i], roster)
+		rate := rates[i]
+		expected, percent, pending := "-", "-", "-"
+		if rate.Expected > 0 {
+			expected = strconv.Itoa(rate.Expected)
+			percent = fmt.Sprintf("%.1f%%", rate.Rate)
+			pending = strconv.Itoa(len(rate.Pending))
+		}
+		md.WriteString(fmt.Sprintf("| %s | %d | %s | %s | %s | %s |\n",
+			rate.Policy, rate.Acknowledged, expected, percent, pending, orDash(acknowledgmentSources(records[i]))))
+	}
+
+	var pending strings.Builder
+	for _, rate := range rates {
+		if len(rate.Pending) == 0 {
+			continue
+		}
+		pending.WriteString(fmt.Sprintf("\n### %s (%d)\n\n", rate.Policy, len(rate.Pending)))
+		for _, employee := range rate.Pending {
+			pending.WriteString(fmt.Sprintf("- %s\n", employeeLabel(employee)))
+		}
+	}
+	if pending.Len() > 0 {
+		md.WriteString("\n## Pending Acknowledgments\n")
+		md.WriteString(pending.String())
+	}
+
+	return md.String()
+}
+
+// acknowledgmentSources counts a policy's acknowledgments by source, e.g. "csv: 10, link: 3"
+func acknowledgmentSources(acks domain.PolicyAcknowledgments) string {
+	counts := map[string]int{}
+	for _, ack := range acks.Acknowledgments {
+		counts[ack.Source]++
+	}
+	var parts []string
+	for _, source := range domain.AcknowledgmentSources {
+		if counts[source] > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %d", source, counts[source]))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func employeeLabel(employee domain.Employee) string {
+	label := employee.Email
+	if employee.Name != "" {
+		label = fmt.Sprintf("%s <%s>", employee.Name, employee.Email)
+	}
+	if employee.Department != "" {
+		label += " (" + employee.Department + ")"
+	}
+	return label
+}
+
+// formatPolicyAcknowledgmentCSV writes a row per roster employee and policy,
+// followed by acknowledgments from people not on the roster
+func formatPolicyAcknowledgmentCSV(records []domain.PolicyAcknowledgments, roster *domain.EmployeeRoster) (string, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	header := []string{
+		"policy", "period", "email", "name", "department", "on_roster", "acknowledged", "acknowledged_at", "version", "source",
+	}
+	if err := writer.Write(header); err != nil {
+		return "", err
+	}
+
+	for i := range records {
+		acks := &records[i]
+		onRoster := map[string]bool{}
+		if roster != nil {
+			for _, employee := range roster.Employees {
+				email := domain.NormalizeEmail(employee.Email)
+				onRoster[email] = true
+				record := []string{acks.Policy, acks.Period, email, employee.Name, employee.Department, "true", "false", "", "", ""}
+				if ack, ok := acks.Find(email); ok {
+					record[6], record[7], record[8], record[9] = "true", ack.AcknowledgedAt.Format(time.RFC3339), ack.Version, ack.Source
+				}
+				if err := writer.Write(record); err != nil {
+					return "", err
+				}
+			}
+		}
+		// Without a roster, whether someone is on it is unknown
+		offRoster := "false"
+		if roster == nil {
+			offRoster = ""
+		}
+		for _, ack := range acks.Acknowledgments {
+			if onRoster[ack.Email] {
+				continue
+			}
+			record := []string{acks.Policy, acks.Period, ack.Email, ack.Name, "", offRoster, "true",
+				ack.AcknowledgedAt.Format(time.RFC3339), ack.Version, ack.Source}
+			if err := writer.Write(record); err != nil {
+				return "", err
+			}
+		}
+	}
+	writer.Flush()
+	return buf.String(), writer.Error()
+}
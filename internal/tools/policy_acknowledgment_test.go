@@ -0,0 +1,156 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubPolicyAcknowledgmentSource struct {
+	acks     []domain.PolicyAcknowledgments
+	roster   *domain.EmployeeRoster
+	policies stubAuthoredPolicySource
+}
+
+func (s stubPolicyAcknowledgmentSource) GetAllPolicyAcknowledgments(period string) ([]domain.PolicyAcknowledgments, error) {
+	var all []domain.PolicyAcknowledgments
+	for _, acks := range s.acks {
+		if acks.Period == period {
+			all = append(all, acks)
+		}
+	}
+	return all, nil
+}
+
+func (s stubPolicyAcknowledgmentSource) GetPolicyAcknowledgments(policy, period string) (*domain.PolicyAcknowledgments, error) {
+	for _, acks := range s.acks {
+		if acks.Policy == policy && acks.Period == period {
+			return &acks, nil
+		}
+	}
+	return &domain.PolicyAcknowledgments{Policy: policy, Period: period}, nil
+}
+
+func (s stubPolicyAcknowledgmentSource) GetEmployeeRoster() (*domain.EmployeeRoster, error) {
+	return s.roster, nil
+}
+
+func (s stubPolicyAcknowledgmentSource) GetAllAuthoredPolicies() ([]domain.AuthoredPolicy, error) {
+	return s.policies, nil
+}
+
+func TestPolicyAcknowledgmentTool_Execute(t *testing.T) {
+	t.Parallel()
+
+	acked := time.Date(2025, 2, 3, 9, 30, 0, 0, time.UTC)
+	aup := domain.PolicyAcknowledgments{Policy: "acceptable-use-policy", Period: "2025"}
+	aup.Add(domain.PolicyAcknowledgment{Email: "alex@example.com", Name: "Alex", Version: "1.0", AcknowledgedAt: acked,
+		Source: domain.AcknowledgmentSourceGoogleForms})
+	aup.Add(domain.PolicyAcknowledgment{Email: "former@example.com", AcknowledgedAt: acked, Source: domain.AcknowledgmentSourceCSV})
+	aup.Add(domain.PolicyAcknowledgment{Email: "sam@example.com", AcknowledgedAt: acked, Source: domain.AcknowledgmentSourceLink})
+	source := stubPolicyAcknowledgmentSource{
+		acks: []domain.PolicyAcknowledgments{aup},
+		roster: &domain.EmployeeRoster{ImportedAt: acked, Employees: []domain.Employee{
+			{Email: "alex@example.com", Name: "Alex"},
+			{Email: "kim@example.com", Name: "Kim", Department: "Sales"},
+			{Email: "sam@example.com"},
+			{Email: "lee@example.com"},
+		}},
+		policies: stubAuthoredPolicySource{
+			{ID: "code-of-conduct", Title: "Code of Conduct", Version: "1.0", Status: domain.AuthoredPolicyApproved, ApprovedDate: "2025-01-01"},
+			{ID: "draft-policy", Title: "Draft Policy", Version: "1.0", Status: domain.AuthoredPolicyDraft},
+		},
+	}
+	log, err := logger.NewTestLogger()
+	require.NoError(t, err)
+	now := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	tool := &PolicyAcknowledgmentTool{logger: log, source: source, now: func() time.Time { return now }}
+
+	tests := map[string]struct {
+		params   map[string]interface{}
+		count    int
+		contains []string
+		excludes []string
+	}{
+		"all policies": {
+			params: map[string]interface{}{},
+			count:  2,
+			contains: []string{
+				"Period: 2025",
+				"Roster: 4 employees, imported 2025-02-03",
+				"| acceptable-use-policy | 2 | 4 | 50.0% | 2 | csv: 1, google-forms: 1, link: 1 |",
+				"| code-of-conduct | 0 | 4 | 0.0% | 4 | - |",
+				"### acceptable-use-policy (2)",
+				"- Kim <kim@example.com> (Sales)",
+				"- lee@example.com",
+			},
+			excludes: []string{"draft-policy"},
+		},
+		"one policy": {
+			params:   map[string]interface{}{"policy": "acceptable-use-policy"},
+			count:    1,
+			excludes: []string{"code-of-conduct"},
+		},
+		"other period": {
+			params:   map[string]interface{}{"period": "2024"},
+			count:    1,
+			contains: []string{"Period: 2024", "| code-of-conduct | 0 | 4 | 0.0% | 4 | - |"},
+			excludes: []string{"acceptable-use-policy"},
+		},
+		"csv": {
+			params: map[string]interface{}{"output_format": "csv", "policy": "acceptable-use-policy"},
+			count:  1,
+			contains: []string{
+				"policy,period,email,name,department,on_roster,acknowledged,acknowledged_at,version,source",
+				"acceptable-use-policy,2025,alex@example.com,Alex,,true,true,2025-02-03T09:30:00Z,1.0,google-forms",
+				"acceptable-use-policy,2025,kim@example.com,Kim,Sales,true,false,,,",
+				"acceptable-use-policy,2025,former@example.com,,,false,true,2025-02-03T09:30:00Z,,csv",
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			report, source, err := tool.Execute(context.Background(), tc.params)
+			require.NoError(t, err)
+			require.NotNil(t, source)
+			assert.Equal(t, "policy-acknowledgment", source.Type)
+			assert.Equal(t, tc.count, source.Metadata["policy_count"])
+			for _, want := range tc.contains {
+				assert.Contains(t, report, want)
+			}
+			for _, unwanted := range tc.excludes {
+				assert.NotContains(t, report, unwanted)
+			}
+		})
+	}
+
+	_, _, err = tool.Execute(context.Background(), map[string]interface{}{"period": "2025-Q5"})
+	assert.ErrorContains(t, err, "invalid acknowledgment period")
+}
+
+func TestPolicyAcknowledgmentTool_NoRoster(t *testing.T) {
+	t.Parallel()
+
+	acks := domain.PolicyAcknowledgments{Policy: "acceptable-use-policy", Period: "2025"}
+	acks.Add(domain.PolicyAcknowledgment{Email: "alex@example.com", AcknowledgedAt: time.Now(), Source: domain.AcknowledgmentSourceCSV})
+	log, err := logger.NewTestLogger()
+	require.NoError(t, err)
+	tool := &PolicyAcknowledgmentTool{logger: log, source: stubPolicyAcknowledgmentSource{acks: []domain.PolicyAcknowledgments{acks}},
+		now: func() time.Time { return time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC) }}
+
+	report, source, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Contains(t, report, "No employee roster has been imported")
+	assert.Contains(t, report, "| acceptable-use-policy | 1 | - | - | - | csv: 1 |")
+	assert.Equal(t, false, source.Metadata["has_roster"])
+}
@@ -0,0 +1,240 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/storage"
+)
+
+// AuthoredPolicySource provides the locally authored policies. It is
+// satisfied by *storage.Storage.
+type AuthoredPolicySource interface {
+	GetAllAuthoredPolicies() ([]domain.AuthoredPolicy, error)
+}
+
+// PolicyReviewTool exports the approval and annual review status of the
+// locally authored policies as evidence for policy management tasks
+type PolicyReviewTool struct {
+	logger   logger.Logger
+	policies AuthoredPolicySource
+	now      func() time.Time
+}
+
+// NewPolicyReviewTool creates a policy review tool reading the authored policies
+func NewPolicyReviewTool(cfg *config.Config, log logger.Logger) Tool {
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		log.Error("Failed to initialize storage for policy review tool",
+			logger.Field{Key: "error", Value: err})
+		return nil
+	}
+	return &PolicyReviewTool{logger: log, policies: store, now: time.Now}
+}
+
+// Name returns the tool name
+func (p *PolicyReviewTool) Name() string {
+	return "policy-review"
+}
+
+// Description returns the tool description
+func (p *PolicyReviewTool) Description() string {
+	return "Export the version, approvals and annual review status of the locally authored policies"
+}
+
+// GetClaudeToolDefinition returns the tool definition for Claude
+func (p *PolicyReviewTool) GetClaudeToolDefinition() models.ClaudeTool {
+	return models.ClaudeTool{
+		Name: p.Name(),
+		Description: "Export the policies authored with 'grctool policy draft': each policy's owner, version, status, " +
+			"who approved which version and when, and when its annual review is due, with overdue reviews and pending " +
+			"approvals called out. Use for evidence that policies are reviewed and approved at least annually.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"output_format": map[string]interface{}{
+					"type":        "string",
+					"description": "markdown report with a summary, or csv of the policies",
+					"enum":        []string{"markdown", "csv"},
+					"default":     "markdown",
+				},
+				"include_drafts": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Include policies that have never been approved",
+					"default":     false,
+				},
+			},
+		},
+	}
+}
+
+// Execute exports the policy review status
+func (p *PolicyReviewTool) Execute(ctx context.Context, params map[string]interface{}) (string, *models.EvidenceSource, error) {
+	format, _ := params["output_format"].(string)
+	includeDrafts, _ := params["include_drafts"].(bool)
+	if format == "" {
+		format = "markdown"
+	}
+
+	all, err := p.policies.GetAllAuthoredPolicies()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to load policies: %w", err)
+	}
+	policies := []domain.AuthoredPolicy{}
+	for _, policy := range all {
+		if policy.ApprovedDate == "" && !includeDrafts {
+			continue
+		}
+		policies = append(policies, policy)
+	}
+	now := p.now()
+
+	var report string
+	switch format {
+	case "markdown":
+		report = formatPolicyReviewMarkdown(policies, now)
+	case "csv":
+		if report, err = formatPolicyReviewCSV(policies, now); err != nil {
+			return "", nil, fmt.Errorf("failed to write policy review: %w", err)
+		}
+	default:
+		return "", nil, fmt.Errorf("unsupported output format: %s", format)
+	}
+
+	overdue := 0
+	for _, policy := range policies {
+		if policy.ReviewStatus(now) == domain.PolicyReviewOverdue {
+			overdue++
+		}
+	}
+	relevance := 0.0
+	if len(policies) > 0 {
+		relevance = 0.9
+	}
+
+	source := &models.EvidenceSource{
+		Type:        "policy-review",
+		Resource:    fmt.Sprintf("Authored policies (%d policies)", len(policies)),
+		Content:     report,
+		Relevance:   relevance,
+		ExtractedAt: now,
+		Metadata: map[string]interface{}{
+			"policy_count":    len(policies),
+			"overdue_reviews": overdue,
+			"include_drafts":  includeDrafts,
+		},
+	}
+	return report, source, nil
+}
+
+func formatPolicyReviewMarkdown(policies []domain.AuthoredPolicy, now time.Time) string {
+	var md strings.Builder
+
+	md.WriteString("# Policy Review\n\n")
+	md.WriteString(fmt.Sprintf("Generated: %s\n\n", now.Format("2006-01-02")))
+
+	if len(policies) == 0 {
+		md.WriteString("No approved policies. Draft and approve them with 'grctool policy draft', 'review' and 'approve'.\n")
+		return md.String()
+	}
+
+	reviews := map[string]int{}
+	for _, policy := range policies {
+		reviews[policy.ReviewStatus(now)]++
+	}
+	md.WriteString("## Summary\n\n")
+	md.WriteString(fmt.Sprintf("- **Policies:** %d\n", len(policies)))
+	md.WriteString(fmt.Sprintf("- **Annual reviews:** %d current, %d due within %d days, %d overdue, %d never approved\n\n",
+		reviews[domain.PolicyReviewCurrent], reviews[domain.PolicyReviewDueSoon], domain.PolicyReviewDueSoonDays,
+		reviews[domain.PolicyReviewOverdue], reviews[domain.PolicyReviewNeverApproved]))
+
+	md.WriteString("## Policies\n\n")
+	md.WriteString("| Policy | Owner | Version | Status | Approved | Approved By | Next Review | Review Status |\n")
+	md.WriteString("|--------|-------|---------|--------|----------|-------------|-------------|---------------|\n")
+	for _, policy := range policies {
+		md.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %s | %s |\n",
+			policy.Title, orDash(policy.Owner), policy.Version, policy.Status, orDash(policy.ApprovedDate),
+			orDash(strings.Join(policyApprovalsOf(policy, policy.ApprovedVersion()), ", ")), orDash(policy.ReviewDate),
+			strings.ReplaceAll(policy.ReviewStatus(now), "_", " ")))
+	}
+
+	var attention []string
+	for _, policy := range policies {
+		var reasons []string
+		if review := policy.ReviewStatus(now); review != domain.PolicyReviewCurrent {
+			reasons = append(reasons, "review "+strings.ReplaceAll(review, "_", " "))
+		}
+		if policy.Status == domain.AuthoredPolicyInReview {
+			if pending := policy.PendingApprovers(); len(pending) > 0 {
+				reasons = append(reasons, fmt.Sprintf("version %s awaiting approval by %s", policy.Version, strings.Join(pending, ", ")))
+			}
+		}
+		if len(reasons) > 0 {
+			attention = append(attention, fmt.Sprintf("- **%s** (%s): %s\n", policy.Title, policy.ID, strings.Join(reasons, "; ")))
+		}
+	}
+	if len(attention) > 0 {
+		md.WriteString("\n## Needing Attention\n\n")
+		md.WriteString(strings.Join(attention, ""))
+	}
+
+	return md.String()
+}
+
+func formatPolicyReviewCSV(policies []domain.AuthoredPolicy, now time.Time) (string, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	header := []string{
+		"id", "title", "owner", "version", "status", "approved_date", "approved_by", "review_date", "review_status",
+		"pending_approvers",
+	}
+	if err := writer.Write(header); err != nil {
+		return "", err
+	}
+	for _, policy := range policies {
+		record := []string{
+			policy.ID, policy.Title, policy.Owner, policy.Version, policy.Status, policy.ApprovedDate,
+			strings.Join(policyApprovalsOf(policy, policy.ApprovedVersion()), "; "), policy.ReviewDate,
+			policy.ReviewStatus(now), strings.Join(policy.PendingApprovers(), "; "),
+		}
+		if err := writer.Write(record); err != nil {
+			return "", err
+		}
+	}
+	writer.Flush()
+	return buf.String(), writer.Error()
+}
+
+// policyApprovalsOf formats the approvals of a version as "approver (date)"
+func policyApprovalsOf(policy domain.AuthoredPolicy, version string) []string {
+	var approvals []string
+	for _, approval := range policy.Approvals {
+		if version != "" && approval.Version == version {
+			approvals = append(approvals, fmt.Sprintf("%s (%s)", approval.Approver, approval.Date))
+		}
+	}
+	return approvals
+}
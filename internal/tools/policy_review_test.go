@@ -0,0 +1,93 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubAuthoredPolicySource []domain.AuthoredPolicy
+
+func (s stubAuthoredPolicySource) GetAllAuthoredPolicies() ([]domain.AuthoredPolicy, error) {
+	return s, nil
+}
+
+func TestPolicyReviewTool_Execute(t *testing.T) {
+	t.Parallel()
+
+	policies := stubAuthoredPolicySource{
+		{ID: "access-control-policy", Title: "Access Control Policy", Owner: "alex@example.com", Version: "1.1",
+			Status: domain.AuthoredPolicyInReview, Approvers: []string{"cto@example.com", "ciso@example.com"},
+			Approvals: []domain.PolicyApproval{
+				{Approver: "cto@example.com", Date: "2024-10-01", Version: "1.0"},
+				{Approver: "ciso@example.com", Date: "2024-10-02", Version: "1.0"},
+				{Approver: "cto@example.com", Date: "2025-10-20", Version: "1.1"},
+			},
+			ApprovedDate: "2024-10-02", ReviewDate: "2025-10-02"},
+		{ID: "encryption-policy", Title: "Encryption Policy", Version: "1.0", Status: domain.AuthoredPolicyApproved,
+			Approvals:    []domain.PolicyApproval{{Approver: "cto@example.com", Date: "2025-06-01", Version: "1.0"}},
+			ApprovedDate: "2025-06-01", ReviewDate: "2026-06-01"},
+		{ID: "vendor-policy", Title: "Vendor Policy", Version: "1.0", Status: domain.AuthoredPolicyDraft},
+	}
+	log, err := logger.NewTestLogger()
+	require.NoError(t, err)
+	now := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	tool := &PolicyReviewTool{logger: log, policies: policies, now: func() time.Time { return now }}
+
+	tests := map[string]struct {
+		params   map[string]interface{}
+		count    int
+		contains []string
+		excludes []string
+	}{
+		"approved policies": {
+			params: map[string]interface{}{},
+			count:  2,
+			contains: []string{
+				"- **Annual reviews:** 1 current, 0 due within 30 days, 1 overdue, 0 never approved",
+				"| Access Control Policy | alex@example.com | 1.1 | review | 2024-10-02 | cto@example.com (2024-10-01), ciso@example.com (2024-10-02) | 2025-10-02 | overdue |",
+				"| Encryption Policy | - | 1.0 | approved | 2025-06-01 | cto@example.com (2025-06-01) | 2026-06-01 | current |",
+				"- **Access Control Policy** (access-control-policy): review overdue; version 1.1 awaiting approval by ciso@example.com",
+			},
+			excludes: []string{"Vendor Policy", "**Encryption Policy**"},
+		},
+		"drafts": {
+			params:   map[string]interface{}{"include_drafts": true},
+			count:    3,
+			contains: []string{"- **Vendor Policy** (vendor-policy): review never approved"},
+		},
+		"csv": {
+			params: map[string]interface{}{"output_format": "csv"},
+			count:  2,
+			contains: []string{
+				"id,title,owner,version,status,approved_date,approved_by,review_date,review_status,pending_approvers",
+				"encryption-policy,Encryption Policy,,1.0,approved,2025-06-01,cto@example.com (2025-06-01),2026-06-01,current,",
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			report, source, err := tool.Execute(context.Background(), tc.params)
+			require.NoError(t, err)
+			require.NotNil(t, source)
+			assert.Equal(t, "policy-review", source.Type)
+			assert.Equal(t, tc.count, source.Metadata["policy_count"])
+			for _, want := range tc.contains {
+				assert.Contains(t, report, want)
+			}
+			for _, unwanted := range tc.excludes {
+				assert.NotContains(t, report, unwanted)
+			}
+		})
+	}
+}
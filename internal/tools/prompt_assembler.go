@@ -27,7 +27,9 @@ import (
 	"github.com/grctool/grctool/internal/config"
 	"github.com/grctool/grctool/internal/domain"
 	"github.com/grctool/grctool/internal/formatters"
+	"github.com/grctool/grctool/internal/frameworks"
 	"github.com/grctool/grctool/internal/interpolation"
+	"github.com/grctool/grctool/internal/llm"
 	"github.com/grctool/grctool/internal/logger"
 	"github.com/grctool/grctool/internal/models"
 	"github.com/grctool/grctool/internal/storage"
@@ -35,7 +37,8 @@ import (
 )
 
 // PromptAssemblerTool provides evidence prompt generation capabilities
-// using template-based approach (no AI API calls)
+// using template-based approach. Only the model configured for prompt
+// assembly under evidence.llm is ever called, to draft a collection plan.
 type PromptAssemblerTool struct {
 	config          *config.Config
 	logger          logger.Logger
@@ -80,7 +83,7 @@ func (pat *PromptAssemblerTool) Name() string {
 
 // Description returns the tool description
 func (pat *PromptAssemblerTool) Description() string {
-	return "Generates comprehensive prompts for evidence collection with context and examples (template-based, no AI API calls)"
+	return "Generates comprehensive prompts for evidence collection with context and examples (template-based; a configured model drafts a collection plan)"
 }
 
 // GetClaudeToolDefinition returns the tool definition for external AI tools
@@ -118,6 +121,11 @@ func (pat *PromptAssemblerTool) GetClaudeToolDefinition() models.ClaudeTool {
 					"description": "Save the generated prompt to a file",
 					"default":     true,
 				},
+				"draft_plan": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Have the model configured under evidence.llm draft a collection plan into the prompt",
+					"default":     true,
+				},
 			},
 			"required": []string{"task_ref"},
 		},
@@ -168,6 +176,13 @@ func (pat *PromptAssemblerTool) Execute(ctx context.Context, params map[string]i
 		}
 	}
 
+	draftPlan := true
+	if dp, exists := params["draft_plan"]; exists {
+		if flag, ok := dp.(bool); ok {
+			draftPlan = flag
+		}
+	}
+
 	// Parse task reference to get numeric ID
 	taskID, err := pat.parseTaskReference(taskRef)
 	if err != nil {
@@ -192,6 +207,15 @@ func (pat *PromptAssemblerTool) Execute(ctx context.Context, params map[string]i
 	// Generate the prompt using template-based approach
 	promptText := pat.generateTemplatePrompt(evidenceContext, outputFormat)
 
+	generationMode := "template-based"
+	if draftPlan {
+		if plan, modelName := pat.draftCollectionPlan(ctx, promptText); plan != "" {
+			promptText = strings.TrimRight(promptText, "\n") +
+				fmt.Sprintf("\n\n## Collection Plan (drafted by %s)\n\n%s\n", modelName, plan)
+			generationMode = "model-assisted"
+		}
+	}
+
 	// Save prompt to file if requested
 	var filePath string
 	if saveToFile {
@@ -214,7 +238,7 @@ func (pat *PromptAssemblerTool) Execute(ctx context.Context, params map[string]i
 		"output_format": %q,
 		"framework_count": %d,
 		"generated_at": %q,
-		"generation_mode": "template-based"
+		"generation_mode": %q
 	},
 	"file_path": %q,
 	"context_summary": {
@@ -223,7 +247,7 @@ func (pat *PromptAssemblerTool) Execute(ctx context.Context, params map[string]i
 		"priority": %q,
 		"status": %q
 	}
-}`, promptText, task.ID, task.ReferenceID, contextLevel, includeExamples, outputFormat, len(evidenceContext.FrameworkReqs), time.Now().Format(time.RFC3339), filePath, task.Name, task.Framework, task.Priority, task.Status)
+}`, promptText, task.ID, task.ReferenceID, contextLevel, includeExamples, outputFormat, len(evidenceContext.FrameworkReqs), time.Now().Format(time.RFC3339), generationMode, filePath, task.Name, task.Framework, task.Priority, task.Status)
 
 	// Create evidence source
 	source := &models.EvidenceSource{
@@ -239,7 +263,7 @@ func (pat *PromptAssemblerTool) Execute(ctx context.Context, params map[string]i
 			"output_format":   outputFormat,
 			"prompt_length":   len(promptText),
 			"file_path":       filePath,
-			"generation_mode": "template-based",
+			"generation_mode": generationMode,
 		},
 	}
 
@@ -249,12 +273,48 @@ func (pat *PromptAssemblerTool) Execute(ctx context.Context, params map[string]i
 		logger.Field{Key: "context_level", Value: contextLevel},
 		logger.Field{Key: "prompt_length", Value: len(promptText)},
 		logger.Field{Key: "file_path", Value: filePath},
-		logger.Field{Key: "generation_mode", Value: "template-based"})
+		logger.Field{Key: "generation_mode", Value: generationMode})
 
 	// Return the JSON response as the main response
 	return responseJSON, source, nil
 }
 
+// draftCollectionPlan has the model configured for prompt assembly under
+// evidence.llm draft a collection plan for the assembled prompt. No plan is drafted when
+// no model is configured or it cannot be used; the prompt then stays as the
+// template made it.
+func (pat *PromptAssemblerTool) draftCollectionPlan(ctx context.Context, promptText string) (plan, modelName string) {
+	llmConfig := pat.config.Evidence.LLM.For(config.LLMOperationPromptAssembly)
+	model, err := llm.NewModel(llmConfig, nil)
+	if err != nil || model == nil {
+		if err != nil {
+			pat.logger.Warn("Model misconfigured, assembling prompt without it", logger.Field{Key: "error", Value: err})
+		}
+		return "", ""
+	}
+	if err := model.Available(ctx); err != nil {
+		pat.logger.Warn("Model unavailable, assembling prompt without it",
+			logger.String("model", model.Name()),
+			logger.Field{Key: "error", Value: err})
+		return "", ""
+	}
+
+	if llmConfig.MaxContextChars > 0 && len(promptText) > llmConfig.MaxContextChars {
+		promptText = strings.ToValidUTF8(promptText[:llmConfig.MaxContextChars], "")
+	}
+	plan, err = model.Generate(ctx, llm.Request{
+		Prompt: "Draft a short, numbered plan for collecting the evidence this compliance task asks for. " +
+			"Name the systems, exports and screenshots to gather and the grctool tools to run. " +
+			"Answer with the plan only, in markdown.\n\n" + promptText,
+		MaxTokens: 1024,
+	})
+	if err != nil {
+		pat.logger.Warn("Model failed to draft a collection plan", logger.Field{Key: "error", Value: err})
+		return "", ""
+	}
+	return strings.TrimSpace(plan), model.Name()
+}
+
 // buildBasicEvidenceContext builds basic evidence context for template-based prompt generation
 func (pat *PromptAssemblerTool) buildBasicEvidenceContext(ctx context.Context, task *domain.EvidenceTask, contextLevel string, includeExamples bool) (*models.EvidenceContext, error) {
 	// Create interpolator from config to substitute template variables
@@ -493,14 +553,52 @@ func (pat *PromptAssemblerTool) extractBasicFrameworkRequirements(task *domain.E
 func (pat *PromptAssemblerTool) getSecurityMappings() models.SecurityMappings {
 	mappings := make(map[string]models.SecurityControlMapping)
 	for k, v := range pat.config.Evidence.SecurityControls.SOC2 {
-		mappings[k] = models.SecurityControlMapping{
-			TerraformResources: v.TerraformResources,
-			Description:        v.Description,
-			Requirements:       v.Requirements,
+		mappings[k] = securityControlMapping(v)
+	}
+
+	// Config keys are lowercased when loaded, so NIST codes are restored to
+	// their canonical form (sc-28 -> SC-28)
+	var nist map[string]models.SecurityControlMapping
+	for k, v := range pat.config.Evidence.SecurityControls.NIST80053 {
+		if nist == nil {
+			nist = make(map[string]models.SecurityControlMapping)
+		}
+		if code, ok := frameworks.NormalizeNISTCode(k); ok {
+			k = code
+		}
+		nist[k] = securityControlMapping(v)
+	}
+
+	// Custom framework controls name their Terraform resources in the
+	// framework definition rather than the config
+	var custom map[string]models.SecurityControlMapping
+	for _, framework := range frameworks.CustomFrameworks() {
+		for _, control := range framework.Controls {
+			if len(control.TerraformResources) == 0 {
+				continue
+			}
+			if custom == nil {
+				custom = make(map[string]models.SecurityControlMapping)
+			}
+			custom[framework.Label+" "+control.Code] = models.SecurityControlMapping{
+				TerraformResources: control.TerraformResources,
+				Description:        control.Title,
+			}
 		}
 	}
+
 	return models.SecurityMappings{
-		SOC2: mappings,
+		SOC2:      mappings,
+		NIST80053: nist,
+		Custom:    custom,
+	}
+}
+
+func securityControlMapping(v config.SecurityControlMapping) models.SecurityControlMapping {
+	return models.SecurityControlMapping{
+		TerraformResources: v.TerraformResources,
+		Description:        v.Description,
+		Requirements:       v.Requirements,
 	}
 }
 
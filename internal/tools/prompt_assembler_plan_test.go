@@ -0,0 +1,61 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromptAssembler_DraftCollectionPlan(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/tags":
+			_, _ = w.Write([]byte(`{"models":[{"name":"llama3.1:8b"}]}`))
+		case "/api/generate":
+			_, _ = w.Write([]byte(`{"response":"1. Run github-permissions\n2. Export the admin list\n"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	tests := map[string]struct {
+		llm       config.LLMConfig
+		wantPlan  string
+		wantModel string
+	}{
+		"no model configured": {},
+		"model drafts plan": {
+			llm:       config.LLMConfig{Provider: "ollama", Model: "llama3.1:8b", BaseURL: server.URL},
+			wantPlan:  "1. Run github-permissions\n2. Export the admin list",
+			wantModel: "ollama/llama3.1:8b",
+		},
+		"model not pulled": {
+			llm: config.LLMConfig{Provider: "ollama", Model: "phi3", BaseURL: server.URL},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			log, err := logger.NewTestLogger()
+			require.NoError(t, err)
+			tool := &PromptAssemblerTool{config: &config.Config{Evidence: config.EvidenceConfig{LLM: tc.llm}}, logger: log}
+
+			plan, model := tool.draftCollectionPlan(context.Background(), "# Evidence Collection Task: Access Review")
+			assert.Equal(t, tc.wantPlan, plan)
+			assert.Equal(t, tc.wantModel, model)
+		})
+	}
+}
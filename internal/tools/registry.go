@@ -21,12 +21,16 @@ import (
 	"sort"
 	"sync"
 
+	"github.com/grctool/grctool/internal/logger"
 	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Registry manages the registration and discovery of evidence collection tools
 type Registry struct {
 	tools map[string]Tool
+	cache *ToolResultCache // Results of cacheable tools; nil runs every tool
 	mutex sync.RWMutex
 }
 
@@ -57,6 +61,14 @@ func (r *Registry) Reset() {
 	r.tools = make(map[string]Tool)
 }
 
+// SetCache sets the cache that results of cacheable tools are reused from;
+// nil disables it
+func (r *Registry) SetCache(cache *ToolResultCache) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.cache = cache
+}
+
 // Register adds a tool to the registry
 func (r *Registry) Register(tool Tool) error {
 	r.mutex.Lock()
@@ -163,14 +175,57 @@ func (r *Registry) Count() int {
 	return len(r.tools)
 }
 
-// Execute runs a tool with the given parameters
-func (r *Registry) Execute(ctx context.Context, toolName string, params map[string]interface{}) (string, *models.EvidenceSource, error) {
+// Execute runs a tool with the given parameters, in a span of the tool's
+// name. A cacheable tool's result is reused from the cache while its
+// parameters and sources are unchanged.
+func (r *Registry) Execute(ctx context.Context, toolName string, params map[string]interface{}) (result string, source *models.EvidenceSource, err error) {
 	tool, err := r.Get(toolName)
 	if err != nil {
 		return "", nil, err
 	}
+	r.mutex.RLock()
+	cache := r.cache
+	r.mutex.RUnlock()
+
+	ctx, span := telemetry.Start(ctx, "tool "+toolName, attribute.String("tool.name", toolName))
+	defer func() {
+		span.SetAttributes(attribute.Int("tool.result_bytes", len(result)))
+		telemetry.End(span, err)
+	}()
+
+	if err := ValidateParams(tool, params); err != nil {
+		return "", nil, err
+	}
 
-	return tool.Execute(ctx, params)
+	var fingerprint string
+	if cacheable, ok := tool.(CacheableTool); !ok {
+		cache = nil
+	} else if cache != nil {
+		if fingerprint, err = cacheable.SourceFingerprint(ctx, params); err != nil {
+			cache.logger.Debug("not caching tool result", logger.String("tool", toolName), logger.Error(err))
+			cache, err = nil, nil
+		} else if !cacheDeclined(params) {
+			if cached, cachedSource, hit := cache.Get(toolName, params, fingerprint); hit {
+				span.SetAttributes(attribute.Bool("tool.cache_hit", true))
+				return cached, cachedSource, nil
+			}
+		}
+	}
+	if cache != nil {
+		span.SetAttributes(attribute.Bool("tool.cache_hit", false))
+	}
+
+	result, source, err = tool.Execute(ctx, params)
+	if err != nil {
+		return result, source, err
+	}
+	if err := ValidateOutput(tool, result); err != nil {
+		return result, source, err
+	}
+	if cache != nil {
+		cache.Put(toolName, params, fingerprint, result, source)
+	}
+	return result, source, nil
 }
 
 // GetClaudeToolDefinitions returns Claude tool definitions for all registered tools
@@ -191,6 +246,56 @@ func (r *Registry) GetClaudeToolDefinitions() []models.ClaudeTool {
 	return definitions
 }
 
+// ToolSchema describes a registered tool and the input it accepts, from its
+// Claude tool definition
+type ToolSchema struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Category    string                 `json:"category,omitempty"`
+	Version     string                 `json:"version,omitempty"`
+	InputSchema map[string]interface{} `json:"input_schema"` // JSON Schema of the tool's parameters
+}
+
+// ToolCatalog lists every registered tool with its input schema, so
+// orchestrators can discover what the tools accept
+type ToolCatalog struct {
+	SchemaVersion string       `json:"schema_version"`
+	Tools         []ToolSchema `json:"tools"`
+}
+
+// Catalog returns the schemas of all registered tools, sorted by name
+func (r *Registry) Catalog() ToolCatalog {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	catalog := ToolCatalog{SchemaVersion: "1", Tools: make([]ToolSchema, 0, len(r.tools))}
+	for _, tool := range r.tools {
+		definition := tool.GetClaudeToolDefinition()
+		schema := ToolSchema{
+			Name:        tool.Name(),
+			Description: definition.Description,
+			InputSchema: definition.InputSchema,
+		}
+		if schema.Description == "" {
+			schema.Description = tool.Description()
+		}
+		if schema.InputSchema == nil {
+			schema.InputSchema = map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+		}
+		if extended, ok := tool.(ExtendedTool); ok {
+			schema.Version = extended.Version()
+			schema.Category = extended.Category()
+		}
+		catalog.Tools = append(catalog.Tools, schema)
+	}
+
+	sort.Slice(catalog.Tools, func(i, j int) bool {
+		return catalog.Tools[i].Name < catalog.Tools[j].Name
+	})
+
+	return catalog
+}
+
 // ExtendedTool is an optional interface for tools that provide additional metadata
 type ExtendedTool interface {
 	Tool
@@ -225,6 +330,11 @@ func ExecuteTool(ctx context.Context, toolName string, params map[string]interfa
 	return GlobalRegistry.Execute(ctx, toolName, params)
 }
 
+// GetToolCatalog returns the schemas of all tools in the global registry
+func GetToolCatalog() ToolCatalog {
+	return GlobalRegistry.Catalog()
+}
+
 // GetAllClaudeToolDefinitions returns Claude tool definitions for all registered tools
 func GetAllClaudeToolDefinitions() []models.ClaudeTool {
 	return GlobalRegistry.GetClaudeToolDefinitions()
@@ -143,6 +143,38 @@ func InitializeToolRegistry(cfg *config.Config, log logger.Logger) error {
 		}
 	}
 
+	if vendorReviewTool := NewVendorReviewTool(cfg, log); vendorReviewTool != nil {
+		if err := RegisterTool(vendorReviewTool); err != nil {
+			log.Error("Failed to register vendor review tool", logger.Field{Key: "error", Value: err})
+		} else {
+			log.Debug("Registered vendor review tool")
+		}
+	}
+
+	if incidentLogTool := NewIncidentLogTool(cfg, log); incidentLogTool != nil {
+		if err := RegisterTool(incidentLogTool); err != nil {
+			log.Error("Failed to register incident log tool", logger.Field{Key: "error", Value: err})
+		} else {
+			log.Debug("Registered incident log tool")
+		}
+	}
+
+	if policyReviewTool := NewPolicyReviewTool(cfg, log); policyReviewTool != nil {
+		if err := RegisterTool(policyReviewTool); err != nil {
+			log.Error("Failed to register policy review tool", logger.Field{Key: "error", Value: err})
+		} else {
+			log.Debug("Registered policy review tool")
+		}
+	}
+
+	if policyAcknowledgmentTool := NewPolicyAcknowledgmentTool(cfg, log); policyAcknowledgmentTool != nil {
+		if err := RegisterTool(policyAcknowledgmentTool); err != nil {
+			log.Error("Failed to register policy acknowledgment tool", logger.Field{Key: "error", Value: err})
+		} else {
+			log.Debug("Registered policy acknowledgment tool")
+		}
+	}
+
 	// Register enhanced data source tools
 	if terraformSecurityTool := NewTerraformSecurityAnalyzerAdapter(cfg, log); terraformSecurityTool != nil {
 		if err := RegisterTool(terraformSecurityTool); err != nil {
@@ -327,6 +359,17 @@ func InitializeToolRegistry(cfg *config.Config, log logger.Logger) error {
 		}
 	}
 
+	// Register external evidence collectors declared in the config
+	for _, pluginCfg := range cfg.Evidence.Tools.Plugins {
+		if err := RegisterTool(NewPluginTool(pluginCfg, log)); err != nil {
+			log.Error("Failed to register plugin tool",
+				logger.Field{Key: "plugin", Value: pluginCfg.Name},
+				logger.Field{Key: "error", Value: err})
+		} else {
+			log.Debug("Registered plugin tool", logger.Field{Key: "plugin", Value: pluginCfg.Name})
+		}
+	}
+
 	log.Info("Tool registry initialization completed",
 		logger.Field{Key: "total_tools", Value: GlobalRegistry.Count()})
 
@@ -386,7 +429,11 @@ func GetDataSourceTools() []ToolInfo {
 		"github-workflow-analyzer": true,
 		"github-review-analyzer":   true,
 		// "github-permissions-refactored": true, // TODO: implement
-		"docs-reader": true,
+		"docs-reader":           true,
+		"vendor-review":         true,
+		"incident-log":          true,
+		"policy-review":         true,
+		"policy-acknowledgment": true,
 	}
 
 	for _, tool := range allTools {
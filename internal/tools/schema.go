@@ -0,0 +1,370 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Targets of schema validation
+const (
+	SchemaTargetParameters = "parameters"
+	SchemaTargetOutput     = "output"
+)
+
+// OutputSchemaTool is an optional interface for tools whose result is a JSON
+// document, declaring its JSON Schema so results are checked like parameters
+type OutputSchemaTool interface {
+	Tool
+	OutputSchema() map[string]interface{}
+}
+
+// SchemaValidationError reports every way a tool's parameters or output fail
+// to match the tool's JSON Schema
+type SchemaValidationError struct {
+	Tool   string            `json:"tool"`
+	Target string            `json:"target"` // parameters or output
+	Errors []ValidationError `json:"errors"`
+}
+
+// Error implements the error interface
+func (e *SchemaValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = fmt.Sprintf("%s %s", err.Field, err.Message)
+	}
+	return fmt.Sprintf("invalid %s %s: %s", e.Tool, e.Target, strings.Join(messages, "; "))
+}
+
+// ValidateParams checks tool parameters against the tool's input schema
+func ValidateParams(tool Tool, params map[string]interface{}) error {
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+	if errs := ValidateSchema(tool.GetClaudeToolDefinition().InputSchema, params); len(errs) > 0 {
+		return &SchemaValidationError{Tool: tool.Name(), Target: SchemaTargetParameters, Errors: errs}
+	}
+	return nil
+}
+
+// ValidateOutput checks a tool's result against its output schema, when the
+// tool declares one
+func ValidateOutput(tool Tool, result string) error {
+	outputTool, ok := tool.(OutputSchemaTool)
+	if !ok {
+		return nil
+	}
+	var document interface{}
+	if err := json.Unmarshal([]byte(result), &document); err != nil {
+		return &SchemaValidationError{Tool: tool.Name(), Target: SchemaTargetOutput, Errors: []ValidationError{{
+			Field:   "$",
+			Rule:    "type",
+			Message: fmt.Sprintf("is not JSON: %v", err),
+		}}}
+	}
+	if errs := ValidateSchema(outputTool.OutputSchema(), document); len(errs) > 0 {
+		return &SchemaValidationError{Tool: tool.Name(), Target: SchemaTargetOutput, Errors: errs}
+	}
+	return nil
+}
+
+// IntParam returns an integer parameter whatever numeric type the caller
+// used: commands pass ints while JSON decodes to float64. The input schema has
+// already checked the value is integral.
+func IntParam(params map[string]interface{}, name string) (int, bool) {
+	number, ok := schemaNumber(params[name])
+	return int(number), ok
+}
+
+// ValidateSchema checks a value against a JSON Schema, returning an error for
+// each mismatch. It covers the keywords tool schemas use: type, properties,
+// required, additionalProperties, items, enum, oneOf, pattern, minimum,
+// maximum and the length and item count limits. Annotations such as default,
+// format and description are not checked.
+func ValidateSchema(schema map[string]interface{}, value interface{}) []ValidationError {
+	var errs []ValidationError
+	validateSchema(schema, value, "$", &errs)
+	return errs
+}
+
+func validateSchema(schema map[string]interface{}, value interface{}, path string, errs *[]ValidationError) {
+	if len(schema) == 0 {
+		return
+	}
+	fail := func(rule, format string, args ...interface{}) {
+		*errs = append(*errs, ValidationError{
+			Field:   path,
+			Value:   schemaValueString(value),
+			Rule:    rule,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	if types := schemaStrings(schema["type"]); len(types) > 0 {
+		matched := false
+		for _, t := range types {
+			if schemaTypeMatches(t, value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			fail("type", "must be of type %s", strings.Join(types, " or "))
+			return // Further keywords would only repeat the mismatch
+		}
+	}
+
+	if enum := schemaList(schema["enum"]); len(enum) > 0 {
+		allowed := make([]string, len(enum))
+		found := false
+		for i, candidate := range enum {
+			allowed[i] = fmt.Sprint(candidate)
+			if schemaEqual(candidate, value) {
+				found = true
+			}
+		}
+		if !found {
+			fail("enum", "must be one of: %s", strings.Join(allowed, ", "))
+		}
+	}
+
+	if variants := schemaList(schema["oneOf"]); len(variants) > 0 {
+		matches := 0
+		for _, variant := range variants {
+			if sub, ok := schemaMap(variant); ok {
+				var variantErrs []ValidationError
+				validateSchema(sub, value, path, &variantErrs)
+				if len(variantErrs) == 0 {
+					matches++
+				}
+			}
+		}
+		if matches != 1 {
+			fail("oneOf", "must match exactly one of %d alternatives, matched %d", len(variants), matches)
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		length := len([]rune(v))
+		if min, ok := schemaNumber(schema["minLength"]); ok && float64(length) < min {
+			fail("minLength", "must be at least %v characters long", min)
+		}
+		if max, ok := schemaNumber(schema["maxLength"]); ok && float64(length) > max {
+			fail("maxLength", "must be no more than %v characters long", max)
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(v) {
+				fail("pattern", "must match pattern %s", pattern)
+			}
+		}
+		return
+	}
+
+	if number, ok := schemaNumber(value); ok {
+		if min, ok := schemaNumber(schema["minimum"]); ok && number < min {
+			fail("minimum", "must be at least %v", min)
+		}
+		if max, ok := schemaNumber(schema["maximum"]); ok && number > max {
+			fail("maximum", "must be at most %v", max)
+		}
+		return
+	}
+
+	if object, ok := schemaObject(value); ok {
+		for _, name := range schemaStrings(schema["required"]) {
+			if _, present := object[name]; !present {
+				*errs = append(*errs, ValidationError{
+					Field:   path + "." + name,
+					Rule:    "required",
+					Message: "is required",
+				})
+			}
+		}
+
+		properties, _ := schemaMap(schema["properties"])
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if sub, ok := schemaMap(properties[name]); ok {
+				validateSchema(sub, object[name], path+"."+name, errs)
+			} else if additional, ok := schema["additionalProperties"].(bool); ok && !additional {
+				*errs = append(*errs, ValidationError{
+					Field:   path + "." + name,
+					Rule:    "additionalProperties",
+					Message: "is not a known property",
+				})
+			}
+		}
+		return
+	}
+
+	if items, ok := schemaArray(value); ok {
+		if min, ok := schemaNumber(schema["minItems"]); ok && float64(len(items)) < min {
+			fail("minItems", "must have at least %v items", min)
+		}
+		if max, ok := schemaNumber(schema["maxItems"]); ok && float64(len(items)) > max {
+			fail("maxItems", "must have no more than %v items", max)
+		}
+		if sub, ok := schemaMap(schema["items"]); ok {
+			for i, item := range items {
+				validateSchema(sub, item, fmt.Sprintf("%s[%d]", path, i), errs)
+			}
+		}
+	}
+}
+
+// schemaTypeMatches reports whether a Go value, as decoded from JSON or
+// built by a command, is of a JSON Schema type
+func schemaTypeMatches(schemaType string, value interface{}) bool {
+	switch schemaType {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "integer":
+		number, ok := schemaNumber(value)
+		return ok && number == math.Trunc(number)
+	case "number":
+		_, ok := schemaNumber(value)
+		return ok
+	case "object":
+		_, ok := schemaObject(value)
+		return ok
+	case "array":
+		_, ok := schemaArray(value)
+		return ok
+	case "null":
+		return value == nil
+	default:
+		return true // Unknown types are not enforced
+	}
+}
+
+// schemaNumber returns a numeric Go value as a float64
+func schemaNumber(value interface{}) (float64, bool) {
+	if value == nil {
+		return 0, false
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	if number, ok := value.(json.Number); ok {
+		f, err := number.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// schemaObject returns a map with string keys as a generic object
+func schemaObject(value interface{}) (map[string]interface{}, bool) {
+	if object, ok := value.(map[string]interface{}); ok {
+		return object, true
+	}
+	if value == nil {
+		return nil, false
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	object := make(map[string]interface{}, v.Len())
+	for _, key := range v.MapKeys() {
+		object[key.String()] = v.MapIndex(key).Interface()
+	}
+	return object, true
+}
+
+// schemaArray returns a slice or array as a generic list
+func schemaArray(value interface{}) ([]interface{}, bool) {
+	if items, ok := value.([]interface{}); ok {
+		return items, true
+	}
+	if value == nil {
+		return nil, false
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, false
+	}
+	items := make([]interface{}, v.Len())
+	for i := range items {
+		items[i] = v.Index(i).Interface()
+	}
+	return items, true
+}
+
+// schemaMap returns a subschema, which tool definitions write as either map type
+func schemaMap(value interface{}) (map[string]interface{}, bool) {
+	object, ok := schemaObject(value)
+	return object, ok && object != nil
+}
+
+// schemaList returns a keyword's list value, such as enum or oneOf
+func schemaList(value interface{}) []interface{} {
+	items, _ := schemaArray(value)
+	return items
+}
+
+// schemaStrings returns a keyword that is a string or list of strings, such
+// as type or required
+func schemaStrings(value interface{}) []string {
+	if s, ok := value.(string); ok {
+		return []string{s}
+	}
+	var strs []string
+	for _, item := range schemaList(value) {
+		if s, ok := item.(string); ok {
+			strs = append(strs, s)
+		}
+	}
+	return strs
+}
+
+// schemaEqual compares an enum value with a value, numbers by value
+func schemaEqual(a, b interface{}) bool {
+	if x, ok := schemaNumber(a); ok {
+		y, ok := schemaNumber(b)
+		return ok && x == y
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// schemaValueString shortens a value for an error report
+func schemaValueString(value interface{}) string {
+	s := fmt.Sprint(value)
+	if len(s) > 80 {
+		s = s[:77] + "..."
+	}
+	return s
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/grctool/grctool/internal/config"
 	"github.com/grctool/grctool/internal/logger"
 	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/storage"
 	"gopkg.in/yaml.v3"
 )
 
@@ -106,8 +107,8 @@ func (s *StorageReadTool) Execute(ctx context.Context, params map[string]interfa
 		return "", nil, fmt.Errorf("path is not a regular file: %s", cleanPath)
 	}
 
-	// Read file content
-	content, err := os.ReadFile(cleanPath)
+	// Read file content, decrypting encrypted evidence
+	content, err := storage.ReadFile(cleanPath)
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to read file: %w", err)
 	}
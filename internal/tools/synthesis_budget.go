@@ -0,0 +1,65 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"fmt"
+
+	"github.com/grctool/grctool/internal/models"
+)
+
+// SynthesisBudget bounds what a batch of drafts may spend. A limit of zero
+// is unlimited.
+type SynthesisBudget struct {
+	MaxTokens int
+	MaxCost   float64 // USD
+
+	Spent  models.ModelUsage
+	Drafts int
+}
+
+// Spend adds a draft's usage to the budget
+func (b *SynthesisBudget) Spend(usage models.ModelUsage) {
+	b.Spent.InputTokens += usage.InputTokens
+	b.Spent.OutputTokens += usage.OutputTokens
+	b.Spent.CostUSD += usage.CostUSD
+	b.Drafts++
+}
+
+// Exhausted returns why another draft does not fit in the budget, or "" when
+// it does. The next draft is expected to spend what the drafts so far did on
+// average, so a batch stops before a draft would overrun a limit.
+func (b *SynthesisBudget) Exhausted() string {
+	if b.MaxTokens > 0 {
+		next := 0
+		if b.Drafts > 0 {
+			next = b.Spent.Tokens() / b.Drafts
+		}
+		if b.Spent.Tokens() >= b.MaxTokens || b.Spent.Tokens()+next > b.MaxTokens {
+			return fmt.Sprintf("%d of the %d token budget spent", b.Spent.Tokens(), b.MaxTokens)
+		}
+	}
+	if b.MaxCost > 0 {
+		next := 0.0
+		if b.Drafts > 0 {
+			next = b.Spent.CostUSD / float64(b.Drafts)
+		}
+		if b.Spent.CostUSD >= b.MaxCost || b.Spent.CostUSD+next > b.MaxCost {
+			return fmt.Sprintf("$%.4f of the $%.2f budget spent", b.Spent.CostUSD, b.MaxCost)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,41 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"testing"
+
+	"github.com/grctool/grctool/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSynthesisBudget_Exhausted(t *testing.T) {
+	t.Parallel()
+
+	draft := models.ModelUsage{InputTokens: 3000, OutputTokens: 1000, CostUSD: 0.03}
+	tests := map[string]struct {
+		budget SynthesisBudget
+		drafts int
+		want   string
+	}{
+		"unlimited":                   {drafts: 50},
+		"first draft always starts":   {budget: SynthesisBudget{MaxTokens: 100}},
+		"room for another draft":      {budget: SynthesisBudget{MaxTokens: 12000}, drafts: 2},
+		"next draft would overrun":    {budget: SynthesisBudget{MaxTokens: 11000}, drafts: 2, want: "8000 of the 11000 token budget spent"},
+		"tokens spent":                {budget: SynthesisBudget{MaxTokens: 4000}, drafts: 1, want: "4000 of the 4000 token budget spent"},
+		"cost spent":                  {budget: SynthesisBudget{MaxCost: 0.10}, drafts: 3, want: "$0.0900 of the $0.10 budget spent"},
+		"cost room with tokens spare": {budget: SynthesisBudget{MaxTokens: 100000, MaxCost: 0.10}, drafts: 2},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			budget := tc.budget
+			for range tc.drafts {
+				budget.Spend(draft)
+			}
+			assert.Equal(t, tc.want, budget.Exhausted())
+		})
+	}
+}
@@ -117,6 +117,16 @@ func (tt *TerraformTool) GetClaudeToolDefinition() models.ClaudeTool {
 	}
 }
 
+// terraformExtensions are the files whose changes invalidate cached results
+// of the Terraform tools
+var terraformExtensions = []string{".tf", ".tfvars", ".hcl"}
+
+// SourceFingerprint fingerprints the configured scan paths for the result
+// cache
+func (tt *TerraformTool) SourceFingerprint(ctx context.Context, _ map[string]interface{}) (string, error) {
+	return PathsFingerprint(ctx, tt.config.ScanPaths, terraformExtensions...)
+}
+
 // Execute runs the Terraform analyzer with the given parameters
 func (tt *TerraformTool) Execute(ctx context.Context, params map[string]interface{}) (string, *models.EvidenceSource, error) {
 	tt.logger.Debug("Executing Terraform analyzer", logger.Field{Key: "params", Value: params})
@@ -0,0 +1,142 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"strings"
+
+	"github.com/grctool/grctool/internal/frameworks"
+)
+
+// annexAResourceMappings maps resource types to the ISO 27001:2022 Annex A
+// controls they provide evidence for
+var annexAResourceMappings = map[string][]string{
+	// AWS IAM
+	"aws_iam_role":                   {"A.5.15", "A.5.18", "A.8.2"},
+	"aws_iam_policy":                 {"A.5.15", "A.5.18", "A.8.2"},
+	"aws_iam_user":                   {"A.5.16", "A.5.18"},
+	"aws_iam_group":                  {"A.5.15", "A.5.18"},
+	"aws_iam_access_key":             {"A.5.17", "A.8.5"},
+	"aws_iam_role_policy_attachment": {"A.5.15", "A.5.18", "A.8.2"},
+
+	// AWS Network Security
+	"aws_vpc":              {"A.8.20", "A.8.22"},
+	"aws_security_group":   {"A.8.20", "A.8.22"},
+	"aws_nacl":             {"A.8.20", "A.8.22"},
+	"aws_network_acl":      {"A.8.20", "A.8.22"},
+	"aws_subnet":           {"A.8.20", "A.8.22"},
+	"aws_route_table":      {"A.8.20", "A.8.22"},
+	"aws_internet_gateway": {"A.8.20", "A.8.21"},
+	"aws_nat_gateway":      {"A.8.20", "A.8.21"},
+
+	// AWS Load Balancing & SSL
+	"aws_lb":                      {"A.8.20", "A.8.24"},
+	"aws_lb_listener":             {"A.8.20", "A.8.24"},
+	"aws_alb":                     {"A.8.20", "A.8.24"},
+	"aws_cloudfront_distribution": {"A.8.21", "A.8.24"},
+	"aws_acm_certificate":         {"A.8.24"},
+
+	// AWS Encryption & Data Protection
+	"aws_kms_key":                       {"A.8.24"},
+	"aws_kms_alias":                     {"A.8.24"},
+	"aws_s3_bucket":                     {"A.5.33", "A.8.24"},
+	"aws_s3_bucket_policy":              {"A.5.15", "A.8.3"},
+	"aws_s3_bucket_encryption":          {"A.8.24"},
+	"aws_s3_bucket_public_access_block": {"A.8.3", "A.8.12"},
+	"aws_s3_bucket_versioning":          {"A.8.13"},
+	"aws_ebs_encryption_by_default":     {"A.8.24"},
+	"aws_rds_cluster":                   {"A.8.14", "A.8.24"},
+	"aws_db_instance":                   {"A.8.24"},
+	"aws_backup_plan":                   {"A.8.13"},
+	"aws_backup_vault":                  {"A.8.13", "A.8.24"},
+
+	// AWS Monitoring & Logging
+	"aws_cloudtrail":                    {"A.8.15", "A.8.16"},
+	"aws_cloudwatch_log_group":          {"A.8.15"},
+	"aws_cloudwatch_metric_alarm":       {"A.8.16"},
+	"aws_config_configuration_recorder": {"A.8.9", "A.8.16"},
+	"aws_guardduty_detector":            {"A.5.7", "A.8.16"},
+
+	// Autoscaling resources (capacity management)
+	"aws_autoscaling_group":               {"A.8.6", "A.8.14"},
+	"aws_autoscaling_policy":              {"A.8.6"},
+	"aws_appautoscaling_target":           {"A.8.6"},
+	"aws_appautoscaling_policy":           {"A.8.6"},
+	"aws_appautoscaling_scheduled_action": {"A.8.6"},
+	"aws_ecs_service":                     {"A.8.6"},
+	"aws_eks_node_group":                  {"A.8.6", "A.8.14"},
+
+	// Azure equivalents
+	"azurerm_resource_group":         {"A.5.9"},
+	"azurerm_virtual_network":        {"A.8.20", "A.8.22"},
+	"azurerm_network_security_group": {"A.8.20", "A.8.22"},
+	"azurerm_key_vault":              {"A.5.17", "A.8.24"},
+	"azurerm_storage_account":        {"A.5.33", "A.8.24"},
+
+	// Google Cloud equivalents
+	"google_compute_network":     {"A.8.20", "A.8.22"},
+	"google_compute_firewall":    {"A.8.20", "A.8.22"},
+	"google_kms_crypto_key":      {"A.8.24"},
+	"google_storage_bucket":      {"A.5.33", "A.8.24"},
+	"google_project_iam_binding": {"A.5.15", "A.5.18", "A.8.2"},
+}
+
+// AnnexAControls returns the ISO 27001 Annex A controls that a resource type
+// relates to, falling back to keyword matching for unknown resource types
+func AnnexAControls(resourceType string) []string {
+	if controls, exists := annexAResourceMappings[resourceType]; exists {
+		return controls
+	}
+
+	resourceLower := strings.ToLower(resourceType)
+	var controls []string
+
+	if strings.Contains(resourceLower, "iam") || strings.Contains(resourceLower, "auth") || strings.Contains(resourceLower, "access") {
+		controls = append(controls, "A.5.15", "A.5.18")
+	}
+	if strings.Contains(resourceLower, "network") || strings.Contains(resourceLower, "firewall") || strings.Contains(resourceLower, "security_group") {
+		controls = append(controls, "A.8.20", "A.8.22")
+	}
+	if strings.Contains(resourceLower, "encrypt") || strings.Contains(resourceLower, "kms") || strings.Contains(resourceLower, "key") {
+		controls = append(controls, "A.8.24")
+	}
+	if strings.Contains(resourceLower, "backup") || strings.Contains(resourceLower, "snapshot") {
+		controls = append(controls, "A.8.13")
+	}
+	if strings.Contains(resourceLower, "log") || strings.Contains(resourceLower, "monitor") || strings.Contains(resourceLower, "audit") {
+		controls = append(controls, "A.8.15", "A.8.16")
+	}
+
+	return controls
+}
+
+// ResourceControls returns the controls of the framework that a resource
+// relates to. SOC2 relevance is computed when the resource is scanned and
+// stored in the index, so it is passed in; Annex A, NIST 800-53, PCI DSS and
+// HIPAA controls are looked up from the resource type.
+func ResourceControls(framework, resourceType string, soc2Relevance []string) []string {
+	switch framework {
+	case frameworks.ISO27001:
+		return AnnexAControls(resourceType)
+	case frameworks.NIST80053:
+		return NISTControls(resourceType)
+	case frameworks.PCIDSS:
+		return PCIControls(resourceType)
+	case frameworks.HIPAA:
+		return HIPAAControls(resourceType)
+	}
+	return soc2Relevance
+}
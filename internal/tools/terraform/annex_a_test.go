@@ -0,0 +1,126 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package terraform
+
+import (
+	"testing"
+
+	"github.com/grctool/grctool/internal/frameworks"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnexAControls(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		resourceType string
+		want         []string
+	}{
+		"mapped":          {resourceType: "aws_kms_key", want: []string{"A.8.24"}},
+		"logging":         {resourceType: "aws_cloudtrail", want: []string{"A.8.15", "A.8.16"}},
+		"keyword network": {resourceType: "oci_core_network_security_group", want: []string{"A.8.20", "A.8.22"}},
+		"keyword backup":  {resourceType: "oci_database_backup", want: []string{"A.8.13"}},
+		"unrelated":       {resourceType: "random_pet", want: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, AnnexAControls(tc.resourceType))
+		})
+	}
+}
+
+func TestAnnexAControls_InCatalog(t *testing.T) {
+	t.Parallel()
+
+	for resourceType, controls := range annexAResourceMappings {
+		for _, control := range controls {
+			_, ok := frameworks.LookupAnnexA(control)
+			assert.True(t, ok, "%s maps to unknown Annex A control %s", resourceType, control)
+		}
+	}
+}
+
+func TestResourceControls(t *testing.T) {
+	t.Parallel()
+
+	soc2 := []string{"CC6.1", "CC6.8"}
+	assert.Equal(t, soc2, ResourceControls(frameworks.SOC2, "aws_s3_bucket", soc2))
+	assert.Equal(t, []string{"A.5.33", "A.8.24"}, ResourceControls(frameworks.ISO27001, "aws_s3_bucket", soc2))
+}
+
+func TestIndexQuery_ByAnnexAControl(t *testing.T) {
+	t.Parallel()
+
+	iq := NewIndexQuery(&PersistedIndex{Index: &SecurityIndex{IndexedResources: []IndexedResource{
+		{ResourceID: "aws_kms_key.main", ResourceType: "aws_kms_key"},
+		{ResourceID: "aws_cloudtrail.audit", ResourceType: "aws_cloudtrail"},
+		{ResourceID: "aws_security_group.web", ResourceType: "aws_security_group"},
+	}}})
+
+	result := iq.ByAnnexAControl("A.8.24", "8.15")
+	require.Equal(t, 2, result.Count)
+	assert.Equal(t, "aws_kms_key.main", result.Resources[0].ResourceID)
+	assert.Equal(t, "aws_cloudtrail.audit", result.Resources[1].ResourceID)
+	assert.Equal(t, "by_annex_a_control", result.Metadata["query_type"])
+
+	assert.Zero(t, iq.ByAnnexAControl("A.7.4").Count)
+}
+
+func TestBuildSARIFLog_AnnexA(t *testing.T) {
+	t.Parallel()
+
+	analyzer := &SecurityAnalyzer{}
+	group := analyzer.extractSecurityResource(models.TerraformScanResult{
+		ResourceType:  "aws_security_group",
+		ResourceName:  "web",
+		FilePath:      "infra/network.tf",
+		Configuration: map[string]interface{}{"ingress_cidr_blocks": "0.0.0.0/0"},
+	}, false)
+	assert.Equal(t, []string{"A.8.20", "A.8.22"}, group.AnnexAControls)
+
+	log := BuildSARIFLog(&SecurityAnalysisResult{
+		Framework:         frameworks.ISO27001,
+		SecurityResources: []SecurityResource{group},
+	}, "")
+
+	run := log.Runs[0]
+	require.Len(t, run.Tool.Driver.Rules, 1)
+	assert.Equal(t, []string{"security", "network", "iso27001/A.8.20", "iso27001/A.8.22"},
+		run.Tool.Driver.Rules[0].Properties["tags"])
+	require.Len(t, run.Results, 1)
+	assert.Equal(t, []string{"A.8.20", "A.8.22"}, run.Results[0].Properties["iso27001_controls"])
+	assert.NotContains(t, run.Results[0].Properties, "soc2_controls")
+}
+
+func TestHCLParser_ApplyFramework(t *testing.T) {
+	t.Parallel()
+
+	newResult := func() *models.TerraformParseResult {
+		return &models.TerraformParseResult{Modules: []models.TerraformModule{{
+			Resources: []models.TerraformResource{
+				{Type: "aws_kms_key", SecurityRelevance: []string{"CC6.8"}},
+				{Type: "aws_cloudtrail", SecurityRelevance: []string{"CC7.2"}},
+			},
+			SecurityRelevance: []string{"CC6.8", "CC7.2"},
+		}}}
+	}
+	parser := &HCLParser{}
+
+	soc2 := newResult()
+	parser.ApplyFramework(soc2, frameworks.SOC2)
+	assert.Equal(t, frameworks.SOC2, soc2.Framework)
+	assert.Equal(t, []string{"CC6.8", "CC7.2"}, soc2.Modules[0].SecurityRelevance, "SOC2 relevance is kept")
+
+	iso := newResult()
+	parser.ApplyFramework(iso, frameworks.ISO27001)
+	assert.Equal(t, frameworks.ISO27001, iso.Framework)
+	module := iso.Modules[0]
+	assert.Equal(t, []string{"A.8.24"}, module.Resources[0].SecurityRelevance)
+	assert.Equal(t, []string{"A.8.15", "A.8.16"}, module.Resources[1].SecurityRelevance)
+	assert.Equal(t, []string{"A.8.15", "A.8.16", "A.8.24"}, module.SecurityRelevance)
+}
@@ -0,0 +1,869 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/grctool/grctool/internal/frameworks"
+	"github.com/grctool/grctool/internal/models"
+)
+
+// CIS benchmarks the check pack covers
+const (
+	CISBenchmarkAWS = "aws"
+	CISBenchmarkGCP = "gcp"
+	CISBenchmarkAll = "all"
+)
+
+// CIS check statuses
+const (
+	CISStatusPass          = "pass"
+	CISStatusFail          = "fail"
+	CISStatusNotApplicable = "not_applicable"
+)
+
+// CISBenchmarks lists the benchmarks in evaluation order
+var CISBenchmarks = []string{CISBenchmarkAWS, CISBenchmarkGCP}
+
+// cisBenchmarkLabels names the benchmark version each check pack follows
+var cisBenchmarkLabels = map[string]string{
+	CISBenchmarkAWS: "CIS Amazon Web Services Foundations Benchmark v3.0.0",
+	CISBenchmarkGCP: "CIS Google Cloud Platform Foundation Benchmark v2.0.0",
+}
+
+// cisBenchmarkPrefixes are the resource type prefixes of each benchmark's
+// provider
+var cisBenchmarkPrefixes = map[string]string{
+	CISBenchmarkAWS: "aws_",
+	CISBenchmarkGCP: "google_",
+}
+
+// CISBenchmarkLabel returns the display name of a benchmark
+func CISBenchmarkLabel(benchmark string) string {
+	if label, ok := cisBenchmarkLabels[benchmark]; ok {
+		return label
+	}
+	return benchmark
+}
+
+// CISCheckResult is the outcome of one benchmark item evaluated against the
+// declared Terraform resources
+type CISCheckResult struct {
+	ID              string              `json:"id"`
+	Benchmark       string              `json:"benchmark"`
+	Title           string              `json:"title"`
+	Status          string              `json:"status"`
+	Resources       []string            `json:"resources,omitempty"`
+	Failures        []string            `json:"failures,omitempty"`
+	CrossReferences map[string][]string `json:"cross_references"`
+}
+
+// Controls returns the controls of the framework the benchmark item maps to
+func (r CISCheckResult) Controls(framework string) []string {
+	return r.CrossReferences[framework]
+}
+
+// cisEvaluation judges one resource against a benchmark item, returning
+// whether the item applies to it and, when it fails, why
+type cisEvaluation func(config map[string]string) (applicable bool, failure string)
+
+// cisCheck is a benchmark item evaluated against resources of its types. An
+// item without an evaluation only requires the resources to be declared.
+type cisCheck struct {
+	ID            string
+	Benchmark     string
+	Title         string
+	ResourceTypes []string
+	// Keys are the configuration keys the evaluation reads, kept in the
+	// security index so cached analyses evaluate like live scans
+	Keys []string
+	// Required items fail when none of their resources are declared
+	Required bool
+	// AnyPasses items pass when one declared resource passes, such as a
+	// single multi-region trail covering the account
+	AnyPasses       bool
+	CrossReferences map[string][]string
+	evaluate        cisEvaluation
+}
+
+// Cross-references shared by benchmark items evidencing the same controls
+var (
+	cisRefPasswordPolicy = map[string][]string{
+		frameworks.SOC2: {"CC6.1"}, frameworks.ISO27001: {"A.5.17"}, frameworks.NIST80053: {"IA-5(1)"},
+		frameworks.PCIDSS: {"8.3.6", "8.3.7"}, frameworks.HIPAA: {"164.308(a)(5)(ii)(D)"},
+	}
+	cisRefLeastPrivilege = map[string][]string{
+		frameworks.SOC2: {"CC6.3"}, frameworks.ISO27001: {"A.5.15", "A.8.2"}, frameworks.NIST80053: {"AC-6"},
+		frameworks.PCIDSS: {"7.2.1", "7.2.2"}, frameworks.HIPAA: {"164.308(a)(4)(ii)(B)"},
+	}
+	cisRefPublicAccess = map[string][]string{
+		frameworks.SOC2: {"CC6.1"}, frameworks.ISO27001: {"A.8.3"}, frameworks.NIST80053: {"AC-3", "AC-22"},
+		frameworks.PCIDSS: {"1.4.1", "7.2.1"}, frameworks.HIPAA: {"164.312(a)(1)"},
+	}
+	cisRefEncryptionAtRest = map[string][]string{
+		frameworks.SOC2: {"CC6.1"}, frameworks.ISO27001: {"A.8.24"}, frameworks.NIST80053: {"SC-28"},
+		frameworks.PCIDSS: {"3.5.1"}, frameworks.HIPAA: {"164.312(a)(2)(iv)"},
+	}
+	cisRefKeyRotation = map[string][]string{
+		frameworks.SOC2: {"CC6.1"}, frameworks.ISO27001: {"A.8.24"}, frameworks.NIST80053: {"SC-12"},
+		frameworks.PCIDSS: {"3.7.4"}, frameworks.HIPAA: {"164.312(a)(2)(iv)"},
+	}
+	cisRefAuditLogging = map[string][]string{
+		frameworks.SOC2: {"CC7.2"}, frameworks.ISO27001: {"A.8.15"}, frameworks.NIST80053: {"AU-2", "AU-12"},
+		frameworks.PCIDSS: {"10.2.1"}, frameworks.HIPAA: {"164.312(b)"},
+	}
+	cisRefLogIntegrity = map[string][]string{
+		frameworks.SOC2: {"CC7.2"}, frameworks.ISO27001: {"A.8.15"}, frameworks.NIST80053: {"AU-9"},
+		frameworks.PCIDSS: {"10.3.2", "10.3.4"}, frameworks.HIPAA: {"164.312(c)(1)"},
+	}
+	cisRefMonitoring = map[string][]string{
+		frameworks.SOC2: {"CC7.1", "CC7.2"}, frameworks.ISO27001: {"A.8.16"}, frameworks.NIST80053: {"SI-4"},
+		frameworks.PCIDSS: {"10.4.1", "11.5.1"}, frameworks.HIPAA: {"164.308(a)(1)(ii)(D)"},
+	}
+	cisRefConfigurationTracking = map[string][]string{
+		frameworks.SOC2: {"CC7.1", "CC8.1"}, frameworks.ISO27001: {"A.8.9"}, frameworks.NIST80053: {"CM-2", "CM-8"},
+		frameworks.PCIDSS: {"11.5.2"}, frameworks.HIPAA: {"164.308(a)(1)(ii)(D)"},
+	}
+	cisRefNetworkBoundary = map[string][]string{
+		frameworks.SOC2: {"CC6.6"}, frameworks.ISO27001: {"A.8.20", "A.8.22"}, frameworks.NIST80053: {"SC-7"},
+		frameworks.PCIDSS: {"1.3.1", "1.4.1"}, frameworks.HIPAA: {"164.312(e)(1)"},
+	}
+	cisRefSecureConfiguration = map[string][]string{
+		frameworks.SOC2: {"CC6.1"}, frameworks.ISO27001: {"A.8.9"}, frameworks.NIST80053: {"CM-6"},
+		frameworks.PCIDSS: {"2.2.1"}, frameworks.HIPAA: {"164.312(a)(1)"},
+	}
+	cisRefCredentials = map[string][]string{
+		frameworks.SOC2: {"CC6.1"}, frameworks.ISO27001: {"A.5.17"}, frameworks.NIST80053: {"IA-5"},
+		frameworks.PCIDSS: {"8.6.3"}, frameworks.HIPAA: {"164.312(d)"},
+	}
+	cisRefTransmission = map[string][]string{
+		frameworks.SOC2: {"CC6.7"}, frameworks.ISO27001: {"A.8.24"}, frameworks.NIST80053: {"SC-8"},
+		frameworks.PCIDSS: {"4.2.1"}, frameworks.HIPAA: {"164.312(e)(1)"},
+	}
+)
+
+// cisChecks is the benchmark check pack, limited to the items that can be
+// judged from Terraform configuration
+var cisChecks = []cisCheck{
+	// CIS AWS Foundations Benchmark v3.0.0
+	{
+		ID: "1.8", Benchmark: CISBenchmarkAWS,
+		Title:           "Ensure IAM password policy requires minimum length of 14 or greater",
+		ResourceTypes:   []string{"aws_iam_account_password_policy"},
+		Keys:            []string{"minimum_password_length"},
+		CrossReferences: cisRefPasswordPolicy,
+		evaluate:        cisMinimum("minimum_password_length", 14),
+	},
+	{
+		ID: "1.9", Benchmark: CISBenchmarkAWS,
+		Title:           "Ensure IAM password policy prevents password reuse",
+		ResourceTypes:   []string{"aws_iam_account_password_policy"},
+		Keys:            []string{"password_reuse_prevention"},
+		CrossReferences: cisRefPasswordPolicy,
+		evaluate:        cisMinimum("password_reuse_prevention", 24),
+	},
+	{
+		ID: "1.15", Benchmark: CISBenchmarkAWS,
+		Title:           "Ensure IAM Users Receive Permissions Only Through Groups",
+		ResourceTypes:   []string{"aws_iam_user_policy", "aws_iam_user_policy_attachment"},
+		CrossReferences: cisRefLeastPrivilege,
+		evaluate: func(map[string]string) (bool, string) {
+			return true, "attaches a policy directly to a user"
+		},
+	},
+	{
+		ID: "1.16", Benchmark: CISBenchmarkAWS,
+		Title:           `Ensure IAM policies that allow full "*:*" administrative privileges are not attached`,
+		ResourceTypes:   []string{"aws_iam_policy", "aws_iam_role_policy", "aws_iam_group_policy", "aws_iam_user_policy"},
+		Keys:            []string{"_content", "policy"},
+		CrossReferences: cisRefLeastPrivilege,
+		evaluate:        cisNoFullAdminPolicy,
+	},
+	{
+		ID: "2.1.4", Benchmark: CISBenchmarkAWS,
+		Title:         "Ensure that S3 Buckets are configured with 'Block public access (bucket settings)'",
+		ResourceTypes: []string{"aws_s3_bucket_public_access_block", "aws_s3_account_public_access_block"},
+		Keys: []string{"block_public_acls", "block_public_policy", "ignore_public_acls",
+			"restrict_public_buckets"},
+		CrossReferences: cisRefPublicAccess,
+		evaluate: cisAllTrue("block_public_acls", "block_public_policy", "ignore_public_acls",
+			"restrict_public_buckets"),
+	},
+	{
+		ID: "2.2.1", Benchmark: CISBenchmarkAWS,
+		Title:           "Ensure EBS Volume Encryption is Enabled in all Regions",
+		ResourceTypes:   []string{"aws_ebs_encryption_by_default"},
+		Keys:            []string{"enabled"},
+		CrossReferences: cisRefEncryptionAtRest,
+		evaluate: func(config map[string]string) (bool, string) {
+			if config["enabled"] == "false" {
+				return true, "enabled is false"
+			}
+			return true, ""
+		},
+	},
+	{
+		ID: "2.3.1", Benchmark: CISBenchmarkAWS,
+		Title:           "Ensure that encryption-at-rest is enabled for RDS Instances",
+		ResourceTypes:   []string{"aws_db_instance", "aws_rds_cluster"},
+		Keys:            []string{"storage_encrypted"},
+		CrossReferences: cisRefEncryptionAtRest,
+		evaluate:        cisAllTrue("storage_encrypted"),
+	},
+	{
+		ID: "2.3.3", Benchmark: CISBenchmarkAWS,
+		Title:           "Ensure that public access is not given to RDS Instance",
+		ResourceTypes:   []string{"aws_db_instance"},
+		Keys:            []string{"publicly_accessible"},
+		CrossReferences: cisRefNetworkBoundary,
+		evaluate: func(config map[string]string) (bool, string) {
+			if config["publicly_accessible"] == "true" {
+				return true, "publicly_accessible is true"
+			}
+			return true, ""
+		},
+	},
+	{
+		ID: "3.1", Benchmark: CISBenchmarkAWS,
+		Title:           "Ensure CloudTrail is enabled in all regions",
+		ResourceTypes:   []string{"aws_cloudtrail"},
+		Keys:            []string{"is_multi_region_trail", "enable_logging"},
+		Required:        true,
+		AnyPasses:       true,
+		CrossReferences: cisRefAuditLogging,
+		evaluate: func(config map[string]string) (bool, string) {
+			if config["enable_logging"] == "false" {
+				return true, "enable_logging is false"
+			}
+			return cisAllTrue("is_multi_region_trail")(config)
+		},
+	},
+	{
+		ID: "3.2", Benchmark: CISBenchmarkAWS,
+		Title:           "Ensure CloudTrail log file validation is enabled",
+		ResourceTypes:   []string{"aws_cloudtrail"},
+		Keys:            []string{"enable_log_file_validation"},
+		CrossReferences: cisRefLogIntegrity,
+		evaluate:        cisAllTrue("enable_log_file_validation"),
+	},
+	{
+		ID: "3.3", Benchmark: CISBenchmarkAWS,
+		Title:           "Ensure AWS Config is enabled in all regions",
+		ResourceTypes:   []string{"aws_config_configuration_recorder"},
+		Required:        true,
+		CrossReferences: cisRefConfigurationTracking,
+	},
+	{
+		ID: "3.5", Benchmark: CISBenchmarkAWS,
+		Title:           "Ensure CloudTrail logs are encrypted at rest using KMS CMKs",
+		ResourceTypes:   []string{"aws_cloudtrail"},
+		Keys:            []string{"kms_key_id"},
+		CrossReferences: cisRefEncryptionAtRest,
+		evaluate:        cisSet("kms_key_id"),
+	},
+	{
+		ID: "3.6", Benchmark: CISBenchmarkAWS,
+		Title:           "Ensure rotation for customer-created symmetric CMKs is enabled",
+		ResourceTypes:   []string{"aws_kms_key"},
+		Keys:            []string{"enable_key_rotation", "customer_master_key_spec", "key_spec"},
+		CrossReferences: cisRefKeyRotation,
+		evaluate: func(config map[string]string) (bool, string) {
+			for _, key := range []string{"customer_master_key_spec", "key_spec"} {
+				if spec := config[key]; spec != "" && spec != "SYMMETRIC_DEFAULT" {
+					return false, ""
+				}
+			}
+			return cisAllTrue("enable_key_rotation")(config)
+		},
+	},
+	{
+		ID: "3.7", Benchmark: CISBenchmarkAWS,
+		Title:           "Ensure VPC flow logging is enabled in all VPCs",
+		ResourceTypes:   []string{"aws_flow_log"},
+		Required:        true,
+		CrossReferences: cisRefAuditLogging,
+	},
+	{
+		ID: "4.16", Benchmark: CISBenchmarkAWS,
+		Title:           "Ensure AWS Security Hub is enabled",
+		ResourceTypes:   []string{"aws_securityhub_account"},
+		Required:        true,
+		CrossReferences: cisRefMonitoring,
+	},
+	{
+		ID: "5.2", Benchmark: CISBenchmarkAWS,
+		Title: "Ensure no security groups allow ingress from 0.0.0.0/0 to remote server administration ports",
+		ResourceTypes: []string{"aws_security_group", "aws_security_group_rule",
+			"aws_vpc_security_group_ingress_rule"},
+		Keys:            cisSecurityGroupKeys,
+		CrossReferences: cisRefNetworkBoundary,
+		evaluate:        cisSecurityGroupIngress("0.0.0.0/0"),
+	},
+	{
+		ID: "5.3", Benchmark: CISBenchmarkAWS,
+		Title: "Ensure no security groups allow ingress from ::/0 to remote server administration ports",
+		ResourceTypes: []string{"aws_security_group", "aws_security_group_rule",
+			"aws_vpc_security_group_ingress_rule"},
+		Keys:            cisSecurityGroupKeys,
+		CrossReferences: cisRefNetworkBoundary,
+		evaluate:        cisSecurityGroupIngress("::/0"),
+	},
+	{
+		ID: "5.6", Benchmark: CISBenchmarkAWS,
+		Title:           "Ensure that EC2 Metadata Service only allows IMDSv2",
+		ResourceTypes:   []string{"aws_instance", "aws_launch_template"},
+		Keys:            []string{"http_tokens"},
+		CrossReferences: cisRefSecureConfiguration,
+		evaluate: func(config map[string]string) (bool, string) {
+			if config["http_tokens"] != "required" {
+				return true, "metadata_options.http_tokens is not \"required\""
+			}
+			return true, ""
+		},
+	},
+
+	// CIS Google Cloud Platform Foundation Benchmark v2.0.0
+	{
+		ID: "1.4", Benchmark: CISBenchmarkGCP,
+		Title:           "Ensure That There Are Only GCP-Managed Service Account Keys for Each Service Account",
+		ResourceTypes:   []string{"google_service_account_key"},
+		CrossReferences: cisRefCredentials,
+		evaluate: func(map[string]string) (bool, string) {
+			return true, "creates a user-managed service account key"
+		},
+	},
+	{
+		ID: "1.10", Benchmark: CISBenchmarkGCP,
+		Title:           "Ensure KMS Encryption Keys Are Rotated Within a Period of 90 Days",
+		ResourceTypes:   []string{"google_kms_crypto_key"},
+		Keys:            []string{"rotation_period"},
+		CrossReferences: cisRefKeyRotation,
+		evaluate: func(config map[string]string) (bool, string) {
+			period := config["rotation_period"]
+			if period == "" {
+				return true, "rotation_period is not set"
+			}
+			seconds, err := strconv.ParseFloat(strings.TrimSuffix(period, "s"), 64)
+			if err == nil && seconds > 90*24*60*60 {
+				return true, fmt.Sprintf("rotation_period %s exceeds 90 days", period)
+			}
+			return true, ""
+		},
+	},
+	{
+		ID: "2.1", Benchmark: CISBenchmarkGCP,
+		Title: "Ensure That Cloud Audit Logging Is Configured Properly",
+		ResourceTypes: []string{"google_project_iam_audit_config", "google_folder_iam_audit_config",
+			"google_organization_iam_audit_config"},
+		Keys:            []string{"_content", "service"},
+		Required:        true,
+		AnyPasses:       true,
+		CrossReferences: cisRefAuditLogging,
+		evaluate: func(config map[string]string) (bool, string) {
+			if config["service"] != "allServices" {
+				return true, "does not audit allServices"
+			}
+			content := cisContent(config)
+			if !strings.Contains(content, "DATA_READ") || !strings.Contains(content, "DATA_WRITE") {
+				return true, "does not log DATA_READ and DATA_WRITE"
+			}
+			return true, ""
+		},
+	},
+	{
+		ID: "3.1", Benchmark: CISBenchmarkGCP,
+		Title:           "Ensure That the Default Network Does Not Exist in a Project",
+		ResourceTypes:   []string{"google_project"},
+		Keys:            []string{"auto_create_network"},
+		CrossReferences: cisRefNetworkBoundary,
+		evaluate: func(config map[string]string) (bool, string) {
+			if config["auto_create_network"] != "false" {
+				return true, "auto_create_network is not false"
+			}
+			return true, ""
+		},
+	},
+	{
+		ID: "3.6", Benchmark: CISBenchmarkGCP,
+		Title:           "Ensure That SSH Access Is Restricted From the Internet",
+		ResourceTypes:   []string{"google_compute_firewall"},
+		Keys:            cisFirewallKeys,
+		CrossReferences: cisRefNetworkBoundary,
+		evaluate:        cisFirewallIngress(22),
+	},
+	{
+		ID: "3.7", Benchmark: CISBenchmarkGCP,
+		Title:           "Ensure That RDP Access Is Restricted From the Internet",
+		ResourceTypes:   []string{"google_compute_firewall"},
+		Keys:            cisFirewallKeys,
+		CrossReferences: cisRefNetworkBoundary,
+		evaluate:        cisFirewallIngress(3389),
+	},
+	{
+		ID: "3.8", Benchmark: CISBenchmarkGCP,
+		Title:           "Ensure that VPC Flow Logs is Enabled for Every Subnet in a VPC Network",
+		ResourceTypes:   []string{"google_compute_subnetwork"},
+		Keys:            []string{"_content", "purpose", "aggregation_interval", "flow_sampling"},
+		CrossReferences: cisRefAuditLogging,
+		evaluate: func(config map[string]string) (bool, string) {
+			if purpose := config["purpose"]; purpose != "" && purpose != "PRIVATE" {
+				return false, ""
+			}
+			if len(cisBlocks(cisContent(config), "log_config")) == 0 &&
+				config["aggregation_interval"] == "" && config["flow_sampling"] == "" {
+				return true, "has no log_config block"
+			}
+			return true, ""
+		},
+	},
+	{
+		ID: "5.1", Benchmark: CISBenchmarkGCP,
+		Title: "Ensure That Cloud Storage Bucket Is Not Anonymously or Publicly Accessible",
+		ResourceTypes: []string{"google_storage_bucket_iam_member", "google_storage_bucket_iam_binding",
+			"google_storage_bucket_access_control"},
+		Keys:            []string{"member", "members", "entity"},
+		CrossReferences: cisRefPublicAccess,
+		evaluate: func(config map[string]string) (bool, string) {
+			for _, key := range []string{"member", "members", "entity"} {
+				for _, principal := range []string{"allUsers", "allAuthenticatedUsers"} {
+					if strings.Contains(config[key], principal) {
+						return true, "grants access to " + principal
+					}
+				}
+			}
+			return true, ""
+		},
+	},
+	{
+		ID: "5.2", Benchmark: CISBenchmarkGCP,
+		Title:           "Ensure That Cloud Storage Buckets Have Uniform Bucket-Level Access Enabled",
+		ResourceTypes:   []string{"google_storage_bucket"},
+		Keys:            []string{"uniform_bucket_level_access"},
+		CrossReferences: cisRefPublicAccess,
+		evaluate:        cisAllTrue("uniform_bucket_level_access"),
+	},
+	{
+		ID: "6.4", Benchmark: CISBenchmarkGCP,
+		Title:           "Ensure That the Cloud SQL Database Instance Requires All Incoming Connections To Use SSL",
+		ResourceTypes:   []string{"google_sql_database_instance"},
+		Keys:            []string{"require_ssl", "ssl_mode"},
+		CrossReferences: cisRefTransmission,
+		evaluate: func(config map[string]string) (bool, string) {
+			switch {
+			case config["require_ssl"] == "true",
+				config["ssl_mode"] == "ENCRYPTED_ONLY",
+				config["ssl_mode"] == "TRUSTED_CLIENT_CERTIFICATE_REQUIRED":
+				return true, ""
+			}
+			return true, "does not require SSL connections"
+		},
+	},
+	{
+		ID: "6.5", Benchmark: CISBenchmarkGCP,
+		Title:           "Ensure That Cloud SQL Database Instances Do Not Implicitly Whitelist All Public IP Addresses",
+		ResourceTypes:   []string{"google_sql_database_instance"},
+		Keys:            []string{"_content", "value"},
+		CrossReferences: cisRefNetworkBoundary,
+		evaluate: func(config map[string]string) (bool, string) {
+			networks := cisBlocks(cisContent(config), "authorized_networks")
+			if _, ok := config["_content"]; !ok {
+				networks = []map[string]string{config}
+			}
+			for _, network := range networks {
+				if network["value"] == "0.0.0.0/0" {
+					return true, "authorizes 0.0.0.0/0"
+				}
+			}
+			return true, ""
+		},
+	},
+}
+
+// cisSecurityGroupKeys are the security group rule keys read by 5.2 and 5.3.
+// Security groups declare their rules in ingress blocks, which only the raw
+// resource content preserves.
+var cisSecurityGroupKeys = []string{"_content", "type", "cidr_blocks", "ipv6_cidr_blocks", "cidr_ipv4",
+	"cidr_ipv6", "protocol", "ip_protocol", "from_port", "to_port"}
+
+// cisFirewallKeys are the firewall keys read by GCP 3.6 and 3.7
+var cisFirewallKeys = []string{"_content", "direction", "disabled", "source_ranges", "protocol", "ports"}
+
+// cisAdminPorts are the remote server administration ports of CIS AWS 5.2
+// and 5.3
+var cisAdminPorts = []int{22, 3389}
+
+var (
+	cisActionWildcard     = regexp.MustCompile(`(?i)(?:^|[^a-z])"?action"?\s*[:=]\s*\[?\s*"\*"`)
+	cisResourceWildcard   = regexp.MustCompile(`(?i)(?:^|[^a-z])"?resource"?\s*[:=]\s*\[?\s*"\*"`)
+	cisSecurityGroupBlock = regexp.MustCompile(`^\s*resource\s+"aws_security_group"\s`)
+	cisBlockStart         = regexp.MustCompile(`^([a-zA-Z_][a-zA-Z0-9_]*)\s*\{`)
+	cisKeyValue           = regexp.MustCompile(`^([a-zA-Z_][a-zA-Z0-9_]*)\s*=\s*(.*)$`)
+)
+
+// cisKeysByType indexes the configuration keys each resource type's checks
+// read
+var cisKeysByType = func() map[string]map[string]bool {
+	index := make(map[string]map[string]bool)
+	for _, check := range cisChecks {
+		for _, resourceType := range check.ResourceTypes {
+			if index[resourceType] == nil {
+				index[resourceType] = make(map[string]bool)
+			}
+			for _, key := range check.Keys {
+				index[resourceType][key] = true
+			}
+		}
+	}
+	return index
+}()
+
+// cisReadsConfig reports whether a CIS check of the resource type reads the
+// configuration key
+func cisReadsConfig(resourceType, key string) bool {
+	return cisKeysByType[resourceType][key]
+}
+
+// CISBenchmarksFor resolves a benchmark selection to the benchmarks to
+// evaluate. "all" selects the benchmarks whose provider has resources.
+func CISBenchmarksFor(selection string, resources []models.TerraformScanResult) []string {
+	if selection != CISBenchmarkAll {
+		if _, ok := cisBenchmarkLabels[selection]; ok {
+			return []string{selection}
+		}
+		return nil
+	}
+	var selected []string
+	for _, benchmark := range CISBenchmarks {
+		for _, resource := range resources {
+			if strings.HasPrefix(resource.ResourceType, cisBenchmarkPrefixes[benchmark]) {
+				selected = append(selected, benchmark)
+				break
+			}
+		}
+	}
+	return selected
+}
+
+// EvaluateCIS evaluates the benchmark's items against the declared
+// resources, in benchmark order
+func EvaluateCIS(benchmark string, resources []models.TerraformScanResult) []CISCheckResult {
+	byType := make(map[string][]models.TerraformScanResult)
+	for _, resource := range resources {
+		byType[resource.ResourceType] = append(byType[resource.ResourceType], resource)
+	}
+
+	var results []CISCheckResult
+	for _, check := range cisChecks {
+		if check.Benchmark == benchmark {
+			results = append(results, check.run(byType))
+		}
+	}
+	return results
+}
+
+// run evaluates the check against the resources of its types
+func (c cisCheck) run(byType map[string][]models.TerraformScanResult) CISCheckResult {
+	result := CISCheckResult{
+		ID:              c.ID,
+		Benchmark:       c.Benchmark,
+		Title:           c.Title,
+		Status:          CISStatusNotApplicable,
+		CrossReferences: c.CrossReferences,
+	}
+
+	passed := false
+	for _, resourceType := range c.ResourceTypes {
+		for _, resource := range byType[resourceType] {
+			config := cisStrings(resource.Configuration)
+			applicable, failure := true, ""
+			if c.evaluate != nil {
+				applicable, failure = c.evaluate(config)
+			}
+			if !applicable {
+				continue
+			}
+			id := resource.ResourceType + "." + resource.ResourceName
+			result.Resources = append(result.Resources, id)
+			if failure != "" {
+				result.Failures = append(result.Failures, id+": "+failure)
+			} else {
+				passed = true
+			}
+		}
+	}
+
+	switch {
+	case len(result.Resources) == 0:
+		if c.Required {
+			result.Status = CISStatusFail
+			result.Failures = []string{"no " + strings.Join(c.ResourceTypes, " or ") + " declared"}
+		}
+	case c.AnyPasses && passed, len(result.Failures) == 0:
+		result.Status = CISStatusPass
+	default:
+		result.Status = CISStatusFail
+	}
+	return result
+}
+
+// cisStrings renders configuration values as the strings the checks compare
+func cisStrings(config map[string]interface{}) map[string]string {
+	values := make(map[string]string, len(config))
+	for key, value := range config {
+		values[key] = strings.Trim(strings.TrimSpace(fmt.Sprint(value)), `"'`)
+	}
+	return values
+}
+
+// cisContent returns the raw resource block, when the scan kept it
+func cisContent(config map[string]string) string {
+	return config["_content"]
+}
+
+// cisBlocks parses the key/value attributes of the nested blocks with the
+// given name from raw resource content
+func cisBlocks(content, name string) []map[string]string {
+	var blocks []map[string]string
+	var current map[string]string
+	depth := 0
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if current == nil {
+			if matches := cisBlockStart.FindStringSubmatch(line); matches != nil && matches[1] == name {
+				current = make(map[string]string)
+				depth = strings.Count(line, "{") - strings.Count(line, "}")
+				if depth <= 0 {
+					blocks = append(blocks, current)
+					current = nil
+				}
+			}
+			continue
+		}
+		if depth == 1 {
+			if matches := cisKeyValue.FindStringSubmatch(line); matches != nil {
+				value := matches[2]
+				if idx := strings.Index(value, "#"); idx != -1 {
+					value = value[:idx]
+				}
+				current[matches[1]] = strings.Trim(strings.TrimSpace(value), `"'`)
+			}
+		}
+		depth += strings.Count(line, "{") - strings.Count(line, "}")
+		if depth <= 0 {
+			blocks = append(blocks, current)
+			current = nil
+		}
+	}
+	return blocks
+}
+
+// cisList splits a list attribute such as ["22", "80-90"] into its items
+func cisList(value string) []string {
+	value = strings.Trim(strings.TrimSpace(value), "[]")
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.Trim(strings.TrimSpace(item), `"'`); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// cisAllTrue fails resources where any of the keys is not set to true
+func cisAllTrue(keys ...string) cisEvaluation {
+	return func(config map[string]string) (bool, string) {
+		var missing []string
+		for _, key := range keys {
+			if config[key] != "true" {
+				missing = append(missing, key)
+			}
+		}
+		if len(missing) > 0 {
+			return true, strings.Join(missing, ", ") + " not enabled"
+		}
+		return true, ""
+	}
+}
+
+// cisSet fails resources where the key is not set
+func cisSet(key string) cisEvaluation {
+	return func(config map[string]string) (bool, string) {
+		if config[key] == "" {
+			return true, key + " is not set"
+		}
+		return true, ""
+	}
+}
+
+// cisMinimum fails resources where the numeric key is unset or below the
+// minimum. Values that are not literals, such as variables, are not judged.
+func cisMinimum(key string, minimum int) cisEvaluation {
+	return func(config map[string]string) (bool, string) {
+		value, ok := config[key]
+		if !ok || value == "" {
+			return true, key + " is not set"
+		}
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return false, ""
+		}
+		if number < minimum {
+			return true, fmt.Sprintf("%s is %d, below %d", key, number, minimum)
+		}
+		return true, ""
+	}
+}
+
+// cisNoFullAdminPolicy fails policies allowing every action on every
+// resource. Both inline jsonencode and heredoc JSON policies are matched.
+func cisNoFullAdminPolicy(config map[string]string) (bool, string) {
+	document := cisContent(config)
+	if document == "" {
+		document = config["policy"]
+	}
+	if cisActionWildcard.MatchString(document) && cisResourceWildcard.MatchString(document) {
+		return true, `allows "*" actions on "*" resources`
+	}
+	return true, ""
+}
+
+// cisSecurityGroupIngress fails security groups and ingress rules opening an
+// administration port to the world CIDR
+func cisSecurityGroupIngress(world string) cisEvaluation {
+	return func(config map[string]string) (bool, string) {
+		if config["type"] == "egress" {
+			return false, ""
+		}
+		// Rule resources are a single rule; security groups nest theirs
+		rules := []map[string]string{config}
+		if content := cisContent(config); cisSecurityGroupBlock.MatchString(content) {
+			rules = cisBlocks(content, "ingress")
+		}
+		for _, rule := range rules {
+			cidrs := rule["cidr_blocks"] + " " + rule["cidr_ipv4"]
+			if world == "::/0" {
+				cidrs = rule["ipv6_cidr_blocks"] + " " + rule["cidr_ipv6"]
+			}
+			if !strings.Contains(cidrs, world) {
+				continue
+			}
+			if port, open := cisRuleOpensAdminPort(rule); open {
+				return true, fmt.Sprintf("allows ingress from %s to port %d", world, port)
+			}
+		}
+		return true, ""
+	}
+}
+
+// cisRuleOpensAdminPort reports the first administration port a security
+// group rule's port range covers. Ranges that are not literals are not judged.
+func cisRuleOpensAdminPort(rule map[string]string) (int, bool) {
+	protocol := rule["protocol"]
+	if protocol == "" {
+		protocol = rule["ip_protocol"]
+	}
+	if protocol == "-1" || protocol == "all" {
+		return cisAdminPorts[0], true
+	}
+	from, errFrom := strconv.Atoi(rule["from_port"])
+	to, errTo := strconv.Atoi(rule["to_port"])
+	if errFrom != nil || errTo != nil {
+		return 0, false
+	}
+	for _, port := range cisAdminPorts {
+		if from <= port && port <= to {
+			return port, true
+		}
+	}
+	return 0, false
+}
+
+// cisFirewallIngress fails enabled ingress firewall rules allowing the port
+// from 0.0.0.0/0
+func cisFirewallIngress(port int) cisEvaluation {
+	return func(config map[string]string) (bool, string) {
+		if config["direction"] == "EGRESS" || config["disabled"] == "true" {
+			return false, ""
+		}
+		if !strings.Contains(config["source_ranges"], "0.0.0.0/0") {
+			return true, ""
+		}
+		allows := cisBlocks(cisContent(config), "allow")
+		if _, ok := config["_content"]; !ok {
+			allows = []map[string]string{config}
+		}
+		for _, allow := range allows {
+			if cisAllowCoversPort(allow, port) {
+				return true, fmt.Sprintf("allows ingress from 0.0.0.0/0 to port %d", port)
+			}
+		}
+		return true, ""
+	}
+}
+
+// cisAllowCoversPort reports whether a firewall allow block admits TCP
+// traffic to the port
+func cisAllowCoversPort(allow map[string]string, port int) bool {
+	switch allow["protocol"] {
+	case "all":
+		return true
+	case "tcp", "6":
+	default:
+		return false
+	}
+	ports := cisList(allow["ports"])
+	if len(ports) == 0 {
+		return true
+	}
+	for _, item := range ports {
+		low, high, isRange := strings.Cut(item, "-")
+		from, err := strconv.Atoi(low)
+		if err != nil {
+			continue
+		}
+		to := from
+		if isRange {
+			if to, err = strconv.Atoi(high); err != nil {
+				continue
+			}
+		}
+		if from <= port && port <= to {
+			return true
+		}
+	}
+	return false
+}
+
+// CISSummary counts the results by status
+func CISSummary(results []CISCheckResult) map[string]int {
+	summary := map[string]int{CISStatusPass: 0, CISStatusFail: 0, CISStatusNotApplicable: 0}
+	for _, result := range results {
+		summary[result.Status]++
+	}
+	return summary
+}
+
+// sortedCISControls returns the result's controls of the framework in
+// catalog order
+func sortedCISControls(result CISCheckResult, framework string) []string {
+	controls := append([]string(nil), result.Controls(framework)...)
+	frameworks.Sort(framework, controls)
+	return controls
+}
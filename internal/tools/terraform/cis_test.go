@@ -0,0 +1,216 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package terraform
+
+import (
+	"testing"
+
+	"github.com/grctool/grctool/internal/frameworks"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cisResource builds a scan result the way the line-based scanner does, with
+// string values and the raw block under _content
+func cisResource(resourceType, name string, config map[string]interface{}) models.TerraformScanResult {
+	return models.TerraformScanResult{ResourceType: resourceType, ResourceName: name, Configuration: config}
+}
+
+// cisStatuses indexes evaluated results by benchmark item
+func cisStatuses(results []CISCheckResult) map[string]CISCheckResult {
+	byID := make(map[string]CISCheckResult, len(results))
+	for _, result := range results {
+		byID[result.ID] = result
+	}
+	return byID
+}
+
+func TestEvaluateCIS_AWS(t *testing.T) {
+	t.Parallel()
+
+	resources := []models.TerraformScanResult{
+		cisResource("aws_iam_account_password_policy", "strict", map[string]interface{}{
+			"minimum_password_length": "8", "password_reuse_prevention": "24",
+		}),
+		cisResource("aws_iam_policy", "admin", map[string]interface{}{
+			"policy":   "jsonencode({",
+			"_content": "resource \"aws_iam_policy\" \"admin\" {\n  policy = jsonencode({\n    Statement = [{\n      Effect   = \"Allow\"\n      Action   = \"*\"\n      Resource = \"*\"\n    }]\n  })\n}\n",
+		}),
+		cisResource("aws_iam_policy", "readonly", map[string]interface{}{
+			"_content": "resource \"aws_iam_policy\" \"readonly\" {\n  policy = <<EOF\n{\"Statement\": [{\"Effect\": \"Allow\", \"NotAction\": \"*\", \"Resource\": \"*\"}]}\nEOF\n}\n",
+		}),
+		cisResource("aws_cloudtrail", "regional", map[string]interface{}{"enable_log_file_validation": "true"}),
+		cisResource("aws_cloudtrail", "org", map[string]interface{}{
+			"is_multi_region_trail": "true", "enable_log_file_validation": "true", "kms_key_id": "aws_kms_key.trail.arn",
+		}),
+		cisResource("aws_kms_key", "trail", map[string]interface{}{"enable_key_rotation": "true"}),
+		cisResource("aws_kms_key", "signing", map[string]interface{}{"customer_master_key_spec": "RSA_2048"}),
+		cisResource("aws_db_instance", "main", map[string]interface{}{"storage_encrypted": "true", "publicly_accessible": "true"}),
+		cisResource("aws_security_group", "bastion", map[string]interface{}{
+			"_content": "resource \"aws_security_group\" \"bastion\" {\n  ingress {\n    from_port   = 443\n    to_port     = 443\n    protocol    = \"tcp\"\n    cidr_blocks = [\"0.0.0.0/0\"]\n  }\n  ingress {\n    from_port   = 22\n    to_port     = 22\n    protocol    = \"tcp\"\n    cidr_blocks = [\"0.0.0.0/0\"]\n  }\n  egress {\n    from_port   = 0\n    to_port     = 0\n    protocol    = \"-1\"\n    cidr_blocks = [\"0.0.0.0/0\"]\n  }\n}\n",
+		}),
+		cisResource("aws_security_group_rule", "egress_all", map[string]interface{}{
+			"type": "egress", "protocol": "-1", "from_port": "0", "to_port": "0", "ipv6_cidr_blocks": `["::/0"]`,
+		}),
+		cisResource("aws_instance", "web", map[string]interface{}{"http_tokens": "required"}),
+		cisResource("aws_config_configuration_recorder", "main", map[string]interface{}{}),
+	}
+
+	results := EvaluateCIS(CISBenchmarkAWS, resources)
+	byID := cisStatuses(results)
+	require.Len(t, results, 18)
+	assert.Equal(t, "1.8", results[0].ID, "results follow benchmark order")
+
+	tests := map[string]struct {
+		status   string
+		failures []string
+	}{
+		"1.8":   {status: CISStatusFail, failures: []string{"aws_iam_account_password_policy.strict: minimum_password_length is 8, below 14"}},
+		"1.9":   {status: CISStatusPass},
+		"1.15":  {status: CISStatusNotApplicable},
+		"1.16":  {status: CISStatusFail, failures: []string{`aws_iam_policy.admin: allows "*" actions on "*" resources`}},
+		"2.1.4": {status: CISStatusNotApplicable},
+		"2.3.1": {status: CISStatusPass},
+		"2.3.3": {status: CISStatusFail, failures: []string{"aws_db_instance.main: publicly_accessible is true"}},
+		"3.1":   {status: CISStatusPass, failures: []string{"aws_cloudtrail.regional: is_multi_region_trail not enabled"}},
+		"3.2":   {status: CISStatusPass},
+		"3.3":   {status: CISStatusPass},
+		"3.5":   {status: CISStatusFail, failures: []string{"aws_cloudtrail.regional: kms_key_id is not set"}},
+		"3.6":   {status: CISStatusPass},
+		"3.7":   {status: CISStatusFail, failures: []string{"no aws_flow_log declared"}},
+		"4.16":  {status: CISStatusFail, failures: []string{"no aws_securityhub_account declared"}},
+		"5.2":   {status: CISStatusFail, failures: []string{"aws_security_group.bastion: allows ingress from 0.0.0.0/0 to port 22"}},
+		"5.3":   {status: CISStatusPass},
+		"5.6":   {status: CISStatusPass},
+	}
+
+	for id, tc := range tests {
+		t.Run(id, func(t *testing.T) {
+			t.Parallel()
+			result, ok := byID[id]
+			require.True(t, ok)
+			assert.Equal(t, tc.status, result.Status)
+			assert.Equal(t, tc.failures, result.Failures)
+		})
+	}
+
+	assert.Equal(t, []string{"aws_kms_key.trail"}, byID["3.6"].Resources, "asymmetric keys are not evaluated")
+	assert.Equal(t, []string{"aws_security_group.bastion"}, byID["5.3"].Resources, "egress rules are not evaluated")
+	assert.Equal(t, []string{"A.8.20", "A.8.22"}, byID["5.2"].Controls(frameworks.ISO27001))
+}
+
+func TestEvaluateCIS_GCP(t *testing.T) {
+	t.Parallel()
+
+	resources := []models.TerraformScanResult{
+		cisResource("google_project", "main", map[string]interface{}{"auto_create_network": "false"}),
+		cisResource("google_compute_firewall", "ssh", map[string]interface{}{
+			"source_ranges": `["0.0.0.0/0"]`,
+			"_content":      "resource \"google_compute_firewall\" \"ssh\" {\n  source_ranges = [\"0.0.0.0/0\"]\n  allow {\n    protocol = \"icmp\"\n  }\n  allow {\n    protocol = \"tcp\"\n    ports    = [\"20-23\", \"443\"]\n  }\n}\n",
+		}),
+		cisResource("google_compute_firewall", "internal", map[string]interface{}{
+			"source_ranges": `["10.0.0.0/8"]`, "_content": "resource \"google_compute_firewall\" \"internal\" {\n  allow {\n    protocol = \"all\"\n  }\n}\n",
+		}),
+		cisResource("google_compute_subnetwork", "app", map[string]interface{}{
+			"_content": "resource \"google_compute_subnetwork\" \"app\" {\n  log_config {\n    aggregation_interval = \"INTERVAL_5_SEC\"\n  }\n}\n",
+		}),
+		cisResource("google_compute_subnetwork", "proxy", map[string]interface{}{"purpose": "REGIONAL_MANAGED_PROXY"}),
+		cisResource("google_kms_crypto_key", "data", map[string]interface{}{"rotation_period": "31536000s"}),
+		cisResource("google_storage_bucket", "logs", map[string]interface{}{"uniform_bucket_level_access": "true"}),
+		cisResource("google_storage_bucket_iam_member", "public", map[string]interface{}{"member": "allUsers"}),
+		cisResource("google_sql_database_instance", "db", map[string]interface{}{
+			"ssl_mode": "ENCRYPTED_ONLY",
+			"_content": "resource \"google_sql_database_instance\" \"db\" {\n  settings {\n    ip_configuration {\n      authorized_networks {\n        value = \"0.0.0.0/0\"\n      }\n    }\n  }\n}\n",
+		}),
+	}
+
+	byID := cisStatuses(EvaluateCIS(CISBenchmarkGCP, resources))
+
+	tests := map[string]struct {
+		status   string
+		failures []string
+	}{
+		"1.4":  {status: CISStatusNotApplicable},
+		"1.10": {status: CISStatusFail, failures: []string{"google_kms_crypto_key.data: rotation_period 31536000s exceeds 90 days"}},
+		"2.1":  {status: CISStatusFail, failures: []string{"no google_project_iam_audit_config or google_folder_iam_audit_config or google_organization_iam_audit_config declared"}},
+		"3.1":  {status: CISStatusPass},
+		"3.6":  {status: CISStatusFail, failures: []string{"google_compute_firewall.ssh: allows ingress from 0.0.0.0/0 to port 22"}},
+		"3.7":  {status: CISStatusPass},
+		"3.8":  {status: CISStatusPass},
+		"5.1":  {status: CISStatusFail, failures: []string{"google_storage_bucket_iam_member.public: grants access to allUsers"}},
+		"5.2":  {status: CISStatusPass},
+		"6.4":  {status: CISStatusPass},
+		"6.5":  {status: CISStatusFail, failures: []string{"google_sql_database_instance.db: authorizes 0.0.0.0/0"}},
+	}
+
+	for id, tc := range tests {
+		t.Run(id, func(t *testing.T) {
+			t.Parallel()
+			result, ok := byID[id]
+			require.True(t, ok)
+			assert.Equal(t, tc.status, result.Status)
+			assert.Equal(t, tc.failures, result.Failures)
+		})
+	}
+
+	assert.Equal(t, []string{"google_compute_subnetwork.app"}, byID["3.8"].Resources, "proxy subnets cannot log flows")
+}
+
+func TestCISBenchmarksFor(t *testing.T) {
+	t.Parallel()
+
+	awsOnly := []models.TerraformScanResult{cisResource("aws_s3_bucket", "data", nil)}
+
+	assert.Equal(t, []string{CISBenchmarkGCP}, CISBenchmarksFor(CISBenchmarkGCP, awsOnly))
+	assert.Equal(t, []string{CISBenchmarkAWS}, CISBenchmarksFor(CISBenchmarkAll, awsOnly))
+	assert.Empty(t, CISBenchmarksFor(CISBenchmarkAll, nil))
+	assert.Empty(t, CISBenchmarksFor("azure", awsOnly))
+}
+
+func TestCISChecks_CrossReferences(t *testing.T) {
+	t.Parallel()
+
+	for _, check := range cisChecks {
+		for _, framework := range frameworks.Supported {
+			controls := check.CrossReferences[framework]
+			assert.NotEmpty(t, controls, "%s %s has no %s cross-reference", check.Benchmark, check.ID, framework)
+			for _, control := range controls {
+				code, err := frameworks.NormalizeControl(framework, control)
+				assert.NoError(t, err, "%s %s", check.Benchmark, check.ID)
+				assert.Equal(t, control, code, "%s %s maps to non-canonical %s code", check.Benchmark, check.ID, framework)
+			}
+		}
+	}
+}
+
+func TestCISReadsConfig(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, cisReadsConfig("aws_iam_account_password_policy", "minimum_password_length"))
+	assert.True(t, cisReadsConfig("aws_security_group", "_content"))
+	assert.False(t, cisReadsConfig("aws_s3_bucket", "_content"))
+	assert.False(t, cisReadsConfig("aws_iam_account_password_policy", "hard_expiry"))
+}
+
+func TestGenerateSummaryMarkdownReport_CIS(t *testing.T) {
+	t.Parallel()
+
+	analyzer := &SecurityAnalyzer{}
+	analysis := &SecurityAnalysisResult{
+		Framework: frameworks.NIST80053,
+		CISResults: EvaluateCIS(CISBenchmarkAWS, []models.TerraformScanResult{
+			cisResource("aws_ebs_encryption_by_default", "main", map[string]interface{}{"enabled": "false"}),
+		}),
+	}
+
+	report, err := analyzer.generateSummaryMarkdownReport(analysis)
+	require.NoError(t, err)
+	assert.Contains(t, report, "## CIS Benchmark Checks\n\n- **Passed:** 0\n- **Failed:** 5\n- **Not Applicable:** 13\n")
+	assert.Contains(t, report, "### CIS Amazon Web Services Foundations Benchmark v3.0.0\n\n| Item | Title | Status | Resources | NIST 800-53 Controls |")
+	assert.Contains(t, report, "| 2.2.1 | Ensure EBS Volume Encryption is Enabled in all Regions | fail | 1 | SC-28 |")
+	assert.Contains(t, report, "| 1.8 | Ensure IAM password policy requires minimum length of 14 or greater | not applicable | 0 | IA-5(1) |")
+	assert.Contains(t, report, "- **AWS 2.2.1**: aws_ebs_encryption_by_default.main: enabled is false\n")
+	assert.Contains(t, report, "- **AWS 3.1**: no aws_cloudtrail declared\n")
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/zclconf/go-cty/cty/function"
 
 	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/frameworks"
 	"github.com/grctool/grctool/internal/logger"
 	"github.com/grctool/grctool/internal/models"
 )
@@ -777,6 +778,25 @@ func (h *HCLParser) analyzeModuleSecurityRelevance(module *models.TerraformModul
 	return relevance
 }
 
+// ApplyFramework replaces the SOC2 security relevance of the parsed resources
+// and modules with the controls of the framework
+func (h *HCLParser) ApplyFramework(result *models.TerraformParseResult, framework string) {
+	result.Framework = framework
+	if framework == frameworks.SOC2 {
+		return
+	}
+
+	for i := range result.Modules {
+		module := &result.Modules[i]
+		for j := range module.Resources {
+			resource := &module.Resources[j]
+			resource.SecurityRelevance = ResourceControls(framework, resource.Type, resource.SecurityRelevance)
+		}
+		module.SecurityRelevance = h.analyzeModuleSecurityRelevance(module)
+		frameworks.Sort(framework, module.SecurityRelevance)
+	}
+}
+
 // analyzeMultiAZConfig analyzes multi-AZ configuration for a resource
 func (h *HCLParser) analyzeMultiAZConfig(resource *models.TerraformResource) *models.MultiAZConfiguration {
 	config := &models.MultiAZConfiguration{}
@@ -0,0 +1,115 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import "strings"
+
+// hipaaResourceMappings maps resource types to the HIPAA Security Rule
+// safeguards they provide evidence for: access control and authentication
+// (164.312(a), 164.312(d)), audit controls (164.312(b)), encryption at rest
+// and in transit (164.312(a)(2)(iv), 164.312(e)) and data backup
+// (164.308(a)(7)). Resources the Security Rule has no safeguard for are
+// left out.
+var hipaaResourceMappings = map[string][]string{
+	// AWS IAM
+	"aws_iam_role":                   {"164.308(a)(4)(ii)(B)", "164.312(a)(1)"},
+	"aws_iam_policy":                 {"164.308(a)(4)(ii)(B)", "164.312(a)(1)"},
+	"aws_iam_user":                   {"164.312(a)(2)(i)", "164.312(d)"},
+	"aws_iam_group":                  {"164.308(a)(4)(ii)(B)", "164.312(a)(1)"},
+	"aws_iam_access_key":             {"164.308(a)(5)(ii)(D)", "164.312(d)"},
+	"aws_iam_role_policy_attachment": {"164.308(a)(4)(ii)(C)"},
+
+	// AWS Network Security
+	"aws_vpc":              {"164.312(e)(1)"},
+	"aws_security_group":   {"164.312(a)(1)", "164.312(e)(1)"},
+	"aws_nacl":             {"164.312(a)(1)", "164.312(e)(1)"},
+	"aws_network_acl":      {"164.312(a)(1)", "164.312(e)(1)"},
+	"aws_subnet":           {"164.312(e)(1)"},
+	"aws_route_table":      {"164.312(e)(1)"},
+	"aws_internet_gateway": {"164.312(e)(1)"},
+	"aws_nat_gateway":      {"164.312(e)(1)"},
+
+	// AWS Load Balancing & SSL
+	"aws_lb":                      {"164.312(e)(1)"},
+	"aws_lb_listener":             {"164.312(e)(1)", "164.312(e)(2)(ii)"},
+	"aws_alb":                     {"164.312(e)(1)"},
+	"aws_cloudfront_distribution": {"164.312(e)(1)", "164.312(e)(2)(ii)"},
+	"aws_acm_certificate":         {"164.312(e)(2)(ii)"},
+
+	// AWS Encryption & Data Protection
+	"aws_kms_key":                       {"164.312(a)(2)(iv)", "164.312(e)(2)(ii)"},
+	"aws_kms_alias":                     {"164.312(a)(2)(iv)"},
+	"aws_s3_bucket":                     {"164.312(a)(2)(iv)", "164.312(c)(1)"},
+	"aws_s3_bucket_policy":              {"164.312(a)(1)"},
+	"aws_s3_bucket_encryption":          {"164.312(a)(2)(iv)"},
+	"aws_s3_bucket_public_access_block": {"164.312(a)(1)"},
+	"aws_ebs_encryption_by_default":     {"164.312(a)(2)(iv)"},
+	"aws_rds_cluster":                   {"164.312(a)(2)(iv)", "164.308(a)(7)(ii)(A)"},
+	"aws_db_instance":                   {"164.312(a)(2)(iv)", "164.308(a)(7)(ii)(A)"},
+
+	// AWS Monitoring & Logging
+	"aws_cloudtrail":                    {"164.308(a)(1)(ii)(D)", "164.312(b)"},
+	"aws_cloudwatch_log_group":          {"164.312(b)"},
+	"aws_cloudwatch_metric_alarm":       {"164.308(a)(1)(ii)(D)", "164.308(a)(5)(ii)(C)"},
+	"aws_config_configuration_recorder": {"164.308(a)(8)", "164.312(b)"},
+	"aws_guardduty_detector":            {"164.308(a)(1)(ii)(D)", "164.308(a)(6)(ii)"},
+
+	// AWS Backup & Recovery
+	"aws_backup_plan":  {"164.308(a)(7)(ii)(A)", "164.310(d)(2)(iv)"},
+	"aws_backup_vault": {"164.308(a)(7)(ii)(A)", "164.310(d)(2)(iv)"},
+
+	// Azure equivalents
+	"azurerm_virtual_network":        {"164.312(e)(1)"},
+	"azurerm_network_security_group": {"164.312(a)(1)", "164.312(e)(1)"},
+	"azurerm_key_vault":              {"164.312(a)(2)(iv)", "164.312(e)(2)(ii)"},
+	"azurerm_storage_account":        {"164.312(a)(2)(iv)", "164.312(e)(2)(ii)"},
+
+	// Google Cloud equivalents
+	"google_compute_network":     {"164.312(e)(1)"},
+	"google_compute_firewall":    {"164.312(a)(1)", "164.312(e)(1)"},
+	"google_kms_crypto_key":      {"164.312(a)(2)(iv)", "164.312(e)(2)(ii)"},
+	"google_storage_bucket":      {"164.312(a)(2)(iv)", "164.312(c)(1)"},
+	"google_project_iam_binding": {"164.308(a)(4)(ii)(B)", "164.312(a)(1)"},
+}
+
+// HIPAAControls returns the HIPAA safeguards that a resource type relates
+// to, falling back to keyword matching for unknown resource types
+func HIPAAControls(resourceType string) []string {
+	if controls, exists := hipaaResourceMappings[resourceType]; exists {
+		return controls
+	}
+
+	resourceLower := strings.ToLower(resourceType)
+	var controls []string
+
+	if strings.Contains(resourceLower, "iam") || strings.Contains(resourceLower, "auth") || strings.Contains(resourceLower, "access") {
+		controls = append(controls, "164.312(a)(1)")
+	}
+	if strings.Contains(resourceLower, "encrypt") || strings.Contains(resourceLower, "kms") || strings.Contains(resourceLower, "key") {
+		controls = append(controls, "164.312(a)(2)(iv)")
+	}
+	if strings.Contains(resourceLower, "log") || strings.Contains(resourceLower, "monitor") || strings.Contains(resourceLower, "audit") {
+		controls = append(controls, "164.312(b)")
+	}
+	if strings.Contains(resourceLower, "network") || strings.Contains(resourceLower, "firewall") || strings.Contains(resourceLower, "security_group") {
+		controls = append(controls, "164.312(e)(1)")
+	}
+	if strings.Contains(resourceLower, "backup") || strings.Contains(resourceLower, "snapshot") {
+		controls = append(controls, "164.308(a)(7)(ii)(A)")
+	}
+
+	return controls
+}
@@ -0,0 +1,91 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package terraform
+
+import (
+	"testing"
+
+	"github.com/grctool/grctool/internal/frameworks"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHIPAAControls(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		resourceType string
+		want         []string
+	}{
+		"access control":  {resourceType: "aws_iam_role", want: []string{"164.308(a)(4)(ii)(B)", "164.312(a)(1)"}},
+		"encryption":      {resourceType: "aws_kms_key", want: []string{"164.312(a)(2)(iv)", "164.312(e)(2)(ii)"}},
+		"audit controls":  {resourceType: "aws_cloudtrail", want: []string{"164.308(a)(1)(ii)(D)", "164.312(b)"}},
+		"backup":          {resourceType: "aws_backup_plan", want: []string{"164.308(a)(7)(ii)(A)", "164.310(d)(2)(iv)"}},
+		"keyword backup":  {resourceType: "google_sql_backup_run", want: []string{"164.308(a)(7)(ii)(A)"}},
+		"no safeguard":    {resourceType: "aws_autoscaling_group", want: nil},
+		"keyword network": {resourceType: "oci_core_network_security_group", want: []string{"164.312(e)(1)"}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, HIPAAControls(tc.resourceType))
+		})
+	}
+}
+
+func TestHIPAAControls_Canonical(t *testing.T) {
+	t.Parallel()
+
+	for resourceType, safeguards := range hipaaResourceMappings {
+		for _, safeguard := range safeguards {
+			code, ok := frameworks.NormalizeHIPAACode(safeguard)
+			assert.True(t, ok, "%s maps to invalid HIPAA safeguard %s", resourceType, safeguard)
+			assert.Equal(t, safeguard, code, "%s maps to non-canonical code", resourceType)
+		}
+	}
+}
+
+func TestIndexQuery_ByHIPAASafeguard(t *testing.T) {
+	t.Parallel()
+
+	iq := NewIndexQuery(&PersistedIndex{Index: &SecurityIndex{IndexedResources: []IndexedResource{
+		{ResourceID: "aws_kms_key.main", ResourceType: "aws_kms_key"},
+		{ResourceID: "aws_cloudtrail.audit", ResourceType: "aws_cloudtrail"},
+		{ResourceID: "aws_security_group.web", ResourceType: "aws_security_group"},
+	}}})
+
+	result := iq.ByHIPAASafeguard("45 CFR § 164.312(b)", "164.312(a)(2)(IV)")
+	require.Equal(t, 2, result.Count)
+	assert.Equal(t, "aws_kms_key.main", result.Resources[0].ResourceID)
+	assert.Equal(t, "aws_cloudtrail.audit", result.Resources[1].ResourceID)
+	assert.Equal(t, "by_hipaa_safeguard", result.Metadata["query_type"])
+
+	assert.Zero(t, iq.ByHIPAASafeguard("164.310(b)").Count)
+}
+
+func TestBuildSARIFLog_HIPAA(t *testing.T) {
+	t.Parallel()
+
+	analyzer := &SecurityAnalyzer{}
+	group := analyzer.extractSecurityResource(models.TerraformScanResult{
+		ResourceType:  "aws_security_group",
+		ResourceName:  "web",
+		FilePath:      "infra/network.tf",
+		Configuration: map[string]interface{}{"ingress_cidr_blocks": "0.0.0.0/0"},
+	}, false)
+
+	log := BuildSARIFLog(&SecurityAnalysisResult{
+		Framework:         frameworks.HIPAA,
+		SecurityResources: []SecurityResource{group},
+	}, "")
+
+	run := log.Runs[0]
+	require.Len(t, run.Tool.Driver.Rules, 1)
+	assert.Equal(t, []string{"security", "network", "hipaa/164.312(a)(1)", "hipaa/164.312(e)(1)"},
+		run.Tool.Driver.Rules[0].Properties["tags"])
+	require.Len(t, run.Results, 1)
+	assert.Equal(t, []string{"164.312(a)(1)", "164.312(e)(1)"}, run.Results[0].Properties["hipaa_controls"])
+}
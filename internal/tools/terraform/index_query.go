@@ -21,6 +21,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/grctool/grctool/internal/frameworks"
+
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
@@ -73,6 +75,65 @@ func (iq *IndexQuery) ByControl(controlCodes ...string) *QueryResult {
 	}
 }
 
+// ByAnnexAControl queries resources by ISO 27001 Annex A control codes. The
+// index stores SOC2 relevance only, so Annex A controls are resolved from
+// each resource's type. Codes may omit the "A." prefix.
+func (iq *IndexQuery) ByAnnexAControl(controlCodes ...string) *QueryResult {
+	return iq.byResourceTypeControls("by_annex_a_control", frameworks.NormalizeAnnexACode, AnnexAControls, controlCodes)
+}
+
+// ByNISTControl queries resources by NIST SP 800-53 control codes, resolved
+// from each resource's type like ByAnnexAControl
+func (iq *IndexQuery) ByNISTControl(controlCodes ...string) *QueryResult {
+	return iq.byResourceTypeControls("by_nist_control", frameworks.NormalizeNISTCode, NISTControls, controlCodes)
+}
+
+// ByPCIRequirement queries resources by PCI DSS requirement numbers, resolved
+// from each resource's type like ByAnnexAControl
+func (iq *IndexQuery) ByPCIRequirement(requirements ...string) *QueryResult {
+	return iq.byResourceTypeControls("by_pci_requirement", frameworks.NormalizePCICode, PCIControls, requirements)
+}
+
+// ByHIPAASafeguard queries resources by HIPAA Security Rule citations,
+// resolved from each resource's type like ByAnnexAControl
+func (iq *IndexQuery) ByHIPAASafeguard(citations ...string) *QueryResult {
+	return iq.byResourceTypeControls("by_hipaa_safeguard", frameworks.NormalizeHIPAACode, HIPAAControls, citations)
+}
+
+// byResourceTypeControls returns the resources whose type relates to any of
+// the control codes, after normalizing the codes
+func (iq *IndexQuery) byResourceTypeControls(queryType string, normalize func(string) (string, bool),
+	controlsOf func(string) []string, controlCodes []string) *QueryResult {
+	start := time.Now()
+	var resources []IndexedResource
+	controlSet := make(map[string]bool)
+
+	for _, control := range controlCodes {
+		if code, ok := normalize(control); ok {
+			controlSet[code] = true
+		}
+	}
+
+	for _, resource := range iq.index.Index.IndexedResources {
+		for _, control := range controlsOf(resource.ResourceType) {
+			if controlSet[control] {
+				resources = append(resources, resource)
+				break
+			}
+		}
+	}
+
+	return &QueryResult{
+		Resources: resources,
+		Count:     len(resources),
+		QueryTime: time.Since(start),
+		Metadata: map[string]interface{}{
+			"query_type":    queryType,
+			"control_codes": controlCodes,
+		},
+	}
+}
+
 // ByAttribute queries resources by security attributes
 func (iq *IndexQuery) ByAttribute(attributes ...string) *QueryResult {
 	start := time.Now()
@@ -30,7 +30,7 @@ import (
 
 const (
 	// IndexVersion is the current version of the index format
-	IndexVersion = "1.0.0"
+	IndexVersion = "1.1.0"
 
 	// IndexFileName is the default name for the index file
 	IndexFileName = "index.json.gz"
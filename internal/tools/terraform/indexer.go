@@ -523,9 +523,10 @@ func (sai *SecurityAttributeIndexer) indexResource(resource models.TerraformScan
 
 	// Include metadata if requested
 	if query.IncludeMetadata {
-		// Filter configuration to include only security-relevant items
+		// Filter configuration to include only security-relevant items and
+		// the keys CIS benchmark checks read
 		for key, value := range resource.Configuration {
-			if sai.isSecurityRelevantConfig(key, value) {
+			if sai.isSecurityRelevantConfig(key, value) || cisReadsConfig(resource.ResourceType, key) {
 				indexed.Configuration[key] = value
 			}
 		}
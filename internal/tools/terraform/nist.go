@@ -0,0 +1,120 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import "strings"
+
+// nistResourceMappings maps resource types to the NIST SP 800-53 Rev. 5
+// controls they provide evidence for
+var nistResourceMappings = map[string][]string{
+	// AWS IAM
+	"aws_iam_role":                   {"AC-2", "AC-3", "AC-6"},
+	"aws_iam_policy":                 {"AC-3", "AC-6"},
+	"aws_iam_user":                   {"AC-2", "IA-2", "IA-4"},
+	"aws_iam_group":                  {"AC-2", "AC-6"},
+	"aws_iam_access_key":             {"IA-2", "IA-5"},
+	"aws_iam_role_policy_attachment": {"AC-3", "AC-6"},
+
+	// AWS Network Security
+	"aws_vpc":              {"SC-7"},
+	"aws_security_group":   {"AC-4", "SC-7"},
+	"aws_nacl":             {"AC-4", "SC-7"},
+	"aws_network_acl":      {"AC-4", "SC-7"},
+	"aws_subnet":           {"SC-7"},
+	"aws_route_table":      {"AC-4", "SC-7"},
+	"aws_internet_gateway": {"SC-7"},
+	"aws_nat_gateway":      {"SC-7"},
+
+	// AWS Load Balancing & SSL
+	"aws_lb":                      {"SC-7", "SC-8"},
+	"aws_lb_listener":             {"SC-8", "SC-13"},
+	"aws_alb":                     {"SC-7", "SC-8"},
+	"aws_cloudfront_distribution": {"SC-5", "SC-8"},
+	"aws_acm_certificate":         {"SC-12", "SC-17"},
+
+	// AWS Encryption & Data Protection
+	"aws_kms_key":                       {"SC-12", "SC-13"},
+	"aws_kms_alias":                     {"SC-12"},
+	"aws_s3_bucket":                     {"AC-3", "SC-28"},
+	"aws_s3_bucket_policy":              {"AC-3"},
+	"aws_s3_bucket_encryption":          {"SC-13", "SC-28"},
+	"aws_s3_bucket_public_access_block": {"AC-3", "AC-22"},
+	"aws_s3_bucket_versioning":          {"CP-9"},
+	"aws_ebs_encryption_by_default":     {"SC-28"},
+	"aws_rds_cluster":                   {"CP-9", "SC-28"},
+	"aws_db_instance":                   {"SC-28"},
+	"aws_backup_plan":                   {"CP-9"},
+	"aws_backup_vault":                  {"CP-9", "SC-28"},
+
+	// AWS Monitoring & Logging
+	"aws_cloudtrail":                    {"AU-2", "AU-9", "AU-12"},
+	"aws_cloudwatch_log_group":          {"AU-9", "AU-11"},
+	"aws_cloudwatch_metric_alarm":       {"AU-6", "SI-4"},
+	"aws_config_configuration_recorder": {"CM-2", "CM-8"},
+	"aws_guardduty_detector":            {"SI-4"},
+
+	// Autoscaling resources (capacity management)
+	"aws_autoscaling_group":               {"CP-2", "SC-6"},
+	"aws_autoscaling_policy":              {"SC-6"},
+	"aws_appautoscaling_target":           {"SC-6"},
+	"aws_appautoscaling_policy":           {"SC-6"},
+	"aws_appautoscaling_scheduled_action": {"SC-6"},
+	"aws_ecs_service":                     {"SC-6"},
+	"aws_eks_node_group":                  {"CP-2", "SC-6"},
+
+	// Azure equivalents
+	"azurerm_resource_group":         {"CM-8"},
+	"azurerm_virtual_network":        {"SC-7"},
+	"azurerm_network_security_group": {"AC-4", "SC-7"},
+	"azurerm_key_vault":              {"SC-12", "SC-13"},
+	"azurerm_storage_account":        {"SC-8", "SC-28"},
+
+	// Google Cloud equivalents
+	"google_compute_network":     {"SC-7"},
+	"google_compute_firewall":    {"AC-4", "SC-7"},
+	"google_kms_crypto_key":      {"SC-12", "SC-13"},
+	"google_storage_bucket":      {"AC-3", "SC-28"},
+	"google_project_iam_binding": {"AC-2", "AC-3", "AC-6"},
+}
+
+// NISTControls returns the NIST SP 800-53 controls that a resource type
+// relates to, falling back to keyword matching for unknown resource types
+func NISTControls(resourceType string) []string {
+	if controls, exists := nistResourceMappings[resourceType]; exists {
+		return controls
+	}
+
+	resourceLower := strings.ToLower(resourceType)
+	var controls []string
+
+	if strings.Contains(resourceLower, "iam") || strings.Contains(resourceLower, "auth") || strings.Contains(resourceLower, "access") {
+		controls = append(controls, "AC-3", "AC-6")
+	}
+	if strings.Contains(resourceLower, "network") || strings.Contains(resourceLower, "firewall") || strings.Contains(resourceLower, "security_group") {
+		controls = append(controls, "SC-7")
+	}
+	if strings.Contains(resourceLower, "encrypt") || strings.Contains(resourceLower, "kms") || strings.Contains(resourceLower, "key") {
+		controls = append(controls, "SC-12", "SC-13")
+	}
+	if strings.Contains(resourceLower, "backup") || strings.Contains(resourceLower, "snapshot") {
+		controls = append(controls, "CP-9")
+	}
+	if strings.Contains(resourceLower, "log") || strings.Contains(resourceLower, "monitor") || strings.Contains(resourceLower, "audit") {
+		controls = append(controls, "AU-2", "AU-12")
+	}
+
+	return controls
+}
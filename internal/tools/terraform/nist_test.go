@@ -0,0 +1,116 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package terraform
+
+import (
+	"testing"
+
+	"github.com/grctool/grctool/internal/frameworks"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNISTControls(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		resourceType string
+		want         []string
+	}{
+		"mapped":          {resourceType: "aws_kms_key", want: []string{"SC-12", "SC-13"}},
+		"logging":         {resourceType: "aws_cloudtrail", want: []string{"AU-2", "AU-9", "AU-12"}},
+		"keyword network": {resourceType: "oci_core_network_security_group", want: []string{"SC-7"}},
+		"keyword backup":  {resourceType: "oci_database_backup", want: []string{"CP-9"}},
+		"unrelated":       {resourceType: "random_pet", want: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, NISTControls(tc.resourceType))
+		})
+	}
+}
+
+func TestNISTControls_InCatalog(t *testing.T) {
+	t.Parallel()
+
+	for resourceType, controls := range nistResourceMappings {
+		for _, control := range controls {
+			code, ok := frameworks.NormalizeNISTCode(control)
+			assert.True(t, ok, "%s maps to unknown NIST 800-53 control %s", resourceType, control)
+			assert.Equal(t, control, code, "%s maps to non-canonical code", resourceType)
+		}
+	}
+}
+
+func TestResourceControls_NIST(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"AC-3", "SC-28"}, ResourceControls(frameworks.NIST80053, "aws_s3_bucket", []string{"CC6.1"}))
+}
+
+func TestIndexQuery_ByNISTControl(t *testing.T) {
+	t.Parallel()
+
+	iq := NewIndexQuery(&PersistedIndex{Index: &SecurityIndex{IndexedResources: []IndexedResource{
+		{ResourceID: "aws_kms_key.main", ResourceType: "aws_kms_key"},
+		{ResourceID: "aws_cloudtrail.audit", ResourceType: "aws_cloudtrail"},
+		{ResourceID: "aws_security_group.web", ResourceType: "aws_security_group"},
+	}}})
+
+	result := iq.ByNISTControl("sc-13", "AU 12")
+	require.Equal(t, 2, result.Count)
+	assert.Equal(t, "aws_kms_key.main", result.Resources[0].ResourceID)
+	assert.Equal(t, "aws_cloudtrail.audit", result.Resources[1].ResourceID)
+	assert.Equal(t, "by_nist_control", result.Metadata["query_type"])
+
+	assert.Zero(t, iq.ByNISTControl("PE-3").Count)
+}
+
+func TestBuildSARIFLog_NIST(t *testing.T) {
+	t.Parallel()
+
+	analyzer := &SecurityAnalyzer{}
+	group := analyzer.extractSecurityResource(models.TerraformScanResult{
+		ResourceType:  "aws_security_group",
+		ResourceName:  "web",
+		FilePath:      "infra/network.tf",
+		Configuration: map[string]interface{}{"ingress_cidr_blocks": "0.0.0.0/0"},
+	}, false)
+	assert.Equal(t, []string{"AC-4", "SC-7"}, group.NISTControls)
+
+	log := BuildSARIFLog(&SecurityAnalysisResult{
+		Framework:         frameworks.NIST80053,
+		SecurityResources: []SecurityResource{group},
+	}, "")
+
+	run := log.Runs[0]
+	require.Len(t, run.Tool.Driver.Rules, 1)
+	assert.Equal(t, []string{"security", "network", "nist80053/AC-4", "nist80053/SC-7"},
+		run.Tool.Driver.Rules[0].Properties["tags"])
+	require.Len(t, run.Results, 1)
+	assert.Equal(t, []string{"AC-4", "SC-7"}, run.Results[0].Properties["nist80053_controls"])
+	assert.NotContains(t, run.Results[0].Properties, "soc2_controls")
+}
+
+func TestHCLParser_ApplyFramework_NIST(t *testing.T) {
+	t.Parallel()
+
+	result := &models.TerraformParseResult{Modules: []models.TerraformModule{{
+		Resources: []models.TerraformResource{
+			{Type: "aws_kms_key", SecurityRelevance: []string{"CC6.8"}},
+			{Type: "aws_cloudtrail", SecurityRelevance: []string{"CC7.2"}},
+		},
+		SecurityRelevance: []string{"CC6.8", "CC7.2"},
+	}}}
+
+	(&HCLParser{}).ApplyFramework(result, frameworks.NIST80053)
+	assert.Equal(t, frameworks.NIST80053, result.Framework)
+	module := result.Modules[0]
+	assert.Equal(t, []string{"SC-12", "SC-13"}, module.Resources[0].SecurityRelevance)
+	assert.Equal(t, []string{"AU-2", "AU-9", "AU-12"}, module.Resources[1].SecurityRelevance)
+	assert.Equal(t, []string{"AU-2", "AU-9", "AU-12", "SC-12", "SC-13"}, module.SecurityRelevance)
+}
@@ -0,0 +1,107 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import "strings"
+
+// pciResourceMappings maps resource types to the PCI DSS v4.0 requirements
+// they provide evidence for: network segmentation (1.x), protection of
+// stored and transmitted account data (3.x, 4.x), access control (7.x, 8.x)
+// and logging and monitoring (10.x, 11.x). Resources PCI DSS has no
+// requirement for, such as autoscaling, are left out.
+var pciResourceMappings = map[string][]string{
+	// AWS IAM
+	"aws_iam_role":                   {"7.2.1", "7.2.2", "7.2.5"},
+	"aws_iam_policy":                 {"7.2.1", "7.2.2"},
+	"aws_iam_user":                   {"8.2.1", "8.3.1"},
+	"aws_iam_group":                  {"7.2.1", "7.2.2"},
+	"aws_iam_access_key":             {"8.3.1", "8.6.3"},
+	"aws_iam_role_policy_attachment": {"7.2.2"},
+
+	// AWS Network Security
+	"aws_vpc":              {"1.2.1", "1.4.1"},
+	"aws_security_group":   {"1.2.5", "1.3.1", "1.3.2"},
+	"aws_nacl":             {"1.2.5", "1.3.1", "1.3.2"},
+	"aws_network_acl":      {"1.2.5", "1.3.1", "1.3.2"},
+	"aws_subnet":           {"1.4.1"},
+	"aws_route_table":      {"1.3.2", "1.4.1"},
+	"aws_internet_gateway": {"1.4.1"},
+	"aws_nat_gateway":      {"1.3.2"},
+
+	// AWS Load Balancing & SSL
+	"aws_lb":                      {"1.4.1", "4.2.1"},
+	"aws_lb_listener":             {"4.2.1"},
+	"aws_alb":                     {"1.4.1", "4.2.1"},
+	"aws_cloudfront_distribution": {"4.2.1"},
+	"aws_acm_certificate":         {"4.2.1", "4.2.1.1"},
+
+	// AWS Encryption & Data Protection
+	"aws_kms_key":                       {"3.6.1", "3.7.1"},
+	"aws_kms_alias":                     {"3.6.1"},
+	"aws_s3_bucket":                     {"3.5.1"},
+	"aws_s3_bucket_policy":              {"7.2.1"},
+	"aws_s3_bucket_encryption":          {"3.5.1"},
+	"aws_s3_bucket_public_access_block": {"1.4.4"},
+	"aws_ebs_encryption_by_default":     {"3.5.1"},
+	"aws_rds_cluster":                   {"3.5.1"},
+	"aws_db_instance":                   {"3.5.1"},
+
+	// AWS Monitoring & Logging
+	"aws_cloudtrail":                    {"10.2.1", "10.2.2", "10.3.2"},
+	"aws_cloudwatch_log_group":          {"10.3.3", "10.5.1"},
+	"aws_cloudwatch_metric_alarm":       {"10.4.1", "10.7.2"},
+	"aws_config_configuration_recorder": {"2.2.1"},
+	"aws_guardduty_detector":            {"10.4.1", "11.5.1"},
+
+	// Azure equivalents
+	"azurerm_virtual_network":        {"1.4.1"},
+	"azurerm_network_security_group": {"1.2.5", "1.3.1", "1.3.2"},
+	"azurerm_key_vault":              {"3.6.1", "3.7.1"},
+	"azurerm_storage_account":        {"3.5.1", "4.2.1"},
+
+	// Google Cloud equivalents
+	"google_compute_network":     {"1.4.1"},
+	"google_compute_firewall":    {"1.2.5", "1.3.1", "1.3.2"},
+	"google_kms_crypto_key":      {"3.6.1", "3.7.1"},
+	"google_storage_bucket":      {"3.5.1"},
+	"google_project_iam_binding": {"7.2.1", "7.2.2"},
+}
+
+// PCIControls returns the PCI DSS requirements that a resource type relates
+// to, falling back to keyword matching for unknown resource types
+func PCIControls(resourceType string) []string {
+	if controls, exists := pciResourceMappings[resourceType]; exists {
+		return controls
+	}
+
+	resourceLower := strings.ToLower(resourceType)
+	var controls []string
+
+	if strings.Contains(resourceLower, "network") || strings.Contains(resourceLower, "firewall") || strings.Contains(resourceLower, "security_group") {
+		controls = append(controls, "1.3.1", "1.3.2")
+	}
+	if strings.Contains(resourceLower, "encrypt") || strings.Contains(resourceLower, "kms") || strings.Contains(resourceLower, "key") {
+		controls = append(controls, "3.6.1")
+	}
+	if strings.Contains(resourceLower, "iam") || strings.Contains(resourceLower, "auth") || strings.Contains(resourceLower, "access") {
+		controls = append(controls, "7.2.1", "7.2.2")
+	}
+	if strings.Contains(resourceLower, "log") || strings.Contains(resourceLower, "monitor") || strings.Contains(resourceLower, "audit") {
+		controls = append(controls, "10.2.1")
+	}
+
+	return controls
+}
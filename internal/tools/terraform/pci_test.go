@@ -0,0 +1,90 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package terraform
+
+import (
+	"testing"
+
+	"github.com/grctool/grctool/internal/frameworks"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPCIControls(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		resourceType string
+		want         []string
+	}{
+		"segmentation":    {resourceType: "aws_security_group", want: []string{"1.2.5", "1.3.1", "1.3.2"}},
+		"encryption":      {resourceType: "aws_kms_key", want: []string{"3.6.1", "3.7.1"}},
+		"logging":         {resourceType: "aws_cloudtrail", want: []string{"10.2.1", "10.2.2", "10.3.2"}},
+		"access control":  {resourceType: "aws_iam_role", want: []string{"7.2.1", "7.2.2", "7.2.5"}},
+		"keyword network": {resourceType: "oci_core_network_security_group", want: []string{"1.3.1", "1.3.2"}},
+		"no requirement":  {resourceType: "aws_autoscaling_group", want: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, PCIControls(tc.resourceType))
+		})
+	}
+}
+
+func TestPCIControls_Canonical(t *testing.T) {
+	t.Parallel()
+
+	for resourceType, requirements := range pciResourceMappings {
+		for _, requirement := range requirements {
+			code, ok := frameworks.NormalizePCICode(requirement)
+			assert.True(t, ok, "%s maps to invalid PCI DSS requirement %s", resourceType, requirement)
+			assert.Equal(t, requirement, code, "%s maps to non-canonical code", resourceType)
+		}
+	}
+}
+
+func TestIndexQuery_ByPCIRequirement(t *testing.T) {
+	t.Parallel()
+
+	iq := NewIndexQuery(&PersistedIndex{Index: &SecurityIndex{IndexedResources: []IndexedResource{
+		{ResourceID: "aws_kms_key.main", ResourceType: "aws_kms_key"},
+		{ResourceID: "aws_cloudtrail.audit", ResourceType: "aws_cloudtrail"},
+		{ResourceID: "aws_security_group.web", ResourceType: "aws_security_group"},
+	}}})
+
+	result := iq.ByPCIRequirement("Req. 1.3.1", "10.2.1")
+	require.Equal(t, 2, result.Count)
+	assert.Equal(t, "aws_cloudtrail.audit", result.Resources[0].ResourceID)
+	assert.Equal(t, "aws_security_group.web", result.Resources[1].ResourceID)
+	assert.Equal(t, "by_pci_requirement", result.Metadata["query_type"])
+
+	assert.Zero(t, iq.ByPCIRequirement("9.4.7").Count)
+}
+
+func TestBuildSARIFLog_PCI(t *testing.T) {
+	t.Parallel()
+
+	analyzer := &SecurityAnalyzer{}
+	group := analyzer.extractSecurityResource(models.TerraformScanResult{
+		ResourceType:  "aws_security_group",
+		ResourceName:  "web",
+		FilePath:      "infra/network.tf",
+		Configuration: map[string]interface{}{"ingress_cidr_blocks": "0.0.0.0/0"},
+	}, false)
+
+	log := BuildSARIFLog(&SecurityAnalysisResult{
+		Framework:         frameworks.PCIDSS,
+		SecurityResources: []SecurityResource{group},
+	}, "")
+
+	run := log.Runs[0]
+	require.Len(t, run.Tool.Driver.Rules, 1)
+	assert.Equal(t, []string{"security", "network", "pcidss/1.3.1", "pcidss/1.4.2"},
+		run.Tool.Driver.Rules[0].Properties["tags"])
+	require.Len(t, run.Results, 1)
+	assert.Equal(t, []string{"1.3.1", "1.4.2"}, run.Results[0].Properties["pcidss_controls"])
+}
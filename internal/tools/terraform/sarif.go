@@ -0,0 +1,272 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/grctool/grctool/internal/frameworks"
+)
+
+// SARIF 2.1.0 schema identifiers
+const (
+	SARIFVersion = "2.1.0"
+	SARIFSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// SARIFLog is the root of a SARIF document
+type SARIFLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+// SARIFRun is a single analysis run
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+}
+
+// SARIFTool describes the analyzer that produced the results
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+// SARIFDriver is the analyzer component with its rule catalog
+type SARIFDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []SARIFRule `json:"rules"`
+}
+
+// SARIFRule describes one security check
+type SARIFRule struct {
+	ID                   string                 `json:"id"`
+	Name                 string                 `json:"name,omitempty"`
+	ShortDescription     SARIFMessage           `json:"shortDescription"`
+	Help                 *SARIFMessage          `json:"help,omitempty"`
+	DefaultConfiguration SARIFRuleConfiguration `json:"defaultConfiguration"`
+	Properties           map[string]interface{} `json:"properties,omitempty"`
+}
+
+// SARIFRuleConfiguration holds the default severity level of a rule
+type SARIFRuleConfiguration struct {
+	Level string `json:"level"`
+}
+
+// SARIFMessage is a plain-text message
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+// SARIFResult is a single finding
+type SARIFResult struct {
+	RuleID     string                 `json:"ruleId"`
+	RuleIndex  int                    `json:"ruleIndex"`
+	Level      string                 `json:"level"`
+	Message    SARIFMessage           `json:"message"`
+	Locations  []SARIFLocation        `json:"locations,omitempty"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+// SARIFLocation points at the resource declaration
+type SARIFLocation struct {
+	PhysicalLocation SARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+// SARIFPhysicalLocation is a file and optional line region
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           *SARIFRegion          `json:"region,omitempty"`
+}
+
+// SARIFArtifactLocation is a file URI, relative to the source root when possible
+type SARIFArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+// SARIFRegion is a line range within a file
+type SARIFRegion struct {
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine,omitempty"`
+}
+
+// BuildSARIFLog converts the security findings of an analysis into a SARIF
+// log. File paths under baseDir are made relative to it so code scanning
+// consumers can resolve them against the repository checkout.
+func BuildSARIFLog(analysis *SecurityAnalysisResult, baseDir string) *SARIFLog {
+	driver := SARIFDriver{
+		Name:           "grctool-terraform-security",
+		InformationURI: "https://github.com/grctool/grctool",
+		Rules:          []SARIFRule{},
+	}
+	ruleIndex := make(map[string]int)
+	results := []SARIFResult{}
+
+	for _, resource := range analysis.SecurityResources {
+		for _, finding := range resource.SecurityFindings {
+			ruleID := sarifRuleID(finding)
+			index, ok := ruleIndex[ruleID]
+			if !ok {
+				index = len(driver.Rules)
+				ruleIndex[ruleID] = index
+				driver.Rules = append(driver.Rules, sarifRule(ruleID, finding, analysis.Framework))
+			}
+
+			result := SARIFResult{
+				RuleID:    ruleID,
+				RuleIndex: index,
+				Level:     sarifLevel(finding.Severity),
+				Message: SARIFMessage{Text: fmt.Sprintf("%s: %s.%s",
+					finding.Description, resource.ResourceType, resource.ResourceName)},
+				Locations: []SARIFLocation{sarifLocation(resource, baseDir)},
+			}
+			if controls := finding.Controls(analysis.Framework); len(controls) > 0 {
+				result.Properties = map[string]interface{}{sarifControlsProperty(analysis.Framework): controls}
+			}
+			results = append(results, result)
+		}
+	}
+
+	return &SARIFLog{
+		Schema:  SARIFSchema,
+		Version: SARIFVersion,
+		Runs:    []SARIFRun{{Tool: SARIFTool{Driver: driver}, Results: results}},
+	}
+}
+
+func sarifRule(ruleID string, finding SecurityFinding, framework string) SARIFRule {
+	tags := []string{"security", finding.Type}
+	controls := append([]string(nil), finding.Controls(framework)...)
+	frameworks.Sort(framework, controls)
+	for _, control := range controls {
+		tags = append(tags, sarifFrameworkTag(framework)+"/"+control)
+	}
+
+	rule := SARIFRule{
+		ID:                   ruleID,
+		Name:                 sarifRuleName(ruleID),
+		ShortDescription:     SARIFMessage{Text: finding.Description},
+		DefaultConfiguration: SARIFRuleConfiguration{Level: sarifLevel(finding.Severity)},
+		Properties: map[string]interface{}{
+			"tags":              tags,
+			"security-severity": sarifSecuritySeverity(finding.Severity),
+		},
+	}
+	if finding.Recommendation != "" {
+		rule.Help = &SARIFMessage{Text: finding.Recommendation}
+	}
+	return rule
+}
+
+// sarifControlsProperty names the result property listing the finding's
+// controls of the framework
+func sarifControlsProperty(framework string) string {
+	return sarifFrameworkTag(framework) + "_controls"
+}
+
+func sarifFrameworkTag(framework string) string {
+	switch framework {
+	case frameworks.ISO27001, frameworks.NIST80053, frameworks.PCIDSS, frameworks.HIPAA:
+		return framework
+	}
+	return frameworks.SOC2
+}
+
+func sarifLocation(resource SecurityResource, baseDir string) SARIFLocation {
+	location := SARIFLocation{
+		PhysicalLocation: SARIFPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation(resource.FilePath, baseDir),
+		},
+	}
+	if start, end := parseLineRange(resource.LineRange); start > 0 {
+		region := &SARIFRegion{StartLine: start}
+		if end >= start {
+			region.EndLine = end
+		}
+		location.PhysicalLocation.Region = region
+	}
+	return location
+}
+
+// sarifArtifactLocation expresses the path relative to baseDir (%SRCROOT%)
+// when it lies inside it, and as an absolute file URI otherwise
+func sarifArtifactLocation(path, baseDir string) SARIFArtifactLocation {
+	if filepath.IsAbs(path) && baseDir != "" {
+		if rel, err := filepath.Rel(baseDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+	}
+	if filepath.IsAbs(path) {
+		return SARIFArtifactLocation{URI: "file://" + filepath.ToSlash(path)}
+	}
+	return SARIFArtifactLocation{URI: filepath.ToSlash(filepath.Clean(path)), URIBaseID: "%SRCROOT%"}
+}
+
+// parseLineRange parses the "start-end" line range recorded on resources
+func parseLineRange(lineRange string) (int, int) {
+	startText, endText, _ := strings.Cut(lineRange, "-")
+	start, _ := strconv.Atoi(strings.TrimSpace(startText))
+	end, _ := strconv.Atoi(strings.TrimSpace(endText))
+	return start, end
+}
+
+func sarifRuleID(finding SecurityFinding) string {
+	if finding.RuleID != "" {
+		return finding.RuleID
+	}
+	return "terraform-" + strings.ToLower(finding.Type)
+}
+
+// sarifRuleName converts "iam-wildcard-permissions" to "IamWildcardPermissions"
+func sarifRuleName(ruleID string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(ruleID, func(r rune) bool { return r == '-' || r == '_' }) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// sarifLevel maps finding severities onto SARIF result levels
+func sarifLevel(severity string) string {
+	switch strings.ToLower(severity) {
+	case "critical", "high":
+		return "error"
+	case "medium":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// sarifSecuritySeverity maps finding severities onto the CVSS-style scores
+// GitHub code scanning uses to rank security alerts
+func sarifSecuritySeverity(severity string) string {
+	switch strings.ToLower(severity) {
+	case "critical":
+		return "9.5"
+	case "high":
+		return "8.0"
+	case "medium":
+		return "5.5"
+	default:
+		return "2.0"
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/grctool/grctool/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSARIFLog(t *testing.T) {
+	t.Parallel()
+
+	baseDir := t.TempDir()
+	analyzer := &SecurityAnalyzer{}
+	bucket := analyzer.extractSecurityResource(models.TerraformScanResult{
+		ResourceType: "aws_s3_bucket",
+		ResourceName: "logs",
+		FilePath:     filepath.Join(baseDir, "infra", "s3.tf"),
+		LineStart:    12,
+		LineEnd:      20,
+	}, false)
+	group := analyzer.extractSecurityResource(models.TerraformScanResult{
+		ResourceType:  "aws_security_group",
+		ResourceName:  "web",
+		FilePath:      "infra/network.tf",
+		Configuration: map[string]interface{}{"ingress_cidr_blocks": "0.0.0.0/0"},
+	}, false)
+	otherBucket := analyzer.extractSecurityResource(models.TerraformScanResult{
+		ResourceType: "aws_s3_bucket",
+		ResourceName: "assets",
+		FilePath:     "/elsewhere/s3.tf",
+		LineStart:    3,
+		LineEnd:      9,
+	}, false)
+
+	log := BuildSARIFLog(&SecurityAnalysisResult{
+		SecurityResources: []SecurityResource{bucket, group, otherBucket},
+	}, baseDir)
+
+	assert.Equal(t, SARIFVersion, log.Version)
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+
+	require.Len(t, run.Tool.Driver.Rules, 2, "rules are deduplicated")
+	encryption := run.Tool.Driver.Rules[0]
+	assert.Equal(t, "s3-bucket-encryption", encryption.ID)
+	assert.Equal(t, "S3BucketEncryption", encryption.Name)
+	assert.Equal(t, "error", encryption.DefaultConfiguration.Level)
+	assert.Equal(t, "8.0", encryption.Properties["security-severity"])
+	assert.Equal(t, []string{"security", "encryption", "soc2/CC6.8"}, encryption.Properties["tags"])
+	require.NotNil(t, encryption.Help)
+	assert.Equal(t, "security-group-open-ingress", run.Tool.Driver.Rules[1].ID)
+
+	require.Len(t, run.Results, 3)
+	first := run.Results[0]
+	assert.Equal(t, 0, first.RuleIndex)
+	assert.Contains(t, first.Message.Text, "aws_s3_bucket.logs")
+	location := first.Locations[0].PhysicalLocation
+	assert.Equal(t, SARIFArtifactLocation{URI: "infra/s3.tf", URIBaseID: "%SRCROOT%"}, location.ArtifactLocation)
+	assert.Equal(t, &SARIFRegion{StartLine: 12, EndLine: 20}, location.Region)
+	assert.Equal(t, []string{"CC6.8"}, first.Properties["soc2_controls"])
+
+	second := run.Results[1]
+	assert.Equal(t, 1, second.RuleIndex)
+	assert.Nil(t, second.Locations[0].PhysicalLocation.Region, "index results carry no line numbers")
+	assert.Equal(t, "infra/network.tf", second.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+
+	assert.Equal(t, "file:///elsewhere/s3.tf", run.Results[2].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+
+	encoded, err := json.Marshal(log)
+	require.NoError(t, err)
+	assert.Contains(t, string(encoded), `"$schema":"https://json.schemastore.org/sarif-2.1.0.json"`)
+}
+
+func TestBuildSARIFLog_NoFindings(t *testing.T) {
+	t.Parallel()
+
+	log := BuildSARIFLog(&SecurityAnalysisResult{}, "")
+
+	encoded, err := json.Marshal(log)
+	require.NoError(t, err)
+	assert.Contains(t, string(encoded), `"results":[]`)
+	assert.Contains(t, string(encoded), `"rules":[]`)
+}
+
+func TestSARIFLevel(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"critical": "error",
+		"high":     "error",
+		"medium":   "warning",
+		"low":      "note",
+		"":         "note",
+	}
+	for severity, want := range tests {
+		assert.Equal(t, want, sarifLevel(severity), "severity %q", severity)
+	}
+}
@@ -19,12 +19,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 	"regexp"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/frameworks"
 	"github.com/grctool/grctool/internal/logger"
 	"github.com/grctool/grctool/internal/models"
 	"golang.org/x/text/cases"
@@ -56,7 +58,7 @@ func (tsa *SecurityAnalyzer) Name() string {
 
 // Description returns the tool description
 func (tsa *SecurityAnalyzer) Description() string {
-	return "Comprehensive security configuration analyzer for Terraform manifests with SOC2 control mapping"
+	return "Comprehensive security configuration analyzer for Terraform manifests with SOC2, ISO 27001, NIST 800-53, PCI DSS and HIPAA control mapping"
 }
 
 // GetClaudeToolDefinition returns the tool definition for Claude
@@ -73,6 +75,19 @@ func (tsa *SecurityAnalyzer) GetClaudeToolDefinition() models.ClaudeTool {
 					"enum":        []string{"encryption", "iam", "network", "backup", "monitoring", "all"},
 					"default":     "all",
 				},
+				"framework": map[string]interface{}{
+					"type":        "string",
+					"description": "Framework to map resources and findings to: soc2 (trust services criteria), iso27001 (ISO 27001:2022 Annex A), nist80053 (NIST SP 800-53 Rev. 5), pcidss (PCI DSS v4.0) or hipaa (HIPAA Security Rule safeguards)",
+					"enum":        frameworks.Supported,
+					"default":     frameworks.SOC2,
+				},
+				"controls": map[string]interface{}{
+					"type":        "array",
+					"description": "Controls of the selected framework to find evidence for (e.g., [\"CC6.1\"], [\"A.8.24\"] or [\"SC-28\"])",
+					"items": map[string]interface{}{
+						"type": "string",
+					},
+				},
 				"soc2_controls": map[string]interface{}{
 					"type":        "array",
 					"description": "Specific SOC2 controls to find evidence for (e.g., [\"CC6.1\", \"CC6.8\"])",
@@ -94,8 +109,8 @@ func (tsa *SecurityAnalyzer) GetClaudeToolDefinition() models.ClaudeTool {
 				},
 				"output_format": map[string]interface{}{
 					"type":        "string",
-					"description": "Output format: detailed_json, summary_markdown, or compliance_csv",
-					"enum":        []string{"detailed_json", "summary_markdown", "compliance_csv"},
+					"description": "Output format: detailed_json, summary_markdown, compliance_csv, or sarif (for code scanning)",
+					"enum":        []string{"detailed_json", "summary_markdown", "compliance_csv", "sarif"},
 					"default":     "detailed_json",
 				},
 				"extract_sensitive_configs": map[string]interface{}{
@@ -103,6 +118,11 @@ func (tsa *SecurityAnalyzer) GetClaudeToolDefinition() models.ClaudeTool {
 					"description": "Extract detailed security configurations (excludes actual secrets)",
 					"default":     true,
 				},
+				"cis_benchmark": map[string]interface{}{
+					"type":        "string",
+					"description": "Evaluate the CIS AWS Foundations (aws) or GCP Foundation (gcp) benchmark items against all declared resources, reporting pass/fail per item with control cross-references; all evaluates each benchmark whose provider has resources",
+					"enum":        []string{CISBenchmarkAWS, CISBenchmarkGCP, CISBenchmarkAll},
+				},
 				"skip_cache": map[string]interface{}{
 					"type":        "boolean",
 					"description": "Skip cached index and force live scan (default: false)",
@@ -124,6 +144,25 @@ func (tsa *SecurityAnalyzer) Execute(ctx context.Context, params map[string]inte
 		securityDomain = sd
 	}
 
+	framework, _ := params["framework"].(string)
+	framework, err := frameworks.Normalize(framework)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var controls []string
+	if c, ok := params["controls"].([]interface{}); ok {
+		for _, v := range c {
+			if str, ok := v.(string); ok {
+				control, err := frameworks.NormalizeControl(framework, str)
+				if err != nil {
+					return "", nil, err
+				}
+				controls = append(controls, control)
+			}
+		}
+	}
+
 	var soc2Controls []string
 	if sc, ok := params["soc2_controls"].([]interface{}); ok {
 		for _, c := range sc {
@@ -132,6 +171,9 @@ func (tsa *SecurityAnalyzer) Execute(ctx context.Context, params map[string]inte
 			}
 		}
 	}
+	if framework == frameworks.SOC2 {
+		controls = append(soc2Controls, controls...)
+	}
 
 	var evidenceTasks []string
 	if et, ok := params["evidence_tasks"].([]interface{}); ok {
@@ -162,8 +204,16 @@ func (tsa *SecurityAnalyzer) Execute(ctx context.Context, params map[string]inte
 		skipCache = sc
 	}
 
+	cisBenchmark, _ := params["cis_benchmark"].(string)
+	cisBenchmark = strings.ToLower(strings.TrimSpace(cisBenchmark))
+	switch cisBenchmark {
+	case "", CISBenchmarkAWS, CISBenchmarkGCP, CISBenchmarkAll:
+	default:
+		return "", nil, fmt.Errorf("unsupported CIS benchmark: %s (supported: aws, gcp, all)", cisBenchmark)
+	}
+
 	// Perform security analysis using index
-	securityAnalysis, err := tsa.performSecurityAnalysis(ctx, securityDomain, soc2Controls, evidenceTasks, extractSensitiveConfigs, skipCache)
+	securityAnalysis, err := tsa.performSecurityAnalysis(ctx, securityDomain, framework, controls, evidenceTasks, extractSensitiveConfigs, skipCache, cisBenchmark)
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to perform security analysis: %w", err)
 	}
@@ -188,6 +238,8 @@ func (tsa *SecurityAnalyzer) Execute(ctx context.Context, params map[string]inte
 		ExtractedAt: time.Now(),
 		Metadata: map[string]interface{}{
 			"security_domain":           securityDomain,
+			"framework":                 framework,
+			"controls":                  controls,
 			"soc2_controls":             soc2Controls,
 			"evidence_tasks":            evidenceTasks,
 			"resources_analyzed":        len(securityAnalysis.SecurityResources),
@@ -202,19 +254,26 @@ func (tsa *SecurityAnalyzer) Execute(ctx context.Context, params map[string]inte
 			"extract_sensitive_configs": extractSensitiveConfigs,
 		},
 	}
+	if cisBenchmark != "" {
+		source.Metadata["cis_benchmark"] = cisBenchmark
+		source.Metadata["cis_checks_failed"] = CISSummary(securityAnalysis.CISResults)[CISStatusFail]
+	}
 
 	return report, source, nil
 }
 
-// performSecurityAnalysis performs comprehensive security configuration analysis
-func (tsa *SecurityAnalyzer) performSecurityAnalysis(ctx context.Context, domain string, soc2Controls, evidenceTasks []string, extractSensitive bool, skipCache bool) (*SecurityAnalysisResult, error) {
+// performSecurityAnalysis performs comprehensive security configuration
+// analysis, restricted to resources evidencing the requested controls of the
+// framework when any are given. CIS benchmark items are evaluated against
+// all indexed resources.
+func (tsa *SecurityAnalyzer) performSecurityAnalysis(ctx context.Context, domain, framework string, controls, evidenceTasks []string, extractSensitive bool, skipCache bool, cisBenchmark string) (*SecurityAnalysisResult, error) {
 	// Load or build index (fast path: use cached index)
 	persistedIndex, err := tsa.indexer.LoadOrBuildIndex(ctx, skipCache)
 	if err != nil {
 		// Fallback to live scan if index fails
 		tsa.logger.Warn("Failed to load index, falling back to live scan",
 			logger.Field{Key: "error", Value: err})
-		return tsa.performLiveScan(ctx, domain, soc2Controls, evidenceTasks, extractSensitive)
+		return tsa.performLiveScan(ctx, domain, framework, controls, evidenceTasks, extractSensitive, cisBenchmark)
 	}
 
 	// Use index query layer for fast filtering
@@ -222,8 +281,20 @@ func (tsa *SecurityAnalyzer) performSecurityAnalysis(ctx context.Context, domain
 	var indexedResources []IndexedResource
 
 	// Query based on requested controls or evidence tasks
-	if len(soc2Controls) > 0 {
-		result := query.ByControl(soc2Controls...)
+	if len(controls) > 0 {
+		var result *QueryResult
+		switch framework {
+		case frameworks.ISO27001:
+			result = query.ByAnnexAControl(controls...)
+		case frameworks.NIST80053:
+			result = query.ByNISTControl(controls...)
+		case frameworks.PCIDSS:
+			result = query.ByPCIRequirement(controls...)
+		case frameworks.HIPAA:
+			result = query.ByHIPAASafeguard(controls...)
+		default:
+			result = query.ByControl(controls...)
+		}
 		indexedResources = result.Resources
 		tsa.logger.Debug("Queried index by controls",
 			logger.Int("results", result.Count),
@@ -251,11 +322,19 @@ func (tsa *SecurityAnalyzer) performSecurityAnalysis(ctx context.Context, domain
 		logger.Int("indexed_resources", len(indexedResources)))
 
 	// Process resources using shared logic
-	return tsa.processResources(allResults, domain, soc2Controls, evidenceTasks, extractSensitive)
+	analysis, err := tsa.processResources(allResults, domain, framework, controls, evidenceTasks, extractSensitive)
+	if err != nil {
+		return nil, err
+	}
+	if cisBenchmark != "" {
+		analysis.CISResults = tsa.evaluateCISBenchmarks(cisBenchmark,
+			tsa.convertIndexedToScanResults(persistedIndex.Index.IndexedResources))
+	}
+	return analysis, nil
 }
 
 // performLiveScan performs a live scan when index is unavailable (fallback)
-func (tsa *SecurityAnalyzer) performLiveScan(ctx context.Context, domain string, soc2Controls, evidenceTasks []string, extractSensitive bool) (*SecurityAnalysisResult, error) {
+func (tsa *SecurityAnalyzer) performLiveScan(ctx context.Context, domain, framework string, controls, evidenceTasks []string, extractSensitive bool, cisBenchmark string) (*SecurityAnalysisResult, error) {
 	// Get all terraform resources via live scan
 	allResults, err := tsa.baseScanner.ScanForResources(ctx, []string{})
 	if err != nil {
@@ -265,7 +344,27 @@ func (tsa *SecurityAnalyzer) performLiveScan(ctx context.Context, domain string,
 	tsa.logger.Info("Performing live scan",
 		logger.Int("resources_scanned", len(allResults)))
 
-	return tsa.processResources(allResults, domain, soc2Controls, evidenceTasks, extractSensitive)
+	analysis, err := tsa.processResources(allResults, domain, framework, controls, evidenceTasks, extractSensitive)
+	if err != nil {
+		return nil, err
+	}
+	if cisBenchmark != "" {
+		analysis.CISResults = tsa.evaluateCISBenchmarks(cisBenchmark, allResults)
+	}
+	return analysis, nil
+}
+
+// evaluateCISBenchmarks evaluates the selected CIS benchmarks against the
+// resources
+func (tsa *SecurityAnalyzer) evaluateCISBenchmarks(selection string, resources []models.TerraformScanResult) []CISCheckResult {
+	results := []CISCheckResult{}
+	for _, benchmark := range CISBenchmarksFor(selection, resources) {
+		results = append(results, EvaluateCIS(benchmark, resources)...)
+	}
+	tsa.logger.Debug("Evaluated CIS benchmarks",
+		logger.String("selection", selection),
+		logger.Int("checks", len(results)))
+	return results
 }
 
 // convertIndexedToScanResults converts indexed resources back to scan results
@@ -288,11 +387,12 @@ func (tsa *SecurityAnalyzer) convertIndexedToScanResults(indexed []IndexedResour
 }
 
 // processResources processes a list of scan results into security analysis
-func (tsa *SecurityAnalyzer) processResources(allResults []models.TerraformScanResult, domain string, soc2Controls, evidenceTasks []string, extractSensitive bool) (*SecurityAnalysisResult, error) {
+func (tsa *SecurityAnalyzer) processResources(allResults []models.TerraformScanResult, domain, framework string, controls, evidenceTasks []string, extractSensitive bool) (*SecurityAnalysisResult, error) {
 	analysis := &SecurityAnalysisResult{
 		AnalysisTimestamp:   time.Now(),
 		SecurityDomain:      domain,
-		RequestedControls:   soc2Controls,
+		Framework:           framework,
+		RequestedControls:   controls,
 		RequestedTasks:      evidenceTasks,
 		SecurityResources:   []SecurityResource{},
 		EncryptionConfigs:   []EncryptionConfig{},
@@ -302,6 +402,10 @@ func (tsa *SecurityAnalyzer) processResources(allResults []models.TerraformScanR
 		MonitoringConfigs:   []MonitoringConfig{},
 		FilesAnalyzed:       []string{},
 		SOC2ControlMapping:  make(map[string][]SecurityResource),
+		AnnexAMapping:       make(map[string][]SecurityResource),
+		NISTMapping:         make(map[string][]SecurityResource),
+		PCIMapping:          make(map[string][]SecurityResource),
+		HIPAAMapping:        make(map[string][]SecurityResource),
 		EvidenceTaskMapping: make(map[string][]SecurityResource),
 	}
 
@@ -352,7 +456,7 @@ func (tsa *SecurityAnalyzer) processResources(allResults []models.TerraformScanR
 			}
 		}
 
-		// Map to SOC2 controls
+		// Map to SOC2 and Annex A controls
 		tsa.mapToControls(securityResource, analysis)
 	}
 
@@ -426,6 +530,10 @@ func (tsa *SecurityAnalyzer) extractSecurityResource(result models.TerraformScan
 		FilePath:          result.FilePath,
 		LineRange:         fmt.Sprintf("%d-%d", result.LineStart, result.LineEnd),
 		SecurityRelevance: result.SecurityRelevance,
+		AnnexAControls:    AnnexAControls(result.ResourceType),
+		NISTControls:      NISTControls(result.ResourceType),
+		PCIControls:       PCIControls(result.ResourceType),
+		HIPAAControls:     HIPAAControls(result.ResourceType),
 		Configuration:     make(map[string]interface{}),
 		SecurityFindings:  []SecurityFinding{},
 	}
@@ -510,11 +618,16 @@ func (tsa *SecurityAnalyzer) analyzeSecurityFindings(result models.TerraformScan
 	if strings.Contains(resourceType, "s3_bucket") {
 		if !tsa.hasConfigValue(result.Configuration, "server_side_encryption") {
 			findings = append(findings, SecurityFinding{
+				RuleID:         "s3-bucket-encryption",
 				Type:           "encryption",
 				Severity:       "high",
 				Description:    "S3 bucket does not have server-side encryption configured",
 				Recommendation: "Enable server-side encryption for S3 bucket",
 				SOC2Controls:   []string{"CC6.8"},
+				AnnexAControls: []string{"A.8.24"},
+				NISTControls:   []string{"SC-28"},
+				PCIControls:    []string{"3.5.1"},
+				HIPAAControls:  []string{"164.312(a)(2)(iv)"},
 			})
 		}
 	}
@@ -522,11 +635,16 @@ func (tsa *SecurityAnalyzer) analyzeSecurityFindings(result models.TerraformScan
 	if strings.Contains(resourceType, "rds") || strings.Contains(resourceType, "db_instance") {
 		if !tsa.hasConfigValue(result.Configuration, "storage_encrypted") {
 			findings = append(findings, SecurityFinding{
+				RuleID:         "rds-storage-encryption",
 				Type:           "encryption",
 				Severity:       "high",
 				Description:    "RDS instance does not have encryption at rest enabled",
 				Recommendation: "Enable storage encryption for RDS instance",
 				SOC2Controls:   []string{"CC6.8"},
+				AnnexAControls: []string{"A.8.24"},
+				NISTControls:   []string{"SC-28"},
+				PCIControls:    []string{"3.5.1"},
+				HIPAAControls:  []string{"164.312(a)(2)(iv)"},
 			})
 		}
 	}
@@ -535,11 +653,16 @@ func (tsa *SecurityAnalyzer) analyzeSecurityFindings(result models.TerraformScan
 	if strings.Contains(resourceType, "iam_policy") {
 		if tsa.hasWildcardPermissions(result.Configuration) {
 			findings = append(findings, SecurityFinding{
+				RuleID:         "iam-wildcard-permissions",
 				Type:           "iam",
 				Severity:       "medium",
 				Description:    "IAM policy contains wildcard permissions",
 				Recommendation: "Use principle of least privilege with specific permissions",
 				SOC2Controls:   []string{"CC6.1", "CC6.3"},
+				AnnexAControls: []string{"A.5.15", "A.8.2"},
+				NISTControls:   []string{"AC-6"},
+				PCIControls:    []string{"7.2.2"},
+				HIPAAControls:  []string{"164.308(a)(4)(ii)(B)", "164.312(a)(1)"},
 			})
 		}
 	}
@@ -548,11 +671,16 @@ func (tsa *SecurityAnalyzer) analyzeSecurityFindings(result models.TerraformScan
 	if strings.Contains(resourceType, "security_group") {
 		if tsa.hasOpenIngress(result.Configuration) {
 			findings = append(findings, SecurityFinding{
+				RuleID:         "security-group-open-ingress",
 				Type:           "network",
 				Severity:       "high",
 				Description:    "Security group allows unrestricted ingress (0.0.0.0/0)",
 				Recommendation: "Restrict ingress to specific IP ranges or security groups",
 				SOC2Controls:   []string{"CC6.6", "CC7.1"},
+				AnnexAControls: []string{"A.8.20", "A.8.22"},
+				NISTControls:   []string{"AC-4", "SC-7"},
+				PCIControls:    []string{"1.3.1", "1.4.2"},
+				HIPAAControls:  []string{"164.312(a)(1)", "164.312(e)(1)"},
 			})
 		}
 	}
@@ -856,12 +984,24 @@ func (tsa *SecurityAnalyzer) extractNetworkRules(config map[string]interface{},
 	return rules
 }
 
-// mapToControls maps security resources to SOC2 controls and evidence tasks
+// mapToControls maps security resources to SOC2, Annex A, NIST 800-53, PCI DSS and HIPAA controls and evidence tasks
 func (tsa *SecurityAnalyzer) mapToControls(resource SecurityResource, analysis *SecurityAnalysisResult) {
 	// Map to SOC2 controls based on resource type and security relevance
 	for _, control := range resource.SecurityRelevance {
 		analysis.SOC2ControlMapping[control] = append(analysis.SOC2ControlMapping[control], resource)
 	}
+	for _, control := range resource.AnnexAControls {
+		analysis.AnnexAMapping[control] = append(analysis.AnnexAMapping[control], resource)
+	}
+	for _, control := range resource.NISTControls {
+		analysis.NISTMapping[control] = append(analysis.NISTMapping[control], resource)
+	}
+	for _, control := range resource.PCIControls {
+		analysis.PCIMapping[control] = append(analysis.PCIMapping[control], resource)
+	}
+	for _, control := range resource.HIPAAControls {
+		analysis.HIPAAMapping[control] = append(analysis.HIPAAMapping[control], resource)
+	}
 
 	// Map to evidence tasks based on resource type
 	evidenceTaskMappings := map[string][]string{
@@ -891,11 +1031,15 @@ func (tsa *SecurityAnalyzer) analyzeComplianceGaps(analysis *SecurityAnalysisRes
 	// Check for missing encryption configurations
 	if len(analysis.EncryptionConfigs) == 0 {
 		gaps = append(gaps, ComplianceGap{
-			Type:          "encryption",
-			Severity:      "high",
-			Description:   "No encryption configurations found in Terraform manifests",
-			SOC2Controls:  []string{"CC6.8"},
-			EvidenceTasks: []string{"ET21", "ET23"},
+			Type:           "encryption",
+			Severity:       "high",
+			Description:    "No encryption configurations found in Terraform manifests",
+			SOC2Controls:   []string{"CC6.8"},
+			AnnexAControls: []string{"A.8.24"},
+			NISTControls:   []string{"SC-12", "SC-13", "SC-28"},
+			PCIControls:    []string{"3.5.1", "3.6.1", "4.2.1"},
+			HIPAAControls:  []string{"164.312(a)(2)(iv)", "164.312(e)(2)(ii)"},
+			EvidenceTasks:  []string{"ET21", "ET23"},
 			Recommendations: []string{
 				"Implement KMS key management for encryption at rest",
 				"Configure SSL/TLS for encryption in transit",
@@ -908,11 +1052,15 @@ func (tsa *SecurityAnalyzer) analyzeComplianceGaps(analysis *SecurityAnalysisRes
 	// Check for insufficient IAM configurations
 	if len(analysis.IAMConfigs) < 3 {
 		gaps = append(gaps, ComplianceGap{
-			Type:          "iam",
-			Severity:      "medium",
-			Description:   "Limited IAM configurations found - may indicate insufficient access controls",
-			SOC2Controls:  []string{"CC6.1", "CC6.3"},
-			EvidenceTasks: []string{"ET47"},
+			Type:           "iam",
+			Severity:       "medium",
+			Description:    "Limited IAM configurations found - may indicate insufficient access controls",
+			SOC2Controls:   []string{"CC6.1", "CC6.3"},
+			AnnexAControls: []string{"A.5.15", "A.5.18", "A.8.2"},
+			NISTControls:   []string{"AC-2", "AC-3", "AC-6"},
+			PCIControls:    []string{"7.2.1", "7.2.2", "8.2.1"},
+			HIPAAControls:  []string{"164.308(a)(4)(ii)(B)", "164.312(a)(1)", "164.312(d)"},
+			EvidenceTasks:  []string{"ET47"},
 			Recommendations: []string{
 				"Implement comprehensive IAM role-based access control",
 				"Use IAM policies with least privilege principles",
@@ -924,11 +1072,15 @@ func (tsa *SecurityAnalyzer) analyzeComplianceGaps(analysis *SecurityAnalysisRes
 	// Check for network security gaps
 	if len(analysis.NetworkConfigs) == 0 {
 		gaps = append(gaps, ComplianceGap{
-			Type:          "network",
-			Severity:      "high",
-			Description:   "No network security configurations found",
-			SOC2Controls:  []string{"CC6.6", "CC7.1"},
-			EvidenceTasks: []string{"ET71", "ET103"},
+			Type:           "network",
+			Severity:       "high",
+			Description:    "No network security configurations found",
+			SOC2Controls:   []string{"CC6.6", "CC7.1"},
+			AnnexAControls: []string{"A.8.20", "A.8.22"},
+			NISTControls:   []string{"SC-7"},
+			PCIControls:    []string{"1.3.1", "1.4.1"},
+			HIPAAControls:  []string{"164.312(e)(1)"},
+			EvidenceTasks:  []string{"ET71", "ET103"},
 			Recommendations: []string{
 				"Configure VPC with proper network segmentation",
 				"Implement security groups with restrictive rules",
@@ -941,11 +1093,15 @@ func (tsa *SecurityAnalyzer) analyzeComplianceGaps(analysis *SecurityAnalysisRes
 	// Check for missing monitoring configurations
 	if len(analysis.MonitoringConfigs) == 0 {
 		gaps = append(gaps, ComplianceGap{
-			Type:          "monitoring",
-			Severity:      "medium",
-			Description:   "No monitoring/logging configurations found",
-			SOC2Controls:  []string{"CC7.2", "CC7.4"},
-			EvidenceTasks: []string{},
+			Type:           "monitoring",
+			Severity:       "medium",
+			Description:    "No monitoring/logging configurations found",
+			SOC2Controls:   []string{"CC7.2", "CC7.4"},
+			AnnexAControls: []string{"A.8.15", "A.8.16"},
+			NISTControls:   []string{"AU-2", "AU-6", "SI-4"},
+			PCIControls:    []string{"10.2.1", "10.4.1"},
+			HIPAAControls:  []string{"164.308(a)(1)(ii)(D)", "164.312(b)"},
+			EvidenceTasks:  []string{},
 			Recommendations: []string{
 				"Enable CloudTrail for API logging",
 				"Configure CloudWatch for monitoring and alerting",
@@ -986,8 +1142,8 @@ func (tsa *SecurityAnalyzer) calculateSecurityRelevance(analysis *SecurityAnalys
 
 	relevance += float64(domainCount) * 0.05
 
-	// Boost for SOC2 control coverage
-	if len(analysis.SOC2ControlMapping) >= 5 {
+	// Boost for control coverage
+	if len(analysis.ControlMapping()) >= 5 {
 		relevance += 0.1
 	}
 
@@ -1013,6 +1169,8 @@ func (tsa *SecurityAnalyzer) generateSecurityReport(analysis *SecurityAnalysisRe
 		return tsa.generateSummaryMarkdownReport(analysis)
 	case "compliance_csv":
 		return tsa.generateComplianceCSVReport(analysis)
+	case "sarif":
+		return tsa.generateSARIFReport(analysis)
 	default:
 		return "", fmt.Errorf("unsupported output format: %s", format)
 	}
@@ -1026,12 +1184,22 @@ func (tsa *SecurityAnalyzer) generateDetailedJSONReport(analysis *SecurityAnalys
 	return string(data), nil
 }
 
+func (tsa *SecurityAnalyzer) generateSARIFReport(analysis *SecurityAnalysisResult) (string, error) {
+	baseDir, _ := os.Getwd()
+	data, err := json.MarshalIndent(BuildSARIFLog(analysis, baseDir), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal SARIF report: %w", err)
+	}
+	return string(data), nil
+}
+
 func (tsa *SecurityAnalyzer) generateSummaryMarkdownReport(analysis *SecurityAnalysisResult) (string, error) {
 	var report strings.Builder
 
 	report.WriteString("# Terraform Security Configuration Analysis\n\n")
 	report.WriteString(fmt.Sprintf("**Analysis Date:** %s\n", analysis.AnalysisTimestamp.Format(time.RFC3339)))
 	report.WriteString(fmt.Sprintf("**Security Domain:** %s\n", analysis.SecurityDomain))
+	report.WriteString(fmt.Sprintf("**Framework:** %s\n", frameworks.Label(analysis.Framework)))
 	report.WriteString(fmt.Sprintf("**Files Analyzed:** %d\n", len(analysis.FilesAnalyzed)))
 	report.WriteString(fmt.Sprintf("**Security Resources Found:** %d\n\n", len(analysis.SecurityResources)))
 
@@ -1044,10 +1212,13 @@ func (tsa *SecurityAnalyzer) generateSummaryMarkdownReport(analysis *SecurityAna
 	report.WriteString(fmt.Sprintf("- **Monitoring Configurations:** %d\n", len(analysis.MonitoringConfigs)))
 	report.WriteString(fmt.Sprintf("- **Compliance Gaps:** %d\n\n", len(analysis.ComplianceGaps)))
 
-	// SOC2 Control Mapping
-	if len(analysis.SOC2ControlMapping) > 0 {
-		report.WriteString("## SOC2 Control Mapping\n\n")
-		for control, resources := range analysis.SOC2ControlMapping {
+	// Control Mapping
+	if mapping := analysis.ControlMapping(); len(mapping) > 0 {
+		report.WriteString(fmt.Sprintf("## %s Control Mapping\n\n", frameworks.Label(analysis.Framework)))
+		for control, resources := range mapping {
+			if title := frameworks.Title(analysis.Framework, control); title != "" {
+				control = fmt.Sprintf("%s %s", control, title)
+			}
 			report.WriteString(fmt.Sprintf("### %s (%d resources)\n", control, len(resources)))
 			for _, resource := range resources {
 				report.WriteString(fmt.Sprintf("- **%s** (%s) - `%s`\n", resource.ResourceName, resource.ResourceType, resource.FilePath))
@@ -1082,17 +1253,58 @@ func (tsa *SecurityAnalyzer) generateSummaryMarkdownReport(analysis *SecurityAna
 		}
 	}
 
+	if len(analysis.CISResults) > 0 {
+		tsa.writeCISMarkdown(&report, analysis)
+	}
+
 	return report.String(), nil
 }
 
+// writeCISMarkdown writes the CIS benchmark items per benchmark, with their
+// controls in the analysis framework and the resources failing them
+func (tsa *SecurityAnalyzer) writeCISMarkdown(report *strings.Builder, analysis *SecurityAnalysisResult) {
+	summary := CISSummary(analysis.CISResults)
+	report.WriteString("## CIS Benchmark Checks\n\n")
+	report.WriteString(fmt.Sprintf("- **Passed:** %d\n", summary[CISStatusPass]))
+	report.WriteString(fmt.Sprintf("- **Failed:** %d\n", summary[CISStatusFail]))
+	report.WriteString(fmt.Sprintf("- **Not Applicable:** %d\n\n", summary[CISStatusNotApplicable]))
+
+	benchmark := ""
+	for _, result := range analysis.CISResults {
+		if result.Benchmark != benchmark {
+			benchmark = result.Benchmark
+			report.WriteString(fmt.Sprintf("### %s\n\n", CISBenchmarkLabel(benchmark)))
+			report.WriteString(fmt.Sprintf("| Item | Title | Status | Resources | %s Controls |\n", frameworks.Label(analysis.Framework)))
+			report.WriteString("|------|-------|--------|-----------|----------|\n")
+		}
+		report.WriteString(fmt.Sprintf("| %s | %s | %s | %d | %s |\n", result.ID, result.Title,
+			strings.ReplaceAll(result.Status, "_", " "), len(result.Resources),
+			strings.Join(sortedCISControls(result, analysis.Framework), ", ")))
+	}
+	report.WriteString("\n")
+
+	var failures []string
+	for _, result := range analysis.CISResults {
+		for _, failure := range result.Failures {
+			failures = append(failures, fmt.Sprintf("- **%s %s**: %s\n", strings.ToUpper(result.Benchmark), result.ID, failure))
+		}
+	}
+	if len(failures) > 0 {
+		report.WriteString("### Failing Items\n\n")
+		report.WriteString(strings.Join(failures, ""))
+		report.WriteString("\n")
+	}
+}
+
 func (tsa *SecurityAnalyzer) generateComplianceCSVReport(analysis *SecurityAnalysisResult) (string, error) {
 	var report strings.Builder
 
 	// CSV Header
-	report.WriteString("Resource Type,Resource Name,File Path,Line Range,SOC2 Controls,Evidence Tasks,Security Findings,Compliance Status\n")
+	report.WriteString(fmt.Sprintf("Resource Type,Resource Name,File Path,Line Range,%s Controls,Evidence Tasks,Security Findings,Compliance Status\n",
+		frameworks.Label(analysis.Framework)))
 
 	for _, resource := range analysis.SecurityResources {
-		controls := strings.Join(resource.SecurityRelevance, ";")
+		controls := strings.Join(resource.Controls(analysis.Framework), ";")
 
 		// Find evidence tasks for this resource
 		var evidenceTasks []string
@@ -18,6 +18,7 @@ package terraform
 import (
 	"time"
 
+	"github.com/grctool/grctool/internal/frameworks"
 	"github.com/grctool/grctool/internal/models"
 )
 
@@ -34,6 +35,7 @@ type EnhancedTerraformResult struct {
 type SecurityAnalysisResult struct {
 	AnalysisTimestamp   time.Time                     `json:"analysis_timestamp"`
 	SecurityDomain      string                        `json:"security_domain"`
+	Framework           string                        `json:"framework"`
 	RequestedControls   []string                      `json:"requested_controls"`
 	RequestedTasks      []string                      `json:"requested_tasks"`
 	SecurityResources   []SecurityResource            `json:"security_resources"`
@@ -44,8 +46,29 @@ type SecurityAnalysisResult struct {
 	MonitoringConfigs   []MonitoringConfig            `json:"monitoring_configs"`
 	FilesAnalyzed       []string                      `json:"files_analyzed"`
 	SOC2ControlMapping  map[string][]SecurityResource `json:"soc2_control_mapping"`
+	AnnexAMapping       map[string][]SecurityResource `json:"iso27001_control_mapping,omitempty"`
+	NISTMapping         map[string][]SecurityResource `json:"nist_800_53_control_mapping,omitempty"`
+	PCIMapping          map[string][]SecurityResource `json:"pci_dss_control_mapping,omitempty"`
+	HIPAAMapping        map[string][]SecurityResource `json:"hipaa_control_mapping,omitempty"`
 	EvidenceTaskMapping map[string][]SecurityResource `json:"evidence_task_mapping"`
 	ComplianceGaps      []ComplianceGap               `json:"compliance_gaps"`
+	CISResults          []CISCheckResult              `json:"cis_results,omitempty"`
+}
+
+// ControlMapping returns the resources grouped by the controls of the
+// analysis framework
+func (a *SecurityAnalysisResult) ControlMapping() map[string][]SecurityResource {
+	switch a.Framework {
+	case frameworks.ISO27001:
+		return a.AnnexAMapping
+	case frameworks.NIST80053:
+		return a.NISTMapping
+	case frameworks.PCIDSS:
+		return a.PCIMapping
+	case frameworks.HIPAA:
+		return a.HIPAAMapping
+	}
+	return a.SOC2ControlMapping
 }
 
 // SecurityResource represents a generic security resource configuration
@@ -55,17 +78,56 @@ type SecurityResource struct {
 	FilePath          string                 `json:"file_path"`
 	LineRange         string                 `json:"line_range"`
 	SecurityRelevance []string               `json:"security_relevance"`
+	AnnexAControls    []string               `json:"iso27001_controls,omitempty"`
+	NISTControls      []string               `json:"nist_800_53_controls,omitempty"`
+	PCIControls       []string               `json:"pci_dss_controls,omitempty"`
+	HIPAAControls     []string               `json:"hipaa_controls,omitempty"`
 	Configuration     map[string]interface{} `json:"configuration"`
 	SecurityFindings  []SecurityFinding      `json:"security_findings"`
 }
 
+// Controls returns the controls of the framework the resource relates to
+func (r SecurityResource) Controls(framework string) []string {
+	switch framework {
+	case frameworks.ISO27001:
+		return r.AnnexAControls
+	case frameworks.NIST80053:
+		return r.NISTControls
+	case frameworks.PCIDSS:
+		return r.PCIControls
+	case frameworks.HIPAA:
+		return r.HIPAAControls
+	}
+	return r.SecurityRelevance
+}
+
 // SecurityFinding represents a security issue or observation
 type SecurityFinding struct {
+	RuleID         string   `json:"rule_id,omitempty"` // Stable check identifier, e.g. "s3-bucket-encryption"
 	Type           string   `json:"type"`
 	Severity       string   `json:"severity"`
 	Description    string   `json:"description"`
 	Recommendation string   `json:"recommendation"`
 	SOC2Controls   []string `json:"soc2_controls"`
+	AnnexAControls []string `json:"iso27001_controls,omitempty"`
+	NISTControls   []string `json:"nist_800_53_controls,omitempty"`
+	PCIControls    []string `json:"pci_dss_controls,omitempty"`
+	HIPAAControls  []string `json:"hipaa_controls,omitempty"`
+}
+
+// Controls returns the controls of the framework the finding relates to
+func (f SecurityFinding) Controls(framework string) []string {
+	switch framework {
+	case frameworks.ISO27001:
+		return f.AnnexAControls
+	case frameworks.NIST80053:
+		return f.NISTControls
+	case frameworks.PCIDSS:
+		return f.PCIControls
+	case frameworks.HIPAA:
+		return f.HIPAAControls
+	}
+	return f.SOC2Controls
 }
 
 // EncryptionConfig represents encryption-specific configuration
@@ -143,10 +205,29 @@ type ComplianceGap struct {
 	Severity        string   `json:"severity"`
 	Description     string   `json:"description"`
 	SOC2Controls    []string `json:"soc2_controls"`
+	AnnexAControls  []string `json:"iso27001_controls,omitempty"`
+	NISTControls    []string `json:"nist_800_53_controls,omitempty"`
+	PCIControls     []string `json:"pci_dss_controls,omitempty"`
+	HIPAAControls   []string `json:"hipaa_controls,omitempty"`
 	EvidenceTasks   []string `json:"evidence_tasks"`
 	Recommendations []string `json:"recommendations"`
 }
 
+// Controls returns the controls of the framework the gap relates to
+func (g ComplianceGap) Controls(framework string) []string {
+	switch framework {
+	case frameworks.ISO27001:
+		return g.AnnexAControls
+	case frameworks.NIST80053:
+		return g.NISTControls
+	case frameworks.PCIDSS:
+		return g.PCIControls
+	case frameworks.HIPAA:
+		return g.HIPAAControls
+	}
+	return g.SOC2Controls
+}
+
 // TerraformSnippet represents a suggested Terraform configuration snippet
 type TerraformSnippet struct {
 	ResourceType     string   `json:"resource_type"`
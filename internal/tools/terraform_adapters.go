@@ -17,8 +17,10 @@ package tools
 
 import (
 	"context"
+	"path/filepath"
 
 	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/frameworks"
 	"github.com/grctool/grctool/internal/logger"
 	"github.com/grctool/grctool/internal/models"
 	"github.com/grctool/grctool/internal/tools/terraform"
@@ -27,12 +29,16 @@ import (
 // TerraformSecurityAnalyzerAdapter adapts the new terraform.SecurityAnalyzer to the Tool interface
 type TerraformSecurityAnalyzerAdapter struct {
 	securityAnalyzer *terraform.SecurityAnalyzer
+	scanPaths        []string
+	indexPath        string
 }
 
 // NewTerraformSecurityAnalyzerAdapter creates a new adapter for the terraform security analyzer
 func NewTerraformSecurityAnalyzerAdapter(cfg *config.Config, log logger.Logger) Tool {
 	return &TerraformSecurityAnalyzerAdapter{
 		securityAnalyzer: terraform.NewSecurityAnalyzer(cfg, log),
+		scanPaths:        cfg.Evidence.Tools.Terraform.ScanPaths,
+		indexPath:        filepath.Join(cfg.Storage.CacheDir, "terraform", terraform.IndexFileName),
 	}
 }
 
@@ -52,6 +58,20 @@ func (t *TerraformSecurityAnalyzerAdapter) Execute(ctx context.Context, params m
 	return t.securityAnalyzer.Execute(ctx, params)
 }
 
+// SourceFingerprint fingerprints the scan paths and the security index the
+// analyzer queries, for the result cache
+func (t *TerraformSecurityAnalyzerAdapter) SourceFingerprint(ctx context.Context, _ map[string]interface{}) (string, error) {
+	sources, err := PathsFingerprint(ctx, t.scanPaths, terraformExtensions...)
+	if err != nil {
+		return "", err
+	}
+	index, err := FileFingerprint(t.indexPath)
+	if err != nil {
+		return "", err
+	}
+	return sources + ":" + index, nil
+}
+
 // TerraformHCLParserAdapter adapts the new terraform.HCLParser to the Tool interface
 type TerraformHCLParserAdapter struct {
 	hclParser *terraform.HCLParser
@@ -104,9 +124,15 @@ func (t *TerraformHCLParserAdapter) GetClaudeToolDefinition() models.ClaudeTool
 					"description": "Perform high availability analysis",
 					"default":     true,
 				},
+				"framework": map[string]interface{}{
+					"type":        "string",
+					"description": "Framework of the resources' security relevance: soc2, iso27001 (ISO 27001:2022 Annex A), nist80053 (NIST SP 800-53 Rev. 5), pcidss (PCI DSS v4.0) or hipaa (HIPAA Security Rule safeguards)",
+					"enum":        frameworks.Supported,
+					"default":     frameworks.SOC2,
+				},
 				"control_mapping": map[string]interface{}{
 					"type":        "array",
-					"description": "Specific controls to map resources to (e.g., [\"CC6.1\", \"CC6.8\"])",
+					"description": "Specific controls to map resources to (e.g., [\"CC6.1\", \"CC6.8\"] or [\"A.8.24\"])",
 					"items": map[string]interface{}{
 						"type": "string",
 					},
@@ -122,8 +148,8 @@ func (t *TerraformHCLParserAdapter) GetClaudeToolDefinition() models.ClaudeTool
 	}
 }
 
-func (t *TerraformHCLParserAdapter) Execute(ctx context.Context, params map[string]interface{}) (string, *models.EvidenceSource, error) {
-	// Extract parameters
+// hclScanPaths returns the paths the parser scans, from the parameters
+func hclScanPaths(params map[string]interface{}) []string {
 	scanPaths := []string{"./"}
 	if sp, ok := params["scan_paths"].([]interface{}); ok {
 		scanPaths = []string{}
@@ -133,6 +159,17 @@ func (t *TerraformHCLParserAdapter) Execute(ctx context.Context, params map[stri
 			}
 		}
 	}
+	return scanPaths
+}
+
+// SourceFingerprint fingerprints the paths a run parses, for the result cache
+func (t *TerraformHCLParserAdapter) SourceFingerprint(ctx context.Context, params map[string]interface{}) (string, error) {
+	return PathsFingerprint(ctx, hclScanPaths(params), terraformExtensions...)
+}
+
+func (t *TerraformHCLParserAdapter) Execute(ctx context.Context, params map[string]interface{}) (string, *models.EvidenceSource, error) {
+	// Extract parameters
+	scanPaths := hclScanPaths(params)
 
 	includeModules := true
 	if im, ok := params["include_modules"].(bool); ok {
@@ -163,11 +200,18 @@ func (t *TerraformHCLParserAdapter) Execute(ctx context.Context, params map[stri
 		includeDiagnostics = id
 	}
 
+	framework, _ := params["framework"].(string)
+	framework, err := frameworks.Normalize(framework)
+	if err != nil {
+		return "", nil, err
+	}
+
 	// Parse using HCL parser
 	result, err := t.hclParser.Parse(ctx, scanPaths, includeModules, analyzeSecurity, analyzeHA, controlMapping, includeDiagnostics)
 	if err != nil {
 		return "", nil, err
 	}
+	t.hclParser.ApplyFramework(result, framework)
 
 	// Generate report
 	report, err := t.hclParser.GenerateReport(result, "detailed")
@@ -194,6 +238,7 @@ func (t *TerraformHCLParserAdapter) Execute(ctx context.Context, params map[stri
 			"analyze_security":    analyzeSecurity,
 			"analyze_ha":          analyzeHA,
 			"include_diagnostics": includeDiagnostics,
+			"framework":           framework,
 		},
 	}
 
@@ -0,0 +1,216 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/storage"
+	"github.com/grctool/grctool/internal/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cacheableStubTool counts its runs and reports a settable source fingerprint
+type cacheableStubTool struct {
+	stubTool
+	fingerprint    string
+	fingerprintErr error
+	runs           int
+}
+
+func (c *cacheableStubTool) SourceFingerprint(_ context.Context, _ map[string]interface{}) (string, error) {
+	return c.fingerprint, c.fingerprintErr
+}
+
+func (c *cacheableStubTool) Execute(_ context.Context, _ map[string]interface{}) (string, *models.EvidenceSource, error) {
+	c.runs++
+	return fmt.Sprintf("run %d", c.runs), &models.EvidenceSource{Type: c.name}, nil
+}
+
+func newCachedRegistry(t *testing.T, tool Tool, cfg config.ToolCacheConfig) *Registry {
+	t.Helper()
+	cache, err := storage.NewCacheManager(t.TempDir())
+	require.NoError(t, err)
+	r := NewRegistry()
+	require.NoError(t, r.Register(tool))
+	r.SetCache(NewToolResultCache(cache, cfg, testhelpers.NewStubLogger()))
+	return r
+}
+
+func TestRegistry_Execute_Cache(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ttl := config.ToolCacheConfig{TTL: time.Hour}
+
+	t.Run("identical runs reuse the result", func(t *testing.T) {
+		t.Parallel()
+		tool := &cacheableStubTool{stubTool: stubTool{name: "scan"}, fingerprint: "head-1"}
+		r := newCachedRegistry(t, tool, ttl)
+
+		first, _, err := r.Execute(ctx, "scan", map[string]interface{}{"limit": 10})
+		require.NoError(t, err)
+		second, source, err := r.Execute(ctx, "scan", map[string]interface{}{"limit": float64(10)})
+		require.NoError(t, err)
+		assert.Equal(t, first, second)
+		assert.Equal(t, "scan", source.Type)
+		assert.Equal(t, 1, tool.runs)
+	})
+
+	t.Run("different params or sources run again", func(t *testing.T) {
+		t.Parallel()
+		tool := &cacheableStubTool{stubTool: stubTool{name: "scan"}, fingerprint: "head-1"}
+		r := newCachedRegistry(t, tool, ttl)
+
+		_, _, err := r.Execute(ctx, "scan", map[string]interface{}{"limit": 10})
+		require.NoError(t, err)
+		_, _, err = r.Execute(ctx, "scan", map[string]interface{}{"limit": 20})
+		require.NoError(t, err)
+		tool.fingerprint = "head-2"
+		result, _, err := r.Execute(ctx, "scan", map[string]interface{}{"limit": 10})
+		require.NoError(t, err)
+		assert.Equal(t, "run 3", result)
+	})
+
+	t.Run("declining a cache refreshes the result", func(t *testing.T) {
+		t.Parallel()
+		tool := &cacheableStubTool{stubTool: stubTool{name: "scan"}}
+		r := newCachedRegistry(t, tool, ttl)
+
+		_, _, err := r.Execute(ctx, "scan", nil)
+		require.NoError(t, err)
+		refreshed, _, err := r.Execute(ctx, "scan", map[string]interface{}{"use_cache": false})
+		require.NoError(t, err)
+		cached, _, err := r.Execute(ctx, "scan", map[string]interface{}{"use_cache": true})
+		require.NoError(t, err)
+		assert.Equal(t, "run 2", refreshed)
+		assert.Equal(t, refreshed, cached)
+	})
+
+	t.Run("fingerprint errors skip the cache", func(t *testing.T) {
+		t.Parallel()
+		tool := &cacheableStubTool{stubTool: stubTool{name: "scan"}, fingerprintErr: errors.New("no git")}
+		r := newCachedRegistry(t, tool, ttl)
+
+		for range 2 {
+			_, _, err := r.Execute(ctx, "scan", nil)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 2, tool.runs)
+	})
+
+	t.Run("zero tool ttl is never cached", func(t *testing.T) {
+		t.Parallel()
+		tool := &cacheableStubTool{stubTool: stubTool{name: "scan"}}
+		r := newCachedRegistry(t, tool, config.ToolCacheConfig{TTL: time.Hour, Tools: map[string]time.Duration{"scan": 0}})
+
+		for range 2 {
+			_, _, err := r.Execute(ctx, "scan", nil)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 2, tool.runs)
+	})
+
+	t.Run("disabled cache runs every time", func(t *testing.T) {
+		t.Parallel()
+		tool := &cacheableStubTool{stubTool: stubTool{name: "scan"}}
+		r := newCachedRegistry(t, tool, ttl)
+		r.SetCache(nil)
+
+		for range 2 {
+			_, _, err := r.Execute(ctx, "scan", nil)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 2, tool.runs)
+	})
+}
+
+func TestPathsFingerprint(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	write := func(name, content string, modTime time.Time) {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	write("main.tf", `resource "aws_s3_bucket" "b" {}`, base)
+
+	fingerprint := func() string {
+		t.Helper()
+		fp, err := PathsFingerprint(ctx, []string{filepath.Join(dir, "**", "*.tf")}, ".tf")
+		require.NoError(t, err)
+		return fp
+	}
+	original := fingerprint()
+	assert.Equal(t, original, fingerprint())
+
+	write("audit.jsonl", "{}", base.Add(time.Hour))
+	write(".cache/result.tf", "cached", base.Add(time.Hour))
+	assert.Equal(t, original, fingerprint(), "other extensions and hidden directories are ignored")
+
+	write("modules/vpc/main.tf", `resource "aws_vpc" "v" {}`, base)
+	added := fingerprint()
+	assert.NotEqual(t, original, added)
+
+	write("modules/vpc/main.tf", `resource "aws_vpc" "v" {}`, base.Add(time.Minute))
+	assert.NotEqual(t, added, fingerprint())
+
+	missing, err := PathsFingerprint(ctx, []string{filepath.Join(dir, "missing")})
+	require.NoError(t, err)
+	assert.NotEmpty(t, missing)
+}
+
+func TestFileFingerprint(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "index.json.gz")
+
+	missing, err := FileFingerprint(path)
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+
+	require.NoError(t, os.WriteFile(path, []byte("v1"), 0644))
+	first, err := FileFingerprint(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte("v2"), 0644))
+	second, err := FileFingerprint(path)
+	require.NoError(t, err)
+	assert.NotEmpty(t, first)
+	assert.NotEqual(t, first, second)
+}
+
+func TestGlobRoot(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		path string
+		want string
+	}{
+		"directory":        {path: "deploy", want: "deploy"},
+		"recursive glob":   {path: "deploy/atmos/**/*.tf", want: "deploy/atmos"},
+		"file glob":        {path: "infra/*.tf", want: "infra"},
+		"glob at the root": {path: "*.tf", want: "."},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, globRoot(tc.path))
+		})
+	}
+}
@@ -304,3 +304,41 @@ func TestEvidenceTaskListTool_EnrichTasksWithURLs(t *testing.T) {
 		assert.NotEmpty(t, tasks[0].TugboatURL)
 	})
 }
+
+// ---------------------------------------------------------------------------
+// OutputSchema
+// ---------------------------------------------------------------------------
+
+func TestEvidenceTaskListTool_OutputSchema(t *testing.T) {
+	t.Parallel()
+	e := newTaskListToolForFilterTesting(t)
+
+	tests := map[string]struct {
+		result string
+		valid  bool
+	}{
+		"no tasks": {
+			result: `{"total_tasks": 0, "filtered_tasks": 0, "filter_applied": {}, "tasks": null, "generated_at": "2025-01-01T00:00:00Z"}`,
+			valid:  true,
+		},
+		"tasks": {
+			result: `{"total_tasks": 2, "filtered_tasks": 1, "filter_applied": {"Status": ["pending"]}, "tasks": [{"id": 1}], "generated_at": "2025-01-01T00:00:00Z"}`,
+			valid:  true,
+		},
+		"missing counts": {
+			result: `{"filter_applied": {}, "tasks": [], "generated_at": "2025-01-01T00:00:00Z"}`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateOutput(e, tc.result)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -4,10 +4,12 @@
 package tools
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 	"time"
 
+	"github.com/grctool/grctool/internal/appcontext"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -118,6 +120,16 @@ func TestGenerateCorrelationID(t *testing.T) {
 	assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`, id1)
 }
 
+func TestCorrelationID(t *testing.T) {
+	t.Parallel()
+
+	ctx := appcontext.WithCorrelationID(context.Background(), "run-123")
+	assert.Equal(t, "run-123", CorrelationID(ctx), "should use the command's ID")
+
+	id := CorrelationID(context.Background())
+	assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`, id, "should generate an ID outside a command")
+}
+
 // ---------------------------------------------------------------------------
 // Error codes are distinct constants
 // ---------------------------------------------------------------------------
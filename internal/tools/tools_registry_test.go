@@ -255,6 +255,35 @@ func TestRegistry_GetClaudeToolDefinitions(t *testing.T) {
 	assert.Equal(t, "beta", defs[1].Name)
 }
 
+// ---------------------------------------------------------------------------
+// Registry.Catalog
+// ---------------------------------------------------------------------------
+
+func TestRegistry_Catalog(t *testing.T) {
+	t.Parallel()
+
+	r := NewRegistry()
+	require.NoError(t, r.Register(&stubTool{name: "beta", description: "B"}))
+	require.NoError(t, r.Register(&stubExtendedTool{
+		stubTool: stubTool{name: "alpha", description: "A"},
+		version:  "2.0.0",
+		category: "security",
+	}))
+
+	catalog := r.Catalog()
+	assert.Equal(t, "1", catalog.SchemaVersion)
+	require.Len(t, catalog.Tools, 2)
+	assert.Equal(t, ToolSchema{
+		Name:        "alpha",
+		Description: "A",
+		Category:    "security",
+		Version:     "2.0.0",
+		InputSchema: map[string]interface{}{"type": "object"},
+	}, catalog.Tools[0])
+	assert.Equal(t, "beta", catalog.Tools[1].Name)
+	assert.Empty(t, catalog.Tools[1].Category)
+}
+
 // ---------------------------------------------------------------------------
 // GetRegistryStats (uses GlobalRegistry - snapshot based test)
 // ---------------------------------------------------------------------------
@@ -0,0 +1,276 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grctool/grctool/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// schemaTool declares an input schema and, as an OutputSchemaTool, an output
+// schema, returning a fixed result
+type schemaTool struct {
+	stubTool
+	input    map[string]interface{}
+	output   map[string]interface{}
+	result   string
+	executed bool
+}
+
+func (s *schemaTool) GetClaudeToolDefinition() models.ClaudeTool {
+	return models.ClaudeTool{Name: s.name, InputSchema: s.input}
+}
+
+func (s *schemaTool) OutputSchema() map[string]interface{} { return s.output }
+
+func (s *schemaTool) Execute(_ context.Context, _ map[string]interface{}) (string, *models.EvidenceSource, error) {
+	s.executed = true
+	return s.result, nil, nil
+}
+
+func TestValidateSchema(t *testing.T) {
+	t.Parallel()
+
+	schema := map[string]interface{}{
+		"type":     "object",
+		"required": []string{"task_ref"},
+		"properties": map[string]interface{}{
+			"task_ref": map[string]interface{}{"type": "string", "pattern": `^ET-\d+$`},
+			"format":   map[string]interface{}{"type": "string", "enum": []string{"json", "markdown"}},
+			"limit":    map[string]interface{}{"type": "integer", "minimum": 1, "maximum": 100},
+			"ratio":    map[string]interface{}{"type": "number"},
+			"verbose":  map[string]interface{}{"type": "boolean"},
+			"labels": map[string]interface{}{
+				"type":     "array",
+				"maxItems": 2,
+				"items":    map[string]interface{}{"type": "string", "minLength": 2},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		value  map[string]interface{}
+		fields []string
+		rules  []string
+	}{
+		"valid with JSON numbers": {
+			value: map[string]interface{}{"task_ref": "ET-0001", "limit": float64(10), "ratio": 0.5},
+		},
+		"valid with Go types": {
+			value: map[string]interface{}{"task_ref": "ET-1", "limit": 10, "labels": []string{"ab"}, "verbose": true},
+		},
+		"missing required": {
+			value:  map[string]interface{}{},
+			fields: []string{"$.task_ref"},
+			rules:  []string{"required"},
+		},
+		"wrong type": {
+			value:  map[string]interface{}{"task_ref": 1},
+			fields: []string{"$.task_ref"},
+			rules:  []string{"type"},
+		},
+		"pattern mismatch": {
+			value:  map[string]interface{}{"task_ref": "1"},
+			fields: []string{"$.task_ref"},
+			rules:  []string{"pattern"},
+		},
+		"enum mismatch": {
+			value:  map[string]interface{}{"task_ref": "ET-1", "format": "csv"},
+			fields: []string{"$.format"},
+			rules:  []string{"enum"},
+		},
+		"fractional integer": {
+			value:  map[string]interface{}{"task_ref": "ET-1", "limit": 1.5},
+			fields: []string{"$.limit"},
+			rules:  []string{"type"},
+		},
+		"integer out of range": {
+			value:  map[string]interface{}{"task_ref": "ET-1", "limit": 0},
+			fields: []string{"$.limit"},
+			rules:  []string{"minimum"},
+		},
+		"string for boolean": {
+			value:  map[string]interface{}{"task_ref": "ET-1", "verbose": "true"},
+			fields: []string{"$.verbose"},
+			rules:  []string{"type"},
+		},
+		"array limits and items": {
+			value:  map[string]interface{}{"task_ref": "ET-1", "labels": []interface{}{"ab", "c", "de"}},
+			fields: []string{"$.labels", "$.labels[1]"},
+			rules:  []string{"maxItems", "minLength"},
+		},
+		"unknown properties allowed": {
+			value: map[string]interface{}{"task_ref": "ET-1", "extra": "x"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			errs := ValidateSchema(schema, tc.value)
+			fields := make([]string, 0, len(errs))
+			rules := make([]string, 0, len(errs))
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+				rules = append(rules, err.Rule)
+			}
+			assert.ElementsMatch(t, tc.fields, fields)
+			assert.ElementsMatch(t, tc.rules, rules)
+		})
+	}
+}
+
+func TestValidateSchema_Keywords(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		schema map[string]interface{}
+		value  interface{}
+		valid  bool
+	}{
+		"oneOf exactly one": {
+			schema: map[string]interface{}{"oneOf": []map[string]interface{}{
+				{"required": []string{"task_ref"}},
+				{"required": []string{"task_id"}},
+			}},
+			value: map[string]interface{}{"task_ref": "ET-1"},
+			valid: true,
+		},
+		"oneOf none": {
+			schema: map[string]interface{}{"oneOf": []map[string]interface{}{
+				{"required": []string{"task_ref"}},
+				{"required": []string{"task_id"}},
+			}},
+			value: map[string]interface{}{},
+		},
+		"oneOf both": {
+			schema: map[string]interface{}{"oneOf": []map[string]interface{}{
+				{"required": []string{"task_ref"}},
+				{"required": []string{"task_id"}},
+			}},
+			value: map[string]interface{}{"task_ref": "ET-1", "task_id": 1},
+		},
+		"type list allows null": {
+			schema: map[string]interface{}{"type": []string{"array", "null"}},
+			value:  nil,
+			valid:  true,
+		},
+		"closed object rejects unknown": {
+			schema: map[string]interface{}{"type": "object", "additionalProperties": false},
+			value:  map[string]interface{}{"extra": 1},
+		},
+		"empty schema accepts anything": {
+			schema: nil,
+			value:  []int{1},
+			valid:  true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.valid, len(ValidateSchema(tc.schema, tc.value)) == 0)
+		})
+	}
+}
+
+func TestIntParam(t *testing.T) {
+	t.Parallel()
+
+	params := map[string]interface{}{"json": float64(5), "cli": 7, "text": "9"}
+	tests := map[string]struct {
+		name  string
+		value int
+		ok    bool
+	}{
+		"float64": {name: "json", value: 5, ok: true},
+		"int":     {name: "cli", value: 7, ok: true},
+		"string":  {name: "text"},
+		"missing": {name: "limit"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			value, ok := IntParam(params, tc.name)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.value, value)
+		})
+	}
+}
+
+func TestRegistry_Execute_ValidatesSchemas(t *testing.T) {
+	t.Parallel()
+
+	input := map[string]interface{}{
+		"type":       "object",
+		"required":   []string{"query"},
+		"properties": map[string]interface{}{"query": map[string]interface{}{"type": "string"}},
+	}
+	output := map[string]interface{}{
+		"type":       "object",
+		"required":   []string{"count"},
+		"properties": map[string]interface{}{"count": map[string]interface{}{"type": "integer"}},
+	}
+
+	tests := map[string]struct {
+		params   map[string]interface{}
+		result   string
+		target   string
+		executed bool
+	}{
+		"valid params and output": {
+			params:   map[string]interface{}{"query": "mfa"},
+			result:   `{"count": 3}`,
+			executed: true,
+		},
+		"invalid params are not executed": {
+			params: map[string]interface{}{"query": 3},
+			result: `{"count": 3}`,
+			target: SchemaTargetParameters,
+		},
+		"nil params checked for required": {
+			result: `{"count": 3}`,
+			target: SchemaTargetParameters,
+		},
+		"output missing field": {
+			params:   map[string]interface{}{"query": "mfa"},
+			result:   `{}`,
+			target:   SchemaTargetOutput,
+			executed: true,
+		},
+		"output not JSON": {
+			params:   map[string]interface{}{"query": "mfa"},
+			result:   "3 results",
+			target:   SchemaTargetOutput,
+			executed: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			tool := &schemaTool{stubTool: stubTool{name: "search"}, input: input, output: output, result: tc.result}
+			r := NewRegistry()
+			require.NoError(t, r.Register(tool))
+
+			_, _, err := r.Execute(context.Background(), "search", tc.params)
+			assert.Equal(t, tc.executed, tool.executed)
+			if tc.target == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var schemaErr *SchemaValidationError
+			require.ErrorAs(t, err, &schemaErr)
+			assert.Equal(t, "search", schemaErr.Tool)
+			assert.Equal(t, tc.target, schemaErr.Target)
+			assert.NotEmpty(t, schemaErr.Errors)
+			assert.Contains(t, err.Error(), "invalid search "+tc.target)
+		})
+	}
+}
@@ -475,16 +475,17 @@ func TestSemanticSearchTool_Execute_SaveAndLoad(t *testing.T) {
 
 	// Save
 	result, _, err := tool.Execute(context.Background(), map[string]interface{}{
-		"action":     "save_index",
-		"index_path": indexPath,
+		"action": "save_index",
+		"path":   indexPath,
 	})
 	require.NoError(t, err)
 	assert.Contains(t, result, "index_saved")
+	assert.FileExists(t, indexPath)
 
 	// Load
 	result, _, err = tool.Execute(context.Background(), map[string]interface{}{
-		"action":     "load_index",
-		"index_path": indexPath,
+		"action": "load_index",
+		"path":   indexPath,
 	})
 	require.NoError(t, err)
 	assert.Contains(t, result, "index_loaded")
@@ -107,7 +107,7 @@ func (t *TugboatSyncWrapperTool) GetClaudeToolDefinition() models.ClaudeTool {
 // Execute runs the tugboat sync wrapper tool
 func (t *TugboatSyncWrapperTool) Execute(ctx context.Context, params map[string]interface{}) (string, *models.EvidenceSource, error) {
 	startTime := time.Now()
-	correlationID := GenerateCorrelationID()
+	correlationID := CorrelationID(ctx)
 
 	t.logger.Info("Starting tugboat sync",
 		logger.String("correlation_id", correlationID),
@@ -0,0 +1,288 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/storage"
+)
+
+// VendorSource provides the vendor inventory. It is satisfied by *storage.Storage.
+type VendorSource interface {
+	GetAllVendors() ([]domain.Vendor, error)
+}
+
+// VendorReviewTool exports the vendor inventory with review and SOC report
+// status as evidence for third-party management tasks
+type VendorReviewTool struct {
+	logger  logger.Logger
+	vendors VendorSource
+	now     func() time.Time
+}
+
+// NewVendorReviewTool creates a vendor review tool reading the local vendor inventory
+func NewVendorReviewTool(cfg *config.Config, log logger.Logger) Tool {
+	store, err := storage.NewStorage(cfg.Storage)
+	if err != nil {
+		log.Error("Failed to initialize storage for vendor review tool",
+			logger.Field{Key: "error", Value: err})
+		return nil
+	}
+	return &VendorReviewTool{logger: log, vendors: store, now: time.Now}
+}
+
+// Name returns the tool name
+func (v *VendorReviewTool) Name() string {
+	return "vendor-review"
+}
+
+// Description returns the tool description
+func (v *VendorReviewTool) Description() string {
+	return "Export the vendor inventory with criticality, data access, SOC report expiry and review status"
+}
+
+// GetClaudeToolDefinition returns the tool definition for Claude
+func (v *VendorReviewTool) GetClaudeToolDefinition() models.ClaudeTool {
+	return models.ClaudeTool{
+		Name: v.Name(),
+		Description: "Export the vendor (third-party) inventory maintained with 'grctool vendor': each vendor's criticality, " +
+			"data access, SOC report and its expiry, and last and next review dates, with vendors whose review is overdue " +
+			"or whose SOC report is expired or missing called out. Use for vendor management and third-party risk evidence.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"output_format": map[string]interface{}{
+					"type":        "string",
+					"description": "markdown report with a summary, or csv of the register",
+					"enum":        []string{"markdown", "csv"},
+					"default":     "markdown",
+				},
+				"min_criticality": map[string]interface{}{
+					"type":        "string",
+					"description": "Only vendors at least this critical",
+					"enum":        domain.VendorCriticalities,
+				},
+				"include_offboarded": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Include offboarded vendors",
+					"default":     false,
+				},
+			},
+		},
+	}
+}
+
+// Execute exports the vendor register and review status
+func (v *VendorReviewTool) Execute(ctx context.Context, params map[string]interface{}) (string, *models.EvidenceSource, error) {
+	format, _ := params["output_format"].(string)
+	minCriticality, _ := params["min_criticality"].(string)
+	includeOffboarded, _ := params["include_offboarded"].(bool)
+	if format == "" {
+		format = "markdown"
+	}
+	if minCriticality != "" && !slices.Contains(domain.VendorCriticalities, minCriticality) {
+		return "", nil, fmt.Errorf("invalid min_criticality %q (must be one of: %v)", minCriticality, domain.VendorCriticalities)
+	}
+
+	all, err := v.vendors.GetAllVendors()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to load vendors: %w", err)
+	}
+	vendors := filterVendors(all, minCriticality, includeOffboarded)
+	now := v.now()
+
+	var report string
+	switch format {
+	case "markdown":
+		report = formatVendorReviewMarkdown(vendors, now)
+	case "csv":
+		if report, err = formatVendorReviewCSV(vendors, now); err != nil {
+			return "", nil, fmt.Errorf("failed to write vendor register: %w", err)
+		}
+	default:
+		return "", nil, fmt.Errorf("unsupported output format: %s", format)
+	}
+
+	attention := 0
+	for _, vendor := range vendors {
+		if vendor.NeedsAttention(now) {
+			attention++
+		}
+	}
+	relevance := 0.0
+	if len(vendors) > 0 {
+		relevance = 0.9
+	}
+
+	source := &models.EvidenceSource{
+		Type:        "vendor-review",
+		Resource:    fmt.Sprintf("Vendor inventory (%d vendors)", len(vendors)),
+		Content:     report,
+		Relevance:   relevance,
+		ExtractedAt: now,
+		Metadata: map[string]interface{}{
+			"vendor_count":       len(vendors),
+			"needing_attention":  attention,
+			"min_criticality":    minCriticality,
+			"include_offboarded": includeOffboarded,
+		},
+	}
+	return report, source, nil
+}
+
+// filterVendors keeps vendors at least minCriticality, most critical first
+func filterVendors(vendors []domain.Vendor, minCriticality string, includeOffboarded bool) []domain.Vendor {
+	rank := func(criticality string) int { return slices.Index(domain.VendorCriticalities, criticality) }
+
+	filtered := []domain.Vendor{}
+	for _, vendor := range vendors {
+		if vendor.Status == domain.VendorStatusOffboarded && !includeOffboarded {
+			continue
+		}
+		if minCriticality != "" && rank(vendor.Criticality) < rank(minCriticality) {
+			continue
+		}
+		filtered = append(filtered, vendor)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		if rank(filtered[i].Criticality) != rank(filtered[j].Criticality) {
+			return rank(filtered[i].Criticality) > rank(filtered[j].Criticality)
+		}
+		return strings.ToLower(filtered[i].Name) < strings.ToLower(filtered[j].Name)
+	})
+	return filtered
+}
+
+func formatVendorReviewMarkdown(vendors []domain.Vendor, now time.Time) string {
+	var md strings.Builder
+
+	md.WriteString("# Vendor Review\n\n")
+	md.WriteString(fmt.Sprintf("Generated: %s\n\n", now.Format("2006-01-02")))
+
+	if len(vendors) == 0 {
+		md.WriteString("No vendors in the inventory. Add them with 'grctool vendor add'.\n")
+		return md.String()
+	}
+
+	reviews := map[string]int{}
+	reports := map[string]int{}
+	withData := 0
+	for _, vendor := range vendors {
+		reviews[vendor.ReviewStatus(now)]++
+		if vendor.DataAccess != domain.VendorDataNone {
+			withData++
+			reports[vendor.SOCReportStatus(now)]++
+		}
+	}
+
+	md.WriteString("## Summary\n\n")
+	md.WriteString(fmt.Sprintf("- **Vendors:** %d\n", len(vendors)))
+	md.WriteString(fmt.Sprintf("- **Reviews:** %d current, %d due within %d days, %d overdue, %d never reviewed\n",
+		reviews[domain.VendorReviewCurrent], reviews[domain.VendorReviewDueSoon], domain.VendorDueSoonDays,
+		reviews[domain.VendorReviewOverdue], reviews[domain.VendorReviewNever]))
+	md.WriteString(fmt.Sprintf("- **SOC reports** (%d vendors with data access): %d valid, %d expiring, %d expired, %d missing\n\n",
+		withData, reports[domain.SOCReportValid], reports[domain.SOCReportExpiring],
+		reports[domain.SOCReportExpired], reports[domain.SOCReportMissing]))
+
+	md.WriteString("## Vendor Register\n\n")
+	md.WriteString("| ID | Vendor | Service | Owner | Criticality | Data Access | SOC Report | Report Expiry | Last Review | Next Review | Review Status |\n")
+	md.WriteString("|----|--------|---------|-------|-------------|-------------|------------|---------------|-------------|-------------|---------------|\n")
+	for _, vendor := range vendors {
+		md.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %s | %s | %s | %s | %s |\n",
+			vendor.ID, vendor.Name, vendor.Service, vendor.Owner, vendor.Criticality, vendor.DataAccess,
+			orDash(vendor.SOCReport), formatOptionalDate(vendor.SOCReportExpiry),
+			formatOptionalDate(vendor.LastReviewed), formatOptionalDate(vendor.NextReview),
+			strings.ReplaceAll(vendor.ReviewStatus(now), "_", " ")))
+	}
+
+	var attention []string
+	for _, vendor := range vendors {
+		if !vendor.NeedsAttention(now) {
+			continue
+		}
+		var reasons []string
+		if review := vendor.ReviewStatus(now); review != domain.VendorReviewCurrent {
+			reasons = append(reasons, "review "+strings.ReplaceAll(review, "_", " "))
+		}
+		if vendor.DataAccess != domain.VendorDataNone {
+			if report := vendor.SOCReportStatus(now); report != domain.SOCReportValid {
+				reasons = append(reasons, "SOC report "+report)
+			}
+		}
+		attention = append(attention, fmt.Sprintf("- **%s** (%s, %s): %s\n",
+			vendor.Name, vendor.ID, vendor.Criticality, strings.Join(reasons, ", ")))
+	}
+	if len(attention) > 0 {
+		md.WriteString("\n## Needing Attention\n\n")
+		md.WriteString(strings.Join(attention, ""))
+	}
+
+	return md.String()
+}
+
+func formatVendorReviewCSV(vendors []domain.Vendor, now time.Time) (string, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	header := []string{
+		"id", "name", "service", "owner", "status", "criticality", "data_access", "soc_report",
+		"soc_report_expiry", "soc_report_status", "last_reviewed", "next_review", "review_status",
+	}
+	if err := writer.Write(header); err != nil {
+		return "", err
+	}
+	for _, vendor := range vendors {
+		record := []string{
+			vendor.ID, vendor.Name, vendor.Service, vendor.Owner, vendor.Status, vendor.Criticality, vendor.DataAccess,
+			vendor.SOCReport, formatDate(vendor.SOCReportExpiry), vendor.SOCReportStatus(now),
+			formatDate(vendor.LastReviewed), formatDate(vendor.NextReview), vendor.ReviewStatus(now),
+		}
+		if err := writer.Write(record); err != nil {
+			return "", err
+		}
+	}
+	writer.Flush()
+	return buf.String(), writer.Error()
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+func formatOptionalDate(t *time.Time) string {
+	return orDash(formatDate(t))
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,113 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubVendorSource []domain.Vendor
+
+func (s stubVendorSource) GetAllVendors() ([]domain.Vendor, error) { return s, nil }
+
+func TestVendorReviewTool_Execute(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	date := func(days int) *time.Time {
+		d := now.AddDate(0, 0, days)
+		return &d
+	}
+	vendors := stubVendorSource{
+		{ID: "VEN-0001", Name: "Acme Cloud", Service: "Hosting", Criticality: domain.VendorCriticalityCritical,
+			Status: domain.VendorStatusActive, DataAccess: domain.VendorDataRestricted, SOCReport: "SOC 2 Type II",
+			SOCReportExpiry: date(200), LastReviewed: date(-100), NextReview: date(265)},
+		{ID: "VEN-0002", Name: "Globex", Criticality: domain.VendorCriticalityHigh,
+			Status: domain.VendorStatusActive, DataAccess: domain.VendorDataConfidential, NextReview: date(-5)},
+		{ID: "VEN-0003", Name: "Initech", Criticality: domain.VendorCriticalityLow,
+			Status: domain.VendorStatusActive, DataAccess: domain.VendorDataNone},
+		{ID: "VEN-0004", Name: "Old Co", Criticality: domain.VendorCriticalityCritical,
+			Status: domain.VendorStatusOffboarded, DataAccess: domain.VendorDataNone},
+	}
+	log, err := logger.NewTestLogger()
+	require.NoError(t, err)
+	tool := &VendorReviewTool{logger: log, vendors: vendors, now: func() time.Time { return now }}
+
+	tests := map[string]struct {
+		params   map[string]interface{}
+		count    int
+		contains []string
+		excludes []string
+	}{
+		"markdown": {
+			params: map[string]interface{}{},
+			count:  3,
+			contains: []string{
+				"- **Reviews:** 1 current, 0 due within 30 days, 1 overdue, 1 never reviewed",
+				"- **SOC reports** (2 vendors with data access): 1 valid, 0 expiring, 0 expired, 1 missing",
+				"| VEN-0001 | Acme Cloud | Hosting |",
+				"- **Globex** (VEN-0002, high): review overdue, SOC report missing",
+				"- **Initech** (VEN-0003, low): review never reviewed",
+			},
+			excludes: []string{"Old Co", "**Acme Cloud**"},
+		},
+		"min criticality and offboarded": {
+			params:   map[string]interface{}{"min_criticality": "high", "include_offboarded": true},
+			count:    3,
+			contains: []string{"Old Co", "Globex"},
+			excludes: []string{"Initech"},
+		},
+		"csv": {
+			params: map[string]interface{}{"output_format": "csv"},
+			count:  3,
+			contains: []string{
+				"id,name,service,owner,status,criticality,data_access,soc_report,",
+				"VEN-0002,Globex,,,active,high,confidential,,,missing,,2025-10-27,overdue",
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			report, source, err := tool.Execute(context.Background(), tc.params)
+			require.NoError(t, err)
+			require.NotNil(t, source)
+			assert.Equal(t, "vendor-review", source.Type)
+			assert.Equal(t, tc.count, source.Metadata["vendor_count"])
+			for _, want := range tc.contains {
+				assert.Contains(t, report, want)
+			}
+			for _, unwanted := range tc.excludes {
+				assert.NotContains(t, report, unwanted)
+			}
+		})
+	}
+
+	_, _, err = tool.Execute(context.Background(), map[string]interface{}{"min_criticality": "extreme"})
+	assert.ErrorContains(t, err, `invalid min_criticality "extreme"`)
+}
+
+func TestFilterVendors_Order(t *testing.T) {
+	t.Parallel()
+
+	vendors := []domain.Vendor{
+		{Name: "b", Criticality: domain.VendorCriticalityLow},
+		{Name: "C", Criticality: domain.VendorCriticalityHigh},
+		{Name: "a", Criticality: domain.VendorCriticalityLow},
+	}
+	var names []string
+	for _, vendor := range filterVendors(vendors, "", false) {
+		names = append(names, vendor.Name)
+	}
+	assert.Equal(t, "C,a,b", strings.Join(names, ","))
+}
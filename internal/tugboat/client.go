@@ -30,6 +30,7 @@ import (
 
 	"github.com/grctool/grctool/internal/config"
 	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/telemetry"
 	"github.com/grctool/grctool/internal/transport"
 	"github.com/grctool/grctool/internal/vcr"
 )
@@ -63,6 +64,16 @@ type APIError struct {
 	Details string `json:"details,omitempty"`
 }
 
+// HTTPError is returned for responses with an error status
+type HTTPError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *HTTPError) Error() string {
+	return e.Message
+}
+
 // APIMeta represents API response metadata
 type APIMeta struct {
 	Page       int `json:"page,omitempty"`
@@ -93,6 +104,9 @@ func NewClient(cfg *config.TugboatConfig, vcrConfig *vcr.Config) *Client {
 		httpTransport = vcr.New(vcrConfig)
 	}
 
+	// Trace each request, around VCR so replayed calls are traced too
+	httpTransport = telemetry.Transport(httpTransport)
+
 	// Get API key from environment variable for Custom Evidence Integration
 	apiKey := os.Getenv("TUGBOAT_API_KEY")
 	if apiKey == "" {
@@ -256,14 +270,15 @@ func (c *Client) handleResponse(resp *http.Response, result interface{}) error {
 
 	// Check for HTTP errors
 	if resp.StatusCode >= 400 {
-		var apiResp APIResponse
-		if err := json.Unmarshal(body, &apiResp); err != nil {
-			return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		httpErr := &HTTPError{
+			StatusCode: resp.StatusCode,
+			Message:    fmt.Sprintf("HTTP %d: %s", resp.StatusCode, string(body)),
 		}
-		if apiResp.Error != nil {
-			return fmt.Errorf("API error %s: %s", apiResp.Error.Code, apiResp.Error.Message)
+		var apiResp APIResponse
+		if err := json.Unmarshal(body, &apiResp); err == nil && apiResp.Error != nil {
+			httpErr.Message = fmt.Sprintf("API error %s: %s", apiResp.Error.Code, apiResp.Error.Message)
 		}
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return httpErr
 	}
 
 	// Parse successful response
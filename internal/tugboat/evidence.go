@@ -157,6 +157,26 @@ func (c *Client) GetEvidenceTaskDetails(ctx context.Context, taskID string) (*mo
 	return &taskDetails, nil
 }
 
+// EvidenceTaskUpdate holds the evidence task fields to change; unset fields
+// are left unchanged
+type EvidenceTaskUpdate struct {
+	Completed *bool   `json:"completed,omitempty"`
+	Assignees []int   `json:"assignees,omitempty"` // Organization member IDs; replaces the current assignees
+	Notes     *string `json:"notes,omitempty"`
+}
+
+// UpdateEvidenceTask applies a partial update to an evidence task
+func (c *Client) UpdateEvidenceTask(ctx context.Context, taskID string, update *EvidenceTaskUpdate) (*models.EvidenceTask, error) {
+	endpoint := fmt.Sprintf("/api/org_evidence/%s/", taskID)
+
+	var task models.EvidenceTask
+	if err := c.patch(ctx, endpoint, update, &task); err != nil {
+		return nil, fmt.Errorf("failed to update evidence task %s: %w", taskID, err)
+	}
+
+	return &task, nil
+}
+
 // GetEvidenceTasksByControl retrieves evidence tasks filtered by control ID
 func (c *Client) GetEvidenceTasksByControl(ctx context.Context, controlID string, org string) ([]models.EvidenceTask, error) {
 	endpoint := fmt.Sprintf("/api/org_evidence/?control_id=%s&org=%s&embeds=org_controls", controlID, org)
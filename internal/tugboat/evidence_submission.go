@@ -27,10 +27,23 @@ import (
 	"time"
 )
 
+// MaxFileSize is the largest file the Custom Evidence Integration API
+// accepts, as per the Tugboat API docs
+const MaxFileSize = 20 * 1024 * 1024 // 20MB
+
+// SupportedFileTypes are the file extensions uploaded to collectors as they
+// are; files of other types are converted or archived before upload
+var SupportedFileTypes = []string{
+	".csv", ".doc", ".docx", ".gif", ".jpeg", ".jpg", ".json", ".md", ".ods",
+	".odt", ".pdf", ".png", ".txt", ".xls", ".xlsx", ".zip",
+}
+
 // SubmitEvidenceRequest represents a request to submit evidence via Custom Evidence Integration API
 type SubmitEvidenceRequest struct {
 	CollectorURL  string    // Full collector URL (e.g., https://openapi.tugboatlogic.com/api/v0/evidence/collector/805/)
 	FilePath      string    // Path to the evidence file to upload
+	Content       []byte    // Uploaded instead of FilePath's content when set, e.g. decrypted evidence
+	Filename      string    // Uploaded as, instead of FilePath's name when set, e.g. a part of a split file
 	CollectedDate time.Time // Date the evidence was collected
 	ContentType   string    // MIME type of the file (e.g., "text/csv", "application/json")
 }
@@ -54,23 +67,31 @@ func (c *Client) SubmitEvidence(ctx context.Context, req *SubmitEvidenceRequest)
 		return nil, fmt.Errorf("file path is required")
 	}
 
-	// Open the evidence file
-	file, err := os.Open(req.FilePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open file %s: %w", req.FilePath, err)
-	}
-	defer file.Close()
+	// Open the evidence file, unless its content was given
+	var file io.Reader
+	var fileSize int64
+	if req.Content != nil {
+		file = bytes.NewReader(req.Content)
+		fileSize = int64(len(req.Content))
+	} else {
+		f, err := os.Open(req.FilePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open file %s: %w", req.FilePath, err)
+		}
+		defer f.Close()
 
-	// Get file info for validation
-	fileInfo, err := file.Stat()
-	if err != nil {
-		return nil, fmt.Errorf("failed to stat file: %w", err)
+		// Get file info for validation
+		fileInfo, err := f.Stat()
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat file: %w", err)
+		}
+		file = f
+		fileSize = fileInfo.Size()
 	}
 
-	// Validate file size (max 20MB as per Tugboat API docs)
-	const maxFileSize = 20 * 1024 * 1024 // 20MB
-	if fileInfo.Size() > maxFileSize {
-		return nil, fmt.Errorf("file size %d bytes exceeds maximum allowed size of %d bytes (20MB)", fileInfo.Size(), maxFileSize)
+	// Validate file size
+	if fileSize > MaxFileSize {
+		return nil, fmt.Errorf("file size %d bytes exceeds maximum allowed size of %d bytes (20MB)", fileSize, MaxFileSize)
 	}
 
 	// Create multipart form-data body
@@ -85,6 +106,9 @@ func (c *Client) SubmitEvidence(ctx context.Context, req *SubmitEvidenceRequest)
 
 	// Add file field
 	filename := filepath.Base(req.FilePath)
+	if req.Filename != "" {
+		filename = req.Filename
+	}
 	part, err := writer.CreateFormFile("file", filename)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create form file: %w", err)
@@ -424,3 +424,37 @@ func basicAuth(username, password string) string {
 	// In real code, http.Request.SetBasicAuth() does this encoding automatically
 	return "dGVzdHVzZXI6dGVzdHBhc3M="
 }
+
+func TestSubmitEvidence_Filename(t *testing.T) {
+	var receivedBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := &config.TugboatConfig{
+		BaseURL:  server.URL,
+		Username: "testuser",
+		Password: "testpass",
+		Timeout:  5 * time.Second,
+	}
+	t.Setenv("TUGBOAT_API_KEY", "test-api-key-12345")
+	client := NewClient(cfg, nil)
+
+	// A part of a split file is uploaded under its own name
+	req := &SubmitEvidenceRequest{
+		CollectorURL:  server.URL + "/api/v0/evidence/collector/123/",
+		FilePath:      "/data/evidence/ET-0001/2025-Q4/access.csv",
+		Content:       []byte("alice,admin\n"),
+		Filename:      "access.part2of3.csv",
+		CollectedDate: time.Date(2025, 10, 23, 0, 0, 0, 0, time.UTC),
+		ContentType:   "text/csv",
+	}
+	if _, err := client.SubmitEvidence(context.Background(), req); err != nil {
+		t.Fatalf("SubmitEvidence() unexpected error = %v", err)
+	}
+	if !strings.Contains(string(receivedBody), `filename="access.part2of3.csv"`) {
+		t.Errorf("Request body should name the file access.part2of3.csv, got %q", receivedBody)
+	}
+}
@@ -1080,6 +1080,30 @@ func TestGetEvidenceTaskDetails(t *testing.T) {
 	assert.Equal(t, "Use GitHub API", details.MasterContent.Guidance)
 }
 
+func TestUpdateEvidenceTask(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/api/org_evidence/327992/", r.URL.Path)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{"completed": true, "assignees": []interface{}{float64(42)}}, body,
+			"unset fields are not sent")
+
+		json.NewEncoder(w).Encode(models.EvidenceTask{ID: 327992, Completed: true, UpdatedAt: "2025-11-10T12:00:00Z"})
+	}))
+	defer server.Close()
+
+	completed := true
+	c := newTestClient(t, server.URL)
+	task, err := c.UpdateEvidenceTask(context.Background(), "327992", &EvidenceTaskUpdate{Completed: &completed, Assignees: []int{42}})
+	require.NoError(t, err)
+	assert.True(t, task.Completed)
+	assert.Equal(t, "2025-11-10T12:00:00Z", task.UpdatedAt)
+}
+
 func TestGetEvidenceTasksByControl(t *testing.T) {
 	t.Parallel()
 
@@ -1201,6 +1225,59 @@ func TestGetAllEvidenceAttachments_Pagination(t *testing.T) {
 	assert.Len(t, all, 101)
 }
 
+func TestGetEvidenceComments(t *testing.T) {
+	t.Parallel()
+
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/org_evidence_comment/", r.URL.Path)
+		assert.Equal(t, "327992", r.URL.Query().Get("org_evidence"))
+		pages = append(pages, r.URL.Query().Get("page"))
+
+		if len(pages) == 1 {
+			items := make([]models.EvidenceComment, 100)
+			for i := range items {
+				items[i] = models.EvidenceComment{ID: i + 1}
+			}
+			json.NewEncoder(w).Encode(models.EvidenceCommentListResponse{Results: items})
+			return
+		}
+		json.NewEncoder(w).Encode(models.EvidenceCommentListResponse{
+			Results: []models.EvidenceComment{{ID: 101, Body: "Screenshot is missing the date", ReviewStatus: "rejected"}},
+		})
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server.URL)
+	comments, err := c.GetEvidenceComments(context.Background(), 327992)
+	require.NoError(t, err)
+	assert.Len(t, comments, 101)
+	assert.Equal(t, []string{"1", "2"}, pages)
+	assert.Equal(t, "rejected", comments[100].ReviewStatus)
+}
+
+func TestCreateEvidenceComment(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/org_evidence_comment/", r.URL.Path)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, float64(327992), body["org_evidence"])
+		assert.Equal(t, "Deferred until 2026-01-15: waiting on vendor report", body["body"])
+
+		json.NewEncoder(w).Encode(models.EvidenceComment{ID: 77, OrgEvidenceID: 327992, Body: body["body"].(string)})
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server.URL)
+	comment, err := c.CreateEvidenceComment(context.Background(), 327992, "Deferred until 2026-01-15: waiting on vendor report")
+	require.NoError(t, err)
+	assert.Equal(t, 77, comment.ID)
+}
+
 func TestGetEvidenceAttachmentsByTask(t *testing.T) {
 	t.Parallel()
 
@@ -1248,6 +1325,72 @@ func TestGetAttachmentDownloadURL(t *testing.T) {
 	assert.Equal(t, "evidence.csv", resp.OriginalFilename)
 }
 
+func TestGetAttachmentSize(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		status       int
+		contentRange string
+		body         string
+		want         int64
+		err          string
+	}{
+		"partial content": {status: http.StatusPartialContent, contentRange: "bytes 0-0/1234", body: "u", want: 1234},
+		"empty file":      {status: http.StatusRequestedRangeNotSatisfiable, contentRange: "bytes */0"},
+		"no ranges":       {status: http.StatusOK, body: "user,role\n", want: 10},
+		"bad range":       {status: http.StatusPartialContent, contentRange: "bytes 0-0/*", err: `unexpected Content-Range "bytes 0-0/*"`},
+		"expired":         {status: http.StatusForbidden, err: "size request failed with status 403"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			s3 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "bytes=0-0", r.Header.Get("Range"))
+				if tc.contentRange != "" {
+					w.Header().Set("Content-Range", tc.contentRange)
+				}
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer s3.Close()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/org_evidence_attachment/9001/download/", r.URL.Path)
+				json.NewEncoder(w).Encode(models.AttachmentDownloadResponse{URL: s3.URL + "/file.csv?token=abc"})
+			}))
+			defer server.Close()
+
+			c := newTestClient(t, server.URL)
+			size, err := c.GetAttachmentSize(context.Background(), 9001)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, size)
+		})
+	}
+}
+
+func TestDeleteEvidenceAttachment(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		if r.URL.Path == "/api/org_evidence_attachment/9002/" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		assert.Equal(t, "/api/org_evidence_attachment/9001/", r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server.URL)
+	require.NoError(t, c.DeleteEvidenceAttachment(context.Background(), 9001))
+	assert.ErrorContains(t, c.DeleteEvidenceAttachment(context.Background(), 9002), "failed to delete attachment 9002")
+}
+
 // ---------------------------------------------------------------------------
 // SubmitEvidence tests (using httptest)
 // ---------------------------------------------------------------------------
@@ -114,3 +114,31 @@ type AttachmentDownloadResponse struct {
 	URL              string `json:"url"`               // Signed S3 URL (temporary, with expiration)
 	OriginalFilename string `json:"original_filename"` // Original filename of the attachment
 }
+
+// EvidenceComment represents an auditor comment on an evidence task from Tugboat Logic API
+// This maps to the org_evidence_comment entity in Tugboat's API
+type EvidenceComment struct {
+	ID                      int                 `json:"id"`
+	EntityType              string              `json:"__entity_type__"`
+	Created                 string              `json:"created"` // ISO 8601 timestamp
+	Updated                 string              `json:"updated"` // ISO 8601 timestamp
+	Body                    string              `json:"body"`
+	ReviewStatus            string              `json:"review_status"`              // "accepted", "rejected", or empty for a plain comment
+	Resolved                bool                `json:"resolved"`                   // Whether the comment was marked resolved
+	OrgEvidenceID           int                 `json:"org_evidence_id"`            // Evidence task ID this belongs to
+	OrgEvidenceAttachmentID *int                `json:"org_evidence_attachment_id"` // Reviewed attachment (null for task-level comments)
+	OwnerID                 int                 `json:"owner_id"`
+	Owner                   *OrganizationMember `json:"owner"` // Comment author (embedded)
+}
+
+// EvidenceCommentListResponse represents the paginated response for evidence comments
+type EvidenceCommentListResponse struct {
+	MaxPageSize int               `json:"max_page_size"`
+	PageSize    int               `json:"page_size"`
+	NumPages    int               `json:"num_pages"`
+	PageNumber  int               `json:"page_number"`
+	Count       int               `json:"count"`
+	Next        *int              `json:"next"`     // Next page number (null if last page)
+	Previous    *int              `json:"previous"` // Previous page number (null if first page)
+	Results     []EvidenceComment `json:"results"`
+}
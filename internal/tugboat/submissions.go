@@ -153,6 +153,46 @@ func (c *Client) GetAllEvidenceAttachments(ctx context.Context, taskID int, obse
 	return allAttachments, nil
 }
 
+// GetEvidenceComments retrieves all auditor comments and review decisions on an
+// evidence task, oldest first, handling pagination automatically
+func (c *Client) GetEvidenceComments(ctx context.Context, taskID int) ([]models.EvidenceComment, error) {
+	var allComments []models.EvidenceComment
+	pageSize := 100
+
+	for page := 1; ; page++ {
+		endpoint := fmt.Sprintf("/api/org_evidence_comment/?org_evidence=%d&ordering=created&embeds=org_members&page=%d&page_size=%d",
+			taskID, page, pageSize)
+
+		var response models.EvidenceCommentListResponse
+		if err := c.get(ctx, endpoint, &response); err != nil {
+			return nil, fmt.Errorf("failed to get evidence comments page %d: %w", page, err)
+		}
+
+		allComments = append(allComments, response.Results...)
+
+		if len(response.Results) < pageSize {
+			break
+		}
+	}
+
+	return allComments, nil
+}
+
+// CreateEvidenceComment posts a task-level note on an evidence task, such as
+// the justification recorded by 'evidence defer'
+func (c *Client) CreateEvidenceComment(ctx context.Context, taskID int, body string) (*models.EvidenceComment, error) {
+	request := map[string]interface{}{
+		"org_evidence": taskID,
+		"body":         body,
+	}
+
+	var comment models.EvidenceComment
+	if err := c.post(ctx, "/api/org_evidence_comment/", request, &comment); err != nil {
+		return nil, fmt.Errorf("failed to create evidence comment: %w", err)
+	}
+	return &comment, nil
+}
+
 // GetEvidenceAttachmentsByTask retrieves all evidence attachments for a specific evidence task
 // This is a convenience method that wraps GetAllEvidenceAttachments with a default observation period
 func (c *Client) GetEvidenceAttachmentsByTask(ctx context.Context, taskID int) ([]models.EvidenceAttachment, error) {
@@ -238,6 +278,54 @@ func (c *Client) DownloadAttachment(ctx context.Context, attachmentID int, destP
 	return nil
 }
 
+// GetAttachmentSize returns the size in bytes of an attachment's file. The
+// API does not report sizes, so the first byte is requested from the signed
+// S3 URL and the size read from the Content-Range of the response.
+func (c *Client) GetAttachmentSize(ctx context.Context, attachmentID int) (int64, error) {
+	downloadInfo, err := c.GetAttachmentDownloadURL(ctx, attachmentID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get download URL: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", downloadInfo.URL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create size request: %w", err)
+	}
+	req.Header.Set("Range", "bytes=0-0")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to request attachment: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent, http.StatusRequestedRangeNotSatisfiable:
+		// "bytes 0-0/1234", or "bytes */0" for an empty file
+		contentRange := resp.Header.Get("Content-Range")
+		_, total, _ := strings.Cut(contentRange, "/")
+		size, err := strconv.ParseInt(total, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("unexpected Content-Range %q", contentRange)
+		}
+		return size, nil
+	case http.StatusOK:
+		// Ranges not supported, so the whole file was sent
+		return io.Copy(io.Discard, resp.Body)
+	}
+	return 0, fmt.Errorf("size request failed with status %d", resp.StatusCode)
+}
+
+// DeleteEvidenceAttachment deletes an attachment from its evidence task, such
+// as a file uploaded in a submission that was withdrawn
+func (c *Client) DeleteEvidenceAttachment(ctx context.Context, attachmentID int) error {
+	endpoint := fmt.Sprintf("/api/org_evidence_attachment/%d/", attachmentID)
+	if err := c.delete(ctx, endpoint); err != nil {
+		return fmt.Errorf("failed to delete attachment %d: %w", attachmentID, err)
+	}
+	return nil
+}
+
 // URLDownloadResult contains the result of downloading a URL
 type URLDownloadResult struct {
 	Filename    string // Suggested filename (from Content-Disposition or URL)
@@ -0,0 +1,487 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tui implements the interactive terminal UI behind 'grctool ui'.
+//
+// Model is a bubbletea model: Update applies key presses, window sizes and
+// the results of reloads and commands, and View renders the model to a
+// string. Bubbletea owns the terminal, so the model is tested without one.
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/models"
+)
+
+// Task is one row of the task list
+type Task struct {
+	Ref   string
+	Task  domain.EvidenceTask
+	State *models.EvidenceTaskState // Nil until the evidence directory has been scanned
+}
+
+// localState returns the task's local evidence state
+func (t Task) localState() models.LocalEvidenceState {
+	if t.State == nil || t.State.LocalState == "" {
+		return models.StateNoEvidence
+	}
+	return t.State.LocalState
+}
+
+// ActionKind identifies what the UI should do after a key press
+type ActionKind int
+
+const (
+	ActionNone ActionKind = iota
+	ActionQuit
+	ActionReload
+	ActionRun // Run a grctool command, see Action.Args
+)
+
+// Action is the result of a key press
+type Action struct {
+	Kind ActionKind
+	Args []string // grctool arguments for ActionRun
+}
+
+// stateFilters is the cycle of local states the 'f' key steps through; the
+// empty state shows every task
+var stateFilters = []models.LocalEvidenceState{
+	"",
+	models.StateNoEvidence,
+	models.StateGenerated,
+	models.StateValidated,
+	models.StateSubmitted,
+	models.StateAccepted,
+	models.StateRejected,
+}
+
+// dueSoonDays matches the 'evidence list --due-soon' horizon
+const dueSoonDays = 7
+
+// Model is the UI state
+type Model struct {
+	tasks   []Task
+	visible []int // Indexes into tasks that pass the filters
+	cursor  int   // Index into visible
+	offset  int   // First visible row shown in the list
+
+	width  int
+	height int
+	now    time.Time
+
+	search      string
+	searching   bool
+	stateFilter int
+	overdue     bool
+	dueSoon     bool
+
+	defaultWindow string
+	windows       map[string]string // Selected window by task ref
+
+	showHelp bool
+	message  string
+
+	// Set by Run; without them reloads and commands are not available
+	ctx  context.Context
+	load Loader
+	run  Runner
+}
+
+// tasksLoadedMsg carries the result of reloading tasks
+type tasksLoadedMsg struct {
+	tasks   []Task
+	now     time.Time
+	message string
+	err     error
+}
+
+// commandFinishedMsg reports that a grctool command run from the UI exited
+type commandFinishedMsg struct {
+	args []string
+	err  error
+}
+
+// NewModel creates a model over tasks. Actions run against defaultWindow
+// until another window is selected for a task.
+func NewModel(tasks []Task, defaultWindow string, now time.Time) *Model {
+	m := &Model{
+		width:         80,
+		height:        24,
+		now:           now,
+		defaultWindow: defaultWindow,
+		windows:       make(map[string]string),
+	}
+	m.SetTasks(tasks)
+	return m
+}
+
+// SetTasks replaces the task list, keeping the selection on the same task
+// when it is still listed
+func (m *Model) SetTasks(tasks []Task) {
+	selected := ""
+	if task, ok := m.Selected(); ok {
+		selected = task.Ref
+	}
+
+	m.tasks = tasks
+	m.applyFilters()
+
+	for i, index := range m.visible {
+		if m.tasks[index].Ref == selected {
+			m.cursor = i
+			m.scrollToCursor()
+			break
+		}
+	}
+}
+
+// SetSize records the terminal size
+func (m *Model) SetSize(width, height int) {
+	if width > 0 {
+		m.width = width
+	}
+	if height > 0 {
+		m.height = height
+	}
+	m.scrollToCursor()
+}
+
+// SetNow sets the time used for overdue and due-soon checks
+func (m *Model) SetNow(now time.Time) {
+	m.now = now
+	m.applyFilters()
+}
+
+// SetMessage shows a message in the footer until the next key press
+func (m *Model) SetMessage(message string) {
+	m.message = message
+}
+
+// Selected returns the task under the cursor
+func (m *Model) Selected() (Task, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return Task{}, false
+	}
+	return m.tasks[m.visible[m.cursor]], true
+}
+
+// Window returns the collection window actions run against for a task
+func (m *Model) Window(task Task) string {
+	if window, ok := m.windows[task.Ref]; ok {
+		return window
+	}
+	return m.defaultWindow
+}
+
+// Init implements tea.Model; the tasks are loaded before the UI starts
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+	case tea.KeyMsg:
+		return m, m.command(m.handleKey(msg))
+	case tasksLoadedMsg:
+		if msg.err != nil {
+			m.SetMessage("Failed to reload tasks: " + msg.err.Error())
+			break
+		}
+		m.SetNow(msg.now)
+		m.SetTasks(msg.tasks)
+		m.SetMessage(msg.message)
+	case commandFinishedMsg:
+		command := strings.Join(msg.args[:min(2, len(msg.args))], " ")
+		if msg.err != nil {
+			return m, m.reload(fmt.Sprintf("grctool %s failed: %v", command, msg.err))
+		}
+		return m, m.reload(fmt.Sprintf("grctool %s finished", command))
+	}
+	return m, nil
+}
+
+// command turns the action of a key press into a bubbletea command
+func (m *Model) command(action Action) tea.Cmd {
+	switch action.Kind {
+	case ActionQuit:
+		return tea.Quit
+	case ActionReload:
+		return m.reload("Reloaded tasks and evidence state")
+	case ActionRun:
+		if m.run == nil {
+			return nil
+		}
+		args := action.Args
+		return tea.Exec(&commandExec{ctx: m.context(), args: args, run: m.run}, func(err error) tea.Msg {
+			return commandFinishedMsg{args: args, err: err}
+		})
+	}
+	return nil
+}
+
+// reload loads the tasks again, showing message once they are loaded
+func (m *Model) reload(message string) tea.Cmd {
+	if m.load == nil {
+		return nil
+	}
+	ctx, load := m.context(), m.load
+	return func() tea.Msg {
+		tasks, err := load(ctx)
+		return tasksLoadedMsg{tasks: tasks, now: time.Now(), message: message, err: err}
+	}
+}
+
+func (m *Model) context() context.Context {
+	if m.ctx == nil {
+		return context.Background()
+	}
+	return m.ctx
+}
+
+// handleKey applies a key press and returns what the UI should do next
+func (m *Model) handleKey(key tea.KeyMsg) Action {
+	m.message = ""
+	if key.Type == tea.KeyCtrlC {
+		return Action{Kind: ActionQuit}
+	}
+	if m.searching {
+		m.updateSearch(key)
+		return Action{}
+	}
+	if m.showHelp {
+		m.showHelp = false
+		return Action{}
+	}
+
+	switch key.Type {
+	case tea.KeyUp:
+		m.move(-1)
+	case tea.KeyDown, tea.KeyTab:
+		m.move(1)
+	case tea.KeyPgUp:
+		m.move(-m.listHeight())
+	case tea.KeyPgDown:
+		m.move(m.listHeight())
+	case tea.KeyHome:
+		m.move(-len(m.visible))
+	case tea.KeyEnd:
+		m.move(len(m.visible))
+	case tea.KeyEsc:
+		m.clearFilters()
+	case tea.KeyRunes:
+		// Pasted text arrives as several runes and has no binding
+		if len(key.Runes) == 1 && !key.Alt {
+			return m.updateRune(key.Runes[0])
+		}
+	}
+	return Action{}
+}
+
+func (m *Model) updateRune(r rune) Action {
+	switch r {
+	case 'q':
+		return Action{Kind: ActionQuit}
+	case 'k':
+		m.move(-1)
+	case 'j':
+		m.move(1)
+	case 'g':
+		m.move(-len(m.visible))
+	case 'G':
+		m.move(len(m.visible))
+	case '/':
+		m.searching = true
+	case 'f':
+		m.stateFilter = (m.stateFilter + 1) % len(stateFilters)
+		m.applyFilters()
+	case 'o':
+		m.overdue = !m.overdue
+		m.dueSoon = false
+		m.applyFilters()
+	case 'd':
+		m.dueSoon = !m.dueSoon
+		m.overdue = false
+		m.applyFilters()
+	case 'w':
+		m.cycleWindow()
+	case 'R':
+		return Action{Kind: ActionReload}
+	case '?':
+		m.showHelp = true
+	case 'c':
+		return m.runOnSelected("evidence", "generate", "--context-only")
+	case 'r':
+		return m.runOnSelected("evidence", "review")
+	case 's':
+		return m.runOnSelected("evidence", "submit", "--dry-run")
+	}
+	return Action{}
+}
+
+func (m *Model) updateSearch(key tea.KeyMsg) {
+	switch key.Type {
+	case tea.KeyEnter:
+		m.searching = false
+	case tea.KeyEsc:
+		m.searching = false
+		m.search = ""
+	case tea.KeyBackspace:
+		if runes := []rune(m.search); len(runes) > 0 {
+			m.search = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.search += string(key.Runes)
+	default:
+		return
+	}
+	m.applyFilters()
+}
+
+// runOnSelected builds the command for an action on the selected task:
+// 'grctool <command...> <ref> --window <window> <flags...>'
+func (m *Model) runOnSelected(group, command string, flags ...string) Action {
+	task, ok := m.Selected()
+	if !ok {
+		m.message = "No task selected"
+		return Action{}
+	}
+	args := []string{group, command, task.Ref, "--window", m.Window(task)}
+	return Action{Kind: ActionRun, Args: append(args, flags...)}
+}
+
+func (m *Model) move(delta int) {
+	m.cursor += delta
+	if m.cursor >= len(m.visible) {
+		m.cursor = len(m.visible) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	m.scrollToCursor()
+}
+
+func (m *Model) scrollToCursor() {
+	height := m.listHeight()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+height {
+		m.offset = m.cursor - height + 1
+	}
+	if maxOffset := len(m.visible) - height; m.offset > maxOffset {
+		m.offset = maxOffset
+	}
+	if m.offset < 0 {
+		m.offset = 0
+	}
+}
+
+func (m *Model) clearFilters() {
+	m.search = ""
+	m.stateFilter = 0
+	m.overdue = false
+	m.dueSoon = false
+	m.applyFilters()
+}
+
+// cycleWindow selects the next collection window of the selected task. The
+// choices are the windows with local evidence plus the default window.
+func (m *Model) cycleWindow() {
+	task, ok := m.Selected()
+	if !ok {
+		return
+	}
+	windows := m.windowChoices(task)
+	current := m.Window(task)
+	for i, window := range windows {
+		if window == current {
+			m.windows[task.Ref] = windows[(i+1)%len(windows)]
+			return
+		}
+	}
+	m.windows[task.Ref] = windows[0]
+}
+
+// windowChoices returns the windows a task can act on, newest first
+func (m *Model) windowChoices(task Task) []string {
+	seen := map[string]bool{m.defaultWindow: true}
+	windows := []string{m.defaultWindow}
+	if task.State != nil {
+		for window := range task.State.Windows {
+			if !seen[window] {
+				seen[window] = true
+				windows = append(windows, window)
+			}
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(windows)))
+	return windows
+}
+
+func (m *Model) applyFilters() {
+	m.visible = m.visible[:0]
+	search := strings.ToLower(m.search)
+	for i, task := range m.tasks {
+		if m.matches(task, search) {
+			m.visible = append(m.visible, i)
+		}
+	}
+	if m.cursor >= len(m.visible) {
+		m.cursor = len(m.visible) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	m.scrollToCursor()
+}
+
+func (m *Model) matches(task Task, search string) bool {
+	if state := stateFilters[m.stateFilter]; state != "" && task.localState() != state {
+		return false
+	}
+
+	due := task.Task.NextDue
+	if m.overdue && !task.Task.IsOverdue(m.now) {
+		return false
+	}
+	if m.dueSoon && (due == nil || due.Before(m.now) || due.After(m.now.AddDate(0, 0, dueSoonDays))) {
+		return false
+	}
+
+	if search == "" {
+		return true
+	}
+	fields := []string{task.Ref, task.Task.Name, task.Task.Framework, task.Task.Category}
+	for _, assignee := range task.Task.Assignees {
+		fields = append(fields, assignee.Name, assignee.Email)
+	}
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), search) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,287 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC)
+
+func dueIn(days int) *time.Time {
+	due := testNow.AddDate(0, 0, days)
+	return &due
+}
+
+func testTasks() []Task {
+	return []Task{
+		{
+			Ref: "ET-0001",
+			Task: domain.EvidenceTask{
+				Name: "Access Review", Framework: "SOC2", Priority: "high", NextDue: dueIn(-3),
+				Assignees:   []domain.Person{{Name: "Jane Doe", Email: "jane@example.com"}},
+				Controls:    []string{"CC6.1"},
+				Description: "Quarterly review of user access to production systems.",
+			},
+			State: &models.EvidenceTaskState{
+				LocalState: models.StateGenerated,
+				Windows: map[string]models.WindowState{
+					"2025-Q3": {FileCount: 2, TotalBytes: 2048, SubmissionStatus: "submitted"},
+					"2025-Q4": {FileCount: 1, TotalBytes: 512},
+				},
+			},
+		},
+		{Ref: "ET-0002", Task: domain.EvidenceTask{Name: "Vulnerability Scans", Framework: "SOC2", NextDue: dueIn(5)}},
+		{Ref: "ET-0003", Task: domain.EvidenceTask{Name: "Pen Test", Framework: "ISO27001", NextDue: dueIn(60)},
+			State: &models.EvidenceTaskState{LocalState: models.StateRejected}},
+	}
+}
+
+func visibleRefs(m *Model) []string {
+	var refs []string
+	for _, index := range m.visible {
+		refs = append(refs, m.tasks[index].Ref)
+	}
+	return refs
+}
+
+func runeKey(r rune) tea.KeyMsg {
+	if r == ' ' {
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{r}}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+func typeKeys(m *Model, text string) {
+	for _, r := range text {
+		m.handleKey(runeKey(r))
+	}
+}
+
+func TestModel_Filters(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		keys     []tea.KeyMsg
+		expected []string
+	}{
+		"no filters":             {expected: []string{"ET-0001", "ET-0002", "ET-0003"}},
+		"overdue":                {keys: []tea.KeyMsg{runeKey('o')}, expected: []string{"ET-0001"}},
+		"due soon":               {keys: []tea.KeyMsg{runeKey('d')}, expected: []string{"ET-0002"}},
+		"due soon after overdue": {keys: []tea.KeyMsg{runeKey('o'), runeKey('d')}, expected: []string{"ET-0002"}},
+		"state no evidence":      {keys: []tea.KeyMsg{runeKey('f')}, expected: []string{"ET-0002"}},
+		"state generated":        {keys: []tea.KeyMsg{runeKey('f'), runeKey('f')}, expected: []string{"ET-0001"}},
+		"search by framework": {
+			keys:     []tea.KeyMsg{runeKey('/'), runeKey('i'), runeKey('s'), runeKey('o'), {Type: tea.KeyEnter}},
+			expected: []string{"ET-0003"},
+		},
+		"search by assignee": {
+			keys:     []tea.KeyMsg{runeKey('/'), runeKey('j'), runeKey('a'), runeKey('n'), runeKey('e'), {Type: tea.KeyEnter}},
+			expected: []string{"ET-0001"},
+		},
+		"search backspace": {
+			keys:     []tea.KeyMsg{runeKey('/'), runeKey('i'), runeKey('s'), runeKey('x'), {Type: tea.KeyBackspace}},
+			expected: []string{"ET-0003"},
+		},
+		"esc clears search": {
+			keys:     []tea.KeyMsg{runeKey('/'), runeKey('p'), runeKey('e'), runeKey('n'), {Type: tea.KeyEsc}},
+			expected: []string{"ET-0001", "ET-0002", "ET-0003"},
+		},
+		"esc clears filters": {
+			keys:     []tea.KeyMsg{runeKey('o'), runeKey('f'), {Type: tea.KeyEsc}},
+			expected: []string{"ET-0001", "ET-0002", "ET-0003"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			m := NewModel(testTasks(), "2025-Q4", testNow)
+			for _, key := range tc.keys {
+				m.handleKey(key)
+			}
+			assert.Equal(t, tc.expected, visibleRefs(m))
+		})
+	}
+}
+
+func TestModel_Navigation(t *testing.T) {
+	t.Parallel()
+
+	m := NewModel(testTasks(), "2025-Q4", testNow)
+	selected := func() string {
+		task, ok := m.Selected()
+		require.True(t, ok)
+		return task.Ref
+	}
+
+	assert.Equal(t, "ET-0001", selected())
+	m.handleKey(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, "ET-0002", selected())
+	m.handleKey(runeKey('G'))
+	assert.Equal(t, "ET-0003", selected())
+	m.handleKey(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, "ET-0003", selected(), "cursor stops at the last task")
+	m.handleKey(runeKey('k'))
+	assert.Equal(t, "ET-0002", selected())
+	m.handleKey(tea.KeyMsg{Type: tea.KeyHome})
+	assert.Equal(t, "ET-0001", selected())
+
+	// Reloading keeps the selection on the same task
+	m.handleKey(runeKey('j'))
+	tasks := testTasks()
+	m.SetTasks([]Task{tasks[2], tasks[1], tasks[0]})
+	assert.Equal(t, "ET-0002", selected())
+
+	// Filtering everything out leaves nothing selected
+	typeKeys(m, "/nothing matches")
+	_, ok := m.Selected()
+	assert.False(t, ok)
+	assert.Equal(t, Action{}, m.handleKey(runeKey('c')), "typing in search does not run actions")
+	m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, Action{}, m.handleKey(runeKey('c')))
+	assert.Contains(t, m.View(), "No task selected")
+}
+
+func TestModel_Actions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		keys     []tea.KeyMsg
+		expected Action
+	}{
+		"generate context": {
+			keys:     []tea.KeyMsg{runeKey('c')},
+			expected: Action{Kind: ActionRun, Args: []string{"evidence", "generate", "ET-0001", "--window", "2025-Q4", "--context-only"}},
+		},
+		"review": {
+			keys:     []tea.KeyMsg{runeKey('j'), runeKey('r')},
+			expected: Action{Kind: ActionRun, Args: []string{"evidence", "review", "ET-0002", "--window", "2025-Q4"}},
+		},
+		"submit dry run in another window": {
+			keys:     []tea.KeyMsg{runeKey('w'), runeKey('s')},
+			expected: Action{Kind: ActionRun, Args: []string{"evidence", "submit", "ET-0001", "--window", "2025-Q3", "--dry-run"}},
+		},
+		"window cycles back": {
+			keys:     []tea.KeyMsg{runeKey('w'), runeKey('w'), runeKey('s')},
+			expected: Action{Kind: ActionRun, Args: []string{"evidence", "submit", "ET-0001", "--window", "2025-Q4", "--dry-run"}},
+		},
+		"reload":          {keys: []tea.KeyMsg{runeKey('R')}, expected: Action{Kind: ActionReload}},
+		"quit":            {keys: []tea.KeyMsg{runeKey('q')}, expected: Action{Kind: ActionQuit}},
+		"ctrl-c":          {keys: []tea.KeyMsg{{Type: tea.KeyCtrlC}}, expected: Action{Kind: ActionQuit}},
+		"help eats a key": {keys: []tea.KeyMsg{runeKey('?'), runeKey('q')}, expected: Action{}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			m := NewModel(testTasks(), "2025-Q4", testNow)
+			var action Action
+			for _, key := range tc.keys {
+				action = m.handleKey(key)
+			}
+			assert.Equal(t, tc.expected, action)
+		})
+	}
+}
+
+func TestModel_View(t *testing.T) {
+	t.Parallel()
+
+	m := NewModel(testTasks(), "2025-Q4", testNow)
+	m.SetSize(100, 20)
+
+	lines := strings.Split(m.View(), "\n")
+	require.Len(t, lines, 20)
+	assert.Contains(t, lines[0], "3 of 3 tasks")
+	assert.Contains(t, lines[2], reverseVideo+"ET-0001", "selected row is highlighted")
+	assert.Contains(t, lines[2], "2025-10-31!", "overdue due date is marked")
+	assert.Contains(t, lines[19], "c context")
+
+	view := m.View()
+	assert.Contains(t, view, "Due: 2025-10-31 (overdue by 3 days)")
+	assert.Contains(t, view, "Assignees: Jane Doe")
+	assert.Contains(t, view, "Controls: CC6.1")
+	assert.Contains(t, view, "Window: 2025-Q4 (w: 2 windows)  1 file(s), 512 B")
+	assert.Contains(t, view, "Quarterly review of user access")
+
+	m.handleKey(runeKey('w'))
+	assert.Contains(t, m.View(), "Window: 2025-Q3 (w: 2 windows)  2 file(s), 2.0 KB  submission: submitted")
+
+	m.handleKey(runeKey('o'))
+	assert.Contains(t, m.View(), "1 of 3 tasks · overdue")
+
+	for _, line := range strings.Split(m.View(), "\n") {
+		plain := strings.NewReplacer(reverseVideo, "", bold, "", resetStyle, "").Replace(line)
+		assert.LessOrEqual(t, len([]rune(plain)), 100, "line fits the terminal: %q", plain)
+	}
+
+	m.handleKey(runeKey('?'))
+	assert.Contains(t, m.View(), "submit dry run (evidence submit --dry-run)")
+}
+
+func TestWrap(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"one two", "three four", "five"}, wrap("one two three four five", 10))
+	assert.Nil(t, wrap("", 10))
+}
+
+func TestModel_Update(t *testing.T) {
+	t.Parallel()
+
+	m := NewModel(testTasks(), "2025-Q4", testNow)
+	var loads int
+	m.load = func(ctx context.Context) ([]Task, error) {
+		loads++
+		if loads > 2 {
+			return nil, errors.New("storage unavailable")
+		}
+		return testTasks()[1:], nil
+	}
+	m.run = func(ctx context.Context, args []string) error { return nil }
+
+	_, cmd := m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	assert.Nil(t, cmd)
+	assert.Len(t, strings.Split(m.View(), "\n"), 30)
+
+	_, cmd = m.Update(runeKey('q'))
+	require.NotNil(t, cmd)
+	assert.Equal(t, tea.Quit(), cmd())
+
+	// Reloading runs the loader and applies its result
+	_, cmd = m.Update(runeKey('R'))
+	require.NotNil(t, cmd)
+	m.Update(cmd())
+	assert.Equal(t, []string{"ET-0002", "ET-0003"}, visibleRefs(m))
+	assert.Contains(t, m.View(), "Reloaded tasks and evidence state")
+
+	// A finished command reloads and reports how it went
+	_, cmd = m.Update(commandFinishedMsg{args: []string{"evidence", "review", "ET-0002"}, err: errors.New("exit status 1")})
+	require.NotNil(t, cmd)
+	m.Update(cmd())
+	assert.Contains(t, m.View(), "grctool evidence review failed: exit status 1")
+
+	_, cmd = m.Update(runeKey('R'))
+	m.Update(cmd())
+	assert.Contains(t, m.View(), "Failed to reload tasks: storage unavailable")
+	assert.Equal(t, []string{"ET-0002", "ET-0003"}, visibleRefs(m), "a failed reload keeps the tasks")
+
+	// Actions hand the terminal to the command
+	_, cmd = m.Update(runeKey('c'))
+	require.NotNil(t, cmd)
+	assert.NotNil(t, cmd())
+}
@@ -0,0 +1,83 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/term"
+)
+
+// ErrNotTerminal is returned when the UI's input is not an interactive terminal
+var ErrNotTerminal = errors.New("grctool ui needs an interactive terminal")
+
+// Loader loads the task list
+type Loader func(ctx context.Context) ([]Task, error)
+
+// Runner runs grctool with args. The terminal is back in its normal mode
+// while it runs, so the command's output and prompts work as usual.
+type Runner func(ctx context.Context, args []string) error
+
+// Run shows the UI on the terminal attached to in and out until the user
+// quits or ctx is cancelled
+func Run(ctx context.Context, model *Model, in *os.File, out io.Writer, load Loader, run Runner) error {
+	if !term.IsTerminal(in.Fd()) {
+		return ErrNotTerminal
+	}
+	model.ctx, model.load, model.run = ctx, load, run
+
+	program := tea.NewProgram(model,
+		tea.WithContext(ctx),
+		tea.WithInput(in),
+		tea.WithOutput(out),
+		tea.WithAltScreen())
+	if _, err := program.Run(); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("grctool ui failed: %w", err)
+	}
+	return nil
+}
+
+// commandExec runs a grctool command while bubbletea has released the
+// terminal, then waits for Enter so its output can be read
+type commandExec struct {
+	ctx    context.Context
+	args   []string
+	run    Runner
+	stdin  io.Reader
+	stdout io.Writer
+}
+
+func (c *commandExec) Run() error {
+	fmt.Fprintf(c.stdout, "$ grctool %s\n\n", strings.Join(c.args, " "))
+	err := c.run(c.ctx, c.args)
+	if err != nil {
+		fmt.Fprintf(c.stdout, "\n❌ %v\n", err)
+	}
+	fmt.Fprint(c.stdout, "\nPress Enter to return to grctool ui")
+	_, _ = bufio.NewReader(c.stdin).ReadString('\n')
+	return err
+}
+
+func (c *commandExec) SetStdin(r io.Reader)  { c.stdin = r }
+func (c *commandExec) SetStdout(w io.Writer) { c.stdout = w }
+func (c *commandExec) SetStderr(io.Writer)   {}
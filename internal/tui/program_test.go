@@ -0,0 +1,52 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tui
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandExec(t *testing.T) {
+	t.Parallel()
+
+	var ran []string
+	failure := errors.New("exit status 1")
+	c := &commandExec{
+		ctx:  context.Background(),
+		args: []string{"evidence", "review", "ET-0001", "--window", "2025-Q4"},
+		run: func(ctx context.Context, args []string) error {
+			ran = args
+			return failure
+		},
+	}
+	var out bytes.Buffer
+	c.SetStdin(strings.NewReader("\n"))
+	c.SetStdout(&out)
+
+	err := c.Run()
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, c.args, ran)
+	assert.Contains(t, out.String(), "$ grctool evidence review ET-0001 --window 2025-Q4")
+	assert.Contains(t, out.String(), "❌ exit status 1")
+	assert.Contains(t, out.String(), "Press Enter to return to grctool ui")
+}
+
+func TestRun_NotTerminal(t *testing.T) {
+	t.Parallel()
+
+	in, err := os.CreateTemp(t.TempDir(), "input")
+	require.NoError(t, err)
+	defer in.Close()
+
+	err = Run(context.Background(), NewModel(nil, "2025-Q4", testNow), in, &bytes.Buffer{}, nil, nil)
+	assert.ErrorIs(t, err, ErrNotTerminal)
+}
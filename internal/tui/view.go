@@ -0,0 +1,297 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/models"
+)
+
+const (
+	reverseVideo = "\x1b[7m"
+	bold         = "\x1b[1m"
+	resetStyle   = "\x1b[0m"
+)
+
+// keyHelp lists the key bindings shown by '?'
+var keyHelp = [][2]string{
+	{"↑/↓, j/k", "move"},
+	{"PgUp/PgDn, g/G", "page, first/last task"},
+	{"/", "search by ref, name, framework, category or assignee"},
+	{"f", "cycle local state filter"},
+	{"o", "toggle overdue tasks"},
+	{"d", "toggle tasks due within 7 days"},
+	{"Esc", "clear all filters"},
+	{"w", "cycle collection window of the selected task"},
+	{"c", "generate context (evidence generate --context-only)"},
+	{"r", "review evidence (evidence review)"},
+	{"s", "submit dry run (evidence submit --dry-run)"},
+	{"R", "reload tasks and evidence state"},
+	{"q, Ctrl-C", "quit"},
+}
+
+// View renders the model as one string of terminal lines. A due date marked
+// with '!' is overdue.
+func (m *Model) View() string {
+	var lines []string
+	if m.showHelp {
+		lines = m.helpLines()
+	} else {
+		lines = append(lines, m.headerLine(), bold+m.fit(listRow("REF", "STATE", "DUE", "PRIORITY", "FRAMEWORK", "NAME"))+resetStyle)
+		list := m.listLines()
+		for len(list) < m.listHeight() {
+			list = append(list, "")
+		}
+		lines = append(lines, list...)
+		lines = append(lines, strings.Repeat("─", m.width))
+		lines = append(lines, m.detailLines(m.detailHeight())...)
+	}
+
+	// Pin the footer to the last line
+	for len(lines) < m.height-1 {
+		lines = append(lines, "")
+	}
+	lines = append(lines[:m.height-1], m.footerLine())
+	return strings.Join(lines, "\n")
+}
+
+// listHeight is the number of task rows; the detail pane gets the rest of
+// the space between the list and the footer
+func (m *Model) listHeight() int {
+	available := m.height - 4 // Header, column header, separator, footer
+	height := available / 2
+	if height < 3 {
+		height = available
+	}
+	if height < 1 {
+		height = 1
+	}
+	return height
+}
+
+func (m *Model) detailHeight() int {
+	return m.height - 4 - m.listHeight()
+}
+
+func (m *Model) headerLine() string {
+	parts := []string{fmt.Sprintf("grctool · %d of %d tasks", len(m.visible), len(m.tasks))}
+	if state := stateFilters[m.stateFilter]; state != "" {
+		parts = append(parts, "state: "+state.String())
+	}
+	if m.overdue {
+		parts = append(parts, "overdue")
+	}
+	if m.dueSoon {
+		parts = append(parts, fmt.Sprintf("due within %d days", dueSoonDays))
+	}
+	if m.search != "" {
+		parts = append(parts, fmt.Sprintf("search: %q", m.search))
+	}
+	return bold + m.fit(strings.Join(parts, " · ")) + resetStyle
+}
+
+func (m *Model) listLines() []string {
+	height := m.listHeight()
+	if len(m.visible) == 0 {
+		return []string{m.fit("No evidence tasks match the filters (Esc clears them)")}
+	}
+
+	var lines []string
+	for i := m.offset; i < len(m.visible) && i < m.offset+height; i++ {
+		task := m.tasks[m.visible[i]]
+		due := ""
+		if task.Task.NextDue != nil {
+			due = task.Task.NextDue.Format("2006-01-02")
+			if task.Task.IsOverdue(m.now) {
+				due += "!"
+			}
+		}
+		line := m.fit(listRow(task.Ref, task.localState().String(), due, task.Task.Priority, task.Task.Framework, task.Task.Name))
+		if i == m.cursor {
+			line = reverseVideo + line + strings.Repeat(" ", m.width-len([]rune(line))) + resetStyle
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func listRow(ref, state, due, priority, framework, name string) string {
+	return fmt.Sprintf("%-10s %-12s %-11s %-8s %-12s %s",
+		clip(ref, 10), clip(state, 12), clip(due, 11), clip(priority, 8), clip(framework, 12), name)
+}
+
+// clip shortens a column value to its width
+func clip(value string, width int) string {
+	runes := []rune(value)
+	if len(runes) <= width {
+		return value
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+func (m *Model) detailLines(height int) []string {
+	task, ok := m.Selected()
+	if !ok || height <= 0 {
+		return nil
+	}
+
+	lines := []string{bold + m.fit(task.Ref+"  "+task.Task.Name) + resetStyle}
+
+	due := "none"
+	if task.Task.NextDue != nil {
+		due = task.Task.NextDue.Format("2006-01-02")
+		if task.Task.IsDeferred(m.now) {
+			due += fmt.Sprintf(" (deferred until %s)", task.Task.Deferral.Until.Format("2006-01-02"))
+		} else if task.Task.NextDue.Before(m.now) {
+			due += fmt.Sprintf(" (overdue by %s)", formatDays(m.now.Sub(*task.Task.NextDue)))
+		}
+	}
+	lines = append(lines, m.fit(fmt.Sprintf("Framework: %s  Priority: %s  Tugboat status: %s  Due: %s",
+		orNone(task.Task.Framework), orNone(task.Task.Priority), orNone(task.Task.Status), due)))
+
+	var assignees []string
+	for _, assignee := range task.Task.Assignees {
+		if assignee.Name != "" {
+			assignees = append(assignees, assignee.Name)
+		} else {
+			assignees = append(assignees, assignee.Email)
+		}
+	}
+	lines = append(lines, m.fit("Assignees: "+orNone(strings.Join(assignees, ", "))))
+	if len(task.Task.Controls) > 0 {
+		lines = append(lines, m.fit("Controls: "+strings.Join(task.Task.Controls, ", ")))
+	}
+
+	if task.State != nil {
+		lines = append(lines, m.fit(fmt.Sprintf("Local state: %s  Automation: %s  Tools: %s",
+			task.localState(), orNone(task.State.AutomationLevel.String()), orNone(strings.Join(task.State.ApplicableTools, ", ")))))
+	}
+	lines = append(lines, m.fit(m.windowLine(task)))
+
+	if description := strings.Join(strings.Fields(task.Task.Description), " "); description != "" {
+		lines = append(lines, "")
+		for _, line := range wrap(description, m.width) {
+			lines = append(lines, m.fit(line))
+		}
+	}
+
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	return lines
+}
+
+// windowLine describes the local evidence in the selected window
+func (m *Model) windowLine(task Task) string {
+	window := m.Window(task)
+	line := "Window: " + window
+	if choices := m.windowChoices(task); len(choices) > 1 {
+		line += fmt.Sprintf(" (w: %d windows)", len(choices))
+	}
+
+	state, ok := models.WindowState{}, false
+	if task.State != nil {
+		state, ok = task.State.Windows[window]
+	}
+	if !ok || state.FileCount == 0 {
+		return line + "  no evidence files"
+	}
+
+	line += fmt.Sprintf("  %d file(s), %s", state.FileCount, formatBytes(state.TotalBytes))
+	if state.SubmissionStatus != "" {
+		line += "  submission: " + state.SubmissionStatus
+	}
+	if state.GeneratedAt != nil {
+		line += "  generated " + state.GeneratedAt.Format("2006-01-02")
+	}
+	return line
+}
+
+func (m *Model) footerLine() string {
+	switch {
+	case m.searching:
+		return m.fit("/" + m.search + "█  (Enter keeps, Esc clears)")
+	case m.message != "":
+		return m.fit(m.message)
+	case m.showHelp:
+		return m.fit("Press any key to return")
+	default:
+		return m.fit("c context  r review  s submit (dry run)  / search  f state  o overdue  d due soon  w window  ? help  q quit")
+	}
+}
+
+func (m *Model) helpLines() []string {
+	lines := []string{bold + m.fit("grctool ui · keys") + resetStyle, ""}
+	for _, binding := range keyHelp {
+		lines = append(lines, m.fit(fmt.Sprintf("  %-16s %s", binding[0], binding[1])))
+	}
+	return lines
+}
+
+// fit truncates a line to the terminal width
+func (m *Model) fit(line string) string {
+	return clip(line, m.width)
+}
+
+// wrap breaks text into lines of at most width runes at word boundaries
+func wrap(text string, width int) []string {
+	var lines []string
+	var line []rune
+	for _, word := range strings.Fields(text) {
+		runes := []rune(word)
+		if len(line) > 0 && len(line)+1+len(runes) > width {
+			lines = append(lines, string(line))
+			line = nil
+		}
+		if len(line) > 0 {
+			line = append(line, ' ')
+		}
+		line = append(line, runes...)
+	}
+	if len(line) > 0 {
+		lines = append(lines, string(line))
+	}
+	return lines
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
+
+func formatDays(d time.Duration) string {
+	days := int(d.Hours() / 24)
+	if days == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", days)
+}
+
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
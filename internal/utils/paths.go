@@ -17,8 +17,10 @@ package utils
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 // RelativizePathFromDataDir converts an absolute path to a relative path from the data directory
@@ -152,3 +154,26 @@ func JoinRelativePaths(components ...string) (string, error) {
 	joined := filepath.Join(components...)
 	return NormalizePathSeparators(joined), nil
 }
+
+// LatestModTime returns the newest modification time of the files matching a
+// path or glob pattern
+func LatestModTime(pattern string) (time.Time, bool) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil || len(matches) == 0 {
+		return time.Time{}, false
+	}
+
+	var latest time.Time
+	found := false
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		if !found || info.ModTime().After(latest) {
+			latest = info.ModTime()
+			found = true
+		}
+	}
+	return latest, found
+}
@@ -0,0 +1,274 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vanta uploads evidence files to Vanta documents through the Vanta
+// API, authenticating with OAuth client credentials.
+package vanta
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+)
+
+// DefaultBaseURL is the Vanta API used when none is configured
+const DefaultBaseURL = "https://api.vanta.com"
+
+// tokenScope is the OAuth scope needed to upload documents
+const tokenScope = "vanta-api.all:write"
+
+// MaxFileSize is the largest file Vanta accepts as a document upload
+const MaxFileSize = 50 * 1024 * 1024 // 50MB
+
+// Client uploads evidence to Vanta custom evidence documents
+type Client struct {
+	baseURL      string
+	clientID     string
+	clientSecret string
+	httpClient   *http.Client
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+// NewClient creates a Vanta API client. The http client defaults to one with
+// the configured timeout.
+func NewClient(cfg config.VantaConfig, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		timeout := cfg.Timeout
+		if timeout <= 0 {
+			timeout = 30 * time.Second
+		}
+		httpClient = &http.Client{Timeout: timeout}
+	}
+	baseURL := strings.TrimRight(cfg.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{
+		baseURL:      baseURL,
+		clientID:     cfg.ClientID,
+		clientSecret: cfg.ClientSecret,
+		httpClient:   httpClient,
+	}
+}
+
+// UploadRequest is a file to upload to a Vanta document
+type UploadRequest struct {
+	DocumentID    string    // Vanta document the evidence is uploaded to
+	Filename      string    // Name of the uploaded file
+	Content       []byte    // File content
+	ContentType   string    // MIME type of the file
+	EffectiveDate time.Time // Date the evidence was collected
+	Description   string    // Optional description shown in Vanta
+}
+
+// UploadResponse is Vanta's record of an uploaded file
+type UploadResponse struct {
+	ID          string    `json:"id"`
+	FileName    string    `json:"fileName,omitempty"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"createdAt,omitempty"`
+}
+
+// UploadDocumentFile uploads a file to a Vanta document
+func (c *Client) UploadDocumentFile(ctx context.Context, req *UploadRequest) (*UploadResponse, error) {
+	if req.DocumentID == "" {
+		return nil, fmt.Errorf("document ID is required")
+	}
+	if req.Filename == "" {
+		return nil, fmt.Errorf("filename is required")
+	}
+	if len(req.Content) > MaxFileSize {
+		return nil, fmt.Errorf("file size %d bytes exceeds maximum allowed size of %d bytes (50MB)", len(req.Content), MaxFileSize)
+	}
+
+	token, err := c.accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	if !req.EffectiveDate.IsZero() {
+		if err := writer.WriteField("effectiveAtDate", req.EffectiveDate.UTC().Format(time.RFC3339)); err != nil {
+			return nil, fmt.Errorf("failed to write effectiveAtDate field: %w", err)
+		}
+	}
+	if req.Description != "" {
+		if err := writer.WriteField("description", req.Description); err != nil {
+			return nil, fmt.Errorf("failed to write description field: %w", err)
+		}
+	}
+	part, err := createFormFile(writer, req.Filename, req.ContentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create form file: %w", err)
+	}
+	if _, err := part.Write(req.Content); err != nil {
+		return nil, fmt.Errorf("failed to copy file content: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/v1/documents/%s/uploads", c.baseURL, url.PathEscape(req.DocumentID))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Vanta request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+token)
+	httpReq.Header.Set("Content-Type", writer.FormDataContentType())
+	httpReq.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload to Vanta: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("vanta upload failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	result := &UploadResponse{}
+	if len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return nil, fmt.Errorf("failed to parse Vanta response: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// DeleteDocumentUpload removes an uploaded file from a Vanta document. An
+// upload that no longer exists is not an error.
+func (c *Client) DeleteDocumentUpload(ctx context.Context, documentID, uploadID string) error {
+	if documentID == "" || uploadID == "" {
+		return fmt.Errorf("document ID and upload ID are required")
+	}
+
+	token, err := c.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/v1/documents/%s/uploads/%s", c.baseURL, url.PathEscape(documentID), url.PathEscape(uploadID))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create Vanta request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+token)
+	httpReq.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to delete from Vanta: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("vanta delete failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+// accessToken returns a cached OAuth token, requesting a new one shortly
+// before the cached one expires
+func (c *Client) accessToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && time.Now().Before(c.tokenExpiry) {
+		return c.token, nil
+	}
+	if c.clientID == "" || c.clientSecret == "" {
+		return "", fmt.Errorf("vanta client credentials not configured - set vanta.client_id and VANTA_CLIENT_SECRET")
+	}
+
+	payload, err := json.Marshal(map[string]string{
+		"client_id":     c.clientID,
+		"client_secret": c.clientSecret,
+		"scope":         tokenScope,
+		"grant_type":    "client_credentials",
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode token request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/oauth/token", bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to authenticate with Vanta: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("vanta authentication failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"` // Seconds
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to parse Vanta token: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("vanta returned an empty access token")
+	}
+
+	// Refresh a minute early so a token doesn't expire mid-upload
+	lifetime := time.Duration(token.ExpiresIn)*time.Second - time.Minute
+	c.token = token.AccessToken
+	c.tokenExpiry = time.Now().Add(lifetime)
+	return c.token, nil
+}
+
+// createFormFile creates the multipart file part with the file's content type
+// rather than the application/octet-stream multipart.CreateFormFile uses
+func createFormFile(writer *multipart.Writer, filename, contentType string) (io.Writer, error) {
+	if contentType == "" {
+		return writer.CreateFormFile("file", filename)
+	}
+	header := make(textproto.MIMEHeader)
+	header["Content-Disposition"] = []string{fmt.Sprintf(`form-data; name="file"; filename="%s"`,
+		strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(filename))}
+	header["Content-Type"] = []string{contentType}
+	return writer.CreatePart(header)
+}
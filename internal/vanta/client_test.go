@@ -0,0 +1,200 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package vanta
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVanta serves the token, document upload and upload delete endpoints
+type fakeVanta struct {
+	mu           sync.Mutex
+	tokenCalls   int
+	uploads      []map[string]string
+	contentTypes []string
+	uploadStatus int
+	deleted      []string
+}
+
+func (f *fakeVanta) handler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		f.mu.Lock()
+		f.tokenCalls++
+		f.mu.Unlock()
+		if req["client_secret"] != "secret" {
+			http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "client_credentials", req["grant_type"])
+		assert.Equal(t, "vanta-api.all:write", req["scope"])
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token-1", "expires_in": 3600})
+	})
+	mux.HandleFunc("/v1/documents/{id}/uploads", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token-1", r.Header.Get("Authorization"))
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		content, _ := io.ReadAll(file)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if f.uploadStatus != 0 {
+			http.Error(w, "document not found", f.uploadStatus)
+			return
+		}
+		f.uploads = append(f.uploads, map[string]string{
+			"document":    r.PathValue("id"),
+			"filename":    header.Filename,
+			"content":     string(content),
+			"effective":   r.FormValue("effectiveAtDate"),
+			"description": r.FormValue("description"),
+		})
+		f.contentTypes = append(f.contentTypes, header.Header.Get("Content-Type"))
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": "upload-1", "fileName": header.Filename})
+	})
+	mux.HandleFunc("DELETE /v1/documents/{id}/uploads/{upload}", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token-1", r.Header.Get("Authorization"))
+		switch r.PathValue("upload") {
+		case "missing":
+			http.Error(w, "upload not found", http.StatusNotFound)
+			return
+		case "locked":
+			http.Error(w, "document is locked for audit", http.StatusConflict)
+			return
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.deleted = append(f.deleted, r.PathValue("id")+"/"+r.PathValue("upload"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return mux
+}
+
+func TestClient_UploadDocumentFile(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeVanta{}
+	server := httptest.NewServer(fake.handler(t))
+	defer server.Close()
+
+	client := NewClient(config.VantaConfig{BaseURL: server.URL + "/", ClientID: "id", ClientSecret: "secret"}, server.Client())
+	req := &UploadRequest{
+		DocumentID:    "access-review-doc",
+		Filename:      "access_review.csv",
+		Content:       []byte("user,role\n"),
+		ContentType:   "text/csv",
+		EffectiveDate: time.Date(2025, 10, 1, 9, 0, 0, 0, time.UTC),
+		Description:   "ET-0001 2025-Q4",
+	}
+
+	resp, err := client.UploadDocumentFile(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "upload-1", resp.ID)
+	_, err = client.UploadDocumentFile(context.Background(), req)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, fake.tokenCalls, "token is cached between uploads")
+	require.Len(t, fake.uploads, 2)
+	assert.Equal(t, map[string]string{
+		"document":    "access-review-doc",
+		"filename":    "access_review.csv",
+		"content":     "user,role\n",
+		"effective":   "2025-10-01T09:00:00Z",
+		"description": "ET-0001 2025-Q4",
+	}, fake.uploads[0])
+	assert.Equal(t, "text/csv", fake.contentTypes[0])
+}
+
+func TestClient_UploadDocumentFile_Errors(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeVanta{}
+	server := httptest.NewServer(fake.handler(t))
+	defer server.Close()
+	upload := &UploadRequest{DocumentID: "doc", Filename: "evidence.md", Content: []byte("# Evidence")}
+
+	tests := map[string]struct {
+		cfg          config.VantaConfig
+		req          *UploadRequest
+		uploadStatus int
+		err          string
+	}{
+		"no credentials": {
+			cfg: config.VantaConfig{BaseURL: server.URL},
+			req: upload,
+			err: "vanta client credentials not configured",
+		},
+		"bad credentials": {
+			cfg: config.VantaConfig{BaseURL: server.URL, ClientID: "id", ClientSecret: "wrong"},
+			req: upload,
+			err: "vanta authentication failed with status 401",
+		},
+		"no document": {
+			cfg: config.VantaConfig{BaseURL: server.URL, ClientID: "id", ClientSecret: "secret"},
+			req: &UploadRequest{Filename: "evidence.md"},
+			err: "document ID is required",
+		},
+		"upload rejected": {
+			cfg:          config.VantaConfig{BaseURL: server.URL, ClientID: "id", ClientSecret: "secret"},
+			req:          upload,
+			uploadStatus: http.StatusNotFound,
+			err:          "vanta upload failed with status 404: document not found",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			fake.mu.Lock()
+			fake.uploadStatus = tc.uploadStatus
+			fake.mu.Unlock()
+			client := NewClient(tc.cfg, server.Client())
+			_, err := client.UploadDocumentFile(context.Background(), tc.req)
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestClient_DeleteDocumentUpload(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeVanta{}
+	server := httptest.NewServer(fake.handler(t))
+	defer server.Close()
+	client := NewClient(config.VantaConfig{BaseURL: server.URL, ClientID: "id", ClientSecret: "secret"}, server.Client())
+
+	tests := map[string]struct {
+		document string
+		upload   string
+		err      string
+	}{
+		"deleted":   {document: "access-review-doc", upload: "upload-1"},
+		"not found": {document: "access-review-doc", upload: "missing"},
+		"rejected":  {document: "access-review-doc", upload: "locked", err: "vanta delete failed with status 409: document is locked for audit"},
+		"no upload": {document: "access-review-doc", err: "document ID and upload ID are required"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := client.DeleteDocumentUpload(context.Background(), tc.document, tc.upload)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+	assert.Equal(t, []string{"access-review-doc/upload-1"}, fake.deleted)
+}
@@ -0,0 +1,277 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webhook applies Tugboat Logic notifications to local state as they
+// arrive: auditor reviews of submitted evidence update the window's submission
+// metadata, and created or changed evidence tasks are synced right away
+// instead of on the next manual sync.
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/grctool/grctool/internal/services"
+)
+
+// Event types
+const (
+	EventEvidenceAccepted = "evidence.accepted"
+	EventEvidenceRejected = "evidence.rejected"
+	EventTaskCreated      = "task.created"
+	EventTaskUpdated      = "task.updated"
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of the request body, keyed with
+// the webhook secret, as "sha256=<hex>"
+const SignatureHeader = "X-Webhook-Signature"
+
+var (
+	// ErrInvalidEvent is returned for payloads that cannot be processed
+	ErrInvalidEvent = errors.New("invalid webhook event")
+
+	// ErrUnknownTask is returned when the event's task cannot be found
+	ErrUnknownTask = errors.New("unknown evidence task")
+)
+
+// identifier restricts task IDs and windows to names that cannot escape the
+// evidence directory
+var identifier = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Event is a Tugboat notification
+type Event struct {
+	Type         string    `json:"event"`
+	TaskID       string    `json:"task_id"`                 // Tugboat evidence task ID
+	Window       string    `json:"window,omitempty"`        // Evidence window, e.g. 2025-Q4
+	SubmissionID string    `json:"submission_id,omitempty"` // Tugboat submission ID
+	Comment      string    `json:"comment,omitempty"`       // Auditor comment
+	Reviewer     string    `json:"reviewer,omitempty"`
+	OccurredAt   time.Time `json:"occurred_at,omitempty"`
+}
+
+// Result reports what processing an event changed
+type Result struct {
+	Event   string `json:"event"`
+	TaskRef string `json:"task_ref"`
+	Window  string `json:"window,omitempty"`
+	Status  string `json:"status"` // Submission status, or "synced" for task events
+}
+
+// Store reads evidence tasks and records submission reviews; it is satisfied
+// by storage.Storage
+type Store interface {
+	GetEvidenceTask(id string) (*domain.EvidenceTask, error)
+	RecordSubmissionReview(taskRef, window string, response models.TugboatSubmissionResponse) (*models.EvidenceSubmission, error)
+}
+
+// TaskSyncer fetches and saves a single evidence task; it is satisfied by
+// services.SyncService
+type TaskSyncer interface {
+	SyncEvidenceTask(ctx context.Context, id string) (*domain.EvidenceTask, error)
+}
+
+// Processor applies events to local state
+type Processor struct {
+	store   Store
+	scanner services.EvidenceScanner
+	syncer  TaskSyncer
+	logger  logger.Logger
+	now     func() time.Time
+}
+
+// NewProcessor creates an event processor
+func NewProcessor(store Store, scanner services.EvidenceScanner, syncer TaskSyncer, log logger.Logger) *Processor {
+	return &Processor{
+		store:   store,
+		scanner: scanner,
+		syncer:  syncer,
+		logger:  log,
+		now:     time.Now,
+	}
+}
+
+// ParseEvent decodes and checks an event payload
+func ParseEvent(body []byte) (Event, error) {
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		return event, fmt.Errorf("%w: %v", ErrInvalidEvent, err)
+	}
+	switch event.Type {
+	case EventEvidenceAccepted, EventEvidenceRejected, EventTaskCreated, EventTaskUpdated:
+	case "":
+		return event, fmt.Errorf("%w: event is required", ErrInvalidEvent)
+	default:
+		return event, fmt.Errorf("%w: unsupported event %q", ErrInvalidEvent, event.Type)
+	}
+	if event.TaskID == "" {
+		return event, fmt.Errorf("%w: task_id is required", ErrInvalidEvent)
+	}
+	if !identifier.MatchString(event.TaskID) {
+		return event, fmt.Errorf("%w: invalid task_id %q", ErrInvalidEvent, event.TaskID)
+	}
+	if event.Window != "" && (!identifier.MatchString(event.Window) || strings.Contains(event.Window, "..")) {
+		return event, fmt.Errorf("%w: invalid window %q", ErrInvalidEvent, event.Window)
+	}
+	return event, nil
+}
+
+// VerifySignature reports whether signature, as sent in SignatureHeader, is
+// the HMAC-SHA256 of body keyed with secret
+func VerifySignature(secret string, body []byte, signature string) bool {
+	sum, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+	got, err := hex.DecodeString(sum)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// Sign returns the SignatureHeader value for body
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Handle applies an event
+func (p *Processor) Handle(ctx context.Context, event Event) (*Result, error) {
+	switch event.Type {
+	case EventEvidenceAccepted, EventEvidenceRejected:
+		return p.handleReview(ctx, event)
+	case EventTaskCreated, EventTaskUpdated:
+		return p.handleTask(ctx, event)
+	default:
+		return nil, fmt.Errorf("%w: unsupported event %q", ErrInvalidEvent, event.Type)
+	}
+}
+
+// handleReview records an auditor's review on the reviewed window
+func (p *Processor) handleReview(ctx context.Context, event Event) (*Result, error) {
+	task, err := p.lookupTask(ctx, event.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	window := event.Window
+	if window == "" {
+		window, err = p.reviewedWindow(ctx, task.ReferenceID, event.SubmissionID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	response := models.TugboatSubmissionResponse{
+		SubmissionID: event.SubmissionID,
+		Status:       strings.TrimPrefix(event.Type, "evidence."),
+		Message:      event.Comment,
+		ReceivedAt:   event.OccurredAt,
+	}
+	if response.ReceivedAt.IsZero() {
+		response.ReceivedAt = p.now()
+	}
+	if event.Reviewer != "" {
+		response.Metadata = map[string]interface{}{"reviewer": event.Reviewer}
+	}
+
+	submission, err := p.store.RecordSubmissionReview(task.ReferenceID, window, response)
+	if err != nil {
+		return nil, fmt.Errorf("recording review of %s %s: %w", task.ReferenceID, window, err)
+	}
+
+	p.logger.Info("recorded evidence review",
+		logger.String("task_ref", task.ReferenceID),
+		logger.String("window", window),
+		logger.String("status", submission.Status))
+	return &Result{Event: event.Type, TaskRef: task.ReferenceID, Window: window, Status: submission.Status}, nil
+}
+
+// handleTask syncs a created or changed task from Tugboat
+func (p *Processor) handleTask(ctx context.Context, event Event) (*Result, error) {
+	if p.syncer == nil {
+		return nil, errors.New("task sync is not configured")
+	}
+	task, err := p.syncer.SyncEvidenceTask(ctx, event.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	p.logger.Info("synced evidence task",
+		logger.String("task_id", task.ID),
+		logger.String("task_ref", task.ReferenceID))
+	return &Result{Event: event.Type, TaskRef: task.ReferenceID, Status: "synced"}, nil
+}
+
+// lookupTask finds a task by its Tugboat ID, syncing it when it is not known
+// locally yet
+func (p *Processor) lookupTask(ctx context.Context, id string) (*domain.EvidenceTask, error) {
+	task, err := p.store.GetEvidenceTask(id)
+	if err == nil {
+		return task, nil
+	}
+	if p.syncer == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTask, id)
+	}
+	task, syncErr := p.syncer.SyncEvidenceTask(ctx, id)
+	if syncErr != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrUnknownTask, id, syncErr)
+	}
+	return task, nil
+}
+
+// reviewedWindow picks the window a review without an explicit window refers
+// to: the one holding the submission ID, else the latest submitted window
+func (p *Processor) reviewedWindow(ctx context.Context, taskRef, submissionID string) (string, error) {
+	state, err := p.scanner.ScanTask(ctx, taskRef)
+	if err != nil {
+		return "", fmt.Errorf("scanning %s: %w", taskRef, err)
+	}
+
+	windows := make([]string, 0, len(state.Windows))
+	for window := range state.Windows {
+		windows = append(windows, window)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(windows)))
+
+	if submissionID != "" {
+		for _, window := range windows {
+			if state.Windows[window].SubmissionID == submissionID {
+				return window, nil
+			}
+		}
+	}
+	for _, window := range windows {
+		if state.Windows[window].SubmissionStatus == "submitted" {
+			return window, nil
+		}
+	}
+	return "", fmt.Errorf("%w: no submitted window found for %s; include window in the event", ErrInvalidEvent, taskRef)
+}
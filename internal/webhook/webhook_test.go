@@ -0,0 +1,235 @@
+// Copyright 2024 GRCTool Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/grctool/grctool/internal/domain"
+	"github.com/grctool/grctool/internal/logger"
+	"github.com/grctool/grctool/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type review struct {
+	taskRef  string
+	window   string
+	response models.TugboatSubmissionResponse
+}
+
+type fakeStore struct {
+	tasks   map[string]*domain.EvidenceTask
+	reviews []review
+}
+
+func (f *fakeStore) GetEvidenceTask(id string) (*domain.EvidenceTask, error) {
+	if task, ok := f.tasks[id]; ok {
+		return task, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (f *fakeStore) RecordSubmissionReview(taskRef, window string, response models.TugboatSubmissionResponse) (*models.EvidenceSubmission, error) {
+	f.reviews = append(f.reviews, review{taskRef, window, response})
+	return &models.EvidenceSubmission{TaskRef: taskRef, Window: window, Status: response.Status}, nil
+}
+
+type fakeScanner struct {
+	windows map[string]models.WindowState
+}
+
+func (f *fakeScanner) ScanAll(ctx context.Context) (map[string]*models.EvidenceTaskState, error) {
+	return nil, nil
+}
+
+func (f *fakeScanner) ScanTask(ctx context.Context, taskRef string) (*models.EvidenceTaskState, error) {
+	return &models.EvidenceTaskState{TaskRef: taskRef, Windows: f.windows}, nil
+}
+
+func (f *fakeScanner) ScanWindow(ctx context.Context, taskRef, window string) (*models.WindowState, error) {
+	return nil, nil
+}
+
+type fakeSyncer struct {
+	tasks  map[string]*domain.EvidenceTask
+	synced []string
+}
+
+func (f *fakeSyncer) SyncEvidenceTask(ctx context.Context, id string) (*domain.EvidenceTask, error) {
+	f.synced = append(f.synced, id)
+	if task, ok := f.tasks[id]; ok {
+		return task, nil
+	}
+	return nil, errors.New("evidence task not found: " + id)
+}
+
+func newTestProcessor(t *testing.T, store *fakeStore, windows map[string]models.WindowState, syncer TaskSyncer) *Processor {
+	t.Helper()
+	log, err := logger.NewTestLogger()
+	require.NoError(t, err)
+	p := NewProcessor(store, &fakeScanner{windows: windows}, syncer, log)
+	p.now = func() time.Time { return time.Date(2025, 11, 10, 12, 0, 0, 0, time.UTC) }
+	return p
+}
+
+func TestParseEvent(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		body    string
+		wantErr string
+	}{
+		"accepted":      {body: `{"event":"evidence.accepted","task_id":"1001","window":"2025-Q4"}`},
+		"task created":  {body: `{"event":"task.created","task_id":"1001"}`},
+		"not json":      {body: `event=task.created`, wantErr: "invalid webhook event"},
+		"missing event": {body: `{"task_id":"1001"}`, wantErr: "event is required"},
+		"unsupported":   {body: `{"event":"policy.updated","task_id":"1001"}`, wantErr: `unsupported event "policy.updated"`},
+		"missing task":  {body: `{"event":"evidence.rejected"}`, wantErr: "task_id is required"},
+		"bad task":      {body: `{"event":"task.created","task_id":"../1001"}`, wantErr: "invalid task_id"},
+		"bad window":    {body: `{"event":"evidence.accepted","task_id":"1001","window":"2025-Q4/../.."}`, wantErr: "invalid window"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseEvent([]byte(tc.body))
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidEvent)
+			assert.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"event":"task.created","task_id":"1001"}`)
+	signature := Sign("s3cret", body)
+
+	assert.True(t, VerifySignature("s3cret", body, signature))
+	assert.False(t, VerifySignature("other", body, signature))
+	assert.False(t, VerifySignature("s3cret", []byte(`{}`), signature))
+	assert.False(t, VerifySignature("s3cret", body, signature[len("sha256="):]), "prefix is required")
+	assert.False(t, VerifySignature("s3cret", body, "sha256=zz"))
+}
+
+func TestProcessor_Review(t *testing.T) {
+	t.Parallel()
+
+	task := &domain.EvidenceTask{ID: "1001", ReferenceID: "ET-0001"}
+	windows := map[string]models.WindowState{
+		"2025-Q2": {Window: "2025-Q2", SubmissionStatus: "accepted", SubmissionID: "sub-1"},
+		"2025-Q3": {Window: "2025-Q3", SubmissionStatus: "submitted", SubmissionID: "sub-2"},
+		"2025-Q4": {Window: "2025-Q4", SubmissionStatus: "submitted", SubmissionID: "sub-3"},
+	}
+	occurred := time.Date(2025, 11, 9, 8, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		event      Event
+		wantWindow string
+		wantStatus string
+		wantAt     time.Time
+	}{
+		"explicit window": {
+			event:      Event{Type: EventEvidenceAccepted, TaskID: "1001", Window: "2025-Q2", OccurredAt: occurred},
+			wantWindow: "2025-Q2",
+			wantStatus: "accepted",
+			wantAt:     occurred,
+		},
+		"window by submission id": {
+			event:      Event{Type: EventEvidenceRejected, TaskID: "1001", SubmissionID: "sub-2", Comment: "Missing date"},
+			wantWindow: "2025-Q3",
+			wantStatus: "rejected",
+			wantAt:     time.Date(2025, 11, 10, 12, 0, 0, 0, time.UTC),
+		},
+		"latest submitted window": {
+			event:      Event{Type: EventEvidenceAccepted, TaskID: "1001", Reviewer: "auditor@example.com"},
+			wantWindow: "2025-Q4",
+			wantStatus: "accepted",
+			wantAt:     time.Date(2025, 11, 10, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			store := &fakeStore{tasks: map[string]*domain.EvidenceTask{"1001": task}}
+			p := newTestProcessor(t, store, windows, nil)
+
+			result, err := p.Handle(context.Background(), tc.event)
+			require.NoError(t, err)
+			assert.Equal(t, &Result{Event: tc.event.Type, TaskRef: "ET-0001", Window: tc.wantWindow, Status: tc.wantStatus}, result)
+
+			require.Len(t, store.reviews, 1)
+			recorded := store.reviews[0]
+			assert.Equal(t, "ET-0001", recorded.taskRef)
+			assert.Equal(t, tc.wantWindow, recorded.window)
+			assert.Equal(t, tc.wantStatus, recorded.response.Status)
+			assert.Equal(t, tc.event.Comment, recorded.response.Message)
+			assert.Equal(t, tc.wantAt, recorded.response.ReceivedAt)
+			if tc.event.Reviewer != "" {
+				assert.Equal(t, tc.event.Reviewer, recorded.response.Metadata["reviewer"])
+			}
+		})
+	}
+}
+
+func TestProcessor_ReviewErrors(t *testing.T) {
+	t.Parallel()
+
+	store := &fakeStore{tasks: map[string]*domain.EvidenceTask{"1001": {ID: "1001", ReferenceID: "ET-0001"}}}
+	p := newTestProcessor(t, store, map[string]models.WindowState{
+		"2025-Q4": {Window: "2025-Q4", SubmissionStatus: "draft"},
+	}, nil)
+
+	_, err := p.Handle(context.Background(), Event{Type: EventEvidenceAccepted, TaskID: "1001"})
+	assert.ErrorIs(t, err, ErrInvalidEvent, "no submitted window to attribute the review to")
+
+	_, err = p.Handle(context.Background(), Event{Type: EventEvidenceAccepted, TaskID: "9999", Window: "2025-Q4"})
+	assert.ErrorIs(t, err, ErrUnknownTask)
+	assert.Empty(t, store.reviews)
+}
+
+func TestProcessor_ReviewSyncsUnknownTask(t *testing.T) {
+	t.Parallel()
+
+	store := &fakeStore{}
+	syncer := &fakeSyncer{tasks: map[string]*domain.EvidenceTask{"1002": {ID: "1002", ReferenceID: "ET-0002"}}}
+	p := newTestProcessor(t, store, nil, syncer)
+
+	result, err := p.Handle(context.Background(), Event{Type: EventEvidenceAccepted, TaskID: "1002", Window: "2025-Q4"})
+	require.NoError(t, err)
+	assert.Equal(t, "ET-0002", result.TaskRef)
+	assert.Equal(t, []string{"1002"}, syncer.synced)
+
+	_, err = p.Handle(context.Background(), Event{Type: EventEvidenceAccepted, TaskID: "9999", Window: "2025-Q4"})
+	assert.ErrorIs(t, err, ErrUnknownTask)
+}
+
+func TestProcessor_Task(t *testing.T) {
+	t.Parallel()
+
+	syncer := &fakeSyncer{tasks: map[string]*domain.EvidenceTask{"1003": {ID: "1003", ReferenceID: "ET-0003"}}}
+	p := newTestProcessor(t, &fakeStore{}, nil, syncer)
+
+	result, err := p.Handle(context.Background(), Event{Type: EventTaskCreated, TaskID: "1003"})
+	require.NoError(t, err)
+	assert.Equal(t, &Result{Event: EventTaskCreated, TaskRef: "ET-0003", Status: "synced"}, result)
+
+	_, err = p.Handle(context.Background(), Event{Type: EventTaskUpdated, TaskID: "9999"})
+	assert.ErrorContains(t, err, "evidence task not found")
+
+	noSync := newTestProcessor(t, &fakeStore{}, nil, nil)
+	_, err = noSync.Handle(context.Background(), Event{Type: EventTaskUpdated, TaskID: "1003"})
+	assert.ErrorContains(t, err, "not configured")
+}
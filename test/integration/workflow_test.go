@@ -523,9 +523,13 @@ session:
 					ExcludePatterns: []string{".terraform/"},
 				},
 			},
+			Generation: config.GenerationConfig{
+				OutputDir: evidenceDir,
+			},
 		},
 		Storage: config.StorageConfig{
-			DataDir: tempDir,
+			DataDir:  tempDir,
+			CacheDir: filepath.Join(tempDir, ".cache"),
 		},
 	}
 
@@ -892,7 +896,10 @@ func TestToolErrorHandlingAndRecovery(t *testing.T) {
 				},
 				expectError: true,
 				errorCheck: func(t *testing.T, err error) {
-					assert.Contains(t, err.Error(), "invalid analysis_type")
+					var schemaErr *tools.SchemaValidationError
+					require.ErrorAs(t, err, &schemaErr)
+					assert.Equal(t, "$.analysis_type", schemaErr.Errors[0].Field)
+					assert.Equal(t, "enum", schemaErr.Errors[0].Rule)
 				},
 			},
 			{